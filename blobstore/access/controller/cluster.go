@@ -148,13 +148,21 @@ type ClusterConfig struct {
 	ConsulToken     string    `json:"consul_token"`
 	ConsulTokenFile string    `json:"consul_token_file"`
 	Clusters        []Cluster `json:"clusters"`
+
+	// AffinityRegions other regions ordered by affinity, clusters in these
+	// regions are loaded too, and chosen to write if no available cluster
+	// in nearer region. Labels selects clusters to write.
+	AffinityRegions []string          `json:"affinity_regions"`
+	Labels          map[string]string `json:"labels"`
 }
 
 // Cluster cluster config, each clusterID related to hosts list
 type Cluster struct {
-	ClusterID proto.ClusterID `json:"cluster_id"`
-	Hosts     []string        `json:"hosts"`
-	Space     SpaceConf       `json:"space"` // one space - one cluster
+	ClusterID proto.ClusterID   `json:"cluster_id"`
+	Hosts     []string          `json:"hosts"`
+	Space     SpaceConf         `json:"space"`  // one space - one cluster
+	Region    string            `json:"region"` // default is the region of controller
+	Labels    map[string]string `json:"labels"`
 }
 
 type cluster struct {
@@ -248,7 +256,6 @@ func (c *clusterControllerImpl) loadWithConfig() error {
 	span.Debugf("clusters info: %+v", c.config.Clusters)
 	allClusters := make(clusterMap)
 	available := make([]*cmapi.ClusterInfo, 0, len(c.config.Clusters))
-	totalAvailable := int64(0)
 	for _, cs := range c.config.Clusters {
 		conf := c.config.CMClientConfig
 		conf.Hosts = cs.Hosts
@@ -260,6 +267,11 @@ func (c *clusterControllerImpl) loadWithConfig() error {
 			continue
 		}
 		clusterInfo := &cmapi.ClusterInfo{}
+		clusterInfo.Region = cs.Region
+		if clusterInfo.Region == "" {
+			clusterInfo.Region = c.region
+		}
+		clusterInfo.Labels = cs.Labels
 		clusterInfo.ClusterID = cs.ClusterID
 		clusterInfo.Capacity = stat.BlobNodeSpaceStat.TotalSpace
 		clusterInfo.Available = stat.BlobNodeSpaceStat.WritableSpace
@@ -270,60 +282,133 @@ func (c *clusterControllerImpl) loadWithConfig() error {
 
 		if !clusterInfo.Readonly && clusterInfo.Available > 0 {
 			available = append(available, clusterInfo)
-			totalAvailable += clusterInfo.Available
 			allClusters[clusterInfo.ClusterID].client = cmCli
 		} else {
 			span.Debug("readonly or no available cluster", clusterInfo.ClusterID)
 		}
 	}
+	available, totalAvailable := c.pickAvailable(available)
 	return c.deal(ctx, available, allClusters, totalAvailable)
 }
 
+// loadWithConsul loads clusters of all regions, the region failed to list keeps
+// its previous clusters, returns error only if all regions failed.
 func (c *clusterControllerImpl) loadWithConsul() error {
 	span, ctx := trace.StartSpanFromContext(context.Background(), "")
 
-	path := cmapi.GetConsulClusterPath(c.region)
-	span.Debug("to list consul path", path)
-
-	pairs, _, err := c.kvClient.KV().List(path, nil)
-	if err != nil {
-		return err
-	}
-	span.Debugf("found %d clusters", len(pairs))
-
 	allClusters := make(clusterMap)
-	available := make([]*cmapi.ClusterInfo, 0, len(pairs))
-	totalAvailable := int64(0)
-	for _, pair := range pairs {
-		clusterInfo := &cmapi.ClusterInfo{}
-		err := json.Unmarshal(pair.Value, clusterInfo)
+	available := make([]*cmapi.ClusterInfo, 0, 8)
+	regions := c.regions()
+	var lastErr error
+	failed := 0
+	for _, region := range regions {
+		path := cmapi.GetConsulClusterPath(region)
+		span.Debug("to list consul path", path)
+
+		pairs, _, err := c.kvClient.KV().List(path, nil)
 		if err != nil {
-			span.Warnf("decode failed, raw:%s, error:%s", string(pair.Value), err.Error())
+			span.Warnf("list clusters of region %s failed, keep the previous, error:%s", region, err.Error())
+			lastErr = err
+			failed++
+			available = c.keepRegionClusters(region, allClusters, available)
 			continue
 		}
+		span.Debugf("found %d clusters in region %s", len(pairs), region)
 
-		clusterKey := filepath.Base(pair.Key)
-		span.Debug("found cluster", clusterKey)
+		for _, pair := range pairs {
+			clusterInfo := c.decodeConsulCluster(span, pair)
+			if clusterInfo == nil {
+				continue
+			}
+			if clusterInfo.Region == "" {
+				clusterInfo.Region = region
+			}
 
-		clusterID, err := strconv.ParseUint(clusterKey, 10, 32)
-		if err != nil {
-			span.Warn("invalid cluster id", clusterKey, err)
-			continue
+			allClusters[clusterInfo.ClusterID] = &cluster{clusterInfo: clusterInfo}
+			if !clusterInfo.Readonly && clusterInfo.Available > 0 {
+				available = append(available, clusterInfo)
+			} else {
+				span.Debug("readonly or no available cluster", clusterInfo.ClusterID)
+			}
 		}
-		if clusterInfo.ClusterID != proto.ClusterID(clusterID) {
-			span.Warn("mismatch cluster id", clusterInfo.ClusterID, clusterID)
+	}
+	if failed == len(regions) {
+		return lastErr
+	}
+	available, totalAvailable := c.pickAvailable(available)
+	return c.deal(ctx, available, allClusters, totalAvailable)
+}
+
+// keepRegionClusters adds the previous loaded clusters of region, returns the available clusters
+func (c *clusterControllerImpl) keepRegionClusters(region string,
+	allClusters clusterMap, available []*cmapi.ClusterInfo,
+) []*cmapi.ClusterInfo {
+	previous, _ := c.clusters.Load().(clusterMap)
+	for clusterID, cs := range previous {
+		if cs.clusterInfo.Region != region {
 			continue
 		}
+		allClusters[clusterID] = &cluster{clusterInfo: cs.clusterInfo, client: cs.client}
+		if !cs.clusterInfo.Readonly && cs.clusterInfo.Available > 0 {
+			available = append(available, cs.clusterInfo)
+		}
+	}
+	return available
+}
 
-		allClusters[proto.ClusterID(clusterID)] = &cluster{clusterInfo: clusterInfo}
-		if !clusterInfo.Readonly && clusterInfo.Available > 0 {
-			available = append(available, clusterInfo)
-			totalAvailable += clusterInfo.Available
-		} else {
-			span.Debug("readonly or no available cluster", clusterID)
+func (c *clusterControllerImpl) decodeConsulCluster(span trace.Span, pair *api.KVPair) *cmapi.ClusterInfo {
+	clusterInfo := &cmapi.ClusterInfo{}
+	err := json.Unmarshal(pair.Value, clusterInfo)
+	if err != nil {
+		span.Warnf("decode failed, raw:%s, error:%s", string(pair.Value), err.Error())
+		return nil
+	}
+
+	clusterKey := filepath.Base(pair.Key)
+	span.Debug("found cluster", clusterKey)
+
+	clusterID, err := strconv.ParseUint(clusterKey, 10, 32)
+	if err != nil {
+		span.Warn("invalid cluster id", clusterKey, err)
+		return nil
+	}
+	if clusterInfo.ClusterID != proto.ClusterID(clusterID) {
+		span.Warn("mismatch cluster id", clusterInfo.ClusterID, clusterID)
+		return nil
+	}
+	return clusterInfo
+}
+
+// regions returns the region of controller and affinity regions in order
+func (c *clusterControllerImpl) regions() []string {
+	regions := make([]string, 0, 1+len(c.config.AffinityRegions))
+	regions = append(regions, c.region)
+	for _, region := range c.config.AffinityRegions {
+		exist := false
+		for _, r := range regions {
+			if r == region {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			regions = append(regions, region)
 		}
 	}
-	return c.deal(ctx, available, allClusters, totalAvailable)
+	return regions
+}
+
+// pickAvailable picks available clusters in the nearest region, returns clusters and total available space
+func (c *clusterControllerImpl) pickAvailable(available []*cmapi.ClusterInfo) ([]*cmapi.ClusterInfo, int64) {
+	picked := cmapi.PickByRegionAffinity(available, c.regions(), c.config.Labels)
+	if picked == nil {
+		picked = make([]*cmapi.ClusterInfo, 0)
+	}
+	totalAvailable := int64(0)
+	for _, clusterInfo := range picked {
+		totalAvailable += clusterInfo.Available
+	}
+	return picked, totalAvailable
 }
 
 func (c *clusterControllerImpl) deal(ctx context.Context,
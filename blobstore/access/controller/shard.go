@@ -54,6 +54,8 @@ type IShardController interface {
 	GetFisrtShard(ctx context.Context) (Shard, error)
 	GetNextShard(ctx context.Context, shardRange sharding.Range) (Shard, error)
 	GetSpaceID() proto.SpaceID
+	// GetWormRetention returns the worm retention of space, zero if not worm space.
+	GetWormRetention() time.Duration
 	UpdateRoute(ctx context.Context) error
 	UpdateShard(ctx context.Context, ss shardnode.ShardStats) error
 }
@@ -112,6 +114,7 @@ type shardControllerImpl struct {
 	ranges       *btree.BTree
 	version      proto.RouteVersion
 	spaceID      proto.SpaceID
+	retention    time.Duration
 	groupRun     singleflight.Group
 	sync.RWMutex // todo: I will optimize locker in the next version
 
@@ -232,6 +235,10 @@ func (s *shardControllerImpl) GetSpaceID() proto.SpaceID {
 	return s.spaceID
 }
 
+func (s *shardControllerImpl) GetWormRetention() time.Duration {
+	return s.retention
+}
+
 func (s *shardControllerImpl) UpdateRoute(ctx context.Context) error {
 	// Aggregation blob operations which comes from upper-layer
 	_, err, _ := s.groupRun.Do("updateRoute", func() (interface{}, error) {
@@ -268,6 +275,7 @@ func (s *shardControllerImpl) UpdateShard(ctx context.Context, sd shardnode.Shar
 		if sd.LeaderSuid.Epoch() >= oldShard.units[sd.LeaderSuid.Index()].Suid.Epoch() {
 			oldShard.leaderDiskID = sd.LeaderDiskID
 			oldShard.leaderSuid = sd.LeaderSuid
+			oldShard.leaderTerm = sd.LeaderTerm
 			return nil, nil
 		} else {
 			span.Warnf("skip update shard, leader suid epoch is less than old. old:%d, new:%d", oldShard.leaderSuid.Epoch(), sd.LeaderSuid.Epoch())
@@ -302,6 +310,7 @@ func (s *shardControllerImpl) initSpace(ctx context.Context) error {
 	}
 
 	s.spaceID = ret.SpaceID
+	s.retention = time.Duration(ret.WormRetentionSecs) * time.Second
 	return nil
 }
 
@@ -318,7 +327,7 @@ func (s *shardControllerImpl) incrementalRoute() {
 		select {
 		case <-tk.C:
 			// there is only one updateRoute, the same time. no concurrence
-			err := s.UpdateRoute(ctx)
+			err := s.validateRoute(ctx)
 			span.Debugf("loop update catalog route, err:%+v", err)
 
 		case <-s.stopCh:
@@ -328,6 +337,41 @@ func (s *shardControllerImpl) incrementalRoute() {
 	}
 }
 
+// validateRoute validates cached route with checksum of clusterMgr, and update route only if the checksum differs.
+// fetch full catalog route if cached route is different at the same version
+func (s *shardControllerImpl) validateRoute(ctx context.Context) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	ret, err := s.cmCli.GetRouteChecksum(ctx, &clustermgr.GetRouteChecksumArgs{SpaceID: s.spaceID})
+	if err != nil {
+		span.Warnf("fail to get route checksum from clusterMgr, update route directly. err:%+v", err)
+		return s.UpdateRoute(ctx)
+	}
+
+	s.Lock()
+	version, checksum := s.version, s.routeChecksumNoLock()
+	if version == ret.RouteVersion && checksum == ret.Checksum {
+		s.Unlock()
+		return nil
+	}
+	if version >= ret.RouteVersion {
+		span.Warnf("cached route is different, fetch full route. version:%d, checksum:%d, expected version:%d, checksum:%d",
+			version, checksum, ret.RouteVersion, ret.Checksum)
+		s.version = 0
+	}
+	s.Unlock()
+	return s.UpdateRoute(ctx)
+}
+
+// routeChecksumNoLock returns checksum of cached route, it is the same with clusterMgr if route is the same
+func (s *shardControllerImpl) routeChecksumNoLock() uint64 {
+	checksum := uint64(0)
+	for _, sd := range s.shards {
+		checksum ^= clustermgr.ShardRouteHash(sd.shardID, sd.version, sd.units)
+	}
+	return checksum
+}
+
 // called by period task, or read/write fail, init route
 // if err is nil, errCatalogNoLeader: OK. we can get leader from sn when write leader node ; else : FATAL
 func (s *shardControllerImpl) updateRoute(ctx context.Context) error {
@@ -512,6 +556,8 @@ func (s *shardControllerImpl) setShardByID(ctx context.Context, info *shard, val
 	}
 
 	// update leader disk id and suid ; leader disk may not in units ; leader disk may not val.disk
+	// leader term is unknown from catalog and kept, shardnode fences the write and
+	// the leader is updated from shardnode if the term of leader changed, the same as shard moved
 	for _, unit := range info.units {
 		if unit.DiskID == val.Unit.LeaderDiskID {
 			info.leaderDiskID = unit.DiskID
@@ -533,6 +579,8 @@ type ShardOpInfo struct {
 	DiskID       proto.DiskID
 	Suid         proto.Suid
 	RouteVersion proto.RouteVersion
+	// LeaderTerm fences writes to stale leader, only set for leader member
+	LeaderTerm uint64
 }
 
 type Shard interface {
@@ -546,6 +594,7 @@ type shard struct {
 	shardID      proto.ShardID
 	leaderDiskID proto.DiskID
 	leaderSuid   proto.Suid
+	leaderTerm   uint64
 	version      proto.RouteVersion
 	rangeExt     sharding.Range
 	units        []clustermgr.ShardUnit
@@ -600,6 +649,7 @@ func (i *shard) getMemberLeader(ctx context.Context) (ShardOpInfo, error) {
 		DiskID:       i.leaderDiskID,
 		Suid:         i.leaderSuid,
 		RouteVersion: i.version,
+		LeaderTerm:   i.leaderTerm,
 	}, nil
 }
 
@@ -161,6 +161,7 @@ func TestShardController(t *testing.T) {
 			Suid:         proto.EncodeSuid(newShard.shardID, 1, 1),
 			LeaderDiskID: newShard.leaderDiskID,
 			LeaderSuid:   proto.EncodeSuid(newShard.shardID, 1, 1),
+			LeaderTerm:   5,
 			RouteVersion: newShard.version,
 			Range:        newShard.rangeExt,
 			Units:        newShard.units,
@@ -180,6 +181,7 @@ func TestShardController(t *testing.T) {
 			DiskID:       2,
 			Suid:         proto.EncodeSuid(1, 1, 1),
 			RouteVersion: 1,
+			LeaderTerm:   5,
 		}, opInfo)
 
 		// update route, disk:3 -> disk:4
@@ -593,11 +595,13 @@ func TestShardUpdate(t *testing.T) {
 	}
 
 	{
-		// update version, leader disk not in units(old leader)
+		// update version, leader disk not in units(old leader), the unit is moved
 		rv := svr.version + 1
 		shardID := proto.ShardID(9)
 		oldShard, exist := svr.getShardNoLock(shardID)
 		require.True(t, exist)
+		// leader term is kept and fenced by shardnode
+		oldShard.leaderTerm = 7
 
 		val := clustermgr.CatalogChangeShardUpdate{
 			ShardID:      shardID,
@@ -635,6 +639,7 @@ func TestShardUpdate(t *testing.T) {
 			DiskID:       1,
 			Suid:         proto.EncodeSuid(shardID, 0, 0),
 			RouteVersion: rv,
+			LeaderTerm:   7,
 		}
 
 		require.Equal(t, expect, opHeader)
@@ -680,6 +685,7 @@ func TestShardUpdate(t *testing.T) {
 			shardID:      oldShard.shardID,
 			leaderDiskID: 4,
 			leaderSuid:   proto.EncodeSuid(shardID, 1, 1),
+			leaderTerm:   7,
 			version:      rv,
 			rangeExt:     oldShard.rangeExt,
 			units:        oldShard.units,
@@ -705,6 +711,54 @@ func TestShardUpdate(t *testing.T) {
 	}
 }
 
+func TestShardValidateRoute(t *testing.T) {
+	ctx := context.Background()
+	ctr := gomock.NewController(t)
+	cmCli := mocks.NewMockClientAPI(ctr)
+	units := []clustermgr.ShardUnit{{Suid: proto.EncodeSuid(1, 0, 1), DiskID: 1}}
+	svr := &shardControllerImpl{
+		shards:  map[proto.ShardID]*shard{1: {shardID: 1, version: 2, units: units}},
+		ranges:  btree.New(defaultBTreeDegree),
+		version: 2,
+		spaceID: 1,
+		cmCli:   cmCli,
+	}
+	checksum := clustermgr.ShardRouteHash(1, 2, units)
+	require.Equal(t, checksum, svr.routeChecksumNoLock())
+
+	expectChanges := func(version proto.RouteVersion) {
+		cmCli.EXPECT().GetCatalogChanges(gAny, gAny).DoAndReturn(
+			func(ctx context.Context, args *clustermgr.GetCatalogChangesArgs) (*clustermgr.GetCatalogChangesRet, error) {
+				require.Equal(t, version, args.RouteVersion)
+				return &clustermgr.GetCatalogChangesRet{}, nil
+			})
+	}
+
+	// update route if failed to get checksum
+	cmCli.EXPECT().GetRouteChecksum(gAny, gAny).Return(nil, errMock)
+	cmCli.EXPECT().GetCatalogChanges(gAny, gAny).Return(nil, errMock)
+	require.ErrorIs(t, svr.validateRoute(ctx), errMock)
+
+	// the same route
+	cmCli.EXPECT().GetRouteChecksum(gAny, gAny).DoAndReturn(
+		func(ctx context.Context, args *clustermgr.GetRouteChecksumArgs) (*clustermgr.GetRouteChecksumRet, error) {
+			require.Equal(t, proto.SpaceID(1), args.SpaceID)
+			return &clustermgr.GetRouteChecksumRet{SpaceID: 1, RouteVersion: 2, Checksum: checksum}, nil
+		})
+	require.NoError(t, svr.validateRoute(ctx))
+
+	// incremental route of new version
+	cmCli.EXPECT().GetRouteChecksum(gAny, gAny).Return(&clustermgr.GetRouteChecksumRet{RouteVersion: 3, Checksum: 1}, nil)
+	expectChanges(2)
+	require.NoError(t, svr.validateRoute(ctx))
+	require.Equal(t, proto.RouteVersion(2), svr.version)
+
+	// full route if checksum differs at the same version
+	cmCli.EXPECT().GetRouteChecksum(gAny, gAny).Return(&clustermgr.GetRouteChecksumRet{RouteVersion: 2, Checksum: 1}, nil)
+	expectChanges(0)
+	require.NoError(t, svr.validateRoute(ctx))
+}
+
 func TestShardGetShard(t *testing.T) {
 	ctx := context.Background()
 	svr := &shardControllerImpl{
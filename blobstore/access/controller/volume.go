@@ -333,8 +333,13 @@ func (v *volumeGetterImpl) getFromProxy(ctx context.Context, vid proto.Vid, flus
 	}
 	copy(phy.Units, volume.Units[:])
 
-	span.Debugf("to update memcache on volume(%d-%d) %+v", cid, vid, phy)
-	v.setToLocalCache(ctx, id, phy)
+	// stale volume served by proxy when clustermgr is unavailable, get again next time
+	if volume.Stale {
+		span.Warnf("got stale volume(%d-%d) %+v", cid, vid, phy)
+	} else {
+		span.Debugf("to update memcache on volume(%d-%d) %+v", cid, vid, phy)
+		v.setToLocalCache(ctx, id, phy)
+	}
 
 	if flush {
 		v.flush(ctx, vid, ver, hosts, triedHosts)
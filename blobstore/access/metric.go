@@ -0,0 +1,97 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cubefs/cubefs/blobstore/access/controller"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+const unknownSpace = "-"
+
+var (
+	spaceTrafficMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "blobstore",
+			Subsystem: "access",
+			Name:      "space_traffic_bytes",
+			Help:      "transferred bytes of succeeded put and get on space",
+		},
+		[]string{"cluster", "space", "api"},
+	)
+	spaceRequestMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "blobstore",
+			Subsystem: "access",
+			Name:      "space_request",
+			Help:      "put and get requests on space by status code",
+		},
+		[]string{"cluster", "space", "api", "code"},
+	)
+	spaceRequestSizeMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "blobstore",
+			Subsystem: "access",
+			Name:      "space_request_size_bytes",
+			Help:      "request size of put and get on space",
+			Buckets:   prometheus.ExponentialBuckets(1<<12, 4, 10), // 4KB - 1TB
+		},
+		[]string{"cluster", "space", "api"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(spaceTrafficMetric)
+	prometheus.MustRegister(spaceRequestMetric)
+	prometheus.MustRegister(spaceRequestSizeMetric)
+}
+
+// spaceNames is the name of space authenticated by access key in each cluster,
+// which is used to attribute the traffic to tenants.
+type spaceNames map[proto.ClusterID]string
+
+func newSpaceNames(clusters []controller.Cluster) spaceNames {
+	names := make(spaceNames, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.Space.IsValid() {
+			names[cluster.ClusterID] = cluster.Space.Name
+		}
+	}
+	return names
+}
+
+// report reports the request of api on the space of cluster, the cluster is
+// unknown if put failed before the location allocated.
+func (names spaceNames) report(cid proto.ClusterID, api string, size int64, err error) {
+	cluster, space := unknownSpace, unknownSpace
+	if cid != 0 {
+		cluster = cid.ToString()
+	}
+	if name, ok := names[cid]; ok {
+		space = name
+	}
+
+	code := strconv.Itoa(rpc.DetectStatusCode(err))
+	spaceRequestMetric.WithLabelValues(cluster, space, api, code).Inc()
+	spaceRequestSizeMetric.WithLabelValues(cluster, space, api).Observe(float64(size))
+	if err == nil {
+		spaceTrafficMetric.WithLabelValues(cluster, space, api).Add(float64(size))
+	}
+}
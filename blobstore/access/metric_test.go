@@ -0,0 +1,52 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/access/controller"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+)
+
+func TestAccessSpaceMetric(t *testing.T) {
+	spaceTrafficMetric.Reset()
+	spaceRequestMetric.Reset()
+	spaceRequestSizeMetric.Reset()
+
+	names := newSpaceNames([]controller.Cluster{
+		{ClusterID: 1, Space: controller.SpaceConf{Name: "tenant-a", AK: "ak", SK: "sk"}},
+		{ClusterID: 2, Space: controller.SpaceConf{Name: "no-auth"}},
+	})
+	require.Equal(t, spaceNames{1: "tenant-a"}, names)
+
+	names.report(1, limitNamePut, 1024, nil)
+	names.report(1, limitNamePut, 2048, nil)
+	names.report(1, limitNameGet, 4096, errcode.ErrAccessLimited)
+	names.report(2, limitNameGet, 100, nil)
+	names.report(0, limitNamePut, 10, errcode.ErrIllegalArguments)
+
+	require.Equal(t, float64(3072), testutil.ToFloat64(spaceTrafficMetric.WithLabelValues("1", "tenant-a", limitNamePut)))
+	require.Equal(t, float64(100), testutil.ToFloat64(spaceTrafficMetric.WithLabelValues("2", unknownSpace, limitNameGet)))
+	require.Equal(t, 2, testutil.CollectAndCount(spaceTrafficMetric))
+
+	require.Equal(t, float64(2), testutil.ToFloat64(spaceRequestMetric.WithLabelValues("1", "tenant-a", limitNamePut, "200")))
+	require.Equal(t, float64(1), testutil.ToFloat64(spaceRequestMetric.WithLabelValues("1", "tenant-a", limitNameGet, "429")))
+	require.Equal(t, float64(1), testutil.ToFloat64(spaceRequestMetric.WithLabelValues(unknownSpace, unknownSpace, limitNamePut, "400")))
+	require.Equal(t, 4, testutil.CollectAndCount(spaceRequestSizeMetric))
+}
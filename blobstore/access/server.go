@@ -25,6 +25,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/api/access"
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/cmd"
+	"github.com/cubefs/cubefs/blobstore/common/config"
 	"github.com/cubefs/cubefs/blobstore/common/consul"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/profile"
@@ -69,8 +70,11 @@ type Config struct {
 type Service struct {
 	config        Config
 	streamHandler stream.StreamHandler
-	limiter       stream.Limiter
 	closer        closer.Closer
+	spaces        spaceNames
+
+	limiterMu sync.RWMutex
+	limiter   stream.Limiter
 }
 
 // New returns an access service
@@ -84,12 +88,33 @@ func New(cfg Config) *Service {
 		log.Fatalf("new stream handler failed, err: %+v", err)
 	}
 
-	return &Service{
+	s := &Service{
 		config:        cfg,
 		streamHandler: h,
 		limiter:       stream.NewLimiter(cfg.Limit),
 		closer:        cl,
+		spaces:        newSpaceNames(cfg.Stream.ClusterConfig.Clusters),
 	}
+	config.Watch("limit", func(limitCfg stream.LimitConfig) error {
+		log.Infof("hot reload limit config: %+v", limitCfg)
+		s.setLimiter(stream.NewLimiter(limitCfg))
+		return nil
+	})
+	return s
+}
+
+func (s *Service) getLimiter() stream.Limiter {
+	s.limiterMu.RLock()
+	limiter := s.limiter
+	s.limiterMu.RUnlock()
+	return limiter
+}
+
+// setLimiter replaces limiter, the running requests release on the old one
+func (s *Service) setLimiter(limiter stream.Limiter) {
+	s.limiterMu.Lock()
+	s.limiter = limiter
+	s.limiterMu.Unlock()
 }
 
 // Close close server
@@ -126,7 +151,7 @@ func (s *Service) RegisterAdminHandler() {
 		span := trace.SpanFromContextSafe(ctx)
 
 		status := new(accessStatus)
-		status.Limit = s.limiter.Status()
+		status.Limit = s.getLimiter().Status()
 		status.Pool = admin.MemPool.Status()
 		status.Config = admin.Config
 		status.Clusters = admin.Controller.All()
@@ -187,9 +212,9 @@ func (s *Service) Limit(c *rpc.Context) {
 		name = limitNamePut
 	case "/putat":
 		name = limitNamePutAt
-	case "/get":
+	case "/get", "/presigned/get":
 		name = limitNameGet
-	case "/delete":
+	case "/delete", "/presigned/delete":
 		name = limitNameDelete
 	case "/sign":
 		name = limitNameSign
@@ -199,13 +224,14 @@ func (s *Service) Limit(c *rpc.Context) {
 		return
 	}
 
-	if err := s.limiter.Acquire(name); err != nil {
+	limiter := s.getLimiter()
+	if err := limiter.Acquire(name); err != nil {
 		span := trace.SpanFromContextSafe(c.Request.Context())
 		span.Info("access concurrent limited", name, err)
 		c.AbortWithError(errcode.ErrAccessLimited)
 		return
 	}
-	defer s.limiter.Release(name)
+	defer limiter.Release(name)
 	c.Next()
 }
 
@@ -233,13 +259,15 @@ func (s *Service) Put(c *rpc.Context) {
 		hasherMap[alg] = alg.ToHasher()
 	}
 
-	rc := s.limiter.Reader(ctx, c.Request.Body)
+	rc := s.getLimiter().Reader(ctx, c.Request.Body)
 	loc, err := s.streamHandler.Put(ctx, rc, args.Size, hasherMap)
 	if err != nil {
+		s.spaces.report(0, limitNamePut, args.Size, err)
 		span.Error("stream put failed", errors.Detail(err))
 		c.RespondError(httpError(err))
 		return
 	}
+	s.spaces.report(loc.ClusterID, limitNamePut, args.Size, nil)
 
 	// hasher sum
 	for alg, hasher := range hasherMap {
@@ -297,8 +325,9 @@ func (s *Service) PutAt(c *rpc.Context) {
 		hasherMap[alg] = alg.ToHasher()
 	}
 
-	rc := s.limiter.Reader(ctx, c.Request.Body)
+	rc := s.getLimiter().Reader(ctx, c.Request.Body)
 	err := s.streamHandler.PutAt(ctx, rc, args.ClusterID, args.Vid, args.BlobID, args.Size, hasherMap)
+	s.spaces.report(args.ClusterID, limitNamePutAt, args.Size, err)
 	if err != nil {
 		span.Error("stream putat failed", errors.Detail(err))
 		c.RespondError(httpError(err))
@@ -369,10 +398,12 @@ func (s *Service) Get(c *rpc.Context) {
 		return
 	}
 
+	ctx = access.WithConsistencyLevel(ctx, args.Consistency)
 	w := c.Writer
-	writer := s.limiter.Writer(ctx, w)
+	writer := s.getLimiter().Writer(ctx, w)
 	transfer, err := s.streamHandler.Get(ctx, writer, args.Location, args.ReadSize, args.Offset)
 	if err != nil {
+		s.spaces.report(args.Location.ClusterID, limitNameGet, int64(args.ReadSize), err)
 		span.Error("stream get prepare failed", errors.Detail(err))
 		c.RespondError(httpError(err))
 		return
@@ -392,6 +423,7 @@ func (s *Service) Get(c *rpc.Context) {
 	c.Flush()
 
 	err = transfer()
+	s.spaces.report(args.Location.ClusterID, limitNameGet, int64(args.ReadSize), err)
 	if err != nil {
 		stream.SteamReportDownload(args.Location.ClusterID, "StatusOKError", "-")
 		span.Error("stream get transfer failed", errors.Detail(err))
@@ -587,6 +619,120 @@ func (s *Service) Sign(c *rpc.Context) {
 	span.Infof("done /sign request crc %d -> %d, resp:%+v", crcOld, loc.Crc, loc)
 }
 
+// Presign mint a time-limited token of the operation on one blob
+func (s *Service) Presign(c *rpc.Context) {
+	args := new(access.PresignArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+
+	span.Debugf("accept /presign request args:%+v", args)
+	if !args.IsValid() || !security.LocationCrcVerify(&args.Location) {
+		c.RespondError(errcode.ErrIllegalArguments)
+		return
+	}
+
+	token := args.Token(security.TokenSecretKeys()[0][:])
+	c.RespondJSON(access.PresignResp{Token: security.EncodePresignToken(token)})
+	span.Infof("done /presign request op:%d blob:(%d %d %d)", args.Op,
+		args.Location.ClusterID, args.Location.Slices[0].Vid, args.Location.Slices[0].MinSliceID)
+}
+
+// PresignedGet get one blob with presign token
+func (s *Service) PresignedGet(c *rpc.Context) {
+	args := new(access.PresignedArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+
+	span.Debugf("accept /presigned/get request args:%+v", args)
+	if !args.IsValid() || !isValidPresignToken(security.PresignOpGet, args) {
+		c.RespondError(errcode.ErrIllegalArguments)
+		return
+	}
+
+	if args.ReadSize == 0 && args.Offset == 0 {
+		args.ReadSize = uint64(args.Size)
+	}
+	location := args.Location()
+	w := c.Writer
+	writer := s.getLimiter().Writer(ctx, w)
+	transfer, err := s.streamHandler.Get(ctx, writer, location, args.ReadSize, args.Offset)
+	if err != nil {
+		s.spaces.report(args.ClusterID, limitNameGet, int64(args.ReadSize), err)
+		span.Error("stream get prepare failed", errors.Detail(err))
+		c.RespondError(httpError(err))
+		return
+	}
+
+	w.Header().Set(rpc.HeaderContentType, rpc.MIMEStream)
+	w.Header().Set(rpc.HeaderContentLength, strconv.FormatInt(int64(args.ReadSize), 10))
+	if args.ReadSize > 0 && args.ReadSize != location.Size_ {
+		w.Header().Set(rpc.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d",
+			args.Offset, args.Offset+args.ReadSize-1, location.Size_))
+		c.RespondStatus(http.StatusPartialContent)
+	} else {
+		c.RespondStatus(http.StatusOK)
+	}
+
+	c.Flush()
+
+	err = transfer()
+	s.spaces.report(args.ClusterID, limitNameGet, int64(args.ReadSize), err)
+	if err != nil {
+		stream.SteamReportDownload(args.ClusterID, "StatusOKError", "-")
+		span.Error("stream get transfer failed", errors.Detail(err))
+		return
+	}
+	span.Info("done /presigned/get request")
+}
+
+// PresignedDelete delete one blob with presign token
+func (s *Service) PresignedDelete(c *rpc.Context) {
+	args := new(access.PresignedArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+
+	span.Debugf("accept /presigned/delete request args:%+v", args)
+	if !args.IsValid() || !isValidPresignToken(security.PresignOpDelete, args) {
+		c.RespondError(errcode.ErrIllegalArguments)
+		return
+	}
+
+	location := args.Location()
+	if err := s.streamHandler.Delete(ctx, &location); err != nil {
+		span.Error("stream delete blob failed", errors.Detail(err))
+		c.RespondError(httpError(err))
+		return
+	}
+
+	c.Respond()
+	span.Info("done /presigned/delete request")
+}
+
+func isValidPresignToken(op security.PresignOp, args *access.PresignedArgs) bool {
+	token := security.DecodePresignToken(args.Token)
+	for _, secretKey := range security.TokenSecretKeys() {
+		if token.IsValid(op, args.ClusterID, args.CodeMode, args.Vid, args.BlobID, uint32(args.Size), secretKey[:]) {
+			return true
+		}
+	}
+	return false
+}
+
 func httpError(err error) error {
 	if e, ok := err.(rpc.HTTPError); ok {
 		return e
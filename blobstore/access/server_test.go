@@ -25,6 +25,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -58,6 +59,9 @@ var (
 
 	testServer *httptest.Server
 	once       sync.Once
+
+	// consistency level of the last get request seen by stream handler
+	getConsistency atomic.Value
 )
 
 func runMockService(s *Service) string {
@@ -108,6 +112,7 @@ func newService() *Service {
 
 	s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
 		func(ctx context.Context, w io.Writer, location proto.Location, readSize, offset uint64) (func() error, error) {
+			getConsistency.Store(access.ConsistencyLevelFromContext(ctx))
 			if readSize < 1024 {
 				return nil, errors.New("fake get nil body")
 			}
@@ -334,6 +339,41 @@ func TestAccessServiceGet(t *testing.T) {
 	}
 }
 
+func TestAccessServiceGetConsistency(t *testing.T) {
+	host := runMockService(newService())
+	cli := newClient()
+
+	url := fmt.Sprintf("%s/get", host)
+	args := access.GetArgs{
+		Location: location.Copy(),
+		ReadSize: 1024,
+	}
+	args.Location.Size_ = 1024
+	security.LocationCrcFill(&args.Location)
+
+	for _, level := range []access.ConsistencyLevel{
+		access.ConsistencyReadAfterWrite,
+		access.ConsistencyEventual,
+	} {
+		getConsistency.Store(access.ConsistencyLevel(0xff))
+		args.Consistency = level
+		resp, err := cli.Post(ctx, url, args)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode, resp.Status)
+		require.Equal(t, level, getConsistency.Load(), level.String())
+	}
+	{
+		getConsistency.Store(access.ConsistencyLevel(0xff))
+		args.Consistency = access.ConsistencyLevel(0xff)
+		resp, err := cli.Post(ctx, url, args)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, 400, resp.StatusCode, resp.Status)
+		require.Equal(t, access.ConsistencyLevel(0xff), getConsistency.Load())
+	}
+}
+
 func TestAccessServiceDelete(t *testing.T) {
 	host := runMockService(newService())
 	cli := newClient()
@@ -527,6 +567,76 @@ func TestAccessServiceSign(t *testing.T) {
 	}
 }
 
+func TestAccessServicePresign(t *testing.T) {
+	host := runMockService(newService())
+	cli := newClient()
+
+	args := access.PresignArgs{
+		Op: security.PresignOpGet,
+		Location: proto.Location{
+			ClusterID: 1,
+			CodeMode:  codemode.EC6P6,
+			Size_:     1024,
+			SliceSize: 1024,
+			Slices:    []proto.Slice{{MinSliceID: 111, Vid: 1111, Count: 1}},
+		},
+		ExpirationS: 60,
+	}
+	{
+		resp := &access.PresignResp{}
+		err := cli.PostWith(ctx, host+"/presign", resp, access.PresignArgs{})
+		assertErrorCode(t, 400, err)
+	}
+	{ // location without signed crc
+		resp := &access.PresignResp{}
+		err := cli.PostWith(ctx, host+"/presign", resp, args)
+		assertErrorCode(t, 400, err)
+	}
+	security.LocationCrcFill(&args.Location)
+	{ // location is tampered after signed
+		tampered := args
+		tampered.Location.Slices = []proto.Slice{{MinSliceID: 112, Vid: 1111, Count: 1}}
+		resp := &access.PresignResp{}
+		err := cli.PostWith(ctx, host+"/presign", resp, tampered)
+		assertErrorCode(t, 400, err)
+	}
+	resp := &access.PresignResp{}
+	err := cli.PostWith(ctx, host+"/presign", resp, args)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Token)
+
+	url := func(path string, size int64, token string) string {
+		return fmt.Sprintf("%s/presigned/%s?clusterid=1&codemode=%d&volumeid=1111&blobid=111&size=%d&token=%s",
+			host, path, codemode.EC6P6, size, token)
+	}
+	{
+		r, err := cli.Get(ctx, url("get", 1024, resp.Token))
+		require.NoError(t, err)
+		r.Body.Close()
+		require.Equal(t, 200, r.StatusCode, r.Status)
+	}
+	{
+		r, err := cli.Get(ctx, url("get", 1023, resp.Token))
+		require.NoError(t, err)
+		r.Body.Close()
+		require.Equal(t, 400, r.StatusCode, r.Status)
+	}
+	{ // token of get cannot delete
+		req, _ := http.NewRequest(http.MethodDelete, url("delete", 1024, resp.Token), nil)
+		err := cli.DoWith(ctx, req, nil)
+		assertErrorCode(t, 400, err)
+	}
+
+	args.Op = security.PresignOpDelete
+	err = cli.PostWith(ctx, host+"/presign", resp, args)
+	require.NoError(t, err)
+	{
+		req, _ := http.NewRequest(http.MethodDelete, url("delete", 1024, resp.Token), nil)
+		err := cli.DoWith(ctx, req, nil)
+		require.NoError(t, err)
+	}
+}
+
 func assertErrorCode(t *testing.T, code int, err error) {
 	require.Error(t, err)
 	codeActual := rpc.DetectStatusCode(err)
@@ -61,6 +61,7 @@ func NewHandler(service *Service) *rpc.Router {
 	rpc.RegisterArgsParser(&access.PutArgs{}, "json")
 	rpc.RegisterArgsParser(&access.PutAtArgs{}, "json")
 	rpc.RegisterArgsParser(&access.DeleteBlobArgs{}, "json")
+	rpc.RegisterArgsParser(&access.PresignedArgs{}, "json")
 
 	rpc.Use(service.Limit)
 
@@ -100,5 +101,16 @@ func NewHandler(service *Service) *rpc.Router {
 	// response body:  json
 	rpc.POST("/sign", service.Sign, rpc.OptArgsBody())
 
+	// POST /presign
+	// request  body:  json
+	// response body:  json
+	rpc.POST("/presign", service.Presign, rpc.OptArgsBody())
+
+	// GET /presigned/get?clusterid={clusterid}&codemode={codemode}&volumeid={volumeid}&blobid={blobid}&size={size}&offset={offset}&read_size={read_size}&token={token}
+	// response body:  DataStream
+	rpc.GET("/presigned/get", service.PresignedGet, rpc.OptArgsQuery())
+	// DELETE /presigned/delete?clusterid={clusterid}&codemode={codemode}&volumeid={volumeid}&blobid={blobid}&size={size}&token={token}
+	rpc.DELETE("/presigned/delete", service.PresignedDelete, rpc.OptArgsQuery())
+
 	return rpc.DefaultRouter
 }
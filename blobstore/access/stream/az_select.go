@@ -0,0 +1,181 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
+)
+
+const (
+	defaultAZSelectLatencyWeight = 0.5
+	defaultAZSelectCrossAZWeight = 1.0
+	defaultAZSelectWindowS       = 10
+
+	azLatencyAlpha = 0.1
+)
+
+// AZSelectConfig bandwidth-aware az selection of reading shards.
+//
+// Shards are read from the idc with lower score firstly, punished disks are still the last.
+//
+//	score = utilization + LatencyWeight * latency / max(latency) + CrossAZWeight * (idc != local)
+//
+// utilization is bytes rate of reading from the idc in window divided by its link bandwidth,
+// it is zero if bandwidth of the idc is not configured. The local idc is preferred by default,
+// unless its link is much more busy than remote ones. Zero weights are set to default.
+type AZSelectConfig struct {
+	Enable            bool             `json:"enable"`
+	LinkBandwidthMBps map[string]int64 `json:"link_bandwidth_mbps"`
+	LatencyWeight     float64          `json:"latency_weight"`
+	CrossAZWeight     float64          `json:"cross_az_weight"`
+	WindowS           int              `json:"window_s"`
+}
+
+// azStat statistics of reading shards from one idc,
+// bytes is decayed exponentially in window and latency is moving average.
+type azStat struct {
+	mu        sync.Mutex
+	bytes     float64
+	latencyMs float64
+	updated   time.Time
+}
+
+func (s *azStat) decay(now time.Time, window time.Duration) {
+	if elapsed := now.Sub(s.updated); elapsed > 0 {
+		s.bytes *= math.Exp(-float64(elapsed) / float64(window))
+		s.updated = now
+	}
+}
+
+type azSelector struct {
+	conf   AZSelectConfig
+	idc    string
+	window time.Duration
+
+	mu    sync.RWMutex
+	stats map[string]*azStat
+}
+
+func newAZSelector(conf AZSelectConfig, idc string) *azSelector {
+	defaulter.FloatLessOrEqual(&conf.LatencyWeight, defaultAZSelectLatencyWeight)
+	defaulter.FloatLessOrEqual(&conf.CrossAZWeight, defaultAZSelectCrossAZWeight)
+	defaulter.LessOrEqual(&conf.WindowS, defaultAZSelectWindowS)
+	return &azSelector{
+		conf:   conf,
+		idc:    idc,
+		window: time.Duration(conf.WindowS) * time.Second,
+		stats:  make(map[string]*azStat),
+	}
+}
+
+func (s *azSelector) getStat(idc string) *azStat {
+	s.mu.RLock()
+	stat := s.stats[idc]
+	s.mu.RUnlock()
+	if stat != nil {
+		return stat
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stat = s.stats[idc]; stat == nil {
+		stat = &azStat{updated: time.Now()}
+		s.stats[idc] = stat
+	}
+	return stat
+}
+
+// record bytes and latency of one shard read from the idc
+func (s *azSelector) record(idc string, bytes int, latency time.Duration) {
+	if idc == "" {
+		return
+	}
+	stat := s.getStat(idc)
+	ms := float64(latency) / float64(time.Millisecond)
+	stat.mu.Lock()
+	stat.decay(time.Now(), s.window)
+	stat.bytes += float64(bytes)
+	if stat.latencyMs == 0 {
+		stat.latencyMs = ms
+	} else {
+		stat.latencyMs += azLatencyAlpha * (ms - stat.latencyMs)
+	}
+	stat.mu.Unlock()
+}
+
+// utilization returns bytes rate in window divided by link bandwidth of the idc
+func (s *azSelector) utilization(idc string, bytes float64) float64 {
+	bandwidth := s.conf.LinkBandwidthMBps[idc]
+	if bandwidth <= 0 {
+		return 0
+	}
+	rate := bytes / s.window.Seconds()
+	return rate / float64(bandwidth<<20)
+}
+
+// scores returns score of idcs, the lower the better
+func (s *azSelector) scores(idcs map[string]struct{}) map[string]float64 {
+	now := time.Now()
+	utils := make(map[string]float64, len(idcs))
+	latencies := make(map[string]float64, len(idcs))
+	maxLatency := 0.0
+	for idc := range idcs {
+		stat := s.getStat(idc)
+		stat.mu.Lock()
+		stat.decay(now, s.window)
+		utils[idc] = s.utilization(idc, stat.bytes)
+		latencies[idc] = stat.latencyMs
+		stat.mu.Unlock()
+		if latencies[idc] > maxLatency {
+			maxLatency = latencies[idc]
+		}
+	}
+
+	scores := make(map[string]float64, len(idcs))
+	for idc := range idcs {
+		score := utils[idc]
+		if maxLatency > 0 {
+			score += s.conf.LatencyWeight * latencies[idc] / maxLatency
+		}
+		if idc != s.idc {
+			score += s.conf.CrossAZWeight
+		}
+		scores[idc] = score
+		reportAZSelect(idc, utils[idc], latencies[idc], score)
+	}
+	return scores
+}
+
+// sort sorts not punished vuids by score of their idc, order in the same score is kept.
+func (s *azSelector) sort(vuids []sortedVuid) {
+	n := 0
+	idcs := make(map[string]struct{})
+	for n < len(vuids) && !vuids[n].punished {
+		idcs[vuids[n].idc] = struct{}{}
+		n++
+	}
+	if len(idcs) <= 1 {
+		return
+	}
+	scores := s.scores(idcs)
+	sort.SliceStable(vuids[:n], func(i, j int) bool {
+		return scores[vuids[i].idc] < scores[vuids[j].idc]
+	})
+}
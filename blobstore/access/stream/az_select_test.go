@@ -0,0 +1,85 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func azSelectedIdcs(vuids []sortedVuid) []string {
+	idcs := make([]string, 0, len(vuids))
+	for _, vuid := range vuids {
+		idcs = append(idcs, vuid.idc)
+	}
+	return idcs
+}
+
+func TestAZSelectorSort(t *testing.T) {
+	newVuids := func() []sortedVuid {
+		return []sortedVuid{
+			{index: 0, idc: "z0"},
+			{index: 1, idc: "z1"},
+			{index: 2, idc: "z2"},
+			{index: 3, idc: "z0", punished: true},
+		}
+	}
+
+	s := newAZSelector(AZSelectConfig{Enable: true}, "z0")
+	require.Equal(t, defaultAZSelectLatencyWeight, s.conf.LatencyWeight)
+	require.Equal(t, defaultAZSelectCrossAZWeight, s.conf.CrossAZWeight)
+	require.Equal(t, defaultAZSelectWindowS*time.Second, s.window)
+
+	// local idc is preferred
+	vuids := newVuids()
+	s.sort(vuids)
+	require.Equal(t, []string{"z0", "z1", "z2", "z0"}, azSelectedIdcs(vuids))
+
+	// lower latency is preferred in remote idcs
+	s.record("z1", 1<<20, 100*time.Millisecond)
+	s.record("z2", 1<<20, 10*time.Millisecond)
+	s.record("", 1<<20, time.Millisecond)
+	vuids = newVuids()
+	s.sort(vuids)
+	require.Equal(t, []string{"z0", "z2", "z1", "z0"}, azSelectedIdcs(vuids))
+	require.Equal(t, 3, vuids[0].index+vuids[3].index)
+	require.True(t, vuids[3].punished)
+
+	// remote idc is preferred if link of local idc is saturated
+	s = newAZSelector(AZSelectConfig{
+		Enable:            true,
+		LinkBandwidthMBps: map[string]int64{"z0": 1, "z1": 100, "z2": 100},
+		CrossAZWeight:     0.1,
+		WindowS:           1,
+	}, "z0")
+	s.record("z0", 4<<20, time.Millisecond)
+	s.record("z1", 1<<20, time.Millisecond)
+	s.record("z2", 1<<20, time.Millisecond)
+	scores := s.scores(map[string]struct{}{"z0": {}, "z1": {}, "z2": {}})
+	require.Less(t, scores["z1"], scores["z0"])
+	require.Less(t, s.utilization("z1", 1<<20), 0.02)
+	require.Equal(t, 0.0, s.utilization("z3", 1<<20))
+	vuids = newVuids()
+	s.sort(vuids)
+	require.Equal(t, "z0", vuids[2].idc)
+	require.True(t, vuids[3].punished)
+
+	// nothing sorted in one idc
+	vuids = []sortedVuid{{index: 1, idc: "z0"}, {index: 0, idc: "z0"}}
+	s.sort(vuids)
+	require.Equal(t, 1, vuids[0].index)
+}
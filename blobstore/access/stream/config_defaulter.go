@@ -25,6 +25,13 @@ const (
 	defaultMinReadShardsX           int = 1
 	defaultShardnodeRetryTimes      int = 3
 	defaultShardnodeRetryIntervalMS int = 200
+	defaultTrashPurgeIntervalS      int = 600
+	defaultTrashListCount           int = 1000
+	defaultPutPipelineDepth         int = 2
+	defaultTransitionConcurrency    int = 4
+	defaultTransitionDeleteDelayS   int = 600
+	defaultBlobEventConcurrency     int = 4
+	defaultBlobEventQueueSize       int = 4096
 
 	// client timeout ms
 	defaultTimeoutClusterMgr int64 = 1000 * 3
@@ -7,6 +7,7 @@ package stream
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	controller "github.com/cubefs/cubefs/blobstore/access/controller"
 	access "github.com/cubefs/cubefs/blobstore/api/access"
@@ -487,6 +488,20 @@ func (mr *MockShardControllerMockRecorder) GetSpaceID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceID", reflect.TypeOf((*MockShardController)(nil).GetSpaceID))
 }
 
+// GetWormRetention mocks base method.
+func (m *MockShardController) GetWormRetention() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWormRetention")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetWormRetention indicates an expected call of GetWormRetention.
+func (mr *MockShardControllerMockRecorder) GetWormRetention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWormRetention", reflect.TypeOf((*MockShardController)(nil).GetWormRetention))
+}
+
 // UpdateRoute mocks base method.
 func (m *MockShardController) UpdateRoute(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -19,6 +19,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc/auditlog"
 )
@@ -43,6 +44,26 @@ var downloadMetric = prometheus.NewCounterVec(
 	[]string{"cluster", "way", "reason"},
 )
 
+var azSelectMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "blobstore",
+		Subsystem: "access",
+		Name:      "az_select",
+		Help:      "link utilization, read latency ms and score of idc in az selection",
+	},
+	[]string{"idc", "item"},
+)
+
+var blobEventDropMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "access",
+		Name:      "blob_event_dropped",
+		Help:      "dropped blob events of space",
+	},
+	[]string{"cluster", "type", "reason"},
+)
+
 var readwriteMetric *prometheus.HistogramVec
 
 var SteamReportDownload = reportDownload
@@ -50,6 +71,8 @@ var SteamReportDownload = reportDownload
 func init() {
 	prometheus.MustRegister(unhealthMetric)
 	prometheus.MustRegister(downloadMetric)
+	prometheus.MustRegister(azSelectMetric)
+	prometheus.MustRegister(blobEventDropMetric)
 
 	hostname, _ := os.Hostname()
 	readwriteMetric = prometheus.NewHistogramVec(
@@ -74,7 +97,17 @@ func reportDownload(cid proto.ClusterID, way, reason string) {
 	downloadMetric.WithLabelValues(cid.ToString(), way, reason).Inc()
 }
 
+func reportBlobEventDrop(cid proto.ClusterID, typ proxy.BlobEventType, reason string) {
+	blobEventDropMetric.WithLabelValues(cid.ToString(), string(typ), reason).Inc()
+}
+
 // upload_read, upload_write, download_read, download_write
 func reportReadwrite(cid, idc, api string, ms int64) {
 	readwriteMetric.WithLabelValues(cid, idc, api).Observe(float64(ms))
 }
+
+func reportAZSelect(idc string, utilization, latencyMs, score float64) {
+	azSelectMetric.WithLabelValues(idc, "utilization").Set(utilization)
+	azSelectMetric.WithLabelValues(idc, "latency_ms").Set(latencyMs)
+	azSelectMetric.WithLabelValues(idc, "score").Set(score)
+}
@@ -106,6 +106,8 @@ type StreamHandler interface {
 	GetBlob(ctx context.Context, args *access.GetBlobArgs) (*proto.Location, error)
 	// DeleteBlob returns error
 	DeleteBlob(ctx context.Context, args *access.DelBlobArgs) error
+	// UndeleteBlob restores blob in trash, returns error
+	UndeleteBlob(ctx context.Context, args *access.UndeleteBlobArgs) error
 	// SealBlob returns error
 	SealBlob(ctx context.Context, args *access.SealBlobArgs) error
 	// CreateBlob returns location
@@ -114,6 +116,10 @@ type StreamHandler interface {
 	ListBlob(ctx context.Context, args *access.ListBlobArgs) (shardnode.ListBlobRet, error)
 	// AllocSlice returns alloc blob
 	AllocSlice(ctx context.Context, args *access.AllocSliceArgs) (shardnode.AllocSliceRet, error)
+	// TransitionBlob re-encodes blob into the codemode and switches its location
+	TransitionBlob(ctx context.Context, args *access.TransitionBlobArgs) error
+	// SendBlobEvent publishes lifecycle event of space blob in background
+	SendBlobEvent(ctx context.Context, clusterID proto.ClusterID, event proxy.BlobEvent)
 }
 
 type StreamAdmin struct {
@@ -146,12 +152,38 @@ type StreamConfig struct {
 	ShardCrcReadEnable         bool   `json:"shard_crc_read_enable"`
 	ShardnodeRetryTimes        int    `json:"shardnode_retry_times"`
 	ShardnodeRetryIntervalMS   int    `json:"shardnode_retry_interval_ms"`
+	// EncoderBackend simd instruction set of ec encoder,
+	// the fastest one is selected by benchmark if empty
+	EncoderBackend ec.Backend `json:"encoder_backend"`
+	// TrashRetentionS deleted blob is moved into trash and can be undeleted
+	// in this window, the data is purged after expired; disabled if 0
+	TrashRetentionS     int `json:"trash_retention_s"`
+	TrashPurgeIntervalS int `json:"trash_purge_interval_s"`
+	// BlobEventEnable publishes put and delete events of space blob with proxy,
+	// proxy sends them to the sink of the space, events are sent by BlobEventConcurrency
+	// workers and dropped if BlobEventQueueSize events are waiting
+	BlobEventEnable      bool `json:"blob_event_enable"`
+	BlobEventConcurrency int  `json:"blob_event_concurrency"`
+	BlobEventQueueSize   int  `json:"blob_event_queue_size"`
+	// DedupEnable put returns the exist location if the same content has been uploaded,
+	// the content is indexed by sha256 at shardnode, and freed after all deleted
+	DedupEnable bool `json:"dedup_enable"`
+	// PutPipelineDepth count of encoded blobs waiting to be written in one put,
+	// encoding of the next blob is overlapped with writing of the current blob
+	PutPipelineDepth int `json:"put_pipeline_depth"`
+	// TransitionConcurrency count of blobs re-encoding into colder codemode at the same time,
+	// the transition is rejected if exceeded. The old location of transited blob is deleted
+	// after TransitionDeleteDelayS, readers got the old location before switched can still read it
+	TransitionConcurrency  int `json:"transition_concurrency"`
+	TransitionDeleteDelayS int `json:"transition_delete_delay_s"`
 
 	LogSlowBaseTimeMS  int     `json:"log_slow_base_time_ms"`
 	LogSlowBaseSpeedKB int     `json:"log_slow_base_speed_kb"`
 	LogSlowTimeFator   float32 `json:"log_slow_time_fator"`
 
 	MemPoolSizeClasses map[int]int `json:"mem_pool_size_classes"`
+	// MemPoolNumaEnable memory pool with sub-pool per NUMA node
+	MemPoolNumaEnable bool `json:"mem_pool_numa_enable"`
 
 	// CodeModesPutQuorums
 	// just for one AZ is down, cant write quorum in all AZs
@@ -162,6 +194,10 @@ type StreamConfig struct {
 	// EC24P8 in 1AZ, C(32, 8) = 10518300 matrix
 	// Inverted matrix memory: (24 + 24*24 + 24*24*8) * 10518300 ~= 51 GB
 	CodeModesGetOrdered map[codemode.CodeMode]bool `json:"code_mode_get_ordered"`
+	// AZSelect reads shards from the idc with more headroom of link firstly
+	AZSelect AZSelectConfig `json:"az_select"`
+	// ReadRepair writes shards reconstructed in degraded get back to blobnode
+	ReadRepair ReadRepairConfig `json:"read_repair"`
 
 	ClusterConfig   controller.ClusterConfig `json:"cluster_config"`
 	BlobnodeConfig  blobnode.Config          `json:"blobnode_config"`
@@ -204,8 +240,12 @@ type Handler struct {
 	allCodeModes  CodeModePairs
 	maxObjectSize int64
 
-	discardVidChan chan discardVid
-	stopCh         <-chan struct{}
+	azSelector        *azSelector
+	readRepairLimiter chan struct{}
+	discardVidChan    chan discardVid
+	transitionLimiter chan struct{}
+	blobEventCh       chan blobEventTask
+	stopCh            <-chan struct{}
 
 	StreamConfig
 }
@@ -247,6 +287,12 @@ func confCheck(cfg *StreamConfig) error {
 	defaulter.LessOrEqual(&cfg.EncoderConcurrency, defaultEncoderConcurrency)
 	defaulter.LessOrEqual(&cfg.MinReadShardsX, defaultMinReadShardsX)
 	defaulter.LessOrEqual(&cfg.ReadDataOnlyTimeoutMS, 3*1000)
+	defaulter.LessOrEqual(&cfg.TrashPurgeIntervalS, defaultTrashPurgeIntervalS)
+	defaulter.LessOrEqual(&cfg.PutPipelineDepth, defaultPutPipelineDepth)
+	defaulter.LessOrEqual(&cfg.TransitionConcurrency, defaultTransitionConcurrency)
+	defaulter.LessOrEqual(&cfg.TransitionDeleteDelayS, defaultTransitionDeleteDelayS)
+	defaulter.LessOrEqual(&cfg.BlobEventConcurrency, defaultBlobEventConcurrency)
+	defaulter.LessOrEqual(&cfg.BlobEventQueueSize, defaultBlobEventQueueSize)
 
 	defaulter.LessOrEqual(&cfg.LogSlowBaseTimeMS, 500)
 	defaulter.Equal(&cfg.LogSlowBaseSpeedKB, 1<<10)
@@ -287,8 +333,12 @@ func NewStreamHandler(cfg *StreamConfig, stopCh <-chan struct{}) (h StreamHandle
 		return
 	}
 
+	memPool := resourcepool.NewMemPool(cfg.MemPoolSizeClasses)
+	if cfg.MemPoolNumaEnable {
+		memPool = resourcepool.NewNumaMemPool(cfg.MemPoolSizeClasses)
+	}
 	handler := &Handler{
-		memPool:           resourcepool.NewMemPool(cfg.MemPoolSizeClasses),
+		memPool:           memPool,
 		clusterController: clusterController,
 
 		blobnodeClient:  blobnode.New(&cfg.BlobnodeConfig),
@@ -302,6 +352,9 @@ func NewStreamHandler(cfg *StreamConfig, stopCh <-chan struct{}) (h StreamHandle
 		// Do not use rpc retry, because the stream blob handles retries itself
 		defaulter.LessOrEqual(&cfg.ShardnodeConfig.Config.Retry, int(1))
 		handler.shardnodeClient = shardnode.New(cfg.ShardnodeConfig.Config)
+	} else if cfg.DedupEnable {
+		e = errors.New("dedup is enabled without shardnode")
+		return
 	}
 
 	rawCodeModePolicies, err := handler.clusterController.GetConfig(context.Background(), proto.CodeModeConfigKey)
@@ -337,6 +390,7 @@ func NewStreamHandler(cfg *StreamConfig, stopCh <-chan struct{}) (h StreamHandle
 			CodeMode:     tactic,
 			EnableVerify: cfg.EncoderEnableVerify,
 			Concurrency:  cfg.EncoderConcurrency,
+			Backend:      cfg.EncoderBackend,
 		})
 		if err != nil {
 			e = errors.Newf("new encoder failed, err: %v", err)
@@ -346,6 +400,12 @@ func NewStreamHandler(cfg *StreamConfig, stopCh <-chan struct{}) (h StreamHandle
 	}
 	handler.allCodeModes = allCodeModes
 	handler.encoder = encoders
+	if cfg.AZSelect.Enable {
+		handler.azSelector = newAZSelector(cfg.AZSelect, cfg.IDC)
+	}
+	if cfg.ReadRepair.Enable {
+		handler.readRepairLimiter = newReadRepairLimiter(&handler.ReadRepair)
+	}
 	if maxSize < handler.maxObjectSize {
 		handler.maxObjectSize = maxSize
 	}
@@ -354,16 +414,41 @@ func NewStreamHandler(cfg *StreamConfig, stopCh <-chan struct{}) (h StreamHandle
 	hystrix.ConfigureCommand(rwCommand, cfg.RWCommandConfig)
 
 	handler.discardVidChan = make(chan discardVid, 8)
+	handler.transitionLimiter = make(chan struct{}, cfg.TransitionConcurrency)
 	handler.stopCh = stopCh
 	handler.loopDiscardVids()
+	if cfg.ShardnodeConfig != nil {
+		if cfg.BlobEventEnable {
+			handler.blobEventCh = make(chan blobEventTask, cfg.BlobEventQueueSize)
+			handler.loopBlobEvent()
+		}
+		if cfg.TrashRetentionS > 0 {
+			handler.loopPurgeTrash()
+		}
+	}
 	return handler, nil
 }
 
 // Delete delete all blobs in this location
 func (h *Handler) Delete(ctx context.Context, location *proto.Location) error {
+	return h.delete(ctx, location, 0)
+}
+
+// delete releases the units of location after delay seconds if it is not referred by dedup
+func (h *Handler) delete(ctx context.Context, location *proto.Location, delayS int64) error {
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("to delete %+v", location)
-	return h.clearGarbage(ctx, location)
+	if h.DedupEnable {
+		free, err := h.dedupDelete(ctx, location)
+		if err != nil {
+			return err
+		}
+		if !free {
+			span.Infof("location is still referred by dedup %+v", location)
+			return nil
+		}
+	}
+	return h.clearGarbage(ctx, location, delayS)
 }
 
 // Admin returns internal admin interface.
@@ -429,7 +514,7 @@ func (h *Handler) sendRepairMsg(ctx context.Context, blob blobIdent, badIdxes []
 	span.Infof("send repair message(%+v)", repairArgs)
 }
 
-func (h *Handler) clearGarbage(ctx context.Context, location *proto.Location) error {
+func (h *Handler) clearGarbage(ctx context.Context, location *proto.Location, delayS int64) error {
 	span := trace.SpanFromContextSafe(ctx)
 	serviceController, err := h.clusterController.GetServiceController(location.ClusterID)
 	if err != nil {
@@ -441,6 +526,7 @@ func (h *Handler) clearGarbage(ctx context.Context, location *proto.Location) er
 	deleteArgs := &proxy.DeleteArgs{
 		ClusterID: location.ClusterID,
 		Blobs:     make([]proxy.BlobDelete, 0, len(blobs)),
+		DelayS:    delayS,
 	}
 
 	for _, blob := range blobs {
@@ -495,6 +581,13 @@ func (h *Handler) getVolume(ctx context.Context, clusterID proto.ClusterID, vid
 	return volume, nil
 }
 
+// getReadVolume get volume info for reading, the cached volume may be stale in bounded
+// expiration, re-fetch the latest one if read-after-write consistency is required in context
+func (h *Handler) getReadVolume(ctx context.Context, clusterID proto.ClusterID, vid proto.Vid) (*controller.VolumePhy, error) {
+	isCache := access.ConsistencyLevelFromContext(ctx) != access.ConsistencyReadAfterWrite
+	return h.getVolume(ctx, clusterID, vid, isCache)
+}
+
 func (h *Handler) updateVolume(ctx context.Context, clusterID proto.ClusterID, vid proto.Vid) {
 	volumeGetter, err := h.clusterController.GetVolumeGetter(clusterID)
 	if err != nil {
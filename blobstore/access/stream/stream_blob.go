@@ -8,6 +8,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/access/controller"
 	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -21,6 +22,18 @@ func (h *Handler) GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (*proto.
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("get blob args:%+v", *args)
 
+	// read the latest blob from leader of shard
+	if args.Consistency == acapi.ConsistencyReadAfterWrite {
+		args.Mode = acapi.GetShardModeLeader
+	}
+
+	blob, err := h.getBlob(ctx, args)
+	return &blob.Location, err
+}
+
+func (h *Handler) getBlob(ctx context.Context, args *acapi.GetBlobArgs) (proto.Blob, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
 	var blob shardnode.GetBlobRet
 	rerr := retry.ExponentialBackoff(3, 200).RuptOn(func() (bool, error) {
 		header, err := h.getShardOpHeader(ctx, &acapi.GetShardCommonArgs{
@@ -58,7 +71,7 @@ func (h *Handler) GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (*proto.
 	if rerr != nil {
 		span.Errorf("get blob failed, args:%+v, err:%+v", *args, rerr)
 	}
-	return &blob.Blob.Location, rerr
+	return blob.Blob, rerr
 }
 
 func (h *Handler) CreateBlob(ctx context.Context, args *acapi.CreateBlobArgs) (*proto.Location, error) {
@@ -93,6 +106,8 @@ func (h *Handler) CreateBlob(ctx context.Context, args *acapi.CreateBlobArgs) (*
 			CodeMode:  args.CodeMode,
 			Size_:     args.Size,
 			SliceSize: args.SliceSize,
+			Codec:     args.Codec,
+			RawSize:   args.RawSize,
 		})
 		if err != nil {
 			return h.punishAndUpdate(ctx, &punishArgs{
@@ -116,6 +131,10 @@ func (h *Handler) DeleteBlob(ctx context.Context, args *acapi.DelBlobArgs) error
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("delete blob args:%+v", *args)
 
+	if h.TrashRetentionS > 0 {
+		return h.trashBlob(ctx, args)
+	}
+
 	var blob shardnode.GetBlobRet
 	rerr := retry.ExponentialBackoff(3, 200).RuptOn(func() (bool, error) {
 		header, err := h.getShardOpHeader(ctx, &acapi.GetShardCommonArgs{
@@ -155,9 +174,104 @@ func (h *Handler) DeleteBlob(ctx context.Context, args *acapi.DelBlobArgs) error
 		return rerr
 	}
 
+	h.sendBlobEventBg(ctx, args.ClusterID, proxy.BlobEvent{
+		Type:     proxy.BlobEventDelete,
+		BlobName: args.BlobName,
+		Location: &blob.Blob.Location,
+	})
 	return h.Delete(ctx, &blob.Blob.Location)
 }
 
+// trashBlob moves blob into trash at shardnode, the data will be deleted by purge loop after expired
+func (h *Handler) trashBlob(ctx context.Context, args *acapi.DelBlobArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	expireTime := time.Now().Add(time.Duration(h.TrashRetentionS) * time.Second).Unix()
+	rerr := retry.ExponentialBackoff(3, 200).RuptOn(func() (bool, error) {
+		header, err := h.getShardOpHeader(ctx, &acapi.GetShardCommonArgs{
+			ClusterID: args.ClusterID,
+			BlobName:  args.BlobName,
+			Mode:      acapi.GetShardModeLeader,
+			ShardKeys: args.ShardKeys,
+		})
+		if err != nil {
+			return true, err
+		}
+
+		host, err := h.getShardHost(ctx, args.ClusterID, header.DiskID)
+		if err != nil {
+			return true, err
+		}
+
+		err = h.shardnodeClient.TrashBlob(ctx, host, shardnode.TrashBlobArgs{
+			Header:     header,
+			Name:       args.BlobName,
+			ExpireTime: expireTime,
+		})
+		if err != nil {
+			return h.punishAndUpdate(ctx, &punishArgs{
+				ShardOpHeader: header,
+				clusterID:     args.ClusterID,
+				host:          host,
+				mode:          acapi.GetShardModeLeader,
+				err:           err,
+			})
+		}
+		return true, nil
+	})
+
+	if rerr != nil {
+		span.Errorf("trash blob failed, args:%+v, err:%+v", *args, rerr)
+		return rerr
+	}
+	h.sendBlobEventBg(ctx, args.ClusterID, proxy.BlobEvent{Type: proxy.BlobEventDelete, BlobName: args.BlobName})
+	return nil
+}
+
+func (h *Handler) UndeleteBlob(ctx context.Context, args *acapi.UndeleteBlobArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debugf("undelete blob args:%+v", *args)
+
+	rerr := retry.ExponentialBackoff(3, 200).RuptOn(func() (bool, error) {
+		header, err := h.getShardOpHeader(ctx, &acapi.GetShardCommonArgs{
+			ClusterID: args.ClusterID,
+			BlobName:  args.BlobName,
+			Mode:      acapi.GetShardModeLeader,
+			ShardKeys: args.ShardKeys,
+		})
+		if err != nil {
+			return true, err
+		}
+
+		host, err := h.getShardHost(ctx, args.ClusterID, header.DiskID)
+		if err != nil {
+			return true, err
+		}
+
+		_, err = h.shardnodeClient.UndeleteBlob(ctx, host, shardnode.UndeleteBlobArgs{
+			Header: header,
+			Name:   args.BlobName,
+		})
+		if err != nil {
+			return h.punishAndUpdate(ctx, &punishArgs{
+				ShardOpHeader: header,
+				clusterID:     args.ClusterID,
+				host:          host,
+				mode:          acapi.GetShardModeLeader,
+				err:           err,
+			})
+		}
+		return true, nil
+	})
+
+	if rerr != nil {
+		span.Errorf("undelete blob failed, args:%+v, err:%+v", *args, rerr)
+		return rerr
+	}
+	h.sendBlobEventBg(ctx, args.ClusterID, proxy.BlobEvent{Type: proxy.BlobEventPut, BlobName: args.BlobName})
+	return nil
+}
+
 func (h *Handler) SealBlob(ctx context.Context, args *acapi.SealBlobArgs) error {
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("seal blob args:%+v", *args)
@@ -198,8 +312,10 @@ func (h *Handler) SealBlob(ctx context.Context, args *acapi.SealBlobArgs) error
 
 	if rerr != nil {
 		span.Errorf("seal blob failed, args:%+v, err:%+v", *args, rerr)
+		return rerr
 	}
-	return rerr
+	h.sendBlobEventBg(ctx, args.ClusterID, proxy.BlobEvent{Type: proxy.BlobEventPut, BlobName: args.BlobName})
+	return nil
 }
 
 func (h *Handler) ListBlob(ctx context.Context, args *acapi.ListBlobArgs) (ret shardnode.ListBlobRet, err error) {
@@ -380,6 +496,7 @@ func (h *Handler) listSingleShardEnough(ctx context.Context, args *acapi.ListBlo
 		Prefix: args.Prefix,
 		Marker: args.Marker,
 		Count:  args.Count,
+		Vid:    args.Vid,
 	})
 	if err != nil {
 		interrupt, err1 := h.punishAndUpdate(ctx, &punishArgs{
@@ -444,6 +561,7 @@ func (h *Handler) getOpHeaderByShard(ctx context.Context, shardMgr controller.IS
 		Suid:         info.Suid,
 		RouteVersion: info.RouteVersion,
 		ShardKeys:    shardKeys, // don't need shardKeys when list blob, other required
+		LeaderTerm:   info.LeaderTerm,
 	}
 
 	span.Debugf("shard op header: %+v", oh)
@@ -516,12 +634,17 @@ func (h *Handler) punishAndUpdate(ctx context.Context, args *punishArgs) (bool,
 		return false, args.err
 
 		// select master
-	case errcode.CodeShardNodeNotLeader: // leader disk id error when create/delete/seal
+	case errcode.CodeShardNodeNotLeader, // leader disk id error when create/delete/seal
+		errcode.CodeShardFenced: // leader term mismatch, leader changed or the shardnode is stale leader
 		if err1 := h.updateShard(ctx, args); err1 != nil {
 			span.Warnf("fail to update shard, cluster:%d, err:%+v", args.clusterID, err1)
 		}
 		return false, args.err
 
+		// retry later with backoff
+	case errcode.CodeShardNodeWriteStall: // writes of shardnode are stopped, wait flush or compaction catch up
+		return false, args.err
+
 	default:
 	}
 
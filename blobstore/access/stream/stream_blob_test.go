@@ -7,13 +7,16 @@ import (
 	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cubefs/cubefs/blobstore/access/controller"
 	acapi "github.com/cubefs/cubefs/blobstore/api/access"
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
@@ -38,6 +41,7 @@ func newStreamHandlerSuccess(t *testing.T) *Handler {
 	shardMgr := NewMockShardController(ctr)
 	shardMgr.EXPECT().GetShard(gAny, gAny).Return(shardInfo, nil).AnyTimes()
 	shardMgr.EXPECT().GetSpaceID().Return(proto.SpaceID(1)).AnyTimes()
+	shardMgr.EXPECT().GetWormRetention().Return(time.Duration(0)).AnyTimes()
 	shardMgr.EXPECT().UpdateRoute(gAny).Return(nil).AnyTimes()
 
 	svrCtrl := NewMockServiceController(ctr)
@@ -197,6 +201,72 @@ func TestStreamBlobDelete(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestStreamBlobTrash(t *testing.T) {
+	ctx := context.Background()
+	gAny := gomock.Any()
+	h := newStreamHandlerSuccess(t)
+	h.TrashRetentionS = 3600
+
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().TrashBlob(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.TrashBlobArgs) error {
+			require.Equal(t, []byte("blob-del"), args.Name)
+			require.Less(t, time.Now().Unix(), args.ExpireTime)
+			return nil
+		})
+	err := h.DeleteBlob(ctx, &acapi.DelBlobArgs{BlobName: []byte("blob-del"), ClusterID: 1})
+	require.NoError(t, err)
+
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().UndeleteBlob(gAny, gAny, gAny).Return(shardnode.UndeleteBlobRet{}, errcode.ErrBlobNotInTrash)
+	err = h.UndeleteBlob(ctx, &acapi.UndeleteBlobArgs{BlobName: []byte("blob-del"), ClusterID: 1})
+	require.ErrorIs(t, err, errcode.ErrBlobNotInTrash)
+}
+
+func TestStreamBlobPurgeTrash(t *testing.T) {
+	ctr := gomock.NewController(t)
+	gAny := gomock.Any()
+
+	shard := NewMockShard(ctr)
+	shard.EXPECT().GetMember(gAny, gAny, gAny).Return(controller.ShardOpInfo{DiskID: 101}, nil)
+	shard.EXPECT().GetRange().Return(sharding.Range{})
+	shardMgr := NewMockShardController(ctr)
+	shardMgr.EXPECT().GetSpaceID().Return(proto.SpaceID(1))
+	shardMgr.EXPECT().GetFisrtShard(gAny).Return(shard, nil)
+	shardMgr.EXPECT().GetNextShard(gAny, gAny).Return(nil, nil)
+
+	svrCtrl := NewMockServiceController(ctr)
+	svrCtrl.EXPECT().GetShardnodeHost(gAny, gAny).Return(&controller.HostIDC{Host: "host"}, nil)
+	svrCtrl.EXPECT().GetServiceHost(gAny, gAny).Return("host", nil)
+	clu := NewMockClusterController(ctr)
+	clu.EXPECT().GetShardController(gAny).Return(shardMgr, nil)
+	clu.EXPECT().GetServiceController(gAny).Return(svrCtrl, nil).Times(2)
+	h := &Handler{
+		clusterController: clu,
+		shardnodeClient:   mocks.NewMockShardnodeAccess(ctr),
+		proxyClient:       mocks.NewMockProxyClient(ctr),
+	}
+
+	now := time.Now().Unix()
+	expired := shardnode.TrashBlob{
+		Blob:       proto.Blob{Name: []byte("expired"), Location: proto.Location{ClusterID: 1, CodeMode: codemode.EC3P3}},
+		ExpireTime: now - 1,
+	}
+	alive := shardnode.TrashBlob{
+		Blob:       proto.Blob{Name: []byte("alive")},
+		ExpireTime: now + 3600,
+	}
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().ListTrashBlob(gAny, gAny, gAny).Return(
+		shardnode.ListTrashBlobRet{Blobs: []shardnode.TrashBlob{expired, alive}}, nil)
+	h.proxyClient.(*mocks.MockProxyClient).EXPECT().SendDeleteMsg(gAny, gAny, gAny).Return(nil)
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().PurgeTrashBlob(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.PurgeTrashBlobArgs) error {
+			require.Equal(t, expired.Blob.Name, args.Name)
+			require.Equal(t, [][]byte{expired.Blob.Name}, args.Header.ShardKeys)
+			return nil
+		})
+
+	h.purgeClusterTrash(1)
+}
+
 func TestStreamBlobSeal(t *testing.T) {
 	ctx := context.Background()
 	gAny := gomock.Any()
@@ -216,6 +286,77 @@ func TestStreamBlobSeal(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestStreamBlobEvent(t *testing.T) {
+	ctx := context.Background()
+	ctr := gomock.NewController(t)
+	gAny := gomock.Any()
+	h := newStreamHandlerSuccess(t)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	h.stopCh = stopCh
+	h.BlobEventEnable = true
+	h.BlobEventConcurrency = 1
+	h.blobEventCh = make(chan blobEventTask, 1)
+
+	shardInfo := NewMockShard(ctr)
+	shardInfo.EXPECT().GetMember(gAny, gAny, gAny).Return(controller.ShardOpInfo{DiskID: 101}, nil).AnyTimes()
+	shardMgr := NewMockShardController(ctr)
+	shardMgr.EXPECT().GetShard(gAny, gAny).Return(shardInfo, nil).AnyTimes()
+	shardMgr.EXPECT().GetSpaceID().Return(proto.SpaceID(1)).AnyTimes()
+	shardMgr.EXPECT().UpdateRoute(gAny).Return(nil).AnyTimes()
+	svrCtrl := NewMockServiceController(ctr)
+	svrCtrl.EXPECT().GetShardnodeHost(gAny, gAny).Return(&controller.HostIDC{Host: "host"}, nil).AnyTimes()
+	svrCtrl.EXPECT().GetServiceHost(gAny, gAny).Return("host", nil).AnyTimes()
+	clu := NewMockClusterController(ctr)
+	clu.EXPECT().GetShardController(gAny).Return(shardMgr, nil).AnyTimes()
+	clu.EXPECT().GetServiceController(gAny).Return(svrCtrl, nil).AnyTimes()
+	h.clusterController = clu
+
+	events := make(chan proxy.BlobEvent, 4)
+	h.proxyClient.(*mocks.MockProxyClient).EXPECT().SendBlobEvent(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, host string, args *proxy.BlobEventArgs) error {
+			require.Equal(t, "host", host)
+			require.Equal(t, proto.ClusterID(1), args.ClusterID)
+			require.Equal(t, 1, len(args.Events))
+			events <- args.Events[0]
+			return nil
+		}).Times(3)
+	checkEvent := func(typ proxy.BlobEventType, name string) {
+		select {
+		case event := <-events:
+			require.Equal(t, typ, event.Type)
+			require.Equal(t, proto.SpaceID(1), event.SpaceID)
+			require.Equal(t, []byte(name), event.BlobName)
+			require.NotZero(t, event.Time)
+		case <-time.After(5 * time.Second):
+			t.Fatal("wait blob event timeout")
+		}
+	}
+
+	// dropped if the queue is full
+	dropped := testutil.ToFloat64(blobEventDropMetric.WithLabelValues("1", string(proxy.BlobEventPut), "queue_full"))
+	h.sendBlobEventBg(ctx, 1, proxy.BlobEvent{Type: proxy.BlobEventPut, BlobName: []byte("blob-queued")})
+	h.sendBlobEventBg(ctx, 1, proxy.BlobEvent{Type: proxy.BlobEventPut, BlobName: []byte("blob-dropped")})
+	require.Equal(t, dropped+1, testutil.ToFloat64(blobEventDropMetric.WithLabelValues("1", string(proxy.BlobEventPut), "queue_full")))
+	h.loopBlobEvent()
+	checkEvent(proxy.BlobEventPut, "blob-queued")
+
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().SealBlob(gAny, gAny, gAny).Return(nil)
+	err := h.SealBlob(ctx, &acapi.SealBlobArgs{BlobName: []byte("blob-seal"), ClusterID: 1, Slices: make([]proto.Slice, 1)})
+	require.NoError(t, err)
+	checkEvent(proxy.BlobEventPut, "blob-seal")
+
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().UndeleteBlob(gAny, gAny, gAny).Return(shardnode.UndeleteBlobRet{}, nil)
+	err = h.UndeleteBlob(ctx, &acapi.UndeleteBlobArgs{BlobName: []byte("blob-undelete"), ClusterID: 1})
+	require.NoError(t, err)
+	checkEvent(proxy.BlobEventPut, "blob-undelete")
+
+	// no event if seal failed
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().SealBlob(gAny, gAny, gAny).Return(errcode.ErrUnexpected)
+	err = h.SealBlob(ctx, &acapi.SealBlobArgs{BlobName: []byte("blob-seal"), ClusterID: 1, Slices: make([]proto.Slice, 1)})
+	require.Error(t, err)
+}
+
 func TestStreamBlobList(t *testing.T) {
 	ctx := context.Background()
 	gAny := gomock.Any()
@@ -434,11 +575,11 @@ func TestStreamBlobOther(t *testing.T) {
 
 	shardMgr := NewMockShardController(ctr)
 	shardMgr.EXPECT().UpdateRoute(gAny).Return(nil).Times(2)
-	shardMgr.EXPECT().UpdateShard(gAny, gAny).Return(nil)
+	shardMgr.EXPECT().UpdateShard(gAny, gAny).Return(nil).Times(2)
 
 	clu := NewMockClusterController(ctr)
 	clu.EXPECT().GetServiceController(gAny).Return(svrCtrl, nil).Times(2)
-	clu.EXPECT().GetShardController(gAny).Return(shardMgr, nil).Times(3)
+	clu.EXPECT().GetShardController(gAny).Return(shardMgr, nil).Times(4)
 
 	h := &Handler{
 		clusterController: clu,
@@ -462,8 +603,15 @@ func TestStreamBlobOther(t *testing.T) {
 	require.Equal(t, false, interrupt)
 	require.ErrorIs(t, err1, io.EOF)
 
+	// write stall, retry later without punish
+	interrupt, err1 = h.punishAndUpdate(ctx, &punishArgs{
+		err: errcode.ErrShardNodeWriteStall,
+	})
+	require.Equal(t, false, interrupt)
+	require.ErrorIs(t, err1, errcode.ErrShardNodeWriteStall)
+
 	shardnodeClient := mocks.NewMockShardnodeAccess(ctr)
-	shardnodeClient.EXPECT().GetShardStats(gAny, gAny, gAny).Return(shardnode.ShardStats{LeaderDiskID: 11}, nil).Times(1)
+	shardnodeClient.EXPECT().GetShardStats(gAny, gAny, gAny).Return(shardnode.ShardStats{LeaderDiskID: 11}, nil).Times(2)
 	h.shardnodeClient = shardnodeClient
 	h.ShardnodeRetryTimes = defaultShardnodeRetryTimes
 	interrupt, err1 = h.punishAndUpdate(ctx, &punishArgs{
@@ -472,6 +620,13 @@ func TestStreamBlobOther(t *testing.T) {
 	require.Equal(t, false, interrupt)
 	require.ErrorIs(t, err1, errcode.ErrShardNodeNotLeader)
 
+	// fenced by leader term, update the leader
+	interrupt, err1 = h.punishAndUpdate(ctx, &punishArgs{
+		err: errcode.ErrShardFenced,
+	})
+	require.Equal(t, false, interrupt)
+	require.ErrorIs(t, err1, errcode.ErrShardFenced)
+
 	// broken disk
 	interrupt, err1 = h.punishAndUpdate(ctx, &punishArgs{
 		ShardOpHeader: shardnode.ShardOpHeader{},
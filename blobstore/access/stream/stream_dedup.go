@@ -0,0 +1,235 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+	"github.com/cubefs/cubefs/blobstore/util/retry"
+)
+
+// dedup index at shardnode of each cluster:
+//
+//	h-[sha256]           --> ref of the content location, referred by each put of the content
+//	l-[cid]-[vid]-[bid]  --> ref of the content hash, deleted after the content is freed
+//
+// the reference of put is the location key of the first slice it uploaded, each reference
+// is saved as a single key at shardnode, so that retried ref of the same put takes effect only once.
+// the location of exist content is returned unchanged, delete unrefers one of the references,
+// and the content is freed only if the last reference is unreferred.
+
+// dedupUnrefTimes max times of unref when the reference is unreferred by concurrent delete
+const dedupUnrefTimes = 3
+
+var errDedupUnrefConflict = errors.New("dedup reference is unreferred concurrently")
+
+func dedupHashKey(hash []byte) []byte {
+	return []byte("h-" + hex.EncodeToString(hash))
+}
+
+func dedupLocationKey(location *proto.Location) []byte {
+	if len(location.Slices) == 0 {
+		return nil
+	}
+	slice := location.Slices[0]
+	return []byte(fmt.Sprintf("l-%d-%d-%d", location.ClusterID, slice.Vid, slice.MinSliceID))
+}
+
+// dedupPut refers the content hash of the location just uploaded,
+// returns the exist location if the same content has been uploaded.
+func (h *Handler) dedupPut(ctx context.Context, location *proto.Location, hash []byte) (*proto.Location, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	// refer location firstly, the orphan location key is harmless
+	locationKey := dedupLocationKey(location)
+	if _, err := h.refDedup(ctx, location.ClusterID, locationKey, hash, location, locationKey); err != nil {
+		return nil, err
+	}
+
+	ref, err := h.refDedup(ctx, location.ClusterID, dedupHashKey(hash), hash, location, locationKey)
+	if err == nil && bytes.Equal(dedupLocationKey(&ref.Location), locationKey) {
+		return location, nil
+	}
+
+	if _, _, errUnref := h.unrefDedup(ctx, location.ClusterID, locationKey, locationKey, location); errUnref != nil &&
+		rpc.DetectStatusCode(errUnref) != errcode.CodeKeyNotFound {
+		span.Warnf("unref dedup location key %s failed, err:%s", locationKey, errors.Detail(errUnref))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	loc := ref.Location.Copy()
+	return &loc, nil
+}
+
+// dedupDelete unrefers one reference of the content hash of the location,
+// returns true if the last reference is unreferred and the location can be freed.
+func (h *Handler) dedupDelete(ctx context.Context, location *proto.Location) (bool, error) {
+	locationKey := dedupLocationKey(location)
+	if locationKey == nil {
+		return true, nil
+	}
+
+	locationRef, err := h.getDedup(ctx, location.ClusterID, locationKey)
+	if err != nil {
+		if rpc.DetectStatusCode(err) == errcode.CodeKeyNotFound {
+			return true, nil // not uploaded with dedup, or has been freed
+		}
+		return false, err
+	}
+
+	free, err := h.unrefDedupHash(ctx, location, dedupHashKey(locationRef.Ref.Hash))
+	if err != nil || !free {
+		return false, err
+	}
+
+	_, _, err = h.unrefDedup(ctx, location.ClusterID, locationKey, locationKey, location)
+	if err != nil && rpc.DetectStatusCode(err) != errcode.CodeKeyNotFound {
+		return false, err
+	}
+	return true, nil
+}
+
+// unrefDedupHash unrefers one of the references of hash key, which is conditioned on the location
+// at shardnode, returns true if the hash key does not refer to the location any more.
+func (h *Handler) unrefDedupHash(ctx context.Context, location *proto.Location, hashKey []byte) (bool, error) {
+	for i := 0; i < dedupUnrefTimes; i++ {
+		got, err := h.getDedup(ctx, location.ClusterID, hashKey)
+		if err != nil {
+			if rpc.DetectStatusCode(err) == errcode.CodeKeyNotFound {
+				return true, nil
+			}
+			return false, err
+		}
+		if !bytes.Equal(dedupLocationKey(&got.Ref.Location), dedupLocationKey(location)) || len(got.FirstRef) == 0 {
+			return true, nil
+		}
+
+		ret, proposed, err := h.unrefDedup(ctx, location.ClusterID, hashKey, got.FirstRef, location)
+		if err != nil {
+			if rpc.DetectStatusCode(err) == errcode.CodeKeyNotFound {
+				return true, nil
+			}
+			return false, err
+		}
+		if ret.Last {
+			return true, nil
+		}
+		// the reference may be unreferred by the former request whose response is lost
+		if ret.Unreferred || proposed > 1 {
+			return false, nil
+		}
+		// the reference has been unreferred by concurrent delete, unrefers the next one
+	}
+	return false, errDedupUnrefConflict
+}
+
+func (h *Handler) refDedup(ctx context.Context, clusterID proto.ClusterID, key, hash []byte,
+	location *proto.Location, refKey []byte,
+) (ref shardnode.DedupRef, err error) {
+	err = h.doDedup(ctx, clusterID, key, func(host string, header shardnode.ShardOpHeader) error {
+		ret, err := h.shardnodeClient.RefDedup(ctx, host, shardnode.RefDedupArgs{
+			Header:   header,
+			Key:      key,
+			Hash:     hash,
+			Location: *location,
+			Ref:      refKey,
+		})
+		ref = ret.Ref
+		return err
+	})
+	return
+}
+
+func (h *Handler) getDedup(ctx context.Context, clusterID proto.ClusterID, key []byte) (ret shardnode.GetDedupRet, err error) {
+	err = h.doDedup(ctx, clusterID, key, func(host string, header shardnode.ShardOpHeader) error {
+		ret, err = h.shardnodeClient.GetDedup(ctx, host, shardnode.GetDedupArgs{
+			Header: header,
+			Key:    key,
+		})
+		return err
+	})
+	return
+}
+
+// unrefDedup unrefers the reference of key, proposed is the times of unref requests
+func (h *Handler) unrefDedup(ctx context.Context, clusterID proto.ClusterID, key, refKey []byte,
+	location *proto.Location,
+) (ret shardnode.UnrefDedupRet, proposed int, err error) {
+	err = h.doDedup(ctx, clusterID, key, func(host string, header shardnode.ShardOpHeader) error {
+		proposed++
+		ret, err = h.shardnodeClient.UnrefDedup(ctx, host, shardnode.UnrefDedupArgs{
+			Header:   header,
+			Key:      key,
+			Ref:      refKey,
+			Location: *location,
+		})
+		return err
+	})
+	return
+}
+
+// doDedup runs dedup operation on leader of the shard which the key belongs to
+func (h *Handler) doDedup(ctx context.Context, clusterID proto.ClusterID, key []byte,
+	fn func(host string, header shardnode.ShardOpHeader) error,
+) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	rerr := retry.ExponentialBackoff(3, 200).RuptOn(func() (bool, error) {
+		header, err := h.getShardOpHeader(ctx, &acapi.GetShardCommonArgs{
+			ClusterID: clusterID,
+			Mode:      acapi.GetShardModeLeader,
+			ShardKeys: [][]byte{key},
+		})
+		if err != nil {
+			return true, err
+		}
+
+		host, err := h.getShardHost(ctx, clusterID, header.DiskID)
+		if err != nil {
+			return true, err
+		}
+
+		if err = fn(host, header); err != nil {
+			if rpc.DetectStatusCode(err) == errcode.CodeKeyNotFound {
+				return true, err
+			}
+			return h.punishAndUpdate(ctx, &punishArgs{
+				ShardOpHeader: header,
+				clusterID:     clusterID,
+				host:          host,
+				mode:          acapi.GetShardModeLeader,
+				err:           err,
+			})
+		}
+		return true, nil
+	})
+
+	if rerr != nil && rpc.DetectStatusCode(rerr) != errcode.CodeKeyNotFound {
+		span.Warnf("dedup key %s failed, err:%s", key, errors.Detail(rerr))
+	}
+	return rerr
+}
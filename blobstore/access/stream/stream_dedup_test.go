@@ -0,0 +1,203 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/access/controller"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
+)
+
+func newStreamHandlerDedup(t *testing.T) (*Handler, *mocks.MockShardnodeAccess) {
+	ctr := gomock.NewController(t)
+	gAny := gomock.Any()
+
+	shard := NewMockShard(ctr)
+	shard.EXPECT().GetMember(gAny, gAny, gAny).Return(controller.ShardOpInfo{DiskID: 101}, nil).AnyTimes()
+	shardMgr := NewMockShardController(ctr)
+	shardMgr.EXPECT().GetShard(gAny, gAny).Return(shard, nil).AnyTimes()
+	shardMgr.EXPECT().GetSpaceID().Return(proto.SpaceID(1)).AnyTimes()
+
+	svrCtrl := NewMockServiceController(ctr)
+	svrCtrl.EXPECT().GetShardnodeHost(gAny, gAny).Return(&controller.HostIDC{Host: "host"}, nil).AnyTimes()
+	clu := NewMockClusterController(ctr)
+	clu.EXPECT().GetShardController(gAny).Return(shardMgr, nil).AnyTimes()
+	clu.EXPECT().GetServiceController(gAny).Return(svrCtrl, nil).AnyTimes()
+
+	shardCli := mocks.NewMockShardnodeAccess(ctr)
+	h := &Handler{
+		clusterController: clu,
+		shardnodeClient:   shardCli,
+	}
+	h.DedupEnable = true
+	return h, shardCli
+}
+
+func TestStreamDedupPut(t *testing.T) {
+	ctx := context.Background()
+	gAny := gomock.Any()
+	h, shardCli := newStreamHandlerDedup(t)
+
+	hash := []byte{0x1f, 0xff}
+	location := &proto.Location{ClusterID: 1, Size_: 10, Slices: []proto.Slice{{MinSliceID: 100, Vid: 10, Count: 1}}}
+	exist := proto.Location{ClusterID: 1, Size_: 10, Slices: []proto.Slice{{MinSliceID: 1, Vid: 1, Count: 1}}}
+
+	// new content
+	shardCli.EXPECT().RefDedup(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.RefDedupArgs) (shardnode.RefDedupRet, error) {
+			require.Equal(t, dedupLocationKey(location), args.Key)
+			require.Equal(t, [][]byte{args.Key}, args.Header.ShardKeys)
+			return shardnode.RefDedupRet{Ref: shardnode.DedupRef{Hash: hash, Location: *location, RefCount: 1}}, nil
+		})
+	shardCli.EXPECT().RefDedup(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.RefDedupArgs) (shardnode.RefDedupRet, error) {
+			require.Equal(t, dedupHashKey(hash), args.Key)
+			require.Equal(t, dedupLocationKey(location), args.Ref)
+			return shardnode.RefDedupRet{Ref: shardnode.DedupRef{Hash: hash, Location: *location, RefCount: 1}}, nil
+		})
+	loc, err := h.dedupPut(ctx, location, hash)
+	require.NoError(t, err)
+	require.True(t, loc == location)
+
+	// exist content
+	shardCli.EXPECT().RefDedup(gAny, gAny, gAny).Return(shardnode.RefDedupRet{}, nil)
+	shardCli.EXPECT().RefDedup(gAny, gAny, gAny).Return(
+		shardnode.RefDedupRet{Ref: shardnode.DedupRef{Hash: hash, Location: exist, RefCount: 2}}, nil)
+	shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.UnrefDedupArgs) (shardnode.UnrefDedupRet, error) {
+			require.Equal(t, dedupLocationKey(location), args.Key)
+			require.Equal(t, dedupLocationKey(location), args.Ref)
+			require.Equal(t, *location, args.Location)
+			return shardnode.UnrefDedupRet{Unreferred: true, Last: true}, nil
+		})
+	loc, err = h.dedupPut(ctx, location, hash)
+	require.NoError(t, err)
+	// the exist location returns unchanged
+	require.Equal(t, exist, *loc)
+
+	// failed to refer hash
+	errMock := errors.New("fake error")
+	shardCli.EXPECT().RefDedup(gAny, gAny, gAny).Return(shardnode.RefDedupRet{}, nil)
+	shardCli.EXPECT().RefDedup(gAny, gAny, gAny).Return(shardnode.RefDedupRet{}, errMock).Times(3)
+	shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).Return(shardnode.UnrefDedupRet{}, nil)
+	_, err = h.dedupPut(ctx, location, hash)
+	require.ErrorIs(t, err, errMock)
+}
+
+func TestStreamDedupDelete(t *testing.T) {
+	ctx := context.Background()
+	gAny := gomock.Any()
+	h, shardCli := newStreamHandlerDedup(t)
+
+	hash := []byte{0x1f, 0xff}
+	location := &proto.Location{ClusterID: 1, Size_: 10, Slices: []proto.Slice{{MinSliceID: 100, Vid: 10, Count: 1}}}
+	other := proto.Location{ClusterID: 1, Size_: 10, Slices: []proto.Slice{{MinSliceID: 200, Vid: 20, Count: 1}}}
+	getLocation := func() {
+		shardCli.EXPECT().GetDedup(gAny, gAny, gAny).DoAndReturn(
+			func(_ context.Context, _ string, args shardnode.GetDedupArgs) (shardnode.GetDedupRet, error) {
+				require.Equal(t, dedupLocationKey(location), args.Key)
+				return shardnode.GetDedupRet{Ref: shardnode.DedupRef{Hash: hash, Location: *location, RefCount: 1}}, nil
+			})
+	}
+	getHash := func(loc proto.Location, firstRef string) {
+		shardCli.EXPECT().GetDedup(gAny, gAny, gAny).DoAndReturn(
+			func(_ context.Context, _ string, args shardnode.GetDedupArgs) (shardnode.GetDedupRet, error) {
+				require.Equal(t, dedupHashKey(hash), args.Key)
+				return shardnode.GetDedupRet{
+					Ref:      shardnode.DedupRef{Hash: hash, Location: loc, RefCount: 2},
+					FirstRef: []byte(firstRef),
+				}, nil
+			})
+	}
+	unrefLocation := func() {
+		shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).DoAndReturn(
+			func(_ context.Context, _ string, args shardnode.UnrefDedupArgs) (shardnode.UnrefDedupRet, error) {
+				require.Equal(t, dedupLocationKey(location), args.Key)
+				require.Equal(t, dedupLocationKey(location), args.Ref)
+				return shardnode.UnrefDedupRet{Unreferred: true, Last: true}, nil
+			})
+	}
+
+	// not uploaded with dedup
+	shardCli.EXPECT().GetDedup(gAny, gAny, gAny).Return(shardnode.GetDedupRet{}, errcode.ErrKeyNotFound)
+	free, err := h.dedupDelete(ctx, location)
+	require.NoError(t, err)
+	require.True(t, free)
+
+	// still referred
+	getLocation()
+	getHash(*location, "ref1")
+	shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.UnrefDedupArgs) (shardnode.UnrefDedupRet, error) {
+			require.Equal(t, dedupHashKey(hash), args.Key)
+			require.Equal(t, []byte("ref1"), args.Ref)
+			require.Equal(t, *location, args.Location)
+			return shardnode.UnrefDedupRet{Ref: shardnode.DedupRef{RefCount: 1}, Unreferred: true}, nil
+		})
+	free, err = h.dedupDelete(ctx, location)
+	require.NoError(t, err)
+	require.False(t, free)
+
+	// the reference has been unreferred by concurrent delete, unrefers the next one
+	getLocation()
+	getHash(*location, "ref1")
+	shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).Return(shardnode.UnrefDedupRet{Ref: shardnode.DedupRef{RefCount: 1}}, nil)
+	getHash(*location, "ref2")
+	shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.UnrefDedupArgs) (shardnode.UnrefDedupRet, error) {
+			require.Equal(t, []byte("ref2"), args.Ref)
+			return shardnode.UnrefDedupRet{Unreferred: true, Last: true}, nil
+		})
+	unrefLocation()
+	free, err = h.dedupDelete(ctx, location)
+	require.NoError(t, err)
+	require.True(t, free)
+
+	// unreferred concurrently all times
+	getLocation()
+	for range [dedupUnrefTimes]struct{}{} {
+		getHash(*location, "ref1")
+		shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).Return(shardnode.UnrefDedupRet{Ref: shardnode.DedupRef{RefCount: 1}}, nil)
+	}
+	free, err = h.dedupDelete(ctx, location)
+	require.ErrorIs(t, err, errDedupUnrefConflict)
+	require.False(t, free)
+
+	// the hash has been recreated with the other location
+	getLocation()
+	getHash(other, "ref1")
+	unrefLocation()
+	free, err = h.dedupDelete(ctx, location)
+	require.NoError(t, err)
+	require.True(t, free)
+
+	// the hash key of the location is missed at shardnode
+	getLocation()
+	getHash(*location, "ref1")
+	shardCli.EXPECT().UnrefDedup(gAny, gAny, gAny).Return(shardnode.UnrefDedupRet{}, errcode.ErrKeyNotFound)
+	unrefLocation()
+	free, err = h.dedupDelete(ctx, location)
+	require.NoError(t, err)
+	require.True(t, free)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+	"github.com/cubefs/cubefs/blobstore/util/retry"
+)
+
+type blobEventTask struct {
+	ctx       context.Context
+	clusterID proto.ClusterID
+	event     proxy.BlobEvent
+}
+
+// SendBlobEvent publishes lifecycle event of space blob with proxy in background.
+func (h *Handler) SendBlobEvent(ctx context.Context, clusterID proto.ClusterID, event proxy.BlobEvent) {
+	h.sendBlobEventBg(ctx, clusterID, event)
+}
+
+// sendBlobEventBg queues lifecycle event of space blob to the senders, the event is
+// dropped if the queue is full or it's failed after retries.
+func (h *Handler) sendBlobEventBg(ctx context.Context, clusterID proto.ClusterID, event proxy.BlobEvent) {
+	if !h.BlobEventEnable {
+		return
+	}
+	span := trace.SpanFromContextSafe(ctx)
+	shardMgr, err := h.clusterController.GetShardController(clusterID)
+	if err != nil {
+		reportBlobEventDrop(clusterID, event.Type, "no_space")
+		span.Warnf("blob event %s of %s dropped, err: %s", event.Type, event.BlobName, err.Error())
+		return
+	}
+	event.SpaceID = shardMgr.GetSpaceID()
+	event.Time = time.Now().Unix()
+
+	select {
+	case h.blobEventCh <- blobEventTask{ctx: trace.NewContextFromContext(ctx), clusterID: clusterID, event: event}:
+	default:
+		reportBlobEventDrop(clusterID, event.Type, "queue_full")
+		span.Warnf("blob event %s of %s dropped, queue is full", event.Type, event.BlobName)
+	}
+}
+
+func (h *Handler) loopBlobEvent() {
+	for i := 0; i < h.BlobEventConcurrency; i++ {
+		go func() {
+			for {
+				select {
+				case <-h.stopCh:
+					return
+				case task := <-h.blobEventCh:
+					h.sendBlobEvent(task.ctx, task.clusterID, task.event)
+				}
+			}
+		}()
+	}
+}
+
+func (h *Handler) sendBlobEvent(ctx context.Context, clusterID proto.ClusterID, event proxy.BlobEvent) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	serviceController, err := h.clusterController.GetServiceController(clusterID)
+	if err != nil {
+		reportBlobEventDrop(clusterID, event.Type, "no_service")
+		span.Errorf("blob event %s of %s dropped, err: %s", event.Type, event.BlobName, errors.Detail(err))
+		return
+	}
+
+	eventArgs := &proxy.BlobEventArgs{
+		ClusterID: clusterID,
+		Events:    []proxy.BlobEvent{event},
+	}
+	if err := retry.Timed(3, 200).On(func() error {
+		host, err := serviceController.GetServiceHost(ctx, serviceProxy)
+		if err != nil {
+			reportUnhealth(clusterID, "event.msg", serviceProxy, "-", "failed")
+			span.Warn(err)
+			return err
+		}
+		err = h.proxyClient.SendBlobEvent(ctx, host, eventArgs)
+		if err != nil {
+			if errorTimeout(err) || errorConnectionRefused(err) {
+				serviceController.PunishServiceWithThreshold(ctx, serviceProxy, host, h.ServicePunishIntervalS)
+				reportUnhealth(clusterID, "punish", serviceProxy, host, "failed")
+			} else {
+				reportUnhealth(clusterID, "event.msg", serviceProxy, host, "failed")
+			}
+			span.Warnf("send to %s blob event(%+v) %s", host, event, err.Error())
+			err = errors.Base(err, host)
+		}
+		return err
+	}); err != nil {
+		reportBlobEventDrop(clusterID, event.Type, "failed")
+		span.Errorf("blob event(%+v) dropped, send failed %s", event, errors.Detail(err))
+		return
+	}
+
+	span.Debugf("send blob event(%+v)", event)
+}
@@ -63,17 +63,20 @@ func (blob *blobGetArgs) ID() string {
 }
 
 type shardData struct {
-	index  int
-	status bool
-	buffer []byte
-	time   int
+	index   int
+	status  bool
+	missing bool // not found on blobnode
+	buffer  []byte
+	time    int
 }
 
 type sortedVuid struct {
-	index  int
-	vuid   proto.Vuid
-	diskID proto.DiskID
-	host   string
+	index    int
+	vuid     proto.Vuid
+	diskID   proto.DiskID
+	host     string
+	idc      string
+	punished bool
 }
 
 func (vuid *sortedVuid) ID() string {
@@ -185,7 +188,7 @@ func (h *Handler) Get(ctx context.Context, w io.Writer, location proto.Location,
 				for _, blob := range blobs {
 					var err error
 					if blobVolume == nil || blobVolume.Vid != blob.Vid {
-						blobVolume, err = h.getVolume(ctx, clusterID, blob.Vid, true)
+						blobVolume, err = h.getReadVolume(ctx, clusterID, blob.Vid)
 						if err != nil {
 							span.Error("get volume", err)
 							ch <- pipeBuffer{err: err}
@@ -195,6 +198,9 @@ func (h *Handler) Get(ctx context.Context, w io.Writer, location proto.Location,
 						// do not use local shards
 						ordered := h.CodeModesGetOrdered[blobVolume.CodeMode]
 						sortedVuids = genSortedVuidByIDC(ctx, serviceController, h.IDC, blobVolume.Units[:tactic.N+tactic.M], ordered)
+						if h.azSelector != nil {
+							h.azSelector.sort(sortedVuids)
+						}
 						span.Debugf("to read %s with read-shard-x:%d active-shard-n:%d of data-n:%d party-n:%d",
 							blob.ID(), h.MinReadShardsX, len(sortedVuids), tactic.N, tactic.M)
 						if len(sortedVuids) < tactic.N {
@@ -366,6 +372,7 @@ func (h *Handler) readOneBlob(ctx context.Context, getTime *timeReadWrite,
 	}()
 
 	received := make(map[int]bool, minShardsRead)
+	missing := make(map[int]bool)
 	for idx := range empties {
 		received[idx] = true
 		h.memPool.Zero(shards[idx])
@@ -406,6 +413,9 @@ func (h *Handler) readOneBlob(ctx context.Context, getTime *timeReadWrite,
 		}
 
 		received[shard.index] = shard.status
+		if shard.missing {
+			missing[shard.index] = true
+		}
 		if len(received) < dataN {
 			continue
 		}
@@ -446,7 +456,8 @@ func (h *Handler) readOneBlob(ctx context.Context, getTime *timeReadWrite,
 		// has bad shards, but have enough shards to reconstruct
 		if len(received) >= dataN+badShards {
 			var err error
-			if shardReadSize < shardSize {
+			segment := shardReadSize < shardSize
+			if segment {
 				span.Debugf("bid(%d) ready to segment ec reconstruct data", blob.Bid)
 				reportDownload(blob.Cid, "EC", "segment")
 				segments := make([][]byte, len(shards))
@@ -461,6 +472,9 @@ func (h *Handler) readOneBlob(ctx context.Context, getTime *timeReadWrite,
 			if err == nil {
 				reconstructed = true
 				close(stopChan)
+				if !segment {
+					h.readRepair(ctx, blob, sortedVuids, shards, received, missing)
+				}
 				break
 			}
 			span.Errorf("%s ec reconstruct data error:%s", blob.ID(), err.Error())
@@ -536,6 +550,7 @@ func (h *Handler) readOneShard(ctx context.Context, serviceController controller
 		if err == errCanceledReadShard {
 			return shardResult
 		}
+		shardResult.missing = rpc.DetectStatusCode(err) == errcode.CodeBidNotFound
 		span.Warnf("rpc read %s on %s: %s", blob.ID(), vuid.ID(), errors.Detail(err))
 		return shardResult
 	}
@@ -567,6 +582,9 @@ func (h *Handler) readOneShard(ctx context.Context, serviceController controller
 	shardResult.status = true
 	shardResult.buffer = buf
 	shardResult.time = int(time.Since(shardStart))
+	if h.azSelector != nil {
+		h.azSelector.record(vuid.idc, int(shardReadSize), time.Since(shardStart))
+	}
 	return shardResult
 }
 
@@ -578,7 +596,7 @@ func (h *Handler) getDataShardOnly(ctx context.Context, getTime *timeReadWrite,
 		return nil
 	}
 
-	blobVolume, err := h.getVolume(ctx, blob.Cid, blob.Vid, true)
+	blobVolume, err := h.getReadVolume(ctx, blob.Cid, blob.Vid)
 	if err != nil {
 		return err
 	}
@@ -708,6 +726,17 @@ func (h *Handler) getOneShardFromHost(ctx context.Context, serviceController con
 		case errcode.CodeOverload:
 			return true, err
 
+		// blobnode has quarantined the bad shard and reported it to repair,
+		// no need to retry or punish the disk
+		case errcode.CodeShardCrcMismatch:
+			reportDownload(clusterID, "Download", "CrcMismatch")
+			span.Warnf("crc mismatch of shard on disk:%d host:%s", diskID, host)
+			return true, err
+
+		// the shard is missing in this unit, no need to retry
+		case errcode.CodeBidNotFound:
+			return true, err
+
 		// EIO and Readonly error, then we need to punish disk in local and no need to retry
 		case errcode.CodeDiskBroken, errcode.CodeVUIDReadonly:
 			h.punishDisk(ctx, clusterID, diskID, host, "BrokenOrRO")
@@ -859,10 +888,12 @@ func genSortedVuidByIDC(ctx context.Context,
 			sortMap[dis] = make([]sortedVuid, 0, 8)
 		}
 		sortMap[dis] = append(sortMap[dis], sortedVuid{
-			index:  idx,
-			vuid:   phy.Vuid,
-			diskID: phy.DiskID,
-			host:   phy.Host,
+			index:    idx,
+			vuid:     phy.Vuid,
+			diskID:   phy.DiskID,
+			host:     phy.Host,
+			idc:      hostIDC.IDC,
+			punished: hostIDC.Punished,
 		})
 	}
 
@@ -18,7 +18,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/cubefs/cubefs/blobstore/access/controller"
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
 	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	"github.com/cubefs/cubefs/blobstore/common/memcache"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
@@ -119,3 +122,105 @@ func (h *Handler) tryDiscardVidOnAllocator(cid proto.ClusterID, vid proto.Vid, a
 		span.Warnf("post on %s discard vids %+v : %v", host, args, err)
 	}
 }
+
+func (h *Handler) loopPurgeTrash() {
+	go func() {
+		ticker := time.NewTicker(time.Duration(h.TrashPurgeIntervalS) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-h.stopCh:
+				return
+			case <-ticker.C:
+				for _, cluster := range h.clusterController.All() {
+					h.purgeClusterTrash(cluster.ClusterID)
+				}
+			}
+		}
+	}()
+}
+
+// purgeClusterTrash deletes data of expired trash blobs in all shards of the cluster
+func (h *Handler) purgeClusterTrash(cid proto.ClusterID) {
+	span, ctx := trace.StartSpanFromContext(context.Background(), "")
+
+	shardMgr, err := h.clusterController.GetShardController(cid)
+	if err != nil {
+		span.Warnf("get shard controller of cluster %d : %v", cid, err)
+		return
+	}
+	shard, err := shardMgr.GetFisrtShard(ctx)
+	for err == nil && shard != nil {
+		select {
+		case <-h.stopCh:
+			return
+		default:
+		}
+		h.purgeShardTrash(ctx, cid, shardMgr, shard)
+		shard, err = shardMgr.GetNextShard(ctx, shard.GetRange())
+	}
+	if err != nil {
+		span.Warnf("iterate shards of cluster %d : %v", cid, err)
+	}
+}
+
+func (h *Handler) purgeShardTrash(ctx context.Context, cid proto.ClusterID, shardMgr controller.IShardController, shard controller.Shard) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	header, err := h.getOpHeaderByShard(ctx, shardMgr, shard, acapi.GetShardModeLeader, nil)
+	if err != nil {
+		span.Warnf("get op header of shard %d : %v", shard.GetShardID(), err)
+		return
+	}
+	host, err := h.getShardHost(ctx, cid, header.DiskID)
+	if err != nil {
+		span.Warnf("get host of shard %d : %v", shard.GetShardID(), err)
+		return
+	}
+
+	var marker []byte
+	for {
+		ret, err := h.shardnodeClient.ListTrashBlob(ctx, host, shardnode.ListTrashBlobArgs{
+			Header: header,
+			Marker: marker,
+			Count:  uint64(defaultTrashListCount),
+		})
+		if err != nil {
+			span.Warnf("list trash of shard %d on %s : %v", shard.GetShardID(), host, err)
+			return
+		}
+
+		now := time.Now().Unix()
+		for idx := range ret.Blobs {
+			tb := &ret.Blobs[idx]
+			if tb.ExpireTime > now {
+				continue
+			}
+			// delete data firstly, the trash meta will be purged in next round if failed
+			if err = h.Delete(ctx, &tb.Blob.Location); err != nil {
+				span.Warnf("delete trash blob %s : %v", tb.Blob.Name, err)
+				continue
+			}
+
+			purgeHeader := header
+			purgeHeader.ShardKeys = tb.ShardKeys
+			if len(purgeHeader.ShardKeys) == 0 {
+				purgeHeader.ShardKeys = [][]byte{tb.Blob.Name}
+			}
+			if err = h.shardnodeClient.PurgeTrashBlob(ctx, host, shardnode.PurgeTrashBlobArgs{
+				Header:  purgeHeader,
+				Name:    tb.Blob.Name,
+				Version: tb.Version,
+			}); err != nil {
+				span.Warnf("purge trash blob %s on %s : %v", tb.Blob.Name, host, err)
+				return
+			}
+		}
+
+		if len(ret.NextMarker) == 0 {
+			return
+		}
+		marker = ret.NextMarker
+	}
+}
@@ -17,7 +17,9 @@ package stream
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"hash"
 	"hash/crc32"
 	"io"
 	"sync"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/access"
 	"github.com/cubefs/cubefs/blobstore/api/blobnode"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	"github.com/cubefs/cubefs/blobstore/common/ec"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -62,24 +65,58 @@ func (h *Handler) Put(ctx context.Context,
 	if len(hasherMap) > 0 {
 		rc = io.TeeReader(rc, hasherMap.ToWriter())
 	}
+	var dedupHasher hash.Hash
+	if h.DedupEnable {
+		dedupHasher = sha256.New()
+		rc = io.TeeReader(rc, dedupHasher)
+	}
 
-	// 2.choose cluster and alloc volume from allocator
+	// 2.choose codemode
 	selectedCodeMode := h.allCodeModes.SelectCodeMode(size)
 	span.Debugf("select codemode %d", selectedCodeMode)
 
+	location, err := h.putObject(ctx, rc, size, selectedCodeMode, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.DedupEnable {
+		dedupLocation, err := h.dedupPut(ctx, location, dedupHasher.Sum(nil))
+		if err != nil {
+			h.cleanFailedLocation(span, location)
+			return nil, err
+		}
+		// the uploaded data is cleaned as garbage if the content exists
+		if dedupLocation != location {
+			span.Infof("dedup to exist location %+v", dedupLocation)
+			h.cleanFailedLocation(span, location)
+			return dedupLocation, nil
+		}
+	}
+	return location, nil
+}
+
+// putObject allocates blobs in codemode, encodes and writes the data of rc into them,
+// allocates in any cluster if clusterID is 0.
+func (h *Handler) putObject(ctx context.Context, rc io.Reader, size int64,
+	codeMode codemode.CodeMode, clusterID proto.ClusterID,
+) (*proto.Location, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	// 3.alloc volume from allocator
 	blobSize := atomic.LoadUint32(&h.MaxBlobSize)
-	clusterID, blobs, err := h.allocFromAllocatorWithHystrix(ctx, selectedCodeMode, uint64(size), blobSize, 0)
+	clusterID, blobs, err := h.allocFromAllocatorWithHystrix(ctx, codeMode, uint64(size), blobSize, clusterID)
 	if err != nil {
 		span.Error("alloc failed", errors.Detail(err))
 		return nil, err
 	}
 	span.Debugf("allocated from %d %+v", clusterID, blobs)
 
-	// 3.read body and split, alloc from mem pool;ec encode and put into data node
+	// 4.read body and split, alloc from mem pool;ec encode and put into data node
 	limitReader := io.LimitReader(rc, int64(size))
 	location := &proto.Location{
 		ClusterID: clusterID,
-		CodeMode:  selectedCodeMode,
+		CodeMode:  codeMode,
 		Size_:     uint64(size),
 		SliceSize: blobSize,
 		Slices:    blobs,
@@ -88,19 +125,12 @@ func (h *Handler) Put(ctx context.Context,
 	uploadSucc := false
 	defer func() {
 		if !uploadSucc {
-			span.Infof("put failed clean location %+v", location)
-			_, newCtx := trace.StartSpanFromContextWithTraceID(context.Background(), "", span.TraceID())
-			if err := h.clearGarbage(newCtx, location); err != nil {
-				span.Warn(errors.Detail(err))
-			}
+			h.cleanFailedLocation(span, location)
 		}
 	}()
 
-	var buffer *ec.Buffer
 	putTime := new(timeReadWrite)
 	defer func() {
-		// release ec buffer which have not takeover
-		buffer.Release()
 		span.AppendRPCTrackLog([]string{putTime.String()})
 		putTime.Report(clusterID.ToString(), h.IDC, true)
 	}()
@@ -112,8 +142,77 @@ func (h *Handler) Put(ctx context.Context,
 		ready <- struct{}{}
 	}
 
-	encoder := h.encoder[selectedCodeMode]
-	tactic := selectedCodeMode.Tactic()
+	// encode blobs in pipeline, overlapped with writing to blobnodes
+	pipeline := make(chan encodedBlob, h.PutPipelineDepth)
+	stop := make(chan struct{})
+	var encodeErr error
+	go func() {
+		defer close(pipeline)
+		encodeErr = h.encodeBlobs(ctx, location, limitReader, putTime, pipeline, stop)
+	}()
+	defer func() {
+		// release ec buffers which have not takeover after encoding stopped
+		close(stop)
+		for blob := range pipeline {
+			blob.buffer.Release()
+		}
+	}()
+
+	for blob := range pipeline {
+		span.Debug("to write", blob.ident)
+
+		// takeover the buffer, release to pool in function writeToBlobnodes
+		takeoverBuffer := blob.buffer
+		<-ready
+		startWrite := time.Now()
+		err = h.writeToBlobnodesWithHystrix(ctx, blob.ident, blob.shards, func() {
+			takeoverBuffer.Release()
+			ready <- struct{}{}
+		})
+		putTime.IncW(time.Since(startWrite))
+		if err != nil {
+			return nil, errors.Info(err, "write to blobnode failed")
+		}
+	}
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+
+	uploadSucc = true
+	return location, nil
+}
+
+// cleanFailedLocation deletes the uploaded data of location with a new context,
+// the context of request may be canceled already.
+func (h *Handler) cleanFailedLocation(span trace.Span, location *proto.Location) {
+	span.Infof("put failed clean location %+v", location)
+	_, newCtx := trace.StartSpanFromContextWithTraceID(context.Background(), "", span.TraceID())
+	if err := h.clearGarbage(newCtx, location, 0); err != nil {
+		span.Warn(errors.Detail(err))
+	}
+}
+
+type encodedBlob struct {
+	ident  blobIdent
+	buffer *ec.Buffer
+	shards [][]byte
+}
+
+// encodeBlobs reads and encodes blobs of location one by one into pipeline,
+// returns if all blobs encoded or stopped.
+func (h *Handler) encodeBlobs(ctx context.Context, location *proto.Location, rc io.Reader,
+	putTime *timeReadWrite, pipeline chan<- encodedBlob, stop <-chan struct{},
+) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	var buffer *ec.Buffer
+	defer func() {
+		// release ec buffer which have not sent to pipeline
+		buffer.Release()
+	}()
+
+	encoder := h.encoder[location.CodeMode]
+	tactic := location.CodeMode.Tactic()
 	for _, blob := range location.Spread() {
 		vid, bid, bsize := blob.Vid, blob.Bid, int(blob.Size)
 
@@ -123,52 +222,44 @@ func (h *Handler) Put(ctx context.Context,
 		buffer, err = ec.NewBuffer(bsize, tactic, h.memPool)
 		putTime.IncA(time.Since(st))
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		readBuff := buffer.DataBuf[:bsize]
 		shards, err := encoder.Split(buffer.ECDataBuf)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		startRead := time.Now()
-		n, err := io.ReadFull(limitReader, readBuff)
+		n, err := io.ReadFull(rc, readBuff)
 		putTime.IncR(time.Since(startRead))
 		if err != nil && err != io.EOF {
 			span.Infof("read blob data failed want:%d read:%d %s", bsize, n, err.Error())
-			return nil, errcode.ErrAccessReadRequestBody
+			return errcode.ErrAccessReadRequestBody
 		}
 		if n != bsize {
 			span.Infof("read blob less data want:%d but:%d", bsize, n)
-			return nil, errcode.ErrAccessReadRequestBody
+			return errcode.ErrAccessReadRequestBody
 		}
 
 		// ec encode
 		if err = encoder.Encode(shards); err != nil {
-			return nil, err
+			return err
 		}
 
-		blobident := blobIdent{clusterID, vid, bid}
-		span.Debug("to write", blobident)
-
-		// takeover the buffer, release to pool in function writeToBlobnodes
-		takeoverBuffer := buffer
-		buffer = nil
-		<-ready
-		startWrite := time.Now()
-		err = h.writeToBlobnodesWithHystrix(ctx, blobident, shards, func() {
-			takeoverBuffer.Release()
-			ready <- struct{}{}
-		})
-		putTime.IncW(time.Since(startWrite))
-		if err != nil {
-			return nil, errors.Info(err, "write to blobnode failed")
+		select {
+		case pipeline <- encodedBlob{
+			ident:  blobIdent{location.ClusterID, vid, bid},
+			buffer: buffer,
+			shards: shards,
+		}:
+			buffer = nil
+		case <-stop:
+			return nil
 		}
 	}
-
-	uploadSucc = true
-	return location, nil
+	return nil
 }
 
 func (h *Handler) writeToBlobnodesWithHystrix(ctx context.Context,
@@ -219,6 +310,10 @@ func (h *Handler) writeToBlobnodes(ctx context.Context,
 	if err != nil {
 		return
 	}
+	spaceID, err := h.wormSpaceID(ctx, clusterID)
+	if err != nil {
+		return
+	}
 
 	statusCh := make(chan shardPutStatus, len(volume.Units))
 	tactic := volume.CodeMode.Tactic()
@@ -254,6 +349,8 @@ func (h *Handler) writeToBlobnodes(ctx context.Context,
 				Bid:    bid,
 				Size:   int64(len(shards[index])),
 				Type:   blobnode.NormalIO,
+
+				SpaceID: spaceID,
 			}
 
 			crcDisable := h.ShardCrcWriteDisable
@@ -215,6 +215,37 @@ func TestAccessStreamPutSum(t *testing.T) {
 	dataShards.clean()
 }
 
+func TestAccessStreamPutPipeline(t *testing.T) {
+	ctx := ctxWithName("TestAccessStreamPutPipeline")
+	depth := streamer.PutPipelineDepth
+	defer func() {
+		streamer.PutPipelineDepth = depth
+		dataShards.clean()
+	}()
+
+	for _, depth := range []int{0, 1, 4} {
+		streamer.PutPipelineDepth = depth
+
+		dataShards.clean()
+		size := (blobSize * 3) + 1024
+		data := make([]byte, size)
+		rand.Read(data)
+		loc, err := streamer.Put(ctx(), bytes.NewReader(data), int64(size), nil)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(loc.Spread()))
+
+		buff := bytes.NewBuffer(nil)
+		transfer, err := streamer.Get(ctx(), buff, *loc, uint64(size), 0)
+		require.NoError(t, err)
+		require.NoError(t, transfer())
+		require.True(t, dataEqual(data, buff.Bytes()))
+
+		// body is shorter than the size
+		_, err = streamer.Put(ctx(), bytes.NewReader(data[:blobSize+1]), int64(size), nil)
+		require.ErrorIs(t, err, errcode.ErrAccessReadRequestBody)
+	}
+}
+
 func TestAccessStreamPutShardTimeout(t *testing.T) {
 	ctx := ctxWithName("TestAccessStreamPutShardTimeout")
 	dataShards.clean()
@@ -0,0 +1,170 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/crc32"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/blobnode"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
+)
+
+const (
+	defaultReadRepairConcurrency = 16
+	defaultReadRepairTimeoutMS   = 10 * 1000
+)
+
+// ReadRepairConfig writes the reconstructed shards back to their units in background
+// when a get has to ec-decode the blob because some shards are missing, to reduce the
+// following degraded reads before the scheduler repairs them.
+//
+// Only the shards not found on blobnode are repaired, crc mismatched shards are
+// quarantined and reported by blobnode itself. Segment reads are not repaired, cos
+// the whole shard is not reconstructed. The repair is skipped if Concurrency blobs
+// are being repaired.
+type ReadRepairConfig struct {
+	Enable      bool `json:"enable"`
+	Concurrency int  `json:"concurrency"`
+	TimeoutMS   int  `json:"timeout_ms"`
+}
+
+func newReadRepairLimiter(cfg *ReadRepairConfig) chan struct{} {
+	defaulter.LessOrEqual(&cfg.Concurrency, defaultReadRepairConcurrency)
+	defaulter.LessOrEqual(&cfg.TimeoutMS, defaultReadRepairTimeoutMS)
+	return make(chan struct{}, cfg.Concurrency)
+}
+
+// readRepair copies the shards of the reconstructed blob, then reconstructs the
+// missing parity shards and puts the missing shards asynchronously.
+//
+//	received is the read status of shards, data shards are all reconstructed
+//	missing is the shards not found on blobnode
+func (h *Handler) readRepair(ctx context.Context, blob blobGetArgs, sortedVuids []sortedVuid,
+	shards [][]byte, received map[int]bool, missing map[int]bool,
+) {
+	if h.readRepairLimiter == nil || len(missing) == 0 {
+		return
+	}
+	span := trace.SpanFromContextSafe(ctx)
+
+	tactic := blob.CodeMode.Tactic()
+	units := make([]sortedVuid, 0, len(missing))
+	readable := make([]sortedVuid, 0, len(received))
+	needParity := false
+	for _, vuid := range sortedVuids {
+		if received[vuid.index] {
+			readable = append(readable, vuid)
+		}
+		if missing[vuid.index] && !vuid.punished {
+			units = append(units, vuid)
+			if vuid.index >= tactic.N {
+				needParity = true
+			}
+		}
+	}
+	if len(units) == 0 {
+		return
+	}
+
+	select {
+	case h.readRepairLimiter <- struct{}{}:
+	default:
+		span.Warnf("skip read repair of %s, too many blobs in repairing", blob.ID())
+		reportDownload(blob.Cid, "ReadRepair", "skipped")
+		return
+	}
+
+	// all shards are needed to reconstruct the missing parity shards,
+	// otherwise just the missing data shards.
+	buffers := make([][]byte, 0, len(shards))
+	release := func() {
+		for _, buf := range buffers {
+			h.memPool.Put(buf)
+		}
+		<-h.readRepairLimiter
+	}
+	repairShards := make([][]byte, len(shards))
+	badIdx := make([]int, 0, tactic.M)
+	for idx := range shards {
+		if !needParity && !missing[idx] {
+			continue
+		}
+		buf, err := h.memPool.Alloc(blob.ShardSize)
+		if err != nil {
+			span.Warnf("skip read repair of %s, alloc buffer: %s", blob.ID(), err.Error())
+			release()
+			return
+		}
+		buffers = append(buffers, buf)
+		repairShards[idx] = buf
+		if idx < tactic.N || received[idx] {
+			copy(buf, shards[idx])
+		} else {
+			badIdx = append(badIdx, idx)
+		}
+	}
+
+	go func() {
+		defer release()
+
+		spanChild, ctxChild := trace.StartSpanFromContextWithTraceID(
+			context.Background(), "ReadRepair", span.TraceID())
+		defer spanChild.Finish()
+		ctxChild, cancel := context.WithTimeout(ctxChild, time.Millisecond*time.Duration(h.ReadRepair.TimeoutMS))
+		defer cancel()
+
+		if needParity {
+			if err := h.encoder[blob.CodeMode].Reconstruct(repairShards, badIdx); err != nil {
+				spanChild.Errorf("%s ec reconstruct for read repair error:%s", blob.ID(), err.Error())
+				reportDownload(blob.Cid, "ReadRepair", "error")
+				return
+			}
+		}
+
+		// keep the worm retention of blob on the repaired shards
+		retention := h.maxShardRetention(ctxChild, readable, blob.Bid)
+
+		for _, vuid := range units {
+			shard := repairShards[vuid.index]
+			crc, err := h.blobnodeClient.PutShard(ctxChild, vuid.host, &blobnode.PutShardArgs{
+				DiskID: vuid.diskID,
+				Vuid:   vuid.vuid,
+				Bid:    blob.Bid,
+				Size:   int64(len(shard)),
+				Type:   blobnode.BackgroundIO,
+				Body:   bytes.NewReader(shard),
+
+				Retention: retention,
+			})
+			if err == nil && !h.ShardCrcWriteDisable {
+				if crcOrigin := crc32.ChecksumIEEE(shard); crc != crcOrigin {
+					err = fmt.Errorf("crc mismatch 0x%x != 0x%x", crc, crcOrigin)
+				}
+			}
+			if err != nil {
+				spanChild.Warnf("read repair %s on %s: %s", blob.ID(), vuid.ID(), err.Error())
+				reportDownload(blob.Cid, "ReadRepair", "error")
+				continue
+			}
+			spanChild.Infof("read repaired %s on %s", blob.ID(), vuid.ID())
+			reportDownload(blob.Cid, "ReadRepair", "-")
+		}
+	}()
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/blobnode"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
+)
+
+func TestAccessStreamGetReadRepair(t *testing.T) {
+	ctx := ctxWithName("TestAccessStreamGetReadRepair")
+	vuidController.Unbreak(1005)
+
+	// the missing shards respond at once, others are slowed down
+	missingIDs := []proto.Vuid{1001, 1008}
+	for _, id := range allID {
+		vuidController.SetSlowdown(proto.Vuid(id), 100*time.Millisecond)
+	}
+	for _, id := range missingIDs {
+		vuidController.SetSlowdown(id, -1)
+	}
+
+	putVuids := make(chan proto.Vuid, len(allID))
+	api := mocks.NewMockStorageAPI(gomock.NewController(t))
+	api.EXPECT().RangeGetShard(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, host string, args *blobnode.RangeGetShardArgs) (io.ReadCloser, uint32, error) {
+			if len(dataShards.get(args.Vuid, args.Bid)) == 0 {
+				return nil, 0, errcode.ErrNoSuchBid
+			}
+			return storageAPIRangeGetShard(ctx, host, args)
+		})
+	// the retention of readable shards is kept on the repaired shards
+	retention := time.Now().Add(time.Hour).Unix()
+	api.EXPECT().StatShard(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, _ string, args *blobnode.StatShardArgs) (*blobnode.ShardInfo, error) {
+			if args.Vuid == proto.Vuid(allID[0]) {
+				return &blobnode.ShardInfo{}, nil
+			}
+			return &blobnode.ShardInfo{Retention: retention}, nil
+		})
+	api.EXPECT().PutShard(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, host string, args *blobnode.PutShardArgs) (uint32, error) {
+			require.Equal(t, retention, args.Retention)
+			crc, err := storageAPIPutShard(ctx, host, args)
+			putVuids <- args.Vuid
+			return crc, err
+		})
+
+	oldClient := streamer.blobnodeClient
+	streamer.blobnodeClient = api
+	streamer.MinReadShardsX = codemode.EC6P6.Tactic().M
+	streamer.readRepairLimiter = newReadRepairLimiter(&streamer.ReadRepair)
+	defer func() {
+		streamer.blobnodeClient = oldClient
+		streamer.MinReadShardsX = minReadShardsX
+		streamer.readRepairLimiter = nil
+		for _, id := range allID {
+			vuidController.SetSlowdown(proto.Vuid(id), -1)
+		}
+		vuidController.Break(1005)
+		dataShards.clean()
+	}()
+
+	size := 1 << 20
+	data := make([]byte, size)
+	rand.Read(data)
+	loc, err := streamer.Put(ctx(), bytes.NewReader(data), int64(size), nil)
+	require.NoError(t, err)
+	bid := loc.Slices[0].MinSliceID
+
+	shards := make(map[proto.Vuid][]byte)
+	for _, id := range missingIDs {
+		shards[id] = dataShards.get(id, bid)
+		dataShards.set(id, bid, nil)
+	}
+
+	buff := bytes.NewBuffer(nil)
+	transfer, err := streamer.Get(ctx(), buff, *loc, uint64(size), 0)
+	require.NoError(t, err)
+	require.NoError(t, transfer())
+	require.True(t, dataEqual(data, buff.Bytes()))
+
+	repaired := make(map[proto.Vuid]bool)
+	for range missingIDs {
+		select {
+		case id := <-putVuids:
+			repaired[id] = true
+		case <-time.After(3 * time.Second):
+			t.Fatal("timeout of read repair")
+		}
+	}
+	for _, id := range missingIDs {
+		require.True(t, repaired[id])
+		require.Equal(t, shards[id], dataShards.get(id, bid))
+	}
+
+	// segment read is not repaired
+	dataShards.set(missingIDs[0], bid, nil)
+	transfer, err = streamer.Get(ctx(), bytes.NewBuffer(nil), *loc, 1024, 0)
+	require.NoError(t, err)
+	require.NoError(t, transfer())
+	select {
+	case id := <-putVuids:
+		t.Fatalf("unexpected repair of vuid %d", id)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
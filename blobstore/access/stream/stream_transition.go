@@ -0,0 +1,178 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/retry"
+)
+
+// TransitionBlob re-encodes the blob into the colder codemode.
+//
+//  1. read the data of blob with the old location, and put it in the new codemode
+//  2. compare and swap the blob record to the new location at shardnode
+//  3. delete the old location after a grace period to release the old units
+//
+// the new location is cleaned if the blob is changed during transition.
+func (h *Handler) TransitionBlob(ctx context.Context, args *acapi.TransitionBlobArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debugf("transition blob args:%+v", *args)
+
+	if _, ok := h.encoder[args.CodeMode]; !ok {
+		span.Warnf("not supported codemode %d", args.CodeMode)
+		return errcode.ErrIllegalArguments
+	}
+
+	select {
+	case h.transitionLimiter <- struct{}{}:
+		defer func() { <-h.transitionLimiter }()
+	default:
+		span.Warn("too many blobs in transition")
+		return errcode.ErrAccessLimited
+	}
+	_, err := h.transitionBlob(ctx, args)
+	return err
+}
+
+func (h *Handler) transitionBlob(ctx context.Context, args *acapi.TransitionBlobArgs) (*proto.Location, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	oldBlob, err := h.getBlob(ctx, &acapi.GetBlobArgs{
+		ClusterID: args.ClusterID,
+		Mode:      acapi.GetShardModeLeader,
+		BlobName:  args.BlobName,
+		ShardKeys: args.ShardKeys,
+	})
+	if err != nil {
+		return nil, err
+	}
+	oldLocation := oldBlob.Location
+	if oldLocation.CodeMode == args.CodeMode {
+		return &oldLocation, nil
+	}
+	// slices of unsealed blob may be still in writing
+	if !oldBlob.Sealed || oldLocation.Size_ == 0 {
+		span.Warnf("blob %s is not sealed or empty", args.BlobName)
+		return nil, errcode.ErrIllegalArguments
+	}
+	oldValue, err := oldBlob.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	// 1.re-encode data of the old location into the new codemode in the same cluster
+	pr, pw := io.Pipe()
+	go func() {
+		transfer, err := h.Get(ctx, pw, oldLocation, oldLocation.Size_, 0)
+		if err == nil {
+			err = transfer()
+		}
+		pw.CloseWithError(err)
+	}()
+	newLocation, err := h.putObject(ctx, pr, int64(oldLocation.Size_), args.CodeMode, oldLocation.ClusterID)
+	pr.CloseWithError(err)
+	if err != nil {
+		span.Errorf("put blob %s in codemode %d failed, err:%+v", args.BlobName, args.CodeMode, err)
+		return nil, err
+	}
+	// the payload is transferred as is, keep its codec
+	newLocation.Codec = oldLocation.Codec
+	newLocation.RawSize = oldLocation.RawSize
+
+	// 2.switch the location if the blob record is not changed
+	newBlob := proto.Blob{Name: oldBlob.Name, Location: *newLocation, Sealed: true}
+	if err = h.swapBlob(ctx, args, oldValue, newBlob); err != nil {
+		span.Errorf("swap blob %s failed, err:%+v", args.BlobName, err)
+		h.cleanFailedLocation(span, newLocation)
+		return nil, err
+	}
+	span.Infof("blob %s transited from codemode %d to %d", args.BlobName, oldLocation.CodeMode, args.CodeMode)
+
+	// 3.release the old units after the readers of old location done, leaves garbage if failed
+	if err = h.delete(ctx, &oldLocation, int64(h.TransitionDeleteDelayS)); err != nil {
+		span.Warnf("delete old location of blob %s failed, location:%+v, err:%+v", args.BlobName, oldLocation, err)
+	}
+	return newLocation, nil
+}
+
+// swapBlob overwrites the blob record if it equals to the old value.
+func (h *Handler) swapBlob(ctx context.Context, args *acapi.TransitionBlobArgs, oldValue []byte, newBlob proto.Blob) error {
+	newValue, err := newBlob.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return retry.ExponentialBackoff(3, 200).RuptOn(func() (bool, error) {
+		header, err := h.getShardOpHeader(ctx, &acapi.GetShardCommonArgs{
+			ClusterID: args.ClusterID,
+			BlobName:  args.BlobName,
+			Mode:      acapi.GetShardModeLeader,
+			ShardKeys: args.ShardKeys,
+		})
+		if err != nil {
+			return true, err
+		}
+
+		host, err := h.getShardHost(ctx, args.ClusterID, header.DiskID)
+		if err != nil {
+			return true, err
+		}
+
+		err = h.shardnodeClient.CommitTxn(ctx, host, shardnode.CommitTxnArgs{
+			Header: header,
+			Ops: []shardnode.TxnOp{{
+				Type:      shardnode.TxnOpPutBlob,
+				ShardKeys: header.ShardKeys,
+				Name:      args.BlobName,
+				Blob:      newBlob,
+				Cond:      shardnode.TxnCond{Type: shardnode.TxnCondValueEqual, Value: oldValue},
+			}},
+		})
+		if err == nil {
+			return true, nil
+		}
+		if rpc.DetectStatusCode(err) == errcode.CodeTxnConflict {
+			// the last retry may have been committed
+			blob, errGet := h.getBlob(ctx, &acapi.GetBlobArgs{
+				ClusterID: args.ClusterID,
+				Mode:      acapi.GetShardModeLeader,
+				BlobName:  args.BlobName,
+				ShardKeys: args.ShardKeys,
+			})
+			if errGet == nil {
+				if value, errMarshal := blob.Marshal(); errMarshal == nil && bytes.Equal(value, newValue) {
+					return true, nil
+				}
+			}
+			return true, err
+		}
+		return h.punishAndUpdate(ctx, &punishArgs{
+			ShardOpHeader: header,
+			clusterID:     args.ClusterID,
+			host:          host,
+			mode:          acapi.GetShardModeLeader,
+			err:           err,
+		})
+	})
+}
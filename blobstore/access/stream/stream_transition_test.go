@@ -0,0 +1,140 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/access/controller"
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	"github.com/cubefs/cubefs/blobstore/common/ec"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
+)
+
+func newStreamHandlerTransition(t *testing.T) (*Handler, *mocks.MockShardnodeAccess) {
+	ctr := gomock.NewController(t)
+	gAny := gomock.Any()
+
+	shard := NewMockShard(ctr)
+	shard.EXPECT().GetMember(gAny, gAny, gAny).Return(controller.ShardOpInfo{DiskID: 101}, nil).AnyTimes()
+	shardMgr := NewMockShardController(ctr)
+	shardMgr.EXPECT().GetShard(gAny, gAny).Return(shard, nil).AnyTimes()
+	shardMgr.EXPECT().GetSpaceID().Return(proto.SpaceID(1)).AnyTimes()
+
+	svrCtrl := NewMockServiceController(ctr)
+	svrCtrl.EXPECT().GetShardnodeHost(gAny, gAny).Return(&controller.HostIDC{Host: "host"}, nil).AnyTimes()
+	svrCtrl.EXPECT().GetServiceHost(gAny, gAny).Return("proxy", nil).AnyTimes()
+	clu := NewMockClusterController(ctr)
+	clu.EXPECT().GetShardController(gAny).Return(shardMgr, nil).AnyTimes()
+	clu.EXPECT().GetServiceController(gAny).Return(svrCtrl, nil).AnyTimes()
+
+	shardCli := mocks.NewMockShardnodeAccess(ctr)
+	h := &Handler{
+		encoder: map[codemode.CodeMode]ec.Encoder{
+			codemode.EC6P6:    nil,
+			codemode.EC6P10L2: nil,
+		},
+		clusterController: clu,
+		shardnodeClient:   shardCli,
+		proxyClient:       mocks.NewMockProxyClient(ctr),
+		transitionLimiter: make(chan struct{}, 1),
+	}
+	return h, shardCli
+}
+
+func TestStreamTransitionBlob(t *testing.T) {
+	ctx := context.Background()
+	gAny := gomock.Any()
+	h, shardCli := newStreamHandlerTransition(t)
+
+	args := &acapi.TransitionBlobArgs{ClusterID: 1, BlobName: []byte("blob"), CodeMode: codemode.EC3P3}
+	require.ErrorIs(t, h.TransitionBlob(ctx, args), errcode.ErrIllegalArguments)
+
+	// too many blobs in transition
+	args.CodeMode = codemode.EC6P10L2
+	h.transitionLimiter <- struct{}{}
+	require.ErrorIs(t, h.TransitionBlob(ctx, args), errcode.ErrAccessLimited)
+	<-h.transitionLimiter
+
+	// in the codemode already
+	blob := proto.Blob{Name: args.BlobName, Location: proto.Location{ClusterID: 1, CodeMode: codemode.EC6P10L2, Size_: 10}, Sealed: true}
+	shardCli.EXPECT().GetBlob(gAny, gAny, gAny).Return(shardnode.GetBlobRet{Blob: blob}, nil)
+	require.NoError(t, h.TransitionBlob(ctx, args))
+
+	// unsealed blob
+	blob.Location.CodeMode = codemode.EC6P6
+	blob.Sealed = false
+	shardCli.EXPECT().GetBlob(gAny, gAny, gAny).Return(shardnode.GetBlobRet{Blob: blob}, nil)
+	require.ErrorIs(t, h.TransitionBlob(ctx, args), errcode.ErrIllegalArguments)
+}
+
+func TestStreamTransitionSwapBlob(t *testing.T) {
+	ctx := context.Background()
+	gAny := gomock.Any()
+	h, shardCli := newStreamHandlerTransition(t)
+
+	args := &acapi.TransitionBlobArgs{ClusterID: 1, BlobName: []byte("blob"), CodeMode: codemode.EC6P10L2}
+	oldBlob := proto.Blob{Name: args.BlobName, Location: proto.Location{ClusterID: 1, CodeMode: codemode.EC6P6, Size_: 10}, Sealed: true}
+	newBlob := proto.Blob{Name: args.BlobName, Location: proto.Location{ClusterID: 1, CodeMode: codemode.EC6P10L2, Size_: 10}, Sealed: true}
+	oldValue, err := oldBlob.Marshal()
+	require.NoError(t, err)
+
+	shardCli.EXPECT().CommitTxn(gAny, gAny, gAny).DoAndReturn(
+		func(_ context.Context, _ string, args shardnode.CommitTxnArgs) error {
+			require.Equal(t, 1, len(args.Ops))
+			op := args.Ops[0]
+			require.Equal(t, shardnode.TxnOpPutBlob, op.Type)
+			require.NotEmpty(t, op.ShardKeys)
+			require.Equal(t, newBlob, op.Blob)
+			require.Equal(t, shardnode.TxnCond{Type: shardnode.TxnCondValueEqual, Value: oldValue}, op.Cond)
+			return nil
+		})
+	require.NoError(t, h.swapBlob(ctx, args, oldValue, newBlob))
+
+	// committed by the last retry
+	shardCli.EXPECT().CommitTxn(gAny, gAny, gAny).Return(errcode.ErrTxnConflict)
+	shardCli.EXPECT().GetBlob(gAny, gAny, gAny).Return(shardnode.GetBlobRet{Blob: newBlob}, nil)
+	require.NoError(t, h.swapBlob(ctx, args, oldValue, newBlob))
+
+	// changed by others
+	shardCli.EXPECT().CommitTxn(gAny, gAny, gAny).Return(errcode.ErrTxnConflict)
+	shardCli.EXPECT().GetBlob(gAny, gAny, gAny).Return(shardnode.GetBlobRet{Blob: oldBlob}, nil)
+	require.ErrorIs(t, h.swapBlob(ctx, args, oldValue, newBlob), errcode.ErrTxnConflict)
+}
+
+func TestStreamTransitionDeleteDelayed(t *testing.T) {
+	ctx := context.Background()
+	gAny := gomock.Any()
+	h, _ := newStreamHandlerTransition(t)
+
+	loc := proto.Location{ClusterID: 1, CodeMode: codemode.EC6P6, Size_: 10, SliceSize: 10,
+		Slices: []proto.Slice{{MinSliceID: 1, Vid: 1, Count: 1}}}
+	h.proxyClient.(*mocks.MockProxyClient).EXPECT().SendDeleteMsg(gAny, "proxy", gAny).DoAndReturn(
+		func(_ context.Context, _ string, args *proxy.DeleteArgs) error {
+			require.Equal(t, int64(600), args.DelayS)
+			require.Equal(t, []proxy.BlobDelete{{Bid: 1, Vid: 1}}, args.Blobs)
+			return nil
+		})
+	require.NoError(t, h.delete(ctx, &loc, 600))
+}
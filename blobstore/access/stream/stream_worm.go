@@ -0,0 +1,87 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cubefs/cubefs/blobstore/api/blobnode"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+type spaceBlobKey struct{}
+
+// WithSpaceBlob returns context of putting data of space blob,
+// the data of worm space is written with retention on blobnode.
+func WithSpaceBlob(ctx context.Context) context.Context {
+	return context.WithValue(ctx, spaceBlobKey{}, struct{}{})
+}
+
+func isSpaceBlob(ctx context.Context) bool {
+	return ctx.Value(spaceBlobKey{}) != nil
+}
+
+// wormSpaceID returns the space of shards to be written if the space is worm,
+// zero if it's not data of space blob or the space is not worm. The retention
+// of shards is set by the policy of space on blobnode.
+func (h *Handler) wormSpaceID(ctx context.Context, clusterID proto.ClusterID) (proto.SpaceID, error) {
+	if !isSpaceBlob(ctx) {
+		return 0, nil
+	}
+	shardMgr, err := h.clusterController.GetShardController(clusterID)
+	if err != nil {
+		return 0, err
+	}
+	if shardMgr.GetWormRetention() <= 0 {
+		return 0, nil
+	}
+	return shardMgr.GetSpaceID(), nil
+}
+
+// maxShardRetention stats the shards of bid on units concurrently,
+// returns the max retention of the readable shards.
+func (h *Handler) maxShardRetention(ctx context.Context, units []sortedVuid, bid proto.BlobID) int64 {
+	span := trace.SpanFromContextSafe(ctx)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		retention int64
+	)
+	wg.Add(len(units))
+	for idx := range units {
+		go func(unit *sortedVuid) {
+			defer wg.Done()
+			si, err := h.blobnodeClient.StatShard(ctx, unit.host, &blobnode.StatShardArgs{
+				DiskID: unit.diskID,
+				Vuid:   unit.vuid,
+				Bid:    bid,
+			})
+			if err != nil {
+				span.Warnf("stat shard of bid(%d) on %s: %s", bid, unit.ID(), err.Error())
+				return
+			}
+			mu.Lock()
+			if si.Retention > retention {
+				retention = si.Retention
+			}
+			mu.Unlock()
+		}(&units[idx])
+	}
+	wg.Wait()
+	return retention
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/access/controller"
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
+)
+
+func newStreamHandlerWorm(t *testing.T, retention time.Duration) *Handler {
+	ctr := gomock.NewController(t)
+	gAny := gomock.Any()
+
+	shardInfo := NewMockShard(ctr)
+	shardInfo.EXPECT().GetMember(gAny, gAny, gAny).Return(controller.ShardOpInfo{DiskID: 101}, nil).AnyTimes()
+	shardMgr := NewMockShardController(ctr)
+	shardMgr.EXPECT().GetShard(gAny, gAny).Return(shardInfo, nil).AnyTimes()
+	shardMgr.EXPECT().GetSpaceID().Return(proto.SpaceID(1)).AnyTimes()
+	shardMgr.EXPECT().GetWormRetention().Return(retention).AnyTimes()
+
+	svrCtrl := NewMockServiceController(ctr)
+	svrCtrl.EXPECT().GetShardnodeHost(gAny, gAny).Return(&controller.HostIDC{Host: "shardnode"}, nil).AnyTimes()
+	svrCtrl.EXPECT().GetDiskHost(gAny, gAny).Return(&controller.HostIDC{Host: "blobnode"}, nil).AnyTimes()
+
+	clu := NewMockClusterController(ctr)
+	clu.EXPECT().GetShardController(gAny).Return(shardMgr, nil).AnyTimes()
+	clu.EXPECT().GetServiceController(gAny).Return(svrCtrl, nil).AnyTimes()
+	clu.EXPECT().GetVolumeGetter(gAny).Return(volumeGetter, nil).AnyTimes()
+
+	return &Handler{
+		clusterController: clu,
+		shardnodeClient:   mocks.NewMockShardnodeAccess(ctr),
+		blobnodeClient:    mocks.NewMockStorageAPI(ctr),
+	}
+}
+
+func TestStreamWormSpaceID(t *testing.T) {
+	ctx := context.Background()
+	h := newStreamHandlerWorm(t, time.Hour)
+
+	spaceID, err := h.wormSpaceID(ctx, clusterID)
+	require.NoError(t, err)
+	require.Equal(t, proto.SpaceID(0), spaceID)
+
+	spaceID, err = h.wormSpaceID(WithSpaceBlob(ctx), clusterID)
+	require.NoError(t, err)
+	require.Equal(t, proto.SpaceID(1), spaceID)
+
+	h = newStreamHandlerWorm(t, 0)
+	spaceID, err = h.wormSpaceID(WithSpaceBlob(ctx), clusterID)
+	require.NoError(t, err)
+	require.Equal(t, proto.SpaceID(0), spaceID)
+}
+
+func TestStreamWormDelete(t *testing.T) {
+	ctx := context.Background()
+	gAny := gomock.Any()
+	h := newStreamHandlerWorm(t, time.Hour)
+
+	// the retention is checked on shardnode, no shard is stat
+	h.shardnodeClient.(*mocks.MockShardnodeAccess).EXPECT().FindAndDeleteBlob(gAny, gAny, gAny).Return(
+		shardnode.GetBlobRet{}, errcode.ErrShardWormProtected)
+	err := h.DeleteBlob(ctx, &acapi.DelBlobArgs{ClusterID: clusterID, BlobName: []byte("blob-worm")})
+	require.ErrorIs(t, err, errcode.ErrShardWormProtected)
+}
@@ -45,6 +45,9 @@ const (
 	defaultMaxPartRetry    int   = 3
 	defaultPartConcurrence int   = 4
 	defaultServiceName           = "access"
+	defaultWarmUpConns     int   = 2
+
+	warmUpTimeout = 5 * time.Second
 )
 
 // RPCConnectMode self-defined rpc client connection config setting
@@ -157,14 +160,23 @@ type Config struct {
 	PartConcurrence int `json:"part_concurrence"`
 
 	// rpc selector config
-	// Failure retry interval, default value is 300s,
-	// if FailRetryIntervalS < 0, remove failed hosts will not work.
+	// Hosts are selected by scores of recent latencies and errors.
+	// Failure retry interval, default value is 300s, the failed host is
+	// not preferred and recovers gradually within this interval,
+	// if FailRetryIntervalS < 0, the failed host recovers at once.
 	FailRetryIntervalS int `json:"fail_retry_interval_s"`
-	// Within MaxFailsPeriodS, if the number of failures is greater than or equal to MaxFails,
-	// the host is considered disconnected.
+	// Deprecated: failed hosts are scored instead of removed.
 	MaxFailsPeriodS int `json:"max_fails_period_s"`
-	// HostTryTimes Number of host failure retries
+	// Deprecated: failed hosts are scored instead of removed.
 	HostTryTimes int `json:"host_try_times"`
+	// WarmUpConns number of connections to every host warmed up
+	// when hosts are discovered, default is 2, disabled if it < 0.
+	WarmUpConns int `json:"warm_up_conns"`
+	// RetryBudget limits retries of hosts shared by all requests of the client,
+	// so that retries do not amplify load during partial outages.
+	RetryBudget RetryBudgetConfig `json:"retry_budget"`
+	// Outlier ejects the host with consecutive failures.
+	Outlier OutlierConfig `json:"outlier"`
 
 	// RPCConfig user-defined rpc config
 	// All connections will use the config if it's not nil
@@ -191,6 +203,7 @@ type Logger = lumberjack.Logger
 // client access rpc client
 type client struct {
 	config    Config
+	budget    *retryBudget
 	rpcClient atomic.Value
 	stop      chan struct{}
 }
@@ -213,6 +226,9 @@ type API interface {
 	// - (nil, ErrIllegalArguments): when args is invalid.
 	// - (failedLocations, err): returns the list of locations that have not yet been deleted.
 	Delete(ctx context.Context, args *DeleteArgs) (failedLocations []proto.Location, err error)
+	// Presign mint a time-limited token of the operation on one blob,
+	// the blob can be accessed with the token by /presigned/get or /presigned/delete.
+	Presign(ctx context.Context, args *PresignArgs) (token string, err error)
 }
 
 type Client interface {
@@ -220,6 +236,8 @@ type Client interface {
 	ListBlob(ctx context.Context, args *ListBlobArgs) (shardnode.ListBlobRet, error)
 	GetBlob(ctx context.Context, args *GetBlobArgs) (io.ReadCloser, error)
 	DeleteBlob(ctx context.Context, args *DelBlobArgs) error
+	UndeleteBlob(ctx context.Context, args *UndeleteBlobArgs) error
+	TransitionBlob(ctx context.Context, args *TransitionBlobArgs) error
 	PutBlob(ctx context.Context, args *PutBlobArgs) (proto.ClusterID, HashSumMap, error)
 }
 
@@ -253,6 +271,7 @@ func New(cfg Config) (API, error) {
 	defaulter.LessOrEqual(&cfg.PartConcurrence, defaultPartConcurrence)
 	defaulter.Equal(&cfg.FailRetryIntervalS, 300)
 	defaulter.LessOrEqual(&cfg.MaxFailsPeriodS, 10)
+	defaulter.Equal(&cfg.WarmUpConns, defaultWarmUpConns)
 	defaulter.Equal(&cfg.ServiceIntervalS, 300) // 5 minutes
 	if cfg.ServiceIntervalS < 5 {
 		cfg.ServiceIntervalS = 5
@@ -265,6 +284,7 @@ func New(cfg Config) (API, error) {
 
 	c := &client{
 		config: cfg,
+		budget: newRetryBudget(cfg.RetryBudget),
 		stop:   make(chan struct{}),
 	}
 
@@ -280,7 +300,7 @@ func New(cfg Config) (API, error) {
 		if len(cfg.PriorityAddrs) < 1 {
 			return nil, errcode.ErrAccessServiceDiscovery
 		}
-		c.rpcClient.Store(getClient(&cfg, cfg.PriorityAddrs))
+		c.rpcClient.Store(getClient(&cfg, cfg.PriorityAddrs, c.budget))
 		return c, nil
 	}
 
@@ -322,7 +342,7 @@ func New(cfg Config) (API, error) {
 		log.Errorf("get hosts from consul failed: %v", err)
 		return nil, errcode.ErrAccessServiceDiscovery
 	}
-	c.rpcClient.Store(getClient(&cfg, hosts))
+	c.rpcClient.Store(getClient(&cfg, hosts, c.budget))
 
 	go func() {
 		ticker := time.NewTicker(time.Duration(cfg.ServiceIntervalS) * time.Second)
@@ -342,7 +362,7 @@ func New(cfg Config) (API, error) {
 						oldClient.Close()
 					}
 					log.Warnf("update hosts of client (%v) -> (%v)", old, hosts)
-					c.rpcClient.Store(getClient(&cfg, hosts))
+					c.rpcClient.Store(getClient(&cfg, hosts, c.budget))
 				}
 			case <-c.stop:
 				return
@@ -369,7 +389,7 @@ func isUpdated(a, b []string) bool {
 	return false
 }
 
-func getClient(cfg *Config, hosts []string) rpc.Client {
+func getClient(cfg *Config, hosts []string, budget *retryBudget) rpc.Client {
 	lbConfig := &rpc.LbConfig{
 		Hosts:              hosts,
 		FailRetryIntervalS: cfg.FailRetryIntervalS,
@@ -378,16 +398,26 @@ func getClient(cfg *Config, hosts []string) rpc.Client {
 		RequestTryTimes:    cfg.MaxHostRetry,
 		ShouldRetry:        shouldRetry,
 	}
+	if budget != nil {
+		lbConfig.RetryBudget = budget
+	}
 
 	if cfg.RPCConfig == nil {
-		rpcConfig := cfg.ConnMode.getConfig(cfg.BodyBandwidthMBPs,
+		lbConfig.Config = cfg.ConnMode.getConfig(cfg.BodyBandwidthMBPs,
 			cfg.ClientTimeoutMs, cfg.BodyBaseTimeoutMs)
-		lbConfig.Config = rpcConfig
-		return rpc.NewLbClient(lbConfig, nil)
+	} else {
+		lbConfig.Config = *cfg.RPCConfig
 	}
-	lbConfig.Config = *cfg.RPCConfig
 
-	return rpc.NewLbClient(lbConfig, nil)
+	sel := newScoreSelector(hosts, time.Duration(cfg.FailRetryIntervalS)*time.Second, cfg.Outlier)
+	rpcClient := rpc.NewLbClient(lbConfig, sel)
+	if cli, ok := rpcClient.(rpc.WarmUpClient); ok && cfg.WarmUpConns > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), warmUpTimeout)
+		// any response of access warms up the connection
+		cli.WarmUp(ctx, http.MethodHead, "/", cfg.WarmUpConns)
+		cancel()
+	}
+	return rpcClient
 }
 
 func (c *client) Put(ctx context.Context, args *PutArgs) (location proto.Location, hashSumMap HashSumMap, err error) {
@@ -789,6 +819,20 @@ func (c *client) Delete(ctx context.Context, args *DeleteArgs) ([]proto.Location
 	return nil, nil
 }
 
+func (c *client) Presign(ctx context.Context, args *PresignArgs) (string, error) {
+	if !args.IsValid() {
+		return "", errcode.ErrIllegalArguments
+	}
+	rpcClient := c.rpcClient.Load().(rpc.Client)
+
+	ctx = withReqidContext(ctx)
+	resp := &PresignResp{}
+	if err := rpcClient.PostWith(ctx, "/presign", resp, args); err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
 func shouldRetry(code int, err error) bool {
 	if err != nil {
 		if httpErr, ok := err.(rpc.HTTPError); ok {
@@ -29,6 +29,7 @@ const (
 const (
 	_ ctxKey = iota
 	reqidKey
+	consistencyKey
 )
 
 var ClientWithReqidContext = withReqidContext
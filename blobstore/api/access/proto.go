@@ -15,6 +15,7 @@
 package access
 
 import (
+	"context"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -22,9 +23,12 @@ import (
 	"hash"
 	"hash/crc32"
 	"io"
+	"math"
+	"time"
 
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/security"
 )
 
 // HashAlgorithm hash.Hash algorithm when uploading data
@@ -278,12 +282,56 @@ type AllocResp struct {
 	Tokens   []string       `json:"tokens"`
 }
 
+// ConsistencyLevel consistency level of reading, trading latency for freshness
+type ConsistencyLevel uint8
+
+const (
+	// ConsistencyEventual reads with cached route and location,
+	// which may be stale in bounded expiration of the cache
+	ConsistencyEventual = ConsistencyLevel(iota)
+	// ConsistencyReadAfterWrite reads with the latest route and location
+	// re-fetched from shardnode leader or clustermgr
+	ConsistencyReadAfterWrite
+	consistencyMax
+)
+
+// IsValid is valid consistency level
+func (c ConsistencyLevel) IsValid() bool {
+	return c < consistencyMax
+}
+
+func (c ConsistencyLevel) String() string {
+	switch c {
+	case ConsistencyEventual:
+		return "eventual"
+	case ConsistencyReadAfterWrite:
+		return "read-after-write"
+	default:
+		return "unknown"
+	}
+}
+
+// WithConsistencyLevel sets consistency level of reading in context
+func WithConsistencyLevel(ctx context.Context, level ConsistencyLevel) context.Context {
+	return context.WithValue(ctx, consistencyKey, level)
+}
+
+// ConsistencyLevelFromContext returns consistency level of reading in context,
+// default is ConsistencyEventual
+func ConsistencyLevelFromContext(ctx context.Context) ConsistencyLevel {
+	if level, ok := ctx.Value(consistencyKey).(ConsistencyLevel); ok {
+		return level
+	}
+	return ConsistencyEventual
+}
+
 // GetArgs for service /get
 type GetArgs struct {
-	Location proto.Location `json:"location"`
-	Offset   uint64         `json:"offset"`
-	ReadSize uint64         `json:"read_size"`
-	Writer   io.Writer      `json:"-"`
+	Location    proto.Location   `json:"location"`
+	Offset      uint64           `json:"offset"`
+	ReadSize    uint64           `json:"read_size"`
+	Consistency ConsistencyLevel `json:"consistency,omitempty"`
+	Writer      io.Writer        `json:"-"`
 }
 
 // IsValid is valid get args
@@ -291,7 +339,8 @@ func (args *GetArgs) IsValid() bool {
 	if args == nil {
 		return false
 	}
-	return args.Offset <= args.Location.Size_ &&
+	return args.Consistency.IsValid() &&
+		args.Offset <= args.Location.Size_ &&
 		args.ReadSize <= args.Location.Size_ &&
 		args.Offset+args.ReadSize <= args.Location.Size_
 }
@@ -355,6 +404,83 @@ type SignResp struct {
 	Location proto.Location `json:"location"`
 }
 
+// PresignArgs for service /presign
+// mint a time-limited token restricted to the operation of one blob,
+// Location is the signed location of the blob, having only one blob in one slice
+type PresignArgs struct {
+	Op          security.PresignOp `json:"op"`
+	Location    proto.Location     `json:"location"`
+	ExpirationS int64              `json:"expiration_s"`
+}
+
+// IsValid is valid presign args
+func (args *PresignArgs) IsValid() bool {
+	if args == nil {
+		return false
+	}
+	loc := &args.Location
+	return args.Op.IsValid() &&
+		loc.ClusterID > proto.ClusterID(0) &&
+		loc.Size_ > 0 && loc.Size_ <= math.MaxUint32 &&
+		len(loc.Slices) == 1 && loc.Slices[0].Count == 1 &&
+		loc.Slices[0].Vid > proto.Vid(0) &&
+		loc.Slices[0].MinSliceID > proto.BlobID(0) &&
+		args.ExpirationS > 0 &&
+		time.Duration(args.ExpirationS)*time.Second <= security.PresignMaxExpiration
+}
+
+// Token returns the presign token of the blob in location
+func (args *PresignArgs) Token(secretKey []byte) security.PresignToken {
+	loc := &args.Location
+	return security.NewPresignToken(args.Op, loc.ClusterID, loc.CodeMode, loc.Slices[0].Vid,
+		loc.Slices[0].MinSliceID, uint32(loc.Size_), time.Duration(args.ExpirationS)*time.Second, secretKey)
+}
+
+// PresignResp presign response token
+type PresignResp struct {
+	Token string `json:"token"`
+}
+
+// PresignedArgs for service /presigned/get and /presigned/delete
+// the blob is accessed with the token minted by /presign
+type PresignedArgs struct {
+	ClusterID proto.ClusterID   `json:"clusterid"`
+	CodeMode  codemode.CodeMode `json:"codemode"`
+	Vid       proto.Vid         `json:"volumeid"`
+	BlobID    proto.BlobID      `json:"blobid"`
+	Size      int64             `json:"size"`
+	Offset    uint64            `json:"offset"`
+	ReadSize  uint64            `json:"read_size"`
+	Token     string            `json:"token"`
+}
+
+// IsValid is valid presigned args
+func (args *PresignedArgs) IsValid() bool {
+	if args == nil {
+		return false
+	}
+	return args.ClusterID > proto.ClusterID(0) &&
+		args.Vid > proto.Vid(0) &&
+		args.BlobID > proto.BlobID(0) &&
+		args.Size > 0 && args.Size <= math.MaxUint32 &&
+		args.Offset+args.ReadSize <= uint64(args.Size)
+}
+
+// Location returns the single blob location of presigned args
+func (args *PresignedArgs) Location() proto.Location {
+	return proto.Location{
+		ClusterID: args.ClusterID,
+		CodeMode:  args.CodeMode,
+		Size_:     uint64(args.Size),
+		SliceSize: uint32(args.Size),
+		Slices: []proto.Slice{{
+			MinSliceID: args.BlobID,
+			Vid:        args.Vid,
+			Count:      1,
+		}},
+	}
+}
+
 // Shardnode Blob
 
 type GetShardMode int
@@ -371,6 +497,9 @@ type CreateBlobArgs struct {
 	ShardKeys [][]byte
 	Size      uint64
 	SliceSize uint32
+	// Codec of payload, Size is the size of encoded payload, and RawSize is the size before encoded
+	Codec   proto.Codec
+	RawSize uint64
 }
 
 func (args *CreateBlobArgs) IsValid() bool {
@@ -391,6 +520,8 @@ type ListBlobArgs struct {
 	Prefix    []byte
 	Marker    []byte
 	Count     uint64
+	// Vid lists only the blobs which have slices located in the volume if set
+	Vid proto.Vid
 }
 
 func (args *ListBlobArgs) IsValid() bool {
@@ -421,9 +552,10 @@ type GetBlobArgs struct {
 	BlobName  []byte
 	ShardKeys [][]byte
 
-	Offset   uint64
-	ReadSize uint64
-	Writer   io.Writer
+	Offset      uint64
+	ReadSize    uint64
+	Consistency ConsistencyLevel
+	Writer      io.Writer
 }
 
 // IsValid is valid get args
@@ -431,7 +563,7 @@ func (args *GetBlobArgs) IsValid() bool {
 	if args == nil {
 		return false
 	}
-	return args.ClusterID != 0 && len(args.BlobName) != 0
+	return args.ClusterID != 0 && len(args.BlobName) != 0 && args.Consistency.IsValid()
 }
 
 type DelBlobArgs struct {
@@ -447,6 +579,36 @@ func (args *DelBlobArgs) IsValid() bool {
 	return args.ClusterID != 0 && len(args.BlobName) != 0
 }
 
+type UndeleteBlobArgs struct {
+	ClusterID proto.ClusterID
+	BlobName  []byte
+	ShardKeys [][]byte
+}
+
+func (args *UndeleteBlobArgs) IsValid() bool {
+	if args == nil {
+		return false
+	}
+	return args.ClusterID != 0 && len(args.BlobName) != 0
+}
+
+// TransitionBlobArgs re-encodes data of the sealed blob into the colder codemode,
+// then switches the location of blob and releases the old units after a grace period.
+// Nothing of transition is persisted in access, the caller retries until it succeeded.
+type TransitionBlobArgs struct {
+	ClusterID proto.ClusterID
+	BlobName  []byte
+	ShardKeys [][]byte
+	CodeMode  codemode.CodeMode
+}
+
+func (args *TransitionBlobArgs) IsValid() bool {
+	if args == nil {
+		return false
+	}
+	return args.ClusterID != 0 && len(args.BlobName) != 0 && args.CodeMode.IsValid()
+}
+
 type AllocSliceArgs struct {
 	ClusterID proto.ClusterID
 	CodeMode  codemode.CodeMode
@@ -472,12 +634,19 @@ type PutBlobArgs struct {
 	Size   uint64
 	Hashes HashAlgorithm
 	Body   io.Reader
+	// Codec of the payload already encoded, the payload of Size is written as is
+	// and RawSize is the size before encoded, only the sealed blob can be encoded
+	Codec   proto.Codec
+	RawSize uint64
 }
 
 func (args *PutBlobArgs) IsValid() bool {
 	if args == nil {
 		return false
 	}
+	if args.Codec != proto.CodecNone && (!args.NeedSeal || args.RawSize == 0) {
+		return false
+	}
 	return args.CodeMode != 0 && args.Size != 0 && len(args.BlobName) != 0
 }
 
@@ -15,6 +15,7 @@
 package access_test
 
 import (
+	"context"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -357,6 +358,20 @@ func TestGetArgs(t *testing.T) {
 	}
 }
 
+func TestGetArgsConsistency(t *testing.T) {
+	args := access.GetArgs{Consistency: access.ConsistencyReadAfterWrite}
+	require.True(t, args.IsValid())
+	args.Consistency = access.ConsistencyLevel(0xff)
+	require.False(t, args.IsValid())
+	require.Equal(t, "unknown", args.Consistency.String())
+
+	ctx := context.Background()
+	require.Equal(t, access.ConsistencyEventual, access.ConsistencyLevelFromContext(ctx))
+	ctx = access.WithConsistencyLevel(ctx, access.ConsistencyReadAfterWrite)
+	require.Equal(t, access.ConsistencyReadAfterWrite, access.ConsistencyLevelFromContext(ctx))
+	require.Equal(t, "read-after-write", access.ConsistencyLevelFromContext(ctx).String())
+}
+
 func TestDeleteArgs(t *testing.T) {
 	args := access.DeleteArgs{}
 	require.False(t, args.IsValid())
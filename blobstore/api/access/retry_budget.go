@@ -0,0 +1,126 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+const defaultRetryBudgetBurst = 10
+
+// RetryBudgetConfig limits the retries of access hosts in proportion to requests.
+type RetryBudgetConfig struct {
+	// Percent max percent of requests that may be retries, disabled if it <= 0.
+	Percent float64 `json:"percent"`
+	// Burst max retries without requests deposited, default is 10.
+	Burst int `json:"burst"`
+}
+
+// OutlierConfig ejects the access host with consecutive failures for a while.
+type OutlierConfig struct {
+	// ConsecutiveFailures the host is ejected after consecutive failures, disabled if it <= 0.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// EjectionS seconds of ejected host, default is 30s.
+	EjectionS int `json:"ejection_s"`
+	// MaxEjectionPercent max percent of ejected hosts, default is 50.
+	MaxEjectionPercent int `json:"max_ejection_percent"`
+}
+
+var (
+	clientMetricOnce sync.Once
+
+	retryBudgetMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "access_client",
+		Name:      "retry_budget",
+		Help:      "retry budget of access client, requests deposited and retries withdrawn",
+	}, []string{"result"})
+	outlierEjectionMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "access_client",
+		Name:      "outlier_ejection",
+		Help:      "ejected access hosts of access client",
+	}, []string{"host"})
+)
+
+const (
+	budgetResultRequest   = "request"
+	budgetResultRetry     = "retry"
+	budgetResultExhausted = "exhausted"
+)
+
+func registerClientMetrics() {
+	clientMetricOnce.Do(func() {
+		prometheus.MustRegister(retryBudgetMetric, outlierEjectionMetric)
+	})
+}
+
+// retryBudget is a token bucket shared by all requests of the client,
+// every request deposits percent of token, and every retry withdraws one.
+type retryBudget struct {
+	ratio float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+var _ rpc.RetryBudget = (*retryBudget)(nil)
+
+// newRetryBudget returns nil if retry budget is disabled.
+func newRetryBudget(cfg RetryBudgetConfig) *retryBudget {
+	if cfg.Percent <= 0 {
+		return nil
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = defaultRetryBudgetBurst
+	}
+	registerClientMetrics()
+	return &retryBudget{
+		ratio:  cfg.Percent / 100,
+		burst:  float64(cfg.Burst),
+		tokens: float64(cfg.Burst),
+	}
+}
+
+func (b *retryBudget) Request() {
+	b.mu.Lock()
+	b.tokens += b.ratio
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.mu.Unlock()
+	retryBudgetMetric.WithLabelValues(budgetResultRequest).Inc()
+}
+
+func (b *retryBudget) Retry() bool {
+	b.mu.Lock()
+	ok := b.tokens >= 1
+	if ok {
+		b.tokens--
+	}
+	b.mu.Unlock()
+
+	if ok {
+		retryBudgetMetric.WithLabelValues(budgetResultRetry).Inc()
+	} else {
+		retryBudgetMetric.WithLabelValues(budgetResultExhausted).Inc()
+	}
+	return ok
+}
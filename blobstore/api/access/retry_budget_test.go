@@ -0,0 +1,47 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryBudget(t *testing.T) {
+	require.Nil(t, newRetryBudget(RetryBudgetConfig{}))
+
+	b := newRetryBudget(RetryBudgetConfig{Percent: 10, Burst: 2})
+	require.True(t, b.Retry())
+	require.True(t, b.Retry())
+	require.False(t, b.Retry())
+
+	for range [9]struct{}{} {
+		b.Request()
+	}
+	require.False(t, b.Retry())
+	b.Request()
+	b.Request()
+	require.True(t, b.Retry())
+	require.False(t, b.Retry())
+
+	// tokens never exceed burst
+	for range [100]struct{}{} {
+		b.Request()
+	}
+	require.True(t, b.Retry())
+	require.True(t, b.Retry())
+	require.False(t, b.Retry())
+}
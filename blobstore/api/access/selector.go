@@ -0,0 +1,246 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+const (
+	// weight of the latest request in moving average of latency and error rate
+	scoreSmoothing = 0.2
+	// min weight factor of a host failed just now, the host is still be selected rarely
+	scoreMinFactor = 0.05
+
+	defaultOutlierEjectionS          = 30
+	defaultOutlierMaxEjectionPercent = 50
+)
+
+// scoredHost is a host scored with moving average of latency and error rate.
+type scoredHost struct {
+	id      int
+	rawHost string
+
+	mu          sync.Mutex
+	sampled     bool
+	latency     float64   // milliseconds
+	errRate     float64   // [0, 1]
+	failedAt    time.Time // last failed time
+	consecutive int       // consecutive failures
+	ejectedTo   time.Time // ejected as outlier until the time
+}
+
+func (h *scoredHost) ID() int      { return h.id }
+func (h *scoredHost) Host() string { return h.rawHost }
+
+// feedback returns the consecutive failures of the host.
+func (h *scoredHost) feedback(duration time.Duration, failed bool, now time.Time) int {
+	latency := float64(duration) / float64(time.Millisecond)
+	var failure float64
+	if failed {
+		failure = 1
+	}
+
+	h.mu.Lock()
+	if !h.sampled {
+		h.sampled = true
+		h.latency, h.errRate = latency, failure
+	} else {
+		h.latency += scoreSmoothing * (latency - h.latency)
+		h.errRate += scoreSmoothing * (failure - h.errRate)
+	}
+	if failed {
+		h.failedAt = now
+		h.consecutive++
+	} else {
+		h.consecutive = 0
+	}
+	consecutive := h.consecutive
+	h.mu.Unlock()
+	return consecutive
+}
+
+func (h *scoredHost) ejected(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return now.Before(h.ejectedTo)
+}
+
+func (h *scoredHost) eject(to time.Time) {
+	h.mu.Lock()
+	h.ejectedTo = to
+	h.consecutive = 0
+	h.mu.Unlock()
+}
+
+func (h *scoredHost) setFailed(now time.Time) {
+	h.mu.Lock()
+	h.failedAt = now
+	h.mu.Unlock()
+}
+
+// weight of host is higher with lower latency and error rate, the weight of
+// failed host recovers gradually in the recover period.
+func (h *scoredHost) weight(now time.Time, recover time.Duration) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w := 1 / (1 + h.latency)
+	w *= 1 - h.errRate*(1-scoreMinFactor)
+	if recover > 0 && !h.failedAt.IsZero() {
+		if elapsed := now.Sub(h.failedAt); elapsed < recover {
+			w *= math.Max(scoreMinFactor, float64(elapsed)/float64(recover))
+		}
+	}
+	return w
+}
+
+// scoreSelector selects hosts by recent latencies and errors, replacing
+// uniform random selection of rpc default selector. The outlier host with
+// consecutive failures is ejected from available hosts for a while.
+type scoreSelector struct {
+	recover time.Duration
+	outlier OutlierConfig
+	hosts   []*scoredHost
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+var (
+	_ rpc.Selector         = (*scoreSelector)(nil)
+	_ rpc.SelectorFeedback = (*scoreSelector)(nil)
+)
+
+// newScoreSelector returns a selector, failed host is not preferred in recover period,
+// recover is disabled if it is not positive.
+func newScoreSelector(hosts []string, recover time.Duration, outlier OutlierConfig) *scoreSelector {
+	if outlier.ConsecutiveFailures > 0 {
+		if outlier.EjectionS <= 0 {
+			outlier.EjectionS = defaultOutlierEjectionS
+		}
+		if outlier.MaxEjectionPercent <= 0 {
+			outlier.MaxEjectionPercent = defaultOutlierMaxEjectionPercent
+		}
+		registerClientMetrics()
+	}
+	s := &scoreSelector{
+		recover: recover,
+		outlier: outlier,
+		hosts:   make([]*scoredHost, 0, len(hosts)),
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for idx, host := range hosts {
+		s.hosts = append(s.hosts, &scoredHost{id: idx + 1, rawHost: host})
+	}
+	return s
+}
+
+func (s *scoreSelector) GetAllHosts() []rpc.UniqueHost {
+	hosts := make([]rpc.UniqueHost, 0, len(s.hosts))
+	for _, host := range s.hosts {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// GetAvailableHosts returns not ejected hosts in weighted random order,
+// the following hosts are the candidates of retry.
+func (s *scoreSelector) GetAvailableHosts() []rpc.UniqueHost {
+	now := time.Now()
+	hosts := make([]rpc.UniqueHost, 0, len(s.hosts))
+	keys := make([]float64, 0, len(s.hosts))
+	s.mu.Lock()
+	for _, host := range s.hosts {
+		if host.ejected(now) {
+			continue
+		}
+		// weighted random sampling without replacement
+		hosts = append(hosts, host)
+		keys = append(keys, math.Pow(s.rand.Float64(), 1/host.weight(now, s.recover)))
+	}
+	s.mu.Unlock()
+
+	sort.Sort(&hostsByKey{hosts: hosts, keys: keys})
+	return hosts
+}
+
+func (s *scoreSelector) SetFailHost(host rpc.UniqueHost) {
+	if h := s.getHost(host); h != nil {
+		h.setFailed(time.Now())
+	}
+}
+
+func (s *scoreSelector) Feedback(host rpc.UniqueHost, duration time.Duration, failed bool) {
+	h := s.getHost(host)
+	if h == nil {
+		return
+	}
+	now := time.Now()
+	if consecutive := h.feedback(duration, failed, now); s.outlier.ConsecutiveFailures > 0 &&
+		consecutive >= s.outlier.ConsecutiveFailures {
+		s.eject(h, now)
+	}
+}
+
+// eject ejects the host if ejected hosts do not exceed the max percent.
+func (s *scoreSelector) eject(host *scoredHost, now time.Time) {
+	maxEjected := len(s.hosts) * s.outlier.MaxEjectionPercent / 100
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if host.ejected(now) {
+		return
+	}
+	ejected := 0
+	for _, h := range s.hosts {
+		if h.ejected(now) {
+			ejected++
+		}
+	}
+	if ejected >= maxEjected {
+		return
+	}
+	host.eject(now.Add(time.Duration(s.outlier.EjectionS) * time.Second))
+	outlierEjectionMetric.WithLabelValues(host.rawHost).Inc()
+}
+
+func (s *scoreSelector) Close() {}
+
+func (s *scoreSelector) getHost(host rpc.UniqueHost) *scoredHost {
+	idx := host.ID() - 1
+	if idx < 0 || idx >= len(s.hosts) {
+		return nil
+	}
+	return s.hosts[idx]
+}
+
+// hostsByKey sorts hosts by keys in descending order
+type hostsByKey struct {
+	hosts []rpc.UniqueHost
+	keys  []float64
+}
+
+func (h *hostsByKey) Len() int           { return len(h.hosts) }
+func (h *hostsByKey) Less(i, j int) bool { return h.keys[i] > h.keys[j] }
+func (h *hostsByKey) Swap(i, j int) {
+	h.hosts[i], h.hosts[j] = h.hosts[j], h.hosts[i]
+	h.keys[i], h.keys[j] = h.keys[j], h.keys[i]
+}
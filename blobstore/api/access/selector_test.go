@@ -0,0 +1,112 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package access
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func firstHostCount(s *scoreSelector, n int) map[string]int {
+	count := make(map[string]int)
+	for i := 0; i < n; i++ {
+		hosts := s.GetAvailableHosts()
+		count[hosts[0].Host()]++
+	}
+	return count
+}
+
+func TestScoreSelectorLatency(t *testing.T) {
+	s := newScoreSelector([]string{"fast", "slow"}, time.Minute, OutlierConfig{})
+	require.Equal(t, 2, len(s.GetAllHosts()))
+	require.Equal(t, 2, len(s.GetAvailableHosts()))
+
+	fast, slow := s.hosts[0], s.hosts[1]
+	for range [10]struct{}{} {
+		s.Feedback(fast, time.Millisecond, false)
+		s.Feedback(slow, 50*time.Millisecond, false)
+	}
+	count := firstHostCount(s, 1000)
+	require.Less(t, count["slow"], 100)
+	require.Greater(t, count["fast"], 900)
+}
+
+func TestScoreSelectorRecover(t *testing.T) {
+	s := newScoreSelector([]string{"a", "b"}, time.Minute, OutlierConfig{})
+	a, b := s.hosts[0], s.hosts[1]
+	s.Feedback(a, time.Millisecond, false)
+	s.Feedback(b, time.Millisecond, false)
+
+	s.SetFailHost(a)
+	count := firstHostCount(s, 1000)
+	require.Less(t, count["a"], 100)
+	require.Greater(t, count["a"], 0)
+
+	now := time.Now()
+	wFailed := a.weight(now, s.recover)
+	wHalf := a.weight(now.Add(30*time.Second), s.recover)
+	wRecovered := a.weight(now.Add(time.Minute), s.recover)
+	require.Less(t, wFailed, wHalf)
+	require.Less(t, wHalf, wRecovered)
+	require.Equal(t, b.weight(now, s.recover), wRecovered)
+
+	// errors make the host degraded after recover period
+	for range [10]struct{}{} {
+		s.Feedback(a, time.Millisecond, true)
+	}
+	require.Less(t, a.weight(now.Add(time.Minute), s.recover), wRecovered/2)
+
+	// failed host recovers at once without recover period
+	s = newScoreSelector([]string{"a"}, 0, OutlierConfig{})
+	s.SetFailHost(s.hosts[0])
+	require.Equal(t, float64(1), s.hosts[0].weight(time.Now(), s.recover))
+}
+
+func TestScoreSelectorOutlier(t *testing.T) {
+	s := newScoreSelector([]string{"a", "b", "c", "d"}, 0, OutlierConfig{ConsecutiveFailures: 3})
+	require.Equal(t, defaultOutlierEjectionS, s.outlier.EjectionS)
+	a, b, c := s.hosts[0], s.hosts[1], s.hosts[2]
+
+	s.Feedback(a, time.Millisecond, true)
+	s.Feedback(a, time.Millisecond, true)
+	s.Feedback(a, time.Millisecond, false)
+	s.Feedback(a, time.Millisecond, true)
+	require.Equal(t, 4, len(s.GetAvailableHosts()))
+
+	s.Feedback(a, time.Millisecond, true)
+	s.Feedback(a, time.Millisecond, true)
+	require.Equal(t, 3, len(s.GetAvailableHosts()))
+	for _, host := range s.GetAvailableHosts() {
+		require.NotEqual(t, "a", host.Host())
+	}
+
+	// max 50% hosts are ejected
+	for range [3]struct{}{} {
+		s.Feedback(b, time.Millisecond, true)
+		s.Feedback(c, time.Millisecond, true)
+	}
+	require.Equal(t, 2, len(s.GetAvailableHosts()))
+	require.True(t, a.ejected(time.Now()))
+	require.True(t, b.ejected(time.Now()))
+	require.False(t, c.ejected(time.Now()))
+	require.False(t, a.ejected(time.Now().Add(time.Minute)))
+
+	// single host is never ejected
+	s = newScoreSelector([]string{"a"}, 0, OutlierConfig{ConsecutiveFailures: 1})
+	s.Feedback(s.hosts[0], time.Millisecond, true)
+	require.Equal(t, 1, len(s.GetAvailableHosts()))
+}
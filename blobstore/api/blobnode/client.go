@@ -34,7 +34,11 @@ type client struct {
 }
 
 func New(cfg *Config) StorageAPI {
-	return &client{rpc.NewClient(&cfg.Config)}
+	conf := cfg.Config
+	if conf.Runtime.Name == "" {
+		conf.Runtime.Name = "blobnode"
+	}
+	return &client{rpc.NewClient(&conf)}
 }
 
 func (c *client) String(ctx context.Context, host string) string {
@@ -61,6 +65,75 @@ type ConfigReloadArgs struct {
 	Value string `json:"value"`
 }
 
+// DiskQosConfig qos parameters of one disk, zero value means not set
+type DiskQosConfig struct {
+	ReadQueueDepth   int32 `json:"read_queue_depth,omitempty"`
+	WriteQueueDepth  int32 `json:"write_queue_depth,omitempty"`
+	DeleteQueueDepth int32 `json:"delete_queue_depth,omitempty"`
+
+	ReadMBPS       int64 `json:"read_mbps,omitempty"`
+	WriteMBPS      int64 `json:"write_mbps,omitempty"`
+	BackgroundMBPS int64 `json:"background_mbps,omitempty"`
+	ReadDiscard    int32 `json:"read_discard,omitempty"`
+	WriteDiscard   int32 `json:"write_discard,omitempty"`
+}
+
+// Merge sets parameters of other which are set
+func (c *DiskQosConfig) Merge(other DiskQosConfig) {
+	mergeValue(&c.ReadQueueDepth, other.ReadQueueDepth)
+	mergeValue(&c.WriteQueueDepth, other.WriteQueueDepth)
+	mergeValue(&c.DeleteQueueDepth, other.DeleteQueueDepth)
+	mergeValue(&c.ReadMBPS, other.ReadMBPS)
+	mergeValue(&c.WriteMBPS, other.WriteMBPS)
+	mergeValue(&c.BackgroundMBPS, other.BackgroundMBPS)
+	mergeValue(&c.ReadDiscard, other.ReadDiscard)
+	mergeValue(&c.WriteDiscard, other.WriteDiscard)
+}
+
+func mergeValue[T int32 | int64](val *T, other T) {
+	if other > 0 {
+		*val = other
+	}
+}
+
+// DiskQosArgs updates qos parameters of the disk at runtime,
+// the parameters not set keep the last value.
+type DiskQosArgs struct {
+	DiskID proto.DiskID `json:"diskid"`
+	DiskQosConfig
+}
+
+// DiskQosRet effective qos config of the disk and its persisted parameters
+type DiskQosRet struct {
+	DiskID   proto.DiskID  `json:"diskid"`
+	Current  DiskQosConfig `json:"current"`
+	Override DiskQosConfig `json:"override"`
+}
+
+// FaultKind kind of fault injected into reading shards
+type FaultKind string
+
+const (
+	FaultEIO     = FaultKind("eio")     // respond disk broken as EIO of disk
+	FaultCrc     = FaultKind("crc")     // respond crc mismatch as corrupted shard
+	FaultLatency = FaultKind("latency") // delay the read
+)
+
+// FaultRule injects fault into percent of shard reads on the disk or vuid,
+// zero DiskID or Vuid matches all disks or vuids.
+type FaultRule struct {
+	DiskID    proto.DiskID `json:"diskid"`
+	Vuid      proto.Vuid   `json:"vuid"`
+	Kind      FaultKind    `json:"kind"`
+	Percent   int          `json:"percent"`
+	LatencyMS int          `json:"latency_ms,omitempty"`
+}
+
+// FaultInjectArgs replaces all fault rules, clears them if empty
+type FaultInjectArgs struct {
+	Rules []FaultRule `json:"rules"`
+}
+
 type InspectRateArgs struct {
 	Rate int `json:"rate"`
 }
@@ -102,6 +175,8 @@ type StorageAPI interface {
 	StatShard(ctx context.Context, host string, args *StatShardArgs) (si *ShardInfo, err error)
 	MarkDeleteShard(ctx context.Context, host string, args *DeleteShardArgs) (err error)
 	DeleteShard(ctx context.Context, host string, args *DeleteShardArgs) (err error)
+	BatchMarkDeleteShards(ctx context.Context, host string, args *BatchDeleteShardsArgs) (ret *BatchDeleteShardsRet, err error)
+	BatchDeleteShards(ctx context.Context, host string, args *BatchDeleteShardsArgs) (ret *BatchDeleteShardsRet, err error)
 	ListShards(ctx context.Context, host string, args *ListShardsArgs) (sis []*ShardInfo, next proto.BlobID, err error)
 
 	WorkerAPI
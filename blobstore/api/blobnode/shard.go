@@ -16,6 +16,7 @@ package blobnode
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -40,6 +41,8 @@ type ShardInfo struct {
 	Crc    uint32       `json:"crc"`
 	Flag   ShardStatus  `json:"flag"` // 1:normal,2:markDelete
 	Inline bool         `json:"inline"`
+	// Retention is the unix seconds of worm retention, zero is not worm shard.
+	Retention int64 `json:"retention,omitempty"`
 }
 
 type ShardStatus uint8
@@ -61,6 +64,12 @@ type PutShardArgs struct {
 	Size   int64        `json:"size"`
 	Type   IOType       `json:"iotype,omitempty"`
 	Body   io.Reader    `json:"-"`
+	// SpaceID is the worm space of data, the retention of shard
+	// is set by the worm policy of space on blobnode.
+	SpaceID proto.SpaceID `json:"space_id,omitempty"`
+	// Retention is the unix seconds of worm retention kept from the source shards,
+	// only for background io of repairing or migrating, zero is not kept.
+	Retention int64 `json:"retention,omitempty"`
 }
 
 type PutShardRet struct {
@@ -87,6 +96,12 @@ func (c *client) PutShard(ctx context.Context, host string, args *PutShardArgs)
 	}
 	urlStr := fmt.Sprintf("%v/shard/put/diskid/%v/vuid/%v/bid/%v/size/%v?iotype=%d",
 		host, args.DiskID, args.Vuid, args.Bid, args.Size, args.Type)
+	if args.SpaceID > 0 {
+		urlStr = fmt.Sprintf("%s&space_id=%d", urlStr, args.SpaceID)
+	}
+	if args.Retention > 0 {
+		urlStr = fmt.Sprintf("%s&retention=%d", urlStr, args.Retention)
+	}
 	req, err := http.NewRequest(http.MethodPost, urlStr, args.Body)
 	if err != nil {
 		err = convertEIO(err)
@@ -234,6 +249,85 @@ func (c *client) DeleteShard(ctx context.Context, host string, args *DeleteShard
 	return
 }
 
+// MaxBatchDeleteShards max number of shards deleted in one batch
+const MaxBatchDeleteShards = 1024
+
+// ShardID identifies a shard of disk
+type ShardID struct {
+	Vuid proto.Vuid   `json:"vuid"`
+	Bid  proto.BlobID `json:"bid"`
+}
+
+// BatchDeleteShardsArgs marks delete or deletes shards of the same disk in one request
+type BatchDeleteShardsArgs struct {
+	DiskID proto.DiskID `json:"diskid"`
+	Shards []ShardID    `json:"shards"`
+}
+
+// DeleteShardResult result of a shard in batch, Code is zero if succeeded
+type DeleteShardResult struct {
+	ShardID
+	Code  int    `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Err returns error of the shard, its status code is the code of error
+func (r *DeleteShardResult) Err() error {
+	if r.Code == 0 {
+		return nil
+	}
+	return rpc.NewError(r.Code, "", errors.New(r.Error))
+}
+
+// BatchDeleteShardsRet results in the same order of shards in request
+type BatchDeleteShardsRet struct {
+	Results []DeleteShardResult `json:"results"`
+}
+
+// Check checks disk and number of shards
+func (args *BatchDeleteShardsArgs) Check() error {
+	if !IsValidDiskID(args.DiskID) {
+		return bloberr.ErrInvalidDiskId
+	}
+	if len(args.Shards) == 0 || len(args.Shards) > MaxBatchDeleteShards {
+		return bloberr.ErrInvalidParam
+	}
+	shards := make(map[ShardID]struct{}, len(args.Shards))
+	for _, shard := range args.Shards {
+		if _, ok := shards[shard]; ok {
+			return bloberr.ErrInvalidParam
+		}
+		shards[shard] = struct{}{}
+	}
+	return nil
+}
+
+func (c *client) BatchMarkDeleteShards(ctx context.Context, host string, args *BatchDeleteShardsArgs) (
+	ret *BatchDeleteShardsRet, err error,
+) {
+	if err = args.Check(); err != nil {
+		return
+	}
+
+	urlStr := fmt.Sprintf("%v/shard/batch/markdelete", host)
+	ret = &BatchDeleteShardsRet{}
+	err = c.PostWith(ctx, urlStr, ret, args)
+	return
+}
+
+func (c *client) BatchDeleteShards(ctx context.Context, host string, args *BatchDeleteShardsArgs) (
+	ret *BatchDeleteShardsRet, err error,
+) {
+	if err = args.Check(); err != nil {
+		return
+	}
+
+	urlStr := fmt.Sprintf("%v/shard/batch/delete", host)
+	ret = &BatchDeleteShardsRet{}
+	err = c.PostWith(ctx, urlStr, ret, args)
+	return
+}
+
 type StatShardArgs struct {
 	DiskID proto.DiskID `json:"diskid"`
 	Vuid   proto.Vuid   `json:"vuid"`
@@ -23,6 +23,8 @@ import (
 type WorkerStats struct {
 	CancelCount  string `json:"cancel_count"`
 	ReclaimCount string `json:"reclaim_count"`
+	// DiskConcurrency adaptive concurrency of destination disks
+	DiskConcurrency map[proto.DiskID]int `json:"disk_concurrency,omitempty"`
 }
 
 func (c *client) RepairShard(ctx context.Context, host string, args *proto.ShardRepairTask) (err error) {
@@ -0,0 +1,53 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// AlertEvent is emitted when the alert rule starts firing or is resolved,
+// Target is the idc if the rule is evaluated on each idc.
+type AlertEvent struct {
+	Region    string             `json:"region"`
+	ClusterID proto.ClusterID    `json:"cluster_id"`
+	Rule      string             `json:"rule"`
+	Severity  string             `json:"severity,omitempty"`
+	Node      string             `json:"node"`
+	Target    string             `json:"target,omitempty"`
+	Expr      string             `json:"expr"`
+	Status    string             `json:"status"`
+	Values    map[string]float64 `json:"values"`     // values of variables in expression
+	StartTime int64              `json:"start_time"` // unix seconds of firing
+	Time      int64              `json:"time"`       // unix seconds of the event
+}
+
+type ListAlertRet struct {
+	Alerts []AlertEvent `json:"alerts"`
+}
+
+// ListAlert returns the firing alerts evaluated on leader
+func (c *Client) ListAlert(ctx context.Context) (ret *ListAlertRet, err error) {
+	ret = &ListAlertRet{}
+	err = c.GetWith(ctx, "/alert/list", ret)
+	return
+}
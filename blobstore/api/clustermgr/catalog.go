@@ -7,7 +7,10 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
 )
 
 const (
@@ -106,3 +109,42 @@ func (c *Client) GetCatalogChanges(ctx context.Context, args *GetCatalogChangesA
 	err = c.GetWith(ctx, fmt.Sprintf("/catalogchanges/get?route_version=%d&node_id=%d", args.RouteVersion, args.NodeID), ret)
 	return
 }
+
+// GetRouteChecksumArgs args of getting checksum of catalog route in space
+type GetRouteChecksumArgs struct {
+	SpaceID proto.SpaceID `json:"space_id"`
+}
+
+// GetRouteChecksumRet checksum of current catalog route, clients validate
+// cached route with it and fetch route only if the checksum differs
+type GetRouteChecksumRet struct {
+	SpaceID      proto.SpaceID      `json:"space_id"`
+	RouteVersion proto.RouteVersion `json:"route_version"`
+	Checksum     uint64             `json:"checksum"`
+}
+
+func (c *Client) GetRouteChecksum(ctx context.Context, args *GetRouteChecksumArgs) (ret *GetRouteChecksumRet, err error) {
+	ret = &GetRouteChecksumRet{}
+	err = c.GetWith(ctx, "/catalog/route/checksum?space_id="+args.SpaceID.ToString(), ret)
+	return
+}
+
+// ShardRouteHash returns hash of shard route, checksum of catalog route is
+// xor of all shard route hashes, so it can be updated by changed shards only
+func ShardRouteHash(shardID proto.ShardID, version proto.RouteVersion, units []ShardUnit) uint64 {
+	h := fnv.New64a()
+	b := make([]byte, 13)
+	binary.LittleEndian.PutUint32(b, uint32(shardID))
+	binary.LittleEndian.PutUint64(b[4:], uint64(version))
+	h.Write(b[:12])
+	for _, unit := range units {
+		binary.LittleEndian.PutUint64(b, uint64(unit.Suid))
+		binary.LittleEndian.PutUint32(b[8:], uint32(unit.DiskID))
+		b[12] = 0
+		if unit.Learner {
+			b[12] = 1
+		}
+		h.Write(b)
+	}
+	return h.Sum64()
+}
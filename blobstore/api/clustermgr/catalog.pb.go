@@ -28,15 +28,17 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Space struct {
-	SpaceID              github_com_cubefs_cubefs_blobstore_common_proto.SpaceID     `protobuf:"varint,1,opt,name=space_id,json=spaceId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.SpaceID" json:"space_id,omitempty"`
-	Name                 string                                                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Status               github_com_cubefs_cubefs_blobstore_common_proto.SpaceStatus `protobuf:"varint,3,opt,name=status,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.SpaceStatus" json:"status,omitempty"`
-	FieldMetas           []FieldMeta                                                 `protobuf:"bytes,4,rep,name=field_metas,json=fieldMetas,proto3" json:"field_metas"`
-	AccKey               string                                                      `protobuf:"bytes,5,opt,name=acc_key,json=accKey,proto3" json:"acc_key,omitempty"`
-	SecKey               string                                                      `protobuf:"bytes,6,opt,name=sec_key,json=secKey,proto3" json:"sec_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                                    `json:"-"`
-	XXX_unrecognized     []byte                                                      `json:"-"`
-	XXX_sizecache        int32                                                       `json:"-"`
+	SpaceID    github_com_cubefs_cubefs_blobstore_common_proto.SpaceID     `protobuf:"varint,1,opt,name=space_id,json=spaceId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.SpaceID" json:"space_id,omitempty"`
+	Name       string                                                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status     github_com_cubefs_cubefs_blobstore_common_proto.SpaceStatus `protobuf:"varint,3,opt,name=status,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.SpaceStatus" json:"status,omitempty"`
+	FieldMetas []FieldMeta                                                 `protobuf:"bytes,4,rep,name=field_metas,json=fieldMetas,proto3" json:"field_metas"`
+	AccKey     string                                                      `protobuf:"bytes,5,opt,name=acc_key,json=accKey,proto3" json:"acc_key,omitempty"`
+	SecKey     string                                                      `protobuf:"bytes,6,opt,name=sec_key,json=secKey,proto3" json:"sec_key,omitempty"`
+	// shards of worm space can not be overwritten or deleted before retention
+	WormRetentionSecs    uint64   `protobuf:"varint,7,opt,name=worm_retention_secs,json=wormRetentionSecs,proto3" json:"worm_retention_secs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Space) Reset()      { *m = Space{} }
@@ -113,6 +115,13 @@ func (m *Space) GetSecKey() string {
 	return ""
 }
 
+func (m *Space) GetWormRetentionSecs() uint64 {
+	if m != nil {
+		return m.WormRetentionSecs
+	}
+	return 0
+}
+
 type FieldMeta struct {
 	ID                   github_com_cubefs_cubefs_blobstore_common_proto.FieldID     `protobuf:"varint,1,opt,name=id,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.FieldID" json:"id,omitempty"`
 	Name                 string                                                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -186,6 +195,7 @@ func (m *FieldMeta) GetIndexOption() github_com_cubefs_cubefs_blobstore_common_p
 type CreateSpaceArgs struct {
 	Name                 string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	FieldMetas           []FieldMeta `protobuf:"bytes,2,rep,name=field_metas,json=fieldMetas,proto3" json:"field_metas"`
+	WormRetentionSecs    uint64      `protobuf:"varint,3,opt,name=worm_retention_secs,json=wormRetentionSecs,proto3" json:"worm_retention_secs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -237,6 +247,13 @@ func (m *CreateSpaceArgs) GetFieldMetas() []FieldMeta {
 	return nil
 }
 
+func (m *CreateSpaceArgs) GetWormRetentionSecs() uint64 {
+	if m != nil {
+		return m.WormRetentionSecs
+	}
+	return 0
+}
+
 type GetSpaceByNameArgs struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -859,69 +876,71 @@ func init() {
 func init() { proto.RegisterFile("catalog.proto", fileDescriptor_0abbfcf058acdf89) }
 
 var fileDescriptor_0abbfcf058acdf89 = []byte{
-	// 866 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xcf, 0x8f, 0xdb, 0x44,
-	0x14, 0xee, 0xb8, 0x8e, 0xc3, 0xbe, 0xdd, 0x08, 0xb0, 0xb6, 0xc2, 0xf4, 0x60, 0xaf, 0x7c, 0x40,
-	0x11, 0x48, 0xb6, 0xd4, 0x4a, 0xa0, 0x6a, 0xf9, 0x95, 0xec, 0xd2, 0x62, 0x7e, 0x2c, 0xaa, 0x97,
-	0x82, 0x84, 0x40, 0xd1, 0xc4, 0x9e, 0x78, 0xad, 0x8d, 0x3d, 0x96, 0x67, 0x8c, 0x1a, 0x4e, 0x48,
-	0x88, 0x3b, 0xff, 0x03, 0x67, 0x38, 0xf1, 0x47, 0xf4, 0x82, 0xd4, 0x63, 0x0f, 0x60, 0xd1, 0xec,
-	0x3f, 0xc0, 0x39, 0x27, 0x34, 0x33, 0x4e, 0x36, 0x6d, 0x28, 0xab, 0x4d, 0xd9, 0x1e, 0x7a, 0x9b,
-	0xc9, 0xcc, 0xfb, 0xde, 0xf7, 0xbe, 0x79, 0xef, 0x8b, 0xa1, 0x13, 0x61, 0x8e, 0xc7, 0x34, 0xf1,
-	0x8a, 0x92, 0x72, 0x6a, 0x3a, 0x51, 0x35, 0x24, 0x23, 0xe6, 0x0d, 0xc7, 0x74, 0xc8, 0x38, 0x2d,
-	0x89, 0x87, 0x8b, 0xd4, 0x8b, 0xc6, 0x15, 0xe3, 0xa4, 0xcc, 0x92, 0xf2, 0xea, 0x76, 0x42, 0x13,
-	0x2a, 0xef, 0xfa, 0x62, 0xa5, 0xc2, 0xae, 0xbe, 0x9a, 0x50, 0x9a, 0x8c, 0x89, 0x2f, 0x77, 0xc3,
-	0x6a, 0xe4, 0xe3, 0x7c, 0xd2, 0x1c, 0xbd, 0xa1, 0x10, 0xfd, 0x05, 0xa2, 0x8f, 0x8b, 0xd4, 0x3f,
-	0x45, 0xf4, 0xd9, 0x11, 0x2e, 0x63, 0x75, 0xd9, 0xfd, 0x43, 0x83, 0xd6, 0x61, 0x81, 0x23, 0x62,
-	0x62, 0x78, 0x81, 0x89, 0xc5, 0x20, 0x8d, 0x2d, 0xb4, 0x83, 0xba, 0x9d, 0xfe, 0xcd, 0x69, 0xed,
-	0xb4, 0xe5, 0x61, 0xb0, 0x3f, 0xab, 0x9d, 0xb7, 0x92, 0x94, 0x1f, 0x55, 0x43, 0x2f, 0xa2, 0x99,
-	0xdf, 0xa4, 0x58, 0xc9, 0x14, 0xd1, 0x2c, 0xa3, 0xb9, 0x62, 0xe5, 0x35, 0xa1, 0x61, 0x5b, 0xe2,
-	0x06, 0xb1, 0x69, 0x82, 0x9e, 0xe3, 0x8c, 0x58, 0xda, 0x0e, 0xea, 0x6e, 0x84, 0x72, 0x6d, 0x7e,
-	0x09, 0x06, 0xe3, 0x98, 0x57, 0xcc, 0xba, 0x2c, 0x93, 0xbe, 0x37, 0xab, 0x9d, 0xdd, 0xb5, 0x32,
-	0x1d, 0x4a, 0x98, 0xb0, 0x81, 0x33, 0x6f, 0xc3, 0xe6, 0x28, 0x25, 0xe3, 0x78, 0x90, 0x11, 0x8e,
-	0x99, 0xa5, 0xef, 0x5c, 0xee, 0x6e, 0x5e, 0x7b, 0xdd, 0x3b, 0x43, 0x6e, 0xef, 0xa6, 0x88, 0xf9,
-	0x94, 0x70, 0xdc, 0xd7, 0xef, 0xd5, 0xce, 0xa5, 0x10, 0x46, 0xf3, 0x1f, 0x98, 0xf9, 0x0a, 0xb4,
-	0x71, 0x14, 0x0d, 0x8e, 0xc9, 0xc4, 0x6a, 0xc9, 0x12, 0x0c, 0x1c, 0x45, 0x1f, 0x93, 0x89, 0x38,
-	0x60, 0x44, 0x1d, 0x18, 0xea, 0x80, 0x11, 0x71, 0xe0, 0xfe, 0xa6, 0xc1, 0xc6, 0x02, 0xd1, 0xbc,
-	0x0d, 0xda, 0x42, 0xdc, 0xde, 0xb4, 0x76, 0xb4, 0xf5, 0x74, 0x95, 0x80, 0xc1, 0x7e, 0xa8, 0xa5,
-	0xff, 0x2e, 0xe9, 0xd7, 0xa0, 0x48, 0x0f, 0xf8, 0xa4, 0x20, 0x8d, 0xac, 0xef, 0xcc, 0x6a, 0xe7,
-	0xc6, 0x5a, 0x89, 0x3e, 0x9f, 0x14, 0x24, 0xdc, 0x18, 0xcd, 0x97, 0xe6, 0x10, 0xb6, 0xd2, 0x3c,
-	0x26, 0x77, 0x07, 0xb4, 0xe0, 0x29, 0xcd, 0x2d, 0x7d, 0xfd, 0x67, 0x0b, 0x04, 0xce, 0x67, 0x12,
-	0x26, 0xdc, 0x4c, 0x4f, 0x37, 0xee, 0x5d, 0x78, 0x71, 0xaf, 0x24, 0x98, 0x13, 0xf9, 0xb0, 0xbd,
-	0x32, 0x61, 0x8b, 0x42, 0xd1, 0x52, 0xa1, 0x8f, 0x3d, 0xb1, 0xf6, 0xf4, 0x4f, 0xec, 0x76, 0xc1,
-	0xbc, 0x45, 0xb8, 0x4c, 0xdb, 0x9f, 0x1c, 0xe0, 0xec, 0x89, 0xc9, 0xdd, 0x0a, 0x5e, 0x3a, 0xbd,
-	0x19, 0xec, 0xcb, 0x7b, 0x17, 0x3f, 0x43, 0xee, 0x8f, 0x08, 0xb6, 0xe6, 0x79, 0x9f, 0x28, 0xcc,
-	0x32, 0x0f, 0xed, 0x62, 0x78, 0xdc, 0x80, 0x4e, 0xaf, 0xe2, 0x47, 0xff, 0xcd, 0x63, 0x1b, 0x5a,
-	0x9c, 0x1e, 0x93, 0xbc, 0x69, 0x4f, 0xb5, 0x71, 0x7f, 0xd1, 0xe0, 0xca, 0x9e, 0x32, 0xc1, 0xbd,
-	0x23, 0x9c, 0x27, 0xe4, 0x50, 0x18, 0x52, 0x2f, 0x8e, 0x25, 0x6f, 0xb1, 0x7e, 0x5c, 0x3f, 0xf1,
-	0xdb, 0x9a, 0xbc, 0x55, 0x68, 0xd8, 0x96, 0xb8, 0x41, 0x6c, 0x12, 0xe8, 0x94, 0xb4, 0xe2, 0x64,
-	0xf0, 0x2d, 0x29, 0x99, 0xe8, 0x5f, 0x41, 0x4d, 0xef, 0xbf, 0x3f, 0xab, 0x9d, 0xb7, 0xcf, 0x0b,
-	0x1e, 0x0a, 0xa0, 0x2f, 0x14, 0x4e, 0xb8, 0x55, 0x2e, 0xed, 0xcc, 0x8f, 0xa0, 0x55, 0xe5, 0x29,
-	0x17, 0xae, 0x26, 0x9a, 0xd2, 0x3b, 0xb3, 0x29, 0x25, 0xd1, 0x3b, 0x79, 0xca, 0x83, 0x7c, 0x44,
-	0x9b, 0xc6, 0x54, 0x10, 0xee, 0xaf, 0x1a, 0x58, 0xab, 0x7a, 0xdd, 0x29, 0x62, 0xcc, 0xc9, 0x73,
-	0x24, 0xd9, 0x87, 0xa0, 0x8b, 0x7a, 0xa5, 0x61, 0xad, 0xab, 0x98, 0x44, 0x70, 0x7f, 0xd0, 0xe0,
-	0xe5, 0x47, 0x04, 0x0b, 0x38, 0xc9, 0x56, 0xcb, 0x40, 0x17, 0x52, 0xc6, 0x37, 0xa0, 0x4b, 0xdf,
-	0x55, 0x73, 0x17, 0xcc, 0x6a, 0xe7, 0x83, 0xf3, 0xa2, 0xaf, 0x70, 0x97, 0x1e, 0x2c, 0x61, 0xcd,
-	0x2e, 0xe8, 0x29, 0x27, 0x59, 0xa3, 0xd2, 0xb6, 0xa7, 0xbe, 0x03, 0xbc, 0xf9, 0x77, 0x80, 0xd7,
-	0xcb, 0x27, 0xa1, 0xbc, 0xe1, 0xfe, 0x89, 0xe0, 0xca, 0x2d, 0xc2, 0x1f, 0x01, 0x63, 0x72, 0x54,
-	0x9f, 0x99, 0x12, 0xed, 0x9c, 0xc6, 0x4b, 0x26, 0xb4, 0x3f, 0xad, 0x1d, 0xe3, 0x80, 0xc6, 0xca,
-	0x83, 0xde, 0x3c, 0x6f, 0x2a, 0x15, 0x19, 0x1a, 0x02, 0x34, 0x88, 0xdd, 0xdf, 0x11, 0x6c, 0xaf,
-	0xd4, 0x17, 0x12, 0xfe, 0xac, 0xca, 0x3b, 0x80, 0x96, 0xd0, 0x79, 0xfe, 0xbf, 0x73, 0xed, 0xcc,
-	0x86, 0x5d, 0x79, 0xd6, 0xf9, 0x98, 0x4b, 0x18, 0xf7, 0x3b, 0xe8, 0x7c, 0x92, 0xb2, 0x25, 0x67,
-	0x3f, 0x04, 0x23, 0xc3, 0xe5, 0x31, 0x29, 0x9b, 0xc1, 0xde, 0x7d, 0x1a, 0xe3, 0x6e, 0xa0, 0x84,
-	0x25, 0x47, 0xb4, 0xca, 0xb9, 0x7a, 0x92, 0x50, 0x6d, 0xdc, 0x9f, 0x11, 0x6c, 0x2d, 0x92, 0x0b,
-	0x0d, 0xdf, 0x05, 0x43, 0x3a, 0x3d, 0xb3, 0x90, 0xac, 0xee, 0xb5, 0xb3, 0xc7, 0x51, 0x86, 0x36,
-	0x51, 0x4b, 0xdc, 0xb5, 0xff, 0x8d, 0x7b, 0xff, 0xfa, 0x83, 0x87, 0xf6, 0xa5, 0xbf, 0x1f, 0xda,
-	0xe8, 0xfb, 0xa9, 0x8d, 0xee, 0x4d, 0x6d, 0x74, 0x7f, 0x6a, 0xa3, 0xbf, 0xa6, 0x36, 0xfa, 0xe9,
-	0xc4, 0x46, 0xf7, 0x4f, 0x6c, 0xf4, 0xe0, 0xc4, 0x46, 0x5f, 0x75, 0x3c, 0x7f, 0xf7, 0x94, 0xd7,
-	0xd0, 0x90, 0x58, 0xd7, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x51, 0xaa, 0x27, 0x88, 0x78, 0x0b,
-	0x00, 0x00,
+	// 900 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0x4f, 0x8f, 0xdb, 0x44,
+	0x14, 0xef, 0x78, 0x9d, 0x84, 0x9d, 0xdd, 0x08, 0x6a, 0xb6, 0xc2, 0xf4, 0x60, 0xaf, 0x7c, 0x40,
+	0x11, 0x48, 0xb6, 0xd4, 0x4a, 0xa0, 0x6a, 0xf9, 0x97, 0xec, 0xd2, 0x62, 0xfe, 0x2c, 0xaa, 0x43,
+	0x41, 0x42, 0xa0, 0x68, 0x62, 0xbf, 0x78, 0xad, 0x8d, 0x3d, 0x91, 0x67, 0x0c, 0x84, 0x13, 0x12,
+	0xe2, 0xc6, 0x81, 0xef, 0xc0, 0x85, 0x0b, 0x9c, 0xf8, 0x10, 0xbd, 0x20, 0xf5, 0xd8, 0x0b, 0x16,
+	0xcd, 0x7e, 0x01, 0xce, 0x39, 0xa1, 0x99, 0x71, 0xb2, 0x69, 0xc3, 0x12, 0x6d, 0xe8, 0xee, 0x81,
+	0xdb, 0x4c, 0xde, 0xbc, 0xdf, 0xfb, 0xbd, 0xdf, 0xfb, 0x13, 0xe3, 0x66, 0x48, 0x38, 0x19, 0xd2,
+	0xd8, 0x1d, 0xe5, 0x94, 0x53, 0xc3, 0x0e, 0x8b, 0x3e, 0x0c, 0x98, 0xdb, 0x1f, 0xd2, 0x3e, 0xe3,
+	0x34, 0x07, 0x97, 0x8c, 0x12, 0x37, 0x1c, 0x16, 0x8c, 0x43, 0x9e, 0xc6, 0xf9, 0xf5, 0x9d, 0x98,
+	0xc6, 0x54, 0xbe, 0xf5, 0xc4, 0x49, 0xb9, 0x5d, 0x7f, 0x31, 0xa6, 0x34, 0x1e, 0x82, 0x27, 0x6f,
+	0xfd, 0x62, 0xe0, 0x91, 0x6c, 0x5c, 0x99, 0x5e, 0x51, 0x88, 0xde, 0x1c, 0xd1, 0x23, 0xa3, 0xc4,
+	0x3b, 0x45, 0xf4, 0xd8, 0x11, 0xc9, 0x23, 0xf5, 0xd8, 0xf9, 0x61, 0x03, 0xd7, 0xba, 0x23, 0x12,
+	0x82, 0x41, 0xf0, 0x33, 0x4c, 0x1c, 0x7a, 0x49, 0x64, 0xa2, 0x5d, 0xd4, 0x6a, 0x76, 0x6e, 0x4f,
+	0x4a, 0xbb, 0x21, 0x8d, 0xfe, 0xc1, 0xb4, 0xb4, 0x5f, 0x8b, 0x13, 0x7e, 0x54, 0xf4, 0xdd, 0x90,
+	0xa6, 0x5e, 0x15, 0x62, 0x29, 0x52, 0x48, 0xd3, 0x94, 0x66, 0x8a, 0x95, 0x5b, 0xb9, 0x06, 0x0d,
+	0x89, 0xeb, 0x47, 0x86, 0x81, 0xf5, 0x8c, 0xa4, 0x60, 0x6a, 0xbb, 0xa8, 0xb5, 0x19, 0xc8, 0xb3,
+	0xf1, 0x29, 0xae, 0x33, 0x4e, 0x78, 0xc1, 0xcc, 0x0d, 0x19, 0xf4, 0xad, 0x69, 0x69, 0xef, 0xad,
+	0x15, 0xa9, 0x2b, 0x61, 0x82, 0x0a, 0xce, 0xb8, 0x8b, 0xb7, 0x06, 0x09, 0x0c, 0xa3, 0x5e, 0x0a,
+	0x9c, 0x30, 0x53, 0xdf, 0xdd, 0x68, 0x6d, 0xdd, 0x78, 0xd9, 0x5d, 0x21, 0xb7, 0x7b, 0x5b, 0xf8,
+	0x7c, 0x08, 0x9c, 0x74, 0xf4, 0xfb, 0xa5, 0x7d, 0x25, 0xc0, 0x83, 0xd9, 0x0f, 0xcc, 0x78, 0x01,
+	0x37, 0x48, 0x18, 0xf6, 0x8e, 0x61, 0x6c, 0xd6, 0x64, 0x0a, 0x75, 0x12, 0x86, 0xef, 0xc3, 0x58,
+	0x18, 0x18, 0x28, 0x43, 0x5d, 0x19, 0x18, 0x48, 0x83, 0x8b, 0x9f, 0xff, 0x8a, 0xe6, 0x69, 0x2f,
+	0x07, 0x0e, 0x19, 0x4f, 0x68, 0xd6, 0x63, 0x10, 0x32, 0xb3, 0xb1, 0x8b, 0x5a, 0x7a, 0x70, 0x55,
+	0x98, 0x82, 0x99, 0xa5, 0x0b, 0x21, 0x73, 0x7e, 0xd3, 0xf0, 0xe6, 0x9c, 0x81, 0x71, 0x17, 0x6b,
+	0xf3, 0x62, 0xb4, 0x27, 0xa5, 0xad, 0xad, 0x57, 0x07, 0x09, 0xe8, 0x1f, 0x04, 0x5a, 0xf2, 0xcf,
+	0x25, 0xf8, 0x1c, 0xab, 0x24, 0x7b, 0x7c, 0x3c, 0x82, 0xaa, 0x0c, 0x6f, 0x4c, 0x4b, 0xfb, 0xd6,
+	0x5a, 0x81, 0x3e, 0x1e, 0x8f, 0x20, 0xd8, 0x1c, 0xcc, 0x8e, 0x46, 0x1f, 0x6f, 0x27, 0x59, 0x04,
+	0x5f, 0xf7, 0xe8, 0x48, 0xa4, 0x69, 0xea, 0xeb, 0x97, 0xd9, 0x17, 0x38, 0x1f, 0x49, 0x98, 0x60,
+	0x2b, 0x39, 0xbd, 0x38, 0x3f, 0x23, 0xfc, 0xec, 0x7e, 0x0e, 0x84, 0x83, 0xec, 0x84, 0x76, 0x1e,
+	0xb3, 0x79, 0xa6, 0x68, 0x21, 0xd3, 0x27, 0x7a, 0x42, 0x7b, 0x0a, 0x3d, 0x71, 0x46, 0x85, 0x37,
+	0xce, 0xaa, 0x70, 0x0b, 0x1b, 0x77, 0x80, 0x4b, 0x9a, 0x9d, 0xf1, 0x21, 0x49, 0xcf, 0x24, 0xeb,
+	0x14, 0xf8, 0xb9, 0xd3, 0x97, 0xfe, 0x81, 0x7c, 0x77, 0xf1, 0x43, 0xea, 0x7c, 0x8f, 0xf0, 0xf6,
+	0x2c, 0xee, 0x99, 0x42, 0x2e, 0xf2, 0xd0, 0x2e, 0x86, 0xc7, 0x2d, 0xdc, 0x6c, 0x17, 0xfc, 0xe8,
+	0xdf, 0x79, 0xec, 0xe0, 0x1a, 0xa7, 0xc7, 0x90, 0x55, 0xfd, 0xac, 0x2e, 0xce, 0x2f, 0x1a, 0xbe,
+	0xb6, 0xaf, 0xb6, 0xec, 0xfe, 0x11, 0xc9, 0x62, 0xe8, 0x8a, 0x8d, 0xd7, 0x8e, 0x22, 0xc9, 0x5b,
+	0x9c, 0x9f, 0xd4, 0x4f, 0xfc, 0xb6, 0x26, 0x6f, 0xe5, 0x1a, 0x34, 0x24, 0xae, 0x1f, 0x19, 0x80,
+	0x9b, 0x39, 0x2d, 0x38, 0xf4, 0xbe, 0x84, 0x9c, 0x25, 0x54, 0x51, 0xd3, 0x3b, 0x6f, 0x4f, 0x4b,
+	0xfb, 0xf5, 0xf3, 0x82, 0x07, 0x02, 0xe8, 0x13, 0x85, 0x13, 0x6c, 0xe7, 0x0b, 0x37, 0xe3, 0x3d,
+	0x5c, 0x2b, 0xb2, 0x84, 0x8b, 0x4e, 0x13, 0x4d, 0xec, 0xae, 0x6c, 0x62, 0x49, 0xf4, 0x5e, 0x96,
+	0x70, 0x3f, 0x1b, 0xd0, 0xaa, 0x91, 0x15, 0x84, 0xf3, 0xab, 0x86, 0xcd, 0x65, 0xbd, 0xee, 0x8d,
+	0x22, 0xc2, 0xe1, 0x7f, 0x24, 0xd9, 0xbb, 0x58, 0x17, 0xf9, 0xca, 0xd9, 0x5c, 0x57, 0x31, 0x89,
+	0xe0, 0x7c, 0xa7, 0xe1, 0xab, 0x8f, 0x09, 0xe6, 0x73, 0x48, 0x97, 0xd3, 0x40, 0x17, 0x92, 0xc6,
+	0x17, 0x58, 0x97, 0x8b, 0x5a, 0xcd, 0x9d, 0x3f, 0x2d, 0xed, 0x77, 0xce, 0x8b, 0xbe, 0xc4, 0x5d,
+	0x2e, 0x6d, 0x09, 0x6b, 0xb4, 0xb0, 0x9e, 0x70, 0x48, 0x2b, 0x95, 0x76, 0x5c, 0xf5, 0xa1, 0xe1,
+	0xce, 0x3e, 0x34, 0xdc, 0x76, 0x36, 0x0e, 0xe4, 0x0b, 0xe7, 0x0f, 0x84, 0xaf, 0xdd, 0x01, 0xfe,
+	0x18, 0x18, 0x93, 0xa3, 0x7a, 0x69, 0x4a, 0x34, 0x32, 0x1a, 0x2d, 0x2c, 0xa1, 0x83, 0x49, 0x69,
+	0xd7, 0x0f, 0x69, 0xa4, 0x76, 0xd0, 0xab, 0xe7, 0x0d, 0xa5, 0x3c, 0x83, 0xba, 0x00, 0xf5, 0x23,
+	0xe7, 0x77, 0x84, 0x77, 0x96, 0xf2, 0x0b, 0x80, 0x5f, 0x56, 0x7a, 0x87, 0xb8, 0x26, 0x74, 0x9e,
+	0xfd, 0x4f, 0xdd, 0x58, 0xd9, 0xb0, 0x4b, 0x65, 0x9d, 0x8d, 0xb9, 0x84, 0x71, 0xbe, 0xc1, 0xcd,
+	0x0f, 0x12, 0xb6, 0xb0, 0xd9, 0xbb, 0xb8, 0x9e, 0x92, 0xfc, 0x18, 0xf2, 0x6a, 0xb0, 0xf7, 0xfe,
+	0xcb, 0xe2, 0xae, 0xa0, 0xc4, 0x4a, 0x0e, 0x69, 0x91, 0x71, 0x55, 0x92, 0x40, 0x5d, 0x9c, 0x9f,
+	0x10, 0xde, 0x9e, 0x07, 0x17, 0x1a, 0xbe, 0x89, 0xeb, 0x72, 0xd3, 0x33, 0x13, 0xc9, 0xec, 0x5e,
+	0x5a, 0x3d, 0x8e, 0xd2, 0xb5, 0xf2, 0x5a, 0xe0, 0xae, 0x3d, 0x35, 0xee, 0x9d, 0x9b, 0x0f, 0x1f,
+	0x59, 0x57, 0xfe, 0x7a, 0x64, 0xa1, 0x6f, 0x27, 0x16, 0xba, 0x3f, 0xb1, 0xd0, 0x83, 0x89, 0x85,
+	0xfe, 0x9c, 0x58, 0xe8, 0xc7, 0x13, 0x0b, 0x3d, 0x38, 0xb1, 0xd0, 0xc3, 0x13, 0x0b, 0x7d, 0xd6,
+	0x74, 0xbd, 0xbd, 0x53, 0x5e, 0xfd, 0xba, 0xc4, 0xba, 0xf9, 0xf7, 0x00, 0xb2, 0xcd, 0x15, 0xa8,
+	0xd9, 0x0b, 0x00, 0x00,
 }
 
 func (this *Space) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 10)
+	s := make([]string, 0, 11)
 	s = append(s, "&clustermgr.Space{")
 	s = append(s, "SpaceID: "+fmt.Sprintf("%#v", this.SpaceID)+",\n")
 	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
@@ -935,6 +954,7 @@ func (this *Space) GoString() string {
 	}
 	s = append(s, "AccKey: "+fmt.Sprintf("%#v", this.AccKey)+",\n")
 	s = append(s, "SecKey: "+fmt.Sprintf("%#v", this.SecKey)+",\n")
+	s = append(s, "WormRetentionSecs: "+fmt.Sprintf("%#v", this.WormRetentionSecs)+",\n")
 	if this.XXX_unrecognized != nil {
 		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
 	}
@@ -961,7 +981,7 @@ func (this *CreateSpaceArgs) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 7)
 	s = append(s, "&clustermgr.CreateSpaceArgs{")
 	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
 	if this.FieldMetas != nil {
@@ -971,6 +991,7 @@ func (this *CreateSpaceArgs) GoString() string {
 		}
 		s = append(s, "FieldMetas: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
+	s = append(s, "WormRetentionSecs: "+fmt.Sprintf("%#v", this.WormRetentionSecs)+",\n")
 	if this.XXX_unrecognized != nil {
 		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
 	}
@@ -1180,6 +1201,11 @@ func (m *Space) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.WormRetentionSecs != 0 {
+		i = encodeVarintCatalog(dAtA, i, uint64(m.WormRetentionSecs))
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.SecKey) > 0 {
 		i -= len(m.SecKey)
 		copy(dAtA[i:], m.SecKey)
@@ -1301,6 +1327,11 @@ func (m *CreateSpaceArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.WormRetentionSecs != 0 {
+		i = encodeVarintCatalog(dAtA, i, uint64(m.WormRetentionSecs))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.FieldMetas) > 0 {
 		for iNdEx := len(m.FieldMetas) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1825,6 +1856,9 @@ func (m *Space) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCatalog(uint64(l))
 	}
+	if m.WormRetentionSecs != 0 {
+		n += 1 + sovCatalog(uint64(m.WormRetentionSecs))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1872,6 +1906,9 @@ func (m *CreateSpaceArgs) Size() (n int) {
 			n += 1 + l + sovCatalog(uint64(l))
 		}
 	}
+	if m.WormRetentionSecs != 0 {
+		n += 1 + sovCatalog(uint64(m.WormRetentionSecs))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2114,6 +2151,7 @@ func (this *Space) String() string {
 		`FieldMetas:` + repeatedStringForFieldMetas + `,`,
 		`AccKey:` + fmt.Sprintf("%v", this.AccKey) + `,`,
 		`SecKey:` + fmt.Sprintf("%v", this.SecKey) + `,`,
+		`WormRetentionSecs:` + fmt.Sprintf("%v", this.WormRetentionSecs) + `,`,
 		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
 		`}`,
 	}, "")
@@ -2145,6 +2183,7 @@ func (this *CreateSpaceArgs) String() string {
 	s := strings.Join([]string{`&CreateSpaceArgs{`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`FieldMetas:` + repeatedStringForFieldMetas + `,`,
+		`WormRetentionSecs:` + fmt.Sprintf("%v", this.WormRetentionSecs) + `,`,
 		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
 		`}`,
 	}, "")
@@ -2503,6 +2542,25 @@ func (m *Space) Unmarshal(dAtA []byte) error {
 			}
 			m.SecKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WormRetentionSecs", wireType)
+			}
+			m.WormRetentionSecs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCatalog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WormRetentionSecs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCatalog(dAtA[iNdEx:])
@@ -2760,6 +2818,25 @@ func (m *CreateSpaceArgs) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WormRetentionSecs", wireType)
+			}
+			m.WormRetentionSecs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCatalog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WormRetentionSecs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCatalog(dAtA[iNdEx:])
@@ -0,0 +1,80 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+// ChunkUsage space usage of chunk reported by blobnode periodically
+type ChunkUsage struct {
+	Vuid       proto.Vuid `json:"vuid"`
+	Allocated  uint64     `json:"allocated"`   // ChunkSize
+	Used       uint64     `json:"used"`        // physical size of chunk file
+	ShardCount uint64     `json:"shard_count"` // number of normal shards
+}
+
+// DiskChunkUsage usage of all chunks on the disk
+type DiskChunkUsage struct {
+	DiskID proto.DiskID `json:"disk_id"`
+	Chunks []ChunkUsage `json:"chunks"`
+}
+
+func (d *DiskChunkUsage) Sum() (allocated, used, shardCount uint64) {
+	for _, chunk := range d.Chunks {
+		allocated += chunk.Allocated
+		used += chunk.Used
+		shardCount += chunk.ShardCount
+	}
+	return
+}
+
+type ReportChunkUsageArgs struct {
+	Disks []DiskChunkUsage `json:"disks"`
+}
+
+// DiskUsageMismatch the disk whose heartbeat free space disagrees with
+// the sum of chunk usage beyond the tolerance
+type DiskUsageMismatch struct {
+	DiskID         proto.DiskID `json:"disk_id"`
+	Host           string       `json:"host"`
+	Size           int64        `json:"size"`
+	HeartbeatFree  int64        `json:"heartbeat_free"`
+	ChunkCount     int          `json:"chunk_count"`
+	ChunkAllocated uint64       `json:"chunk_allocated"`
+	ChunkUsed      uint64       `json:"chunk_used"`
+	ShardCount     uint64       `json:"shard_count"`
+	Diff           int64        `json:"diff"`        // Size - ChunkUsed - HeartbeatFree
+	ReportTime     int64        `json:"report_time"` // unix seconds of chunk usage reported
+}
+
+type ListDiskUsageMismatchRet struct {
+	Disks []DiskUsageMismatch `json:"disks"`
+}
+
+// ReportChunkUsage report usage of all chunks of disks, the last report of disk replaces the previous
+func (c *Client) ReportChunkUsage(ctx context.Context, args *ReportChunkUsageArgs) (err error) {
+	err = c.PostWith(ctx, "/chunk/usage/report", nil, args)
+	return
+}
+
+// ListDiskUsageMismatch returns the mismatched disks of the last reconciliation on leader
+func (c *Client) ListDiskUsageMismatch(ctx context.Context) (ret *ListDiskUsageMismatchRet, err error) {
+	ret = &ListDiskUsageMismatchRet{}
+	err = c.GetWith(ctx, "/disk/usage/mismatch/list", ret)
+	return
+}
@@ -36,6 +36,7 @@ var retryCodes = []int{
 	http.StatusBadGateway,
 	http.StatusGatewayTimeout,
 	int(errors.ErrRaftReadIndex),
+	errors.CodeWriteStall,
 }
 
 type MemberType uint8
@@ -64,7 +65,11 @@ func New(cfg *Config) *Client {
 	if cfg.ShouldRetry == nil {
 		cfg.ShouldRetry = defaultShouldRetry
 	}
-	return &Client{rpc.NewLbClient(&cfg.LbConfig, nil)}
+	lbConfig := cfg.LbConfig
+	if lbConfig.Runtime.Name == "" {
+		lbConfig.Runtime.Name = "clustermgr"
+	}
+	return &Client{rpc.NewLbClient(&lbConfig, nil)}
 }
 
 type BidScopeArgs struct {
@@ -118,6 +123,55 @@ func (c *Client) RemoveMember(ctx context.Context, peerID uint64) (err error) {
 	return
 }
 
+// ReplaceMemberStage stage of member replacement
+type ReplaceMemberStage string
+
+const (
+	ReplaceMemberStageAddLearner ReplaceMemberStage = "add_learner"
+	ReplaceMemberStageCatchUp    ReplaceMemberStage = "catch_up"
+	ReplaceMemberStagePromote    ReplaceMemberStage = "promote"
+	ReplaceMemberStageRemoveOld  ReplaceMemberStage = "remove_old"
+	ReplaceMemberStageDone       ReplaceMemberStage = "done"
+	ReplaceMemberStageFailed     ReplaceMemberStage = "failed"
+)
+
+// ReplaceMemberArgs replace the old member with the new one
+type ReplaceMemberArgs struct {
+	OldPeerID uint64 `json:"old_peer_id"`
+	PeerID    uint64 `json:"peer_id"`
+	Host      string `json:"host"`
+	NodeHost  string `json:"node_host"`
+}
+
+// ReplaceMemberStatus progress of the member replacement
+type ReplaceMemberStatus struct {
+	ReplaceMemberArgs
+	Stage ReplaceMemberStage `json:"stage"`
+	// entries the new member lags behind the commit of leader
+	Lag      uint64 `json:"lag"`
+	Error    string `json:"error,omitempty"`
+	StartAt  int64  `json:"start_at"` // unix seconds
+	UpdateAt int64  `json:"update_at"`
+}
+
+func (s *ReplaceMemberStatus) Finished() bool {
+	return s.Stage == ReplaceMemberStageDone || s.Stage == ReplaceMemberStageFailed
+}
+
+// ReplaceMember replace raft member in background: add the new member as learner,
+// wait for it caught up, promote it and then remove the old member
+func (c *Client) ReplaceMember(ctx context.Context, args *ReplaceMemberArgs) (err error) {
+	err = c.PostWith(ctx, "/member/replace", nil, args)
+	return
+}
+
+// ReplaceMemberStatus returns progress of the last member replacement
+func (c *Client) ReplaceMemberStatus(ctx context.Context) (ret *ReplaceMemberStatus, err error) {
+	ret = &ReplaceMemberStatus{}
+	err = c.GetWith(ctx, "/member/replace/status", ret)
+	return
+}
+
 // RemoveMember remove member from raft cluster
 func (c *Client) TransferLeadership(ctx context.Context, transfereeID uint64) (err error) {
 	err = c.PostWith(ctx, "/leadership/transfer", nil, &RemoveMemberArgs{PeerID: transfereeID})
@@ -131,6 +185,13 @@ func (c *Client) Stat(ctx context.Context) (ret *StatInfo, err error) {
 	return
 }
 
+// APISchema returns machine-readable schema of clustermgr http api, includes routes and error codes
+func (c *Client) APISchema(ctx context.Context) (ret *rpc.APISchema, err error) {
+	ret = &rpc.APISchema{}
+	err = c.GetWith(ctx, "/schema", ret)
+	return
+}
+
 func (c *Client) Snapshot(ctx context.Context) (*http.Response, error) {
 	return c.Get(ctx, "/snapshot/dump")
 }
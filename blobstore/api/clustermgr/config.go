@@ -33,6 +33,12 @@ type AllConfig struct {
 	Configs map[string]string `json:"configs"`
 }
 
+// ConfigBatchArgs sets and deletes configs in one proposal, all or nothing is applied
+type ConfigBatchArgs struct {
+	Sets    []ConfigSetArgs `json:"sets"`
+	Deletes []string        `json:"deletes"`
+}
+
 func (c *Client) GetConfig(ctx context.Context, key string) (ret string, err error) {
 	err = c.GetWith(ctx, "/config/get?key="+key, &ret)
 	return
@@ -47,3 +53,13 @@ func (c *Client) DeleteConfig(ctx context.Context, key string) (err error) {
 	err = c.PostWith(ctx, "/config/delete?key="+key, nil, rpc.NoneBody)
 	return
 }
+
+func (c *Client) ListConfig(ctx context.Context) (ret AllConfig, err error) {
+	err = c.GetWith(ctx, "/config/list", &ret)
+	return
+}
+
+func (c *Client) BatchConfig(ctx context.Context, args *ConfigBatchArgs) (err error) {
+	err = c.PostWith(ctx, "/config/batch", nil, args)
+	return
+}
@@ -19,8 +19,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 )
@@ -92,6 +94,28 @@ type DiskSetArgs struct {
 	Status proto.DiskStatus `json:"status"`
 }
 
+// DiskSetCheckRet result of checking status transition of disk without applying it,
+// Status is the current status, Reason is why the transition is not allowed.
+type DiskSetCheckRet struct {
+	DiskID   proto.DiskID     `json:"disk_id"`
+	Status   proto.DiskStatus `json:"status"`
+	Dropping bool             `json:"dropping"`
+	Allowed  bool             `json:"allowed"`
+	Reason   string           `json:"reason,omitempty"`
+}
+
+// DiskSortBy field to sort disks by when listing
+type DiskSortBy string
+
+const (
+	DiskSortByFree       DiskSortBy = "free"
+	DiskSortByCreateTime DiskSortBy = "create_time"
+)
+
+func (s DiskSortBy) IsValid() bool {
+	return s == DiskSortByFree || s == DiskSortByCreateTime
+}
+
 type ListOptionArgs struct {
 	Idc    string           `json:"idc,omitempty"`
 	Rack   string           `json:"rack,omitempty"`
@@ -101,11 +125,22 @@ type ListOptionArgs struct {
 	Marker proto.DiskID `json:"marker,omitempty"`
 	// one page count
 	Count int `json:"count,omitempty"`
+
+	// filter disks by the state if set
+	Readonly         *bool `json:"readonly,omitempty"`
+	Dropping         *bool `json:"dropping,omitempty"`
+	HeartbeatExpired *bool `json:"heartbeat_expired,omitempty"`
+	// list disk info sorted by the field after cursor, marker is ignored
+	SortBy DiskSortBy `json:"sort_by,omitempty"`
+	Desc   bool       `json:"desc,omitempty"`
+	Cursor string     `json:"cursor,omitempty"`
 }
 
 type ListDiskRet struct {
 	Disks  []*BlobNodeDiskInfo `json:"disks"`
 	Marker proto.DiskID        `json:"marker"`
+	// cursor of the last disk if sorted
+	Cursor string `json:"cursor,omitempty"`
 }
 
 type ListShardNodeDiskRet struct {
@@ -154,11 +189,13 @@ type SpaceStatInfo struct {
 	FreeSpace      int64          `json:"free_space"`     // free physical space which is writable
 	ReadOnlySpace  int64          `json:"readonly_space"` // free physical space which is readonly
 	UsedSpace      int64          `json:"used_space"`     // used physical space
-	WritableSpace  int64          `json:"writable_space"` // writable logical space
+	WritableSpace  int64          `json:"writable_space"` // writable logical space of the code mode with max su count
 	TotalBlobNode  int64          `json:"total_blob_node,omitempty"`
 	TotalShardNode int64          `json:"total_shard_node,omitempty"`
 	TotalDisk      int64          `json:"total_disk"`
 	DisksStatInfos []DiskStatInfo `json:"disk_stat_infos"`
+	// writable logical space of each configured code mode
+	CodeModeWritableSpace map[codemode.CodeModeName]int64 `json:"code_mode_writable_space,omitempty"`
 }
 
 type DiskAccessArgs struct {
@@ -197,6 +234,17 @@ func (c *Client) SetDisk(ctx context.Context, id proto.DiskID, status proto.Disk
 	return c.PostWith(ctx, "/disk/set", nil, &DiskSetArgs{DiskID: id, Status: status})
 }
 
+// CheckSetDisk checks if disk status could be changed to status, nothing is changed.
+// Dropped status is checked by dropping the disk firstly if it is not dropping.
+func (c *Client) CheckSetDisk(ctx context.Context, id proto.DiskID, status proto.DiskStatus) (ret *DiskSetCheckRet, err error) {
+	if !status.IsValid() {
+		return nil, errors.New("invalid status")
+	}
+	ret = &DiskSetCheckRet{}
+	err = c.GetWith(ctx, fmt.Sprintf("/disk/set/check?disk_id=%d&status=%d", id, status), ret)
+	return
+}
+
 // ListHostDisk list specified host disk info from cluster manager
 func (c *Client) ListHostDisk(ctx context.Context, host string) (ret []*BlobNodeDiskInfo, err error) {
 	listRet := ListDiskRet{}
@@ -214,7 +262,7 @@ func (c *Client) ListHostDisk(ctx context.Context, host string) (ret []*BlobNode
 // ListDisk list disk info from cluster manager
 // when ListOptionArgs is default value, defalut return 10 diskInfos
 func (c *Client) ListDisk(ctx context.Context, options *ListOptionArgs) (ret ListDiskRet, err error) {
-	err = c.GetWith(ctx, fmt.Sprintf(
+	uri := fmt.Sprintf(
 		"/disk/list?idc=%s&rack=%s&host=%s&status=%d&marker=%d&count=%d",
 		options.Idc,
 		options.Rack,
@@ -222,7 +270,20 @@ func (c *Client) ListDisk(ctx context.Context, options *ListOptionArgs) (ret Lis
 		options.Status,
 		options.Marker,
 		options.Count,
-	), &ret)
+	)
+	if options.Readonly != nil {
+		uri += fmt.Sprintf("&readonly=%t", *options.Readonly)
+	}
+	if options.Dropping != nil {
+		uri += fmt.Sprintf("&dropping=%t", *options.Dropping)
+	}
+	if options.HeartbeatExpired != nil {
+		uri += fmt.Sprintf("&heartbeat_expired=%t", *options.HeartbeatExpired)
+	}
+	if options.SortBy != "" {
+		uri += fmt.Sprintf("&sort_by=%s&desc=%t&cursor=%s", options.SortBy, options.Desc, url.QueryEscape(options.Cursor))
+	}
+	err = c.GetWith(ctx, uri, &ret)
 	return
 }
 
@@ -252,6 +313,27 @@ func (c *Client) ListDroppingDisk(ctx context.Context) (ret []*BlobNodeDiskInfo,
 	return
 }
 
+// StuckDroppingDisk the dropping disk without migration progress
+type StuckDroppingDisk struct {
+	DiskID       proto.DiskID `json:"disk_id"`
+	Host         string       `json:"host"`
+	VolumeUnits  int          `json:"volume_units"`  // remaining volume units on the disk
+	ProgressTime int64        `json:"progress_time"` // unix seconds of the last progress
+	RequeueTime  int64        `json:"requeue_time"`  // unix seconds of requeued with scheduler
+	Stuck        bool         `json:"stuck"`         // still without progress after requeued
+}
+
+type ListStuckDroppingDiskRet struct {
+	Disks []StuckDroppingDisk `json:"disks"`
+}
+
+// ListStuckDroppingDisk returns the dropping disks without migration progress tracked on leader
+func (c *Client) ListStuckDroppingDisk(ctx context.Context) (ret *ListStuckDroppingDiskRet, err error) {
+	ret = &ListStuckDroppingDiskRet{}
+	err = c.GetWith(ctx, "/disk/drop/stuck/list", ret)
+	return
+}
+
 func (c *Client) SetReadonlyDisk(ctx context.Context, id proto.DiskID, readonly bool) (err error) {
 	err = c.PostWith(ctx, "/disk/access", nil, &DiskAccessArgs{DiskID: id, Readonly: readonly})
 	return
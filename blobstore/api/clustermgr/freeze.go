@@ -0,0 +1,94 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+const (
+	// DefaultFreezeTTLS default seconds of cluster freeze if ttl not specified
+	DefaultFreezeTTLS = 3600
+	// MaxFreezeTTLS max seconds of cluster freeze, freeze again to extend it
+	MaxFreezeTTLS = 24 * 3600
+)
+
+// FreezeArgs freeze mutations of cluster for TTLS seconds, 0 means DefaultFreezeTTLS
+type FreezeArgs struct {
+	Reason string `json:"reason"`
+	TTLS   int64  `json:"ttl_s"`
+}
+
+// Check returns error if the args is invalid
+func (args *FreezeArgs) Check() error {
+	if args.TTLS < 0 || args.TTLS > MaxFreezeTTLS {
+		return fmt.Errorf("invalid ttl %d, not in [0, %d]", args.TTLS, MaxFreezeTTLS)
+	}
+	return nil
+}
+
+// ClusterFreeze emergency switch stored in config, the mutating operations of
+// cluster are rejected in time range [FreezeTime, ExpireTime), expired automatically.
+type ClusterFreeze struct {
+	Reason     string `json:"reason"`
+	FreezeTime int64  `json:"freeze_time"` // unix seconds
+	ExpireTime int64  `json:"expire_time"` // unix seconds
+}
+
+// IsFrozen returns the cluster is frozen at time t or not
+func (f *ClusterFreeze) IsFrozen(t time.Time) bool {
+	return f != nil && t.Unix() < f.ExpireTime
+}
+
+// DecodeClusterFreeze decode cluster freeze from config value, returns nil if empty
+func DecodeClusterFreeze(value string) (*ClusterFreeze, error) {
+	if value == "" {
+		return nil, nil
+	}
+	f := new(ClusterFreeze)
+	if err := json.Unmarshal([]byte(value), f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// GetFreezeRet freeze status of cluster, Freeze is the last unexpired freeze
+type GetFreezeRet struct {
+	Frozen bool           `json:"frozen"`
+	Freeze *ClusterFreeze `json:"freeze,omitempty"`
+}
+
+// FreezeCluster freeze mutations of cluster, returns the effective freeze
+func (c *Client) FreezeCluster(ctx context.Context, args *FreezeArgs) (ret ClusterFreeze, err error) {
+	err = c.PostWith(ctx, "/config/freeze", &ret, args)
+	return
+}
+
+// UnfreezeCluster unfreeze the cluster before expired
+func (c *Client) UnfreezeCluster(ctx context.Context) (err error) {
+	err = c.PostWith(ctx, "/config/unfreeze", nil, rpc.NoneBody)
+	return
+}
+
+// GetClusterFreeze get freeze status of cluster
+func (c *Client) GetClusterFreeze(ctx context.Context) (ret GetFreezeRet, err error) {
+	err = c.GetWith(ctx, "/config/freeze/get", &ret)
+	return
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterFreeze(t *testing.T) {
+	for _, args := range []FreezeArgs{{TTLS: -1}, {TTLS: MaxFreezeTTLS + 1}} {
+		require.Error(t, args.Check(), args)
+	}
+	require.NoError(t, (&FreezeArgs{}).Check())
+	require.NoError(t, (&FreezeArgs{TTLS: MaxFreezeTTLS}).Check())
+
+	f, err := DecodeClusterFreeze("")
+	require.NoError(t, err)
+	require.Nil(t, f)
+	require.False(t, f.IsFrozen(time.Now()))
+	_, err = DecodeClusterFreeze("{")
+	require.Error(t, err)
+
+	f, err = DecodeClusterFreeze(`{"reason":"incident","freeze_time":10,"expire_time":20}`)
+	require.NoError(t, err)
+	require.Equal(t, ClusterFreeze{Reason: "incident", FreezeTime: 10, ExpireTime: 20}, *f)
+	require.True(t, f.IsFrozen(time.Unix(19, 0)))
+	require.False(t, f.IsFrozen(time.Unix(20, 0)))
+}
@@ -0,0 +1,196 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+// MaxMaintenanceFactor max concurrency factor of maintenance window
+const MaxMaintenanceFactor = 10.0
+
+// MaintenanceTaskTypes background tasks whose admission consults maintenance windows
+var MaintenanceTaskTypes = []proto.TaskType{
+	proto.TaskTypeDiskRepair,
+	proto.TaskTypeBalance,
+	proto.TaskTypeShardDiskRepair,
+}
+
+// MaintenanceWindow time range [StartTime, EndTime) in which the concurrency of
+// background tasks is scaled by Factor, 0 pauses the tasks and (1, 10] boosts them.
+// TaskTypes is empty means affecting all background tasks.
+type MaintenanceWindow struct {
+	ID        uint32           `json:"id"`
+	Name      string           `json:"name"`
+	TaskTypes []proto.TaskType `json:"task_types,omitempty"`
+	StartTime int64            `json:"start_time"` // unix seconds
+	EndTime   int64            `json:"end_time"`   // unix seconds
+	Factor    float64          `json:"factor"`
+}
+
+// Check returns error if the window is invalid
+func (w *MaintenanceWindow) Check() error {
+	if w.StartTime <= 0 || w.EndTime <= w.StartTime {
+		return fmt.Errorf("invalid time range [%d, %d)", w.StartTime, w.EndTime)
+	}
+	if w.Factor < 0 || w.Factor > MaxMaintenanceFactor {
+		return fmt.Errorf("invalid factor %v, not in [0, %v]", w.Factor, MaxMaintenanceFactor)
+	}
+	for _, taskType := range w.TaskTypes {
+		if !isMaintenanceTaskType(taskType) {
+			return fmt.Errorf("task type %s not support maintenance window", taskType)
+		}
+	}
+	return nil
+}
+
+// IsActive returns the window is active at time t or not
+func (w *MaintenanceWindow) IsActive(t time.Time) bool {
+	now := t.Unix()
+	return now >= w.StartTime && now < w.EndTime
+}
+
+// IsExpired returns the window has been ended at time t or not
+func (w *MaintenanceWindow) IsExpired(t time.Time) bool {
+	return t.Unix() >= w.EndTime
+}
+
+// Affects returns the window affects the task type or not
+func (w *MaintenanceWindow) Affects(taskType proto.TaskType) bool {
+	if len(w.TaskTypes) == 0 {
+		return true
+	}
+	for _, tt := range w.TaskTypes {
+		if tt == taskType {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceWindows calendar of maintenance windows stored in config
+type MaintenanceWindows []MaintenanceWindow
+
+// Factor returns the effective factor of the task type at time t,
+// the smallest one takes effect if windows overlapped.
+func (ws MaintenanceWindows) Factor(taskType proto.TaskType, t time.Time) float64 {
+	factor := 1.0
+	matched := false
+	for idx := range ws {
+		w := &ws[idx]
+		if !w.IsActive(t) || !w.Affects(taskType) {
+			continue
+		}
+		if !matched || w.Factor < factor {
+			factor = w.Factor
+			matched = true
+		}
+	}
+	return factor
+}
+
+// Concurrency returns the effective concurrency of the task type at time t,
+// returns at least 1 if the task is not paused.
+func (ws MaintenanceWindows) Concurrency(taskType proto.TaskType, concurrency int, t time.Time) int {
+	factor := ws.Factor(taskType, t)
+	if factor == 0 {
+		return 0
+	}
+	if c := int(float64(concurrency) * factor); c > 0 {
+		return c
+	}
+	return 1
+}
+
+// Policy returns the effective policy at time t
+func (ws MaintenanceWindows) Policy(t time.Time) MaintenancePolicy {
+	policy := MaintenancePolicy{
+		Time:    t.Unix(),
+		Windows: make([]MaintenanceWindow, 0),
+		Factors: make(map[proto.TaskType]float64, len(MaintenanceTaskTypes)),
+	}
+	for idx := range ws {
+		if ws[idx].IsActive(t) {
+			policy.Windows = append(policy.Windows, ws[idx])
+		}
+	}
+	for _, taskType := range MaintenanceTaskTypes {
+		policy.Factors[taskType] = ws.Factor(taskType, t)
+	}
+	return policy
+}
+
+// DecodeMaintenanceWindows decode maintenance windows from config value
+func DecodeMaintenanceWindows(value string) (MaintenanceWindows, error) {
+	var ws MaintenanceWindows
+	if value == "" {
+		return ws, nil
+	}
+	if err := json.Unmarshal([]byte(value), &ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func isMaintenanceTaskType(taskType proto.TaskType) bool {
+	for _, tt := range MaintenanceTaskTypes {
+		if tt == taskType {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenancePolicyArgs dry-run the effective policy at the time, 0 means now
+type MaintenancePolicyArgs struct {
+	Time int64 `json:"time"`
+}
+
+// MaintenancePolicy effective factors of background tasks at the time
+type MaintenancePolicy struct {
+	Time    int64                      `json:"time"`
+	Windows []MaintenanceWindow        `json:"windows"`
+	Factors map[proto.TaskType]float64 `json:"factors"`
+}
+
+// ListMaintenanceWindowsRet all maintenance windows
+type ListMaintenanceWindowsRet struct {
+	Windows []MaintenanceWindow `json:"windows"`
+}
+
+// CreateMaintenanceWindow create a maintenance window, returns it with allocated id,
+// the id in args is ignored and ids are never reused
+func (c *Client) CreateMaintenanceWindow(ctx context.Context, args *MaintenanceWindow) (ret MaintenanceWindow, err error) {
+	err = c.PostWith(ctx, "/config/maintenance/window/create", &ret, args)
+	return
+}
+
+// ListMaintenanceWindows list all maintenance windows, expired windows are listed
+// until they are cleaned by the next create
+func (c *Client) ListMaintenanceWindows(ctx context.Context) (ret ListMaintenanceWindowsRet, err error) {
+	err = c.GetWith(ctx, "/config/maintenance/window/list", &ret)
+	return
+}
+
+// GetMaintenancePolicy dry-run the effective policy at the time
+func (c *Client) GetMaintenancePolicy(ctx context.Context, args *MaintenancePolicyArgs) (ret MaintenancePolicy, err error) {
+	err = c.GetWith(ctx, fmt.Sprintf("/config/maintenance/policy?time=%d", args.Time), &ret)
+	return
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestMaintenanceWindowCheck(t *testing.T) {
+	for _, w := range []MaintenanceWindow{
+		{StartTime: 0, EndTime: 10, Factor: 1},
+		{StartTime: 10, EndTime: 10, Factor: 1},
+		{StartTime: 10, EndTime: 20, Factor: -1},
+		{StartTime: 10, EndTime: 20, Factor: MaxMaintenanceFactor + 1},
+		{StartTime: 10, EndTime: 20, Factor: 1, TaskTypes: []proto.TaskType{proto.TaskTypeBlobDelete}},
+		{StartTime: 10, EndTime: 20, Factor: 1, TaskTypes: []proto.TaskType{"x"}},
+	} {
+		require.Error(t, w.Check(), w)
+	}
+
+	w := MaintenanceWindow{StartTime: 10, EndTime: 20, Factor: 0, TaskTypes: []proto.TaskType{proto.TaskTypeBalance}}
+	require.NoError(t, w.Check())
+	require.False(t, w.IsActive(time.Unix(9, 0)))
+	require.True(t, w.IsActive(time.Unix(10, 0)))
+	require.False(t, w.IsActive(time.Unix(20, 0)))
+	require.False(t, w.IsExpired(time.Unix(19, 0)))
+	require.True(t, w.IsExpired(time.Unix(20, 0)))
+	require.True(t, w.Affects(proto.TaskTypeBalance))
+	require.False(t, w.Affects(proto.TaskTypeDiskRepair))
+}
+
+func TestMaintenanceWindowsPolicy(t *testing.T) {
+	ws := MaintenanceWindows{
+		{ID: 1, StartTime: 100, EndTime: 200, Factor: 0.5},
+		{ID: 2, StartTime: 150, EndTime: 300, Factor: 0, TaskTypes: []proto.TaskType{proto.TaskTypeBalance}},
+		{ID: 3, StartTime: 400, EndTime: 500, Factor: 2, TaskTypes: []proto.TaskType{proto.TaskTypeDiskRepair}},
+	}
+
+	at := func(sec int64) time.Time { return time.Unix(sec, 0) }
+	require.Equal(t, 1.0, ws.Factor(proto.TaskTypeBalance, at(50)))
+	require.Equal(t, 0.5, ws.Factor(proto.TaskTypeBalance, at(120)))
+	require.Equal(t, 0.0, ws.Factor(proto.TaskTypeBalance, at(160)))
+	require.Equal(t, 0.5, ws.Factor(proto.TaskTypeDiskRepair, at(160)))
+	require.Equal(t, 2.0, ws.Factor(proto.TaskTypeDiskRepair, at(450)))
+
+	require.Equal(t, 10, ws.Concurrency(proto.TaskTypeDiskRepair, 10, at(50)))
+	require.Equal(t, 5, ws.Concurrency(proto.TaskTypeDiskRepair, 10, at(120)))
+	require.Equal(t, 1, ws.Concurrency(proto.TaskTypeDiskRepair, 1, at(120)))
+	require.Equal(t, 0, ws.Concurrency(proto.TaskTypeBalance, 10, at(160)))
+	require.Equal(t, 20, ws.Concurrency(proto.TaskTypeDiskRepair, 10, at(450)))
+
+	policy := ws.Policy(at(160))
+	require.Equal(t, int64(160), policy.Time)
+	require.Len(t, policy.Windows, 2)
+	require.Len(t, policy.Factors, len(MaintenanceTaskTypes))
+	require.Equal(t, 0.0, policy.Factors[proto.TaskTypeBalance])
+	require.Equal(t, 0.5, policy.Factors[proto.TaskTypeShardDiskRepair])
+
+	value, err := json.Marshal(ws)
+	require.NoError(t, err)
+	decoded, err := DecodeMaintenanceWindows(string(value))
+	require.NoError(t, err)
+	require.Equal(t, ws, decoded)
+	decoded, err = DecodeMaintenanceWindows("")
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+	_, err = DecodeMaintenanceWindows("{")
+	require.Error(t, err)
+}
@@ -66,6 +66,45 @@ type TopoInfo struct {
 	AllNodeSets  map[string]map[proto.NodeSetID]*NodeSetInfo `json:"all_node_sets"`
 }
 
+// topology issue types found by topology check
+const (
+	TopoIssueOrphanDisk      = "orphan_disk"
+	TopoIssueNodeDiskMissing = "node_disk_missing"
+	TopoIssueNodeSetMissing  = "node_set_missing"
+	TopoIssueNodeSetMember   = "node_set_member"
+	TopoIssueDiskSetMember   = "disk_set_member"
+	TopoIssueFilterMissing   = "filter_missing"
+	TopoIssueFilterStale     = "filter_stale"
+	TopoIssueTableMismatch   = "table_mismatch"
+)
+
+// TopoCheckArgs repair the memory topology of the requested cluster manager if Repair is true,
+// mismatches between memory and persistent tables are only reported.
+type TopoCheckArgs struct {
+	Repair bool `json:"repair"`
+}
+
+type TopoIssue struct {
+	Type      string          `json:"type"`
+	NodeID    proto.NodeID    `json:"node_id,omitempty"`
+	DiskID    proto.DiskID    `json:"disk_id,omitempty"`
+	NodeSetID proto.NodeSetID `json:"node_set_id,omitempty"`
+	DiskSetID proto.DiskSetID `json:"disk_set_id,omitempty"`
+	Detail    string          `json:"detail,omitempty"`
+	Repaired  bool            `json:"repaired"`
+}
+
+type TopoCheckRet struct {
+	Issues []TopoIssue `json:"issues"`
+}
+
+// NodeRelabelArgs relabel idc and rack of the node and its disks, empty one keeps unchanged
+type NodeRelabelArgs struct {
+	NodeID proto.NodeID `json:"node_id"`
+	Idc    string       `json:"idc"`
+	Rack   string       `json:"rack"`
+}
+
 // AddNode add a new node into cluster manager and return allocated nodeID
 func (c *Client) AddNode(ctx context.Context, info *BlobNodeInfo) (proto.NodeID, error) {
 	ret := &NodeIDAllocRet{}
@@ -96,6 +135,19 @@ func (c *Client) TopoInfo(ctx context.Context) (ret *TopoInfo, err error) {
 	return
 }
 
+// CheckTopo check consistency of blobnode topology in cluster manager
+func (c *Client) CheckTopo(ctx context.Context, args *TopoCheckArgs) (ret *TopoCheckRet, err error) {
+	ret = &TopoCheckRet{}
+	err = c.PostWith(ctx, "/admin/topo/check", ret, args)
+	return
+}
+
+// RelabelNode relabel idc and rack of blobnode and its disks
+func (c *Client) RelabelNode(ctx context.Context, args *NodeRelabelArgs) (err error) {
+	err = c.PostWith(ctx, "/admin/node/relabel", nil, args)
+	return
+}
+
 // AddShardNode add a new shardnode into cluster manager and return allocated nodeID
 func (c *Client) AddShardNode(ctx context.Context, info *ShardNodeInfo) (proto.NodeID, error) {
 	ret := &NodeIDAllocRet{}
@@ -119,3 +171,16 @@ func (c *Client) ShardNodeTopoInfo(ctx context.Context) (ret *TopoInfo, err erro
 	err = c.GetWith(ctx, "/shardnode/topo/info", ret)
 	return
 }
+
+// CheckShardNodeTopo check consistency of shardnode topology in cluster manager
+func (c *Client) CheckShardNodeTopo(ctx context.Context, args *TopoCheckArgs) (ret *TopoCheckRet, err error) {
+	ret = &TopoCheckRet{}
+	err = c.PostWith(ctx, "/admin/shardnode/topo/check", ret, args)
+	return
+}
+
+// RelabelShardNode relabel idc and rack of shardnode and its disks
+func (c *Client) RelabelShardNode(ctx context.Context, args *NodeRelabelArgs) (err error) {
+	err = c.PostWith(ctx, "/admin/shardnode/node/relabel", nil, args)
+	return
+}
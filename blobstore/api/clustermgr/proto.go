@@ -34,6 +34,35 @@ type ClusterInfo struct {
 	Available int64           `json:"available"`
 	Readonly  bool            `json:"readonly"`
 	Nodes     []string        `json:"nodes"`
+	// Labels of cluster registered, like zone, rack, storage class
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// MatchLabels returns true if the cluster has all the labels
+func (c *ClusterInfo) MatchLabels(labels map[string]string) bool {
+	for key, val := range labels {
+		if v, ok := c.Labels[key]; !ok || v != val {
+			return false
+		}
+	}
+	return true
+}
+
+// PickByRegionAffinity returns clusters matched the labels in the nearest region,
+// regions are ordered by affinity, the first one is the nearest.
+func PickByRegionAffinity(clusters []*ClusterInfo, regions []string, labels map[string]string) []*ClusterInfo {
+	for _, region := range regions {
+		picked := make([]*ClusterInfo, 0, len(clusters))
+		for _, cluster := range clusters {
+			if cluster.Region == region && cluster.MatchLabels(labels) {
+				picked = append(picked, cluster)
+			}
+		}
+		if len(picked) > 0 {
+			return picked
+		}
+	}
+	return nil
 }
 
 type StatInfo struct {
@@ -43,6 +72,8 @@ type StatInfo struct {
 	BlobNodeSpaceStat  SpaceStatInfo  `json:"space_stat"`
 	ShardNodeSpaceStat SpaceStatInfo  `json:"shard_node_space_stat"`
 	VolumeStat         VolumeStatInfo `json:"volume_stat"`
+	// BlobNodeDiskTypeSpaceStat space stat of blobnode by disk type name
+	BlobNodeDiskTypeSpaceStat map[string]SpaceStatInfo `json:"disk_type_space_stat,omitempty"`
 }
 
 func GetConsulClusterPath(region string) string {
@@ -63,6 +94,7 @@ type APIAccess interface {
 	AuthSpace(ctx context.Context, args *AuthSpaceArgs) (err error)
 	GetSpaceByName(ctx context.Context, args *GetSpaceByNameArgs) (ret *Space, err error)
 	GetCatalogChanges(ctx context.Context, args *GetCatalogChangesArgs) (ret *GetCatalogChangesRet, err error)
+	GetRouteChecksum(ctx context.Context, args *GetRouteChecksumArgs) (ret *GetRouteChecksumRet, err error)
 	ShardNodeDiskInfo(ctx context.Context, id proto.DiskID) (ret *ShardNodeDiskInfo, err error)
 	ListShardNodeDisk(ctx context.Context, options *ListOptionArgs) (ret ListShardNodeDiskRet, err error)
 }
@@ -71,6 +103,7 @@ type APIAccess interface {
 type APIProxy interface {
 	GetConfig(ctx context.Context, key string) (string, error)
 	GetVolumeInfo(ctx context.Context, args *GetVolumeArgs) (*VolumeInfo, error)
+	GetVolumeChanges(ctx context.Context, args *GetVolumeChangesArgs) (*GetVolumeChangesRet, error)
 	DiskInfo(ctx context.Context, id proto.DiskID) (*BlobNodeDiskInfo, error)
 	AllocVolume(ctx context.Context, args *AllocVolumeArgs) (AllocatedVolumeInfos, error)
 	AllocBid(ctx context.Context, args *BidScopeArgs) (*BidScopeRet, error)
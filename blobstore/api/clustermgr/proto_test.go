@@ -0,0 +1,52 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterInfoRegionAffinity(t *testing.T) {
+	clusters := []*ClusterInfo{
+		{Region: "r1", ClusterID: 1, Labels: map[string]string{"class": "hdd"}},
+		{Region: "r1", ClusterID: 2, Labels: map[string]string{"class": "ssd"}},
+		{Region: "r2", ClusterID: 3, Labels: map[string]string{"class": "ssd", "zone": "z1"}},
+		{Region: "r3", ClusterID: 4},
+	}
+
+	require.True(t, clusters[0].MatchLabels(nil))
+	require.True(t, clusters[2].MatchLabels(map[string]string{"zone": "z1"}))
+	require.False(t, clusters[3].MatchLabels(map[string]string{"zone": "z1"}))
+
+	picked := PickByRegionAffinity(clusters, []string{"r1", "r2"}, nil)
+	require.Equal(t, 2, len(picked))
+
+	picked = PickByRegionAffinity(clusters, []string{"r1", "r2"}, map[string]string{"class": "ssd"})
+	require.Equal(t, 1, len(picked))
+	require.Equal(t, clusters[1], picked[0])
+
+	picked = PickByRegionAffinity(clusters, []string{"r1", "r2"}, map[string]string{"zone": "z1"})
+	require.Equal(t, 1, len(picked))
+	require.Equal(t, clusters[2], picked[0])
+
+	picked = PickByRegionAffinity(clusters, []string{"r0", "r3"}, nil)
+	require.Equal(t, 1, len(picked))
+	require.Equal(t, clusters[3], picked[0])
+
+	require.Nil(t, PickByRegionAffinity(clusters, []string{"r0"}, nil))
+	require.Nil(t, PickByRegionAffinity(clusters, nil, nil))
+}
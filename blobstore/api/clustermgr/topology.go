@@ -0,0 +1,134 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+// Locality of a disk relative to the client, the smaller is the nearer.
+type Locality int
+
+const (
+	LocalityHost Locality = iota
+	LocalityRack
+	LocalityIdc
+	LocalityRemote
+	LocalityUnknown
+)
+
+// DiskLocation is the disk placed on host of rack in idc.
+type DiskLocation struct {
+	DiskID proto.DiskID `json:"disk_id"`
+	NodeID proto.NodeID `json:"node_id"`
+	Host   string       `json:"host"`
+	Idc    string       `json:"idc"`
+	Rack   string       `json:"rack"`
+}
+
+// ClientLocation is where the data-path client is, empty field matches nothing.
+type ClientLocation struct {
+	Host string `json:"host"`
+	Idc  string `json:"idc"`
+	Rack string `json:"rack"`
+}
+
+// Locality returns the locality of the disk to the client,
+// rack is named in idc, so the same rack of different idc is not local.
+func (loc ClientLocation) Locality(disk DiskLocation) Locality {
+	switch {
+	case loc.Idc == "" || loc.Idc != disk.Idc:
+		return LocalityRemote
+	case loc.Host != "" && loc.Host == disk.Host:
+		return LocalityHost
+	case loc.Rack != "" && loc.Rack == disk.Rack:
+		return LocalityRack
+	default:
+		return LocalityIdc
+	}
+}
+
+// Topology maps disk to host, rack and idc, it's read only after built.
+type Topology struct {
+	disks map[proto.DiskID]DiskLocation
+}
+
+// NewTopology returns topology of the disks.
+func NewTopology(disks []*BlobNodeDiskInfo) *Topology {
+	t := &Topology{disks: make(map[proto.DiskID]DiskLocation, len(disks))}
+	for _, disk := range disks {
+		t.disks[disk.DiskID] = DiskLocation{
+			DiskID: disk.DiskID,
+			NodeID: disk.NodeID,
+			Host:   disk.Host,
+			Idc:    disk.Idc,
+			Rack:   disk.Rack,
+		}
+	}
+	return t
+}
+
+// Len returns number of disks in topology.
+func (t *Topology) Len() int {
+	return len(t.disks)
+}
+
+// Location returns the location of the disk.
+func (t *Topology) Location(diskID proto.DiskID) (DiskLocation, bool) {
+	loc, ok := t.disks[diskID]
+	return loc, ok
+}
+
+// Locality returns the locality of the disk to the client,
+// LocalityUnknown if the disk is not in topology.
+func (t *Topology) Locality(client ClientLocation, diskID proto.DiskID) Locality {
+	disk, ok := t.disks[diskID]
+	if !ok {
+		return LocalityUnknown
+	}
+	return client.Locality(disk)
+}
+
+// SortByLocality sorts the disks from the nearest to the client in place,
+// disks with the same locality keep the original order.
+func (t *Topology) SortByLocality(client ClientLocation, diskIDs []proto.DiskID) {
+	localities := make(map[proto.DiskID]Locality, len(diskIDs))
+	for _, diskID := range diskIDs {
+		localities[diskID] = t.Locality(client, diskID)
+	}
+	sort.SliceStable(diskIDs, func(i, j int) bool {
+		return localities[diskIDs[i]] < localities[diskIDs[j]]
+	})
+}
+
+// LoadTopology lists all blobnode disks from cluster manager and returns the topology.
+func (c *Client) LoadTopology(ctx context.Context) (*Topology, error) {
+	var disks []*BlobNodeDiskInfo
+	opt := &ListOptionArgs{Count: 200}
+	for {
+		ret, err := c.ListDisk(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, ret.Disks...)
+		if len(ret.Disks) == 0 || ret.Marker == proto.InvalidDiskID {
+			return NewTopology(disks), nil
+		}
+		opt.Marker = ret.Marker
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestTopologyLocality(t *testing.T) {
+	newDisk := func(id proto.DiskID, idc, rack, host string) *BlobNodeDiskInfo {
+		disk := &BlobNodeDiskInfo{}
+		disk.DiskID, disk.Idc, disk.Rack, disk.Host = id, idc, rack, host
+		return disk
+	}
+	topo := NewTopology([]*BlobNodeDiskInfo{
+		newDisk(1, "z1", "r1", "h1"),
+		newDisk(2, "z1", "r1", "h2"),
+		newDisk(3, "z1", "r2", "h3"),
+		newDisk(4, "z2", "r1", "h4"),
+	})
+	require.Equal(t, 4, topo.Len())
+	loc, ok := topo.Location(3)
+	require.True(t, ok)
+	require.Equal(t, "h3", loc.Host)
+	_, ok = topo.Location(5)
+	require.False(t, ok)
+
+	client := ClientLocation{Idc: "z1", Rack: "r1", Host: "h1"}
+	require.Equal(t, LocalityHost, topo.Locality(client, 1))
+	require.Equal(t, LocalityRack, topo.Locality(client, 2))
+	require.Equal(t, LocalityIdc, topo.Locality(client, 3))
+	require.Equal(t, LocalityRemote, topo.Locality(client, 4))
+	require.Equal(t, LocalityUnknown, topo.Locality(client, 5))
+	require.Equal(t, LocalityRemote, topo.Locality(ClientLocation{}, 1))
+
+	diskIDs := []proto.DiskID{5, 4, 3, 2, 1}
+	topo.SortByLocality(client, diskIDs)
+	require.Equal(t, []proto.DiskID{1, 2, 3, 4, 5}, diskIDs)
+
+	diskIDs = []proto.DiskID{4, 3, 2, 1}
+	topo.SortByLocality(ClientLocation{Idc: "z1"}, diskIDs)
+	require.Equal(t, []proto.DiskID{3, 2, 1, 4}, diskIDs)
+}
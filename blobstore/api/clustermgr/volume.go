@@ -70,6 +70,10 @@ type VolumeInfoBase struct {
 	Free           uint64             `json:"free"`
 	Used           uint64             `json:"used"`
 	CreateByNodeID uint64             `json:"create_by_node_id"`
+	// LeaseExpireTime is unix nano time of lease expired, zero means volume not leased.
+	// unit data of lease expired volume is marked for deletion.
+	LeaseExpireTime int64 `json:"lease_expire_time,omitempty"`
+	LeaseExpired    bool  `json:"lease_expired,omitempty"`
 }
 
 type AllocVolumeInfo struct {
@@ -110,10 +114,32 @@ func (c *Client) ListVolumeV2(ctx context.Context, args *ListVolumeV2Args) (ret
 	return
 }
 
+// GetVolumeChangesArgs gets volumes whose units changed after route version
+type GetVolumeChangesArgs struct {
+	RouteVersion uint64 `json:"route_version"`
+}
+
+// GetVolumeChangesRet the latest route version and the changed volumes,
+// Expired means changes after the route version are truncated, all volumes need to be reloaded
+type GetVolumeChangesRet struct {
+	RouteVersion uint64        `json:"route_version"`
+	Expired      bool          `json:"expired"`
+	Volumes      []*VolumeInfo `json:"volumes"`
+}
+
+func (c *Client) GetVolumeChanges(ctx context.Context, args *GetVolumeChangesArgs) (ret *GetVolumeChangesRet, err error) {
+	ret = &GetVolumeChangesRet{}
+	err = c.GetWith(ctx, fmt.Sprintf("/volume/changes/get?route_version=%d", args.RouteVersion), ret)
+	return
+}
+
 type AllocVolumeArgs struct {
 	IsInit   bool              `json:"is_init"`
 	CodeMode codemode.CodeMode `json:"code_mode"`
 	Count    int               `json:"count"`
+	// LeaseTTLS allocates volumes for temporary data, lease of volumes
+	// expired after LeaseTTLS seconds unless renewed.
+	LeaseTTLS int64 `json:"lease_ttl_s,omitempty"`
 }
 
 type AllocatedVolumeInfos struct {
@@ -125,6 +151,20 @@ func (c *Client) AllocVolume(ctx context.Context, args *AllocVolumeArgs) (ret Al
 	return
 }
 
+type RenewVolumeLeaseArgs struct {
+	Vids      []proto.Vid `json:"vids"`
+	LeaseTTLS int64       `json:"lease_ttl_s"`
+}
+
+type RenewVolumeLeaseRet struct {
+	LeaseExpireTime int64 `json:"lease_expire_time"`
+}
+
+func (c *Client) RenewVolumeLease(ctx context.Context, args *RenewVolumeLeaseArgs) (ret RenewVolumeLeaseRet, err error) {
+	err = c.PostWith(ctx, "/volume/lease/renew", &ret, args)
+	return
+}
+
 type UpdateVolumeArgs struct {
 	NewVuid   proto.Vuid   `json:"new_vuid"`
 	NewDiskID proto.DiskID `json:"new_disk_id"`
@@ -222,6 +262,18 @@ func (c *Client) ListVolumeUnit(ctx context.Context, args *ListVolumeUnitArgs) (
 	return ret.VolumeUnitInfos, err
 }
 
+// ListVolumeUnitsOnDiskRet vuids of all volume units in the disk
+type ListVolumeUnitsOnDiskRet struct {
+	Vuids []proto.Vuid `json:"vuids"`
+}
+
+// ListVolumeUnitsOnDisk list vuids of the disk from in-memory index, lighter than ListVolumeUnit
+func (c *Client) ListVolumeUnitsOnDisk(ctx context.Context, args *ListVolumeUnitArgs) ([]proto.Vuid, error) {
+	ret := &ListVolumeUnitsOnDiskRet{}
+	err := c.GetWith(ctx, "/volume/unit/disk/list?disk_id="+args.DiskID.ToString(), ret)
+	return ret.Vuids, err
+}
+
 type ReportChunkArgs struct {
 	ChunkInfos []ChunkInfo `json:"chunk_infos"`
 }
@@ -360,3 +412,69 @@ type AdminUpdateUnitArgs struct {
 	NextEpoch uint32 `json:"next_epoch"`
 	VolumeUnitInfo
 }
+
+// AllocSimulateArgs simulate allocating count volumes of code mode, disk type default hdd
+type AllocSimulateArgs struct {
+	CodeMode codemode.CodeMode `json:"code_mode"`
+	Count    int               `json:"count"`
+	DiskType proto.DiskType    `json:"disk_type,omitempty"`
+}
+
+// AllocSimulateRet distribution of allocated chunks by idc, idc-rack and host,
+// and times of every failure reason
+type AllocSimulateRet struct {
+	Count    int            `json:"count"`
+	Success  int            `json:"success"`
+	Idcs     map[string]int `json:"idcs"`
+	Racks    map[string]int `json:"racks"`
+	Nodes    map[string]int `json:"nodes"`
+	Failures map[string]int `json:"failures"`
+}
+
+// SimulateAllocVolume simulates allocating volumes against current allocator without committing
+func (c *Client) SimulateAllocVolume(ctx context.Context, args *AllocSimulateArgs) (ret *AllocSimulateRet, err error) {
+	ret = &AllocSimulateRet{}
+	err = c.PostWith(ctx, "/admin/volume/alloc/simulate", ret, args)
+	return
+}
+
+const (
+	PlacementViolationDiskMissing = "disk_missing"
+	PlacementViolationDiskSet     = "disk_set"
+	PlacementViolationIdc         = "idc"
+	PlacementViolationHost        = "host"
+	PlacementViolationRack        = "rack"
+)
+
+// PlacementCheckArgs re-checks unit placement of volumes against current topology,
+// checks the volumes of Vids if not empty, or one page of volumes after Marker.
+type PlacementCheckArgs struct {
+	Vids   []proto.Vid `json:"vids,omitempty"`
+	Marker proto.Vid   `json:"marker,omitempty"`
+	Count  int         `json:"count"`
+}
+
+// PlacementViolation the unit violates placement constraint of the type,
+// it's suggested to migrate the unit to SuggestDiskID if not zero.
+type PlacementViolation struct {
+	Type          string       `json:"type"`
+	Vid           proto.Vid    `json:"vid"`
+	Vuid          proto.Vuid   `json:"vuid"`
+	DiskID        proto.DiskID `json:"disk_id"`
+	Detail        string       `json:"detail,omitempty"`
+	SuggestDiskID proto.DiskID `json:"suggest_disk_id,omitempty"`
+}
+
+// PlacementCheckRet violations of checked volumes, Marker is the last checked vid.
+type PlacementCheckRet struct {
+	Checked    int                  `json:"checked"`
+	Marker     proto.Vid            `json:"marker"`
+	Violations []PlacementViolation `json:"violations"`
+}
+
+// CheckVolumePlacement re-checks volume unit placement after topology changes
+func (c *Client) CheckVolumePlacement(ctx context.Context, args *PlacementCheckArgs) (ret *PlacementCheckRet, err error) {
+	ret = &PlacementCheckRet{}
+	err = c.PostWith(ctx, "/admin/volume/placement/check", ret, args)
+	return
+}
@@ -30,6 +30,8 @@ import (
 type VersionVolume struct {
 	clustermgr.VolumeInfo
 	Version uint32 `json:"version,omitempty"`
+	// Stale is set if the volume was served from cache as clustermgr was unavailable.
+	Stale bool `json:"stale,omitempty"`
 }
 
 // GetVersion calculate version with volume's units.
@@ -37,7 +37,11 @@ type Client interface {
 }
 
 func New(cfg *Config) Client {
-	return &client{rpc.NewClient(&cfg.Config)}
+	conf := cfg.Config
+	if conf.Runtime.Name == "" {
+		conf.Runtime.Name = "proxy"
+	}
+	return &client{rpc.NewClient(&conf)}
 }
 
 func (c *client) VolumeAlloc(ctx context.Context, host string, args *AllocVolsArgs) (ret []AllocRet, err error) {
@@ -55,6 +59,10 @@ func (c *client) SendShardRepairMsg(ctx context.Context, host string, args *Shar
 	return c.PostWith(ctx, host+"/repairmsg", nil, args)
 }
 
+func (c *client) SendBlobEvent(ctx context.Context, host string, args *BlobEventArgs) error {
+	return c.PostWith(ctx, host+"/blobevent", nil, args)
+}
+
 func (c *client) SendDeleteMsg(ctx context.Context, host string, args *DeleteArgs) error {
 	return c.PostWith(ctx, host+"/deletemsg", nil, args)
 }
@@ -16,6 +16,7 @@ package proxy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -218,6 +219,37 @@ func TestLbClient_BlobDelete(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestLbClient_BlobEvent(t *testing.T) {
+	var args BlobEventArgs
+	mqproxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/blobevent", req.URL.Path)
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&args))
+		w.WriteHeader(200)
+	}))
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(200)
+		w.Write([]byte(fmt.Sprintf(`{"nodes":[{"cluster_id":1,"name":"PROXY","host":"%s","idc":"z0"}]}`, mqproxyServer.URL)))
+	}))
+	defer func() {
+		s.Close()
+		mqproxyServer.Close()
+	}()
+
+	cmCfg := clustermgr.Config{LbConfig: rpc.LbConfig{
+		Hosts: []string{s.URL},
+	}}
+	cm := clustermgr.New(&cmCfg)
+	cli := NewMQLbClient(&LbConfig{}, cm, 1)
+
+	err := cli.SendBlobEvent(context.Background(), &BlobEventArgs{
+		ClusterID: 1,
+		Events:    []BlobEvent{{Type: BlobEventRepair, Vid: 1, Bid: 2, Time: 100}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, BlobEventRepair, args.Events[0].Type)
+	require.Equal(t, proto.BlobID(2), args.Events[0].Bid)
+}
+
 func TestCacher_DiskvPathTransform(t *testing.T) {
 	for _, cs := range []struct {
 		key   string
@@ -111,6 +111,24 @@ func (c *lbClient) SendShardRepairMsg(ctx context.Context, args *ShardRepairArgs
 	return err
 }
 
+func (c *lbClient) SendBlobEvent(ctx context.Context, args *BlobEventArgs) (err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	hosts := c.selector.GetRandomN(c.hostRetry)
+	if len(hosts) == 0 {
+		return errNoServiceAvailable
+	}
+	for _, h := range hosts {
+		err = c.Client.SendBlobEvent(ctx, h, args)
+		if err == nil || !shouldRetry(err) {
+			return err
+		}
+		span.Errorf("send blob event failed, host: %s, args: %+v, err:%+v", h, args, err)
+	}
+
+	return err
+}
+
 func shouldRetry(err error) bool {
 	if err == nil {
 		return false // success
@@ -21,11 +21,13 @@ import (
 )
 
 type MsgSender interface {
+	SendBlobEvent(ctx context.Context, host string, args *BlobEventArgs) error
 	SendDeleteMsg(ctx context.Context, host string, args *DeleteArgs) error
 	SendShardRepairMsg(ctx context.Context, host string, args *ShardRepairArgs) error
 }
 
 type LbMsgSender interface {
+	SendBlobEvent(ctx context.Context, args *BlobEventArgs) error
 	SendDeleteMsg(ctx context.Context, args *DeleteArgs) error
 	SendShardRepairMsg(ctx context.Context, args *ShardRepairArgs) error
 }
@@ -33,6 +35,8 @@ type LbMsgSender interface {
 type DeleteArgs struct {
 	ClusterID proto.ClusterID `json:"cluster_id"`
 	Blobs     []BlobDelete    `json:"blobs"`
+	// DelayS the blobs are deleted after the delay seconds at least
+	DelayS int64 `json:"delay_s,omitempty"`
 }
 
 type BlobDelete struct {
@@ -46,4 +50,32 @@ type ShardRepairArgs struct {
 	Vid       proto.Vid       `json:"vid"`
 	BadIdxes  []uint8         `json:"bad_idxes"`
 	Reason    string          `json:"reason"`
+	// BadRange optional bytes range of the bad shard
+	BadRange *proto.ShardRange `json:"bad_range,omitempty"`
+}
+
+// BlobEventType lifecycle event of blob
+type BlobEventType string
+
+const (
+	BlobEventPut    = BlobEventType("put")    // blob of space is put completed, sealed or undeleted
+	BlobEventDelete = BlobEventType("delete") // blob of space is deleted or moved into trash
+	BlobEventRepair = BlobEventType("repair") // bad shards of blob are repaired
+)
+
+// BlobEvent event published to the sink of space, events without space id are
+// published to the default sink, e.g. repair event which knows only vid and bid.
+type BlobEvent struct {
+	Type     BlobEventType   `json:"type"`
+	SpaceID  proto.SpaceID   `json:"space_id,omitempty"`
+	BlobName []byte          `json:"blob_name,omitempty"`
+	Location *proto.Location `json:"location,omitempty"`
+	Vid      proto.Vid       `json:"vid,omitempty"`
+	Bid      proto.BlobID    `json:"bid,omitempty"`
+	Time     int64           `json:"time"` // unix seconds of event happened
+}
+
+type BlobEventArgs struct {
+	ClusterID proto.ClusterID `json:"cluster_id"`
+	Events    []BlobEvent     `json:"events"`
 }
@@ -40,9 +40,18 @@ const (
 	PathInspectAcquire       = "/inspect/acquire"
 	PathManualMigrateTaskAdd = "/manual/migrate/task/add"
 
+	PathManualMigrateTaskStatus = "/manual/migrate/task/status"
+
 	PathTaskDetail    = "/task/detail"
 	PathTaskDetailURI = PathTaskDetail + "/:type/:id" // "/task/detail/:type/:id"
 	PathUpdateVolume  = "/update/vol"
+
+	PathTaskRecords           = "/task/records"
+	PathTaskDiskMigrateReport = "/task/disk/report"
+
+	PathDiskDropRequeue = "/disk/drop/requeue"
+
+	PathVolumeInspect = "/volume/inspect"
 )
 
 const defaultHostSyncIntervalMs = 3600000 // 1 hour
@@ -83,11 +92,19 @@ type ISchedulerStatus interface {
 	DiskMigratingStats(ctx context.Context, args *DiskMigratingStatsArgs) (ret *DiskMigratingStats, err error)
 	Stats(ctx context.Context, host string) (ret TasksStat, err error)
 	LeaderStats(ctx context.Context) (ret TasksStat, err error)
+	ListTaskRecords(ctx context.Context, args *ListTaskRecordsArgs) (ret *ListTaskRecordsRet, err error)
+	DiskMigrateReport(ctx context.Context, args *DiskMigrateReportArgs) (ret *DiskMigrateReport, err error)
 }
 
-// IManualMigrator add manual migrate task.
+// IManualMigrator add manual migrate task and query its status.
 type IManualMigrator interface {
-	AddManualMigrateTask(ctx context.Context, args *AddManualMigrateArgs) (err error)
+	AddManualMigrateTask(ctx context.Context, args *AddManualMigrateArgs) (ret AddManualMigrateRet, err error)
+	ManualMigrateTaskStatus(ctx context.Context, args *ManualMigrateStatusArgs) (ret ManualMigrateStatus, err error)
+}
+
+// IVolumeInspector inspect and repair the specified volume.
+type IVolumeInspector interface {
+	InspectVolume(ctx context.Context, args *InspectVolumeArgs) (ret InspectVolumeRet, err error)
 }
 
 // IVolumeUpdater volume updater.
@@ -95,12 +112,18 @@ type IVolumeUpdater interface {
 	UpdateVolume(ctx context.Context, host string, vid proto.Vid) (err error)
 }
 
+// IDiskDropRequeuer requeue the stuck dropping disk.
+type IDiskDropRequeuer interface {
+	RequeueDropDisk(ctx context.Context, host string, diskID proto.DiskID) (err error)
+}
+
 // IScheduler scheduler api interface.
 type IScheduler interface {
 	IMigrator
 	IInspector
 	ISchedulerStatus
 	IManualMigrator
+	IVolumeInspector
 	IVolumeUpdater
 }
 
@@ -143,16 +166,35 @@ func New(cfg *Config, service cmapi.APIService, clusterID proto.ClusterID) ISche
 	if cfg.HostRetry == 0 {
 		cfg.HostRetry = 1
 	}
+	// the client retries on hosts by itself
+	conf := cfg.Config
+	conf.Runtime.Retry = rpc.RetryConfig{}
+	if conf.Runtime.Name == "" {
+		conf.Runtime.Name = "scheduler"
+	}
 	return &client{
 		hostRetry: cfg.HostRetry,
 		selector:  selector.MakeSelector(cfg.HostSyncIntervalMs, hostGetter),
-		Client:    rpc.NewClient(&cfg.Config),
+		Client:    rpc.NewClient(&conf),
 	}
 }
 
 // NewVolumeUpdater returns volume updater client.
 func NewVolumeUpdater(cfg *Config) IVolumeUpdater {
-	return &client{Client: rpc.NewClient(&cfg.Config)}
+	conf := cfg.Config
+	if conf.Runtime.Name == "" {
+		conf.Runtime.Name = "scheduler"
+	}
+	return &client{Client: rpc.NewClient(&conf)}
+}
+
+// NewDiskDropRequeuer returns dropping disk requeuer client.
+func NewDiskDropRequeuer(cfg *Config) IDiskDropRequeuer {
+	conf := cfg.Config
+	if conf.Runtime.Name == "" {
+		conf.Runtime.Name = "scheduler"
+	}
+	return &client{Client: rpc.NewClient(&conf)}
 }
 
 // UpdateVolumeArgs argument of volume to update.
@@ -164,6 +206,17 @@ func (c *client) UpdateVolume(ctx context.Context, host string, vid proto.Vid) (
 	return c.PostWith(ctx, hostWithScheme(host)+PathUpdateVolume, nil, UpdateVolumeArgs{Vid: vid})
 }
 
+// DiskDropRequeueArgs argument of dropping disk to requeue.
+type DiskDropRequeueArgs struct {
+	DiskID proto.DiskID `json:"disk_id"`
+}
+
+// RequeueDropDisk the scheduler regenerates drop tasks of the disk which lost track of its tasks,
+// request is forwarded to the leader of scheduler.
+func (c *client) RequeueDropDisk(ctx context.Context, host string, diskID proto.DiskID) (err error) {
+	return c.PostWith(ctx, hostWithScheme(host)+PathDiskDropRequeue, nil, DiskDropRequeueArgs{DiskID: diskID})
+}
+
 func (c *client) ReportShardTask(ctx context.Context, args *ShardTaskReportArgs) (err error) {
 	taskArgs, err := args.TaskArgs()
 	if err != nil {
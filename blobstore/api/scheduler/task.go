@@ -19,7 +19,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"time"
 
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 )
@@ -160,19 +162,133 @@ func (c *client) CompleteInspectTask(ctx context.Context, args *proto.VolumeInsp
 	})
 }
 
+// AddManualMigrateArgs migrates the volume unit specified by vuid or chunk id off its disk,
+// the unit must be on the disk if DiskID is set.
 type AddManualMigrateArgs struct {
-	Vuid           proto.Vuid `json:"vuid"`
-	DirectDownload bool       `json:"direct_download"`
+	Vuid           proto.Vuid   `json:"vuid"`
+	Chunk          string       `json:"chunk,omitempty"`
+	DiskID         proto.DiskID `json:"disk_id,omitempty"`
+	DirectDownload bool         `json:"direct_download"`
+}
+
+// Unit returns vuid of the args, it's decoded from chunk id if vuid is not set.
+func (args *AddManualMigrateArgs) Unit() proto.Vuid {
+	if args.Vuid.IsValid() || args.Chunk == "" {
+		return args.Vuid
+	}
+	chunk, err := cmapi.DecodeChunk(args.Chunk)
+	if err != nil {
+		return proto.InvalidVuid
+	}
+	return chunk.VolumeUnitId()
 }
 
 func (args *AddManualMigrateArgs) Valid() bool {
-	return args.Vuid.IsValid()
+	return args.Unit().IsValid()
 }
 
-func (c *client) AddManualMigrateTask(ctx context.Context, args *AddManualMigrateArgs) (err error) {
-	return c.request(func(host string) error {
-		return c.PostWith(ctx, host+PathManualMigrateTaskAdd, nil, args)
+// AddManualMigrateRet the added manual migrate task on source disk.
+type AddManualMigrateRet struct {
+	TaskID string       `json:"task_id"`
+	DiskID proto.DiskID `json:"disk_id"`
+}
+
+func (c *client) AddManualMigrateTask(ctx context.Context, args *AddManualMigrateArgs) (ret AddManualMigrateRet, err error) {
+	err = c.request(func(host string) error {
+		return c.PostWith(ctx, host+PathManualMigrateTaskAdd, &ret, args)
 	})
+	return
+}
+
+type ManualMigrateStatusArgs struct {
+	TaskID string       `json:"task_id"`
+	DiskID proto.DiskID `json:"disk_id"`
+}
+
+// ManualMigrateStatus status of manual migrate task, the record is set after the task finished.
+type ManualMigrateStatus struct {
+	TaskID   string             `json:"task_id"`
+	State    proto.MigrateState `json:"state"`
+	Finished bool               `json:"finished"`
+	Record   *TaskRecord        `json:"record,omitempty"`
+}
+
+func (c *client) ManualMigrateTaskStatus(ctx context.Context, args *ManualMigrateStatusArgs) (ret ManualMigrateStatus, err error) {
+	if args == nil || args.TaskID == "" || args.DiskID == proto.InvalidDiskID {
+		err = errcode.ErrIllegalArguments
+		return
+	}
+	err = c.request(func(host string) error {
+		path := host + PathManualMigrateTaskStatus + fmt.Sprintf("?task_id=%s&disk_id=%d",
+			url.QueryEscape(args.TaskID), args.DiskID)
+		return c.GetWith(ctx, path, &ret)
+	})
+	return
+}
+
+// InspectVolumeArgs inspects the volume immediately, bypassing the inspection cycle,
+// only the blob is inspected if Bid is set. Missing or corrupt shards are repaired if Repair is set.
+type InspectVolumeArgs struct {
+	Vid    proto.Vid    `json:"vid"`
+	Bid    proto.BlobID `json:"bid,omitempty"`
+	Repair bool         `json:"repair"`
+}
+
+// status of inspected shard
+const (
+	InspectShardNormal      = "normal"
+	InspectShardMissing     = "missing"
+	InspectShardCorrupt     = "corrupt"
+	InspectShardMarkDeleted = "mark_deleted"
+	InspectShardUnknown     = "unknown"
+)
+
+// InspectShard shard of the blob on volume unit.
+type InspectShard struct {
+	Index  uint8  `json:"index"`
+	Status string `json:"status"`
+	Size   int64  `json:"size,omitempty"`
+	Crc    uint32 `json:"crc,omitempty"`
+	Err    string `json:"err,omitempty"`
+}
+
+// InspectBlob inspected blob, Bads are indexes of missing or corrupt shards.
+type InspectBlob struct {
+	Bid         proto.BlobID   `json:"bid"`
+	Shards      []InspectShard `json:"shards,omitempty"`
+	Bads        []uint8        `json:"bads,omitempty"`
+	Recoverable bool           `json:"recoverable"`
+	Repairing   bool           `json:"repairing"`
+}
+
+// InspectUnit volume unit with count of shards on its disk.
+type InspectUnit struct {
+	Index    uint8        `json:"index"`
+	Vuid     proto.Vuid   `json:"vuid"`
+	Host     string       `json:"host"`
+	DiskID   proto.DiskID `json:"disk_id"`
+	ShardCnt int          `json:"shard_cnt"`
+	Err      string       `json:"err,omitempty"`
+}
+
+// InspectVolumeRet result of inspecting the volume,
+// Blobs are all blobs with bad shards, or the specified blob.
+type InspectVolumeRet struct {
+	Vid    proto.Vid     `json:"vid"`
+	Active bool          `json:"active"`
+	Units  []InspectUnit `json:"units"`
+	Blobs  []InspectBlob `json:"blobs"`
+}
+
+func (c *client) InspectVolume(ctx context.Context, args *InspectVolumeArgs) (ret InspectVolumeRet, err error) {
+	if args == nil || args.Vid == proto.InvalidVid {
+		err = errcode.ErrIllegalArguments
+		return
+	}
+	err = c.request(func(host string) error {
+		return c.PostWith(ctx, host+PathVolumeInspect, &ret, args)
+	})
+	return
 }
 
 // MigrateTaskDetailArgs migrate task detail args.
@@ -266,6 +382,51 @@ type BlobnodeTaskStats struct {
 	VolumeInspect *VolumeInspectTasksStat `json:"volume_inspect,omitempty"`
 	ShardRepair   *RunnerStat             `json:"shard_repair"`
 	BlobDelete    *RunnerStat             `json:"blob_delete"`
+
+	ClusterMigrate *ClusterMigrateStat `json:"cluster_migrate,omitempty"`
+}
+
+// ClusterMigrateVolumeProgress progress of the blobs located in the source volume
+type ClusterMigrateVolumeProgress struct {
+	Vid proto.Vid `json:"vid"`
+	// blobs of the volume are listed from Marker, Listed is true if all blobs of it listed
+	Marker   []byte `json:"marker,omitempty"`
+	Listed   bool   `json:"listed"`
+	Migrated int64  `json:"migrated"`
+	Bytes    uint64 `json:"bytes"`
+	Failed   int64  `json:"failed"`
+}
+
+// ClusterMigrateSkipped counts of the skipped blobs by reason
+type ClusterMigrateSkipped struct {
+	// blobs already in destination with the same size and crc
+	Existed  int64 `json:"existed"`
+	Unsealed int64 `json:"unsealed"`
+	Empty    int64 `json:"empty"`
+}
+
+// ClusterMigrateProgress progress of copying blobs into the destination cluster,
+// the blobs are listed from marker after restarted or leader switched.
+type ClusterMigrateProgress struct {
+	Marker []byte `json:"marker"`
+	// Listed is true if all blobs listed, it's finished after the failed blobs are migrated
+	Listed   bool   `json:"listed"`
+	Finished bool   `json:"finished"`
+	Migrated int64  `json:"migrated"`
+	Bytes    uint64 `json:"bytes"`
+
+	Skipped ClusterMigrateSkipped `json:"skipped"`
+	// Failed is count of FailedBlobs, which are retried before listing next batch
+	Failed      int64        `json:"failed"`
+	FailedBlobs []proto.Blob `json:"failed_blobs,omitempty"`
+	// progress of the specified volumes
+	Volumes []ClusterMigrateVolumeProgress `json:"volumes,omitempty"`
+	Mtime   string                         `json:"mtime"`
+}
+
+type ClusterMigrateStat struct {
+	Enable bool `json:"enable"`
+	ClusterMigrateProgress
 }
 
 type ShardTaskStats struct {
@@ -323,6 +484,80 @@ func (c *client) DiskMigratingStats(ctx context.Context, args *DiskMigratingStat
 	return
 }
 
+// TaskRecord record of finished background task
+type TaskRecord struct {
+	TaskID            string             `json:"task_id"`
+	TaskType          proto.TaskType     `json:"task_type"`
+	State             proto.MigrateState `json:"state"`
+	SourceDiskID      proto.DiskID       `json:"source_disk_id"`
+	SourceVuid        proto.Vuid         `json:"source_vuid"`
+	DestinationDiskID proto.DiskID       `json:"destination_disk_id"`
+	DestinationVuid   proto.Vuid         `json:"destination_vuid"`
+	BytesMoved        uint64             `json:"bytes_moved"`
+	ShardsMoved       uint64             `json:"shards_moved"`
+	RedoCount         uint8              `json:"redo_count"`
+	Error             string             `json:"error,omitempty"` // reason of finishing in advance
+	StartTime         time.Time          `json:"start_time"`
+	FinishTime        time.Time          `json:"finish_time"`
+	DurationMs        int64              `json:"duration_ms"`
+}
+
+// ListTaskRecordsArgs list records of finished tasks, filter by disk and task type if not empty
+type ListTaskRecordsArgs struct {
+	DiskID   proto.DiskID   `json:"disk_id"`
+	TaskType proto.TaskType `json:"task_type"`
+	Marker   string         `json:"marker"`
+	Count    int            `json:"count"`
+}
+
+type ListTaskRecordsRet struct {
+	Records []*TaskRecord `json:"records"`
+	Marker  string        `json:"marker"`
+}
+
+type DiskMigrateReportArgs struct {
+	DiskID proto.DiskID `json:"disk_id"`
+}
+
+// DiskMigrateReport report of finished tasks migrating from the disk
+type DiskMigrateReport struct {
+	DiskID                 proto.DiskID           `json:"disk_id"`
+	TaskCount              int                    `json:"task_count"`
+	FinishedCount          int                    `json:"finished_count"`
+	FinishedInAdvanceCount int                    `json:"finished_in_advance_count"`
+	BytesMoved             uint64                 `json:"bytes_moved"`
+	ShardsMoved            uint64                 `json:"shards_moved"`
+	TaskTypes              map[proto.TaskType]int `json:"task_types"`
+	Destinations           map[proto.DiskID]int   `json:"destinations"`
+	Errors                 map[string]int         `json:"errors"`
+	FirstStartTime         time.Time              `json:"first_start_time"`
+	LastFinishTime         time.Time              `json:"last_finish_time"`
+}
+
+func (c *client) ListTaskRecords(ctx context.Context, args *ListTaskRecordsArgs) (ret *ListTaskRecordsRet, err error) {
+	if args == nil || (args.TaskType != "" && !args.TaskType.Valid()) {
+		err = errcode.ErrIllegalArguments
+		return
+	}
+	err = c.request(func(host string) error {
+		path := host + PathTaskRecords + fmt.Sprintf("?disk_id=%d&task_type=%s&marker=%s&count=%d",
+			args.DiskID, args.TaskType, url.QueryEscape(args.Marker), args.Count)
+		return c.GetWith(ctx, path, &ret)
+	})
+	return
+}
+
+func (c *client) DiskMigrateReport(ctx context.Context, args *DiskMigrateReportArgs) (ret *DiskMigrateReport, err error) {
+	if args == nil || args.DiskID == proto.InvalidDiskID {
+		err = errcode.ErrIllegalArguments
+		return
+	}
+	err = c.request(func(host string) error {
+		return c.GetWith(ctx, host+PathTaskDiskMigrateReport+fmt.Sprintf("?disk_id=%d", args.DiskID), &ret)
+	})
+	return
+}
+
 func (c *client) selectHost() ([]string, error) {
 	hosts := c.selector.GetRandomN(c.hostRetry)
 	if len(hosts) == 0 {
@@ -50,3 +50,36 @@ func (c *Client) AllocSlice(ctx context.Context, host string, args AllocSliceArg
 	err = c.doRequest(ctx, host, "/slice/alloc", &args, &ret)
 	return
 }
+
+func (c *Client) TrashBlob(ctx context.Context, host string, args TrashBlobArgs) error {
+	return c.doRequest(ctx, host, "/blob/trash", &args, nil)
+}
+
+func (c *Client) UndeleteBlob(ctx context.Context, host string, args UndeleteBlobArgs) (ret UndeleteBlobRet, err error) {
+	err = c.doRequest(ctx, host, "/blob/undelete", &args, &ret)
+	return
+}
+
+func (c *Client) ListTrashBlob(ctx context.Context, host string, args ListTrashBlobArgs) (ret ListTrashBlobRet, err error) {
+	err = c.doRequest(ctx, host, "/blob/trash/list", &args, &ret)
+	return
+}
+
+func (c *Client) PurgeTrashBlob(ctx context.Context, host string, args PurgeTrashBlobArgs) error {
+	return c.doRequest(ctx, host, "/blob/trash/purge", &args, nil)
+}
+
+func (c *Client) RefDedup(ctx context.Context, host string, args RefDedupArgs) (ret RefDedupRet, err error) {
+	err = c.doRequest(ctx, host, "/dedup/ref", &args, &ret)
+	return
+}
+
+func (c *Client) GetDedup(ctx context.Context, host string, args GetDedupArgs) (ret GetDedupRet, err error) {
+	err = c.doRequest(ctx, host, "/dedup/get", &args, &ret)
+	return
+}
+
+func (c *Client) UnrefDedup(ctx context.Context, host string, args UnrefDedupArgs) (ret UnrefDedupRet, err error) {
+	err = c.doRequest(ctx, host, "/dedup/unref", &args, &ret)
+	return
+}
@@ -44,6 +44,14 @@ type AccessAPI interface {
 	SealBlob(ctx context.Context, host string, args SealBlobArgs) error
 	AllocSlice(ctx context.Context, host string, args AllocSliceArgs) (ret AllocSliceRet, err error)
 	FindAndDeleteBlob(ctx context.Context, host string, args DeleteBlobArgs) (ret GetBlobRet, err error)
+	TrashBlob(ctx context.Context, host string, args TrashBlobArgs) error
+	UndeleteBlob(ctx context.Context, host string, args UndeleteBlobArgs) (ret UndeleteBlobRet, err error)
+	ListTrashBlob(ctx context.Context, host string, args ListTrashBlobArgs) (ret ListTrashBlobRet, err error)
+	PurgeTrashBlob(ctx context.Context, host string, args PurgeTrashBlobArgs) error
+	RefDedup(ctx context.Context, host string, args RefDedupArgs) (ret RefDedupRet, err error)
+	GetDedup(ctx context.Context, host string, args GetDedupArgs) (ret GetDedupRet, err error)
+	UnrefDedup(ctx context.Context, host string, args UnrefDedupArgs) (ret UnrefDedupRet, err error)
+	CommitTxn(ctx context.Context, host string, args CommitTxnArgs) error
 
 	GetShardStats(ctx context.Context, host string, args GetShardArgs) (ret ShardStats, err error)
 }
@@ -93,3 +101,35 @@ func (c *FakeClient) AllocSlice(ctx context.Context, host string, args AllocSlic
 func (c *FakeClient) FindAndDeleteBlob(ctx context.Context, host string, args DeleteBlobArgs) (ret GetBlobRet, err error) {
 	return GetBlobRet{}, errcode.ErrShardNodeUnsupport
 }
+
+func (c *FakeClient) TrashBlob(ctx context.Context, host string, args TrashBlobArgs) error {
+	return errcode.ErrShardNodeUnsupport
+}
+
+func (c *FakeClient) UndeleteBlob(ctx context.Context, host string, args UndeleteBlobArgs) (ret UndeleteBlobRet, err error) {
+	return UndeleteBlobRet{}, errcode.ErrShardNodeUnsupport
+}
+
+func (c *FakeClient) ListTrashBlob(ctx context.Context, host string, args ListTrashBlobArgs) (ret ListTrashBlobRet, err error) {
+	return ListTrashBlobRet{}, errcode.ErrShardNodeUnsupport
+}
+
+func (c *FakeClient) PurgeTrashBlob(ctx context.Context, host string, args PurgeTrashBlobArgs) error {
+	return errcode.ErrShardNodeUnsupport
+}
+
+func (c *FakeClient) RefDedup(ctx context.Context, host string, args RefDedupArgs) (ret RefDedupRet, err error) {
+	return RefDedupRet{}, errcode.ErrShardNodeUnsupport
+}
+
+func (c *FakeClient) GetDedup(ctx context.Context, host string, args GetDedupArgs) (ret GetDedupRet, err error) {
+	return GetDedupRet{}, errcode.ErrShardNodeUnsupport
+}
+
+func (c *FakeClient) UnrefDedup(ctx context.Context, host string, args UnrefDedupArgs) (ret UnrefDedupRet, err error) {
+	return UnrefDedupRet{}, errcode.ErrShardNodeUnsupport
+}
+
+func (c *FakeClient) CommitTxn(ctx context.Context, host string, args CommitTxnArgs) error {
+	return errcode.ErrShardNodeUnsupport
+}
@@ -15,6 +15,7 @@
 package shardnode
 
 import (
+	"bytes"
 	"context"
 )
 
@@ -39,3 +40,30 @@ func (c *Client) ListItem(ctx context.Context, host string, args ListItemArgs) (
 	err = c.doRequest(ctx, host, "/item/list", &args, &ret)
 	return
 }
+
+// Valid returns false if the operator of filter is unknown
+func (m *FieldFilter) Valid() bool {
+	return m.Op > FilterOpNone && m.Op <= FilterOpPrefix
+}
+
+// Match returns true if the field value satisfies the filter
+func (m *FieldFilter) Match(value []byte) bool {
+	switch m.Op {
+	case FilterOpEqual:
+		return bytes.Equal(value, m.Value)
+	case FilterOpNotEqual:
+		return !bytes.Equal(value, m.Value)
+	case FilterOpLess:
+		return bytes.Compare(value, m.Value) < 0
+	case FilterOpLessEqual:
+		return bytes.Compare(value, m.Value) <= 0
+	case FilterOpGreater:
+		return bytes.Compare(value, m.Value) > 0
+	case FilterOpGreaterEqual:
+		return bytes.Compare(value, m.Value) >= 0
+	case FilterOpPrefix:
+		return bytes.HasPrefix(value, m.Value)
+	default:
+		return false
+	}
+}
@@ -32,6 +32,11 @@ func (c *Client) TransferShardLeader(ctx context.Context, host string, args Tran
 	return c.doRequest(ctx, host, "/shard/leadertransfer", &args, nil)
 }
 
+// SetShardReadonly rejects writes of shard on all replicas if readonly, args should be sent to the leader
+func (c *Client) SetShardReadonly(ctx context.Context, host string, args SetShardReadonlyArgs) error {
+	return c.doRequest(ctx, host, "/shard/readonly", &args, nil)
+}
+
 func (c *Client) GetShardUintInfo(ctx context.Context, host string, args GetShardArgs) (ret clustermgr.ShardUnitInfo, err error) {
 	err = c.doRequest(ctx, host, "/shard/info", &args, &ret)
 	return
@@ -29,9 +29,133 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type TxnOpType int32
+
+const (
+	TxnOpNone TxnOpType = 0
+	// insert or overwrite the item
+	TxnOpPutItem TxnOpType = 1
+	// update fields of the exist item, conflicts if not exist
+	TxnOpUpdateItem TxnOpType = 2
+	TxnOpDeleteItem TxnOpType = 3
+	// seal the blob created by CreateBlob with size and slices of blob location,
+	// the location is validated as SealBlob, conflicts if not exist
+	TxnOpPutBlob TxnOpType = 4
+	// move the blob into trash with expire time, its data is deleted by purge loop
+	TxnOpDeleteBlob TxnOpType = 5
+)
+
+var TxnOpType_name = map[int32]string{
+	0: "TxnOpNone",
+	1: "TxnOpPutItem",
+	2: "TxnOpUpdateItem",
+	3: "TxnOpDeleteItem",
+	4: "TxnOpPutBlob",
+	5: "TxnOpDeleteBlob",
+}
+
+var TxnOpType_value = map[string]int32{
+	"TxnOpNone":       0,
+	"TxnOpPutItem":    1,
+	"TxnOpUpdateItem": 2,
+	"TxnOpDeleteItem": 3,
+	"TxnOpPutBlob":    4,
+	"TxnOpDeleteBlob": 5,
+}
+
+func (x TxnOpType) String() string {
+	return proto.EnumName(TxnOpType_name, int32(x))
+}
+
+func (TxnOpType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{0}
+}
+
+type TxnCondType int32
+
+const (
+	TxnCondNone     TxnCondType = 0
+	TxnCondNotExist TxnCondType = 1
+	TxnCondExist    TxnCondType = 2
+	// the field of item equals to the value
+	TxnCondFieldEqual TxnCondType = 3
+	// the marshaled blob record equals to the value, for blob only
+	TxnCondValueEqual TxnCondType = 4
+)
+
+var TxnCondType_name = map[int32]string{
+	0: "TxnCondNone",
+	1: "TxnCondNotExist",
+	2: "TxnCondExist",
+	3: "TxnCondFieldEqual",
+	4: "TxnCondValueEqual",
+}
+
+var TxnCondType_value = map[string]int32{
+	"TxnCondNone":       0,
+	"TxnCondNotExist":   1,
+	"TxnCondExist":      2,
+	"TxnCondFieldEqual": 3,
+	"TxnCondValueEqual": 4,
+}
+
+func (x TxnCondType) String() string {
+	return proto.EnumName(TxnCondType_name, int32(x))
+}
+
+func (TxnCondType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{1}
+}
+
+type FilterOp int32
+
+const (
+	FilterOpNone     FilterOp = 0
+	FilterOpEqual    FilterOp = 1
+	FilterOpNotEqual FilterOp = 2
+	// bytewise comparisons with the value
+	FilterOpLess         FilterOp = 3
+	FilterOpLessEqual    FilterOp = 4
+	FilterOpGreater      FilterOp = 5
+	FilterOpGreaterEqual FilterOp = 6
+	// the field value has prefix of the value
+	FilterOpPrefix FilterOp = 7
+)
+
+var FilterOp_name = map[int32]string{
+	0: "FilterOpNone",
+	1: "FilterOpEqual",
+	2: "FilterOpNotEqual",
+	3: "FilterOpLess",
+	4: "FilterOpLessEqual",
+	5: "FilterOpGreater",
+	6: "FilterOpGreaterEqual",
+	7: "FilterOpPrefix",
+}
+
+var FilterOp_value = map[string]int32{
+	"FilterOpNone":         0,
+	"FilterOpEqual":        1,
+	"FilterOpNotEqual":     2,
+	"FilterOpLess":         3,
+	"FilterOpLessEqual":    4,
+	"FilterOpGreater":      5,
+	"FilterOpGreaterEqual": 6,
+	"FilterOpPrefix":       7,
+}
+
+func (x FilterOp) String() string {
+	return proto.EnumName(FilterOp_name, int32(x))
+}
+
+func (FilterOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{2}
+}
+
 type Item struct {
 	ID                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Fields               []Field  `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields"`
+	Version              uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -84,6 +208,13 @@ func (m *Item) GetFields() []Field {
 	return nil
 }
 
+func (m *Item) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type Field struct {
 	ID                   github_com_cubefs_cubefs_blobstore_common_proto.FieldID `protobuf:"varint,1,opt,name=id,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.FieldID" json:"id,omitempty"`
 	Value                []byte                                                  `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -140,15 +271,20 @@ func (m *Field) GetValue() []byte {
 }
 
 type ShardOpHeader struct {
-	SpaceID              github_com_cubefs_cubefs_blobstore_common_proto.SpaceID      `protobuf:"varint,1,opt,name=space_id,json=spaceId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.SpaceID" json:"space_id,omitempty"`
-	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID       `protobuf:"varint,2,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
-	Suid                 github_com_cubefs_cubefs_blobstore_common_proto.Suid         `protobuf:"varint,3,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
-	SpaceVersion         uint64                                                       `protobuf:"varint,4,opt,name=spaceVersion,proto3" json:"spaceVersion,omitempty"`
-	RouteVersion         github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion `protobuf:"varint,5,opt,name=route_version,json=routeVersion,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.RouteVersion" json:"route_version,omitempty"`
-	ShardKeys            [][]byte                                                     `protobuf:"bytes,6,rep,name=shard_keys,json=shardKeys,proto3" json:"shard_keys,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                                     `json:"-"`
-	XXX_unrecognized     []byte                                                       `json:"-"`
-	XXX_sizecache        int32                                                        `json:"-"`
+	SpaceID      github_com_cubefs_cubefs_blobstore_common_proto.SpaceID      `protobuf:"varint,1,opt,name=space_id,json=spaceId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.SpaceID" json:"space_id,omitempty"`
+	DiskID       github_com_cubefs_cubefs_blobstore_common_proto.DiskID       `protobuf:"varint,2,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
+	Suid         github_com_cubefs_cubefs_blobstore_common_proto.Suid         `protobuf:"varint,3,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
+	SpaceVersion uint64                                                       `protobuf:"varint,4,opt,name=spaceVersion,proto3" json:"spaceVersion,omitempty"`
+	RouteVersion github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion `protobuf:"varint,5,opt,name=route_version,json=routeVersion,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.RouteVersion" json:"route_version,omitempty"`
+	ShardKeys    [][]byte                                                     `protobuf:"bytes,6,rep,name=shard_keys,json=shardKeys,proto3" json:"shard_keys,omitempty"`
+	// sync wal of the request immediately, bypass the group commit window
+	Sync bool `protobuf:"varint,7,opt,name=sync,proto3" json:"sync,omitempty"`
+	// raft term of the leader known by client, used as fencing token of writes,
+	// zero means no fencing
+	LeaderTerm           uint64   `protobuf:"varint,8,opt,name=leader_term,json=leaderTerm,proto3" json:"leader_term,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ShardOpHeader) Reset()         { *m = ShardOpHeader{} }
@@ -226,6 +362,20 @@ func (m *ShardOpHeader) GetShardKeys() [][]byte {
 	return nil
 }
 
+func (m *ShardOpHeader) GetSync() bool {
+	if m != nil {
+		return m.Sync
+	}
+	return false
+}
+
+func (m *ShardOpHeader) GetLeaderTerm() uint64 {
+	if m != nil {
+		return m.LeaderTerm
+	}
+	return 0
+}
+
 type InsertItemArgs struct {
 	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
 	Item                 Item          `protobuf:"bytes,2,opt,name=item,proto3" json:"item"`
@@ -509,11 +659,13 @@ func (m *DeleteItemRet) XXX_DiscardUnknown() {
 var xxx_messageInfo_DeleteItemRet proto.InternalMessageInfo
 
 type GetItemArgs struct {
-	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	ID                   []byte        `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Header ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	ID     []byte        `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// version of item, zero means the latest
+	Version              uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetItemArgs) Reset()         { *m = GetItemArgs{} }
@@ -563,6 +715,13 @@ func (m *GetItemArgs) GetID() []byte {
 	return nil
 }
 
+func (m *GetItemArgs) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type GetItemRet struct {
 	Item                 Item     `protobuf:"bytes,1,opt,name=item,proto3" json:"item"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -611,10 +770,13 @@ func (m *GetItemRet) GetItem() Item {
 }
 
 type ListItemArgs struct {
-	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Prefix               []byte        `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	Marker               []byte        `protobuf:"bytes,3,opt,name=marker,proto3" json:"marker,omitempty"`
-	Count                uint64        `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Header ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Prefix []byte        `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Marker []byte        `protobuf:"bytes,3,opt,name=marker,proto3" json:"marker,omitempty"`
+	Count  uint64        `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// only the items matching all filters are returned, the scanned items
+	// are limited, so there may be less items with next marker
+	Filters              []FieldFilter `protobuf:"bytes,5,rep,name=filters,proto3" json:"filters"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -681,6 +843,13 @@ func (m *ListItemArgs) GetCount() uint64 {
 	return 0
 }
 
+func (m *ListItemArgs) GetFilters() []FieldFilter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
 type ListItemRet struct {
 	Items                []Item   `protobuf:"bytes,1,rep,name=items,proto3" json:"items"`
 	NextMarker           []byte   `protobuf:"bytes,2,opt,name=nextMarker,proto3" json:"nextMarker,omitempty"`
@@ -1066,6 +1235,69 @@ func (m *TransferShardLeaderRet) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_TransferShardLeaderRet proto.InternalMessageInfo
 
+type SetShardReadonlyArgs struct {
+	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
+	Suid                 github_com_cubefs_cubefs_blobstore_common_proto.Suid   `protobuf:"varint,2,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
+	Readonly             bool                                                   `protobuf:"varint,3,opt,name=readonly,proto3" json:"readonly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                               `json:"-"`
+	XXX_unrecognized     []byte                                                 `json:"-"`
+	XXX_sizecache        int32                                                  `json:"-"`
+}
+
+func (m *SetShardReadonlyArgs) Reset()         { *m = SetShardReadonlyArgs{} }
+func (m *SetShardReadonlyArgs) String() string { return proto.CompactTextString(m) }
+func (*SetShardReadonlyArgs) ProtoMessage()    {}
+func (*SetShardReadonlyArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{19}
+}
+func (m *SetShardReadonlyArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetShardReadonlyArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetShardReadonlyArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetShardReadonlyArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetShardReadonlyArgs.Merge(m, src)
+}
+func (m *SetShardReadonlyArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetShardReadonlyArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetShardReadonlyArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetShardReadonlyArgs proto.InternalMessageInfo
+
+func (m *SetShardReadonlyArgs) GetDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
+	if m != nil {
+		return m.DiskID
+	}
+	return 0
+}
+
+func (m *SetShardReadonlyArgs) GetSuid() github_com_cubefs_cubefs_blobstore_common_proto.Suid {
+	if m != nil {
+		return m.Suid
+	}
+	return 0
+}
+
+func (m *SetShardReadonlyArgs) GetReadonly() bool {
+	if m != nil {
+		return m.Readonly
+	}
+	return false
+}
+
 type GetShardArgs struct {
 	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
 	Suid                 github_com_cubefs_cubefs_blobstore_common_proto.Suid   `protobuf:"varint,2,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
@@ -1078,7 +1310,7 @@ func (m *GetShardArgs) Reset()         { *m = GetShardArgs{} }
 func (m *GetShardArgs) String() string { return proto.CompactTextString(m) }
 func (*GetShardArgs) ProtoMessage()    {}
 func (*GetShardArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{19}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{20}
 }
 func (m *GetShardArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1132,7 +1364,7 @@ func (m *GetShardRet) Reset()         { *m = GetShardRet{} }
 func (m *GetShardRet) String() string { return proto.CompactTextString(m) }
 func (*GetShardRet) ProtoMessage()    {}
 func (*GetShardRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{20}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{21}
 }
 func (m *GetShardRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1169,21 +1401,25 @@ func (m *GetShardRet) GetShard() clustermgr.ShardUnitInfo {
 }
 
 type CreateBlobArgs struct {
-	Header               ShardOpHeader                                               `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Name                 []byte                                                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CodeMode             github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,3,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"codemode,omitempty"`
-	Size_                uint64                                                      `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	SliceSize            uint32                                                      `protobuf:"varint,5,opt,name=slice_size,json=sliceSize,proto3" json:"slice_size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                                    `json:"-"`
-	XXX_unrecognized     []byte                                                      `json:"-"`
-	XXX_sizecache        int32                                                       `json:"-"`
+	Header    ShardOpHeader                                               `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Name      []byte                                                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CodeMode  github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,3,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"codemode,omitempty"`
+	Size_     uint64                                                      `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	SliceSize uint32                                                      `protobuf:"varint,5,opt,name=slice_size,json=sliceSize,proto3" json:"slice_size,omitempty"`
+	// codec of payload, size is the size of encoded payload
+	Codec github_com_cubefs_cubefs_blobstore_common_proto.Codec `protobuf:"varint,6,opt,name=codec,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Codec" json:"codec,omitempty"`
+	// size of the raw payload before encoded by codec
+	RawSize              uint64   `protobuf:"varint,7,opt,name=raw_size,json=rawSize,proto3" json:"raw_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CreateBlobArgs) Reset()         { *m = CreateBlobArgs{} }
 func (m *CreateBlobArgs) String() string { return proto.CompactTextString(m) }
 func (*CreateBlobArgs) ProtoMessage()    {}
 func (*CreateBlobArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{21}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{22}
 }
 func (m *CreateBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1247,6 +1483,20 @@ func (m *CreateBlobArgs) GetSliceSize() uint32 {
 	return 0
 }
 
+func (m *CreateBlobArgs) GetCodec() github_com_cubefs_cubefs_blobstore_common_proto.Codec {
+	if m != nil {
+		return m.Codec
+	}
+	return 0
+}
+
+func (m *CreateBlobArgs) GetRawSize() uint64 {
+	if m != nil {
+		return m.RawSize
+	}
+	return 0
+}
+
 type CreateBlobRet struct {
 	Blob                 proto1.Blob `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -1258,7 +1508,7 @@ func (m *CreateBlobRet) Reset()         { *m = CreateBlobRet{} }
 func (m *CreateBlobRet) String() string { return proto.CompactTextString(m) }
 func (*CreateBlobRet) ProtoMessage()    {}
 func (*CreateBlobRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{22}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{23}
 }
 func (m *CreateBlobRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1306,7 +1556,7 @@ func (m *GetBlobArgs) Reset()         { *m = GetBlobArgs{} }
 func (m *GetBlobArgs) String() string { return proto.CompactTextString(m) }
 func (*GetBlobArgs) ProtoMessage()    {}
 func (*GetBlobArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{23}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{24}
 }
 func (m *GetBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1360,7 +1610,7 @@ func (m *GetBlobRet) Reset()         { *m = GetBlobRet{} }
 func (m *GetBlobRet) String() string { return proto.CompactTextString(m) }
 func (*GetBlobRet) ProtoMessage()    {}
 func (*GetBlobRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{24}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{25}
 }
 func (m *GetBlobRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1397,20 +1647,22 @@ func (m *GetBlobRet) GetBlob() proto1.Blob {
 }
 
 type ListBlobArgs struct {
-	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Prefix               []byte        `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	Marker               []byte        `protobuf:"bytes,3,opt,name=marker,proto3" json:"marker,omitempty"`
-	Count                uint64        `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Header ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Prefix []byte        `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Marker []byte        `protobuf:"bytes,3,opt,name=marker,proto3" json:"marker,omitempty"`
+	Count  uint64        `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// lists only the blobs which have slices located in the volume if set
+	Vid                  github_com_cubefs_cubefs_blobstore_common_proto.Vid `protobuf:"varint,5,opt,name=vid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Vid" json:"vid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                            `json:"-"`
+	XXX_unrecognized     []byte                                              `json:"-"`
+	XXX_sizecache        int32                                               `json:"-"`
 }
 
 func (m *ListBlobArgs) Reset()         { *m = ListBlobArgs{} }
 func (m *ListBlobArgs) String() string { return proto.CompactTextString(m) }
 func (*ListBlobArgs) ProtoMessage()    {}
 func (*ListBlobArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{25}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{26}
 }
 func (m *ListBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1467,6 +1719,13 @@ func (m *ListBlobArgs) GetCount() uint64 {
 	return 0
 }
 
+func (m *ListBlobArgs) GetVid() github_com_cubefs_cubefs_blobstore_common_proto.Vid {
+	if m != nil {
+		return m.Vid
+	}
+	return 0
+}
+
 type ListBlobRet struct {
 	Blobs                []proto1.Blob `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs"`
 	NextMarker           []byte        `protobuf:"bytes,2,opt,name=nextMarker,proto3" json:"nextMarker,omitempty"`
@@ -1479,7 +1738,7 @@ func (m *ListBlobRet) Reset()         { *m = ListBlobRet{} }
 func (m *ListBlobRet) String() string { return proto.CompactTextString(m) }
 func (*ListBlobRet) ProtoMessage()    {}
 func (*ListBlobRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{26}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{27}
 }
 func (m *ListBlobRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1534,7 +1793,7 @@ func (m *DeleteBlobArgs) Reset()         { *m = DeleteBlobArgs{} }
 func (m *DeleteBlobArgs) String() string { return proto.CompactTextString(m) }
 func (*DeleteBlobArgs) ProtoMessage()    {}
 func (*DeleteBlobArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{27}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{28}
 }
 func (m *DeleteBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1587,7 +1846,7 @@ func (m *DeleteBlobRet) Reset()         { *m = DeleteBlobRet{} }
 func (m *DeleteBlobRet) String() string { return proto.CompactTextString(m) }
 func (*DeleteBlobRet) ProtoMessage()    {}
 func (*DeleteBlobRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{28}
+	return fileDescriptor_9d3815ca0e5f30f0, []int{29}
 }
 func (m *DeleteBlobRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1616,27 +1875,99 @@ func (m *DeleteBlobRet) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteBlobRet proto.InternalMessageInfo
 
-type RetainBlobArgs struct {
+type TrashBlob struct {
+	Blob       proto1.Blob `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob"`
+	ExpireTime int64       `protobuf:"varint,2,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	ShardKeys  [][]byte    `protobuf:"bytes,3,rep,name=shard_keys,json=shardKeys,proto3" json:"shard_keys,omitempty"`
+	// version distinguishes trashes of blobs with the same name
+	Version              uint64   `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrashBlob) Reset()         { *m = TrashBlob{} }
+func (m *TrashBlob) String() string { return proto.CompactTextString(m) }
+func (*TrashBlob) ProtoMessage()    {}
+func (*TrashBlob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{30}
+}
+func (m *TrashBlob) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TrashBlob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TrashBlob.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TrashBlob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrashBlob.Merge(m, src)
+}
+func (m *TrashBlob) XXX_Size() int {
+	return m.Size()
+}
+func (m *TrashBlob) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrashBlob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrashBlob proto.InternalMessageInfo
+
+func (m *TrashBlob) GetBlob() proto1.Blob {
+	if m != nil {
+		return m.Blob
+	}
+	return proto1.Blob{}
+}
+
+func (m *TrashBlob) GetExpireTime() int64 {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return 0
+}
+
+func (m *TrashBlob) GetShardKeys() [][]byte {
+	if m != nil {
+		return m.ShardKeys
+	}
+	return nil
+}
+
+func (m *TrashBlob) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type TrashBlobArgs struct {
 	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
 	Name                 []byte        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Lease                uint64        `protobuf:"varint,3,opt,name=lease,proto3" json:"lease,omitempty"`
+	ExpireTime           int64         `protobuf:"varint,3,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *RetainBlobArgs) Reset()         { *m = RetainBlobArgs{} }
-func (m *RetainBlobArgs) String() string { return proto.CompactTextString(m) }
-func (*RetainBlobArgs) ProtoMessage()    {}
-func (*RetainBlobArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{29}
+func (m *TrashBlobArgs) Reset()         { *m = TrashBlobArgs{} }
+func (m *TrashBlobArgs) String() string { return proto.CompactTextString(m) }
+func (*TrashBlobArgs) ProtoMessage()    {}
+func (*TrashBlobArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{31}
 }
-func (m *RetainBlobArgs) XXX_Unmarshal(b []byte) error {
+func (m *TrashBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RetainBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TrashBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RetainBlobArgs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TrashBlobArgs.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1646,57 +1977,57 @@ func (m *RetainBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *RetainBlobArgs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RetainBlobArgs.Merge(m, src)
+func (m *TrashBlobArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrashBlobArgs.Merge(m, src)
 }
-func (m *RetainBlobArgs) XXX_Size() int {
+func (m *TrashBlobArgs) XXX_Size() int {
 	return m.Size()
 }
-func (m *RetainBlobArgs) XXX_DiscardUnknown() {
-	xxx_messageInfo_RetainBlobArgs.DiscardUnknown(m)
+func (m *TrashBlobArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrashBlobArgs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RetainBlobArgs proto.InternalMessageInfo
+var xxx_messageInfo_TrashBlobArgs proto.InternalMessageInfo
 
-func (m *RetainBlobArgs) GetHeader() ShardOpHeader {
+func (m *TrashBlobArgs) GetHeader() ShardOpHeader {
 	if m != nil {
 		return m.Header
 	}
 	return ShardOpHeader{}
 }
 
-func (m *RetainBlobArgs) GetName() []byte {
+func (m *TrashBlobArgs) GetName() []byte {
 	if m != nil {
 		return m.Name
 	}
 	return nil
 }
 
-func (m *RetainBlobArgs) GetLease() uint64 {
+func (m *TrashBlobArgs) GetExpireTime() int64 {
 	if m != nil {
-		return m.Lease
+		return m.ExpireTime
 	}
 	return 0
 }
 
-type RetainBlobRet struct {
+type TrashBlobRet struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RetainBlobRet) Reset()         { *m = RetainBlobRet{} }
-func (m *RetainBlobRet) String() string { return proto.CompactTextString(m) }
-func (*RetainBlobRet) ProtoMessage()    {}
-func (*RetainBlobRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{30}
+func (m *TrashBlobRet) Reset()         { *m = TrashBlobRet{} }
+func (m *TrashBlobRet) String() string { return proto.CompactTextString(m) }
+func (*TrashBlobRet) ProtoMessage()    {}
+func (*TrashBlobRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{32}
 }
-func (m *RetainBlobRet) XXX_Unmarshal(b []byte) error {
+func (m *TrashBlobRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RetainBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TrashBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RetainBlobRet.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TrashBlobRet.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1706,40 +2037,38 @@ func (m *RetainBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *RetainBlobRet) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RetainBlobRet.Merge(m, src)
+func (m *TrashBlobRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrashBlobRet.Merge(m, src)
 }
-func (m *RetainBlobRet) XXX_Size() int {
+func (m *TrashBlobRet) XXX_Size() int {
 	return m.Size()
 }
-func (m *RetainBlobRet) XXX_DiscardUnknown() {
-	xxx_messageInfo_RetainBlobRet.DiscardUnknown(m)
+func (m *TrashBlobRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrashBlobRet.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RetainBlobRet proto.InternalMessageInfo
+var xxx_messageInfo_TrashBlobRet proto.InternalMessageInfo
 
-type SealBlobArgs struct {
-	Header               ShardOpHeader  `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Size_                uint64         `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	Name                 []byte         `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Slices               []proto1.Slice `protobuf:"bytes,4,rep,name=slices,proto3" json:"slices"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type UndeleteBlobArgs struct {
+	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Name                 []byte        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *SealBlobArgs) Reset()         { *m = SealBlobArgs{} }
-func (m *SealBlobArgs) String() string { return proto.CompactTextString(m) }
-func (*SealBlobArgs) ProtoMessage()    {}
-func (*SealBlobArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{31}
+func (m *UndeleteBlobArgs) Reset()         { *m = UndeleteBlobArgs{} }
+func (m *UndeleteBlobArgs) String() string { return proto.CompactTextString(m) }
+func (*UndeleteBlobArgs) ProtoMessage()    {}
+func (*UndeleteBlobArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{33}
 }
-func (m *SealBlobArgs) XXX_Unmarshal(b []byte) error {
+func (m *UndeleteBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SealBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UndeleteBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SealBlobArgs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UndeleteBlobArgs.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1749,64 +2078,51 @@ func (m *SealBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *SealBlobArgs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SealBlobArgs.Merge(m, src)
+func (m *UndeleteBlobArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteBlobArgs.Merge(m, src)
 }
-func (m *SealBlobArgs) XXX_Size() int {
+func (m *UndeleteBlobArgs) XXX_Size() int {
 	return m.Size()
 }
-func (m *SealBlobArgs) XXX_DiscardUnknown() {
-	xxx_messageInfo_SealBlobArgs.DiscardUnknown(m)
+func (m *UndeleteBlobArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteBlobArgs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SealBlobArgs proto.InternalMessageInfo
+var xxx_messageInfo_UndeleteBlobArgs proto.InternalMessageInfo
 
-func (m *SealBlobArgs) GetHeader() ShardOpHeader {
+func (m *UndeleteBlobArgs) GetHeader() ShardOpHeader {
 	if m != nil {
 		return m.Header
 	}
 	return ShardOpHeader{}
 }
 
-func (m *SealBlobArgs) GetSize_() uint64 {
-	if m != nil {
-		return m.Size_
-	}
-	return 0
-}
-
-func (m *SealBlobArgs) GetName() []byte {
+func (m *UndeleteBlobArgs) GetName() []byte {
 	if m != nil {
 		return m.Name
 	}
 	return nil
 }
 
-func (m *SealBlobArgs) GetSlices() []proto1.Slice {
-	if m != nil {
-		return m.Slices
-	}
-	return nil
-}
-
-type SealBlobRet struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type UndeleteBlobRet struct {
+	Blob                 proto1.Blob `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *SealBlobRet) Reset()         { *m = SealBlobRet{} }
-func (m *SealBlobRet) String() string { return proto.CompactTextString(m) }
-func (*SealBlobRet) ProtoMessage()    {}
-func (*SealBlobRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{32}
+func (m *UndeleteBlobRet) Reset()         { *m = UndeleteBlobRet{} }
+func (m *UndeleteBlobRet) String() string { return proto.CompactTextString(m) }
+func (*UndeleteBlobRet) ProtoMessage()    {}
+func (*UndeleteBlobRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{34}
 }
-func (m *SealBlobRet) XXX_Unmarshal(b []byte) error {
+func (m *UndeleteBlobRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SealBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UndeleteBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SealBlobRet.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UndeleteBlobRet.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1816,41 +2132,46 @@ func (m *SealBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *SealBlobRet) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SealBlobRet.Merge(m, src)
+func (m *UndeleteBlobRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteBlobRet.Merge(m, src)
 }
-func (m *SealBlobRet) XXX_Size() int {
+func (m *UndeleteBlobRet) XXX_Size() int {
 	return m.Size()
 }
-func (m *SealBlobRet) XXX_DiscardUnknown() {
-	xxx_messageInfo_SealBlobRet.DiscardUnknown(m)
+func (m *UndeleteBlobRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteBlobRet.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SealBlobRet proto.InternalMessageInfo
+var xxx_messageInfo_UndeleteBlobRet proto.InternalMessageInfo
 
-type AllocSliceArgs struct {
-	Header               ShardOpHeader                                               `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Name                 []byte                                                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CodeMode             github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,3,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"codemode,omitempty"`
-	Size_                uint64                                                      `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	FailedSlice          proto1.Slice                                                `protobuf:"bytes,5,opt,name=failed_slice,json=failedSlice,proto3" json:"failed_slice"`
-	XXX_NoUnkeyedLiteral struct{}                                                    `json:"-"`
-	XXX_unrecognized     []byte                                                      `json:"-"`
-	XXX_sizecache        int32                                                       `json:"-"`
+func (m *UndeleteBlobRet) GetBlob() proto1.Blob {
+	if m != nil {
+		return m.Blob
+	}
+	return proto1.Blob{}
 }
 
-func (m *AllocSliceArgs) Reset()         { *m = AllocSliceArgs{} }
-func (m *AllocSliceArgs) String() string { return proto.CompactTextString(m) }
-func (*AllocSliceArgs) ProtoMessage()    {}
-func (*AllocSliceArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{33}
+type ListTrashBlobArgs struct {
+	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Marker               []byte        `protobuf:"bytes,2,opt,name=marker,proto3" json:"marker,omitempty"`
+	Count                uint64        `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (m *AllocSliceArgs) XXX_Unmarshal(b []byte) error {
+
+func (m *ListTrashBlobArgs) Reset()         { *m = ListTrashBlobArgs{} }
+func (m *ListTrashBlobArgs) String() string { return proto.CompactTextString(m) }
+func (*ListTrashBlobArgs) ProtoMessage()    {}
+func (*ListTrashBlobArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{35}
+}
+func (m *ListTrashBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AllocSliceArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListTrashBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AllocSliceArgs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListTrashBlobArgs.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1860,72 +2181,59 @@ func (m *AllocSliceArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *AllocSliceArgs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AllocSliceArgs.Merge(m, src)
+func (m *ListTrashBlobArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTrashBlobArgs.Merge(m, src)
 }
-func (m *AllocSliceArgs) XXX_Size() int {
+func (m *ListTrashBlobArgs) XXX_Size() int {
 	return m.Size()
 }
-func (m *AllocSliceArgs) XXX_DiscardUnknown() {
-	xxx_messageInfo_AllocSliceArgs.DiscardUnknown(m)
+func (m *ListTrashBlobArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTrashBlobArgs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AllocSliceArgs proto.InternalMessageInfo
+var xxx_messageInfo_ListTrashBlobArgs proto.InternalMessageInfo
 
-func (m *AllocSliceArgs) GetHeader() ShardOpHeader {
+func (m *ListTrashBlobArgs) GetHeader() ShardOpHeader {
 	if m != nil {
 		return m.Header
 	}
 	return ShardOpHeader{}
 }
 
-func (m *AllocSliceArgs) GetName() []byte {
+func (m *ListTrashBlobArgs) GetMarker() []byte {
 	if m != nil {
-		return m.Name
+		return m.Marker
 	}
 	return nil
 }
 
-func (m *AllocSliceArgs) GetCodeMode() github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode {
-	if m != nil {
-		return m.CodeMode
-	}
-	return 0
-}
-
-func (m *AllocSliceArgs) GetSize_() uint64 {
+func (m *ListTrashBlobArgs) GetCount() uint64 {
 	if m != nil {
-		return m.Size_
+		return m.Count
 	}
 	return 0
 }
 
-func (m *AllocSliceArgs) GetFailedSlice() proto1.Slice {
-	if m != nil {
-		return m.FailedSlice
-	}
-	return proto1.Slice{}
-}
-
-type AllocSliceRet struct {
-	Slices               []proto1.Slice `protobuf:"bytes,1,rep,name=slices,proto3" json:"slices"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type ListTrashBlobRet struct {
+	Blobs                []TrashBlob `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs"`
+	NextMarker           []byte      `protobuf:"bytes,2,opt,name=nextMarker,proto3" json:"nextMarker,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *AllocSliceRet) Reset()         { *m = AllocSliceRet{} }
-func (m *AllocSliceRet) String() string { return proto.CompactTextString(m) }
-func (*AllocSliceRet) ProtoMessage()    {}
-func (*AllocSliceRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{34}
+func (m *ListTrashBlobRet) Reset()         { *m = ListTrashBlobRet{} }
+func (m *ListTrashBlobRet) String() string { return proto.CompactTextString(m) }
+func (*ListTrashBlobRet) ProtoMessage()    {}
+func (*ListTrashBlobRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{36}
 }
-func (m *AllocSliceRet) XXX_Unmarshal(b []byte) error {
+func (m *ListTrashBlobRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AllocSliceRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListTrashBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AllocSliceRet.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListTrashBlobRet.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1935,53 +2243,53 @@ func (m *AllocSliceRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *AllocSliceRet) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AllocSliceRet.Merge(m, src)
+func (m *ListTrashBlobRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTrashBlobRet.Merge(m, src)
 }
-func (m *AllocSliceRet) XXX_Size() int {
+func (m *ListTrashBlobRet) XXX_Size() int {
 	return m.Size()
 }
-func (m *AllocSliceRet) XXX_DiscardUnknown() {
-	xxx_messageInfo_AllocSliceRet.DiscardUnknown(m)
+func (m *ListTrashBlobRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTrashBlobRet.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AllocSliceRet proto.InternalMessageInfo
+var xxx_messageInfo_ListTrashBlobRet proto.InternalMessageInfo
 
-func (m *AllocSliceRet) GetSlices() []proto1.Slice {
+func (m *ListTrashBlobRet) GetBlobs() []TrashBlob {
 	if m != nil {
-		return m.Slices
+		return m.Blobs
 	}
 	return nil
 }
 
-type ShardStats struct {
-	Suid                 github_com_cubefs_cubefs_blobstore_common_proto.Suid         `protobuf:"varint,1,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
-	AppliedIndex         uint64                                                       `protobuf:"varint,2,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`
-	LeaderHost           string                                                       `protobuf:"bytes,3,opt,name=leader_host,json=leaderHost,proto3" json:"leader_host,omitempty"`
-	LeaderDiskID         github_com_cubefs_cubefs_blobstore_common_proto.DiskID       `protobuf:"varint,4,opt,name=leader_disk_id,json=leaderDiskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"leader_disk_id,omitempty"`
-	LeaderSuid           github_com_cubefs_cubefs_blobstore_common_proto.Suid         `protobuf:"varint,5,opt,name=leader_suid,json=leaderSuid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"leader_suid,omitempty"`
-	Learner              bool                                                         `protobuf:"varint,6,opt,name=learner,proto3" json:"learner,omitempty"`
-	RouteVersion         github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion `protobuf:"varint,7,opt,name=route_version,json=routeVersion,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.RouteVersion" json:"route_version,omitempty"`
-	Range                sharding.Range                                               `protobuf:"bytes,8,opt,name=range,proto3" json:"range"`
-	Units                []clustermgr.ShardUnit                                       `protobuf:"bytes,9,rep,name=units,proto3" json:"units"`
-	RaftStat             raft.Stat                                                    `protobuf:"bytes,10,opt,name=raftStat,proto3" json:"raftStat"`
-	XXX_NoUnkeyedLiteral struct{}                                                     `json:"-"`
-	XXX_unrecognized     []byte                                                       `json:"-"`
-	XXX_sizecache        int32                                                        `json:"-"`
+func (m *ListTrashBlobRet) GetNextMarker() []byte {
+	if m != nil {
+		return m.NextMarker
+	}
+	return nil
 }
 
-func (m *ShardStats) Reset()         { *m = ShardStats{} }
-func (m *ShardStats) String() string { return proto.CompactTextString(m) }
-func (*ShardStats) ProtoMessage()    {}
-func (*ShardStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{35}
+type PurgeTrashBlobArgs struct {
+	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Name                 []byte        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version              uint64        `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (m *ShardStats) XXX_Unmarshal(b []byte) error {
+
+func (m *PurgeTrashBlobArgs) Reset()         { *m = PurgeTrashBlobArgs{} }
+func (m *PurgeTrashBlobArgs) String() string { return proto.CompactTextString(m) }
+func (*PurgeTrashBlobArgs) ProtoMessage()    {}
+func (*PurgeTrashBlobArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{37}
+}
+func (m *PurgeTrashBlobArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ShardStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PurgeTrashBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ShardStats.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PurgeTrashBlobArgs.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1991,107 +2299,165 @@ func (m *ShardStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *ShardStats) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ShardStats.Merge(m, src)
+func (m *PurgeTrashBlobArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeTrashBlobArgs.Merge(m, src)
 }
-func (m *ShardStats) XXX_Size() int {
+func (m *PurgeTrashBlobArgs) XXX_Size() int {
 	return m.Size()
 }
-func (m *ShardStats) XXX_DiscardUnknown() {
-	xxx_messageInfo_ShardStats.DiscardUnknown(m)
+func (m *PurgeTrashBlobArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeTrashBlobArgs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ShardStats proto.InternalMessageInfo
+var xxx_messageInfo_PurgeTrashBlobArgs proto.InternalMessageInfo
 
-func (m *ShardStats) GetSuid() github_com_cubefs_cubefs_blobstore_common_proto.Suid {
+func (m *PurgeTrashBlobArgs) GetHeader() ShardOpHeader {
 	if m != nil {
-		return m.Suid
+		return m.Header
 	}
-	return 0
+	return ShardOpHeader{}
 }
 
-func (m *ShardStats) GetAppliedIndex() uint64 {
+func (m *PurgeTrashBlobArgs) GetName() []byte {
 	if m != nil {
-		return m.AppliedIndex
+		return m.Name
 	}
-	return 0
+	return nil
 }
 
-func (m *ShardStats) GetLeaderHost() string {
+func (m *PurgeTrashBlobArgs) GetVersion() uint64 {
 	if m != nil {
-		return m.LeaderHost
+		return m.Version
 	}
-	return ""
+	return 0
 }
 
-func (m *ShardStats) GetLeaderDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
-	if m != nil {
-		return m.LeaderDiskID
-	}
-	return 0
+type PurgeTrashBlobRet struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ShardStats) GetLeaderSuid() github_com_cubefs_cubefs_blobstore_common_proto.Suid {
-	if m != nil {
-		return m.LeaderSuid
+func (m *PurgeTrashBlobRet) Reset()         { *m = PurgeTrashBlobRet{} }
+func (m *PurgeTrashBlobRet) String() string { return proto.CompactTextString(m) }
+func (*PurgeTrashBlobRet) ProtoMessage()    {}
+func (*PurgeTrashBlobRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{38}
+}
+func (m *PurgeTrashBlobRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PurgeTrashBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PurgeTrashBlobRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (m *PurgeTrashBlobRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeTrashBlobRet.Merge(m, src)
+}
+func (m *PurgeTrashBlobRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *PurgeTrashBlobRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeTrashBlobRet.DiscardUnknown(m)
 }
 
-func (m *ShardStats) GetLearner() bool {
-	if m != nil {
-		return m.Learner
-	}
-	return false
+var xxx_messageInfo_PurgeTrashBlobRet proto.InternalMessageInfo
+
+type DedupRef struct {
+	Hash                 []byte          `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Location             proto1.Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location"`
+	RefCount             uint64          `protobuf:"varint,3,opt,name=ref_count,json=refCount,proto3" json:"ref_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *ShardStats) GetRouteVersion() github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion {
-	if m != nil {
-		return m.RouteVersion
+func (m *DedupRef) Reset()         { *m = DedupRef{} }
+func (m *DedupRef) String() string { return proto.CompactTextString(m) }
+func (*DedupRef) ProtoMessage()    {}
+func (*DedupRef) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{39}
+}
+func (m *DedupRef) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DedupRef) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DedupRef.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (m *DedupRef) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DedupRef.Merge(m, src)
+}
+func (m *DedupRef) XXX_Size() int {
+	return m.Size()
+}
+func (m *DedupRef) XXX_DiscardUnknown() {
+	xxx_messageInfo_DedupRef.DiscardUnknown(m)
 }
 
-func (m *ShardStats) GetRange() sharding.Range {
+var xxx_messageInfo_DedupRef proto.InternalMessageInfo
+
+func (m *DedupRef) GetHash() []byte {
 	if m != nil {
-		return m.Range
+		return m.Hash
 	}
-	return sharding.Range{}
+	return nil
 }
 
-func (m *ShardStats) GetUnits() []clustermgr.ShardUnit {
+func (m *DedupRef) GetLocation() proto1.Location {
 	if m != nil {
-		return m.Units
+		return m.Location
 	}
-	return nil
+	return proto1.Location{}
 }
 
-func (m *ShardStats) GetRaftStat() raft.Stat {
+func (m *DedupRef) GetRefCount() uint64 {
 	if m != nil {
-		return m.RaftStat
+		return m.RefCount
 	}
-	return raft.Stat{}
+	return 0
 }
 
-type ListVolumeArgs struct {
-	CodeMode             github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,1,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"codemode,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                                    `json:"-"`
-	XXX_unrecognized     []byte                                                      `json:"-"`
-	XXX_sizecache        int32                                                       `json:"-"`
+type RefDedupArgs struct {
+	Header   ShardOpHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Key      []byte          `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Hash     []byte          `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Location proto1.Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location"`
+	// ref is the reference id, ref of the same reference takes effect only once
+	Ref                  []byte   `protobuf:"bytes,5,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListVolumeArgs) Reset()         { *m = ListVolumeArgs{} }
-func (m *ListVolumeArgs) String() string { return proto.CompactTextString(m) }
-func (*ListVolumeArgs) ProtoMessage()    {}
-func (*ListVolumeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{36}
+func (m *RefDedupArgs) Reset()         { *m = RefDedupArgs{} }
+func (m *RefDedupArgs) String() string { return proto.CompactTextString(m) }
+func (*RefDedupArgs) ProtoMessage()    {}
+func (*RefDedupArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{40}
 }
-func (m *ListVolumeArgs) XXX_Unmarshal(b []byte) error {
+func (m *RefDedupArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListVolumeArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RefDedupArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListVolumeArgs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RefDedupArgs.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2101,44 +2467,72 @@ func (m *ListVolumeArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *ListVolumeArgs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListVolumeArgs.Merge(m, src)
+func (m *RefDedupArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefDedupArgs.Merge(m, src)
 }
-func (m *ListVolumeArgs) XXX_Size() int {
+func (m *RefDedupArgs) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListVolumeArgs) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListVolumeArgs.DiscardUnknown(m)
+func (m *RefDedupArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefDedupArgs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListVolumeArgs proto.InternalMessageInfo
+var xxx_messageInfo_RefDedupArgs proto.InternalMessageInfo
 
-func (m *ListVolumeArgs) GetCodeMode() github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode {
+func (m *RefDedupArgs) GetHeader() ShardOpHeader {
 	if m != nil {
-		return m.CodeMode
+		return m.Header
 	}
-	return 0
+	return ShardOpHeader{}
 }
 
-type ListVolumeRet struct {
-	Vids                 []github_com_cubefs_cubefs_blobstore_common_proto.Vid `protobuf:"varint,1,rep,packed,name=vids,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Vid" json:"vids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                              `json:"-"`
-	XXX_unrecognized     []byte                                                `json:"-"`
-	XXX_sizecache        int32                                                 `json:"-"`
+func (m *RefDedupArgs) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
 }
 
-func (m *ListVolumeRet) Reset()         { *m = ListVolumeRet{} }
-func (m *ListVolumeRet) String() string { return proto.CompactTextString(m) }
-func (*ListVolumeRet) ProtoMessage()    {}
-func (*ListVolumeRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{37}
+func (m *RefDedupArgs) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
 }
-func (m *ListVolumeRet) XXX_Unmarshal(b []byte) error {
+
+func (m *RefDedupArgs) GetLocation() proto1.Location {
+	if m != nil {
+		return m.Location
+	}
+	return proto1.Location{}
+}
+
+func (m *RefDedupArgs) GetRef() []byte {
+	if m != nil {
+		return m.Ref
+	}
+	return nil
+}
+
+type RefDedupRet struct {
+	Ref                  DedupRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefDedupRet) Reset()         { *m = RefDedupRet{} }
+func (m *RefDedupRet) String() string { return proto.CompactTextString(m) }
+func (*RefDedupRet) ProtoMessage()    {}
+func (*RefDedupRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{41}
+}
+func (m *RefDedupRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListVolumeRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RefDedupRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListVolumeRet.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RefDedupRet.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2148,46 +2542,45 @@ func (m *ListVolumeRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *ListVolumeRet) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListVolumeRet.Merge(m, src)
+func (m *RefDedupRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefDedupRet.Merge(m, src)
 }
-func (m *ListVolumeRet) XXX_Size() int {
+func (m *RefDedupRet) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListVolumeRet) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListVolumeRet.DiscardUnknown(m)
+func (m *RefDedupRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefDedupRet.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListVolumeRet proto.InternalMessageInfo
+var xxx_messageInfo_RefDedupRet proto.InternalMessageInfo
 
-func (m *ListVolumeRet) GetVids() []github_com_cubefs_cubefs_blobstore_common_proto.Vid {
+func (m *RefDedupRet) GetRef() DedupRef {
 	if m != nil {
-		return m.Vids
+		return m.Ref
 	}
-	return nil
+	return DedupRef{}
 }
 
-type ListShardArgs struct {
-	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID  `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
-	ShardID              github_com_cubefs_cubefs_blobstore_common_proto.ShardID `protobuf:"varint,2,opt,name=shard_id,json=shardId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.ShardID" json:"shard_id,omitempty"`
-	Count                uint64                                                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                                `json:"-"`
-	XXX_unrecognized     []byte                                                  `json:"-"`
-	XXX_sizecache        int32                                                   `json:"-"`
+type GetDedupArgs struct {
+	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Key                  []byte        `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *ListShardArgs) Reset()         { *m = ListShardArgs{} }
-func (m *ListShardArgs) String() string { return proto.CompactTextString(m) }
-func (*ListShardArgs) ProtoMessage()    {}
-func (*ListShardArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{38}
+func (m *GetDedupArgs) Reset()         { *m = GetDedupArgs{} }
+func (m *GetDedupArgs) String() string { return proto.CompactTextString(m) }
+func (*GetDedupArgs) ProtoMessage()    {}
+func (*GetDedupArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{42}
 }
-func (m *ListShardArgs) XXX_Unmarshal(b []byte) error {
+func (m *GetDedupArgs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListShardArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetDedupArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListShardArgs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetDedupArgs.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2197,63 +2590,53 @@ func (m *ListShardArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *ListShardArgs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListShardArgs.Merge(m, src)
+func (m *GetDedupArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDedupArgs.Merge(m, src)
 }
-func (m *ListShardArgs) XXX_Size() int {
+func (m *GetDedupArgs) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListShardArgs) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListShardArgs.DiscardUnknown(m)
+func (m *GetDedupArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDedupArgs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListShardArgs proto.InternalMessageInfo
-
-func (m *ListShardArgs) GetDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
-	if m != nil {
-		return m.DiskID
-	}
-	return 0
-}
+var xxx_messageInfo_GetDedupArgs proto.InternalMessageInfo
 
-func (m *ListShardArgs) GetShardID() github_com_cubefs_cubefs_blobstore_common_proto.ShardID {
+func (m *GetDedupArgs) GetHeader() ShardOpHeader {
 	if m != nil {
-		return m.ShardID
+		return m.Header
 	}
-	return 0
+	return ShardOpHeader{}
 }
 
-func (m *ListShardArgs) GetCount() uint64 {
+func (m *GetDedupArgs) GetKey() []byte {
 	if m != nil {
-		return m.Count
+		return m.Key
 	}
-	return 0
+	return nil
 }
 
-type ListShardBaseInfo struct {
-	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID  `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
-	ShardID              github_com_cubefs_cubefs_blobstore_common_proto.ShardID `protobuf:"varint,2,opt,name=shard_id,json=shardId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.ShardID" json:"shard_id,omitempty"`
-	Suid                 github_com_cubefs_cubefs_blobstore_common_proto.Suid    `protobuf:"varint,3,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
-	Index                uint32                                                  `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
-	Epoch                uint32                                                  `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	Units                []clustermgr.ShardUnit                                  `protobuf:"bytes,6,rep,name=units,proto3" json:"units"`
-	XXX_NoUnkeyedLiteral struct{}                                                `json:"-"`
-	XXX_unrecognized     []byte                                                  `json:"-"`
-	XXX_sizecache        int32                                                   `json:"-"`
+type GetDedupRet struct {
+	Ref DedupRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref"`
+	// first_ref is one of the references of the dedup key
+	FirstRef             []byte   `protobuf:"bytes,2,opt,name=first_ref,json=firstRef,proto3" json:"first_ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListShardBaseInfo) Reset()         { *m = ListShardBaseInfo{} }
-func (m *ListShardBaseInfo) String() string { return proto.CompactTextString(m) }
-func (*ListShardBaseInfo) ProtoMessage()    {}
-func (*ListShardBaseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{39}
+func (m *GetDedupRet) Reset()         { *m = GetDedupRet{} }
+func (m *GetDedupRet) String() string { return proto.CompactTextString(m) }
+func (*GetDedupRet) ProtoMessage()    {}
+func (*GetDedupRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{43}
 }
-func (m *ListShardBaseInfo) XXX_Unmarshal(b []byte) error {
+func (m *GetDedupRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListShardBaseInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetDedupRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListShardBaseInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetDedupRet.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2263,79 +2646,129 @@ func (m *ListShardBaseInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *ListShardBaseInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListShardBaseInfo.Merge(m, src)
+func (m *GetDedupRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDedupRet.Merge(m, src)
 }
-func (m *ListShardBaseInfo) XXX_Size() int {
+func (m *GetDedupRet) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListShardBaseInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListShardBaseInfo.DiscardUnknown(m)
+func (m *GetDedupRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDedupRet.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListShardBaseInfo proto.InternalMessageInfo
+var xxx_messageInfo_GetDedupRet proto.InternalMessageInfo
 
-func (m *ListShardBaseInfo) GetDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
+func (m *GetDedupRet) GetRef() DedupRef {
 	if m != nil {
-		return m.DiskID
+		return m.Ref
 	}
-	return 0
+	return DedupRef{}
 }
 
-func (m *ListShardBaseInfo) GetShardID() github_com_cubefs_cubefs_blobstore_common_proto.ShardID {
+func (m *GetDedupRet) GetFirstRef() []byte {
 	if m != nil {
-		return m.ShardID
+		return m.FirstRef
 	}
-	return 0
+	return nil
 }
 
-func (m *ListShardBaseInfo) GetSuid() github_com_cubefs_cubefs_blobstore_common_proto.Suid {
-	if m != nil {
-		return m.Suid
+type UnrefDedupArgs struct {
+	Header ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Key    []byte        `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Ref    []byte        `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	// the dedup key is unreferred only if it refers to the location,
+	// key not found returns if the dedup key has been recreated with another location
+	Location             proto1.Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UnrefDedupArgs) Reset()         { *m = UnrefDedupArgs{} }
+func (m *UnrefDedupArgs) String() string { return proto.CompactTextString(m) }
+func (*UnrefDedupArgs) ProtoMessage()    {}
+func (*UnrefDedupArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{44}
+}
+func (m *UnrefDedupArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnrefDedupArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnrefDedupArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (m *UnrefDedupArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnrefDedupArgs.Merge(m, src)
+}
+func (m *UnrefDedupArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnrefDedupArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnrefDedupArgs.DiscardUnknown(m)
 }
 
-func (m *ListShardBaseInfo) GetIndex() uint32 {
+var xxx_messageInfo_UnrefDedupArgs proto.InternalMessageInfo
+
+func (m *UnrefDedupArgs) GetHeader() ShardOpHeader {
 	if m != nil {
-		return m.Index
+		return m.Header
 	}
-	return 0
+	return ShardOpHeader{}
 }
 
-func (m *ListShardBaseInfo) GetEpoch() uint32 {
+func (m *UnrefDedupArgs) GetKey() []byte {
 	if m != nil {
-		return m.Epoch
+		return m.Key
 	}
-	return 0
+	return nil
 }
 
-func (m *ListShardBaseInfo) GetUnits() []clustermgr.ShardUnit {
+func (m *UnrefDedupArgs) GetRef() []byte {
 	if m != nil {
-		return m.Units
+		return m.Ref
 	}
 	return nil
 }
 
-type ListShardRet struct {
-	Shards               []ListShardBaseInfo `protobuf:"bytes,1,rep,name=shards,proto3" json:"shards"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+func (m *UnrefDedupArgs) GetLocation() proto1.Location {
+	if m != nil {
+		return m.Location
+	}
+	return proto1.Location{}
 }
 
-func (m *ListShardRet) Reset()         { *m = ListShardRet{} }
-func (m *ListShardRet) String() string { return proto.CompactTextString(m) }
-func (*ListShardRet) ProtoMessage()    {}
-func (*ListShardRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{40}
+type UnrefDedupRet struct {
+	Ref DedupRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref"`
+	// unreferred is true if the reference is removed by this request
+	Unreferred bool `protobuf:"varint,2,opt,name=unreferred,proto3" json:"unreferred,omitempty"`
+	// last is true if the last reference is removed and the dedup key is deleted,
+	// the location can be freed only if last is true
+	Last                 bool     `protobuf:"varint,3,opt,name=last,proto3" json:"last,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *ListShardRet) XXX_Unmarshal(b []byte) error {
+
+func (m *UnrefDedupRet) Reset()         { *m = UnrefDedupRet{} }
+func (m *UnrefDedupRet) String() string { return proto.CompactTextString(m) }
+func (*UnrefDedupRet) ProtoMessage()    {}
+func (*UnrefDedupRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{45}
+}
+func (m *UnrefDedupRet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListShardRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UnrefDedupRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListShardRet.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UnrefDedupRet.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2345,43 +2778,61 @@ func (m *ListShardRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ListShardRet) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListShardRet.Merge(m, src)
+func (m *UnrefDedupRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnrefDedupRet.Merge(m, src)
 }
-func (m *ListShardRet) XXX_Size() int {
+func (m *UnrefDedupRet) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListShardRet) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListShardRet.DiscardUnknown(m)
+func (m *UnrefDedupRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnrefDedupRet.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListShardRet proto.InternalMessageInfo
+var xxx_messageInfo_UnrefDedupRet proto.InternalMessageInfo
 
-func (m *ListShardRet) GetShards() []ListShardBaseInfo {
+func (m *UnrefDedupRet) GetRef() DedupRef {
 	if m != nil {
-		return m.Shards
+		return m.Ref
 	}
-	return nil
+	return DedupRef{}
 }
 
-type TCMallocArgs struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *UnrefDedupRet) GetUnreferred() bool {
+	if m != nil {
+		return m.Unreferred
+	}
+	return false
 }
 
-func (m *TCMallocArgs) Reset()         { *m = TCMallocArgs{} }
-func (m *TCMallocArgs) String() string { return proto.CompactTextString(m) }
-func (*TCMallocArgs) ProtoMessage()    {}
-func (*TCMallocArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{41}
+func (m *UnrefDedupRet) GetLast() bool {
+	if m != nil {
+		return m.Last
+	}
+	return false
 }
-func (m *TCMallocArgs) XXX_Unmarshal(b []byte) error {
+
+// FieldFilter compares the field of item with the value, absent field equals to empty value
+type FieldFilter struct {
+	FieldID              github_com_cubefs_cubefs_blobstore_common_proto.FieldID `protobuf:"varint,1,opt,name=field_id,json=fieldId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.FieldID" json:"field_id,omitempty"`
+	Op                   FilterOp                                                `protobuf:"varint,2,opt,name=op,proto3,enum=cubefs.blobstore.api.shardnode.FilterOp" json:"op,omitempty"`
+	Value                []byte                                                  `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                                `json:"-"`
+	XXX_unrecognized     []byte                                                  `json:"-"`
+	XXX_sizecache        int32                                                   `json:"-"`
+}
+
+func (m *FieldFilter) Reset()         { *m = FieldFilter{} }
+func (m *FieldFilter) String() string { return proto.CompactTextString(m) }
+func (*FieldFilter) ProtoMessage()    {}
+func (*FieldFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{46}
+}
+func (m *FieldFilter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TCMallocArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FieldFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TCMallocArgs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FieldFilter.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2391,85 +2842,60 @@ func (m *TCMallocArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *TCMallocArgs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TCMallocArgs.Merge(m, src)
+func (m *FieldFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldFilter.Merge(m, src)
 }
-func (m *TCMallocArgs) XXX_Size() int {
+func (m *FieldFilter) XXX_Size() int {
 	return m.Size()
 }
-func (m *TCMallocArgs) XXX_DiscardUnknown() {
-	xxx_messageInfo_TCMallocArgs.DiscardUnknown(m)
+func (m *FieldFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldFilter.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TCMallocArgs proto.InternalMessageInfo
+var xxx_messageInfo_FieldFilter proto.InternalMessageInfo
 
-type TCMallocRet struct {
-	Stats                string   `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *FieldFilter) GetFieldID() github_com_cubefs_cubefs_blobstore_common_proto.FieldID {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
 }
 
-func (m *TCMallocRet) Reset()         { *m = TCMallocRet{} }
-func (m *TCMallocRet) String() string { return proto.CompactTextString(m) }
-func (*TCMallocRet) ProtoMessage()    {}
-func (*TCMallocRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{42}
-}
-func (m *TCMallocRet) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *TCMallocRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_TCMallocRet.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *FieldFilter) GetOp() FilterOp {
+	if m != nil {
+		return m.Op
 	}
-}
-func (m *TCMallocRet) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TCMallocRet.Merge(m, src)
-}
-func (m *TCMallocRet) XXX_Size() int {
-	return m.Size()
-}
-func (m *TCMallocRet) XXX_DiscardUnknown() {
-	xxx_messageInfo_TCMallocRet.DiscardUnknown(m)
+	return FilterOpNone
 }
 
-var xxx_messageInfo_TCMallocRet proto.InternalMessageInfo
-
-func (m *TCMallocRet) GetStats() string {
+func (m *FieldFilter) GetValue() []byte {
 	if m != nil {
-		return m.Stats
+		return m.Value
 	}
-	return ""
+	return nil
 }
 
-type DBStatsArgs struct {
-	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
-	DBName               string                                                 `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                               `json:"-"`
-	XXX_unrecognized     []byte                                                 `json:"-"`
-	XXX_sizecache        int32                                                  `json:"-"`
+type TxnCond struct {
+	Type                 TxnCondType                                             `protobuf:"varint,1,opt,name=type,proto3,enum=cubefs.blobstore.api.shardnode.TxnCondType" json:"type,omitempty"`
+	FieldID              github_com_cubefs_cubefs_blobstore_common_proto.FieldID `protobuf:"varint,2,opt,name=field_id,json=fieldId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.FieldID" json:"field_id,omitempty"`
+	Value                []byte                                                  `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                                `json:"-"`
+	XXX_unrecognized     []byte                                                  `json:"-"`
+	XXX_sizecache        int32                                                   `json:"-"`
 }
 
-func (m *DBStatsArgs) Reset()         { *m = DBStatsArgs{} }
-func (m *DBStatsArgs) String() string { return proto.CompactTextString(m) }
-func (*DBStatsArgs) ProtoMessage()    {}
-func (*DBStatsArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{43}
+func (m *TxnCond) Reset()         { *m = TxnCond{} }
+func (m *TxnCond) String() string { return proto.CompactTextString(m) }
+func (*TxnCond) ProtoMessage()    {}
+func (*TxnCond) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{47}
 }
-func (m *DBStatsArgs) XXX_Unmarshal(b []byte) error {
+func (m *TxnCond) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DBStatsArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TxnCond) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DBStatsArgs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TxnCond.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2479,56 +2905,68 @@ func (m *DBStatsArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *DBStatsArgs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DBStatsArgs.Merge(m, src)
+func (m *TxnCond) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnCond.Merge(m, src)
 }
-func (m *DBStatsArgs) XXX_Size() int {
+func (m *TxnCond) XXX_Size() int {
 	return m.Size()
 }
-func (m *DBStatsArgs) XXX_DiscardUnknown() {
-	xxx_messageInfo_DBStatsArgs.DiscardUnknown(m)
+func (m *TxnCond) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnCond.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DBStatsArgs proto.InternalMessageInfo
+var xxx_messageInfo_TxnCond proto.InternalMessageInfo
 
-func (m *DBStatsArgs) GetDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
+func (m *TxnCond) GetType() TxnCondType {
 	if m != nil {
-		return m.DiskID
+		return m.Type
+	}
+	return TxnCondNone
+}
+
+func (m *TxnCond) GetFieldID() github_com_cubefs_cubefs_blobstore_common_proto.FieldID {
+	if m != nil {
+		return m.FieldID
 	}
 	return 0
 }
 
-func (m *DBStatsArgs) GetDBName() string {
+func (m *TxnCond) GetValue() []byte {
 	if m != nil {
-		return m.DBName
+		return m.Value
 	}
-	return ""
+	return nil
 }
 
-type DBStatsRet struct {
-	Used                 uint64   `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
-	BlobCacheUsage       uint64   `protobuf:"varint,2,opt,name=blob_cache_usage,json=blobCacheUsage,proto3" json:"blob_cache_usage,omitempty"`
-	IndexAndFilterUsage  uint64   `protobuf:"varint,3,opt,name=index_and_filter_usage,json=indexAndFilterUsage,proto3" json:"index_and_filter_usage,omitempty"`
-	MemtableUsage        uint64   `protobuf:"varint,4,opt,name=memtable_usage,json=memtableUsage,proto3" json:"memtable_usage,omitempty"`
-	BlockPinnedUsage     uint64   `protobuf:"varint,5,opt,name=block_pinned_usage,json=blockPinnedUsage,proto3" json:"block_pinned_usage,omitempty"`
-	TotalMemoryUsage     uint64   `protobuf:"varint,6,opt,name=total_memory_usage,json=totalMemoryUsage,proto3" json:"total_memory_usage,omitempty"`
+// TxnOp operation of transaction, item.id is the key of item operations,
+// and name is the key of blob operations
+type TxnOp struct {
+	Type TxnOpType `protobuf:"varint,1,opt,name=type,proto3,enum=cubefs.blobstore.api.shardnode.TxnOpType" json:"type,omitempty"`
+	// shard keys of the operation, must belong to the shard of header
+	ShardKeys [][]byte    `protobuf:"bytes,2,rep,name=shard_keys,json=shardKeys,proto3" json:"shard_keys,omitempty"`
+	Item      Item        `protobuf:"bytes,3,opt,name=item,proto3" json:"item"`
+	Name      []byte      `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Blob      proto1.Blob `protobuf:"bytes,5,opt,name=blob,proto3" json:"blob"`
+	Cond      TxnCond     `protobuf:"bytes,6,opt,name=cond,proto3" json:"cond"`
+	// expire time of trash for delete blob operation
+	ExpireTime           int64    `protobuf:"varint,7,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DBStatsRet) Reset()         { *m = DBStatsRet{} }
-func (m *DBStatsRet) String() string { return proto.CompactTextString(m) }
-func (*DBStatsRet) ProtoMessage()    {}
-func (*DBStatsRet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9d3815ca0e5f30f0, []int{44}
+func (m *TxnOp) Reset()         { *m = TxnOp{} }
+func (m *TxnOp) String() string { return proto.CompactTextString(m) }
+func (*TxnOp) ProtoMessage()    {}
+func (*TxnOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{48}
 }
-func (m *DBStatsRet) XXX_Unmarshal(b []byte) error {
+func (m *TxnOp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DBStatsRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TxnOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DBStatsRet.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TxnOp.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2538,1440 +2976,1396 @@ func (m *DBStatsRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *DBStatsRet) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DBStatsRet.Merge(m, src)
+func (m *TxnOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnOp.Merge(m, src)
 }
-func (m *DBStatsRet) XXX_Size() int {
+func (m *TxnOp) XXX_Size() int {
 	return m.Size()
 }
-func (m *DBStatsRet) XXX_DiscardUnknown() {
-	xxx_messageInfo_DBStatsRet.DiscardUnknown(m)
+func (m *TxnOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnOp.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DBStatsRet proto.InternalMessageInfo
+var xxx_messageInfo_TxnOp proto.InternalMessageInfo
 
-func (m *DBStatsRet) GetUsed() uint64 {
+func (m *TxnOp) GetType() TxnOpType {
 	if m != nil {
-		return m.Used
+		return m.Type
 	}
-	return 0
+	return TxnOpNone
 }
 
-func (m *DBStatsRet) GetBlobCacheUsage() uint64 {
+func (m *TxnOp) GetShardKeys() [][]byte {
 	if m != nil {
-		return m.BlobCacheUsage
+		return m.ShardKeys
 	}
-	return 0
+	return nil
 }
 
-func (m *DBStatsRet) GetIndexAndFilterUsage() uint64 {
+func (m *TxnOp) GetItem() Item {
 	if m != nil {
-		return m.IndexAndFilterUsage
+		return m.Item
 	}
-	return 0
+	return Item{}
 }
 
-func (m *DBStatsRet) GetMemtableUsage() uint64 {
+func (m *TxnOp) GetName() []byte {
 	if m != nil {
-		return m.MemtableUsage
+		return m.Name
 	}
-	return 0
+	return nil
 }
 
-func (m *DBStatsRet) GetBlockPinnedUsage() uint64 {
+func (m *TxnOp) GetBlob() proto1.Blob {
 	if m != nil {
-		return m.BlockPinnedUsage
+		return m.Blob
 	}
-	return 0
+	return proto1.Blob{}
 }
 
-func (m *DBStatsRet) GetTotalMemoryUsage() uint64 {
+func (m *TxnOp) GetCond() TxnCond {
 	if m != nil {
-		return m.TotalMemoryUsage
+		return m.Cond
 	}
-	return 0
+	return TxnCond{}
 }
 
-func init() {
-	proto.RegisterType((*Item)(nil), "cubefs.blobstore.api.shardnode.Item")
-	proto.RegisterType((*Field)(nil), "cubefs.blobstore.api.shardnode.Field")
-	proto.RegisterType((*ShardOpHeader)(nil), "cubefs.blobstore.api.shardnode.ShardOpHeader")
-	proto.RegisterType((*InsertItemArgs)(nil), "cubefs.blobstore.api.shardnode.InsertItemArgs")
-	proto.RegisterType((*InsertItemRet)(nil), "cubefs.blobstore.api.shardnode.InsertItemRet")
-	proto.RegisterType((*UpdateItemArgs)(nil), "cubefs.blobstore.api.shardnode.UpdateItemArgs")
-	proto.RegisterType((*UpdateItemRet)(nil), "cubefs.blobstore.api.shardnode.UpdateItemRet")
-	proto.RegisterType((*DeleteItemArgs)(nil), "cubefs.blobstore.api.shardnode.DeleteItemArgs")
-	proto.RegisterType((*DeleteItemRet)(nil), "cubefs.blobstore.api.shardnode.DeleteItemRet")
-	proto.RegisterType((*GetItemArgs)(nil), "cubefs.blobstore.api.shardnode.GetItemArgs")
-	proto.RegisterType((*GetItemRet)(nil), "cubefs.blobstore.api.shardnode.GetItemRet")
-	proto.RegisterType((*ListItemArgs)(nil), "cubefs.blobstore.api.shardnode.ListItemArgs")
-	proto.RegisterType((*ListItemRet)(nil), "cubefs.blobstore.api.shardnode.ListItemRet")
-	proto.RegisterType((*AddShardArgs)(nil), "cubefs.blobstore.api.shardnode.AddShardArgs")
-	proto.RegisterType((*AddShardRet)(nil), "cubefs.blobstore.api.shardnode.AddShardRet")
-	proto.RegisterType((*UpdateShardArgs)(nil), "cubefs.blobstore.api.shardnode.UpdateShardArgs")
-	proto.RegisterType((*UpdateShardRet)(nil), "cubefs.blobstore.api.shardnode.UpdateShardRet")
-	proto.RegisterType((*TransferShardLeaderArgs)(nil), "cubefs.blobstore.api.shardnode.TransferShardLeaderArgs")
-	proto.RegisterType((*TransferShardLeaderRet)(nil), "cubefs.blobstore.api.shardnode.TransferShardLeaderRet")
-	proto.RegisterType((*GetShardArgs)(nil), "cubefs.blobstore.api.shardnode.GetShardArgs")
-	proto.RegisterType((*GetShardRet)(nil), "cubefs.blobstore.api.shardnode.GetShardRet")
-	proto.RegisterType((*CreateBlobArgs)(nil), "cubefs.blobstore.api.shardnode.CreateBlobArgs")
-	proto.RegisterType((*CreateBlobRet)(nil), "cubefs.blobstore.api.shardnode.CreateBlobRet")
-	proto.RegisterType((*GetBlobArgs)(nil), "cubefs.blobstore.api.shardnode.GetBlobArgs")
-	proto.RegisterType((*GetBlobRet)(nil), "cubefs.blobstore.api.shardnode.GetBlobRet")
-	proto.RegisterType((*ListBlobArgs)(nil), "cubefs.blobstore.api.shardnode.ListBlobArgs")
-	proto.RegisterType((*ListBlobRet)(nil), "cubefs.blobstore.api.shardnode.ListBlobRet")
-	proto.RegisterType((*DeleteBlobArgs)(nil), "cubefs.blobstore.api.shardnode.DeleteBlobArgs")
-	proto.RegisterType((*DeleteBlobRet)(nil), "cubefs.blobstore.api.shardnode.DeleteBlobRet")
-	proto.RegisterType((*RetainBlobArgs)(nil), "cubefs.blobstore.api.shardnode.RetainBlobArgs")
-	proto.RegisterType((*RetainBlobRet)(nil), "cubefs.blobstore.api.shardnode.RetainBlobRet")
-	proto.RegisterType((*SealBlobArgs)(nil), "cubefs.blobstore.api.shardnode.SealBlobArgs")
-	proto.RegisterType((*SealBlobRet)(nil), "cubefs.blobstore.api.shardnode.SealBlobRet")
-	proto.RegisterType((*AllocSliceArgs)(nil), "cubefs.blobstore.api.shardnode.AllocSliceArgs")
-	proto.RegisterType((*AllocSliceRet)(nil), "cubefs.blobstore.api.shardnode.AllocSliceRet")
-	proto.RegisterType((*ShardStats)(nil), "cubefs.blobstore.api.shardnode.ShardStats")
-	proto.RegisterType((*ListVolumeArgs)(nil), "cubefs.blobstore.api.shardnode.ListVolumeArgs")
-	proto.RegisterType((*ListVolumeRet)(nil), "cubefs.blobstore.api.shardnode.ListVolumeRet")
-	proto.RegisterType((*ListShardArgs)(nil), "cubefs.blobstore.api.shardnode.ListShardArgs")
-	proto.RegisterType((*ListShardBaseInfo)(nil), "cubefs.blobstore.api.shardnode.ListShardBaseInfo")
-	proto.RegisterType((*ListShardRet)(nil), "cubefs.blobstore.api.shardnode.ListShardRet")
-	proto.RegisterType((*TCMallocArgs)(nil), "cubefs.blobstore.api.shardnode.TCMallocArgs")
-	proto.RegisterType((*TCMallocRet)(nil), "cubefs.blobstore.api.shardnode.TCMallocRet")
-	proto.RegisterType((*DBStatsArgs)(nil), "cubefs.blobstore.api.shardnode.DBStatsArgs")
-	proto.RegisterType((*DBStatsRet)(nil), "cubefs.blobstore.api.shardnode.DBStatsRet")
+func (m *TxnOp) GetExpireTime() int64 {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return 0
 }
 
-func init() { proto.RegisterFile("shardnode.proto", fileDescriptor_9d3815ca0e5f30f0) }
-
-var fileDescriptor_9d3815ca0e5f30f0 = []byte{
-	// 1687 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0xcb, 0x6f, 0x1b, 0xd5,
-	0x1a, 0xbf, 0xe3, 0x57, 0x92, 0xcf, 0x63, 0xa7, 0x77, 0x6e, 0xd4, 0x6b, 0x45, 0xba, 0x71, 0x34,
-	0x69, 0xd5, 0xe8, 0x52, 0x6c, 0x91, 0x22, 0x58, 0x14, 0x68, 0xe3, 0x58, 0x69, 0xdd, 0x34, 0x2d,
-	0x8c, 0xd3, 0x48, 0x20, 0xa1, 0xd1, 0xb1, 0xcf, 0xb1, 0x33, 0x64, 0x1e, 0x66, 0xe6, 0xb8, 0x6a,
-	0xba, 0x42, 0x6c, 0x58, 0x21, 0xc4, 0x1f, 0x80, 0x10, 0x7f, 0x05, 0x12, 0x12, 0x2b, 0x16, 0x74,
-	0x09, 0x2b, 0x76, 0x16, 0xf2, 0x86, 0xff, 0x21, 0x2b, 0x74, 0xbe, 0x73, 0x66, 0xec, 0x36, 0x4d,
-	0x53, 0xe7, 0x25, 0x0a, 0x9b, 0xc4, 0xe7, 0x9b, 0xef, 0xf1, 0x3b, 0xdf, 0x7b, 0x06, 0x66, 0xa3,
-	0x1d, 0x12, 0x52, 0x3f, 0xa0, 0xac, 0xd2, 0x0b, 0x03, 0x1e, 0x18, 0x0b, 0xed, 0x7e, 0x8b, 0x75,
-	0xa2, 0x4a, 0xcb, 0x0d, 0x5a, 0x11, 0x0f, 0x42, 0x56, 0x21, 0x3d, 0xa7, 0x92, 0x70, 0xcd, 0xcf,
-	0x75, 0x83, 0x6e, 0x80, 0xac, 0x55, 0xf1, 0x4b, 0x4a, 0xcd, 0x5f, 0x95, 0x52, 0xd5, 0x44, 0xaa,
-	0xda, 0x0e, 0x3c, 0x2f, 0xf0, 0xab, 0x28, 0xe8, 0xf8, 0xdd, 0x6a, 0x48, 0xfc, 0xae, 0xb2, 0x31,
-	0xff, 0xda, 0x01, 0x6e, 0xd2, 0x73, 0xaa, 0x6d, 0xb7, 0x1f, 0x71, 0x16, 0x7a, 0xdd, 0x50, 0x4a,
-	0x29, 0xe6, 0xe5, 0xc3, 0x54, 0x4b, 0x10, 0x82, 0xac, 0x38, 0xaf, 0x1c, 0xc6, 0x19, 0x92, 0x0e,
-	0xc7, 0x3f, 0x92, 0xd1, 0x6c, 0x43, 0xa6, 0xc1, 0x99, 0x67, 0x5c, 0x84, 0x94, 0x43, 0x4b, 0xda,
-	0xa2, 0xb6, 0xac, 0xd7, 0x72, 0xc3, 0x41, 0x39, 0xd5, 0xa8, 0x5b, 0x29, 0x87, 0x1a, 0x6b, 0x90,
-	0xeb, 0x38, 0xcc, 0xa5, 0x51, 0x29, 0xb5, 0x98, 0x5e, 0xce, 0xaf, 0x5c, 0xae, 0xbc, 0xd8, 0x29,
-	0x95, 0x75, 0xc1, 0x5d, 0xcb, 0x3c, 0x19, 0x94, 0xff, 0x65, 0x29, 0x51, 0xb3, 0x07, 0x59, 0x24,
-	0x1b, 0x1f, 0x24, 0x56, 0x0a, 0xb5, 0x55, 0x69, 0x65, 0x7f, 0x50, 0x7e, 0xbb, 0xeb, 0xf0, 0x9d,
-	0x7e, 0xab, 0xd2, 0x0e, 0xbc, 0xaa, 0xc2, 0xfd, 0xc2, 0x8b, 0x4a, 0x1b, 0x0a, 0xe0, 0x1c, 0x64,
-	0x1f, 0x12, 0xb7, 0xcf, 0x4a, 0x29, 0x81, 0xdd, 0x92, 0x07, 0xf3, 0xd7, 0x34, 0x14, 0x9a, 0x02,
-	0xd3, 0xfd, 0xde, 0x6d, 0x46, 0x28, 0x0b, 0x0d, 0x02, 0xd3, 0x51, 0x8f, 0xb4, 0x99, 0xad, 0x00,
-	0x64, 0x6a, 0xeb, 0xc3, 0x41, 0x79, 0xaa, 0x29, 0x68, 0xc7, 0x43, 0xa1, 0x44, 0xad, 0x29, 0xd4,
-	0xdb, 0xa0, 0xc6, 0xc7, 0x30, 0x45, 0x9d, 0x68, 0x57, 0x58, 0x48, 0xe1, 0x15, 0xeb, 0xc3, 0x41,
-	0x39, 0x57, 0x77, 0xa2, 0x5d, 0x34, 0xf0, 0xd6, 0xa4, 0x06, 0xa4, 0xa4, 0x95, 0x13, 0x4a, 0x1b,
-	0xd4, 0xd8, 0x82, 0x4c, 0xd4, 0x77, 0x68, 0x29, 0x8d, 0xba, 0x6f, 0x0e, 0x07, 0xe5, 0x4c, 0xb3,
-	0xef, 0xd0, 0xfd, 0x41, 0xf9, 0xcd, 0x89, 0xa1, 0xf7, 0x1d, 0x6a, 0xa1, 0x36, 0xc3, 0x04, 0x1d,
-	0xf1, 0x6f, 0xb3, 0x30, 0x72, 0x02, 0xbf, 0x94, 0x11, 0xbe, 0xb1, 0x9e, 0xa2, 0x19, 0x0c, 0x0a,
-	0x61, 0xd0, 0xe7, 0xcc, 0x7e, 0xa8, 0x98, 0xb2, 0xe8, 0xc0, 0x9b, 0xfb, 0x83, 0xf2, 0x3b, 0x93,
-	0x9a, 0xb6, 0x84, 0x22, 0xa5, 0xd8, 0xd2, 0xc3, 0xb1, 0x93, 0xf1, 0x3f, 0x00, 0xcc, 0x23, 0x7b,
-	0x97, 0xed, 0x45, 0xa5, 0xdc, 0x62, 0x7a, 0x59, 0xb7, 0x66, 0x90, 0xb2, 0xc1, 0xf6, 0x22, 0xf3,
-	0x1b, 0x0d, 0x8a, 0x0d, 0x3f, 0x62, 0x21, 0x17, 0x19, 0xbb, 0x1a, 0x76, 0x23, 0x63, 0x03, 0x72,
-	0x3b, 0x18, 0x5e, 0x0c, 0x69, 0x7e, 0xe5, 0xf5, 0xa3, 0xb2, 0xf3, 0xa9, 0x9c, 0x88, 0xb3, 0x54,
-	0xaa, 0x30, 0xde, 0x83, 0x8c, 0xc3, 0x99, 0x87, 0xb1, 0xcb, 0xaf, 0x5c, 0x3a, 0x4a, 0x95, 0x00,
-	0xa1, 0x34, 0xa0, 0x9c, 0x39, 0x0b, 0x85, 0x11, 0x3c, 0x8b, 0x71, 0x04, 0xfc, 0xa0, 0x47, 0x09,
-	0x67, 0x7f, 0x59, 0xc0, 0x23, 0x78, 0x02, 0x70, 0x1f, 0x8a, 0x75, 0xe6, 0xb2, 0xb3, 0xc2, 0x2b,
-	0x7b, 0x4c, 0xea, 0xd9, 0x1e, 0x23, 0x70, 0x8c, 0xcc, 0x0a, 0x1c, 0x21, 0xe4, 0x6f, 0x31, 0x7e,
-	0xbe, 0x20, 0xee, 0x02, 0x28, 0x9b, 0x16, 0xe3, 0x89, 0x6b, 0xb5, 0x63, 0xba, 0xf6, 0x3b, 0x0d,
-	0xf4, 0xbb, 0x4e, 0x74, 0x66, 0x77, 0xc8, 0xf5, 0x42, 0xd6, 0x71, 0x1e, 0xa9, 0xa6, 0xa7, 0x4e,
-	0x82, 0xee, 0x91, 0x70, 0x97, 0x85, 0xd8, 0x23, 0x74, 0x4b, 0x9d, 0x44, 0x8f, 0x6c, 0x07, 0x7d,
-	0x9f, 0xab, 0xe2, 0x96, 0x07, 0x33, 0x80, 0x7c, 0x0c, 0x51, 0x5c, 0xf9, 0x26, 0x64, 0x05, 0xf4,
-	0xa8, 0xa4, 0x61, 0xa3, 0x9f, 0xe4, 0xce, 0x52, 0xd0, 0x58, 0x00, 0xf0, 0xd9, 0x23, 0xbe, 0x29,
-	0x21, 0x48, 0x68, 0x63, 0x14, 0xf3, 0xfb, 0x34, 0xe8, 0xab, 0x94, 0xe2, 0xcd, 0xd0, 0x29, 0x63,
-	0x0d, 0x53, 0x3b, 0xc3, 0x86, 0x99, 0x92, 0xdd, 0xea, 0x94, 0x1a, 0xe6, 0x1a, 0x64, 0x71, 0x80,
-	0xa3, 0x8f, 0xf3, 0x2b, 0x57, 0x0e, 0xfa, 0x49, 0x4a, 0x56, 0xe2, 0x79, 0x5f, 0xb1, 0x04, 0x7b,
-	0xec, 0x2a, 0x94, 0x35, 0xd6, 0x21, 0xdb, 0xf7, 0x1d, 0x1e, 0x95, 0x32, 0xe8, 0xec, 0xff, 0x3f,
-	0xdf, 0xd9, 0xa3, 0x35, 0x40, 0xa6, 0xc3, 0x03, 0xdf, 0xe1, 0xb1, 0x1e, 0x14, 0x3f, 0xa7, 0xce,
-	0x6c, 0x16, 0x20, 0x1f, 0x07, 0x4e, 0xd4, 0xe7, 0x57, 0x69, 0x98, 0x95, 0x9d, 0xe3, 0x15, 0x8f,
-	0xe5, 0x67, 0x1a, 0xcc, 0x4a, 0xcf, 0xe2, 0x6d, 0xb6, 0xf6, 0x7a, 0x4c, 0x8d, 0xd7, 0xed, 0xe1,
-	0xa0, 0xfc, 0xec, 0xa3, 0xfd, 0x41, 0xf9, 0xc6, 0xc4, 0xc6, 0x9e, 0x56, 0x61, 0x3d, 0xab, 0xd3,
-	0xa8, 0x43, 0x46, 0x84, 0x12, 0x4b, 0xf3, 0x38, 0x89, 0x80, 0xd2, 0xe6, 0x85, 0x78, 0xd2, 0x24,
-	0x31, 0xfa, 0x21, 0x05, 0xff, 0xdd, 0x0a, 0x89, 0x1f, 0x75, 0x58, 0x88, 0xc4, 0xbb, 0xd8, 0x3b,
-	0x5e, 0xdd, 0x58, 0x7d, 0x02, 0x3a, 0x65, 0x11, 0xb7, 0x63, 0xe4, 0x32, 0x4e, 0xb7, 0x87, 0x83,
-	0x32, 0xd4, 0x59, 0xc4, 0x4f, 0x8c, 0x1e, 0x68, 0xac, 0x85, 0x9a, 0x25, 0xb8, 0xf8, 0x1c, 0xdf,
-	0x09, 0xb7, 0xfe, 0xa4, 0x81, 0x7e, 0x8b, 0xf1, 0x57, 0x3b, 0xef, 0xcd, 0x0f, 0x71, 0xc0, 0xc6,
-	0xb9, 0x62, 0xdc, 0x81, 0x2c, 0x36, 0x2b, 0x35, 0x9b, 0x2a, 0x2f, 0x9f, 0x84, 0x0d, 0xbf, 0x13,
-	0xc4, 0x1d, 0x09, 0x55, 0x98, 0x5f, 0xa6, 0xa0, 0xb8, 0x16, 0x32, 0xc2, 0x59, 0xcd, 0x0d, 0x5a,
-	0xa7, 0x3f, 0xfb, 0x0c, 0xc8, 0xf8, 0xc4, 0x8b, 0xd7, 0x7d, 0xfc, 0x6d, 0x74, 0x61, 0xba, 0x1d,
-	0x50, 0xe6, 0x05, 0x34, 0x2e, 0xdf, 0x8d, 0xe1, 0xa0, 0x3c, 0xbd, 0x16, 0x50, 0xb6, 0x19, 0x50,
-	0x51, 0xb7, 0xd7, 0x5f, 0xde, 0x59, 0xb1, 0xa6, 0x4a, 0x2c, 0x6e, 0x25, 0xca, 0x85, 0xf1, 0xc8,
-	0x79, 0xcc, 0xd4, 0x1c, 0xc5, 0xdf, 0xb8, 0xb5, 0xba, 0x4e, 0x9b, 0xd9, 0xf8, 0x44, 0xf4, 0xdf,
-	0x82, 0x35, 0x83, 0x94, 0xa6, 0xf3, 0x98, 0x99, 0xf7, 0xa0, 0x30, 0x72, 0x87, 0x70, 0xf6, 0xbb,
-	0x90, 0x11, 0x36, 0x95, 0x2f, 0x96, 0x0e, 0x1d, 0x1f, 0x32, 0x68, 0x42, 0x2a, 0xae, 0x74, 0xc1,
-	0x62, 0xfa, 0x18, 0xba, 0x73, 0xf3, 0xad, 0xb9, 0x81, 0x7b, 0xd1, 0x29, 0x81, 0x8f, 0xd7, 0xa2,
-	0xb3, 0x81, 0x7f, 0x3a, 0x6b, 0x91, 0x2f, 0xd7, 0xa2, 0xf8, 0xc6, 0x37, 0x20, 0x8b, 0x58, 0xd4,
-	0x5a, 0x34, 0xc1, 0x95, 0xa5, 0xdc, 0x91, 0x5b, 0xd1, 0xa7, 0xf1, 0xd2, 0x7d, 0x7e, 0x31, 0x4d,
-	0x16, 0x6e, 0x75, 0x49, 0xf3, 0x0b, 0x0d, 0x8a, 0x16, 0xe3, 0xc4, 0xf1, 0xcf, 0xaf, 0x68, 0xe7,
-	0x20, 0xeb, 0x32, 0x12, 0xc9, 0x8a, 0xcd, 0x58, 0xf2, 0x20, 0xa0, 0x8d, 0x80, 0x08, 0x68, 0x3f,
-	0x6b, 0xa0, 0x37, 0x19, 0x71, 0xcf, 0x0c, 0x18, 0x96, 0x6d, 0x6a, 0xac, 0xa0, 0x63, 0xb0, 0xe9,
-	0x31, 0xb0, 0x35, 0xc8, 0x61, 0x49, 0xc7, 0x0b, 0xdb, 0xa5, 0x23, 0xd2, 0xa0, 0x29, 0x98, 0x63,
-	0x5b, 0x52, 0x52, 0x2c, 0x51, 0xf1, 0x45, 0xc4, 0xc5, 0x7e, 0x4c, 0x41, 0x71, 0xd5, 0x75, 0x83,
-	0x36, 0xf2, 0xfe, 0x03, 0x1a, 0xe5, 0x26, 0xe8, 0x1d, 0xe2, 0xb8, 0x8c, 0xda, 0xe8, 0x10, 0x6c,
-	0x95, 0x93, 0x79, 0x32, 0x2f, 0xe5, 0x91, 0x64, 0x36, 0xa1, 0x30, 0x72, 0x9f, 0xa8, 0xd4, 0x51,
-	0x8c, 0xb4, 0x63, 0xc7, 0xe8, 0xb7, 0x2c, 0x00, 0x3a, 0xb5, 0xc9, 0x09, 0x8f, 0x92, 0xe9, 0xab,
-	0x9d, 0xea, 0x26, 0xb3, 0x04, 0x05, 0xd2, 0xeb, 0xb9, 0x0e, 0xa3, 0xb6, 0xe3, 0x53, 0xf6, 0x48,
-	0x65, 0x9f, 0xae, 0x88, 0x0d, 0x41, 0x33, 0xca, 0x90, 0x77, 0x31, 0x90, 0xf6, 0x4e, 0x10, 0x71,
-	0x8c, 0xd6, 0x8c, 0x05, 0x92, 0x74, 0x3b, 0x88, 0xb8, 0xd1, 0x83, 0xa2, 0x62, 0x88, 0xf7, 0x8f,
-	0x0c, 0x46, 0xf4, 0xce, 0x70, 0x50, 0xd6, 0xe5, 0xc2, 0x72, 0xe2, 0x2d, 0x44, 0x77, 0x47, 0x7a,
-	0xa8, 0xd1, 0x4d, 0x20, 0xa1, 0x53, 0xb2, 0xc9, 0x57, 0x34, 0x90, 0xe6, 0x4e, 0xe4, 0x1a, 0x75,
-	0x35, 0xf1, 0xdb, 0x28, 0xc1, 0x94, 0xcb, 0x48, 0xe8, 0xb3, 0xb0, 0x94, 0x5b, 0xd4, 0x96, 0xa7,
-	0xad, 0xf8, 0x78, 0xf0, 0x7d, 0x67, 0xea, 0x4c, 0xbe, 0x44, 0x25, 0xef, 0x78, 0xd3, 0xa7, 0xf1,
-	0x8e, 0x37, 0x73, 0xb2, 0x77, 0xbc, 0x3a, 0x4c, 0x87, 0xa4, 0xc3, 0x45, 0x46, 0x96, 0x00, 0xf1,
-	0x98, 0x87, 0xe2, 0xc1, 0x2f, 0xbb, 0x82, 0x53, 0xa9, 0x48, 0x24, 0xcd, 0x3d, 0x28, 0x8a, 0xb1,
-	0xb6, 0x1d, 0xb8, 0x7d, 0x4f, 0x76, 0x9b, 0xf1, 0x66, 0xa0, 0x9d, 0x61, 0x33, 0x30, 0x29, 0x14,
-	0x46, 0xa6, 0x45, 0xa5, 0x36, 0x21, 0xf3, 0xd0, 0xa1, 0xb2, 0x4e, 0x0b, 0xb5, 0x1b, 0xa2, 0xac,
-	0xb6, 0x1d, 0x1a, 0xed, 0x0f, 0xca, 0xd7, 0x26, 0x0d, 0xe2, 0xb6, 0xa8, 0x2a, 0xa1, 0xcc, 0xfc,
-	0x43, 0x93, 0x66, 0xce, 0x6d, 0x35, 0x27, 0x30, 0x2d, 0x3f, 0x57, 0x26, 0xdf, 0x7b, 0xe5, 0x17,
-	0x65, 0x41, 0x3b, 0xe6, 0x17, 0x65, 0x29, 0x6a, 0x4d, 0xa1, 0xde, 0x06, 0x1d, 0x6d, 0x28, 0xe9,
-	0xf1, 0x0d, 0xe5, 0xdb, 0x34, 0xfc, 0x3b, 0xb9, 0x69, 0x8d, 0x44, 0x4c, 0x6c, 0xe1, 0x7f, 0x83,
-	0xdb, 0x8e, 0x7f, 0xe0, 0x3e, 0xbd, 0x6e, 0x3b, 0x07, 0x59, 0xd9, 0x65, 0xb1, 0x3d, 0x5a, 0xf2,
-	0x20, 0xa8, 0xac, 0x17, 0xb4, 0x77, 0xd4, 0xc2, 0x2e, 0x0f, 0xa3, 0x92, 0xcd, 0x9d, 0xa8, 0x64,
-	0x4d, 0x5b, 0xae, 0xb9, 0xc9, 0x0b, 0xd6, 0x7d, 0xc8, 0xe1, 0x25, 0xe3, 0xd1, 0xf4, 0xc6, 0x51,
-	0x83, 0xfd, 0x40, 0x78, 0x93, 0x39, 0x85, 0x6a, 0xcc, 0x22, 0xe8, 0x5b, 0x6b, 0x9b, 0x44, 0xcc,
-	0x3f, 0x91, 0xea, 0xe6, 0x12, 0xe4, 0xe3, 0xb3, 0xb0, 0x37, 0x07, 0xd9, 0x48, 0x0c, 0x30, 0xcc,
-	0x84, 0x19, 0x4b, 0x1e, 0xcc, 0xaf, 0x35, 0xc8, 0xd7, 0x6b, 0x38, 0xd9, 0xce, 0xa3, 0x3e, 0x96,
-	0x60, 0x8a, 0xb6, 0xec, 0x64, 0x07, 0x99, 0xa9, 0x01, 0xaa, 0xaf, 0xdd, 0x23, 0x1e, 0xb3, 0x72,
-	0xb4, 0x25, 0xfe, 0x9b, 0x9f, 0xa7, 0x00, 0x14, 0x26, 0x01, 0xdc, 0x80, 0x4c, 0x3f, 0x62, 0x6a,
-	0xe0, 0x5a, 0xf8, 0xdb, 0x58, 0x86, 0x0b, 0xc2, 0xa0, 0xdd, 0x26, 0xed, 0x1d, 0x66, 0xf7, 0x23,
-	0xd2, 0x8d, 0xf7, 0xb5, 0xa2, 0xa0, 0xaf, 0x09, 0xf2, 0x03, 0x41, 0x35, 0xae, 0xc1, 0x45, 0x8c,
-	0xae, 0x4d, 0x7c, 0x6a, 0x77, 0x1c, 0x97, 0xb3, 0x50, 0xf1, 0xcb, 0xfa, 0xf9, 0x0f, 0x3e, 0x5d,
-	0xf5, 0xe9, 0x3a, 0x3e, 0x93, 0x42, 0x97, 0xa1, 0xe8, 0x31, 0x8f, 0x93, 0x96, 0x1b, 0x2b, 0x97,
-	0x4b, 0x4b, 0x21, 0xa6, 0x4a, 0xb6, 0xab, 0x60, 0xb4, 0xdc, 0xa0, 0xbd, 0x6b, 0xf7, 0x1c, 0xdf,
-	0x67, 0x54, 0xb1, 0xe2, 0x0c, 0xb4, 0x2e, 0xe0, 0x93, 0xf7, 0xf1, 0x41, 0xc2, 0xcd, 0x03, 0x4e,
-	0x5c, 0xdb, 0x63, 0x5e, 0x10, 0xee, 0x29, 0xee, 0x9c, 0xe4, 0xc6, 0x27, 0x9b, 0xf8, 0x00, 0xb9,
-	0x6b, 0xf3, 0x4f, 0x86, 0x0b, 0xda, 0x2f, 0xc3, 0x05, 0xed, 0xf7, 0xe1, 0x82, 0xf6, 0x91, 0x5e,
-	0xa9, 0x5e, 0x4f, 0x32, 0xa1, 0x95, 0x43, 0xdf, 0x5e, 0xfb, 0x33, 0x00, 0x00, 0xff, 0xff, 0x1e,
-	0xff, 0x46, 0x77, 0x9e, 0x1c, 0x00, 0x00,
+// CommitTxnArgs operations of the transaction are applied atomically,
+// all keys must belong to the shard of header, cross-shard transaction is not supported
+type CommitTxnArgs struct {
+	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Ops                  []TxnOp       `protobuf:"bytes,2,rep,name=ops,proto3" json:"ops"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *Item) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *CommitTxnArgs) Reset()         { *m = CommitTxnArgs{} }
+func (m *CommitTxnArgs) String() string { return proto.CompactTextString(m) }
+func (*CommitTxnArgs) ProtoMessage()    {}
+func (*CommitTxnArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{49}
 }
-
-func (m *Item) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *CommitTxnArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *Item) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Fields) > 0 {
-		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintShardnode(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+func (m *CommitTxnArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitTxnArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *CommitTxnArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitTxnArgs.Merge(m, src)
+}
+func (m *CommitTxnArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitTxnArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitTxnArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitTxnArgs proto.InternalMessageInfo
+
+func (m *CommitTxnArgs) GetHeader() ShardOpHeader {
+	if m != nil {
+		return m.Header
 	}
-	return len(dAtA) - i, nil
+	return ShardOpHeader{}
 }
 
-func (m *Field) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *CommitTxnArgs) GetOps() []TxnOp {
+	if m != nil {
+		return m.Ops
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Field) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type CommitTxnRet struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.ID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.ID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ShardOpHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *CommitTxnRet) Reset()         { *m = CommitTxnRet{} }
+func (m *CommitTxnRet) String() string { return proto.CompactTextString(m) }
+func (*CommitTxnRet) ProtoMessage()    {}
+func (*CommitTxnRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{50}
 }
-
-func (m *ShardOpHeader) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *CommitTxnRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *ShardOpHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ShardKeys) > 0 {
-		for iNdEx := len(m.ShardKeys) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.ShardKeys[iNdEx])
-			copy(dAtA[i:], m.ShardKeys[iNdEx])
-			i = encodeVarintShardnode(dAtA, i, uint64(len(m.ShardKeys[iNdEx])))
-			i--
-			dAtA[i] = 0x32
+func (m *CommitTxnRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitTxnRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.RouteVersion != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.RouteVersion))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.SpaceVersion != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.SpaceVersion))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Suid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.DiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.SpaceID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.SpaceID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
 }
-
-func (m *InsertItemArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *CommitTxnRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitTxnRet.Merge(m, src)
+}
+func (m *CommitTxnRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitTxnRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitTxnRet.DiscardUnknown(m)
 }
 
-func (m *InsertItemArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_CommitTxnRet proto.InternalMessageInfo
+
+type RetainBlobArgs struct {
+	Header               ShardOpHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Name                 []byte        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Lease                uint64        `protobuf:"varint,3,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *InsertItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	{
-		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0x12
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+func (m *RetainBlobArgs) Reset()         { *m = RetainBlobArgs{} }
+func (m *RetainBlobArgs) String() string { return proto.CompactTextString(m) }
+func (*RetainBlobArgs) ProtoMessage()    {}
+func (*RetainBlobArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{51}
+}
+func (m *RetainBlobArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RetainBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RetainBlobArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *InsertItemRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *RetainBlobArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainBlobArgs.Merge(m, src)
+}
+func (m *RetainBlobArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *RetainBlobArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainBlobArgs.DiscardUnknown(m)
 }
 
-func (m *InsertItemRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_RetainBlobArgs proto.InternalMessageInfo
+
+func (m *RetainBlobArgs) GetHeader() ShardOpHeader {
+	if m != nil {
+		return m.Header
+	}
+	return ShardOpHeader{}
 }
 
-func (m *InsertItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *RetainBlobArgs) GetName() []byte {
+	if m != nil {
+		return m.Name
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *UpdateItemArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RetainBlobArgs) GetLease() uint64 {
+	if m != nil {
+		return m.Lease
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *UpdateItemArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RetainBlobRet struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	{
-		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0x12
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+func (m *RetainBlobRet) Reset()         { *m = RetainBlobRet{} }
+func (m *RetainBlobRet) String() string { return proto.CompactTextString(m) }
+func (*RetainBlobRet) ProtoMessage()    {}
+func (*RetainBlobRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{52}
+}
+func (m *RetainBlobRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RetainBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RetainBlobRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
-}
-
-func (m *UpdateItemRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *UpdateItemRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RetainBlobRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainBlobRet.Merge(m, src)
 }
-
-func (m *UpdateItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+func (m *RetainBlobRet) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *DeleteItemArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *RetainBlobRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainBlobRet.DiscardUnknown(m)
 }
 
-func (m *DeleteItemArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_RetainBlobRet proto.InternalMessageInfo
+
+type SealBlobArgs struct {
+	Header               ShardOpHeader  `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Size_                uint64         `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Name                 []byte         `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Slices               []proto1.Slice `protobuf:"bytes,4,rep,name=slices,proto3" json:"slices"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *DeleteItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+func (m *SealBlobArgs) Reset()         { *m = SealBlobArgs{} }
+func (m *SealBlobArgs) String() string { return proto.CompactTextString(m) }
+func (*SealBlobArgs) ProtoMessage()    {}
+func (*SealBlobArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{53}
+}
+func (m *SealBlobArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SealBlobArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SealBlobArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *SealBlobArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SealBlobArgs.Merge(m, src)
+}
+func (m *SealBlobArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *SealBlobArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_SealBlobArgs.DiscardUnknown(m)
 }
 
-func (m *DeleteItemRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SealBlobArgs proto.InternalMessageInfo
+
+func (m *SealBlobArgs) GetHeader() ShardOpHeader {
+	if m != nil {
+		return m.Header
 	}
-	return dAtA[:n], nil
+	return ShardOpHeader{}
 }
 
-func (m *DeleteItemRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SealBlobArgs) GetSize_() uint64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
 }
 
-func (m *DeleteItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *SealBlobArgs) GetName() []byte {
+	if m != nil {
+		return m.Name
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetItemArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SealBlobArgs) GetSlices() []proto1.Slice {
+	if m != nil {
+		return m.Slices
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetItemArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SealBlobRet struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+func (m *SealBlobRet) Reset()         { *m = SealBlobRet{} }
+func (m *SealBlobRet) String() string { return proto.CompactTextString(m) }
+func (*SealBlobRet) ProtoMessage()    {}
+func (*SealBlobRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{54}
+}
+func (m *SealBlobRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SealBlobRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SealBlobRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *GetItemRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *SealBlobRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SealBlobRet.Merge(m, src)
+}
+func (m *SealBlobRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *SealBlobRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_SealBlobRet.DiscardUnknown(m)
 }
 
-func (m *GetItemRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_SealBlobRet proto.InternalMessageInfo
+
+type AllocSliceArgs struct {
+	Header               ShardOpHeader                                               `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Name                 []byte                                                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CodeMode             github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,3,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"codemode,omitempty"`
+	Size_                uint64                                                      `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	FailedSlice          proto1.Slice                                                `protobuf:"bytes,5,opt,name=failed_slice,json=failedSlice,proto3" json:"failed_slice"`
+	XXX_NoUnkeyedLiteral struct{}                                                    `json:"-"`
+	XXX_unrecognized     []byte                                                      `json:"-"`
+	XXX_sizecache        int32                                                       `json:"-"`
 }
 
-func (m *GetItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	{
-		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
+func (m *AllocSliceArgs) Reset()         { *m = AllocSliceArgs{} }
+func (m *AllocSliceArgs) String() string { return proto.CompactTextString(m) }
+func (*AllocSliceArgs) ProtoMessage()    {}
+func (*AllocSliceArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{55}
+}
+func (m *AllocSliceArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AllocSliceArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AllocSliceArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *ListItemArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *AllocSliceArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocSliceArgs.Merge(m, src)
 }
-
-func (m *ListItemArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AllocSliceArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *AllocSliceArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocSliceArgs.DiscardUnknown(m)
 }
 
-func (m *ListItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Count != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Count))
-		i--
-		dAtA[i] = 0x20
+var xxx_messageInfo_AllocSliceArgs proto.InternalMessageInfo
+
+func (m *AllocSliceArgs) GetHeader() ShardOpHeader {
+	if m != nil {
+		return m.Header
 	}
-	if len(m.Marker) > 0 {
-		i -= len(m.Marker)
-		copy(dAtA[i:], m.Marker)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Marker)))
-		i--
-		dAtA[i] = 0x1a
+	return ShardOpHeader{}
+}
+
+func (m *AllocSliceArgs) GetName() []byte {
+	if m != nil {
+		return m.Name
 	}
-	if len(m.Prefix) > 0 {
-		i -= len(m.Prefix)
-		copy(dAtA[i:], m.Prefix)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Prefix)))
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *AllocSliceArgs) GetCodeMode() github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode {
+	if m != nil {
+		return m.CodeMode
 	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	return 0
+}
+
+func (m *AllocSliceArgs) GetSize_() uint64 {
+	if m != nil {
+		return m.Size_
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ListItemRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AllocSliceArgs) GetFailedSlice() proto1.Slice {
+	if m != nil {
+		return m.FailedSlice
 	}
-	return dAtA[:n], nil
+	return proto1.Slice{}
 }
 
-func (m *ListItemRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type AllocSliceRet struct {
+	Slices               []proto1.Slice `protobuf:"bytes,1,rep,name=slices,proto3" json:"slices"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *ListItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.NextMarker) > 0 {
-		i -= len(m.NextMarker)
-		copy(dAtA[i:], m.NextMarker)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.NextMarker)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Items) > 0 {
-		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintShardnode(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *AllocSliceRet) Reset()         { *m = AllocSliceRet{} }
+func (m *AllocSliceRet) String() string { return proto.CompactTextString(m) }
+func (*AllocSliceRet) ProtoMessage()    {}
+func (*AllocSliceRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{56}
+}
+func (m *AllocSliceRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AllocSliceRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AllocSliceRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *AllocSliceRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocSliceRet.Merge(m, src)
+}
+func (m *AllocSliceRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *AllocSliceRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocSliceRet.DiscardUnknown(m)
 }
 
-func (m *AddShardArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AllocSliceRet proto.InternalMessageInfo
+
+func (m *AllocSliceRet) GetSlices() []proto1.Slice {
+	if m != nil {
+		return m.Slices
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AddShardArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ShardStats struct {
+	Suid         github_com_cubefs_cubefs_blobstore_common_proto.Suid         `protobuf:"varint,1,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
+	AppliedIndex uint64                                                       `protobuf:"varint,2,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`
+	LeaderHost   string                                                       `protobuf:"bytes,3,opt,name=leader_host,json=leaderHost,proto3" json:"leader_host,omitempty"`
+	LeaderDiskID github_com_cubefs_cubefs_blobstore_common_proto.DiskID       `protobuf:"varint,4,opt,name=leader_disk_id,json=leaderDiskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"leader_disk_id,omitempty"`
+	LeaderSuid   github_com_cubefs_cubefs_blobstore_common_proto.Suid         `protobuf:"varint,5,opt,name=leader_suid,json=leaderSuid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"leader_suid,omitempty"`
+	Learner      bool                                                         `protobuf:"varint,6,opt,name=learner,proto3" json:"learner,omitempty"`
+	RouteVersion github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion `protobuf:"varint,7,opt,name=route_version,json=routeVersion,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.RouteVersion" json:"route_version,omitempty"`
+	Range        sharding.Range                                               `protobuf:"bytes,8,opt,name=range,proto3" json:"range"`
+	Units        []clustermgr.ShardUnit                                       `protobuf:"bytes,9,rep,name=units,proto3" json:"units"`
+	RaftStat     raft.Stat                                                    `protobuf:"bytes,10,opt,name=raftStat,proto3" json:"raftStat"`
+	// raft term of the leader elected in
+	LeaderTerm           uint64   `protobuf:"varint,11,opt,name=leader_term,json=leaderTerm,proto3" json:"leader_term,omitempty"`
+	Readonly             bool     `protobuf:"varint,12,opt,name=readonly,proto3" json:"readonly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.RouteVersion != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.RouteVersion))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.Units) > 0 {
-		for iNdEx := len(m.Units) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Units[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintShardnode(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	{
-		size, err := m.Range.MarshalToSizedBuffer(dAtA[:i])
+func (m *ShardStats) Reset()         { *m = ShardStats{} }
+func (m *ShardStats) String() string { return proto.CompactTextString(m) }
+func (*ShardStats) ProtoMessage()    {}
+func (*ShardStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{57}
+}
+func (m *ShardStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0x1a
-	if m.Suid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
-		i--
-		dAtA[i] = 0x10
+		return b[:n], nil
 	}
-	if m.DiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
-		i--
-		dAtA[i] = 0x8
+}
+func (m *ShardStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardStats.Merge(m, src)
+}
+func (m *ShardStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardStats proto.InternalMessageInfo
+
+func (m *ShardStats) GetSuid() github_com_cubefs_cubefs_blobstore_common_proto.Suid {
+	if m != nil {
+		return m.Suid
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *AddShardRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ShardStats) GetAppliedIndex() uint64 {
+	if m != nil {
+		return m.AppliedIndex
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *AddShardRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ShardStats) GetLeaderHost() string {
+	if m != nil {
+		return m.LeaderHost
+	}
+	return ""
 }
 
-func (m *AddShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ShardStats) GetLeaderDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
+	if m != nil {
+		return m.LeaderDiskID
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *UpdateShardArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ShardStats) GetLeaderSuid() github_com_cubefs_cubefs_blobstore_common_proto.Suid {
+	if m != nil {
+		return m.LeaderSuid
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *UpdateShardArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ShardStats) GetLearner() bool {
+	if m != nil {
+		return m.Learner
+	}
+	return false
 }
 
-func (m *UpdateShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	{
-		size, err := m.Unit.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0x22
-	if m.ShardUpdateType != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.ShardUpdateType))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Suid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
-		i--
-		dAtA[i] = 0x10
+func (m *ShardStats) GetRouteVersion() github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion {
+	if m != nil {
+		return m.RouteVersion
 	}
-	if m.DiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
-		i--
-		dAtA[i] = 0x8
+	return 0
+}
+
+func (m *ShardStats) GetRange() sharding.Range {
+	if m != nil {
+		return m.Range
 	}
-	return len(dAtA) - i, nil
+	return sharding.Range{}
 }
 
-func (m *UpdateShardRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ShardStats) GetUnits() []clustermgr.ShardUnit {
+	if m != nil {
+		return m.Units
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *UpdateShardRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ShardStats) GetRaftStat() raft.Stat {
+	if m != nil {
+		return m.RaftStat
+	}
+	return raft.Stat{}
 }
 
-func (m *UpdateShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ShardStats) GetLeaderTerm() uint64 {
+	if m != nil {
+		return m.LeaderTerm
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *TransferShardLeaderArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ShardStats) GetReadonly() bool {
+	if m != nil {
+		return m.Readonly
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *TransferShardLeaderArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ListVolumeArgs struct {
+	CodeMode             github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,1,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"codemode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                                    `json:"-"`
+	XXX_unrecognized     []byte                                                      `json:"-"`
+	XXX_sizecache        int32                                                       `json:"-"`
 }
 
-func (m *TransferShardLeaderArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.DestDiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DestDiskID))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Suid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.DiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
-		i--
-		dAtA[i] = 0x8
+func (m *ListVolumeArgs) Reset()         { *m = ListVolumeArgs{} }
+func (m *ListVolumeArgs) String() string { return proto.CompactTextString(m) }
+func (*ListVolumeArgs) ProtoMessage()    {}
+func (*ListVolumeArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{58}
+}
+func (m *ListVolumeArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListVolumeArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListVolumeArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ListVolumeArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumeArgs.Merge(m, src)
+}
+func (m *ListVolumeArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListVolumeArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumeArgs.DiscardUnknown(m)
 }
 
-func (m *TransferShardLeaderRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ListVolumeArgs proto.InternalMessageInfo
+
+func (m *ListVolumeArgs) GetCodeMode() github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode {
+	if m != nil {
+		return m.CodeMode
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *TransferShardLeaderRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ListVolumeRet struct {
+	Vids                 []github_com_cubefs_cubefs_blobstore_common_proto.Vid `protobuf:"varint,1,rep,packed,name=vids,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Vid" json:"vids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                              `json:"-"`
+	XXX_unrecognized     []byte                                                `json:"-"`
+	XXX_sizecache        int32                                                 `json:"-"`
 }
 
-func (m *TransferShardLeaderRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ListVolumeRet) Reset()         { *m = ListVolumeRet{} }
+func (m *ListVolumeRet) String() string { return proto.CompactTextString(m) }
+func (*ListVolumeRet) ProtoMessage()    {}
+func (*ListVolumeRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{59}
+}
+func (m *ListVolumeRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListVolumeRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListVolumeRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ListVolumeRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumeRet.Merge(m, src)
+}
+func (m *ListVolumeRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListVolumeRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumeRet.DiscardUnknown(m)
 }
 
-func (m *GetShardArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ListVolumeRet proto.InternalMessageInfo
+
+func (m *ListVolumeRet) GetVids() []github_com_cubefs_cubefs_blobstore_common_proto.Vid {
+	if m != nil {
+		return m.Vids
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetShardArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ListShardArgs struct {
+	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID  `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
+	ShardID              github_com_cubefs_cubefs_blobstore_common_proto.ShardID `protobuf:"varint,2,opt,name=shard_id,json=shardId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.ShardID" json:"shard_id,omitempty"`
+	Count                uint64                                                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                                `json:"-"`
+	XXX_unrecognized     []byte                                                  `json:"-"`
+	XXX_sizecache        int32                                                   `json:"-"`
 }
 
-func (m *GetShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ListShardArgs) Reset()         { *m = ListShardArgs{} }
+func (m *ListShardArgs) String() string { return proto.CompactTextString(m) }
+func (*ListShardArgs) ProtoMessage()    {}
+func (*ListShardArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{60}
+}
+func (m *ListShardArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListShardArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListShardArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Suid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
-		i--
-		dAtA[i] = 0x10
+}
+func (m *ListShardArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListShardArgs.Merge(m, src)
+}
+func (m *ListShardArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListShardArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListShardArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListShardArgs proto.InternalMessageInfo
+
+func (m *ListShardArgs) GetDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
+	if m != nil {
+		return m.DiskID
 	}
-	if m.DiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
-		i--
-		dAtA[i] = 0x8
+	return 0
+}
+
+func (m *ListShardArgs) GetShardID() github_com_cubefs_cubefs_blobstore_common_proto.ShardID {
+	if m != nil {
+		return m.ShardID
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *GetShardRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ListShardArgs) GetCount() uint64 {
+	if m != nil {
+		return m.Count
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GetShardRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ListShardBaseInfo struct {
+	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID  `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
+	ShardID              github_com_cubefs_cubefs_blobstore_common_proto.ShardID `protobuf:"varint,2,opt,name=shard_id,json=shardId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.ShardID" json:"shard_id,omitempty"`
+	Suid                 github_com_cubefs_cubefs_blobstore_common_proto.Suid    `protobuf:"varint,3,opt,name=suid,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.Suid" json:"suid,omitempty"`
+	Index                uint32                                                  `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	Epoch                uint32                                                  `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Units                []clustermgr.ShardUnit                                  `protobuf:"bytes,6,rep,name=units,proto3" json:"units"`
+	XXX_NoUnkeyedLiteral struct{}                                                `json:"-"`
+	XXX_unrecognized     []byte                                                  `json:"-"`
+	XXX_sizecache        int32                                                   `json:"-"`
 }
 
-func (m *GetShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	{
-		size, err := m.Shard.MarshalToSizedBuffer(dAtA[:i])
+func (m *ListShardBaseInfo) Reset()         { *m = ListShardBaseInfo{} }
+func (m *ListShardBaseInfo) String() string { return proto.CompactTextString(m) }
+func (*ListShardBaseInfo) ProtoMessage()    {}
+func (*ListShardBaseInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{61}
+}
+func (m *ListShardBaseInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListShardBaseInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListShardBaseInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *CreateBlobArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ListShardBaseInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListShardBaseInfo.Merge(m, src)
 }
-
-func (m *CreateBlobArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ListShardBaseInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListShardBaseInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListShardBaseInfo.DiscardUnknown(m)
 }
 
-func (m *CreateBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.SliceSize != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.SliceSize))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Size_ != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Size_))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.CodeMode != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.CodeMode))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+var xxx_messageInfo_ListShardBaseInfo proto.InternalMessageInfo
+
+func (m *ListShardBaseInfo) GetDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
+	if m != nil {
+		return m.DiskID
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *CreateBlobRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ListShardBaseInfo) GetShardID() github_com_cubefs_cubefs_blobstore_common_proto.ShardID {
+	if m != nil {
+		return m.ShardID
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *CreateBlobRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ListShardBaseInfo) GetSuid() github_com_cubefs_cubefs_blobstore_common_proto.Suid {
+	if m != nil {
+		return m.Suid
+	}
+	return 0
 }
 
-func (m *CreateBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ListShardBaseInfo) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
 	}
-	{
-		size, err := m.Blob.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	return 0
+}
+
+func (m *ListShardBaseInfo) GetEpoch() uint32 {
+	if m != nil {
+		return m.Epoch
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *GetBlobArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ListShardBaseInfo) GetUnits() []clustermgr.ShardUnit {
+	if m != nil {
+		return m.Units
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetBlobArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ListShardRet struct {
+	Shards               []ListShardBaseInfo `protobuf:"bytes,1,rep,name=shards,proto3" json:"shards"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *GetBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+func (m *ListShardRet) Reset()         { *m = ListShardRet{} }
+func (m *ListShardRet) String() string { return proto.CompactTextString(m) }
+func (*ListShardRet) ProtoMessage()    {}
+func (*ListShardRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{62}
+}
+func (m *ListShardRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListShardRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListShardRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *ListShardRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListShardRet.Merge(m, src)
+}
+func (m *ListShardRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListShardRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListShardRet.DiscardUnknown(m)
 }
 
-func (m *GetBlobRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ListShardRet proto.InternalMessageInfo
+
+func (m *ListShardRet) GetShards() []ListShardBaseInfo {
+	if m != nil {
+		return m.Shards
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetBlobRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type TCMallocArgs struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	{
-		size, err := m.Blob.MarshalToSizedBuffer(dAtA[:i])
+func (m *TCMallocArgs) Reset()         { *m = TCMallocArgs{} }
+func (m *TCMallocArgs) String() string { return proto.CompactTextString(m) }
+func (*TCMallocArgs) ProtoMessage()    {}
+func (*TCMallocArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{63}
+}
+func (m *TCMallocArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TCMallocArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TCMallocArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *ListBlobArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *TCMallocArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TCMallocArgs.Merge(m, src)
+}
+func (m *TCMallocArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *TCMallocArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_TCMallocArgs.DiscardUnknown(m)
 }
 
-func (m *ListBlobArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_TCMallocArgs proto.InternalMessageInfo
+
+type TCMallocRet struct {
+	Stats                string   `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Count != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Count))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.Marker) > 0 {
-		i -= len(m.Marker)
-		copy(dAtA[i:], m.Marker)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Marker)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Prefix) > 0 {
-		i -= len(m.Prefix)
-		copy(dAtA[i:], m.Prefix)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Prefix)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+func (m *TCMallocRet) Reset()         { *m = TCMallocRet{} }
+func (m *TCMallocRet) String() string { return proto.CompactTextString(m) }
+func (*TCMallocRet) ProtoMessage()    {}
+func (*TCMallocRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{64}
+}
+func (m *TCMallocRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TCMallocRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TCMallocRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *TCMallocRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TCMallocRet.Merge(m, src)
+}
+func (m *TCMallocRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *TCMallocRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_TCMallocRet.DiscardUnknown(m)
 }
 
-func (m *ListBlobRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_TCMallocRet proto.InternalMessageInfo
+
+func (m *TCMallocRet) GetStats() string {
+	if m != nil {
+		return m.Stats
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ListBlobRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type DBStatsArgs struct {
+	DiskID               github_com_cubefs_cubefs_blobstore_common_proto.DiskID `protobuf:"varint,1,opt,name=disk_id,json=diskId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.DiskID" json:"disk_id,omitempty"`
+	DBName               string                                                 `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                               `json:"-"`
+	XXX_unrecognized     []byte                                                 `json:"-"`
+	XXX_sizecache        int32                                                  `json:"-"`
 }
 
-func (m *ListBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.NextMarker) > 0 {
-		i -= len(m.NextMarker)
-		copy(dAtA[i:], m.NextMarker)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.NextMarker)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Blobs) > 0 {
-		for iNdEx := len(m.Blobs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Blobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintShardnode(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *DBStatsArgs) Reset()         { *m = DBStatsArgs{} }
+func (m *DBStatsArgs) String() string { return proto.CompactTextString(m) }
+func (*DBStatsArgs) ProtoMessage()    {}
+func (*DBStatsArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{65}
+}
+func (m *DBStatsArgs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DBStatsArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DBStatsArgs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DBStatsArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBStatsArgs.Merge(m, src)
+}
+func (m *DBStatsArgs) XXX_Size() int {
+	return m.Size()
+}
+func (m *DBStatsArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBStatsArgs.DiscardUnknown(m)
 }
 
-func (m *DeleteBlobArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DBStatsArgs proto.InternalMessageInfo
+
+func (m *DBStatsArgs) GetDiskID() github_com_cubefs_cubefs_blobstore_common_proto.DiskID {
+	if m != nil {
+		return m.DiskID
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *DeleteBlobArgs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DBStatsArgs) GetDBName() string {
+	if m != nil {
+		return m.DBName
+	}
+	return ""
 }
 
-func (m *DeleteBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+type DBStatsRet struct {
+	Used                 uint64   `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	BlobCacheUsage       uint64   `protobuf:"varint,2,opt,name=blob_cache_usage,json=blobCacheUsage,proto3" json:"blob_cache_usage,omitempty"`
+	IndexAndFilterUsage  uint64   `protobuf:"varint,3,opt,name=index_and_filter_usage,json=indexAndFilterUsage,proto3" json:"index_and_filter_usage,omitempty"`
+	MemtableUsage        uint64   `protobuf:"varint,4,opt,name=memtable_usage,json=memtableUsage,proto3" json:"memtable_usage,omitempty"`
+	BlockPinnedUsage     uint64   `protobuf:"varint,5,opt,name=block_pinned_usage,json=blockPinnedUsage,proto3" json:"block_pinned_usage,omitempty"`
+	TotalMemoryUsage     uint64   `protobuf:"varint,6,opt,name=total_memory_usage,json=totalMemoryUsage,proto3" json:"total_memory_usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DBStatsRet) Reset()         { *m = DBStatsRet{} }
+func (m *DBStatsRet) String() string { return proto.CompactTextString(m) }
+func (*DBStatsRet) ProtoMessage()    {}
+func (*DBStatsRet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9d3815ca0e5f30f0, []int{66}
+}
+func (m *DBStatsRet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DBStatsRet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DBStatsRet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *DBStatsRet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBStatsRet.Merge(m, src)
+}
+func (m *DBStatsRet) XXX_Size() int {
+	return m.Size()
+}
+func (m *DBStatsRet) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBStatsRet.DiscardUnknown(m)
 }
 
-func (m *DeleteBlobRet) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DBStatsRet proto.InternalMessageInfo
+
+func (m *DBStatsRet) GetUsed() uint64 {
+	if m != nil {
+		return m.Used
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *DeleteBlobRet) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DBStatsRet) GetBlobCacheUsage() uint64 {
+	if m != nil {
+		return m.BlobCacheUsage
+	}
+	return 0
 }
 
-func (m *DeleteBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *DBStatsRet) GetIndexAndFilterUsage() uint64 {
+	if m != nil {
+		return m.IndexAndFilterUsage
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *RetainBlobArgs) Marshal() (dAtA []byte, err error) {
+func (m *DBStatsRet) GetMemtableUsage() uint64 {
+	if m != nil {
+		return m.MemtableUsage
+	}
+	return 0
+}
+
+func (m *DBStatsRet) GetBlockPinnedUsage() uint64 {
+	if m != nil {
+		return m.BlockPinnedUsage
+	}
+	return 0
+}
+
+func (m *DBStatsRet) GetTotalMemoryUsage() uint64 {
+	if m != nil {
+		return m.TotalMemoryUsage
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("cubefs.blobstore.api.shardnode.TxnOpType", TxnOpType_name, TxnOpType_value)
+	proto.RegisterEnum("cubefs.blobstore.api.shardnode.TxnCondType", TxnCondType_name, TxnCondType_value)
+	proto.RegisterEnum("cubefs.blobstore.api.shardnode.FilterOp", FilterOp_name, FilterOp_value)
+	proto.RegisterType((*Item)(nil), "cubefs.blobstore.api.shardnode.Item")
+	proto.RegisterType((*Field)(nil), "cubefs.blobstore.api.shardnode.Field")
+	proto.RegisterType((*ShardOpHeader)(nil), "cubefs.blobstore.api.shardnode.ShardOpHeader")
+	proto.RegisterType((*InsertItemArgs)(nil), "cubefs.blobstore.api.shardnode.InsertItemArgs")
+	proto.RegisterType((*InsertItemRet)(nil), "cubefs.blobstore.api.shardnode.InsertItemRet")
+	proto.RegisterType((*UpdateItemArgs)(nil), "cubefs.blobstore.api.shardnode.UpdateItemArgs")
+	proto.RegisterType((*UpdateItemRet)(nil), "cubefs.blobstore.api.shardnode.UpdateItemRet")
+	proto.RegisterType((*DeleteItemArgs)(nil), "cubefs.blobstore.api.shardnode.DeleteItemArgs")
+	proto.RegisterType((*DeleteItemRet)(nil), "cubefs.blobstore.api.shardnode.DeleteItemRet")
+	proto.RegisterType((*GetItemArgs)(nil), "cubefs.blobstore.api.shardnode.GetItemArgs")
+	proto.RegisterType((*GetItemRet)(nil), "cubefs.blobstore.api.shardnode.GetItemRet")
+	proto.RegisterType((*ListItemArgs)(nil), "cubefs.blobstore.api.shardnode.ListItemArgs")
+	proto.RegisterType((*ListItemRet)(nil), "cubefs.blobstore.api.shardnode.ListItemRet")
+	proto.RegisterType((*AddShardArgs)(nil), "cubefs.blobstore.api.shardnode.AddShardArgs")
+	proto.RegisterType((*AddShardRet)(nil), "cubefs.blobstore.api.shardnode.AddShardRet")
+	proto.RegisterType((*UpdateShardArgs)(nil), "cubefs.blobstore.api.shardnode.UpdateShardArgs")
+	proto.RegisterType((*UpdateShardRet)(nil), "cubefs.blobstore.api.shardnode.UpdateShardRet")
+	proto.RegisterType((*TransferShardLeaderArgs)(nil), "cubefs.blobstore.api.shardnode.TransferShardLeaderArgs")
+	proto.RegisterType((*TransferShardLeaderRet)(nil), "cubefs.blobstore.api.shardnode.TransferShardLeaderRet")
+	proto.RegisterType((*SetShardReadonlyArgs)(nil), "cubefs.blobstore.api.shardnode.SetShardReadonlyArgs")
+	proto.RegisterType((*GetShardArgs)(nil), "cubefs.blobstore.api.shardnode.GetShardArgs")
+	proto.RegisterType((*GetShardRet)(nil), "cubefs.blobstore.api.shardnode.GetShardRet")
+	proto.RegisterType((*CreateBlobArgs)(nil), "cubefs.blobstore.api.shardnode.CreateBlobArgs")
+	proto.RegisterType((*CreateBlobRet)(nil), "cubefs.blobstore.api.shardnode.CreateBlobRet")
+	proto.RegisterType((*GetBlobArgs)(nil), "cubefs.blobstore.api.shardnode.GetBlobArgs")
+	proto.RegisterType((*GetBlobRet)(nil), "cubefs.blobstore.api.shardnode.GetBlobRet")
+	proto.RegisterType((*ListBlobArgs)(nil), "cubefs.blobstore.api.shardnode.ListBlobArgs")
+	proto.RegisterType((*ListBlobRet)(nil), "cubefs.blobstore.api.shardnode.ListBlobRet")
+	proto.RegisterType((*DeleteBlobArgs)(nil), "cubefs.blobstore.api.shardnode.DeleteBlobArgs")
+	proto.RegisterType((*DeleteBlobRet)(nil), "cubefs.blobstore.api.shardnode.DeleteBlobRet")
+	proto.RegisterType((*TrashBlob)(nil), "cubefs.blobstore.api.shardnode.TrashBlob")
+	proto.RegisterType((*TrashBlobArgs)(nil), "cubefs.blobstore.api.shardnode.TrashBlobArgs")
+	proto.RegisterType((*TrashBlobRet)(nil), "cubefs.blobstore.api.shardnode.TrashBlobRet")
+	proto.RegisterType((*UndeleteBlobArgs)(nil), "cubefs.blobstore.api.shardnode.UndeleteBlobArgs")
+	proto.RegisterType((*UndeleteBlobRet)(nil), "cubefs.blobstore.api.shardnode.UndeleteBlobRet")
+	proto.RegisterType((*ListTrashBlobArgs)(nil), "cubefs.blobstore.api.shardnode.ListTrashBlobArgs")
+	proto.RegisterType((*ListTrashBlobRet)(nil), "cubefs.blobstore.api.shardnode.ListTrashBlobRet")
+	proto.RegisterType((*PurgeTrashBlobArgs)(nil), "cubefs.blobstore.api.shardnode.PurgeTrashBlobArgs")
+	proto.RegisterType((*PurgeTrashBlobRet)(nil), "cubefs.blobstore.api.shardnode.PurgeTrashBlobRet")
+	proto.RegisterType((*DedupRef)(nil), "cubefs.blobstore.api.shardnode.DedupRef")
+	proto.RegisterType((*RefDedupArgs)(nil), "cubefs.blobstore.api.shardnode.RefDedupArgs")
+	proto.RegisterType((*RefDedupRet)(nil), "cubefs.blobstore.api.shardnode.RefDedupRet")
+	proto.RegisterType((*GetDedupArgs)(nil), "cubefs.blobstore.api.shardnode.GetDedupArgs")
+	proto.RegisterType((*GetDedupRet)(nil), "cubefs.blobstore.api.shardnode.GetDedupRet")
+	proto.RegisterType((*UnrefDedupArgs)(nil), "cubefs.blobstore.api.shardnode.UnrefDedupArgs")
+	proto.RegisterType((*UnrefDedupRet)(nil), "cubefs.blobstore.api.shardnode.UnrefDedupRet")
+	proto.RegisterType((*FieldFilter)(nil), "cubefs.blobstore.api.shardnode.FieldFilter")
+	proto.RegisterType((*TxnCond)(nil), "cubefs.blobstore.api.shardnode.TxnCond")
+	proto.RegisterType((*TxnOp)(nil), "cubefs.blobstore.api.shardnode.TxnOp")
+	proto.RegisterType((*CommitTxnArgs)(nil), "cubefs.blobstore.api.shardnode.CommitTxnArgs")
+	proto.RegisterType((*CommitTxnRet)(nil), "cubefs.blobstore.api.shardnode.CommitTxnRet")
+	proto.RegisterType((*RetainBlobArgs)(nil), "cubefs.blobstore.api.shardnode.RetainBlobArgs")
+	proto.RegisterType((*RetainBlobRet)(nil), "cubefs.blobstore.api.shardnode.RetainBlobRet")
+	proto.RegisterType((*SealBlobArgs)(nil), "cubefs.blobstore.api.shardnode.SealBlobArgs")
+	proto.RegisterType((*SealBlobRet)(nil), "cubefs.blobstore.api.shardnode.SealBlobRet")
+	proto.RegisterType((*AllocSliceArgs)(nil), "cubefs.blobstore.api.shardnode.AllocSliceArgs")
+	proto.RegisterType((*AllocSliceRet)(nil), "cubefs.blobstore.api.shardnode.AllocSliceRet")
+	proto.RegisterType((*ShardStats)(nil), "cubefs.blobstore.api.shardnode.ShardStats")
+	proto.RegisterType((*ListVolumeArgs)(nil), "cubefs.blobstore.api.shardnode.ListVolumeArgs")
+	proto.RegisterType((*ListVolumeRet)(nil), "cubefs.blobstore.api.shardnode.ListVolumeRet")
+	proto.RegisterType((*ListShardArgs)(nil), "cubefs.blobstore.api.shardnode.ListShardArgs")
+	proto.RegisterType((*ListShardBaseInfo)(nil), "cubefs.blobstore.api.shardnode.ListShardBaseInfo")
+	proto.RegisterType((*ListShardRet)(nil), "cubefs.blobstore.api.shardnode.ListShardRet")
+	proto.RegisterType((*TCMallocArgs)(nil), "cubefs.blobstore.api.shardnode.TCMallocArgs")
+	proto.RegisterType((*TCMallocRet)(nil), "cubefs.blobstore.api.shardnode.TCMallocRet")
+	proto.RegisterType((*DBStatsArgs)(nil), "cubefs.blobstore.api.shardnode.DBStatsArgs")
+	proto.RegisterType((*DBStatsRet)(nil), "cubefs.blobstore.api.shardnode.DBStatsRet")
+}
+
+func init() { proto.RegisterFile("shardnode.proto", fileDescriptor_9d3815ca0e5f30f0) }
+
+var fileDescriptor_9d3815ca0e5f30f0 = []byte{
+	// 2554 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x1a, 0x4d, 0x6f, 0x23, 0x49,
+	0x75, 0xbb, 0xdd, 0xfe, 0xc8, 0xf3, 0x47, 0x3c, 0x3d, 0x61, 0x30, 0x41, 0xc4, 0x51, 0xcf, 0xae,
+	0x36, 0x3b, 0xbb, 0x38, 0x62, 0x06, 0x58, 0xd0, 0xb2, 0xcc, 0xc4, 0xc9, 0x7c, 0x64, 0xe7, 0x23,
+	0x43, 0x27, 0x13, 0x09, 0x24, 0x64, 0x75, 0xdc, 0xe5, 0xa4, 0x49, 0xbb, 0xbb, 0xb7, 0xbb, 0x3d,
+	0x9b, 0x20, 0x21, 0x21, 0x10, 0x0b, 0x07, 0xc4, 0x0a, 0x89, 0x1b, 0x42, 0x1c, 0xe0, 0x3f, 0xac,
+	0x84, 0x84, 0x84, 0xb4, 0x07, 0xf6, 0xc0, 0x81, 0x1f, 0x80, 0x2c, 0x94, 0x0b, 0x17, 0xc4, 0x9d,
+	0x9c, 0xd0, 0x7b, 0x55, 0xd5, 0x6e, 0x7b, 0x92, 0xc9, 0x24, 0x71, 0x2c, 0x06, 0x2e, 0x49, 0xd7,
+	0xf3, 0xfb, 0x7e, 0x55, 0xaf, 0x5e, 0xbd, 0x2a, 0x98, 0x8e, 0x76, 0xac, 0xd0, 0xf6, 0x7c, 0x9b,
+	0x35, 0x82, 0xd0, 0x8f, 0x7d, 0x7d, 0xae, 0xdd, 0xdb, 0x62, 0x9d, 0xa8, 0xb1, 0xe5, 0xfa, 0x5b,
+	0x51, 0xec, 0x87, 0xac, 0x61, 0x05, 0x4e, 0x23, 0xc1, 0x9a, 0x9d, 0xd9, 0xf6, 0xb7, 0x7d, 0x42,
+	0x5d, 0xc4, 0x2f, 0x4e, 0x35, 0xfb, 0x16, 0xa7, 0x5a, 0x4c, 0xa8, 0x16, 0xdb, 0x7e, 0xb7, 0xeb,
+	0x7b, 0x8b, 0x44, 0xe8, 0x78, 0xdb, 0x8b, 0xa1, 0xe5, 0x6d, 0x0b, 0x19, 0xb3, 0x6f, 0x3e, 0x83,
+	0x6d, 0x05, 0xce, 0x62, 0xdb, 0xed, 0x45, 0x31, 0x0b, 0xbb, 0xdb, 0x21, 0xa7, 0x12, 0xc8, 0x0b,
+	0xc7, 0xb1, 0xe6, 0x4a, 0x20, 0x58, 0x60, 0xbe, 0x7e, 0x1c, 0x66, 0x68, 0x75, 0x62, 0xfa, 0xc3,
+	0x11, 0x8d, 0x1f, 0x80, 0xb6, 0x1a, 0xb3, 0xae, 0x7e, 0x05, 0x54, 0xc7, 0xae, 0x29, 0xf3, 0xca,
+	0x42, 0xa9, 0x99, 0x3b, 0xe8, 0xd7, 0xd5, 0xd5, 0x15, 0x53, 0x75, 0x6c, 0x7d, 0x19, 0x72, 0x1d,
+	0x87, 0xb9, 0x76, 0x54, 0x53, 0xe7, 0x33, 0x0b, 0xc5, 0xeb, 0xaf, 0x35, 0x9e, 0xef, 0x94, 0xc6,
+	0x1d, 0xc4, 0x6e, 0x6a, 0x9f, 0xf6, 0xeb, 0xaf, 0x98, 0x82, 0x54, 0xaf, 0x41, 0xfe, 0x29, 0x0b,
+	0x23, 0xc7, 0xf7, 0x6a, 0x99, 0x79, 0x65, 0x41, 0x33, 0xe5, 0xd0, 0x08, 0x20, 0x4b, 0x04, 0xfa,
+	0xb7, 0x12, 0xf9, 0xe5, 0xe6, 0x12, 0x97, 0x7f, 0xd8, 0xaf, 0xbf, 0xbd, 0xed, 0xc4, 0x3b, 0xbd,
+	0xad, 0x46, 0xdb, 0xef, 0x2e, 0x0a, 0x8b, 0x9e, 0xeb, 0x02, 0x2e, 0x5d, 0xa8, 0x3e, 0x03, 0xd9,
+	0xa7, 0x96, 0xdb, 0x63, 0x35, 0x15, 0xad, 0x32, 0xf9, 0xc0, 0xf8, 0x50, 0x83, 0xf2, 0x3a, 0x6a,
+	0xbb, 0x16, 0xdc, 0x63, 0x96, 0xcd, 0x42, 0xdd, 0x82, 0x42, 0x14, 0x58, 0x6d, 0xd6, 0x12, 0x0a,
+	0x68, 0xcd, 0x3b, 0x07, 0xfd, 0x7a, 0x7e, 0x1d, 0x61, 0x67, 0xd3, 0x42, 0x90, 0x9a, 0x79, 0xe2,
+	0xbb, 0x6a, 0xeb, 0xdf, 0x85, 0xbc, 0xed, 0x44, 0xbb, 0x28, 0x41, 0x25, 0x13, 0x57, 0x0e, 0xfa,
+	0xf5, 0xdc, 0x8a, 0x13, 0xed, 0x92, 0x80, 0xaf, 0x9e, 0x56, 0x00, 0xa7, 0x34, 0x73, 0xc8, 0x74,
+	0xd5, 0xd6, 0x37, 0x40, 0x8b, 0x7a, 0x8e, 0x4d, 0xce, 0x2d, 0x37, 0x6f, 0x1d, 0xf4, 0xeb, 0xda,
+	0x7a, 0xcf, 0xb1, 0x0f, 0xfb, 0xf5, 0x2f, 0x9f, 0x5a, 0xf5, 0x9e, 0x63, 0x9b, 0xc4, 0x4d, 0x37,
+	0xa0, 0x44, 0xfa, 0x6f, 0x8a, 0xd0, 0x69, 0x14, 0xba, 0x21, 0x98, 0xce, 0xa0, 0x1c, 0xfa, 0xbd,
+	0x98, 0xb5, 0x64, 0x7c, 0xb3, 0xe4, 0xc0, 0x5b, 0x87, 0xfd, 0xfa, 0x37, 0x4e, 0x2b, 0xda, 0x44,
+	0x46, 0x82, 0xb1, 0x59, 0x0a, 0x53, 0x23, 0xfd, 0x0b, 0x00, 0x34, 0xc3, 0x5a, 0xbb, 0x6c, 0x3f,
+	0xaa, 0xe5, 0xe6, 0x33, 0x0b, 0x25, 0x73, 0x8a, 0x20, 0xf7, 0xd9, 0x7e, 0xa4, 0xeb, 0xa0, 0x45,
+	0xfb, 0x5e, 0xbb, 0x96, 0x9f, 0x57, 0x16, 0x0a, 0x26, 0x7d, 0xeb, 0x75, 0x28, 0xba, 0x14, 0xdf,
+	0x16, 0x2e, 0xa4, 0x5a, 0x81, 0x94, 0x07, 0x0e, 0xda, 0x60, 0x61, 0xd7, 0xf8, 0x8d, 0x02, 0x95,
+	0x55, 0x2f, 0x62, 0x61, 0x8c, 0x0b, 0x60, 0x29, 0xdc, 0x8e, 0xf4, 0xfb, 0x90, 0xdb, 0x21, 0x04,
+	0x9a, 0x07, 0xc5, 0xeb, 0x5f, 0x3c, 0x69, 0xb2, 0x0f, 0x4d, 0x24, 0x39, 0xe9, 0x39, 0x0b, 0xfd,
+	0x9b, 0xa0, 0x39, 0x31, 0xeb, 0x52, 0xc0, 0x8b, 0xd7, 0x5f, 0x3d, 0x89, 0x15, 0x2a, 0x21, 0x38,
+	0x10, 0x9d, 0x31, 0x0d, 0xe5, 0x81, 0x7a, 0x26, 0x8b, 0x49, 0xe1, 0x27, 0x81, 0x6d, 0xc5, 0xec,
+	0xbf, 0x56, 0xe1, 0x81, 0x7a, 0xa8, 0x70, 0x0f, 0x2a, 0x2b, 0xcc, 0x65, 0x17, 0xa5, 0x2f, 0x4f,
+	0x59, 0xea, 0x68, 0xca, 0x42, 0x3d, 0x06, 0x62, 0x51, 0x8f, 0x9f, 0x2b, 0x50, 0xbc, 0xcb, 0xe2,
+	0x89, 0x6a, 0xf1, 0x9c, 0x9c, 0xf7, 0x00, 0x40, 0x68, 0x63, 0xb2, 0x38, 0xf1, 0xba, 0x72, 0x46,
+	0xaf, 0xff, 0x4b, 0x81, 0xd2, 0x03, 0x27, 0xba, 0x30, 0xeb, 0x72, 0x41, 0xc8, 0x3a, 0xce, 0x9e,
+	0x48, 0xa2, 0x62, 0x84, 0xf0, 0xae, 0x15, 0xee, 0xb2, 0x90, 0x8c, 0x2b, 0x99, 0x62, 0x84, 0x39,
+	0xb7, 0xed, 0xf7, 0xbc, 0x58, 0x24, 0x0b, 0x3e, 0xd0, 0xef, 0x43, 0xbe, 0xe3, 0xb8, 0x31, 0x0b,
+	0xa3, 0x5a, 0x96, 0x76, 0x91, 0x37, 0x5f, 0x68, 0x17, 0xb9, 0x43, 0x34, 0x42, 0x23, 0xc9, 0xc1,
+	0xf0, 0xa1, 0x28, 0xed, 0x45, 0xff, 0xdd, 0x82, 0x2c, 0xfa, 0x21, 0xaa, 0x29, 0xf3, 0x99, 0x53,
+	0x3a, 0x90, 0x13, 0xea, 0x73, 0x00, 0x1e, 0xdb, 0x8b, 0x1f, 0x72, 0x7b, 0xb8, 0x9d, 0x29, 0x88,
+	0xf1, 0x71, 0x06, 0x4a, 0x4b, 0xb6, 0x4d, 0x6e, 0x22, 0x0f, 0xa7, 0xb2, 0xb9, 0x72, 0x81, 0xd9,
+	0x5c, 0xe5, 0xa9, 0x74, 0x4c, 0xd9, 0x7c, 0x19, 0xb2, 0x54, 0x77, 0x50, 0xc0, 0x8a, 0xd7, 0x5f,
+	0x7f, 0xd6, 0x4f, 0x9c, 0xb2, 0x21, 0xcb, 0x94, 0x86, 0x89, 0xe8, 0xd2, 0x55, 0x44, 0xab, 0xdf,
+	0x81, 0x6c, 0xcf, 0x73, 0xe2, 0xa8, 0xa6, 0x91, 0xb3, 0xaf, 0x1d, 0xed, 0xec, 0x41, 0xf5, 0xc2,
+	0xe7, 0xd6, 0x13, 0xcf, 0x89, 0x25, 0x1f, 0x22, 0x9f, 0xd0, 0xb6, 0x61, 0x94, 0xa1, 0x28, 0x03,
+	0x87, 0x79, 0xe0, 0xa3, 0x0c, 0x4c, 0xf3, 0x0c, 0xf5, 0x92, 0xc7, 0xf2, 0x87, 0x0a, 0x4c, 0x73,
+	0xcf, 0x92, 0x35, 0x1b, 0xfb, 0x01, 0x13, 0x7b, 0xff, 0xe6, 0x41, 0xbf, 0x3e, 0xfa, 0xd3, 0x61,
+	0xbf, 0x7e, 0xf3, 0xd4, 0xc2, 0x86, 0x59, 0x98, 0xa3, 0x3c, 0xf5, 0x15, 0xd0, 0x30, 0x94, 0xb4,
+	0xce, 0xcf, 0x32, 0x11, 0x88, 0xda, 0xa8, 0xca, 0x1d, 0x2d, 0x89, 0xd1, 0x1f, 0x54, 0xf8, 0xec,
+	0x46, 0x68, 0x79, 0x51, 0x87, 0x85, 0x04, 0x7c, 0x40, 0x89, 0xe8, 0xe5, 0x8d, 0xd5, 0xf7, 0xa0,
+	0x64, 0xb3, 0x28, 0x6e, 0x49, 0xcd, 0x79, 0x9c, 0xee, 0x1d, 0xf4, 0xeb, 0xb0, 0xc2, 0xa2, 0xf8,
+	0xdc, 0xda, 0x83, 0x2d, 0xb9, 0xd8, 0x46, 0x0d, 0xae, 0x1c, 0xe1, 0x3b, 0x74, 0xeb, 0x3f, 0x14,
+	0x98, 0x59, 0x67, 0xb1, 0x70, 0xb3, 0x65, 0xfb, 0x9e, 0xbb, 0xff, 0xf2, 0xfa, 0x74, 0x16, 0x0a,
+	0xa1, 0x30, 0x82, 0xfc, 0x59, 0x30, 0x93, 0xb1, 0xf1, 0x89, 0x02, 0xa5, 0xbb, 0xc2, 0xd2, 0x97,
+	0xd6, 0x42, 0xe3, 0xdb, 0x50, 0x94, 0x46, 0xe0, 0x26, 0xf7, 0x1e, 0x64, 0x29, 0x2d, 0x8b, 0x2d,
+	0xbd, 0xf1, 0xe2, 0xcb, 0x6d, 0xd5, 0xeb, 0xf8, 0x32, 0xf7, 0x12, 0x0b, 0xe3, 0xdf, 0x2a, 0x54,
+	0x96, 0x43, 0x66, 0xc5, 0xac, 0xe9, 0xfa, 0x5b, 0xe3, 0x2f, 0x19, 0x74, 0xd0, 0x3c, 0xab, 0x2b,
+	0x4f, 0x5d, 0xf4, 0xad, 0x6f, 0x43, 0xa1, 0xed, 0xdb, 0xac, 0xeb, 0xdb, 0x32, 0x51, 0xdd, 0x3f,
+	0xe8, 0xd7, 0x0b, 0xcb, 0xbe, 0xcd, 0x1e, 0xfa, 0x36, 0x66, 0xa8, 0x77, 0x5e, 0xdc, 0x59, 0x92,
+	0x53, 0x43, 0x92, 0x9b, 0x09, 0x73, 0x14, 0x1e, 0x39, 0xdf, 0x67, 0xa2, 0xfc, 0xa0, 0x6f, 0x3a,
+	0x3c, 0xb8, 0x4e, 0x9b, 0xb5, 0xe8, 0x17, 0xdc, 0x69, 0xca, 0xe6, 0x14, 0x41, 0xd6, 0xf1, 0xe7,
+	0x35, 0x2c, 0x59, 0x6c, 0xd6, 0xae, 0xe5, 0x48, 0xb1, 0xaf, 0x1f, 0xf6, 0xeb, 0x5f, 0x39, 0x6d,
+	0xe4, 0x50, 0x93, 0xb6, 0xc9, 0xf9, 0xe8, 0x9f, 0x83, 0x42, 0x68, 0x7d, 0xc0, 0xa5, 0xe5, 0x79,
+	0xe9, 0x17, 0x5a, 0x1f, 0xa0, 0x2c, 0xe3, 0x11, 0x94, 0x07, 0xae, 0xc7, 0xc0, 0xbe, 0x0b, 0x1a,
+	0xb2, 0x14, 0x7e, 0xbf, 0x7a, 0xec, 0xa6, 0xcc, 0xc5, 0x20, 0x95, 0xcc, 0x9f, 0x88, 0x62, 0x78,
+	0x34, 0x4d, 0x26, 0x16, 0x47, 0xe3, 0x3e, 0x80, 0x90, 0x37, 0x06, 0xe5, 0xff, 0x29, 0x2a, 0xd7,
+	0x8b, 0x51, 0x7f, 0x3c, 0x95, 0xeb, 0x2a, 0x64, 0x9e, 0x3a, 0x36, 0x9f, 0x34, 0xcd, 0xb7, 0x0f,
+	0xfb, 0xf5, 0x1b, 0xa7, 0x9d, 0x1a, 0x9b, 0x8e, 0x6d, 0x22, 0x0f, 0x8c, 0x95, 0xb4, 0x16, 0x9d,
+	0x77, 0x13, 0xb2, 0x44, 0x21, 0xea, 0xd6, 0x53, 0x78, 0x8f, 0xd3, 0x9d, 0x58, 0xb6, 0xbe, 0x2f,
+	0x4f, 0x5f, 0x93, 0x9b, 0x1e, 0xc9, 0xc9, 0x4b, 0x18, 0x69, 0xfc, 0x5e, 0x81, 0xa9, 0x8d, 0xd0,
+	0x8a, 0x76, 0x10, 0x70, 0xce, 0xf9, 0x82, 0x27, 0x7a, 0xb6, 0x17, 0x38, 0x21, 0x6b, 0xc5, 0x8e,
+	0x10, 0x9c, 0x31, 0x81, 0x83, 0x36, 0x9c, 0x2e, 0x1b, 0xe9, 0x12, 0x64, 0x46, 0xbb, 0x04, 0xa9,
+	0x13, 0x99, 0x36, 0x7c, 0x22, 0xfb, 0xa5, 0x02, 0xe5, 0x44, 0xcd, 0xc9, 0x64, 0xc4, 0x11, 0x63,
+	0x32, 0xa3, 0xc6, 0x18, 0x15, 0x28, 0x25, 0x2a, 0xa1, 0x2b, 0x23, 0xa8, 0x3e, 0xf1, 0xec, 0x09,
+	0x07, 0xf4, 0x31, 0x4c, 0xa7, 0x85, 0x8e, 0x61, 0xd1, 0xff, 0x42, 0x81, 0x4b, 0xb8, 0x0c, 0x2e,
+	0xd0, 0xdd, 0x83, 0x15, 0xae, 0x1e, 0xbd, 0xc2, 0x33, 0xa9, 0x15, 0x6e, 0xec, 0x43, 0x75, 0x48,
+	0x1f, 0xb4, 0xf1, 0xf6, 0xf0, 0xda, 0x7c, 0xe3, 0x24, 0x6d, 0x12, 0xe2, 0xd3, 0xad, 0xd0, 0x8f,
+	0x14, 0xd0, 0x1f, 0xf7, 0xc2, 0x6d, 0x36, 0xe1, 0xb9, 0x77, 0x7c, 0x6b, 0xe2, 0x32, 0x5c, 0x1a,
+	0x56, 0x08, 0x67, 0xde, 0x87, 0x0a, 0x14, 0x56, 0x98, 0xdd, 0x0b, 0x4c, 0xd6, 0x41, 0x7e, 0x3b,
+	0x56, 0xb4, 0xc3, 0x3b, 0xc5, 0x26, 0x7d, 0xeb, 0xab, 0x50, 0x70, 0xfd, 0xb6, 0x15, 0x23, 0x43,
+	0xf5, 0x84, 0xd3, 0x25, 0x9f, 0x16, 0x0f, 0x04, 0xba, 0x50, 0x36, 0x21, 0xd7, 0x3f, 0x0f, 0x53,
+	0x21, 0xeb, 0xb4, 0xd2, 0x71, 0x2a, 0x84, 0xac, 0xb3, 0x4c, 0xa1, 0xea, 0x2b, 0x50, 0x32, 0x59,
+	0x87, 0x74, 0x19, 0xbf, 0xa7, 0xaa, 0x90, 0xd9, 0x65, 0xfb, 0xc2, 0x51, 0xf8, 0x99, 0xd8, 0x9a,
+	0x39, 0xc6, 0x56, 0xed, 0x7c, 0xb6, 0x56, 0x21, 0x13, 0xb2, 0x0e, 0xed, 0x2d, 0x25, 0x13, 0x3f,
+	0x8d, 0x35, 0x28, 0x4a, 0xfb, 0x78, 0x6b, 0x83, 0x10, 0xb8, 0x6d, 0x0b, 0x27, 0xd9, 0x26, 0x43,
+	0x24, 0xe4, 0x10, 0xc3, 0x2e, 0xd5, 0xc2, 0x93, 0x72, 0x98, 0xe1, 0x42, 0x51, 0x8a, 0x1b, 0x8b,
+	0xfe, 0x38, 0x1d, 0x3a, 0x4e, 0x18, 0xc5, 0x2d, 0xe4, 0xc3, 0x05, 0x15, 0x08, 0x60, 0xb2, 0x8e,
+	0xf1, 0x17, 0xec, 0x87, 0x7a, 0xe1, 0x04, 0x27, 0x84, 0x88, 0x58, 0x26, 0x89, 0xd8, 0x18, 0xa7,
+	0x83, 0xf1, 0x13, 0x05, 0xca, 0x03, 0x73, 0xc6, 0xe3, 0xbf, 0x39, 0x80, 0x1e, 0xb2, 0x64, 0x61,
+	0xc8, 0xf8, 0x11, 0xa5, 0x60, 0xa6, 0x20, 0x38, 0xc3, 0x5d, 0x2b, 0x8a, 0xc5, 0x21, 0x8a, 0xbe,
+	0x8d, 0x3f, 0x29, 0x50, 0x4c, 0xb5, 0xdf, 0xf0, 0x7a, 0x84, 0xae, 0x71, 0x06, 0x07, 0x28, 0xba,
+	0x1e, 0x11, 0x37, 0x2d, 0xe7, 0xb9, 0xa4, 0xc9, 0x13, 0xdf, 0x55, 0x5b, 0xff, 0x1a, 0xa8, 0x7e,
+	0x40, 0xea, 0x55, 0x4e, 0xb6, 0x93, 0xab, 0xb5, 0x16, 0x98, 0xaa, 0x1f, 0x0c, 0xee, 0x78, 0x32,
+	0xe9, 0x3b, 0x9e, 0x4f, 0x14, 0xc8, 0x6f, 0xec, 0x79, 0xcb, 0xbe, 0x67, 0xeb, 0x37, 0x41, 0x8b,
+	0xb1, 0x3f, 0xa2, 0x10, 0xf7, 0x13, 0x1b, 0x8f, 0x82, 0x8c, 0x9a, 0x1e, 0x44, 0x38, 0x64, 0xbf,
+	0x7a, 0x31, 0xf6, 0x1f, 0x6d, 0xc5, 0xdf, 0x54, 0xc8, 0x6e, 0xec, 0x79, 0x6b, 0x01, 0xee, 0xb9,
+	0x29, 0x1b, 0xde, 0x78, 0x01, 0x1b, 0xd6, 0x82, 0x94, 0x05, 0xc3, 0x75, 0x91, 0x3a, 0x5a, 0x17,
+	0xc9, 0x0e, 0x74, 0xe6, 0x6c, 0x1d, 0xe8, 0x64, 0x8b, 0xd1, 0x52, 0x5b, 0x8c, 0xac, 0x12, 0xb2,
+	0x67, 0x2b, 0xf5, 0x96, 0x40, 0x6b, 0xfb, 0x9e, 0x5d, 0xcb, 0x1d, 0xb7, 0xa4, 0x8e, 0x0c, 0x9a,
+	0x64, 0x81, 0xa4, 0xa3, 0x05, 0x56, 0xfe, 0x99, 0x02, 0xeb, 0xd7, 0x0a, 0x94, 0x97, 0xfd, 0x6e,
+	0xd7, 0x89, 0x37, 0xf6, 0xbc, 0xf1, 0x67, 0x8f, 0x77, 0x21, 0xe3, 0x07, 0x2f, 0x7c, 0x6b, 0x4a,
+	0x21, 0x93, 0x2b, 0xd7, 0x0f, 0x22, 0x2c, 0xff, 0x12, 0xe5, 0x70, 0x13, 0xfe, 0xa9, 0x02, 0x15,
+	0x93, 0xc5, 0x96, 0xe3, 0x4d, 0xae, 0x4e, 0x98, 0x81, 0xac, 0xcb, 0xac, 0x88, 0xc9, 0x82, 0x89,
+	0x06, 0x58, 0xe4, 0x0f, 0x14, 0x41, 0xd5, 0xfe, 0xac, 0x40, 0x69, 0x9d, 0x59, 0xee, 0x85, 0x29,
+	0x46, 0x27, 0x69, 0x35, 0x75, 0xa2, 0x97, 0xca, 0x66, 0x52, 0xca, 0x36, 0x21, 0x47, 0x67, 0x7a,
+	0xd9, 0x9b, 0x7e, 0xf5, 0x84, 0x39, 0xb7, 0x8e, 0xc8, 0x52, 0x16, 0xa7, 0xc4, 0x7e, 0xb1, 0x34,
+	0x04, 0x0d, 0xfb, 0xa3, 0x0a, 0x95, 0x25, 0xd7, 0xf5, 0xdb, 0x84, 0xfb, 0x7f, 0xd0, 0x29, 0x79,
+	0x08, 0xa5, 0x8e, 0xe5, 0xb8, 0xcc, 0x6e, 0x91, 0x43, 0xc4, 0xea, 0x3d, 0x8d, 0x27, 0x8b, 0x9c,
+	0x9e, 0x40, 0xc6, 0x3a, 0x94, 0x07, 0xee, 0xc3, 0x0d, 0x6d, 0x10, 0x23, 0xe5, 0xcc, 0x31, 0xfa,
+	0x55, 0x0e, 0x80, 0x9c, 0xba, 0x1e, 0x5b, 0x71, 0x94, 0xb4, 0xdf, 0x94, 0xb1, 0x36, 0x18, 0xaf,
+	0x42, 0xd9, 0x0a, 0x02, 0xd7, 0x61, 0x76, 0xcb, 0xf1, 0x6c, 0xb6, 0x27, 0x66, 0x5f, 0x49, 0x00,
+	0x57, 0x11, 0x96, 0xba, 0x61, 0xde, 0xf1, 0xc5, 0x1e, 0x3a, 0x25, 0x6f, 0x98, 0xef, 0xf9, 0x51,
+	0xac, 0x07, 0x50, 0x11, 0x08, 0xb2, 0x01, 0xa9, 0x51, 0x44, 0xdf, 0x3b, 0xe8, 0xd7, 0x4b, 0xbc,
+	0x37, 0x7b, 0xee, 0x36, 0x64, 0xc9, 0x1d, 0xf0, 0xb1, 0xf5, 0xed, 0x44, 0x25, 0x72, 0x4a, 0x36,
+	0x79, 0xcd, 0x00, 0x5c, 0xdc, 0xb9, 0x5c, 0x23, 0x4c, 0xc3, 0x6f, 0x3c, 0x42, 0xb8, 0xcc, 0x0a,
+	0x3d, 0x16, 0x52, 0x8e, 0x2e, 0x98, 0x72, 0xf8, 0xec, 0xd5, 0x4e, 0xfe, 0x42, 0x5e, 0x04, 0x24,
+	0xd7, 0x59, 0x85, 0x71, 0x5c, 0x67, 0x4d, 0x9d, 0xef, 0x3a, 0x6b, 0x05, 0x3b, 0x7e, 0x9d, 0x18,
+	0x67, 0x64, 0x0d, 0x48, 0x1f, 0xe3, 0x58, 0x7d, 0x10, 0xb1, 0x81, 0x98, 0xb2, 0x00, 0x94, 0x94,
+	0xa3, 0x2f, 0x16, 0x8a, 0xa3, 0x2f, 0x16, 0x86, 0xda, 0xde, 0xa5, 0x91, 0xb6, 0xf7, 0x3e, 0x54,
+	0xf0, 0x18, 0xbb, 0xe9, 0xbb, 0xbd, 0x2e, 0x4f, 0x55, 0xe9, 0x4c, 0xa2, 0x5c, 0x60, 0x26, 0x31,
+	0x6c, 0x28, 0x0f, 0x44, 0xe3, 0x32, 0x5f, 0x07, 0xed, 0xa9, 0x63, 0xf3, 0x45, 0x5e, 0x6e, 0xde,
+	0xc4, 0x35, 0xb9, 0xe9, 0xd8, 0xd1, 0x59, 0xbb, 0x67, 0xc4, 0x0c, 0x6f, 0x30, 0x48, 0xcc, 0xc4,
+	0x1a, 0xfb, 0xf8, 0x2c, 0x88, 0xaa, 0xa6, 0xe1, 0xba, 0x8f, 0xe4, 0x9f, 0xf1, 0x59, 0x10, 0x27,
+	0x35, 0xf3, 0xc4, 0x97, 0xd7, 0x7d, 0x47, 0x74, 0x24, 0x7e, 0x9b, 0xe1, 0x2d, 0x12, 0x42, 0x6f,
+	0x5a, 0x11, 0xc3, 0x1e, 0xfe, 0xff, 0x80, 0xb5, 0xe9, 0x57, 0x4a, 0xe3, 0x4b, 0xd5, 0x33, 0x90,
+	0xe5, 0x29, 0x9a, 0x72, 0xab, 0xc9, 0x07, 0x08, 0x65, 0x81, 0xdf, 0xde, 0x11, 0xed, 0x7e, 0x3e,
+	0x18, 0xac, 0xf7, 0xdc, 0xb9, 0xd6, 0xbb, 0xd1, 0xe2, 0x8d, 0xeb, 0xe4, 0x7a, 0x66, 0x0d, 0x72,
+	0x64, 0xa4, 0xdc, 0xd7, 0xbe, 0x74, 0x52, 0x55, 0xf0, 0x4c, 0x78, 0x93, 0x4d, 0x8e, 0xd8, 0x50,
+	0xf3, 0x6f, 0xf9, 0xa1, 0x85, 0x9b, 0x27, 0x4e, 0x75, 0xe3, 0x2a, 0x14, 0xe5, 0x18, 0xe5, 0xcd,
+	0x40, 0x36, 0xc2, 0xdd, 0x8f, 0x66, 0xc2, 0x94, 0xc9, 0x07, 0xd8, 0xc5, 0x2c, 0xae, 0x34, 0x69,
+	0x5b, 0x9c, 0xc4, 0xfa, 0xb8, 0x0a, 0x79, 0x7b, 0xab, 0x95, 0x14, 0x30, 0x53, 0x4d, 0x20, 0xf6,
+	0xcd, 0x47, 0x56, 0x97, 0x99, 0x39, 0x7b, 0x0b, 0xff, 0x1b, 0x3f, 0x52, 0x01, 0x84, 0x4e, 0xa8,
+	0xb8, 0x0e, 0x5a, 0x2f, 0x62, 0x62, 0xb7, 0x36, 0xe9, 0x5b, 0x5f, 0x80, 0x2a, 0x0a, 0x6c, 0xb5,
+	0xad, 0xf6, 0x0e, 0x6b, 0xf5, 0x22, 0x6b, 0x5b, 0x16, 0x7b, 0x15, 0x84, 0x2f, 0x23, 0xf8, 0x09,
+	0x42, 0xf5, 0x1b, 0x70, 0x85, 0xa2, 0xdb, 0xb2, 0x3c, 0xbb, 0xc5, 0x9f, 0x83, 0x08, 0x7c, 0xbe,
+	0x7e, 0x2e, 0xd3, 0xaf, 0x4b, 0x9e, 0x38, 0xb9, 0x72, 0xa2, 0xd7, 0xa0, 0xd2, 0x65, 0xdd, 0xd8,
+	0xda, 0x72, 0x25, 0x73, 0x5e, 0xf1, 0x94, 0x25, 0x94, 0xa3, 0xbd, 0x05, 0xfa, 0x96, 0xeb, 0xb7,
+	0x77, 0x5b, 0x81, 0xe3, 0x79, 0xcc, 0x16, 0xa8, 0xb4, 0x81, 0x9a, 0x55, 0xfa, 0xe5, 0x31, 0xfd,
+	0x90, 0x60, 0xc7, 0x7e, 0x6c, 0xb9, 0xad, 0x2e, 0xeb, 0xfa, 0xe1, 0xbe, 0xc0, 0xce, 0x71, 0x6c,
+	0xfa, 0xe5, 0x21, 0xfd, 0x40, 0xd8, 0xd7, 0x7e, 0x8c, 0x5d, 0x70, 0x79, 0x26, 0xd3, 0xcb, 0x62,
+	0xf0, 0xc8, 0xf7, 0x58, 0xf5, 0x15, 0xbd, 0x0a, 0x25, 0x1a, 0x3e, 0xee, 0xd1, 0x93, 0x96, 0xaa,
+	0xa2, 0x5f, 0x86, 0x69, 0x82, 0x0c, 0x1e, 0x53, 0x55, 0xd5, 0x04, 0x38, 0x78, 0xd9, 0x54, 0xcd,
+	0xa4, 0x69, 0xb1, 0x66, 0xad, 0x6a, 0x23, 0x68, 0x04, 0xcc, 0xce, 0x6a, 0x3f, 0xfb, 0xdd, 0xdc,
+	0x2b, 0xd7, 0xf6, 0xa1, 0x98, 0x3a, 0xdc, 0xea, 0xd3, 0xc9, 0x50, 0x28, 0xc2, 0x49, 0x39, 0x20,
+	0xbe, 0xbd, 0xe7, 0x44, 0x71, 0x55, 0x11, 0x12, 0x10, 0xc8, 0x21, 0xaa, 0xfe, 0x19, 0xb8, 0x24,
+	0x20, 0x74, 0x8c, 0xbd, 0xfd, 0x7e, 0xcf, 0x72, 0xab, 0x99, 0x14, 0x78, 0x13, 0x0f, 0xaf, 0x1c,
+	0xac, 0x09, 0xd1, 0x1f, 0x2b, 0x50, 0x90, 0xc7, 0x76, 0x64, 0x29, 0xbf, 0x85, 0xe4, 0x4b, 0x50,
+	0x96, 0x10, 0x4e, 0xa7, 0xe8, 0x33, 0x50, 0x1d, 0x20, 0xc5, 0x1c, 0xaa, 0xa6, 0x49, 0x1f, 0xb0,
+	0x28, 0xe2, 0x62, 0xd3, 0x10, 0x21, 0x16, 0x6d, 0x91, 0xe0, 0xbb, 0x74, 0xdf, 0x16, 0x56, 0xb3,
+	0x7a, 0x0d, 0x66, 0x46, 0x80, 0x1c, 0x3d, 0xa7, 0xeb, 0x50, 0x91, 0xbf, 0x3c, 0xa6, 0x5b, 0xa2,
+	0x6a, 0x9e, 0x6b, 0xde, 0x9c, 0xfd, 0xf4, 0x60, 0x4e, 0xf9, 0xeb, 0xc1, 0x9c, 0xf2, 0xf7, 0x83,
+	0x39, 0xe5, 0x3b, 0xa5, 0xc6, 0xe2, 0x3b, 0xc9, 0x22, 0xde, 0xca, 0xd1, 0xb2, 0xb8, 0xf1, 0x9f,
+	0x01, 0x00, 0x89, 0x18, 0xb0, 0xe1, 0x38, 0x2c, 0x00, 0x00,
+}
+
+func (m *Item) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3981,12 +4375,12 @@ func (m *RetainBlobArgs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RetainBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+func (m *Item) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RetainBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Item) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3995,32 +4389,36 @@ func (m *RetainBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Lease != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Lease))
+	if m.Version != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Version))
 		i--
 		dAtA[i] = 0x18
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	{
-		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
 		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	i--
-	dAtA[i] = 0xa
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RetainBlobRet) Marshal() (dAtA []byte, err error) {
+func (m *Field) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4030,12 +4428,12 @@ func (m *RetainBlobRet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RetainBlobRet) MarshalTo(dAtA []byte) (int, error) {
+func (m *Field) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RetainBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4044,10 +4442,22 @@ func (m *RetainBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *SealBlobArgs) Marshal() (dAtA []byte, err error) {
+func (m *ShardOpHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4057,12 +4467,12 @@ func (m *SealBlobArgs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SealBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+func (m *ShardOpHeader) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SealBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ShardOpHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4071,32 +4481,92 @@ func (m *SealBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Slices) > 0 {
-		for iNdEx := len(m.Slices) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Slices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintShardnode(dAtA, i, uint64(size))
-			}
+	if m.LeaderTerm != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.LeaderTerm))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Sync {
+		i--
+		if m.Sync {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.ShardKeys) > 0 {
+		for iNdEx := len(m.ShardKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ShardKeys[iNdEx])
+			copy(dAtA[i:], m.ShardKeys[iNdEx])
+			i = encodeVarintShardnode(dAtA, i, uint64(len(m.ShardKeys[iNdEx])))
 			i--
-			dAtA[i] = 0x22
+			dAtA[i] = 0x32
 		}
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+	if m.RouteVersion != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.RouteVersion))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x28
 	}
-	if m.Size_ != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Size_))
+	if m.SpaceVersion != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.SpaceVersion))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Suid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
 		i--
 		dAtA[i] = 0x10
 	}
+	if m.SpaceID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.SpaceID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InsertItemArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InsertItemArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InsertItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	{
+		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
 	{
 		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -4110,7 +4580,7 @@ func (m *SealBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *SealBlobRet) Marshal() (dAtA []byte, err error) {
+func (m *InsertItemRet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4120,12 +4590,12 @@ func (m *SealBlobRet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SealBlobRet) MarshalTo(dAtA []byte) (int, error) {
+func (m *InsertItemRet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SealBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *InsertItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4137,7 +4607,7 @@ func (m *SealBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AllocSliceArgs) Marshal() (dAtA []byte, err error) {
+func (m *UpdateItemArgs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4147,12 +4617,12 @@ func (m *AllocSliceArgs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AllocSliceArgs) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateItemArgs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AllocSliceArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4162,7 +4632,7 @@ func (m *AllocSliceArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	{
-		size, err := m.FailedSlice.MarshalToSizedBuffer(dAtA[:i])
+		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
 			return 0, err
 		}
@@ -4170,24 +4640,7 @@ func (m *AllocSliceArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
 	i--
-	dAtA[i] = 0x2a
-	if m.Size_ != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Size_))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.CodeMode != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.CodeMode))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
+	dAtA[i] = 0x12
 	{
 		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -4201,7 +4654,7 @@ func (m *AllocSliceArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AllocSliceRet) Marshal() (dAtA []byte, err error) {
+func (m *UpdateItemRet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4211,12 +4664,12 @@ func (m *AllocSliceRet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AllocSliceRet) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateItemRet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AllocSliceRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4225,24 +4678,10 @@ func (m *AllocSliceRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Slices) > 0 {
-		for iNdEx := len(m.Slices) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Slices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintShardnode(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ShardStats) Marshal() (dAtA []byte, err error) {
+func (m *DeleteItemArgs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4252,12 +4691,12 @@ func (m *ShardStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ShardStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteItemArgs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ShardStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4266,32 +4705,15 @@ func (m *ShardStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	{
-		size, err := m.RaftStat.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintShardnode(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0x52
-	if len(m.Units) > 0 {
-		for iNdEx := len(m.Units) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Units[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintShardnode(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x4a
-		}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
 	}
 	{
-		size, err := m.Range.MarshalToSizedBuffer(dAtA[:i])
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
 			return 0, err
 		}
@@ -4299,53 +4721,11 @@ func (m *ShardStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
 	i--
-	dAtA[i] = 0x42
-	if m.RouteVersion != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.RouteVersion))
-		i--
-		dAtA[i] = 0x38
-	}
-	if m.Learner {
-		i--
-		if m.Learner {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.LeaderSuid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.LeaderSuid))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.LeaderDiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.LeaderDiskID))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.LeaderHost) > 0 {
-		i -= len(m.LeaderHost)
-		copy(dAtA[i:], m.LeaderHost)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.LeaderHost)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.AppliedIndex != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.AppliedIndex))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Suid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
-		i--
-		dAtA[i] = 0x8
-	}
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *ListVolumeArgs) Marshal() (dAtA []byte, err error) {
+func (m *DeleteItemRet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4355,12 +4735,12 @@ func (m *ListVolumeArgs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListVolumeArgs) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteItemRet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListVolumeArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4369,15 +4749,10 @@ func (m *ListVolumeArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CodeMode != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.CodeMode))
-		i--
-		dAtA[i] = 0x8
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ListVolumeRet) Marshal() (dAtA []byte, err error) {
+func (m *GetItemArgs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4387,12 +4762,12 @@ func (m *ListVolumeRet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListVolumeRet) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetItemArgs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListVolumeRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4401,30 +4776,34 @@ func (m *ListVolumeRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Vids) > 0 {
-		dAtA25 := make([]byte, len(m.Vids)*10)
-		var j24 int
-		for _, num := range m.Vids {
-			for num >= 1<<7 {
-				dAtA25[j24] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j24++
-			}
-			dAtA25[j24] = uint8(num)
-			j24++
-		}
-		i -= j24
-		copy(dAtA[i:], dAtA25[:j24])
-		i = encodeVarintShardnode(dAtA, i, uint64(j24))
+	if m.Version != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Version))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x18
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ListShardArgs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *GetItemRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
@@ -4432,12 +4811,12 @@ func (m *ListShardArgs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListShardArgs) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetItemRet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4446,25 +4825,20 @@ func (m *ListShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Count != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Count))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.ShardID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.ShardID))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.DiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
-		i--
-		dAtA[i] = 0x8
+	{
+		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *ListShardBaseInfo) Marshal() (dAtA []byte, err error) {
+func (m *ListItemArgs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4474,12 +4848,12 @@ func (m *ListShardBaseInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListShardBaseInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListItemArgs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListShardBaseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListItemArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4488,10 +4862,10 @@ func (m *ListShardBaseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Units) > 0 {
-		for iNdEx := len(m.Units) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Filters) > 0 {
+		for iNdEx := len(m.Filters) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Units[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Filters[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -4499,38 +4873,42 @@ func (m *ListShardBaseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintShardnode(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x32
+			dAtA[i] = 0x2a
 		}
 	}
-	if m.Epoch != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Epoch))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Index != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Index))
+	if m.Count != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Count))
 		i--
 		dAtA[i] = 0x20
 	}
-	if m.Suid != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+	if len(m.Marker) > 0 {
+		i -= len(m.Marker)
+		copy(dAtA[i:], m.Marker)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Marker)))
 		i--
-		dAtA[i] = 0x18
+		dAtA[i] = 0x1a
 	}
-	if m.ShardID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.ShardID))
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Prefix)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x12
 	}
-	if m.DiskID != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
-		i--
-		dAtA[i] = 0x8
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *ListShardRet) Marshal() (dAtA []byte, err error) {
+func (m *ListItemRet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4540,12 +4918,12 @@ func (m *ListShardRet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListShardRet) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListItemRet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListItemRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4554,10 +4932,17 @@ func (m *ListShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Shards) > 0 {
-		for iNdEx := len(m.Shards) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.NextMarker) > 0 {
+		i -= len(m.NextMarker)
+		copy(dAtA[i:], m.NextMarker)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.NextMarker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Shards[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -4571,7 +4956,7 @@ func (m *ListShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *TCMallocArgs) Marshal() (dAtA []byte, err error) {
+func (m *AddShardArgs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4581,12 +4966,12 @@ func (m *TCMallocArgs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TCMallocArgs) MarshalTo(dAtA []byte) (int, error) {
+func (m *AddShardArgs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TCMallocArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AddShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4595,10 +4980,49 @@ func (m *TCMallocArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.RouteVersion != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.RouteVersion))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Units) > 0 {
+		for iNdEx := len(m.Units) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Units[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.Range.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Suid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *TCMallocRet) Marshal() (dAtA []byte, err error) {
+func (m *AddShardRet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4608,12 +5032,12 @@ func (m *TCMallocRet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TCMallocRet) MarshalTo(dAtA []byte) (int, error) {
+func (m *AddShardRet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TCMallocRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AddShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4622,17 +5046,10 @@ func (m *TCMallocRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Stats) > 0 {
-		i -= len(m.Stats)
-		copy(dAtA[i:], m.Stats)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Stats)))
-		i--
-		dAtA[i] = 0xa
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DBStatsArgs) Marshal() (dAtA []byte, err error) {
+func (m *UpdateShardArgs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4642,12 +5059,12 @@ func (m *DBStatsArgs) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DBStatsArgs) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateShardArgs) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DBStatsArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4656,12 +5073,25 @@ func (m *DBStatsArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.DBName) > 0 {
-		i -= len(m.DBName)
-		copy(dAtA[i:], m.DBName)
-		i = encodeVarintShardnode(dAtA, i, uint64(len(m.DBName)))
+	{
+		size, err := m.Unit.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if m.ShardUpdateType != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.ShardUpdateType))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x18
+	}
+	if m.Suid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+		i--
+		dAtA[i] = 0x10
 	}
 	if m.DiskID != 0 {
 		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
@@ -4671,7 +5101,7 @@ func (m *DBStatsArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *DBStatsRet) Marshal() (dAtA []byte, err error) {
+func (m *UpdateShardRet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4681,12 +5111,12 @@ func (m *DBStatsRet) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DBStatsRet) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateShardRet) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DBStatsRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4695,930 +5125,6363 @@ func (m *DBStatsRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.TotalMemoryUsage != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.TotalMemoryUsage))
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.BlockPinnedUsage != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.BlockPinnedUsage))
-		i--
-		dAtA[i] = 0x28
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferShardLeaderArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if m.MemtableUsage != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.MemtableUsage))
-		i--
-		dAtA[i] = 0x20
+	return dAtA[:n], nil
+}
+
+func (m *TransferShardLeaderArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferShardLeaderArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.IndexAndFilterUsage != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.IndexAndFilterUsage))
+	if m.DestDiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DestDiskID))
 		i--
 		dAtA[i] = 0x18
 	}
-	if m.BlobCacheUsage != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.BlobCacheUsage))
+	if m.Suid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.Used != 0 {
-		i = encodeVarintShardnode(dAtA, i, uint64(m.Used))
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintShardnode(dAtA []byte, offset int, v uint64) int {
-	offset -= sovShardnode(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *TransferShardLeaderRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Item) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if len(m.Fields) > 0 {
-		for _, e := range m.Fields {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+func (m *TransferShardLeaderRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Field) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *TransferShardLeaderRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovShardnode(uint64(m.ID))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ShardOpHeader) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SetShardReadonlyArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SetShardReadonlyArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetShardReadonlyArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.SpaceID != 0 {
-		n += 1 + sovShardnode(uint64(m.SpaceID))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
+	if m.Readonly {
+		i--
+		if m.Readonly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
 	}
 	if m.Suid != 0 {
-		n += 1 + sovShardnode(uint64(m.Suid))
-	}
-	if m.SpaceVersion != 0 {
-		n += 1 + sovShardnode(uint64(m.SpaceVersion))
-	}
-	if m.RouteVersion != 0 {
-		n += 1 + sovShardnode(uint64(m.RouteVersion))
-	}
-	if len(m.ShardKeys) > 0 {
-		for _, b := range m.ShardKeys {
-			l = len(b)
-			n += 1 + l + sovShardnode(uint64(l))
-		}
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *InsertItemArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = m.Item.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *GetShardArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *InsertItemRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *GetShardArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UpdateItemArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = m.Item.Size()
-	n += 1 + l + sovShardnode(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *UpdateItemRet) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Suid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+		i--
+		dAtA[i] = 0x10
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DeleteItemArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *GetShardRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DeleteItemRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetShardRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	{
+		size, err := m.Shard.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *GetItemArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *CreateBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetItemRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Item.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *CreateBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListItemArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CreateBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.Prefix)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Marker)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if m.RawSize != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.RawSize))
+		i--
+		dAtA[i] = 0x38
 	}
-	if m.Count != 0 {
-		n += 1 + sovShardnode(uint64(m.Count))
+	if m.Codec != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Codec))
+		i--
+		dAtA[i] = 0x30
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.SliceSize != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.SliceSize))
+		i--
+		dAtA[i] = 0x28
 	}
-	return n
-}
-
-func (m *ListItemRet) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Size_ != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x20
 	}
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
-		}
+	if m.CodeMode != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.CodeMode))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = len(m.NextMarker)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *AddShardArgs) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
-	}
-	if m.Suid != 0 {
-		n += 1 + sovShardnode(uint64(m.Suid))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = m.Range.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if len(m.Units) > 0 {
-		for _, e := range m.Units {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
+	{
+		size, err := m.Blob.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	if m.RouteVersion != 0 {
-		n += 1 + sovShardnode(uint64(m.RouteVersion))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *AddShardRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *GetBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *UpdateShardArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
-	}
-	if m.Suid != 0 {
-		n += 1 + sovShardnode(uint64(m.Suid))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ShardUpdateType != 0 {
-		n += 1 + sovShardnode(uint64(m.ShardUpdateType))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
 	}
-	l = m.Unit.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *UpdateShardRet) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	{
+		size, err := m.Blob.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *TransferShardLeaderArgs) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
-	}
-	if m.Suid != 0 {
-		n += 1 + sovShardnode(uint64(m.Suid))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.DestDiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DestDiskID))
+	if m.Vid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Vid))
+		i--
+		dAtA[i] = 0x28
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Count != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x20
 	}
-	return n
+	if len(m.Marker) > 0 {
+		i -= len(m.Marker)
+		copy(dAtA[i:], m.Marker)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Marker)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *TransferShardLeaderRet) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.NextMarker) > 0 {
+		i -= len(m.NextMarker)
+		copy(dAtA[i:], m.NextMarker)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.NextMarker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Blobs) > 0 {
+		for iNdEx := len(m.Blobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Blobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *GetShardArgs) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Suid != 0 {
-		n += 1 + sovShardnode(uint64(m.Suid))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *GetShardRet) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Shard.Size()
-	n += 1 + l + sovShardnode(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CreateBlobArgs) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TrashBlob) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TrashBlob) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TrashBlob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CodeMode != 0 {
-		n += 1 + sovShardnode(uint64(m.CodeMode))
+	if m.Version != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.Size_ != 0 {
-		n += 1 + sovShardnode(uint64(m.Size_))
+	if len(m.ShardKeys) > 0 {
+		for iNdEx := len(m.ShardKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ShardKeys[iNdEx])
+			copy(dAtA[i:], m.ShardKeys[iNdEx])
+			i = encodeVarintShardnode(dAtA, i, uint64(len(m.ShardKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	if m.SliceSize != 0 {
-		n += 1 + sovShardnode(uint64(m.SliceSize))
+	if m.ExpireTime != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.ExpireTime))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Blob.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *CreateBlobRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Blob.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *TrashBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetBlobArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *TrashBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetBlobRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *TrashBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Blob.Size()
-	n += 1 + l + sovShardnode(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *ListBlobArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.Prefix)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Marker)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if m.ExpireTime != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.ExpireTime))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.Count != 0 {
-		n += 1 + sovShardnode(uint64(m.Count))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *ListBlobRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Blobs) > 0 {
-		for _, e := range m.Blobs {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
-		}
-	}
-	l = len(m.NextMarker)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *TrashBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DeleteBlobArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *TrashBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteBlobRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *TrashBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *RetainBlobArgs) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UndeleteBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UndeleteBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UndeleteBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Lease != 0 {
-		n += 1 + sovShardnode(uint64(m.Lease))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *RetainBlobRet) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UndeleteBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UndeleteBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UndeleteBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	{
+		size, err := m.Blob.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *SealBlobArgs) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListTrashBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTrashBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListTrashBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if m.Size_ != 0 {
-		n += 1 + sovShardnode(uint64(m.Size_))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if m.Count != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x18
 	}
-	if len(m.Slices) > 0 {
-		for _, e := range m.Slices {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
-		}
+	if len(m.Marker) > 0 {
+		i -= len(m.Marker)
+		copy(dAtA[i:], m.Marker)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Marker)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *SealBlobRet) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListTrashBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTrashBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListTrashBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *AllocSliceArgs) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.NextMarker) > 0 {
+		i -= len(m.NextMarker)
+		copy(dAtA[i:], m.NextMarker)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.NextMarker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Blobs) > 0 {
+		for iNdEx := len(m.Blobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Blobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PurgeTrashBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeTrashBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PurgeTrashBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CodeMode != 0 {
-		n += 1 + sovShardnode(uint64(m.CodeMode))
+	if m.Version != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.Size_ != 0 {
-		n += 1 + sovShardnode(uint64(m.Size_))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
 	}
-	l = m.FailedSlice.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	return n
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *AllocSliceRet) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PurgeTrashBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeTrashBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PurgeTrashBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Slices) > 0 {
-		for _, e := range m.Slices {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ShardStats) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DedupRef) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DedupRef) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DedupRef) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Suid != 0 {
-		n += 1 + sovShardnode(uint64(m.Suid))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.AppliedIndex != 0 {
-		n += 1 + sovShardnode(uint64(m.AppliedIndex))
+	if m.RefCount != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.RefCount))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = len(m.LeaderHost)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
+	{
+		size, err := m.Location.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	if m.LeaderDiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.LeaderDiskID))
+	i--
+	dAtA[i] = 0x12
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
 	}
-	if m.LeaderSuid != 0 {
-		n += 1 + sovShardnode(uint64(m.LeaderSuid))
+	return len(dAtA) - i, nil
+}
+
+func (m *RefDedupArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if m.Learner {
-		n += 2
+	return dAtA[:n], nil
+}
+
+func (m *RefDedupArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RefDedupArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.RouteVersion != 0 {
-		n += 1 + sovShardnode(uint64(m.RouteVersion))
+	if len(m.Ref) > 0 {
+		i -= len(m.Ref)
+		copy(dAtA[i:], m.Ref)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Ref)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	l = m.Range.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if len(m.Units) > 0 {
-		for _, e := range m.Units {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
+	{
+		size, err := m.Location.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
 	}
-	l = m.RaftStat.Size()
-	n += 1 + l + sovShardnode(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	i--
+	dAtA[i] = 0x22
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	return n
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *ListVolumeArgs) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RefDedupRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RefDedupRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RefDedupRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CodeMode != 0 {
-		n += 1 + sovShardnode(uint64(m.CodeMode))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	{
+		size, err := m.Ref.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *ListVolumeRet) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetDedupArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Vids) > 0 {
-		l = 0
-		for _, e := range m.Vids {
-			l += sovShardnode(uint64(e))
+	return dAtA[:n], nil
+}
+
+func (m *GetDedupArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetDedupArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *GetDedupRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDedupRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetDedupRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.FirstRef) > 0 {
+		i -= len(m.FirstRef)
+		copy(dAtA[i:], m.FirstRef)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.FirstRef)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Ref.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *UnrefDedupArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnrefDedupArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnrefDedupArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	{
+		size, err := m.Location.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if len(m.Ref) > 0 {
+		i -= len(m.Ref)
+		copy(dAtA[i:], m.Ref)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Ref)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *UnrefDedupRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnrefDedupRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnrefDedupRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Last {
+		i--
+		if m.Last {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Unreferred {
+		i--
+		if m.Unreferred {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.Ref.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *FieldFilter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FieldFilter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FieldFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Op != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Op))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FieldID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.FieldID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TxnCond) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxnCond) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxnCond) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.FieldID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.FieldID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Type != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TxnOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxnOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxnOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExpireTime != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.ExpireTime))
+		i--
+		dAtA[i] = 0x38
+	}
+	{
+		size, err := m.Cond.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	{
+		size, err := m.Blob.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x22
+	}
+	{
+		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.ShardKeys) > 0 {
+		for iNdEx := len(m.ShardKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ShardKeys[iNdEx])
+			copy(dAtA[i:], m.ShardKeys[iNdEx])
+			i = encodeVarintShardnode(dAtA, i, uint64(len(m.ShardKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Type != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommitTxnArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitTxnArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommitTxnArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Ops) > 0 {
+		for iNdEx := len(m.Ops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *CommitTxnRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitTxnRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommitTxnRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RetainBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetainBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RetainBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Lease != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Lease))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *RetainBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetainBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RetainBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SealBlobArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SealBlobArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SealBlobArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Slices) > 0 {
+		for iNdEx := len(m.Slices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Slices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SealBlobRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SealBlobRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SealBlobRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AllocSliceArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AllocSliceArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AllocSliceArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	{
+		size, err := m.FailedSlice.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if m.Size_ != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.CodeMode != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.CodeMode))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *AllocSliceRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AllocSliceRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AllocSliceRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Slices) > 0 {
+		for iNdEx := len(m.Slices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Slices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ShardStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ShardStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Readonly {
+		i--
+		if m.Readonly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.LeaderTerm != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.LeaderTerm))
+		i--
+		dAtA[i] = 0x58
+	}
+	{
+		size, err := m.RaftStat.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x52
+	if len(m.Units) > 0 {
+		for iNdEx := len(m.Units) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Units[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	{
+		size, err := m.Range.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintShardnode(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if m.RouteVersion != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.RouteVersion))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Learner {
+		i--
+		if m.Learner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.LeaderSuid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.LeaderSuid))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.LeaderDiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.LeaderDiskID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.LeaderHost) > 0 {
+		i -= len(m.LeaderHost)
+		copy(dAtA[i:], m.LeaderHost)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.LeaderHost)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.AppliedIndex != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.AppliedIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Suid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListVolumeArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListVolumeArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListVolumeArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.CodeMode != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.CodeMode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListVolumeRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListVolumeRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListVolumeRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Vids) > 0 {
+		dAtA44 := make([]byte, len(m.Vids)*10)
+		var j43 int
+		for _, num := range m.Vids {
+			for num >= 1<<7 {
+				dAtA44[j43] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j43++
+			}
+			dAtA44[j43] = uint8(num)
+			j43++
+		}
+		i -= j43
+		copy(dAtA[i:], dAtA44[:j43])
+		i = encodeVarintShardnode(dAtA, i, uint64(j43))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListShardArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListShardArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListShardArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Count != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ShardID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.ShardID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListShardBaseInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListShardBaseInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListShardBaseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Units) > 0 {
+		for iNdEx := len(m.Units) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Units[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Epoch != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Index != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Suid != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Suid))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ShardID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.ShardID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListShardRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListShardRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListShardRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Shards) > 0 {
+		for iNdEx := len(m.Shards) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Shards[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintShardnode(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TCMallocArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TCMallocArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TCMallocArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TCMallocRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TCMallocRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TCMallocRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Stats) > 0 {
+		i -= len(m.Stats)
+		copy(dAtA[i:], m.Stats)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.Stats)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DBStatsArgs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DBStatsArgs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DBStatsArgs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DBName) > 0 {
+		i -= len(m.DBName)
+		copy(dAtA[i:], m.DBName)
+		i = encodeVarintShardnode(dAtA, i, uint64(len(m.DBName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.DiskID != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.DiskID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DBStatsRet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DBStatsRet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DBStatsRet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TotalMemoryUsage != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.TotalMemoryUsage))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BlockPinnedUsage != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.BlockPinnedUsage))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MemtableUsage != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.MemtableUsage))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.IndexAndFilterUsage != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.IndexAndFilterUsage))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.BlobCacheUsage != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.BlobCacheUsage))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Used != 0 {
+		i = encodeVarintShardnode(dAtA, i, uint64(m.Used))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintShardnode(dAtA []byte, offset int, v uint64) int {
+	offset -= sovShardnode(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Item) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if len(m.Fields) > 0 {
+		for _, e := range m.Fields {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.Version != 0 {
+		n += 1 + sovShardnode(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Field) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovShardnode(uint64(m.ID))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardOpHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SpaceID != 0 {
+		n += 1 + sovShardnode(uint64(m.SpaceID))
+	}
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	if m.SpaceVersion != 0 {
+		n += 1 + sovShardnode(uint64(m.SpaceVersion))
+	}
+	if m.RouteVersion != 0 {
+		n += 1 + sovShardnode(uint64(m.RouteVersion))
+	}
+	if len(m.ShardKeys) > 0 {
+		for _, b := range m.ShardKeys {
+			l = len(b)
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.Sync {
+		n += 2
+	}
+	if m.LeaderTerm != 0 {
+		n += 1 + sovShardnode(uint64(m.LeaderTerm))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InsertItemArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = m.Item.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InsertItemRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateItemArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = m.Item.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateItemRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteItemArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteItemRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetItemArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovShardnode(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetItemRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Item.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListItemArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = len(m.Marker)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovShardnode(uint64(m.Count))
+	}
+	if len(m.Filters) > 0 {
+		for _, e := range m.Filters {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListItemRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	l = len(m.NextMarker)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddShardArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	l = m.Range.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if len(m.Units) > 0 {
+		for _, e := range m.Units {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.RouteVersion != 0 {
+		n += 1 + sovShardnode(uint64(m.RouteVersion))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddShardRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateShardArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	if m.ShardUpdateType != 0 {
+		n += 1 + sovShardnode(uint64(m.ShardUpdateType))
+	}
+	l = m.Unit.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateShardRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferShardLeaderArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	if m.DestDiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DestDiskID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferShardLeaderRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetShardReadonlyArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	if m.Readonly {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetShardArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetShardRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Shard.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.CodeMode != 0 {
+		n += 1 + sovShardnode(uint64(m.CodeMode))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovShardnode(uint64(m.Size_))
+	}
+	if m.SliceSize != 0 {
+		n += 1 + sovShardnode(uint64(m.SliceSize))
+	}
+	if m.Codec != 0 {
+		n += 1 + sovShardnode(uint64(m.Codec))
+	}
+	if m.RawSize != 0 {
+		n += 1 + sovShardnode(uint64(m.RawSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Blob.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Blob.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = len(m.Marker)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovShardnode(uint64(m.Count))
+	}
+	if m.Vid != 0 {
+		n += 1 + sovShardnode(uint64(m.Vid))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Blobs) > 0 {
+		for _, e := range m.Blobs {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	l = len(m.NextMarker)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TrashBlob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Blob.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.ExpireTime != 0 {
+		n += 1 + sovShardnode(uint64(m.ExpireTime))
+	}
+	if len(m.ShardKeys) > 0 {
+		for _, b := range m.ShardKeys {
+			l = len(b)
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.Version != 0 {
+		n += 1 + sovShardnode(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TrashBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.ExpireTime != 0 {
+		n += 1 + sovShardnode(uint64(m.ExpireTime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TrashBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UndeleteBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UndeleteBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Blob.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListTrashBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Marker)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovShardnode(uint64(m.Count))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListTrashBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Blobs) > 0 {
+		for _, e := range m.Blobs {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	l = len(m.NextMarker)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PurgeTrashBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovShardnode(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PurgeTrashBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DedupRef) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = m.Location.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.RefCount != 0 {
+		n += 1 + sovShardnode(uint64(m.RefCount))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RefDedupArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = m.Location.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Ref)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RefDedupRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Ref.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetDedupArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetDedupRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Ref.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.FirstRef)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UnrefDedupArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = len(m.Ref)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = m.Location.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UnrefDedupRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Ref.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.Unreferred {
+		n += 2
+	}
+	if m.Last {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FieldFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FieldID != 0 {
+		n += 1 + sovShardnode(uint64(m.FieldID))
+	}
+	if m.Op != 0 {
+		n += 1 + sovShardnode(uint64(m.Op))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TxnCond) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovShardnode(uint64(m.Type))
+	}
+	if m.FieldID != 0 {
+		n += 1 + sovShardnode(uint64(m.FieldID))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TxnOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovShardnode(uint64(m.Type))
+	}
+	if len(m.ShardKeys) > 0 {
+		for _, b := range m.ShardKeys {
+			l = len(b)
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	l = m.Item.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	l = m.Blob.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = m.Cond.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.ExpireTime != 0 {
+		n += 1 + sovShardnode(uint64(m.ExpireTime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitTxnArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if len(m.Ops) > 0 {
+		for _, e := range m.Ops {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitTxnRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RetainBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.Lease != 0 {
+		n += 1 + sovShardnode(uint64(m.Lease))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RetainBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SealBlobArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.Size_ != 0 {
+		n += 1 + sovShardnode(uint64(m.Size_))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if len(m.Slices) > 0 {
+		for _, e := range m.Slices {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SealBlobRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AllocSliceArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.CodeMode != 0 {
+		n += 1 + sovShardnode(uint64(m.CodeMode))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovShardnode(uint64(m.Size_))
+	}
+	l = m.FailedSlice.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AllocSliceRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Slices) > 0 {
+		for _, e := range m.Slices {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	if m.AppliedIndex != 0 {
+		n += 1 + sovShardnode(uint64(m.AppliedIndex))
+	}
+	l = len(m.LeaderHost)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.LeaderDiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.LeaderDiskID))
+	}
+	if m.LeaderSuid != 0 {
+		n += 1 + sovShardnode(uint64(m.LeaderSuid))
+	}
+	if m.Learner {
+		n += 2
+	}
+	if m.RouteVersion != 0 {
+		n += 1 + sovShardnode(uint64(m.RouteVersion))
+	}
+	l = m.Range.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if len(m.Units) > 0 {
+		for _, e := range m.Units {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	l = m.RaftStat.Size()
+	n += 1 + l + sovShardnode(uint64(l))
+	if m.LeaderTerm != 0 {
+		n += 1 + sovShardnode(uint64(m.LeaderTerm))
+	}
+	if m.Readonly {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListVolumeArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeMode != 0 {
+		n += 1 + sovShardnode(uint64(m.CodeMode))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListVolumeRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Vids) > 0 {
+		l = 0
+		for _, e := range m.Vids {
+			l += sovShardnode(uint64(e))
+		}
+		n += 1 + sovShardnode(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListShardArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.ShardID != 0 {
+		n += 1 + sovShardnode(uint64(m.ShardID))
+	}
+	if m.Count != 0 {
+		n += 1 + sovShardnode(uint64(m.Count))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListShardBaseInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	if m.ShardID != 0 {
+		n += 1 + sovShardnode(uint64(m.ShardID))
+	}
+	if m.Suid != 0 {
+		n += 1 + sovShardnode(uint64(m.Suid))
+	}
+	if m.Index != 0 {
+		n += 1 + sovShardnode(uint64(m.Index))
+	}
+	if m.Epoch != 0 {
+		n += 1 + sovShardnode(uint64(m.Epoch))
+	}
+	if len(m.Units) > 0 {
+		for _, e := range m.Units {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListShardRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Shards) > 0 {
+		for _, e := range m.Shards {
+			l = e.Size()
+			n += 1 + l + sovShardnode(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TCMallocArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TCMallocRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Stats)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DBStatsArgs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DiskID != 0 {
+		n += 1 + sovShardnode(uint64(m.DiskID))
+	}
+	l = len(m.DBName)
+	if l > 0 {
+		n += 1 + l + sovShardnode(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DBStatsRet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Used != 0 {
+		n += 1 + sovShardnode(uint64(m.Used))
+	}
+	if m.BlobCacheUsage != 0 {
+		n += 1 + sovShardnode(uint64(m.BlobCacheUsage))
+	}
+	if m.IndexAndFilterUsage != 0 {
+		n += 1 + sovShardnode(uint64(m.IndexAndFilterUsage))
+	}
+	if m.MemtableUsage != 0 {
+		n += 1 + sovShardnode(uint64(m.MemtableUsage))
+	}
+	if m.BlockPinnedUsage != 0 {
+		n += 1 + sovShardnode(uint64(m.BlockPinnedUsage))
+	}
+	if m.TotalMemoryUsage != 0 {
+		n += 1 + sovShardnode(uint64(m.TotalMemoryUsage))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovShardnode(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozShardnode(x uint64) (n int) {
+	return sovShardnode(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Item) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Item: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Item: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, Field{})
+			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Field) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Field: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Field: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= github_com_cubefs_cubefs_blobstore_common_proto.FieldID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardOpHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardOpHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpaceID", wireType)
+			}
+			m.SpaceID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SpaceID |= github_com_cubefs_cubefs_blobstore_common_proto.SpaceID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			}
+			m.DiskID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			}
+			m.Suid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpaceVersion", wireType)
+			}
+			m.SpaceVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SpaceVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RouteVersion", wireType)
+			}
+			m.RouteVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RouteVersion |= github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShardKeys = append(m.ShardKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ShardKeys[len(m.ShardKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Sync = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderTerm", wireType)
+			}
+			m.LeaderTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderTerm |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InsertItemArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InsertItemArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InsertItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InsertItemRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InsertItemRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InsertItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateItemArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateItemArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateItemRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateItemRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteItemArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteItemArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteItemRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteItemRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetItemArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetItemArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetItemRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetItemRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListItemArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListItemArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Marker == nil {
+				m.Marker = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filters", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Filters = append(m.Filters, FieldFilter{})
+			if err := m.Filters[len(m.Filters)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListItemRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListItemRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, Item{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextMarker == nil {
+				m.NextMarker = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddShardArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddShardArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddShardArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			}
+			m.DiskID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			}
+			m.Suid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Range", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Range.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Units", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Units = append(m.Units, clustermgr.ShardUnit{})
+			if err := m.Units[len(m.Units)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RouteVersion", wireType)
+			}
+			m.RouteVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RouteVersion |= github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddShardRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddShardRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddShardRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateShardArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateShardArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateShardArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			}
+			m.DiskID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			}
+			m.Suid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardUpdateType", wireType)
+			}
+			m.ShardUpdateType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardUpdateType |= github_com_cubefs_cubefs_blobstore_common_proto.ShardUpdateType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Unit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateShardRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateShardRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateShardRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferShardLeaderArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferShardLeaderArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferShardLeaderArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			}
+			m.DiskID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			}
+			m.Suid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DestDiskID", wireType)
+			}
+			m.DestDiskID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DestDiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferShardLeaderRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferShardLeaderRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferShardLeaderRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetShardReadonlyArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetShardReadonlyArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetShardReadonlyArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			}
+			m.DiskID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			}
+			m.Suid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Readonly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Readonly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetShardArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetShardArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetShardArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			}
+			m.DiskID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			}
+			m.Suid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetShardRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetShardRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetShardRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateBlobArgs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeMode", wireType)
+			}
+			m.CodeMode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeMode |= github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SliceSize", wireType)
+			}
+			m.SliceSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SliceSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codec", wireType)
+			}
+			m.Codec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Codec |= github_com_cubefs_cubefs_blobstore_common_proto.Codec(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RawSize", wireType)
+			}
+			m.RawSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RawSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
-		n += 1 + sovShardnode(uint64(l)) + l
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func (m *ListShardArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
-	}
-	if m.ShardID != 0 {
-		n += 1 + sovShardnode(uint64(m.ShardID))
-	}
-	if m.Count != 0 {
-		n += 1 + sovShardnode(uint64(m.Count))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *ListShardBaseInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
-	}
-	if m.ShardID != 0 {
-		n += 1 + sovShardnode(uint64(m.ShardID))
-	}
-	if m.Suid != 0 {
-		n += 1 + sovShardnode(uint64(m.Suid))
-	}
-	if m.Index != 0 {
-		n += 1 + sovShardnode(uint64(m.Index))
-	}
-	if m.Epoch != 0 {
-		n += 1 + sovShardnode(uint64(m.Epoch))
-	}
-	if len(m.Units) > 0 {
-		for _, e := range m.Units {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
+func (m *CreateBlobRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
 		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *ListShardRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Shards) > 0 {
-		for _, e := range m.Shards {
-			l = e.Size()
-			n += 1 + l + sovShardnode(uint64(l))
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateBlobRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Blob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *TCMallocArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *TCMallocRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Stats)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *DBStatsArgs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.DiskID != 0 {
-		n += 1 + sovShardnode(uint64(m.DiskID))
-	}
-	l = len(m.DBName)
-	if l > 0 {
-		n += 1 + l + sovShardnode(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func (m *DBStatsRet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Used != 0 {
-		n += 1 + sovShardnode(uint64(m.Used))
-	}
-	if m.BlobCacheUsage != 0 {
-		n += 1 + sovShardnode(uint64(m.BlobCacheUsage))
-	}
-	if m.IndexAndFilterUsage != 0 {
-		n += 1 + sovShardnode(uint64(m.IndexAndFilterUsage))
-	}
-	if m.MemtableUsage != 0 {
-		n += 1 + sovShardnode(uint64(m.MemtableUsage))
-	}
-	if m.BlockPinnedUsage != 0 {
-		n += 1 + sovShardnode(uint64(m.BlockPinnedUsage))
-	}
-	if m.TotalMemoryUsage != 0 {
-		n += 1 + sovShardnode(uint64(m.TotalMemoryUsage))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovShardnode(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozShardnode(x uint64) (n int) {
-	return sovShardnode(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Item) Unmarshal(dAtA []byte) error {
+func (m *GetBlobArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5641,17 +11504,17 @@ func (m *Item) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Item: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetBlobArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Item: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5661,31 +11524,30 @@ func (m *Item) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5695,24 +11557,24 @@ func (m *Item) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fields = append(m.Fields, Field{})
-			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -5737,7 +11599,7 @@ func (m *Item) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Field) Unmarshal(dAtA []byte) error {
+func (m *GetBlobRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5760,36 +11622,17 @@ func (m *Field) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Field: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetBlobRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Field: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowShardnode
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= github_com_cubefs_cubefs_blobstore_common_proto.FieldID(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5799,24 +11642,23 @@ func (m *Field) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			if err := m.Blob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -5841,7 +11683,7 @@ func (m *Field) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
+func (m *ListBlobArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5864,17 +11706,17 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardOpHeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListBlobArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardOpHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SpaceID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			m.SpaceID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5884,16 +11726,30 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SpaceID |= github_com_cubefs_cubefs_blobstore_common_proto.SpaceID(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
-			m.DiskID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5903,16 +11759,31 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
 			}
-			m.Suid = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5922,16 +11793,31 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Marker == nil {
+				m.Marker = []byte{}
+			}
+			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SpaceVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			m.SpaceVersion = 0
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5941,16 +11827,16 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SpaceVersion |= uint64(b&0x7F) << shift
+				m.Count |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RouteVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Vid", wireType)
 			}
-			m.RouteVersion = 0
+			m.Vid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -5960,14 +11846,99 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RouteVersion |= github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion(b&0x7F) << shift
+				m.Vid |= github_com_cubefs_cubefs_blobstore_common_proto.Vid(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardnode(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListBlobRet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardnode
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListBlobRet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Blobs = append(m.Blobs, proto1.Blob{})
+			if err := m.Blobs[len(m.Blobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5994,8 +11965,10 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ShardKeys = append(m.ShardKeys, make([]byte, postIndex-iNdEx))
-			copy(m.ShardKeys[len(m.ShardKeys)-1], dAtA[iNdEx:postIndex])
+			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextMarker == nil {
+				m.NextMarker = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6019,7 +11992,7 @@ func (m *ShardOpHeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InsertItemArgs) Unmarshal(dAtA []byte) error {
+func (m *DeleteBlobArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6042,10 +12015,10 @@ func (m *InsertItemArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InsertItemArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteBlobArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InsertItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6083,9 +12056,9 @@ func (m *InsertItemArgs) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -6095,23 +12068,24 @@ func (m *InsertItemArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -6136,7 +12110,7 @@ func (m *InsertItemArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InsertItemRet) Unmarshal(dAtA []byte) error {
+func (m *DeleteBlobRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6159,10 +12133,10 @@ func (m *InsertItemRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InsertItemRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteBlobRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InsertItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -6187,7 +12161,7 @@ func (m *InsertItemRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateItemArgs) Unmarshal(dAtA []byte) error {
+func (m *TrashBlob) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6210,15 +12184,15 @@ func (m *UpdateItemArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateItemArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: TrashBlob: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TrashBlob: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6245,15 +12219,34 @@ func (m *UpdateItemArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Blob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTime", wireType)
+			}
+			m.ExpireTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeys", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -6263,76 +12256,43 @@ func (m *UpdateItemArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ShardKeys = append(m.ShardKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ShardKeys[len(m.ShardKeys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipShardnode(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthShardnode
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *UpdateItemRet) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowShardnode
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateItemRet: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -6355,7 +12315,7 @@ func (m *UpdateItemRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteItemArgs) Unmarshal(dAtA []byte) error {
+func (m *TrashBlobArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6378,10 +12338,10 @@ func (m *DeleteItemArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteItemArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: TrashBlobArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TrashBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6419,7 +12379,7 @@ func (m *DeleteItemArgs) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6446,11 +12406,30 @@ func (m *DeleteItemArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTime", wireType)
+			}
+			m.ExpireTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -6473,7 +12452,7 @@ func (m *DeleteItemArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteItemRet) Unmarshal(dAtA []byte) error {
+func (m *TrashBlobRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6496,10 +12475,10 @@ func (m *DeleteItemRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteItemRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: TrashBlobRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TrashBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -6524,7 +12503,7 @@ func (m *DeleteItemRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetItemArgs) Unmarshal(dAtA []byte) error {
+func (m *UndeleteBlobArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6547,10 +12526,10 @@ func (m *GetItemArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetItemArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: UndeleteBlobArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UndeleteBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6588,7 +12567,7 @@ func (m *GetItemArgs) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6615,9 +12594,9 @@ func (m *GetItemArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -6642,7 +12621,7 @@ func (m *GetItemArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetItemRet) Unmarshal(dAtA []byte) error {
+func (m *UndeleteBlobRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6665,15 +12644,15 @@ func (m *GetItemRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetItemRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: UndeleteBlobRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UndeleteBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6700,7 +12679,7 @@ func (m *GetItemRet) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Blob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -6726,7 +12705,7 @@ func (m *GetItemRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListItemArgs) Unmarshal(dAtA []byte) error {
+func (m *ListTrashBlobArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6749,10 +12728,10 @@ func (m *ListItemArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListItemArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListTrashBlobArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListItemArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListTrashBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6789,40 +12768,6 @@ func (m *ListItemArgs) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowShardnode
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthShardnode
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthShardnode
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
-			if m.Prefix == nil {
-				m.Prefix = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
 			}
@@ -6856,7 +12801,7 @@ func (m *ListItemArgs) Unmarshal(dAtA []byte) error {
 				m.Marker = []byte{}
 			}
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
@@ -6897,7 +12842,7 @@ func (m *ListItemArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListItemRet) Unmarshal(dAtA []byte) error {
+func (m *ListTrashBlobRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6920,15 +12865,15 @@ func (m *ListItemRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListItemRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListTrashBlobRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListItemRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListTrashBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6955,8 +12900,8 @@ func (m *ListItemRet) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, Item{})
-			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Blobs = append(m.Blobs, TrashBlob{})
+			if err := m.Blobs[len(m.Blobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -7016,7 +12961,7 @@ func (m *ListItemRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddShardArgs) Unmarshal(dAtA []byte) error {
+func (m *PurgeTrashBlobArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7039,53 +12984,15 @@ func (m *AddShardArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddShardArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: PurgeTrashBlobArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddShardArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PurgeTrashBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
-			}
-			m.DiskID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowShardnode
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
-			}
-			m.Suid = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowShardnode
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Range", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7112,15 +13019,15 @@ func (m *AddShardArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Range.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Units", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7130,31 +13037,31 @@ func (m *AddShardArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Units = append(m.Units, clustermgr.ShardUnit{})
-			if err := m.Units[len(m.Units)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RouteVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.RouteVersion = 0
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7164,7 +13071,7 @@ func (m *AddShardArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RouteVersion |= github_com_cubefs_cubefs_blobstore_common_proto.RouteVersion(b&0x7F) << shift
+				m.Version |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7191,7 +13098,7 @@ func (m *AddShardArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddShardRet) Unmarshal(dAtA []byte) error {
+func (m *PurgeTrashBlobRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7214,10 +13121,10 @@ func (m *AddShardRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddShardRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: PurgeTrashBlobRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddShardRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PurgeTrashBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -7242,7 +13149,7 @@ func (m *AddShardRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateShardArgs) Unmarshal(dAtA []byte) error {
+func (m *DedupRef) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7265,17 +13172,17 @@ func (m *UpdateShardArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateShardArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: DedupRef: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateShardArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DedupRef: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			m.DiskID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7285,52 +13192,29 @@ func (m *UpdateShardArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
 			}
-			m.Suid = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowShardnode
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardUpdateType", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.ShardUpdateType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowShardnode
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ShardUpdateType |= github_com_cubefs_cubefs_blobstore_common_proto.ShardUpdateType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
-		case 4:
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7357,10 +13241,29 @@ func (m *UpdateShardArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Unit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Location.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefCount", wireType)
+			}
+			m.RefCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RefCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -7383,7 +13286,7 @@ func (m *UpdateShardArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateShardRet) Unmarshal(dAtA []byte) error {
+func (m *RefDedupArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7406,68 +13309,84 @@ func (m *UpdateShardRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateShardRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: RefDedupArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateShardRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RefDedupArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipShardnode(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TransferShardLeaderArgs) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowShardnode
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if iNdEx >= l {
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TransferShardLeaderArgs: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferShardLeaderArgs: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			m.DiskID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7477,16 +13396,31 @@ func (m *TransferShardLeaderArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
 			}
-			m.Suid = 0
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7496,16 +13430,30 @@ func (m *TransferShardLeaderArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DestDiskID", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
 			}
-			m.DestDiskID = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Location.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7515,11 +13463,26 @@ func (m *TransferShardLeaderArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DestDiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ref = append(m.Ref[:0], dAtA[iNdEx:postIndex]...)
+			if m.Ref == nil {
+				m.Ref = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -7542,7 +13505,7 @@ func (m *TransferShardLeaderArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferShardLeaderRet) Unmarshal(dAtA []byte) error {
+func (m *RefDedupRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7565,12 +13528,45 @@ func (m *TransferShardLeaderRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferShardLeaderRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: RefDedupRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferShardLeaderRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RefDedupRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Ref.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -7593,7 +13589,7 @@ func (m *TransferShardLeaderRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetShardArgs) Unmarshal(dAtA []byte) error {
+func (m *GetDedupArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7616,17 +13612,17 @@ func (m *GetShardArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetShardArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetDedupArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetShardArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetDedupArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DiskID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			m.DiskID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7636,16 +13632,30 @@ func (m *GetShardArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DiskID |= github_com_cubefs_cubefs_blobstore_common_proto.DiskID(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			m.Suid = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7655,11 +13665,26 @@ func (m *GetShardArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -7682,7 +13707,7 @@ func (m *GetShardArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetShardRet) Unmarshal(dAtA []byte) error {
+func (m *GetDedupRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7705,15 +13730,15 @@ func (m *GetShardRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetShardRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetDedupRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetShardRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetDedupRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7740,10 +13765,44 @@ func (m *GetShardRet) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Ref.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstRef", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FirstRef = append(m.FirstRef[:0], dAtA[iNdEx:postIndex]...)
+			if m.FirstRef == nil {
+				m.FirstRef = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -7766,7 +13825,7 @@ func (m *GetShardRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
+func (m *UnrefDedupArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7789,10 +13848,10 @@ func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateBlobArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: UnrefDedupArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UnrefDedupArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7830,7 +13889,7 @@ func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -7857,16 +13916,16 @@ func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
-			if m.Name == nil {
-				m.Name = []byte{}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeMode", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
 			}
-			m.CodeMode = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7876,16 +13935,31 @@ func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CodeMode |= github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ref = append(m.Ref[:0], dAtA[iNdEx:postIndex]...)
+			if m.Ref == nil {
+				m.Ref = []byte{}
+			}
+			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
 			}
-			m.Size_ = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -7895,30 +13969,25 @@ func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Size_ |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SliceSize", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthShardnode
 			}
-			m.SliceSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowShardnode
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SliceSize |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			if err := m.Location.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -7941,7 +14010,7 @@ func (m *CreateBlobArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateBlobRet) Unmarshal(dAtA []byte) error {
+func (m *UnrefDedupRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7964,15 +14033,15 @@ func (m *CreateBlobRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateBlobRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: UnrefDedupRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UnrefDedupRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7999,10 +14068,50 @@ func (m *CreateBlobRet) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Blob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Ref.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unreferred", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Unreferred = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Last = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -8025,7 +14134,7 @@ func (m *CreateBlobRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetBlobArgs) Unmarshal(dAtA []byte) error {
+func (m *FieldFilter) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8048,17 +14157,17 @@ func (m *GetBlobArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetBlobArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: FieldFilter: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FieldFilter: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FieldID", wireType)
 			}
-			var msglen int
+			m.FieldID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -8068,28 +14177,33 @@ func (m *GetBlobArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.FieldID |= github_com_cubefs_cubefs_blobstore_common_proto.FieldID(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthShardnode
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthShardnode
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			}
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= FilterOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -8116,9 +14230,9 @@ func (m *GetBlobArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
-			if m.Name == nil {
-				m.Name = []byte{}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -8143,7 +14257,7 @@ func (m *GetBlobArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetBlobRet) Unmarshal(dAtA []byte) error {
+func (m *TxnCond) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8166,17 +14280,55 @@ func (m *GetBlobRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetBlobRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxnCond: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxnCond: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= TxnCondType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FieldID", wireType)
+			}
+			m.FieldID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FieldID |= github_com_cubefs_cubefs_blobstore_common_proto.FieldID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -8186,23 +14338,24 @@ func (m *GetBlobRet) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Blob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -8227,7 +14380,7 @@ func (m *GetBlobRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListBlobArgs) Unmarshal(dAtA []byte) error {
+func (m *TxnOp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8250,17 +14403,17 @@ func (m *ListBlobArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListBlobArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxnOp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxnOp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var msglen int
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -8270,28 +14423,14 @@ func (m *ListBlobArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Type |= TxnOpType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthShardnode
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthShardnode
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeys", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -8318,16 +14457,14 @@ func (m *ListBlobArgs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
-			if m.Prefix == nil {
-				m.Prefix = []byte{}
-			}
+			m.ShardKeys = append(m.ShardKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ShardKeys[len(m.ShardKeys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -8337,31 +14474,30 @@ func (m *ListBlobArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
-			if m.Marker == nil {
-				m.Marker = []byte{}
+			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			m.Count = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -8371,65 +14507,29 @@ func (m *ListBlobArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Count |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipShardnode(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ListBlobRet) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowShardnode
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardnode
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ListBlobRet: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8456,16 +14556,15 @@ func (m *ListBlobRet) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Blobs = append(m.Blobs, proto1.Blob{})
-			if err := m.Blobs[len(m.Blobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Blob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cond", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -8475,26 +14574,44 @@ func (m *ListBlobRet) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
-			if m.NextMarker == nil {
-				m.NextMarker = []byte{}
+			if err := m.Cond.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTime", wireType)
+			}
+			m.ExpireTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -8517,7 +14634,7 @@ func (m *ListBlobRet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteBlobArgs) Unmarshal(dAtA []byte) error {
+func (m *CommitTxnArgs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8540,10 +14657,10 @@ func (m *DeleteBlobArgs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteBlobArgs: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommitTxnArgs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteBlobArgs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommitTxnArgs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8581,9 +14698,9 @@ func (m *DeleteBlobArgs) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ops", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowShardnode
@@ -8593,24 +14710,24 @@ func (m *DeleteBlobArgs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthShardnode
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthShardnode
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
-			if m.Name == nil {
-				m.Name = []byte{}
+			m.Ops = append(m.Ops, TxnOp{})
+			if err := m.Ops[len(m.Ops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -8635,7 +14752,7 @@ func (m *DeleteBlobArgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteBlobRet) Unmarshal(dAtA []byte) error {
+func (m *CommitTxnRet) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8658,10 +14775,10 @@ func (m *DeleteBlobRet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteBlobRet: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommitTxnRet: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteBlobRet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommitTxnRet: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -9646,6 +15763,45 @@ func (m *ShardStats) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderTerm", wireType)
+			}
+			m.LeaderTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderTerm |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Readonly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardnode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Readonly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShardnode(dAtA[iNdEx:])
@@ -0,0 +1,26 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package shardnode
+
+import (
+	"context"
+)
+
+// CommitTxn commits operations of items and blobs in one shard atomically,
+// returns ErrTxnConflict if any condition is not satisfied, the caller could
+// read the latest values and retry
+func (c *Client) CommitTxn(ctx context.Context, host string, args CommitTxnArgs) error {
+	return c.doRequest(ctx, host, "/txn/commit", &args, nil)
+}
@@ -0,0 +1,362 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+/*
+ * Encryption at rest of shard payloads:
+ *   - the data keys of disk are wrapped by the key encryption key(KEK) with AES-GCM,
+ *     the KEKs are provided by the registered KEKProvider, static KEKs of config by default.
+ *   - the payload is split into units, each unit is sealed by AES-GCM of data key, the sealed
+ *     unit is the plaintext followed by the tag, so each one is authenticated independently
+ *     and range reads open the covered units only.
+ *     the nonce of unit is the random nonce of chunk and the position of unit in chunk,
+ *     the position is never reused in one chunk, so it's unique with the data key.
+ */
+
+const (
+	KeySize   = 32
+	NonceSize = 8
+	TagSize   = 16
+
+	gcmNonceSize = NonceSize + 8
+)
+
+var (
+	ErrKEKNotFound      = errors.New("encrypt: kek not found")
+	ErrNoActiveKEK      = errors.New("encrypt: no active kek")
+	ErrInvalidKey       = errors.New("encrypt: invalid key")
+	ErrWrappedKeyBad    = errors.New("encrypt: wrapped key is broken")
+	ErrUnitAuthFailed   = errors.New("encrypt: sealed unit authentication failed")
+	ErrProviderNotFound = errors.New("encrypt: kek provider not found")
+)
+
+// Config of encryption at rest
+type Config struct {
+	Enable bool `json:"enable"`
+	// KEKs are the base64 encoded AES keys by id, keep the retired ones to unwrap the old data keys
+	KEKs      map[string]string `json:"keks"`
+	ActiveKEK string            `json:"active_kek"`
+
+	// Provider is name of the registered KEK provider, static KEKs above are used if empty
+	Provider       string            `json:"provider"`
+	ProviderConfig map[string]string `json:"provider_config"`
+}
+
+// String hides the keys and the config of provider
+func (c Config) String() string {
+	ids := make([]string, 0, len(c.KEKs))
+	for id := range c.KEKs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("{Enable:%v KEKs:%v ActiveKEK:%s Provider:%s}", c.Enable, ids, c.ActiveKEK, c.Provider)
+}
+
+// KEKProvider wraps and unwraps data keys, it may be implemented by KMS.
+// The active KEK may be changed at runtime, data keys wrapped by the retired
+// ones are rewrapped when rotating data key of disk.
+type KEKProvider interface {
+	ActiveID() string
+	Wrap(plain []byte) (kekID string, wrapped []byte, err error)
+	Unwrap(kekID string, wrapped []byte) (plain []byte, err error)
+}
+
+// NewKEKProviderFunc returns KEK provider of the disk, KEKs may be different for each disk
+type NewKEKProviderFunc func(cfg Config, diskPath string) (KEKProvider, error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]NewKEKProviderFunc)
+)
+
+// RegisterKEKProvider registers KEK provider with name, it should be called in init
+func RegisterKEKProvider(name string, fn NewKEKProviderFunc) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if _, ok := providers[name]; ok {
+		panic("encrypt: kek provider registered twice: " + name)
+	}
+	providers[name] = fn
+}
+
+// NewKEKProvider returns KEK provider of the disk by config
+func NewKEKProvider(cfg Config, diskPath string) (KEKProvider, error) {
+	if cfg.Provider == "" {
+		return NewStaticKEKProvider(cfg)
+	}
+	providersMu.RLock()
+	fn, ok := providers[cfg.Provider]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return fn(cfg, diskPath)
+}
+
+type staticKEKProvider struct {
+	active string
+	keks   map[string]cipher.AEAD
+}
+
+// NewStaticKEKProvider returns provider with the KEKs in config
+func NewStaticKEKProvider(cfg Config) (KEKProvider, error) {
+	p := &staticKEKProvider{active: cfg.ActiveKEK, keks: make(map[string]cipher.AEAD, len(cfg.KEKs))}
+	for id, encoded := range cfg.KEKs {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode kek %s: %s", id, err.Error())
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("kek %s: %s", id, err.Error())
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		p.keks[id] = aead
+	}
+	if _, ok := p.keks[p.active]; !ok {
+		return nil, ErrNoActiveKEK
+	}
+	return p, nil
+}
+
+func (p *staticKEKProvider) ActiveID() string {
+	return p.active
+}
+
+// Wrap seals the key with the active kek, the result is nonce + ciphertext
+func (p *staticKEKProvider) Wrap(plain []byte) (string, []byte, error) {
+	aead := p.keks[p.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", nil, err
+	}
+	return p.active, aead.Seal(nonce, nonce, plain, []byte(p.active)), nil
+}
+
+func (p *staticKEKProvider) Unwrap(kekID string, wrapped []byte) ([]byte, error) {
+	aead, ok := p.keks[kekID]
+	if !ok {
+		return nil, ErrKEKNotFound
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrWrappedKeyBad
+	}
+	nonce, sealed := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, []byte(kekID))
+	if err != nil {
+		return nil, ErrWrappedKeyBad
+	}
+	return plain, nil
+}
+
+// GenerateKey returns a random data key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GenerateNonce returns a random nonce of chunk
+func GenerateNonce() (nonce [NonceSize]byte, err error) {
+	_, err = io.ReadFull(rand.Reader, nonce[:])
+	return
+}
+
+// Key is the data key to seal payloads
+type Key struct {
+	aead cipher.AEAD
+}
+
+func NewKey(raw []byte) (*Key, error) {
+	if len(raw) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, gcmNonceSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{aead: aead}, nil
+}
+
+func unitNonce(nonce [NonceSize]byte, pos uint64) []byte {
+	var n [gcmNonceSize]byte
+	copy(n[:], nonce[:])
+	binary.BigEndian.PutUint64(n[NonceSize:], pos)
+	return n[:]
+}
+
+// Seal appends the sealed unit of plain at position pos to dst
+func (k *Key) Seal(dst, plain []byte, nonce [NonceSize]byte, pos uint64) []byte {
+	return k.aead.Seal(dst, unitNonce(nonce, pos), plain, nil)
+}
+
+// Open appends the plaintext of the sealed unit at position pos to dst
+func (k *Key) Open(dst, sealed []byte, nonce [NonceSize]byte, pos uint64) ([]byte, error) {
+	plain, err := k.aead.Open(dst, unitNonce(nonce, pos), sealed, nil)
+	if err != nil {
+		return nil, ErrUnitAuthFailed
+	}
+	return plain, nil
+}
+
+// SealedSize returns size of payload sealed by units, unit is the sealed size of one unit
+func SealedSize(size, unit int64) int64 {
+	plain := unit - TagSize
+	return size + (size+plain-1)/plain*TagSize
+}
+
+// SealedRange returns the sealed range covers units of plaintext range [from, to)
+func SealedRange(size, from, to, unit int64) (int64, int64) {
+	plain := unit - TagSize
+	sfrom := from / plain * unit
+	sto := (to + plain - 1) / plain * unit
+	if sealed := SealedSize(size, unit); sto > sealed {
+		sto = sealed
+	}
+	return sfrom, sto
+}
+
+// NewSealReader returns reader of the sealed payload of size from r, base is the
+// position of payload in chunk, unit at index i is sealed at position base+i*unit.
+func (k *Key) NewSealReader(r io.Reader, size int64, nonce [NonceSize]byte, base uint64, unit int64) io.Reader {
+	return &sealReader{key: k, r: r, remain: size, nonce: nonce, pos: base, unit: unit}
+}
+
+// NewOpenReader returns reader of plaintext [from, to) of payload, r reads the
+// sealed range from SealedRange, base is the position of payload in chunk.
+func (k *Key) NewOpenReader(r io.Reader, size int64, nonce [NonceSize]byte, base uint64, unit, from, to int64) io.Reader {
+	plain := unit - TagSize
+	idx := from / plain
+	return &openReader{
+		key: k, r: r, nonce: nonce, unit: unit,
+		pos:    base + uint64(idx*unit),
+		remain: size - idx*plain,
+		skip:   from - idx*plain,
+		limit:  to - from,
+	}
+}
+
+type sealReader struct {
+	key    *Key
+	r      io.Reader
+	remain int64
+	nonce  [NonceSize]byte
+	pos    uint64
+	unit   int64
+
+	buf []byte
+	off int
+}
+
+func (sr *sealReader) Read(p []byte) (n int, err error) {
+	if sr.off >= len(sr.buf) {
+		if sr.remain <= 0 {
+			return 0, io.EOF
+		}
+		plain := sr.unit - TagSize
+		if plain > sr.remain {
+			plain = sr.remain
+		}
+		if sr.buf == nil {
+			sr.buf = make([]byte, 0, sr.unit)
+		}
+		sr.buf = sr.buf[:plain]
+		if _, err = io.ReadFull(sr.r, sr.buf); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		sr.buf = sr.key.Seal(sr.buf[:0], sr.buf, sr.nonce, sr.pos)
+		sr.remain -= plain
+		sr.pos += uint64(sr.unit)
+		sr.off = 0
+	}
+	n = copy(p, sr.buf[sr.off:])
+	sr.off += n
+	return n, nil
+}
+
+type openReader struct {
+	key    *Key
+	r      io.Reader
+	nonce  [NonceSize]byte
+	pos    uint64
+	unit   int64
+	remain int64 // plaintext remain in payload from the current unit
+	skip   int64
+	limit  int64
+
+	buf []byte
+	off int
+}
+
+func (or *openReader) Read(p []byte) (n int, err error) {
+	if or.limit <= 0 {
+		return 0, io.EOF
+	}
+	if or.off >= len(or.buf) {
+		plain := or.unit - TagSize
+		if plain > or.remain {
+			plain = or.remain
+		}
+		if or.buf == nil {
+			or.buf = make([]byte, 0, or.unit)
+		}
+		sealed := or.buf[:plain+TagSize]
+		if _, err = io.ReadFull(or.r, sealed); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		if or.buf, err = or.key.Open(sealed[:0], sealed, or.nonce, or.pos); err != nil {
+			return 0, err
+		}
+		or.remain -= plain
+		or.pos += uint64(or.unit)
+		or.off = int(or.skip)
+		or.skip = 0
+	}
+	if int64(len(p)) > or.limit {
+		p = p[:or.limit]
+	}
+	n = copy(p, or.buf[or.off:])
+	or.off += n
+	or.limit -= int64(n)
+	return n, nil
+}
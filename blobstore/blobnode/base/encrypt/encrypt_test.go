@@ -0,0 +1,143 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package encrypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func genKEK(t *testing.T) string {
+	raw, err := GenerateKey()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestKEKProvider(t *testing.T) {
+	_, err := NewStaticKEKProvider(Config{})
+	require.ErrorIs(t, err, ErrNoActiveKEK)
+	_, err = NewStaticKEKProvider(Config{KEKs: map[string]string{"k1": "invalid"}, ActiveKEK: "k1"})
+	require.Error(t, err)
+
+	cfg := Config{Enable: true, KEKs: map[string]string{"k1": genKEK(t)}, ActiveKEK: "k1"}
+	require.False(t, strings.Contains(cfg.String(), cfg.KEKs["k1"]))
+	p1, err := NewStaticKEKProvider(cfg)
+	require.NoError(t, err)
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	kekID, wrapped, err := p1.Wrap(key)
+	require.NoError(t, err)
+	require.Equal(t, "k1", kekID)
+	plain, err := p1.Unwrap(kekID, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, key, plain)
+
+	_, err = p1.Unwrap("k2", wrapped)
+	require.ErrorIs(t, err, ErrKEKNotFound)
+	wrapped[len(wrapped)-1]++
+	_, err = p1.Unwrap(kekID, wrapped)
+	require.ErrorIs(t, err, ErrWrappedKeyBad)
+	_, err = p1.Unwrap(kekID, wrapped[:4])
+	require.ErrorIs(t, err, ErrWrappedKeyBad)
+
+	// rotate kek, the old one is kept to unwrap
+	cfg.KEKs["k2"] = genKEK(t)
+	cfg.ActiveKEK = "k2"
+	p2, err := NewStaticKEKProvider(cfg)
+	require.NoError(t, err)
+	kekID, wrapped, err = p1.Wrap(key)
+	require.NoError(t, err)
+	plain, err = p2.Unwrap(kekID, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, key, plain)
+}
+
+func TestKEKProviderRegister(t *testing.T) {
+	_, err := NewKEKProvider(Config{Provider: "not-exist"}, "/disk1")
+	require.ErrorIs(t, err, ErrProviderNotFound)
+
+	cfg := Config{Enable: true, KEKs: map[string]string{"k1": genKEK(t)}, ActiveKEK: "k1"}
+	var disks []string
+	RegisterKEKProvider("test", func(cfg Config, diskPath string) (KEKProvider, error) {
+		disks = append(disks, diskPath)
+		return NewStaticKEKProvider(cfg)
+	})
+	require.Panics(t, func() { RegisterKEKProvider("test", nil) })
+
+	p, err := NewKEKProvider(cfg, "/disk1")
+	require.NoError(t, err)
+	require.Equal(t, "k1", p.ActiveID())
+	require.Empty(t, disks)
+
+	cfg.Provider = "test"
+	_, err = NewKEKProvider(cfg, "/disk1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/disk1"}, disks)
+}
+
+func TestKeySealOpen(t *testing.T) {
+	_, err := NewKey([]byte("short"))
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	raw, err := GenerateKey()
+	require.NoError(t, err)
+	key, err := NewKey(raw)
+	require.NoError(t, err)
+	nonce, err := GenerateNonce()
+	require.NoError(t, err)
+
+	const (
+		unit = 64
+		base = 4096
+	)
+	for _, size := range []int64{1, 47, 48, 49, 96, 1000} {
+		data := make([]byte, size)
+		_, err = rand.Read(data)
+		require.NoError(t, err)
+
+		sealed, err := io.ReadAll(key.NewSealReader(bytes.NewReader(data), size, nonce, base, unit))
+		require.NoError(t, err)
+		require.Equal(t, SealedSize(size, unit), int64(len(sealed)))
+
+		// open any range
+		for _, r := range [][2]int64{{0, size}, {0, 1}, {size - 1, size}, {size / 3, size / 2}, {size / 2, size}} {
+			from, to := r[0], r[1]
+			sfrom, sto := SealedRange(size, from, to, unit)
+			plain, err := io.ReadAll(key.NewOpenReader(bytes.NewReader(sealed[sfrom:sto]), size, nonce, base, unit, from, to))
+			require.NoError(t, err)
+			require.Equal(t, data[from:to], plain)
+		}
+
+		// tampered data, other position or nonce is not authenticated
+		_, err = io.ReadAll(key.NewOpenReader(bytes.NewReader(sealed), size, nonce, base+unit, unit, 0, size))
+		require.ErrorIs(t, err, ErrUnitAuthFailed)
+		other := nonce
+		other[0]++
+		_, err = io.ReadAll(key.NewOpenReader(bytes.NewReader(sealed), size, other, base, unit, 0, size))
+		require.ErrorIs(t, err, ErrUnitAuthFailed)
+		sealed[len(sealed)-1]++
+		_, err = io.ReadAll(key.NewOpenReader(bytes.NewReader(sealed), size, nonce, base, unit, 0, size))
+		require.ErrorIs(t, err, ErrUnitAuthFailed)
+		_, err = io.ReadAll(key.NewOpenReader(bytes.NewReader(sealed[:len(sealed)-1]), size, nonce, base, unit, 0, size))
+		require.Error(t, err)
+	}
+}
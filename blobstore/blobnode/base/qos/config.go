@@ -15,6 +15,7 @@
 package qos
 
 import (
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base/flow"
 	"github.com/cubefs/cubefs/blobstore/common/iostat"
 	"github.com/cubefs/cubefs/blobstore/util/defaulter"
@@ -46,6 +47,35 @@ type Config struct {
 	WriteDiscard   int32 `json:"write_discard"`
 }
 
+// DiskQosConfig returns qos parameters of the config
+func (c Config) DiskQosConfig() bnapi.DiskQosConfig {
+	return bnapi.DiskQosConfig{
+		ReadQueueDepth:   c.ReadQueueDepth,
+		WriteQueueDepth:  c.WriteQueueDepth,
+		DeleteQueueDepth: c.DeleteQueueDepth,
+		ReadMBPS:         c.ReadMBPS,
+		WriteMBPS:        c.WriteMBPS,
+		BackgroundMBPS:   c.BackgroundMBPS,
+		ReadDiscard:      c.ReadDiscard,
+		WriteDiscard:     c.WriteDiscard,
+	}
+}
+
+// Override returns a copy of config with the parameters set in disk qos config
+func (c Config) Override(o bnapi.DiskQosConfig) Config {
+	dc := c.DiskQosConfig()
+	dc.Merge(o)
+	c.ReadQueueDepth = dc.ReadQueueDepth
+	c.WriteQueueDepth = dc.WriteQueueDepth
+	c.DeleteQueueDepth = dc.DeleteQueueDepth
+	c.ReadMBPS = dc.ReadMBPS
+	c.WriteMBPS = dc.WriteMBPS
+	c.BackgroundMBPS = dc.BackgroundMBPS
+	c.ReadDiscard = dc.ReadDiscard
+	c.WriteDiscard = dc.WriteDiscard
+	return c
+}
+
 type ParaConfig struct {
 	Bandwidth int64   `json:"bandwidth_MBPS"`
 	Factor    float64 `json:"factor"`
@@ -344,3 +344,17 @@ func TestQosTryAcquire(t *testing.T) {
 		require.False(t, ok)
 	}
 }
+
+func TestConfigOverride(t *testing.T) {
+	conf := Config{ReadQueueDepth: 1}
+	InitAndFixQosConfig(&conf)
+	override := bnapi.DiskQosConfig{ReadMBPS: 1, WriteQueueDepth: 8, ReadDiscard: 90}
+	overridden := conf.Override(override)
+	require.Equal(t, int64(1), overridden.ReadMBPS)
+	require.Equal(t, int32(8), overridden.WriteQueueDepth)
+	require.Equal(t, int32(90), overridden.ReadDiscard)
+	require.Equal(t, conf.WriteMBPS, overridden.WriteMBPS)
+	require.Equal(t, conf.ReadQueueDepth, overridden.ReadQueueDepth)
+	require.Equal(t, int64(defaultReadBandwidthMBPS), conf.ReadMBPS)
+	require.Equal(t, conf, conf.Override(bnapi.DiskQosConfig{}))
+}
@@ -39,6 +39,13 @@ func IsEIO(err error) bool {
 	return strings.Contains(errMsg, syscall.EIO.Error()) || strings.Contains(errMsg, syscall.EROFS.Error())
 }
 
+func IsENOSPC(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), syscall.ENOSPC.Error())
+}
+
 func IsFileExists(filename string) (bool, error) {
 	absPath, err := filepath.Abs(filename)
 	if err != nil {
@@ -33,6 +33,8 @@ type BufConfig struct {
 	MigrateBufCapacity int `json:"migrate_buf_capacity"`
 	RepairBufSize      int `json:"repair_buf_size"`
 	RepairBufCapacity  int `json:"repair_buf_capacity"`
+	// NumaEnable buffer pool with sub-pool per NUMA node
+	NumaEnable bool `json:"numa_enable"`
 }
 
 type BufPool struct {
@@ -55,6 +57,9 @@ func NewBufPool(cfg *BufConfig) *BufPool {
 		cfg.MigrateBufSize: cfg.MigrateBufCapacity,
 	}
 	bufPool := resourcepool.NewMemPool(sizeClasses)
+	if cfg.NumaEnable {
+		bufPool = resourcepool.NewNumaMemPool(sizeClasses)
+	}
 	return &BufPool{
 		bufPool:        bufPool,
 		migrateBufSize: cfg.MigrateBufSize,
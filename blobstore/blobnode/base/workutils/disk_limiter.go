@@ -0,0 +1,211 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package workutils
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
+)
+
+const (
+	defaultMinConcurrency   = 1
+	defaultMaxConcurrency   = 8
+	defaultLatencyCeilingMs = 500
+	defaultDecreaseFactor   = 0.5
+)
+
+// AdaptiveConfig adaptive concurrency of writing to destination disk,
+// the concurrency is increased additively while the latency is below the ceiling,
+// and decreased multiplicatively if the latency is over the ceiling or the disk is overloaded.
+type AdaptiveConfig struct {
+	Enable         bool `json:"enable"`
+	MinConcurrency int  `json:"min_concurrency"`
+	MaxConcurrency int  `json:"max_concurrency"`
+	// LatencyCeilingMs latency ceiling of putting one shard to destination disk
+	LatencyCeilingMs int64   `json:"latency_ceiling_ms"`
+	DecreaseFactor   float64 `json:"decrease_factor"`
+}
+
+// CheckAndFix fix config
+func (conf *AdaptiveConfig) CheckAndFix() {
+	defaulter.LessOrEqual(&conf.MinConcurrency, defaultMinConcurrency)
+	defaulter.LessOrEqual(&conf.MaxConcurrency, defaultMaxConcurrency)
+	defaulter.LessOrEqual(&conf.LatencyCeilingMs, int64(defaultLatencyCeilingMs))
+	if conf.MaxConcurrency < conf.MinConcurrency {
+		conf.MaxConcurrency = conf.MinConcurrency
+	}
+	if conf.DecreaseFactor <= 0 || conf.DecreaseFactor >= 1 {
+		conf.DecreaseFactor = defaultDecreaseFactor
+	}
+}
+
+// DiskLimiter limits the concurrency of writing to one destination disk by AIMD,
+// nil limiter means no limit.
+type DiskLimiter struct {
+	conf    AdaptiveConfig
+	ceiling time.Duration
+
+	mu           sync.Mutex
+	limit        float64
+	inflight     int
+	lastDecrease time.Time
+	notify       chan struct{}
+}
+
+func newDiskLimiter(conf AdaptiveConfig) *DiskLimiter {
+	return &DiskLimiter{
+		conf:    conf,
+		ceiling: time.Duration(conf.LatencyCeilingMs) * time.Millisecond,
+		limit:   float64(conf.MinConcurrency),
+		notify:  make(chan struct{}),
+	}
+}
+
+// Acquire waits for a slot of the disk until ctx is done
+func (l *DiskLimiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		if l.inflight < int(l.limit) {
+			l.inflight++
+			l.mu.Unlock()
+			return nil
+		}
+		notify := l.notify
+		l.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release releases the slot acquired
+func (l *DiskLimiter) Release() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	l.inflight--
+	l.wakeup()
+	l.mu.Unlock()
+}
+
+// Feedback adjusts the concurrency by the cost and error of one request to the disk
+func (l *DiskLimiter) Feedback(cost time.Duration, err error) {
+	if l == nil {
+		return
+	}
+	overloaded := cost > l.ceiling
+	if err != nil {
+		code := rpc.DetectStatusCode(err)
+		overloaded = overloaded || code == errcode.CodeOverload || code == http.StatusServiceUnavailable
+		if !overloaded {
+			return
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if overloaded {
+		// requests in flight see the same overload, decrease once in a ceiling period
+		now := time.Now()
+		if now.Sub(l.lastDecrease) < l.ceiling {
+			return
+		}
+		l.lastDecrease = now
+		l.limit *= l.conf.DecreaseFactor
+		if l.limit < float64(l.conf.MinConcurrency) {
+			l.limit = float64(l.conf.MinConcurrency)
+		}
+		return
+	}
+
+	// increase about one after a round of the current concurrency
+	before := int(l.limit)
+	l.limit += 1 / l.limit
+	if l.limit > float64(l.conf.MaxConcurrency) {
+		l.limit = float64(l.conf.MaxConcurrency)
+	}
+	if int(l.limit) > before {
+		l.wakeup()
+	}
+}
+
+// Concurrency returns the current concurrency limit
+func (l *DiskLimiter) Concurrency() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+func (l *DiskLimiter) wakeup() {
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// DiskLimiters adaptive concurrency limiters of destination disks
+type DiskLimiters struct {
+	conf AdaptiveConfig
+
+	mu       sync.Mutex
+	limiters map[proto.DiskID]*DiskLimiter
+}
+
+// NewDiskLimiters returns limiters of disks
+func NewDiskLimiters(conf AdaptiveConfig) *DiskLimiters {
+	conf.CheckAndFix()
+	return &DiskLimiters{conf: conf, limiters: make(map[proto.DiskID]*DiskLimiter)}
+}
+
+// Get returns limiter of the disk, nil if adaptive concurrency is disabled
+func (ls *DiskLimiters) Get(diskID proto.DiskID) *DiskLimiter {
+	if !ls.conf.Enable {
+		return nil
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	l, ok := ls.limiters[diskID]
+	if !ok {
+		l = newDiskLimiter(ls.conf)
+		ls.limiters[diskID] = l
+	}
+	return l
+}
+
+// Stats returns the current concurrency limit of disks
+func (ls *DiskLimiters) Stats() map[proto.DiskID]int {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	stats := make(map[proto.DiskID]int, len(ls.limiters))
+	for diskID, l := range ls.limiters {
+		stats[diskID] = l.Concurrency()
+	}
+	return stats
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package workutils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestDiskLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	// disabled
+	ls := NewDiskLimiters(AdaptiveConfig{})
+	l := ls.Get(1)
+	require.Nil(t, l)
+	require.NoError(t, l.Acquire(ctx))
+	l.Feedback(time.Second, nil)
+	l.Release()
+	require.Empty(t, ls.Stats())
+
+	ls = NewDiskLimiters(AdaptiveConfig{Enable: true, MaxConcurrency: 4, LatencyCeilingMs: 10})
+	l = ls.Get(1)
+	require.True(t, l == ls.Get(1))
+	require.Equal(t, 1, l.Concurrency())
+
+	// the slot is limited
+	require.NoError(t, l.Acquire(ctx))
+	ctxTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	require.ErrorIs(t, l.Acquire(ctxTimeout), context.DeadlineExceeded)
+	cancel()
+
+	// waiters are woken up after released or increased
+	done := make(chan struct{})
+	go func() {
+		require.NoError(t, l.Acquire(ctx))
+		close(done)
+	}()
+	l.Feedback(time.Millisecond, nil)
+	<-done
+	require.Equal(t, 2, l.Concurrency())
+	l.Release()
+	l.Release()
+
+	// additive increase, no more than max
+	for i := 0; i < 100; i++ {
+		l.Feedback(time.Millisecond, nil)
+	}
+	require.Equal(t, 4, l.Concurrency())
+	require.Equal(t, map[proto.DiskID]int{1: 4}, ls.Stats())
+
+	// other errors are ignored
+	l.Feedback(time.Millisecond, errors.New("fake error"))
+	require.Equal(t, 4, l.Concurrency())
+
+	// multiplicative decrease, once in a ceiling period
+	l.Feedback(20*time.Millisecond, nil)
+	require.Equal(t, 2, l.Concurrency())
+	l.Feedback(time.Millisecond, errcode.ErrOverload)
+	require.Equal(t, 2, l.Concurrency())
+	time.Sleep(10 * time.Millisecond)
+	l.Feedback(time.Millisecond, errcode.ErrOverload)
+	require.Equal(t, 1, l.Concurrency())
+	time.Sleep(10 * time.Millisecond)
+	l.Feedback(20*time.Millisecond, nil)
+	require.Equal(t, 1, l.Concurrency())
+}
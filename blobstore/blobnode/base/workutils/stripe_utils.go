@@ -61,21 +61,23 @@ func (s *BidExistStatus) CanRecover() bool {
 	return existInGlobalStripe >= s.mode.T().N
 }
 
-// IdxSplitByLocalStripe returns local stripe idx
-func IdxSplitByLocalStripe(idxs []uint8, mode codemode.CodeMode) [][]uint8 {
+// IdxSplitByLocalStripe returns local stripe idx, and the idx belongs to no local stripe
+// which can only be repaired by global stripe
+func IdxSplitByLocalStripe(idxs []uint8, mode codemode.CodeMode) (ret [][]uint8, globalIdxs []uint8) {
 	splitMap := make(map[int][]uint8)
 	tactic := mode.Tactic()
 	for _, idx := range idxs {
 		stripeIdxs, _, _ := tactic.LocalStripe(int(idx))
 		if len(stripeIdxs) == 0 {
+			globalIdxs = append(globalIdxs, idx)
 			continue
 		}
 		splitMap[stripeIdxs[0]] = append(splitMap[stripeIdxs[0]], idx)
 	}
 
-	ret := [][]uint8{}
+	ret = [][]uint8{}
 	for _, val := range splitMap {
 		ret = append(ret, val)
 	}
-	return ret
+	return ret, globalIdxs
 }
@@ -139,7 +139,14 @@ func testIdxSplitByIdc(t *testing.T, mode codemode.CodeMode) {
 		allIdxs = append(allIdxs, uint8(idx))
 	}
 
-	splitIdxs := IdxSplitByLocalStripe(allIdxs, mode)
+	splitIdxs, globalIdxs := IdxSplitByLocalStripe(allIdxs, mode)
+	if codeInfo.L == 0 {
+		// no local stripe, all units are repaired by global stripe
+		require.Empty(t, splitIdxs)
+		require.Equal(t, allIdxs, globalIdxs)
+		return
+	}
+	require.Empty(t, globalIdxs)
 	for _, idxs := range splitIdxs {
 		idcIdx := testGetIdcIdx(mode, int(idxs[0]))
 		var compareStripe []int
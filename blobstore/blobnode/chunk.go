@@ -73,6 +73,12 @@ func (s *Service) ChunkCreate(c *rpc.Context) {
 		return
 	}
 
+	if s.protectMgr.isReadonly(args.DiskID) {
+		span.Errorf("diskId:%d is readonly by protection", args.DiskID)
+		c.RespondError(bloberr.ErrReadonlyVUID)
+		return
+	}
+
 	cs, err := ds.CreateChunk(ctx, args.Vuid, args.ChunkSize)
 	if err != nil {
 		span.Errorf("Failed register vuid:%v, err:%v", args.DiskID, err)
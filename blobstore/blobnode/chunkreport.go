@@ -16,8 +16,10 @@ package blobnode
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base"
 	"github.com/cubefs/cubefs/blobstore/blobnode/core"
@@ -25,6 +27,8 @@ import (
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 )
 
+const countShardBatch = 1024
+
 // report chunk info to clusterMgr
 func (s *Service) loopReportChunkInfoToClusterMgr() {
 	span, _ := trace.StartSpanFromContextWithTraceID(context.Background(), "", "ChunkReport")
@@ -81,3 +85,94 @@ func (s *Service) reportChunkInfoToClusterMgr() {
 	}
 	span.Debugf("report chunks info to clusterMgr success")
 }
+
+// report usage of all chunks to clusterMgr for space reconciliation,
+// shards are counted by scanning meta, so it's less frequent than chunk report
+func (s *Service) loopReportChunkUsageToClusterMgr() {
+	span, _ := trace.StartSpanFromContextWithTraceID(context.Background(), "", "ChunkUsageReport")
+	span.Infof("loop report chunk usage to cluster mgr")
+
+	ticker := time.NewTicker(time.Duration(s.Conf.ChunkUsageReportIntervalSec) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closeCh:
+			span.Warnf("loop report chunk usage done.")
+			return
+		case <-ticker.C:
+			s.reportChunkUsageToClusterMgr()
+		}
+	}
+}
+
+func (s *Service) reportChunkUsageToClusterMgr() {
+	span, ctx := trace.StartSpanFromContextWithTraceID(context.Background(), "", base.BackgroudReqID("ChunkUsageReport"))
+
+	disks := s.copyDiskStorages(ctx)
+	args := &cmapi.ReportChunkUsageArgs{Disks: make([]cmapi.DiskChunkUsage, 0, len(disks))}
+	for _, ds := range disks {
+		if ds.Status() != proto.DiskStatusNormal {
+			continue
+		}
+		usage, err := diskChunkUsage(ctx, ds)
+		if err != nil {
+			// partial usage of disk would be mismatched, skip the disk
+			span.Warnf("get chunk usage of disk %d failed: %v", ds.ID(), err)
+			continue
+		}
+		args.Disks = append(args.Disks, usage)
+	}
+
+	if len(args.Disks) == 0 {
+		span.Debugf("do not need to report")
+		return
+	}
+	if err := s.ClusterMgrClient.ReportChunkUsage(ctx, args); err != nil {
+		span.Errorf("report chunk usage to clusterMgr failed: %v", err)
+		return
+	}
+	span.Debugf("report chunk usage of %d disks to clusterMgr success", len(args.Disks))
+}
+
+func diskChunkUsage(ctx context.Context, ds core.DiskAPI) (usage cmapi.DiskChunkUsage, err error) {
+	chunks := make([]core.ChunkAPI, 0)
+	_ = ds.WalkChunksWithLock(ctx, func(cs core.ChunkAPI) error {
+		chunks = append(chunks, cs)
+		return nil
+	})
+
+	usage.DiskID = ds.ID()
+	usage.Chunks = make([]cmapi.ChunkUsage, 0, len(chunks))
+	for _, cs := range chunks {
+		info := cs.ChunkInfo(ctx)
+		if info.Vuid != cs.Vuid() {
+			return usage, fmt.Errorf("stat chunk %s failed", cs.ID())
+		}
+		count, err := countChunkShards(ctx, cs)
+		if err != nil {
+			return usage, err
+		}
+		usage.Chunks = append(usage.Chunks, cmapi.ChunkUsage{
+			Vuid:       info.Vuid,
+			Allocated:  info.Total,
+			Used:       info.Used,
+			ShardCount: count,
+		})
+	}
+	return usage, nil
+}
+
+func countChunkShards(ctx context.Context, cs core.ChunkAPI) (count uint64, err error) {
+	startBid := proto.InValidBlobID
+	for {
+		shards, next, err := cs.ListShards(ctx, startBid, countShardBatch, bnapi.ShardStatusNormal)
+		if err != nil {
+			return 0, err
+		}
+		count += uint64(len(shards))
+		if next == proto.InValidBlobID {
+			return count, nil
+		}
+		startBid = next
+	}
+}
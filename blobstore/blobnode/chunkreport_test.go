@@ -103,6 +103,50 @@ func TestChunkReport(t *testing.T) {
 	require.Equal(t, false, cs.IsDirty())
 }
 
+func TestChunkUsageReport(t *testing.T) {
+	service, mcm := newTestBlobNodeService(t, "ChunkUsageReport")
+	defer cleanTestBlobNodeService(service)
+
+	host := runTestServer(service)
+	client := bnapi.New(&bnapi.Config{})
+	ctx := context.TODO()
+
+	diskID := proto.DiskID(101)
+	vuid := proto.Vuid(2001)
+	err := client.CreateChunk(ctx, host, &bnapi.CreateChunkArgs{DiskID: diskID, Vuid: vuid})
+	require.NoError(t, err)
+
+	shardData := []byte("testData")
+	for bid := proto.BlobID(1); bid <= 3; bid++ {
+		_, err = client.PutShard(ctx, host, &bnapi.PutShardArgs{
+			DiskID: diskID,
+			Vuid:   vuid,
+			Bid:    bid,
+			Size:   int64(len(shardData)),
+			Body:   bytes.NewReader(shardData),
+		})
+		require.NoError(t, err)
+	}
+	err = client.MarkDeleteShard(ctx, host, &bnapi.DeleteShardArgs{DiskID: diskID, Vuid: vuid, Bid: 3})
+	require.NoError(t, err)
+
+	ds, exist := service.Disks[diskID]
+	require.True(t, exist)
+	usage, err := diskChunkUsage(ctx, ds)
+	require.NoError(t, err)
+	require.Equal(t, diskID, usage.DiskID)
+	require.Equal(t, 1, len(usage.Chunks))
+	require.Equal(t, vuid, usage.Chunks[0].Vuid)
+	require.Equal(t, uint64(2), usage.Chunks[0].ShardCount)
+	require.Less(t, uint64(0), usage.Chunks[0].Allocated)
+
+	service.reportChunkUsageToClusterMgr()
+	mcm.usageLock.Lock()
+	defer mcm.usageLock.Unlock()
+	require.NotNil(t, mcm.usageReport)
+	require.Equal(t, len(service.Disks), len(mcm.usageReport.Disks))
+}
+
 func TestChunkReport2(t *testing.T) {
 	ctx := context.Background()
 
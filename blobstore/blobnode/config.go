@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
@@ -26,6 +27,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/blobnode/core"
 	"github.com/cubefs/cubefs/blobstore/blobnode/db"
 	"github.com/cubefs/cubefs/blobstore/cmd"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
@@ -37,11 +39,13 @@ import (
 const (
 	DefaultHeartbeatIntervalSec        = 30           // 30 s
 	DefaultChunkReportIntervalSec      = 60           // 1 min
+	DefaultChunkUsageReportIntervalSec = 60 * 60      // 60 min
 	DefaultCleanExpiredStatIntervalSec = 60 * 60      // 60 min
 	DefaultChunkGcIntervalSec          = 30 * 60      // 30 min
 	DefaultChunkInspectIntervalSec     = 24 * 60 * 60 // 24 hour
 	DefaultChunkProtectionPeriodSec    = 48 * 60 * 60 // 48 hour
 	DefaultDiskStatusCheckIntervalSec  = 2 * 60       // 2 min
+	DefaultDiskLoadTimeoutSec          = 5 * 60       // 5 min
 
 	DefaultDeleteQpsLimitPerDisk = 128
 	DefaultInspectRate           = 4 * 1024 * 1024 // rate limit 4MB per second
@@ -66,14 +70,20 @@ type Config struct {
 
 	HeartbeatIntervalSec        int `json:"heartbeat_interval_S"`
 	ChunkReportIntervalSec      int `json:"chunk_report_interval_S"`
+	ChunkUsageReportIntervalSec int `json:"chunk_usage_report_interval_S"`
 	ChunkGcIntervalSec          int `json:"chunk_gc_interval_S"`
 	ChunkProtectionPeriodSec    int `json:"chunk_protection_period_S"`
 	CleanExpiredStatIntervalSec int `json:"clean_expired_stat_interval_S"`
 	DiskStatusCheckIntervalSec  int `json:"disk_status_check_interval_S"`
+	DiskLoadTimeoutSec          int `json:"disk_load_timeout_S"`
 
 	DeleteQpsLimitPerDisk int `json:"delete_qps_limit_per_disk"`
 
-	InspectConf DataInspectConf `json:"inspect_conf"`
+	InspectConf    DataInspectConf     `json:"inspect_conf"`
+	QuarantineConf ShardQuarantineConf `json:"quarantine_conf"`
+	ProtectConf    DiskProtectConf     `json:"protect_conf"`
+	CacheConf      ShardCacheConf      `json:"cache_conf"`
+	ShutdownConf   ShutdownConf        `json:"shutdown_conf"`
 }
 
 func configInit(config *Config) {
@@ -98,10 +108,18 @@ func configInit(config *Config) {
 		config.DiskStatusCheckIntervalSec = DefaultDiskStatusCheckIntervalSec
 	}
 
+	if config.DiskLoadTimeoutSec <= 0 {
+		config.DiskLoadTimeoutSec = DefaultDiskLoadTimeoutSec
+	}
+
 	if config.ChunkReportIntervalSec <= 0 {
 		config.ChunkReportIntervalSec = DefaultChunkReportIntervalSec
 	}
 
+	if config.ChunkUsageReportIntervalSec <= 0 {
+		config.ChunkUsageReportIntervalSec = DefaultChunkUsageReportIntervalSec
+	}
+
 	if config.CleanExpiredStatIntervalSec <= 0 {
 		config.CleanExpiredStatIntervalSec = DefaultCleanExpiredStatIntervalSec
 	}
@@ -111,21 +129,31 @@ func configInit(config *Config) {
 	}
 	defaulter.LessOrEqual(&config.InspectConf.IntervalSec, DefaultChunkInspectIntervalSec)
 	defaulter.LessOrEqual(&config.InspectConf.RateLimit, DefaultInspectRate)
+	defaulter.LessOrEqual(&config.QuarantineConf.ExpireSec, DefaultQuarantineExpireSec)
+	defaulter.LessOrEqual(&config.QuarantineConf.ReportSize, DefaultQuarantineReportSize)
+	defaulter.LessOrEqual(&config.ProtectConf.WindowSec, DefaultDiskProtectWindowSec)
 	defaulter.LessOrEqual(&config.HostInfo.DiskType, proto.DiskTypeHDD)
+	defaulter.LessOrEqual(&config.CacheConf.Capacity, int64(DefaultShardCacheCapacity))
+	defaulter.LessOrEqual(&config.CacheConf.MaxShardSize, int64(DefaultShardCacheMaxShardSize))
+	defaulter.LessOrEqual(&config.CacheConf.HotThreshold, DefaultShardCacheHotThreshold)
+	defaulter.LessOrEqual(&config.CacheConf.WindowSec, DefaultShardCacheWindowSec)
+	defaulter.LessOrEqual(&config.ShutdownConf.DrainTimeoutSec, int(DefaultShutdownTimeout/time.Second))
+	defaulter.Empty(&config.ShutdownConf.StateFilename, DefaultShutdownStateFilename)
 }
 
-func (s *Service) changeLimit(ctx context.Context, c Config) {
-	span := trace.SpanFromContextSafe(ctx)
-	configInit(&c)
-	s.DeleteQpsLimitPerDisk.Reset(c.DeleteQpsLimitPerDisk)
-	span.Info("hot reload limit config success.")
+func (s *Service) changeLimit(limit int) error {
+	if limit <= 0 {
+		limit = DefaultDeleteQpsLimitPerDisk
+	}
+	s.DeleteQpsLimitPerDisk.Reset(limit)
+	log.Infof("hot reload delete qps limit per disk: %d", limit)
+	return nil
 }
 
-func (s *Service) changeQos(ctx context.Context, c Config) error {
-	span := trace.SpanFromContextSafe(ctx)
-	qosConf := c.DiskConfig.DataQos
-	span.Infof("qos config:%v", qosConf)
-	return s.reloadQos(ctx, qosConf)
+func (s *Service) changeQos(c core.RuntimeConfig) error {
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+	log.Infof("hot reload qos config: %+v", c.DataQos)
+	return s.reloadQos(ctx, c.DataQos)
 }
 
 func (s *Service) ConfigReload(c *rpc.Context) {
@@ -198,9 +226,148 @@ func (s *Service) reloadDataQos(ctx context.Context, args *bnapi.ConfigReloadArg
 }
 
 func (s *Service) reloadQos(ctx context.Context, qosConf qos.Config) error {
+	s.qosLock.Lock()
+	defer s.qosLock.Unlock()
+	s.Conf.DiskConfig.DataQos = qosConf
+
+	span := trace.SpanFromContextSafe(ctx)
 	disks := s.copyDiskStorages(ctx)
 	for _, ds := range disks {
-		ds.GetIoQos().ResetQosLimit(qosConf)
+		// keep qos parameters updated on the disk
+		override, err := core.ReadDiskQosConfig(ctx, ds.GetConfig().Path)
+		if err != nil {
+			span.Errorf("read qos config of disk %d failed: %v", ds.ID(), err)
+		}
+		ds.GetIoQos().ResetQosLimit(s.diskQosConfig(ds, override))
+	}
+	return nil
+}
+
+// diskQosConfig returns qos config of the disk, overridden by the runtime
+// config of all disks, then by the qos parameters updated on the disk
+func (s *Service) diskQosConfig(ds core.DiskAPI, override bnapi.DiskQosConfig) qos.Config {
+	return ds.GetConfig().DataQos.Override(s.Conf.DiskConfig.DataQos.DiskQosConfig()).Override(override)
+}
+
+func checkDiskQosConfig(conf *bnapi.DiskQosConfig) error {
+	for _, depth := range []int32{conf.ReadQueueDepth, conf.WriteQueueDepth, conf.DeleteQueueDepth} {
+		if depth < 0 || depth > qos.MaxQueueDepth {
+			return ErrValueOutOfLimit
+		}
+	}
+	for _, mbps := range []int64{conf.ReadMBPS, conf.WriteMBPS, conf.BackgroundMBPS} {
+		if mbps < 0 || mbps > 10000 {
+			return ErrValueOutOfLimit
+		}
+	}
+	for _, discard := range []int32{conf.ReadDiscard, conf.WriteDiscard} {
+		if discard < 0 || discard > 100 {
+			return ErrValueOutOfLimit
+		}
 	}
 	return nil
 }
+
+func (s *Service) getDiskForQos(c *rpc.Context, diskID proto.DiskID) (core.DiskAPI, bool) {
+	if !bnapi.IsValidDiskID(diskID) {
+		c.RespondError(bloberr.ErrInvalidDiskId)
+		return nil, false
+	}
+	s.lock.RLock()
+	ds, exist := s.Disks[diskID]
+	s.lock.RUnlock()
+	if !exist {
+		c.RespondError(bloberr.ErrNoSuchDisk)
+		return nil, false
+	}
+	return ds, true
+}
+
+// DiskQosSet updates qos parameters of the disk at runtime, and persists them
+// on the disk to survive restarts
+func (s *Service) DiskQosSet(c *rpc.Context) {
+	args := new(bnapi.DiskQosArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	span.Infof("disk qos set args:%+v", args)
+
+	if err := checkDiskQosConfig(&args.DiskQosConfig); err != nil {
+		c.RespondWith(http.StatusBadRequest, "", []byte(err.Error()))
+		return
+	}
+	ds, ok := s.getDiskForQos(c, args.DiskID)
+	if !ok {
+		return
+	}
+
+	s.qosLock.Lock()
+	defer s.qosLock.Unlock()
+	path := ds.GetConfig().Path
+	override, err := core.ReadDiskQosConfig(ctx, path)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+	override.Merge(args.DiskQosConfig)
+	if err = core.SaveDiskQosConfig(ctx, path, &override); err != nil {
+		c.RespondError(err)
+		return
+	}
+	ds.GetIoQos().ResetQosLimit(s.diskQosConfig(ds, override))
+	c.Respond()
+}
+
+// DiskQosReset removes qos parameters updated on the disk, and restores qos config of all disks
+func (s *Service) DiskQosReset(c *rpc.Context) {
+	args := new(bnapi.DiskStatArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	span.Infof("disk qos reset args:%+v", args)
+
+	ds, ok := s.getDiskForQos(c, args.DiskID)
+	if !ok {
+		return
+	}
+
+	s.qosLock.Lock()
+	defer s.qosLock.Unlock()
+	if err := core.RemoveDiskQosConfig(ctx, ds.GetConfig().Path); err != nil {
+		c.RespondError(err)
+		return
+	}
+	ds.GetIoQos().ResetQosLimit(s.diskQosConfig(ds, bnapi.DiskQosConfig{}))
+	c.Respond()
+}
+
+// DiskQosGet returns effective qos config of the disk and its persisted parameters
+func (s *Service) DiskQosGet(c *rpc.Context) {
+	args := new(bnapi.DiskStatArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	ctx := c.Request.Context()
+
+	ds, ok := s.getDiskForQos(c, args.DiskID)
+	if !ok {
+		return
+	}
+	override, err := core.ReadDiskQosConfig(ctx, ds.GetConfig().Path)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+	c.RespondJSON(&bnapi.DiskQosRet{
+		DiskID:   args.DiskID,
+		Current:  ds.GetIoQos().GetConfig().DiskQosConfig(),
+		Override: override,
+	})
+}
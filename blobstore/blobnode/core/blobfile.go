@@ -16,6 +16,7 @@ package core
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -89,11 +90,13 @@ func (ef *blobFile) WriteAt(b []byte, off int64) (n int, err error) {
 }
 
 func (ef *blobFile) ReadAtCtx(ctx context.Context, b []byte, off int64) (n int, err error) {
+	var executed bool
 	task := taskpool.IoPoolTaskArgs{
 		BucketId: ef.chunk,
 		Tm:       time.Now(),
 		Ctx:      ctx,
 		TaskFn: func() {
+			executed = true
 			select {
 			case <-ctx.Done():
 				n, err = 0, ctx.Err()
@@ -104,17 +107,22 @@ func (ef *blobFile) ReadAtCtx(ctx context.Context, b []byte, off int64) (n int,
 		},
 	}
 	ef.ioPools[qos.IOTypeRead].Submit(task)
+	if !executed { // dropped by io pool if ctx done
+		n, err = 0, ctx.Err()
+	}
 
 	ef.handleError(err)
 	return
 }
 
 func (ef *blobFile) WriteAtCtx(ctx context.Context, b []byte, off int64) (n int, err error) {
+	var executed bool
 	task := taskpool.IoPoolTaskArgs{
 		BucketId: ef.chunk,
 		Tm:       time.Now(),
 		Ctx:      ctx,
 		TaskFn: func() {
+			executed = true
 			select {
 			case <-ctx.Done():
 				n, err = 0, ctx.Err()
@@ -125,6 +133,9 @@ func (ef *blobFile) WriteAtCtx(ctx context.Context, b []byte, off int64) (n int,
 		},
 	}
 	ef.ioPools[qos.IOTypeWrite].Submit(task)
+	if !executed { // dropped by io pool if ctx done
+		n, err = 0, ctx.Err()
+	}
 
 	ef.handleError(err)
 	return
@@ -188,11 +199,41 @@ func (ef *blobFile) Close() error {
 }
 
 func (ef *blobFile) handleError(err error) {
-	if base.IsEIO(err) && ef.handleIOError != nil {
+	if (base.IsEIO(err) || base.IsENOSPC(err)) && ef.handleIOError != nil {
 		ef.handleIOError(err)
 	}
 }
 
+// ctxReaderAt reads blob file with ctx of request
+type ctxReaderAt struct {
+	ctx context.Context
+	ef  BlobFile
+}
+
+func (r *ctxReaderAt) ReadAt(b []byte, off int64) (n int, err error) {
+	return r.ef.ReadAtCtx(r.ctx, b, off)
+}
+
+// NewCtxReaderAt returns reader of blob file, io is cancelled if ctx done
+func NewCtxReaderAt(ctx context.Context, ef BlobFile) io.ReaderAt {
+	return &ctxReaderAt{ctx: ctx, ef: ef}
+}
+
+// ctxWriterAt writes blob file with ctx of request
+type ctxWriterAt struct {
+	ctx context.Context
+	ef  BlobFile
+}
+
+func (w *ctxWriterAt) WriteAt(b []byte, off int64) (n int, err error) {
+	return w.ef.WriteAtCtx(w.ctx, b, off)
+}
+
+// NewCtxWriterAt returns writer of blob file, io is cancelled if ctx done
+func NewCtxWriterAt(ctx context.Context, ef BlobFile) io.WriterAt {
+	return &ctxWriterAt{ctx: ctx, ef: ef}
+}
+
 func AlignSize(p int64, bound int64) (r int64) {
 	r = (p + bound - 1) & (^(bound - 1))
 	return r
@@ -140,3 +140,44 @@ func TestBlobFile_Op(t *testing.T) {
 	// phy allocate == 0
 	require.Equal(t, int(stat.Blocks), 0)
 }
+
+func TestBlobFile_CtxDropped(t *testing.T) {
+	testDir, err := os.MkdirTemp(os.TempDir(), "BlobFileCtx")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	f, err := OpenFile(filepath.Join(testDir, "PoxsixFile"), true)
+	require.NoError(t, err)
+
+	// pool drops task of done ctx without executing
+	ctr := gomock.NewController(t)
+	ioPool := mocks.NewMockIoPool(ctr)
+	ioPool.EXPECT().Submit(gomock.Any()).Do(func(args taskpool.IoPoolTaskArgs) {
+		if args.Ctx.Err() == nil {
+			args.TaskFn()
+		}
+	}).AnyTimes()
+	ioPools := map[qos.IOTypeRW]taskpool.IoPool{
+		qos.IOTypeRead:  ioPool,
+		qos.IOTypeWrite: ioPool,
+	}
+	ef := &blobFile{file: f, chunk: 1, ioPools: ioPools}
+
+	data := []byte("test data")
+	ctx, cancel := context.WithCancel(context.Background())
+	n, err := NewCtxWriterAt(ctx, ef).WriteAt(data, 0)
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	buf := make([]byte, len(data))
+	n, err = NewCtxReaderAt(ctx, ef).ReadAt(buf, 0)
+	require.NoError(t, err)
+	require.Equal(t, data, buf[:n])
+
+	cancel()
+	n, err = NewCtxWriterAt(ctx, ef).WriteAt(data, 0)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, n)
+	n, err = NewCtxReaderAt(ctx, ef).ReadAt(buf, 0)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, n)
+}
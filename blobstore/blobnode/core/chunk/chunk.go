@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -142,8 +143,11 @@ func newChunkStorage(ctx context.Context, dataPath string, vm core.VuidMeta, ioP
 
 	// init stg
 	stg := storage.NewStorage(cm, cd)
-	// enhence stg, with inline feat
-	stg = storage.NewTinyFileStg(stg, opt.Conf.TinyFileThresholdB)
+	// enhence stg, with inline feat. the encrypted chunk never inlines shards,
+	// otherwise the small payloads are kept in plaintext in meta db
+	if vm.EncryptKeyID == 0 {
+		stg = storage.NewTinyFileStg(stg, opt.Conf.TinyFileThresholdB)
+	}
 
 	cs.setStg(stg)
 
@@ -263,6 +267,11 @@ Fill Shard:
   - Crc
 */
 func (cs *chunk) Write(ctx context.Context, b *core.Shard) (err error) {
+	return cs.write(ctx, b, true)
+}
+
+// write writes the shard, the compaction copies worm shards without checking.
+func (cs *chunk) write(ctx context.Context, b *core.Shard, checkWorm bool) (err error) {
 	if b.Vuid != cs.vuid {
 		return bloberr.ErrVuidNotMatch
 	}
@@ -285,12 +294,23 @@ func (cs *chunk) Write(ctx context.Context, b *core.Shard) (err error) {
 
 	cs.lock.RUnlock()
 
+	// the worm shard can not be overwritten before retention
+	if checkWorm {
+		meta, err := stg.ReadShardMeta(ctx, b.Bid)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil && meta.WormProtected(time.Now()) {
+			return bloberr.ErrShardWormProtected
+		}
+	}
+
 	if err = stg.Write(ctx, b); err != nil {
 		return err
 	}
 
-	// update stats
-	atomic.AddUint64(&cs.fileInfo.Used, uint64(core.Alignphysize(int64(b.Size))))
+	// update stats by the size on disk, the payload is sealed if encrypted
+	atomic.AddUint64(&cs.fileInfo.Used, uint64(stg.DataHandler().PhySize(int64(b.Size))))
 	atomic.StoreUint32(&cs.dirty, 1)
 
 	return nil
@@ -354,6 +374,8 @@ func (cs *chunk) vuidMeta() (vm *core.VuidMeta) {
 		Mtime:       cs.lastModifyTime,
 		Status:      cs.status,
 		Compacting:  cs.compacting,
+
+		EncryptKeyID: stat.EncryptKey,
 	}
 	return vm
 }
@@ -603,12 +625,88 @@ func (cs *chunk) Delete(ctx context.Context, bid proto.BlobID) (err error) {
 	}
 
 	// update stats
-	atomic.AddUint64(&cs.fileInfo.Used, -uint64(core.Alignphysize(n)))
+	atomic.AddUint64(&cs.fileInfo.Used, -uint64(stg.DataHandler().PhySize(n)))
 	atomic.StoreUint32(&cs.dirty, 1)
 
 	return nil
 }
 
+func (cs *chunk) BatchMarkDelete(ctx context.Context, bids []proto.BlobID) (errs []error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	// statistics
+	cs.stats.markdeleteBefore()
+	defer cs.stats.markdeleteAfter(time.Now())
+
+	cs.lock.RLock()
+
+	if cs.compacting {
+		cs.lock.RUnlock()
+		return fillErrors(len(bids), bloberr.ErrChunkInCompact)
+	}
+
+	stg := cs.GetStg()
+	defer cs.PutStg(stg)
+
+	cs.lock.RUnlock()
+
+	errs = stg.BatchMarkDelete(ctx, bids)
+	for idx, err := range errs {
+		if err != nil {
+			span.Errorf("Failed mark delete bid:%d, err:%v", bids[idx], err)
+		}
+	}
+
+	return errs
+}
+
+func (cs *chunk) BatchDelete(ctx context.Context, bids []proto.BlobID) (errs []error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	// statistics
+	cs.stats.deleteBefore()
+	defer cs.stats.deleteAfter(time.Now())
+
+	cs.lock.RLock()
+
+	if cs.compacting {
+		cs.lock.RUnlock()
+		return fillErrors(len(bids), bloberr.ErrChunkInCompact)
+	}
+
+	stg := cs.GetStg()
+	defer cs.PutStg(stg)
+
+	cs.lock.RUnlock()
+
+	ns, errs := stg.BatchDelete(ctx, bids)
+
+	var used uint64
+	for idx, err := range errs {
+		if err != nil {
+			span.Errorf("Failed delete, bid:%v, err:%v", bids[idx], err)
+			continue
+		}
+		used += uint64(stg.DataHandler().PhySize(ns[idx]))
+	}
+
+	// update stats
+	if used > 0 {
+		atomic.AddUint64(&cs.fileInfo.Used, -used)
+		atomic.StoreUint32(&cs.dirty, 1)
+	}
+
+	return errs
+}
+
+func fillErrors(n int, err error) []error {
+	errs := make([]error, n)
+	for idx := range errs {
+		errs[idx] = err
+	}
+	return errs
+}
+
 func (cs *chunk) ReadShardMeta(ctx context.Context, bid proto.BlobID) (sm *core.ShardMeta, err error) {
 	span := trace.SpanFromContextSafe(ctx)
 
@@ -661,6 +759,8 @@ func (cs *chunk) ListShards(ctx context.Context, startBid proto.BlobID, cnt int,
 			Crc:    shard.Crc,
 			Flag:   shard.Flag,
 			Inline: shard.Inline,
+
+			Retention: shard.Retention,
 		})
 
 		next = bid
@@ -34,6 +34,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/blobnode/base/qos"
 	"github.com/cubefs/cubefs/blobstore/blobnode/core"
 	"github.com/cubefs/cubefs/blobstore/blobnode/db"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/testing/mocks"
@@ -538,6 +539,85 @@ func TestChunkStorage_DeleteOp(t *testing.T) {
 	require.Contains(t, err.Error(), "not exist")
 }
 
+func TestChunkStorage_Worm(t *testing.T) {
+	testDir, err := os.MkdirTemp(os.TempDir(), defaultDiskTestDir+"ChunkStorageWorm")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	ctx := context.Background()
+
+	conf := &core.Config{
+		RuntimeConfig: core.RuntimeConfig{
+			MetricReportIntervalS: 30,
+			BlockBufferSize:       64 * 1024,
+		},
+	}
+
+	vuid := proto.Vuid(1)
+	chunkid := clustermgr.NewChunkID(vuid)
+
+	err = core.EnsureDiskArea(testDir, "")
+	require.NoError(t, err)
+
+	datapath := core.GetDataPath(testDir)
+	metapath := core.GetMetaPath(testDir, "")
+
+	kvdb, err := db.NewMetaHandler(metapath, db.MetaConfig{})
+	require.NoError(t, err)
+	require.NotNil(t, kvdb)
+
+	vm := core.VuidMeta{
+		Vuid:    vuid,
+		DiskID:  12,
+		ChunkID: chunkid,
+		Mtime:   time.Now().UnixNano(),
+		Status:  clustermgr.ChunkStatusNormal,
+	}
+
+	ioPools := newIoPoolMock(t)
+	ioQos, _ := qos.NewIoQueueQos(qos.Config{ReadQueueDepth: 2, WriteQueueDepth: 2, WriteChanQueCnt: 2})
+	defer ioQos.Close()
+	cs, err := NewChunkStorage(ctx, datapath, vm, ioPools, func(option *core.Option) {
+		option.Conf = conf
+		option.DB = kvdb
+		option.CreateDataIfMiss = true
+		option.IoQos = ioQos
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cs)
+
+	shardData := []byte("test data")
+	newShard := func(bid proto.BlobID, retention int64) *core.Shard {
+		return &core.Shard{
+			Bid:       bid,
+			Vuid:      vuid,
+			Flag:      bnapi.ShardStatusNormal,
+			Size:      uint32(len(shardData)),
+			Body:      bytes.NewReader(shardData),
+			Retention: retention,
+		}
+	}
+
+	// in retention
+	protected, expired := proto.BlobID(1024), proto.BlobID(1025)
+	retention := time.Now().Add(time.Hour).Unix()
+	require.NoError(t, cs.Write(ctx, newShard(protected, retention)))
+	sm, err := cs.ReadShardMeta(ctx, protected)
+	require.NoError(t, err)
+	require.Equal(t, retention, sm.Retention)
+
+	err = cs.Write(ctx, newShard(protected, 0))
+	require.ErrorIs(t, err, bloberr.ErrShardWormProtected)
+	err = cs.MarkDelete(ctx, protected)
+	require.ErrorIs(t, err, bloberr.ErrShardWormProtected)
+
+	// retention passed
+	require.NoError(t, cs.Write(ctx, newShard(expired, time.Now().Add(-time.Second).Unix())))
+	require.NoError(t, cs.Write(ctx, newShard(expired, 0)))
+	require.NoError(t, cs.MarkDelete(ctx, expired))
+	require.NoError(t, cs.Delete(ctx, expired))
+}
+
 func TestChunkStorage_Finalizer(t *testing.T) {
 	testDir, err := os.MkdirTemp(os.TempDir(), defaultDiskTestDir+"Finalizer")
 	require.NoError(t, err)
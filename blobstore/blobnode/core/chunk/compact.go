@@ -60,6 +60,8 @@ func (cs *chunk) StartCompact(ctx context.Context) (newcs core.ChunkAPI, err err
 		Ctime:       now,
 		Mtime:       now,
 		Status:      clustermgr.ChunkStatusDefault,
+		// the compacted chunk is encrypted by the active data key
+		EncryptKeyID: cs.Disk().GetConfig().KeyRing.ActiveID(),
 	}
 
 	stg := cs.getStg()
@@ -179,7 +181,7 @@ func (cs *chunk) doCompact(ctx context.Context, ncs *chunk) (err error) {
 		}
 
 		// dstChunkStorage write data
-		err = ncs.Write(ctx, shard)
+		err = ncs.write(ctx, shard, false)
 		// read old shard, and write to new chunk. should manual free buffer
 		if rc, ok := shard.Body.(*storage.ShardReadCloser); ok {
 			rc.Close()
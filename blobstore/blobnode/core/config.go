@@ -19,6 +19,7 @@ import (
 	"errors"
 
 	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/blobnode/base/encrypt"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base/qos"
 	"github.com/cubefs/cubefs/blobstore/blobnode/db"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -86,7 +87,8 @@ type RuntimeConfig struct {
 	DeleteThreadCnt              int     `json:"delete_thread_cnt"`
 	EnableDeleteShardVerify      bool    `json:"enable_delete_shard_verify"`
 
-	DataQos qos.Config `json:"data_qos"`
+	DataQos qos.Config     `json:"data_qos"`
+	Encrypt encrypt.Config `json:"encrypt"`
 }
 
 type HostInfo struct {
@@ -109,6 +111,9 @@ type Config struct {
 	HandleIOError    func(ctx context.Context, diskID proto.DiskID, diskErr error)
 	NotifyCompacting func(ctx context.Context, args *cmapi.SetCompactChunkArgs) (err error)
 	GetGlobalConfig  func(ctx context.Context, key string) (value string, err error)
+
+	// KeyRing holds the data keys of disk, it's loaded with the disk
+	KeyRing *KeyRing `json:"-"`
 }
 
 func InitConfig(conf *Config) error {
@@ -333,6 +333,8 @@ func (dsw *DiskStorageWrapper) CreateChunk(ctx context.Context, vuid proto.Vuid,
 		Ctime:     nowtime,
 		Mtime:     nowtime,
 		Status:    clustermgr.ChunkStatusNormal,
+
+		EncryptKeyID: ds.Conf.KeyRing.ActiveID(),
 	}
 
 	// create chunk storage
@@ -472,6 +474,18 @@ func newDiskStorage(ctx context.Context, conf core.Config) (ds *DiskStorage, err
 		return nil, bloberr.ErrUnexpected
 	}
 
+	// load data keys of encryption at rest
+	dataKeys, err := sb.LoadDataKeys(ctx)
+	if err != nil {
+		span.Errorf("Failed load data keys, err:%v", err)
+		return nil, err
+	}
+	conf.KeyRing, err = core.NewKeyRing(ctx, conf.Encrypt, conf.Path, dataKeys, sb.UpsertDataKeys)
+	if err != nil {
+		span.Errorf("Failed new key ring, err:%v", err)
+		return nil, err
+	}
+
 	// init eio handler
 	sb.SetHandlerIOError(func(err error) {
 		conf.HandleIOError(context.Background(), dm.DiskID, err)
@@ -489,7 +503,18 @@ func newDiskStorage(ctx context.Context, conf core.Config) (ds *DiskStorage, err
 	conf.DataQos.StatGetter = dataIos
 	conf.DataQos.DiskViewer = diskView
 
-	dataQos, err := qos.NewIoQueueQos(conf.DataQos)
+	// qos parameters of the disk updated at runtime override the config,
+	// fall back to the config if the persisted parameters are bad
+	diskQos, err := core.ReadDiskQosConfig(ctx, path)
+	if err != nil {
+		span.Warnf("Failed read disk qos config, use default qos, err:%v", err)
+		diskQos = bnapi.DiskQosConfig{}
+	}
+	dataQos, err := qos.NewIoQueueQos(conf.DataQos.Override(diskQos))
+	if err != nil && diskQos != (bnapi.DiskQosConfig{}) {
+		span.Warnf("Failed new io qos by disk qos config, use default qos, config:%+v, err:%v", diskQos, err)
+		dataQos, err = qos.NewIoQueueQos(conf.DataQos)
+	}
 	if err != nil {
 		span.Errorf("Failed new io qos, err:%v", err)
 		return nil, err
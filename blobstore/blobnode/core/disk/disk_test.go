@@ -117,6 +117,10 @@ func TestNewDiskStorage(t *testing.T) {
 		t.Fail()
 	}
 
+	// bad qos config of the disk falls back to the default
+	err = os.WriteFile(filepath.Join(diskpath, ".sys", ".qos.json"), []byte("{bad"), 0o644)
+	require.NoError(t, err)
+
 	// second time. reload
 	ds, err = NewDiskStorage(ctx, diskConfig)
 	require.NoError(t, err)
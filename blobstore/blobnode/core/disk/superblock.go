@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -69,6 +70,7 @@ const (
 	_vuidSpacePrefix  = "vuids"
 
 	_diskmetaKey = "diskinfo"
+	_datakeysKey = "datakeys"
 )
 
 var (
@@ -253,6 +255,42 @@ func (s *SuperBlock) LoadDiskInfo(ctx context.Context) (dm core.DiskMeta, err er
 	return dm, err
 }
 
+// UpsertDataKeys saves the wrapped data keys of disk
+func (s *SuperBlock) UpsertDataKeys(ctx context.Context, keys []core.DataKey) (err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		span.Errorf("Failed marshal data keys, err:%v", err)
+		return err
+	}
+
+	return s.writeData(ctx, []byte(GenDiskKey(_datakeysKey)), data)
+}
+
+// LoadDataKeys returns the wrapped data keys of disk, empty if never saved
+func (s *SuperBlock) LoadDataKeys(ctx context.Context) (keys []core.DataKey, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	key := []byte(GenDiskKey(_datakeysKey))
+	data, err := s.readData(ctx, key)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		span.Errorf("Failed readData: %s, err:%v", string(key), err)
+		return
+	}
+
+	err = json.Unmarshal(data, &keys)
+	if err != nil {
+		span.Errorf("Failed unmarshal, err:%v", err)
+		return
+	}
+
+	return keys, nil
+}
+
 func (s *SuperBlock) ListChunks(ctx context.Context) (chunks map[clustermgr.ChunkID]core.VuidMeta, err error) {
 	iter := s.db.NewIterator(ctx)
 	defer iter.Close()
@@ -89,6 +89,30 @@ func TestNewSuperBlock(t *testing.T) {
 	require.Equal(t, true, os.IsNotExist(err))
 }
 
+func TestSuperBlock_DataKeys(t *testing.T) {
+	testDir, err := os.MkdirTemp(os.TempDir(), "SuperBlockDataKeys")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	ctx := context.Background()
+	s, err := NewSuperBlock(testDir, &core.Config{})
+	require.NoError(t, err)
+	defer s.Close(ctx)
+
+	keys, err := s.LoadDataKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 0)
+
+	dks := []core.DataKey{
+		{ID: 1, KEKID: "k1", Wrapped: []byte("wrapped1"), Ctime: 1},
+		{ID: 2, KEKID: "k2", Wrapped: []byte("wrapped2"), Ctime: 2},
+	}
+	require.NoError(t, s.UpsertDataKeys(ctx, dks))
+	keys, err = s.LoadDataKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, dks, keys)
+}
+
 func TestSuperBlock_RegisterDisk(t *testing.T) {
 	testDir, err := os.MkdirTemp(os.TempDir(), "SBRegisterDisk")
 	require.NoError(t, err)
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
@@ -39,6 +40,8 @@ import (
 const (
 	formatConfigFile    = ".format.json"
 	formatConfigFileTmp = ".format.json.tmp"
+	qosConfigFile       = ".qos.json"
+	qosConfigFileTmp    = ".qos.json.tmp"
 )
 
 const (
@@ -200,6 +203,53 @@ func IsFormatConfigExist(diskRootPath string) (bool, error) {
 	return base.IsFileExists(configFile)
 }
 
+// SaveDiskQosConfig persists qos parameters of the disk, which overrides qos config of all disks
+func SaveDiskQosConfig(ctx context.Context, diskRootPath string, conf *bnapi.DiskQosConfig) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	configFile := filepath.Join(sysRootPath(diskRootPath), qosConfigFile)
+	configFileTemp := filepath.Join(sysRootPath(diskRootPath), qosConfigFileTmp)
+
+	b, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(configFileTemp, b, 0o644); err != nil {
+		span.Errorf("Failed write file:%s, err:%v", configFileTemp, err)
+		return err
+	}
+	if err = os.Rename(configFileTemp, configFile); err != nil {
+		span.Errorf("Failed rename, err:%v", err)
+		return err
+	}
+	span.Infof("save qos config success, path:%v, config:%+v", diskRootPath, conf)
+	return nil
+}
+
+// ReadDiskQosConfig returns persisted qos parameters of the disk, returns empty config if not exist
+func ReadDiskQosConfig(ctx context.Context, diskRootPath string) (conf bnapi.DiskQosConfig, err error) {
+	configFile := filepath.Join(sysRootPath(diskRootPath), qosConfigFile)
+	buf, err := os.ReadFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return conf, nil
+		}
+		trace.SpanFromContextSafe(ctx).Errorf("Failed read file:%v, err:%v", configFile, err)
+		return conf, err
+	}
+	err = json.Unmarshal(buf, &conf)
+	return conf, err
+}
+
+// RemoveDiskQosConfig removes persisted qos parameters of the disk
+func RemoveDiskQosConfig(ctx context.Context, diskRootPath string) error {
+	configFile := filepath.Join(sysRootPath(diskRootPath), qosConfigFile)
+	if err := os.Remove(configFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 func (fi *FormatInfo) CalCheckSum() (uint32, error) {
 	crc := crc32.New(crc32.MakeTable(formatInfoCheckSumPoly))
 
@@ -0,0 +1,224 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/blobnode/base/encrypt"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+var (
+	ErrEncryptKeyNotFound = errors.New("encrypt key of chunk not found")
+	ErrEncryptDisabled    = errors.New("encryption at rest is disabled")
+)
+
+// DataKey is the data key of disk wrapped by KEK
+type DataKey struct {
+	ID      uint32 `json:"id"`
+	KEKID   string `json:"kek_id"`
+	Wrapped []byte `json:"wrapped"`
+	Ctime   int64  `json:"ctime"`
+}
+
+type (
+	// PersistDataKeysFunc saves all data keys of disk
+	PersistDataKeysFunc func(ctx context.Context, keys []DataKey) error
+
+	// KeyRing holds the unwrapped data keys of disk. new chunks are encrypted by the active
+	// data key, and the old chunks are still readable by the retired data keys.
+	KeyRing struct {
+		lock    sync.RWMutex
+		enable  bool
+		active  uint32
+		kek     encrypt.KEKProvider
+		keys    map[uint32]*encrypt.Key
+		wrapped []DataKey
+		persist PersistDataKeysFunc
+	}
+)
+
+// NewKeyRing unwraps the data keys of disk by the KEK provider of config. the data keys
+// wrapped by retired KEK will be rewrapped by the active one, and the first data key is
+// generated if enabled.
+func NewKeyRing(ctx context.Context, cfg encrypt.Config, diskPath string, keys []DataKey, persist PersistDataKeysFunc) (*KeyRing, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	kr := &KeyRing{
+		enable:  cfg.Enable,
+		keys:    make(map[uint32]*encrypt.Key),
+		wrapped: keys,
+		persist: persist,
+	}
+	if !cfg.Enable && len(keys) == 0 {
+		return kr, nil
+	}
+
+	kek, err := encrypt.NewKEKProvider(cfg, diskPath)
+	if err != nil {
+		span.Errorf("new kek provider %s of disk %s failed, err: %v", cfg.Provider, diskPath, err)
+		return nil, err
+	}
+	kr.kek = kek
+
+	for i := range keys {
+		dk := &keys[i]
+		raw, err := kek.Unwrap(dk.KEKID, dk.Wrapped)
+		if err != nil {
+			span.Errorf("unwrap data key %d by kek %s failed, err: %v", dk.ID, dk.KEKID, err)
+			return nil, err
+		}
+		if kr.keys[dk.ID], err = encrypt.NewKey(raw); err != nil {
+			return nil, err
+		}
+		if cfg.Enable && dk.ID > kr.active {
+			kr.active = dk.ID
+		}
+	}
+
+	if cfg.Enable && len(keys) == 0 {
+		if _, err = kr.Rotate(ctx); err != nil {
+			return nil, err
+		}
+		return kr, nil
+	}
+	rewrapped, dirty, err := kr.rewrap(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		if err = kr.persist(ctx, rewrapped); err != nil {
+			return nil, err
+		}
+		kr.wrapped = rewrapped
+	}
+	return kr, nil
+}
+
+// rewrap returns the data keys wrapped by the active KEK, the active one of provider
+// may be changed at runtime, e.g. KEK rotated in KMS.
+func (kr *KeyRing) rewrap(ctx context.Context, keys []DataKey) ([]DataKey, bool, error) {
+	span := trace.SpanFromContextSafe(ctx)
+	activeKEK := kr.kek.ActiveID()
+	rewrapped := append([]DataKey(nil), keys...)
+	dirty := false
+	for i := range rewrapped {
+		dk := &rewrapped[i]
+		if dk.KEKID == activeKEK {
+			continue
+		}
+		raw, err := kr.kek.Unwrap(dk.KEKID, dk.Wrapped)
+		if err != nil {
+			span.Errorf("unwrap data key %d by kek %s failed, err: %v", dk.ID, dk.KEKID, err)
+			return nil, false, err
+		}
+		span.Warnf("rewrap data key %d, kek %s -> %s", dk.ID, dk.KEKID, activeKEK)
+		if dk.KEKID, dk.Wrapped, err = kr.kek.Wrap(raw); err != nil {
+			return nil, false, err
+		}
+		dirty = true
+	}
+	return rewrapped, dirty, nil
+}
+
+// ActiveID returns id of the active data key, zero means no encryption for new chunks
+func (kr *KeyRing) ActiveID() uint32 {
+	if kr == nil {
+		return 0
+	}
+	kr.lock.RLock()
+	defer kr.lock.RUnlock()
+	return kr.active
+}
+
+// Get returns the data key of id
+func (kr *KeyRing) Get(id uint32) (*encrypt.Key, error) {
+	if kr == nil {
+		return nil, ErrEncryptKeyNotFound
+	}
+	kr.lock.RLock()
+	defer kr.lock.RUnlock()
+	key, ok := kr.keys[id]
+	if !ok {
+		return nil, ErrEncryptKeyNotFound
+	}
+	return key, nil
+}
+
+// IDs returns ids of all data keys
+func (kr *KeyRing) IDs() []uint32 {
+	if kr == nil {
+		return nil
+	}
+	kr.lock.RLock()
+	defer kr.lock.RUnlock()
+	ids := make([]uint32, 0, len(kr.keys))
+	for id := range kr.keys {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Rotate generates and activates a new data key, the new chunks will be encrypted by it.
+// the old data keys are rewrapped if the active KEK of provider has been changed.
+func (kr *KeyRing) Rotate(ctx context.Context) (uint32, error) {
+	if kr == nil || !kr.enable {
+		return 0, ErrEncryptDisabled
+	}
+	span := trace.SpanFromContextSafe(ctx)
+
+	raw, err := encrypt.GenerateKey()
+	if err != nil {
+		return 0, err
+	}
+	key, err := encrypt.NewKey(raw)
+	if err != nil {
+		return 0, err
+	}
+	kekID, wrapped, err := kr.kek.Wrap(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	kr.lock.Lock()
+	defer kr.lock.Unlock()
+	var id uint32
+	for _, dk := range kr.wrapped {
+		if dk.ID > id {
+			id = dk.ID
+		}
+	}
+	id++
+	keys, _, err := kr.rewrap(ctx, kr.wrapped)
+	if err != nil {
+		return 0, err
+	}
+	keys = append(keys, DataKey{ID: id, KEKID: kekID, Wrapped: wrapped, Ctime: time.Now().Unix()})
+	if err = kr.persist(ctx, keys); err != nil {
+		span.Errorf("persist data keys failed, err: %v", err)
+		return 0, err
+	}
+	kr.wrapped = keys
+	kr.keys[id] = key
+	kr.active = id
+	span.Infof("data key rotated, active: %d, kek: %s", id, kekID)
+	return id, nil
+}
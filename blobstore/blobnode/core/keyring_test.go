@@ -0,0 +1,167 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/blobnode/base/encrypt"
+)
+
+func TestKeyRing(t *testing.T) {
+	ctx := context.Background()
+	var saved []DataKey
+	persist := func(_ context.Context, keys []DataKey) error {
+		saved = append([]DataKey(nil), keys...)
+		return nil
+	}
+	genKEK := func() string {
+		raw, err := encrypt.GenerateKey()
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(raw)
+	}
+
+	// nil and disabled key ring
+	var nilRing *KeyRing
+	require.Equal(t, uint32(0), nilRing.ActiveID())
+	_, err := nilRing.Get(1)
+	require.ErrorIs(t, err, ErrEncryptKeyNotFound)
+	kr, err := NewKeyRing(ctx, encrypt.Config{}, "/disk1", nil, persist)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), kr.ActiveID())
+	_, err = kr.Rotate(ctx)
+	require.ErrorIs(t, err, ErrEncryptDisabled)
+
+	// generate the first data key
+	cfg := encrypt.Config{Enable: true, KEKs: map[string]string{"k1": genKEK()}, ActiveKEK: "k1"}
+	kr, err = NewKeyRing(ctx, cfg, "/disk1", nil, persist)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), kr.ActiveID())
+	require.Len(t, saved, 1)
+	key1, err := kr.Get(1)
+	require.NoError(t, err)
+
+	id, err := kr.Rotate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), id)
+	require.Equal(t, []uint32{1, 2}, kr.IDs())
+	require.Len(t, saved, 2)
+
+	// reload with rotated kek, the data keys are rewrapped
+	cfg.KEKs["k2"] = genKEK()
+	cfg.ActiveKEK = "k2"
+	kr, err = NewKeyRing(ctx, cfg, "/disk1", saved, persist)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), kr.ActiveID())
+	for _, dk := range saved {
+		require.Equal(t, "k2", dk.KEKID)
+	}
+	key, err := kr.Get(1)
+	require.NoError(t, err)
+	var nonce [encrypt.NonceSize]byte
+	src := []byte("data key is kept")
+	require.Equal(t, key1.Seal(nil, src, nonce, 0), key.Seal(nil, src, nonce, 0))
+
+	// disabled, the old chunks are still readable
+	delete(cfg.KEKs, "k1")
+	cfg.Enable = false
+	kr, err = NewKeyRing(ctx, cfg, "/disk1", saved, persist)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), kr.ActiveID())
+	_, err = kr.Get(2)
+	require.NoError(t, err)
+	_, err = kr.Get(3)
+	require.ErrorIs(t, err, ErrEncryptKeyNotFound)
+
+	// kek is missing
+	cfg.KEKs = map[string]string{"k3": genKEK()}
+	cfg.ActiveKEK = "k3"
+	_, err = NewKeyRing(ctx, cfg, "/disk1", saved, persist)
+	require.ErrorIs(t, err, encrypt.ErrKEKNotFound)
+}
+
+type switchKEKProvider struct {
+	cfg *encrypt.Config
+}
+
+func (p *switchKEKProvider) ActiveID() string { return p.cfg.ActiveKEK }
+
+func (p *switchKEKProvider) Wrap(plain []byte) (string, []byte, error) {
+	kek, err := encrypt.NewStaticKEKProvider(*p.cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	return kek.Wrap(plain)
+}
+
+func (p *switchKEKProvider) Unwrap(kekID string, wrapped []byte) ([]byte, error) {
+	kek, err := encrypt.NewStaticKEKProvider(*p.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return kek.Unwrap(kekID, wrapped)
+}
+
+func TestKeyRingProvider(t *testing.T) {
+	ctx := context.Background()
+	var saved []DataKey
+	persist := func(_ context.Context, keys []DataKey) error {
+		saved = append([]DataKey(nil), keys...)
+		return nil
+	}
+	genKEK := func() string {
+		raw, err := encrypt.GenerateKey()
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(raw)
+	}
+
+	kekCfg := &encrypt.Config{KEKs: map[string]string{"k1": genKEK()}, ActiveKEK: "k1"}
+	var disks []string
+	encrypt.RegisterKEKProvider("keyring-test", func(cfg encrypt.Config, diskPath string) (encrypt.KEKProvider, error) {
+		disks = append(disks, diskPath)
+		return &switchKEKProvider{cfg: kekCfg}, nil
+	})
+
+	cfg := encrypt.Config{Enable: true, Provider: "keyring-test"}
+	kr, err := NewKeyRing(ctx, cfg, "/disk1", nil, persist)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/disk1"}, disks)
+	require.Len(t, saved, 1)
+	require.Equal(t, "k1", saved[0].KEKID)
+
+	// kek rotated by provider at runtime, the old data keys are rewrapped when rotating
+	kekCfg.KEKs["k2"] = genKEK()
+	kekCfg.ActiveKEK = "k2"
+	_, err = kr.Rotate(ctx)
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	for _, dk := range saved {
+		require.Equal(t, "k2", dk.KEKID)
+	}
+
+	delete(kekCfg.KEKs, "k1")
+	kr, err = NewKeyRing(ctx, cfg, "/disk2", saved, persist)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 2}, kr.IDs())
+	require.Equal(t, []string{"/disk1", "/disk2"}, disks)
+
+	cfg.Provider = "not-exist"
+	_, err = NewKeyRing(ctx, cfg, "/disk1", saved, persist)
+	require.ErrorIs(t, err, encrypt.ErrProviderNotFound)
+}
@@ -42,6 +42,8 @@ type VuidMeta struct {
 	Compacting  bool                   `json:"compacting"`
 	Status      clustermgr.ChunkStatus `json:"status"` // normal、release
 	Reason      string                 `json:"reason"`
+	// EncryptKeyID is the data key of disk encrypts the chunk, zero means plaintext
+	EncryptKeyID uint32 `json:"encrypt_key_id,omitempty"`
 }
 
 // disk meta data for rocksdb
@@ -66,6 +68,7 @@ type StorageStat struct {
 	PhySize    int64              `json:"phy_size"`
 	ParentID   clustermgr.ChunkID `json:"parent_id"`
 	CreateTime int64              `json:"create_time"`
+	EncryptKey uint32             `json:"encrypt_key"`
 }
 
 type MetaHandler interface {
@@ -75,6 +78,8 @@ type MetaHandler interface {
 	Write(ctx context.Context, bid proto.BlobID, value ShardMeta) (err error)
 	Read(ctx context.Context, bid proto.BlobID) (value ShardMeta, err error)
 	Delete(ctx context.Context, bid proto.BlobID) (err error)
+	BatchWrite(ctx context.Context, bids []proto.BlobID, values []ShardMeta) (err error)
+	BatchDelete(ctx context.Context, bids []proto.BlobID) (err error)
 	Scan(ctx context.Context, startBid proto.BlobID, limit int,
 		fn func(bid proto.BlobID, sm *ShardMeta) error) (err error)
 	Destroy(ctx context.Context) (err error)
@@ -87,6 +92,8 @@ type DataHandler interface {
 	Stat() (stat *StorageStat, err error)
 	Flush() (err error)
 	Delete(ctx context.Context, shard *Shard) (err error)
+	// PhySize returns size of the shard on disk
+	PhySize(size int64) int64
 	Destroy(ctx context.Context) (err error)
 	Close()
 }
@@ -101,6 +108,8 @@ type Storage interface {
 	NewRangeReader(ctx context.Context, b *Shard, from, to int64) (rc io.ReadCloser, err error)
 	MarkDelete(ctx context.Context, bid proto.BlobID) (err error)
 	Delete(ctx context.Context, bid proto.BlobID) (n int64, err error)
+	BatchMarkDelete(ctx context.Context, bids []proto.BlobID) (errs []error)
+	BatchDelete(ctx context.Context, bids []proto.BlobID) (ns []int64, errs []error)
 	ScanMeta(ctx context.Context, startBid proto.BlobID, limit int,
 		fn func(bid proto.BlobID, sm *ShardMeta) error) (err error)
 	SyncData(ctx context.Context) (err error)
@@ -130,6 +139,8 @@ type ChunkAPI interface {
 	RangeRead(ctx context.Context, b *Shard) (n int64, err error)
 	MarkDelete(ctx context.Context, bid proto.BlobID) (err error)
 	Delete(ctx context.Context, bid proto.BlobID) (err error)
+	BatchMarkDelete(ctx context.Context, bids []proto.BlobID) (errs []error)
+	BatchDelete(ctx context.Context, bids []proto.BlobID) (errs []error)
 	ReadShardMeta(ctx context.Context, bid proto.BlobID) (sm *ShardMeta, err error)
 	ListShards(ctx context.Context, startBid proto.BlobID, cnt int, status bnapi.ShardStatus) (infos []*bnapi.ShardInfo, next proto.BlobID, err error)
 	Sync(ctx context.Context) (err error)
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"time"
 
 	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
@@ -136,9 +137,11 @@ type ShardMeta struct {
 	Offset  int64
 	Size    uint32
 	Crc     uint32
-	Padding [8]byte
-	Inline  bool
-	Buffer  []byte
+	// Retention is the unix seconds before which worm shard
+	// can not be overwritten or deleted, zero is not worm shard.
+	Retention int64
+	Inline    bool
+	Buffer    []byte
 }
 
 // Blob Shard in memory
@@ -151,6 +154,8 @@ type Shard struct {
 	Crc    uint32            // crc for shard data
 	Flag   bnapi.ShardStatus // shard status
 
+	Retention int64 // worm retention in unix seconds
+
 	Inline bool   // shard data inline
 	Buffer []byte // inline data
 
@@ -183,7 +188,7 @@ func (sm *ShardMeta) Marshal() ([]byte, error) {
 	binary.LittleEndian.PutUint32(buf[16:20], uint32(sm.Size))
 	binary.LittleEndian.PutUint32(buf[20:24], uint32(sm.Crc))
 
-	copy(buf[24:32], sm.Padding[:])
+	binary.LittleEndian.PutUint64(buf[24:32], uint64(sm.Retention))
 
 	if sm.Inline && sm.Buffer != nil {
 		copy(buf[32:32+sm.Size], sm.Buffer)
@@ -204,7 +209,7 @@ func (sm *ShardMeta) Unmarshal(data []byte) error {
 	sm.Size = binary.LittleEndian.Uint32(data[16:20])
 	sm.Crc = binary.LittleEndian.Uint32(data[20:24])
 
-	copy(sm.Padding[:], data[24:32])
+	sm.Retention = int64(binary.LittleEndian.Uint64(data[24:32]))
 
 	sm.Inline = sm.Flag&bnapi.ShardDataInline != 0
 	if sm.Inline {
@@ -215,6 +220,11 @@ func (sm *ShardMeta) Unmarshal(data []byte) error {
 	return nil
 }
 
+// WormProtected returns true if the worm shard is not expired at now.
+func (sm *ShardMeta) WormProtected(now time.Time) bool {
+	return sm.Retention > 0 && now.Unix() < sm.Retention
+}
+
 func (b *Shard) WriterHeader(buf []byte) (err error) {
 	if len(buf) != _shardHeaderSize {
 		return ErrShardHeaderSize
@@ -311,6 +321,7 @@ func (b *Shard) FillMeta(meta ShardMeta) {
 	b.Size = meta.Size
 	b.Crc = meta.Crc
 	b.Flag = meta.Flag
+	b.Retention = meta.Retention
 
 	b.Inline = meta.Inline
 	b.Buffer = meta.Buffer
@@ -357,6 +368,7 @@ func ShardCopy(src *Shard) (dest *Shard) {
 	dest.Offset = src.Offset
 	dest.Crc = src.Crc
 	dest.Flag = src.Flag
+	dest.Retention = src.Retention
 
 	dest.Body = src.Body
 	dest.From, dest.To = src.From, src.To
@@ -16,6 +16,7 @@ package core
 
 import (
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/stretchr/testify/require"
@@ -112,6 +113,8 @@ func TestShardMeta_Marshal(t *testing.T) {
 		Offset:  1024,
 		Size:    2048,
 		Crc:     4096,
+
+		Retention: 1 << 40,
 	}
 
 	require.Equal(t, int(unsafe.Sizeof(ShardMeta{})) >= _ShardMetaSize, true)
@@ -144,4 +147,15 @@ func TestShardMeta_Marshal(t *testing.T) {
 	require.Equal(t, true, sm1.Inline)
 	require.Equal(t, int(1), int(sm1.Flag))
 	require.Equal(t, sm.Buffer, sm1.Buffer)
+	require.Equal(t, sm.Retention, sm1.Retention)
+}
+
+func TestShardMeta_WormProtected(t *testing.T) {
+	now := time.Now()
+	sm := &ShardMeta{}
+	require.False(t, sm.WormProtected(now))
+	sm.Retention = now.Unix() + 1
+	require.True(t, sm.WormProtected(now))
+	sm.Retention = now.Unix()
+	require.False(t, sm.WormProtected(now))
 }
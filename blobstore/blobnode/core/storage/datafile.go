@@ -30,6 +30,7 @@ import (
 	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	bncomm "github.com/cubefs/cubefs/blobstore/blobnode/base"
+	"github.com/cubefs/cubefs/blobstore/blobnode/base/encrypt"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base/qos"
 	"github.com/cubefs/cubefs/blobstore/blobnode/core"
 	"github.com/cubefs/cubefs/blobstore/common/crc32block"
@@ -47,7 +48,9 @@ import (
 // | version      |   ---- 1 byte
 // | parent chunk |   ---- 16 byte
 // | create time  |   ---- 8 byte
-// | padding      |   ---- aligned with shard padding size ( 4k-4-1-16-8)
+// | encrypt key  |   ---- 4 byte, id of data key, zero means plaintext
+// | nonce        |   ---- 8 byte, nonce of encryption
+// | padding      |   ---- aligned with shard padding size ( 4k-4-1-16-8-4-8)
 //  --------------
 // |    shard     |
 // |    shard     |
@@ -63,18 +66,25 @@ const (
 	_chunkVerSize         = 1
 	_chunkParentChunkSize = clustermgr.ChunkIDLength
 	_chunkCreateTimeSize  = 8
-	//_chunkPaddingSize     = _chunkHeaderSize - _chunkMagicSize - _chunkVerSize - _chunkParentChunkSize - _chunkCreateTimeSize
+	_chunkEncryptKeySize  = 4
+	_chunkNonceSize       = encrypt.NonceSize
+	//_chunkPaddingSize     = _chunkHeaderSize - _chunkMagicSize - _chunkVerSize - _chunkParentChunkSize - _chunkCreateTimeSize - _chunkEncryptKeySize - _chunkNonceSize
 
 	// chunk offset
 	_chunkMagicOffset       = 0
 	_chunkVerOffset         = _chunkMagicOffset + _chunkMagicSize
 	_chunkParentChunkOffset = _chunkVerOffset + _chunkVerSize
 	_chunkCreateTimeOffset  = _chunkParentChunkOffset + _chunkParentChunkSize
-	//_chunkPaddingOffset     = _chunkCreateTimeOffset + _chunkCreateTimeSize
+	_chunkEncryptKeyOffset  = _chunkCreateTimeOffset + _chunkCreateTimeSize
+	_chunkNonceOffset       = _chunkEncryptKeyOffset + _chunkEncryptKeySize
+	//_chunkPaddingOffset     = _chunkNonceOffset + _chunkNonceSize
 
 	_pageSize  = 4 * 1024 // 4k
 	_fullsize  = core.HeaderSize + core.CrcBlockUnitSize + core.FooterSize
 	sectorSize = 512
+
+	// _encryptUnitSize is the sealed size of encrypted unit, it fills the payload of one crc block
+	_encryptUnitSize = core.CrcBlockUnitSize - crc32.Size
 )
 
 var (
@@ -93,10 +103,12 @@ var (
 )
 
 type ChunkHeader struct {
-	magic       [_chunkMagicSize]byte
-	version     byte
-	parentChunk clustermgr.ChunkID
-	createTime  int64
+	magic        [_chunkMagicSize]byte
+	version      byte
+	parentChunk  clustermgr.ChunkID
+	createTime   int64
+	encryptKeyID uint32
+	nonce        [_chunkNonceSize]byte
 }
 
 type datafile struct {
@@ -111,6 +123,9 @@ type datafile struct {
 
 	ioQos  qos.Qos
 	closed bool
+
+	// key encrypts payloads of shards, nil means plaintext
+	key *encrypt.Key
 }
 
 func (hdr *ChunkHeader) Marshal() ([]byte, error) {
@@ -124,6 +139,9 @@ func (hdr *ChunkHeader) Marshal() ([]byte, error) {
 	copy(buf[_chunkParentChunkOffset:], hdr.parentChunk[:])
 	// create time
 	binary.BigEndian.PutUint64(buf[_chunkCreateTimeOffset:], uint64(hdr.createTime))
+	// encryption
+	binary.BigEndian.PutUint32(buf[_chunkEncryptKeyOffset:], hdr.encryptKeyID)
+	copy(buf[_chunkNonceOffset:], hdr.nonce[:])
 
 	return buf, nil
 }
@@ -141,14 +159,16 @@ func (hdr *ChunkHeader) Unmarshal(data []byte) error {
 	hdr.version = data[_chunkVerOffset : _chunkVerOffset+_chunkVerSize][0]
 	copy(hdr.parentChunk[:], data[_chunkParentChunkOffset:_chunkParentChunkOffset+_chunkParentChunkSize])
 	hdr.createTime = int64(binary.BigEndian.Uint64(data[_chunkCreateTimeOffset : _chunkCreateTimeOffset+_chunkCreateTimeSize]))
+	hdr.encryptKeyID = binary.BigEndian.Uint32(data[_chunkEncryptKeyOffset : _chunkEncryptKeyOffset+_chunkEncryptKeySize])
+	copy(hdr.nonce[:], data[_chunkNonceOffset:_chunkNonceOffset+_chunkNonceSize])
 
 	return nil
 }
 
 func (hdr *ChunkHeader) String() string {
 	ctime := time.Unix(0, hdr.createTime)
-	s := fmt.Sprintf("magic:\t%v\nversion:\t%v\nparent:\t%s\nctime:\t%s",
-		hdr.magic, hdr.version, hdr.parentChunk, ctime)
+	s := fmt.Sprintf("magic:\t%v\nversion:\t%v\nparent:\t%s\nctime:\t%s\nencrypt key:\t%d",
+		hdr.magic, hdr.version, hdr.parentChunk, ctime, hdr.encryptKeyID)
 	return s
 }
 
@@ -192,13 +212,18 @@ func NewChunkData(ctx context.Context, vm core.VuidMeta, file string, conf *core
 	return cd, nil
 }
 
-func (cd *datafile) initHeader(meta *core.VuidMeta) {
+func (cd *datafile) initHeader(meta *core.VuidMeta) (err error) {
 	cd.header = ChunkHeader{
-		magic:       chunkHeaderMagic,
-		version:     meta.Version,
-		parentChunk: meta.ParentChunk,
-		createTime:  meta.Ctime,
+		magic:        chunkHeaderMagic,
+		version:      meta.Version,
+		parentChunk:  meta.ParentChunk,
+		createTime:   meta.Ctime,
+		encryptKeyID: meta.EncryptKeyID,
+	}
+	if meta.EncryptKeyID != 0 {
+		cd.header.nonce, err = encrypt.GenerateNonce()
 	}
+	return
 }
 
 func (cd *datafile) init(meta *core.VuidMeta) (err error) {
@@ -211,7 +236,9 @@ func (cd *datafile) init(meta *core.VuidMeta) (err error) {
 	chunkSize := sysstat.Size
 	if chunkSize == 0 {
 		// first time. auto format
-		cd.initHeader(meta)
+		if err = cd.initHeader(meta); err != nil {
+			return
+		}
 		if err = cd.writeMeta(); err != nil {
 			return
 		}
@@ -223,6 +250,9 @@ func (cd *datafile) init(meta *core.VuidMeta) (err error) {
 		cd.wOff = core.AlignSize(chunkSize, int64(_pageSize))
 	}
 
+	if cd.header.encryptKeyID != 0 {
+		cd.key, err = cd.conf.KeyRing.Get(cd.header.encryptKeyID)
+	}
 	return
 }
 
@@ -310,7 +340,8 @@ func (cd *datafile) Write(ctx context.Context, shard *core.Shard) (err error) {
 	defer cd.qosRelease(qos.IOTypeWrite)
 
 	// allocate space
-	phySize := core.Alignphysize(int64(shard.Size))
+	payloadSize := cd.payloadSize(int64(shard.Size))
+	phySize := core.Alignphysize(payloadSize)
 	pos, err := cd.allocSpace(phySize)
 	if err != nil {
 		return err
@@ -333,18 +364,23 @@ func (cd *datafile) Write(ctx context.Context, shard *core.Shard) (err error) {
 	defer recycle()
 
 	// prepare reader and writer
-	w := &bncomm.Writer{WriterAt: cd.ef, Offset: pos}
+	w := &bncomm.Writer{WriterAt: core.NewCtxWriterAt(ctx, cd.ef), Offset: pos}
 	twRaw := bncomm.NewTimeWriter(w)
 
 	qosw := cd.qosWriter(ctx, twRaw)
 	tw := bncomm.NewTimeWriter(qosw)
 
+	// shard crc is the checksum of plaintext returned to client, the crc blocks
+	// on disk cover the sealed payload if encrypted, which is authenticated by units
 	crc := crc32.NewIEEE()
 	body := io.LimitReader(shard.Body, int64(shard.Size))
 	body = io.TeeReader(body, crc)
+	if cd.key != nil {
+		body = cd.key.NewSealReader(body, int64(shard.Size), cd.header.nonce, uint64(pos), _encryptUnitSize)
+	}
 	tr := bncomm.NewTimeReader(body)
 
-	encoder := crc32block.NewSizedBlockEncoder(io.NopCloser(body), int64(shard.Size), core.CrcBlockUnitSize)
+	encoder := crc32block.NewSizedBlockEncoder(io.NopCloser(body), payloadSize, core.CrcBlockUnitSize)
 	defer func() {
 		encoder.Close()
 		span.AppendTrackLogWithDuration("net.r", tr.Duration(), err)
@@ -359,7 +395,7 @@ func (cd *datafile) Write(ctx context.Context, shard *core.Shard) (err error) {
 	}
 
 	hasHeader := true
-	remain := int(crc32block.EncodeSize(int64(shard.Size), core.CrcBlockUnitSize))
+	remain := int(crc32block.EncodeSize(payloadSize, core.CrcBlockUnitSize))
 	for remain > 0 {
 		buf := buffer[core.HeaderSize : len(buffer)-core.FooterSize]
 		n, err := encoder.Read(buf)
@@ -424,21 +460,32 @@ func (cd *datafile) Read(ctx context.Context, shard *core.Shard, from, to uint32
 	pos := shard.Offset + core.GetShardHeaderSize()
 
 	// new reader
-	iosr := cd.qosReaderAt(ctx, cd.ef)
+	iosr := cd.qosReaderAt(ctx, core.NewCtxReaderAt(ctx, cd.ef))
 
 	// new buffer
 	buffer := bytespool.Alloc(core.CrcBlockUnitSize)
 
 	// decode crc
-	decoder, err := crc32block.NewDecoderWithBlock(iosr, pos, int64(shard.Size), buffer, cd.conf.BlockBufferSize)
+	decoder, err := crc32block.NewDecoderWithBlock(iosr, pos, cd.payloadSize(int64(shard.Size)), buffer, cd.conf.BlockBufferSize)
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := decoder.Reader(int64(from), int64(to))
+	if cd.key == nil {
+		r, err := decoder.Reader(int64(from), int64(to))
+		if err != nil {
+			return nil, err
+		}
+		return newReadCloser(r, buffer), nil
+	}
+
+	// read the sealed units covering the range, and open them
+	sfrom, sto := encrypt.SealedRange(int64(shard.Size), int64(from), int64(to), _encryptUnitSize)
+	r, err := decoder.Reader(sfrom, sto)
 	if err != nil {
 		return nil, err
 	}
+	r = cd.key.NewOpenReader(r, int64(shard.Size), cd.header.nonce, uint64(shard.Offset), _encryptUnitSize, int64(from), int64(to))
 
 	return newReadCloser(r, buffer), nil
 }
@@ -483,7 +530,7 @@ func (cd *datafile) Delete(ctx context.Context, shard *core.Shard) (err error) {
 	}
 
 	// punch hole
-	discardSize = core.Alignphysize(int64(shard.Size))
+	discardSize = cd.PhySize(int64(shard.Size))
 	discardSize = core.AlignSize(discardSize, _pageSize)
 	err = cd.ef.Discard(shard.Offset, discardSize)
 	span.AppendTrackLog("dat.d", start, err) // cost time: Discard(PunchHole)
@@ -491,6 +538,19 @@ func (cd *datafile) Delete(ctx context.Context, shard *core.Shard) (err error) {
 	return err
 }
 
+// PhySize returns size of the shard on disk, with header, footer and crc of the payload
+func (cd *datafile) PhySize(size int64) int64 {
+	return core.Alignphysize(cd.payloadSize(size))
+}
+
+// payloadSize returns size of payload on disk without crc, it's sealed if encrypted
+func (cd *datafile) payloadSize(size int64) int64 {
+	if cd.key != nil {
+		return encrypt.SealedSize(size, _encryptUnitSize)
+	}
+	return size
+}
+
 func (cd *datafile) Destroy(ctx context.Context) (err error) {
 	log.Warnf("destroy chunk data: %s", cd.ef.Name())
 	return os.Remove(cd.File)
@@ -517,6 +577,7 @@ func (cd *datafile) Stat() (stat *core.StorageStat, err error) {
 		PhySize:    physize,
 		ParentID:   cd.header.parentChunk,
 		CreateTime: cd.header.createTime,
+		EncryptKey: cd.header.encryptKeyID,
 	}
 
 	return stat, nil
@@ -17,6 +17,7 @@ package storage
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -31,6 +32,7 @@ import (
 
 	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/blobnode/base/encrypt"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base/qos"
 	"github.com/cubefs/cubefs/blobstore/blobnode/core"
 	"github.com/cubefs/cubefs/blobstore/common/crc32block"
@@ -947,3 +949,76 @@ func TestChunkHeader(t *testing.T) {
 	s := chunkHeader.String()
 	require.NotNil(t, s)
 }
+
+func TestChunkData_Encrypt(t *testing.T) {
+	testDir, err := os.MkdirTemp(os.TempDir(), defaultDiskTestDir+"ChunkDataEncrypt")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	ctx := context.Background()
+	chunkname := filepath.Join(testDir, clustermgr.NewChunkID(0).String())
+
+	kek, err := encrypt.GenerateKey()
+	require.NoError(t, err)
+	keyRing, err := core.NewKeyRing(ctx, encrypt.Config{
+		Enable:    true,
+		KEKs:      map[string]string{"k1": base64.StdEncoding.EncodeToString(kek)},
+		ActiveKEK: "k1",
+	}, testDir, nil, func(context.Context, []core.DataKey) error { return nil })
+	require.NoError(t, err)
+
+	diskConfig := &core.Config{
+		BaseConfig:    core.BaseConfig{Path: testDir},
+		RuntimeConfig: core.RuntimeConfig{BlockBufferSize: 64 * 1024},
+		KeyRing:       keyRing,
+	}
+	meta := core.VuidMeta{EncryptKeyID: keyRing.ActiveID()}
+
+	ioPools := newIoPoolMock(t)
+	ioQos, _ := qos.NewIoQueueQos(qos.Config{ReadQueueDepth: 2, WriteQueueDepth: 2, WriteChanQueCnt: 2})
+	defer ioQos.Close()
+	cd, err := NewChunkData(ctx, meta, chunkname, diskConfig, true, ioQos, ioPools)
+	require.NoError(t, err)
+	defer cd.Close()
+	require.Equal(t, keyRing.ActiveID(), cd.header.encryptKeyID)
+	require.NotNil(t, cd.key)
+
+	data := bytes.Repeat([]byte("encryption at rest"), 8*1024)
+	shard := &core.Shard{
+		Bid:  1024,
+		Vuid: 10,
+		Flag: bnapi.ShardStatusNormal,
+		Size: uint32(len(data)),
+		Body: bytes.NewReader(data),
+	}
+	require.NoError(t, cd.Write(ctx, shard))
+	require.Equal(t, crc32.ChecksumIEEE(data), shard.Crc)
+	// space on disk is of the sealed payload
+	require.Greater(t, cd.PhySize(int64(shard.Size)), core.Alignphysize(int64(shard.Size)))
+	require.LessOrEqual(t, cd.PhySize(int64(shard.Size)), cd.wOff-shard.Offset)
+
+	// payload is not plaintext on disk
+	raw := make([]byte, cd.wOff-shard.Offset)
+	_, err = cd.ef.ReadAt(raw, shard.Offset)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(raw, data[:64]))
+
+	// the reopened chunk data decrypts from any offset
+	cd1, err := NewChunkData(ctx, core.VuidMeta{}, chunkname, diskConfig, false, ioQos, ioPools)
+	require.NoError(t, err)
+	defer cd1.Close()
+	require.Equal(t, cd.header, cd1.header)
+	for _, rg := range [][2]uint32{{0, shard.Size}, {1, 2}, {17, 70000}, {65535, 65537}, {shard.Size - 1, shard.Size}} {
+		r, err := cd1.Read(ctx, shard, rg[0], rg[1])
+		require.NoError(t, err)
+		dst := make([]byte, rg[1]-rg[0])
+		_, err = io.ReadFull(r, dst)
+		require.NoError(t, err)
+		require.Equal(t, data[rg[0]:rg[1]], dst)
+	}
+
+	// data key is missing
+	diskConfig.KeyRing = nil
+	_, err = NewChunkData(ctx, core.VuidMeta{}, chunkname, diskConfig, false, ioQos, ioPools)
+	require.ErrorContains(t, err, core.ErrEncryptKeyNotFound.Error())
+}
@@ -240,6 +240,59 @@ func (cm *metafile) Delete(ctx context.Context, bid proto.BlobID) (err error) {
 	return nil
 }
 
+// BatchWrite writes meta of shards in one atomic batch.
+func (cm *metafile) BatchWrite(ctx context.Context, bids []proto.BlobID, values []core.ShardMeta) (err error) {
+	if len(bids) != len(values) {
+		return bloberr.ErrInvalidParam
+	}
+
+	batch := new(rdb.WriteBatch)
+	keys := make([][]byte, 0, len(bids))
+	defer func() {
+		for _, key := range keys {
+			cm.shardkeyPool.Put(key) // nolint: staticcheck
+		}
+	}()
+
+	for idx, bid := range bids {
+		key := cm.genShardKey(core.ShardKey{Chunk: cm.id, Bid: bid})
+		keys = append(keys, key)
+		valBytes, _ := values[idx].Marshal()
+		batch.Put(key, valBytes)
+	}
+
+	return cm.doBatch(ctx, batch, "md.w")
+}
+
+// BatchDelete deletes meta of shards in one atomic batch.
+func (cm *metafile) BatchDelete(ctx context.Context, bids []proto.BlobID) (err error) {
+	batch := new(rdb.WriteBatch)
+	keys := make([][]byte, 0, len(bids))
+	defer func() {
+		for _, key := range keys {
+			cm.shardkeyPool.Put(key) // nolint: staticcheck
+		}
+	}()
+
+	for _, bid := range bids {
+		key := cm.genShardKey(core.ShardKey{Chunk: cm.id, Bid: bid})
+		keys = append(keys, key)
+		batch.Delete(key)
+	}
+
+	return cm.doBatch(ctx, batch, "md.d")
+}
+
+func (cm *metafile) doBatch(ctx context.Context, batch *rdb.WriteBatch, track string) (err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	start := time.Now()
+
+	err = cm.db.DoBatch(ctx, batch)
+	span.AppendTrackLog(track, start, err)
+	return
+}
+
 func (cm *metafile) Close() {
 	cm.lock.Lock()
 	defer cm.lock.Unlock()
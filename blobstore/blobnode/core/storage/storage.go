@@ -20,6 +20,7 @@ import (
 	"io"
 	"math"
 	"sync/atomic"
+	"time"
 
 	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
@@ -91,6 +92,8 @@ func (stg *storage) Write(ctx context.Context, b *core.Shard) (err error) {
 		Crc:     b.Crc,
 		Offset:  b.Offset,
 		Flag:    b.Flag,
+
+		Retention: b.Retention,
 	})
 }
 
@@ -127,6 +130,10 @@ func (stg *storage) MarkDelete(ctx context.Context, bid proto.BlobID) (err error
 		return bloberr.ErrShardMarkDeleted
 	}
 
+	if shard.WormProtected(time.Now()) {
+		return bloberr.ErrShardWormProtected
+	}
+
 	shard.Flag = bnapi.ShardStatusMarkDelete
 
 	err = meta.Write(ctx, bid, shard)
@@ -153,6 +160,11 @@ func (stg *storage) Delete(ctx context.Context, bid proto.BlobID) (n int64, err
 		return n, bloberr.ErrShardNotMarkDelete
 	}
 
+	if shardMeta.WormProtected(time.Now()) {
+		span.Errorf("Failed: shard:%v is worm protected before:%d", bid, shardMeta.Retention)
+		return n, bloberr.ErrShardWormProtected
+	}
+
 	// delete meta
 	err = meta.Delete(ctx, bid)
 	if err != nil {
@@ -184,6 +196,126 @@ func (stg *storage) Delete(ctx context.Context, bid proto.BlobID) (n int64, err
 	return int64(shardMeta.Size), nil
 }
 
+// BatchMarkDelete marks shards deleted, the meta of all valid shards
+// is written in one batch, errs has the same order with bids.
+func (stg *storage) BatchMarkDelete(ctx context.Context, bids []proto.BlobID) (errs []error) {
+	meta := stg.meta
+	errs = make([]error, len(bids))
+
+	now := time.Now()
+	idxes := make([]int, 0, len(bids))
+	shards := make([]core.ShardMeta, 0, len(bids))
+	for idx, bid := range bids {
+		shard, err := meta.Read(ctx, bid)
+		if err != nil {
+			errs[idx] = err
+			continue
+		}
+		if shard.Flag == bnapi.ShardStatusMarkDelete {
+			errs[idx] = bloberr.ErrShardMarkDeleted
+			continue
+		}
+		if shard.WormProtected(now) {
+			errs[idx] = bloberr.ErrShardWormProtected
+			continue
+		}
+		shard.Flag = bnapi.ShardStatusMarkDelete
+		idxes = append(idxes, idx)
+		shards = append(shards, shard)
+	}
+	if len(idxes) == 0 {
+		return
+	}
+
+	validBids := make([]proto.BlobID, 0, len(idxes))
+	for _, idx := range idxes {
+		validBids = append(validBids, bids[idx])
+	}
+	if err := meta.BatchWrite(ctx, validBids, shards); err != nil {
+		for _, idx := range idxes {
+			errs[idx] = err
+		}
+	}
+	return
+}
+
+// BatchDelete deletes mark deleted shards, the meta of all valid shards
+// is deleted in one batch, then discards their data one by one.
+func (stg *storage) BatchDelete(ctx context.Context, bids []proto.BlobID) (ns []int64, errs []error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	meta, data := stg.meta, stg.data
+	ns = make([]int64, len(bids))
+	errs = make([]error, len(bids))
+
+	now := time.Now()
+	idxes := make([]int, 0, len(bids))
+	shardMetas := make([]core.ShardMeta, 0, len(bids))
+	for idx, bid := range bids {
+		shardMeta, err := meta.Read(ctx, bid)
+		if err != nil {
+			span.Errorf("Failed: shard:%v read err:%v", bid, err)
+			errs[idx] = err
+			continue
+		}
+		if shardMeta.Flag != bnapi.ShardStatusMarkDelete {
+			span.Errorf("Failed: shard:%v not mark delete", bid)
+			errs[idx] = bloberr.ErrShardNotMarkDelete
+			continue
+		}
+		if shardMeta.WormProtected(now) {
+			span.Errorf("Failed: shard:%v is worm protected before:%d", bid, shardMeta.Retention)
+			errs[idx] = bloberr.ErrShardWormProtected
+			continue
+		}
+		idxes = append(idxes, idx)
+		shardMetas = append(shardMetas, shardMeta)
+	}
+	if len(idxes) == 0 {
+		return
+	}
+
+	validBids := make([]proto.BlobID, 0, len(idxes))
+	for _, idx := range idxes {
+		validBids = append(validBids, bids[idx])
+	}
+	// delete meta
+	if err := meta.BatchDelete(ctx, validBids); err != nil {
+		span.Errorf("Failed: shards:%v meta batch delete:%v", validBids, err)
+		for _, idx := range idxes {
+			errs[idx] = err
+		}
+		return
+	}
+
+	for ii, idx := range idxes {
+		shardMeta := shardMetas[ii]
+		// data inline , skip
+		if shardMeta.Inline {
+			ns[idx] = int64(shardMeta.Size)
+			continue
+		}
+
+		shard := &core.Shard{
+			Vuid:   meta.ID().VolumeUnitId(),
+			Bid:    bids[idx],
+			Size:   shardMeta.Size,
+			Flag:   shardMeta.Flag,
+			Offset: shardMeta.Offset,
+			Crc:    shardMeta.Crc,
+		}
+
+		// discard hole
+		if err := data.Delete(ctx, shard); err != nil {
+			span.Errorf("Failed: shard:%v discard hole err:%v", bids[idx], err)
+			errs[idx] = err
+			continue
+		}
+		ns[idx] = int64(shardMeta.Size)
+	}
+	return
+}
+
 func (stg *storage) ScanMeta(ctx context.Context, startBid proto.BlobID, limit int,
 	fn func(bid proto.BlobID, sm *core.ShardMeta) error,
 ) (err error) {
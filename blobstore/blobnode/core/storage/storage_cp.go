@@ -187,6 +187,14 @@ func (stg *replicateStorage) Delete(ctx context.Context, bid proto.BlobID) (n in
 	return stg.masterStg.Delete(ctx, bid)
 }
 
+func (stg *replicateStorage) BatchMarkDelete(ctx context.Context, bids []proto.BlobID) (errs []error) {
+	return stg.masterStg.BatchMarkDelete(ctx, bids)
+}
+
+func (stg *replicateStorage) BatchDelete(ctx context.Context, bids []proto.BlobID) (ns []int64, errs []error) {
+	return stg.masterStg.BatchDelete(ctx, bids)
+}
+
 func (stg *replicateStorage) ScanMeta(ctx context.Context, startBid proto.BlobID, limit int,
 	fn func(bid proto.BlobID, sm *core.ShardMeta) error,
 ) (err error) {
@@ -57,6 +57,14 @@ func (mm *mockBrokenMeta) Delete(ctx context.Context, bid proto.BlobID) (err err
 	return
 }
 
+func (mm *mockBrokenMeta) BatchWrite(ctx context.Context, bids []proto.BlobID, values []core.ShardMeta) (err error) {
+	return bloberr.ErrUnexpected
+}
+
+func (mm *mockBrokenMeta) BatchDelete(ctx context.Context, bids []proto.BlobID) (err error) {
+	return bloberr.ErrUnexpected
+}
+
 func (mm *mockBrokenMeta) Scan(ctx context.Context, startBid proto.BlobID, limit int,
 	fn func(bid proto.BlobID, sm *core.ShardMeta) error,
 ) (err error) {
@@ -103,6 +111,10 @@ func (mm *mockBrokenData) Delete(ctx context.Context, shard *core.Shard) (err er
 	return
 }
 
+func (mm *mockBrokenData) PhySize(size int64) int64 {
+	return core.Alignphysize(size)
+}
+
 func (mm *mockBrokenData) Destroy(ctx context.Context) (err error) {
 	err = bloberr.ErrUnexpected
 
@@ -99,6 +99,19 @@ func (mm *mockmeta) Delete(ctx context.Context, bid proto.BlobID) (err error) {
 	return
 }
 
+func (mm *mockmeta) BatchWrite(ctx context.Context, bids []proto.BlobID, values []core.ShardMeta) (err error) {
+	for _, bid := range bids {
+		if err = _testBidTestMetas[int64(bid)].retErr; err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (mm *mockmeta) BatchDelete(ctx context.Context, bids []proto.BlobID) (err error) {
+	return
+}
+
 func (mm *mockmeta) Scan(ctx context.Context, startBid proto.BlobID, limit int,
 	fn func(bid proto.BlobID, sm *core.ShardMeta) error,
 ) (err error) {
@@ -142,6 +155,10 @@ func (mm *mockdata) Delete(ctx context.Context, shard *core.Shard) (err error) {
 	return
 }
 
+func (mm *mockdata) PhySize(size int64) int64 {
+	return core.Alignphysize(size)
+}
+
 func (mm *mockdata) Destroy(ctx context.Context) (err error) {
 	return
 }
@@ -232,6 +249,25 @@ func TestStorage_Operations(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestStorage_BatchDelete(t *testing.T) {
+	stg := NewStorage(&mockmeta{
+		id:   clustermgr.ChunkID{0x1},
+		bids: map[proto.BlobID]core.ShardMeta{},
+	}, &mockdata{})
+	ctx := context.TODO()
+
+	errs := stg.BatchMarkDelete(ctx, []proto.BlobID{1, 3, 4})
+	require.Equal(t, []error{nil, bloberr.ErrUnexpected, bloberr.ErrShardMarkDeleted}, errs)
+
+	ns, errs := stg.BatchDelete(ctx, []proto.BlobID{1, 3, 4})
+	require.Equal(t, []error{bloberr.ErrShardNotMarkDelete, bloberr.ErrUnexpected, nil}, errs)
+	require.Equal(t, []int64{0, 0, 0}, ns)
+
+	stg = NewStorage(&mockBrokenMeta{id: clustermgr.ChunkID{0x1}}, &mockdata{})
+	errs = stg.BatchMarkDelete(ctx, []proto.BlobID{1, 2})
+	require.Equal(t, []error{bloberr.ErrUnexpected, bloberr.ErrUnexpected}, errs)
+}
+
 func TestStorage_ShardInline(t *testing.T) {
 	stg := NewStorage(&mockmeta{
 		id:            clustermgr.ChunkID{0x1},
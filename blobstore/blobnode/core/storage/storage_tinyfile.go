@@ -91,6 +91,8 @@ func (stg *tinyfileStorage) Write(ctx context.Context, b *core.Shard) (err error
 		Flag:    b.Flag,
 		Inline:  true,
 		Buffer:  buffer,
+
+		Retention: b.Retention,
 	})
 }
 
@@ -37,6 +37,7 @@ type MetaHandler interface {
 	Put(ctx context.Context, kv rdb.KV) error
 	Delete(ctx context.Context, key []byte) error
 	DeleteRange(ctx context.Context, start, end []byte) error
+	DoBatch(ctx context.Context, batch *rdb.WriteBatch) error
 	Flush(ctx context.Context) error
 	NewIterator(ctx context.Context, opts ...rdb.OpOption) rdb.Iterator
 	SetHandleIOError(handler func(err error))
@@ -91,6 +92,12 @@ func (md *metadb) DeleteRange(ctx context.Context, start, end []byte) (err error
 	return
 }
 
+func (md *metadb) DoBatch(ctx context.Context, batch *rdb.WriteBatch) (err error) {
+	err = md.db.DoBatch(batch)
+	md.handleError(err)
+	return
+}
+
 func (md *metadb) NewIterator(ctx context.Context, opts ...rdb.OpOption) rdb.Iterator {
 	return md.db.NewIterator(nil, opts...)
 }
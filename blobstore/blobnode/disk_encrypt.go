@@ -0,0 +1,187 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/blobnode/core"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+var errEncryptDisabled = rpc.NewError(http.StatusBadRequest, "encrypt_disabled", core.ErrEncryptDisabled)
+
+// DiskEncryptStat is the encryption at rest state of a disk.
+type DiskEncryptStat struct {
+	DiskID    proto.DiskID `json:"disk_id"`
+	ActiveKey uint32       `json:"active_key"` // zero means new chunks are plaintext
+	Keys      []uint32     `json:"keys"`
+	// Chunks is count of chunks by data key, the key zero is plaintext
+	Chunks map[uint32]int `json:"chunks"`
+}
+
+// DiskEncryptMigrateRet is the result of migrating chunks to the active data key.
+type DiskEncryptMigrateRet struct {
+	DiskID    proto.DiskID `json:"disk_id"`
+	ActiveKey uint32       `json:"active_key"`
+	Pending   int          `json:"pending"`
+}
+
+func (s *Service) getDiskForEncrypt(c *rpc.Context) (core.DiskAPI, bool) {
+	args := new(bnapi.DiskStatArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return nil, false
+	}
+	if !bnapi.IsValidDiskID(args.DiskID) {
+		c.RespondError(bloberr.ErrInvalidDiskId)
+		return nil, false
+	}
+	s.lock.RLock()
+	ds, exist := s.Disks[args.DiskID]
+	s.lock.RUnlock()
+	if !exist {
+		c.RespondError(bloberr.ErrNoSuchDisk)
+		return nil, false
+	}
+	return ds, true
+}
+
+// unencryptedChunks returns the normal chunks not encrypted by the active data key
+func unencryptedChunks(ctx context.Context, ds core.DiskAPI) ([]core.VuidMeta, error) {
+	active := ds.GetConfig().KeyRing.ActiveID()
+	vms, err := ds.ListChunks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]core.VuidMeta, 0)
+	for _, vm := range vms {
+		if vm.Status != clustermgr.ChunkStatusNormal || vm.Compacting || vm.EncryptKeyID == active {
+			continue
+		}
+		if cs, exist := ds.GetChunkStorage(vm.Vuid); !exist || cs.ID() != vm.ChunkID {
+			continue
+		}
+		chunks = append(chunks, vm)
+	}
+	return chunks, nil
+}
+
+/*
+ *  method:         GET
+ *  url:            /disk/encrypt/stat/diskid/{diskid}
+ *  response body:  json.Marshal(DiskEncryptStat)
+ */
+func (s *Service) DiskEncryptStat(c *rpc.Context) {
+	ds, ok := s.getDiskForEncrypt(c)
+	if !ok {
+		return
+	}
+
+	vms, err := ds.ListChunks(c.Request.Context())
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+	keyRing := ds.GetConfig().KeyRing
+	stat := DiskEncryptStat{
+		DiskID:    ds.ID(),
+		ActiveKey: keyRing.ActiveID(),
+		Keys:      keyRing.IDs(),
+		Chunks:    make(map[uint32]int),
+	}
+	for _, vm := range vms {
+		if vm.Status == clustermgr.ChunkStatusRelease {
+			continue
+		}
+		stat.Chunks[vm.EncryptKeyID]++
+	}
+	c.RespondJSON(&stat)
+}
+
+/*
+ *  method:         POST
+ *  url:            /disk/encrypt/rotate/diskid/{diskid}
+ *  response body:  json.Marshal(DiskEncryptStat)
+ */
+func (s *Service) DiskEncryptRotate(c *rpc.Context) {
+	ds, ok := s.getDiskForEncrypt(c)
+	if !ok {
+		return
+	}
+	span := trace.SpanFromContextSafe(c.Request.Context())
+
+	keyRing := ds.GetConfig().KeyRing
+	id, err := keyRing.Rotate(c.Request.Context())
+	if err != nil {
+		span.Errorf("rotate data key of disk:%d failed: %v", ds.ID(), err)
+		if err == core.ErrEncryptDisabled {
+			err = errEncryptDisabled
+		}
+		c.RespondError(err)
+		return
+	}
+	span.Warnf("data key of disk:%d rotated to %d", ds.ID(), id)
+	c.RespondJSON(&DiskEncryptStat{DiskID: ds.ID(), ActiveKey: id, Keys: keyRing.IDs()})
+}
+
+/*
+ *  method:         POST
+ *  url:            /disk/encrypt/migrate/diskid/{diskid}
+ *  response body:  json.Marshal(DiskEncryptMigrateRet)
+ *  note:           the chunks not encrypted by the active data key are compacted in background,
+ *                  compaction rewrites shards into a new chunk encrypted by the active one.
+ */
+func (s *Service) DiskEncryptMigrate(c *rpc.Context) {
+	ds, ok := s.getDiskForEncrypt(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+
+	active := ds.GetConfig().KeyRing.ActiveID()
+	if active == 0 {
+		c.RespondError(errEncryptDisabled)
+		return
+	}
+	chunks, err := unencryptedChunks(ctx, ds)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	// compact channel of disk is unbuffered, enqueue in background,
+	// concurrent migration of the same disk is merged.
+	key := fmt.Sprintf("encrypt-migrate-%d", ds.ID())
+	s.singleFlight.DoChan(key, func() (interface{}, error) {
+		bgSpan, bgCtx := trace.StartSpanFromContextWithTraceID(s.ctx, "", span.TraceID())
+		for _, vm := range chunks {
+			bgSpan.Infof("migrate chunk:%s of disk:%d, key %d -> %d", vm.ChunkID, ds.ID(), vm.EncryptKeyID, active)
+			ds.EnqueueCompact(bgCtx, vm.Vuid)
+		}
+		return nil, nil
+	})
+
+	span.Infof("disk:%d enqueue %d chunks to migrate to data key %d", ds.ID(), len(chunks), active)
+	c.RespondJSON(&DiskEncryptMigrateRet{DiskID: ds.ID(), ActiveKey: active, Pending: len(chunks)})
+}
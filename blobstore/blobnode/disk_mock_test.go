@@ -396,6 +396,35 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// BatchDelete mocks base method.
+func (m *MockStorage) BatchDelete(arg0 context.Context, arg1 []proto.BlobID) ([]int64, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockStorageMockRecorder) BatchDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockStorage)(nil).BatchDelete), arg0, arg1)
+}
+
+// BatchMarkDelete mocks base method.
+func (m *MockStorage) BatchMarkDelete(arg0 context.Context, arg1 []proto.BlobID) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchMarkDelete", arg0, arg1)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// BatchMarkDelete indicates an expected call of BatchMarkDelete.
+func (mr *MockStorageMockRecorder) BatchMarkDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchMarkDelete", reflect.TypeOf((*MockStorage)(nil).BatchMarkDelete), arg0, arg1)
+}
+
 // Close mocks base method.
 func (m *MockStorage) Close(arg0 context.Context) {
 	m.ctrl.T.Helper()
@@ -695,6 +724,34 @@ func (mr *MockChunkAPIMockRecorder) AllowModify() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllowModify", reflect.TypeOf((*MockChunkAPI)(nil).AllowModify))
 }
 
+// BatchDelete mocks base method.
+func (m *MockChunkAPI) BatchDelete(arg0 context.Context, arg1 []proto.BlobID) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", arg0, arg1)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockChunkAPIMockRecorder) BatchDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockChunkAPI)(nil).BatchDelete), arg0, arg1)
+}
+
+// BatchMarkDelete mocks base method.
+func (m *MockChunkAPI) BatchMarkDelete(arg0 context.Context, arg1 []proto.BlobID) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchMarkDelete", arg0, arg1)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// BatchMarkDelete indicates an expected call of BatchMarkDelete.
+func (mr *MockChunkAPIMockRecorder) BatchMarkDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchMarkDelete", reflect.TypeOf((*MockChunkAPI)(nil).BatchMarkDelete), arg0, arg1)
+}
+
 // ChunkInfo mocks base method.
 func (m *MockChunkAPI) ChunkInfo(arg0 context.Context) clustermgr.ChunkInfo {
 	m.ctrl.T.Helper()
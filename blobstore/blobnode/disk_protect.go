@@ -0,0 +1,235 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	"github.com/cubefs/cubefs/blobstore/blobnode/base"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+const (
+	DefaultDiskProtectWindowSec = 60 // 1 min
+
+	diskProtectReasonNoSpace = "enospc"
+	diskProtectReasonEIO     = "eio"
+)
+
+// DiskProtectConf is thresholds of errors within the window to switch a disk to readonly,
+// zero threshold disables the protection of the error type.
+// Without eio threshold, the disk is set broken at the first eio error.
+type DiskProtectConf struct {
+	EnospcThreshold int `json:"enospc_threshold"`
+	EIOThreshold    int `json:"eio_threshold"`
+	WindowSec       int `json:"window_S"`
+}
+
+// DiskProtectStat is the protection state of a disk.
+type DiskProtectStat struct {
+	DiskID      proto.DiskID `json:"disk_id"`
+	Readonly    bool         `json:"readonly"`
+	Reason      string       `json:"reason,omitempty"`
+	TriggeredAt time.Time    `json:"triggered_at,omitempty"`
+	Reported    bool         `json:"reported"`
+	EnospcCount int          `json:"enospc_count"`
+	EIOCount    int          `json:"eio_count"`
+}
+
+type diskProtectState struct {
+	windowStart time.Time
+	stat        DiskProtectStat
+}
+
+// DiskProtectMgr switches a disk to readonly locally when the count of ENOSPC
+// or EIO errors crosses the threshold within the window, the readonly disk
+// rejects writes and is reported to clustermgr in the next heartbeat.
+type DiskProtectMgr struct {
+	conf DiskProtectConf
+
+	lock  sync.RWMutex
+	disks map[proto.DiskID]*diskProtectState
+}
+
+func NewDiskProtectMgr(conf DiskProtectConf) *DiskProtectMgr {
+	return &DiskProtectMgr{
+		conf:  conf,
+		disks: make(map[proto.DiskID]*diskProtectState),
+	}
+}
+
+// handleError records the error of disk, returns true if the error is handled by protection.
+func (mgr *DiskProtectMgr) handleError(ctx context.Context, diskID proto.DiskID, err error) bool {
+	var reason string
+	switch {
+	case base.IsENOSPC(err):
+		if mgr.conf.EnospcThreshold <= 0 {
+			return true // not broken, ignore it
+		}
+		reason = diskProtectReasonNoSpace
+	case base.IsEIO(err):
+		if mgr.conf.EIOThreshold <= 0 {
+			return false
+		}
+		reason = diskProtectReasonEIO
+	default:
+		return false
+	}
+
+	span := trace.SpanFromContextSafe(ctx)
+	now := time.Now()
+
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	state, exist := mgr.disks[diskID]
+	if !exist {
+		state = &diskProtectState{windowStart: now, stat: DiskProtectStat{DiskID: diskID}}
+		mgr.disks[diskID] = state
+	}
+	if state.stat.Readonly {
+		return true
+	}
+	if now.Sub(state.windowStart) > time.Duration(mgr.conf.WindowSec)*time.Second {
+		state.windowStart = now
+		state.stat.EnospcCount, state.stat.EIOCount = 0, 0
+	}
+
+	var count, threshold int
+	if reason == diskProtectReasonNoSpace {
+		state.stat.EnospcCount++
+		count, threshold = state.stat.EnospcCount, mgr.conf.EnospcThreshold
+	} else {
+		state.stat.EIOCount++
+		count, threshold = state.stat.EIOCount, mgr.conf.EIOThreshold
+	}
+	if count < threshold {
+		span.Warnf("disk:%d %s error count:%d threshold:%d, err:%v", diskID, reason, count, threshold, err)
+		return true
+	}
+
+	state.stat.Readonly = true
+	state.stat.Reason = fmt.Sprintf("%s count %d reached threshold %d in %ds: %v",
+		reason, count, threshold, mgr.conf.WindowSec, err)
+	state.stat.TriggeredAt = now
+	span.Errorf("disk:%d switch to readonly, reason: %s", diskID, state.stat.Reason)
+	return true
+}
+
+func (mgr *DiskProtectMgr) isReadonly(diskID proto.DiskID) bool {
+	mgr.lock.RLock()
+	defer mgr.lock.RUnlock()
+	state, exist := mgr.disks[diskID]
+	return exist && state.stat.Readonly
+}
+
+// unreported returns the readonly disks not reported to clustermgr
+func (mgr *DiskProtectMgr) unreported() []proto.DiskID {
+	mgr.lock.RLock()
+	defer mgr.lock.RUnlock()
+	diskIDs := make([]proto.DiskID, 0)
+	for diskID, state := range mgr.disks {
+		if state.stat.Readonly && !state.stat.Reported {
+			diskIDs = append(diskIDs, diskID)
+		}
+	}
+	return diskIDs
+}
+
+func (mgr *DiskProtectMgr) setReported(diskID proto.DiskID) {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	if state, exist := mgr.disks[diskID]; exist && state.stat.Readonly {
+		state.stat.Reported = true
+	}
+}
+
+func (mgr *DiskProtectMgr) getStat(diskID proto.DiskID) DiskProtectStat {
+	mgr.lock.RLock()
+	defer mgr.lock.RUnlock()
+	if state, exist := mgr.disks[diskID]; exist {
+		return state.stat
+	}
+	return DiskProtectStat{DiskID: diskID}
+}
+
+// reset clears the protection state, the disk is writable locally again
+func (mgr *DiskProtectMgr) reset(diskID proto.DiskID) {
+	mgr.lock.Lock()
+	delete(mgr.disks, diskID)
+	mgr.lock.Unlock()
+}
+
+// reportReadonlyDisks sets the disks switched to readonly locally readonly in clustermgr
+func (s *Service) reportReadonlyDisks(ctx context.Context, readonly map[proto.DiskID]bool) {
+	span := trace.SpanFromContextSafe(ctx)
+	for _, diskID := range s.protectMgr.unreported() {
+		if !readonly[diskID] {
+			if err := s.ClusterMgrClient.SetReadonlyDisk(ctx, diskID, true); err != nil {
+				span.Errorf("report readonly disk:%d failed: %v", diskID, err)
+				continue
+			}
+		}
+		span.Warnf("readonly disk:%d reported, reason: %s", diskID, s.protectMgr.getStat(diskID).Reason)
+		s.protectMgr.setReported(diskID)
+	}
+}
+
+/*
+ *  method:         GET
+ *  url:            /disk/protect/stat/diskid/{diskid}
+ *  response body:  json.Marshal(DiskProtectStat)
+ */
+func (s *Service) DiskProtectStat(c *rpc.Context) {
+	args := new(bnapi.DiskStatArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if !bnapi.IsValidDiskID(args.DiskID) {
+		c.RespondError(bloberr.ErrInvalidDiskId)
+		return
+	}
+
+	stat := s.protectMgr.getStat(args.DiskID)
+	c.RespondJSON(&stat)
+}
+
+/*
+ *  method:         POST
+ *  url:            /disk/protect/reset/diskid/{diskid}
+ */
+func (s *Service) DiskProtectReset(c *rpc.Context) {
+	args := new(bnapi.DiskStatArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span := trace.SpanFromContextSafe(c.Request.Context())
+	if !bnapi.IsValidDiskID(args.DiskID) {
+		c.RespondError(bloberr.ErrInvalidDiskId)
+		return
+	}
+
+	span.Warnf("reset protection of disk:%d, stat: %+v", args.DiskID, s.protectMgr.getStat(args.DiskID))
+	s.protectMgr.reset(args.DiskID)
+	c.Respond()
+}
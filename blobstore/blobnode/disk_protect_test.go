@@ -0,0 +1,129 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+func TestDiskProtectMgr(t *testing.T) {
+	ctx := context.Background()
+	diskID := proto.DiskID(101)
+	errNoSpace := fmt.Errorf("write failed: %w", syscall.ENOSPC)
+	errIO := fmt.Errorf("read failed: %w", syscall.EIO)
+
+	// disabled protection
+	mgr := NewDiskProtectMgr(DiskProtectConf{WindowSec: 60})
+	require.True(t, mgr.handleError(ctx, diskID, errNoSpace))
+	require.False(t, mgr.handleError(ctx, diskID, errIO))
+	require.False(t, mgr.handleError(ctx, diskID, bloberr.ErrDiskBroken))
+	require.False(t, mgr.isReadonly(diskID))
+
+	mgr = NewDiskProtectMgr(DiskProtectConf{EnospcThreshold: 3, EIOThreshold: 2, WindowSec: 60})
+	require.True(t, mgr.handleError(ctx, diskID, errNoSpace))
+	require.True(t, mgr.handleError(ctx, diskID, errNoSpace))
+	require.False(t, mgr.isReadonly(diskID))
+	require.Equal(t, 2, mgr.getStat(diskID).EnospcCount)
+	require.True(t, mgr.handleError(ctx, diskID, errNoSpace))
+	require.True(t, mgr.isReadonly(diskID))
+	stat := mgr.getStat(diskID)
+	require.Contains(t, stat.Reason, diskProtectReasonNoSpace)
+	require.False(t, stat.Reported)
+	require.Equal(t, []proto.DiskID{diskID}, mgr.unreported())
+
+	mgr.setReported(diskID)
+	require.Equal(t, 0, len(mgr.unreported()))
+	mgr.reset(diskID)
+	require.False(t, mgr.isReadonly(diskID))
+	require.Equal(t, DiskProtectStat{DiskID: diskID}, mgr.getStat(diskID))
+
+	// errors out of the window are not counted
+	mgr.handleError(ctx, diskID, errIO)
+	mgr.disks[diskID].windowStart = time.Now().Add(-2 * time.Minute)
+	mgr.handleError(ctx, diskID, errIO)
+	require.False(t, mgr.isReadonly(diskID))
+	require.Equal(t, 1, mgr.getStat(diskID).EIOCount)
+	mgr.handleError(ctx, diskID, errIO)
+	require.True(t, mgr.isReadonly(diskID))
+	require.Contains(t, mgr.getStat(diskID).Reason, diskProtectReasonEIO)
+}
+
+func TestDiskProtectReadonly(t *testing.T) {
+	service, mcm := newTestBlobNodeService(t, "DiskProtectReadonly")
+	defer cleanTestBlobNodeService(service)
+	service.protectMgr = NewDiskProtectMgr(DiskProtectConf{EnospcThreshold: 2, WindowSec: 60})
+
+	host := runTestServer(service)
+	client := bnapi.New(&bnapi.Config{})
+	ctx := context.TODO()
+
+	diskID := proto.DiskID(101)
+	vuid := proto.Vuid(2001)
+	require.NoError(t, client.CreateChunk(ctx, host, &bnapi.CreateChunkArgs{DiskID: diskID, Vuid: vuid}))
+
+	errNoSpace := fmt.Errorf("write failed: %w", syscall.ENOSPC)
+	for range [2]struct{}{} {
+		service.handleDiskIOError(ctx, diskID, errNoSpace)
+	}
+	require.Equal(t, proto.DiskStatusNormal, service.Disks[diskID].Status())
+	require.True(t, service.protectMgr.isReadonly(diskID))
+
+	shardData := []byte("testData")
+	_, err := client.PutShard(ctx, host, &bnapi.PutShardArgs{
+		DiskID: diskID,
+		Vuid:   vuid,
+		Bid:    proto.BlobID(30001),
+		Size:   int64(len(shardData)),
+		Body:   bytes.NewReader(shardData),
+	})
+	require.Equal(t, bloberr.CodeVUIDReadonly, rpc.DetectStatusCode(err))
+	err = client.CreateChunk(ctx, host, &bnapi.CreateChunkArgs{DiskID: diskID, Vuid: proto.Vuid(2002)})
+	require.Equal(t, bloberr.CodeVUIDReadonly, rpc.DetectStatusCode(err))
+
+	// reported in the next heartbeat
+	service.heartbeatToClusterMgr()
+	readonly, ok := mcm.readonlyDisks.Load(diskID)
+	require.True(t, ok)
+	require.Equal(t, true, readonly)
+
+	resp, err := http.Get(fmt.Sprintf("%s/disk/protect/stat/diskid/%d", host, diskID))
+	require.NoError(t, err)
+	stat := DiskProtectStat{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stat))
+	resp.Body.Close()
+	require.True(t, stat.Readonly)
+	require.True(t, stat.Reported)
+	require.Contains(t, stat.Reason, diskProtectReasonNoSpace)
+
+	resp, err = http.Post(fmt.Sprintf("%s/disk/protect/reset/diskid/%d", host, diskID), "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.False(t, service.protectMgr.isReadonly(diskID))
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+var errFaultInjectNotBuilt = rpc.NewError(http.StatusNotImplemented, "fault_inject_not_built",
+	errors.New("fault injection is not built, build blobnode with tag faultinject"))
+
+// faultInjector injects faults into reading shards for chaos testing,
+// it works only if blobnode is built with tag faultinject.
+type faultInjector struct {
+	lock  sync.RWMutex
+	rules []bnapi.FaultRule
+}
+
+func checkFaultRule(rule *bnapi.FaultRule) error {
+	switch rule.Kind {
+	case bnapi.FaultEIO, bnapi.FaultCrc:
+	case bnapi.FaultLatency:
+		if rule.LatencyMS <= 0 {
+			return fmt.Errorf("invalid latency_ms %d of fault latency", rule.LatencyMS)
+		}
+	default:
+		return fmt.Errorf("invalid fault kind %s", rule.Kind)
+	}
+	if rule.Percent <= 0 || rule.Percent > 100 {
+		return fmt.Errorf("invalid fault percent %d", rule.Percent)
+	}
+	return nil
+}
+
+func (f *faultInjector) setRules(rules []bnapi.FaultRule) {
+	f.lock.Lock()
+	f.rules = rules
+	f.lock.Unlock()
+}
+
+func (f *faultInjector) getRules() []bnapi.FaultRule {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]bnapi.FaultRule{}, f.rules...)
+}
+
+// fault returns the first matched rule hit by its percent
+func (f *faultInjector) fault(diskID proto.DiskID, vuid proto.Vuid) (bnapi.FaultRule, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	for _, rule := range f.rules {
+		if (rule.DiskID != 0 && rule.DiskID != diskID) || (rule.Vuid != 0 && rule.Vuid != vuid) {
+			continue
+		}
+		if rand.Intn(100) < rule.Percent {
+			return rule, true
+		}
+	}
+	return bnapi.FaultRule{}, false
+}
+
+// inject returns error of the injected fault, or delays the read
+func (f *faultInjector) inject(ctx context.Context, diskID proto.DiskID, vuid proto.Vuid) error {
+	if !faultInjectBuilt {
+		return nil
+	}
+	rule, ok := f.fault(diskID, vuid)
+	if !ok {
+		return nil
+	}
+
+	span := trace.SpanFromContextSafe(ctx)
+	span.Warnf("inject fault %+v into disk:%d vuid:%d", rule, diskID, vuid)
+	switch rule.Kind {
+	case bnapi.FaultEIO:
+		return bloberr.ErrDiskBroken
+	case bnapi.FaultCrc:
+		return bloberr.ErrShardCrcMismatch
+	case bnapi.FaultLatency:
+		timer := time.NewTimer(time.Duration(rule.LatencyMS) * time.Millisecond)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// FaultInjectSet replaces the fault rules of reading shards
+func (s *Service) FaultInjectSet(c *rpc.Context) {
+	if !faultInjectBuilt {
+		c.RespondError(errFaultInjectNotBuilt)
+		return
+	}
+	args := new(bnapi.FaultInjectArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span := trace.SpanFromContextSafe(c.Request.Context())
+	span.Warnf("set fault inject args:%+v", args)
+
+	for idx := range args.Rules {
+		if err := checkFaultRule(&args.Rules[idx]); err != nil {
+			c.RespondWith(http.StatusBadRequest, "", []byte(err.Error()))
+			return
+		}
+	}
+	s.faults.setRules(args.Rules)
+	c.Respond()
+}
+
+// FaultInjectGet returns the fault rules of reading shards
+func (s *Service) FaultInjectGet(c *rpc.Context) {
+	if !faultInjectBuilt {
+		c.RespondError(errFaultInjectNotBuilt)
+		return
+	}
+	c.RespondJSON(bnapi.FaultInjectArgs{Rules: s.faults.getRules()})
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
+)
+
+func TestFaultInjectRule(t *testing.T) {
+	for _, rule := range []bnapi.FaultRule{
+		{Kind: "x", Percent: 10},
+		{Kind: bnapi.FaultEIO},
+		{Kind: bnapi.FaultCrc, Percent: 101},
+		{Kind: bnapi.FaultLatency, Percent: 10},
+	} {
+		require.Error(t, checkFaultRule(&rule))
+	}
+	for _, rule := range []bnapi.FaultRule{
+		{Kind: bnapi.FaultEIO, Percent: 1},
+		{Kind: bnapi.FaultCrc, Percent: 100},
+		{Kind: bnapi.FaultLatency, Percent: 10, LatencyMS: 10},
+	} {
+		require.NoError(t, checkFaultRule(&rule))
+	}
+}
+
+func TestFaultInjectMatch(t *testing.T) {
+	f := new(faultInjector)
+	_, ok := f.fault(1, 1)
+	require.False(t, ok)
+
+	f.setRules([]bnapi.FaultRule{
+		{DiskID: 1, Vuid: 11, Kind: bnapi.FaultCrc, Percent: 100},
+		{DiskID: 1, Kind: bnapi.FaultEIO, Percent: 100},
+		{Vuid: 22, Kind: bnapi.FaultLatency, Percent: 100, LatencyMS: 10},
+		{DiskID: 3, Kind: bnapi.FaultEIO, Percent: 0},
+	})
+	require.Len(t, f.getRules(), 4)
+
+	rule, ok := f.fault(1, 11)
+	require.True(t, ok)
+	require.Equal(t, bnapi.FaultCrc, rule.Kind)
+	rule, ok = f.fault(1, 12)
+	require.True(t, ok)
+	require.Equal(t, bnapi.FaultEIO, rule.Kind)
+	rule, ok = f.fault(2, 22)
+	require.True(t, ok)
+	require.Equal(t, bnapi.FaultLatency, rule.Kind)
+	_, ok = f.fault(2, 21)
+	require.False(t, ok)
+	_, ok = f.fault(3, 31)
+	require.False(t, ok)
+
+	ctx := context.Background()
+	if !faultInjectBuilt {
+		require.NoError(t, f.inject(ctx, 1, 11))
+		return
+	}
+	require.ErrorIs(t, f.inject(ctx, 1, 11), bloberr.ErrShardCrcMismatch)
+	require.ErrorIs(t, f.inject(ctx, 1, 12), bloberr.ErrDiskBroken)
+	st := time.Now()
+	require.NoError(t, f.inject(ctx, 2, 22))
+	require.GreaterOrEqual(t, time.Since(st), 10*time.Millisecond)
+	require.NoError(t, f.inject(ctx, 2, 21))
+
+	f.setRules(nil)
+	require.NoError(t, f.inject(ctx, 1, 11))
+}
@@ -69,6 +69,13 @@ func (s *Service) heartbeatToClusterMgr() {
 
 	// sync disk status
 	s.syncDiskStatus(ctx, heartbeatResult)
+
+	// report disks switched to readonly by protection
+	readonly := make(map[proto.DiskID]bool, len(heartbeatResult))
+	for _, ret := range heartbeatResult {
+		readonly[ret.DiskID] = ret.ReadOnly
+	}
+	s.reportReadonlyDisks(ctx, readonly)
 }
 
 func (s *Service) syncDiskStatus(ctx context.Context, diskInfosRet []*cmapi.DiskHeartbeatRet) {
@@ -1,6 +1,9 @@
 package blobnode
 
 import (
+	"context"
+	"errors"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
@@ -27,11 +30,22 @@ var (
 		},
 		[]string{"cluster_id", "idc", "host", "node", "api", "io_type"},
 	)
+
+	ioCancelledMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "blobstore",
+			Subsystem: "blobnode",
+			Name:      "io_cancelled",
+			Help:      "blobnode disk io cancelled by client deadline or disconnect",
+		},
+		[]string{"cluster_id", "idc", "host", "node", "api", "reason"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(diskHealthMetric)
 	prometheus.MustRegister(networkMetric)
+	prometheus.MustRegister(ioCancelledMetric)
 }
 
 // when find the lost disk, set value 1
@@ -66,3 +80,20 @@ func (s *Service) reportPutTraffic(ioType bnapi.IOType, size int64) {
 	networkMetric.WithLabelValues(node.ClusterID.ToString(), node.IDC, node.Host, node.NodeID.ToString(),
 		"put", ioType.String()).Add(float64(size))
 }
+
+// reportIOCancelled returns true if the io is cancelled by ctx of request
+func (s *Service) reportIOCancelled(api string, err error) bool {
+	reason := ""
+	switch {
+	case errors.Is(err, context.Canceled):
+		reason = "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		reason = "deadline"
+	default:
+		return false
+	}
+	node := s.Conf.HostInfo
+	ioCancelledMetric.WithLabelValues(node.ClusterID.ToString(), node.IDC, node.Host, node.NodeID.ToString(),
+		api, reason).Inc()
+	return true
+}
@@ -35,10 +35,11 @@ var (
 
 func init() {
 	mod := &cmd.Module{
-		Name:       "BLOBNODE",
-		InitConfig: initConfig,
-		SetUp:      setUp,
-		TearDown:   tearDown,
+		Name:        "BLOBNODE",
+		InitConfig:  initConfig,
+		SetUp:       setUp,
+		PreShutdown: preShutdown,
+		TearDown:    tearDown,
 	}
 	cmd.RegisterModule(mod)
 }
@@ -92,6 +93,10 @@ func setUp() (*rpc.Router, []rpc.ProgressHandler) {
 	return NewHandler(gService), nil
 }
 
+func preShutdown() {
+	gService.PrepareShutdown()
+}
+
 func tearDown() {
 	base.DroppedBidRecorderInst().Close()
 	gService.Close()
@@ -103,6 +108,7 @@ func NewHandler(service *Service) *rpc.Router {
 	rpc.RegisterArgsParser(&bnapi.DiskStatArgs{}, "json")
 	rpc.RegisterArgsParser(&bnapi.DiskProbeArgs{}, "json")
 	rpc.RegisterArgsParser(&bnapi.ConfigReloadArgs{}, "json")
+	rpc.RegisterArgsParser(&bnapi.DiskQosArgs{}, "json")
 
 	rpc.RegisterArgsParser(&bnapi.CreateChunkArgs{}, "json")
 	rpc.RegisterArgsParser(&bnapi.ChangeChunkStatusArgs{}, "json")
@@ -125,6 +131,14 @@ func NewHandler(service *Service) *rpc.Router {
 
 	r.Handle(http.MethodGet, "/disk/stat/diskid/:diskid", service.DiskStat, rpc.OptArgsURI())
 	r.Handle(http.MethodPost, "/disk/probe", service.DiskProbe, rpc.OptArgsBody())
+	r.Handle(http.MethodPost, "/disk/qos/set", service.DiskQosSet, rpc.OptArgsBody())
+	r.Handle(http.MethodPost, "/disk/qos/reset/diskid/:diskid", service.DiskQosReset, rpc.OptArgsURI())
+	r.Handle(http.MethodGet, "/disk/qos/get/diskid/:diskid", service.DiskQosGet, rpc.OptArgsURI())
+	r.Handle(http.MethodGet, "/disk/protect/stat/diskid/:diskid", service.DiskProtectStat, rpc.OptArgsURI())
+	r.Handle(http.MethodPost, "/disk/protect/reset/diskid/:diskid", service.DiskProtectReset, rpc.OptArgsURI())
+	r.Handle(http.MethodGet, "/disk/encrypt/stat/diskid/:diskid", service.DiskEncryptStat, rpc.OptArgsURI())
+	r.Handle(http.MethodPost, "/disk/encrypt/rotate/diskid/:diskid", service.DiskEncryptRotate, rpc.OptArgsURI())
+	r.Handle(http.MethodPost, "/disk/encrypt/migrate/diskid/:diskid", service.DiskEncryptMigrate, rpc.OptArgsURI())
 
 	r.Handle(http.MethodPost, "/chunk/inspect/diskid/:diskid/vuid/:vuid", service.ChunkInspect, rpc.OptArgsURI())
 	r.Handle(http.MethodPost, "/chunk/create/diskid/:diskid/vuid/:vuid", service.ChunkCreate, rpc.OptArgsURI(), rpc.OptArgsQuery())
@@ -140,6 +154,8 @@ func NewHandler(service *Service) *rpc.Router {
 	r.Handle(http.MethodGet, "/shard/stat/diskid/:diskid/vuid/:vuid/bid/:bid", service.ShardStat, rpc.OptArgsURI())
 	r.Handle(http.MethodPost, "/shard/markdelete/diskid/:diskid/vuid/:vuid/bid/:bid", service.ShardMarkdelete, rpc.OptArgsURI())
 	r.Handle(http.MethodPost, "/shard/delete/diskid/:diskid/vuid/:vuid/bid/:bid", service.ShardDelete, rpc.OptArgsURI())
+	r.Handle(http.MethodPost, "/shard/batch/markdelete", service.ShardBatchMarkdelete, rpc.OptArgsBody())
+	r.Handle(http.MethodPost, "/shard/batch/delete", service.ShardBatchDelete, rpc.OptArgsBody())
 	r.Handle(http.MethodPost, "/shard/put/diskid/:diskid/vuid/:vuid/bid/:bid/size/:size", service.ShardPut, rpc.OptArgsURI(), rpc.OptArgsQuery())
 
 	r.Handle(http.MethodPost, "/shard/repair", service.WorkerService.ShardRepair, rpc.OptArgsBody())
@@ -147,5 +163,8 @@ func NewHandler(service *Service) *rpc.Router {
 	r.Handle(http.MethodPost, "/inspect/rate/:rate", service.SetInspectRate, rpc.OptArgsURI())
 	r.Handle(http.MethodGet, "/inspect/stat", service.GetInspectStat, rpc.OptArgsQuery())
 
+	r.Handle(http.MethodPost, "/fault/inject/set", service.FaultInjectSet, rpc.OptArgsBody())
+	r.Handle(http.MethodGet, "/fault/inject/get", service.FaultInjectGet)
+
 	return r
 }
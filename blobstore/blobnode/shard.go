@@ -15,9 +15,11 @@
 package blobnode
 
 import (
+	"io"
 	"math"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -50,7 +52,9 @@ func (s *Service) ShardGet(c *rpc.Context) {
 		return
 	}
 
-	ctx, w := c.Request.Context(), c.Writer
+	ctx, cancel := c.DeadlineContext() // cancel disk io abandoned by client
+	defer cancel()
+	w := c.Writer
 	span := trace.SpanFromContextSafe(ctx)
 
 	if !bnapi.IsValidDiskID(args.DiskID) {
@@ -104,37 +108,38 @@ func (s *Service) ShardGet(c *rpc.Context) {
 		return
 	}
 
+	if err := s.faults.inject(ctx, args.DiskID, args.Vuid); err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	// fail fast on the quarantined section, it will be repaired by scheduler
+	if s.quarantineMgr.isQuarantined(args.Vuid, args.Bid, from, to) {
+		span.Warnf("read quarantined shard. args:%v, range:[%d, %d)", args, from, to)
+		c.RespondError(bloberr.ErrShardCrcMismatch)
+		return
+	}
+
+	// serve the shard of hot chunk from local cache
+	hot := s.shardCache.touch(args.Vuid)
+	if hot && s.readShardCache(c, args, rangeBytesStr != "", from, to) {
+		return
+	}
+
+	// fill the cache with whole shard read from disk
+	var cacheBuffer *shardCacheBuffer
+	writer := io.Writer(w)
+	if hot && rangeBytesStr == "" && s.shardCache.beginFill(args.Vuid, args.Bid) {
+		cacheBuffer = &shardCacheBuffer{limit: s.shardCache.conf.MaxShardSize}
+		writer = io.MultiWriter(w, cacheBuffer)
+	}
+
 	// build shard reader
-	shard := core.NewShardReader(args.Bid, args.Vuid, from, to, w)
+	shard := core.NewShardReader(args.Bid, args.Vuid, from, to, writer)
 
 	shard.PrepareHook = func(shard *core.Shard) {
-		// set crc to header
-		// build http response header
-		w.Header().Set("Content-Type", "application/octet-stream")
-		w.Header().Set("Content-Transfer-Encoding", "binary")
-
-		from, to := shard.From, shard.To
-		bodySize := int64(shard.Size)
-
-		if rangeBytesStr != "" {
-			bodySize = to - from
-			if bodySize == int64(shard.Size) {
-				w.Header().Set("CRC", strconv.FormatUint(uint64(shard.Crc), 10))
-			}
-			rangeResp := "bytes " + strconv.FormatInt(from, 10) + "-" + strconv.FormatInt(to-1, 10) + "/" + strconv.FormatInt(int64(shard.Size), 10)
-			w.Header().Set("Content-Length", strconv.FormatInt(int64(bodySize), 10))
-			w.Header().Set("Content-Range", rangeResp)
-			c.RespondStatus(http.StatusPartialContent)
-		} else {
-			w.Header().Set("CRC", strconv.FormatUint(uint64(shard.Crc), 10))
-			w.Header().Set("Content-Length", strconv.FormatInt(bodySize, 10))
-			c.RespondStatus(http.StatusOK)
-		}
-
+		respondShardHeader(c, rangeBytesStr != "", shard.From, shard.To, int64(shard.Size), shard.Crc)
 		wroteHeader = true
-
-		// flush header, First byte optimization
-		c.Flush()
 	}
 
 	if rangeBytesStr != "" {
@@ -144,10 +149,24 @@ func (s *Service) ShardGet(c *rpc.Context) {
 		written, err = cs.Read(ctx, shard)
 	}
 
+	if cacheBuffer != nil {
+		if err != nil || cacheBuffer.overflow || int64(cacheBuffer.buf.Len()) != int64(shard.Size) {
+			s.shardCache.abortFill(args.Vuid, args.Bid)
+		} else if errFill := s.shardCache.fill(args.Vuid, args.Bid, cacheBuffer.buf.Bytes(), shard.Crc); errFill != nil {
+			span.Warnf("Failed to fill shard cache. args:%v err:%v", args, errFill)
+		}
+	}
+
 	if err != nil {
-		span.Errorf("Failed read. args:%v err:%v, written:%v", args, err, written)
+		if s.reportIOCancelled("get", err) {
+			span.Warnf("read cancelled. args:%v err:%v, written:%v", args, err, written)
+		} else {
+			span.Errorf("Failed read. args:%v err:%v, written:%v", args, err, written)
+		}
 		if isShardErr(err) {
 			s.inspectMgr.reportBadShard(cs, args.Bid, err)
+			s.quarantineMgr.quarantine(ctx, args.Vuid, args.Bid, shard.From, shard.To)
+			err = bloberr.ErrShardCrcMismatch
 		}
 		if !wroteHeader {
 			err = handlerBidNotFoundErr(err)
@@ -159,6 +178,60 @@ func (s *Service) ShardGet(c *rpc.Context) {
 	s.reportGetTraffic(args.Type, written)
 }
 
+// readShardCache serves the shard from local cache, returns false if not cached
+func (s *Service) readShardCache(c *rpc.Context, args *bnapi.GetShardArgs, ranged bool, start, end int64) bool {
+	f, item, ok := s.shardCache.get(args.Vuid, args.Bid)
+	if !ok {
+		return false
+	}
+	defer f.Close()
+
+	from, to := int64(0), item.size
+	if ranged {
+		var err error
+		// let the disk responds invalid range
+		if from, to, err = base.FixHttpRange(start, end, item.size); err != nil {
+			return false
+		}
+	}
+
+	span := trace.SpanFromContextSafe(c.Request.Context())
+	respondShardHeader(c, ranged, from, to, item.size, item.crc)
+	written, err := io.Copy(c.Writer, io.NewSectionReader(f, from, to-from))
+	if err != nil {
+		span.Errorf("Failed read shard cache. args:%v err:%v, written:%v", args, err, written)
+		return true
+	}
+	s.reportGetTraffic(args.Type, written)
+	return true
+}
+
+func respondShardHeader(c *rpc.Context, ranged bool, from, to, size int64, crc uint32) {
+	// set crc to header
+	// build http response header
+	w := c.Writer
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+
+	if ranged {
+		bodySize := to - from
+		if bodySize == size {
+			w.Header().Set("CRC", strconv.FormatUint(uint64(crc), 10))
+		}
+		rangeResp := "bytes " + strconv.FormatInt(from, 10) + "-" + strconv.FormatInt(to-1, 10) + "/" + strconv.FormatInt(size, 10)
+		w.Header().Set("Content-Length", strconv.FormatInt(bodySize, 10))
+		w.Header().Set("Content-Range", rangeResp)
+		c.RespondStatus(http.StatusPartialContent)
+	} else {
+		w.Header().Set("CRC", strconv.FormatUint(uint64(crc), 10))
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		c.RespondStatus(http.StatusOK)
+	}
+
+	// flush header, First byte optimization
+	c.Flush()
+}
+
 /*
  *  method:         GET
  *  url:            /shard/list/diskid/{diskid}/vuid/{vuid}/startbid/{bid}/status/{status}/count/{count}
@@ -268,6 +341,8 @@ func (s *Service) ShardStat(c *rpc.Context) {
 		Crc:    sm.Crc,
 		Flag:   sm.Flag,
 		Inline: sm.Inline,
+
+		Retention: sm.Retention,
 	}
 	c.RespondJSON(stat)
 }
@@ -348,6 +423,7 @@ func (s *Service) ShardMarkdelete(c *rpc.Context) {
 	ctx = limitio.SetLimitTrack(ctx)
 
 	err = cs.MarkDelete(ctx, args.Bid)
+	s.shardCache.invalidate(args.Vuid, args.Bid)
 	if err != nil {
 		err = handlerBidNotFoundErr(err)
 		span.Errorf("Failed to mark delete, err:%v", err)
@@ -431,12 +507,162 @@ func (s *Service) ShardDelete(c *rpc.Context) {
 	ctx = limitio.SetLimitTrack(ctx)
 
 	err = cs.Delete(ctx, args.Bid)
+	s.shardCache.invalidate(args.Vuid, args.Bid)
 	if err != nil {
 		err = handlerBidNotFoundErr(err)
 		span.Errorf("Failed to delete, err:%v", err)
 		c.RespondError(err)
 		return
 	}
+	s.quarantineMgr.release(args.Vuid, args.Bid)
+}
+
+/*
+ *  method:         POST
+ *  url:            /shard/batch/markdelete
+ *  request body:   json.Marshal(bnapi.BatchDeleteShardsArgs)
+ *  response body:  json.Marshal(bnapi.BatchDeleteShardsRet)
+ */
+func (s *Service) ShardBatchMarkdelete(c *rpc.Context) {
+	s.shardBatchDelete(c, true)
+}
+
+/*
+ *  method:         POST
+ *  url:            /shard/batch/delete
+ *  request body:   json.Marshal(bnapi.BatchDeleteShardsArgs)
+ *  response body:  json.Marshal(bnapi.BatchDeleteShardsRet)
+ */
+func (s *Service) ShardBatchDelete(c *rpc.Context) {
+	s.shardBatchDelete(c, false)
+}
+
+// shardBatchDelete marks delete or deletes shards of one disk,
+// shards of the same chunk are handled in one batch of metadata,
+// the error of each shard is responded in its result.
+func (s *Service) shardBatchDelete(c *rpc.Context, markDelete bool) {
+	args := new(bnapi.BatchDeleteShardsArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+
+	if err := args.Check(); err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	s.lock.RLock()
+	ds, exist := s.Disks[args.DiskID]
+	s.lock.RUnlock()
+	if !exist {
+		c.RespondError(bloberr.ErrNoSuchDisk)
+		return
+	}
+
+	if !ds.IsWritable() { // not normal disk, skip
+		c.RespondError(bloberr.ErrDiskBroken)
+		return
+	}
+
+	perDiskLimitKey := args.DiskID
+	if err := s.DeleteQpsLimitPerDisk.Acquire(perDiskLimitKey); err != nil {
+		span.Warnf("Shard batch delete overload perdisk. key:%d", perDiskLimitKey)
+		c.RespondError(bloberr.ErrOverload)
+		return
+	}
+	defer s.DeleteQpsLimitPerDisk.Release(perDiskLimitKey)
+
+	qosLmt := ds.GetIoQos() // max io wait
+	if !qosLmt.TryAllow(qos.IOTypeDel) {
+		c.RespondError(bloberr.ErrOverload)
+		return
+	}
+	defer qosLmt.Release(qos.IOTypeDel)
+
+	// set io type
+	ctx = bnapi.SetIoType(ctx, bnapi.NormalIO)
+	ctx = limitio.SetLimitTrack(ctx)
+
+	ret := &bnapi.BatchDeleteShardsRet{Results: make([]bnapi.DeleteShardResult, len(args.Shards))}
+	setResult := func(idx int, err error) {
+		ret.Results[idx].ShardID = args.Shards[idx]
+		if err != nil {
+			ret.Results[idx].Code = rpc.DetectStatusCode(err)
+			ret.Results[idx].Error = err.Error()
+		}
+	}
+
+	// group shards by vuid in order of request
+	vuids := make([]proto.Vuid, 0)
+	groups := make(map[proto.Vuid][]int)
+	for idx, shard := range args.Shards {
+		if _, ok := groups[shard.Vuid]; !ok {
+			vuids = append(vuids, shard.Vuid)
+		}
+		groups[shard.Vuid] = append(groups[shard.Vuid], idx)
+	}
+
+	for _, vuid := range vuids {
+		idxes := groups[vuid]
+		cs, exist := ds.GetChunkStorage(vuid)
+		if !exist {
+			for _, idx := range idxes {
+				setResult(idx, bloberr.ErrNoSuchVuid)
+			}
+			continue
+		}
+		if err := cs.AllowModify(); err != nil {
+			span.Warnf("ChunkStorage vuid:%d can not batch delete: %v", vuid, err)
+			for _, idx := range idxes {
+				setResult(idx, err)
+			}
+			continue
+		}
+
+		// acquire all keys of the vuid at once in order of bid,
+		// avoiding deadlock with the concurrent batches of the same bids
+		sort.Slice(idxes, func(i, j int) bool { return args.Shards[idxes[i]].Bid < args.Shards[idxes[j]].Bid })
+		bids := make([]proto.BlobID, 0, len(idxes))
+		keys := make([]interface{}, 0, len(idxes))
+		for _, idx := range idxes {
+			bids = append(bids, args.Shards[idx].Bid)
+			keys = append(keys, args.Shards[idx].Bid)
+		}
+		if err := s.DeleteQpsLimitPerKey.Acquire(keys...); err != nil {
+			span.Warnf("Shard batch delete concurrency keys of vuid:%d", vuid)
+			for _, idx := range idxes {
+				setResult(idx, bloberr.ErrOverload)
+			}
+			continue
+		}
+
+		var errs []error
+		if markDelete {
+			errs = cs.BatchMarkDelete(ctx, bids)
+		} else {
+			errs = cs.BatchDelete(ctx, bids)
+		}
+		s.DeleteQpsLimitPerKey.Release(keys...)
+		for ii, idx := range idxes {
+			bid := bids[ii]
+			s.shardCache.invalidate(vuid, bid)
+
+			err := errs[ii]
+			if err != nil {
+				err = handlerBidNotFoundErr(err)
+				span.Errorf("Failed to batch delete vuid:%d bid:%d mark:%v, err:%v", vuid, bid, markDelete, err)
+			} else if !markDelete {
+				s.quarantineMgr.release(vuid, bid)
+			}
+			setResult(idx, err)
+		}
+	}
+
+	c.RespondJSON(ret)
 }
 
 /*
@@ -451,7 +677,8 @@ func (s *Service) ShardPut(c *rpc.Context) {
 		return
 	}
 
-	ctx := c.Request.Context()
+	ctx, cancel := c.DeadlineContext() // cancel disk io abandoned by client
+	defer cancel()
 	span := trace.SpanFromContextSafe(ctx)
 
 	ret := &bnapi.PutShardRet{
@@ -497,6 +724,12 @@ func (s *Service) ShardPut(c *rpc.Context) {
 		return
 	}
 
+	if s.protectMgr.isReadonly(args.DiskID) {
+		span.Warnf("disk:%d is readonly by protection, args:%v", args.DiskID, args)
+		c.RespondError(bloberr.ErrReadonlyVUID)
+		return
+	}
+
 	err := cs.AllowModify()
 	if err != nil {
 		span.Errorf("cs status check Invalid. err: %v", err)
@@ -511,12 +744,22 @@ func (s *Service) ShardPut(c *rpc.Context) {
 		return
 	}
 
+	retention, err := s.shardRetention(ctx, args)
+	if err != nil {
+		span.Errorf("get worm retention failed, args:%+v, err:%v", args, err)
+		c.RespondError(err)
+		return
+	}
+
 	shard := core.NewShardWriter(args.Bid, args.Vuid, uint32(args.Size), c.Request.Body)
+	shard.Retention = retention
 
 	start := time.Now()
 	err = cs.Write(ctx, shard)
+	s.shardCache.invalidate(args.Vuid, args.Bid)
 	span.AppendTrackLog("disk.put", start, err)
 	if err != nil {
+		s.reportIOCancelled("put", err)
 		span.Errorf("Failed to put shard, args: %+v, err: %v", args, err)
 		c.RespondError(err)
 		return
@@ -534,6 +777,7 @@ func (s *Service) ShardPut(c *rpc.Context) {
 		}
 	}
 
+	s.quarantineMgr.release(args.Vuid, args.Bid)
 	s.reportPutTraffic(args.Type, args.Size)
 	c.RespondJSON(ret)
 }
@@ -0,0 +1,265 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+const (
+	DefaultShardCacheCapacity     = 16 << 30 // 16 GB
+	DefaultShardCacheMaxShardSize = 4 << 20  // 4 MB
+	DefaultShardCacheHotThreshold = 32
+	DefaultShardCacheWindowSec    = 60 // 1 min
+)
+
+// ShardCacheConf is the local read cache on SSD for the hot chunks of HDD disks.
+type ShardCacheConf struct {
+	Enable       bool   `json:"enable"`
+	Dir          string `json:"dir"`            // cache directory on SSD, cleared at startup
+	Capacity     int64  `json:"capacity"`       // max bytes of cached shards
+	MaxShardSize int64  `json:"max_shard_size"` // larger shards are not cached
+	HotThreshold int    `json:"hot_threshold"`  // reads of a chunk within the window to be hot
+	WindowSec    int    `json:"window_S"`
+}
+
+type shardCacheKey struct {
+	vuid proto.Vuid
+	bid  proto.BlobID
+}
+
+type shardCacheItem struct {
+	key  shardCacheKey
+	size int64
+	crc  uint32
+}
+
+// ShardCacheMgr tracks the read frequency of chunks, the shards of hot chunks
+// are cached in the SSD directory and evicted in LRU order.
+// Cached shard is invalidated when it is written or deleted, and the filling
+// of it started before is discarded.
+type ShardCacheMgr struct {
+	conf ShardCacheConf
+
+	freqLock sync.Mutex
+	freqs    map[proto.Vuid]int      // reads of chunks in current window
+	hots     map[proto.Vuid]struct{} // hot chunks of last window
+
+	lock    sync.Mutex
+	size    int64
+	lru     *list.List
+	items   map[shardCacheKey]*list.Element
+	filling map[shardCacheKey]struct{}
+}
+
+func NewShardCacheMgr(conf ShardCacheConf) (*ShardCacheMgr, error) {
+	if conf.Dir == "" {
+		return nil, fmt.Errorf("shard cache dir is empty")
+	}
+	// index of cached shards is in memory, drop the shards cached before
+	if err := os.RemoveAll(conf.Dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(conf.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &ShardCacheMgr{
+		conf:    conf,
+		freqs:   make(map[proto.Vuid]int),
+		hots:    make(map[proto.Vuid]struct{}),
+		lru:     list.New(),
+		items:   make(map[shardCacheKey]*list.Element),
+		filling: make(map[shardCacheKey]struct{}),
+	}, nil
+}
+
+// touch records a read of the chunk, returns true if the chunk is hot
+func (mgr *ShardCacheMgr) touch(vuid proto.Vuid) bool {
+	if mgr == nil {
+		return false
+	}
+	mgr.freqLock.Lock()
+	defer mgr.freqLock.Unlock()
+	mgr.freqs[vuid]++
+	if mgr.freqs[vuid] >= mgr.conf.HotThreshold {
+		return true
+	}
+	_, hot := mgr.hots[vuid]
+	return hot
+}
+
+// rotate starts a new window, chunks read frequently in the last window keep hot
+func (mgr *ShardCacheMgr) rotate() {
+	mgr.freqLock.Lock()
+	hots := make(map[proto.Vuid]struct{})
+	for vuid, freq := range mgr.freqs {
+		if freq >= mgr.conf.HotThreshold {
+			hots[vuid] = struct{}{}
+		}
+	}
+	mgr.hots = hots
+	mgr.freqs = make(map[proto.Vuid]int)
+	mgr.freqLock.Unlock()
+}
+
+func (mgr *ShardCacheMgr) shardPath(key shardCacheKey) string {
+	return filepath.Join(mgr.conf.Dir, fmt.Sprintf("%d_%d", key.vuid, key.bid))
+}
+
+// get opens the cached shard, the file is still readable if evicted after opened
+func (mgr *ShardCacheMgr) get(vuid proto.Vuid, bid proto.BlobID) (*os.File, shardCacheItem, bool) {
+	if mgr == nil {
+		return nil, shardCacheItem{}, false
+	}
+	key := shardCacheKey{vuid: vuid, bid: bid}
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	e, ok := mgr.items[key]
+	if !ok {
+		return nil, shardCacheItem{}, false
+	}
+	item := e.Value.(shardCacheItem)
+	f, err := os.Open(mgr.shardPath(key))
+	if err != nil {
+		mgr.removeLocked(e)
+		return nil, shardCacheItem{}, false
+	}
+	mgr.lru.MoveToFront(e)
+	return f, item, true
+}
+
+// beginFill returns true if the shard is not cached and could be filled
+func (mgr *ShardCacheMgr) beginFill(vuid proto.Vuid, bid proto.BlobID) bool {
+	if mgr == nil {
+		return false
+	}
+	key := shardCacheKey{vuid: vuid, bid: bid}
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	if _, ok := mgr.items[key]; ok {
+		return false
+	}
+	if _, ok := mgr.filling[key]; ok {
+		return false
+	}
+	mgr.filling[key] = struct{}{}
+	return true
+}
+
+// fill caches the data of shard, it is discarded if the shard is invalidated after beginFill
+func (mgr *ShardCacheMgr) fill(vuid proto.Vuid, bid proto.BlobID, data []byte, crc uint32) error {
+	key := shardCacheKey{vuid: vuid, bid: bid}
+	if int64(len(data)) > mgr.conf.MaxShardSize {
+		mgr.abortFill(vuid, bid)
+		return nil
+	}
+
+	path := mgr.shardPath(key)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		mgr.abortFill(vuid, bid)
+		os.Remove(tmpPath)
+		return err
+	}
+
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	if _, ok := mgr.filling[key]; !ok {
+		os.Remove(tmpPath)
+		return nil
+	}
+	delete(mgr.filling, key)
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	item := shardCacheItem{key: key, size: int64(len(data)), crc: crc}
+	mgr.items[key] = mgr.lru.PushFront(item)
+	mgr.size += item.size
+	for mgr.size > mgr.conf.Capacity && mgr.lru.Len() > 0 {
+		mgr.removeLocked(mgr.lru.Back())
+	}
+	return nil
+}
+
+func (mgr *ShardCacheMgr) abortFill(vuid proto.Vuid, bid proto.BlobID) {
+	if mgr == nil {
+		return
+	}
+	mgr.lock.Lock()
+	delete(mgr.filling, shardCacheKey{vuid: vuid, bid: bid})
+	mgr.lock.Unlock()
+}
+
+// invalidate the cached shard when it has been written or deleted
+func (mgr *ShardCacheMgr) invalidate(vuid proto.Vuid, bid proto.BlobID) {
+	if mgr == nil {
+		return
+	}
+	key := shardCacheKey{vuid: vuid, bid: bid}
+	mgr.lock.Lock()
+	delete(mgr.filling, key)
+	if e, ok := mgr.items[key]; ok {
+		mgr.removeLocked(e)
+	}
+	mgr.lock.Unlock()
+}
+
+func (mgr *ShardCacheMgr) removeLocked(e *list.Element) {
+	item := mgr.lru.Remove(e).(shardCacheItem)
+	delete(mgr.items, item.key)
+	mgr.size -= item.size
+	os.Remove(mgr.shardPath(item.key))
+}
+
+func (mgr *ShardCacheMgr) loopRotate(closeCh <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(mgr.conf.WindowSec) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			mgr.rotate()
+		case <-closeCh:
+			return
+		}
+	}
+}
+
+// shardCacheBuffer buffers the data read from disk to fill the cache,
+// it gives up buffering if the data is larger than limit.
+type shardCacheBuffer struct {
+	buf      bytes.Buffer
+	limit    int64
+	overflow bool
+}
+
+func (b *shardCacheBuffer) Write(p []byte) (int, error) {
+	if b.overflow || int64(b.buf.Len()+len(p)) > b.limit {
+		b.overflow = true
+		b.buf.Reset()
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
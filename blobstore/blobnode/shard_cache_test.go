@@ -0,0 +1,126 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestShardCacheHot(t *testing.T) {
+	var nilMgr *ShardCacheMgr
+	require.False(t, nilMgr.touch(1))
+	require.False(t, nilMgr.beginFill(1, 1))
+	nilMgr.invalidate(1, 1)
+
+	mgr, err := NewShardCacheMgr(ShardCacheConf{Dir: t.TempDir(), HotThreshold: 3})
+	require.NoError(t, err)
+
+	vuid := proto.EncodeVuid(proto.EncodeVuidPrefix(1, 2), 1)
+	require.False(t, mgr.touch(vuid))
+	require.False(t, mgr.touch(vuid))
+	require.True(t, mgr.touch(vuid))
+
+	// keep hot in the next window
+	mgr.rotate()
+	require.True(t, mgr.touch(vuid))
+	mgr.rotate()
+	require.False(t, mgr.touch(vuid))
+
+	_, err = NewShardCacheMgr(ShardCacheConf{})
+	require.Error(t, err)
+}
+
+func TestShardCacheFill(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "left"), []byte("left"), 0o644))
+
+	mgr, err := NewShardCacheMgr(ShardCacheConf{Dir: dir, Capacity: 10, MaxShardSize: 6, HotThreshold: 1})
+	require.NoError(t, err)
+	// cached shards before are removed
+	_, err = os.Stat(filepath.Join(dir, "left"))
+	require.True(t, os.IsNotExist(err))
+
+	vuid := proto.EncodeVuid(proto.EncodeVuidPrefix(1, 2), 1)
+	_, _, ok := mgr.get(vuid, 1)
+	require.False(t, ok)
+
+	require.True(t, mgr.beginFill(vuid, 1))
+	require.False(t, mgr.beginFill(vuid, 1))
+	require.NoError(t, mgr.fill(vuid, 1, []byte("hello"), 100))
+	require.False(t, mgr.beginFill(vuid, 1))
+
+	f, item, ok := mgr.get(vuid, 1)
+	require.True(t, ok)
+	require.Equal(t, int64(5), item.size)
+	require.Equal(t, uint32(100), item.crc)
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), data)
+	f.Close()
+
+	// too large to cache
+	require.True(t, mgr.beginFill(vuid, 2))
+	require.NoError(t, mgr.fill(vuid, 2, []byte("too large"), 0))
+	_, _, ok = mgr.get(vuid, 2)
+	require.False(t, ok)
+
+	// invalidated while filling
+	require.True(t, mgr.beginFill(vuid, 3))
+	mgr.invalidate(vuid, 3)
+	require.NoError(t, mgr.fill(vuid, 3, []byte("old"), 0))
+	_, _, ok = mgr.get(vuid, 3)
+	require.False(t, ok)
+
+	// evict the least recently used
+	require.True(t, mgr.beginFill(vuid, 4))
+	require.NoError(t, mgr.fill(vuid, 4, []byte("world"), 0))
+	f, _, ok = mgr.get(vuid, 1)
+	require.True(t, ok)
+	f.Close()
+	require.True(t, mgr.beginFill(vuid, 5))
+	require.NoError(t, mgr.fill(vuid, 5, []byte("!"), 0))
+	_, _, ok = mgr.get(vuid, 4)
+	require.False(t, ok)
+	_, err = os.Stat(mgr.shardPath(shardCacheKey{vuid: vuid, bid: 4}))
+	require.True(t, os.IsNotExist(err))
+	require.Equal(t, int64(6), mgr.size)
+
+	// invalidated after written
+	mgr.invalidate(vuid, 1)
+	_, _, ok = mgr.get(vuid, 1)
+	require.False(t, ok)
+	require.Equal(t, int64(1), mgr.size)
+}
+
+func TestShardCacheBuffer(t *testing.T) {
+	b := &shardCacheBuffer{limit: 4}
+	n, err := b.Write([]byte("abc"))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.False(t, b.overflow)
+	n, err = b.Write([]byte("de"))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.True(t, b.overflow)
+	require.Equal(t, 0, b.buf.Len())
+}
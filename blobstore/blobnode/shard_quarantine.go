@@ -0,0 +1,181 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+const (
+	DefaultQuarantineExpireSec  = 60 * 60 // 1 hour
+	DefaultQuarantineReportSize = 1024
+
+	quarantineReportRetry    = 3
+	quarantineReportInterval = time.Second
+	quarantineRepairReason   = "blobnode-crc-mismatch"
+)
+
+type ShardQuarantineConf struct {
+	ExpireSec  int            `json:"expire_sec"`  // quarantined section expires if not repaired
+	ReportSize int            `json:"report_size"` // max pending reports to shard repair queue
+	Proxy      proxy.LbConfig `json:"proxy"`       // proxy client to send shard repair message
+}
+
+type quarantineKey struct {
+	vuid proto.Vuid
+	bid  proto.BlobID
+}
+
+type quarantineItem struct {
+	rg       proto.ShardRange
+	expireAt time.Time
+}
+
+// ShardQuarantineMgr quarantines the bad section of shard found by reading,
+// the reads overlapped with the section fail fast until the shard is rewritten,
+// and the bad shard is reported to the shard repair queue asynchronously.
+type ShardQuarantineMgr struct {
+	conf      ShardQuarantineConf
+	clusterID proto.ClusterID
+	sender    proxy.LbMsgSender
+
+	lock   sync.RWMutex
+	shards map[quarantineKey]quarantineItem
+
+	reportCh chan *proxy.ShardRepairArgs
+}
+
+func NewShardQuarantineMgr(conf ShardQuarantineConf, clusterID proto.ClusterID, sender proxy.LbMsgSender) *ShardQuarantineMgr {
+	return &ShardQuarantineMgr{
+		conf:      conf,
+		clusterID: clusterID,
+		sender:    sender,
+		shards:    make(map[quarantineKey]quarantineItem),
+		reportCh:  make(chan *proxy.ShardRepairArgs, conf.ReportSize),
+	}
+}
+
+// quarantine the bad section [from, to) of shard, reports it if not quarantined before
+func (mgr *ShardQuarantineMgr) quarantine(ctx context.Context, vuid proto.Vuid, bid proto.BlobID, from, to int64) {
+	span := trace.SpanFromContextSafe(ctx)
+	key := quarantineKey{vuid: vuid, bid: bid}
+	rg := proto.ShardRange{From: from, To: to}
+
+	mgr.lock.Lock()
+	item, exist := mgr.shards[key]
+	if exist && !time.Now().After(item.expireAt) {
+		// merge with the quarantined section
+		if item.rg.From < rg.From {
+			rg.From = item.rg.From
+		}
+		if item.rg.To > rg.To {
+			rg.To = item.rg.To
+		}
+	} else {
+		exist = false
+	}
+	mgr.shards[key] = quarantineItem{rg: rg, expireAt: time.Now().Add(time.Duration(mgr.conf.ExpireSec) * time.Second)}
+	mgr.lock.Unlock()
+
+	span.Warnf("quarantine shard vuid:%d bid:%d range:[%d, %d)", vuid, bid, rg.From, rg.To)
+	if exist {
+		return
+	}
+
+	args := &proxy.ShardRepairArgs{
+		ClusterID: mgr.clusterID,
+		Bid:       bid,
+		Vid:       vuid.Vid(),
+		BadIdxes:  []uint8{vuid.Index()},
+		Reason:    quarantineRepairReason,
+		BadRange:  &proto.ShardRange{From: from, To: to},
+	}
+	select {
+	case mgr.reportCh <- args:
+	default:
+		span.Errorf("too many pending shard repair reports, drop vuid:%d bid:%d", vuid, bid)
+	}
+}
+
+// isQuarantined returns true if the section [from, to) overlaps with the quarantined one
+func (mgr *ShardQuarantineMgr) isQuarantined(vuid proto.Vuid, bid proto.BlobID, from, to int64) bool {
+	mgr.lock.RLock()
+	item, exist := mgr.shards[quarantineKey{vuid: vuid, bid: bid}]
+	mgr.lock.RUnlock()
+	if !exist || time.Now().After(item.expireAt) {
+		return false
+	}
+	if from < 0 || to <= from { // whole shard or unresolved range
+		from, to = 0, math.MaxInt64
+	}
+	return from < item.rg.To && item.rg.From < to
+}
+
+// release the quarantined shard after it has been rewritten or deleted
+func (mgr *ShardQuarantineMgr) release(vuid proto.Vuid, bid proto.BlobID) {
+	key := quarantineKey{vuid: vuid, bid: bid}
+	mgr.lock.Lock()
+	delete(mgr.shards, key)
+	mgr.lock.Unlock()
+}
+
+func (mgr *ShardQuarantineMgr) cleanExpired() {
+	now := time.Now()
+	mgr.lock.Lock()
+	for key, item := range mgr.shards {
+		if now.After(item.expireAt) {
+			delete(mgr.shards, key)
+		}
+	}
+	mgr.lock.Unlock()
+}
+
+func (mgr *ShardQuarantineMgr) loopReport(closeCh <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(mgr.conf.ExpireSec) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case args := <-mgr.reportCh:
+			mgr.report(args)
+		case <-ticker.C:
+			mgr.cleanExpired()
+		case <-closeCh:
+			return
+		}
+	}
+}
+
+func (mgr *ShardQuarantineMgr) report(args *proxy.ShardRepairArgs) {
+	span, ctx := trace.StartSpanFromContext(context.Background(), "")
+
+	var err error
+	for i := 0; i < quarantineReportRetry; i++ {
+		if err = mgr.sender.SendShardRepairMsg(ctx, args); err == nil {
+			span.Infof("report bad shard to repair, args:%+v", args)
+			return
+		}
+		span.Warnf("failed to report bad shard, args:%+v, err:%+v", args, err)
+		time.Sleep(quarantineReportInterval)
+	}
+	span.Errorf("give up reporting bad shard, args:%+v, err:%+v", args, err)
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
+)
+
+func TestShardQuarantine(t *testing.T) {
+	ctr := gomock.NewController(t)
+	ctx := context.Background()
+	vuid := proto.EncodeVuid(proto.EncodeVuidPrefix(1, 2), 1)
+	bid := proto.BlobID(100)
+
+	reported := make(chan *proxy.ShardRepairArgs, 1)
+	sender := mocks.NewMockProxyLbRpcClient(ctr)
+	sender.EXPECT().SendShardRepairMsg(any, any).DoAndReturn(
+		func(_ context.Context, args *proxy.ShardRepairArgs) error {
+			reported <- args
+			return nil
+		})
+
+	mgr := NewShardQuarantineMgr(ShardQuarantineConf{ExpireSec: 1, ReportSize: 2}, 1, sender)
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+	go mgr.loopReport(closeCh)
+
+	require.False(t, mgr.isQuarantined(vuid, bid, 0, 0))
+	mgr.quarantine(ctx, vuid, bid, 1024, 2048)
+	// merged, reported only once
+	mgr.quarantine(ctx, vuid, bid, 4096, 8192)
+
+	args := <-reported
+	require.Equal(t, proto.Vid(1), args.Vid)
+	require.Equal(t, []uint8{2}, args.BadIdxes)
+	require.Equal(t, &proto.ShardRange{From: 1024, To: 2048}, args.BadRange)
+
+	require.True(t, mgr.isQuarantined(vuid, bid, 0, 0))
+	require.True(t, mgr.isQuarantined(vuid, bid, 2000, 3000))
+	require.True(t, mgr.isQuarantined(vuid, bid, 8000, 9000))
+	require.False(t, mgr.isQuarantined(vuid, bid, 0, 1024))
+	require.False(t, mgr.isQuarantined(vuid, bid, 8192, 10000))
+	require.False(t, mgr.isQuarantined(vuid, bid+1, 0, 0))
+
+	// released by rewriting
+	mgr.release(vuid, bid)
+	require.False(t, mgr.isQuarantined(vuid, bid, 0, 0))
+
+	// expired
+	mgr.shards[quarantineKey{vuid: vuid, bid: bid}] = quarantineItem{
+		rg: proto.ShardRange{From: 0, To: 10}, expireAt: time.Now().Add(-time.Second),
+	}
+	require.False(t, mgr.isQuarantined(vuid, bid, 0, 0))
+	mgr.cleanExpired()
+	require.Equal(t, 0, len(mgr.shards))
+}
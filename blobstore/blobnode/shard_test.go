@@ -32,6 +32,7 @@ import (
 	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
 )
 
 func noLimitClient() bnapi.StorageAPI {
@@ -705,6 +706,82 @@ func TestShardDelete(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestShardBatchDelete(t *testing.T) {
+	service, _ := newTestBlobNodeService(t, "ShardBatchDelete")
+	defer cleanTestBlobNodeService(service)
+
+	host := runTestServer(service)
+	client := bnapi.New(&bnapi.Config{})
+	ctx := context.TODO()
+
+	diskID := proto.DiskID(101)
+	vuid := proto.Vuid(2001)
+	shardData := []byte("testData")
+
+	args := &bnapi.BatchDeleteShardsArgs{DiskID: diskID}
+	_, err := client.BatchMarkDeleteShards(ctx, host, args)
+	require.Error(t, err)
+	args.Shards = make([]bnapi.ShardID, bnapi.MaxBatchDeleteShards+1)
+	_, err = client.BatchDeleteShards(ctx, host, args)
+	require.Error(t, err)
+
+	err = client.CreateChunk(ctx, host, &bnapi.CreateChunkArgs{DiskID: diskID, Vuid: vuid})
+	require.NoError(t, err)
+	for bid := proto.BlobID(1); bid <= 2; bid++ {
+		_, err = client.PutShard(ctx, host, &bnapi.PutShardArgs{
+			DiskID: diskID,
+			Vuid:   vuid,
+			Bid:    bid,
+			Size:   int64(len(shardData)),
+			Body:   bytes.NewReader(shardData),
+		})
+		require.NoError(t, err)
+	}
+
+	// duplicated shard in one batch
+	args.Shards = []bnapi.ShardID{{Vuid: vuid, Bid: 1}, {Vuid: vuid, Bid: 1}}
+	_, err = client.BatchMarkDeleteShards(ctx, host, args)
+	require.Error(t, err)
+	require.Error(t, args.Check())
+
+	args.Shards = []bnapi.ShardID{{Vuid: vuid, Bid: 2}, {Vuid: vuid, Bid: 1}, {Vuid: vuid + 1, Bid: 3}}
+	codes := func(ret *bnapi.BatchDeleteShardsRet) (codes []int) {
+		require.Equal(t, len(args.Shards), len(ret.Results))
+		for idx, result := range ret.Results {
+			require.Equal(t, args.Shards[idx], result.ShardID)
+			codes = append(codes, rpc.DetectStatusCode(result.Err()))
+		}
+		return
+	}
+
+	ret, err := client.BatchDeleteShards(ctx, host, args)
+	require.NoError(t, err)
+	require.Equal(t, []int{bloberr.CodeShardNotMarkDelete, bloberr.CodeShardNotMarkDelete, bloberr.CodeVuidNotFound}, codes(ret))
+
+	ret, err = client.BatchMarkDeleteShards(ctx, host, args)
+	require.NoError(t, err)
+	require.Equal(t, []int{http.StatusOK, http.StatusOK, bloberr.CodeVuidNotFound}, codes(ret))
+
+	ret, err = client.BatchMarkDeleteShards(ctx, host, args)
+	require.NoError(t, err)
+	require.Equal(t, []int{bloberr.CodeShardMarkDeleted, bloberr.CodeShardMarkDeleted, bloberr.CodeVuidNotFound}, codes(ret))
+
+	ret, err = client.BatchDeleteShards(ctx, host, args)
+	require.NoError(t, err)
+	require.Equal(t, []int{http.StatusOK, http.StatusOK, bloberr.CodeVuidNotFound}, codes(ret))
+
+	for bid := proto.BlobID(1); bid <= 2; bid++ {
+		_, err = client.StatShard(ctx, host, &bnapi.StatShardArgs{DiskID: diskID, Vuid: vuid, Bid: bid})
+		require.Error(t, err)
+	}
+
+	service.Disks[diskID].SetStatus(proto.DiskStatusBroken)
+	_, err = client.BatchMarkDeleteShards(ctx, host, args)
+	require.Error(t, err)
+	_, err = client.BatchDeleteShards(ctx, host, args)
+	require.Error(t, err)
+}
+
 func TestShardDeleteConcurrency(t *testing.T) {
 	service, _ := newTestBlobNodeService(t, "ShardDeleteCon")
 	defer cleanTestBlobNodeService(service)
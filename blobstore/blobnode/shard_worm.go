@@ -0,0 +1,86 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+const spaceRetentionExpire = 10 * time.Minute
+
+type spaceRetentionItem struct {
+	retention time.Duration
+	expireAt  time.Time
+}
+
+// getSpaceRetention returns the worm retention of space, which is cached for a while
+func (s *Service) getSpaceRetention(ctx context.Context, sid proto.SpaceID) (time.Duration, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	itemVal, exist := s.spaceRetentions.Load(sid)
+	if exist && !itemVal.(spaceRetentionItem).expireAt.Before(time.Now()) {
+		return itemVal.(spaceRetentionItem).retention, nil
+	}
+
+	ret, err, _ := s.singleFlight.Do("space-"+strconv.FormatUint(uint64(sid), 10), func() (interface{}, error) {
+		space, err := s.ClusterMgrClient.GetSpaceByID(ctx, &cmapi.GetSpaceByIDArgs{SpaceID: sid})
+		if err != nil {
+			span.Warnf("get space[%d] from clustermgr failed: %s", sid, err)
+			if exist {
+				retention := itemVal.(spaceRetentionItem).retention
+				s.spaceRetentions.Store(sid, spaceRetentionItem{retention: retention, expireAt: time.Now().Add(spaceRetentionExpire)})
+				return retention, nil
+			}
+			return time.Duration(0), err
+		}
+		retention := time.Duration(space.WormRetentionSecs) * time.Second
+		s.spaceRetentions.Store(sid, spaceRetentionItem{retention: retention, expireAt: time.Now().Add(spaceRetentionExpire)})
+		return retention, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return ret.(time.Duration), nil
+}
+
+// shardRetention returns the worm retention of shard to be written. The retention is set
+// by the policy of space, and the retention of source shards is kept by background io.
+func (s *Service) shardRetention(ctx context.Context, args *bnapi.PutShardArgs) (int64, error) {
+	if args.Retention > 0 && args.Type != bnapi.BackgroundIO {
+		return 0, bloberr.ErrInvalidParam
+	}
+	retention := args.Retention
+	if args.SpaceID == 0 {
+		return retention, nil
+	}
+	spaceRetention, err := s.getSpaceRetention(ctx, args.SpaceID)
+	if err != nil {
+		return 0, err
+	}
+	if spaceRetention > 0 {
+		if r := time.Now().Add(spaceRetention).Unix(); r > retention {
+			retention = r
+		}
+	}
+	return retention, nil
+}
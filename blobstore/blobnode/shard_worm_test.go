@@ -0,0 +1,60 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestShardRetention(t *testing.T) {
+	ctx := context.Background()
+	s := &Service{}
+	s.spaceRetentions.Store(proto.SpaceID(1), spaceRetentionItem{retention: time.Hour, expireAt: time.Now().Add(time.Hour)})
+	s.spaceRetentions.Store(proto.SpaceID(2), spaceRetentionItem{expireAt: time.Now().Add(time.Hour)})
+
+	// retention can not be set by user io
+	_, err := s.shardRetention(ctx, &bnapi.PutShardArgs{Type: bnapi.NormalIO, Retention: 1})
+	require.ErrorIs(t, err, bloberr.ErrInvalidParam)
+
+	retention, err := s.shardRetention(ctx, &bnapi.PutShardArgs{Type: bnapi.NormalIO})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), retention)
+
+	// set by policy of space
+	now := time.Now()
+	retention, err = s.shardRetention(ctx, &bnapi.PutShardArgs{Type: bnapi.NormalIO, SpaceID: 1})
+	require.NoError(t, err)
+	require.LessOrEqual(t, now.Add(time.Hour).Unix(), retention)
+	retention, err = s.shardRetention(ctx, &bnapi.PutShardArgs{Type: bnapi.NormalIO, SpaceID: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), retention)
+
+	// kept from source shards
+	kept := now.Add(2 * time.Hour).Unix()
+	retention, err = s.shardRetention(ctx, &bnapi.PutShardArgs{Type: bnapi.BackgroundIO, Retention: kept})
+	require.NoError(t, err)
+	require.Equal(t, kept, retention)
+	retention, err = s.shardRetention(ctx, &bnapi.PutShardArgs{Type: bnapi.BackgroundIO, Retention: kept, SpaceID: 1})
+	require.NoError(t, err)
+	require.Equal(t, kept, retention)
+}
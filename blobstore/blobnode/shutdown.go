@@ -0,0 +1,144 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+const DefaultShutdownStateFilename = "./blobnode.shutdown"
+
+// ShutdownConf is graceful shutdown for rolling upgrade. If enabled, the writable disks are set
+// readonly in clustermgr before shutdown, no new chunk is created on them but they are not dropped,
+// and set writable again after restarted. The disks are recorded in state file.
+type ShutdownConf struct {
+	Enable bool `json:"enable"`
+	// DrainTimeoutSec waits the in-flight requests done at most
+	DrainTimeoutSec int    `json:"drain_timeout_S"`
+	StateFilename   string `json:"state_filename"`
+}
+
+// shutdownState disks set readonly by shutdown
+type shutdownState struct {
+	ReadonlyDisks []proto.DiskID `json:"readonly_disks"`
+}
+
+func loadShutdownState(filename string) (state shutdownState, err error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &state)
+	return
+}
+
+func saveShutdownState(filename string, state shutdownState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := filename + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// PrepareShutdown marks the writable disks of node readonly in clustermgr before servers shutdown,
+// the in-flight requests are drained and chunks are synced when the service closed.
+func (s *Service) PrepareShutdown() {
+	if !s.Conf.ShutdownConf.Enable {
+		return
+	}
+	span, ctx := trace.StartSpanFromContextWithTraceID(context.Background(), "", "PrepareShutdown")
+
+	registered, err := s.ClusterMgrClient.ListHostDisk(ctx, s.Conf.Host)
+	if err != nil {
+		span.Errorf("list host disk failed: %v", err)
+		return
+	}
+	loaded := make(map[proto.DiskID]bool)
+	for _, ds := range s.copyDiskStorages(ctx) {
+		loaded[ds.ID()] = ds.Status() == proto.DiskStatusNormal
+	}
+
+	var state shutdownState
+	for _, info := range registered {
+		// the disk set readonly by others is kept readonly after restarted
+		if info.Status == proto.DiskStatusNormal && !info.Readonly && loaded[info.DiskID] {
+			state.ReadonlyDisks = append(state.ReadonlyDisks, info.DiskID)
+		}
+	}
+	if len(state.ReadonlyDisks) == 0 {
+		return
+	}
+	// record before switching, the disks are reset even if shutdown is interrupted
+	if err = saveShutdownState(s.Conf.ShutdownConf.StateFilename, state); err != nil {
+		span.Errorf("save shutdown state failed: %v", err)
+		return
+	}
+	for _, diskID := range state.ReadonlyDisks {
+		if err = s.ClusterMgrClient.SetReadonlyDisk(ctx, diskID, true); err != nil {
+			span.Errorf("set disk:%d readonly failed: %v", diskID, err)
+			continue
+		}
+		span.Warnf("disk:%d set readonly before shutdown", diskID)
+	}
+}
+
+// recoverShutdownDisks sets the disks set readonly by last shutdown writable again,
+// the failed ones are retried at next startup.
+func (s *Service) recoverShutdownDisks(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+	filename := s.Conf.ShutdownConf.StateFilename
+	state, err := loadShutdownState(filename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			span.Errorf("load shutdown state failed: %v", err)
+		}
+		return
+	}
+
+	var remain shutdownState
+	for _, diskID := range state.ReadonlyDisks {
+		s.lock.RLock()
+		ds, exist := s.Disks[diskID]
+		s.lock.RUnlock()
+		if !exist || ds.Status() != proto.DiskStatusNormal {
+			span.Warnf("disk:%d set readonly by shutdown is not normal, skip it", diskID)
+			continue
+		}
+		if err = s.ClusterMgrClient.SetReadonlyDisk(ctx, diskID, false); err != nil {
+			span.Errorf("set disk:%d writable failed: %v", diskID, err)
+			remain.ReadonlyDisks = append(remain.ReadonlyDisks, diskID)
+			continue
+		}
+		span.Infof("disk:%d set writable after restarted", diskID)
+	}
+
+	if len(remain.ReadonlyDisks) > 0 {
+		err = saveShutdownState(filename, remain)
+	} else {
+		err = os.Remove(filename)
+	}
+	if err != nil {
+		span.Errorf("update shutdown state failed: %v", err)
+	}
+}
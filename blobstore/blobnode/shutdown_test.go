@@ -0,0 +1,88 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package blobnode
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestShutdownState(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "blobnode.shutdown")
+	_, err := loadShutdownState(filename)
+	require.True(t, os.IsNotExist(err))
+
+	state := shutdownState{ReadonlyDisks: []proto.DiskID{101, 102}}
+	require.NoError(t, saveShutdownState(filename, state))
+	loaded, err := loadShutdownState(filename)
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+
+	require.NoError(t, os.WriteFile(filename, []byte("{"), 0o644))
+	_, err = loadShutdownState(filename)
+	require.Error(t, err)
+}
+
+func TestServicePrepareShutdown(t *testing.T) {
+	ctx := context.Background()
+	service, mcm := newTestBlobNodeService(t, "PrepareShutdown")
+	defer cleanTestBlobNodeService(service)
+
+	filename := filepath.Join(t.TempDir(), "blobnode.shutdown")
+	service.Conf.ShutdownConf.StateFilename = filename
+
+	// disabled
+	service.PrepareShutdown()
+	_, err := os.Stat(filename)
+	require.True(t, os.IsNotExist(err))
+
+	diskIDs := make([]proto.DiskID, 0, len(mcm.disks))
+	for _, d := range mcm.disks {
+		diskIDs = append(diskIDs, d.diskId)
+	}
+	require.Equal(t, 2, len(diskIDs))
+	// readonly disk set by others is not recorded
+	mcm.readonlyDisks.Store(diskIDs[0], true)
+
+	service.Conf.ShutdownConf.Enable = true
+	service.PrepareShutdown()
+	state, err := loadShutdownState(filename)
+	require.NoError(t, err)
+	require.Equal(t, []proto.DiskID{diskIDs[1]}, state.ReadonlyDisks)
+	readonly, ok := mcm.readonlyDisks.Load(diskIDs[1])
+	require.True(t, ok)
+	require.True(t, readonly.(bool))
+
+	// recover after restarted
+	service.recoverShutdownDisks(ctx)
+	readonly, _ = mcm.readonlyDisks.Load(diskIDs[1])
+	require.False(t, readonly.(bool))
+	readonly, _ = mcm.readonlyDisks.Load(diskIDs[0])
+	require.True(t, readonly.(bool))
+	_, err = os.Stat(filename)
+	require.True(t, os.IsNotExist(err))
+
+	// disk not loaded is skipped
+	require.NoError(t, saveShutdownState(filename, shutdownState{ReadonlyDisks: []proto.DiskID{10000}}))
+	service.recoverShutdownDisks(ctx)
+	_, err = os.Stat(filename)
+	require.True(t, os.IsNotExist(err))
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base/flow"
 	"github.com/cubefs/cubefs/blobstore/blobnode/core"
 	"github.com/cubefs/cubefs/blobstore/blobnode/core/disk"
@@ -39,6 +40,8 @@ import (
 	"github.com/cubefs/cubefs/blobstore/util/log"
 )
 
+var errDiskLoadSkipped = errors.New("disk load skipped")
+
 const (
 	TickInterval   = 1
 	HeartbeatTicks = 30
@@ -102,6 +105,11 @@ func (s *Service) handleDiskIOError(ctx context.Context, diskID proto.DiskID, di
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("start to handle broken diskID:%d diskErr: %v", diskID, diskErr)
 
+	// errors under the thresholds of protection, or switching disk to readonly
+	if s.protectMgr.handleError(ctx, diskID, diskErr) {
+		return
+	}
+
 	// limit once. May be used by callback func, when concurrently read/write shard in datafile.go.
 	err := s.BrokenLimitPerDisk.Acquire(diskID)
 	if err != nil {
@@ -355,97 +363,30 @@ func NewService(conf Config) (svr *Service, err error) {
 		return nil, err
 	}
 
-	svr.ctx, svr.cancel = context.WithCancel(context.Background())
-
-	wg := sync.WaitGroup{}
+	svr.protectMgr = NewDiskProtectMgr(conf.ProtectConf)
+	svr.quarantineMgr = NewShardQuarantineMgr(conf.QuarantineConf, conf.ClusterID,
+		proxy.NewMQLbClient(&conf.QuarantineConf.Proxy, clusterMgrCli, conf.ClusterID))
 
-	lostCnt := int32(0)
-	for _, diskConf := range conf.Disks {
-		wg.Add(1)
-
-		go func(diskConf core.Config) {
-			var err error
-			defer wg.Done()
-
-			svr.fixDiskConf(&diskConf)
-
-			if diskConf.MustMountPoint && !myos.IsMountPoint(diskConf.Path) {
-				lost := atomic.AddInt32(&lostCnt, 1)
-				svr.reportLostDisk(&diskConf.HostInfo, diskConf.Path) // startup check lost disk
-				// skip
-				span.Errorf("Path is not mount point:%s, err:%+v. skip init", diskConf.Path, err)
-				if lost >= LostDiskCount {
-					log.Fatalf("lost disk count:%d over threshold:%d", lost, LostDiskCount)
-				}
-				return // skip
-			}
-			// read disk meta. get DiskID
-			format, err := readFormatInfo(ctx, diskConf.Path)
-			if err != nil {
-				// todo: report to ums
-				span.Errorf("Failed read diskMeta:%s, err:%+v. skip init", diskConf.Path, err)
-				return // skip
-			}
-
-			span.Debugf("local disk meta: %v", format)
-
-			// found diskInfo store in cluster mgr
-			diskInfo, foundInCluster := findDisk(registeredDisks, conf.ClusterID, format.DiskID)
-			span.Debugf("diskInfo: %v, foundInCluster:%v", diskInfo, foundInCluster)
-
-			nonNormal := foundInCluster && diskInfo.Status != proto.DiskStatusNormal
-			if nonNormal {
-				// todo: report to ums
-				span.Warnf("disk(%d):path(%s) is not normal, skip init", format.DiskID, diskConf.Path)
-				return // skip
-			}
-
-			ds, err := disk.NewDiskStorage(svr.ctx, diskConf)
-			if err != nil {
-				span.Fatalf("Failed Open DiskStorage. conf:%v, err:%+v", diskConf, err)
-				return
-			}
-
-			if !foundInCluster || conf.HostInfo.ReAddDisk { // need to re-register all disks
-				span.Warnf("diskInfo:%v not found in cm, will register to cm, nodeID:%d", diskInfo, conf.NodeID)
-				diskInfo := ds.DiskInfo() // get nodeID to add disk
-				err = clusterMgrCli.AddDisk(ctx, &diskInfo)
-				// if it need re-register disk, it is necessary to ignore duplicate registrations
-				if err != nil && (conf.HostInfo.ReAddDisk && rpc.DetectStatusCode(err) != http.StatusCreated) {
-					span.Fatalf("Failed register disk: %v, err:%+v", diskInfo, err)
-					return
-				}
-			}
+	// only HDD node needs local read cache on SSD
+	if conf.CacheConf.Enable && conf.HostInfo.DiskType == proto.DiskTypeHDD {
+		if svr.shardCache, err = NewShardCacheMgr(conf.CacheConf); err != nil {
+			span.Errorf("Failed to new shard cache, err: %v", err)
+			return nil, err
+		}
+	}
 
-			svr.lock.Lock()
-			svr.Disks[ds.DiskID] = ds
-			svr.lock.Unlock()
+	svr.ctx, svr.cancel = context.WithCancel(context.Background())
 
-			svr.reportOnlineDisk(&diskConf.HostInfo, diskConf.Path) // restart, normal disk
-			span.Infof("Init disk storage, cluster:%d, formatID:%d, diskID:%d", conf.ClusterID, format.DiskID, ds.ID())
-		}(diskConf)
-	}
-	wg.Wait()
+	svr.loadDisks(ctx, registeredDisks)
+	svr.recoverShutdownDisks(ctx)
 
 	if err = setDefaultIOStat(conf.DiskConfig.IOStatFileDryRun); err != nil {
 		span.Errorf("Failed set default iostat file, err:%v", err)
 		return nil, err
 	}
 
-	callBackFn := func(conf []byte) error {
-		_, ctx := trace.StartSpanFromContext(ctx, "")
-		c := Config{}
-		if err = config.LoadData(&c, conf); err != nil {
-			log.Errorf("reload fail to load config, err: %v", err)
-			return err
-		}
-		// limit
-		svr.changeLimit(ctx, c)
-		// qos
-		err := svr.changeQos(ctx, c)
-		return err
-	}
-	config.Register(callBackFn)
+	config.Watch("delete_qps_limit_per_disk", svr.changeLimit)
+	config.Watch("disk_config", svr.changeQos)
 
 	svr.WorkerService, err = NewWorkerService(&conf.WorkerConfig, clusterMgrCli, conf.ClusterID, conf.IDC)
 	if err != nil {
@@ -456,13 +397,167 @@ func NewService(conf Config) (svr *Service, err error) {
 	// background loop goroutines
 	go svr.loopHeartbeatToClusterMgr()
 	go svr.loopReportChunkInfoToClusterMgr()
+	go svr.loopReportChunkUsageToClusterMgr()
 	go svr.loopGcRubbishChunkFile()
 	go svr.loopCleanExpiredStatFile()
 	go svr.inspectMgr.loopDataInspect()
+	go svr.quarantineMgr.loopReport(svr.closeCh)
+	if svr.shardCache != nil {
+		go svr.shardCache.loopRotate(svr.closeCh)
+	}
 
 	return
 }
 
+// diskLoader loads one disk at startup
+type diskLoader struct {
+	conf core.Config
+	done chan struct{}
+
+	// set before done closed
+	diskID proto.DiskID // registered disk id, zero if not in cluster
+	ds     core.DiskAPI
+	err    error
+}
+
+func (l *diskLoader) finish(ds core.DiskAPI, err error) {
+	l.ds, l.err = ds, err
+	close(l.done)
+}
+
+// loadDisks loads all disks in parallel and waits each of them independently,
+// startup is not blocked by the disk loading timeout, which is registered once loaded.
+func (s *Service) loadDisks(ctx context.Context, registeredDisks []*cmapi.BlobNodeDiskInfo) {
+	lostCnt := int32(0)
+	timeout := time.Duration(s.Conf.DiskLoadTimeoutSec) * time.Second
+	var wg sync.WaitGroup
+	for _, diskConf := range s.Conf.Disks {
+		s.fixDiskConf(&diskConf)
+		loader := &diskLoader{conf: diskConf, done: make(chan struct{})}
+		go func() {
+			ds, err := s.loadDisk(ctx, loader, registeredDisks, &lostCnt)
+			loader.finish(ds, err)
+		}()
+
+		wg.Add(1)
+		go func() {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case <-loader.done:
+				s.diskLoaded(ctx, loader)
+				wg.Done()
+			case <-timer.C:
+				trace.SpanFromContextSafe(ctx).Warnf("load disk path(%s) timeout, keep loading in background", loader.conf.Path)
+				wg.Done()
+				<-loader.done
+				s.diskLoaded(ctx, loader)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// diskLoaded registers the loaded disk, or reports the disk broken to clustermgr if failed to load
+func (s *Service) diskLoaded(ctx context.Context, loader *diskLoader) {
+	span := trace.SpanFromContextSafe(ctx)
+	if loader.err != nil {
+		if loader.err != errDiskLoadSkipped {
+			span.Errorf("load disk(%d) path(%s) failed, err:%+v", loader.diskID, loader.conf.Path, loader.err)
+			s.setDiskBrokenAtStartup(ctx, loader.diskID)
+		}
+		return
+	}
+
+	ds := loader.ds
+	select {
+	case <-s.closeCh: // loaded after service closed
+		ds.Close(ctx)
+		return
+	default:
+	}
+	s.lock.Lock()
+	s.Disks[ds.ID()] = ds
+	s.lock.Unlock()
+	s.reportOnlineDisk(&loader.conf.HostInfo, loader.conf.Path) // restart, normal disk
+	span.Infof("Init disk storage, cluster:%d, diskID:%d", s.Conf.ClusterID, ds.ID())
+}
+
+// loadDisk returns errDiskLoadSkipped if the disk need not be loaded
+func (s *Service) loadDisk(ctx context.Context, loader *diskLoader,
+	registeredDisks []*cmapi.BlobNodeDiskInfo, lostCnt *int32,
+) (core.DiskAPI, error) {
+	span := trace.SpanFromContextSafe(ctx)
+	diskConf := loader.conf
+	conf := s.Conf
+
+	if diskConf.MustMountPoint && !myos.IsMountPoint(diskConf.Path) {
+		lost := atomic.AddInt32(lostCnt, 1)
+		s.reportLostDisk(&diskConf.HostInfo, diskConf.Path) // startup check lost disk
+		// skip
+		span.Errorf("Path is not mount point:%s. skip init", diskConf.Path)
+		if lost >= LostDiskCount {
+			log.Fatalf("lost disk count:%d over threshold:%d", lost, LostDiskCount)
+		}
+		return nil, errDiskLoadSkipped
+	}
+	// read disk meta. get DiskID
+	format, err := readFormatInfo(ctx, diskConf.Path)
+	if err != nil {
+		// todo: report to ums
+		span.Errorf("Failed read diskMeta:%s, err:%+v. skip init", diskConf.Path, err)
+		return nil, errDiskLoadSkipped
+	}
+
+	span.Debugf("local disk meta: %v", format)
+
+	// found diskInfo store in cluster mgr
+	diskInfo, foundInCluster := findDisk(registeredDisks, conf.ClusterID, format.DiskID)
+	span.Debugf("diskInfo: %v, foundInCluster:%v", diskInfo, foundInCluster)
+
+	nonNormal := foundInCluster && diskInfo.Status != proto.DiskStatusNormal
+	if nonNormal {
+		// todo: report to ums
+		span.Warnf("disk(%d):path(%s) is not normal, skip init", format.DiskID, diskConf.Path)
+		return nil, errDiskLoadSkipped
+	}
+	if foundInCluster {
+		loader.diskID = format.DiskID
+	}
+
+	ds, err := disk.NewDiskStorage(s.ctx, diskConf)
+	if err != nil {
+		span.Errorf("Failed Open DiskStorage. conf:%v, err:%+v", diskConf, err)
+		return nil, err
+	}
+
+	if !foundInCluster || conf.HostInfo.ReAddDisk { // need to re-register all disks
+		span.Warnf("diskInfo:%v not found in cm, will register to cm, nodeID:%d", diskInfo, conf.NodeID)
+		diskInfo := ds.DiskInfo() // get nodeID to add disk
+		err = s.ClusterMgrClient.AddDisk(ctx, &diskInfo)
+		// if it need re-register disk, it is necessary to ignore duplicate registrations
+		if err != nil && (conf.HostInfo.ReAddDisk && rpc.DetectStatusCode(err) != http.StatusCreated) {
+			span.Fatalf("Failed register disk: %v, err:%+v", diskInfo, err)
+			return nil, err
+		}
+	}
+	return ds, nil
+}
+
+// setDiskBrokenAtStartup reports disk broken to clustermgr once, disk not registered is ignored
+func (s *Service) setDiskBrokenAtStartup(ctx context.Context, diskID proto.DiskID) {
+	span := trace.SpanFromContextSafe(ctx)
+	if diskID == proto.InvalidDiskID {
+		return
+	}
+	err := s.ClusterMgrClient.SetDisk(ctx, diskID, proto.DiskStatusBroken)
+	if err != nil && rpc.DetectStatusCode(err) != bloberr.CodeChangeDiskStatusNotAllow {
+		span.Errorf("set disk(%d) broken failed: %+v", diskID, err)
+		return
+	}
+	span.Infof("set disk(%d) broken at startup, err:%+v", diskID, err)
+}
+
 func registerNode(ctx context.Context, clusterMgrCli *cmapi.Client, conf *Config) error {
 	span := trace.SpanFromContextSafe(ctx)
 	if err := core.CheckNodeConf(&conf.HostInfo); err != nil {
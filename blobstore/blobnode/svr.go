@@ -46,8 +46,12 @@ type Service struct {
 	// client handler
 	ClusterMgrClient *cmapi.Client
 
-	Conf       *Config
-	inspectMgr *DataInspectMgr
+	Conf          *Config
+	inspectMgr    *DataInspectMgr
+	quarantineMgr *ShardQuarantineMgr
+	protectMgr    *DiskProtectMgr
+	shardCache    *ShardCacheMgr // nil if disabled
+	faults        faultInjector  // chaos testing of reading shards
 
 	// limiter
 	DeleteQpsLimitPerKey  limit.Limiter
@@ -59,8 +63,10 @@ type Service struct {
 
 	RequestCount int64
 
-	globalConfig sync.Map
-	singleFlight singleflight.Group
+	globalConfig    sync.Map
+	spaceRetentions sync.Map // space id to its worm retention
+	singleFlight    singleflight.Group
+	qosLock         sync.Mutex // serializes updating qos of disks
 
 	// ctx is used for initiated requests that
 	// may need to be canceled on server shutdown.
@@ -80,7 +86,7 @@ func (s *Service) requestCounter(c *rpc.Context) {
 func (s *Service) waitAllRequestsDone(ctx context.Context) {
 	span := trace.SpanFromContextSafe(ctx)
 
-	shutdownTimer := time.NewTimer(DefaultShutdownTimeout)
+	shutdownTimer := time.NewTimer(time.Duration(s.Conf.ShutdownConf.DrainTimeoutSec) * time.Second)
 	defer shutdownTimer.Stop()
 	ticker := time.NewTicker(serverShutdownPoll)
 	defer ticker.Stop()
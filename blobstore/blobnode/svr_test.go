@@ -17,6 +17,7 @@ package blobnode
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"math"
 	"net/http"
@@ -613,6 +614,11 @@ type mockClusterMgr struct {
 	reqIdx  int64
 	nodeIdx int32
 	disks   []mockDiskInfo
+
+	readonlyDisks sync.Map
+
+	usageLock   sync.Mutex
+	usageReport *cmapi.ReportChunkUsageArgs
 }
 
 func init() {
@@ -621,7 +627,9 @@ func init() {
 	rpc.RegisterArgsParser(&cmapi.DiskInfoArgs{}, "json")
 	rpc.RegisterArgsParser(&cmapi.DisksHeartbeatArgs{}, "json")
 	rpc.RegisterArgsParser(&cmapi.DiskSetArgs{}, "json")
+	rpc.RegisterArgsParser(&cmapi.DiskAccessArgs{}, "json")
 	rpc.RegisterArgsParser(&cmapi.ReportChunkArgs{}, "json")
+	rpc.RegisterArgsParser(&cmapi.ReportChunkUsageArgs{}, "json")
 	rpc.RegisterArgsParser(&cmapi.GetVolumeArgs{}, "json")
 	rpc.RegisterArgsParser(&cmapi.NodeInfoArgs{}, "json")
 }
@@ -635,7 +643,9 @@ func mockClusterMgrRouter(service *mockClusterMgr) *rpc.Router {
 	r.Handle(http.MethodPost, "/disk/heartbeat", service.DiskHeartbeat, rpc.OptArgsBody())
 	r.Handle(http.MethodPost, "/disk/add", service.DiskAdd, rpc.OptArgsBody())
 	r.Handle(http.MethodPost, "/disk/set", service.DiskSet, rpc.OptArgsBody())
+	r.Handle(http.MethodPost, "/disk/access", service.DiskAccess, rpc.OptArgsBody())
 	r.Handle(http.MethodPost, "/chunk/report", service.ChunkReport, rpc.OptArgsBody())
+	r.Handle(http.MethodPost, "/chunk/usage/report", service.ChunkUsageReport, rpc.OptArgsBody())
 	r.Handle(http.MethodGet, "/volume/get", service.VolumeGet, rpc.OptArgsQuery())
 	r.Handle(http.MethodPost, "/service/register", service.ServiceRegister, rpc.OptArgsBody())
 
@@ -669,6 +679,9 @@ func (mcm *mockClusterMgr) DiskList(c *rpc.Context) {
 			},
 		}
 		info.DiskID = d.diskId
+		if readonly, ok := mcm.readonlyDisks.Load(d.diskId); ok {
+			info.Readonly = readonly.(bool)
+		}
 		ret.Disks = append(ret.Disks, info)
 	}
 
@@ -740,10 +753,11 @@ func (mcm *mockClusterMgr) DiskHeartbeat(c *rpc.Context) {
 	ret := &cmapi.DisksHeartbeatRet{}
 
 	for _, diskInfo := range args.Disks {
+		readonly, _ := mcm.readonlyDisks.Load(diskInfo.DiskID)
 		diskRet := &cmapi.DiskHeartbeatRet{
 			DiskID:   diskInfo.DiskID,
 			Status:   proto.DiskStatusNormal,
-			ReadOnly: true,
+			ReadOnly: readonly == true,
 		}
 
 		ret.Disks = append(ret.Disks, diskRet)
@@ -767,10 +781,30 @@ func (mcm *mockClusterMgr) DiskSet(c *rpc.Context) {
 	}
 }
 
+func (mcm *mockClusterMgr) DiskAccess(c *rpc.Context) {
+	args := new(cmapi.DiskAccessArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(bloberr.ErrIllegalArguments)
+		return
+	}
+	mcm.readonlyDisks.Store(args.DiskID, args.Readonly)
+}
+
 func (mcm *mockClusterMgr) ChunkReport(c *rpc.Context) {
 	// do nothing
 }
 
+func (mcm *mockClusterMgr) ChunkUsageReport(c *rpc.Context) {
+	args := new(cmapi.ReportChunkUsageArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(bloberr.ErrIllegalArguments)
+		return
+	}
+	mcm.usageLock.Lock()
+	mcm.usageReport = args
+	mcm.usageLock.Unlock()
+}
+
 func (mcm *mockClusterMgr) ServiceRegister(c *rpc.Context) {
 	// do nothing
 }
@@ -916,6 +950,18 @@ func TestService_ConfigReload(t *testing.T) {
 	q2, err := qos.NewIoQueueQos(con2) // for disk 2
 	require.NoError(t, err)
 
+	workDir, err := os.MkdirTemp(os.TempDir(), defaultSvrTestDir+"ConfigReload")
+	require.NoError(t, err)
+	defer os.RemoveAll(workDir)
+	ds1.EXPECT().GetConfig().Return(&core.Config{
+		BaseConfig:    core.BaseConfig{Path: filepath.Join(workDir, "disk1")},
+		RuntimeConfig: core.RuntimeConfig{DataQos: q1.GetConfig()},
+	}).AnyTimes()
+	ds2.EXPECT().GetConfig().Return(&core.Config{
+		BaseConfig:    core.BaseConfig{Path: filepath.Join(workDir, "disk2")},
+		RuntimeConfig: core.RuntimeConfig{DataQos: q2.GetConfig()},
+	}).AnyTimes()
+
 	require.Equal(t, 4*1024*1024*2, q1.(*qos.IoQueueQos).GetBpsLimiter()[qos.LimitTypeWrite].Burst())
 	require.Equal(t, 1*1024*1024*2, q1.(*qos.IoQueueQos).GetBpsLimiter()[qos.LimitTypeBack].Burst())
 	require.Equal(t, 5*1024*1024*2, q1.(*qos.IoQueueQos).GetBpsLimiter()[qos.LimitTypeRead].Burst())
@@ -1145,3 +1191,127 @@ func TestService_RegisterNode(t *testing.T) {
 	err = registerNode(ctx, svr.ClusterMgrClient, svr.Conf)
 	require.NotNil(t, err)
 }
+
+func TestServiceDiskLoaded(t *testing.T) {
+	ctx := context.Background()
+	ctr := gomock.NewController(t)
+	ds := NewMockDiskAPI(ctr)
+	ds.EXPECT().ID().AnyTimes().Return(proto.DiskID(101))
+
+	svr := &Service{
+		Disks: make(map[proto.DiskID]core.DiskAPI),
+		Conf:  &Config{},
+	}
+
+	// skipped disk is neither registered nor reported broken
+	loader := &diskLoader{done: make(chan struct{})}
+	loader.finish(nil, errDiskLoadSkipped)
+	svr.diskLoaded(ctx, loader)
+	require.Equal(t, 0, len(svr.Disks))
+
+	// disk not registered in cluster is not reported broken
+	loader = &diskLoader{done: make(chan struct{})}
+	loader.finish(nil, errors.New("open disk"))
+	svr.diskLoaded(ctx, loader)
+	require.Equal(t, 0, len(svr.Disks))
+
+	loader = &diskLoader{done: make(chan struct{})}
+	loader.finish(ds, nil)
+	svr.diskLoaded(ctx, loader)
+	require.Equal(t, ds, svr.Disks[101])
+}
+
+func TestService_DiskQos(t *testing.T) {
+	ctr := gomock.NewController(t)
+	ds1 := NewMockDiskAPI(ctr)
+	svr := &Service{
+		Disks: map[proto.DiskID]core.DiskAPI{101: ds1},
+		Conf:  &Config{DiskConfig: core.RuntimeConfig{DataQos: qos.Config{}}},
+	}
+	testServer := httptest.NewServer(NewHandler(svr))
+	defer testServer.Close()
+
+	workDir, err := os.MkdirTemp(os.TempDir(), defaultSvrTestDir+"DiskQos")
+	require.NoError(t, err)
+	defer os.RemoveAll(workDir)
+	require.NoError(t, core.EnsureDiskArea(workDir, ""))
+
+	conf := qos.Config{
+		ReadMBPS:         10,
+		WriteMBPS:        8,
+		BackgroundMBPS:   2,
+		ReadQueueDepth:   8,
+		WriteQueueDepth:  4,
+		WriteChanQueCnt:  1,
+		DeleteQueueDepth: 4,
+		ReadDiscard:      10,
+		WriteDiscard:     10,
+	}
+	q1, err := qos.NewIoQueueQos(conf)
+	require.NoError(t, err)
+	ds1.EXPECT().GetConfig().Return(&core.Config{
+		BaseConfig:    core.BaseConfig{Path: workDir},
+		RuntimeConfig: core.RuntimeConfig{DataQos: conf},
+	}).AnyTimes()
+	ds1.EXPECT().GetIoQos().Return(q1).AnyTimes()
+
+	ctx := context.Background()
+	cli := rpc.NewClient(&rpc.Config{})
+	getQos := func(diskID proto.DiskID) (*bnapi.DiskQosRet, error) {
+		ret := &bnapi.DiskQosRet{}
+		err := cli.GetWith(ctx, fmt.Sprintf("%s/disk/qos/get/diskid/%d", testServer.URL, diskID), ret)
+		return ret, err
+	}
+
+	// invalid args
+	err = cli.PostWith(ctx, testServer.URL+"/disk/qos/set", nil, &bnapi.DiskQosArgs{
+		DiskID: 101, DiskQosConfig: bnapi.DiskQosConfig{ReadDiscard: 101},
+	})
+	require.Error(t, err)
+	err = cli.PostWith(ctx, testServer.URL+"/disk/qos/set", nil, &bnapi.DiskQosArgs{
+		DiskID: 202, DiskQosConfig: bnapi.DiskQosConfig{ReadMBPS: 20},
+	})
+	require.Equal(t, bloberr.CodeDiskNotFound, rpc.DetectStatusCode(err))
+	_, err = getQos(202)
+	require.Equal(t, bloberr.CodeDiskNotFound, rpc.DetectStatusCode(err))
+
+	// set and persist
+	err = cli.PostWith(ctx, testServer.URL+"/disk/qos/set", nil, &bnapi.DiskQosArgs{
+		DiskID: 101, DiskQosConfig: bnapi.DiskQosConfig{ReadMBPS: 20, WriteQueueDepth: 16},
+	})
+	require.NoError(t, err)
+	err = cli.PostWith(ctx, testServer.URL+"/disk/qos/set", nil, &bnapi.DiskQosArgs{
+		DiskID: 101, DiskQosConfig: bnapi.DiskQosConfig{WriteMBPS: 6},
+	})
+	require.NoError(t, err)
+	ret, err := getQos(101)
+	require.NoError(t, err)
+	require.Equal(t, bnapi.DiskQosConfig{ReadMBPS: 20, WriteMBPS: 6, WriteQueueDepth: 16}, ret.Override)
+	require.Equal(t, int64(20), ret.Current.ReadMBPS)
+	require.Equal(t, int64(6), ret.Current.WriteMBPS)
+	require.Equal(t, int32(16), ret.Current.WriteQueueDepth)
+	require.Equal(t, int32(8), ret.Current.ReadQueueDepth)
+	require.Equal(t, int64(20*1024*1024), int64(q1.(*qos.IoQueueQos).GetBpsLimiter()[qos.LimitTypeRead].Limit()))
+	persisted, err := core.ReadDiskQosConfig(ctx, workDir)
+	require.NoError(t, err)
+	require.Equal(t, ret.Override, persisted)
+
+	// reload of all disks keeps parameters of the disk
+	svr.Conf.DiskConfig.DataQos.ReadMBPS = 30
+	svr.Conf.DiskConfig.DataQos.ReadQueueDepth = 32
+	require.NoError(t, svr.reloadQos(ctx, svr.Conf.DiskConfig.DataQos))
+	ret, err = getQos(101)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), ret.Current.ReadMBPS)
+	require.Equal(t, int32(32), ret.Current.ReadQueueDepth)
+
+	// reset
+	err = cli.PostWith(ctx, fmt.Sprintf("%s/disk/qos/reset/diskid/%d", testServer.URL, 101), nil, rpc.NoneBody)
+	require.NoError(t, err)
+	ret, err = getQos(101)
+	require.NoError(t, err)
+	require.Equal(t, bnapi.DiskQosConfig{}, ret.Override)
+	require.Equal(t, int64(30), ret.Current.ReadMBPS)
+	require.Equal(t, int64(8), ret.Current.WriteMBPS)
+	require.Equal(t, int32(4), ret.Current.WriteQueueDepth)
+}
@@ -140,6 +140,9 @@ func (tm *TaskRunnerMgr) AddTask(ctx context.Context, task MigrateTaskEx) error
 
 	w := tm.genWorker(task)
 	concurrency := tm.meter.concurrencyByType(t.TaskType)
+	if tm.meter.AdaptiveConcurrency.Enable {
+		concurrency = tm.meter.AdaptiveConcurrency.MaxConcurrency
+	}
 	runner := NewTaskRunner(ctx, t.TaskID, w, t.SourceIDC, concurrency, &tm.taskCounter, tm.schedulerCli)
 	if err := mgr.addTask(t.TaskID, runner); err != nil {
 		return err
@@ -17,8 +17,11 @@ package blobnode
 import (
 	"context"
 	"errors"
+	"io"
 	"sync"
+	"time"
 
+	api "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/blobnode/base/workutils"
 	"github.com/cubefs/cubefs/blobstore/blobnode/client"
@@ -44,6 +47,7 @@ type MigrateWorker struct {
 	benchmarkBids            []*ShardInfoSimple
 	downloadShardConcurrency int
 	forbiddenDirectDownload  bool
+	dstLimiter               *workutils.DiskLimiter
 }
 
 // MigrateTaskEx migrate task execution machine
@@ -52,6 +56,8 @@ type MigrateTaskEx struct {
 
 	downloadShardConcurrency int
 	blobNodeCli              client.IBlobNode
+	// dstLimiter adaptive concurrency limiter of destination disk, nil means no limit
+	dstLimiter *workutils.DiskLimiter
 }
 
 // NewMigrateWorker returns migrate worker
@@ -61,6 +67,7 @@ func NewMigrateWorker(task MigrateTaskEx) ITaskWorker {
 		bolbNodeCli:              task.blobNodeCli,
 		downloadShardConcurrency: task.downloadShardConcurrency,
 		forbiddenDirectDownload:  task.taskInfo.ForbiddenDirectDownload,
+		dstLimiter:               task.dstLimiter,
 	}
 }
 
@@ -104,6 +111,11 @@ func (w *MigrateWorker) GenTasklets(ctx context.Context) ([]Tasklet, *WorkError)
 
 // ExecTasklet execute migrate tasklet
 func (w *MigrateWorker) ExecTasklet(ctx context.Context, tasklet Tasklet) *WorkError {
+	if err := w.dstLimiter.Acquire(ctx); err != nil {
+		return OtherError(err)
+	}
+	defer w.dstLimiter.Release()
+
 	replicas := w.t.Sources
 	mode := w.t.CodeMode
 	shardRecover := NewShardRecover(replicas, mode, tasklet.bids, w.bolbNodeCli, w.downloadShardConcurrency, w.t.TaskType)
@@ -115,7 +127,29 @@ func (w *MigrateWorker) ExecTasklet(ctx context.Context, tasklet Tasklet) *WorkE
 		w.t.Destination,
 		w.canDirectDownload(),
 		tasklet.bids,
-		w.bolbNodeCli)
+		w.dstBlobNodeCli())
+}
+
+func (w *MigrateWorker) dstBlobNodeCli() client.IBlobNode {
+	if w.dstLimiter == nil {
+		return w.bolbNodeCli
+	}
+	return &feedbackBlobNode{IBlobNode: w.bolbNodeCli, limiter: w.dstLimiter}
+}
+
+// feedbackBlobNode feeds back the latency of putting shard to the limiter of destination disk
+type feedbackBlobNode struct {
+	client.IBlobNode
+	limiter *workutils.DiskLimiter
+}
+
+func (c *feedbackBlobNode) PutShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID,
+	size int64, body io.Reader, ioType api.IOType, retention int64,
+) error {
+	start := time.Now()
+	err := c.IBlobNode.PutShard(ctx, location, bid, size, body, ioType, retention)
+	c.limiter.Feedback(time.Since(start), err)
+	return err
 }
 
 // Check checks migrate task execute result
@@ -110,6 +110,9 @@ func (repairer *ShardRepairer) RepairShard(ctx context.Context, task *proto.Shar
 		return nil
 	}
 
+	// keep the worm retention of source shards on the repaired shards
+	retention := maxRetention(shardInfos)
+
 	span.Infof("start recover blob: bid[%d], badIdx[%+v]", task.Bid, task.BadIdxs)
 	bidInfos := []*ShardInfoSimple{{Bid: task.Bid, Size: shardSize}}
 	shardRecover := NewShardRecover(task.Sources, task.CodeMode, bidInfos, repairer.cli, 1, proto.TaskTypeShardRepair)
@@ -134,7 +137,7 @@ func (repairer *ShardRepairer) RepairShard(ctx context.Context, task *proto.Shar
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-			err = repairer.cli.PutShard(ctx, dstLocation, task.Bid, shardSize, bytes.NewReader(data), api.BackgroundIO)
+			err = repairer.cli.PutShard(ctx, dstLocation, task.Bid, shardSize, bytes.NewReader(data), api.BackgroundIO, retention)
 			retErrs[i] = err
 		}(badi)
 	}
@@ -156,6 +159,15 @@ func (repairer *ShardRepairer) RepairShard(ctx context.Context, task *proto.Shar
 	return err
 }
 
+func maxRetention(shardInfos []*ShardInfoEx) (retention int64) {
+	for _, shard := range shardInfos {
+		if !shard.IsBad() && shard.info.Retention > retention {
+			retention = shard.info.Retention
+		}
+	}
+	return
+}
+
 func hasRepaired(shardInfos []*ShardInfoEx, repairIdxs []int) (bool, error) {
 	var repairCnt int
 	for idx, shard := range shardInfos {
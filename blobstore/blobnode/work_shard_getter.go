@@ -45,6 +45,9 @@ var (
 type ShardInfoSimple struct {
 	Bid  proto.BlobID
 	Size int64
+	// Retention is the max worm retention of source shards,
+	// which is kept on the migrated or repaired shard.
+	Retention int64
 }
 
 // ShardInfoWithCrc with blob id and size and crc
@@ -110,19 +113,26 @@ func GetReplicasBids(ctx context.Context, cli client.IBlobNode, replicas Vunits)
 
 // MergeBids merge bids
 func MergeBids(replicasBids map[proto.Vuid]*ReplicaBidsRet) []*ShardInfoSimple {
-	allBidsMap := make(map[proto.BlobID]*client.ShardInfo)
+	allBidsMap := make(map[proto.BlobID]*ShardInfoSimple)
 	for _, info := range replicasBids {
 		if info.RetErr == nil {
 			for _, bidInfo := range info.Bids {
-				allBidsMap[bidInfo.Bid] = bidInfo
+				bid, ok := allBidsMap[bidInfo.Bid]
+				if !ok {
+					allBidsMap[bidInfo.Bid] = &ShardInfoSimple{Bid: bidInfo.Bid, Size: bidInfo.Size, Retention: bidInfo.Retention}
+					continue
+				}
+				bid.Size = bidInfo.Size
+				if bidInfo.Retention > bid.Retention {
+					bid.Retention = bidInfo.Retention
+				}
 			}
 		}
 	}
 
-	var allBidsList []*ShardInfoSimple
+	allBidsList := make([]*ShardInfoSimple, 0, len(allBidsMap))
 	for _, bid := range allBidsMap {
-		bidInfo := ShardInfoSimple{Bid: bid.Bid, Size: bid.Size}
-		allBidsList = append(allBidsList, &bidInfo)
+		allBidsList = append(allBidsList, bid)
 	}
 	return allBidsList
 }
@@ -183,7 +193,7 @@ func GetBenchmarkBids(ctx context.Context, cli client.IBlobNode, replicas Vunits
 		}
 
 		if existStatus.CanRecover() {
-			bidInfo := ShardInfoSimple{Bid: bid.Bid, Size: bid.Size}
+			bidInfo := ShardInfoSimple{Bid: bid.Bid, Size: bid.Size, Retention: bid.Retention}
 			benchMark = append(benchMark, &bidInfo)
 			continue
 		}
@@ -17,9 +17,11 @@ package blobnode
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/blobnode/client"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -152,6 +154,19 @@ func testMergeBids(t *testing.T, mode codemode.CodeMode) {
 	bidsEqual(t, bidIfos1, bids1, sizes1)
 }
 
+func TestMergeBidsRetention(t *testing.T) {
+	retention := time.Now().Add(time.Hour).Unix()
+	ret := map[proto.Vuid]*ReplicaBidsRet{
+		1: {Bids: map[proto.BlobID]*client.ShardInfo{1: {ShardInfo: bnapi.ShardInfo{Bid: 1, Size: 10}}}},
+		2: {Bids: map[proto.BlobID]*client.ShardInfo{1: {ShardInfo: bnapi.ShardInfo{Bid: 1, Size: 10, Retention: retention}}}},
+		3: {Bids: map[proto.BlobID]*client.ShardInfo{1: {ShardInfo: bnapi.ShardInfo{Bid: 1, Size: 10}}}},
+		4: {RetErr: errors.New("fake error")},
+	}
+	bids := MergeBids(ret)
+	require.Len(t, bids, 1)
+	require.Equal(t, ShardInfoSimple{Bid: 1, Size: 10, Retention: retention}, *bids[0])
+}
+
 func TestGetBenchmarkBids(t *testing.T) {
 	testWithAllMode(t, testGetBenchmarkBids)
 }
@@ -109,8 +109,12 @@ func (stripe *repairStripe) genDownloadPlans() []downloadPlan {
 	var downloadPlans []downloadPlan
 	var wellReplications Vunits
 
-	stripeReplicas := make([]proto.VunitLocation, len(stripe.replicas))
-	copy(stripeReplicas, stripe.replicas)
+	sources := stripe.sources
+	if len(sources) == 0 {
+		sources = stripe.replicas
+	}
+	stripeReplicas := make([]proto.VunitLocation, len(sources))
+	copy(stripeReplicas, sources)
 	rand.Shuffle(len(stripeReplicas), func(i, j int) {
 		stripeReplicas[i], stripeReplicas[j] = stripeReplicas[j], stripeReplicas[i]
 	})
@@ -143,6 +147,7 @@ func (stripe *repairStripe) genDownloadPlans() []downloadPlan {
 
 type repairStripe struct {
 	replicas Vunits
+	sources  Vunits // replicas can be downloaded for repair, all replicas if empty
 	n        int
 	m        int
 	badIdxes []uint8
@@ -466,9 +471,12 @@ func (r *ShardRecover) recoverReplicaShards(ctx context.Context, repairIdxs []ui
 	failBids := repairBids
 	var err error
 
-	if localRepairable(repairIdxs, r.codeMode) {
-		span.Info("recover by local stripe")
-		err = r.recoverByLocalStripe(ctx, failBids, repairIdxs)
+	// the bad units in azs which can be repaired by local stripe only read
+	// the local group units in the same az, others need global stripe
+	localIdxs, globalIdxs := splitLocalRepairable(repairIdxs, r.codeMode)
+	if len(localIdxs) > 0 {
+		span.Infof("recover by local stripe: localIdxs[%+v], globalIdxs[%+v]", localIdxs, globalIdxs)
+		err = r.recoverByLocalStripe(ctx, failBids, localIdxs)
 		if err != nil {
 			span.Warnf("recover by local stripe failed:%v", err)
 		}
@@ -480,11 +488,34 @@ func (r *ShardRecover) recoverReplicaShards(ctx context.Context, repairIdxs []ui
 		span.Warnf("after local repaired, still fail bids is:%v", failBids)
 	}
 
-	span.Infof("recover by global stripe")
-	err = r.recoverByGlobalStripe(ctx, failBids, repairIdxs)
-	if err != nil {
-		span.Errorf("recover by global stripe failed %v", err)
-		return err
+	// bids repaired by local stripe take the repaired units as well units of global stripe
+	localFailBids := r.collectFailBids(failBids, localIdxs)
+	localFailed := make(map[proto.BlobID]struct{}, len(localFailBids))
+	for _, bid := range localFailBids {
+		localFailed[bid] = struct{}{}
+	}
+	var partialBids []proto.BlobID
+	for _, bid := range failBids {
+		if _, ok := localFailed[bid]; !ok {
+			partialBids = append(partialBids, bid)
+		}
+	}
+
+	if len(partialBids) > 0 {
+		span.Infof("recover by global stripe: badIdxs[%+v], len(bids)[%d]", globalIdxs, len(partialBids))
+		err = r.recoverByGlobalStripe(ctx, partialBids, globalIdxs)
+		if err != nil {
+			span.Errorf("recover by global stripe failed %v", err)
+			return err
+		}
+	}
+	if len(localFailBids) > 0 {
+		span.Infof("recover by global stripe: badIdxs[%+v], len(bids)[%d]", repairIdxs, len(localFailBids))
+		err = r.recoverByGlobalStripe(ctx, localFailBids, repairIdxs)
+		if err != nil {
+			span.Errorf("recover by global stripe failed %v", err)
+			return err
+		}
 	}
 
 	failBids = r.collectFailBids(failBids, repairIdxs)
@@ -549,8 +580,10 @@ func (r *ShardRecover) recoverByGlobalStripe(ctx context.Context, repairBids []p
 	span := trace.SpanFromContextSafe(ctx)
 	span.Infof("start recoverByGlobalStripe: repairIdxs[%+v]", repairIdxs)
 
+	// local parity units are useless for global decoding, never download them
 	stripe := repairStripe{
 		replicas: r.replicas,
+		sources:  r.replicas.IntactGlobalSet(r.codeMode, repairIdxs),
 		n:        r.codeMode.T().N,
 		m:        r.codeMode.T().M,
 		badIdxes: repairIdxs,
@@ -775,7 +808,7 @@ func (r *ShardRecover) repair(ctx context.Context, repairBids []proto.BlobID, st
 
 func (r *ShardRecover) genLocalStripes(repairIdxs []uint8) (stripes []repairStripe, err error) {
 	// generate local stripes list in same az with repairIdxs
-	repairIdxsInIdc := workutils.IdxSplitByLocalStripe(repairIdxs, r.codeMode)
+	repairIdxsInIdc, _ := workutils.IdxSplitByLocalStripe(repairIdxs, r.codeMode)
 	for _, oneIdcRepairIdxs := range repairIdxsInIdc {
 		if len(oneIdcRepairIdxs) == 0 {
 			continue
@@ -871,20 +904,17 @@ func AllShardsCanNotDownload(shardDownloadFail error) bool {
 	}
 }
 
-func localRepairable(badIdxs []uint8, mode codemode.CodeMode) bool {
-	// localMap use count each az bad uint num
-	localMap := make(map[int]int)
-	for _, idx := range badIdxs {
-		stripeIdxs, _, _ := mode.T().LocalStripe(int(idx))
-		if len(stripeIdxs) == 0 {
-			return false
-		}
-		localMap[stripeIdxs[0]]++
-	}
-	for _, v := range localMap {
-		if v > mode.T().L/mode.T().AZCount {
-			return false
+// splitLocalRepairable splits bad indexes into units can be repaired by local stripe
+// and units must be repaired by global stripe, units out of local stripes are global
+func splitLocalRepairable(badIdxs []uint8, mode codemode.CodeMode) (localIdxs, globalIdxs []uint8) {
+	tactic := mode.T()
+	stripes, globalIdxs := workutils.IdxSplitByLocalStripe(badIdxs, mode)
+	for _, idxs := range stripes {
+		if len(idxs) > tactic.L/tactic.AZCount {
+			globalIdxs = append(globalIdxs, idxs...)
+			continue
 		}
+		localIdxs = append(localIdxs, idxs...)
 	}
-	return true
+	return
 }
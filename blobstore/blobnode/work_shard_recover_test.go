@@ -238,6 +238,59 @@ func TestRecoverShards(t *testing.T) {
 	testCheckData(t, repair4, getter4, badi4)
 }
 
+func TestRecoverShardsPartialLocal(t *testing.T) {
+	ctx := context.Background()
+	mode := codemode.EC6P10L2
+
+	// az0: 0 1 2 6 7 8 9 10 16, az1: 3 4 5 11 12 13 14 15 17
+	localIdxs, globalIdxs := splitLocalRepairable([]uint8{0, 3, 4}, mode)
+	require.Equal(t, []uint8{0}, localIdxs)
+	require.Equal(t, []uint8{3, 4}, globalIdxs)
+	localIdxs, globalIdxs = splitLocalRepairable([]uint8{0, 3}, mode)
+	require.ElementsMatch(t, []uint8{0, 3}, localIdxs)
+	require.Empty(t, globalIdxs)
+	localIdxs, globalIdxs = splitLocalRepairable([]uint8{0, 3}, codemode.EC6P6)
+	require.Empty(t, localIdxs)
+	require.Equal(t, []uint8{0, 3}, globalIdxs)
+
+	repair, _, getter, replicas := InitMockRepair(mode)
+	badi := []uint8{0, 3, 4}
+	err := repair.RecoverShards(ctx, badi, false)
+	require.NoError(t, err)
+	testCheckData(t, repair, getter, badi)
+
+	// local parity of az1 is useless for global stripe
+	_, ok := repair.ds.downloadedMap[replicas[17].Vuid]
+	require.False(t, ok)
+
+	// only local group units of az0 be downloaded
+	repair, _, getter, replicas = InitMockRepair(mode)
+	badi = []uint8{0}
+	err = repair.RecoverShards(ctx, badi, false)
+	require.NoError(t, err)
+	testCheckData(t, repair, getter, badi)
+	for _, idx := range []uint8{3, 4, 5, 11, 12, 13, 14, 15, 17} {
+		_, ok := repair.ds.downloadedMap[replicas[idx].Vuid]
+		require.False(t, ok)
+	}
+}
+
+func TestSplitLocalRepairableNoLoss(t *testing.T) {
+	for _, mode := range codemode.GetECCodeModes() {
+		tactic := mode.T()
+		var badIdxs []uint8
+		for idx := 0; idx < tactic.N+tactic.M+tactic.L; idx++ {
+			badIdxs = append(badIdxs, uint8(idx))
+		}
+		localIdxs, globalIdxs := splitLocalRepairable(badIdxs, mode)
+		require.ElementsMatch(t, badIdxs, append(localIdxs, globalIdxs...), mode.String())
+		if tactic.L == 0 {
+			// only global parity, all units are repaired by global stripe
+			require.Empty(t, localIdxs, mode.String())
+		}
+	}
+}
+
 func TestRecoverShards2(t *testing.T) {
 	// test without local :eg EC6p6
 	ctx := context.Background()
@@ -96,7 +96,7 @@ func MigrateBids(ctx context.Context, shardRecover *ShardRecover, badIdx uint8,
 			return OtherError(err)
 		}
 		err = retry.Timed(3, 1000).On(func() error {
-			return blobnodeCli.PutShard(ctx, destLocation, bid.Bid, bid.Size, bytes.NewReader(data), shardRecover.ioType)
+			return blobnodeCli.PutShard(ctx, destLocation, bid.Bid, bid.Size, bytes.NewReader(data), shardRecover.ioType, bid.Retention)
 		})
 		if err != nil {
 			return DstError(err)
@@ -183,7 +183,7 @@ func (getter *MockGetter) setVunitStatus(vuid proto.Vuid, status clustermgr.Chun
 	}
 }
 
-func (getter *MockGetter) PutShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID, size int64, body io.Reader, ioType api.IOType) (err error) {
+func (getter *MockGetter) PutShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID, size int64, body io.Reader, ioType api.IOType, retention int64) (err error) {
 	getter.mu.Lock()
 	defer getter.mu.Unlock()
 	if err, ok := getter.failVuid[location.Vuid]; ok {
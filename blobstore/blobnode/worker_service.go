@@ -55,6 +55,10 @@ type WorkerConfigMeter struct {
 
 	// batch download concurrency of single tasklet
 	DownloadShardConcurrency int `json:"download_shard_concurrency"`
+
+	// tasklets writing to the same destination disk are limited by latency feedback of the disk,
+	// tasklet concurrency of single task is the max concurrency if enabled
+	AdaptiveConcurrency base.AdaptiveConfig `json:"adaptive_concurrency"`
 }
 
 func (meter *WorkerConfigMeter) concurrencyByType(taskType proto.TaskType) int {
@@ -101,6 +105,7 @@ type WorkerService struct {
 
 	shardRepairLimit limit.Limiter
 	shardRepairer    *ShardRepairer
+	dstLimiters      *base.DiskLimiters
 
 	schedulerCli scheduler.IScheduler
 	blobNodeCli  client.IBlobNode
@@ -117,6 +122,7 @@ func (cfg *WorkerConfig) checkAndFix() {
 	defaulter.LessOrEqual(&cfg.ShardRepairConcurrency, 1)
 	defaulter.LessOrEqual(&cfg.InspectConcurrency, 1)
 	defaulter.LessOrEqual(&cfg.DownloadShardConcurrency, 10)
+	cfg.AdaptiveConcurrency.CheckAndFix()
 	defaulter.IntegerLessOrEqual[int64](&cfg.Scheduler.ClientTimeoutMs, 1000)
 	defaulter.IntegerLessOrEqual[int64](&cfg.Scheduler.HostSyncIntervalMs, 1000)
 	defaulter.IntegerLessOrEqual[int64](&cfg.BlobNode.ClientTimeoutMs, 1000)
@@ -161,6 +167,7 @@ func NewWorkerService(cfg *WorkerConfig, service cmapi.APIService, clusterID pro
 
 		shardRepairLimit: shardRepairLimit,
 		shardRepairer:    shardRepairer,
+		dstLimiters:      base.NewDiskLimiters(cfg.AdaptiveConcurrency),
 	}
 
 	go svr.Run()
@@ -192,7 +199,9 @@ func (s *WorkerService) ShardRepair(c *rpc.Context) {
 
 // WorkerStats returns worker_service stats
 func (s *WorkerService) WorkerStats(c *rpc.Context) {
-	c.RespondJSON(s.taskRunnerMgr.TaskStats())
+	stats := s.taskRunnerMgr.TaskStats()
+	stats.DiskConcurrency = s.dstLimiters.Stats()
+	c.RespondJSON(stats)
 }
 
 // Run runs backend task
@@ -305,6 +314,7 @@ func (s *WorkerService) addBlobNodeTask(ctx context.Context, t *proto.Task) {
 		taskInfo:                 task,
 		downloadShardConcurrency: s.DownloadShardConcurrency,
 		blobNodeCli:              s.blobNodeCli,
+		dstLimiter:               s.dstLimiters.Get(task.Destination.DiskID),
 	}); err != nil {
 		span.Errorf("add task failed: taskID[%s], err[%v]", task.TaskID, err)
 		return
@@ -61,7 +61,7 @@ func (m *mBlobNodeCli) GetShard(ctx context.Context, location proto.VunitLocatio
 	return nil, 0, nil
 }
 
-func (m *mBlobNodeCli) PutShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID, size int64, body io.Reader, ioType bnapi.IOType) (err error) {
+func (m *mBlobNodeCli) PutShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID, size int64, body io.Reader, ioType bnapi.IOType, retention int64) (err error) {
 	return
 }
 
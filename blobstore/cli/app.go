@@ -28,6 +28,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/cli/common/flags"
 	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
 	"github.com/cubefs/cubefs/blobstore/cli/config"
+	"github.com/cubefs/cubefs/blobstore/cli/doctor"
 	"github.com/cubefs/cubefs/blobstore/cli/proxy"
 	"github.com/cubefs/cubefs/blobstore/cli/scheduler"
 	"github.com/cubefs/cubefs/blobstore/cli/sdk"
@@ -107,4 +108,5 @@ func init() {
 	sdk.Register(App)
 
 	toolbox.Register(App)
+	doctor.Register(App)
 }
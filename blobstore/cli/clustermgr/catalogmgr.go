@@ -117,6 +117,7 @@ func addCmdCatalog(cmd *grumble.Command) {
 		Flags: func(f *grumble.Flags) {
 			flags.VerboseRegister(f)
 			clusterFlags(f)
+			f.Uint64L("worm_retention_secs", 0, "worm retention seconds of space, 0 is not worm space")
 		},
 	})
 
@@ -274,6 +275,8 @@ func cmdCreateSpace(c *grumble.Context) error {
 	createSpaceArgs := &clustermgr.CreateSpaceArgs{
 		Name:       spaceName,
 		FieldMetas: fieldMetas,
+
+		WormRetentionSecs: c.Flags.Uint64("worm_retention_secs"),
 	}
 	err := cmClient.CreateSpace(ctx, createSpaceArgs)
 	if err != nil {
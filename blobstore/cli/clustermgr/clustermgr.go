@@ -78,4 +78,18 @@ func Register(app *grumble.App) {
 			return nil
 		},
 	})
+	cmCommand.AddCommand(&grumble.Command{
+		Name:  "schema",
+		Help:  "show api schema of clustermgr in json",
+		Flags: clusterFlags,
+		Run: func(c *grumble.Context) error {
+			cli := newCMClient(c.Flags)
+			schema, err := cli.APISchema(common.CmdContext())
+			if err != nil {
+				return err
+			}
+			fmt.Println(common.RawString(schema))
+			return nil
+		},
+	})
 }
@@ -56,6 +56,7 @@ func addCmdConfig(cmd *grumble.Command) {
 		LongHelp: "config tools for clustermgr",
 	}
 	cmd.AddCommand(configCommand)
+	addCmdConfigDiff(configCommand)
 
 	configCommand.AddCommand(&grumble.Command{
 		Name: "get",
@@ -0,0 +1,206 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"os"
+	"sort"
+
+	"github.com/desertbit/grumble"
+	"gopkg.in/yaml.v2"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/cli/common"
+	"github.com/cubefs/cubefs/blobstore/cli/common/flags"
+	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+// configDiff changes from configs of cluster to configs of local file,
+// keys only in cluster are deleted if pruned
+type configDiff struct {
+	adds    []string
+	changes []string
+	deletes []string
+	skips   []string // system keys not allowed to change
+	remote  map[string]string
+	local   map[string]string
+}
+
+func (d *configDiff) empty() bool {
+	return len(d.adds)+len(d.changes)+len(d.deletes) == 0
+}
+
+func (d *configDiff) batchArgs() *clustermgr.ConfigBatchArgs {
+	args := &clustermgr.ConfigBatchArgs{Deletes: d.deletes}
+	for _, keys := range [][]string{d.adds, d.changes} {
+		for _, key := range keys {
+			args.Sets = append(args.Sets, clustermgr.ConfigSetArgs{Key: key, Value: d.local[key]})
+		}
+	}
+	return args
+}
+
+func (d *configDiff) show() {
+	for _, key := range d.adds {
+		fmt.Printf("%s %s: %s\n", common.Normal.Sprint("+"), common.Loaded.Sprint(key), d.local[key])
+	}
+	for _, key := range d.changes {
+		fmt.Printf("%s %s: %s\n", common.Danger.Sprint("-"), common.Loaded.Sprint(key), d.remote[key])
+		fmt.Printf("%s %s: %s\n", common.Normal.Sprint("+"), common.Loaded.Sprint(key), d.local[key])
+	}
+	for _, key := range d.deletes {
+		fmt.Printf("%s %s: %s\n", common.Danger.Sprint("-"), common.Loaded.Sprint(key), d.remote[key])
+	}
+	for _, key := range d.skips {
+		fmt.Printf("! %s: system config cannot be changed, skipped\n", common.Dead.Sprint(key))
+	}
+	if d.empty() {
+		fmt.Println("no config changes")
+	}
+}
+
+func diffConfig(remote, local map[string]string, prune bool) *configDiff {
+	d := &configDiff{remote: remote, local: local}
+	for key, val := range local {
+		old, ok := remote[key]
+		switch {
+		case ok && old == val:
+		case proto.IsUnmodifiableSysConfigKey(key):
+			d.skips = append(d.skips, key)
+		case ok:
+			d.changes = append(d.changes, key)
+		default:
+			d.adds = append(d.adds, key)
+		}
+	}
+	if prune {
+		for key := range remote {
+			if _, ok := local[key]; ok {
+				continue
+			}
+			if proto.IsSysConfigKey(key) {
+				d.skips = append(d.skips, key)
+				continue
+			}
+			d.deletes = append(d.deletes, key)
+		}
+	}
+	for _, keys := range [][]string{d.adds, d.changes, d.deletes, d.skips} {
+		sort.Strings(keys)
+	}
+	return d
+}
+
+func loadConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	configs := make(map[string]string)
+	if err = yaml.Unmarshal(data, &configs); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+func loadConfigDiff(c *grumble.Context) (*configDiff, error) {
+	local, err := loadConfigFile(c.Args.String("file"))
+	if err != nil {
+		return nil, err
+	}
+	all, err := newCMClient(c.Flags).ListConfig(common.CmdContext())
+	if err != nil {
+		return nil, err
+	}
+	return diffConfig(all.Configs, local, c.Flags.Bool("prune")), nil
+}
+
+func cmdDumpConfig(c *grumble.Context) error {
+	all, err := newCMClient(c.Flags).ListConfig(common.CmdContext())
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(all.Configs)
+	if err != nil {
+		return err
+	}
+	if path := flags.FilePath(c.Flags); path != "" {
+		return os.WriteFile(path, data, 0o644)
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+func cmdDiffConfig(c *grumble.Context) error {
+	d, err := loadConfigDiff(c)
+	if err != nil {
+		return err
+	}
+	d.show()
+	return nil
+}
+
+func cmdApplyConfig(c *grumble.Context) error {
+	d, err := loadConfigDiff(c)
+	if err != nil {
+		return err
+	}
+	d.show()
+	if d.empty() {
+		return nil
+	}
+	if !common.Confirm(fmt.Sprintf("to apply %d adds, %d changes and %d deletes ?",
+		len(d.adds), len(d.changes), len(d.deletes))) {
+		return nil
+	}
+	return newCMClient(c.Flags).BatchConfig(common.CmdContext(), d.batchArgs())
+}
+
+func addCmdConfigDiff(configCommand *grumble.Command) {
+	configCommand.AddCommand(&grumble.Command{
+		Name:     "dump",
+		Help:     "dump all configs as yaml",
+		LongHelp: "dump configs of clustermgr config file and runtime keys as yaml",
+		Run:      cmdDumpConfig,
+		Flags: func(f *grumble.Flags) {
+			flags.FilePathRegister(f)
+			clusterFlags(f)
+		},
+	})
+	diffFlags := func(f *grumble.Flags) {
+		f.BoolL("prune", false, "delete keys not in the file")
+		clusterFlags(f)
+	}
+	configCommand.AddCommand(&grumble.Command{
+		Name: "diff",
+		Help: "diff configs against local yaml file",
+		Run:  cmdDiffConfig,
+		Args: func(a *grumble.Args) {
+			a.String("file", "local yaml file of configs")
+		},
+		Flags: diffFlags,
+	})
+	configCommand.AddCommand(&grumble.Command{
+		Name:     "apply",
+		Help:     "apply configs of local yaml file",
+		LongHelp: "apply changes of configs in local yaml file after confirmation, all or nothing is applied",
+		Run:      cmdApplyConfig,
+		Args: func(a *grumble.Args) {
+			a.String("file", "local yaml file of configs")
+		},
+		Flags: diffFlags,
+	})
+}
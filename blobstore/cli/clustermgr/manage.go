@@ -58,6 +58,30 @@ func addCmdManage(cmd *grumble.Command) {
 		},
 	})
 
+	manageCommand.AddCommand(&grumble.Command{
+		Name: "replace",
+		Help: "replace raft member by adding learner, catching up, promoting and removing old",
+		Run:  cmdReplaceMember,
+		Args: func(a *grumble.Args) {
+			a.Uint64("old_peer_id", "the peer id to be replaced")
+			a.Uint64("peer_id", "new peer id")
+			a.String("host", "new raft host addr")
+			a.String("node_host", "new service host addr")
+		},
+		Flags: func(f *grumble.Flags) {
+			clusterFlags(f)
+		},
+	})
+
+	manageCommand.AddCommand(&grumble.Command{
+		Name: "replaceStatus",
+		Help: "show progress of the last member replacement",
+		Run:  cmdReplaceMemberStatus,
+		Flags: func(f *grumble.Flags) {
+			clusterFlags(f)
+		},
+	})
+
 	manageCommand.AddCommand(&grumble.Command{
 		Name: "transfer",
 		Help: "transfer leadership",
@@ -105,6 +129,37 @@ func cmdRemoveMember(c *grumble.Context) error {
 	return cli.RemoveMember(ctx, id)
 }
 
+func cmdReplaceMember(c *grumble.Context) error {
+	cli := newCMClient(c.Flags)
+	ctx := common.CmdContext()
+
+	args := &clustermgr.ReplaceMemberArgs{
+		OldPeerID: c.Args.Uint64("old_peer_id"),
+		PeerID:    c.Args.Uint64("peer_id"),
+		Host:      c.Args.String("host"),
+		NodeHost:  c.Args.String("node_host"),
+	}
+	if !common.Confirm("confirm replace?") {
+		fmt.Println("replace command canceled")
+		return nil
+	}
+	if err := cli.ReplaceMember(ctx, args); err != nil {
+		return err
+	}
+	fmt.Println("replacement started, show progress by `cm cluster replaceStatus`")
+	return nil
+}
+
+func cmdReplaceMemberStatus(c *grumble.Context) error {
+	cli := newCMClient(c.Flags)
+	status, err := cli.ReplaceMemberStatus(common.CmdContext())
+	if err != nil {
+		return err
+	}
+	fmt.Println(common.Readable(status))
+	return nil
+}
+
 func cmdTransferLeadership(c *grumble.Context) error {
 	cli := newCMClient(c.Flags)
 	ctx := common.CmdContext()
@@ -32,6 +32,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/persistence/kvdb"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/persistence/volumedb"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	"github.com/cubefs/cubefs/blobstore/common/kvstore"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
@@ -106,6 +107,33 @@ func addCmdVolume(cmd *grumble.Command) {
 		},
 	})
 
+	command.AddCommand(&grumble.Command{
+		Name: "simulateAlloc",
+		Help: "simulate allocating volumes without committing",
+		Run:  cmdSimulateAlloc,
+		Args: func(a *grumble.Args) {
+			a.String("codemode", "codemode name of volumes, like EC6P6")
+			a.Int("count", "number of volumes to simulate")
+		},
+		Flags: func(f *grumble.Flags) {
+			f.IntL("disk_type", int(proto.DiskTypeHDD), "disk type of volumes")
+			clusterFlags(f)
+		},
+	})
+
+	command.AddCommand(&grumble.Command{
+		Name: "checkPlacement",
+		Help: "check unit placement of volumes against current topology",
+		Run:  cmdCheckPlacement,
+		Args: func(a *grumble.Args) {
+			a.Int("count", "number of volumes to check")
+			a.Uint64("marker", "check volumes start from special Vid", grumble.Default(uint64(0)))
+		},
+		Flags: func(f *grumble.Flags) {
+			clusterFlags(f)
+		},
+	})
+
 	command.AddCommand(&grumble.Command{
 		Name: "getInConsistentVolumes",
 		Help: "get inconsistent volumes between leader and follower",
@@ -136,6 +164,41 @@ func cmdListVolumes(c *grumble.Context) error {
 	return nil
 }
 
+func cmdSimulateAlloc(c *grumble.Context) error {
+	ctx := common.CmdContext()
+	cmClient := newCMClient(c.Flags)
+
+	mode := codemode.CodeModeName(c.Args.String("codemode"))
+	if !mode.IsValid() {
+		return fmt.Errorf("invalid codemode: %s", mode)
+	}
+	ret, err := cmClient.SimulateAllocVolume(ctx, &clustermgr.AllocSimulateArgs{
+		CodeMode: mode.GetCodeMode(),
+		Count:    c.Args.Int("count"),
+		DiskType: proto.DiskType(c.Flags.Int("disk_type")),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(common.Readable(ret))
+	return nil
+}
+
+func cmdCheckPlacement(c *grumble.Context) error {
+	ctx := common.CmdContext()
+	cmClient := newCMClient(c.Flags)
+
+	ret, err := cmClient.CheckVolumePlacement(ctx, &clustermgr.PlacementCheckArgs{
+		Count:  c.Args.Int("count"),
+		Marker: proto.Vid(c.Args.Uint64("marker")),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(common.Readable(ret))
+	return nil
+}
+
 func cmdUpdateVolume(c *grumble.Context) error {
 	vid := args.Vid(c.Args)
 	dbPath := c.Args.String("dbPath")
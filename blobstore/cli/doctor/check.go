@@ -0,0 +1,327 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package doctor
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/api/scheduler"
+	"github.com/cubefs/cubefs/blobstore/cli/common"
+	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+const (
+	lowFreeSpacePercent = 10
+	raftApplyLagLimit   = 10000
+)
+
+type level int
+
+const (
+	levelNotice level = iota + 1
+	levelWarning
+	levelCritical
+)
+
+func (l level) colored() string {
+	switch l {
+	case levelCritical:
+		return common.Danger.Sprint("CRITICAL")
+	case levelWarning:
+		return common.Warn.Sprint("WARNING ")
+	default:
+		return common.Loaded.Sprint("NOTICE  ")
+	}
+}
+
+type problem struct {
+	level     level
+	component string
+	summary   string
+	detail    string
+	remedy    string
+}
+
+// raftStatus is the part of raft status in stat of clustermgr
+type raftStatus struct {
+	Leader  uint64 `json:"leader"`
+	Commit  uint64 `json:"commit"`
+	Applied uint64 `json:"applied"`
+	Peers   []struct {
+		Id           uint64 `json:"id"`
+		Host         string `json:"host"`
+		RecentActive bool   `json:"active"`
+	} `json:"peers"`
+}
+
+func (d *diagnosis) diagnose() (problems []problem) {
+	problems = append(problems, d.checkClusterMgr()...)
+	problems = append(problems, d.checkExpiredDisks()...)
+	problems = append(problems, d.checkScheduler()...)
+	return
+}
+
+func (d *diagnosis) checkClusterMgr() (problems []problem) {
+	const component = "clustermgr"
+	if d.stat == nil {
+		return []problem{{
+			level:     levelCritical,
+			component: component,
+			summary:   "failed to get stat of clustermgr",
+			detail:    errors.Detail(d.statErr),
+			remedy:    "check processes and network of clustermgr hosts, then retry `cm stat` (GET /stat)",
+		}}
+	}
+
+	stat := d.stat
+	if stat.LeaderHost == "" {
+		problems = append(problems, problem{
+			level:     levelCritical,
+			component: component,
+			summary:   "no raft leader of clustermgr",
+			remedy:    "check all members by GET /stat, the majority of members should be alive to elect a leader",
+		})
+	}
+	if stat.ReadOnly {
+		problems = append(problems, problem{
+			level:     levelCritical,
+			component: component,
+			summary:   "clustermgr is in readonly mode, all writes are rejected",
+			remedy:    "unset `readonly` in config of clustermgr and restart it",
+		})
+	}
+
+	var raft raftStatus
+	if data, err := common.Marshal(stat.RaftStatus); err == nil && common.Unmarshal(data, &raft) == nil {
+		for _, peer := range raft.Peers {
+			if peer.Id != raft.Leader && !peer.RecentActive {
+				problems = append(problems, problem{
+					level:     levelWarning,
+					component: component,
+					summary:   fmt.Sprintf("raft member %d (%s) is not active", peer.Id, peer.Host),
+					remedy:    "restart clustermgr on the host, or replace it by `cm cluster replace` (POST /member/replace)",
+				})
+			}
+		}
+		if raft.Commit > raft.Applied+raftApplyLagLimit {
+			problems = append(problems, problem{
+				level:     levelWarning,
+				component: component,
+				summary:   fmt.Sprintf("raft applied %d lags behind commit %d", raft.Applied, raft.Commit),
+				remedy:    "check disk io and load of clustermgr, transfer leadership by `cm cluster transfer` (POST /leadership/transfer) if it's the leader",
+			})
+		}
+	}
+
+	if stat.VolumeStat.TotalVolume > 0 && stat.VolumeStat.AllocatableVolume == 0 {
+		problems = append(problems, problem{
+			level:     levelCritical,
+			component: component,
+			summary:   "no allocatable volume, writes of access will fail",
+			detail:    common.RawString(stat.VolumeStat),
+			remedy:    "add blobnode disks to create volumes, or unlock volumes by POST /volume/unlock",
+		})
+	}
+
+	space := stat.BlobNodeSpaceStat
+	if space.TotalSpace > 0 && space.FreeSpace*100 < space.TotalSpace*lowFreeSpacePercent {
+		problems = append(problems, problem{
+			level:     levelWarning,
+			component: component,
+			summary: fmt.Sprintf("free space of blobnode is low, %d%% of %s", space.FreeSpace*100/space.TotalSpace,
+				humanize.IBytes(uint64(space.TotalSpace))),
+			remedy: fmt.Sprintf("add blobnode disks, or `cm background enable %s` to balance the chunks", proto.TaskTypeBalance),
+		})
+	}
+	if space.ReadOnlySpace > 0 && space.ReadOnlySpace > space.FreeSpace {
+		problems = append(problems, problem{
+			level:     levelNotice,
+			component: component,
+			summary:   "most free space of blobnode is readonly",
+			remedy:    "set disks writable by POST /disk/access if they are readonly by mistake",
+		})
+	}
+	return
+}
+
+func (d *diagnosis) checkExpiredDisks() (problems []problem) {
+	const component = "blobnode"
+	if d.expiredErr != nil {
+		problems = append(problems, problem{
+			level:     levelWarning,
+			component: component,
+			summary:   "failed to list heartbeat expired disks",
+			detail:    errors.Detail(d.expiredErr),
+			remedy:    "retry `cm disk listDisk` (GET /disk/list?heartbeat_expired=true)",
+		})
+	}
+
+	var hosts []string
+	byHost := make(map[string][]*clustermgr.BlobNodeDiskInfo)
+	for _, disk := range d.expired {
+		if _, ok := byHost[disk.Host]; !ok {
+			hosts = append(hosts, disk.Host)
+		}
+		byHost[disk.Host] = append(byHost[disk.Host], disk)
+	}
+	for _, host := range hosts {
+		disks := byHost[host]
+		lvl := levelNotice
+		ids := make([]string, 0, len(disks))
+		for _, disk := range disks {
+			// expired disk in normal status is not being repaired yet
+			if disk.Status == proto.DiskStatusNormal {
+				lvl = levelWarning
+			}
+			ids = append(ids, disk.DiskID.ToString()+":"+disk.Status.String())
+		}
+		problems = append(problems, problem{
+			level:     lvl,
+			component: component,
+			summary:   fmt.Sprintf("%d disk(s) on host %s heartbeat expired", len(disks), host),
+			detail:    "disks " + strings.Join(ids, ", "),
+			remedy:    "check blobnode on the host, set the damaged disk broken by `cm disk updateDisk` (POST /disk/set) to repair it",
+		})
+	}
+	return
+}
+
+func (d *diagnosis) checkScheduler() (problems []problem) {
+	const component = "scheduler"
+	if d.schedule == nil {
+		return []problem{{
+			level:     levelWarning,
+			component: component,
+			summary:   "failed to get leader stats of scheduler",
+			detail:    errors.Detail(d.scheduleErr),
+			remedy:    fmt.Sprintf("check scheduler registered by `cm service %s`, then retry `scheduler stat`", proto.ServiceNameScheduler),
+		}}
+	}
+
+	stats := d.schedule.Blobnode
+	if stat := stats.DiskRepair; stat != nil {
+		problems = append(problems, checkMigrating(proto.TaskTypeDiskRepair, stat.Enable, stat.RepairingDisks,
+			stat.TotalTasksCnt-stat.RepairedTasksCnt, stat.MigrateTasksStat)...)
+	}
+	if stat := stats.DiskDrop; stat != nil {
+		problems = append(problems, checkMigrating(proto.TaskTypeDiskDrop, stat.Enable, stat.DroppingDisks,
+			stat.TotalTasksCnt-stat.DroppedTasksCnt, stat.MigrateTasksStat)...)
+	}
+	if stat := d.schedule.Shard.ShardDiskRepair; stat != nil {
+		problems = append(problems, checkMigrating(proto.TaskTypeShardDiskRepair, stat.Enable, stat.RepairingDisks,
+			stat.TotalTasksCnt-stat.RepairedTasksCnt, scheduler.MigrateTasksStat{
+				PreparingCnt:   stat.PreparingCnt,
+				WorkerDoingCnt: stat.WorkerDoingCnt,
+				FinishingCnt:   stat.FinishingCnt,
+			})...)
+	}
+	if stat := stats.ShardRepair; stat != nil {
+		problems = append(problems, checkRunner(proto.TaskTypeShardRepair, stat)...)
+	}
+	if stat := stats.BlobDelete; stat != nil {
+		problems = append(problems, checkRunner(proto.TaskTypeBlobDelete, stat)...)
+	}
+	if stat := stats.VolumeInspect; stat != nil {
+		if timeout := sumPerMin(stat.TimeOutPerMin); timeout > 0 {
+			problems = append(problems, problem{
+				level:     levelNotice,
+				component: component,
+				summary:   fmt.Sprintf("%d volume inspect tasks timeout in recent minutes", timeout),
+				remedy:    "check load of blobnode workers and the volumes in timeout",
+			})
+		}
+	}
+	return
+}
+
+func checkMigrating(typ proto.TaskType, enable bool, disks []proto.DiskID, backlog int,
+	stat scheduler.MigrateTasksStat,
+) (problems []problem) {
+	if len(disks) == 0 && backlog <= 0 {
+		return
+	}
+	if !enable {
+		return []problem{{
+			level:     levelCritical,
+			component: "scheduler",
+			summary:   fmt.Sprintf("%s is disabled while %d disk(s) are pending", typ, len(disks)),
+			detail:    fmt.Sprintf("disks %v", disks),
+			remedy:    fmt.Sprintf("`cm background enable %s` (POST /config/set key=%s value=true)", typ, typ),
+		}}
+	}
+	if backlog > 0 && stat.WorkerDoingCnt == 0 && stat.FinishingCnt == 0 {
+		problems = append(problems, problem{
+			level:     levelWarning,
+			component: "scheduler",
+			summary:   fmt.Sprintf("%d %s task(s) are pending but none is running", backlog, typ),
+			detail:    fmt.Sprintf("disks %v, preparing %d", disks, stat.PreparingCnt),
+			remedy:    fmt.Sprintf("check blobnode workers acquiring tasks, and task details by `scheduler migrate` with type %s", typ),
+		})
+	} else if backlog > 0 {
+		problems = append(problems, problem{
+			level:     levelNotice,
+			component: "scheduler",
+			summary:   fmt.Sprintf("%d %s task(s) in backlog", backlog, typ),
+			detail: fmt.Sprintf("disks %v, preparing %d, doing %d, finishing %d",
+				disks, stat.PreparingCnt, stat.WorkerDoingCnt, stat.FinishingCnt),
+			remedy: "wait for the tasks, or speed up by raising the worker concurrency of blobnode",
+		})
+	}
+	return
+}
+
+func checkRunner(typ proto.TaskType, stat *scheduler.RunnerStat) (problems []problem) {
+	if !stat.Enable {
+		problems = append(problems, problem{
+			level:     levelWarning,
+			component: "scheduler",
+			summary:   fmt.Sprintf("%s is disabled", typ),
+			remedy:    fmt.Sprintf("`cm background enable %s` (POST /config/set key=%s value=true)", typ, typ),
+		})
+	}
+
+	failed, success := sumPerMin(stat.FailedPerMin), sumPerMin(stat.SuccessPerMin)
+	if failed == 0 {
+		return
+	}
+	lvl := levelNotice
+	if failed > success {
+		lvl = levelWarning
+	}
+	problems = append(problems, problem{
+		level:     lvl,
+		component: "scheduler",
+		summary:   fmt.Sprintf("%d %s task(s) failed in recent minutes, %d succeeded", failed, typ, success),
+		detail:    fmt.Sprintf("total errors %d, %s", stat.TotalErrCnt, strings.Join(stat.ErrStats, "; ")),
+		remedy:    "check the blobnode or shardnode in the errors, and topics of kafka consumed by `scheduler kafka`",
+	})
+	return
+}
+
+// sumPerMin sums the printed counter slots like "[1 0 2]"
+func sumPerMin(s string) (sum int) {
+	for _, field := range strings.Fields(strings.Trim(s, "[]")) {
+		if n, err := strconv.Atoi(field); err == nil {
+			sum += n
+		}
+	}
+	return
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/desertbit/grumble"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/api/scheduler"
+	"github.com/cubefs/cubefs/blobstore/cli/common"
+	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
+	"github.com/cubefs/cubefs/blobstore/cli/config"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+const listDiskPageSize = 200
+
+// Register register doctor
+func Register(app *grumble.App) {
+	app.AddCommand(&grumble.Command{
+		Name:     "doctor",
+		Help:     "diagnose health of cluster",
+		LongHelp: "Collect stats of clustermgr and scheduler, and print the ranked problems with suggested remediation",
+		Run:      cmdDoctor,
+		Flags: func(f *grumble.Flags) {
+			f.StringL("cluster_id", "", "specific clustermgr cluster id")
+			f.StringL("secret", "", "specific clustermgr secret")
+			f.StringL("hosts", "", "specific clustermgr hosts")
+		},
+	})
+}
+
+// diagnosis is the collected states of the cluster, nil state means failed to collect
+type diagnosis struct {
+	stat        *clustermgr.StatInfo
+	statErr     error
+	expired     []*clustermgr.BlobNodeDiskInfo
+	expiredErr  error
+	schedule    *scheduler.TasksStat
+	scheduleErr error
+}
+
+func cmdDoctor(c *grumble.Context) error {
+	clusterID := c.Flags.String("cluster_id")
+	if clusterID == "" {
+		clusterID = fmt.Sprintf("%d", config.DefaultClusterID())
+	}
+	cid, err := strconv.ParseUint(clusterID, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid cluster id %s", clusterID)
+	}
+	var hosts []string
+	if str := strings.TrimSpace(c.Flags.String("hosts")); str != "" {
+		hosts = strings.Split(str, " ")
+	}
+	cmCli := config.NewCluster(clusterID, hosts, c.Flags.String("secret"))
+	schedulerCli := scheduler.New(&scheduler.Config{}, cmCli, proto.ClusterID(cid))
+
+	d := collect(common.CmdContext(), cmCli, schedulerCli)
+	problems := d.diagnose()
+	printProblems(problems)
+	return nil
+}
+
+func collect(ctx context.Context, cmCli *clustermgr.Client, schedulerCli scheduler.ISchedulerStatus) *diagnosis {
+	d := &diagnosis{}
+	stat, err := cmCli.Stat(ctx)
+	if err != nil {
+		d.statErr = err
+	} else {
+		d.stat = stat
+	}
+
+	expired := true
+	args := &clustermgr.ListOptionArgs{HeartbeatExpired: &expired, Count: listDiskPageSize}
+	for {
+		ret, err := cmCli.ListDisk(ctx, args)
+		if err != nil {
+			d.expiredErr = err
+			break
+		}
+		d.expired = append(d.expired, ret.Disks...)
+		if len(ret.Disks) == 0 || ret.Marker == proto.InvalidDiskID {
+			break
+		}
+		args.Marker = ret.Marker
+	}
+
+	schedule, err := schedulerCli.LeaderStats(ctx)
+	if err != nil {
+		d.scheduleErr = err
+	} else {
+		d.schedule = &schedule
+	}
+	return d
+}
+
+func printProblems(problems []problem) {
+	if len(problems) == 0 {
+		fmt.Println(common.Normal.Sprint("no problem found"))
+		return
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].level > problems[j].level
+	})
+
+	fmt.Printf("found %d problem(s), most likely first:\n", len(problems))
+	for idx, p := range problems {
+		fmt.Printf("%2d. %s [%s] %s\n", idx+1, p.level.colored(), p.component, p.summary)
+		if p.detail != "" {
+			fmt.Println("      detail:", p.detail)
+		}
+		fmt.Println("      remedy:", p.remedy)
+	}
+}
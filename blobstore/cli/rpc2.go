@@ -90,6 +90,17 @@ func cmdRpc2Request(c *grumble.Context) error {
 	return nil
 }
 
+func cmdRpc2Methods(c *grumble.Context) error {
+	methods, err := config.Rpc2Client.Methods(common.CmdContext(), c.Args.String("addr"))
+	if err != nil {
+		return err
+	}
+	for _, method := range methods {
+		fmt.Printf("%-32s parameter: %s\n", method.Path, method.Parameter)
+	}
+	return nil
+}
+
 func registerRpc2(app *grumble.App) {
 	rpc2Command := &grumble.Command{
 		Name:     "rpc2",
@@ -106,5 +117,13 @@ func registerRpc2(app *grumble.App) {
 		},
 		Run: cmdRpc2Request,
 	}
+	rpc2Command.AddCommand(&grumble.Command{
+		Name: "methods",
+		Help: "list registered methods of rpc2 server",
+		Args: func(a *grumble.Args) {
+			a.String("addr", "request address")
+		},
+		Run: cmdRpc2Methods,
+	})
 	app.AddCommand(rpc2Command)
 }
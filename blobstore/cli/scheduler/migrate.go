@@ -56,9 +56,20 @@ func addCmdMigrateTask(cmd *grumble.Command) {
 		Flags: func(f *grumble.Flags) {
 			clusterFlags(f)
 			f.Uint64("", "vuid", 0, "set the vuid")
+			f.Uint64L(_diskID, 0, "migrate the vuid off the disk, not checked if 0")
 			f.Bool("", _directDownload, true, "whether download directly")
 		},
 	})
+	migrateCommand.AddCommand(&grumble.Command{
+		Name: "status",
+		Help: "get status of manual migrate task",
+		Run:  cmdManualTaskStatus,
+		Flags: func(f *grumble.Flags) {
+			clusterFlags(f)
+			f.StringL(_taskID, "", "set the task_id")
+			f.Uint64L(_diskID, 0, "source disk id of the task")
+		},
+	})
 	migrateCommand.AddCommand(&grumble.Command{
 		Name: "list",
 		Help: "list migrate tasks",
@@ -120,19 +131,36 @@ func cmdAddTask(c *grumble.Context) error {
 	clusterID := getClusterID(c.Flags)
 	directDownload := c.Flags.Bool(_directDownload)
 	vuid := proto.Vuid(c.Flags.Uint64("vuid"))
-	if !common.Confirm(fmt.Sprintf("add manual migrate task: vid[%d], vuid[%d] ?", vuid.Vid(), vuid)) {
+	diskID := proto.DiskID(c.Flags.Uint64(_diskID))
+	if !common.Confirm(fmt.Sprintf("add manual migrate task: vid[%d], vuid[%d], disk_id[%d] ?", vuid.Vid(), vuid, diskID)) {
 		return nil
 	}
 	clusterMgrCli := newClusterMgrClient(clusterID)
 	cli := scheduler.New(&scheduler.Config{}, clusterMgrCli, clusterID)
-	err := cli.AddManualMigrateTask(ctx, &scheduler.AddManualMigrateArgs{
+	ret, err := cli.AddManualMigrateTask(ctx, &scheduler.AddManualMigrateArgs{
 		Vuid:           vuid,
+		DiskID:         diskID,
 		DirectDownload: directDownload,
 	})
 	if err != nil {
 		return err
 	}
-	fmt.Println("add manual migrate task successfully")
+	fmt.Printf("add manual migrate task successfully: task_id[%s], disk_id[%d]\n", ret.TaskID, ret.DiskID)
+	return nil
+}
+
+func cmdManualTaskStatus(c *grumble.Context) error {
+	clusterID := getClusterID(c.Flags)
+	clusterMgrCli := newClusterMgrClient(clusterID)
+	cli := scheduler.New(&scheduler.Config{}, clusterMgrCli, clusterID)
+	status, err := cli.ManualMigrateTaskStatus(common.CmdContext(), &scheduler.ManualMigrateStatusArgs{
+		TaskID: c.Flags.String(_taskID),
+		DiskID: proto.DiskID(c.Flags.Uint64(_diskID)),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(common.Readable(status))
 	return nil
 }
 
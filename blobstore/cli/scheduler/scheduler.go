@@ -83,6 +83,7 @@ func Register(app *grumble.App) {
 
 	addCmdMigrateTask(schedulerCommand)
 	addCmdVolumeInspectCheckpointTask(schedulerCommand)
+	addCmdVolumeInspect(schedulerCommand)
 	addCmdKafkaConsumer(schedulerCommand)
 }
 
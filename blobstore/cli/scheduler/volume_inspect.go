@@ -17,6 +17,7 @@ package scheduler
 import (
 	"github.com/desertbit/grumble"
 
+	"github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/cli/common"
 	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -70,3 +71,39 @@ func cmdSetInspectCheckpoint(c *grumble.Context) error {
 	fmt.Println("set volume inspect checkpoint successfully")
 	return nil
 }
+
+func addCmdVolumeInspect(cmd *grumble.Command) {
+	cmd.AddCommand(&grumble.Command{
+		Name:     "inspect",
+		Help:     "inspect volume immediately",
+		LongHelp: "inspect shards of the volume or the blob, and repair its missing or corrupt shards",
+		Run:      cmdInspectVolume,
+		Flags: func(f *grumble.Flags) {
+			clusterFlags(f)
+			f.Uint64("b", "bid", 0, "inspect the blob only")
+			f.Bool("r", "repair", false, "repair missing or corrupt shards")
+		},
+		Args: func(a *grumble.Args) {
+			a.Uint64("volume_id", "volume id to inspect")
+		},
+	})
+}
+
+func cmdInspectVolume(c *grumble.Context) error {
+	clusterID := getClusterID(c.Flags)
+	args := &scheduler.InspectVolumeArgs{
+		Vid:    proto.Vid(c.Args.Uint64("volume_id")),
+		Bid:    proto.BlobID(c.Flags.Uint64("bid")),
+		Repair: c.Flags.Bool("repair"),
+	}
+	if args.Repair && !common.Confirm(fmt.Sprintf("inspect and repair volume: vid[%d], bid[%d] ?", args.Vid, args.Bid)) {
+		return nil
+	}
+	cli := scheduler.New(&scheduler.Config{}, newClusterMgrClient(clusterID), clusterID)
+	ret, err := cli.InspectVolume(common.CmdContext(), args)
+	if err != nil {
+		return err
+	}
+	fmt.Println(common.RawString(ret))
+	return nil
+}
@@ -85,6 +85,19 @@ func addCmdShard(cmd *grumble.Command) {
 		Run: cmdTransferShardLeader,
 	})
 
+	// set shard readonly
+	shardCommand.AddCommand(&grumble.Command{
+		Name: "setReadonly",
+		Help: "set shard readonly or writable on leader",
+		Args: func(a *grumble.Args) {
+			args.NodeHostRegister(a)
+			args.DiskIDRegister(a)
+			args.SuidRegister(a)
+			a.Bool("readonly", "reject writes of shard if true")
+		},
+		Run: cmdSetShardReadonly,
+	})
+
 	// add shard
 	shardCommand.AddCommand(&grumble.Command{
 		Name: "addShard",
@@ -251,6 +264,20 @@ func cmdTransferShardLeader(c *grumble.Context) error {
 	return nil
 }
 
+func cmdSetShardReadonly(c *grumble.Context) error {
+	ctx := common.CmdContext()
+	host := args.NodeHost(c.Args)
+	diskID := args.DiskID(c.Args)
+	suid := args.Suid(c.Args)
+
+	cli := shardnode.New(rpc2.Client{})
+	return cli.SetShardReadonly(ctx, host, shardnode.SetShardReadonlyArgs{
+		DiskID:   diskID,
+		Suid:     suid,
+		Readonly: c.Args.Bool("readonly"),
+	})
+}
+
 func cmdAddShard(c *grumble.Context) error {
 	ctx := common.CmdContext()
 	host := args.NodeHost(c.Args)
@@ -27,7 +27,6 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/access"
 	"github.com/cubefs/cubefs/blobstore/cli/common"
-	"github.com/cubefs/cubefs/blobstore/cli/common/args"
 	"github.com/cubefs/cubefs/blobstore/cli/common/cfmt"
 	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -127,27 +126,36 @@ func registerUtil(app *grumble.App) {
 		},
 	})
 	utilCommand.AddCommand(&grumble.Command{
-		Name: "vuid",
-		Help: "parse vuid <vuid>",
+		Name:     "vuid",
+		Help:     "parse vuid <vuid|vid:index:epoch>",
+		LongHelp: "parse vuid, or encode vuid from vid:index:epoch",
 		Args: func(a *grumble.Args) {
-			args.VuidRegister(a)
+			a.String("vuid", "vuid or vid:index:epoch")
 		},
 		Run: func(c *grumble.Context) error {
-			fmt.Println("Parse VUID: ", cfmt.VuidCF(args.Vuid(c.Args)))
-			return nil
+			vuid, err := proto.ParseVuid(c.Args.String("vuid"))
+			if vuid == proto.InvalidVuid && err != nil {
+				return err
+			}
+			fmt.Println("Parse VUID: ", cfmt.VuidCF(vuid))
+			return err
 		},
 	})
 	utilCommand.AddCommand(&grumble.Command{
-		Name: "suid",
-		Help: "parse suid <suid>",
+		Name:     "suid",
+		Help:     "parse suid <suid|shardID:index:epoch>",
+		LongHelp: "parse suid, or encode suid from shardID:index:epoch",
 		Args: func(a *grumble.Args) {
-			args.SuidRegister(a)
+			a.String("suid", "suid or shardID:index:epoch")
 		},
 		Run: func(c *grumble.Context) error {
-			suid := args.Suid(c.Args)
+			suid, err := proto.ParseSuid(c.Args.String("suid"))
+			if suid == proto.InvalidSuid && err != nil {
+				return err
+			}
 			fmt.Println("Full  SUID: ", suid.ToString())
 			fmt.Println("Parse SUID: ", cfmt.SuidF(suid))
-			return nil
+			return err
 		},
 	})
 	utilCommand.AddCommand(&grumble.Command{
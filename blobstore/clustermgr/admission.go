@@ -0,0 +1,259 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+const (
+	admissionKindRegister  = "register"
+	admissionKindHeartbeat = "heartbeat"
+
+	admissionRejectQueueFull = "queue_full"
+	admissionRejectTimeout   = "timeout"
+
+	defaultAdmissionMaxQueued     = 1024
+	defaultAdmissionWaitTimeoutMs = 3000
+)
+
+// AdmissionConfig throttles the register and heartbeat requests of nodes and disks,
+// to protect raft apply pipeline from restart storm of hundreds of nodes.
+// Zero rate means no limit.
+type AdmissionConfig struct {
+	RegisterPerSec  int `json:"register_per_sec"`
+	HeartbeatPerSec int `json:"heartbeat_per_sec"`
+	// requests exceed the rate are queued, and rejected if the queue is full or waiting timeout
+	MaxQueued     int `json:"max_queued"`
+	WaitTimeoutMs int `json:"wait_timeout_ms"`
+}
+
+// admissionQueue admits requests at most rate per second, the queued requests
+// are admitted in round robin of remote hosts, so a node with many disks
+// can not starve the others.
+type admissionQueue struct {
+	kind      string
+	region    string
+	clusterID string
+	maxQueued int
+	timeout   time.Duration
+	limiter   *rate.Limiter
+
+	mu      sync.Mutex
+	keys    []string // keys having waiters in round robin order
+	waiters map[string][]chan struct{}
+	queued  int
+
+	notify chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// newAdmissionQueue returns nil if perSec is not positive, nil queue admits all requests.
+func newAdmissionQueue(kind string, perSec int, cfg AdmissionConfig, region, clusterID string) *admissionQueue {
+	if perSec <= 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &admissionQueue{
+		kind:      kind,
+		region:    region,
+		clusterID: clusterID,
+		maxQueued: cfg.MaxQueued,
+		timeout:   time.Duration(cfg.WaitTimeoutMs) * time.Millisecond,
+		limiter:   rate.NewLimiter(rate.Limit(perSec), perSec),
+		waiters:   make(map[string][]chan struct{}),
+		notify:    make(chan struct{}, 1),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	go q.loop()
+	return q
+}
+
+// admit waits until the request of key is admitted, returns ErrAdmissionRejected
+// if the queue is full or waiting timeout.
+func (q *admissionQueue) admit(ctx context.Context, key string) error {
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	if q.queued == 0 && q.limiter.Allow() {
+		q.mu.Unlock()
+		return nil
+	}
+	if q.queued >= q.maxQueued {
+		q.mu.Unlock()
+		q.reportRejected(admissionRejectQueueFull)
+		return apierrors.ErrAdmissionRejected
+	}
+	ch := make(chan struct{})
+	if len(q.waiters[key]) == 0 {
+		q.keys = append(q.keys, key)
+	}
+	q.waiters[key] = append(q.waiters[key], ch)
+	q.queued++
+	q.reportQueued()
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	timer := time.NewTimer(q.timeout)
+	defer timer.Stop()
+	select {
+	case <-ch:
+		return nil
+	case <-timer.C:
+	case <-ctx.Done():
+	case <-q.ctx.Done():
+	}
+
+	q.mu.Lock()
+	removed := q.remove(key, ch)
+	q.mu.Unlock()
+	if !removed { // admitted just now
+		return nil
+	}
+	q.reportRejected(admissionRejectTimeout)
+	return apierrors.ErrAdmissionRejected
+}
+
+func (q *admissionQueue) loop() {
+	for {
+		select {
+		case <-q.notify:
+		case <-q.ctx.Done():
+			return
+		}
+		for q.queuedNum() > 0 {
+			if err := q.limiter.Wait(q.ctx); err != nil {
+				return
+			}
+			q.mu.Lock()
+			q.admitNext()
+			q.mu.Unlock()
+		}
+	}
+}
+
+// admitNext admits the head waiter of the next key, must be called with lock held.
+func (q *admissionQueue) admitNext() {
+	if q.queued == 0 {
+		return
+	}
+	key := q.keys[0]
+	q.keys = q.keys[1:]
+	waiters := q.waiters[key]
+	close(waiters[0])
+	if waiters = waiters[1:]; len(waiters) > 0 {
+		q.waiters[key] = waiters
+		q.keys = append(q.keys, key)
+	} else {
+		delete(q.waiters, key)
+	}
+	q.queued--
+	q.reportQueued()
+}
+
+// remove removes the waiter, returns false if not found, must be called with lock held.
+func (q *admissionQueue) remove(key string, ch chan struct{}) bool {
+	waiters := q.waiters[key]
+	for idx := range waiters {
+		if waiters[idx] != ch {
+			continue
+		}
+		waiters = append(waiters[:idx], waiters[idx+1:]...)
+		if len(waiters) > 0 {
+			q.waiters[key] = waiters
+		} else {
+			delete(q.waiters, key)
+			for i := range q.keys {
+				if q.keys[i] == key {
+					q.keys = append(q.keys[:i], q.keys[i+1:]...)
+					break
+				}
+			}
+		}
+		q.queued--
+		q.reportQueued()
+		return true
+	}
+	return false
+}
+
+func (q *admissionQueue) queuedNum() int {
+	if q == nil {
+		return 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queued
+}
+
+func (q *admissionQueue) Close() {
+	if q != nil {
+		q.cancel()
+	}
+}
+
+func (q *admissionQueue) reportQueued() {
+	admissionQueuedMetric.WithLabelValues(q.region, q.clusterID, q.kind).Set(float64(q.queued))
+}
+
+func (q *admissionQueue) reportRejected(reason string) {
+	admissionRejectedMetric.WithLabelValues(q.region, q.clusterID, q.kind, reason).Inc()
+}
+
+// admitWith wraps the register or heartbeat handler, the request is throttled by
+// the admission queue before proposing into raft
+func (s *Service) admitWith(q *admissionQueue, f rpc.HandlerFunc) rpc.HandlerFunc {
+	return func(c *rpc.Context) {
+		ctx := c.Request.Context()
+		if err := q.admit(ctx, admissionKey(c)); err != nil {
+			span := trace.SpanFromContextSafe(ctx)
+			span.Warnf("reject %s from %s, queued: %d", c.Request.URL.Path, admissionKey(c), q.queuedNum())
+			c.RespondError(err)
+			return
+		}
+		f(c)
+	}
+}
+
+// admissionKey returns the remote host of request, the request forwarded
+// by follower takes the original host in X-Forwarded-For
+func admissionKey(c *rpc.Context) string {
+	if forwarded := c.Request.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		return c.Request.RemoteAddr
+	}
+	return host
+}
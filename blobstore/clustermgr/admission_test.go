@@ -0,0 +1,111 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+func TestAdmissionQueue(t *testing.T) {
+	ctx := context.Background()
+	cfg := AdmissionConfig{MaxQueued: 4, WaitTimeoutMs: 50}
+
+	// no limit
+	var q *admissionQueue = newAdmissionQueue(admissionKindRegister, 0, cfg, "region", "1")
+	require.Nil(t, q)
+	require.NoError(t, q.admit(ctx, "host"))
+	require.Equal(t, 0, q.queuedNum())
+	q.Close()
+
+	// burst is admitted at once, then timeout in queue
+	q = newAdmissionQueue(admissionKindRegister, 2, cfg, "region", "1")
+	defer q.Close()
+	require.NoError(t, q.admit(ctx, "host"))
+	require.NoError(t, q.admit(ctx, "host"))
+	require.ErrorIs(t, q.admit(ctx, "host"), apierrors.ErrAdmissionRejected)
+	require.Equal(t, 0, q.queuedNum())
+
+	// queue full
+	var wg sync.WaitGroup
+	for range [4]struct{}{} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.admit(ctx, "host")
+		}()
+	}
+	for q.queuedNum() < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	require.ErrorIs(t, q.admit(ctx, "other"), apierrors.ErrAdmissionRejected)
+	wg.Wait()
+	require.Equal(t, 0, q.queuedNum())
+}
+
+func TestAdmissionQueueFairness(t *testing.T) {
+	ctx := context.Background()
+	q := newAdmissionQueue(admissionKindHeartbeat, 10, AdmissionConfig{MaxQueued: 100, WaitTimeoutMs: 10000}, "region", "1")
+	defer q.Close()
+	for range [10]struct{}{} { // take the burst tokens
+		require.NoError(t, q.admit(ctx, "busy"))
+	}
+
+	var (
+		mu       sync.Mutex
+		admitted []string
+		wg       sync.WaitGroup
+	)
+	admit := func(key string) {
+		defer wg.Done()
+		require.NoError(t, q.admit(ctx, key))
+		mu.Lock()
+		admitted = append(admitted, key)
+		mu.Unlock()
+	}
+	for range [3]struct{}{} {
+		wg.Add(1)
+		go admit("busy")
+	}
+	for q.queuedNum() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	wg.Add(1)
+	go admit("idle")
+	wg.Wait()
+
+	// the idle host is admitted in the second round, not after all of busy host
+	require.Equal(t, 4, len(admitted))
+	require.Equal(t, "idle", admitted[1])
+}
+
+func TestAdmissionKey(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/disk/heartbeat", nil)
+	require.NoError(t, err)
+	req.RemoteAddr = "127.0.0.1:9000"
+	c := &rpc.Context{Request: req}
+	require.Equal(t, "127.0.0.1", admissionKey(c))
+
+	req.Header.Set("X-Forwarded-For", "10.0.0.1, 127.0.0.1")
+	require.Equal(t, "10.0.0.1", admissionKey(c))
+}
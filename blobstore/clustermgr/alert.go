@@ -0,0 +1,675 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+const (
+	defaultAlertIntervalS = 60
+	defaultAlertTimeoutS  = 10
+	alertPostQueueSize    = 64
+
+	alertNodeBlobNode  = "blobnode"
+	alertNodeShardNode = "shardnode"
+	alertScopeCluster  = "cluster"
+	alertScopeIDC      = "idc"
+)
+
+// AlertRuleConfig is evaluated over space stat of blobnode or shardnode disks,
+// on the whole cluster or on each idc, the rule fires if Expr is true, eg:
+// "free_ratio < 10%", "broken_disk + expired_disk >= 3 || stuck_dropping_disk > 0".
+type AlertRuleConfig struct {
+	Name     string `json:"name"`
+	Node     string `json:"node"`  // blobnode(default) or shardnode
+	Scope    string `json:"scope"` // cluster(default) or idc
+	Expr     string `json:"expr"`
+	Severity string `json:"severity"`
+}
+
+// AlertConfig evaluates rules on leader every IntervalS seconds, the firing and
+// resolved events are logged and posted to Webhooks in json array in background,
+// the events are dropped if the posting falls behind.
+// The alert is disabled if there is no rule.
+type AlertConfig struct {
+	IntervalS int               `json:"interval_s"`
+	TimeoutS  int               `json:"timeout_s"`
+	Webhooks  []string          `json:"webhooks"`
+	Rules     []AlertRuleConfig `json:"rules"`
+}
+
+// variables of idc scope, free_ratio is ratio of free chunks or shards
+var alertIDCVars = map[string]struct{}{
+	"total_disk": {}, "available_disk": {}, "readonly_disk": {}, "expired_disk": {},
+	"broken_disk": {}, "repairing_disk": {}, "repaired_disk": {}, "dropping_disk": {}, "dropped_disk": {},
+	"total_chunk": {}, "free_chunk": {}, "total_shard": {}, "free_shard": {}, "free_ratio": {},
+}
+
+// variables of cluster scope, free_ratio is ratio of free space
+var alertClusterVars = map[string]struct{}{
+	"total_space": {}, "free_space": {}, "readonly_space": {}, "used_space": {}, "writable_space": {},
+	"used_ratio": {}, "total_node": {}, "stalled_dropping_disk": {}, "stuck_dropping_disk": {},
+}
+
+type alertRule struct {
+	AlertRuleConfig
+	expr alertExpr
+	vars []string
+}
+
+type alertSample struct {
+	target string
+	vars   map[string]float64
+}
+
+// alerter keeps firing alerts on leader in memory, the new leader evaluates from scratch.
+type alerter struct {
+	sync.Mutex
+	rules  []*alertRule
+	firing map[string]*clustermgr.AlertEvent
+
+	webhooks []string
+	timeout  time.Duration
+	posts    chan []clustermgr.AlertEvent
+	client   *http.Client
+}
+
+func newAlerter(cfg *AlertConfig) (*alerter, error) {
+	names := make(map[string]struct{}, len(cfg.Rules))
+	rules := make([]*alertRule, 0, len(cfg.Rules))
+	for _, rc := range cfg.Rules {
+		if rc.Name == "" {
+			return nil, errors.New("alert rule name is empty")
+		}
+		if _, ok := names[rc.Name]; ok {
+			return nil, fmt.Errorf("duplicated alert rule %s", rc.Name)
+		}
+		names[rc.Name] = struct{}{}
+
+		if rc.Node == "" {
+			rc.Node = alertNodeBlobNode
+		}
+		if rc.Node != alertNodeBlobNode && rc.Node != alertNodeShardNode {
+			return nil, fmt.Errorf("alert rule %s has invalid node %s", rc.Name, rc.Node)
+		}
+		if rc.Scope == "" {
+			rc.Scope = alertScopeCluster
+		}
+		if rc.Scope != alertScopeCluster && rc.Scope != alertScopeIDC {
+			return nil, fmt.Errorf("alert rule %s has invalid scope %s", rc.Name, rc.Scope)
+		}
+
+		expr, vars, err := parseAlertExpr(rc.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %s: %s", rc.Name, err.Error())
+		}
+		for _, name := range vars {
+			_, ok := alertIDCVars[name]
+			if !ok && rc.Scope == alertScopeCluster {
+				_, ok = alertClusterVars[name]
+			}
+			if !ok {
+				return nil, fmt.Errorf("alert rule %s: undefined variable %s in scope %s", rc.Name, name, rc.Scope)
+			}
+		}
+		rules = append(rules, &alertRule{AlertRuleConfig: rc, expr: expr, vars: vars})
+	}
+	timeout := time.Duration(cfg.TimeoutS) * time.Second
+	return &alerter{
+		rules:    rules,
+		firing:   make(map[string]*clustermgr.AlertEvent),
+		webhooks: cfg.Webhooks,
+		timeout:  timeout,
+		posts:    make(chan []clustermgr.AlertEvent, alertPostQueueSize),
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (a *alerter) reset() {
+	a.Lock()
+	a.firing = make(map[string]*clustermgr.AlertEvent)
+	a.Unlock()
+}
+
+// evaluate evaluates rules over samples of node, returns the events of
+// alerts started firing or resolved, the firing alerts are kept.
+func (a *alerter) evaluate(samples map[string][]alertSample, now time.Time) []clustermgr.AlertEvent {
+	events := make([]clustermgr.AlertEvent, 0)
+	seen := make(map[string]struct{})
+
+	a.Lock()
+	defer a.Unlock()
+	for _, rule := range a.rules {
+		for _, sample := range samples[rule.Node+"/"+rule.Scope] {
+			key := rule.Name + "/" + sample.target
+			seen[key] = struct{}{}
+
+			values := make(map[string]float64, len(rule.vars))
+			for _, name := range rule.vars {
+				values[name] = sample.vars[name]
+			}
+			event, firing := a.firing[key]
+			if !rule.expr.eval(sample.vars) {
+				if firing {
+					delete(a.firing, key)
+					event.Status = clustermgr.AlertStatusResolved
+					event.Values = values
+					event.Time = now.Unix()
+					events = append(events, *event)
+				}
+				continue
+			}
+			if firing {
+				event.Values = values
+				continue
+			}
+			event = &clustermgr.AlertEvent{
+				Rule:      rule.Name,
+				Severity:  rule.Severity,
+				Node:      rule.Node,
+				Target:    sample.target,
+				Expr:      rule.Expr,
+				Status:    clustermgr.AlertStatusFiring,
+				Values:    values,
+				StartTime: now.Unix(),
+				Time:      now.Unix(),
+			}
+			a.firing[key] = event
+			events = append(events, *event)
+		}
+	}
+	// the idc has no disk anymore
+	for key, event := range a.firing {
+		if _, ok := seen[key]; !ok {
+			delete(a.firing, key)
+			event.Status = clustermgr.AlertStatusResolved
+			event.Time = now.Unix()
+			events = append(events, *event)
+		}
+	}
+	return events
+}
+
+func (a *alerter) list() []clustermgr.AlertEvent {
+	a.Lock()
+	alerts := make([]clustermgr.AlertEvent, 0, len(a.firing))
+	for _, event := range a.firing {
+		alerts = append(alerts, *event)
+	}
+	a.Unlock()
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Rule != alerts[j].Rule {
+			return alerts[i].Rule < alerts[j].Rule
+		}
+		return alerts[i].Target < alerts[j].Target
+	})
+	return alerts
+}
+
+// send queues events to be posted to webhooks, returns false if the queue is full
+func (a *alerter) send(events []clustermgr.AlertEvent) bool {
+	if len(a.webhooks) == 0 {
+		return true
+	}
+	select {
+	case a.posts <- events:
+		return true
+	default:
+		return false
+	}
+}
+
+// loopPost posts the queued events to webhooks in order, so the slow
+// webhook does not block the service loop
+func (a *alerter) loopPost(closeCh <-chan interface{}) {
+	for {
+		select {
+		case <-closeCh:
+			return
+		case events := <-a.posts:
+			span, ctx := trace.StartSpanFromContext(context.Background(), "alert-post")
+			for _, url := range a.webhooks {
+				postCtx, cancel := context.WithTimeout(ctx, a.timeout)
+				if err := a.post(postCtx, url, events); err != nil {
+					span.Errorf("post %d alert events to webhook %s failed, err: %s", len(events), url, errors.Detail(err))
+				}
+				cancel()
+			}
+		}
+	}
+}
+
+func (a *alerter) post(ctx context.Context, url string, events []clustermgr.AlertEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(rpc.HeaderContentType, rpc.MIMEJSON)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ratio returns 1 if total is zero, nothing is used
+func ratio(n, total int64) float64 {
+	if total <= 0 {
+		return 1
+	}
+	return float64(n) / float64(total)
+}
+
+// alertSamples returns samples of the whole cluster and each idc of space stat
+func alertSamples(stat *clustermgr.SpaceStatInfo, node string) (cluster alertSample, idcs []alertSample) {
+	vars := map[string]float64{
+		"total_space":    float64(stat.TotalSpace),
+		"free_space":     float64(stat.FreeSpace),
+		"readonly_space": float64(stat.ReadOnlySpace),
+		"used_space":     float64(stat.UsedSpace),
+		"writable_space": float64(stat.WritableSpace),
+		"total_disk":     float64(stat.TotalDisk),
+		"free_ratio":     ratio(stat.FreeSpace, stat.TotalSpace),
+		"used_ratio":     1 - ratio(stat.TotalSpace-stat.UsedSpace, stat.TotalSpace),
+	}
+	if node == alertNodeShardNode {
+		vars["total_node"] = float64(stat.TotalShardNode)
+	} else {
+		vars["total_node"] = float64(stat.TotalBlobNode)
+	}
+
+	idcs = make([]alertSample, 0, len(stat.DisksStatInfos))
+	for _, info := range stat.DisksStatInfos {
+		idcVars := map[string]float64{
+			"total_disk":     float64(info.Total),
+			"available_disk": float64(info.Available),
+			"readonly_disk":  float64(info.Readonly),
+			"expired_disk":   float64(info.Expired),
+			"broken_disk":    float64(info.Broken),
+			"repairing_disk": float64(info.Repairing),
+			"repaired_disk":  float64(info.Repaired),
+			"dropping_disk":  float64(info.Dropping),
+			"dropped_disk":   float64(info.Dropped),
+			"total_chunk":    float64(info.TotalChunk),
+			"free_chunk":     float64(info.TotalFreeChunk),
+			"total_shard":    float64(info.TotalShard),
+			"free_shard":     float64(info.TotalFreeShard),
+		}
+		if node == alertNodeShardNode {
+			idcVars["free_ratio"] = ratio(info.TotalFreeShard, info.TotalShard)
+		} else {
+			idcVars["free_ratio"] = ratio(info.TotalFreeChunk, info.TotalChunk)
+		}
+		for name, value := range idcVars {
+			if name != "total_disk" && name != "free_ratio" {
+				vars[name] += value
+			}
+		}
+		idcs = append(idcs, alertSample{target: info.IDC, vars: idcVars})
+	}
+	return alertSample{vars: vars}, idcs
+}
+
+func (s *Service) alertSamples(ctx context.Context) map[string][]alertSample {
+	samples := make(map[string][]alertSample)
+	for _, node := range []string{alertNodeBlobNode, alertNodeShardNode} {
+		var stat *clustermgr.SpaceStatInfo
+		if node == alertNodeShardNode {
+			stat = s.ShardNodeMgr.Stat(ctx, proto.DiskTypeNVMeSSD)
+		} else {
+			stat = s.BlobNodeMgr.Stat(ctx, proto.DiskTypeHDD)
+		}
+		cluster, idcs := alertSamples(stat, node)
+		if node == alertNodeBlobNode {
+			stalled, stuck := s.dropWatchdog.count(time.Duration(s.DropWatchdogConfig.StuckHours) * time.Hour)
+			cluster.vars["stalled_dropping_disk"] = float64(len(stalled))
+			cluster.vars["stuck_dropping_disk"] = float64(stuck)
+		}
+		samples[node+"/"+alertScopeCluster] = []alertSample{cluster}
+		samples[node+"/"+alertScopeIDC] = idcs
+	}
+	return samples
+}
+
+// AlertList returns the firing alerts on leader
+func (s *Service) AlertList(c *rpc.Context) {
+	if !s.raftNode.IsLeader() {
+		s.forwardToLeader(c.Writer, c.Request)
+		return
+	}
+	alerts := make([]clustermgr.AlertEvent, 0)
+	if s.alerter != nil {
+		alerts = s.alerter.list()
+	}
+	c.RespondJSON(&clustermgr.ListAlertRet{Alerts: alerts})
+}
+
+// evaluateAlerts evaluates alert rules over space stat and dropping progress,
+// the events of alerts started firing or resolved are logged and queued to webhooks.
+func (s *Service) evaluateAlerts(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	events := s.alerter.evaluate(s.alertSamples(ctx), time.Now())
+	s.reportFiringAlert(s.alerter.list())
+	if len(events) == 0 {
+		return
+	}
+	for idx := range events {
+		event := &events[idx]
+		event.Region = s.Region
+		event.ClusterID = s.ClusterID
+		if event.Status == clustermgr.AlertStatusFiring {
+			span.Warnf("alert %s of %s %s is firing, expr: %s, values: %v",
+				event.Rule, event.Node, event.Target, event.Expr, event.Values)
+		} else {
+			span.Infof("alert %s of %s %s is resolved, expr: %s, values: %v",
+				event.Rule, event.Node, event.Target, event.Expr, event.Values)
+		}
+	}
+
+	if !s.alerter.send(events) {
+		span.Errorf("alert post queue is full, %d alert events dropped", len(events))
+	}
+}
+
+// alertExpr is boolean expression of comparisons combined with && and ||,
+// the operand is variable or number, number with suffix % is divided by 100.
+type alertExpr interface {
+	eval(vars map[string]float64) bool
+}
+
+type alertOperand struct {
+	name  string
+	value float64
+}
+
+func (o alertOperand) get(vars map[string]float64) float64 {
+	if o.name != "" {
+		return vars[o.name]
+	}
+	return o.value
+}
+
+type alertCompare struct {
+	op          string
+	left, right []alertOperand // sum of operands
+}
+
+func sumOperands(operands []alertOperand, vars map[string]float64) (sum float64) {
+	for _, o := range operands {
+		sum += o.get(vars)
+	}
+	return
+}
+
+func (c *alertCompare) eval(vars map[string]float64) bool {
+	left, right := sumOperands(c.left, vars), sumOperands(c.right, vars)
+	switch c.op {
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "==":
+		return left == right
+	default:
+		return left != right
+	}
+}
+
+type alertLogic struct {
+	and   bool
+	exprs []alertExpr
+}
+
+func (l *alertLogic) eval(vars map[string]float64) bool {
+	for _, expr := range l.exprs {
+		if expr.eval(vars) != l.and {
+			return !l.and
+		}
+	}
+	return l.and
+}
+
+type alertParser struct {
+	tokens []string
+	pos    int
+	vars   []string
+}
+
+func parseAlertExpr(s string) (alertExpr, []string, error) {
+	tokens, err := tokenizeAlertExpr(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("empty expression")
+	}
+	p := &alertParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, nil, fmt.Errorf("unexpected %s", p.tokens[p.pos])
+	}
+	return expr, p.vars, nil
+}
+
+func isAlertIdentChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
+
+func isAlertNumberChar(c byte) bool {
+	return c == '.' || (c >= '0' && c <= '9')
+}
+
+func tokenizeAlertExpr(s string) ([]string, error) {
+	tokens := make([]string, 0)
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')' || c == '+':
+			tokens = append(tokens, s[i:i+1])
+			i++
+		case c == '&' || c == '|':
+			if i+1 >= len(s) || s[i+1] != c {
+				return nil, fmt.Errorf("invalid operator at %d", i)
+			}
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		case c == '<' || c == '>' || c == '=' || c == '!':
+			j := i + 1
+			if j < len(s) && s[j] == '=' {
+				j++
+			}
+			if op := s[i:j]; op == "=" || op == "!" {
+				return nil, fmt.Errorf("invalid operator at %d", i)
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case isAlertNumberChar(c):
+			j := i
+			for j < len(s) && isAlertNumberChar(s[j]) {
+				j++
+			}
+			if j < len(s) && s[j] == '%' {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case isAlertIdentChar(c, true):
+			j := i
+			for j < len(s) && isAlertIdentChar(s[j], false) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("invalid character %q at %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func (p *alertParser) next() string {
+	if p.pos < len(p.tokens) {
+		p.pos++
+		return p.tokens[p.pos-1]
+	}
+	return ""
+}
+
+func (p *alertParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *alertParser) parseLogic(op string, sub func() (alertExpr, error)) (alertExpr, error) {
+	expr, err := sub()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != op {
+		return expr, nil
+	}
+	logic := &alertLogic{and: op == "&&", exprs: []alertExpr{expr}}
+	for p.peek() == op {
+		p.next()
+		if expr, err = sub(); err != nil {
+			return nil, err
+		}
+		logic.exprs = append(logic.exprs, expr)
+	}
+	return logic, nil
+}
+
+func (p *alertParser) parseOr() (alertExpr, error) {
+	return p.parseLogic("||", p.parseAnd)
+}
+
+func (p *alertParser) parseAnd() (alertExpr, error) {
+	return p.parseLogic("&&", p.parsePrimary)
+}
+
+func (p *alertParser) parsePrimary() (alertExpr, error) {
+	if p.peek() == "(" {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New("missing )")
+		}
+		return expr, nil
+	}
+
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	switch op {
+	case "<", "<=", ">", ">=", "==", "!=":
+	default:
+		return nil, fmt.Errorf("expect comparison operator but %q", op)
+	}
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return &alertCompare{op: op, left: left, right: right}, nil
+}
+
+func (p *alertParser) parseSum() ([]alertOperand, error) {
+	operands := make([]alertOperand, 0, 1)
+	for {
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		if p.peek() != "+" {
+			return operands, nil
+		}
+		p.next()
+	}
+}
+
+func (p *alertParser) parseOperand() (alertOperand, error) {
+	token := p.next()
+	if token == "" {
+		return alertOperand{}, errors.New("unexpected end of expression")
+	}
+	if isAlertIdentChar(token[0], true) {
+		p.vars = appendUnique(p.vars, token)
+		return alertOperand{name: token}, nil
+	}
+	if !isAlertNumberChar(token[0]) {
+		return alertOperand{}, fmt.Errorf("expect operand but %q", token)
+	}
+	number, percent := token, false
+	if number[len(number)-1] == '%' {
+		number, percent = number[:len(number)-1], true
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return alertOperand{}, fmt.Errorf("invalid number %q", token)
+	}
+	if percent {
+		value /= 100
+	}
+	return alertOperand{value: value}, nil
+}
+
+func appendUnique(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
@@ -0,0 +1,246 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+)
+
+func TestAlertExpr(t *testing.T) {
+	vars := map[string]float64{"free_ratio": 0.05, "broken_disk": 2, "expired_disk": 1, "dropping_disk": 0}
+	for _, cs := range []struct {
+		expr  string
+		fired bool
+	}{
+		{"free_ratio < 10%", true},
+		{"free_ratio<0.05", false},
+		{"free_ratio <= 0.05", true},
+		{"broken_disk + expired_disk >= 3", true},
+		{"broken_disk == 2 && dropping_disk > 0", false},
+		{"broken_disk != 2 || dropping_disk == 0", true},
+		{"free_ratio > 50% || broken_disk > 1 && expired_disk > 1", false},
+		{"(free_ratio > 50% || broken_disk > 1) && expired_disk >= 1", true},
+		{"1 > free_ratio", true},
+	} {
+		expr, _, err := parseAlertExpr(cs.expr)
+		require.NoError(t, err, cs.expr)
+		require.Equal(t, cs.fired, expr.eval(vars), cs.expr)
+	}
+
+	_, names, err := parseAlertExpr("broken_disk + expired_disk > 0 || broken_disk > 1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"broken_disk", "expired_disk"}, names)
+
+	for _, expr := range []string{
+		"", "free_ratio", "free_ratio < ", "free_ratio = 1", "free_ratio < 1 &", "free_ratio < 1 &&",
+		"(free_ratio < 1", "free_ratio < 1)", "free_ratio < 1.1.1", "free_ratio < 10% $", "< 1",
+	} {
+		_, _, err := parseAlertExpr(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestNewAlerter(t *testing.T) {
+	for _, rules := range [][]AlertRuleConfig{
+		{{Expr: "free_ratio < 10%"}},
+		{{Name: "a", Expr: "free_ratio < 10%"}, {Name: "a", Expr: "free_ratio < 10%"}},
+		{{Name: "a", Node: "access", Expr: "free_ratio < 10%"}},
+		{{Name: "a", Scope: "rack", Expr: "free_ratio < 10%"}},
+		{{Name: "a", Expr: "free_ratio <"}},
+		{{Name: "a", Expr: "unknown > 0"}},
+		{{Name: "a", Scope: alertScopeIDC, Expr: "free_space < 1"}},
+	} {
+		_, err := newAlerter(&AlertConfig{Rules: rules})
+		require.Error(t, err)
+	}
+
+	a, err := newAlerter(&AlertConfig{Rules: []AlertRuleConfig{
+		{Name: "a", Expr: "free_space < 1 && broken_disk > 0"},
+		{Name: "b", Node: alertNodeShardNode, Scope: alertScopeIDC, Expr: "free_ratio < 10%"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, alertNodeBlobNode, a.rules[0].Node)
+	require.Equal(t, alertScopeCluster, a.rules[0].Scope)
+}
+
+func TestAlertSamples(t *testing.T) {
+	stat := &clustermgr.SpaceStatInfo{
+		TotalSpace: 100, FreeSpace: 20, UsedSpace: 70, TotalDisk: 3, TotalBlobNode: 2,
+		DisksStatInfos: []clustermgr.DiskStatInfo{
+			{IDC: "z0", Total: 2, Available: 1, Broken: 1, TotalChunk: 10, TotalFreeChunk: 1},
+			{IDC: "z1", Total: 1, Expired: 1},
+		},
+	}
+	cluster, idcs := alertSamples(stat, alertNodeBlobNode)
+	require.Equal(t, "", cluster.target)
+	require.Equal(t, 0.2, cluster.vars["free_ratio"])
+	require.Equal(t, 0.7, cluster.vars["used_ratio"])
+	require.Equal(t, float64(3), cluster.vars["total_disk"])
+	require.Equal(t, float64(2), cluster.vars["total_node"])
+	require.Equal(t, float64(1), cluster.vars["broken_disk"])
+	require.Equal(t, float64(1), cluster.vars["expired_disk"])
+
+	require.Equal(t, 2, len(idcs))
+	require.Equal(t, "z0", idcs[0].target)
+	require.Equal(t, 0.1, idcs[0].vars["free_ratio"])
+	require.Equal(t, "z1", idcs[1].target)
+	require.Equal(t, float64(1), idcs[1].vars["free_ratio"])
+
+	cluster, _ = alertSamples(&clustermgr.SpaceStatInfo{TotalShardNode: 3}, alertNodeShardNode)
+	require.Equal(t, float64(1), cluster.vars["free_ratio"])
+	require.Equal(t, float64(0), cluster.vars["used_ratio"])
+	require.Equal(t, float64(3), cluster.vars["total_node"])
+}
+
+func TestAlerterEvaluate(t *testing.T) {
+	a, err := newAlerter(&AlertConfig{Rules: []AlertRuleConfig{
+		{Name: "idc_free", Scope: alertScopeIDC, Expr: "free_ratio < 10%", Severity: "critical"},
+		{Name: "broken", Expr: "broken_disk > 0"},
+	}})
+	require.NoError(t, err)
+
+	stat := &clustermgr.SpaceStatInfo{DisksStatInfos: []clustermgr.DiskStatInfo{
+		{IDC: "z0", TotalChunk: 100, TotalFreeChunk: 5},
+		{IDC: "z1", TotalChunk: 100, TotalFreeChunk: 50},
+	}}
+	samples := func() map[string][]alertSample {
+		cluster, idcs := alertSamples(stat, alertNodeBlobNode)
+		return map[string][]alertSample{
+			alertNodeBlobNode + "/" + alertScopeCluster: {cluster},
+			alertNodeBlobNode + "/" + alertScopeIDC:     idcs,
+		}
+	}
+
+	now := time.Now()
+	events := a.evaluate(samples(), now)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, "idc_free", events[0].Rule)
+	require.Equal(t, "z0", events[0].Target)
+	require.Equal(t, "critical", events[0].Severity)
+	require.Equal(t, clustermgr.AlertStatusFiring, events[0].Status)
+	require.Equal(t, map[string]float64{"free_ratio": 0.05}, events[0].Values)
+	require.Equal(t, now.Unix(), events[0].StartTime)
+
+	// still firing
+	stat.DisksStatInfos[0].TotalFreeChunk = 4
+	stat.DisksStatInfos[1].Broken = 1
+	events = a.evaluate(samples(), now.Add(time.Minute))
+	require.Equal(t, 1, len(events))
+	require.Equal(t, "broken", events[0].Rule)
+	alerts := a.list()
+	require.Equal(t, 2, len(alerts))
+	require.Equal(t, "broken", alerts[0].Rule)
+	require.Equal(t, "idc_free", alerts[1].Rule)
+	require.Equal(t, map[string]float64{"free_ratio": 0.04}, alerts[1].Values)
+	require.Equal(t, now.Unix(), alerts[1].StartTime)
+
+	// resolved, and the idc is removed
+	stat.DisksStatInfos = stat.DisksStatInfos[1:]
+	stat.DisksStatInfos[0].Broken = 0
+	events = a.evaluate(samples(), now.Add(2*time.Minute))
+	require.Equal(t, 2, len(events))
+	for _, event := range events {
+		require.Equal(t, clustermgr.AlertStatusResolved, event.Status)
+		require.Equal(t, now.Add(2*time.Minute).Unix(), event.Time)
+	}
+	require.Equal(t, 0, len(a.list()))
+
+	stat.DisksStatInfos[0].Broken = 1
+	require.Equal(t, 1, len(a.evaluate(samples(), now)))
+	a.reset()
+	require.Equal(t, 0, len(a.list()))
+}
+
+func TestAlertWebhook(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	ret, err := testClusterClient.ListAlert(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(ret.Alerts))
+
+	var lock sync.Mutex
+	received := make([]clustermgr.AlertEvent, 0)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var events []clustermgr.AlertEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&events))
+		lock.Lock()
+		received = append(received, events...)
+		lock.Unlock()
+	}))
+	defer webhook.Close()
+
+	testService.alerter, err = newAlerter(&AlertConfig{
+		TimeoutS: 1,
+		Webhooks: []string{webhook.URL + "/", webhook.URL + "/404", "http://127.0.0.1:0"},
+		Rules: []AlertRuleConfig{
+			{Name: "no_stuck", Expr: "stuck_dropping_disk + stalled_dropping_disk == 0"},
+		},
+	})
+	require.NoError(t, err)
+	closeCh := make(chan interface{})
+	defer close(closeCh)
+	go testService.alerter.loopPost(closeCh)
+
+	testService.evaluateAlerts(ctx)
+	testService.evaluateAlerts(ctx)
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(received) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	lock.Lock()
+	require.Equal(t, 1, len(received))
+	require.Equal(t, "no_stuck", received[0].Rule)
+	require.Equal(t, testService.ClusterID, received[0].ClusterID)
+	require.Equal(t, clustermgr.AlertStatusFiring, received[0].Status)
+	lock.Unlock()
+
+	ret, err = testClusterClient.ListAlert(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ret.Alerts))
+	require.Equal(t, "no_stuck", ret.Alerts[0].Rule)
+}
+
+func TestAlertPostQueue(t *testing.T) {
+	a, err := newAlerter(&AlertConfig{Rules: []AlertRuleConfig{{Name: "rule", Expr: "free_ratio < 10%"}}})
+	require.NoError(t, err)
+	// nothing to post without webhook
+	for i := 0; i <= alertPostQueueSize; i++ {
+		require.True(t, a.send([]clustermgr.AlertEvent{{Rule: "rule"}}))
+	}
+	require.Equal(t, 0, len(a.posts))
+
+	a.webhooks = []string{"http://127.0.0.1:0"}
+	for i := 0; i < alertPostQueueSize; i++ {
+		require.True(t, a.send([]clustermgr.AlertEvent{{Rule: "rule"}}))
+	}
+	require.False(t, a.send([]clustermgr.AlertEvent{{Rule: "rule"}}))
+}
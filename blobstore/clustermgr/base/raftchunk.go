@@ -0,0 +1,302 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package base
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+// ChunkModule is the reserved module name of chunked proposals
+const ChunkModule = "#chunk"
+
+const (
+	chunkOperAppend int32 = iota + 1
+	chunkOperAbort
+)
+
+// ErrChunkedProposalDiscarded the chunks of proposal are discarded before all chunks applied,
+// none of the proposal takes effect, the client may retry it.
+var ErrChunkedProposalDiscarded = errors.New("chunked proposal discarded")
+
+// proposalChunk is one of the sub proposals split from an oversized proposal,
+// the chunks of one proposal share the same operation id.
+type proposalChunk struct {
+	OpID    string
+	Seq     uint32
+	Total   uint32
+	Payload []byte
+}
+
+func (c *proposalChunk) Marshal() []byte {
+	w := bytes.NewBuffer(make([]byte, 0, 4+len(c.OpID)+8+len(c.Payload)))
+	binary.Write(w, binary.BigEndian, int32(len(c.OpID)))
+	w.WriteString(c.OpID)
+	binary.Write(w, binary.BigEndian, c.Seq)
+	binary.Write(w, binary.BigEndian, c.Total)
+	w.Write(c.Payload)
+	return w.Bytes()
+}
+
+func (c *proposalChunk) Unmarshal(raw []byte) error {
+	if len(raw) < 4 {
+		return errors.New("invalid proposal chunk")
+	}
+	idSize := int(binary.BigEndian.Uint32(raw))
+	if idSize < 0 || len(raw) < 4+idSize+8 {
+		return errors.New("invalid proposal chunk")
+	}
+	raw = raw[4:]
+	c.OpID = string(raw[:idSize])
+	raw = raw[idSize:]
+	c.Seq = binary.BigEndian.Uint32(raw)
+	c.Total = binary.BigEndian.Uint32(raw[4:])
+	c.Payload = raw[8:]
+	if c.Seq >= c.Total {
+		return fmt.Errorf("invalid proposal chunk seq %d of total %d", c.Seq, c.Total)
+	}
+	return nil
+}
+
+// splitProposal splits the proposal data into chunks not larger than chunkSize
+func splitProposal(opID string, data []byte, chunkSize int) []proposalChunk {
+	total := (len(data) + chunkSize - 1) / chunkSize
+	chunks := make([]proposalChunk, 0, total)
+	for seq := 0; seq < total; seq++ {
+		end := (seq + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, proposalChunk{
+			OpID:    opID,
+			Seq:     uint32(seq),
+			Total:   uint32(total),
+			Payload: data[seq*chunkSize : end],
+		})
+	}
+	return chunks
+}
+
+type chunkedProposal struct {
+	total uint32
+	// startIndex is the apply index before the first chunk,
+	// the stable apply index should not exceed it until the proposal finished.
+	startIndex uint64
+	startTime  time.Time
+	payloads   [][]byte
+}
+
+// proposalChunks stages the applied chunks in memory until all chunks of proposal
+// applied, then the assembled proposal is applied at once. The stable apply index is
+// held before the first staged chunk, so the staged chunks are replayed from raft log
+// after restart or snapshot.
+//
+// The chunks of one proposal are proposed in serial by one node, so a proposal is
+// discarded if its chunks are out of order or the chunks of a newer proposal start.
+type proposalChunks struct {
+	sync.Mutex
+	pending map[string]*chunkedProposal
+	// results of the proposals proposed by this node
+	results map[string]error
+
+	proposeMu sync.Mutex
+	opSeq     uint64
+}
+
+func (p *proposalChunks) init() {
+	p.pending = make(map[string]*chunkedProposal)
+	p.results = make(map[string]error)
+}
+
+func (p *proposalChunks) nextOpID(nodeID uint64) string {
+	return fmt.Sprintf("%d-%d-%d", nodeID, time.Now().UnixNano(), atomic.AddUint64(&p.opSeq, 1))
+}
+
+func (p *proposalChunks) register(opID string) {
+	p.Lock()
+	p.results[opID] = ErrChunkedProposalDiscarded
+	p.Unlock()
+}
+
+func (p *proposalChunks) result(opID string) error {
+	p.Lock()
+	defer p.Unlock()
+	err := p.results[opID]
+	delete(p.results, opID)
+	return err
+}
+
+// discardLocked discards the staged chunks of proposal, must hold the lock
+func (p *proposalChunks) discardLocked(opID string) {
+	delete(p.pending, opID)
+	if _, ok := p.results[opID]; ok {
+		p.results[opID] = ErrChunkedProposalDiscarded
+	}
+}
+
+// apply stages the chunk, returns the assembled proposal if all chunks applied.
+func (p *proposalChunks) apply(ctx context.Context, operType int32, raw []byte, applyIndex uint64) ([]byte, error) {
+	span := trace.SpanFromContextSafe(ctx)
+	chunk := &proposalChunk{}
+	if err := chunk.Unmarshal(raw); err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if operType == chunkOperAbort {
+		span.Warnf("abort chunked proposal %s", chunk.OpID)
+		p.discardLocked(chunk.OpID)
+		return nil, nil
+	}
+	if operType != chunkOperAppend {
+		return nil, fmt.Errorf("unsupported chunk oper type %d", operType)
+	}
+
+	prop, ok := p.pending[chunk.OpID]
+	if chunk.Seq == 0 {
+		for opID := range p.pending {
+			span.Warnf("discard chunked proposal %s by newer %s", opID, chunk.OpID)
+			p.discardLocked(opID)
+		}
+		prop = &chunkedProposal{
+			total:      chunk.Total,
+			startIndex: applyIndex,
+			startTime:  time.Now(),
+			payloads:   make([][]byte, 0, chunk.Total),
+		}
+		p.pending[chunk.OpID] = prop
+	} else if !ok || prop.total != chunk.Total || int(chunk.Seq) != len(prop.payloads) {
+		span.Warnf("discard chunk %d/%d of proposal %s, staged: %v", chunk.Seq, chunk.Total, chunk.OpID, ok)
+		p.discardLocked(chunk.OpID)
+		return nil, nil
+	}
+	prop.payloads = append(prop.payloads, chunk.Payload)
+	if len(prop.payloads) < int(prop.total) {
+		return nil, nil
+	}
+
+	delete(p.pending, chunk.OpID)
+	if _, ok := p.results[chunk.OpID]; ok {
+		p.results[chunk.OpID] = nil
+	}
+	return bytes.Join(prop.payloads, nil), nil
+}
+
+// holdIndex returns the apply index which the stable apply index should not exceed
+func (p *proposalChunks) holdIndex() (uint64, bool) {
+	p.Lock()
+	defer p.Unlock()
+	index, ok := uint64(0), false
+	for _, prop := range p.pending {
+		if !ok || prop.startIndex < index {
+			index, ok = prop.startIndex, true
+		}
+	}
+	return index, ok
+}
+
+// stale returns the proposals staged longer than timeout
+func (p *proposalChunks) stale(timeout time.Duration) []string {
+	p.Lock()
+	defer p.Unlock()
+	var opIDs []string
+	for opID, prop := range p.pending {
+		if time.Since(prop.startTime) > timeout {
+			opIDs = append(opIDs, opID)
+		}
+	}
+	return opIDs
+}
+
+func (p *proposalChunks) reset() {
+	p.Lock()
+	for opID := range p.pending {
+		p.discardLocked(opID)
+	}
+	p.Unlock()
+}
+
+// IsChunkProposal returns true if the proposal is a chunk of oversized proposal
+func IsChunkProposal(info *ProposeInfo) bool {
+	return info.Module == ChunkModule
+}
+
+// ApplyChunk applies the chunk of oversized proposal, returns the assembled proposal
+// data when the last chunk applied, or nil if the proposal is not finished or discarded.
+func (r *RaftNode) ApplyChunk(ctx context.Context, info *ProposeInfo) ([]byte, error) {
+	return r.chunks.apply(ctx, info.OperType, info.Data, r.GetCurrentApplyIndex())
+}
+
+// proposeChunked splits the oversized proposal into chunks and proposes them in serial,
+// the proposal takes effect after the last chunk applied. The applied chunks are aborted
+// if any chunk failed to propose.
+func (r *RaftNode) proposeChunked(ctx context.Context, data []byte) error {
+	span := trace.SpanFromContextSafe(ctx)
+	r.chunks.proposeMu.Lock()
+	defer r.chunks.proposeMu.Unlock()
+
+	opID := r.chunks.nextOpID(r.Status().Id)
+	chunks := splitProposal(opID, data, r.MaxProposalSize)
+	span.Infof("propose %d bytes in %d chunks, operation: %s", len(data), len(chunks), opID)
+
+	r.chunks.register(opID)
+	for i := range chunks {
+		proposal := EncodeProposeInfo(ChunkModule, chunkOperAppend, chunks[i].Marshal(), ProposeContext{ReqID: span.TraceID()})
+		if err := r.RaftServer.Propose(ctx, proposal); err != nil {
+			r.chunks.result(opID)
+			span.Warnf("propose chunk %d/%d of %s failed: %s", i, len(chunks), opID, err.Error())
+			r.abortChunked(ctx, opID)
+			return err
+		}
+	}
+	return r.chunks.result(opID)
+}
+
+// abortChunked proposes to discard the staged chunks of proposal
+func (r *RaftNode) abortChunked(ctx context.Context, opID string) {
+	span := trace.SpanFromContextSafe(ctx)
+	ctx, cancel := context.WithTimeout(trace.ContextWithSpan(context.Background(), span),
+		time.Duration(r.ChunkPendingTimeoutS)*time.Second)
+	defer cancel()
+
+	chunk := &proposalChunk{OpID: opID, Total: 1}
+	proposal := EncodeProposeInfo(ChunkModule, chunkOperAbort, chunk.Marshal(), ProposeContext{ReqID: span.TraceID()})
+	if err := r.RaftServer.Propose(ctx, proposal); err != nil {
+		span.Warnf("abort chunked proposal %s failed: %s", opID, err.Error())
+	}
+}
+
+// abortStaleChunks aborts the proposals which are not finished in time, like the leader
+// crashed in halfway, only leader proposes the abort.
+func (r *RaftNode) abortStaleChunks(ctx context.Context) {
+	if _, ok := r.chunks.holdIndex(); !ok {
+		return
+	}
+	if !r.IsLeader() {
+		return
+	}
+	for _, opID := range r.chunks.stale(time.Duration(r.ChunkPendingTimeoutS) * time.Second) {
+		r.abortChunked(ctx, opID)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package base
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/raftserver"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
+)
+
+func TestProposalChunk(t *testing.T) {
+	data := []byte(strings.Repeat("abc", 10))
+	chunks := splitProposal("op", data, 7)
+	require.Equal(t, 5, len(chunks))
+	require.Equal(t, 2, len(chunks[4].Payload))
+
+	for i := range chunks {
+		decoded := &proposalChunk{}
+		require.NoError(t, decoded.Unmarshal(chunks[i].Marshal()))
+		require.Equal(t, chunks[i], *decoded)
+	}
+	require.Error(t, (&proposalChunk{}).Unmarshal([]byte{0, 0}))
+	require.Error(t, (&proposalChunk{}).Unmarshal((&proposalChunk{OpID: "op", Seq: 1, Total: 1}).Marshal()))
+}
+
+func TestProposalChunksApply(t *testing.T) {
+	ctx := context.Background()
+	p := &proposalChunks{}
+	p.init()
+	apply := func(c proposalChunk, index uint64) []byte {
+		ret, err := p.apply(ctx, chunkOperAppend, c.Marshal(), index)
+		require.NoError(t, err)
+		return ret
+	}
+
+	data := []byte(strings.Repeat("abc", 10))
+	chunks := splitProposal("op1", data, 8)
+	p.register("op1")
+	for i := range chunks[:len(chunks)-1] {
+		require.Nil(t, apply(chunks[i], uint64(10+i)))
+		index, ok := p.holdIndex()
+		require.True(t, ok)
+		require.Equal(t, uint64(10), index)
+	}
+	require.Equal(t, data, apply(chunks[len(chunks)-1], 20))
+	require.NoError(t, p.result("op1"))
+	_, ok := p.holdIndex()
+	require.False(t, ok)
+
+	// out of order chunk discards the proposal
+	p.register("op2")
+	chunks = splitProposal("op2", data, 8)
+	require.Nil(t, apply(chunks[0], 30))
+	require.Nil(t, apply(chunks[2], 31))
+	require.Nil(t, apply(chunks[3], 32))
+	require.ErrorIs(t, p.result("op2"), ErrChunkedProposalDiscarded)
+	_, ok = p.holdIndex()
+	require.False(t, ok)
+
+	// newer proposal discards the staged one
+	require.Nil(t, apply(splitProposal("op3", data, 8)[0], 40))
+	chunks = splitProposal("op4", data, 16)
+	require.Nil(t, apply(chunks[0], 41))
+	require.Equal(t, data, apply(chunks[1], 42))
+
+	// abort
+	require.Nil(t, apply(splitProposal("op5", data, 8)[0], 50))
+	require.Equal(t, 1, len(p.stale(0)))
+	ret, err := p.apply(ctx, chunkOperAbort, (&proposalChunk{OpID: "op5", Total: 1}).Marshal(), 51)
+	require.NoError(t, err)
+	require.Nil(t, ret)
+	require.Equal(t, 0, len(p.stale(0)))
+
+	_, err = p.apply(ctx, 100, chunks[0].Marshal(), 60)
+	require.Error(t, err)
+}
+
+func TestRaftNodeProposeChunked(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRaftServer := mocks.NewMockRaftServer(ctrl)
+	mockRaftServer.EXPECT().Status().AnyTimes().Return(raftserver.Status{Id: 1})
+
+	raftNode := &RaftNode{RaftNodeConfig: &RaftNodeConfig{MaxProposalSize: 64, ChunkPendingTimeoutS: 1}}
+	raftNode.chunks.init()
+	raftNode.SetRaftServer(mockRaftServer)
+
+	var applied [][]byte
+	applyIndex := uint64(0)
+	mockRaftServer.EXPECT().Propose(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, data []byte) error {
+			applyIndex++
+			info := DecodeProposeInfo(data)
+			require.True(t, IsChunkProposal(info))
+			assembled, err := raftNode.ApplyChunk(ctx, info)
+			require.NoError(t, err)
+			if assembled != nil {
+				applied = append(applied, assembled)
+			}
+			raftNode.currentApplyIndex = applyIndex
+			return nil
+		})
+
+	proposal := EncodeProposeInfo("TestModule", 1, []byte(strings.Repeat("a", 300)), ProposeContext{ReqID: "req"})
+	require.NoError(t, raftNode.proposeChunked(ctx, proposal))
+	require.Equal(t, [][]byte{proposal}, applied)
+	info := DecodeProposeInfo(applied[0])
+	require.Equal(t, "TestModule", info.Module)
+	require.Equal(t, "req", info.Context.ReqID)
+
+	// stable apply index is held before the staged chunks
+	_, err := raftNode.ApplyChunk(ctx, DecodeProposeInfo(EncodeProposeInfo(ChunkModule, chunkOperAppend,
+		splitProposal("x", proposal, 64)[0].Marshal(), ProposeContext{})))
+	require.NoError(t, err)
+	require.Equal(t, applyIndex, raftNode.holdStableApplyIndex(applyIndex+10))
+
+	// failed chunk aborts the staged chunks
+	errPropose := errors.New("propose failed")
+	mockRaftServer = mocks.NewMockRaftServer(ctrl)
+	mockRaftServer.EXPECT().Status().AnyTimes().Return(raftserver.Status{Id: 1})
+	raftNode.SetRaftServer(mockRaftServer)
+	gomock.InOrder(
+		mockRaftServer.EXPECT().Propose(gomock.Any(), gomock.Any()).Return(nil),
+		mockRaftServer.EXPECT().Propose(gomock.Any(), gomock.Any()).Return(errPropose),
+		mockRaftServer.EXPECT().Propose(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, data []byte) error {
+				require.Equal(t, chunkOperAbort, DecodeProposeInfo(data).OperType)
+				return nil
+			}),
+	)
+	require.ErrorIs(t, raftNode.proposeChunked(ctx, proposal), errPropose)
+	require.Equal(t, 0, len(raftNode.chunks.results))
+}
@@ -29,7 +29,9 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/blobstore/clustermgr/persistence/raftdb"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/kvstore"
+	kvstorev2 "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
 	"github.com/cubefs/cubefs/blobstore/common/raftserver"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/util/errors"
@@ -42,6 +44,12 @@ type RaftNodeConfig struct {
 	NodeProtocol        string       `json:"node_protocol"`
 	Members             []RaftMember `json:"members"`
 	ApplyFlush          bool         `json:"apply_flush"`
+	// interval of checking write stall of dbs, proposals are rejected when writes stopped
+	WriteStallCheckIntervalMs int `json:"write_stall_check_interval_ms"`
+	// proposals larger than it are split into chunks, and applied after all chunks applied
+	MaxProposalSize int `json:"max_proposal_size"`
+	// staged chunks of proposal are aborted by leader if not finished in time
+	ChunkPendingTimeoutS int `json:"chunk_pending_timeout_s"`
 
 	ApplyIndex uint64 `json:"-"`
 }
@@ -75,6 +83,8 @@ type RaftNode struct {
 	closeCh     chan interface{}
 	raftDB      *raftdb.RaftDB
 	snapshotDBs map[string]SnapshotDB
+	writeStall  *kvstorev2.WriteStallDetector
+	chunks      proposalChunks
 
 	raftserver.RaftServer
 	*RaftNodeConfig
@@ -90,6 +100,12 @@ func NewRaftNode(cfg *RaftNodeConfig, raftDB *raftdb.RaftDB, snapshotDBs map[str
 	if cfg.TruncateNumInterval == 0 {
 		cfg.TruncateNumInterval = defaultTruncateNumInterval
 	}
+	if cfg.MaxProposalSize <= 0 {
+		cfg.MaxProposalSize = defaultMaxProposalSize
+	}
+	if cfg.ChunkPendingTimeoutS <= 0 {
+		cfg.ChunkPendingTimeoutS = defaultChunkPendingTimeoutS
+	}
 
 	raftNode := &RaftNode{
 		snapshotDBs:    snapshotDBs,
@@ -106,6 +122,9 @@ func NewRaftNode(cfg *RaftNodeConfig, raftDB *raftdb.RaftDB, snapshotDBs map[str
 
 		nodes: make(map[uint64]string),
 	}
+	raftNode.chunks.init()
+	raftNode.writeStall = kvstorev2.NewWriteStallDetector(time.Duration(cfg.WriteStallCheckIntervalMs)*time.Millisecond,
+		raftNode.probeWriteStall, nil)
 
 	members, err := raftNode.GetRaftMembers(context.Background())
 	if err != nil {
@@ -156,6 +175,31 @@ func (r *RaftNode) SetRaftServer(raftServer raftserver.RaftServer) {
 	r.RaftServer = raftServer
 }
 
+// Propose proposes data into raft, returns ErrWriteStall if writes of dbs are stopped
+// and the proposal can't be applied in time, client should retry later.
+// The proposal larger than MaxProposalSize is split into chunks, see proposeChunked
+func (r *RaftNode) Propose(ctx context.Context, data []byte) error {
+	if state := r.writeStall.State(); state == kvstorev2.WriteStallStopped {
+		trace.SpanFromContextSafe(ctx).Warnf("reject proposal as write stall: %s", state)
+		return apierrors.ErrWriteStall
+	}
+	if len(data) > r.MaxProposalSize {
+		return r.proposeChunked(ctx, data)
+	}
+	return r.RaftServer.Propose(ctx, data)
+}
+
+// probeWriteStall returns the worst write stall state of all dbs
+func (r *RaftNode) probeWriteStall() kvstorev2.WriteStallState {
+	state := kvstorev2.WriteStallNone
+	for _, db := range r.snapshotDBs {
+		if s := kvstorev2.ProbeWriteStall(db.GetDB()); s > state {
+			state = s
+		}
+	}
+	return state
+}
+
 // registRaftApplier use reflect to find out all RaftApplier and register
 func (r *RaftNode) RegistRaftApplier(target interface{}) {
 	// reflect all mgr's method, get the Applies and regist
@@ -455,6 +499,8 @@ func (r *RaftNode) ApplyRaftSnapshot(ctx context.Context, st raftserver.Snapshot
 		span.Errorf("ApplyRaftSnapshot read unexpected error, err: %v", err)
 		return err
 	}
+	// staged chunks before snapshot are useless
+	r.chunks.reset()
 	// applier LoadData callback
 	for _, applier := range r.appliers {
 		if err := applier.LoadData(ctx); err != nil {
@@ -468,12 +514,15 @@ func (r *RaftNode) ApplyRaftSnapshot(ctx context.Context, st raftserver.Snapshot
 
 func (r *RaftNode) Start() {
 	span, ctx := trace.StartSpanFromContextWithTraceID(context.Background(), "", "raft-node-loop")
+	r.writeStall.Run()
+	defer r.writeStall.Close()
 	ticker := time.NewTicker(time.Duration(defaultFlushCheckIntervalS) * time.Second)
 	lastFlushTime := time.Now()
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
+			r.abortStaleChunks(ctx)
 			now := time.Now()
 			current := atomic.LoadUint64(&r.currentApplyIndex)
 			stable := r.GetStableApplyIndex()
@@ -536,6 +585,7 @@ func (r *RaftNode) Stop() {
 }
 
 func (r *RaftNode) saveStableApplyIndex(new uint64) error {
+	new = r.holdStableApplyIndex(new)
 	old := atomic.LoadUint64(&r.stableApplyIndex)
 	if old >= new {
 		return nil
@@ -563,6 +613,7 @@ func (r *RaftNode) saveStableApplyIndex(new uint64) error {
 }
 
 func (r *RaftNode) saveStableApplyIndexAndMembers(new uint64, members []RaftMember) error {
+	new = r.holdStableApplyIndex(new)
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
@@ -584,6 +635,15 @@ func (r *RaftNode) saveStableApplyIndexAndMembers(new uint64, members []RaftMemb
 	return nil
 }
 
+// holdStableApplyIndex holds the stable apply index before the staged chunks,
+// so the staged chunks can be replayed from raft log after restart
+func (r *RaftNode) holdStableApplyIndex(new uint64) uint64 {
+	if index, ok := r.chunks.holdIndex(); ok && index < new {
+		return index
+	}
+	return new
+}
+
 // FlushAll will call all applier's flush method and record flush_apply_index into persistent storage
 func (r *RaftNode) flushAll(ctx context.Context) error {
 	wg := sync.WaitGroup{}
@@ -26,6 +26,9 @@ const (
 	defaultFlushTimeIntervalS  = 300
 	defaultFlushCheckIntervalS = 2
 	defaultTruncateNumInterval = uint64(50000)
+	// raft MaxSizePerMsg is 64MB, keep proposals far below it
+	defaultMaxProposalSize      = 8 << 20
+	defaultChunkPendingTimeoutS = 60
 )
 
 var (
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/blobstore/clustermgr/persistence/raftdb"
+	kvstorev2 "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/testing/mocks"
 
@@ -117,6 +118,13 @@ func TestRaftNode(t *testing.T) {
 		raftNode.RegistRaftApplier(&testTarget{TestModule: applier})
 	}
 
+	// propose without write stall
+	{
+		mockRaftSever.EXPECT().Propose(gomock.Any(), gomock.Any()).Return(nil)
+		require.Equal(t, kvstorev2.WriteStallNone, raftNode.probeWriteStall())
+		require.NoError(t, raftNode.Propose(ctx, []byte("data")))
+	}
+
 	// apply index
 	{
 		index := raftNode.GetStableApplyIndex()
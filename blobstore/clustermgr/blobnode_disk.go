@@ -15,7 +15,9 @@
 package clustermgr
 
 import (
+	"context"
 	"encoding/json"
+	"sync/atomic"
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
@@ -123,7 +125,12 @@ func (s *Service) DiskList(c *rpc.Context) {
 		c.RespondError(apierrors.ErrIllegalArguments)
 		return
 	}
-	if args.Marker != proto.InvalidDiskID {
+	if args.SortBy != "" && !args.SortBy.IsValid() {
+		span.Warnf("invalid sort by: %s", args.SortBy)
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	if args.SortBy == "" && args.Marker != proto.InvalidDiskID {
 		if _, err := s.BlobNodeMgr.GetDiskInfo(ctx, args.Marker); err != nil {
 			span.Warnf("invalid marker, marker disk not exist")
 			err = apierrors.ErrIllegalArguments
@@ -135,6 +142,22 @@ func (s *Service) DiskList(c *rpc.Context) {
 		args.Count = 10
 	}
 
+	if args.SortBy != "" {
+		disks, cursor, err := s.BlobNodeMgr.ListDiskInfoSorted(ctx, args)
+		if err != nil {
+			if err == apierrors.ErrIllegalArguments {
+				span.Warnf("invalid cursor: %s", args.Cursor)
+				c.RespondError(err)
+				return
+			}
+			span.Errorf("list sorted disk info failed =>", errors.Detail(err))
+			c.RespondError(errors.Info(apierrors.ErrUnexpected).Detail(err))
+			return
+		}
+		c.RespondJSON(&clustermgr.ListDiskRet{Disks: disks, Cursor: cursor})
+		return
+	}
+
 	disks, marker, err := s.BlobNodeMgr.ListDiskInfo(ctx, args)
 	if err != nil {
 		span.Errorf("list disk info failed =>", errors.Detail(err))
@@ -202,6 +225,71 @@ func (s *Service) DiskSet(c *rpc.Context) {
 	}
 }
 
+// DiskSetCheck reports if the disk status could be changed without proposing it,
+// setting dropped is checked by the steps of dropping:
+// the disk is added into dropping list at first, then set dropped after its units migrated.
+func (s *Service) DiskSetCheck(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.DiskSetArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept DiskSetCheck request, args: %v", args)
+
+	if !args.Status.IsValid() {
+		c.RespondError(apierrors.ErrInvalidStatus)
+		return
+	}
+	diskInfo, err := s.BlobNodeMgr.GetDiskInfo(ctx, args.DiskID)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+	isDropping, err := s.BlobNodeMgr.IsDroppingDisk(ctx, args.DiskID)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	ret := &clustermgr.DiskSetCheckRet{
+		DiskID:   args.DiskID,
+		Status:   diskInfo.Status,
+		Dropping: isDropping,
+		Allowed:  true,
+	}
+	if err = s.checkDiskSetStatus(ctx, diskInfo, isDropping, args.Status); err != nil {
+		ret.Allowed = false
+		ret.Reason = err.Error()
+	}
+	c.RespondJSON(ret)
+}
+
+func (s *Service) checkDiskSetStatus(ctx context.Context, diskInfo *clustermgr.BlobNodeDiskInfo,
+	isDropping bool, status proto.DiskStatus,
+) error {
+	if diskInfo.Status == status {
+		return nil
+	}
+	if status != proto.DiskStatusDropped {
+		return s.BlobNodeMgr.SetStatus(ctx, diskInfo.DiskID, status, false)
+	}
+
+	// same as DiskDrop, only normal and readonly disk can add into dropping list
+	if !isDropping {
+		if diskInfo.Status != proto.DiskStatusNormal || !diskInfo.Readonly {
+			return apierrors.ErrDiskAbnormalOrNotReadOnly
+		}
+		return nil
+	}
+	// same as DiskDropped, all volume units on the disk should be migrated
+	if vuids := s.VolumeMgr.ListVolumeUnitsOnDisk(ctx, diskInfo.DiskID); len(vuids) != 0 {
+		return apierrors.ErrDroppedDiskHasVolumeUnit
+	}
+	return nil
+}
+
 func (s *Service) DiskDrop(c *rpc.Context) {
 	ctx := c.Request.Context()
 	span := trace.SpanFromContextSafe(ctx)
@@ -252,13 +340,9 @@ func (s *Service) DiskDropped(c *rpc.Context) {
 	}
 
 	// 2. check if disk's chunk has been removed
-	volumeUnits, err := s.VolumeMgr.ListVolumeUnitInfo(ctx, &clustermgr.ListVolumeUnitArgs{DiskID: args.DiskID})
-	if err != nil {
-		c.RespondError(err)
-		return
-	}
-	if len(volumeUnits) != 0 {
-		span.Warnf("disk: %d still has existing volume unit, %v", args.DiskID, volumeUnits)
+	vuids := s.VolumeMgr.ListVolumeUnitsOnDisk(ctx, args.DiskID)
+	if len(vuids) != 0 {
+		span.Warnf("disk: %d still has existing volume unit, %v", args.DiskID, vuids)
 		c.RespondError(apierrors.ErrDroppedDiskHasVolumeUnit)
 		return
 	}
@@ -311,6 +395,7 @@ func (s *Service) DiskHeartbeat(c *rpc.Context) {
 	}
 
 	heartbeatDisks := make([]*clustermgr.DiskHeartBeatInfo, 0)
+	deltas := make([]cluster.DiskHeartbeatDelta, 0)
 	disks := make([]*clustermgr.DiskHeartbeatRet, len(args.Disks))
 	for i := range args.Disks {
 		info, err := s.BlobNodeMgr.GetDiskInfo(ctx, args.Disks[i].DiskID)
@@ -334,6 +419,7 @@ func (s *Service) DiskHeartbeat(c *rpc.Context) {
 		}
 		if !frequentHeartBeat {
 			heartbeatDisks = append(heartbeatDisks, args.Disks[i])
+			deltas = append(deltas, cluster.NewDiskHeartbeatDelta(&info.DiskHeartBeatInfo, args.Disks[i]))
 		} else {
 			span.Warnf("disk %d heartbeat too frequent", args.Disks[i].DiskID)
 		}
@@ -345,7 +431,13 @@ func (s *Service) DiskHeartbeat(c *rpc.Context) {
 	}
 
 	args.Disks = heartbeatDisks
-	data, err := json.Marshal(args)
+	operType := int32(cluster.OperTypeHeartbeatDiskInfo)
+	var proposeArgs interface{} = args
+	if s.HeartbeatDelta && !s.needFullHeartbeat(heartbeatDisks) {
+		operType = cluster.OperTypeHeartbeatDiskDelta
+		proposeArgs = &cluster.DisksHeartbeatDeltaArgs{Disks: deltas}
+	}
+	data, err := json.Marshal(proposeArgs)
 	span.Debugf("heartbeat params: %s", string(data))
 	if err != nil {
 		span.Errorf("heartbeat args: %v, error: %v", args, err)
@@ -353,7 +445,7 @@ func (s *Service) DiskHeartbeat(c *rpc.Context) {
 		c.RespondError(err)
 		return
 	}
-	proposeInfo := base.EncodeProposeInfo(s.BlobNodeMgr.GetModuleName(), cluster.OperTypeHeartbeatDiskInfo, data, base.ProposeContext{ReqID: span.TraceID()})
+	proposeInfo := base.EncodeProposeInfo(s.BlobNodeMgr.GetModuleName(), operType, data, base.ProposeContext{ReqID: span.TraceID()})
 	err = s.raftNode.Propose(ctx, proposeInfo)
 	if err != nil {
 		span.Error(err)
@@ -363,6 +455,30 @@ func (s *Service) DiskHeartbeat(c *rpc.Context) {
 	c.RespondJSON(ret)
 }
 
+// needFullHeartbeat returns true if any disk heartbeats the first time since leader changed,
+// or heartbeats every N times, the full heartbeat corrects memory of disks diverged by delta
+func (s *Service) needFullHeartbeat(disks []*clustermgr.DiskHeartBeatInfo) bool {
+	interval := uint32(defaultHeartbeatFullIntervalN)
+	if s.HeartbeatFullIntervalN > 0 {
+		interval = uint32(s.HeartbeatFullIntervalN)
+	}
+	full := false
+	for _, disk := range disks {
+		v, loaded := s.heartbeatCounts.LoadOrStore(disk.DiskID, new(uint32))
+		if cnt := atomic.AddUint32(v.(*uint32), 1); !loaded || cnt%interval == 0 {
+			full = true
+		}
+	}
+	return full
+}
+
+func (s *Service) resetHeartbeatCounts() {
+	s.heartbeatCounts.Range(func(key, _ interface{}) bool {
+		s.heartbeatCounts.Delete(key)
+		return true
+	})
+}
+
 func (s *Service) DiskAccess(c *rpc.Context) {
 	ctx := c.Request.Context()
 	span := trace.SpanFromContextSafe(ctx)
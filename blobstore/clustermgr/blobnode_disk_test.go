@@ -135,6 +135,27 @@ func TestDisk(t *testing.T) {
 		err = testClusterClient.SetDisk(ctx, 1, proto.DiskStatusBroken)
 		require.NoError(t, err)
 
+		// check set disk without changing status
+		checkRet, err := testClusterClient.CheckSetDisk(ctx, 1, proto.DiskStatusNormal)
+		require.NoError(t, err)
+		require.Equal(t, proto.DiskStatusBroken, checkRet.Status)
+		require.False(t, checkRet.Allowed)
+		require.NotEmpty(t, checkRet.Reason)
+		checkRet, err = testClusterClient.CheckSetDisk(ctx, 1, proto.DiskStatusRepairing)
+		require.NoError(t, err)
+		require.True(t, checkRet.Allowed)
+		checkRet, err = testClusterClient.CheckSetDisk(ctx, 1, proto.DiskStatusRepaired)
+		require.NoError(t, err)
+		require.False(t, checkRet.Allowed)
+		checkRet, err = testClusterClient.CheckSetDisk(ctx, 1, proto.DiskStatusDropped)
+		require.NoError(t, err)
+		require.False(t, checkRet.Allowed)
+		_, err = testClusterClient.CheckSetDisk(ctx, 99, proto.DiskStatusBroken)
+		require.Error(t, err)
+		disk1, err = testClusterClient.DiskInfo(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, proto.DiskStatusBroken, disk1.Status)
+
 		// setDisk failed case
 		err = testClusterClient.SetDisk(ctx, 1, 0)
 		require.Error(t, err)
@@ -183,10 +204,26 @@ func TestDisk(t *testing.T) {
 		err := testClusterClient.DropDisk(ctx, 2)
 		require.Error(t, err)
 
+		checkRet, err := testClusterClient.CheckSetDisk(ctx, 2, proto.DiskStatusDropped)
+		require.NoError(t, err)
+		require.False(t, checkRet.Allowed)
+
 		err = testClusterClient.SetReadonlyDisk(ctx, 2, true)
 		require.NoError(t, err)
+		checkRet, err = testClusterClient.CheckSetDisk(ctx, 2, proto.DiskStatusDropped)
+		require.NoError(t, err)
+		require.True(t, checkRet.Allowed)
+		require.False(t, checkRet.Dropping)
 		err = testClusterClient.DropDisk(ctx, 2)
 		require.NoError(t, err)
+
+		checkRet, err = testClusterClient.CheckSetDisk(ctx, 2, proto.DiskStatusBroken)
+		require.NoError(t, err)
+		require.False(t, checkRet.Allowed)
+		require.True(t, checkRet.Dropping)
+		checkRet, err = testClusterClient.CheckSetDisk(ctx, 2, proto.DiskStatusDropped)
+		require.NoError(t, err)
+		require.True(t, checkRet.Allowed)
 		ret, err := testClusterClient.ListDroppingDisk(ctx)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(ret))
@@ -312,3 +349,21 @@ func TestDisk(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestDiskHeartbeatFull(t *testing.T) {
+	s := &Service{Config: &Config{HeartbeatFullIntervalN: 3}}
+	disks := []*clustermgr.DiskHeartBeatInfo{{DiskID: 1}, {DiskID: 2}}
+
+	// first heartbeat is full
+	require.True(t, s.needFullHeartbeat(disks))
+	require.False(t, s.needFullHeartbeat(disks))
+	require.True(t, s.needFullHeartbeat(disks))
+	require.False(t, s.needFullHeartbeat(disks[:1]))
+	// new disk
+	require.True(t, s.needFullHeartbeat([]*clustermgr.DiskHeartBeatInfo{{DiskID: 3}}))
+
+	// full after leader changed
+	s.resetHeartbeatCounts()
+	require.True(t, s.needFullHeartbeat(disks[:1]))
+	require.False(t, s.needFullHeartbeat(disks[:1]))
+}
@@ -107,11 +107,105 @@ func (c *CatalogMgr) GetCatalogChanges(ctx context.Context, args *clustermgr.Get
 	return ret, nil
 }
 
+// GetRouteChecksum returns checksum of current catalog route, all spaces share the shards of catalog.
+// The checksum is xor of route hashes of all shards, it's updated by changed shards of route items
+// since last calculated, and recalculated with all shards if the route items have been truncated.
+func (c *CatalogMgr) GetRouteChecksum(ctx context.Context, args *clustermgr.GetRouteChecksumArgs) (*clustermgr.GetRouteChecksumRet, error) {
+	if _, err := c.GetSpaceInfoByID(ctx, args.SpaceID); err != nil {
+		return nil, err
+	}
+
+	r := &c.routeMgr.checksum
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	version := proto.RouteVersion(c.routeMgr.getRouteVersion())
+	if r.hashes == nil || version != r.version {
+		if !c.updateRouteChecksum(ctx, r) {
+			c.calculateRouteChecksum(ctx, r, version)
+		}
+	}
+	return &clustermgr.GetRouteChecksumRet{
+		SpaceID:      args.SpaceID,
+		RouteVersion: r.version,
+		Checksum:     r.checksum,
+	}, nil
+}
+
+// updateRouteChecksum updates checksum with changed shards since last version,
+// returns false if it can't be updated incrementally
+func (c *CatalogMgr) updateRouteChecksum(ctx context.Context, r *routeChecksum) bool {
+	if r.hashes == nil {
+		return false
+	}
+	items, _ := c.routeMgr.getRouteItems(ctx, r.version)
+	if len(items) == 0 {
+		return false
+	}
+
+	hashes := make(map[proto.ShardID]uint64, len(items))
+	for _, item := range items {
+		var shardID proto.ShardID
+		switch detail := item.ItemDetail.(type) {
+		case *routeItemShardAdd:
+			shardID = detail.ShardID
+		case *routeItemShardUpdate:
+			shardID = detail.SuidPrefix.ShardID()
+		default:
+			return false
+		}
+		shard := c.allShards.getShard(shardID)
+		if shard == nil {
+			return false
+		}
+		hashes[shardID] = shardRouteHash(shard)
+	}
+
+	for shardID, hash := range hashes {
+		r.checksum ^= r.hashes[shardID] ^ hash
+		r.hashes[shardID] = hash
+	}
+	r.version = items[len(items)-1].RouteVersion
+	return true
+}
+
+func (c *CatalogMgr) calculateRouteChecksum(ctx context.Context, r *routeChecksum, version proto.RouteVersion) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	r.hashes = make(map[proto.ShardID]uint64, c.allShards.getShardNum())
+	r.checksum = 0
+	c.allShards.rangeShard(func(shard *shardItem) error {
+		hash := shardRouteHash(shard)
+		r.hashes[shard.shardID] = hash
+		r.checksum ^= hash
+		return nil
+	})
+	r.version = version
+	span.Infof("calculate route checksum of %d shards, version: %d", len(r.hashes), version)
+}
+
+func shardRouteHash(shard *shardItem) (hash uint64) {
+	shard.withRLocked(func() error {
+		hash = clustermgr.ShardRouteHash(shard.shardID, shard.info.RouteVersion, shard.info.Units)
+		return nil
+	})
+	return
+}
+
+// routeChecksum checksum of route at version, and route hash of every shard
+type routeChecksum struct {
+	lock     sync.Mutex
+	version  proto.RouteVersion
+	checksum uint64
+	hashes   map[proto.ShardID]uint64
+}
+
 type routeMgr struct {
 	truncateIntervalNum  uint32
 	unstableRouteVersion proto.RouteVersion
 	stableRouteVersion   proto.RouteVersion
 	increments           *routeItemRing
+	checksum             routeChecksum
 	done                 chan struct{}
 	lock                 sync.RWMutex
 
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/persistence/catalogdb"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/util/log"
@@ -182,3 +183,43 @@ func TestCatalogMgr_Route(t *testing.T) {
 	require.Equal(t, 0, len(ret.Items))
 	require.Equal(t, proto.RouteVersion(0), ret.RouteVersion)
 }
+
+func TestCatalogMgr_RouteChecksum(t *testing.T) {
+	mockCatalogMgr, clean := initMockCatalogMgr(t, testConfig)
+	defer clean()
+	_, ctx := trace.StartSpanFromContext(context.Background(), "checksum")
+
+	checksum := func() (ret uint64) {
+		mockCatalogMgr.allShards.rangeShard(func(shard *shardItem) error {
+			ret ^= shardRouteHash(shard)
+			return nil
+		})
+		return
+	}
+
+	_, err := mockCatalogMgr.GetRouteChecksum(ctx, &clustermgr.GetRouteChecksumArgs{SpaceID: 100})
+	require.ErrorIs(t, err, apierrors.ErrSpaceNotFound)
+
+	ret, err := mockCatalogMgr.GetRouteChecksum(ctx, &clustermgr.GetRouteChecksumArgs{SpaceID: 1})
+	require.NoError(t, err)
+	require.Equal(t, proto.SpaceID(1), ret.SpaceID)
+	require.Equal(t, proto.RouteVersion(11), ret.RouteVersion)
+	require.Equal(t, checksum(), ret.Checksum)
+
+	// updated by the changed shard
+	err = mockCatalogMgr.applyUpdateShardUnit(ctx, proto.EncodeSuid(2, 1, 2), 4, false)
+	require.NoError(t, err)
+	ret2, err := mockCatalogMgr.GetRouteChecksum(ctx, &clustermgr.GetRouteChecksumArgs{SpaceID: 2})
+	require.NoError(t, err)
+	require.Equal(t, proto.RouteVersion(12), ret2.RouteVersion)
+	require.Equal(t, checksum(), ret2.Checksum)
+	require.NotEqual(t, ret.Checksum, ret2.Checksum)
+	require.Equal(t, 10, len(mockCatalogMgr.routeMgr.checksum.hashes))
+
+	// recalculated if route items of the version has been truncated
+	mockCatalogMgr.routeMgr.checksum.version = 0
+	mockCatalogMgr.routeMgr.checksum.checksum = 0
+	ret, err = mockCatalogMgr.GetRouteChecksum(ctx, &clustermgr.GetRouteChecksumArgs{SpaceID: 2})
+	require.NoError(t, err)
+	require.Equal(t, *ret2, *ret)
+}
@@ -53,6 +53,37 @@ func (c *CatalogMgr) ListShardUnitInfo(ctx context.Context, args *cmapi.ListShar
 	return ret, nil
 }
 
+// ListPlacedUnits returns disks of units grouped by az of the shards which have any unit on the disk
+func (c *CatalogMgr) ListPlacedUnits(ctx context.Context, diskID proto.DiskID) ([]cluster.PlacedUnits, error) {
+	unitPrefixes, err := c.catalogTbl.ListShardUnit(diskID)
+	if err != nil {
+		return nil, errors.Info(err, "list shardUnit from tbl failed")
+	}
+
+	tactic := c.CodeMode.Tactic()
+	azs := tactic.GetECLayoutByAZ()
+	ret := make([]cluster.PlacedUnits, 0, len(unitPrefixes))
+	for _, unitPrefix := range unitPrefixes {
+		shard := c.allShards.getShard(unitPrefix.ShardID())
+		if shard == nil {
+			continue
+		}
+		shard.withRLocked(func() error {
+			units := cluster.PlacedUnits{ID: uint64(shard.shardID), AZDisks: make([][]proto.DiskID, len(azs))}
+			for az, idxes := range azs {
+				for _, idx := range idxes {
+					if idx < len(shard.info.Units) {
+						units.AZDisks[az] = append(units.AZDisks[az], shard.info.Units[idx].DiskID)
+					}
+				}
+			}
+			ret = append(ret, units)
+			return nil
+		})
+	}
+	return ret, nil
+}
+
 func (c *CatalogMgr) AllocShardUnit(ctx context.Context, args *cmapi.AllocShardUnitArgs) (*cmapi.AllocShardUnitRet, error) {
 	span := trace.SpanFromContextSafe(ctx)
 	suid := args.Suid
@@ -120,6 +120,8 @@ func (c *CatalogMgr) CreateSpace(ctx context.Context, args *clustermgr.CreateSpa
 		FieldMetas: args.FieldMetas,
 		AccKey:     makeKey(),
 		SecKey:     makeKey(),
+
+		WormRetentionSecs: args.WormRetentionSecs,
 	}
 	data, err := json.Marshal(spaceInfo)
 	if err != nil {
@@ -194,6 +196,8 @@ func spaceInfoToSpaceRecord(info *clustermgr.Space) *catalogdb.SpaceInfoRecord {
 		FieldMetas: info.FieldMetas,
 		AccessKey:  info.AccKey,
 		SecretKey:  info.SecKey,
+
+		WormRetentionSecs: info.WormRetentionSecs,
 	}
 }
 
@@ -205,6 +209,8 @@ func spaceRecordToSpaceInfo(record *catalogdb.SpaceInfoRecord) *clustermgr.Space
 		FieldMetas: record.FieldMetas,
 		AccKey:     record.AccessKey,
 		SecKey:     record.SecretKey,
+
+		WormRetentionSecs: record.WormRetentionSecs,
 	}
 }
 
@@ -101,3 +101,14 @@ func TestCatalogMgr_Space(t *testing.T) {
 	err = mockCatalogMgr.CreateSpace(ctx, args)
 	require.Error(t, err)
 }
+
+func TestSpaceRecordWormRetention(t *testing.T) {
+	info := &clustermgr.Space{
+		SpaceID:           1,
+		Name:              "worm",
+		WormRetentionSecs: 3600,
+	}
+	record := spaceInfoToSpaceRecord(info)
+	require.Equal(t, info.WormRetentionSecs, record.WormRetentionSecs)
+	require.Equal(t, info.WormRetentionSecs, spaceRecordToSpaceInfo(record).WormRetentionSecs)
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+const (
+	defaultUsageReconcileIntervalS = 600
+	defaultUsageReportExpireS      = 3600
+	defaultUsageTolerancePercent   = 5
+	defaultUsageToleranceBytes     = 10 << 30
+
+	maxChunkUsageReportDisks = 1024
+)
+
+// UsageReconcileConfig reconciles the heartbeat free space of blobnode disks with the
+// sum of chunk usage reported by blobnode, reconciliation is disabled if IntervalS < 0.
+// The disk is mismatched if the difference exceeds both of the tolerances.
+type UsageReconcileConfig struct {
+	IntervalS        int     `json:"interval_s"`
+	ReportExpireS    int     `json:"report_expire_s"`
+	TolerancePercent float64 `json:"tolerance_percent"` // percent of disk size
+	ToleranceBytes   int64   `json:"tolerance_bytes"`
+}
+
+type diskChunkUsage struct {
+	chunks     int
+	allocated  uint64
+	used       uint64
+	shardCount uint64
+	reportTime int64
+}
+
+// chunkUsages holds the chunk usage reports and mismatched disks on leader in memory,
+// the new leader reconciles after blobnode reported again.
+type chunkUsages struct {
+	sync.Mutex
+	disks      map[proto.DiskID]diskChunkUsage
+	mismatches []clustermgr.DiskUsageMismatch
+}
+
+func newChunkUsages() *chunkUsages {
+	return &chunkUsages{disks: make(map[proto.DiskID]diskChunkUsage)}
+}
+
+// ChunkUsageReport accept chunk usage of disks reported by blobnode
+func (s *Service) ChunkUsageReport(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.ReportChunkUsageArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept ChunkUsageReport request, disks: %d", len(args.Disks))
+	if len(args.Disks) > maxChunkUsageReportDisks {
+		span.Warnf("too many disks of chunk usage report: %d", len(args.Disks))
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+
+	now := time.Now().Unix()
+	s.chunkUsages.Lock()
+	for i := range args.Disks {
+		allocated, used, shardCount := args.Disks[i].Sum()
+		s.chunkUsages.disks[args.Disks[i].DiskID] = diskChunkUsage{
+			chunks:     len(args.Disks[i].Chunks),
+			allocated:  allocated,
+			used:       used,
+			shardCount: shardCount,
+			reportTime: now,
+		}
+	}
+	s.chunkUsages.Unlock()
+}
+
+// DiskUsageMismatchList returns the mismatched disks of the last reconciliation on leader
+func (s *Service) DiskUsageMismatchList(c *rpc.Context) {
+	if !s.raftNode.IsLeader() {
+		s.forwardToLeader(c.Writer, c.Request)
+		return
+	}
+	s.chunkUsages.Lock()
+	disks := append([]clustermgr.DiskUsageMismatch{}, s.chunkUsages.mismatches...)
+	s.chunkUsages.Unlock()
+	c.RespondJSON(&clustermgr.ListDiskUsageMismatchRet{Disks: disks})
+}
+
+// reconcileDiskUsage flags the normal disks whose heartbeat free space disagrees with
+// the sum of chunk usage, expected free space is disk size minus used of all chunks.
+func (s *Service) reconcileDiskUsage(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+	cfg := s.UsageReconcileConfig
+	now := time.Now().Unix()
+
+	s.chunkUsages.Lock()
+	usages := make(map[proto.DiskID]diskChunkUsage, len(s.chunkUsages.disks))
+	for diskID, usage := range s.chunkUsages.disks {
+		if now-usage.reportTime > int64(cfg.ReportExpireS) {
+			delete(s.chunkUsages.disks, diskID)
+			continue
+		}
+		usages[diskID] = usage
+	}
+	s.chunkUsages.Unlock()
+
+	mismatches := make([]clustermgr.DiskUsageMismatch, 0)
+	for diskID, usage := range usages {
+		info, err := s.BlobNodeMgr.GetDiskInfo(ctx, diskID)
+		if err != nil {
+			span.Warnf("get disk info %d failed, err: %v", diskID, err)
+			continue
+		}
+		if info.Status != proto.DiskStatusNormal {
+			continue
+		}
+
+		diff := info.Size - int64(usage.used) - info.Free
+		tolerance := int64(float64(info.Size) * cfg.TolerancePercent / 100)
+		if tolerance < cfg.ToleranceBytes {
+			tolerance = cfg.ToleranceBytes
+		}
+		if diff <= tolerance && diff >= -tolerance {
+			continue
+		}
+		mismatch := clustermgr.DiskUsageMismatch{
+			DiskID:         diskID,
+			Host:           info.Host,
+			Size:           info.Size,
+			HeartbeatFree:  info.Free,
+			ChunkCount:     usage.chunks,
+			ChunkAllocated: usage.allocated,
+			ChunkUsed:      usage.used,
+			ShardCount:     usage.shardCount,
+			Diff:           diff,
+			ReportTime:     usage.reportTime,
+		}
+		span.Warnf("disk usage mismatch: %+v", mismatch)
+		mismatches = append(mismatches, mismatch)
+	}
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].DiskID < mismatches[j].DiskID
+	})
+
+	s.chunkUsages.Lock()
+	s.chunkUsages.mismatches = mismatches
+	s.chunkUsages.Unlock()
+	s.reportDiskUsageMismatch(float64(len(mismatches)))
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestChunkUsageReconcile(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	insertNodeInfos(t, testClusterClient, 0, 0, testService.IDC[0])
+	insertDiskInfos(t, testClusterClient, 1, 3, testService.IDC[0])
+
+	ret, err := testClusterClient.ListDiskUsageMismatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(ret.Disks))
+
+	// heartbeat free space of test disks is the size
+	args := &clustermgr.ReportChunkUsageArgs{Disks: []clustermgr.DiskChunkUsage{
+		{DiskID: 1, Chunks: []clustermgr.ChunkUsage{
+			{Vuid: proto.EncodeVuid(proto.EncodeVuidPrefix(1, 0), 1), Allocated: 16 << 30, Used: 1 << 30, ShardCount: 10},
+		}},
+		{DiskID: 2, Chunks: []clustermgr.ChunkUsage{
+			{Vuid: proto.EncodeVuid(proto.EncodeVuidPrefix(2, 0), 1), Allocated: 16 << 30, Used: 1 << 40, ShardCount: 100},
+			{Vuid: proto.EncodeVuid(proto.EncodeVuidPrefix(3, 0), 1), Allocated: 16 << 30, Used: 1 << 30, ShardCount: 1},
+		}},
+		{DiskID: 99},
+	}}
+	require.NoError(t, testClusterClient.ReportChunkUsage(ctx, args))
+
+	testService.reconcileDiskUsage(ctx)
+	ret, err = testClusterClient.ListDiskUsageMismatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ret.Disks))
+	mismatch := ret.Disks[0]
+	require.Equal(t, proto.DiskID(2), mismatch.DiskID)
+	require.Equal(t, 2, mismatch.ChunkCount)
+	require.Equal(t, uint64(32<<30), mismatch.ChunkAllocated)
+	require.Equal(t, uint64(1<<40+1<<30), mismatch.ChunkUsed)
+	require.Equal(t, uint64(101), mismatch.ShardCount)
+	require.Equal(t, int64(1<<40+1<<30), mismatch.Diff)
+
+	// expired reports are not reconciled
+	testService.UsageReconcileConfig.ReportExpireS = -1
+	testService.reconcileDiskUsage(ctx)
+	ret, err = testClusterClient.ListDiskUsageMismatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(ret.Disks))
+}
@@ -75,6 +75,12 @@ type allocRet struct {
 // Alloc alloc disk id
 // todo: add retry when diskset alloc failed or idc alloc failed
 func (a *allocator) Alloc(ctx context.Context, diskType proto.DiskType, mode codemode.CodeMode, excludes []proto.DiskSetID) ([]allocRet, error) {
+	ret, _, err := a.alloc(ctx, diskType, mode, excludes)
+	return ret, err
+}
+
+// alloc returns the failed step of allocation as reason if error occurred
+func (a *allocator) alloc(ctx context.Context, diskType proto.DiskType, mode codemode.CodeMode, excludes []proto.DiskSetID) ([]allocRet, string, error) {
 	span := trace.SpanFromContextSafe(ctx)
 	var (
 		err        error
@@ -87,19 +93,19 @@ func (a *allocator) Alloc(ctx context.Context, diskType proto.DiskType, mode cod
 	nodeSetAllocator, err := a.allocNodeSet(ctx, diskType, mode)
 	if err != nil {
 		span.Errorf("alloc nodeset failed, err: %s", err.Error())
-		return nil, err
+		return nil, "alloc nodeset", err
 	}
 	// alloc diskset
 	diskSetAllocator, err := nodeSetAllocator.allocDiskSet(ctx, allocCount, excludes)
 	if err != nil {
 		span.Errorf("alloc diskset failed, err: %s", err.Error())
-		return nil, err
+		return nil, "alloc diskset", err
 	}
 
 	idcAllocators := diskSetAllocator.alloc(ctx, len(idcIndexes[0]))
 	if len(idcAllocators) < len(idcIndexes) {
 		span.Errorf("need %d idcAllocators, but got %d", len(idcIndexes), len(idcAllocators))
-		return nil, ErrNoEnoughSpace
+		return nil, "alloc idc", ErrNoEnoughSpace
 	}
 
 	for i := range idcIndexes {
@@ -107,7 +113,7 @@ func (a *allocator) Alloc(ctx context.Context, diskType proto.DiskType, mode cod
 		_disks, _err := idcAllocators[i].alloc(ctx, count, nil)
 		if _err != nil {
 			span.Errorf("alloc from idc allocator failed, err:%s", _err.Error())
-			return nil, _err
+			return nil, "alloc disk in idc " + idcAllocators[i].idc, _err
 		}
 
 		ret = append(ret, allocRet{
@@ -119,7 +125,7 @@ func (a *allocator) Alloc(ctx context.Context, diskType proto.DiskType, mode cod
 	atomic.AddInt64(&diskSetAllocator.weight, -int64(allocCount))
 	atomic.AddInt64(&nodeSetAllocator.weight, -int64(allocCount))
 
-	return ret, nil
+	return ret, "", nil
 }
 
 type reAllocPolicy struct {
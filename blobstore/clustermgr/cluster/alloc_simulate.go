@@ -0,0 +1,184 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// MaxSimulateAllocCount max volume count of one allocation simulation
+const MaxSimulateAllocCount = 10000
+
+// simulateCloner copies allocators and disks, every disk is copied only once
+// as the disk may be shared by diskset allocator and ec diskset allocator
+type simulateCloner struct {
+	dg    clusterInfoGetter
+	disks map[*diskItem]*diskItem
+}
+
+func cloneExtraInfo(extraInfo interface{}) interface{} {
+	switch info := extraInfo.(type) {
+	case *clustermgr.DiskHeartBeatInfo:
+		copied := *info
+		return &copied
+	case *clustermgr.ShardNodeDiskHeartbeatInfo:
+		copied := *info
+		return &copied
+	default:
+		return extraInfo
+	}
+}
+
+func (c *simulateCloner) disk(src *diskItem) *diskItem {
+	if disk, ok := c.disks[src]; ok {
+		return disk
+	}
+	disk := &diskItem{diskID: src.diskID}
+	src.withRLocked(func() error {
+		disk.info = diskItemInfo{DiskInfo: src.info.DiskInfo, extraInfo: cloneExtraInfo(src.info.extraInfo)}
+		disk.expireTime = src.expireTime
+		disk.lastExpireTime = src.lastExpireTime
+		disk.dropping = src.dropping
+		disk.weightGetter = src.weightGetter
+		disk.weightDecrease = src.weightDecrease
+		return nil
+	})
+	// locate disk by its node like allocator generating
+	if node, ok := c.dg.getNode(disk.info.NodeID); ok {
+		node.withRLocked(func() error {
+			disk.info.Idc = node.info.Idc
+			disk.info.Rack = node.info.Rack
+			disk.info.Host = node.info.Host
+			return nil
+		})
+	}
+	c.disks[src] = disk
+	return disk
+}
+
+func (c *simulateCloner) nodeAllocators(srcs []*nodeAllocator, nodes map[*nodeAllocator]*nodeAllocator) []*nodeAllocator {
+	ret := make([]*nodeAllocator, 0, len(srcs))
+	for _, src := range srcs {
+		node, ok := nodes[src]
+		if !ok {
+			node = &nodeAllocator{
+				host:   src.host,
+				weight: atomic.LoadInt64(&src.weight),
+				free:   src.free,
+				disks:  make([]*diskItem, 0, len(src.disks)),
+			}
+			for _, disk := range src.disks {
+				node.disks = append(node.disks, c.disk(disk))
+			}
+			nodes[src] = node
+		}
+		ret = append(ret, node)
+	}
+	return ret
+}
+
+func (c *simulateCloner) diskSetAllocator(src *diskSetAllocator) *diskSetAllocator {
+	idcAllocators := make(map[string]*idcAllocator, len(src.idcAllocators))
+	for idc, srcIdc := range src.idcAllocators {
+		nodes := make(map[*nodeAllocator]*nodeAllocator)
+		idcAllocator := &idcAllocator{
+			idc:          srcIdc.idc,
+			weight:       atomic.LoadInt64(&srcIdc.weight),
+			diffRack:     srcIdc.diffRack,
+			diffHost:     srcIdc.diffHost,
+			rackStorages: make(map[string]*rackAllocator, len(srcIdc.rackStorages)),
+			nodeStorages: c.nodeAllocators(srcIdc.nodeStorages, nodes),
+		}
+		for rack, srcRack := range srcIdc.rackStorages {
+			idcAllocator.rackStorages[rack] = &rackAllocator{
+				rack:         srcRack.rack,
+				weight:       atomic.LoadInt64(&srcRack.weight),
+				nodeStorages: c.nodeAllocators(srcRack.nodeStorages, nodes),
+			}
+		}
+		idcAllocators[idc] = idcAllocator
+	}
+	return newDiskSetAllocator(src.diskSetID, atomic.LoadInt64(&src.weight), idcAllocators)
+}
+
+// clone returns a deep copy of allocator, allocation on the copy never changes the cluster
+func (a *allocator) clone() (*allocator, *simulateCloner) {
+	cloner := &simulateCloner{dg: a.cfg.dg, disks: make(map[*diskItem]*diskItem)}
+	cfg := a.cfg
+	cfg.nodeSets = make(map[proto.DiskType]nodeSetAllocatorMap, len(a.nodeSets))
+	cfg.diskSets = make(map[proto.DiskType]diskSetAllocatorMap, len(a.diskSets))
+	for diskType, diskSets := range a.diskSets {
+		cfg.diskSets[diskType] = make(diskSetAllocatorMap, len(diskSets))
+		for id, diskSet := range diskSets {
+			cfg.diskSets[diskType][id] = cloner.diskSetAllocator(diskSet)
+		}
+	}
+	for diskType, nodeSets := range a.nodeSets {
+		cfg.nodeSets[diskType] = make(nodeSetAllocatorMap, len(nodeSets))
+		for id, nodeSet := range nodeSets {
+			ns := newNodeSetAllocator(nodeSet.nodeSetID)
+			for diskSetID := range nodeSet.diskSets {
+				ns.addDiskSet(cfg.diskSets[diskType][diskSetID])
+			}
+			cfg.nodeSets[diskType][id] = ns
+		}
+	}
+	return newAllocator(cfg), cloner
+}
+
+// SimulateAllocVolumes simulates allocating chunks of count volumes on a copy of current allocator,
+// returns distribution of allocated chunks and failure reasons without committing anything
+func (b *BlobNodeManager) SimulateAllocVolumes(ctx context.Context, diskType proto.DiskType,
+	mode codemode.CodeMode, count int,
+) *clustermgr.AllocSimulateRet {
+	span := trace.SpanFromContextSafe(ctx)
+
+	allocator, cloner := b.allocator.Load().(*allocator).clone()
+	ret := &clustermgr.AllocSimulateRet{
+		Count:    count,
+		Idcs:     make(map[string]int),
+		Racks:    make(map[string]int),
+		Nodes:    make(map[string]int),
+		Failures: make(map[string]int),
+	}
+	for i := 0; i < count; i++ {
+		allocRets, reason, err := allocator.alloc(ctx, diskType, mode, nil)
+		if err != nil {
+			ret.Failures[reason+": "+err.Error()]++
+			continue
+		}
+		ret.Success++
+		for _, r := range allocRets {
+			for _, diskID := range r.Disks {
+				src, ok := b.getDisk(diskID)
+				if !ok {
+					continue
+				}
+				disk := cloner.disk(src)
+				ret.Idcs[disk.info.Idc]++
+				ret.Racks[disk.info.Idc+"-"+disk.info.Rack]++
+				ret.Nodes[disk.info.Host]++
+			}
+		}
+	}
+	span.Infof("simulate alloc %d volumes of codemode %s, success: %d, failures: %v", count, mode, ret.Success, ret.Failures)
+	return ret
+}
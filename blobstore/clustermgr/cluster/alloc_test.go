@@ -466,6 +466,49 @@ func TestAlloc(t *testing.T) {
 	}
 }
 
+func TestSimulateAllocVolumes(t *testing.T) {
+	testDiskMgr, closeTestDiskMgr := initTestBlobNodeMgr(t)
+	defer closeTestDiskMgr()
+	testDiskMgr.cfg.HeartbeatExpireIntervalS = 6000
+
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+	initTestBlobNodeMgrNodes(t, testDiskMgr, 1, 10, testIdcs...)
+	initTestBlobNodeMgrDisks(t, testDiskMgr, 1, 600, false, testIdcs...)
+	testDiskMgr.refresh(ctx)
+
+	weights := func() (ret []int64) {
+		a := testDiskMgr.manager.allocator.Load().(*allocator)
+		ret = append(ret, a.nodeSets[proto.DiskTypeHDD][ecNodeSetID].weight)
+		for _, idc := range testIdcs {
+			ret = append(ret, a.diskSets[proto.DiskTypeHDD][ecDiskSetID].idcAllocators[idc].weight)
+		}
+		for _, disk := range testDiskMgr.getAllDisk() {
+			ret = append(ret, disk.weight())
+		}
+		return
+	}
+	before := weights()
+
+	ret := testDiskMgr.SimulateAllocVolumes(ctx, proto.DiskTypeHDD, codemode.EC6P3, 10)
+	require.Equal(t, 10, ret.Count)
+	require.Equal(t, 10, ret.Success)
+	require.Equal(t, 0, len(ret.Failures))
+	for _, idc := range testIdcs {
+		require.Equal(t, 30, ret.Idcs[idc])
+	}
+	total := 0
+	for _, n := range ret.Nodes {
+		total += n
+	}
+	require.Equal(t, 90, total)
+	require.Equal(t, before, weights())
+
+	// disk type without disks
+	ret = testDiskMgr.SimulateAllocVolumes(ctx, proto.DiskTypeNVMeSSD, codemode.EC6P3, 1)
+	require.Equal(t, 0, ret.Success)
+	require.Equal(t, 1, ret.Failures["alloc nodeset: "+ErrNoEnoughSpace.Error()])
+}
+
 func TestAllocWithSameHost(t *testing.T) {
 	testDiskMgr, closeTestDiskMgr := initTestBlobNodeMgr(t)
 	defer closeTestDiskMgr()
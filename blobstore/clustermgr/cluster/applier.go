@@ -32,6 +32,9 @@ const (
 	OperTypeAddNode
 	OperTypeDroppingNode
 	OperTypeDroppedNode
+	OperTypeAdminRelabelNode
+	OperTypeHeartbeatDiskDelta
+	OperTypeRepairTopo
 )
 
 const synchronizedDiskID = 1
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -50,6 +51,8 @@ type BlobNodeManagerAPI interface {
 	ListDroppingDisk(ctx context.Context) ([]*clustermgr.BlobNodeDiskInfo, error)
 	// ListDiskInfo return disk list with list option
 	ListDiskInfo(ctx context.Context, opt *clustermgr.ListOptionArgs) (disks []*clustermgr.BlobNodeDiskInfo, marker proto.DiskID, err error)
+	// ListDiskInfoSorted return disk list sorted by the field of list option after the cursor
+	ListDiskInfoSorted(ctx context.Context, opt *clustermgr.ListOptionArgs) (disks []*clustermgr.BlobNodeDiskInfo, cursor string, err error)
 	// AllocChunks return available chunks in data center
 	AllocChunks(ctx context.Context, policy AllocPolicy) ([]proto.DiskID, []proto.Vuid, error)
 
@@ -65,6 +68,7 @@ func NewBlobNodeMgr(scopeMgr scopemgr.ScopeMgrAPI, db *normaldb.NormalDB, cfg Di
 	defaulter.LessOrEqual(&cfg.RefreshIntervalS, defaultRefreshIntervalS)
 	defaulter.LessOrEqual(&cfg.HeartbeatExpireIntervalS, defaultHeartbeatExpireIntervalS)
 	defaulter.LessOrEqual(&cfg.FlushIntervalS, defaultFlushIntervalS)
+	defaulter.LessOrEqual(&cfg.TopoCheckIntervalS, defaultTopoCheckIntervalS)
 	defaulter.LessOrEqual(&cfg.ApplyConcurrency, defaultApplyConcurrency)
 	if cfg.AllocTolerateBuffer >= 0 {
 		defaultAllocTolerateBuff = cfg.AllocTolerateBuffer
@@ -99,9 +103,9 @@ func NewBlobNodeMgr(scopeMgr scopemgr.ScopeMgrAPI, db *normaldb.NormalDB, cfg Di
 		scopeMgr:          scopeMgr,
 		persistentHandler: bm,
 
-		closeCh: make(chan interface{}),
-		cfg:     cfg,
+		cfg: cfg,
 	}
+	m.initLifecycle("blobnode_mgr")
 	bm.manager = m
 
 	// initial load data
@@ -119,11 +123,12 @@ func NewBlobNodeMgr(scopeMgr scopemgr.ScopeMgrAPI, db *normaldb.NormalDB, cfg Di
 			select {
 			case <-ticker.C:
 				bm.refresh(ctxNew)
-			case <-bm.closeCh:
+			case <-bm.lifecycle.Done():
 				return
 			}
 		}
 	}()
+	bm.startTopoCheck()
 
 	return bm, nil
 }
@@ -156,7 +161,7 @@ func (b *BlobNodeManager) Start() {
 			case <-ticker.C:
 				_, ctxNew := trace.StartSpanFromContext(context.Background(), "")
 				b.checkDroppingNode(ctxNew)
-			case <-b.closeCh:
+			case <-b.lifecycle.Done():
 				return
 			}
 		}
@@ -216,7 +221,7 @@ func (b *BlobNodeManager) ListDiskInfo(ctx context.Context, opt *clustermgr.List
 		opt.Count = defaultListDiskMaxCount
 	}
 
-	diskInfoDBs, err := b.diskTbl.ListDisk(opt)
+	diskInfoDBs, err := b.diskTbl.ListDiskWithFilter(opt, b.recordFilter(opt))
 	if err != nil {
 		span.Error("diskMgr ListDiskInfo failed, err: %v", err)
 		return nil, 0, errors.Info(err, "diskMgr ListDiskInfo failed").Detail(err)
@@ -225,9 +230,121 @@ func (b *BlobNodeManager) ListDiskInfo(ctx context.Context, opt *clustermgr.List
 	if len(diskInfoDBs) > 0 {
 		marker = diskInfoDBs[len(diskInfoDBs)-1].DiskID
 	}
+	disks = b.diskInfoRecordsToDiskInfos(diskInfoDBs)
+	if len(disks) == 0 {
+		marker = proto.InvalidDiskID
+	}
+
+	return disks, marker, nil
+}
+
+// ListDiskInfoSorted return disk info with specified query condition sorted by free space or create time
+func (b *BlobNodeManager) ListDiskInfoSorted(ctx context.Context, opt *clustermgr.ListOptionArgs) (disks []*clustermgr.BlobNodeDiskInfo, cursor string, err error) {
+	if opt == nil {
+		return nil, "", nil
+	}
+	span := trace.SpanFromContextSafe(ctx)
+
+	if opt.Count > defaultListDiskMaxCount {
+		opt.Count = defaultListDiskMaxCount
+	}
+
+	switch opt.SortBy {
+	case clustermgr.DiskSortByCreateTime:
+		var diskInfoDBs []*normaldb.BlobNodeDiskInfoRecord
+		diskInfoDBs, cursor, err = b.diskTbl.ListDiskByCreateTime(opt, b.recordFilter(opt))
+		if err != nil {
+			if errors.Is(err, normaldb.ErrInvalidListCursor) {
+				return nil, "", apierrors.ErrIllegalArguments
+			}
+			span.Error("diskMgr ListDiskInfoSorted failed, err: %v", err)
+			return nil, "", errors.Info(err, "diskMgr ListDiskInfoSorted failed").Detail(err)
+		}
+		return b.diskInfoRecordsToDiskInfos(diskInfoDBs), cursor, nil
+	case clustermgr.DiskSortByFree:
+		return b.listDiskInfoByFree(ctx, opt)
+	default:
+		return nil, "", apierrors.ErrIllegalArguments
+	}
+}
+
+// listDiskInfoByFree sort disks by free space in memory, as the free space changes with every heartbeat.
+// the cursor is free space and disk id of the last disk
+func (b *BlobNodeManager) listDiskInfoByFree(ctx context.Context, opt *clustermgr.ListOptionArgs) ([]*clustermgr.BlobNodeDiskInfo, string, error) {
+	var (
+		cursorFree int64
+		cursorID   proto.DiskID
+	)
+	if opt.Cursor != "" {
+		if _, err := fmt.Sscanf(opt.Cursor, "%d-%d", &cursorFree, &cursorID); err != nil {
+			return nil, "", apierrors.ErrIllegalArguments
+		}
+	}
+
+	filter := b.diskStateFilter(opt)
+	all := b.getAllDisk()
+	disks := make([]*clustermgr.BlobNodeDiskInfo, 0, len(all))
+	for _, disk := range all {
+		if filter != nil && !filter(disk.diskID) {
+			continue
+		}
+		diskInfo, err := b.GetDiskInfo(ctx, disk.diskID)
+		if err != nil || !matchListOption(opt, &diskInfo.DiskInfo) {
+			continue
+		}
+		disks = append(disks, diskInfo)
+	}
+
+	less := func(free1 int64, id1 proto.DiskID, free2 int64, id2 proto.DiskID) bool {
+		if free1 != free2 {
+			return free1 < free2
+		}
+		return id1 < id2
+	}
+	sort.Slice(disks, func(i, j int) bool {
+		if opt.Desc {
+			return less(disks[j].Free, disks[j].DiskID, disks[i].Free, disks[i].DiskID)
+		}
+		return less(disks[i].Free, disks[i].DiskID, disks[j].Free, disks[j].DiskID)
+	})
+	start := 0
+	if opt.Cursor != "" {
+		start = sort.Search(len(disks), func(i int) bool {
+			if opt.Desc {
+				return less(disks[i].Free, disks[i].DiskID, cursorFree, cursorID)
+			}
+			return less(cursorFree, cursorID, disks[i].Free, disks[i].DiskID)
+		})
+	}
+	end := start + opt.Count
+	if end > len(disks) {
+		end = len(disks)
+	}
+	disks = disks[start:end]
+
+	cursor := ""
+	if len(disks) > 0 {
+		last := disks[len(disks)-1]
+		cursor = fmt.Sprintf("%d-%d", last.Free, last.DiskID)
+	}
+	return disks, cursor, nil
+}
 
-	for i := range diskInfoDBs {
-		diskInfo := b.diskInfoRecordToDiskInfo(diskInfoDBs[i])
+func (b *BlobNodeManager) recordFilter(opt *clustermgr.ListOptionArgs) func(*normaldb.BlobNodeDiskInfoRecord) bool {
+	filter := b.diskStateFilter(opt)
+	if filter == nil {
+		return nil
+	}
+	return func(record *normaldb.BlobNodeDiskInfoRecord) bool {
+		return filter(record.DiskID)
+	}
+}
+
+// diskInfoRecordsToDiskInfos convert records to disk infos with the latest heartbeat info
+func (b *BlobNodeManager) diskInfoRecordsToDiskInfos(records []*normaldb.BlobNodeDiskInfoRecord) []*clustermgr.BlobNodeDiskInfo {
+	disks := make([]*clustermgr.BlobNodeDiskInfo, 0, len(records))
+	for i := range records {
+		diskInfo := b.diskInfoRecordToDiskInfo(records[i])
 		disk, _ := b.getDisk(diskInfo.DiskID)
 		disk.withRLocked(func() error {
 			heartbeatInfo := disk.info.extraInfo.(*clustermgr.DiskHeartBeatInfo)
@@ -242,11 +359,7 @@ func (b *BlobNodeManager) ListDiskInfo(ctx context.Context, opt *clustermgr.List
 		})
 		disks = append(disks, diskInfo)
 	}
-	if len(disks) == 0 {
-		marker = proto.InvalidDiskID
-	}
-
-	return disks, marker, nil
+	return disks
 }
 
 func (b *BlobNodeManager) AddDisk(ctx context.Context, args *clustermgr.BlobNodeDiskInfo) error {
@@ -697,6 +810,18 @@ func (b *BlobNodeManager) Apply(ctx context.Context, operTypes []int32, datas []
 				errs[idx] = b.applyHeartBeatDiskInfo(taskCtx, args.Disks)
 				wg.Done()
 			})
+		case OperTypeHeartbeatDiskDelta:
+			args := &DisksHeartbeatDeltaArgs{}
+			err := json.Unmarshal(datas[idx], args)
+			if err != nil {
+				errs[idx] = errors.Info(err, t, datas[idx]).Detail(err)
+				wg.Done()
+				continue
+			}
+			b.taskPool.Run(rand.Intn(int(b.cfg.ApplyConcurrency)), func() {
+				errs[idx] = b.applyHeartBeatDiskDelta(taskCtx, args.Disks)
+				wg.Done()
+			})
 		case OperTypeSwitchReadonly:
 			args := &clustermgr.DiskAccessArgs{}
 			err := json.Unmarshal(datas[i], args)
@@ -770,6 +895,29 @@ func (b *BlobNodeManager) Apply(ctx context.Context, operTypes []int32, datas []
 				}
 				wg.Done()
 			})
+		case OperTypeAdminRelabelNode:
+			args := &clustermgr.NodeRelabelArgs{}
+			err := json.Unmarshal(datas[idx], args)
+			if err != nil {
+				errs[idx] = errors.Info(err, t, datas[idx]).Detail(err)
+				wg.Done()
+				continue
+			}
+			// relabel node run on fixed goroutine synchronously, and refresh allocator after relabeling
+			b.taskPool.Run(b.getTaskIdx(synchronizedDiskID), func() {
+				errs[idx] = b.applyNodeRelabel(taskCtx, args)
+				if errs[idx] == nil {
+					b.refresh(taskCtx)
+				}
+				wg.Done()
+			})
+		case OperTypeRepairTopo:
+			// repair topo run on fixed goroutine synchronously, and refresh allocator after repairing
+			b.taskPool.Run(b.getTaskIdx(synchronizedDiskID), func() {
+				b.applyRepairTopo(taskCtx)
+				b.refresh(taskCtx)
+				wg.Done()
+			})
 		default:
 		}
 	}
@@ -1062,6 +1210,26 @@ func (b *blobNodePersistentHandler) droppedNode(id proto.NodeID) error {
 	return b.nodeTbl.DroppedNode(id)
 }
 
+func (b *blobNodePersistentHandler) getAllDiskAndNodeRecords() ([]*normaldb.DiskInfoRecord, []*normaldb.NodeInfoRecord, error) {
+	diskDBs, err := b.diskTbl.GetAllDisks()
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeDBs, err := b.nodeTbl.GetAllNodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	disks := make([]*normaldb.DiskInfoRecord, 0, len(diskDBs))
+	for _, disk := range diskDBs {
+		disks = append(disks, &disk.DiskInfoRecord)
+	}
+	nodes := make([]*normaldb.NodeInfoRecord, 0, len(nodeDBs))
+	for _, node := range nodeDBs {
+		nodes = append(nodes, &node.NodeInfoRecord)
+	}
+	return disks, nodes, nil
+}
+
 func blobNodeDiskWeightGetter(extraInfo interface{}) int64 {
 	info := extraInfo.(*clustermgr.DiskHeartBeatInfo)
 	freeChunk := info.FreeChunkCnt
@@ -4,7 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/testing/mocks"
 	"github.com/golang/mock/gomock"
@@ -21,8 +24,27 @@ func TestWritableSpace(t *testing.T) {
 			idcBlobNodeStgs[testDiskMgr.cfg.IDC[i]] = append(idcBlobNodeStgs[testDiskMgr.cfg.IDC[i]], &nodeAllocator{free: 100 * testDiskMgr.cfg.ChunkSize})
 		}
 	}
-	testDiskMgr.calculateWritable(idcBlobNodeStgs)
+	codeMode, _ := testDiskMgr.getMaxSuCount()
+	spaceInfo.WritableSpace = testDiskMgr.calculateWritable(idcBlobNodeStgs, codeMode)
 	t.Log("writable space: ", spaceInfo.WritableSpace)
+
+	// writable space of each code mode
+	for _, mode := range testDiskMgr.cfg.CodeModes {
+		writable := testDiskMgr.calculateWritable(idcBlobNodeStgs, mode)
+		require.Less(t, int64(0), writable)
+		require.Equal(t, int64(0), writable%(int64(mode.Tactic().N)*testDiskMgr.cfg.ChunkSize))
+		if mode == codeMode {
+			require.Equal(t, spaceInfo.WritableSpace, writable)
+		}
+	}
+
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+	initTestBlobNodeMgrNodes(t, testDiskMgr, 1, 1, testIdcs...)
+	initTestBlobNodeMgrDisks(t, testDiskMgr, 1, 10, false, testIdcs...)
+	testDiskMgr.refresh(ctx)
+	stat := testDiskMgr.Stat(ctx, proto.DiskTypeHDD)
+	require.Len(t, stat.CodeModeWritableSpace, len(testDiskMgr.cfg.CodeModes))
+	require.Equal(t, stat.WritableSpace, stat.CodeModeWritableSpace[codeMode.Name()])
 }
 
 func TestReadonlySpace(t *testing.T) {
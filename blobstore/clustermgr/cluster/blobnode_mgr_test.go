@@ -307,6 +307,59 @@ func TestDiskMgr_ListDisks(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 1, len(ret))
 	}
+
+	{
+		for i := 1; i <= 10; i++ {
+			disk, _ := testDiskMgr.getDisk(proto.DiskID(i))
+			disk.lock.Lock()
+			disk.info.extraInfo.(*clustermgr.DiskHeartBeatInfo).Free = int64(i % 5)
+			disk.dropping = i == 3
+			if i == 4 {
+				disk.expireTime = time.Now().Add(-time.Second)
+			}
+			disk.lock.Unlock()
+		}
+		listAll := func(opt *clustermgr.ListOptionArgs) (ids []proto.DiskID) {
+			for {
+				ret, cursor, err := testDiskMgr.ListDiskInfoSorted(ctx, opt)
+				require.NoError(t, err)
+				if len(ret) == 0 {
+					return
+				}
+				for _, disk := range ret {
+					ids = append(ids, disk.DiskID)
+				}
+				opt.Cursor = cursor
+			}
+		}
+
+		ids := listAll(&clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByFree, Count: 3})
+		require.Equal(t, []proto.DiskID{5, 10, 1, 6, 2, 7, 3, 8, 4, 9}, ids)
+		ids = listAll(&clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByFree, Count: 4, Desc: true})
+		require.Equal(t, []proto.DiskID{9, 4, 8, 3, 7, 2, 6, 1, 10, 5}, ids)
+
+		dropping, expired := true, true
+		ids = listAll(&clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByFree, Count: 3, Dropping: &dropping})
+		require.Equal(t, []proto.DiskID{3}, ids)
+		ids = listAll(&clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByCreateTime, Count: 3, HeartbeatExpired: &expired})
+		require.Equal(t, []proto.DiskID{4}, ids)
+		ids = listAll(&clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByCreateTime, Count: 3, Status: proto.DiskStatusBroken})
+		require.Equal(t, []proto.DiskID{1}, ids)
+		ids = listAll(&clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByCreateTime, Count: 3})
+		require.Equal(t, 10, len(ids))
+
+		dropping, expired = false, false
+		ret, _, err := testDiskMgr.ListDiskInfo(ctx, &clustermgr.ListOptionArgs{Count: 1000, Dropping: &dropping, HeartbeatExpired: &expired})
+		require.NoError(t, err)
+		require.Equal(t, 8, len(ret))
+
+		_, _, err = testDiskMgr.ListDiskInfoSorted(ctx, &clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByFree, Cursor: "x"})
+		require.ErrorIs(t, err, apierrors.ErrIllegalArguments)
+		_, _, err = testDiskMgr.ListDiskInfoSorted(ctx, &clustermgr.ListOptionArgs{SortBy: clustermgr.DiskSortByCreateTime, Cursor: "x"})
+		require.ErrorIs(t, err, apierrors.ErrIllegalArguments)
+		_, _, err = testDiskMgr.ListDiskInfoSorted(ctx, &clustermgr.ListOptionArgs{SortBy: "x"})
+		require.ErrorIs(t, err, apierrors.ErrIllegalArguments)
+	}
 }
 
 func TestDiskMgr_AdminUpdateDisk(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	clustermgr "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	normaldb "github.com/cubefs/cubefs/blobstore/clustermgr/persistence/normaldb"
 	proto "github.com/cubefs/cubefs/blobstore/common/proto"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -215,6 +216,22 @@ func (mr *MockBlobNodeManagerAPIMockRecorder) ListDiskInfo(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiskInfo", reflect.TypeOf((*MockBlobNodeManagerAPI)(nil).ListDiskInfo), arg0, arg1)
 }
 
+// ListDiskInfoSorted mocks base method.
+func (m *MockBlobNodeManagerAPI) ListDiskInfoSorted(arg0 context.Context, arg1 *clustermgr.ListOptionArgs) ([]*clustermgr.BlobNodeDiskInfo, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDiskInfoSorted", arg0, arg1)
+	ret0, _ := ret[0].([]*clustermgr.BlobNodeDiskInfo)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDiskInfoSorted indicates an expected call of ListDiskInfoSorted.
+func (mr *MockBlobNodeManagerAPIMockRecorder) ListDiskInfoSorted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiskInfoSorted", reflect.TypeOf((*MockBlobNodeManagerAPI)(nil).ListDiskInfoSorted), arg0, arg1)
+}
+
 // ListDroppingDisk mocks base method.
 func (m *MockBlobNodeManagerAPI) ListDroppingDisk(arg0 context.Context) ([]*clustermgr.BlobNodeDiskInfo, error) {
 	m.ctrl.T.Helper()
@@ -284,6 +301,20 @@ func (mr *MockBlobNodeManagerAPIMockRecorder) ValidateNodeInfo(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateNodeInfo", reflect.TypeOf((*MockBlobNodeManagerAPI)(nil).ValidateNodeInfo), arg0, arg1)
 }
 
+// ValidateNodeRelabel mocks base method.
+func (m *MockBlobNodeManagerAPI) ValidateNodeRelabel(arg0 context.Context, arg1 *clustermgr.NodeRelabelArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateNodeRelabel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateNodeRelabel indicates an expected call of ValidateNodeRelabel.
+func (mr *MockBlobNodeManagerAPIMockRecorder) ValidateNodeRelabel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateNodeRelabel", reflect.TypeOf((*MockBlobNodeManagerAPI)(nil).ValidateNodeRelabel), arg0, arg1)
+}
+
 // addDiskNoLocked mocks base method.
 func (m *MockBlobNodeManagerAPI) addDiskNoLocked(arg0 *diskItem) error {
 	m.ctrl.T.Helper()
@@ -354,6 +385,22 @@ func (mr *MockBlobNodeManagerAPIMockRecorder) droppedNode(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "droppedNode", reflect.TypeOf((*MockBlobNodeManagerAPI)(nil).droppedNode), arg0)
 }
 
+// getAllDiskAndNodeRecords mocks base method.
+func (m *MockBlobNodeManagerAPI) getAllDiskAndNodeRecords() ([]*normaldb.DiskInfoRecord, []*normaldb.NodeInfoRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getAllDiskAndNodeRecords")
+	ret0, _ := ret[0].([]*normaldb.DiskInfoRecord)
+	ret1, _ := ret[1].([]*normaldb.NodeInfoRecord)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// getAllDiskAndNodeRecords indicates an expected call of getAllDiskAndNodeRecords.
+func (mr *MockBlobNodeManagerAPIMockRecorder) getAllDiskAndNodeRecords() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getAllDiskAndNodeRecords", reflect.TypeOf((*MockBlobNodeManagerAPI)(nil).getAllDiskAndNodeRecords))
+}
+
 // isDroppingDisk mocks base method.
 func (m *MockBlobNodeManagerAPI) isDroppingDisk(arg0 proto.DiskID) (bool, error) {
 	m.ctrl.T.Helper()
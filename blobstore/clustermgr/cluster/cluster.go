@@ -27,12 +27,14 @@ import (
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/persistence/normaldb"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/scopemgr"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/raftserver"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/closer"
 	"github.com/cubefs/cubefs/blobstore/util/errors"
 )
 
@@ -45,6 +47,7 @@ const (
 	defaultRefreshIntervalS                = 300
 	defaultHeartbeatExpireIntervalS        = 60
 	defaultFlushIntervalS                  = 600
+	defaultTopoCheckIntervalS              = 3600
 	defaultListDiskMaxCount                = 200
 	defaultApplyConcurrency         uint32 = 10
 )
@@ -65,6 +68,7 @@ var (
 	ErrShardNodeCreateShardFailed = errors.New("shard node create shard failed")
 	ErrNodeExist                  = errors.New("node already exist")
 	ErrNodeNotExist               = errors.New("node not exist")
+	ErrTopoRepairNotLeader        = errors.New("topo can only be repaired by leader")
 )
 
 var validSetStatus = map[proto.DiskStatus]int{
@@ -95,6 +99,8 @@ type NodeManagerAPI interface {
 	GetHeartbeatChangeDisks() []HeartbeatEvent
 	// ValidateNodeInfo validate node info and return any validation error when validate fail
 	ValidateNodeInfo(ctx context.Context, info *clustermgr.NodeInfo) error
+	// ValidateNodeRelabel validate relabeling idc and rack of node and return any validation error
+	ValidateNodeRelabel(ctx context.Context, args *clustermgr.NodeRelabelArgs) error
 	CheckNodeInfoDuplicated(ctx context.Context, info *clustermgr.NodeInfo) (proto.NodeID, bool)
 	RefreshExpireTime()
 }
@@ -110,6 +116,18 @@ type persistentHandler interface {
 	isDroppingNode(id proto.NodeID) (bool, error)
 	droppedDisk(id proto.DiskID) error
 	droppedNode(id proto.NodeID) error
+	getAllDiskAndNodeRecords() ([]*normaldb.DiskInfoRecord, []*normaldb.NodeInfoRecord, error)
+}
+
+// PlacedUnits is the disks of units of a volume or shard, grouped by az of its code mode
+type PlacedUnits struct {
+	ID      uint64
+	AZDisks [][]proto.DiskID
+}
+
+// PlacedUnitsGetter lists placed units of volumes or shards which have any unit on the disk
+type PlacedUnitsGetter interface {
+	ListPlacedUnits(ctx context.Context, diskID proto.DiskID) ([]PlacedUnits, error)
 }
 
 //type Module struct {
@@ -133,6 +151,8 @@ type DiskMgrConfig struct {
 	ShardNodeConfig          shardnode.Config    `json:"shard_node_config"`
 	AllocTolerateBuffer      int64               `json:"alloc_tolerate_buffer"`
 	EnsureIndex              bool                `json:"ensure_index"`
+	TopoCheckIntervalS       int                 `json:"topo_check_interval_s"`
+	TopoAutoRepair           bool                `json:"topo_auto_repair"`
 	IDC                      []string            `json:"-"`
 	CodeModes                []codemode.CodeMode `json:"-"`
 	ChunkSize                int64               `json:"-"`
@@ -165,17 +185,24 @@ type manager struct {
 	raftServer        raftserver.RaftServer
 	scopeMgr          scopemgr.ScopeMgrAPI
 	persistentHandler persistentHandler
+	unitsGetter       PlacedUnitsGetter
 
 	lastFlushTime time.Time
 	spaceStatInfo atomic.Value
 	metaLock      sync.RWMutex
-	closeCh       chan interface{}
+	lifecycle     closer.Lifecycle
 	cfg           DiskMgrConfig
 }
 
+// initLifecycle the background loops of manager exit when the lifecycle is done,
+// and the task pool is closed after that.
+func (d *manager) initLifecycle(name string) {
+	d.lifecycle = closer.NewLifecycle(closer.Subsystem{Name: name})
+	d.lifecycle.Register(closer.Subsystem{Name: "task_pool", Stop: d.taskPool.Close})
+}
+
 func (d *manager) Close() {
-	close(d.closeCh)
-	d.taskPool.Close()
+	d.lifecycle.Close()
 }
 
 func (d *manager) RefreshExpireTime() {
@@ -194,6 +221,12 @@ func (d *manager) SetRaftServer(raftServer raftserver.RaftServer) {
 	d.raftServer = raftServer
 }
 
+// SetPlacedUnitsGetter sets the getter of units on disks, which is used to check placement of units
+// when relabeling node, it must be set before raft applying
+func (d *manager) SetPlacedUnitsGetter(getter PlacedUnitsGetter) {
+	d.unitsGetter = getter
+}
+
 func (d *manager) AllocDiskID(ctx context.Context) (proto.DiskID, error) {
 	_, diskID, err := d.scopeMgr.Alloc(ctx, d.cfg.DiskIDScopeName, 1)
 	if err != nil {
@@ -741,6 +774,208 @@ func (d *manager) applyDroppedNode(ctx context.Context, nodeID proto.NodeID) err
 	})
 }
 
+// ValidateNodeRelabel validate relabeling idc and rack of node, the idc must be one of cluster,
+// and the nodes of the same idc or rack in node set must not exceed the capacity after relabeling
+func (d *manager) ValidateNodeRelabel(ctx context.Context, args *clustermgr.NodeRelabelArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+	if args.Idc == "" && args.Rack == "" {
+		return apierrors.ErrIllegalArguments
+	}
+	if args.Idc != "" && !d.isValidIDC(args.Idc) {
+		span.Warnf("invalid idc %s, cluster idc: %v", args.Idc, d.cfg.IDC)
+		return apierrors.ErrIllegalArguments
+	}
+
+	node, ok := d.getNode(args.NodeID)
+	if !ok {
+		return apierrors.ErrCMNodeNotFound
+	}
+	var (
+		info    clustermgr.NodeInfo
+		diskIDs []proto.DiskID
+	)
+	err := node.withRLocked(func() error {
+		if !node.isUsingStatus() || node.dropping {
+			return apierrors.ErrCMNodeIsDropping
+		}
+		info = node.info.NodeInfo
+		diskIDs = make([]proto.DiskID, 0, len(node.disks))
+		for diskID := range node.disks {
+			diskIDs = append(diskIDs, diskID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	idc, rack := info.Idc, info.Rack
+	if args.Idc != "" {
+		idc = args.Idc
+	}
+	if args.Rack != "" {
+		rack = args.Rack
+	}
+	nodeSet := d.topoMgr.getNodeSet(info.DiskType, info.NodeSetID)
+	if nodeSet == nil {
+		return apierrors.ErrCMNodeSetNotFound
+	}
+
+	// the placement should be no worse than before relabeling,
+	// as the node set may exceed the capacity of rack when allocated without rack aware
+	exceeded := func(newLen, oldLen, capacity int) bool {
+		return newLen+1 > capacity && newLen+1 > oldLen
+	}
+	config := d.cfg.CopySetConfigs[info.DiskType]
+	oldIdcLen, oldRackLen := nodeSet.getNodeSetIDCAndRackLen(info.Idc, info.Rack)
+	newIdcLen, newRackLen := nodeSet.getNodeSetIDCAndRackLen(idc, rack)
+	if idc != info.Idc && exceeded(newIdcLen, oldIdcLen, config.NodeSetIdcCap) {
+		span.Warnf("node set %d idc %s exceeds capacity %d", info.NodeSetID, idc, config.NodeSetIdcCap)
+		return apierrors.ErrIllegalArguments
+	}
+	if d.cfg.RackAware && rack != info.Rack && exceeded(newRackLen, oldRackLen, config.NodeSetRackCap) {
+		span.Warnf("node set %d rack %s exceeds capacity %d", info.NodeSetID, rack, config.NodeSetRackCap)
+		return apierrors.ErrIllegalArguments
+	}
+	if idc != info.Idc {
+		return d.checkUnitsRelabel(ctx, diskIDs, idc)
+	}
+
+	return nil
+}
+
+// checkUnitsRelabel checks idc placement of units on the disks relabeled to idc,
+// the violations of every volume or shard should be no more than before relabeling
+func (d *manager) checkUnitsRelabel(ctx context.Context, diskIDs []proto.DiskID, idc string) error {
+	span := trace.SpanFromContextSafe(ctx)
+	if d.unitsGetter == nil {
+		return nil
+	}
+
+	relabeled := make(map[proto.DiskID]struct{}, len(diskIDs))
+	for _, diskID := range diskIDs {
+		relabeled[diskID] = struct{}{}
+	}
+	oldIdcOf := func(diskID proto.DiskID) (ret string) {
+		if di, ok := d.getDisk(diskID); ok {
+			di.withRLocked(func() error {
+				ret = di.info.Idc
+				return nil
+			})
+		}
+		return
+	}
+	newIdcOf := func(diskID proto.DiskID) string {
+		if _, ok := relabeled[diskID]; ok {
+			return idc
+		}
+		return oldIdcOf(diskID)
+	}
+
+	checked := make(map[uint64]struct{})
+	for _, diskID := range diskIDs {
+		placedUnits, err := d.unitsGetter.ListPlacedUnits(ctx, diskID)
+		if err != nil {
+			return errors.Info(err, "list placed units failed").Detail(err)
+		}
+		for _, units := range placedUnits {
+			if _, ok := checked[units.ID]; ok {
+				continue
+			}
+			checked[units.ID] = struct{}{}
+			if idcViolations(units, newIdcOf) > idcViolations(units, oldIdcOf) {
+				span.Warnf("units of %d violate idc placement after relabeling disk %d to idc %s", units.ID, diskID, idc)
+				return apierrors.ErrIllegalArguments
+			}
+		}
+	}
+	return nil
+}
+
+// idcViolations returns the count of units not located in the idc of its az,
+// the idc of az is where most of its units are, and azs should be in different idcs
+func idcViolations(units PlacedUnits, idcOf func(diskID proto.DiskID) string) (n int) {
+	azIdcs := make(map[string]struct{}, len(units.AZDisks))
+	for _, disks := range units.AZDisks {
+		idcs := make([]string, len(disks))
+		for i, diskID := range disks {
+			idcs[i] = idcOf(diskID)
+		}
+		idc := majority(idcs)
+		if _, ok := azIdcs[idc]; ok {
+			n += len(disks)
+			continue
+		}
+		azIdcs[idc] = struct{}{}
+		for i := range idcs {
+			if idcs[i] != idc {
+				n++
+			}
+		}
+	}
+	return
+}
+
+// applyNodeRelabel relabel idc and rack of node and all its disks
+// the relabeling is validated again as topology or units may be changed after proposing
+func (d *manager) applyNodeRelabel(ctx context.Context, args *clustermgr.NodeRelabelArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+	if err := d.ValidateNodeRelabel(ctx, args); err != nil {
+		span.Warnf("skip invalid node relabel %+v, err: %v", args, err)
+		return nil
+	}
+	node, ok := d.getNode(args.NodeID)
+	if !ok {
+		return apierrors.ErrCMNodeNotFound
+	}
+
+	var diskItems []*diskItem
+	err := node.withLocked(func() error {
+		if args.Idc != "" {
+			node.info.Idc = args.Idc
+		}
+		if args.Rack != "" {
+			node.info.Rack = args.Rack
+		}
+		// copy diskIDs of node, avoid nested node and disk lock
+		diskItems = make([]*diskItem, 0, len(node.disks))
+		for _, di := range node.disks {
+			diskItems = append(diskItems, di)
+		}
+		return d.persistentHandler.updateNodeNoLocked(node)
+	})
+	if err != nil {
+		return errors.Info(err, "diskMgr.applyNodeRelabel update node failed").Detail(err)
+	}
+
+	for _, di := range diskItems {
+		err = di.withLocked(func() error {
+			if args.Idc != "" {
+				di.info.Idc = args.Idc
+			}
+			if args.Rack != "" {
+				di.info.Rack = args.Rack
+			}
+			return d.persistentHandler.updateDiskNoLocked(di)
+		})
+		if err != nil {
+			return errors.Info(err, "diskMgr.applyNodeRelabel update disk failed").Detail(err)
+		}
+	}
+	span.Infof("relabel node %d with %d disks, idc: %s, rack: %s", args.NodeID, len(diskItems), args.Idc, args.Rack)
+
+	return nil
+}
+
+func (d *manager) isValidIDC(idc string) bool {
+	for i := range d.cfg.IDC {
+		if d.cfg.IDC[i] == idc {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *manager) getDisk(diskID proto.DiskID) (disk *diskItem, exist bool) {
 	d.metaLock.RLock()
 	disk, exist = d.allDisks[diskID]
@@ -748,6 +983,50 @@ func (d *manager) getDisk(diskID proto.DiskID) (disk *diskItem, exist bool) {
 	return
 }
 
+// diskStateFilter return filter of disk with the state in memory, return nil if not filtered
+func (d *manager) diskStateFilter(opt *clustermgr.ListOptionArgs) func(id proto.DiskID) bool {
+	if opt.Dropping == nil && opt.HeartbeatExpired == nil {
+		return nil
+	}
+	return func(id proto.DiskID) bool {
+		disk, ok := d.getDisk(id)
+		if !ok {
+			return false
+		}
+		matched := true
+		disk.withRLocked(func() error {
+			if opt.Dropping != nil && disk.dropping != *opt.Dropping {
+				matched = false
+			}
+			if opt.HeartbeatExpired != nil && disk.isExpire() != *opt.HeartbeatExpired {
+				matched = false
+			}
+			return nil
+		})
+		return matched
+	}
+}
+
+// matchListOption return true if disk info matched with the condition of list option
+func matchListOption(opt *clustermgr.ListOptionArgs, info *clustermgr.DiskInfo) bool {
+	if opt.Host != "" && info.Host != opt.Host {
+		return false
+	}
+	if opt.Idc != "" && info.Idc != opt.Idc {
+		return false
+	}
+	if opt.Rack != "" && info.Rack != opt.Rack {
+		return false
+	}
+	if opt.Status.IsValid() && info.Status != opt.Status {
+		return false
+	}
+	if opt.Readonly != nil && info.Readonly != *opt.Readonly {
+		return false
+	}
+	return true
+}
+
 // getAllDisk copy all diskItem pointer array
 func (d *manager) getAllDisk() []*diskItem {
 	d.metaLock.RLock()
@@ -972,16 +1251,36 @@ func (d *manager) generateDiskSetStorage(ctx context.Context, disks []*diskItem,
 			}
 			freeChunk += idcFreeItems[d.cfg.IDC[i]]
 		}
-		spaceStatInfo.WritableSpace += d.calculateWritable(idcNodeStgs)
+		if spaceStatInfo.CodeModeWritableSpace == nil {
+			spaceStatInfo.CodeModeWritableSpace = make(map[codemode.CodeModeName]int64)
+		}
+		maxSuCodeMode, _ := d.getMaxSuCount()
+		writables := make(map[codemode.CodeModeName]int64, len(d.cfg.CodeModes))
+		for _, codeMode := range d.cfg.CodeModes {
+			name := codeMode.Name()
+			if _, ok := writables[name]; ok {
+				continue
+			}
+			writable := d.calculateWritable(idcNodeStgs, codeMode)
+			writables[name] = writable
+			spaceStatInfo.CodeModeWritableSpace[name] += writable
+			// writable space is calculated by the code mode of max su count
+			if codeMode == maxSuCodeMode {
+				spaceStatInfo.WritableSpace += writable
+			}
+		}
 	}
 
 	return
 }
 
-func (d *manager) calculateWritable(nodeStgs map[string][]*nodeAllocator) int64 {
+func (d *manager) calculateWritable(nodeStgs map[string][]*nodeAllocator, codeMode codemode.CodeMode) int64 {
 	// writable space statistic
-	codeMode, suCount := d.getMaxSuCount()
-	idcSuCount := suCount / len(d.cfg.IDC)
+	tactic := codeMode.Tactic()
+	idcSuCount := (tactic.N + tactic.M + tactic.L) / len(d.cfg.IDC)
+	if idcSuCount == 0 {
+		return 0
+	}
 	var itemSize int64
 	if d.cfg.ChunkSize != 0 {
 		itemSize = d.cfg.ChunkSize
@@ -1030,7 +1329,7 @@ func (d *manager) calculateWritable(nodeStgs map[string][]*nodeAllocator) int64
 				minimumStripeCount = n
 			}
 		}
-		return minimumStripeCount * int64(codeMode.Tactic().N) * itemSize
+		return minimumStripeCount * int64(tactic.N) * itemSize
 	}
 
 	if len(nodeStgs) > 0 {
@@ -1044,7 +1343,7 @@ func (d *manager) calculateWritable(nodeStgs map[string][]*nodeAllocator) int64
 				minimumChunkNum = idcChunkNum
 			}
 		}
-		return minimumChunkNum / int64(idcSuCount) * int64(codeMode.Tactic().N) * itemSize
+		return minimumChunkNum / int64(idcSuCount) * int64(tactic.N) * itemSize
 	}
 
 	return 0
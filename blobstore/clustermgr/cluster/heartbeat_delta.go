@@ -0,0 +1,85 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+// DiskHeartbeatDelta is the changed fields of disk heartbeat compared with the
+// memory of clustermgr, nil field is not changed. The delta with disk id only
+// refreshes the expire time of disk. Short json keys shrink the raft log.
+type DiskHeartbeatDelta struct {
+	DiskID       proto.DiskID `json:"i"`
+	Used         *int64       `json:"u,omitempty"`
+	Free         *int64       `json:"f,omitempty"`
+	Size         *int64       `json:"s,omitempty"`
+	UsedChunkCnt *int64       `json:"c,omitempty"`
+}
+
+// DisksHeartbeatDeltaArgs is the proposal of disks heartbeat of one node.
+type DisksHeartbeatDeltaArgs struct {
+	Disks []DiskHeartbeatDelta `json:"disks"`
+}
+
+// NewDiskHeartbeatDelta returns the delta of the reported info from the last info.
+func NewDiskHeartbeatDelta(last, info *clustermgr.DiskHeartBeatInfo) DiskHeartbeatDelta {
+	changed := func(lastVal, val int64) *int64 {
+		if lastVal == val {
+			return nil
+		}
+		return &val
+	}
+	return DiskHeartbeatDelta{
+		DiskID:       info.DiskID,
+		Used:         changed(last.Used, info.Used),
+		Free:         changed(last.Free, info.Free),
+		Size:         changed(last.Size, info.Size),
+		UsedChunkCnt: changed(last.UsedChunkCnt, info.UsedChunkCnt),
+	}
+}
+
+func (delta *DiskHeartbeatDelta) mergeTo(info *clustermgr.DiskHeartBeatInfo) {
+	merge := func(val *int64, field *int64) {
+		if val != nil {
+			*field = *val
+		}
+	}
+	merge(delta.Used, &info.Used)
+	merge(delta.Free, &info.Free)
+	merge(delta.Size, &info.Size)
+	merge(delta.UsedChunkCnt, &info.UsedChunkCnt)
+}
+
+// applyHeartBeatDiskDelta merges the delta to heartbeat info in memory, then applies as full heartbeat
+func (b *BlobNodeManager) applyHeartBeatDiskDelta(ctx context.Context, deltas []DiskHeartbeatDelta) error {
+	infos := make([]*clustermgr.DiskHeartBeatInfo, len(deltas))
+	for i := range deltas {
+		info := &clustermgr.DiskHeartBeatInfo{}
+		if disk, ok := b.getDisk(deltas[i].DiskID); ok {
+			disk.withRLocked(func() error {
+				*info = *(disk.info.extraInfo.(*clustermgr.DiskHeartBeatInfo))
+				return nil
+			})
+		}
+		info.DiskID = deltas[i].DiskID
+		deltas[i].mergeTo(info)
+		infos[i] = info
+	}
+	return b.applyHeartBeatDiskInfo(ctx, infos)
+}
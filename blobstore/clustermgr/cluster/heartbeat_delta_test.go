@@ -0,0 +1,96 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+func TestDiskHeartbeatDelta(t *testing.T) {
+	last := &clustermgr.DiskHeartBeatInfo{DiskID: 1, Used: 10, Free: 90, Size: 100, UsedChunkCnt: 1}
+	info := *last
+	delta := NewDiskHeartbeatDelta(last, &info)
+	data, err := json.Marshal(delta)
+	require.NoError(t, err)
+	require.Equal(t, `{"i":1}`, string(data))
+
+	info.Used, info.Free = 20, 80
+	delta = NewDiskHeartbeatDelta(last, &info)
+	data, err = json.Marshal(delta)
+	require.NoError(t, err)
+	require.Equal(t, `{"i":1,"u":20,"f":80}`, string(data))
+
+	merged := *last
+	delta.mergeTo(&merged)
+	require.Equal(t, info, merged)
+}
+
+func TestDiskMgr_HeartbeatDelta(t *testing.T) {
+	testDiskMgr, closeTestDiskMgr := initTestBlobNodeMgr(t)
+	defer closeTestDiskMgr()
+	initTestBlobNodeMgrNodes(t, testDiskMgr, 1, 1, testIdcs[0])
+	initTestBlobNodeMgrDisks(t, testDiskMgr, 1, 10, false, testIdcs[0])
+	span, ctx := trace.StartSpanFromContext(context.Background(), "")
+
+	args := &DisksHeartbeatDeltaArgs{}
+	lasts := make(map[proto.DiskID]clustermgr.DiskHeartBeatInfo)
+	for i := 1; i <= 10; i++ {
+		diskInfo, err := testDiskMgr.GetDiskInfo(ctx, proto.DiskID(i))
+		require.NoError(t, err)
+		lasts[diskInfo.DiskID] = diskInfo.DiskHeartBeatInfo
+		info := diskInfo.DiskHeartBeatInfo
+		if i%2 == 0 {
+			info.Used += testDiskMgr.cfg.ChunkSize
+			info.Free -= testDiskMgr.cfg.ChunkSize
+		}
+		args.Disks = append(args.Disks, NewDiskHeartbeatDelta(&diskInfo.DiskHeartBeatInfo, &info))
+	}
+	// not found disk is ignored
+	args.Disks = append(args.Disks, DiskHeartbeatDelta{DiskID: 100})
+	data, err := json.Marshal(args)
+	require.NoError(t, err)
+
+	before := time.Now()
+	err = testDiskMgr.Apply(ctx, []int32{OperTypeHeartbeatDiskDelta}, [][]byte{data},
+		[]base.ProposeContext{{ReqID: span.TraceID()}})
+	require.NoError(t, err)
+
+	for i := 1; i <= 10; i++ {
+		diskInfo, err := testDiskMgr.GetDiskInfo(ctx, proto.DiskID(i))
+		require.NoError(t, err)
+		last := lasts[diskInfo.DiskID]
+		if i%2 == 0 {
+			require.Equal(t, last.Used+testDiskMgr.cfg.ChunkSize, diskInfo.Used)
+			require.Equal(t, last.Free-testDiskMgr.cfg.ChunkSize, diskInfo.Free)
+		} else {
+			require.Equal(t, last.Used, diskInfo.Used)
+			require.Equal(t, last.Free, diskInfo.Free)
+		}
+		require.Equal(t, last.Size, diskInfo.Size)
+
+		disk, _ := testDiskMgr.getDisk(proto.DiskID(i))
+		require.True(t, disk.expireTime.After(before))
+	}
+}
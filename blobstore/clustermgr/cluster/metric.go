@@ -20,6 +20,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 )
 
@@ -51,12 +52,39 @@ var (
 		},
 		[]string{"region", "cluster", "idc", "item", "is_leader"},
 	)
+	topoIssueMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "blobstore",
+			Subsystem: "clusterMgr",
+			Name:      "topo_issue",
+			Help:      "cluster topology issues found by the last check",
+		},
+		[]string{"module", "type"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(spaceStatInfoMetric)
 	prometheus.MustRegister(diskStatInfoMetric)
 	prometheus.MustRegister(chunkStatInfoMetric)
+	prometheus.MustRegister(topoIssueMetric)
+}
+
+var topoIssueTypes = []string{
+	clustermgr.TopoIssueOrphanDisk,
+	clustermgr.TopoIssueNodeDiskMissing,
+	clustermgr.TopoIssueNodeSetMissing,
+	clustermgr.TopoIssueNodeSetMember,
+	clustermgr.TopoIssueDiskSetMember,
+	clustermgr.TopoIssueFilterMissing,
+	clustermgr.TopoIssueFilterStale,
+	clustermgr.TopoIssueTableMismatch,
+}
+
+func (d *manager) reportTopoIssueMetric(counts map[string]int) {
+	for _, typ := range topoIssueTypes {
+		topoIssueMetric.WithLabelValues(d.module, typ).Set(float64(counts[typ]))
+	}
 }
 
 func (d *manager) Report(ctx context.Context, region string, clusterID proto.ClusterID, isLeader string) {
@@ -73,6 +101,9 @@ func (d *manager) Report(ctx context.Context, region string, clusterID proto.Clu
 		fieldName := reflectTyes.Field(i).Name
 		vec.WithLabelValues(region, clusterID.ToString(), fieldName, isLeader).Set(float64(reflectVals.FieldByName(fieldName).Interface().(int64)))
 	}
+	for name, writable := range spaceStatInfo.CodeModeWritableSpace {
+		vec.WithLabelValues(region, clusterID.ToString(), "WritableSpace_"+string(name), isLeader).Set(float64(writable))
+	}
 
 	vecDisk := diskStatInfoMetric
 	vecDisk.Reset()
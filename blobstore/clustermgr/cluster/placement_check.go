@@ -0,0 +1,308 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// placementDisk is the location of disk in current topology, located by its node
+// like allocator generating, free is zero if the disk is not writable
+type placementDisk struct {
+	diskID    proto.DiskID
+	diskType  proto.DiskType
+	diskSetID proto.DiskSetID
+	idc       string
+	rack      string
+	host      string
+	free      int64
+}
+
+// placementChecker checks unit placement of volumes against a snapshot of topology,
+// suggested disks are accounted in the snapshot to spread migrations
+type placementChecker struct {
+	hostAware bool
+	rackAware bool // racks are considered by allocator only if host aware too
+
+	disks      map[proto.DiskID]*placementDisk
+	candidates []*placementDisk
+	racks      map[string]map[string]struct{} // racks in disk set of idc
+}
+
+func rackKey(diskType proto.DiskType, diskSetID proto.DiskSetID, idc string) string {
+	return fmt.Sprintf("%d-%d-%s", diskType, diskSetID, idc)
+}
+
+func (b *BlobNodeManager) newPlacementChecker() *placementChecker {
+	c := &placementChecker{
+		hostAware: b.cfg.HostAware,
+		rackAware: b.cfg.RackAware && b.cfg.HostAware,
+		disks:     make(map[proto.DiskID]*placementDisk),
+		racks:     make(map[string]map[string]struct{}),
+	}
+	for _, di := range b.getAllDisk() {
+		var (
+			info  clustermgr.DiskInfo
+			inUse bool
+		)
+		disk := &placementDisk{diskID: di.diskID}
+		di.withRLocked(func() error {
+			info = di.info.DiskInfo
+			inUse = !di.dropping && di.needFilter()
+			if di.isWritable() {
+				disk.free = di.weight()
+			}
+			return nil
+		})
+		disk.diskSetID = info.DiskSetID
+		disk.idc, disk.rack, disk.host = info.Idc, info.Rack, info.Host
+		if node, ok := b.getNode(info.NodeID); ok {
+			node.withRLocked(func() error {
+				disk.diskType = node.info.DiskType
+				disk.idc, disk.rack, disk.host = node.info.Idc, node.info.Rack, node.info.Host
+				return nil
+			})
+		}
+		c.disks[disk.diskID] = disk
+		if !inUse || !disk.diskType.IsValid() {
+			continue
+		}
+
+		for _, setID := range []proto.DiskSetID{disk.diskSetID, ecDiskSetID} {
+			key := rackKey(disk.diskType, setID, disk.idc)
+			if c.racks[key] == nil {
+				c.racks[key] = make(map[string]struct{})
+			}
+			c.racks[key][disk.rack] = struct{}{}
+		}
+		if disk.free > 0 {
+			c.candidates = append(c.candidates, disk)
+		}
+	}
+	sort.Slice(c.candidates, func(i, j int) bool { return c.candidates[i].diskID < c.candidates[j].diskID })
+	return c
+}
+
+// placed locations of units which satisfy the constraints
+type placed struct {
+	disks map[proto.DiskID]struct{}
+	hosts map[string]struct{}
+	racks []map[string]struct{} // racks of each az
+}
+
+// suggest returns the writable disk with most free chunks which satisfies the constraints
+func (c *placementChecker) suggest(diskType proto.DiskType, setID proto.DiskSetID,
+	idc string, az int, checkRack bool, p *placed,
+) *placementDisk {
+	var chosen *placementDisk
+	for _, disk := range c.candidates {
+		if disk.diskType != diskType || disk.idc != idc || disk.free <= 0 ||
+			(setID != ecDiskSetID && disk.diskSetID != setID) {
+			continue
+		}
+		if _, ok := p.disks[disk.diskID]; ok {
+			continue
+		}
+		if _, ok := p.hosts[disk.host]; ok && c.hostAware {
+			continue
+		}
+		if _, ok := p.racks[az][disk.rack]; ok && checkRack {
+			continue
+		}
+		if chosen == nil || disk.free > chosen.free {
+			chosen = disk
+		}
+	}
+	return chosen
+}
+
+func majority[T comparable](vals []T) (ret T) {
+	counts := make(map[T]int, len(vals))
+	most := 0
+	for _, val := range vals {
+		counts[val]++
+		if counts[val] > most {
+			most, ret = counts[val], val
+		}
+	}
+	return
+}
+
+func (c *placementChecker) check(vol *clustermgr.VolumeInfo) []clustermgr.PlacementViolation {
+	tactic := vol.CodeMode.Tactic()
+	units := make([]*placementDisk, len(vol.Units))
+	violations := make(map[int]*clustermgr.PlacementViolation)
+	violate := func(idx int, typ, detail string) {
+		if _, ok := violations[idx]; ok {
+			return
+		}
+		violations[idx] = &clustermgr.PlacementViolation{
+			Type:   typ,
+			Vid:    vol.Vid,
+			Vuid:   vol.Units[idx].Vuid,
+			DiskID: vol.Units[idx].DiskID,
+			Detail: detail,
+		}
+	}
+
+	var (
+		diskTypes  []proto.DiskType
+		diskSetIDs []proto.DiskSetID
+	)
+	for idx, unit := range vol.Units {
+		disk, ok := c.disks[unit.DiskID]
+		if !ok {
+			violate(idx, clustermgr.PlacementViolationDiskMissing, "disk not found")
+			continue
+		}
+		units[idx] = disk
+		diskTypes = append(diskTypes, disk.diskType)
+		diskSetIDs = append(diskSetIDs, disk.diskSetID)
+	}
+	diskType := majority(diskTypes)
+
+	// units of replicate mode are in one disk set, ec disk set has all disks
+	setID := ecDiskSetID
+	if tactic.IsReplicateMode() {
+		setID = majority(diskSetIDs)
+		for idx, disk := range units {
+			if disk != nil && disk.diskSetID != setID {
+				violate(idx, clustermgr.PlacementViolationDiskSet,
+					fmt.Sprintf("disk set: %d, volume disk set: %d", disk.diskSetID, setID))
+			}
+		}
+	}
+
+	// units of an az are in one idc, and azs are in different idcs
+	azs := tactic.GetECLayoutByAZ()
+	azIdcs := make([]string, len(azs))
+	unitAz := make(map[int]int, len(units))
+	idcAzs := make(map[string]int, len(azs))
+	for az, idxes := range azs {
+		idcs := make([]string, 0, len(idxes))
+		for _, idx := range idxes {
+			unitAz[idx] = az
+			if units[idx] != nil {
+				idcs = append(idcs, units[idx].idc)
+			}
+		}
+		idc := majority(idcs)
+		if prev, ok := idcAzs[idc]; ok {
+			for _, idx := range idxes {
+				violate(idx, clustermgr.PlacementViolationIdc,
+					fmt.Sprintf("az %d shares idc %s with az %d", az, idc, prev))
+			}
+			continue
+		}
+		if len(idcs) > 0 {
+			idcAzs[idc] = az
+			azIdcs[az] = idc
+		}
+		for _, idx := range idxes {
+			if disk := units[idx]; disk != nil && disk.idc != idc {
+				violate(idx, clustermgr.PlacementViolationIdc, fmt.Sprintf("idc: %s, az idc: %s", disk.idc, idc))
+			}
+		}
+	}
+
+	// rack is checked only if there are enough racks for units of the az
+	checkRacks := make([]bool, len(azs))
+	for az, idxes := range azs {
+		checkRacks[az] = c.rackAware && azIdcs[az] != "" &&
+			len(c.racks[rackKey(diskType, setID, azIdcs[az])]) >= len(idxes)
+	}
+
+	p := &placed{
+		disks: make(map[proto.DiskID]struct{}, len(units)),
+		hosts: make(map[string]struct{}, len(units)),
+		racks: make([]map[string]struct{}, len(azs)),
+	}
+	for az := range azs {
+		p.racks[az] = make(map[string]struct{})
+	}
+	hostUnits := make(map[string]int, len(units))
+	rackUnits := make(map[string]int, len(units))
+	for idx, disk := range units {
+		if disk == nil {
+			continue
+		}
+		// never suggest the disk of any unit
+		p.disks[disk.diskID] = struct{}{}
+		if _, ok := violations[idx]; ok {
+			continue
+		}
+		if j, ok := hostUnits[disk.host]; ok && c.hostAware {
+			violate(idx, clustermgr.PlacementViolationHost, fmt.Sprintf("host %s with unit %d", disk.host, j))
+			continue
+		}
+		az := unitAz[idx]
+		key := fmt.Sprintf("%d-%s", az, disk.rack)
+		if j, ok := rackUnits[key]; ok && checkRacks[az] {
+			violate(idx, clustermgr.PlacementViolationRack, fmt.Sprintf("rack %s with unit %d", disk.rack, j))
+			continue
+		}
+		hostUnits[disk.host] = idx
+		rackUnits[key] = idx
+		p.hosts[disk.host] = struct{}{}
+		p.racks[az][disk.rack] = struct{}{}
+	}
+
+	idxes := make([]int, 0, len(violations))
+	for idx := range violations {
+		idxes = append(idxes, idx)
+	}
+	sort.Ints(idxes)
+	ret := make([]clustermgr.PlacementViolation, 0, len(idxes))
+	for _, idx := range idxes {
+		violation := violations[idx]
+		az := unitAz[idx]
+		if idc := azIdcs[az]; idc != "" {
+			if disk := c.suggest(diskType, setID, idc, az, checkRacks[az], p); disk != nil {
+				violation.SuggestDiskID = disk.diskID
+				disk.free--
+				p.disks[disk.diskID] = struct{}{}
+				p.hosts[disk.host] = struct{}{}
+				p.racks[az][disk.rack] = struct{}{}
+			}
+		}
+		ret = append(ret, *violation)
+	}
+	return ret
+}
+
+// CheckVolumePlacement re-checks unit placement of volumes against current topology,
+// like idc of az, disk set of replicate mode, different hosts and racks if host or rack aware.
+// It returns the violations with suggested disks to migrate to, nothing is changed.
+func (b *BlobNodeManager) CheckVolumePlacement(ctx context.Context, vols []*clustermgr.VolumeInfo) []clustermgr.PlacementViolation {
+	span := trace.SpanFromContextSafe(ctx)
+
+	checker := b.newPlacementChecker()
+	ret := make([]clustermgr.PlacementViolation, 0)
+	for _, vol := range vols {
+		violations := checker.check(vol)
+		for _, violation := range violations {
+			span.Warnf("found placement violation: %+v", violation)
+		}
+		ret = append(ret, violations...)
+	}
+	span.Infof("check placement of %d volumes, violations: %d", len(vols), len(ret))
+	return ret
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// newTestPlacementVolume returns EC6P6 volume, units of az are on the first disks of different nodes in idc
+func newTestPlacementVolume(vid proto.Vid) *clustermgr.VolumeInfo {
+	vol := &clustermgr.VolumeInfo{VolumeInfoBase: clustermgr.VolumeInfoBase{Vid: vid, CodeMode: codemode.EC6P6}}
+	vol.Units = make([]clustermgr.Unit, codemode.EC6P6.GetShardNum())
+	tactic := codemode.EC6P6.Tactic()
+	for az, idxes := range tactic.GetECLayoutByAZ() {
+		for k, idx := range idxes {
+			vol.Units[idx] = clustermgr.Unit{
+				Vuid:   proto.EncodeVuid(proto.EncodeVuidPrefix(vid, uint8(idx)), 1),
+				DiskID: proto.DiskID(az*10000 + k*60 + 1),
+			}
+		}
+	}
+	return vol
+}
+
+func TestCheckVolumePlacement(t *testing.T) {
+	testDiskMgr, closeTestDiskMgr := initTestBlobNodeMgr(t)
+	defer closeTestDiskMgr()
+	testDiskMgr.cfg.HeartbeatExpireIntervalS = 6000
+
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+	initTestBlobNodeMgrNodes(t, testDiskMgr, 1, 10, testIdcs...)
+	initTestBlobNodeMgrDisks(t, testDiskMgr, 1, 599, false, testIdcs...)
+
+	tactic := codemode.EC6P6.Tactic()
+	azs := tactic.GetECLayoutByAZ()
+	unitAz := make(map[uint8]int)
+	for az, idxes := range azs {
+		for _, idx := range idxes {
+			unitAz[uint8(idx)] = az
+		}
+	}
+	require.Equal(t, 0, len(testDiskMgr.CheckVolumePlacement(ctx, []*clustermgr.VolumeInfo{newTestPlacementVolume(1)})))
+
+	// idc, host and missing disk
+	vol := newTestPlacementVolume(2)
+	vol.Units[azs[0][0]].DiskID = 10000 + 5*60 + 1
+	vol.Units[azs[1][1]].DiskID = 10000 + 2
+	vol.Units[azs[2][2]].DiskID = 99999
+	violations := testDiskMgr.CheckVolumePlacement(ctx, []*clustermgr.VolumeInfo{vol})
+	require.Equal(t, 3, len(violations))
+	types := make(map[proto.DiskID]string)
+	for _, violation := range violations {
+		types[violation.DiskID] = violation.Type
+		require.Equal(t, proto.Vid(2), violation.Vid)
+		require.NotEqual(t, proto.DiskID(0), violation.SuggestDiskID)
+
+		disk, ok := testDiskMgr.getDisk(violation.SuggestDiskID)
+		require.True(t, ok)
+		require.Equal(t, testIdcs[unitAz[violation.Vuid.Index()]], disk.info.Idc)
+		for _, unit := range vol.Units {
+			require.NotEqual(t, unit.DiskID, violation.SuggestDiskID)
+			if unitDisk, ok := testDiskMgr.getDisk(unit.DiskID); ok {
+				require.NotEqual(t, unitDisk.info.Host, disk.info.Host)
+			}
+		}
+	}
+	require.Equal(t, clustermgr.PlacementViolationIdc, types[10000+5*60+1])
+	require.Equal(t, clustermgr.PlacementViolationHost, types[10000+2])
+	require.Equal(t, clustermgr.PlacementViolationDiskMissing, types[99999])
+
+	// rack aware after relabeling node 2 to rack of node 1
+	vol = newTestPlacementVolume(3)
+	require.NoError(t, testDiskMgr.applyNodeRelabel(ctx, &clustermgr.NodeRelabelArgs{NodeID: 2, Rack: "1"}))
+	require.Equal(t, 0, len(testDiskMgr.CheckVolumePlacement(ctx, []*clustermgr.VolumeInfo{vol})))
+	testDiskMgr.cfg.RackAware = true
+	violations = testDiskMgr.CheckVolumePlacement(ctx, []*clustermgr.VolumeInfo{vol})
+	require.Equal(t, 1, len(violations))
+	require.Equal(t, clustermgr.PlacementViolationRack, violations[0].Type)
+	require.Equal(t, proto.DiskID(61), violations[0].DiskID)
+	disk, _ := testDiskMgr.getDisk(violations[0].SuggestDiskID)
+	require.Equal(t, testIdcs[0], disk.info.Idc)
+	require.NotEqual(t, "1", disk.info.Rack)
+}
@@ -58,6 +58,7 @@ func NewShardNodeMgr(scopeMgr scopemgr.ScopeMgrAPI, db *normaldb.NormalDB, cfg D
 	defaulter.LessOrEqual(&cfg.RefreshIntervalS, defaultRefreshIntervalS)
 	defaulter.LessOrEqual(&cfg.HeartbeatExpireIntervalS, defaultHeartbeatExpireIntervalS)
 	defaulter.LessOrEqual(&cfg.FlushIntervalS, defaultFlushIntervalS)
+	defaulter.LessOrEqual(&cfg.TopoCheckIntervalS, defaultTopoCheckIntervalS)
 	defaulter.LessOrEqual(&cfg.ApplyConcurrency, defaultApplyConcurrency)
 	if cfg.AllocTolerateBuffer >= 0 {
 		defaultAllocTolerateBuff = cfg.AllocTolerateBuffer
@@ -92,9 +93,9 @@ func NewShardNodeMgr(scopeMgr scopemgr.ScopeMgrAPI, db *normaldb.NormalDB, cfg D
 		scopeMgr:          scopeMgr,
 		persistentHandler: sm,
 
-		closeCh: make(chan interface{}),
-		cfg:     cfg,
+		cfg: cfg,
 	}
+	m.initLifecycle("shardnode_mgr")
 	sm.manager = m
 
 	// initial load data
@@ -112,11 +113,12 @@ func NewShardNodeMgr(scopeMgr scopemgr.ScopeMgrAPI, db *normaldb.NormalDB, cfg D
 			select {
 			case <-ticker.C:
 				sm.refresh(ctxNew)
-			case <-sm.closeCh:
+			case <-sm.lifecycle.Done():
 				return
 			}
 		}
 	}()
+	sm.startTopoCheck()
 
 	return sm, nil
 }
@@ -621,6 +623,29 @@ func (s *ShardNodeManager) Apply(ctx context.Context, operTypes []int32, datas [
 				}
 				wg.Done()
 			})
+		case OperTypeAdminRelabelNode:
+			args := &clustermgr.NodeRelabelArgs{}
+			err := json.Unmarshal(datas[idx], args)
+			if err != nil {
+				errs[idx] = errors.Info(err, t, datas[idx]).Detail(err)
+				wg.Done()
+				continue
+			}
+			// relabel node run on fixed goroutine synchronously, and refresh allocator after relabeling
+			s.taskPool.Run(s.getTaskIdx(synchronizedDiskID), func() {
+				errs[idx] = s.applyNodeRelabel(taskCtx, args)
+				if errs[idx] == nil {
+					s.refresh(taskCtx)
+				}
+				wg.Done()
+			})
+		case OperTypeRepairTopo:
+			// repair topo run on fixed goroutine synchronously, and refresh allocator after repairing
+			s.taskPool.Run(s.getTaskIdx(synchronizedDiskID), func() {
+				s.applyRepairTopo(taskCtx)
+				s.refresh(taskCtx)
+				wg.Done()
+			})
 		default:
 		}
 	}
@@ -905,6 +930,26 @@ func (s *shardNodePersistentHandler) droppedNode(id proto.NodeID) error {
 	return nil
 }
 
+func (s *shardNodePersistentHandler) getAllDiskAndNodeRecords() ([]*normaldb.DiskInfoRecord, []*normaldb.NodeInfoRecord, error) {
+	diskDBs, err := s.diskTbl.GetAllDisks()
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeDBs, err := s.nodeTbl.GetAllNodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	disks := make([]*normaldb.DiskInfoRecord, 0, len(diskDBs))
+	for _, disk := range diskDBs {
+		disks = append(disks, &disk.DiskInfoRecord)
+	}
+	nodes := make([]*normaldb.NodeInfoRecord, 0, len(nodeDBs))
+	for _, node := range nodeDBs {
+		nodes = append(nodes, &node.NodeInfoRecord)
+	}
+	return disks, nodes, nil
+}
+
 func shardNodeDiskWeightGetter(extraInfo interface{}) int64 {
 	return int64(extraInfo.(*clustermgr.ShardNodeDiskHeartbeatInfo).FreeShardCnt)
 }
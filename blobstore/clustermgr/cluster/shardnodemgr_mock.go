@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	clustermgr "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	normaldb "github.com/cubefs/cubefs/blobstore/clustermgr/persistence/normaldb"
 	proto "github.com/cubefs/cubefs/blobstore/common/proto"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -284,6 +285,20 @@ func (mr *MockShardNodeManagerAPIMockRecorder) ValidateNodeInfo(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateNodeInfo", reflect.TypeOf((*MockShardNodeManagerAPI)(nil).ValidateNodeInfo), arg0, arg1)
 }
 
+// ValidateNodeRelabel mocks base method.
+func (m *MockShardNodeManagerAPI) ValidateNodeRelabel(arg0 context.Context, arg1 *clustermgr.NodeRelabelArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateNodeRelabel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateNodeRelabel indicates an expected call of ValidateNodeRelabel.
+func (mr *MockShardNodeManagerAPIMockRecorder) ValidateNodeRelabel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateNodeRelabel", reflect.TypeOf((*MockShardNodeManagerAPI)(nil).ValidateNodeRelabel), arg0, arg1)
+}
+
 // addDiskNoLocked mocks base method.
 func (m *MockShardNodeManagerAPI) addDiskNoLocked(arg0 *diskItem) error {
 	m.ctrl.T.Helper()
@@ -354,6 +369,22 @@ func (mr *MockShardNodeManagerAPIMockRecorder) droppedNode(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "droppedNode", reflect.TypeOf((*MockShardNodeManagerAPI)(nil).droppedNode), arg0)
 }
 
+// getAllDiskAndNodeRecords mocks base method.
+func (m *MockShardNodeManagerAPI) getAllDiskAndNodeRecords() ([]*normaldb.DiskInfoRecord, []*normaldb.NodeInfoRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getAllDiskAndNodeRecords")
+	ret0, _ := ret[0].([]*normaldb.DiskInfoRecord)
+	ret1, _ := ret[1].([]*normaldb.NodeInfoRecord)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// getAllDiskAndNodeRecords indicates an expected call of getAllDiskAndNodeRecords.
+func (mr *MockShardNodeManagerAPIMockRecorder) getAllDiskAndNodeRecords() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getAllDiskAndNodeRecords", reflect.TypeOf((*MockShardNodeManagerAPI)(nil).getAllDiskAndNodeRecords))
+}
+
 // isDroppingDisk mocks base method.
 func (m *MockShardNodeManagerAPI) isDroppingDisk(arg0 proto.DiskID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -409,3 +409,24 @@ func (d *diskSetItem) getDiskSetLen(nodeID proto.NodeID) (int, int) {
 
 	return len(d.disks), d.nodeDiskCount[nodeID]
 }
+
+func (n *nodeSetItem) hasNode(nodeID proto.NodeID) bool {
+	n.RLock()
+	_, ok := n.nodes[nodeID]
+	n.RUnlock()
+	return ok
+}
+
+func (n *nodeSetItem) hasDisk(diskSetID proto.DiskSetID, diskID proto.DiskID) bool {
+	n.RLock()
+	diskSet, ok := n.diskSets[diskSetID]
+	n.RUnlock()
+	if !ok {
+		return false
+	}
+
+	diskSet.RLock()
+	_, ok = diskSet.disks[diskID]
+	diskSet.RUnlock()
+	return ok
+}
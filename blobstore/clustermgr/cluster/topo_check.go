@@ -0,0 +1,548 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+// topoReporter records an issue, and calls repair if repairing is enabled
+type topoReporter func(issue clustermgr.TopoIssue, repair func())
+
+// topoSnapshot is the disks and nodes of manager checked in one round
+type topoSnapshot struct {
+	nodes map[proto.NodeID]*nodeItem
+	disks map[proto.DiskID]*diskItem
+}
+
+func (t *topoSnapshot) getNode(nodeID proto.NodeID) (node *nodeItem, exist bool) {
+	node, exist = t.nodes[nodeID]
+	return
+}
+
+func (t *topoSnapshot) getDisk(diskID proto.DiskID) (disk *diskItem, exist bool) {
+	disk, exist = t.disks[diskID]
+	return
+}
+
+// getTopoSnapshotNoLocked copies disks and nodes of manager, metaLock should be held by caller
+func (d *manager) getTopoSnapshotNoLocked() *topoSnapshot {
+	snap := &topoSnapshot{
+		nodes: make(map[proto.NodeID]*nodeItem, len(d.allNodes)),
+		disks: make(map[proto.DiskID]*diskItem, len(d.allDisks)),
+	}
+	for nodeID, node := range d.allNodes {
+		snap.nodes[nodeID] = node
+	}
+	for diskID, disk := range d.allDisks {
+		snap.disks[diskID] = disk
+	}
+	return snap
+}
+
+func (d *manager) startTopoCheck() {
+	ticker := time.NewTicker(time.Duration(d.cfg.TopoCheckIntervalS) * time.Second)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				span, ctx := trace.StartSpanFromContext(context.Background(), "")
+				// memory topology is repaired through raft by leader only
+				repair := d.cfg.TopoAutoRepair && d.raftServer.IsLeader()
+				if _, err := d.CheckTopo(ctx, repair); err != nil {
+					span.Errorf("check topo failed, err: %s", err.Error())
+				}
+			case <-d.lifecycle.Done():
+				return
+			}
+		}
+	}()
+}
+
+// CheckTopo cross-checks all disks and nodes against node sets, disk sets, host path filter
+// and persistent tables. The mismatches between memory and persistent tables are only reported.
+// If repair is true, repairing is proposed by leader, and every raft node checks and repairs
+// its own memory topology under metaLock at applying.
+func (d *manager) CheckTopo(ctx context.Context, repair bool) (*clustermgr.TopoCheckRet, error) {
+	span := trace.SpanFromContextSafe(ctx)
+	if repair && !d.raftServer.IsLeader() {
+		return nil, ErrTopoRepairNotLeader
+	}
+
+	d.metaLock.RLock()
+	snap := d.getTopoSnapshotNoLocked()
+	d.metaLock.RUnlock()
+
+	ret := &clustermgr.TopoCheckRet{Issues: make([]clustermgr.TopoIssue, 0)}
+	counts := make(map[string]int)
+	repairable := false
+	report := func(issue clustermgr.TopoIssue, repairFn func()) {
+		if repair && repairFn != nil {
+			repairable = true
+			issue.Repaired = true
+		}
+		span.Warnf("found topo issue: %+v", issue)
+		ret.Issues = append(ret.Issues, issue)
+		counts[issue.Type]++
+	}
+
+	d.checkNodeSets(snap, report)
+	d.checkDiskSets(snap, report)
+	d.checkHostPathFilter(snap, report)
+	if err := d.checkPersistentTables(snap, report); err != nil {
+		return nil, errors.Info(err, "check persistent tables failed").Detail(err)
+	}
+
+	if repairable {
+		proposeInfo := base.EncodeProposeInfo(d.module, OperTypeRepairTopo, nil, base.ProposeContext{ReqID: span.TraceID()})
+		if err := d.raftServer.Propose(ctx, proposeInfo); err != nil {
+			return nil, errors.Info(err, "propose repair topo failed").Detail(err)
+		}
+	}
+
+	d.reportTopoIssueMetric(counts)
+	return ret, nil
+}
+
+// applyRepairTopo checks and repairs memory topology, disks and nodes can't be added or removed while repairing
+func (d *manager) applyRepairTopo(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	d.metaLock.Lock()
+	defer d.metaLock.Unlock()
+
+	snap := d.getTopoSnapshotNoLocked()
+	repaired := 0
+	report := func(issue clustermgr.TopoIssue, repairFn func()) {
+		if repairFn == nil {
+			return
+		}
+		repairFn()
+		repaired++
+		span.Infof("repair topo issue: %+v", issue)
+	}
+	d.checkNodeSets(snap, report)
+	d.checkDiskSets(snap, report)
+	d.checkHostPathFilter(snap, report)
+	span.Infof("repaired %d topo issues", repaired)
+}
+
+// checkNodeSets checks node in using status is a member of its node set
+func (d *manager) checkNodeSets(snap *topoSnapshot, report topoReporter) {
+	for _, node := range snap.nodes {
+		var (
+			info  clustermgr.NodeInfo
+			using bool
+		)
+		node.withRLocked(func() error {
+			info = node.info.NodeInfo
+			using = node.isUsingStatus()
+			return nil
+		})
+
+		nodeSet := d.topoMgr.getNodeSet(info.DiskType, info.NodeSetID)
+		if nodeSet == nil {
+			report(clustermgr.TopoIssue{
+				Type:      clustermgr.TopoIssueNodeSetMissing,
+				NodeID:    node.nodeID,
+				NodeSetID: info.NodeSetID,
+			}, func() { d.topoMgr.AddNodeToNodeSet(node) })
+			continue
+		}
+		if member := nodeSet.hasNode(node.nodeID); member != using {
+			report(clustermgr.TopoIssue{
+				Type:      clustermgr.TopoIssueNodeSetMember,
+				NodeID:    node.nodeID,
+				NodeSetID: info.NodeSetID,
+				Detail:    fmt.Sprintf("member: %v, node status: %d", member, info.Status),
+			}, func() {
+				if using {
+					nodeSet.addNode(node)
+					return
+				}
+				nodeSet.removeNode(node.nodeID)
+			})
+		}
+	}
+
+	// stale members of node sets
+	for diskType, nodeSets := range d.topoMgr.GetAllNodeSets(context.Background()) {
+		for _, nodeSet := range nodeSets {
+			for _, nodeID := range nodeSet.GetNodeIDs() {
+				node, ok := snap.getNode(nodeID)
+				if ok {
+					var info clustermgr.NodeInfo
+					node.withRLocked(func() error {
+						info = node.info.NodeInfo
+						return nil
+					})
+					if info.DiskType == diskType && info.NodeSetID == nodeSet.ID() {
+						continue
+					}
+				}
+				nodeSet := nodeSet
+				report(clustermgr.TopoIssue{
+					Type:      clustermgr.TopoIssueNodeSetMember,
+					NodeID:    nodeID,
+					NodeSetID: nodeSet.ID(),
+					Detail:    "stale member of node set",
+				}, func() { nodeSet.removeNode(nodeID) })
+			}
+		}
+	}
+}
+
+// checkDiskSets checks disk belongs to an existing node, and disk in use is a member
+// of its disk set, disk not in use like dropping or repaired one should not be a member
+func (d *manager) checkDiskSets(snap *topoSnapshot, report topoReporter) {
+	for _, disk := range snap.disks {
+		var (
+			info  clustermgr.DiskInfo
+			inUse bool
+		)
+		disk.withRLocked(func() error {
+			info = disk.info.DiskInfo
+			inUse = !disk.dropping && disk.needFilter()
+			return nil
+		})
+		// compatible case, disk added without node
+		if info.NodeID == proto.InvalidNodeID {
+			continue
+		}
+
+		node, ok := snap.getNode(info.NodeID)
+		if !ok {
+			report(clustermgr.TopoIssue{
+				Type:   clustermgr.TopoIssueOrphanDisk,
+				DiskID: disk.diskID,
+				NodeID: info.NodeID,
+			}, nil)
+			continue
+		}
+		var (
+			nodeInfo clustermgr.NodeInfo
+			inNode   bool
+		)
+		node.withRLocked(func() error {
+			nodeInfo = node.info.NodeInfo
+			_, inNode = node.disks[disk.diskID]
+			return nil
+		})
+		if !inNode {
+			report(clustermgr.TopoIssue{
+				Type:   clustermgr.TopoIssueNodeDiskMissing,
+				DiskID: disk.diskID,
+				NodeID: info.NodeID,
+			}, func() {
+				node.withLocked(func() error {
+					node.disks[disk.diskID] = disk
+					return nil
+				})
+			})
+		}
+
+		// missing node set is reported by node sets checking
+		nodeSet := d.topoMgr.getNodeSet(nodeInfo.DiskType, nodeInfo.NodeSetID)
+		if nodeSet == nil {
+			continue
+		}
+		if member := nodeSet.hasDisk(info.DiskSetID, disk.diskID); member != inUse {
+			report(clustermgr.TopoIssue{
+				Type:      clustermgr.TopoIssueDiskSetMember,
+				DiskID:    disk.diskID,
+				NodeSetID: nodeInfo.NodeSetID,
+				DiskSetID: info.DiskSetID,
+				Detail:    fmt.Sprintf("member: %v, disk in use: %v", member, inUse),
+			}, func() {
+				if inUse {
+					nodeSet.addDisk(disk)
+					return
+				}
+				nodeSet.removeDisk(disk)
+			})
+		}
+	}
+
+	// stale members of disk sets
+	for diskType, nodeSets := range d.topoMgr.GetAllNodeSets(context.Background()) {
+		for _, nodeSet := range nodeSets {
+			for _, diskSet := range nodeSet.GetDiskSets() {
+				for _, disk := range diskSet.GetDisks() {
+					if d.isDiskSetMember(snap, disk, diskType, nodeSet.ID(), diskSet.ID()) {
+						continue
+					}
+					diskSet, disk := diskSet, disk
+					report(clustermgr.TopoIssue{
+						Type:      clustermgr.TopoIssueDiskSetMember,
+						DiskID:    disk.diskID,
+						NodeSetID: nodeSet.ID(),
+						DiskSetID: diskSet.ID(),
+						Detail:    "stale member of disk set",
+					}, func() { diskSet.remove(disk) })
+				}
+			}
+		}
+	}
+}
+
+// isDiskSetMember returns true if disk belongs to the disk set, in use or not is checked by disks of manager
+func (d *manager) isDiskSetMember(snap *topoSnapshot, disk *diskItem, diskType proto.DiskType, nodeSetID proto.NodeSetID, diskSetID proto.DiskSetID) bool {
+	if di, ok := snap.getDisk(disk.diskID); !ok || di != disk {
+		return false
+	}
+	var info clustermgr.DiskInfo
+	disk.withRLocked(func() error {
+		info = disk.info.DiskInfo
+		return nil
+	})
+	if info.DiskSetID != diskSetID {
+		return false
+	}
+	node, ok := snap.getNode(info.NodeID)
+	if !ok {
+		return false
+	}
+	var nodeInfo clustermgr.NodeInfo
+	node.withRLocked(func() error {
+		nodeInfo = node.info.NodeInfo
+		return nil
+	})
+	return nodeInfo.DiskType == diskType && nodeInfo.NodeSetID == nodeSetID
+}
+
+// checkHostPathFilter checks host path filter keeps keys of all nodes and disks in use
+func (d *manager) checkHostPathFilter(snap *topoSnapshot, report topoReporter) {
+	nodeKeys := make(map[string]proto.NodeID)
+	for _, node := range snap.nodes {
+		var key string
+		node.withRLocked(func() error {
+			key = node.genFilterKey()
+			return nil
+		})
+		nodeKeys[key] = node.nodeID
+		if v, ok := d.hostPathFilter.Load(key); ok {
+			if nodeID, ok := v.(proto.NodeID); ok {
+				if _, exist := snap.getNode(nodeID); exist {
+					continue
+				}
+			}
+		}
+		nodeID := node.nodeID
+		report(clustermgr.TopoIssue{
+			Type:   clustermgr.TopoIssueFilterMissing,
+			NodeID: nodeID,
+			Detail: key,
+		}, func() { d.hostPathFilter.Store(key, nodeID) })
+	}
+
+	diskKeys := make(map[string]proto.DiskID)
+	for _, disk := range snap.disks {
+		var (
+			key   string
+			inUse bool
+		)
+		disk.withRLocked(func() error {
+			key = disk.genFilterKey()
+			inUse = disk.needFilter()
+			return nil
+		})
+		if !inUse {
+			continue
+		}
+		diskKeys[key] = disk.diskID
+		if _, ok := d.hostPathFilter.Load(key); ok {
+			continue
+		}
+		report(clustermgr.TopoIssue{
+			Type:   clustermgr.TopoIssueFilterMissing,
+			DiskID: disk.diskID,
+			Detail: key,
+		}, func() { d.hostPathFilter.Store(key, 1) })
+	}
+
+	d.hostPathFilter.Range(func(k, v interface{}) bool {
+		key := k.(string)
+		if _, ok := nodeKeys[key]; ok {
+			return true
+		}
+		if _, ok := diskKeys[key]; ok {
+			return true
+		}
+		report(clustermgr.TopoIssue{
+			Type:   clustermgr.TopoIssueFilterStale,
+			Detail: key,
+		}, func() {
+			// disk or node may be added concurrently, check again before deleting
+			if !d.isFilterKeyInUse(snap, key) {
+				d.hostPathFilter.Delete(key)
+			}
+		})
+		return true
+	})
+}
+
+func (d *manager) isFilterKeyInUse(snap *topoSnapshot, key string) bool {
+	for _, node := range snap.nodes {
+		var nodeKey string
+		node.withRLocked(func() error {
+			nodeKey = node.genFilterKey()
+			return nil
+		})
+		if nodeKey == key {
+			return true
+		}
+	}
+	for _, disk := range snap.disks {
+		var inUse bool
+		disk.withRLocked(func() error {
+			inUse = disk.needFilter() && disk.genFilterKey() == key
+			return nil
+		})
+		if inUse {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPersistentTables checks disks and nodes in memory are the same as the persistent tables,
+// mismatches may be transient when disks or nodes are changing concurrently.
+func (d *manager) checkPersistentTables(snap *topoSnapshot, report topoReporter) error {
+	diskInfos, nodeInfos, err := d.getAllDiskAndNodeInfo()
+	if err != nil {
+		return err
+	}
+
+	for _, disk := range snap.disks {
+		var info clustermgr.DiskInfo
+		disk.withRLocked(func() error {
+			info = disk.info.DiskInfo
+			return nil
+		})
+		record, ok := diskInfos[disk.diskID]
+		delete(diskInfos, disk.diskID)
+		detail := ""
+		switch {
+		case !ok:
+			detail = "disk not found in table"
+		case record.NodeID != info.NodeID:
+			detail = fmt.Sprintf("node id of table: %d, memory: %d", record.NodeID, info.NodeID)
+		case record.DiskSetID != info.DiskSetID:
+			detail = fmt.Sprintf("disk set id of table: %d, memory: %d", record.DiskSetID, info.DiskSetID)
+		case record.Status != info.Status:
+			detail = fmt.Sprintf("status of table: %s, memory: %s", record.Status.String(), info.Status.String())
+		case record.Host != info.Host || record.Path != info.Path:
+			detail = fmt.Sprintf("host path of table: %s%s, memory: %s%s", record.Host, record.Path, info.Host, info.Path)
+		default:
+			continue
+		}
+		report(clustermgr.TopoIssue{
+			Type:   clustermgr.TopoIssueTableMismatch,
+			DiskID: disk.diskID,
+			Detail: detail,
+		}, nil)
+	}
+	for diskID := range diskInfos {
+		report(clustermgr.TopoIssue{
+			Type:   clustermgr.TopoIssueTableMismatch,
+			DiskID: diskID,
+			Detail: "disk not found in memory",
+		}, nil)
+	}
+
+	for _, node := range snap.nodes {
+		var info clustermgr.NodeInfo
+		node.withRLocked(func() error {
+			info = node.info.NodeInfo
+			return nil
+		})
+		record, ok := nodeInfos[node.nodeID]
+		delete(nodeInfos, node.nodeID)
+		detail := ""
+		switch {
+		case !ok:
+			detail = "node not found in table"
+		case record.NodeSetID != info.NodeSetID:
+			detail = fmt.Sprintf("node set id of table: %d, memory: %d", record.NodeSetID, info.NodeSetID)
+		case record.DiskType != info.DiskType:
+			detail = fmt.Sprintf("disk type of table: %s, memory: %s", record.DiskType.String(), info.DiskType.String())
+		case record.Status != info.Status:
+			detail = fmt.Sprintf("status of table: %d, memory: %d", record.Status, info.Status)
+		case record.Host != info.Host:
+			detail = fmt.Sprintf("host of table: %s, memory: %s", record.Host, info.Host)
+		default:
+			continue
+		}
+		report(clustermgr.TopoIssue{
+			Type:   clustermgr.TopoIssueTableMismatch,
+			NodeID: node.nodeID,
+			Detail: detail,
+		}, nil)
+	}
+	for nodeID := range nodeInfos {
+		report(clustermgr.TopoIssue{
+			Type:   clustermgr.TopoIssueTableMismatch,
+			NodeID: nodeID,
+			Detail: "node not found in memory",
+		}, nil)
+	}
+	return nil
+}
+
+// getAllDiskAndNodeInfo returns disks and nodes of persistent tables
+func (d *manager) getAllDiskAndNodeInfo() (map[proto.DiskID]clustermgr.DiskInfo, map[proto.NodeID]clustermgr.NodeInfo, error) {
+	diskRecords, nodeRecords, err := d.persistentHandler.getAllDiskAndNodeRecords()
+	if err != nil {
+		return nil, nil, err
+	}
+	disks := make(map[proto.DiskID]clustermgr.DiskInfo, len(diskRecords))
+	for _, record := range diskRecords {
+		disks[record.DiskID] = clustermgr.DiskInfo{
+			ClusterID: record.ClusterID,
+			Idc:       record.Idc,
+			Rack:      record.Rack,
+			Host:      record.Host,
+			Path:      record.Path,
+			Status:    record.Status,
+			Readonly:  record.Readonly,
+			DiskSetID: record.DiskSetID,
+			NodeID:    record.NodeID,
+		}
+	}
+	nodes := make(map[proto.NodeID]clustermgr.NodeInfo, len(nodeRecords))
+	for _, record := range nodeRecords {
+		nodes[record.NodeID] = clustermgr.NodeInfo{
+			NodeID:    record.NodeID,
+			NodeSetID: record.NodeSetID,
+			ClusterID: record.ClusterID,
+			DiskType:  record.DiskType,
+			Idc:       record.Idc,
+			Rack:      record.Rack,
+			Host:      record.Host,
+			Role:      record.Role,
+			Status:    record.Status,
+		}
+	}
+	return disks, nodes, nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
 )
 
 func TestTopoMgr_AllocSetID(t *testing.T) {
@@ -65,3 +69,174 @@ func TestTopoMgr_AllocSetID(t *testing.T) {
 		require.Equal(t, proto.DiskSetID(startID), diskSetID)
 	}
 }
+
+func TestManager_CheckTopo(t *testing.T) {
+	testDiskMgr, closeTestDiskMgr := initTestBlobNodeMgr(t)
+	defer closeTestDiskMgr()
+	initTestBlobNodeMgrNodes(t, testDiskMgr, 1, 2, testIdcs[0])
+	initTestBlobNodeMgrDisks(t, testDiskMgr, 1, 10, false, testIdcs[0])
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+
+	ret, err := testDiskMgr.CheckTopo(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(ret.Issues))
+
+	// broken memory topology
+	node1, _ := testDiskMgr.getNode(1)
+	disk1, _ := testDiskMgr.getDisk(1)
+	disk2, _ := testDiskMgr.getDisk(2)
+	nodeSet := testDiskMgr.topoMgr.getNodeSet(node1.info.DiskType, node1.info.NodeSetID)
+	nodeSet.removeNode(node1.nodeID)
+	nodeSet.removeDisk(disk1)
+	delete(node1.disks, disk1.diskID)
+	testDiskMgr.hostPathFilter.Delete(disk2.genFilterKey())
+	testDiskMgr.hostPathFilter.Store("stale-host-path", 1)
+	testDiskMgr.allDisks[100] = &diskItem{
+		diskID: 100,
+		info:   diskItemInfo{DiskInfo: clustermgr.DiskInfo{NodeID: 100, Host: "orphan", Path: "orphan"}},
+	}
+
+	issueTypes := func(ret *clustermgr.TopoCheckRet) map[string]int {
+		types := make(map[string]int)
+		for _, issue := range ret.Issues {
+			types[issue.Type]++
+		}
+		return types
+	}
+	ret, err = testDiskMgr.CheckTopo(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		clustermgr.TopoIssueNodeSetMember:   1,
+		clustermgr.TopoIssueNodeDiskMissing: 1,
+		clustermgr.TopoIssueDiskSetMember:   1,
+		clustermgr.TopoIssueFilterMissing:   2,
+		clustermgr.TopoIssueFilterStale:     1,
+		clustermgr.TopoIssueOrphanDisk:      1,
+		clustermgr.TopoIssueTableMismatch:   1,
+	}, issueTypes(ret))
+	for _, issue := range ret.Issues {
+		require.False(t, issue.Repaired)
+	}
+
+	// repair memory topology on follower is not allowed
+	ctrl := gomock.NewController(t)
+	isLeader := false
+	mockRaftServer := mocks.NewMockRaftServer(ctrl)
+	mockRaftServer.EXPECT().IsLeader().AnyTimes().DoAndReturn(func() bool { return isLeader })
+	mockRaftServer.EXPECT().Propose(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(ctx context.Context, data []byte) error {
+			proposeInfo := base.DecodeProposeInfo(data)
+			require.Equal(t, OperTypeRepairTopo, proposeInfo.OperType)
+			return testDiskMgr.Apply(ctx, []int32{proposeInfo.OperType}, [][]byte{proposeInfo.Data},
+				[]base.ProposeContext{proposeInfo.Context})
+		})
+	testDiskMgr.SetRaftServer(mockRaftServer)
+	_, err = testDiskMgr.CheckTopo(ctx, true)
+	require.ErrorIs(t, err, ErrTopoRepairNotLeader)
+
+	// repair memory topology by leader through raft, orphan disk and table mismatch are reported only
+	isLeader = true
+	ret, err = testDiskMgr.CheckTopo(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(ret.Issues))
+	ret, err = testDiskMgr.CheckTopo(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		clustermgr.TopoIssueOrphanDisk:    1,
+		clustermgr.TopoIssueTableMismatch: 1,
+	}, issueTypes(ret))
+	require.True(t, nodeSet.hasNode(node1.nodeID))
+	require.True(t, nodeSet.hasDisk(disk1.info.DiskSetID, disk1.diskID))
+	_, ok := testDiskMgr.hostPathFilter.Load("stale-host-path")
+	require.False(t, ok)
+}
+
+func TestManager_NodeRelabel(t *testing.T) {
+	testDiskMgr, closeTestDiskMgr := initTestBlobNodeMgr(t)
+	defer closeTestDiskMgr()
+	initTestBlobNodeMgrNodes(t, testDiskMgr, 1, 1, testIdcs[0])
+	initTestBlobNodeMgrDisks(t, testDiskMgr, 1, 10, false, testIdcs[0])
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+
+	// invalid arguments
+	err := testDiskMgr.ValidateNodeRelabel(ctx, &clustermgr.NodeRelabelArgs{NodeID: 1})
+	require.ErrorIs(t, err, apierrors.ErrIllegalArguments)
+	err = testDiskMgr.ValidateNodeRelabel(ctx, &clustermgr.NodeRelabelArgs{NodeID: 1, Idc: "not-exist"})
+	require.ErrorIs(t, err, apierrors.ErrIllegalArguments)
+	err = testDiskMgr.ValidateNodeRelabel(ctx, &clustermgr.NodeRelabelArgs{NodeID: 100, Rack: "rack"})
+	require.ErrorIs(t, err, apierrors.ErrCMNodeNotFound)
+
+	args := &clustermgr.NodeRelabelArgs{NodeID: 1, Idc: testIdcs[1], Rack: "new-rack"}
+	require.NoError(t, testDiskMgr.ValidateNodeRelabel(ctx, args))
+	require.NoError(t, testDiskMgr.applyNodeRelabel(ctx, args))
+
+	node, _ := testDiskMgr.getNode(1)
+	require.Equal(t, testIdcs[1], node.info.Idc)
+	require.Equal(t, "new-rack", node.info.Rack)
+	for diskID := range node.disks {
+		disk, _ := testDiskMgr.getDisk(diskID)
+		require.Equal(t, testIdcs[1], disk.info.Idc)
+		require.Equal(t, "new-rack", disk.info.Rack)
+	}
+
+	// rack only
+	args = &clustermgr.NodeRelabelArgs{NodeID: 1, Rack: "rack1"}
+	require.NoError(t, testDiskMgr.applyNodeRelabel(ctx, args))
+	require.Equal(t, testIdcs[1], node.info.Idc)
+	require.Equal(t, "rack1", node.info.Rack)
+}
+
+type mockPlacedUnitsGetter struct {
+	units []PlacedUnits
+}
+
+func (m *mockPlacedUnitsGetter) ListPlacedUnits(ctx context.Context, diskID proto.DiskID) ([]PlacedUnits, error) {
+	ret := make([]PlacedUnits, 0)
+	for _, units := range m.units {
+		for _, disks := range units.AZDisks {
+			for _, id := range disks {
+				if id == diskID {
+					ret = append(ret, units)
+				}
+			}
+		}
+	}
+	return ret, nil
+}
+
+func TestManager_NodeRelabelUnits(t *testing.T) {
+	testDiskMgr, closeTestDiskMgr := initTestBlobNodeMgr(t)
+	defer closeTestDiskMgr()
+	initTestBlobNodeMgrNodes(t, testDiskMgr, 1, 1, testIdcs[0], testIdcs[1])
+	initTestBlobNodeMgrDisks(t, testDiskMgr, 1, 10, false, testIdcs[0], testIdcs[1])
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+
+	getter := &mockPlacedUnitsGetter{units: []PlacedUnits{{ID: 1, AZDisks: [][]proto.DiskID{{1}, {10001}}}}}
+	testDiskMgr.SetPlacedUnitsGetter(getter)
+
+	// azs of the volume share one idc after relabeling
+	args := &clustermgr.NodeRelabelArgs{NodeID: 1, Idc: testIdcs[1]}
+	require.ErrorIs(t, testDiskMgr.ValidateNodeRelabel(ctx, args), apierrors.ErrIllegalArguments)
+	require.NoError(t, testDiskMgr.applyNodeRelabel(ctx, args))
+	node, _ := testDiskMgr.getNode(1)
+	require.Equal(t, testIdcs[0], node.info.Idc)
+	disk, _ := testDiskMgr.getDisk(1)
+	require.Equal(t, testIdcs[0], disk.info.Idc)
+
+	// relabel rack only
+	args = &clustermgr.NodeRelabelArgs{NodeID: 1, Rack: "rack1"}
+	require.NoError(t, testDiskMgr.ValidateNodeRelabel(ctx, args))
+
+	// relabel to another idc
+	args = &clustermgr.NodeRelabelArgs{NodeID: 1, Idc: testIdcs[2]}
+	require.NoError(t, testDiskMgr.ValidateNodeRelabel(ctx, args))
+	require.NoError(t, testDiskMgr.applyNodeRelabel(ctx, args))
+	require.Equal(t, testIdcs[2], node.info.Idc)
+
+	// fix the violation of units in one az
+	getter.units = []PlacedUnits{{ID: 2, AZDisks: [][]proto.DiskID{{1, 10001}}}}
+	args = &clustermgr.NodeRelabelArgs{NodeID: 1, Idc: testIdcs[1]}
+	require.NoError(t, testDiskMgr.ValidateNodeRelabel(ctx, args))
+	require.NoError(t, testDiskMgr.applyNodeRelabel(ctx, args))
+	require.Equal(t, testIdcs[1], node.info.Idc)
+}
@@ -68,7 +68,7 @@ func (s *Service) ConfigSet(c *rpc.Context) {
 	}
 	span.Debugf("accept ConfigSet request :%v", args)
 
-	if proto.IsUnmodifiableSysConfigKey(args.Key) {
+	if isReservedConfigKey(args.Key) {
 		span.Warnf("key[%s] not allow to set by config api", args.Key)
 		c.RespondError(apierrors.ErrIllegalArguments)
 		return
@@ -89,6 +89,80 @@ func (s *Service) ConfigSet(c *rpc.Context) {
 	}
 }
 
+func (s *Service) ConfigList(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debug("accept ConfigList request")
+
+	// linear read
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+
+	configs, err := s.ConfigMgr.List(ctx)
+	if err != nil {
+		span.Errorf("config list error: %v", err)
+		c.RespondError(err)
+		return
+	}
+	c.RespondJSON(&clustermgr.AllConfig{Configs: configs})
+}
+
+// ConfigBatch sets and deletes configs in one proposal, nothing is changed if any key is not allowed
+func (s *Service) ConfigBatch(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.ConfigBatchArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept ConfigBatch request :%v", args)
+
+	if len(args.Sets)+len(args.Deletes) == 0 {
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	keys := make(map[string]struct{}, len(args.Sets)+len(args.Deletes))
+	for _, arg := range args.Sets {
+		if _, ok := keys[arg.Key]; ok || arg.Key == "" || isReservedConfigKey(arg.Key) {
+			span.Warnf("key[%s] not allow to set by config batch api", arg.Key)
+			c.RespondError(apierrors.ErrIllegalArguments)
+			return
+		}
+		keys[arg.Key] = struct{}{}
+	}
+	for _, key := range args.Deletes {
+		if _, ok := keys[key]; ok || key == "" {
+			span.Warnf("key[%s] not allow to delete by config batch api", key)
+			c.RespondError(apierrors.ErrIllegalArguments)
+			return
+		}
+		if proto.IsSysConfigKey(key) {
+			span.Warnf("%s is system config, not allow delete", key)
+			c.RespondError(apierrors.ErrRejectDelSysConfig)
+			return
+		}
+		keys[key] = struct{}{}
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		span.Errorf("ConfigBatch json marshal failed, args: %v, error: %v", args, err)
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	proposeInfo := base.EncodeProposeInfo(s.ConfigMgr.GetModuleName(), configmgr.OperTypeBatchConfig, data, base.ProposeContext{ReqID: span.TraceID()})
+	err = s.raftNode.Propose(ctx, proposeInfo)
+	if err != nil {
+		span.Errorf("raft propose failed, err:%v ", err)
+		c.RespondError(apierrors.ErrRaftPropose)
+		return
+	}
+}
+
 func (s *Service) ConfigDelete(c *rpc.Context) {
 	ctx := c.Request.Context()
 	span := trace.SpanFromContextSafe(ctx)
@@ -119,3 +193,8 @@ func (s *Service) ConfigDelete(c *rpc.Context) {
 		return
 	}
 }
+
+// isReservedConfigKey returns true if the key is unmodifiable or only modified by its own api
+func isReservedConfigKey(key string) bool {
+	return proto.IsUnmodifiableSysConfigKey(key) || key == proto.MaintenanceWindowsConfigKey
+}
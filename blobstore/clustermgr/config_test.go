@@ -19,11 +19,15 @@ import (
 	"encoding/json"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
 )
 
 func TestConfig(t *testing.T) {
@@ -96,3 +100,175 @@ func TestConfig(t *testing.T) {
 
 	}
 }
+
+func TestConfigListAndBatch(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	require.NoError(t, testClusterClient.SetConfig(ctx, proto.TaskTypeBalance.String(), "true"))
+	require.NoError(t, testClusterClient.SetConfig(ctx, "batch_del", "1"))
+	all, err := testClusterClient.ListConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "true", all.Configs[proto.TaskTypeBalance.String()])
+	require.NotEmpty(t, all.Configs[proto.CodeModeConfigKey])
+	_, ok := all.Configs[proto.TaskTypeDiskDrop.String()]
+	require.False(t, ok)
+
+	// failed cases, nothing is changed
+	for _, args := range []*clustermgr.ConfigBatchArgs{
+		{},
+		{Sets: []clustermgr.ConfigSetArgs{{Key: "batch_set", Value: "1"}, {Key: proto.CodeModeConfigKey, Value: "1"}}},
+		{Sets: []clustermgr.ConfigSetArgs{{Key: "batch_set", Value: "1"}, {Key: "batch_set", Value: "2"}}},
+		{Sets: []clustermgr.ConfigSetArgs{{Key: "batch_set", Value: "1"}}, Deletes: []string{"batch_set"}},
+		{Sets: []clustermgr.ConfigSetArgs{{Key: "batch_set", Value: "1"}}, Deletes: []string{proto.VolumeReserveSizeKey}},
+	} {
+		require.Error(t, testClusterClient.BatchConfig(ctx, args))
+	}
+	_, err = testClusterClient.GetConfig(ctx, "batch_set")
+	require.Error(t, err)
+
+	err = testClusterClient.BatchConfig(ctx, &clustermgr.ConfigBatchArgs{
+		Sets: []clustermgr.ConfigSetArgs{
+			{Key: "batch_set", Value: "1"},
+			{Key: proto.TaskTypeBalance.String(), Value: "false"},
+		},
+		Deletes: []string{"batch_del"},
+	})
+	require.NoError(t, err)
+	val, err := testClusterClient.GetConfig(ctx, "batch_set")
+	require.NoError(t, err)
+	require.Equal(t, "1", val)
+	_, err = testClusterClient.GetConfig(ctx, "batch_del")
+	require.Error(t, err)
+	all, err = testClusterClient.ListConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "false", all.Configs[proto.TaskTypeBalance.String()])
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	ret, err := testClusterClient.ListMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	require.Empty(t, ret.Windows)
+
+	now := time.Now().Unix()
+	_, err = testClusterClient.CreateMaintenanceWindow(ctx, &clustermgr.MaintenanceWindow{
+		StartTime: now, EndTime: now - 1, Factor: 1,
+	})
+	require.Error(t, err)
+	_, err = testClusterClient.CreateMaintenanceWindow(ctx, &clustermgr.MaintenanceWindow{
+		StartTime: now, EndTime: now + 100, Factor: 1, TaskTypes: []proto.TaskType{proto.TaskTypeBlobDelete},
+	})
+	require.Error(t, err)
+
+	w1, err := testClusterClient.CreateMaintenanceWindow(ctx, &clustermgr.MaintenanceWindow{
+		Name: "pause balance", StartTime: now - 100, EndTime: now + 100, Factor: 0,
+		TaskTypes: []proto.TaskType{proto.TaskTypeBalance},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), w1.ID)
+	w2, err := testClusterClient.CreateMaintenanceWindow(ctx, &clustermgr.MaintenanceWindow{
+		Name: "boost repair", StartTime: now + 200, EndTime: now + 300, Factor: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), w2.ID)
+
+	ret, err = testClusterClient.ListMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []clustermgr.MaintenanceWindow{w1, w2}, ret.Windows)
+
+	policy, err := testClusterClient.GetMaintenancePolicy(ctx, &clustermgr.MaintenancePolicyArgs{})
+	require.NoError(t, err)
+	require.Equal(t, []clustermgr.MaintenanceWindow{w1}, policy.Windows)
+	require.Equal(t, 0.0, policy.Factors[proto.TaskTypeBalance])
+	require.Equal(t, 1.0, policy.Factors[proto.TaskTypeDiskRepair])
+
+	policy, err = testClusterClient.GetMaintenancePolicy(ctx, &clustermgr.MaintenancePolicyArgs{Time: now + 250})
+	require.NoError(t, err)
+	require.Equal(t, []clustermgr.MaintenanceWindow{w2}, policy.Windows)
+	require.Equal(t, 2.0, policy.Factors[proto.TaskTypeBalance])
+	require.Equal(t, 2.0, policy.Factors[proto.TaskTypeDiskRepair])
+
+	// id in args is ignored, expired window is listed until the next create
+	w3, err := testClusterClient.CreateMaintenanceWindow(ctx, &clustermgr.MaintenanceWindow{
+		ID: 100, Name: "expired", StartTime: now - 300, EndTime: now - 200, Factor: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), w3.ID)
+	ret, err = testClusterClient.ListMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []clustermgr.MaintenanceWindow{w1, w2, w3}, ret.Windows)
+
+	// id of cleaned window is not reused
+	w4, err := testClusterClient.CreateMaintenanceWindow(ctx, &clustermgr.MaintenanceWindow{
+		Name: "boost balance", StartTime: now + 400, EndTime: now + 500, Factor: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(4), w4.ID)
+	ret, err = testClusterClient.ListMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []clustermgr.MaintenanceWindow{w1, w2, w4}, ret.Windows)
+
+	// windows are modified by maintenance api only
+	err = testClusterClient.SetConfig(ctx, proto.MaintenanceWindowsConfigKey, "[]")
+	require.Error(t, err)
+	err = testClusterClient.BatchConfig(ctx, &clustermgr.ConfigBatchArgs{
+		Sets: []clustermgr.ConfigSetArgs{{Key: proto.MaintenanceWindowsConfigKey, Value: "[]"}},
+	})
+	require.Error(t, err)
+	ret, err = testClusterClient.ListMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []clustermgr.MaintenanceWindow{w1, w2, w4}, ret.Windows)
+}
+
+func TestClusterFreeze(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	ret, err := testClusterClient.GetClusterFreeze(ctx)
+	require.NoError(t, err)
+	require.False(t, ret.Frozen)
+
+	_, err = testClusterClient.FreezeCluster(ctx, &clustermgr.FreezeArgs{TTLS: clustermgr.MaxFreezeTTLS + 1})
+	require.Error(t, err)
+	freeze, err := testClusterClient.FreezeCluster(ctx, &clustermgr.FreezeArgs{Reason: "incident"})
+	require.NoError(t, err)
+	require.Equal(t, int64(clustermgr.DefaultFreezeTTLS), freeze.ExpireTime-freeze.FreezeTime)
+
+	ret, err = testClusterClient.GetClusterFreeze(ctx)
+	require.NoError(t, err)
+	require.True(t, ret.Frozen)
+	require.Equal(t, freeze, *ret.Freeze)
+
+	// mutations are rejected and reads are served
+	_, err = testClusterClient.AllocDiskID(ctx)
+	require.Error(t, err)
+	require.Equal(t, apierrors.CodeClusterFrozen, err.(rpc.HTTPError).StatusCode())
+	_, err = testClusterClient.GetConfig(ctx, proto.VolumeChunkSizeKey)
+	require.NoError(t, err)
+
+	require.NoError(t, testClusterClient.UnfreezeCluster(ctx))
+	ret, err = testClusterClient.GetClusterFreeze(ctx)
+	require.NoError(t, err)
+	require.False(t, ret.Frozen)
+	_, err = testClusterClient.AllocDiskID(ctx)
+	require.NoError(t, err)
+
+	// expired freeze has no effect
+	value, err := json.Marshal(&clustermgr.ClusterFreeze{FreezeTime: freeze.FreezeTime - 10, ExpireTime: freeze.FreezeTime - 1})
+	require.NoError(t, err)
+	require.NoError(t, testClusterClient.SetConfig(ctx, proto.ClusterFreezeConfigKey, string(value)))
+	ret, err = testClusterClient.GetClusterFreeze(ctx)
+	require.NoError(t, err)
+	require.False(t, ret.Frozen)
+	_, err = testClusterClient.AllocDiskID(ctx)
+	require.NoError(t, err)
+}
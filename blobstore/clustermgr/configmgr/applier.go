@@ -64,6 +64,30 @@ func (v *ConfigMgr) Apply(ctx context.Context, operTypes []int32, datas [][]byte
 				span.Errorf("ConfigMgr.Apply OperTypeDeleteConfig delete failed, err: %v, args: %v", err, configDelArgs)
 				return
 			}
+		case OperTypeBatchConfig:
+			configBatchArgs := &clustermgr.ConfigBatchArgs{}
+			err = json.Unmarshal(datas[i], configBatchArgs)
+			if err != nil {
+				span.Errorf("ConfigMgr.Apply OperTypeBatchConfig json unmarshal failed, err: %v, data: %v", err, datas[i])
+				return
+			}
+			err = v.Batch(ctx, configBatchArgs)
+			if err != nil {
+				span.Errorf("ConfigMgr.Apply OperTypeBatchConfig batch failed, err: %v, args: %v", err, configBatchArgs)
+				return
+			}
+		case OperTypeCreateMaintenanceWindow:
+			createArgs := &MaintenanceWindowCreateArgs{}
+			err = json.Unmarshal(datas[i], createArgs)
+			if err != nil {
+				span.Errorf("ConfigMgr.Apply OperTypeCreateMaintenanceWindow json unmarshal failed, err: %v, data: %v", err, datas[i])
+				return
+			}
+			err = v.CreateMaintenanceWindow(ctx, createArgs)
+			if err != nil {
+				span.Errorf("ConfigMgr.Apply OperTypeCreateMaintenanceWindow create failed, err: %v, args: %v", err, createArgs)
+				return
+			}
 		default:
 			err = errors.New("unsupported operation")
 			return
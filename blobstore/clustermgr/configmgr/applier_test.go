@@ -26,7 +26,9 @@ import (
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/mock"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
 )
 
 func TestConfigMgr_Others(t *testing.T) {
@@ -145,3 +147,50 @@ func TestConfigMgr_Apply(t *testing.T) {
 	err = configmgr.Apply(ctx, operTypes, datas, ctxs)
 	require.NoError(t, err)
 }
+
+func TestConfigMgr_ApplyMaintenanceWindow(t *testing.T) {
+	span, ctx := trace.StartSpanFromContext(context.Background(), "")
+	ctr := gomock.NewController(t)
+	mockKvMgr := mock.NewMockKvMgrAPI(ctr)
+	configmgr, err := New(mockKvMgr, map[string]interface{}{})
+	require.NoError(t, err)
+
+	now := int64(1000)
+	w1 := clustermgr.MaintenanceWindow{ID: 1, StartTime: now - 200, EndTime: now - 100, Factor: 2}
+	w2 := clustermgr.MaintenanceWindow{ID: 2, StartTime: now - 100, EndTime: now + 100, Factor: 0}
+	w3 := clustermgr.MaintenanceWindow{ID: 3, StartTime: now, EndTime: now + 200, Factor: 2}
+	apply := func(w clustermgr.MaintenanceWindow) error {
+		data, err := json.Marshal(&MaintenanceWindowCreateArgs{Window: w, Time: now})
+		require.NoError(t, err)
+		return configmgr.Apply(ctx, []int32{OperTypeCreateMaintenanceWindow}, [][]byte{data},
+			[]base.ProposeContext{{ReqID: span.TraceID()}})
+	}
+
+	// not exist
+	mockKvMgr.EXPECT().Get(proto.MaintenanceWindowsConfigKey).Return(nil, os.ErrNotExist)
+	mockKvMgr.EXPECT().Set(proto.MaintenanceWindowsConfigKey, gomock.Any()).DoAndReturn(func(_ string, value []byte) error {
+		windows, err := clustermgr.DecodeMaintenanceWindows(string(value))
+		require.NoError(t, err)
+		require.Equal(t, clustermgr.MaintenanceWindows{w1}, windows)
+		return nil
+	})
+	require.NoError(t, apply(w1))
+
+	// expired window is cleaned
+	value, _ := json.Marshal(clustermgr.MaintenanceWindows{w1, w2})
+	mockKvMgr.EXPECT().Get(proto.MaintenanceWindowsConfigKey).Return(value, nil)
+	mockKvMgr.EXPECT().Set(proto.MaintenanceWindowsConfigKey, gomock.Any()).DoAndReturn(func(_ string, value []byte) error {
+		windows, err := clustermgr.DecodeMaintenanceWindows(string(value))
+		require.NoError(t, err)
+		require.Equal(t, clustermgr.MaintenanceWindows{w2, w3}, windows)
+		return nil
+	})
+	require.NoError(t, apply(w3))
+
+	// duplicated id is skipped
+	mockKvMgr.EXPECT().Get(proto.MaintenanceWindowsConfigKey).Return(value, nil)
+	require.NoError(t, apply(w2))
+
+	mockKvMgr.EXPECT().Get(proto.MaintenanceWindowsConfigKey).Return(nil, errors.New("get failed"))
+	require.Error(t, apply(w3))
+}
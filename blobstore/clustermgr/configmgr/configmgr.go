@@ -17,18 +17,49 @@ package configmgr
 import (
 	"context"
 	"encoding/json"
+	"os"
 	"sync"
+	"time"
 
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/kvmgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/raftserver"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/util/errors"
 )
 
 const (
 	OperTypeSetConfig = iota + 1
 	OperTypeDeleteConfig
+	OperTypeBatchConfig
+	OperTypeCreateMaintenanceWindow
 )
 
+// runtimeKeys config keys which are not in config file of clustermgr
+var runtimeKeys = []string{
+	proto.CodeModeConfigKey,
+	proto.VolumeReserveSizeKey,
+	proto.VolumeChunkSizeKey,
+	proto.VolumeOverboughtRatioKey,
+	proto.ChunkOversoldRatioKey,
+	proto.MaintenanceWindowsConfigKey,
+	proto.ClusterFreezeConfigKey,
+	proto.VolumeInspectConfigKey,
+	// switches of scheduler tasks
+	proto.TaskTypeDiskRepair.String(),
+	proto.TaskTypeBalance.String(),
+	proto.TaskTypeDiskDrop.String(),
+	proto.TaskTypeManualMigrate.String(),
+	proto.TaskTypeShardRepair.String(),
+	proto.TaskTypeBlobDelete.String(),
+	proto.TaskTypeClusterMigrate.String(),
+	proto.TaskTypeShardInspect.String(),
+	proto.TaskTypeShardDiskRepair.String(),
+	proto.TaskTypeShardMigrate.String(),
+	proto.TaskTypeShardDiskDrop.String(),
+}
+
 type ConfigMgr struct {
 	module               string
 	kvMgr                kvmgr.KvMgrAPI
@@ -37,6 +68,13 @@ type ConfigMgr struct {
 	raftServer           raftserver.RaftServer
 }
 
+// MaintenanceWindowCreateArgs is the proposal of creating maintenance window,
+// the windows expired at the proposing time are cleaned when applying
+type MaintenanceWindowCreateArgs struct {
+	Window clustermgr.MaintenanceWindow `json:"window"`
+	Time   int64                        `json:"time"`
+}
+
 type ConfigMgrAPI interface {
 	Get(ctx context.Context, key string) (val string, err error)
 	Set(ctx context.Context, key string, value string) (err error)
@@ -84,6 +122,91 @@ func (c *ConfigMgr) Get(ctx context.Context, key string) (val string, err error)
 	return "", errors.New("config key is empty")
 }
 
+// List returns configs of clustermgr config file and the runtime keys which have been set,
+// other keys set by config api can only be got one by one.
+func (c *ConfigMgr) List(ctx context.Context) (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	configs := make(map[string]string, len(c.defaultClusterConfig)+len(runtimeKeys))
+	for key, val := range c.defaultClusterConfig {
+		configs[key] = val
+	}
+	for _, key := range runtimeKeys {
+		configs[key] = ""
+	}
+	for key := range configs {
+		val, err := c.kvMgr.Get(key)
+		if err != nil {
+			if _, ok := c.defaultClusterConfig[key]; !ok {
+				delete(configs, key)
+			}
+			continue
+		}
+		configs[key] = string(val)
+	}
+	return configs, nil
+}
+
+// Batch deletes and sets configs, readers of config never see the partial changes
+func (c *ConfigMgr) Batch(ctx context.Context, args *clustermgr.ConfigBatchArgs) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range args.Deletes {
+		if err = c.kvMgr.Delete(key); err != nil {
+			return
+		}
+	}
+	for _, arg := range args.Sets {
+		if err = c.kvMgr.Set(arg.Key, []byte(arg.Value)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// CreateMaintenanceWindow reads maintenance windows, appends the window and cleans expired windows,
+// the window with duplicated id is skipped
+func (c *ConfigMgr) CreateMaintenanceWindow(ctx context.Context, args *MaintenanceWindowCreateArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var value string
+	val, err := c.kvMgr.Get(proto.MaintenanceWindowsConfigKey)
+	switch {
+	case err == nil:
+		value = string(val)
+	case err == os.ErrNotExist:
+		value = c.defaultClusterConfig[proto.MaintenanceWindowsConfigKey]
+	default:
+		return err
+	}
+	windows, err := clustermgr.DecodeMaintenanceWindows(value)
+	if err != nil {
+		span.Errorf("decode maintenance windows failed and skip creating window %+v, err: %v", args.Window, err)
+		return nil
+	}
+
+	now := time.Unix(args.Time, 0)
+	newWindows := make(clustermgr.MaintenanceWindows, 0, len(windows)+1)
+	for _, w := range windows {
+		if w.ID == args.Window.ID {
+			span.Warnf("skip creating maintenance window with duplicated id, window: %+v", args.Window)
+			return nil
+		}
+		if !w.IsExpired(now) {
+			newWindows = append(newWindows, w)
+		}
+	}
+	newWindows = append(newWindows, args.Window)
+
+	data, err := json.Marshal(newWindows)
+	if err != nil {
+		return err
+	}
+	return c.kvMgr.Set(proto.MaintenanceWindowsConfigKey, data)
+}
+
 func (c *ConfigMgr) Delete(ctx context.Context, key string) (err error) {
 	err = c.kvMgr.Delete(key)
 	return
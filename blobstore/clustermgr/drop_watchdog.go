@@ -0,0 +1,190 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/api/scheduler"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+const (
+	defaultDropWatchdogIntervalS  = 600
+	defaultDropWatchdogStuckHours = 6
+)
+
+var errNoSchedulerAvailable = errors.New("no scheduler available")
+
+// DropWatchdogConfig requeues the dropping disk without migration progress for StuckHours
+// with scheduler, and raises event if the disk is still stuck for StuckHours after requeued.
+// The watchdog is disabled if IntervalS < 0.
+type DropWatchdogConfig struct {
+	IntervalS  int              `json:"interval_s"`
+	StuckHours int              `json:"stuck_hours"`
+	Scheduler  scheduler.Config `json:"scheduler"`
+}
+
+type droppingProgress struct {
+	host         string
+	units        int // remaining volume units on the disk
+	progressTime time.Time
+	requeueTime  time.Time
+	stuck        bool
+}
+
+// dropWatchdog tracks migration progress of dropping disks on leader in memory,
+// the new leader tracks from scratch.
+type dropWatchdog struct {
+	sync.Mutex
+	disks    map[proto.DiskID]*droppingProgress
+	requeuer scheduler.IDiskDropRequeuer
+}
+
+func newDropWatchdog(cfg *scheduler.Config) *dropWatchdog {
+	return &dropWatchdog{
+		disks:    make(map[proto.DiskID]*droppingProgress),
+		requeuer: scheduler.NewDiskDropRequeuer(cfg),
+	}
+}
+
+func (w *dropWatchdog) reset() {
+	w.Lock()
+	w.disks = make(map[proto.DiskID]*droppingProgress)
+	w.Unlock()
+}
+
+// count returns dropping disks without progress for stuck duration sorted by disk id,
+// and number of disks still stuck after requeued
+func (w *dropWatchdog) count(stuckDuration time.Duration) (stalled []clustermgr.StuckDroppingDisk, stuck int) {
+	now := time.Now()
+	stalled = make([]clustermgr.StuckDroppingDisk, 0)
+	w.Lock()
+	for diskID, p := range w.disks {
+		if now.Sub(p.progressTime) < stuckDuration {
+			continue
+		}
+		disk := clustermgr.StuckDroppingDisk{
+			DiskID:       diskID,
+			Host:         p.host,
+			VolumeUnits:  p.units,
+			ProgressTime: p.progressTime.Unix(),
+			Stuck:        p.stuck,
+		}
+		if !p.requeueTime.IsZero() {
+			disk.RequeueTime = p.requeueTime.Unix()
+		}
+		if p.stuck {
+			stuck++
+		}
+		stalled = append(stalled, disk)
+	}
+	w.Unlock()
+	sort.Slice(stalled, func(i, j int) bool {
+		return stalled[i].DiskID < stalled[j].DiskID
+	})
+	return
+}
+
+// StuckDroppingDiskList returns the dropping disks without migration progress for stuck hours
+func (s *Service) StuckDroppingDiskList(c *rpc.Context) {
+	if !s.raftNode.IsLeader() {
+		s.forwardToLeader(c.Writer, c.Request)
+		return
+	}
+	disks, _ := s.dropWatchdog.count(time.Duration(s.DropWatchdogConfig.StuckHours) * time.Hour)
+	c.RespondJSON(&clustermgr.ListStuckDroppingDiskRet{Disks: disks})
+}
+
+// checkDroppingDisks measures progress of dropping disk by its remaining volume units,
+// the disk without progress for stuck hours is requeued with scheduler once,
+// and it's stuck if there is still no progress for stuck hours after requeued.
+func (s *Service) checkDroppingDisks(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+	stuckDuration := time.Duration(s.DropWatchdogConfig.StuckHours) * time.Hour
+	now := time.Now()
+
+	disks, err := s.BlobNodeMgr.ListDroppingDisk(ctx)
+	if err != nil {
+		span.Errorf("list dropping disk failed, err: %s", errors.Detail(err))
+		return
+	}
+
+	dropping := make(map[proto.DiskID]struct{}, len(disks))
+	requeues := make([]proto.DiskID, 0)
+	stuck := 0
+	s.dropWatchdog.Lock()
+	for _, disk := range disks {
+		dropping[disk.DiskID] = struct{}{}
+		units := len(s.VolumeMgr.ListVolumeUnitsOnDisk(ctx, disk.DiskID))
+		p, ok := s.dropWatchdog.disks[disk.DiskID]
+		if !ok || units < p.units {
+			s.dropWatchdog.disks[disk.DiskID] = &droppingProgress{host: disk.Host, units: units, progressTime: now}
+			continue
+		}
+		if now.Sub(p.progressTime) < stuckDuration {
+			continue
+		}
+		if p.requeueTime.IsZero() {
+			p.requeueTime = now
+			requeues = append(requeues, disk.DiskID)
+			continue
+		}
+		if !p.stuck && now.Sub(p.requeueTime) >= stuckDuration {
+			p.stuck = true
+			span.Errorf("dropping disk is still stuck after requeued, disk_id: %d, host: %s, volume units: %d, progress time: %s, requeue time: %s",
+				disk.DiskID, p.host, p.units, p.progressTime, p.requeueTime)
+		}
+		if p.stuck {
+			stuck++
+		}
+	}
+	for diskID := range s.dropWatchdog.disks {
+		if _, ok := dropping[diskID]; !ok {
+			delete(s.dropWatchdog.disks, diskID)
+		}
+	}
+	s.dropWatchdog.Unlock()
+	s.reportStuckDroppingDisk(float64(stuck))
+
+	for _, diskID := range requeues {
+		span.Warnf("dropping disk has no progress for %d hours, requeue it with scheduler, disk_id: %d",
+			s.DropWatchdogConfig.StuckHours, diskID)
+		if err := s.requeueDroppingDisk(ctx, diskID); err != nil {
+			span.Errorf("requeue dropping disk %d failed, err: %s", diskID, errors.Detail(err))
+		}
+	}
+}
+
+// requeueDroppingDisk requests the scheduler of this cluster, and it's forwarded to the leader of scheduler
+func (s *Service) requeueDroppingDisk(ctx context.Context, diskID proto.DiskID) (err error) {
+	err = errNoSchedulerAvailable
+	for _, node := range s.ServiceMgr.GetServiceInfo(proto.ServiceNameScheduler).Nodes {
+		if proto.ClusterID(node.ClusterID) != s.ClusterID {
+			continue
+		}
+		if err = s.dropWatchdog.requeuer.RequeueDropDisk(ctx, node.Host, diskID); err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+type mockDropRequeuer struct {
+	hosts []string
+	disks []proto.DiskID
+}
+
+func (m *mockDropRequeuer) RequeueDropDisk(ctx context.Context, host string, diskID proto.DiskID) error {
+	m.hosts = append(m.hosts, host)
+	m.disks = append(m.disks, diskID)
+	return nil
+}
+
+func TestDropWatchdog(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	requeuer := &mockDropRequeuer{}
+	testService.dropWatchdog.requeuer = requeuer
+	stuckDuration := time.Duration(testService.DropWatchdogConfig.StuckHours) * time.Hour
+	setBack := func(diskID proto.DiskID, progress, requeue bool) {
+		testService.dropWatchdog.Lock()
+		p := testService.dropWatchdog.disks[diskID]
+		if progress {
+			p.progressTime = p.progressTime.Add(-stuckDuration)
+		}
+		if requeue {
+			p.requeueTime = p.requeueTime.Add(-stuckDuration)
+		}
+		testService.dropWatchdog.Unlock()
+	}
+
+	insertNodeInfos(t, testClusterClient, 0, 0, testService.IDC[0])
+	insertDiskInfos(t, testClusterClient, 1, 3, testService.IDC[0])
+	require.NoError(t, testClusterClient.SetReadonlyDisk(ctx, 1, true))
+	require.NoError(t, testClusterClient.DropDisk(ctx, 1))
+
+	testService.checkDroppingDisks(ctx)
+	ret, err := testClusterClient.ListStuckDroppingDisk(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(ret.Disks))
+
+	// without scheduler registered
+	setBack(1, true, false)
+	testService.checkDroppingDisks(ctx)
+	require.Equal(t, 0, len(requeuer.disks))
+	ret, err = testClusterClient.ListStuckDroppingDisk(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ret.Disks))
+	require.Equal(t, proto.DiskID(1), ret.Disks[0].DiskID)
+	require.NotZero(t, ret.Disks[0].RequeueTime)
+	require.False(t, ret.Disks[0].Stuck)
+
+	// requeue once with scheduler of the cluster
+	testService.dropWatchdog.reset()
+	testService.checkDroppingDisks(ctx)
+	node := clustermgr.ServiceNode{
+		ClusterID: uint64(testService.ClusterID),
+		Name:      proto.ServiceNameScheduler,
+		Host:      "127.0.0.1:9800",
+		Idc:       testService.IDC[0],
+	}
+	require.NoError(t, testClusterClient.RegisterService(ctx, node, 10, 10, 60))
+	setBack(1, true, false)
+	testService.checkDroppingDisks(ctx)
+	testService.checkDroppingDisks(ctx)
+	require.Equal(t, []string{"127.0.0.1:9800"}, requeuer.hosts)
+	require.Equal(t, []proto.DiskID{1}, requeuer.disks)
+
+	// still stuck after requeued
+	setBack(1, false, true)
+	testService.checkDroppingDisks(ctx)
+	ret, err = testClusterClient.ListStuckDroppingDisk(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ret.Disks))
+	require.True(t, ret.Disks[0].Stuck)
+	require.Equal(t, 1, len(requeuer.disks))
+
+	// untracked after dropped
+	require.NoError(t, testClusterClient.DroppedDisk(ctx, 1))
+	testService.checkDroppingDisks(ctx)
+	ret, err = testClusterClient.ListStuckDroppingDisk(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(ret.Disks))
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/configmgr"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+// ClusterFreeze freeze the mutating operations of cluster until expired,
+// freeze again will replace the last one
+func (s *Service) ClusterFreeze(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.FreezeArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept ClusterFreeze request, args: %v", args)
+
+	if err := args.Check(); err != nil {
+		span.Warnf("invalid freeze args: %v", err)
+		c.RespondError(errors.Info(apierrors.ErrIllegalArguments).Detail(err))
+		return
+	}
+	if args.TTLS == 0 {
+		args.TTLS = clustermgr.DefaultFreezeTTLS
+	}
+
+	now := time.Now().Unix()
+	freeze := clustermgr.ClusterFreeze{Reason: args.Reason, FreezeTime: now, ExpireTime: now + args.TTLS}
+	value, err := json.Marshal(freeze)
+	if err != nil {
+		span.Errorf("json marshal failed, freeze: %v, error: %v", freeze, err)
+		c.RespondError(errors.Info(apierrors.ErrIllegalArguments).Detail(err))
+		return
+	}
+	data, err := json.Marshal(&clustermgr.ConfigSetArgs{Key: proto.ClusterFreezeConfigKey, Value: string(value)})
+	if err != nil {
+		span.Errorf("json marshal failed, error: %v", err)
+		c.RespondError(errors.Info(apierrors.ErrIllegalArguments).Detail(err))
+		return
+	}
+	proposeInfo := base.EncodeProposeInfo(s.ConfigMgr.GetModuleName(), configmgr.OperTypeSetConfig, data, base.ProposeContext{ReqID: span.TraceID()})
+	if err = s.raftNode.Propose(ctx, proposeInfo); err != nil {
+		span.Errorf("raft propose failed, err:%v ", err)
+		c.RespondError(apierrors.ErrRaftPropose)
+		return
+	}
+	span.Warnf("cluster frozen until %s, reason: %s", time.Unix(freeze.ExpireTime, 0), freeze.Reason)
+	c.RespondJSON(&freeze)
+}
+
+// ClusterUnfreeze unfreeze the cluster before expired
+func (s *Service) ClusterUnfreeze(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debug("accept ClusterUnfreeze request")
+
+	data, err := json.Marshal(&clustermgr.ConfigArgs{Key: proto.ClusterFreezeConfigKey})
+	if err != nil {
+		span.Errorf("json marshal failed, error: %v", err)
+		c.RespondError(errors.Info(apierrors.ErrConfigArgument).Detail(err))
+		return
+	}
+	proposeInfo := base.EncodeProposeInfo(s.ConfigMgr.GetModuleName(), configmgr.OperTypeDeleteConfig, data, base.ProposeContext{ReqID: span.TraceID()})
+	if err = s.raftNode.Propose(ctx, proposeInfo); err != nil {
+		span.Errorf("raft propose failed, err:%v ", err)
+		c.RespondError(apierrors.ErrRaftPropose)
+		return
+	}
+	span.Warn("cluster unfrozen")
+}
+
+// ClusterFreezeGet get freeze status of cluster
+func (s *Service) ClusterFreezeGet(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debug("accept ClusterFreezeGet request")
+
+	// linear read
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+
+	freeze, err := s.getClusterFreeze(ctx)
+	if err != nil {
+		span.Errorf("get cluster freeze failed: %v", err)
+		c.RespondError(err)
+		return
+	}
+	ret := &clustermgr.GetFreezeRet{}
+	if freeze.IsFrozen(time.Now()) {
+		ret.Frozen = true
+		ret.Freeze = freeze
+	}
+	c.RespondJSON(ret)
+}
+
+// rejectIfFrozen wraps the mutating handler, rejects the request with ErrClusterFrozen
+// if the cluster is frozen, the reads are not affected
+func (s *Service) rejectIfFrozen(f rpc.HandlerFunc) rpc.HandlerFunc {
+	return func(c *rpc.Context) {
+		ctx := c.Request.Context()
+		span := trace.SpanFromContextSafe(ctx)
+		freeze, err := s.getClusterFreeze(ctx)
+		if err != nil {
+			span.Errorf("get cluster freeze failed: %v", err)
+			c.RespondError(err)
+			return
+		}
+		if freeze.IsFrozen(time.Now()) {
+			span.Warnf("reject %s, cluster frozen until %s, reason: %s",
+				c.Request.URL.Path, time.Unix(freeze.ExpireTime, 0), freeze.Reason)
+			c.RespondError(apierrors.ErrClusterFrozen)
+			return
+		}
+		f(c)
+	}
+}
+
+func (s *Service) getClusterFreeze(ctx context.Context) (*clustermgr.ClusterFreeze, error) {
+	value, err := s.ConfigMgr.Get(ctx, proto.ClusterFreezeConfigKey)
+	if err != nil {
+		if err == os.ErrNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	freeze, err := clustermgr.DecodeClusterFreeze(value)
+	if err != nil {
+		return nil, errors.Info(apierrors.ErrConfigArgument).Detail(err)
+	}
+	return freeze, nil
+}
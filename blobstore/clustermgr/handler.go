@@ -24,101 +24,182 @@ func NewHandler(service *Service) *rpc.Router {
 	rpc.RegisterArgsParser(&clustermgr.ConfigArgs{}, "json")
 
 	// POST "/config/set?key={key}&value={value}"
-	rpc.POST("/config/set", service.ConfigSet, rpc.OptArgsBody())
+	rpc.POST("/config/set", service.ConfigSet, rpc.OptArgsBody(), rpc.OptArgsSchema(&clustermgr.ConfigSetArgs{}))
 
-	rpc.GET("/config/get", service.ConfigGet, rpc.OptArgsQuery())
+	rpc.GET("/config/get", service.ConfigGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ConfigArgs{}), rpc.OptRetSchema(""))
 
-	rpc.POST("/config/delete", service.ConfigDelete, rpc.OptArgsQuery())
+	rpc.POST("/config/delete", service.ConfigDelete, rpc.OptArgsQuery(), rpc.OptArgsSchema(&clustermgr.ConfigArgs{}))
+
+	rpc.GET("/config/list", service.ConfigList, rpc.OptRetSchema(&clustermgr.AllConfig{}))
+
+	rpc.POST("/config/batch", service.ConfigBatch, rpc.OptArgsBody(), rpc.OptArgsSchema(&clustermgr.ConfigBatchArgs{}))
+
+	rpc.RegisterArgsParser(&clustermgr.MaintenancePolicyArgs{}, "json")
+
+	rpc.POST("/config/maintenance/window/create", service.MaintenanceWindowCreate, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.MaintenanceWindow{}), rpc.OptRetSchema(&clustermgr.MaintenanceWindow{}))
+
+	rpc.GET("/config/maintenance/window/list", service.MaintenanceWindowList,
+		rpc.OptRetSchema(&clustermgr.ListMaintenanceWindowsRet{}))
+
+	rpc.GET("/config/maintenance/policy", service.MaintenancePolicyGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.MaintenancePolicyArgs{}), rpc.OptRetSchema(&clustermgr.MaintenancePolicy{}))
+
+	// mutating operations wrapped by rejectIfFrozen are rejected while the cluster is frozen
+	rpc.POST("/config/freeze", service.ClusterFreeze, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.FreezeArgs{}), rpc.OptRetSchema(&clustermgr.ClusterFreeze{}))
+
+	rpc.POST("/config/unfreeze", service.ClusterUnfreeze)
+
+	rpc.GET("/config/freeze/get", service.ClusterFreezeGet, rpc.OptRetSchema(&clustermgr.GetFreezeRet{}))
 
 	//==================blobnode disk==========================
 	rpc.RegisterArgsParser(&clustermgr.DiskInfoArgs{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.ListOptionArgs{}, "json")
+	rpc.RegisterArgsParser(&clustermgr.DiskSetArgs{}, "json")
+
+	rpc.POST("/diskid/alloc", service.rejectIfFrozen(service.DiskIDAlloc),
+		rpc.OptRetSchema(&clustermgr.DiskIDAllocRet{}))
+
+	rpc.GET("/disk/info", service.DiskInfo, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.DiskInfoArgs{}), rpc.OptRetSchema(&clustermgr.BlobNodeDiskInfo{}))
 
-	rpc.POST("/diskid/alloc", service.DiskIDAlloc)
+	rpc.POST("/disk/add", service.admitWith(service.registerAdmission, service.rejectIfFrozen(service.DiskAdd)), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.BlobNodeDiskInfo{}))
 
-	rpc.GET("/disk/info", service.DiskInfo, rpc.OptArgsQuery())
+	rpc.POST("/disk/set", service.rejectIfFrozen(service.DiskSet), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.DiskSetArgs{}))
 
-	rpc.POST("/disk/add", service.DiskAdd, rpc.OptArgsBody())
+	rpc.GET("/disk/set/check", service.DiskSetCheck, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.DiskSetArgs{}), rpc.OptRetSchema(&clustermgr.DiskSetCheckRet{}))
 
-	rpc.POST("/disk/set", service.DiskSet, rpc.OptArgsBody())
+	rpc.GET("/disk/list", service.DiskList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListOptionArgs{}), rpc.OptRetSchema(&clustermgr.ListDiskRet{}))
 
-	rpc.GET("/disk/list", service.DiskList, rpc.OptArgsQuery())
+	rpc.POST("/disk/heartbeat", service.admitWith(service.heartbeatAdmission, service.DiskHeartbeat), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.DisksHeartbeatArgs{}), rpc.OptRetSchema(&clustermgr.DisksHeartbeatRet{}))
 
-	rpc.POST("/disk/heartbeat", service.DiskHeartbeat, rpc.OptArgsBody())
+	rpc.POST("/disk/drop", service.rejectIfFrozen(service.DiskDrop), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.DiskInfoArgs{}))
 
-	rpc.POST("/disk/drop", service.DiskDrop, rpc.OptArgsBody())
+	rpc.POST("/disk/dropped", service.rejectIfFrozen(service.DiskDropped), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.DiskInfoArgs{}))
 
-	rpc.POST("/disk/dropped", service.DiskDropped, rpc.OptArgsBody())
+	rpc.GET("/disk/droppinglist", service.DiskDroppingList, rpc.OptRetSchema(&clustermgr.ListDiskRet{}))
 
-	rpc.GET("/disk/droppinglist", service.DiskDroppingList)
+	rpc.GET("/disk/drop/stuck/list", service.StuckDroppingDiskList,
+		rpc.OptRetSchema(&clustermgr.ListStuckDroppingDiskRet{}))
 
-	rpc.POST("/disk/access", service.DiskAccess, rpc.OptArgsBody())
+	rpc.POST("/disk/access", service.rejectIfFrozen(service.DiskAccess), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.DiskAccessArgs{}))
 
-	rpc.POST("/admin/disk/update", service.AdminDiskUpdate, rpc.OptArgsBody())
+	rpc.POST("/admin/disk/update", service.rejectIfFrozen(service.AdminDiskUpdate), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.BlobNodeDiskInfo{}))
 
 	//=====================blobnode==========================
 	rpc.RegisterArgsParser(&clustermgr.NodeInfoArgs{}, "json")
 
-	rpc.POST("/node/add", service.NodeAdd, rpc.OptArgsBody())
+	rpc.POST("/node/add", service.admitWith(service.registerAdmission, service.rejectIfFrozen(service.NodeAdd)), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.BlobNodeInfo{}), rpc.OptRetSchema(&clustermgr.NodeIDAllocRet{}))
 
-	rpc.POST("/node/drop", service.NodeDrop, rpc.OptArgsBody())
+	rpc.POST("/node/drop", service.rejectIfFrozen(service.NodeDrop), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.NodeInfoArgs{}))
 
-	rpc.GET("/node/info", service.NodeInfo, rpc.OptArgsQuery())
+	rpc.GET("/node/info", service.NodeInfo, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.NodeInfoArgs{}), rpc.OptRetSchema(&clustermgr.BlobNodeInfo{}))
 
-	rpc.GET("/topo/info", service.TopoInfo)
+	rpc.GET("/topo/info", service.TopoInfo, rpc.OptRetSchema(&clustermgr.TopoInfo{}))
+
+	rpc.POST("/admin/topo/check", service.AdminTopoCheck, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.TopoCheckArgs{}), rpc.OptRetSchema(&clustermgr.TopoCheckRet{}))
+
+	rpc.POST("/admin/node/relabel", service.rejectIfFrozen(service.AdminNodeRelabel), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.NodeRelabelArgs{}))
 
 	//==================shardnode disk==========================
-	rpc.POST("/shardnode/diskid/alloc", service.ShardNodeDiskIDAlloc)
+	rpc.POST("/shardnode/diskid/alloc", service.rejectIfFrozen(service.ShardNodeDiskIDAlloc),
+		rpc.OptRetSchema(&clustermgr.DiskIDAllocRet{}))
 
-	rpc.GET("/shardnode/disk/info", service.ShardNodeDiskInfo, rpc.OptArgsQuery())
+	rpc.GET("/shardnode/disk/info", service.ShardNodeDiskInfo, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.DiskInfoArgs{}), rpc.OptRetSchema(&clustermgr.ShardNodeDiskInfo{}))
 
-	rpc.POST("/shardnode/disk/add", service.ShardNodeDiskAdd, rpc.OptArgsBody())
+	rpc.POST("/shardnode/disk/add", service.admitWith(service.registerAdmission, service.rejectIfFrozen(service.ShardNodeDiskAdd)), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ShardNodeDiskInfo{}))
 
-	rpc.POST("/shardnode/disk/set", service.ShardNodeDiskSet, rpc.OptArgsBody())
+	rpc.POST("/shardnode/disk/set", service.rejectIfFrozen(service.ShardNodeDiskSet), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.DiskSetArgs{}))
 
-	rpc.GET("/shardnode/disk/list", service.ShardNodeDiskList, rpc.OptArgsQuery())
+	rpc.GET("/shardnode/disk/list", service.ShardNodeDiskList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListOptionArgs{}), rpc.OptRetSchema(&clustermgr.ListShardNodeDiskRet{}))
 
-	rpc.POST("/shardnode/disk/heartbeat", service.ShardNodeDiskHeartbeat, rpc.OptArgsBody())
+	rpc.POST("/shardnode/disk/heartbeat", service.admitWith(service.heartbeatAdmission, service.ShardNodeDiskHeartbeat), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ShardNodeDisksHeartbeatArgs{}))
 
-	rpc.POST("/admin/shardnode/disk/update", service.AdminShardNodeDiskUpdate, rpc.OptArgsBody())
+	rpc.POST("/admin/shardnode/disk/update", service.rejectIfFrozen(service.AdminShardNodeDiskUpdate), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ShardNodeDiskInfo{}))
 
 	//=====================shardnode==========================
-	rpc.POST("/shardnode/add", service.ShardNodeAdd, rpc.OptArgsBody())
+	rpc.POST("/shardnode/add", service.admitWith(service.registerAdmission, service.rejectIfFrozen(service.ShardNodeAdd)), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ShardNodeInfo{}), rpc.OptRetSchema(&clustermgr.NodeIDAllocRet{}))
+
+	rpc.GET("/shardnode/info", service.ShardNodeInfo, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.NodeInfoArgs{}), rpc.OptRetSchema(&clustermgr.ShardNodeInfo{}))
 
-	rpc.GET("/shardnode/info", service.ShardNodeInfo, rpc.OptArgsQuery())
+	rpc.GET("/shardnode/topo/info", service.ShardNodeTopoInfo, rpc.OptRetSchema(&clustermgr.TopoInfo{}))
 
-	rpc.GET("/shardnode/topo/info", service.ShardNodeTopoInfo)
+	rpc.POST("/admin/shardnode/topo/check", service.AdminShardNodeTopoCheck, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.TopoCheckArgs{}), rpc.OptRetSchema(&clustermgr.TopoCheckRet{}))
+
+	rpc.POST("/admin/shardnode/node/relabel", service.rejectIfFrozen(service.AdminShardNodeRelabel), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.NodeRelabelArgs{}))
 
 	//========================space============================
 	rpc.RegisterArgsParser(&clustermgr.GetSpaceArgs{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.AuthSpaceArgs{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.ListSpaceArgs{}, "json")
 
-	rpc.POST("/space/create", service.SpaceCreate, rpc.OptArgsBody())
+	rpc.POST("/space/create", service.rejectIfFrozen(service.SpaceCreate), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.CreateSpaceArgs{}))
 
-	rpc.GET("/space/get", service.SpaceGet, rpc.OptArgsQuery())
+	rpc.GET("/space/get", service.SpaceGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.GetSpaceArgs{}), rpc.OptRetSchema(&clustermgr.Space{}))
 
-	rpc.GET("/space/auth", service.SpaceAuth, rpc.OptArgsQuery())
+	rpc.GET("/space/auth", service.SpaceAuth, rpc.OptArgsQuery(), rpc.OptArgsSchema(&clustermgr.AuthSpaceArgs{}))
 
-	rpc.GET("/space/list", service.SpaceList, rpc.OptArgsQuery())
+	rpc.GET("/space/list", service.SpaceList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListSpaceArgs{}), rpc.OptRetSchema(&clustermgr.ListSpaceRet{}))
 
 	//========================route============================
 	rpc.RegisterArgsParser(&clustermgr.GetCatalogChangesArgs{}, "json")
+	rpc.RegisterArgsParser(&clustermgr.GetVolumeChangesArgs{}, "json")
+	rpc.RegisterArgsParser(&clustermgr.GetRouteChecksumArgs{}, "json")
+
+	rpc.GET("/catalogchanges/get", service.CatalogChangesGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.GetCatalogChangesArgs{}), rpc.OptRetSchema(&clustermgr.GetCatalogChangesRet{}))
 
-	rpc.GET("/catalogchanges/get", service.CatalogChangesGet, rpc.OptArgsQuery())
+	rpc.GET("/catalog/route/checksum", service.RouteChecksumGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.GetRouteChecksumArgs{}), rpc.OptRetSchema(&clustermgr.GetRouteChecksumRet{}))
+
+	rpc.GET("/volume/changes/get", service.VolumeChangesGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.GetVolumeChangesArgs{}), rpc.OptRetSchema(&clustermgr.GetVolumeChangesRet{}))
 
 	//==================service==========================
 	rpc.RegisterArgsParser(&clustermgr.GetServiceArgs{}, "json")
 
-	rpc.POST("/service/register", service.ServiceRegister, rpc.OptArgsBody())
+	rpc.POST("/service/register", service.rejectIfFrozen(service.ServiceRegister), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.RegisterArgs{}))
 
-	rpc.POST("/service/unregister", service.ServiceUnregister, rpc.OptArgsBody())
+	rpc.POST("/service/unregister", service.rejectIfFrozen(service.ServiceUnregister), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.UnregisterArgs{}))
 
-	rpc.GET("/service/get", service.ServiceGet, rpc.OptArgsQuery())
+	rpc.GET("/service/get", service.ServiceGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.GetServiceArgs{}), rpc.OptRetSchema(clustermgr.ServiceInfo{}))
 
-	rpc.POST("/service/heartbeat", service.ServiceHeartbeat, rpc.OptArgsBody())
+	rpc.POST("/service/heartbeat", service.ServiceHeartbeat, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.HeartbeatArgs{}))
 
-	rpc.GET("/service/list", service.ServiceList)
+	rpc.GET("/service/list", service.ServiceList, rpc.OptRetSchema(clustermgr.ServiceInfo{}))
 
 	//==================volume==========================
 	rpc.RegisterArgsParser(&clustermgr.GetVolumeArgs{}, "json")
@@ -127,91 +208,146 @@ func NewHandler(service *Service) *rpc.Router {
 	rpc.RegisterArgsParser(&clustermgr.ListVolumeUnitArgs{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.ListAllocatedVolumeArgs{}, "json")
 
-	rpc.GET("/volume/get", service.VolumeGet, rpc.OptArgsQuery())
+	rpc.GET("/volume/get", service.VolumeGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.GetVolumeArgs{}), rpc.OptRetSchema(&clustermgr.VolumeInfo{}))
+
+	rpc.GET("/volume/list", service.VolumeList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListVolumeArgs{}), rpc.OptRetSchema(&clustermgr.ListVolumes{}))
+
+	rpc.GET("/v2/volume/list", service.V2VolumeList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListVolumeV2Args{}), rpc.OptRetSchema(&clustermgr.ListVolumes{}))
+
+	rpc.POST("/volume/alloc", service.rejectIfFrozen(service.VolumeAlloc), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.AllocVolumeArgs{}), rpc.OptRetSchema(&clustermgr.AllocatedVolumeInfos{}))
+
+	rpc.POST("/volume/update", service.rejectIfFrozen(service.VolumeUpdate), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.UpdateVolumeArgs{}))
 
-	rpc.GET("/volume/list", service.VolumeList, rpc.OptArgsQuery())
+	rpc.POST("/volume/retain", service.VolumeRetain, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.RetainVolumeArgs{}), rpc.OptRetSchema(&clustermgr.RetainVolumes{}))
 
-	rpc.GET("/v2/volume/list", service.V2VolumeList, rpc.OptArgsQuery())
+	rpc.POST("/volume/lease/renew", service.rejectIfFrozen(service.VolumeLeaseRenew), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.RenewVolumeLeaseArgs{}), rpc.OptRetSchema(clustermgr.RenewVolumeLeaseRet{}))
 
-	rpc.POST("/volume/alloc", service.VolumeAlloc, rpc.OptArgsBody())
+	rpc.POST("/volume/lock", service.rejectIfFrozen(service.VolumeLock), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.LockVolumeArgs{}))
 
-	rpc.POST("/volume/update", service.VolumeUpdate, rpc.OptArgsBody())
+	rpc.POST("/volume/unlock", service.rejectIfFrozen(service.VolumeUnlock), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.UnlockVolumeArgs{}))
 
-	rpc.POST("/volume/retain", service.VolumeRetain, rpc.OptArgsBody())
+	rpc.POST("/volume/unit/alloc", service.rejectIfFrozen(service.VolumeUnitAlloc), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.AllocVolumeUnitArgs{}), rpc.OptRetSchema(&clustermgr.AllocVolumeUnit{}))
 
-	rpc.POST("/volume/lock", service.VolumeLock, rpc.OptArgsBody())
+	rpc.POST("/volume/unit/release", service.rejectIfFrozen(service.VolumeUnitRelease), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ReleaseVolumeUnitArgs{}))
 
-	rpc.POST("/volume/unlock", service.VolumeUnlock, rpc.OptArgsBody())
+	rpc.GET("/volume/unit/list", service.VolumeUnitList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListVolumeUnitArgs{}), rpc.OptRetSchema(&clustermgr.ListVolumeUnitInfos{}))
 
-	rpc.POST("/volume/unit/alloc", service.VolumeUnitAlloc, rpc.OptArgsBody())
+	rpc.GET("/volume/unit/disk/list", service.VolumeUnitsOnDiskList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListVolumeUnitArgs{}), rpc.OptRetSchema(&clustermgr.ListVolumeUnitsOnDiskRet{}))
 
-	rpc.POST("/volume/unit/release", service.VolumeUnitRelease, rpc.OptArgsBody())
+	rpc.GET("/volume/allocated/list", service.VolumeAllocatedList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListAllocatedVolumeArgs{}), rpc.OptRetSchema(&clustermgr.AllocatedVolumeInfos{}))
 
-	rpc.GET("/volume/unit/list", service.VolumeUnitList, rpc.OptArgsQuery())
+	rpc.POST("/admin/update/volume/unit", service.rejectIfFrozen(service.AdminUpdateVolumeUnit), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.AdminUpdateUnitArgs{}))
 
-	rpc.GET("/volume/allocated/list", service.VolumeAllocatedList, rpc.OptArgsQuery())
+	rpc.POST("/admin/update/volume", service.rejectIfFrozen(service.AdminUpdateVolume), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.VolumeInfoBase{}))
 
-	rpc.POST("/admin/update/volume/unit", service.AdminUpdateVolumeUnit, rpc.OptArgsBody())
+	rpc.POST("/admin/volume/alloc/simulate", service.AdminVolumeAllocSimulate, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.AllocSimulateArgs{}), rpc.OptRetSchema(&clustermgr.AllocSimulateRet{}))
 
-	rpc.POST("/admin/update/volume", service.AdminUpdateVolume, rpc.OptArgsBody())
+	rpc.POST("/admin/volume/placement/check", service.AdminVolumePlacementCheck, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.PlacementCheckArgs{}), rpc.OptRetSchema(&clustermgr.PlacementCheckRet{}))
 
 	//==================shard==========================
 	rpc.RegisterArgsParser(&clustermgr.GetShardArgs{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.ListShardArgs{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.ListShardUnitArgs{}, "json")
 
-	rpc.GET("/shard/get", service.ShardGet, rpc.OptArgsQuery())
+	rpc.GET("/shard/get", service.ShardGet, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.GetShardArgs{}), rpc.OptRetSchema(&clustermgr.Shard{}))
 
-	rpc.GET("/shard/list", service.ShardList, rpc.OptArgsQuery())
+	rpc.GET("/shard/list", service.ShardList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListShardArgs{}), rpc.OptRetSchema(&clustermgr.ListShardRet{}))
 
-	rpc.GET("/shard/unit/list", service.ShardUnitList, rpc.OptArgsQuery())
+	rpc.GET("/shard/unit/list", service.ShardUnitList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListShardUnitArgs{}), rpc.OptRetSchema(&clustermgr.ListShardUnitRet{}))
 
-	rpc.POST("/shard/unit/alloc", service.ShardUnitAlloc, rpc.OptArgsBody())
+	rpc.POST("/shard/unit/alloc", service.rejectIfFrozen(service.ShardUnitAlloc), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.AllocShardUnitArgs{}), rpc.OptRetSchema(&clustermgr.AllocShardUnitRet{}))
 
-	rpc.POST("/shard/update", service.ShardUpdate, rpc.OptArgsBody())
+	rpc.POST("/shard/update", service.rejectIfFrozen(service.ShardUpdate), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.UpdateShardArgs{}))
 
-	rpc.POST("/shard/report", service.ShardReport, rpc.OptArgsBody())
+	rpc.POST("/shard/report", service.ShardReport, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ShardReportArgs{}), rpc.OptRetSchema(&clustermgr.ShardReportRet{}))
 
-	rpc.POST("/admin/update/shard/unit", service.AdminUpdateShardUnit, rpc.OptArgsBody())
+	rpc.POST("/admin/update/shard/unit", service.rejectIfFrozen(service.AdminUpdateShardUnit), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.AdminUpdateShardUnitArgs{}))
 
-	rpc.POST("/admin/update/shard", service.AdminUpdateShard, rpc.OptArgsBody())
+	rpc.POST("/admin/update/shard", service.rejectIfFrozen(service.AdminUpdateShard), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.Shard{}))
 
 	//==================chunk==========================
 
-	rpc.POST("/chunk/report", service.ChunkReport, rpc.OptArgsBody())
+	rpc.POST("/chunk/report", service.ChunkReport, rpc.OptArgsBody(), rpc.OptArgsSchema(&clustermgr.ReportChunkArgs{}))
 
-	rpc.POST("/chunk/set/compact", service.ChunkSetCompact, rpc.OptArgsBody())
+	rpc.POST("/chunk/set/compact", service.rejectIfFrozen(service.ChunkSetCompact), rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.SetCompactChunkArgs{}))
+
+	rpc.POST("/chunk/usage/report", service.ChunkUsageReport, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ReportChunkUsageArgs{}))
+
+	rpc.GET("/disk/usage/mismatch/list", service.DiskUsageMismatchList,
+		rpc.OptRetSchema(&clustermgr.ListDiskUsageMismatchRet{}))
 
 	//==================srv==========================
 
-	rpc.POST("/bid/alloc", service.BidAlloc, rpc.OptArgsBody())
+	rpc.POST("/bid/alloc", service.BidAlloc, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.BidScopeArgs{}), rpc.OptRetSchema(&clustermgr.BidScopeRet{}))
 
 	//==================manage==========================
 
-	rpc.POST("/member/add", service.MemberAdd, rpc.OptArgsBody())
+	rpc.POST("/member/add", service.MemberAdd, rpc.OptArgsBody(), rpc.OptArgsSchema(&clustermgr.AddMemberArgs{}))
+
+	rpc.POST("/member/remove", service.MemberRemove, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.RemoveMemberArgs{}))
 
-	rpc.POST("/member/remove", service.MemberRemove, rpc.OptArgsBody())
+	rpc.POST("/member/replace", service.MemberReplace, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.ReplaceMemberArgs{}))
 
-	rpc.POST("/leadership/transfer", service.LeadershipTransfer, rpc.OptArgsBody())
+	rpc.GET("/member/replace/status", service.MemberReplaceStatus, rpc.OptRetSchema(&clustermgr.ReplaceMemberStatus{}))
 
-	rpc.GET("/stat", service.Stat)
+	rpc.POST("/leadership/transfer", service.LeadershipTransfer, rpc.OptArgsBody(),
+		rpc.OptArgsSchema(&clustermgr.RemoveMemberArgs{}))
+
+	rpc.GET("/stat", service.Stat, rpc.OptRetSchema(&clustermgr.StatInfo{}))
+
+	rpc.GET("/alert/list", service.AlertList, rpc.OptRetSchema(&clustermgr.ListAlertRet{}))
 
 	rpc.GET("/snapshot/dump", service.SnapshotDump)
 
+	rpc.GET("/schema", service.APISchema, rpc.OptRetSchema(&rpc.APISchema{}))
+
 	//==================kv==========================
 	rpc.RegisterArgsParser(&clustermgr.ListKvOpts{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.GetKvArgs{}, "json")
 	rpc.RegisterArgsParser(&clustermgr.DeleteKvArgs{}, "json")
 
-	rpc.GET("/kv/get/:key", service.KvGet, rpc.OptArgsURI())
+	rpc.GET("/kv/get/:key", service.KvGet, rpc.OptArgsURI(),
+		rpc.OptArgsSchema(&clustermgr.GetKvArgs{}), rpc.OptRetSchema(&clustermgr.GetKvRet{}))
 
-	rpc.POST("/kv/delete/:key", service.KvDelete, rpc.OptArgsURI())
+	rpc.POST("/kv/delete/:key", service.KvDelete, rpc.OptArgsURI(), rpc.OptArgsSchema(&clustermgr.DeleteKvArgs{}))
 
-	rpc.POST("/kv/set/:key", service.KvSet, rpc.OptArgsBody())
+	rpc.POST("/kv/set/:key", service.KvSet, rpc.OptArgsBody(), rpc.OptArgsSchema(&clustermgr.SetKvArgs{}))
 
-	rpc.POST("/kv/set", service.KvSet, rpc.OptArgsBody())
+	rpc.POST("/kv/set", service.KvSet, rpc.OptArgsBody(), rpc.OptArgsSchema(&clustermgr.SetKvArgs{}))
 
-	rpc.GET("/kv/list", service.KvList, rpc.OptArgsQuery())
+	rpc.GET("/kv/list", service.KvList, rpc.OptArgsQuery(),
+		rpc.OptArgsSchema(&clustermgr.ListKvOpts{}), rpc.OptRetSchema(&clustermgr.ListKvRet{}))
 
 	return rpc.DefaultRouter
 }
@@ -0,0 +1,149 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/configmgr"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+// maintenanceWindowScopeName scope of maintenance window id, ids are never reused
+// even if the windows are expired and cleaned
+const maintenanceWindowScopeName = "maintenance-window"
+
+// MaintenanceWindowCreate create a maintenance window with allocated id, the id in args is ignored,
+// expired windows are cleaned at the same time when applying
+func (s *Service) MaintenanceWindowCreate(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.MaintenanceWindow)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept MaintenanceWindowCreate request, args: %v", args)
+
+	if err := args.Check(); err != nil {
+		span.Warnf("invalid maintenance window: %v", err)
+		c.RespondError(errors.Info(apierrors.ErrIllegalArguments).Detail(err))
+		return
+	}
+
+	_, id, err := s.ScopeMgr.Alloc(ctx, maintenanceWindowScopeName, 1)
+	if err != nil {
+		span.Errorf("alloc maintenance window id failed: %v", err)
+		c.RespondError(err)
+		return
+	}
+	args.ID = uint32(id)
+
+	data, err := json.Marshal(&configmgr.MaintenanceWindowCreateArgs{Window: *args, Time: time.Now().Unix()})
+	if err != nil {
+		span.Errorf("json marshal failed, error: %v", err)
+		c.RespondError(errors.Info(apierrors.ErrIllegalArguments).Detail(err))
+		return
+	}
+	proposeInfo := base.EncodeProposeInfo(s.ConfigMgr.GetModuleName(), configmgr.OperTypeCreateMaintenanceWindow, data, base.ProposeContext{ReqID: span.TraceID()})
+	if err = s.raftNode.Propose(ctx, proposeInfo); err != nil {
+		span.Errorf("raft propose failed, err:%v ", err)
+		c.RespondError(apierrors.ErrRaftPropose)
+		return
+	}
+	c.RespondJSON(args)
+}
+
+// MaintenanceWindowList list all maintenance windows, expired windows are listed
+// until they are cleaned by the next create
+func (s *Service) MaintenanceWindowList(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debug("accept MaintenanceWindowList request")
+
+	// linear read
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+
+	windows, err := s.getMaintenanceWindows(ctx)
+	if err != nil {
+		span.Errorf("get maintenance windows failed: %v", err)
+		c.RespondError(err)
+		return
+	}
+	if windows == nil {
+		windows = make(clustermgr.MaintenanceWindows, 0)
+	}
+	c.RespondJSON(&clustermgr.ListMaintenanceWindowsRet{Windows: windows})
+}
+
+// MaintenancePolicyGet dry-run the effective policy of background tasks at the time
+func (s *Service) MaintenancePolicyGet(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.MaintenancePolicyArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept MaintenancePolicyGet request, args: %v", args)
+
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+
+	windows, err := s.getMaintenanceWindows(ctx)
+	if err != nil {
+		span.Errorf("get maintenance windows failed: %v", err)
+		c.RespondError(err)
+		return
+	}
+
+	t := time.Now()
+	if args.Time > 0 {
+		t = time.Unix(args.Time, 0)
+	}
+	policy := windows.Policy(t)
+	c.RespondJSON(&policy)
+}
+
+func (s *Service) getMaintenanceWindows(ctx context.Context) (clustermgr.MaintenanceWindows, error) {
+	value, err := s.ConfigMgr.Get(ctx, proto.MaintenanceWindowsConfigKey)
+	if err != nil {
+		if err == os.ErrNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	windows, err := clustermgr.DecodeMaintenanceWindows(value)
+	if err != nil {
+		return nil, errors.Info(apierrors.ErrConfigArgument).Detail(err)
+	}
+	return windows, nil
+}
@@ -15,6 +15,7 @@
 package clustermgr
 
 import (
+	"context"
 	"io"
 	"strconv"
 
@@ -118,15 +119,33 @@ func (s *Service) Stat(c *rpc.Context) {
 	ctx := c.Request.Context()
 	span := trace.SpanFromContextSafe(ctx)
 	span.Info("accept Stat request")
+	c.RespondJSON(s.statInfo(ctx))
+}
 
+func (s *Service) statInfo(ctx context.Context) *clustermgr.StatInfo {
 	ret := new(clustermgr.StatInfo)
 	ret.RaftStatus = s.raftNode.Status()
 	ret.LeaderHost = s.raftNode.GetLeaderHost()
 	ret.BlobNodeSpaceStat = *(s.BlobNodeMgr.Stat(ctx, proto.DiskTypeHDD))
+	ret.BlobNodeDiskTypeSpaceStat = make(map[string]clustermgr.SpaceStatInfo)
+	for diskType := proto.DiskTypeHDD; diskType < proto.DiskTypeMax; diskType++ {
+		if stat := s.BlobNodeMgr.Stat(ctx, diskType); stat.TotalDisk > 0 {
+			ret.BlobNodeDiskTypeSpaceStat[diskType.String()] = *stat
+		}
+	}
 	ret.ShardNodeSpaceStat = *(s.ShardNodeMgr.Stat(ctx, proto.DiskTypeNVMeSSD))
 	ret.VolumeStat = s.VolumeMgr.Stat(ctx)
 	ret.ReadOnly = s.Readonly
-	c.RespondJSON(ret)
+	return ret
+}
+
+// APISchema returns schema of clustermgr http api, it's generated from route registration
+func (s *Service) APISchema(c *rpc.Context) {
+	c.RespondJSON(&rpc.APISchema{
+		Service: "clustermgr",
+		Routes:  rpc.Routes(),
+		Errors:  apierrors.ErrorSchemas(apierrors.CodeCMUnexpect, 999),
+	})
 }
 
 // SnapshotDump will dump all data using snapshot
@@ -18,6 +18,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/clustermgr/persistence/volumedb"
 	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/kvstore"
+	"github.com/cubefs/cubefs/blobstore/common/raftserver"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 )
 
@@ -119,3 +120,74 @@ func TestManage(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestMemberReplace(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	status, err := testClusterClient.ReplaceMemberStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, clustermgr.ReplaceMemberStage(""), status.Stage)
+
+	args := &clustermgr.ReplaceMemberArgs{OldPeerID: 10, PeerID: 2, Host: "127.0.0.1", NodeHost: "127.0.0.2"}
+	err = testClusterClient.ReplaceMember(ctx, args)
+	require.Equal(t, apierrors.ErrIllegalArguments.Error(), err.Error())
+
+	// not allow to replace leader
+	args.OldPeerID = 1
+	err = testClusterClient.ReplaceMember(ctx, args)
+	require.Equal(t, apierrors.ErrRequestNotAllow.Error(), err.Error())
+}
+
+func TestAPISchema(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	schema, err := testClusterClient.APISchema(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "clustermgr", schema.Service)
+	require.Less(t, 0, len(schema.Errors))
+	require.Equal(t, apierrors.CodeCMUnexpect, schema.Errors[0].Code)
+
+	routes := make(map[string]rpc.RouteSchema, len(schema.Routes))
+	for _, route := range schema.Routes {
+		routes[route.Method+" "+route.Path] = route
+	}
+	diskInfo, ok := routes["GET /disk/info"]
+	require.True(t, ok)
+	require.Equal(t, []string{"query"}, diskInfo.ArgsIn)
+	require.Equal(t, "clustermgr.DiskInfoArgs", diskInfo.Args.Name)
+	require.Equal(t, "disk_id", diskInfo.Args.Fields[0].Name)
+	require.Equal(t, "clustermgr.BlobNodeDiskInfo", diskInfo.Ret.Name)
+
+	kvGet, ok := routes["GET /kv/get/:key"]
+	require.True(t, ok)
+	require.Equal(t, []string{"key"}, kvGet.PathParams)
+
+	_, ok = routes["GET /schema"]
+	require.True(t, ok)
+}
+
+func TestCheckQuorum(t *testing.T) {
+	status := raftserver.Status{Id: 1, Peers: []raftserver.Peer{
+		{Id: 1},
+		{Id: 2, RecentActive: true},
+		{Id: 3},
+		{Id: 4, IsLearner: true, RecentActive: true},
+	}}
+	require.NoError(t, checkQuorum(status, 0, 0))
+	require.NoError(t, checkQuorum(status, 4, 0))
+	require.NoError(t, checkQuorum(status, 4, 3))
+	require.NoError(t, checkQuorum(status, 0, 3))
+
+	status.Peers[1].RecentActive = false
+	require.Error(t, checkQuorum(status, 0, 0))
+	require.Error(t, checkQuorum(status, 4, 0))
+	// removing the inactive one
+	require.NoError(t, checkQuorum(status, 4, 3))
+	require.Error(t, checkQuorum(status, 0, 3))
+}
@@ -0,0 +1,257 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/raftserver"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+/*
+	member.go implements the member replacement workflow of raft
+*/
+
+const (
+	memberCatchUpInterval = time.Second
+	memberCatchUpTimeout  = 30 * time.Minute
+	// the learner is caught up if its log lags behind the commit within it
+	memberCatchUpLag = 100
+
+	peerStateReplicate = "StateReplicate"
+)
+
+var (
+	errReplaceLeaderChanged = errors.New("leadership changed during member replacement")
+	errReplaceServiceClosed = errors.New("service closed during member replacement")
+	errReplaceCatchUpTimout = errors.New("wait for the new member catching up timeout")
+)
+
+// MemberReplace replaces the old member in background by steps, only one replacement
+// runs at a time on the leader. The quorum is checked before the voters changed.
+func (s *Service) MemberReplace(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.ReplaceMemberArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Infof("accept MemberReplace request, args: %v", args)
+
+	if args.OldPeerID == args.PeerID || args.PeerID == 0 || args.Host == "" {
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	status := s.raftNode.Status()
+	var old *raftserver.Peer
+	for i := range status.Peers {
+		peer := &status.Peers[i]
+		if peer.Id == args.OldPeerID {
+			old = peer
+			continue
+		}
+		if peer.Id == args.PeerID || peer.Host == args.Host {
+			c.RespondError(apierrors.ErrDuplicatedMemberInfo)
+			return
+		}
+	}
+	if old == nil {
+		span.Warnf("old peer_id not exist")
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	// not allow to remove leader directly, must transfer leadership firstly
+	if args.OldPeerID == status.Leader {
+		c.RespondError(apierrors.ErrRequestNotAllow)
+		return
+	}
+	mc, err := marshalMemberContext(args.NodeHost)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+	if err = checkQuorum(status, args.PeerID, args.OldPeerID); err != nil {
+		span.Warnf("replace member not allowed, err: %s", err.Error())
+		c.RespondError(apierrors.ErrRequestNotAllow)
+		return
+	}
+
+	s.replaceLock.Lock()
+	if s.replaceStatus != nil && !s.replaceStatus.Finished() {
+		s.replaceLock.Unlock()
+		span.Warnf("member replacement is running: %+v", *s.replaceStatus)
+		c.RespondError(apierrors.ErrRequestNotAllow)
+		return
+	}
+	now := time.Now().Unix()
+	s.replaceStatus = &clustermgr.ReplaceMemberStatus{
+		ReplaceMemberArgs: *args,
+		Stage:             clustermgr.ReplaceMemberStageAddLearner,
+		StartAt:           now,
+		UpdateAt:          now,
+	}
+	s.replaceLock.Unlock()
+
+	go s.replaceMember(*args, old.IsLearner, mc)
+}
+
+// MemberReplaceStatus returns progress of the last member replacement on leader
+func (s *Service) MemberReplaceStatus(c *rpc.Context) {
+	if !s.raftNode.IsLeader() {
+		s.forwardToLeader(c.Writer, c.Request)
+		return
+	}
+	s.replaceLock.Lock()
+	defer s.replaceLock.Unlock()
+	if s.replaceStatus == nil {
+		c.RespondJSON(&clustermgr.ReplaceMemberStatus{})
+		return
+	}
+	c.RespondJSON(*s.replaceStatus)
+}
+
+func (s *Service) replaceMember(args clustermgr.ReplaceMemberArgs, learner bool, mc []byte) {
+	span, ctx := trace.StartSpanFromContext(context.Background(), "replace-member")
+	if err := s.doReplaceMember(ctx, args, learner, mc); err != nil {
+		span.Errorf("replace member %+v failed, err: %s", args, err.Error())
+		s.setReplaceStage(clustermgr.ReplaceMemberStageFailed, err)
+		return
+	}
+	span.Infof("replace member %+v done", args)
+	s.setReplaceStage(clustermgr.ReplaceMemberStageDone, nil)
+}
+
+func (s *Service) doReplaceMember(ctx context.Context, args clustermgr.ReplaceMemberArgs, learner bool, mc []byte) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	// 1. add the new member as learner, it does not change the quorum
+	err := s.raftNode.AddMember(ctx, raftserver.Member{NodeID: args.PeerID, Host: args.Host, Learner: true, Context: mc})
+	if err != nil {
+		return err
+	}
+
+	// 2. wait for the learner catching up with the leader
+	s.setReplaceStage(clustermgr.ReplaceMemberStageCatchUp, nil)
+	if err = s.waitMemberCatchUp(ctx, args.PeerID); err != nil {
+		return err
+	}
+
+	// 3. promote the learner to voter if the old member is a voter
+	if !learner {
+		s.setReplaceStage(clustermgr.ReplaceMemberStagePromote, nil)
+		if !s.raftNode.IsLeader() {
+			return errReplaceLeaderChanged
+		}
+		if err = checkQuorum(s.raftNode.Status(), args.PeerID, 0); err != nil {
+			return err
+		}
+		err = s.raftNode.AddMember(ctx, raftserver.Member{NodeID: args.PeerID, Host: args.Host, Learner: false, Context: mc})
+		if err != nil {
+			return err
+		}
+		span.Infof("member %d promoted", args.PeerID)
+	}
+
+	// 4. remove the old member
+	s.setReplaceStage(clustermgr.ReplaceMemberStageRemoveOld, nil)
+	if !s.raftNode.IsLeader() {
+		return errReplaceLeaderChanged
+	}
+	if err = checkQuorum(s.raftNode.Status(), 0, args.OldPeerID); err != nil {
+		return err
+	}
+	return s.raftNode.RemoveMember(ctx, args.OldPeerID)
+}
+
+func (s *Service) waitMemberCatchUp(ctx context.Context, peerID uint64) error {
+	span := trace.SpanFromContextSafe(ctx)
+	ticker := time.NewTicker(memberCatchUpInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(memberCatchUpTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			return errReplaceCatchUpTimout
+		case <-s.closeCh:
+			return errReplaceServiceClosed
+		}
+
+		if !s.raftNode.IsLeader() {
+			return errReplaceLeaderChanged
+		}
+		status := s.raftNode.Status()
+		for _, peer := range status.Peers {
+			if peer.Id != peerID {
+				continue
+			}
+			var lag uint64
+			if status.Commit > peer.Match {
+				lag = status.Commit - peer.Match
+			}
+			s.setReplaceLag(lag)
+			if peer.State == peerStateReplicate && lag <= memberCatchUpLag {
+				span.Infof("member %d caught up, match: %d, commit: %d", peerID, peer.Match, status.Commit)
+				return nil
+			}
+		}
+	}
+}
+
+func (s *Service) setReplaceStage(stage clustermgr.ReplaceMemberStage, err error) {
+	s.replaceLock.Lock()
+	s.replaceStatus.Stage = stage
+	if err != nil {
+		s.replaceStatus.Error = err.Error()
+	}
+	s.replaceStatus.UpdateAt = time.Now().Unix()
+	s.replaceLock.Unlock()
+}
+
+func (s *Service) setReplaceLag(lag uint64) {
+	s.replaceLock.Lock()
+	s.replaceStatus.Lag = lag
+	s.replaceStatus.UpdateAt = time.Now().Unix()
+	s.replaceLock.Unlock()
+}
+
+// checkQuorum returns error if the active voters could not make up the quorum
+// after the learner promoted or the member removed, zero means none.
+func checkQuorum(status raftserver.Status, promoted, removed uint64) error {
+	voters, active := 0, 0
+	for _, peer := range status.Peers {
+		if peer.Id == removed || (peer.IsLearner && peer.Id != promoted) {
+			continue
+		}
+		voters++
+		if peer.RecentActive || peer.Id == status.Id {
+			active++
+		}
+	}
+	if active < voters/2+1 {
+		return fmt.Errorf("active voters %d could not make up the quorum of %d voters", active, voters)
+	}
+	return nil
+}
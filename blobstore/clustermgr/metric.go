@@ -18,6 +18,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -59,12 +60,62 @@ var (
 		},
 		[]string{"region", "cluster"},
 	)
+	admissionQueuedMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "blobstore",
+			Subsystem: "clusterMgr",
+			Name:      "admission_queued",
+			Help:      "queued register or heartbeat requests",
+		},
+		[]string{"region", "cluster", "kind"},
+	)
+	diskUsageMismatchMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "blobstore",
+			Subsystem: "clusterMgr",
+			Name:      "disk_usage_mismatch",
+			Help:      "disks whose heartbeat free space disagrees with chunk usage",
+		},
+		[]string{"region", "cluster"},
+	)
+	dropStuckDiskMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "blobstore",
+			Subsystem: "clusterMgr",
+			Name:      "disk_drop_stuck",
+			Help:      "dropping disks still without migration progress after requeued",
+		},
+		[]string{"region", "cluster"},
+	)
+	alertFiringMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "blobstore",
+			Subsystem: "clusterMgr",
+			Name:      "alert_firing",
+			Help:      "firing alerts of rules",
+		},
+		[]string{"region", "cluster", "rule", "severity"},
+	)
+	admissionRejectedMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "blobstore",
+			Subsystem: "clusterMgr",
+			Name:      "admission_rejected",
+			Help:      "rejected register or heartbeat requests",
+		},
+		[]string{"region", "cluster", "kind", "reason"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(raftStatMetric)
 	prometheus.MustRegister(diskHeartbeatChangeMetric)
 	prometheus.MustRegister(VolInconsistencyMetric)
+	prometheus.MustRegister(admissionQueuedMetric)
+	prometheus.MustRegister(admissionRejectedMetric)
+	prometheus.MustRegister(diskUsageMismatchMetric)
+	prometheus.MustRegister(dropStuckDiskMetric)
+	prometheus.MustRegister(alertFiringMetric)
 }
 
 func (s *Service) report(ctx context.Context) {
@@ -94,3 +145,20 @@ func (s *Service) reportShardNodeHeartbeatChange(num float64) {
 	shardNodeDiskHeartbeatChangeMetric.Reset()
 	shardNodeDiskHeartbeatChangeMetric.WithLabelValues(s.Region, s.ClusterID.ToString()).Set(num)
 }
+
+func (s *Service) reportDiskUsageMismatch(num float64) {
+	diskUsageMismatchMetric.Reset()
+	diskUsageMismatchMetric.WithLabelValues(s.Region, s.ClusterID.ToString()).Set(num)
+}
+
+func (s *Service) reportStuckDroppingDisk(num float64) {
+	dropStuckDiskMetric.Reset()
+	dropStuckDiskMetric.WithLabelValues(s.Region, s.ClusterID.ToString()).Set(num)
+}
+
+func (s *Service) reportFiringAlert(alerts []clustermgr.AlertEvent) {
+	alertFiringMetric.Reset()
+	for _, alert := range alerts {
+		alertFiringMetric.WithLabelValues(s.Region, s.ClusterID.ToString(), alert.Rule, alert.Severity).Add(1)
+	}
+}
@@ -48,6 +48,8 @@ type SpaceInfoRecord struct {
 	FieldMetas []clustermgr.FieldMeta `json:"field_metas"`
 	AccessKey  string                 `json:"access_key"`
 	SecretKey  string                 `json:"secret_key"`
+	// WormRetentionSecs is the worm retention of space, zero means not worm space
+	WormRetentionSecs uint64 `json:"worm_retention_secs,omitempty"`
 }
 
 type RouteInfoRecord struct {
@@ -45,17 +45,18 @@ func OpenBlobNodeDiskTable(db kvstore.KVStore, ensureIndex bool) (*BlobNodeDiskT
 			diskTbl:        db.Table(diskCF),
 			droppedDiskTbl: db.Table(diskDropCF),
 			indexes: map[string]indexItem{
-				diskStatusIndex:  {indexNames: []string{diskStatusIndex}, tbl: db.Table(diskStatusIndexCF)},
-				diskHostIndex:    {indexNames: []string{diskHostIndex}, tbl: db.Table(diskHostIndexCF)},
-				diskIDCIndex:     {indexNames: []string{diskIDCIndex}, tbl: db.Table(diskIDCIndexCF)},
-				diskIDCRACKIndex: {indexNames: strings.Split(diskIDCRACKIndex, "-"), tbl: db.Table(diskIDCRackIndexCF)},
+				diskStatusIndex:   {indexNames: []string{diskStatusIndex}, tbl: db.Table(diskStatusIndexCF)},
+				diskHostIndex:     {indexNames: []string{diskHostIndex}, tbl: db.Table(diskHostIndexCF)},
+				diskIDCIndex:      {indexNames: []string{diskIDCIndex}, tbl: db.Table(diskIDCIndexCF)},
+				diskIDCRACKIndex:  {indexNames: strings.Split(diskIDCRACKIndex, "-"), tbl: db.Table(diskIDCRackIndexCF)},
+				diskCreateAtIndex: {indexNames: []string{diskCreateAtIndex}, tbl: db.Table(diskCreateAtIndexCF)},
 			},
 		},
 	}
 	table.diskTable.rd = table
 
-	// ensure index
-	if ensureIndex {
+	// ensure index, the create time index was added later and built if empty
+	if ensureIndex || table.diskTable.isIndexEmpty(diskCreateAtIndex) {
 		list, err := table.GetAllDisks()
 		if err != nil {
 			return nil, errors.Info(err, "get all disk failed").Detail(err)
@@ -98,6 +99,29 @@ func (b *BlobNodeDiskTable) ListDisk(opt *clustermgr.ListOptionArgs) ([]*BlobNod
 	return ret, err
 }
 
+// ListDiskWithFilter list disks matched with option and the filter
+func (b *BlobNodeDiskTable) ListDiskWithFilter(opt *clustermgr.ListOptionArgs,
+	filter func(*BlobNodeDiskInfoRecord) bool,
+) ([]*BlobNodeDiskInfoRecord, error) {
+	ret := make([]*BlobNodeDiskInfoRecord, 0)
+	err := b.diskTable.listDisk(opt, b.wrapFilter(filter), func(i interface{}) {
+		ret = append(ret, i.(*BlobNodeDiskInfoRecord))
+	})
+	return ret, err
+}
+
+// ListDiskByCreateTime list disks matched with option and the filter in order of create time,
+// return the cursor of the last disk
+func (b *BlobNodeDiskTable) ListDiskByCreateTime(opt *clustermgr.ListOptionArgs,
+	filter func(*BlobNodeDiskInfoRecord) bool,
+) ([]*BlobNodeDiskInfoRecord, string, error) {
+	ret := make([]*BlobNodeDiskInfoRecord, 0)
+	cursor, err := b.diskTable.listDiskByIndex(diskCreateAtIndex, opt, b.wrapFilter(filter), func(i interface{}) {
+		ret = append(ret, i.(*BlobNodeDiskInfoRecord))
+	})
+	return ret, cursor, err
+}
+
 func (b *BlobNodeDiskTable) AddDisk(disk *BlobNodeDiskInfoRecord) error {
 	return b.diskTable.AddDisk(disk.DiskID, disk)
 }
@@ -151,6 +175,15 @@ func (b *BlobNodeDiskTable) marshalRecord(v interface{}) ([]byte, error) {
 	return data, nil
 }
 
+func (b *BlobNodeDiskTable) wrapFilter(filter func(*BlobNodeDiskInfoRecord) bool) func(i interface{}) bool {
+	if filter == nil {
+		return nil
+	}
+	return func(i interface{}) bool {
+		return filter(i.(*BlobNodeDiskInfoRecord))
+	}
+}
+
 func (b *BlobNodeDiskTable) diskID(i interface{}) proto.DiskID {
 	return i.(*BlobNodeDiskInfoRecord).DiskID
 }
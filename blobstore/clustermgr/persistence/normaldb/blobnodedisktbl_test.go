@@ -163,6 +163,83 @@ func TestBlobNodeDiskTbl(t *testing.T) {
 	}
 }
 
+func TestBlobNodeDiskTblListSorted(t *testing.T) {
+	tmpDBPath := path.Join(os.TempDir(), "normaldb", uuid.NewString()) + strconv.Itoa(rand.Intn(1000000000))
+	defer os.RemoveAll(tmpDBPath)
+
+	db, err := OpenNormalDB(tmpDBPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	diskTbl, err := OpenBlobNodeDiskTable(db, false)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i := 1; i <= 10; i++ {
+		disk := dr1
+		disk.DiskID = proto.DiskID(i)
+		// disk created earlier with greater disk id
+		disk.CreateAt = now.Add(-time.Duration(i) * time.Minute)
+		disk.Readonly = i%2 == 0
+		require.NoError(t, diskTbl.AddDisk(&disk))
+	}
+
+	listAll := func(opt *clustermgr.ListOptionArgs) (ids []proto.DiskID) {
+		for {
+			disks, cursor, err := diskTbl.ListDiskByCreateTime(opt, nil)
+			require.NoError(t, err)
+			if len(disks) == 0 {
+				require.Equal(t, "", cursor)
+				return
+			}
+			for _, disk := range disks {
+				ids = append(ids, disk.DiskID)
+			}
+			opt.Cursor = cursor
+		}
+	}
+	ids := listAll(&clustermgr.ListOptionArgs{Count: 3})
+	require.Equal(t, []proto.DiskID{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, ids)
+	ids = listAll(&clustermgr.ListOptionArgs{Count: 3, Desc: true})
+	require.Equal(t, []proto.DiskID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ids)
+	readonly := true
+	ids = listAll(&clustermgr.ListOptionArgs{Count: 2, Readonly: &readonly})
+	require.Equal(t, []proto.DiskID{10, 8, 6, 4, 2}, ids)
+
+	disks, _, err := diskTbl.ListDiskByCreateTime(&clustermgr.ListOptionArgs{Count: 10},
+		func(disk *BlobNodeDiskInfoRecord) bool { return disk.DiskID > 8 })
+	require.NoError(t, err)
+	require.Equal(t, 2, len(disks))
+	_, _, err = diskTbl.ListDiskByCreateTime(&clustermgr.ListOptionArgs{Count: 10, Cursor: "invalid"}, nil)
+	require.ErrorIs(t, err, ErrInvalidListCursor)
+
+	readonly = false
+	list, err := diskTbl.ListDiskWithFilter(&clustermgr.ListOptionArgs{Count: 2, Readonly: &readonly},
+		func(disk *BlobNodeDiskInfoRecord) bool { return disk.DiskID > 1 })
+	require.NoError(t, err)
+	require.Equal(t, 2, len(list))
+	require.Equal(t, proto.DiskID(3), list[0].DiskID)
+	require.Equal(t, proto.DiskID(5), list[1].DiskID)
+	list, err = diskTbl.ListDiskWithFilter(&clustermgr.ListOptionArgs{Host: dr1.Host, Count: 10, Readonly: &readonly}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(list))
+
+	// index of create time is built when opened if empty
+	batch := db.Table(diskCreateAtIndexCF).NewWriteBatch()
+	for i := 1; i <= 10; i++ {
+		disk, err := diskTbl.GetDisk(proto.DiskID(i))
+		require.NoError(t, err)
+		batch.DeleteCF(db.Table(diskCreateAtIndexCF).GetCf(),
+			[]byte(genIndexKey(diskCreateAtIndex, disk.CreateAt)+disk.DiskID.ToString()))
+	}
+	require.NoError(t, db.Table(diskCreateAtIndexCF).DoBatch(batch))
+	batch.Destroy()
+	require.True(t, diskTbl.diskTable.isIndexEmpty(diskCreateAtIndex))
+	diskTbl, err = OpenBlobNodeDiskTable(db, false)
+	require.NoError(t, err)
+	require.False(t, diskTbl.diskTable.isIndexEmpty(diskCreateAtIndex))
+}
+
 func TestBlobNodeDiskDropTbl(t *testing.T) {
 	tmpDBPath := path.Join(os.TempDir(), "normaldb", uuid.NewString()) + strconv.Itoa(rand.Intn(1000000000))
 	defer os.RemoveAll(tmpDBPath)
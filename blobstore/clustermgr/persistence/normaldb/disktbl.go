@@ -17,6 +17,7 @@ package normaldb
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
@@ -28,6 +29,8 @@ import (
 var (
 	_          diskRecordDescriptor = (*BlobNodeDiskTable)(nil)
 	uselessVal                      = []byte("1")
+
+	ErrInvalidListCursor = errors.New("invalid list cursor")
 )
 
 const (
@@ -42,6 +45,8 @@ var (
 	diskHostIndex    = "Host"
 	diskIDCIndex     = "Idc"
 	diskIDCRACKIndex = "Idc-Rack"
+
+	diskCreateAtIndex = "CreateAt"
 )
 
 type DiskInfoRecord struct {
@@ -95,6 +100,11 @@ func (d *diskTable) GetDisk(diskID proto.DiskID) (info interface{}, err error) {
 }
 
 func (d *diskTable) ListDisk(opt *clustermgr.ListOptionArgs, listCallback func(i interface{})) error {
+	return d.listDisk(opt, nil, listCallback)
+}
+
+// listDisk list disks matched with option and filter after the marker
+func (d *diskTable) listDisk(opt *clustermgr.ListOptionArgs, filter func(i interface{}) bool, listCallback func(i interface{})) error {
 	if opt == nil {
 		return errors.New("invalid list option")
 	}
@@ -135,7 +145,9 @@ func (d *diskTable) ListDisk(opt *clustermgr.ListOptionArgs, listCallback func(i
 	}
 
 	if !useIndex {
-		return d.ListDisksByDiskTbl(opt.Marker, opt.Count, listCallback)
+		return d.listDisksByDiskTbl(opt.Marker, opt.Count, func(i interface{}) bool {
+			return d.match(opt, i, filter)
+		}, listCallback)
 	}
 
 	seekKey += indexKeyPrefix
@@ -176,18 +188,8 @@ func (d *diskTable) ListDisk(opt *clustermgr.ListOptionArgs, listCallback func(i
 				return errors.Info(err, "list disk table iterate failed")
 			}
 
-			diskInfo := d.rd.diskInfo(record)
 			// two part of detail filter
-			if opt.Host != "" && diskInfo.Host != opt.Host {
-				goto FREE
-			}
-			if opt.Idc != "" && diskInfo.Idc != opt.Idc {
-				goto FREE
-			}
-			if opt.Rack != "" && diskInfo.Rack != opt.Rack {
-				goto FREE
-			}
-			if opt.Status.IsValid() && diskInfo.Status != opt.Status {
+			if !d.match(opt, record, filter) {
 				goto FREE
 			}
 			listCallback(record)
@@ -200,6 +202,106 @@ func (d *diskTable) ListDisk(opt *clustermgr.ListOptionArgs, listCallback func(i
 	return nil
 }
 
+// listDiskByIndex list disks matched with option and filter in order of the index,
+// return index key of the last disk as cursor
+func (d *diskTable) listDiskByIndex(index string, opt *clustermgr.ListOptionArgs,
+	filter func(i interface{}) bool, listCallback func(i interface{}),
+) (string, error) {
+	if opt == nil {
+		return "", errors.New("invalid list option")
+	}
+	item, ok := d.indexes[index]
+	if !ok {
+		return "", errors.New("index not exist")
+	}
+	prefix := item.indexNames[0] + seperateChar
+	if opt.Cursor != "" && !strings.HasPrefix(opt.Cursor, prefix) {
+		return "", ErrInvalidListCursor
+	}
+
+	tbl := item.tbl
+	snap := tbl.NewSnapshot()
+	defer tbl.ReleaseSnapshot(snap)
+	iter := tbl.NewIterator(snap)
+	defer iter.Close()
+
+	next := iter.Next
+	if opt.Desc {
+		next = iter.Prev
+		if opt.Cursor != "" {
+			iter.SeekForPrev([]byte(opt.Cursor))
+		} else {
+			iter.SeekForPrev([]byte(prefix + "\xff"))
+		}
+	} else {
+		if opt.Cursor != "" {
+			iter.Seek([]byte(opt.Cursor))
+		} else {
+			iter.Seek([]byte(prefix))
+		}
+	}
+	if opt.Cursor != "" && iter.Valid() {
+		key := iter.Key()
+		isCursor := string(key.Data()) == opt.Cursor
+		key.Free()
+		if isCursor {
+			next()
+		}
+	}
+
+	cursor := ""
+	for count := opt.Count; count > 0 && iter.ValidForPrefix([]byte(prefix)); next() {
+		if iter.Err() != nil {
+			return "", errors.Info(iter.Err(), "list disk index iterate failed")
+		}
+		key, value := iter.Key(), iter.Value()
+		indexKey := string(key.Data())
+		var diskID proto.DiskID
+		diskID = diskID.Decode(value.Data())
+		key.Free()
+		value.Free()
+
+		record, err := d.GetDisk(diskID)
+		if err != nil {
+			return "", errors.Info(err, "list disk index get disk failed")
+		}
+		if d.match(opt, record, filter) {
+			listCallback(record)
+			cursor = indexKey
+			count--
+		}
+	}
+	return cursor, nil
+}
+
+// match return true if the disk matched with option and filter
+func (d *diskTable) match(opt *clustermgr.ListOptionArgs, info interface{}, filter func(i interface{}) bool) bool {
+	diskInfo := d.rd.diskInfo(info)
+	if opt.Host != "" && diskInfo.Host != opt.Host {
+		return false
+	}
+	if opt.Idc != "" && diskInfo.Idc != opt.Idc {
+		return false
+	}
+	if opt.Rack != "" && diskInfo.Rack != opt.Rack {
+		return false
+	}
+	if opt.Status.IsValid() && diskInfo.Status != opt.Status {
+		return false
+	}
+	if opt.Readonly != nil && diskInfo.Readonly != *opt.Readonly {
+		return false
+	}
+	return filter == nil || filter(info)
+}
+
+func (d *diskTable) isIndexEmpty(index string) bool {
+	iter := d.indexes[index].tbl.NewIterator(nil)
+	defer iter.Close()
+	iter.SeekToFirst()
+	return !iter.Valid()
+}
+
 func (d *diskTable) AddDisk(diskID proto.DiskID, info interface{}) error {
 	key := diskID.Encode()
 	value, err := d.rd.marshalRecord(info)
@@ -316,6 +418,12 @@ func (d *diskTable) DeleteDisk(diskID proto.DiskID) error {
 }
 
 func (d *diskTable) ListDisksByDiskTbl(marker proto.DiskID, count int, listCallback func(i interface{})) error {
+	return d.listDisksByDiskTbl(marker, count, nil, listCallback)
+}
+
+func (d *diskTable) listDisksByDiskTbl(marker proto.DiskID, count int,
+	filter func(i interface{}) bool, listCallback func(i interface{}),
+) error {
 	snap := d.diskTbl.NewSnapshot()
 	defer d.diskTbl.ReleaseSnapshot(snap)
 	iter := d.diskTbl.NewIterator(snap)
@@ -340,6 +448,9 @@ func (d *diskTable) ListDisksByDiskTbl(marker proto.DiskID, count int, listCallb
 		}
 		iter.Key().Free()
 		iter.Value().Free()
+		if filter != nil && !filter(info) {
+			continue
+		}
 		listCallback(info)
 		if count != 0 && i >= count {
 			return nil
@@ -435,5 +546,16 @@ func (d *diskTable) IsDroppingDisk(diskID proto.DiskID) (exist bool, err error)
 }
 
 func genIndexKey(indexName string, indexValue interface{}) string {
+	// time is fixed width in index key to be sorted
+	if t, ok := indexValue.(time.Time); ok {
+		indexValue = fmt.Sprintf("%020d", timeIndexValue(t))
+	}
 	return fmt.Sprintf(indexName+seperateChar+"%v"+seperateChar, indexValue)
 }
+
+func timeIndexValue(t time.Time) int64 {
+	if t.IsZero() || t.UnixNano() < 0 {
+		return 0
+	}
+	return t.UnixNano()
+}
@@ -29,6 +29,8 @@ var (
 	diskIDCIndexCF     = "disk-idc"
 	diskIDCRackIndexCF = "disk-idc-rack"
 
+	diskCreateAtIndexCF = "disk-create-at"
+
 	shardNodeDiskCF             = "sn-disk"
 	shardNodeCF                 = "shard-node"
 	shardNodeDiskStatusIndexCF  = "sn-disk-status"
@@ -49,6 +51,7 @@ var (
 		diskHostIndexCF,
 		diskIDCIndexCF,
 		diskIDCRackIndexCF,
+		diskCreateAtIndexCF,
 
 		shardNodeDiskCF,
 		shardNodeCF,
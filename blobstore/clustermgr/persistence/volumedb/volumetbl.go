@@ -52,6 +52,9 @@ type VolumeRecord struct {
 	Free           uint64
 	Used           uint64
 	CreateByNodeID uint64
+	// lease of temporary volume
+	LeaseExpireTime int64
+	LeaseExpired    bool
 }
 
 type VolumeTaskRecord struct {
@@ -69,6 +72,8 @@ type VolumeUnitRecord struct {
 	Total      uint64
 	Used       uint64
 	Compacting bool
+	// route version of the last location change
+	RouteVersion uint64
 }
 
 type TokenRecord struct {
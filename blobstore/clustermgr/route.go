@@ -17,6 +17,7 @@ package clustermgr
 import (
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/util/errors"
@@ -47,3 +48,59 @@ func (s *Service) CatalogChangesGet(c *rpc.Context) {
 	}
 	c.RespondJSON(ret)
 }
+
+func (s *Service) RouteChecksumGet(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.GetRouteChecksumArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if args.SpaceID == proto.InvalidSpaceID {
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	span.Debugf("accept RouteChecksumGet request, args: %v", args)
+
+	// linear read
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("get route checksum read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+
+	ret, err := s.CatalogMgr.GetRouteChecksum(ctx, args)
+	if err != nil {
+		span.Errorf("get route checksum err => %s", errors.Detail(err))
+		c.RespondError(err)
+		return
+	}
+	c.RespondJSON(ret)
+}
+
+func (s *Service) VolumeChangesGet(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.GetVolumeChangesArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept VolumeChangesGet request, args: %v", args)
+
+	// linear read
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("get volume changes read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+
+	ret, err := s.VolumeMgr.GetVolumeChanges(ctx, args.RouteVersion)
+	if err != nil {
+		span.Errorf("get volume changes err =>", errors.Detail(err))
+		c.RespondError(err)
+		return
+	}
+	c.RespondJSON(ret)
+}
@@ -125,3 +125,53 @@ func (s *Service) ShardNodeTopoInfo(c *rpc.Context) {
 	}
 	c.RespondJSON(s.ShardNodeMgr.GetTopoInfo(ctx))
 }
+
+func (s *Service) AdminShardNodeTopoCheck(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.TopoCheckArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Infof("accept AdminShardNodeTopoCheck request, args: %v", args)
+
+	ret, err := s.ShardNodeMgr.CheckTopo(ctx, args.Repair)
+	if err != nil {
+		span.Errorf("check topo failed, err: %v", err)
+		c.RespondError(errors.Info(apierrors.ErrUnexpected).Detail(err))
+		return
+	}
+	c.RespondJSON(ret)
+}
+
+func (s *Service) AdminShardNodeRelabel(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.NodeRelabelArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Infof("accept AdminShardNodeRelabel request, args: %v", args)
+
+	if err := s.ShardNodeMgr.ValidateNodeRelabel(ctx, args); err != nil {
+		span.Warnf("invalid node relabel args: %v, err: %v", args, err)
+		c.RespondError(err)
+		return
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		span.Errorf("json marshal failed, args: %v, error: %v", args, err)
+		c.RespondError(errors.Info(apierrors.ErrUnexpected).Detail(err))
+		return
+	}
+	proposeInfo := base.EncodeProposeInfo(s.ShardNodeMgr.GetModuleName(), cluster.OperTypeAdminRelabelNode, data, base.ProposeContext{ReqID: span.TraceID()})
+	err = s.raftNode.Propose(ctx, proposeInfo)
+	if err != nil {
+		span.Error(err)
+		c.RespondError(apierrors.ErrRaftPropose)
+		return
+	}
+}
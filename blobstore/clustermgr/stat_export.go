@@ -0,0 +1,270 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+const (
+	defaultStatExportIntervalM     = 60
+	defaultStatExportRetentionDays = 30
+	defaultStatExportTimeoutS      = 60
+
+	statSnapshotPrefix     = "clustermgr-stat-"
+	statSnapshotSuffix     = ".json.gz"
+	statSnapshotTimeLayout = "20060102T150405Z"
+)
+
+// StatExportS3Config bucket of s3 compatible object storage to store stat snapshots.
+type StatExportS3Config struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+// StatExportConfig exports gzipped json snapshot of topology and stat on leader
+// every IntervalM minutes, into local Path or S3 bucket, snapshots older than
+// RetentionDays are removed. The exporter is disabled if neither Path nor bucket is set.
+type StatExportConfig struct {
+	IntervalM     int                `json:"interval_m"`
+	RetentionDays int                `json:"retention_days"`
+	TimeoutS      int                `json:"timeout_s"`
+	Path          string             `json:"path"`
+	S3            StatExportS3Config `json:"s3"`
+}
+
+func (c *StatExportConfig) enabled() bool {
+	return c.Path != "" || c.S3.Bucket != ""
+}
+
+// statSnapshot is historical data for capacity trend analysis and postmortems
+type statSnapshot struct {
+	Time          int64                `json:"time"`
+	Region        string               `json:"region"`
+	ClusterID     proto.ClusterID      `json:"cluster_id"`
+	Stat          *clustermgr.StatInfo `json:"stat"`
+	BlobNodeTopo  *clustermgr.TopoInfo `json:"blobnode_topo"`
+	ShardNodeTopo *clustermgr.TopoInfo `json:"shardnode_topo"`
+}
+
+// snapshotStore stores snapshots by name
+type snapshotStore interface {
+	Put(ctx context.Context, name string, data []byte) error
+	List(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+func newSnapshotStore(cfg *StatExportConfig) snapshotStore {
+	if cfg.S3.Bucket != "" {
+		return newS3SnapshotStore(&cfg.S3)
+	}
+	return &localSnapshotStore{dir: cfg.Path}
+}
+
+type localSnapshotStore struct {
+	dir string
+}
+
+func (l *localSnapshotStore) Put(ctx context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(l.dir, 0o755); err != nil {
+		return err
+	}
+	// rename after written, no partial snapshot is seen
+	tmp := filepath.Join(l.dir, "."+name+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(l.dir, name))
+}
+
+func (l *localSnapshotStore) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (l *localSnapshotStore) Delete(ctx context.Context, name string) error {
+	return os.Remove(filepath.Join(l.dir, name))
+}
+
+type s3SnapshotStore struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+func newS3SnapshotStore(cfg *StatExportS3Config) *s3SnapshotStore {
+	ac := aws.NewConfig().WithS3ForcePathStyle(true)
+	if cfg.Endpoint != "" {
+		ac.Endpoint = aws.String(cfg.Endpoint)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "default"
+	}
+	ac.Region = aws.String(region)
+	if cfg.AccessKey != "" {
+		ac.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+	return &s3SnapshotStore{
+		client: s3.New(session.Must(session.NewSession()), ac),
+		bucket: cfg.Bucket,
+		prefix: cfg.Prefix,
+	}
+}
+
+func (s *s3SnapshotStore) Put(ctx context.Context, name string, data []byte) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.prefix, name)),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (s *s3SnapshotStore) List(ctx context.Context) ([]string, error) {
+	prefix := path.Join(s.prefix, statSnapshotPrefix)
+	names := make([]string, 0)
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			names = append(names, path.Base(aws.StringValue(obj.Key)))
+		}
+		return true
+	})
+	return names, err
+}
+
+func (s *s3SnapshotStore) Delete(ctx context.Context, name string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.prefix, name)),
+	})
+	return err
+}
+
+func statSnapshotName(t time.Time) string {
+	return statSnapshotPrefix + t.UTC().Format(statSnapshotTimeLayout) + statSnapshotSuffix
+}
+
+func parseStatSnapshotName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, statSnapshotPrefix) || !strings.HasSuffix(name, statSnapshotSuffix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(statSnapshotTimeLayout,
+		strings.TrimSuffix(strings.TrimPrefix(name, statSnapshotPrefix), statSnapshotSuffix))
+	return t, err == nil
+}
+
+func encodeStatSnapshot(snapshot *statSnapshot) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	w := gzip.NewWriter(buf)
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// expiredStatSnapshots returns names of snapshots exported before the time, oldest first
+func expiredStatSnapshots(names []string, before time.Time) []string {
+	expired := make([]string, 0)
+	for _, name := range names {
+		if t, ok := parseStatSnapshotName(name); ok && t.Before(before) {
+			expired = append(expired, name)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// exportStatSnapshot exports snapshot of now and removes the expired snapshots
+func (s *Service) exportStatSnapshot(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+	cfg := &s.StatExportConfig
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(cfg.TimeoutS)*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	data, err := encodeStatSnapshot(&statSnapshot{
+		Time:          now.Unix(),
+		Region:        s.Region,
+		ClusterID:     s.ClusterID,
+		Stat:          s.statInfo(ctx),
+		BlobNodeTopo:  s.BlobNodeMgr.GetTopoInfo(ctx),
+		ShardNodeTopo: s.ShardNodeMgr.GetTopoInfo(ctx),
+	})
+	if err != nil {
+		span.Errorf("encode stat snapshot failed, err: %s", errors.Detail(err))
+		return
+	}
+	name := statSnapshotName(now)
+	if err = s.statExporter.Put(ctx, name, data); err != nil {
+		span.Errorf("export stat snapshot %s failed, err: %s", name, errors.Detail(err))
+		return
+	}
+	span.Infof("exported stat snapshot %s, size: %d", name, len(data))
+
+	names, err := s.statExporter.List(ctx)
+	if err != nil {
+		span.Warnf("list stat snapshots failed, err: %s", errors.Detail(err))
+		return
+	}
+	for _, expired := range expiredStatSnapshots(names, now.AddDate(0, 0, -cfg.RetentionDays)) {
+		if err = s.statExporter.Delete(ctx, expired); err != nil {
+			span.Warnf("delete expired stat snapshot %s failed, err: %s", expired, errors.Detail(err))
+			return
+		}
+		span.Infof("deleted expired stat snapshot %s", expired)
+	}
+}
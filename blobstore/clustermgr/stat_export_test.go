@@ -0,0 +1,82 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clustermgr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatSnapshotName(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	name := statSnapshotName(now)
+	tm, ok := parseStatSnapshotName(name)
+	require.True(t, ok)
+	require.True(t, now.Equal(tm))
+
+	_, ok = parseStatSnapshotName("clustermgr-stat-xxx.json.gz")
+	require.False(t, ok)
+	_, ok = parseStatSnapshotName(".tmp")
+	require.False(t, ok)
+
+	names := []string{
+		statSnapshotName(now),
+		statSnapshotName(now.Add(-48 * time.Hour)),
+		statSnapshotName(now.Add(-72 * time.Hour)),
+		"other-file",
+	}
+	require.Equal(t, []string{names[2], names[1]}, expiredStatSnapshots(names, now.Add(-24*time.Hour)))
+}
+
+func TestStatExport(t *testing.T) {
+	testService, clean := initTestService(t)
+	defer clean()
+	testClusterClient := initTestClusterClient(testService)
+	ctx := newCtx()
+
+	insertNodeInfos(t, testClusterClient, 0, 0, testService.IDC[0])
+	insertDiskInfos(t, testClusterClient, 1, 3, testService.IDC[0])
+
+	dir := t.TempDir()
+	testService.StatExportConfig = StatExportConfig{Path: dir, RetentionDays: 1, TimeoutS: 10}
+	testService.statExporter = newSnapshotStore(&testService.StatExportConfig)
+
+	expired := statSnapshotName(time.Now().Add(-48 * time.Hour))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, expired), []byte("expired"), 0o644))
+
+	testService.exportStatSnapshot(ctx)
+	names, err := testService.statExporter.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(names))
+	require.NotEqual(t, expired, names[0])
+
+	data, err := os.ReadFile(filepath.Join(dir, names[0]))
+	require.NoError(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	snapshot := new(statSnapshot)
+	require.NoError(t, json.NewDecoder(r).Decode(snapshot))
+	require.Equal(t, testService.ClusterID, snapshot.ClusterID)
+	require.NotZero(t, snapshot.Stat.BlobNodeSpaceStat.TotalDisk)
+	require.NotNil(t, snapshot.BlobNodeTopo)
+	require.NotNil(t, snapshot.ShardNodeTopo)
+}
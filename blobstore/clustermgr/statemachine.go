@@ -94,6 +94,23 @@ func (s *Service) Apply(data [][]byte, index uint64) error {
 			span.Error(errMsg)
 			return errors.New(errMsg)
 		}
+		// apply the assembled proposal after all chunks applied
+		if base.IsChunkProposal(proposeInfo) {
+			assembled, err := s.raftNode.ApplyChunk(ctx, proposeInfo)
+			if err != nil {
+				span.Errorf("raft statemachine Apply chunk failed, err: %s", err.Error())
+				return err
+			}
+			if assembled == nil {
+				continue
+			}
+			if proposeInfo = base.DecodeProposeInfo(assembled); proposeInfo == nil || proposeInfo.Module == "" ||
+				proposeInfo.OperType == 0 || proposeInfo.Data == nil || base.IsChunkProposal(proposeInfo) {
+				errMsg := fmt.Sprintf("raft statemachine Apply check failed ==> invalid assembled propose data, size: %d", len(assembled))
+				span.Error(errMsg)
+				return errors.New(errMsg)
+			}
+		}
 		moduleOperTypes[proposeInfo.Module] = append(moduleOperTypes[proposeInfo.Module], proposeInfo.OperType)
 		moduleDatas[proposeInfo.Module] = append(moduleDatas[proposeInfo.Module], proposeInfo.Data)
 		moduleContexts[proposeInfo.Module] = append(moduleContexts[proposeInfo.Module], proposeInfo.Context)
@@ -189,6 +206,8 @@ func (s *Service) LeaderChange(leader uint64, host string) {
 	span, ctx := trace.StartSpanFromContext(context.Background(), "")
 	span.Debugf("receive leader change, leader: %d, host: %s ", leader, host)
 
+	// memory of disks heartbeat may be different between the old and new leader
+	s.resetHeartbeatCounts()
 	if leader > 0 {
 		s.raftNode.SetLeaderHost(leader, host)
 		// use for blocking raft start
@@ -80,6 +80,7 @@ const (
 	defaultClusterReportIntervalS   = 60
 	defaultHeartbeatNotifyIntervalS = 10
 	defaultMaxHeartbeatNotifyNum    = 2000
+	defaultHeartbeatFullIntervalN   = 10
 	defaultMetricReportIntervalM    = 2
 	defaultCheckConsistentIntervalM = 360
 
@@ -105,6 +106,7 @@ type Config struct {
 	UnavailableIDC           string                    `json:"unavailable_idc"`
 	ClusterID                proto.ClusterID           `json:"cluster_id"`
 	Readonly                 bool                      `json:"readonly"`
+	Labels                   map[string]string         `json:"labels"` // registered with cluster info for client routing
 	VolumeMgrConfig          volumemgr.VolumeMgrConfig `json:"volume_mgr_config"`
 	CatalogMgrConfig         catalog.Config            `json:"catalog_mgr_config"`
 	DBPath                   string                    `json:"db_path"`
@@ -126,6 +128,17 @@ type Config struct {
 	ChunkSize                uint64                    `json:"chunk_size"`
 	MetricReportIntervalM    int                       `json:"metric_report_interval_m"`
 	ConsistentCheckIntervalM int                       `json:"consistent_check_interval_m"`
+	AdmissionConfig          AdmissionConfig           `json:"admission_config"`
+	UsageReconcileConfig     UsageReconcileConfig      `json:"usage_reconcile_config"`
+	DropWatchdogConfig       DropWatchdogConfig        `json:"drop_watchdog_config"`
+	StatExportConfig         StatExportConfig          `json:"stat_export_config"`
+	AlertConfig              AlertConfig               `json:"alert_config"`
+	// HeartbeatDelta proposes the changed fields of disks heartbeat only,
+	// enable it after all clustermgr nodes were upgraded.
+	HeartbeatDelta bool `json:"heartbeat_delta"`
+	// HeartbeatFullIntervalN proposes full heartbeat of disk every N heartbeats when delta enabled,
+	// and the first heartbeat of disk after leader changed is always full
+	HeartbeatFullIntervalN int `json:"heartbeat_full_interval_n"`
 
 	cmd.Config
 }
@@ -159,6 +172,22 @@ type Service struct {
 	raftStartCh            chan interface{}
 	closeCh                chan interface{}
 	consulClient           *api.Client
+	// progress of the last member replacement on leader
+	replaceLock   sync.Mutex
+	replaceStatus *clustermgr.ReplaceMemberStatus
+	// throttle register and heartbeat of nodes and disks
+	registerAdmission  *admissionQueue
+	heartbeatAdmission *admissionQueue
+	// heartbeat counts of disks since leader changed, for proposing full heartbeat
+	heartbeatCounts sync.Map
+	// chunk usage reported by blobnode for reconciliation on leader
+	chunkUsages *chunkUsages
+	// migration progress of dropping disks on leader
+	dropWatchdog *dropWatchdog
+	// store of exported topology and stat snapshots
+	statExporter snapshotStore
+	// alert rules evaluated on leader
+	alerter *alerter
 	*Config
 }
 
@@ -243,7 +272,21 @@ func New(cfg *Config) (*Service, error) {
 		status:       ServiceStatusNormal,
 		consulClient: consulClient,
 		closeCh:      make(chan interface{}),
+		chunkUsages:  newChunkUsages(),
+		dropWatchdog: newDropWatchdog(&cfg.DropWatchdogConfig.Scheduler),
 	}
+	if cfg.StatExportConfig.enabled() {
+		service.statExporter = newSnapshotStore(&cfg.StatExportConfig)
+	}
+	if len(cfg.AlertConfig.Rules) > 0 {
+		if service.alerter, err = newAlerter(&cfg.AlertConfig); err != nil {
+			log.Fatalf("new alerter failed, err: %v", err)
+		}
+	}
+	service.registerAdmission = newAdmissionQueue(admissionKindRegister, cfg.AdmissionConfig.RegisterPerSec,
+		cfg.AdmissionConfig, cfg.Region, cfg.ClusterID.ToString())
+	service.heartbeatAdmission = newAdmissionQueue(admissionKindHeartbeat, cfg.AdmissionConfig.HeartbeatPerSec,
+		cfg.AdmissionConfig, cfg.Region, cfg.ClusterID.ToString())
 
 	// module manager initial
 	scopeMgr, err := scopemgr.NewScopeMgr(normalDB)
@@ -282,6 +325,9 @@ func New(cfg *Config) (*Service, error) {
 		log.Fatalf("new catalogMgr failed, error: %v", errors.Detail(err))
 	}
 
+	blobNodeMgr.SetPlacedUnitsGetter(volumeMgr)
+	shardNodeMgr.SetPlacedUnitsGetter(catalogMgr)
+
 	service.KvMgr = kvMgr
 	service.VolumeMgr = volumeMgr
 	service.ConfigMgr = configMgr
@@ -387,6 +433,8 @@ func (s *Service) Handler(w http.ResponseWriter, req *http.Request, f func(http.
 func (s *Service) Close() {
 	// 1. close service loop
 	close(s.closeCh)
+	s.registerAdmission.Close()
+	s.heartbeatAdmission.Close()
 
 	// 2. stop raft server
 	s.raftNode.Stop()
@@ -438,6 +486,19 @@ func (c *Config) checkAndFix() (err error) {
 	if c.ChunkSize == 0 {
 		c.ChunkSize = DefaultChunkSize
 	}
+	defaulter.LessOrEqual(&c.AdmissionConfig.MaxQueued, defaultAdmissionMaxQueued)
+	defaulter.LessOrEqual(&c.AdmissionConfig.WaitTimeoutMs, defaultAdmissionWaitTimeoutMs)
+	defaulter.Equal(&c.UsageReconcileConfig.IntervalS, defaultUsageReconcileIntervalS)
+	defaulter.LessOrEqual(&c.UsageReconcileConfig.ReportExpireS, defaultUsageReportExpireS)
+	defaulter.LessOrEqual(&c.UsageReconcileConfig.TolerancePercent, float64(defaultUsageTolerancePercent))
+	defaulter.LessOrEqual(&c.UsageReconcileConfig.ToleranceBytes, int64(defaultUsageToleranceBytes))
+	defaulter.Equal(&c.DropWatchdogConfig.IntervalS, defaultDropWatchdogIntervalS)
+	defaulter.LessOrEqual(&c.DropWatchdogConfig.StuckHours, defaultDropWatchdogStuckHours)
+	defaulter.LessOrEqual(&c.StatExportConfig.IntervalM, defaultStatExportIntervalM)
+	defaulter.LessOrEqual(&c.StatExportConfig.RetentionDays, defaultStatExportRetentionDays)
+	defaulter.LessOrEqual(&c.StatExportConfig.TimeoutS, defaultStatExportTimeoutS)
+	defaulter.LessOrEqual(&c.AlertConfig.IntervalS, defaultAlertIntervalS)
+	defaulter.LessOrEqual(&c.AlertConfig.TimeoutS, defaultAlertTimeoutS)
 	if c.ClusterCfg == nil {
 		c.ClusterCfg = make(map[string]interface{})
 	}
@@ -505,6 +566,11 @@ func (c *Config) checkAndFix() (err error) {
 		}
 		c.BlobNodeDiskMgrConfig.CodeModes = append(c.BlobNodeDiskMgrConfig.CodeModes, codeMode)
 	}
+	for _, mode := range c.CustomCodeModes {
+		if c.UnavailableIDC == "" && mode.AZCount != len(c.IDC) {
+			return errors.New("idc count not match custom modeTactic AZCount")
+		}
+	}
 	c.VolumeMgrConfig.CodeModePolicies = c.VolumeCodeModePolicies
 
 	c.BlobNodeDiskMgrConfig.IDC = c.IDC
@@ -657,6 +723,32 @@ func (s *Service) loop() {
 	checkTicker := time.NewTicker(time.Duration(s.ConsistentCheckIntervalM) * time.Minute)
 	defer checkTicker.Stop()
 
+	var usageReconcileC <-chan time.Time
+	if s.UsageReconcileConfig.IntervalS > 0 {
+		usageReconcileTicker := time.NewTicker(time.Duration(s.UsageReconcileConfig.IntervalS) * time.Second)
+		defer usageReconcileTicker.Stop()
+		usageReconcileC = usageReconcileTicker.C
+	}
+	var dropWatchdogC <-chan time.Time
+	if s.DropWatchdogConfig.IntervalS > 0 {
+		dropWatchdogTicker := time.NewTicker(time.Duration(s.DropWatchdogConfig.IntervalS) * time.Second)
+		defer dropWatchdogTicker.Stop()
+		dropWatchdogC = dropWatchdogTicker.C
+	}
+	var statExportC <-chan time.Time
+	if s.statExporter != nil {
+		statExportTicker := time.NewTicker(time.Duration(s.StatExportConfig.IntervalM) * time.Minute)
+		defer statExportTicker.Stop()
+		statExportC = statExportTicker.C
+	}
+	var alertC <-chan time.Time
+	if s.alerter != nil {
+		alertTicker := time.NewTicker(time.Duration(s.AlertConfig.IntervalS) * time.Second)
+		defer alertTicker.Stop()
+		alertC = alertTicker.C
+		go s.alerter.loopPost(s.closeCh)
+	}
+
 	for {
 		select {
 		case <-reportTicker.C:
@@ -668,6 +760,7 @@ func (s *Service) loop() {
 				ClusterID: s.ClusterID,
 				Readonly:  s.Readonly,
 				Nodes:     make([]string, 0),
+				Labels:    s.Labels,
 			}
 			spaceStatInfo := s.BlobNodeMgr.Stat(ctx, proto.DiskTypeHDD)
 			clusterInfo.Capacity = spaceStatInfo.TotalSpace
@@ -779,6 +872,33 @@ func (s *Service) loop() {
 				}
 			}()
 
+		case <-usageReconcileC:
+			if !s.raftNode.IsLeader() {
+				continue
+			}
+			s.reconcileDiskUsage(ctx)
+
+		case <-dropWatchdogC:
+			if !s.raftNode.IsLeader() {
+				s.dropWatchdog.reset()
+				continue
+			}
+			s.checkDroppingDisks(ctx)
+
+		case <-statExportC:
+			if !s.raftNode.IsLeader() {
+				continue
+			}
+			s.exportStatSnapshot(ctx)
+
+		case <-alertC:
+			if !s.raftNode.IsLeader() {
+				s.alerter.reset()
+				s.reportFiringAlert(nil)
+				continue
+			}
+			s.evaluateAlerts(ctx)
+
 		case <-s.closeCh:
 			return
 		}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/base"
+	"github.com/cubefs/cubefs/blobstore/clustermgr/cluster"
 	"github.com/cubefs/cubefs/blobstore/clustermgr/volumemgr"
 	apierrors "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/kvstore"
@@ -111,7 +112,15 @@ func (s *Service) VolumeAlloc(c *rpc.Context) {
 		return
 	}
 
-	ret, err := s.VolumeMgr.AllocVolume(ctx, args.CodeMode, args.Count, clientIP(c.Request))
+	var (
+		ret *clustermgr.AllocatedVolumeInfos
+		err error
+	)
+	if args.LeaseTTLS > 0 {
+		ret, err = s.VolumeMgr.AllocLeaseVolume(ctx, args.CodeMode, args.Count, clientIP(c.Request), args.LeaseTTLS)
+	} else {
+		ret, err = s.VolumeMgr.AllocVolume(ctx, args.CodeMode, args.Count, clientIP(c.Request))
+	}
 	if err != nil {
 		span.Errorf("alloc volume error:%v", err)
 		c.RespondError(err)
@@ -123,6 +132,82 @@ func (s *Service) VolumeAlloc(c *rpc.Context) {
 	}
 }
 
+func (s *Service) AdminVolumeAllocSimulate(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.AllocSimulateArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Infof("accept AdminVolumeAllocSimulate request, args: %v", args)
+
+	if args.DiskType == 0 {
+		args.DiskType = proto.DiskTypeHDD
+	}
+	if !args.CodeMode.IsValid() || !args.DiskType.IsValid() ||
+		args.Count <= 0 || args.Count > cluster.MaxSimulateAllocCount {
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	c.RespondJSON(s.BlobNodeMgr.SimulateAllocVolumes(ctx, args.DiskType, args.CodeMode, args.Count))
+}
+
+// AdminVolumePlacementCheck re-checks unit placement of volumes after topology changes,
+// returns violations with suggested migrations
+func (s *Service) AdminVolumePlacementCheck(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.PlacementCheckArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Infof("accept AdminVolumePlacementCheck request, args: %v", args)
+
+	if len(args.Vids) == 0 && args.Count <= 0 {
+		c.RespondError(apierrors.ErrIllegalArguments)
+		return
+	}
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("check placement read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+
+	var (
+		volInfos []*clustermgr.VolumeInfo
+		err      error
+	)
+	if len(args.Vids) > 0 {
+		for _, vid := range args.Vids {
+			var volInfo *clustermgr.VolumeInfo
+			if volInfo, err = s.VolumeMgr.GetVolumeInfo(ctx, vid); err != nil {
+				span.Errorf("get volume %d error: %v", vid, err)
+				c.RespondError(err)
+				return
+			}
+			volInfos = append(volInfos, volInfo)
+		}
+	} else {
+		volInfos, err = s.VolumeMgr.ListVolumeInfo(ctx, &clustermgr.ListVolumeArgs{Marker: args.Marker, Count: args.Count})
+		if err != nil && err != kvstore.ErrNotFound {
+			span.Errorf("list volume error, args is: %v, error: %v", args, err)
+			c.RespondError(apierrors.ErrCMUnexpect)
+			return
+		}
+	}
+
+	ret := &clustermgr.PlacementCheckRet{
+		Checked:    len(volInfos),
+		Violations: s.BlobNodeMgr.CheckVolumePlacement(ctx, volInfos),
+	}
+	if len(volInfos) > 0 {
+		ret.Marker = volInfos[len(volInfos)-1].Vid
+	}
+	c.RespondJSON(ret)
+}
+
 func (s *Service) VolumeAllocatedList(c *rpc.Context) {
 	ctx := c.Request.Context()
 	span := trace.SpanFromContextSafe(ctx)
@@ -230,6 +315,25 @@ func (s *Service) VolumeRetain(c *rpc.Context) {
 	c.RespondJSON(retainVolumes)
 }
 
+func (s *Service) VolumeLeaseRenew(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.RenewVolumeLeaseArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept VolumeLeaseRenew request, args: %v", args)
+
+	leaseExpireTime, err := s.VolumeMgr.RenewVolumeLease(ctx, args.Vids, args.LeaseTTLS)
+	if err != nil {
+		span.Errorf("renew volume lease error:%v", err)
+		c.RespondError(err)
+		return
+	}
+	c.RespondJSON(clustermgr.RenewVolumeLeaseRet{LeaseExpireTime: leaseExpireTime})
+}
+
 func (s *Service) VolumeLock(c *rpc.Context) {
 	ctx := c.Request.Context()
 	span := trace.SpanFromContextSafe(ctx)
@@ -300,6 +404,24 @@ func (s *Service) VolumeUnitList(c *rpc.Context) {
 	c.RespondJSON(&clustermgr.ListVolumeUnitInfos{VolumeUnitInfos: vuInfos})
 }
 
+func (s *Service) VolumeUnitsOnDiskList(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+	args := new(clustermgr.ListVolumeUnitArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	span.Debugf("accept VolumeUnitsOnDiskList request, args: %v", args)
+
+	if err := s.raftNode.ReadIndex(ctx); err != nil {
+		span.Errorf("list units on disk read index error: %v", err)
+		c.RespondError(apierrors.ErrRaftReadIndex)
+		return
+	}
+	c.RespondJSON(&clustermgr.ListVolumeUnitsOnDiskRet{Vuids: s.VolumeMgr.ListVolumeUnitsOnDisk(ctx, args.DiskID)})
+}
+
 // direct use blobnode client release chunk
 func (s *Service) VolumeUnitRelease(c *rpc.Context) {
 	ctx := c.Request.Context()
@@ -344,6 +344,16 @@ func TestService_VolumeUnitList(t *testing.T) {
 		_, err = cmClient.ListVolumeUnit(ctx, &clustermgr.ListVolumeUnitArgs{DiskID: proto.DiskID(99)})
 		require.NoError(t, err)
 		require.Nil(t, err)
+
+		vuids, err := cmClient.ListVolumeUnitsOnDisk(ctx, &clustermgr.ListVolumeUnitArgs{DiskID: proto.DiskID(2)})
+		require.NoError(t, err)
+		require.Equal(t, len(ret), len(vuids))
+		for idx := range ret {
+			require.Equal(t, ret[idx].Vuid, vuids[idx])
+		}
+		vuids, err = cmClient.ListVolumeUnitsOnDisk(ctx, &clustermgr.ListVolumeUnitArgs{DiskID: proto.DiskID(99)})
+		require.NoError(t, err)
+		require.Empty(t, vuids)
 	}
 }
 
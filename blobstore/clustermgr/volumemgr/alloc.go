@@ -197,7 +197,7 @@ func (a *volumeAllocator) VolumeStatusIdleCallback(ctx context.Context, vol *vol
 	span.Debugf("vid: %d set status idle callback, status is %d,free is %d,health is %d", vol.vid, vol.volInfoBase.Status, vol.volInfoBase.Free, vol.volInfoBase.HealthScore)
 	if vol.canAlloc(a.allocatableSize, allocatableScoreThreshold) {
 		a.idles[vol.volInfoBase.CodeMode].addAllocatable(vol)
-	} else {
+	} else if vol.canInsert() {
 		a.idles[vol.volInfoBase.CodeMode].addNotAllocatable(vol)
 	}
 
@@ -47,6 +47,9 @@ const (
 	OperTypeAdminUpdateVolumeUnit
 	OperTypeInitCreateVolume
 	OperTypeIncreaseVolumeUnitsEpoch
+	OperTypeRenewVolumeLease
+	OperTypeExpireVolumeLease
+	OperTypeReleaseVolumeLease
 )
 
 type CreateVolumeCtx struct {
@@ -86,9 +89,18 @@ type AllocVolumeCtx struct {
 	Vids               []proto.Vid `json:"vids"`
 	Host               string      `json:"host"`
 	ExpireTime         int64       `json:"expire_time"`
+	LeaseExpireTime    int64       `json:"lease_expire_time,omitempty"`
 	PendingAllocVolKey interface{} `json:"pending_alloc_vol_key"`
 }
 
+type VolumeLeaseCtx struct {
+	Vids []proto.Vid `json:"vids"`
+	// renew: new expire time of lease
+	// expire: time of lease expiration checked by leader
+	// release: not used
+	ExpireTime int64 `json:"expire_time"`
+}
+
 type ChangeVolStatusCtx struct {
 	Vid      proto.Vid           `json:"vid"`
 	TaskID   string              `json:"task_id"`
@@ -152,7 +164,7 @@ func (v *VolumeMgr) Apply(ctx context.Context, operTypes []int32, datas [][]byte
 			v.applyTaskPool.Run(1, func() {
 				defer wg.Done()
 				for _, vid := range args.Vids {
-					ret, err := v.applyAllocVolume(taskCtx, vid, args.Host, args.ExpireTime)
+					ret, err := v.applyAllocVolume(taskCtx, vid, args.Host, args.ExpireTime, args.LeaseExpireTime)
 					if err != nil {
 						errs[idx] = errors.Info(err, "apply alloc volume failed, args: ", args).Detail(err)
 						return
@@ -397,6 +409,51 @@ func (v *VolumeMgr) Apply(ctx context.Context, operTypes []int32, datas [][]byte
 				wg.Done()
 			})
 
+		case OperTypeRenewVolumeLease:
+			args := &VolumeLeaseCtx{}
+			err := json.Unmarshal(datas[idx], args)
+			if err != nil {
+				errs[idx] = errors.Info(err, t, datas[idx]).Detail(err)
+				wg.Done()
+				continue
+			}
+			v.applyTaskPool.Run(1, func() {
+				if err = v.applyRenewVolumeLease(taskCtx, args.Vids, args.ExpireTime); err != nil {
+					errs[idx] = errors.Info(err, "apply renew volume lease failed, args: ", args).Detail(err)
+				}
+				wg.Done()
+			})
+
+		case OperTypeExpireVolumeLease:
+			args := &VolumeLeaseCtx{}
+			err := json.Unmarshal(datas[idx], args)
+			if err != nil {
+				errs[idx] = errors.Info(err, t, datas[idx]).Detail(err)
+				wg.Done()
+				continue
+			}
+			v.applyTaskPool.Run(1, func() {
+				if err = v.applyExpireVolumeLease(taskCtx, args.Vids, args.ExpireTime); err != nil {
+					errs[idx] = errors.Info(err, "apply expire volume lease failed, args: ", args).Detail(err)
+				}
+				wg.Done()
+			})
+
+		case OperTypeReleaseVolumeLease:
+			args := &VolumeLeaseCtx{}
+			err := json.Unmarshal(datas[idx], args)
+			if err != nil {
+				errs[idx] = errors.Info(err, t, datas[idx]).Detail(err)
+				wg.Done()
+				continue
+			}
+			v.applyTaskPool.Run(1, func() {
+				if err = v.applyReleaseVolumeLease(taskCtx, args.Vids); err != nil {
+					errs[idx] = errors.Info(err, "apply release volume lease failed, args: ", args).Detail(err)
+				}
+				wg.Done()
+			})
+
 		default:
 			errs[idx] = errors.New("unsupported operation")
 			wg.Done()
@@ -229,6 +229,9 @@ func (v *VolumeMgr) applyCreateVolume(ctx context.Context, vol *volume) error {
 		return errors.Info(err, fmt.Sprintf("put volume[%+v] and volume unit[%+v] into volume table failed", volumeRecord, unitRecords)).Detail(err)
 	}
 	v.all.putVol(vol)
+	for _, unit := range vol.vUnits {
+		v.diskUnits.add(unit.vuInfo.DiskID, unit.vuidPrefix)
+	}
 
 	return nil
 }
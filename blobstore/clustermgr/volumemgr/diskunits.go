@@ -0,0 +1,84 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package volumemgr
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+// diskUnits is the in-memory reverse index of disk to volume units on it,
+// built when loading volumes, updated when volume created or the disk of
+// volume unit changed.
+type diskUnits struct {
+	lock  sync.RWMutex
+	units map[proto.DiskID]map[proto.VuidPrefix]struct{}
+}
+
+func newDiskUnits() *diskUnits {
+	return &diskUnits{units: make(map[proto.DiskID]map[proto.VuidPrefix]struct{})}
+}
+
+func (d *diskUnits) add(diskID proto.DiskID, vuidPrefix proto.VuidPrefix) {
+	d.lock.Lock()
+	d.addLocked(diskID, vuidPrefix)
+	d.lock.Unlock()
+}
+
+// move the volume unit from the old disk to the new disk.
+func (d *diskUnits) move(vuidPrefix proto.VuidPrefix, from, to proto.DiskID) {
+	if from == to {
+		return
+	}
+	d.lock.Lock()
+	if units, ok := d.units[from]; ok {
+		delete(units, vuidPrefix)
+		if len(units) == 0 {
+			delete(d.units, from)
+		}
+	}
+	d.addLocked(to, vuidPrefix)
+	d.lock.Unlock()
+}
+
+func (d *diskUnits) addLocked(diskID proto.DiskID, vuidPrefix proto.VuidPrefix) {
+	units, ok := d.units[diskID]
+	if !ok {
+		units = make(map[proto.VuidPrefix]struct{})
+		d.units[diskID] = units
+	}
+	units[vuidPrefix] = struct{}{}
+}
+
+// list returns the sorted volume units on the disk.
+func (d *diskUnits) list(diskID proto.DiskID) []proto.VuidPrefix {
+	d.lock.RLock()
+	units := d.units[diskID]
+	ret := make([]proto.VuidPrefix, 0, len(units))
+	for vuidPrefix := range units {
+		ret = append(ret, vuidPrefix)
+	}
+	d.lock.RUnlock()
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+func (d *diskUnits) count(diskID proto.DiskID) int {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return len(d.units[diskID])
+}
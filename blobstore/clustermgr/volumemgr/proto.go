@@ -59,15 +59,17 @@ func (vol *volume) ToRecord() *volumedb.VolumeRecord {
 		vuidPrefixs = append(vuidPrefixs, unit.vuidPrefix)
 	}
 	return &volumedb.VolumeRecord{
-		Vid:            vol.vid,
-		VuidPrefixs:    vuidPrefixs,
-		CodeMode:       vol.volInfoBase.CodeMode,
-		HealthScore:    vol.volInfoBase.HealthScore,
-		Status:         vol.volInfoBase.Status,
-		Total:          vol.volInfoBase.Total,
-		Free:           vol.volInfoBase.Free,
-		Used:           vol.volInfoBase.Used,
-		CreateByNodeID: vol.volInfoBase.CreateByNodeID,
+		Vid:             vol.vid,
+		VuidPrefixs:     vuidPrefixs,
+		CodeMode:        vol.volInfoBase.CodeMode,
+		HealthScore:     vol.volInfoBase.HealthScore,
+		Status:          vol.volInfoBase.Status,
+		Total:           vol.volInfoBase.Total,
+		Free:            vol.volInfoBase.Free,
+		Used:            vol.volInfoBase.Used,
+		CreateByNodeID:  vol.volInfoBase.CreateByNodeID,
+		LeaseExpireTime: vol.volInfoBase.LeaseExpireTime,
+		LeaseExpired:    vol.volInfoBase.LeaseExpired,
 	}
 }
 
@@ -108,9 +110,10 @@ func (vol *volume) setHealthScore(ctx context.Context, score int) {
 	defaultVolumeNotifyQueue.Notify(ctx, VolFreeHealthChangeNotifyKey, vol)
 }
 
-// only idle volume can Insert into volume allocator
+// only idle volume can Insert into volume allocator,
+// leased volume is kept for temporary data until lease expired and unit data released
 func (vol *volume) canInsert() bool {
-	return vol.volInfoBase.Status == proto.VolumeStatusIdle
+	return vol.volInfoBase.Status == proto.VolumeStatusIdle && !vol.isLeased()
 }
 
 func (vol *volume) canAlloc(allocatableSize uint64, allocatableScoreThreshold int) bool {
@@ -146,6 +149,14 @@ func (vol *volume) isExpired() bool {
 	return vol.getStatus() == proto.VolumeStatusActive && vol.token.expireTime < time.Now().UnixNano()
 }
 
+func (vol *volume) isLeased() bool {
+	return vol.volInfoBase.LeaseExpireTime > 0
+}
+
+func (vol *volume) isLeaseExpired(now int64) bool {
+	return vol.isLeased() && !vol.volInfoBase.LeaseExpired && vol.volInfoBase.LeaseExpireTime < now
+}
+
 func (vol *volume) isValid() bool {
 	if vol.vid == proto.InvalidVid {
 		return false
@@ -164,6 +175,8 @@ type volumeUnit struct {
 	epoch      uint32
 	nextEpoch  uint32
 	vuInfo     *cm.VolumeUnitInfo
+	// route version of the last location change
+	routeVersion uint64
 }
 
 func (vUnit *volumeUnit) ToVolumeUnitRecord() (ret *volumedb.VolumeUnitRecord) {
@@ -176,6 +189,8 @@ func (vUnit *volumeUnit) ToVolumeUnitRecord() (ret *volumedb.VolumeUnitRecord) {
 		Used:       vUnit.vuInfo.Used,
 		Total:      vUnit.vuInfo.Total,
 		Compacting: vUnit.vuInfo.Compacting,
+
+		RouteVersion: vUnit.routeVersion,
 	}
 }
 
@@ -305,13 +320,15 @@ func (w *volumeNotifyQueue) Notify(ctx context.Context, key interface{}, vol *vo
 
 func volumeRecordToVolumeInfoBase(volRecord *volumedb.VolumeRecord) cm.VolumeInfoBase {
 	return cm.VolumeInfoBase{
-		Vid:            volRecord.Vid,
-		CodeMode:       volRecord.CodeMode,
-		HealthScore:    volRecord.HealthScore,
-		Used:           volRecord.Used,
-		Total:          volRecord.Total,
-		Free:           volRecord.Free,
-		CreateByNodeID: volRecord.CreateByNodeID,
+		Vid:             volRecord.Vid,
+		CodeMode:        volRecord.CodeMode,
+		HealthScore:     volRecord.HealthScore,
+		Used:            volRecord.Used,
+		Total:           volRecord.Total,
+		Free:            volRecord.Free,
+		CreateByNodeID:  volRecord.CreateByNodeID,
+		LeaseExpireTime: volRecord.LeaseExpireTime,
+		LeaseExpired:    volRecord.LeaseExpired,
 	}
 }
 
@@ -337,6 +354,7 @@ func volumeUnitRecordToVolumeUnit(record *volumedb.VolumeUnitRecord) (ret *volum
 			Total:      record.Total,
 			Compacting: record.Compacting,
 		},
+		routeVersion: record.RouteVersion,
 	}
 }
 
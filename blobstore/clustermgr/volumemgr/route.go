@@ -0,0 +1,131 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package volumemgr
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	cm "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// volumeRouteChange units of volume changed at the route version
+type volumeRouteChange struct {
+	routeVersion uint64
+	vid          proto.Vid
+}
+
+// volumeRoute versions the location changes of volume units. route version increases
+// by one on each change when applying, and it's persisted with the changed unit, so
+// the recent changes can be rebuilt from the latest route version of all units.
+type volumeRoute struct {
+	lock         sync.RWMutex
+	capacity     int
+	routeVersion uint64
+	// changes after floor are all kept in memory
+	floor   uint64
+	changes []volumeRouteChange
+}
+
+func newVolumeRoute(capacity int) *volumeRoute {
+	return &volumeRoute{capacity: capacity}
+}
+
+// load rebuilds the route by the latest route version of volume units
+func (r *volumeRoute) load(changes []volumeRouteChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].routeVersion < changes[j].routeVersion
+	})
+
+	r.lock.Lock()
+	r.routeVersion, r.floor, r.changes = 0, 0, changes
+	if len(changes) > 0 {
+		r.routeVersion = changes[len(changes)-1].routeVersion
+	}
+	r.truncate()
+	r.lock.Unlock()
+}
+
+// add returns the new route version of the volume changed
+func (r *volumeRoute) add(vid proto.Vid) uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeVersion++
+	r.changes = append(r.changes, volumeRouteChange{routeVersion: r.routeVersion, vid: vid})
+	if len(r.changes) >= 2*r.capacity {
+		r.truncate()
+	}
+	return r.routeVersion
+}
+
+func (r *volumeRoute) truncate() {
+	if len(r.changes) <= r.capacity {
+		return
+	}
+	dropped := len(r.changes) - r.capacity
+	r.floor = r.changes[dropped-1].routeVersion
+	r.changes = append([]volumeRouteChange(nil), r.changes[dropped:]...)
+}
+
+func (r *volumeRoute) getRouteVersion() uint64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.routeVersion
+}
+
+// getChanges returns vids changed after the route version, and expired if the changes are truncated
+func (r *volumeRoute) getChanges(routeVersion uint64) (vids []proto.Vid, current uint64, expired bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	current = r.routeVersion
+	// route version larger than current may come from other node which applied more changes
+	if routeVersion == 0 || routeVersion < r.floor || routeVersion > current {
+		return nil, current, true
+	}
+	idx := sort.Search(len(r.changes), func(i int) bool {
+		return r.changes[i].routeVersion > routeVersion
+	})
+	seen := make(map[proto.Vid]struct{})
+	for _, change := range r.changes[idx:] {
+		if _, ok := seen[change.vid]; !ok {
+			seen[change.vid] = struct{}{}
+			vids = append(vids, change.vid)
+		}
+	}
+	return vids, current, false
+}
+
+// GetVolumeChanges returns the volumes whose units changed after the route version
+func (v *VolumeMgr) GetVolumeChanges(ctx context.Context, routeVersion uint64) (*cm.GetVolumeChangesRet, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	vids, current, expired := v.route.getChanges(routeVersion)
+	ret := &cm.GetVolumeChangesRet{RouteVersion: current, Expired: expired}
+	for _, vid := range vids {
+		volInfo, err := v.GetVolumeInfo(ctx, vid)
+		if err != nil {
+			span.Errorf("get changed volume %d failed, err: %v", vid, err)
+			return nil, err
+		}
+		ret.Volumes = append(ret.Volumes, volInfo)
+	}
+	span.Debugf("get volume changes after route version %d, current: %d, expired: %v, changed: %d",
+		routeVersion, current, expired, len(vids))
+	return ret, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package volumemgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestVolumeRoute(t *testing.T) {
+	r := newVolumeRoute(4)
+	_, current, expired := r.getChanges(0)
+	require.True(t, expired)
+	require.Equal(t, uint64(0), current)
+
+	require.Equal(t, uint64(1), r.add(1))
+	require.Equal(t, uint64(2), r.add(2))
+	require.Equal(t, uint64(3), r.add(1))
+
+	vids, current, expired := r.getChanges(1)
+	require.False(t, expired)
+	require.Equal(t, uint64(3), current)
+	require.Equal(t, []proto.Vid{2, 1}, vids)
+
+	vids, _, expired = r.getChanges(3)
+	require.False(t, expired)
+	require.Equal(t, 0, len(vids))
+
+	// from other node applied more changes
+	_, _, expired = r.getChanges(4)
+	require.True(t, expired)
+
+	// truncate the oldest changes
+	for vid := proto.Vid(3); vid < 8; vid++ {
+		r.add(vid)
+	}
+	require.Equal(t, uint64(8), r.getRouteVersion())
+	require.Equal(t, uint64(4), r.floor)
+	_, _, expired = r.getChanges(3)
+	require.True(t, expired)
+	vids, _, expired = r.getChanges(4)
+	require.False(t, expired)
+	require.Equal(t, []proto.Vid{4, 5, 6, 7}, vids)
+
+	// rebuild by the units
+	r = newVolumeRoute(2)
+	r.load([]volumeRouteChange{{routeVersion: 5, vid: 3}, {routeVersion: 2, vid: 1}, {routeVersion: 4, vid: 2}})
+	require.Equal(t, uint64(5), r.getRouteVersion())
+	require.Equal(t, uint64(2), r.floor)
+	vids, _, expired = r.getChanges(2)
+	require.False(t, expired)
+	require.Equal(t, []proto.Vid{2, 3}, vids)
+	require.Equal(t, uint64(6), r.add(1))
+}
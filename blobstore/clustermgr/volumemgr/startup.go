@@ -56,6 +56,8 @@ type VolumeMgrConfig struct {
 	AllocatableSize uint64 `json:"allocatable_size"`
 	// the number of volume partitions that can be allocated
 	ShardNum int `json:"shard_num"`
+	// the number of recent volume route changes kept in memory for delta sync
+	RouteChangesCapacity int `json:"route_changes_capacity"`
 
 	// the volume in Proxy which free size small than FreezeThreshold treat filled
 	FreezeThreshold  uint64            `json:"-"`
@@ -103,6 +105,9 @@ func (c *VolumeMgrConfig) checkAndFix() {
 	if c.ShardNum <= 0 {
 		c.ShardNum = defaultShardNum
 	}
+	if c.RouteChangesCapacity <= 0 {
+		c.RouteChangesCapacity = defaultRouteChangesCapacity
+	}
 }
 
 // NewVolumeMgr constructs a new volume manager.
@@ -131,6 +136,8 @@ func NewVolumeMgr(conf VolumeMgrConfig, diskMgr cluster.BlobNodeManagerAPI, scop
 	// initial volumeMgr
 	volumeMgr := &VolumeMgr{
 		all:             newShardedVolumes(conf.VolumeSliceMapNum),
+		diskUnits:       newDiskUnits(),
+		route:           newVolumeRoute(conf.RouteChangesCapacity),
 		volumeTbl:       volumeTable,
 		transitedTbl:    transitedTable,
 		createVolChan:   make(chan struct{}, 1),
@@ -196,7 +203,8 @@ func (v *VolumeMgr) Start() {
 }
 
 func (v *VolumeMgr) loadVolume(ctx context.Context) error {
-	return v.volumeTbl.RangeVolumeRecord(func(volRecord *volumedb.VolumeRecord) error {
+	var routeChanges []volumeRouteChange
+	err := v.volumeTbl.RangeVolumeRecord(func(volRecord *volumedb.VolumeRecord) error {
 		// volumeUnits use for internal
 		var volumeUnits []*volumeUnit
 
@@ -218,6 +226,9 @@ func (v *VolumeMgr) loadVolume(ctx context.Context) error {
 			vUnit := volumeUnitRecordToVolumeUnit(unitRecord)
 			vUnit.vuInfo.Host = diskInfo.Host
 			volumeUnits = append(volumeUnits, vUnit)
+			if vUnit.routeVersion > 0 {
+				routeChanges = append(routeChanges, volumeRouteChange{routeVersion: vUnit.routeVersion, vid: volRecord.Vid})
+			}
 		}
 
 		volInfo := volumeRecordToVolumeInfoBase(volRecord)
@@ -233,6 +244,9 @@ func (v *VolumeMgr) loadVolume(ctx context.Context) error {
 		}
 
 		v.all.putVol(volume)
+		for _, unit := range volumeUnits {
+			v.diskUnits.add(unit.vuInfo.DiskID, unit.vuidPrefix)
+		}
 
 		// refresh volume health
 		err := v.refreshHealth(ctx, volRecord.Vid)
@@ -245,6 +259,11 @@ func (v *VolumeMgr) loadVolume(ctx context.Context) error {
 		volume.setStatus(ctx, volRecord.Status)
 		return err
 	})
+	if err != nil {
+		return err
+	}
+	v.route.load(routeChanges)
+	return nil
 }
 
 func (v *VolumeMgr) Close() {
@@ -56,6 +56,7 @@ const (
 	defaultAllocFactor                 = 5
 	defaultAllocatableSize             = 1 << 30
 	defaultShardNum                    = 16
+	defaultRouteChangesCapacity        = 1 << 16
 )
 
 // notify queue key definition
@@ -75,6 +76,8 @@ var (
 	ErrInvalidVolume            = errors.New(" volume is invalid ")
 	ErrInvalidToken             = errors.New("retain token is invalid")
 	ErrRepeatUpdateUnit         = errors.New("repeat update volume unit")
+	ErrVolumeNotLeased          = apierrors.ErrVolumeNotLeased
+	ErrVolumeLeaseExpired       = apierrors.ErrVolumeLeaseExpired
 )
 
 // VolumeMgr defines volume manager interface
@@ -91,6 +94,13 @@ type VolumeMgrAPI interface {
 	// VolumeAlloc alloc volumes
 	AllocVolume(ctx context.Context, mode codemode.CodeMode, count int, host string) (ret *cm.AllocatedVolumeInfos, err error)
 
+	// AllocLeaseVolume alloc volumes for temporary data, unit data of volumes will be
+	// marked for deletion when lease expired unless renewed
+	AllocLeaseVolume(ctx context.Context, mode codemode.CodeMode, count int, host string, leaseTTLS int64) (ret *cm.AllocatedVolumeInfos, err error)
+
+	// RenewVolumeLease renew lease of volumes, return new expire time of lease
+	RenewVolumeLease(ctx context.Context, vids []proto.Vid, leaseTTLS int64) (leaseExpireTime int64, err error)
+
 	// ListAllocatedVolume list allocated volumes
 	ListAllocatedVolume(ctx context.Context, host string, mode codemode.CodeMode) (ret *cm.AllocatedVolumeInfos)
 
@@ -111,9 +121,16 @@ type VolumeMgrAPI interface {
 
 	// ListVolumeUnitInfo head all volume unit info in the disk
 	ListVolumeUnitInfo(ctx context.Context, args *cm.ListVolumeUnitArgs) ([]*cm.VolumeUnitInfo, error)
+
+	// ListVolumeUnitsOnDisk returns vuids of all volume units in the disk from the in-memory index
+	ListVolumeUnitsOnDisk(ctx context.Context, diskID proto.DiskID) []proto.Vuid
+
 	LockVolume(ctx context.Context, vid proto.Vid) error
 	UnlockVolume(ctx context.Context, vid proto.Vid) error
 
+	// GetVolumeChanges returns volumes whose units changed after the route version
+	GetVolumeChanges(ctx context.Context, routeVersion uint64) (*cm.GetVolumeChangesRet, error)
+
 	// Stat return volume statistic info
 	Stat(ctx context.Context) (stat cm.VolumeStatInfo)
 }
@@ -123,6 +140,8 @@ type VolumeMgr struct {
 	module        string
 	raftServer    raftserver.RaftServer
 	all           *shardedVolumes
+	diskUnits     *diskUnits
+	route         *volumeRoute
 	allocator     *volumeAllocator
 	taskMgr       *taskManager
 	lastTaskIdMap sync.Map
@@ -280,6 +299,17 @@ func (v *VolumeMgr) PreRetainVolume(ctx context.Context, tokens []string, host s
 }
 
 func (v *VolumeMgr) AllocVolume(ctx context.Context, mode codemode.CodeMode, count int, host string) (ret *cm.AllocatedVolumeInfos, err error) {
+	return v.allocVolume(ctx, mode, count, host, 0)
+}
+
+func (v *VolumeMgr) AllocLeaseVolume(ctx context.Context, mode codemode.CodeMode, count int, host string, leaseTTLS int64) (ret *cm.AllocatedVolumeInfos, err error) {
+	if leaseTTLS <= 0 {
+		return nil, apierrors.ErrAllocVolumeInvalidParams
+	}
+	return v.allocVolume(ctx, mode, count, host, leaseTTLS)
+}
+
+func (v *VolumeMgr) allocVolume(ctx context.Context, mode codemode.CodeMode, count int, host string, leaseTTLS int64) (ret *cm.AllocatedVolumeInfos, err error) {
 	if _, ok := v.codeMode[mode]; !ok {
 		return nil, ErrInvalidCodeMode
 	}
@@ -326,6 +356,9 @@ func (v *VolumeMgr) AllocVolume(ctx context.Context, mode codemode.CodeMode, cou
 		PendingAllocVolKey: pendingKey,
 		ExpireTime:         time.Now().Add(time.Second * time.Duration(v.RetainTimeS)).UnixNano(),
 	}
+	if leaseTTLS > 0 {
+		allocArgs.LeaseExpireTime = time.Now().Add(time.Second * time.Duration(leaseTTLS)).UnixNano()
+	}
 	span.Debugf("alloc volume is %+v", allocArgs)
 	data, err := json.Marshal(allocArgs)
 	if err != nil {
@@ -375,12 +408,63 @@ func (v *VolumeMgr) AllocVolume(ctx context.Context, mode codemode.CodeMode, cou
 	return ret, nil
 }
 
-func (v *VolumeMgr) DiskWritableChange(ctx context.Context, diskID proto.DiskID) (err error) {
+func (v *VolumeMgr) RenewVolumeLease(ctx context.Context, vids []proto.Vid, leaseTTLS int64) (int64, error) {
 	span := trace.SpanFromContextSafe(ctx)
-	vuidPrefixes, err := v.volumeTbl.ListVolumeUnit(diskID)
+	if len(vids) == 0 || leaseTTLS <= 0 {
+		return 0, apierrors.ErrIllegalArguments
+	}
+	for _, vid := range vids {
+		vol := v.all.getVol(vid)
+		if vol == nil {
+			span.Errorf("volume not found, vid: %d", vid)
+			return 0, ErrVolumeNotExist
+		}
+		if err := vol.withRLocked(func() error {
+			if !vol.isLeased() {
+				return ErrVolumeNotLeased
+			}
+			if vol.volInfoBase.LeaseExpired {
+				return ErrVolumeLeaseExpired
+			}
+			return nil
+		}); err != nil {
+			span.Warnf("can't renew lease of volume %d, err: %v", vid, err)
+			return 0, err
+		}
+	}
+
+	args := &VolumeLeaseCtx{
+		Vids:       vids,
+		ExpireTime: time.Now().Add(time.Second * time.Duration(leaseTTLS)).UnixNano(),
+	}
+	data, err := json.Marshal(args)
 	if err != nil {
-		return err
+		span.Errorf("json marshal failed, args: %+v, error: %v", args, err)
+		return 0, apierrors.ErrCMUnexpect
 	}
+	proposeInfo := base.EncodeProposeInfo(v.GetModuleName(), OperTypeRenewVolumeLease, data, base.ProposeContext{ReqID: span.TraceID()})
+	if err = v.raftServer.Propose(ctx, proposeInfo); err != nil {
+		span.Errorf("raft propose error: %v", err)
+		return 0, apierrors.ErrRaftPropose
+	}
+
+	// lease may be expired before renew applied
+	for _, vid := range vids {
+		vol := v.all.getVol(vid)
+		vol.lock.RLock()
+		expired := vol.volInfoBase.LeaseExpired
+		vol.lock.RUnlock()
+		if expired {
+			span.Warnf("lease of volume %d expired before renewed", vid)
+			return 0, ErrVolumeLeaseExpired
+		}
+	}
+	return args.ExpireTime, nil
+}
+
+func (v *VolumeMgr) DiskWritableChange(ctx context.Context, diskID proto.DiskID) (err error) {
+	span := trace.SpanFromContextSafe(ctx)
+	vuidPrefixes := v.diskUnits.list(diskID)
 	if len(vuidPrefixes) == 0 {
 		return nil
 	}
@@ -565,7 +649,7 @@ func (v *VolumeMgr) applyRetainVolume(ctx context.Context, retainVolTokens []cm.
 	return nil
 }
 
-func (v *VolumeMgr) applyAllocVolume(ctx context.Context, vid proto.Vid, host string, expireTime int64) (ret cm.AllocVolumeInfo, err error) {
+func (v *VolumeMgr) applyAllocVolume(ctx context.Context, vid proto.Vid, host string, expireTime, leaseExpireTime int64) (ret cm.AllocVolumeInfo, err error) {
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("start apply alloc volume,vid is %d", vid)
 
@@ -600,6 +684,7 @@ func (v *VolumeMgr) applyAllocVolume(ctx context.Context, vid proto.Vid, host st
 		expireTime: expireTime,
 	}
 	volume.token = token
+	volume.volInfoBase.LeaseExpireTime = leaseExpireTime
 	// set volume status into active, it'll call change status event function
 	volume.setStatus(ctx, proto.VolumeStatusActive)
 	volRecord := volume.ToRecord()
@@ -657,6 +742,129 @@ func (v *VolumeMgr) applyExpireVolume(ctx context.Context, vids []proto.Vid) (er
 	return
 }
 
+func (v *VolumeMgr) applyRenewVolumeLease(ctx context.Context, vids []proto.Vid, leaseExpireTime int64) error {
+	span := trace.SpanFromContextSafe(ctx)
+	for _, vid := range vids {
+		vol := v.all.getVol(vid)
+		if vol == nil {
+			span.Errorf("apply renew volume lease, vid %d not exist", vid)
+			return ErrVolumeNotExist
+		}
+		vol.lock.Lock()
+		// expired lease can't be renewed any more
+		if !vol.isLeased() || vol.volInfoBase.LeaseExpired {
+			vol.lock.Unlock()
+			span.Warnf("volume %d lease can't be renewed, volume info is %+v", vid, vol.volInfoBase)
+			continue
+		}
+		if vol.volInfoBase.LeaseExpireTime >= leaseExpireTime {
+			vol.lock.Unlock()
+			continue
+		}
+		vol.volInfoBase.LeaseExpireTime = leaseExpireTime
+		if err := v.volumeTbl.PutVolumeRecord(vol.ToRecord()); err != nil {
+			vol.lock.Unlock()
+			return err
+		}
+		vol.lock.Unlock()
+	}
+	return nil
+}
+
+// applyExpireVolumeLease marks unit data of lease expired volumes for deletion,
+// expireTime is checked by leader to keep all raft nodes consistent
+func (v *VolumeMgr) applyExpireVolumeLease(ctx context.Context, vids []proto.Vid, expireTime int64) error {
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debugf("start apply expire volume lease, vids is %v", vids)
+
+	for _, vid := range vids {
+		vol := v.all.getVol(vid)
+		if vol == nil {
+			span.Errorf("apply expire volume lease, vid %d not exist", vid)
+			return ErrVolumeNotExist
+		}
+		vol.lock.Lock()
+		// renewed after checked or already been proceed
+		if !vol.isLeaseExpired(expireTime) {
+			vol.lock.Unlock()
+			continue
+		}
+
+		span.Infof("volume %d lease expired, mark unit data for deletion", vid)
+		vol.volInfoBase.LeaseExpired = true
+		// stop writing, lease expired volume will insert into allocator after unit data released
+		if vol.getStatus() == proto.VolumeStatusActive {
+			vol.setStatus(ctx, proto.VolumeStatusIdle)
+		}
+		if err := v.volumeTbl.PutVolumeRecord(vol.ToRecord()); err != nil {
+			vol.lock.Unlock()
+			return err
+		}
+		vol.lock.Unlock()
+	}
+
+	span.Debugf("finish apply expire volume lease, vids is %v", vids)
+	return nil
+}
+
+// applyReleaseVolumeLease resets lease of volumes whose units have been replaced by
+// empty chunks, the volume is reusable and inserted into allocator when idle
+func (v *VolumeMgr) applyReleaseVolumeLease(ctx context.Context, vids []proto.Vid) error {
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debugf("start apply release volume lease, vids is %v", vids)
+
+	for _, vid := range vids {
+		vol := v.all.getVol(vid)
+		if vol == nil {
+			span.Errorf("apply release volume lease, vid %d not exist", vid)
+			return ErrVolumeNotExist
+		}
+		vol.lock.Lock()
+		// wal log replay or already been released
+		if !vol.volInfoBase.LeaseExpired {
+			vol.lock.Unlock()
+			continue
+		}
+
+		vol.volInfoBase.LeaseExpireTime = 0
+		vol.volInfoBase.LeaseExpired = false
+		vol.volInfoBase.Free = vol.volInfoBase.Total
+		vol.volInfoBase.Used = 0
+		unitRecords := make([]*volumedb.VolumeUnitRecord, 0, len(vol.vUnits))
+		for _, unit := range vol.vUnits {
+			unit.vuInfo.Free = unit.vuInfo.Total
+			unit.vuInfo.Used = 0
+			unitRecords = append(unitRecords, unit.ToVolumeUnitRecord())
+		}
+		if err := v.volumeTbl.PutVolumeAndVolumeUnit([]*volumedb.VolumeRecord{vol.ToRecord()},
+			[][]*volumedb.VolumeUnitRecord{unitRecords}); err != nil {
+			vol.lock.Unlock()
+			return err
+		}
+		span.Infof("volume %d lease released", vid)
+		// notify allocator to insert the released volume
+		if vol.getStatus() == proto.VolumeStatusIdle {
+			vol.setStatus(ctx, proto.VolumeStatusIdle)
+		}
+		vol.lock.Unlock()
+	}
+
+	span.Debugf("finish apply release volume lease, vids is %v", vids)
+	return nil
+}
+
+func (v *VolumeMgr) getLeaseExpiredVolumes(now int64) (vids []proto.Vid) {
+	v.all.rangeVol(func(vol *volume) error {
+		vol.lock.RLock()
+		if vol.isLeaseExpired(now) {
+			vids = append(vids, vol.vid)
+		}
+		vol.lock.RUnlock()
+		return nil
+	})
+	return
+}
+
 func (v *VolumeMgr) applyAdminUpdateVolume(ctx context.Context, volInfo *cm.VolumeInfoBase) error {
 	span := trace.SpanFromContextSafe(ctx)
 	vol := v.all.getVol(volInfo.Vid)
@@ -693,6 +901,7 @@ func (v *VolumeMgr) applyAdminUpdateVolumeUnit(ctx context.Context, unitInfo *cm
 	vol.lock.RUnlock()
 
 	vol.lock.Lock()
+	oldDiskID := vol.vUnits[index].vuInfo.DiskID
 	if proto.IsValidEpoch(unitInfo.Epoch) {
 		vol.vUnits[index].epoch = unitInfo.Epoch
 		vol.vUnits[index].vuInfo.Vuid = proto.EncodeVuid(vol.vUnits[index].vuidPrefix, unitInfo.Epoch)
@@ -708,9 +917,14 @@ func (v *VolumeMgr) applyAdminUpdateVolumeUnit(ctx context.Context, unitInfo *cm
 	vol.vUnits[index].vuInfo.DiskID = diskInfo.DiskID
 	vol.vUnits[index].vuInfo.Host = diskInfo.Host
 	vol.vUnits[index].vuInfo.Compacting = unitInfo.Compacting
+	vol.vUnits[index].routeVersion = v.route.add(vol.vid)
 
 	unitRecord := vol.vUnits[index].ToVolumeUnitRecord()
-	err = v.volumeTbl.PutVolumeUnit(unitInfo.Vuid.VuidPrefix(), unitRecord)
+	// update the disk index of volume unit in table if the disk changed
+	err = v.volumeTbl.UpdateVolumeUnit(unitInfo.Vuid.VuidPrefix(), unitRecord)
+	if err == nil {
+		v.diskUnits.move(unitRecord.VuidPrefix, oldDiskID, unitRecord.DiskID)
+	}
 	vol.lock.Unlock()
 	return err
 }
@@ -787,9 +1001,12 @@ func (v *VolumeMgr) loop() {
 				continue
 			}
 
-			span_, _ := trace.StartSpanFromContext(context.Background(), "")
+			span_, ctx_ := trace.StartSpanFromContext(context.Background(), "")
 			span_.Debug("start check expiredVolume")
 
+			v.checkLeaseExpiredVolumes(ctx_)
+			v.releaseLeaseExpiredVolumes(ctx_)
+
 			expiredVids := v.allocator.GetExpiredVolumes()
 			if len(expiredVids) == 0 {
 				continue
@@ -814,6 +1031,102 @@ func (v *VolumeMgr) loop() {
 	}
 }
 
+func (v *VolumeMgr) checkLeaseExpiredVolumes(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+	now := time.Now().UnixNano()
+	vids := v.getLeaseExpiredVolumes(now)
+	if len(vids) == 0 {
+		return
+	}
+	span.Infof("lease expired vids is %v", vids)
+
+	data, err := json.Marshal(&VolumeLeaseCtx{Vids: vids, ExpireTime: now})
+	if err != nil {
+		span.Errorf("json Marshal error:%v", err)
+		return
+	}
+	proposeInfo := base.EncodeProposeInfo(v.GetModuleName(), OperTypeExpireVolumeLease, data, base.ProposeContext{ReqID: span.TraceID()})
+	if err = v.raftServer.Propose(ctx, proposeInfo); err != nil {
+		span.Errorf("raft propose data error:%v", err)
+	}
+}
+
+// releaseLeaseExpiredVolumes replaces every unit of lease expired volumes with an
+// empty chunk and releases the old one, then resets the lease so that volume can
+// be allocated again. all units are replaced again if interrupted, chunks of stale
+// epoch left on blobnode will be cleaned by blobnode garbage epoch check
+func (v *VolumeMgr) releaseLeaseExpiredVolumes(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+	var vids []proto.Vid
+	v.all.rangeVol(func(vol *volume) error {
+		vol.lock.RLock()
+		if vol.volInfoBase.LeaseExpired && vol.getStatus() == proto.VolumeStatusIdle {
+			vids = append(vids, vol.vid)
+		}
+		vol.lock.RUnlock()
+		return nil
+	})
+	if len(vids) == 0 {
+		return
+	}
+
+	released := make([]proto.Vid, 0, len(vids))
+	for _, vid := range vids {
+		if err := v.releaseVolumeUnits(ctx, vid); err != nil {
+			span.Errorf("release units of lease expired volume %d failed: %s", vid, errors.Detail(err))
+			continue
+		}
+		released = append(released, vid)
+	}
+	if len(released) == 0 {
+		return
+	}
+	span.Infof("lease released vids is %v", released)
+
+	data, err := json.Marshal(&VolumeLeaseCtx{Vids: released})
+	if err != nil {
+		span.Errorf("json Marshal error:%v", err)
+		return
+	}
+	proposeInfo := base.EncodeProposeInfo(v.GetModuleName(), OperTypeReleaseVolumeLease, data, base.ProposeContext{ReqID: span.TraceID()})
+	if err = v.raftServer.Propose(ctx, proposeInfo); err != nil {
+		span.Errorf("raft propose data error:%v", err)
+	}
+}
+
+func (v *VolumeMgr) releaseVolumeUnits(ctx context.Context, vid proto.Vid) error {
+	span := trace.SpanFromContextSafe(ctx)
+	vol := v.all.getVol(vid)
+	if vol == nil {
+		return ErrVolumeNotExist
+	}
+	vol.lock.RLock()
+	units := make([]cm.VolumeUnitInfo, 0, len(vol.vUnits))
+	for _, unit := range vol.vUnits {
+		units = append(units, *unit.vuInfo)
+	}
+	vol.lock.RUnlock()
+
+	for _, unit := range units {
+		allocUnit, err := v.AllocVolumeUnit(ctx, unit.Vuid)
+		if err != nil {
+			return errors.Info(err, "alloc volume unit failed").Detail(err)
+		}
+		data, err := json.Marshal(&cm.UpdateVolumeArgs{OldVuid: unit.Vuid, NewVuid: allocUnit.Vuid, NewDiskID: allocUnit.DiskID})
+		if err != nil {
+			return errors.Info(err, "json marshal failed").Detail(err)
+		}
+		proposeInfo := base.EncodeProposeInfo(v.GetModuleName(), OperTypeUpdateVolumeUnit, data, base.ProposeContext{ReqID: span.TraceID()})
+		if err = v.raftServer.Propose(ctx, proposeInfo); err != nil {
+			return errors.Info(err, "raft propose update volume unit failed").Detail(err)
+		}
+		if err = v.ReleaseVolumeUnit(ctx, unit.Vuid, unit.DiskID, true); err != nil {
+			span.Warnf("release old chunk of volume unit %d on disk %d failed: %s", unit.Vuid, unit.DiskID, err)
+		}
+	}
+	return nil
+}
+
 // refreshHealth use for refreshing volume healthScore
 // healthScore only correspond with writable volumeUnit num
 func (v *VolumeMgr) refreshHealth(ctx context.Context, vid proto.Vid) error {
@@ -532,7 +532,7 @@ func TestVolumeMgr_applyAllocVolume(t *testing.T) {
 		allocVolLenMap := mockVolumeMgr.allocator.StatAllocatable()
 		beforeLength := allocVolLenMap[mode]
 		for _, vid := range args.Vids {
-			_, err := mockVolumeMgr.applyAllocVolume(ctx, vid, args.Host, args.ExpireTime)
+			_, err := mockVolumeMgr.applyAllocVolume(ctx, vid, args.Host, args.ExpireTime, 0)
 			require.NoError(t, err)
 		}
 
@@ -543,7 +543,7 @@ func TestVolumeMgr_applyAllocVolume(t *testing.T) {
 		// test count > len(allocatorVol)
 		args.Vids = []proto.Vid{0, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28}
 		for _, vid := range args.Vids {
-			_, err := mockVolumeMgr.applyAllocVolume(ctx, vid, args.Host, args.ExpireTime)
+			_, err := mockVolumeMgr.applyAllocVolume(ctx, vid, args.Host, args.ExpireTime, 0)
 			require.NoError(t, err)
 		}
 
@@ -551,7 +551,7 @@ func TestVolumeMgr_applyAllocVolume(t *testing.T) {
 		allocVolLenMap = mockVolumeMgr.allocator.StatAllocatable()
 		require.Equal(t, 0, allocVolLenMap[mode])
 		for _, vid := range args.Vids {
-			ret, err := mockVolumeMgr.applyAllocVolume(ctx, vid, args.Host, args.ExpireTime)
+			ret, err := mockVolumeMgr.applyAllocVolume(ctx, vid, args.Host, args.ExpireTime, 0)
 			require.NoError(t, err)
 			// skip active volume when allocation
 			require.Equal(t, 0, len(ret.Units))
@@ -559,7 +559,7 @@ func TestVolumeMgr_applyAllocVolume(t *testing.T) {
 
 		// test vid not exist
 		args.Vids = []proto.Vid{44}
-		_, err := mockVolumeMgr.applyAllocVolume(ctx, args.Vids[0], args.Host, args.ExpireTime)
+		_, err := mockVolumeMgr.applyAllocVolume(ctx, args.Vids[0], args.Host, args.ExpireTime, 0)
 		require.Error(t, err)
 	}
 
@@ -740,6 +740,151 @@ func TestVolumeMgr_applyExpireVolume(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestVolumeMgr_VolumeLease(t *testing.T) {
+	mockVolumeMgr, clean := initMockVolumeMgr(t)
+	defer clean()
+
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+	mode := codemode.EC15P12
+	leaseExpireTime := time.Now().Add(10 * time.Second).UnixNano()
+	ret, err := mockVolumeMgr.applyAllocVolume(ctx, 2, "127.0.0.1:8080", time.Now().Add(time.Minute).UnixNano(), leaseExpireTime)
+	require.NoError(t, err)
+	require.Equal(t, leaseExpireTime, ret.LeaseExpireTime)
+	require.False(t, ret.LeaseExpired)
+
+	_, err = mockVolumeMgr.AllocLeaseVolume(ctx, mode, 1, "127.0.0.1:8080", 0)
+	require.Error(t, err)
+
+	// failed case: invalid args, vid not exist, volume not leased
+	_, err = mockVolumeMgr.RenewVolumeLease(ctx, []proto.Vid{2}, 0)
+	require.Error(t, err)
+	_, err = mockVolumeMgr.RenewVolumeLease(ctx, []proto.Vid{55}, 10)
+	require.ErrorIs(t, err, ErrVolumeNotExist)
+	_, err = mockVolumeMgr.RenewVolumeLease(ctx, []proto.Vid{2, 1}, 10)
+	require.ErrorIs(t, err, ErrVolumeNotLeased)
+
+	mockRaftServer := mocks.NewMockRaftServer(gomock.NewController(t))
+	mockVolumeMgr.raftServer = mockRaftServer
+	mockRaftServer.EXPECT().Propose(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	renewed, err := mockVolumeMgr.RenewVolumeLease(ctx, []proto.Vid{2}, 60)
+	require.NoError(t, err)
+	require.Greater(t, renewed, leaseExpireTime)
+	require.NoError(t, mockVolumeMgr.applyRenewVolumeLease(ctx, []proto.Vid{2}, renewed))
+	// lease will not be shortened
+	require.NoError(t, mockVolumeMgr.applyRenewVolumeLease(ctx, []proto.Vid{2}, leaseExpireTime))
+	vol2 := mockVolumeMgr.all.getVol(2)
+	require.Equal(t, renewed, vol2.volInfoBase.LeaseExpireTime)
+
+	require.Equal(t, 0, len(mockVolumeMgr.getLeaseExpiredVolumes(time.Now().UnixNano())))
+	require.Equal(t, []proto.Vid{2}, mockVolumeMgr.getLeaseExpiredVolumes(renewed+1))
+
+	// renewed after checked
+	require.NoError(t, mockVolumeMgr.applyExpireVolumeLease(ctx, []proto.Vid{2}, leaseExpireTime))
+	require.False(t, vol2.volInfoBase.LeaseExpired)
+	require.Equal(t, proto.VolumeStatusActive, vol2.getStatus())
+
+	require.NoError(t, mockVolumeMgr.applyExpireVolumeLease(ctx, []proto.Vid{2}, renewed+1))
+	require.True(t, vol2.volInfoBase.LeaseExpired)
+	require.Equal(t, proto.VolumeStatusIdle, vol2.getStatus())
+	require.False(t, vol2.canInsert())
+	require.Nil(t, mockVolumeMgr.allocator.idles[mode].get(2))
+	require.Equal(t, 0, len(mockVolumeMgr.getLeaseExpiredVolumes(renewed+1)))
+	require.ErrorIs(t, mockVolumeMgr.applyExpireVolumeLease(ctx, []proto.Vid{55}, renewed), ErrVolumeNotExist)
+
+	// expired lease can't be renewed
+	_, err = mockVolumeMgr.RenewVolumeLease(ctx, []proto.Vid{2}, 60)
+	require.ErrorIs(t, err, ErrVolumeLeaseExpired)
+	require.NoError(t, mockVolumeMgr.applyRenewVolumeLease(ctx, []proto.Vid{2}, renewed+int64(time.Minute)))
+	require.Equal(t, renewed, vol2.volInfoBase.LeaseExpireTime)
+
+	// idle volume with expired lease will not be inserted into allocator
+	vol2.lock.Lock()
+	vol2.setStatus(ctx, proto.VolumeStatusIdle)
+	vol2.lock.Unlock()
+	require.Nil(t, mockVolumeMgr.allocator.idles[mode].get(2))
+
+	// lease is persisted
+	mockVolumeMgr.volumeTbl.RangeVolumeRecord(func(rec *volumedb.VolumeRecord) error {
+		if rec.Vid == 2 {
+			require.Equal(t, renewed, rec.LeaseExpireTime)
+			require.True(t, rec.LeaseExpired)
+		}
+		return nil
+	})
+
+	// propose expire lease by leader
+	mockVolumeMgr.checkLeaseExpiredVolumes(ctx)
+	vol4 := mockVolumeMgr.all.getVol(4)
+	vol4.lock.Lock()
+	vol4.volInfoBase.LeaseExpireTime = time.Now().Add(-time.Second).UnixNano()
+	vol4.lock.Unlock()
+	mockVolumeMgr.checkLeaseExpiredVolumes(ctx)
+}
+
+func TestVolumeMgr_ReleaseVolumeLease(t *testing.T) {
+	mockVolumeMgr, clean := initMockVolumeMgr(t)
+	defer clean()
+
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+	mode := codemode.EC15P12
+	leaseExpireTime := time.Now().Add(10 * time.Second).UnixNano()
+	_, err := mockVolumeMgr.applyAllocVolume(ctx, 2, "127.0.0.1:8080", time.Now().Add(time.Minute).UnixNano(), leaseExpireTime)
+	require.NoError(t, err)
+	require.NoError(t, mockVolumeMgr.applyExpireVolumeLease(ctx, []proto.Vid{2}, leaseExpireTime+1))
+	vol2 := mockVolumeMgr.all.getVol(2)
+	require.True(t, vol2.volInfoBase.LeaseExpired)
+	require.False(t, vol2.canInsert())
+
+	oldUnits := make([]clustermgr.VolumeUnitInfo, 0, len(vol2.vUnits))
+	for _, unit := range vol2.vUnits {
+		unit.vuInfo.Used = 1024
+		oldUnits = append(oldUnits, *unit.vuInfo)
+	}
+
+	ctr := gomock.NewController(t)
+	mockRaftServer := mocks.NewMockRaftServer(ctr)
+	mockRaftServer.EXPECT().Propose(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(ctx context.Context, data []byte) error {
+		info := base.DecodeProposeInfo(data)
+		return mockVolumeMgr.Apply(ctx, []int32{info.OperType}, [][]byte{info.Data}, []base.ProposeContext{info.Context})
+	})
+	mockVolumeMgr.raftServer = mockRaftServer
+	mockDiskMgr := cluster.NewMockBlobNodeManagerAPI(ctr)
+	mockDiskMgr.EXPECT().GetDiskInfo(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(mockGetDiskInfo)
+	mockDiskMgr.EXPECT().AllocChunks(gomock.Any(), gomock.Any()).Times(len(oldUnits)).DoAndReturn(
+		func(ctx context.Context, policy cluster.AllocPolicy) ([]proto.DiskID, []proto.Vuid, error) {
+			return []proto.DiskID{proto.DiskID(1000 + int(policy.Vuids[0].Index()))}, policy.Vuids, nil
+		})
+	mockVolumeMgr.diskMgr = mockDiskMgr
+	mockBlobNode := mocks.NewMockStorageAPI(ctr)
+	mockBlobNode.EXPECT().ReleaseChunk(gomock.Any(), gomock.Any(), gomock.Any()).Times(len(oldUnits)).Return(nil)
+	mockVolumeMgr.blobNodeClient = mockBlobNode
+
+	// lease expired volume is reusable after unit data released
+	mockVolumeMgr.releaseLeaseExpiredVolumes(ctx)
+	require.False(t, vol2.volInfoBase.LeaseExpired)
+	require.Equal(t, int64(0), vol2.volInfoBase.LeaseExpireTime)
+	require.Equal(t, vol2.volInfoBase.Total, vol2.volInfoBase.Free)
+	require.True(t, vol2.canInsert())
+	require.NotNil(t, mockVolumeMgr.allocator.idles[mode].get(2))
+	for idx, unit := range vol2.vUnits {
+		require.NotEqual(t, oldUnits[idx].Vuid, unit.vuInfo.Vuid)
+		require.NotEqual(t, oldUnits[idx].DiskID, unit.vuInfo.DiskID)
+		require.Equal(t, uint64(0), unit.vuInfo.Used)
+	}
+	mockVolumeMgr.volumeTbl.RangeVolumeRecord(func(rec *volumedb.VolumeRecord) error {
+		if rec.Vid == 2 {
+			require.Equal(t, int64(0), rec.LeaseExpireTime)
+			require.False(t, rec.LeaseExpired)
+		}
+		return nil
+	})
+
+	// released already, nothing to do
+	mockVolumeMgr.releaseLeaseExpiredVolumes(ctx)
+	require.NoError(t, mockVolumeMgr.applyReleaseVolumeLease(ctx, []proto.Vid{2}))
+	require.ErrorIs(t, mockVolumeMgr.applyReleaseVolumeLease(ctx, []proto.Vid{55}), ErrVolumeNotExist)
+}
+
 func TestVolumeMgr_ListAllocatedVolume(t *testing.T) {
 	mockVolumeMgr, clean := initMockVolumeMgr(t)
 	defer clean()
@@ -802,6 +947,13 @@ func TestVolumeMgr_ApplyAdminUpdateVolumeUnit(t *testing.T) {
 	require.Equal(t, unitRecord.NextEpoch, unitRecord.NextEpoch)
 	require.Equal(t, unitRecord.DiskID, unitRecord.DiskID)
 
+	// disk index of volume unit moved
+	require.Equal(t, []proto.Vuid{unitInfo.Vuid}, mockVolumeMgr.ListVolumeUnitsOnDisk(context.Background(), 88))
+	require.Equal(t, volumeCount-1, len(mockVolumeMgr.ListVolumeUnitsOnDisk(context.Background(), 2)))
+	vuidPrefixes, err := mockVolumeMgr.volumeTbl.ListVolumeUnit(88)
+	require.NoError(t, err)
+	require.Equal(t, []proto.VuidPrefix{proto.EncodeVuidPrefix(1, 1)}, vuidPrefixes)
+
 	// failed case,diskid = 0 ,not update
 	unitInfo1 := &clustermgr.AdminUpdateUnitArgs{
 		Epoch:     1,
@@ -30,25 +30,66 @@ import (
 	"github.com/cubefs/cubefs/blobstore/util/errors"
 )
 
-// ListVolumeUnitInfo return disk's volume unit infos, it use index-table disk-vuid as index
+// ListVolumeUnitInfo return disk's volume unit infos, it use in-memory index disk-vuid as index
 // this API is lightly operation, it only call when broken disk or some else, so here is just get data from db
 func (v *VolumeMgr) ListVolumeUnitInfo(ctx context.Context, args *cmapi.ListVolumeUnitArgs) ([]*cmapi.VolumeUnitInfo, error) {
-	unitPrefixs, err := v.volumeTbl.ListVolumeUnit(args.DiskID)
-	if err != nil {
-		return nil, errors.Info(err, "head volume unit from tbl failed").Detail(err)
-	}
-
 	ret := make([]*cmapi.VolumeUnitInfo, 0)
-	for _, unitPrefix := range unitPrefixs {
-		record, err := v.volumeTbl.GetVolumeUnit(unitPrefix)
-		if err != nil {
-			return nil, errors.Info(err, "get volume unit from tbl failed").Detail(err)
+	v.rangeUnitsOnDisk(args.DiskID, func(unit *volumeUnit) {
+		vuInfo := *unit.vuInfo
+		ret = append(ret, &vuInfo)
+	})
+	return ret, nil
+}
+
+func (v *VolumeMgr) ListVolumeUnitsOnDisk(ctx context.Context, diskID proto.DiskID) []proto.Vuid {
+	ret := make([]proto.Vuid, 0, v.diskUnits.count(diskID))
+	v.rangeUnitsOnDisk(diskID, func(unit *volumeUnit) {
+		ret = append(ret, unit.vuInfo.Vuid)
+	})
+	return ret
+}
+
+// ListPlacedUnits returns disks of units grouped by az of the volumes which have any unit on the disk
+func (v *VolumeMgr) ListPlacedUnits(ctx context.Context, diskID proto.DiskID) ([]cluster.PlacedUnits, error) {
+	ret := make([]cluster.PlacedUnits, 0)
+	for _, vuidPrefix := range v.diskUnits.list(diskID) {
+		vol := v.all.getVol(vuidPrefix.Vid())
+		if vol == nil {
+			continue
 		}
-		ret = append(ret, volumeUnitRecordToVolumeUnit(record).vuInfo)
+		vol.withRLocked(func() error {
+			tactic := vol.volInfoBase.CodeMode.Tactic()
+			azs := tactic.GetECLayoutByAZ()
+			units := cluster.PlacedUnits{ID: uint64(vol.vid), AZDisks: make([][]proto.DiskID, len(azs))}
+			for az, idxes := range azs {
+				for _, idx := range idxes {
+					if idx < len(vol.vUnits) {
+						units.AZDisks[az] = append(units.AZDisks[az], vol.vUnits[idx].vuInfo.DiskID)
+					}
+				}
+			}
+			ret = append(ret, units)
+			return nil
+		})
 	}
 	return ret, nil
 }
 
+// rangeUnitsOnDisk calls f with read lock of volume for every volume unit in the disk
+func (v *VolumeMgr) rangeUnitsOnDisk(diskID proto.DiskID, f func(unit *volumeUnit)) {
+	for _, vuidPrefix := range v.diskUnits.list(diskID) {
+		vol := v.all.getVol(vuidPrefix.Vid())
+		if vol == nil {
+			continue
+		}
+		vol.lock.RLock()
+		if index := int(vuidPrefix.Index()); index < len(vol.vUnits) && vol.vUnits[index].vuInfo.DiskID == diskID {
+			f(vol.vUnits[index])
+		}
+		vol.lock.RUnlock()
+	}
+}
+
 func (v *VolumeMgr) AllocVolumeUnit(ctx context.Context, vuid proto.Vuid) (*cmapi.AllocVolumeUnit, error) {
 	span := trace.SpanFromContextSafe(ctx)
 	vid := vuid.Vid()
@@ -222,11 +263,13 @@ func (v *VolumeMgr) applyUpdateVolumeUnit(ctx context.Context, newVuid proto.Vui
 		return err
 	}
 
+	oldDiskID := vol.vUnits[index].vuInfo.DiskID
 	vol.vUnits[index].epoch = newVuid.Epoch()
 	vol.vUnits[index].vuInfo.DiskID = newDiskID
 	vol.vUnits[index].vuInfo.Host = diskInfo.Host
 	vol.vUnits[index].vuInfo.Compacting = false
 	vol.vUnits[index].vuInfo.Vuid = newVuid
+	vol.vUnits[index].routeVersion = v.route.add(vol.vid)
 
 	unitRecord := vol.vUnits[index].ToVolumeUnitRecord()
 	err = v.volumeTbl.UpdateVolumeUnit(unitRecord.VuidPrefix, unitRecord)
@@ -234,6 +277,7 @@ func (v *VolumeMgr) applyUpdateVolumeUnit(ctx context.Context, newVuid proto.Vui
 		vol.lock.Unlock()
 		return err
 	}
+	v.diskUnits.move(unitRecord.VuidPrefix, oldDiskID, newDiskID)
 	vol.lock.Unlock()
 
 	// refresh health
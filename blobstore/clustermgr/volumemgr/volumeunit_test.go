@@ -42,6 +42,32 @@ func TestVolumeMgr_ListVolumeUnitInfo(t *testing.T) {
 	require.Equal(t, volumeCount, len(ret))
 }
 
+func TestVolumeMgr_ListVolumeUnitsOnDisk(t *testing.T) {
+	mockVolumeMgr, clean := initMockVolumeMgr(t)
+	defer clean()
+	ctx := context.Background()
+
+	vuids := mockVolumeMgr.ListVolumeUnitsOnDisk(ctx, 2)
+	require.Equal(t, volumeCount, len(vuids))
+	for idx, vuid := range vuids {
+		require.Equal(t, proto.Vid(idx), vuid.Vid())
+		require.Equal(t, uint8(1), vuid.Index())
+	}
+	require.Empty(t, mockVolumeMgr.ListVolumeUnitsOnDisk(ctx, 10000))
+
+	units := newDiskUnits()
+	units.add(1, proto.EncodeVuidPrefix(2, 0))
+	units.add(1, proto.EncodeVuidPrefix(1, 0))
+	units.add(2, proto.EncodeVuidPrefix(1, 1))
+	require.Equal(t, []proto.VuidPrefix{proto.EncodeVuidPrefix(1, 0), proto.EncodeVuidPrefix(2, 0)}, units.list(1))
+	units.move(proto.EncodeVuidPrefix(1, 1), 2, 2)
+	require.Equal(t, 1, units.count(2))
+	units.move(proto.EncodeVuidPrefix(1, 1), 2, 3)
+	require.Equal(t, 0, units.count(2))
+	require.Equal(t, []proto.VuidPrefix{proto.EncodeVuidPrefix(1, 1)}, units.list(3))
+	require.Equal(t, 2, len(units.units))
+}
+
 func TestVolumeMgr_AllocVolumeUnit(t *testing.T) {
 	mockVolumeMgr, clean := initMockVolumeMgr(t)
 	defer clean()
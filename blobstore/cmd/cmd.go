@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"io"
 	"net"
 	"net/http"
@@ -27,6 +28,7 @@ import (
 
 	"gopkg.in/natefinch/lumberjack.v2"
 
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	"github.com/cubefs/cubefs/blobstore/common/config"
 	"github.com/cubefs/cubefs/blobstore/common/profile"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
@@ -34,6 +36,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/common/rpc/auth"
 	auth_proto "github.com/cubefs/cubefs/blobstore/common/rpc/auth/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc2"
+	"github.com/cubefs/cubefs/blobstore/common/security"
 	"github.com/cubefs/cubefs/blobstore/util/graceful"
 	"github.com/cubefs/cubefs/blobstore/util/log"
 
@@ -51,6 +54,11 @@ type LogConfig struct {
 	MaxSize    int       `json:"maxsize"`
 	MaxAge     int       `json:"maxage"`
 	MaxBackups int       `json:"maxbackups"`
+
+	// Format is "text" or "json", ModuleLevels overrides the level of modules,
+	// the key is path of package directory, like "blobnode" or "common/rpc".
+	Format       string               `json:"format"`
+	ModuleLevels map[string]log.Level `json:"module_levels"`
 }
 
 type Config struct {
@@ -63,6 +71,11 @@ type Config struct {
 	Auth     auth_proto.Config `json:"auth"`
 
 	Rpc2Server *rpc2.Server `json:"rpc2_server,omitempty"`
+	// Rpc2Reflection enables reflection path of rpc2 router, it works only if auth is enabled.
+	Rpc2Reflection bool `json:"rpc2_reflection"`
+
+	// CustomCodeModes registered at startup, keep the same in all services of one cluster.
+	CustomCodeModes []codemode.CustomCodeMode `json:"custom_code_modes,omitempty"`
 }
 
 type Module struct {
@@ -70,8 +83,10 @@ type Module struct {
 	InitConfig func(args []string) (*Config, error)
 	SetUp      func() (*rpc.Router, []rpc.ProgressHandler)
 	SetUp2     func() (*rpc2.Router, []rpc2.Interceptor)
-	TearDown   func()
-	graceful   bool
+	// PreShutdown is called after receiving stop signal and before servers shutdown
+	PreShutdown func()
+	TearDown    func()
+	graceful    bool
 }
 
 var mod *Module
@@ -113,10 +128,17 @@ func Main(args []string) {
 	if err != nil {
 		log.Fatalf("init config error: %v", err)
 	}
+	if err = codemode.RegisterCustomCodeModes(cfg.CustomCodeModes); err != nil {
+		log.Fatalf("register custom codemode error: %v", err)
+	}
 	if cfg.MaxProcs > 0 {
 		runtime.GOMAXPROCS(cfg.MaxProcs)
 	}
 	log.SetOutputLevel(cfg.LogConf.Level)
+	if err = log.SetFormat(cfg.LogConf.Format); err != nil {
+		log.Fatalf("init log format error: %v", err)
+	}
+	log.SetModuleLevels(cfg.LogConf.ModuleLevels)
 	registerLogLevel()
 	if cfg.LogConf.Filename != "" {
 		log.SetOutput(NewLogWriter(&cfg.LogConf))
@@ -136,6 +158,37 @@ func Main(args []string) {
 	ctx, cancel1 := context.WithCancel(context.Background())
 	defer cancel1()
 	config.HotReload(ctx, config.ConfName())
+	config.Watch("log", func(c LogConfig) error {
+		if err := log.SetFormat(c.Format); err != nil {
+			return err
+		}
+		log.SetOutputLevel(c.Level)
+		log.SetModuleLevels(c.ModuleLevels)
+		return nil
+	})
+
+	// service auth shared by servers and clients of process, rotates keys online
+	if cfg.Auth.EnableAuth && cfg.Auth.ServiceAuth != nil {
+		svcAuth, err := security.NewServiceAuth(*cfg.Auth.ServiceAuth)
+		if err != nil {
+			log.Fatal("failed to new service auth:", err)
+		}
+		defer svcAuth.Close()
+		auth_proto.SetServiceAuth(svcAuth)
+		config.Watch("auth", func(c auth_proto.Config) error {
+			if c.ServiceAuth == nil {
+				return nil
+			}
+			return svcAuth.ReloadKeys(c.ServiceAuth.Keys)
+		})
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.Auth.EnableAuth && cfg.Auth.TLS != nil {
+		if tlsConfig, err = cfg.Auth.TLS.ServerConfig(); err != nil {
+			log.Fatal("failed to load auth tls:", err)
+		}
+	}
 
 	// new profile handler firstly
 	profileHandler := profile.NewProfileHandler(cfg.BindAddr)
@@ -151,8 +204,12 @@ func Main(args []string) {
 			}
 
 			log.Info("server is running at:", cfg.BindAddr)
+			var ln net.Listener = state.ListenerFds[0].(*net.TCPListener)
+			if tlsConfig != nil {
+				ln = tls.NewListener(ln, tlsConfig)
+			}
 			go func() {
-				if err := httpServer.Serve(state.ListenerFds[0].(*net.TCPListener)); err != nil && err != http.ErrServerClosed {
+				if err := httpServer.Serve(ln); err != nil && err != http.ErrServerClosed {
 					log.Fatal("server exits:", err)
 				}
 			}()
@@ -160,6 +217,9 @@ func Main(args []string) {
 			// wait for signal
 			<-state.CloseCh
 			log.Info("graceful shutdown...")
+			if mod.PreShutdown != nil {
+				mod.PreShutdown()
+			}
 			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeoutS)*time.Second)
 			defer cancel()
 			httpServer.Shutdown(ctx)
@@ -179,7 +239,7 @@ func Main(args []string) {
 	if mod.SetUp2 != nil {
 		router, interceptors := mod.SetUp2()
 		rpc2Server := cfg.Rpc2Server
-		rpc2Server.Handler = rpc2Handler(router, lh, cfg.Auth, interceptors)
+		rpc2Server.Handler = rpc2Handler(router, lh, cfg.Auth, cfg.Rpc2Reflection, interceptors)
 		log.Info("rpc2 Server is running at", rpc2Server.Addresses)
 		go func() {
 			if err := rpc2Server.Serve(); err != nil && err != rpc2.ErrServerClosed {
@@ -196,11 +256,16 @@ func Main(args []string) {
 			Handler:      reorderMiddleWareHandlers(router, lh, profileHandler, cfg.Auth, handlers),
 			ReadTimeout:  5 * time.Minute,
 			WriteTimeout: 5 * time.Minute,
+			TLSConfig:    tlsConfig,
 		}
 
 		log.Info("Server is running at", cfg.BindAddr)
 		go func() {
-			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serve := httpServer.ListenAndServe
+			if tlsConfig != nil {
+				serve = func() error { return httpServer.ListenAndServeTLS("", "") }
+			}
+			if err := serve(); err != nil && err != http.ErrServerClosed {
 				log.Fatalf("Server exits, err: %v", err)
 			}
 		}()
@@ -212,6 +277,9 @@ func Main(args []string) {
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT)
 	sig := <-ch
 	log.Infof("receive signal: %s, stop service...", sig.String())
+	if mod.PreShutdown != nil {
+		mod.PreShutdown()
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeoutS)*time.Second)
 	defer cancel()
 	for _, shutdown := range shutdowns {
@@ -238,19 +306,32 @@ func reorderMiddleWareHandlers(r *rpc.Router, lh, profileHandler rpc.ProgressHan
 	if profileHandler != nil {
 		hs = append(hs, profileHandler)
 	}
-	if authCfg.EnableAuth && authCfg.Secret != "" {
-		hs = append(hs, auth.New(&authCfg))
+	if authCfg.Enabled() {
+		h, err := auth.New(&authCfg)
+		if err != nil {
+			log.Fatal("failed to new auth handler:", err)
+		}
+		hs = append(hs, h)
 	}
 	hs = append(hs, handlers...)
 	return rpc.MiddlewareHandlerWith(r, hs...)
 }
 
 func rpc2Handler(r *rpc2.Router, auditlogIc rpc2.Interceptor,
-	authCfg auth_proto.Config, interceptors []rpc2.Interceptor,
+	authCfg auth_proto.Config, reflection bool, interceptors []rpc2.Interceptor,
 ) rpc2.Handle {
 	r.Interceptor(auditlogIc)
-	if authCfg.EnableAuth && authCfg.Secret != "" {
-		r.Interceptor(auth.New(&authCfg))
+	if authCfg.Enabled() {
+		ic, err := auth.New(&authCfg)
+		if err != nil {
+			log.Fatal("failed to new auth interceptor:", err)
+		}
+		r.Interceptor(ic)
+		if reflection {
+			r.EnableReflection()
+		}
+	} else if reflection {
+		log.Warn("rpc2 reflection is disabled as auth is not enabled")
 	}
 	r.Interceptor(interceptors...)
 	return r.MakeHandler()
@@ -264,4 +345,12 @@ func registerLogLevel() {
 	profile.HandleFunc(http.MethodGet, logLevelPath, func(c *rpc.Context) {
 		logLevelHandler.ServeHTTP(c.Writer, c.Request)
 	})
+
+	moduleLevelPath, moduleLevelHandler := log.ChangeModuleLevelHandler()
+	profile.HandleFunc(http.MethodPost, moduleLevelPath, func(c *rpc.Context) {
+		moduleLevelHandler.ServeHTTP(c.Writer, c.Request)
+	})
+	profile.HandleFunc(http.MethodGet, moduleLevelPath, func(c *rpc.Context) {
+		moduleLevelHandler.ServeHTTP(c.Writer, c.Request)
+	})
 }
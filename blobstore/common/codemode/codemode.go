@@ -389,7 +389,7 @@ func GetECCodeModes() []CodeMode {
 
 // GetAllCodeModes get all the available CodeModes
 func GetAllCodeModes() []CodeMode {
-	return []CodeMode{
+	modes := []CodeMode{
 		EC15P12,
 		EC6P6,
 		EC16P20L2,
@@ -413,4 +413,5 @@ func GetAllCodeModes() []CodeMode {
 		EC6P8L10,
 		Replica4TwoAZ,
 	}
+	return append(modes, customCodeModes...)
 }
@@ -0,0 +1,112 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package codemode
+
+import (
+	"fmt"
+)
+
+// CustomCodeMode config of custom code mode, registered at startup
+// for private deployments, all services of one cluster MUST keep the same.
+type CustomCodeMode struct {
+	Mode         CodeMode     `json:"mode"`
+	Name         CodeModeName `json:"name"`
+	N            int          `json:"n"`
+	M            int          `json:"m"`
+	L            int          `json:"l"`
+	AZCount      int          `json:"az_count"`
+	PutQuorum    int          `json:"put_quorum"`
+	GetQuorum    int          `json:"get_quorum"`
+	MinShardSize int          `json:"min_shard_size"`
+}
+
+// Tactic returns tactic of the custom code mode
+func (c *CustomCodeMode) Tactic() Tactic {
+	return Tactic{
+		N:            c.N,
+		M:            c.M,
+		L:            c.L,
+		AZCount:      c.AZCount,
+		PutQuorum:    c.PutQuorum,
+		GetQuorum:    c.GetQuorum,
+		MinShardSize: c.MinShardSize,
+	}
+}
+
+// registered custom code modes in order
+var customCodeModes []CodeMode
+
+// Register register a custom code mode with its name and tactic.
+// Note: it's not concurrency safe, MUST be called at startup
+// before any code mode in use.
+func Register(mode CodeMode, name CodeModeName, tactic Tactic) error {
+	if mode == 0 || name == "" {
+		return fmt.Errorf("invalid custom codemode:%d name:%s", mode, name)
+	}
+	if _, ok := constCodeModeTactic[mode]; ok {
+		return fmt.Errorf("codemode:%d has been registered", mode)
+	}
+	if _, ok := constName2CodeMode[name]; ok {
+		return fmt.Errorf("codemode name:%s has been registered", name)
+	}
+	if err := checkTactic(tactic); err != nil {
+		return fmt.Errorf("invalid custom codemode:%d %s", mode, err.Error())
+	}
+
+	constCodeModeTactic[mode] = tactic
+	constName2CodeMode[name] = mode
+	constCodeMode2Name[mode] = name
+	customCodeModes = append(customCodeModes, mode)
+	return nil
+}
+
+// RegisterCustomCodeModes register all custom code modes of config
+func RegisterCustomCodeModes(modes []CustomCodeMode) error {
+	for idx := range modes {
+		if err := Register(modes[idx].Mode, modes[idx].Name, modes[idx].Tactic()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCustomCodeModes get all registered custom CodeModes
+func GetCustomCodeModes() []CodeMode {
+	return append([]CodeMode(nil), customCodeModes...)
+}
+
+func checkTactic(tactic Tactic) error {
+	if !tactic.IsValid() {
+		return fmt.Errorf("Tactic:%+v", tactic)
+	}
+
+	if tactic.IsReplicateMode() {
+		if tactic.PutQuorum > tactic.N {
+			return fmt.Errorf("PutQuorum:%d([1,%d])", tactic.PutQuorum, tactic.N)
+		}
+		return nil
+	}
+
+	// single az has no need to tolerate one az down
+	min := tactic.N
+	if tactic.AZCount > 1 {
+		min += (tactic.N + tactic.M) / tactic.AZCount
+	}
+	max := tactic.N + tactic.M
+	if tactic.PutQuorum < min || tactic.PutQuorum > max {
+		return fmt.Errorf("PutQuorum:%d([%d,%d])", tactic.PutQuorum, min, max)
+	}
+	return nil
+}
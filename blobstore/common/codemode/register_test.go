@@ -0,0 +1,69 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package codemode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cleanupRegistered unregisters the custom code modes registered in test
+func cleanupRegistered(t *testing.T) {
+	registered := len(customCodeModes)
+	t.Cleanup(func() {
+		for _, mode := range customCodeModes[registered:] {
+			delete(constName2CodeMode, constCodeMode2Name[mode])
+			delete(constCodeMode2Name, mode)
+			delete(constCodeModeTactic, mode)
+		}
+		customCodeModes = customCodeModes[:registered]
+	})
+}
+
+func TestCodeModeRegister(t *testing.T) {
+	cleanupRegistered(t)
+	tactic := Tactic{N: 10, M: 4, AZCount: 1, PutQuorum: 13, MinShardSize: alignSize2KB}
+	// conflict with pre-defined
+	require.Error(t, Register(0, "EC10P4Custom", tactic))
+	require.Error(t, Register(150, "", tactic))
+	require.Error(t, Register(EC10P4, "EC10P4Custom", tactic))
+	require.Error(t, Register(150, "EC10P4", tactic))
+	// invalid tactic
+	require.Error(t, Register(150, "EC10P4Custom", Tactic{N: 10, M: 4, AZCount: 3, PutQuorum: 13}))
+	require.Error(t, Register(150, "EC10P4Custom", Tactic{N: 10, M: 4, AZCount: 1, PutQuorum: 9}))
+	require.Error(t, Register(150, "EC10P4Custom", Tactic{N: 10, M: 4, AZCount: 1, PutQuorum: 15}))
+	require.Error(t, Register(150, "EC10P4Custom", Tactic{N: 6, M: 6, AZCount: 3, PutQuorum: 9}))
+	require.Error(t, Register(150, "Replica5Custom", Tactic{N: 5, AZCount: 1, PutQuorum: 6}))
+	require.False(t, CodeMode(150).IsValid())
+
+	require.NoError(t, RegisterCustomCodeModes([]CustomCodeMode{
+		{Mode: 150, Name: "EC10P4Custom", N: 10, M: 4, AZCount: 1, PutQuorum: 13, MinShardSize: alignSize2KB},
+		{Mode: 151, Name: "Replica5Custom", N: 5, AZCount: 1, PutQuorum: 4},
+	}))
+	require.Error(t, Register(150, "EC10P4Custom", tactic))
+	require.Equal(t, []CodeMode{150, 151}, GetCustomCodeModes())
+
+	mode := CodeModeName("EC10P4Custom").GetCodeMode()
+	require.Equal(t, CodeMode(150), mode)
+	require.True(t, mode.IsValid())
+	require.Equal(t, tactic, mode.Tactic())
+	require.Equal(t, "EC10P4Custom", mode.String())
+	require.Equal(t, 14, mode.GetShardNum())
+	require.Contains(t, GetAllCodeModes(), mode)
+	require.Contains(t, GetECCodeModes(), mode)
+	require.NotContains(t, GetECCodeModes(), CodeMode(151))
+	require.True(t, CodeMode(151).T().IsReplicateMode())
+}
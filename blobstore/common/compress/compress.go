@@ -0,0 +1,182 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compress
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
+)
+
+const (
+	defaultLevel      = 1
+	defaultMinSize    = 4 << 10
+	defaultMaxSize    = 4 << 20
+	defaultSampleSize = 16 << 10
+	defaultMaxRatio   = 0.9
+
+	// decoded payload is limited to the max size of blob
+	maxDecodedSize = 1 << 30
+)
+
+// Config of zstd compression of blob payloads
+type Config struct {
+	Enable bool `json:"enable"`
+	// Level zstd level, 1 (fastest) to 22 (best)
+	Level int `json:"level"`
+	// payload out of [MinSize, MaxSize] is not compressed, the payload is buffered to be compressed
+	MinSize int64 `json:"min_size"`
+	MaxSize int64 `json:"max_size"`
+	// SampleSize the head of payload is compressed firstly to detect the incompressible payload,
+	// the payload is kept raw if the compressed ratio of sample or whole is greater than MaxRatio
+	SampleSize int     `json:"sample_size"`
+	MaxRatio   float64 `json:"max_ratio"`
+}
+
+// Compressor compresses payloads by zstd with the dictionary of space,
+// the payload is decompressed by the dictionary of the same space.
+type Compressor struct {
+	conf     Config
+	dicts    map[string][]byte
+	encoders map[string]*zstd.Encoder
+	decoders map[string]*zstd.Decoder
+}
+
+// New returns compressor with dictionaries by space name,
+// the payload of space without dictionary is compressed without dictionary.
+func New(conf Config, dicts map[string][]byte) (*Compressor, error) {
+	defaulter.LessOrEqual(&conf.Level, defaultLevel)
+	defaulter.LessOrEqual(&conf.MinSize, int64(defaultMinSize))
+	defaulter.LessOrEqual(&conf.MaxSize, int64(defaultMaxSize))
+	defaulter.LessOrEqual(&conf.SampleSize, defaultSampleSize)
+	if conf.MaxRatio <= 0 || conf.MaxRatio > 1 {
+		conf.MaxRatio = defaultMaxRatio
+	}
+
+	c := &Compressor{
+		conf:     conf,
+		dicts:    make(map[string][]byte, len(dicts)),
+		encoders: make(map[string]*zstd.Encoder, len(dicts)+1),
+		decoders: make(map[string]*zstd.Decoder, len(dicts)+1),
+	}
+	if err := c.addSpace("", nil); err != nil {
+		c.Close()
+		return nil, err
+	}
+	for space, dict := range dicts {
+		if len(dict) == 0 {
+			continue
+		}
+		if err := c.addSpace(space, dict); err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.dicts[space] = dict
+	}
+	return c, nil
+}
+
+func (c *Compressor) addSpace(space string, dict []byte) error {
+	eopts := []zstd.EOption{zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(c.conf.Level))}
+	if len(dict) > 0 {
+		eopts = append(eopts, zstd.WithEncoderDict(dict))
+	}
+	encoder, err := zstd.NewWriter(nil, eopts...)
+	if err != nil {
+		return err
+	}
+	c.encoders[space] = encoder
+
+	decoder, err := zstd.NewReader(nil, c.decoderOptions(dict)...)
+	if err != nil {
+		return err
+	}
+	c.decoders[space] = decoder
+	return nil
+}
+
+func (c *Compressor) decoderOptions(dict []byte) []zstd.DOption {
+	opts := []zstd.DOption{zstd.WithDecoderMaxMemory(maxDecodedSize)}
+	if len(dict) > 0 {
+		opts = append(opts, zstd.WithDecoderDicts(dict))
+	}
+	return opts
+}
+
+func (c *Compressor) encoder(space string) *zstd.Encoder {
+	if encoder, ok := c.encoders[space]; ok {
+		return encoder
+	}
+	return c.encoders[""]
+}
+
+func (c *Compressor) decoder(space string) *zstd.Decoder {
+	if decoder, ok := c.decoders[space]; ok {
+		return decoder
+	}
+	return c.decoders[""]
+}
+
+// Accept returns true if payload of size should try to be compressed
+func (c *Compressor) Accept(size int64) bool {
+	return c.conf.Enable && size >= c.conf.MinSize && size <= c.conf.MaxSize
+}
+
+// Compress returns the payload compressed with dictionary of space, false if the payload is incompressible
+func (c *Compressor) Compress(space string, raw []byte) ([]byte, bool) {
+	encoder := c.encoder(space)
+	if len(raw) > c.conf.SampleSize*2 {
+		sample := encoder.EncodeAll(raw[:c.conf.SampleSize], nil)
+		if c.exceed(len(sample), c.conf.SampleSize) {
+			return nil, false
+		}
+	}
+	compressed := encoder.EncodeAll(raw, make([]byte, 0, len(raw)))
+	if c.exceed(len(compressed), len(raw)) {
+		return nil, false
+	}
+	return compressed, true
+}
+
+func (c *Compressor) exceed(compressed, raw int) bool {
+	return float64(compressed) > float64(raw)*c.conf.MaxRatio
+}
+
+// Decompress returns the raw payload compressed in space
+func (c *Compressor) Decompress(space string, data []byte) ([]byte, error) {
+	return c.decoder(space).DecodeAll(data, nil)
+}
+
+// NewReader returns reader of the raw payload which is decompressed from r in stream
+func (c *Compressor) NewReader(space string, r io.Reader) (io.ReadCloser, error) {
+	opts := append(c.decoderOptions(c.dicts[space]), zstd.WithDecoderConcurrency(1))
+	decoder, err := zstd.NewReader(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+// Close releases the resources of compressor
+func (c *Compressor) Close() {
+	for _, encoder := range c.encoders {
+		encoder.Close()
+	}
+	for _, decoder := range c.decoders {
+		decoder.Close()
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compress
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressor(t *testing.T) {
+	c, err := New(Config{Enable: true, MinSize: 16, MaxSize: 1 << 20, SampleSize: 1 << 10}, nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.False(t, c.Accept(15))
+	require.True(t, c.Accept(16))
+	require.True(t, c.Accept(1<<20))
+	require.False(t, c.Accept(1<<20+1))
+
+	raw := bytes.Repeat([]byte("compressible payload of blob "), 1<<10)
+	compressed, ok := c.Compress("space", raw)
+	require.True(t, ok)
+	require.Less(t, len(compressed), len(raw))
+	decompressed, err := c.Decompress("space", compressed)
+	require.NoError(t, err)
+	require.Equal(t, raw, decompressed)
+
+	// incompressible, detected by sample or the whole
+	random := make([]byte, 64<<10)
+	_, err = rand.Read(random)
+	require.NoError(t, err)
+	_, ok = c.Compress("space", random)
+	require.False(t, ok)
+	_, ok = c.Compress("space", random[:1<<10])
+	require.False(t, ok)
+	// compressible head, but random tail
+	mixed := append(bytes.Repeat([]byte{'a'}, 2<<10), random...)
+	_, ok = c.Compress("space", mixed)
+	require.False(t, ok)
+
+	_, err = c.Decompress("space", raw)
+	require.Error(t, err)
+
+	disabled, err := New(Config{}, nil)
+	require.NoError(t, err)
+	defer disabled.Close()
+	require.False(t, disabled.Accept(1<<10))
+	decompressed, err = disabled.Decompress("", compressed)
+	require.NoError(t, err)
+	require.Equal(t, raw, decompressed)
+
+	_, err = New(Config{}, map[string][]byte{"space": []byte("invalid dictionary")})
+	require.Error(t, err)
+}
+
+func TestCompressorReader(t *testing.T) {
+	c, err := New(Config{Enable: true}, nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	raw := bytes.Repeat([]byte("compressible payload of blob "), 4<<10)
+	compressed, ok := c.Compress("", raw)
+	require.True(t, ok)
+
+	r, err := c.NewReader("", bytes.NewReader(compressed))
+	require.NoError(t, err)
+	_, err = io.CopyN(io.Discard, r, 1000)
+	require.NoError(t, err)
+	data := make([]byte, 1000)
+	_, err = io.ReadFull(r, data)
+	require.NoError(t, err)
+	require.Equal(t, raw[1000:2000], data)
+	require.NoError(t, r.Close())
+
+	r, err = c.NewReader("", bytes.NewReader(compressed[:len(compressed)/2]))
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+	r.Close()
+}
@@ -5,14 +5,19 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/cubefs/cubefs/blobstore/util/log"
 )
 
 var r *Reload
 
+// WatchInterval interval of checking modification of config file
+var WatchInterval = 10 * time.Second
+
 type Reload struct {
 	reloadFunc func(conf []byte) error
+	watchers   *fileWatchers
 }
 
 func New() *Reload {
@@ -20,6 +25,7 @@ func New() *Reload {
 		reloadFunc: func(conf []byte) error {
 			return nil
 		},
+		watchers: newFileWatchers(),
 	}
 	return r
 }
@@ -36,23 +42,50 @@ func init() {
 	r = New()
 }
 
+func (r *Reload) reload(path string) {
+	conf, err := os.ReadFile(path)
+	if err != nil {
+		log.Errorf("reload fail to read config file, filename: %s, err: %v", path, err)
+		return
+	}
+	if err = r.reloadFunc(conf); err != nil {
+		log.Errorf("reload config error: %v", err)
+	}
+	r.watchers.notify(conf)
+}
+
+// HotReload reloads config file on signal SIGUSR1 or modification of the file,
+// the registered reload function and watchers are notified.
 func HotReload(ctx context.Context, confName string) {
 	s := make(chan os.Signal, 1)
 	signal.Notify(s, syscall.SIGUSR1)
+
+	var modTime time.Time
+	if info, err := os.Stat(confName); err == nil {
+		modTime = info.ModTime()
+	}
+	rl := r
+	if conf, err := os.ReadFile(confName); err == nil {
+		rl.watchers.load(conf)
+	}
+
 	go func(path string) {
+		ticker := time.NewTicker(WatchInterval)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-s:
-				conf, err := os.ReadFile(path)
-				if err != nil {
-					log.Errorf("reload fail to read config file, filename: %s, err: %v", path, err)
+				rl.reload(path)
+			case <-ticker.C:
+				info, err := os.Stat(path)
+				if err != nil || !info.ModTime().After(modTime) {
 					continue
 				}
-				if err = r.reloadFunc(conf); err != nil {
-					log.Errorf("reload config error: %v", err)
-				}
+				modTime = info.ModTime()
+				log.Infof("config file %s modified, reload it", path)
+				rl.reload(path)
 			}
 		}
 	}(confName)
@@ -0,0 +1,150 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/util/log"
+)
+
+// KeyGetter gets value of the config key, like config of clustermgr
+type KeyGetter interface {
+	GetConfig(ctx context.Context, key string) (string, error)
+}
+
+type fileWatcher struct {
+	module string
+	last   []byte
+	notify func(data []byte) error
+}
+
+// fileWatchers watchers of the top level sections in config file
+type fileWatchers struct {
+	mu       sync.Mutex
+	sections map[string]json.RawMessage
+	watchers []*fileWatcher
+}
+
+func newFileWatchers() *fileWatchers {
+	return &fileWatchers{sections: make(map[string]json.RawMessage)}
+}
+
+func parseSections(conf []byte) (map[string]json.RawMessage, error) {
+	sections := make(map[string]json.RawMessage)
+	err := json.Unmarshal(trimComments(conf), &sections)
+	return sections, err
+}
+
+// load the snapshot of config file, without notification
+func (fw *fileWatchers) load(conf []byte) {
+	sections, err := parseSections(conf)
+	if err != nil {
+		log.Errorf("parse config sections error: %v", err)
+		return
+	}
+	fw.mu.Lock()
+	fw.sections = sections
+	for _, w := range fw.watchers {
+		w.last = sections[w.module]
+	}
+	fw.mu.Unlock()
+}
+
+func (fw *fileWatchers) add(module string, notify func(data []byte) error) {
+	fw.mu.Lock()
+	fw.watchers = append(fw.watchers, &fileWatcher{
+		module: module,
+		last:   fw.sections[module],
+		notify: notify,
+	})
+	fw.mu.Unlock()
+}
+
+// notify the watchers whose section has changed
+func (fw *fileWatchers) notify(conf []byte) {
+	sections, err := parseSections(conf)
+	if err != nil {
+		log.Errorf("parse config sections error: %v", err)
+		return
+	}
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	fw.sections = sections
+	for _, w := range fw.watchers {
+		data, ok := sections[w.module]
+		if !ok || bytes.Equal(data, w.last) {
+			continue
+		}
+		if err = w.notify(data); err != nil {
+			log.Errorf("notify config of module %s error: %v", w.module, err)
+			continue
+		}
+		w.last = data
+		log.Infof("config of module %s changed: %s", w.module, data)
+	}
+}
+
+func decodeNotify[T any](fn func(T) error) func(data []byte) error {
+	return func(data []byte) error {
+		var val T
+		if err := json.Unmarshal(data, &val); err != nil {
+			return err
+		}
+		return fn(val)
+	}
+}
+
+// Watch registers a module to receive the typed value of top level section
+// in config file, fn is called with the new value when the section changed.
+func Watch[T any](module string, fn func(T) error) {
+	r.watchers.add(module, decodeNotify(fn))
+}
+
+// WatchKey polls the config key from getter in interval, fn is called with
+// the typed value decoded from json when the value changed.
+func WatchKey[T any](ctx context.Context, getter KeyGetter, key string, interval time.Duration, fn func(T) error) {
+	notify := decodeNotify(fn)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last string
+		for {
+			val, err := getter.GetConfig(ctx, key)
+			if err != nil {
+				log.Warnf("get config key %s error: %v", key, err)
+			} else if val != last {
+				if err = notify([]byte(val)); err != nil {
+					log.Errorf("notify config key %s error: %v", key, err)
+				} else {
+					last = val
+					log.Infof("config key %s changed: %s", key, val)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFile(t *testing.T) {
+	type limitConf struct {
+		Rps int `json:"rps"`
+	}
+
+	oldR, oldInterval := r, WatchInterval
+	defer func() { r, WatchInterval = oldR, oldInterval }()
+	r = New()
+	WatchInterval = 50 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "watch.conf")
+	write := func(data string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	now := time.Now()
+	write(`{"limit": {"rps": 10}, "level": 1}`, now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	HotReload(ctx, path)
+
+	var (
+		mu     sync.Mutex
+		limits []limitConf
+		levels []int
+	)
+	Watch("limit", func(c limitConf) error {
+		mu.Lock()
+		limits = append(limits, c)
+		mu.Unlock()
+		return nil
+	})
+	Watch("level", func(l int) error {
+		mu.Lock()
+		levels = append(levels, l)
+		mu.Unlock()
+		return errors.New("not allowed")
+	})
+	get := func() ([]limitConf, []int) {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]limitConf{}, limits...), append([]int{}, levels...)
+	}
+
+	// only the changed section is notified
+	write(`{"limit": {"rps": 10}, "level": 2}`, now.Add(time.Second))
+	require.Eventually(t, func() bool {
+		_, l := get()
+		return len(l) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	ls, _ := get()
+	require.Equal(t, 0, len(ls))
+
+	// comments and spaces do not make changes
+	write(`{"limit": { "rps" : 10 }, // comment
+		"level": 2}`, now.Add(2*time.Second))
+	time.Sleep(200 * time.Millisecond)
+	ls, l := get()
+	require.Equal(t, 0, len(ls))
+	// failed notification retries on next reload
+	require.Equal(t, 2, len(l))
+
+	write(`{"limit": {"rps": 20}, "level": 2}`, now.Add(3*time.Second))
+	require.Eventually(t, func() bool {
+		ls, _ := get()
+		return len(ls) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	ls, _ = get()
+	require.Equal(t, 20, ls[0].Rps)
+
+	// bad config
+	write(`{"limit": {"rps": "20"}`, now.Add(4*time.Second))
+	time.Sleep(200 * time.Millisecond)
+	ls, _ = get()
+	require.Equal(t, 1, len(ls))
+}
+
+type mockKeyGetter struct {
+	mu  sync.Mutex
+	val string
+	err error
+}
+
+func (g *mockKeyGetter) GetConfig(ctx context.Context, key string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.val, g.err
+}
+
+func (g *mockKeyGetter) set(val string, err error) {
+	g.mu.Lock()
+	g.val, g.err = val, err
+	g.mu.Unlock()
+}
+
+func TestWatchKey(t *testing.T) {
+	getter := &mockKeyGetter{val: "10"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan int, 4)
+	WatchKey(ctx, getter, "rps", 20*time.Millisecond, func(v int) error {
+		ch <- v
+		return nil
+	})
+	require.Equal(t, 10, <-ch)
+
+	getter.set("", errors.New("get config"))
+	time.Sleep(100 * time.Millisecond)
+	getter.set("10", nil)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 0, len(ch))
+
+	getter.set("20", nil)
+	require.Equal(t, 20, <-ch)
+}
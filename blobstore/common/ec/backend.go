@@ -0,0 +1,196 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ec
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klauspost/cpuid/v2"
+	"github.com/klauspost/reedsolomon"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+)
+
+// Backend instruction set of reed-solomon engine,
+// the simd kernels of galois field multiplication are the same as ISA-L's.
+type Backend string
+
+// backends, the faster the former
+const (
+	BackendAuto    Backend = "auto"
+	BackendGFNI    Backend = "gfni"
+	BackendAVX512  Backend = "avx512"
+	BackendAVX2    Backend = "avx2"
+	BackendSSSE3   Backend = "ssse3"
+	BackendGeneric Backend = "generic"
+)
+
+const (
+	benchShardSize = 64 << 10
+	benchRounds    = 4
+)
+
+var (
+	allBackends = []Backend{BackendGFNI, BackendAVX512, BackendAVX2, BackendSSSE3, BackendGeneric}
+
+	// selected backend of benchmark, key is codec
+	selectedBackends sync.Map
+
+	codecOpMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "blobstore",
+			Subsystem: "ec",
+			Name:      "codec_op",
+			Help:      "ec codec operations with backend",
+		},
+		[]string{"backend", "codec", "op"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(codecOpMetric)
+}
+
+// IsSupported returns the backend is supported by this cpu or not
+func (b Backend) IsSupported() bool {
+	switch b {
+	case BackendGFNI:
+		return cpuid.CPU.Supports(cpuid.AVX512F, cpuid.GFNI, cpuid.AVX512DQ)
+	case BackendAVX512:
+		return cpuid.CPU.Supports(cpuid.AVX512F, cpuid.AVX512BW, cpuid.AVX512VL)
+	case BackendAVX2:
+		return cpuid.CPU.Supports(cpuid.AVX2)
+	case BackendSSSE3:
+		return cpuid.CPU.Supports(cpuid.SSSE3)
+	case BackendGeneric:
+		return true
+	default:
+		return false
+	}
+}
+
+// options returns options of reed-solomon engine to run on this backend only
+func (b Backend) options() []reedsolomon.Option {
+	switch b {
+	case BackendGFNI:
+		return []reedsolomon.Option{reedsolomon.WithAVX512(true)}
+	case BackendAVX512:
+		return []reedsolomon.Option{reedsolomon.WithAVX512(true), reedsolomon.WithGFNI(false)}
+	case BackendAVX2:
+		return []reedsolomon.Option{reedsolomon.WithAVX512(false), reedsolomon.WithAVX2(true)}
+	case BackendSSSE3:
+		return []reedsolomon.Option{
+			reedsolomon.WithAVX512(false), reedsolomon.WithAVX2(false),
+			reedsolomon.WithSSSE3(true),
+		}
+	default:
+		return []reedsolomon.Option{
+			reedsolomon.WithAVX512(false), reedsolomon.WithAVX2(false),
+			reedsolomon.WithSSSE3(false), reedsolomon.WithSSE2(false),
+		}
+	}
+}
+
+// SupportedBackends returns all supported backends of this cpu, the faster the former
+func SupportedBackends() []Backend {
+	backends := make([]Backend, 0, len(allBackends))
+	for _, b := range allBackends {
+		if b.IsSupported() {
+			backends = append(backends, b)
+		}
+	}
+	return backends
+}
+
+// SelectBackend returns the fastest backend of the tactic on this cpu,
+// it encodes a sample stripe by every supported backend once for each codec.
+func SelectBackend(tactic codemode.Tactic) Backend {
+	codec := codecName(tactic)
+	if b, ok := selectedBackends.Load(codec); ok {
+		return b.(Backend)
+	}
+
+	backends := SupportedBackends()
+	selected := backends[len(backends)-1]
+	if len(backends) > 1 {
+		shards := make([][]byte, tactic.N+tactic.M)
+		for idx := range shards {
+			shards[idx] = make([]byte, benchShardSize)
+			for ii := range shards[idx] {
+				shards[idx][ii] = byte(idx + ii)
+			}
+		}
+
+		var minCost time.Duration
+		for _, b := range backends {
+			engine, err := reedsolomon.New(tactic.N, tactic.M, b.options()...)
+			if err != nil {
+				continue
+			}
+			startTime := time.Now()
+			for ii := 0; ii < benchRounds; ii++ {
+				engine.Encode(shards)
+			}
+			if cost := time.Since(startTime); minCost == 0 || cost < minCost {
+				minCost = cost
+				selected = b
+			}
+		}
+	}
+
+	b, _ := selectedBackends.LoadOrStore(codec, selected)
+	return b.(Backend)
+}
+
+// resolveBackend returns the backend to use of config
+func resolveBackend(backend Backend, tactic codemode.Tactic) (Backend, error) {
+	switch backend {
+	case "", BackendAuto:
+		return SelectBackend(tactic), nil
+	default:
+		if !backend.IsSupported() {
+			return "", fmt.Errorf("ec backend(%s) not supported", backend)
+		}
+		return backend, nil
+	}
+}
+
+func codecName(tactic codemode.Tactic) string {
+	if tactic.L > 0 {
+		return fmt.Sprintf("EC%dP%dL%d", tactic.N, tactic.M, tactic.L)
+	}
+	return fmt.Sprintf("EC%dP%d", tactic.N, tactic.M)
+}
+
+// codecOpCounters counters of codec operations, resolved once by labels
+// so that no label lookup on the hot path of encoding.
+type codecOpCounters struct {
+	encode          prometheus.Counter
+	verify          prometheus.Counter
+	reconstruct     prometheus.Counter
+	reconstructData prometheus.Counter
+}
+
+func newCodecOpCounters(backend Backend, codec string) *codecOpCounters {
+	return &codecOpCounters{
+		encode:          codecOpMetric.WithLabelValues(string(backend), codec, "encode"),
+		verify:          codecOpMetric.WithLabelValues(string(backend), codec, "verify"),
+		reconstruct:     codecOpMetric.WithLabelValues(string(backend), codec, "reconstruct"),
+		reconstructData: codecOpMetric.WithLabelValues(string(backend), codec, "reconstruct_data"),
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ec
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+)
+
+func TestEncoderBackend(t *testing.T) {
+	backends := SupportedBackends()
+	require.Contains(t, backends, BackendGeneric)
+	require.Equal(t, BackendGeneric, backends[len(backends)-1])
+	require.False(t, Backend("xxx").IsSupported())
+
+	tactic := codemode.EC6P6.Tactic()
+	selected := SelectBackend(tactic)
+	require.True(t, selected.IsSupported())
+	require.Equal(t, selected, SelectBackend(tactic))
+
+	_, err := NewEncoder(Config{CodeMode: tactic, Backend: "xxx"})
+	require.Error(t, err)
+
+	data := make([]byte, 1<<20)
+	rand.Read(data)
+	var expected [][]byte
+	for _, cm := range []codemode.CodeMode{codemode.EC6P6, codemode.EC6P10L2} {
+		tactic := cm.Tactic()
+		for _, backend := range append(backends, BackendAuto) {
+			encoder, err := NewEncoder(Config{CodeMode: tactic, Backend: backend, EnableVerify: true})
+			require.NoError(t, err)
+
+			shards, err := encoder.Split(append([]byte{}, data...))
+			require.NoError(t, err)
+			require.NoError(t, encoder.Encode(shards))
+			if backend == backends[0] {
+				expected = copyShards(shards)
+				continue
+			}
+			require.Equal(t, expected, shards)
+
+			require.NoError(t, encoder.Reconstruct(shards, []int{0, tactic.N}))
+			require.Equal(t, expected, shards)
+		}
+	}
+}
+
+func TestEncoderCodecOpCounters(t *testing.T) {
+	tactic := codemode.EC6P6.Tactic()
+	encoder, err := NewEncoder(Config{CodeMode: tactic, Backend: BackendGeneric})
+	require.NoError(t, err)
+	counter := codecOpMetric.WithLabelValues(string(BackendGeneric), codecName(tactic), "verify")
+	before := testutil.ToFloat64(counter)
+
+	shards, err := encoder.Split(make([]byte, 1<<10))
+	require.NoError(t, err)
+	require.NoError(t, encoder.Encode(shards))
+	_, err = encoder.Verify(shards)
+	require.NoError(t, err)
+	require.Equal(t, before+1, testutil.ToFloat64(counter))
+}
@@ -66,12 +66,15 @@ type Config struct {
 	CodeMode     codemode.Tactic
 	EnableVerify bool
 	Concurrency  int
+	// Backend override of simd instruction set, selected by benchmark if empty
+	Backend Backend
 }
 
 type encoder struct {
 	Config
 	pool   limit.Limiter // concurrency pool
 	engine reedsolomon.Encoder
+	ops    *codecOpCounters
 }
 
 // NewEncoder return an encoder which support normal EC or LRC
@@ -83,16 +86,21 @@ func NewEncoder(cfg Config) (Encoder, error) {
 		cfg.Concurrency = defaultConcurrency
 	}
 
-	engine, err := reedsolomon.New(cfg.CodeMode.N, cfg.CodeMode.M)
+	backend, err := resolveBackend(cfg.Backend, cfg.CodeMode)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := reedsolomon.New(cfg.CodeMode.N, cfg.CodeMode.M, backend.options()...)
 	if err != nil {
 		return nil, err
 	}
 	pool := count.NewBlockingCount(cfg.Concurrency)
+	ops := newCodecOpCounters(backend, codecName(cfg.CodeMode))
 
 	if cfg.CodeMode.L != 0 {
 		localN := (cfg.CodeMode.N + cfg.CodeMode.M) / cfg.CodeMode.AZCount
 		localM := cfg.CodeMode.L / cfg.CodeMode.AZCount
-		localEngine, err := reedsolomon.New(localN, localM)
+		localEngine, err := reedsolomon.New(localN, localM, backend.options()...)
 		if err != nil {
 			return nil, err
 		}
@@ -101,6 +109,7 @@ func NewEncoder(cfg Config) (Encoder, error) {
 			pool:        pool,
 			engine:      engine,
 			localEngine: localEngine,
+			ops:         ops,
 		}, nil
 	}
 
@@ -108,10 +117,12 @@ func NewEncoder(cfg Config) (Encoder, error) {
 		Config: cfg,
 		pool:   pool,
 		engine: engine,
+		ops:    ops,
 	}, nil
 }
 
 func (e *encoder) Encode(shards [][]byte) error {
+	e.ops.encode.Inc()
 	e.pool.Acquire()
 	defer e.pool.Release()
 
@@ -131,12 +142,14 @@ func (e *encoder) Encode(shards [][]byte) error {
 }
 
 func (e *encoder) Verify(shards [][]byte) (bool, error) {
+	e.ops.verify.Inc()
 	e.pool.Acquire()
 	defer e.pool.Release()
 	return e.engine.Verify(shards)
 }
 
 func (e *encoder) Reconstruct(shards [][]byte, badIdx []int) error {
+	e.ops.reconstruct.Inc()
 	initBadShards(shards, badIdx)
 	e.pool.Acquire()
 	defer e.pool.Release()
@@ -144,6 +157,7 @@ func (e *encoder) Reconstruct(shards [][]byte, badIdx []int) error {
 }
 
 func (e *encoder) ReconstructData(shards [][]byte, badIdx []int) error {
+	e.ops.reconstructData.Inc()
 	initBadShards(shards, badIdx)
 	e.pool.Acquire()
 	defer e.pool.Release()
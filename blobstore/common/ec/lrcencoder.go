@@ -30,9 +30,11 @@ type lrcEncoder struct {
 	pool        limit.Limiter // concurrency pool
 	engine      reedsolomon.Encoder
 	localEngine reedsolomon.Encoder
+	ops         *codecOpCounters
 }
 
 func (e *lrcEncoder) Encode(shards [][]byte) error {
+	e.ops.encode.Inc()
 	if len(shards) != (e.CodeMode.N + e.CodeMode.M + e.CodeMode.L) {
 		return ErrInvalidShards
 	}
@@ -87,6 +89,7 @@ type verifyError struct {
 }
 
 func (e *lrcEncoder) Verify(shards [][]byte) (bool, error) {
+	e.ops.verify.Inc()
 	e.pool.Acquire()
 	defer e.pool.Release()
 
@@ -131,6 +134,7 @@ func (e *lrcEncoder) Verify(shards [][]byte) (bool, error) {
 }
 
 func (e *lrcEncoder) Reconstruct(shards [][]byte, badIdx []int) error {
+	e.ops.reconstruct.Inc()
 	fillFullShards(shards)
 
 	globalBadIdx := make([]int, 0)
@@ -186,6 +190,7 @@ func (e *lrcEncoder) Reconstruct(shards [][]byte, badIdx []int) error {
 }
 
 func (e *lrcEncoder) ReconstructData(shards [][]byte, badIdx []int) error {
+	e.ops.reconstructData.Inc()
 	fillFullShards(shards[:e.CodeMode.N+e.CodeMode.M])
 	globalBadIdx := make([]int, 0)
 	for _, i := range badIdx {
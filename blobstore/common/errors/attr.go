@@ -0,0 +1,256 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+// Fault which side of request the error is blamed on
+type Fault string
+
+const (
+	FaultUnknown Fault = ""
+	FaultClient  Fault = "client"
+	FaultServer  Fault = "server"
+)
+
+// owner subsystem of error code
+const (
+	OwnerCommon     = "common"
+	OwnerAccess     = "access"
+	OwnerBlobnode   = "blobnode"
+	OwnerScheduler  = "scheduler"
+	OwnerProxy      = "proxy"
+	OwnerClusterMgr = "clustermgr"
+	OwnerShardnode  = "shardnode"
+)
+
+const attrRetryable = "retryable"
+
+// Attr machine-readable attributes of error,
+// clients retry or bucket errors with it instead of matching codes one by one.
+type Attr struct {
+	Retryable bool
+	Fault     Fault
+	Owner     string
+}
+
+// String encodes attr as "owner;fault;retryable", it is carried by rpc and rpc2 responses
+func (a Attr) String() string {
+	if a == (Attr{}) {
+		return ""
+	}
+	retryable := ""
+	if a.Retryable {
+		retryable = attrRetryable
+	}
+	return a.Owner + ";" + string(a.Fault) + ";" + retryable
+}
+
+// ParseAttr decodes attr from string, returns zero attr if malformed
+func ParseAttr(s string) Attr {
+	fields := strings.Split(s, ";")
+	if len(fields) != 3 {
+		return Attr{}
+	}
+	return Attr{
+		Owner:     fields[0],
+		Fault:     Fault(fields[1]),
+		Retryable: fields[2] == attrRetryable,
+	}
+}
+
+type codeAttr struct {
+	retryable bool
+	fault     Fault
+}
+
+// errAttrMap attributes of codes, owner is detected by range of code
+var errAttrMap = map[int]codeAttr{
+	// access
+	CodeAccessExceedSize:       {fault: FaultClient},
+	CodeAccessLimited:          {retryable: true, fault: FaultServer},
+	CodeAccessReadRequestBody:  {fault: FaultClient},
+	CodeCallShardNodeFail:      {retryable: true, fault: FaultServer},
+	CodeConnectionRefused:      {retryable: true, fault: FaultServer},
+	CodeAccessServiceDiscovery: {retryable: true, fault: FaultServer},
+
+	// blobnode
+	CodeInvalidParam:         {fault: FaultClient},
+	CodeOutOfLimit:           {fault: FaultClient},
+	CodeOverload:             {retryable: true, fault: FaultServer},
+	CodeInvalidDiskId:        {fault: FaultClient},
+	CodeInvalidChunkId:       {fault: FaultClient},
+	CodeChunkCompacting:      {retryable: true, fault: FaultServer},
+	CodeSizeOverBurst:        {fault: FaultClient},
+	CodeBidNotFound:          {fault: FaultClient},
+	CodeShardSizeTooLarge:    {fault: FaultClient},
+	CodeShardInvalidOffset:   {fault: FaultClient},
+	CodeShardInvalidBid:      {fault: FaultClient},
+	CodeShardListExceedLimit: {fault: FaultClient},
+	CodeShardWormProtected:   {fault: FaultClient},
+	CodeRequestLimited:       {retryable: true, fault: FaultServer},
+	CodePutShardTimeout:      {retryable: true, fault: FaultServer},
+
+	// scheduler
+	CodeNotingTodo:         {retryable: true, fault: FaultServer},
+	CodeUpdateVolCacheFreq: {retryable: true, fault: FaultClient},
+
+	// proxy
+	CodeNoCodemodeVolume:  {retryable: true, fault: FaultServer},
+	CodeAllocBidFromCm:    {retryable: true, fault: FaultServer},
+	CodeClusterIDNotMatch: {fault: FaultClient},
+
+	// clustermgr
+	CodeRaftPropose:                  {retryable: true, fault: FaultServer},
+	CodeNoLeader:                     {retryable: true, fault: FaultServer},
+	CodeRaftReadIndex:                {retryable: true, fault: FaultServer},
+	CodeInvalidDiskStatus:            {fault: FaultClient},
+	CodeChangeDiskStatusNotAllow:     {fault: FaultClient},
+	CodeConcurrentAllocVolumeUnit:    {retryable: true, fault: FaultServer},
+	CodeAllocVolumeInvalidParams:     {fault: FaultClient},
+	CodeNoAvailableVolume:            {retryable: true, fault: FaultServer},
+	CodeConfigArgument:               {fault: FaultClient},
+	CodeInvalidClusterID:             {fault: FaultClient},
+	CodeInvalidIDC:                   {fault: FaultClient},
+	CodeRegisterServiceInvalidParams: {fault: FaultClient},
+	CodeInvalidCodeMode:              {fault: FaultClient},
+	CodeNotSupportIdle:               {fault: FaultClient},
+	CodeRejectDeleteSystemConfig:     {fault: FaultClient},
+	CodeConcurrentAllocShardUnit:     {retryable: true, fault: FaultServer},
+	CodeShardInitNotDone:             {retryable: true, fault: FaultServer},
+	CodeWriteStall:                   {retryable: true, fault: FaultServer},
+	CodeClusterFrozen:                {fault: FaultClient},
+	CodeAdmissionRejected:            {retryable: true, fault: FaultServer},
+	CodeVolumeLeaseExpired:           {fault: FaultClient},
+
+	// shardnode
+	CodeShardNodeNotLeader:          {retryable: true, fault: FaultServer},
+	CodeShardRangeMismatch:          {retryable: true, fault: FaultClient},
+	CodeUnknownField:                {fault: FaultClient},
+	CodeShardRouteVersionNeedUpdate: {retryable: true, fault: FaultClient},
+	CodeShardNoLeader:               {retryable: true, fault: FaultServer},
+	CodeIllegalSlices:               {fault: FaultClient},
+	CodeBlobAlreadyExists:           {fault: FaultClient},
+	CodeUnsupport:                   {fault: FaultClient},
+	CodeShardConflicts:              {retryable: true, fault: FaultServer},
+	CodeKeySizeTooLarge:             {fault: FaultClient},
+	CodeValueSizeTooLarge:           {fault: FaultClient},
+	CodeKeyNotFound:                 {fault: FaultClient},
+	CodeBlobAlreadySealed:           {fault: FaultClient},
+	CodeBlobNameEmpty:               {fault: FaultClient},
+	CodeItemIDEmpty:                 {fault: FaultClient},
+	CodeIllegalLocationSize:         {fault: FaultClient},
+	CodeBlobNotInTrash:              {fault: FaultClient},
+	CodeTxnConflict:                 {retryable: true, fault: FaultClient},
+	CodeIllegalTxn:                  {fault: FaultClient},
+	CodeShardNodeWriteStall:         {retryable: true, fault: FaultServer},
+	CodeShardFenced:                 {retryable: true, fault: FaultServer},
+	CodeShardRecovering:             {retryable: true, fault: FaultServer},
+	CodeShardReadonly:               {fault: FaultServer},
+}
+
+// codeOwner returns owner subsystem by range of code
+func codeOwner(code int) string {
+	switch {
+	case code >= 550 && code < 600:
+		return OwnerAccess
+	case code >= 600 && code < 700:
+		return OwnerBlobnode
+	case code >= 700 && code < 800:
+		return OwnerScheduler
+	case code >= 800 && code < 900:
+		return OwnerProxy
+	case code >= 900 && code < 1000:
+		return OwnerClusterMgr
+	case code >= 1000 && code < 1100:
+		return OwnerShardnode
+	default:
+		return OwnerCommon
+	}
+}
+
+// statusAttr returns attributes of http status code
+func statusAttr(status int) Attr {
+	attr := Attr{Owner: codeOwner(status)}
+	switch {
+	case status < 500:
+		attr.Fault = FaultClient
+		attr.Retryable = status == http.StatusRequestTimeout || status == http.StatusTooManyRequests
+	case status < 550:
+		attr.Fault = FaultServer
+		attr.Retryable = status == http.StatusBadGateway ||
+			status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+	default:
+		attr.Fault = FaultServer
+	}
+	return attr
+}
+
+// CodeAttr returns attributes of error code,
+// code not in table is detected as http status code
+func CodeAttr(code int) Attr {
+	if ca, ok := errAttrMap[code]; ok {
+		return Attr{Retryable: ca.retryable, Fault: ca.fault, Owner: codeOwner(code)}
+	}
+	return statusAttr(code)
+}
+
+// Attr returns attributes of the error code
+func (e Error) Attr() Attr {
+	return CodeAttr(int(e))
+}
+
+// ErrorAttr implements attributes of error carried by rpc and rpc2
+func (e Error) ErrorAttr() string {
+	return e.Attr().String()
+}
+
+type errorAttrer interface {
+	ErrorAttr() string
+}
+
+// DetectAttr detect attributes of error,
+// attributes carried by remote error is preferred,
+// otherwise detected by status code
+func DetectAttr(err error) Attr {
+	if err == nil {
+		return Attr{}
+	}
+	if code, ok := err.(Error); ok {
+		return code.Attr()
+	}
+	var ea errorAttrer
+	if errors.As(err, &ea) {
+		if attr := ParseAttr(ea.ErrorAttr()); attr != (Attr{}) {
+			return attr
+		}
+	}
+	if errors.Is(err, context.Canceled) {
+		return Attr{Fault: FaultClient, Owner: OwnerCommon}
+	}
+	return statusAttr(rpc.DetectStatusCode(err))
+}
+
+// IsRetryable returns true if the request with the error can be retried
+func IsRetryable(err error) bool {
+	return err != nil && DetectAttr(err).Retryable
+}
@@ -0,0 +1,85 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+func TestErrorAttr(t *testing.T) {
+	for code := range errCodeMap {
+		attr := CodeAttr(code)
+		require.NotEqual(t, FaultUnknown, attr.Fault, code)
+		require.NotEmpty(t, attr.Owner, code)
+		require.Equal(t, attr, ParseAttr(attr.String()))
+	}
+
+	require.Equal(t, Attr{Retryable: true, Fault: FaultServer, Owner: OwnerShardnode},
+		ErrShardNodeNotLeader.Attr())
+	require.Equal(t, Attr{Fault: FaultClient, Owner: OwnerClusterMgr}, CodeAttr(CodeInvalidIDC))
+	require.Equal(t, Attr{Fault: FaultServer, Owner: OwnerBlobnode}, CodeAttr(CodeDiskBroken))
+	require.Equal(t, Attr{Fault: FaultServer, Owner: OwnerCommon}, CodeAttr(http.StatusInternalServerError))
+	require.Equal(t, Attr{Retryable: true, Fault: FaultServer, Owner: OwnerCommon},
+		CodeAttr(http.StatusServiceUnavailable))
+
+	require.Equal(t, Attr{}, ParseAttr(""))
+	require.Equal(t, Attr{}, ParseAttr("a;b"))
+	require.Equal(t, "", Attr{}.String())
+}
+
+func TestErrorDetectAttr(t *testing.T) {
+	require.Equal(t, Attr{}, DetectAttr(nil))
+	require.False(t, IsRetryable(nil))
+
+	require.True(t, IsRetryable(Error(CodeNoLeader)))
+	require.False(t, IsRetryable(Error(CodeVolumeNotExist)))
+	require.True(t, IsRetryable(fmt.Errorf("wrapped: %w", Error(CodeOverload))))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(t, FaultClient, DetectAttr(ctx.Err()).Fault)
+	require.Equal(t, FaultServer, DetectAttr(errors.New("server error")).Fault)
+
+	remote := rpc.NewError(600, "", errors.New("remote"))
+	remote.Attr = "scheduler;client;retryable"
+	require.Equal(t, Attr{Retryable: true, Fault: FaultClient, Owner: OwnerScheduler}, DetectAttr(remote))
+	remote.Attr = ""
+	require.Equal(t, Attr{Fault: FaultServer, Owner: OwnerBlobnode}, DetectAttr(remote))
+}
+
+func TestErrorAttrHTTP(t *testing.T) {
+	for _, err := range []error{
+		Error(CodeWriteStall),
+		fmt.Errorf("wrapped: %w", Error(CodeInvalidIDC)),
+		ErrUnexpected,
+	} {
+		w := httptest.NewRecorder()
+		c := &rpc.Context{Writer: w}
+		c.RespondError(err)
+
+		remote := rpc.ParseResponseErr(w.Result())
+		require.Equal(t, rpc.DetectStatusCode(err), rpc.DetectStatusCode(remote))
+		require.Equal(t, DetectAttr(err), DetectAttr(remote))
+	}
+}
@@ -51,6 +51,8 @@ const (
 	CodeShardInvalidOffset   = 655
 	CodeShardListExceedLimit = 656
 	CodeShardInvalidBid      = 657
+	CodeShardCrcMismatch     = 658
+	CodeShardWormProtected   = 659
 
 	CodeDestReplicaBad          = 670
 	CodeOrphanShard             = 671
@@ -94,6 +96,8 @@ var (
 	ErrShardInvalidOffset   = Error(CodeShardInvalidOffset)
 	ErrShardListExceedLimit = Error(CodeShardListExceedLimit)
 	ErrShardInvalidBid      = Error(CodeShardInvalidBid)
+	ErrShardCrcMismatch     = Error(CodeShardCrcMismatch)
+	ErrShardWormProtected   = Error(CodeShardWormProtected)
 
 	ErrOrphanShard             = Error(CodeOrphanShard)
 	ErrIllegalTask             = Error(CodeIllegalTask)
@@ -57,6 +57,11 @@ const (
 	CodeOldIsLeanerNotMatch          = 943
 	CodeConcurrentAllocShardUnit     = 944
 	CodeShardInitNotDone             = 945
+	CodeWriteStall                   = 946
+	CodeClusterFrozen                = 947
+	CodeAdmissionRejected            = 948
+	CodeVolumeNotLeased              = 949
+	CodeVolumeLeaseExpired           = 950
 )
 
 var (
@@ -102,4 +107,9 @@ var (
 	ErrOldIsLeanerNotMatch          = Error(CodeOldIsLeanerNotMatch)
 	ErrConcurrentAllocShardUnit     = Error(CodeConcurrentAllocShardUnit)
 	ErrShardInitNotDone             = Error(CodeShardInitNotDone)
+	ErrWriteStall                   = Error(CodeWriteStall)
+	ErrClusterFrozen                = Error(CodeClusterFrozen)
+	ErrAdmissionRejected            = Error(CodeAdmissionRejected)
+	ErrVolumeNotLeased              = Error(CodeVolumeNotLeased)
+	ErrVolumeLeaseExpired           = Error(CodeVolumeLeaseExpired)
 )
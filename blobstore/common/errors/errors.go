@@ -16,6 +16,7 @@ package errors
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 )
@@ -102,6 +103,11 @@ var errCodeMap = map[int]string{
 	CodeOldIsLeanerNotMatch:      "old leaner not match",
 	CodeConcurrentAllocShardUnit: "concurrent alloc shard unit",
 	CodeShardInitNotDone:         "shard init not done",
+	CodeWriteStall:               "write stall, retry later",
+	CodeClusterFrozen:            "cluster is frozen, mutation rejected",
+	CodeAdmissionRejected:        "too many register or heartbeat requests, retry later",
+	CodeVolumeNotLeased:          "volume is not allocated with lease",
+	CodeVolumeLeaseExpired:       "volume lease expired",
 
 	// scheduler
 	CodeNotingTodo:         "nothing to do",
@@ -147,6 +153,8 @@ var errCodeMap = map[int]string{
 	CodeShardInvalidOffset:   "shard offset is invalid",
 	CodeShardInvalidBid:      "shard key bid is invalid",
 	CodeShardListExceedLimit: "shard list exceed the limit",
+	CodeShardCrcMismatch:     "shard crc mismatch",
+	CodeShardWormProtected:   "shard is immutable before worm retention",
 
 	CodeDestReplicaBad: "dest replica is bad can not repair",
 	CodeOrphanShard:    "shard is an orphan",
@@ -175,6 +183,13 @@ var errCodeMap = map[int]string{
 	CodeIllegalUpdateUnit:           "shardnode:illegal update suid",
 	CodeItemIDEmpty:                 "shardnode:item ID is empty",
 	CodeIllegalLocationSize:         "shardnode:illegal location size",
+	CodeBlobNotInTrash:              "shardnode:blob not in trash",
+	CodeTxnConflict:                 "shardnode:txn conflict",
+	CodeIllegalTxn:                  "shardnode:illegal txn",
+	CodeShardNodeWriteStall:         "shardnode:write stall, retry later",
+	CodeShardFenced:                 "shardnode:request fenced by leader term",
+	CodeShardRecovering:             "shardnode:shard is recovering from inconsistent state",
+	CodeShardReadonly:               "shardnode:shard is readonly",
 }
 
 // HTTPError make rpc.HTTPError
@@ -209,3 +224,17 @@ func DetectCode(err error) int {
 	}
 	return http.StatusInternalServerError
 }
+
+// ErrorSchemas returns schema of error codes in range [min, max] for api schema
+func ErrorSchemas(min, max int) []rpc.ErrorSchema {
+	schemas := make([]rpc.ErrorSchema, 0)
+	for code, msg := range errCodeMap {
+		if code >= min && code <= max {
+			schemas = append(schemas, rpc.ErrorSchema{Code: code, Message: msg})
+		}
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Code < schemas[j].Code
+	})
+	return schemas
+}
@@ -35,6 +35,13 @@ const (
 	CodeIllegalUpdateUnit           = 1018
 	CodeItemIDEmpty                 = 1019
 	CodeIllegalLocationSize         = 1020
+	CodeBlobNotInTrash              = 1021
+	CodeTxnConflict                 = 1022
+	CodeIllegalTxn                  = 1023
+	CodeShardNodeWriteStall         = 1024
+	CodeShardFenced                 = 1025
+	CodeShardRecovering             = 1026
+	CodeShardReadonly               = 1027
 )
 
 // 10xx
@@ -59,4 +66,11 @@ var (
 	ErrIllegalUpdateUnit           = Error(CodeIllegalUpdateUnit)
 	ErrItemIDEmpty                 = Error(CodeItemIDEmpty)
 	ErrIllegalLocationSize         = Error(CodeIllegalLocationSize)
+	ErrBlobNotInTrash              = Error(CodeBlobNotInTrash)
+	ErrTxnConflict                 = Error(CodeTxnConflict)
+	ErrIllegalTxn                  = Error(CodeIllegalTxn)
+	ErrShardNodeWriteStall         = Error(CodeShardNodeWriteStall)
+	ErrShardFenced                 = Error(CodeShardFenced)
+	ErrShardRecovering             = Error(CodeShardRecovering)
+	ErrShardReadonly               = Error(CodeShardReadonly)
 )
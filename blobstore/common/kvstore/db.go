@@ -45,10 +45,12 @@ type Iterator interface {
 	SeekToFirst()
 	SeekToLast()
 	Seek([]byte)
+	SeekForPrev([]byte)
 	Valid() bool
 	ValidForPrefix(prefix []byte) bool
 	Key() *rdb.Slice
 	Next()
+	Prev()
 	Value() *rdb.Slice
 	// destroy iterator and read option
 	Close()
@@ -181,6 +183,15 @@ func (i *iterator) Seek(key []byte) {
 	<-done
 }
 
+func (i *iterator) SeekForPrev(key []byte) {
+	done := make(chan struct{})
+	i.rpool.Run(func() {
+		defer close(done)
+		i.iter.SeekForPrev(key)
+	})
+	<-done
+}
+
 func (i *iterator) Valid() bool {
 	return i.iter.Valid()
 }
@@ -202,6 +213,15 @@ func (i *iterator) Next() {
 	<-done
 }
 
+func (i *iterator) Prev() {
+	done := make(chan struct{})
+	i.rpool.Run(func() {
+		defer close(done)
+		i.iter.Prev()
+	})
+	<-done
+}
+
 func (i *iterator) Value() *rdb.Slice {
 	return i.iter.Value()
 }
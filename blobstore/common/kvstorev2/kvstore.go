@@ -35,10 +35,14 @@ const (
 	ReadTierPersisted  = rdb.ReadTier(2)
 	ReadTierMemtable   = rdb.ReadTier(3)
 
+	WriteBatchValueRecord   = WriteBatchType(rdb.WriteBatchValueRecord)
+	WriteBatchCFValueRecord = WriteBatchType(rdb.WriteBatchCFValueRecord)
+
 	defaultReadConcurrency  = 10
 	defaultReadQueueLen     = 10
 	defaultWriteConcurrency = 4
 	defaultWriteQueueLen    = 10
+	defaultBlockCache       = 2147483648
 )
 
 var (
@@ -71,7 +75,15 @@ type (
 		NewWriteOption() (writeOption WriteOption)
 		NewWriteBatch() (writeBatch WriteBatch)
 		FlushCF(ctx context.Context, col CF) error
+		// FlushWAL writes buffered wal into file, and syncs the file if sync
+		FlushWAL(ctx context.Context, sync bool) error
 		Stats(ctx context.Context) (Stats, error)
+		WriteStall() WriteStallState
+		// NewRestorer returns a restorer which builds column family
+		// from sorted key-value pairs by ingesting sst files
+		NewRestorer(ctx context.Context, col CF, opt RestoreOption) (Restorer, error)
+		// NewTransaction returns an optimistic transaction of the column family
+		NewTransaction(col CF) (Transaction, error)
 		Close()
 	}
 	OptionHelper interface {
@@ -150,9 +162,23 @@ type (
 		SetHighPriorityBackgroundThreads(n int)
 		Close()
 	}
+	// SstFileManager tracks sst files of stores sharing it, obsolete files are moved into
+	// trash and deleted in limited rate if delete rate is set
 	SstFileManager interface {
+		SetDeleteRateBytesPerSecond(rate int64)
+		SetMaxTrashDBRatio(ratio float64)
+		// GetTotalTrashSize returns bytes of files pending in trash to be deleted
+		GetTotalTrashSize() uint64
 		Close()
 	}
+	// SstFileManagerConfig limits deleting rate of obsolete sst files, mass deletions such as
+	// dropping column families or compacting deleted ranges don't produce io spikes on disk
+	SstFileManagerConfig struct {
+		// DeleteRateBytesPerSec files are deleted immediately if zero
+		DeleteRateBytesPerSec int64 `json:"delete_rate_bytes_per_sec,omitempty"`
+		// MaxTrashDBRatio files are deleted immediately if trash size exceeds the ratio of db size
+		MaxTrashDBRatio float64 `json:"max_trash_db_ratio,omitempty"`
+	}
 	WriteBatch interface {
 		Put(col CF, key, value []byte)
 		Delete(col CF, key []byte)
@@ -172,6 +198,28 @@ type (
 		CF() int
 		Type() WriteBatchType
 	}
+	// Restorer writes key-value pairs into sst files, keys must be added in
+	// ascending order. The sst files are ingested into store by Ingest at once,
+	// which is much faster than writing pairs into memtable and wal.
+	Restorer interface {
+		Put(key, value []byte) error
+		Ingest(ctx context.Context) error
+		Close()
+	}
+	// Transaction reads its own writes of one column family, the keys read
+	// in transaction are checked at commit, ErrTxnConflict is returned if any
+	// of them has been changed since read, then the transaction should be retried.
+	Transaction interface {
+		Get(ctx context.Context, key []byte) ([]byte, error)
+		Set(key, value []byte)
+		Delete(key []byte)
+		Commit(ctx context.Context, opts ...WriteOptFunc) error
+		Rollback()
+	}
+	RestoreOption struct {
+		// MaxFileSize is the bytes of key-value pairs in one sst file
+		MaxFileSize int64
+	}
 
 	Stats struct {
 		Used              uint64
@@ -184,6 +232,21 @@ type (
 		RunningCompaction uint64
 		PendingCompaction bool
 		BackgroundErrors  uint64
+		// PendingTrashBytes bytes of obsolete sst files waiting to be deleted in trash
+		PendingTrashBytes uint64
+		// Filter is collected only if statistics is enabled
+		Filter FilterStats
+	}
+	// FilterStats counts of bloom filter checking of all column families
+	FilterStats struct {
+		// Useful reads avoided by whole key filter
+		Useful uint64
+		// FullPositive whole key filter passed, FullTruePositive the key exists indeed
+		FullPositive     uint64
+		FullTruePositive uint64
+		// PrefixChecked prefix filter checked by seeking, PrefixUseful seeks avoided by prefix filter
+		PrefixChecked uint64
+		PrefixUseful  uint64
 	}
 	MemoryUsage struct {
 		BlockCacheUsage     uint64
@@ -221,18 +284,45 @@ type (
 		MaxWalLogSize                    uint64               `json:"max_wal_log_size,omitempty"`
 		CompactionStyle                  CompactionStyle      `json:"compaction_style,omitempty"`
 		CompactionOptionFIFO             CompactionOptionFIFO `json:"compaction_option_fifo,omitempty"`
+		WriteStallCheckIntervalMs        int                  `json:"write_stall_check_interval_ms,omitempty"`
+		// WalSync policy of flushing and syncing wal, ignored if wal is disabled
+		WalSync WalSyncOption `json:"wal_sync,omitempty"`
+		// EnableStatistics collects statistics of db, such as filter stats, which costs a little performance
+		EnableStatistics bool `json:"enable_statistics,omitempty"`
+		// ColumnFamilyOptions table options of column families, which is not in ColumnFamily is ignored
+		ColumnFamilyOptions map[CF]ColumnFamilyOption `json:"column_family_options,omitempty"`
+		// SstFileManagerConfig creates the store's own sst file manager if SstFileManager is nil
+		// and delete rate is set, the shared SstFileManager is configured by its owner
+		SstFileManagerConfig SstFileManagerConfig `json:"sst_file_manager,omitempty"`
 
+		// SharedResource name of registered shared resource, which shares
+		// block cache and write buffer manager with other stores
+		SharedResource     string `json:"shared_resource,omitempty"`
 		Cache              LruCache
 		WriteBufferManager WriteBufferManager
 		Env                Env
 		SstFileManager     SstFileManager
 		HandleError        HandleError
+		HandleWriteStall   HandleWriteStall
 
 		ReadConcurrency  int `json:"read_concurrency,omitempty"`
 		ReadQueueLen     int `json:"read_queue_len,omitempty"`
 		WriteConcurrency int `json:"write_concurrency,omitempty"`
 		WriteQueueLen    int `json:"write_queue_len,omitempty"`
 	}
+	// ColumnFamilyOption bloom filter of column family. The first PrefixLen bytes of keys are
+	// extracted as prefix if PrefixLen is not zero, seeking a key with the prefix length skips
+	// the sst files without the prefix, so the column family should be listed by the prefix
+	// not shorter than PrefixLen or without marker.
+	ColumnFamilyOption struct {
+		// BloomBitsPerKey bits per key of full bloom filter, no filter if zero
+		BloomBitsPerKey int `json:"bloom_bits_per_key,omitempty"`
+		PrefixLen       int `json:"prefix_len,omitempty"`
+		// WholeKeyFiltering adds whole keys into filter besides prefixes, always true if PrefixLen is zero
+		WholeKeyFiltering bool `json:"whole_key_filtering,omitempty"`
+		// MemtablePrefixBloomRatio ratio of write buffer size for prefix bloom of memtable
+		MemtablePrefixBloomRatio float64 `json:"memtable_prefix_bloom_ratio,omitempty"`
+	}
 	CompactionOptionFIFO struct {
 		MaxTableFileSize int  `json:"max_table_file_size,omitempty"`
 		AllowCompaction  bool `json:"allow_compaction,omitempty"`
@@ -294,6 +384,27 @@ func NewSstFileManager(ctx context.Context, lsmType LsmKVType, env Env) SstFileM
 	}
 }
 
+// NewSstFileManagerWithConfig creates sst file manager with default env if env is nil
+func NewSstFileManagerWithConfig(ctx context.Context, lsmType LsmKVType, env Env, cfg SstFileManagerConfig) SstFileManager {
+	if env == nil {
+		// default env is static, only the wrapper is released
+		env = NewEnv(ctx, lsmType)
+		if env == nil {
+			return nil
+		}
+		defer env.Close()
+	}
+	m := NewSstFileManager(ctx, lsmType, env)
+	if m == nil {
+		return nil
+	}
+	m.SetDeleteRateBytesPerSecond(cfg.DeleteRateBytesPerSec)
+	if cfg.MaxTrashDBRatio > 0 {
+		m.SetMaxTrashDBRatio(cfg.MaxTrashDBRatio)
+	}
+	return m
+}
+
 func WithReadOption(opt ReadOption) ReadOptFunc {
 	return func(ro *readOpts) {
 		ro.opt = opt
@@ -322,6 +433,28 @@ func (cf CF) String() string {
 	return string(cf)
 }
 
+// UsefulRate returns the rate of reads avoided by whole key filter
+func (fs FilterStats) UsefulRate() float64 {
+	return rate(fs.Useful, fs.Useful+fs.FullPositive)
+}
+
+// FalsePositiveRate returns the rate of whole key filter passed but the key not exists
+func (fs FilterStats) FalsePositiveRate() float64 {
+	return rate(fs.FullPositive-fs.FullTruePositive, fs.Useful+fs.FullPositive)
+}
+
+// PrefixUsefulRate returns the rate of seeks avoided by prefix filter
+func (fs FilterStats) PrefixUsefulRate() float64 {
+	return rate(fs.PrefixUseful, fs.PrefixChecked)
+}
+
+func rate(n, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
 func (ro *readOpts) applyOptions(opts []ReadOptFunc) {
 	for _, opt := range opts {
 		if opt != nil {
@@ -127,6 +127,20 @@ func (mr *MockStoreMockRecorder) FlushCF(ctx, col interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushCF", reflect.TypeOf((*MockStore)(nil).FlushCF), ctx, col)
 }
 
+// FlushWAL mocks base method.
+func (m *MockStore) FlushWAL(ctx context.Context, sync bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushWAL", ctx, sync)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushWAL indicates an expected call of FlushWAL.
+func (mr *MockStoreMockRecorder) FlushWAL(ctx, sync interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushWAL", reflect.TypeOf((*MockStore)(nil).FlushWAL), ctx, sync)
+}
+
 // Get mocks base method.
 func (m *MockStore) Get(ctx context.Context, col CF, key []byte, opts ...ReadOptFunc) (ValueGetter, error) {
 	m.ctrl.T.Helper()
@@ -243,6 +257,21 @@ func (mr *MockStoreMockRecorder) NewReadOption() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewReadOption", reflect.TypeOf((*MockStore)(nil).NewReadOption))
 }
 
+// NewRestorer mocks base method.
+func (m *MockStore) NewRestorer(ctx context.Context, col CF, opt RestoreOption) (Restorer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewRestorer", ctx, col, opt)
+	ret0, _ := ret[0].(Restorer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewRestorer indicates an expected call of NewRestorer.
+func (mr *MockStoreMockRecorder) NewRestorer(ctx, col, opt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRestorer", reflect.TypeOf((*MockStore)(nil).NewRestorer), ctx, col, opt)
+}
+
 // NewSnapshot mocks base method.
 func (m *MockStore) NewSnapshot() Snapshot {
 	m.ctrl.T.Helper()
@@ -257,6 +286,21 @@ func (mr *MockStoreMockRecorder) NewSnapshot() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSnapshot", reflect.TypeOf((*MockStore)(nil).NewSnapshot))
 }
 
+// NewTransaction mocks base method.
+func (m *MockStore) NewTransaction(col CF) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTransaction", col)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewTransaction indicates an expected call of NewTransaction.
+func (mr *MockStoreMockRecorder) NewTransaction(col interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransaction", reflect.TypeOf((*MockStore)(nil).NewTransaction), col)
+}
+
 // NewWriteBatch mocks base method.
 func (m *MockStore) NewWriteBatch() WriteBatch {
 	m.ctrl.T.Helper()
@@ -358,6 +402,20 @@ func (mr *MockStoreMockRecorder) Write(ctx, batch interface{}, opts ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockStore)(nil).Write), varargs...)
 }
 
+// WriteStall mocks base method.
+func (m *MockStore) WriteStall() WriteStallState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteStall")
+	ret0, _ := ret[0].(WriteStallState)
+	return ret0
+}
+
+// WriteStall indicates an expected call of WriteStall.
+func (mr *MockStoreMockRecorder) WriteStall() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteStall", reflect.TypeOf((*MockStore)(nil).WriteStall))
+}
+
 // MockOptionHelper is a mock of OptionHelper interface.
 type MockOptionHelper struct {
 	ctrl     *gomock.Controller
@@ -1317,6 +1375,44 @@ func (mr *MockSstFileManagerMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSstFileManager)(nil).Close))
 }
 
+// GetTotalTrashSize mocks base method.
+func (m *MockSstFileManager) GetTotalTrashSize() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalTrashSize")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetTotalTrashSize indicates an expected call of GetTotalTrashSize.
+func (mr *MockSstFileManagerMockRecorder) GetTotalTrashSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalTrashSize", reflect.TypeOf((*MockSstFileManager)(nil).GetTotalTrashSize))
+}
+
+// SetDeleteRateBytesPerSecond mocks base method.
+func (m *MockSstFileManager) SetDeleteRateBytesPerSecond(rate int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDeleteRateBytesPerSecond", rate)
+}
+
+// SetDeleteRateBytesPerSecond indicates an expected call of SetDeleteRateBytesPerSecond.
+func (mr *MockSstFileManagerMockRecorder) SetDeleteRateBytesPerSecond(rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleteRateBytesPerSecond", reflect.TypeOf((*MockSstFileManager)(nil).SetDeleteRateBytesPerSecond), rate)
+}
+
+// SetMaxTrashDBRatio mocks base method.
+func (m *MockSstFileManager) SetMaxTrashDBRatio(ratio float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxTrashDBRatio", ratio)
+}
+
+// SetMaxTrashDBRatio indicates an expected call of SetMaxTrashDBRatio.
+func (mr *MockSstFileManagerMockRecorder) SetMaxTrashDBRatio(ratio interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxTrashDBRatio", reflect.TypeOf((*MockSstFileManager)(nil).SetMaxTrashDBRatio), ratio)
+}
+
 // MockWriteBatch is a mock of WriteBatch interface.
 type MockWriteBatch struct {
 	ctrl     *gomock.Controller
@@ -1546,3 +1642,159 @@ func (mr *MockWriteBatchReaderMockRecorder) Value() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Value", reflect.TypeOf((*MockWriteBatchReader)(nil).Value))
 }
+
+// MockRestorer is a mock of Restorer interface.
+type MockRestorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRestorerMockRecorder
+}
+
+// MockRestorerMockRecorder is the mock recorder for MockRestorer.
+type MockRestorerMockRecorder struct {
+	mock *MockRestorer
+}
+
+// NewMockRestorer creates a new mock instance.
+func NewMockRestorer(ctrl *gomock.Controller) *MockRestorer {
+	mock := &MockRestorer{ctrl: ctrl}
+	mock.recorder = &MockRestorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRestorer) EXPECT() *MockRestorerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRestorer) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRestorerMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRestorer)(nil).Close))
+}
+
+// Ingest mocks base method.
+func (m *MockRestorer) Ingest(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ingest", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ingest indicates an expected call of Ingest.
+func (mr *MockRestorerMockRecorder) Ingest(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ingest", reflect.TypeOf((*MockRestorer)(nil).Ingest), ctx)
+}
+
+// Put mocks base method.
+func (m *MockRestorer) Put(key, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockRestorerMockRecorder) Put(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockRestorer)(nil).Put), key, value)
+}
+
+// MockTransaction is a mock of Transaction interface.
+type MockTransaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionMockRecorder
+}
+
+// MockTransactionMockRecorder is the mock recorder for MockTransaction.
+type MockTransactionMockRecorder struct {
+	mock *MockTransaction
+}
+
+// NewMockTransaction creates a new mock instance.
+func NewMockTransaction(ctrl *gomock.Controller) *MockTransaction {
+	mock := &MockTransaction{ctrl: ctrl}
+	mock.recorder = &MockTransactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransaction) EXPECT() *MockTransactionMockRecorder {
+	return m.recorder
+}
+
+// Commit mocks base method.
+func (m *MockTransaction) Commit(ctx context.Context, opts ...WriteOptFunc) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Commit", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockTransactionMockRecorder) Commit(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTransaction)(nil).Commit), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockTransaction) Delete(key []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", key)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTransactionMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransaction)(nil).Delete), key)
+}
+
+// Get mocks base method.
+func (m *MockTransaction) Get(ctx context.Context, key []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTransactionMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTransaction)(nil).Get), ctx, key)
+}
+
+// Rollback mocks base method.
+func (m *MockTransaction) Rollback() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Rollback")
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockTransactionMockRecorder) Rollback() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTransaction)(nil).Rollback))
+}
+
+// Set mocks base method.
+func (m *MockTransaction) Set(key, value []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", key, value)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockTransactionMockRecorder) Set(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockTransaction)(nil).Set), key, value)
+}
@@ -177,13 +177,18 @@ func (oph *optHelper) SetFIFOCompactionAllow(value bool) error {
 }
 
 func genRocksdbOpts(opt *Option) (opts *rdb.Options) {
+	return genRocksdbCFOpts(opt, ColumnFamilyOption{WholeKeyFiltering: true})
+}
+
+// genRocksdbCFOpts returns options with block based table options of column family
+func genRocksdbCFOpts(opt *Option, cfOpt ColumnFamilyOption) (opts *rdb.Options) {
 	opts = rdb.NewDefaultOptions()
 	opts.SetCreateIfMissing(opt.CreateIfMissing)
 	blockBaseOpt := rdb.NewDefaultBlockBasedTableOptions()
 	fifoCompactionOpt := rdb.NewDefaultFIFOCompactionOptions()
 
 	defaulter.IntegerEqual(&opt.BlockSize, 12288)
-	defaulter.IntegerEqual(&opt.BlockCache, 2147483648)
+	defaulter.IntegerEqual(&opt.BlockCache, defaultBlockCache)
 	defaulter.IntegerEqual(&opt.WriteBufferSize, 268435456)
 	defaulter.IntegerEqual(&opt.TargetFileSizeBase, 268435456)
 	defaulter.IntegerEqual(&opt.MaxBytesForLevelBase, 268435456)
@@ -262,6 +267,20 @@ func genRocksdbOpts(opt *Option) (opts *rdb.Options) {
 		opts.SetSstFileManager(opt.SstFileManager.(*sstFileManager).SstFileManager)
 	}
 
+	if cfOpt.BloomBitsPerKey > 0 {
+		blockBaseOpt.SetFilterPolicy(rdb.NewBloomFilterFull(cfOpt.BloomBitsPerKey))
+	}
+	if cfOpt.PrefixLen > 0 {
+		opts.SetPrefixExtractor(rdb.NewFixedPrefixTransform(cfOpt.PrefixLen))
+		blockBaseOpt.SetWholeKeyFiltering(cfOpt.WholeKeyFiltering)
+		if cfOpt.MemtablePrefixBloomRatio > 0 {
+			opts.SetMemTablePrefixBloomSizeRatio(cfOpt.MemtablePrefixBloomRatio)
+		}
+	}
+	if opt.EnableStatistics {
+		opts.EnableStatistics()
+	}
+
 	// opts.SetStatsDumpPeriodSec(0)
 	// opts.SetStatsPersistPeriodSec(0)
 	opts.SetBlockBasedTableFactory(blockBaseOpt)
@@ -0,0 +1,169 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrSharedResourceNotFound = errors.New("shared resource not found")
+	ErrSharedResourceConflict = errors.New("shared resource registered with different config")
+	ErrSharedResourceInvalid  = errors.New("invalid shared resource config")
+)
+
+type (
+	// SharedResourceConfig config of the block cache and write buffer manager shared by
+	// multiple store instances, the memory of all instances is limited by the sizes
+	SharedResourceConfig struct {
+		Name            string `json:"name"`
+		BlockCacheSize  uint64 `json:"block_cache_size"`
+		WriteBufferSize uint64 `json:"write_buffer_size"`
+	}
+	SharedResourceStats struct {
+		Name             string `json:"name"`
+		Refs             int    `json:"refs"`
+		BlockCacheSize   uint64 `json:"block_cache_size"`
+		BlockCacheUsage  uint64 `json:"block_cache_usage"`
+		BlockPinnedUsage uint64 `json:"block_pinned_usage"`
+		WriteBufferSize  uint64 `json:"write_buffer_size"`
+	}
+
+	// SharedResource is reference counted, and closed after the last reference released
+	SharedResource struct {
+		cfg                SharedResourceConfig
+		lsmType            LsmKVType
+		cache              LruCache
+		writeBufferManager WriteBufferManager
+		refs               int
+	}
+	resourceRegistry struct {
+		lock      sync.Mutex
+		resources map[string]*SharedResource
+	}
+)
+
+var registry = &resourceRegistry{resources: make(map[string]*SharedResource)}
+
+// RegisterSharedResource creates shared resource with the config, returns the registered one
+// if registered with the same config. Release it after all stores using it opened.
+func RegisterSharedResource(ctx context.Context, lsmType LsmKVType, cfg SharedResourceConfig) (*SharedResource, error) {
+	if cfg.Name == "" || (cfg.BlockCacheSize == 0 && cfg.WriteBufferSize == 0) {
+		return nil, ErrSharedResourceInvalid
+	}
+
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	if r, ok := registry.resources[cfg.Name]; ok {
+		if r.cfg != cfg || r.lsmType != lsmType {
+			return nil, ErrSharedResourceConflict
+		}
+		r.refs++
+		return r, nil
+	}
+
+	r := &SharedResource{cfg: cfg, lsmType: lsmType, refs: 1}
+	if cfg.BlockCacheSize > 0 {
+		r.cache = NewCache(ctx, lsmType, cfg.BlockCacheSize)
+	}
+	if cfg.WriteBufferSize > 0 {
+		r.writeBufferManager = NewWriteBufferManager(ctx, lsmType, cfg.WriteBufferSize)
+	}
+	registry.resources[cfg.Name] = r
+	return r, nil
+}
+
+// AcquireSharedResource returns the registered shared resource and holds a reference of it
+func AcquireSharedResource(name string) (*SharedResource, error) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	r, ok := registry.resources[name]
+	if !ok {
+		return nil, ErrSharedResourceNotFound
+	}
+	r.refs++
+	return r, nil
+}
+
+// ListSharedResources returns stats of all registered shared resources
+func ListSharedResources() []SharedResourceStats {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	ret := make([]SharedResourceStats, 0, len(registry.resources))
+	for _, r := range registry.resources {
+		ret = append(ret, r.stats())
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
+
+func (r *SharedResource) Name() string {
+	return r.cfg.Name
+}
+
+// Release releases a reference, closes the resource and unregisters it if no reference
+func (r *SharedResource) Release() {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	r.refs--
+	if r.refs > 0 {
+		return
+	}
+	if registry.resources[r.cfg.Name] == r {
+		delete(registry.resources, r.cfg.Name)
+	}
+	if r.cache != nil {
+		r.cache.Close()
+	}
+	if r.writeBufferManager != nil {
+		r.writeBufferManager.Close()
+	}
+}
+
+func (r *SharedResource) Stats() SharedResourceStats {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	return r.stats()
+}
+
+func (r *SharedResource) stats() SharedResourceStats {
+	stats := SharedResourceStats{
+		Name:            r.cfg.Name,
+		Refs:            r.refs,
+		BlockCacheSize:  r.cfg.BlockCacheSize,
+		WriteBufferSize: r.cfg.WriteBufferSize,
+	}
+	if r.cache != nil {
+		stats.BlockCacheUsage = r.cache.GetUsage()
+		stats.BlockPinnedUsage = r.cache.GetPinnedUsage()
+	}
+	return stats
+}
+
+// apply returns a copy of option using the shared resource, the option's own
+// cache and write buffer manager take precedence
+func (r *SharedResource) apply(option *Option) *Option {
+	opt := *option
+	if opt.Cache == nil {
+		opt.Cache = r.cache
+	}
+	if opt.WriteBufferManager == nil {
+		opt.WriteBufferManager = r.writeBufferManager
+	}
+	return &opt
+}
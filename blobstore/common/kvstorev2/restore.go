@@ -0,0 +1,143 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	rdb "github.com/tecbot/gorocksdb"
+)
+
+const (
+	restoreDir = "restore"
+
+	defaultRestoreMaxFileSize = 64 << 20
+)
+
+var errRestorerIngested = errors.New("restorer has been ingested")
+
+type sstRestorer struct {
+	s           *rocksdb
+	col         CF
+	dir         string
+	maxFileSize int64
+
+	envOpt   *rdb.EnvOptions
+	writer   *rdb.SSTFileWriter
+	fileSize int64
+	files    []string
+	ingested bool
+}
+
+// NewRestorer creates a restorer with sst files in temporary directory of store,
+// the directory is removed when the restorer is closed.
+func (s *rocksdb) NewRestorer(ctx context.Context, col CF, opt RestoreOption) (Restorer, error) {
+	if !s.CheckColumns(col) {
+		return nil, fmt.Errorf("column family %s not found", col)
+	}
+	if opt.MaxFileSize <= 0 {
+		opt.MaxFileSize = defaultRestoreMaxFileSize
+	}
+	parent := filepath.Join(s.path, restoreDir)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp(parent, col.String()+"-")
+	if err != nil {
+		return nil, err
+	}
+	return &sstRestorer{
+		s:           s,
+		col:         col,
+		dir:         dir,
+		maxFileSize: opt.MaxFileSize,
+		envOpt:      rdb.NewDefaultEnvOptions(),
+	}, nil
+}
+
+func (r *sstRestorer) Put(key, value []byte) error {
+	if r.ingested {
+		return errRestorerIngested
+	}
+	if r.writer == nil {
+		path := filepath.Join(r.dir, fmt.Sprintf("%06d.sst", len(r.files)))
+		writer := rdb.NewSSTFileWriter(r.envOpt, r.s.opt)
+		if err := writer.Open(path); err != nil {
+			writer.Destroy()
+			return err
+		}
+		r.writer = writer
+		r.files = append(r.files, path)
+		r.fileSize = 0
+	}
+	if err := r.writer.Add(key, value); err != nil {
+		return err
+	}
+	r.fileSize += int64(len(key) + len(value))
+	if r.fileSize >= r.maxFileSize {
+		return r.finishFile()
+	}
+	return nil
+}
+
+// Ingest ingests all sst files into column family, the ingested keys
+// overwrite the existed keys and the previous deleted range.
+func (r *sstRestorer) Ingest(ctx context.Context) error {
+	if r.ingested {
+		return errRestorerIngested
+	}
+	if err := r.finishFile(); err != nil {
+		return err
+	}
+	r.ingested = true
+	if len(r.files) == 0 {
+		return nil
+	}
+
+	opts := rdb.NewDefaultIngestExternalFileOptions()
+	defer opts.Destroy()
+	opts.SetMoveFiles(true)
+
+	r.s.lock.RLock()
+	defer r.s.lock.RUnlock()
+	if err := r.s.db.IngestExternalFileCF(r.s.getColumnFamily(r.col), r.files, opts); err != nil {
+		r.s.handleError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (r *sstRestorer) Close() {
+	if r.writer != nil {
+		r.writer.Destroy()
+		r.writer = nil
+	}
+	r.envOpt.Destroy()
+	os.RemoveAll(r.dir)
+}
+
+func (r *sstRestorer) finishFile() error {
+	if r.writer == nil {
+		return nil
+	}
+	err := r.writer.Finish()
+	r.writer.Destroy()
+	r.writer = nil
+	return err
+}
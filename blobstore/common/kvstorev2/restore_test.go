@@ -0,0 +1,81 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestorer(t *testing.T) {
+	ctx := context.TODO()
+	eg, err := newEngine(ctx, &Option{ColumnFamily: []CF{"data"}})
+	require.NoError(t, err)
+	defer eg.close()
+
+	_, err = eg.engine.NewRestorer(ctx, "not-exist", RestoreOption{})
+	require.Error(t, err)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, eg.engine.SetRaw(ctx, "data", []byte(fmt.Sprintf("key-%03d", i)), []byte("old")))
+	}
+	require.NoError(t, eg.engine.DeleteRange(ctx, "data", []byte("key-"), []byte("key.")))
+
+	r, err := eg.engine.NewRestorer(ctx, "data", RestoreOption{MaxFileSize: 64})
+	require.NoError(t, err)
+	dir := r.(*sstRestorer).dir
+	for i := 0; i < 100; i += 2 {
+		require.NoError(t, r.Put([]byte(fmt.Sprintf("key-%03d", i)), []byte("new")))
+	}
+	// keys must be in ascending order
+	require.Error(t, r.Put([]byte("key-000"), []byte("new")))
+	require.NoError(t, r.Ingest(ctx))
+	require.Greater(t, len(r.(*sstRestorer).files), 1)
+	require.ErrorIs(t, r.Ingest(ctx), errRestorerIngested)
+	require.ErrorIs(t, r.Put([]byte("key-100"), nil), errRestorerIngested)
+	r.Close()
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+
+	for i := 0; i < 100; i++ {
+		value, err := eg.engine.GetRaw(ctx, "data", []byte(fmt.Sprintf("key-%03d", i)))
+		if i%2 == 1 {
+			require.ErrorIs(t, err, ErrNotFound)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, []byte("new"), value)
+	}
+
+	// empty restorer
+	r, err = eg.engine.NewRestorer(ctx, "data", RestoreOption{})
+	require.NoError(t, err)
+	require.NoError(t, r.Ingest(ctx))
+	r.Close()
+
+	// sst files left are removed when reopened
+	_, err = eg.engine.NewRestorer(ctx, "data", RestoreOption{})
+	require.NoError(t, err)
+	eg.engine.Close()
+	eg.engine, err = newRocksdb(ctx, eg.path, eg.opt)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(eg.path, restoreDir))
+	require.True(t, os.IsNotExist(err))
+}
@@ -21,10 +21,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
 	"github.com/cubefs/cubefs/util"
 
 	rdb "github.com/tecbot/gorocksdb"
@@ -36,13 +40,21 @@ type (
 		db          *rdb.DB
 		cfHandles   map[CF]*rdb.ColumnFamilyHandle
 		handleError HandleError
+		writeStall  *WriteStallDetector
+		walSyncer   *walSyncer
+		resource    *SharedResource
+		// sstFileManager owned by the store is closed with it
+		sstFileManager      SstFileManager
+		ownedSstFileManager bool
 
 		optHelper *optHelper
 		opt       *rdb.Options
+		cfOpts    map[CF]*rdb.Options
 		ro        *rdb.ReadOptions
 		wo        *rdb.WriteOptions
 		fo        *rdb.FlushOptions
 		lock      sync.RWMutex
+		txnLocks  sync.Map // commit lock of transactions by column family
 
 		wg sync.WaitGroup
 
@@ -129,8 +141,41 @@ func newRocksdb(ctx context.Context, path string, option *Option) (Store, error)
 	if err != nil {
 		return nil, err
 	}
+	// remove sst files of restorers not ingested before
+	if err = os.RemoveAll(filepath.Join(path, restoreDir)); err != nil {
+		return nil, err
+	}
 
-	dbOpt := genRocksdbOpts(option)
+	var resource *SharedResource
+	genOption := option
+	if option.SharedResource != "" {
+		if resource, err = AcquireSharedResource(option.SharedResource); err != nil {
+			return nil, err
+		}
+		genOption = resource.apply(option)
+	}
+	if len(genOption.ColumnFamilyOptions) > 0 && genOption.Cache == nil {
+		// column families with different table options share the block cache
+		copied := *genOption
+		defaulter.IntegerEqual(&copied.BlockCache, defaultBlockCache)
+		copied.Cache = newRocksdbLruCache(ctx, copied.BlockCache)
+		genOption = &copied
+	}
+	ownedSstFileManager := false
+	if genOption.SstFileManager == nil && genOption.SstFileManagerConfig.DeleteRateBytesPerSec > 0 {
+		copied := *genOption
+		copied.SstFileManager = NewSstFileManagerWithConfig(ctx, RocksdbLsmKVType, copied.Env, copied.SstFileManagerConfig)
+		genOption = &copied
+		ownedSstFileManager = true
+	}
+	dbOpt := genRocksdbOpts(genOption)
+	if option.WalSync.ManualFlush && !option.DisableWal {
+		setManualWalFlush(dbOpt, true)
+	}
+	colOpts := make(map[CF]*rdb.Options, len(genOption.ColumnFamilyOptions))
+	for col, cfOpt := range genOption.ColumnFamilyOptions {
+		colOpts[col] = genRocksdbCFOpts(genOption, cfOpt)
+	}
 
 	cfNum := len(option.ColumnFamily) + 1
 	cols := make([]CF, 0, cfNum)
@@ -141,11 +186,21 @@ func newRocksdb(ctx context.Context, path string, option *Option) (Store, error)
 	cfOpts := make([]*rdb.Options, 0, cfNum)
 	for i := 0; i < cfNum; i++ {
 		cfNames = append(cfNames, cols[i].String())
+		if colOpt, ok := colOpts[cols[i]]; ok {
+			cfOpts = append(cfOpts, colOpt)
+			continue
+		}
 		cfOpts = append(cfOpts, dbOpt)
 	}
 
 	db, cfhs, err := rdb.OpenDbColumnFamilies(dbOpt, path, cfNames, cfOpts)
 	if err != nil {
+		if resource != nil {
+			resource.Release()
+		}
+		if ownedSstFileManager {
+			genOption.SstFileManager.Close()
+		}
 		return nil, err
 	}
 
@@ -156,8 +211,8 @@ func newRocksdb(ctx context.Context, path string, option *Option) (Store, error)
 
 	wo := rdb.NewDefaultWriteOptions()
 	wo.DisableWAL(option.DisableWal)
-	if option.Sync {
-		wo.SetSync(option.Sync)
+	if option.Sync || option.WalSync.Policy == WalSyncEveryWrite {
+		wo.SetSync(true)
 	}
 	ro := rdb.NewDefaultReadOptions()
 
@@ -171,11 +226,16 @@ func newRocksdb(ctx context.Context, path string, option *Option) (Store, error)
 		path:        path,
 		optHelper:   &optHelper{db: db, opt: option},
 		opt:         dbOpt,
+		cfOpts:      colOpts,
 		ro:          ro,
 		wo:          wo,
 		fo:          rdb.NewDefaultFlushOptions(),
 		cfHandles:   cfhMap,
 		handleError: option.HandleError,
+		resource:    resource,
+
+		sstFileManager:      genOption.SstFileManager,
+		ownedSstFileManager: ownedSstFileManager,
 
 		rTaskPool: sync.Pool{New: func() interface{} {
 			return &readTask{retChan: make(chan readRet, 1)}
@@ -202,6 +262,18 @@ func newRocksdb(ctx context.Context, path string, option *Option) (Store, error)
 		idx := i
 		go ins.readLoop(ins.rchans[idx])
 	}
+
+	ins.writeStall = NewWriteStallDetector(time.Duration(option.WriteStallCheckIntervalMs)*time.Millisecond,
+		func() WriteStallState { return ProbeWriteStall(db) }, option.HandleWriteStall)
+	ins.writeStall.Run()
+	if !option.DisableWal {
+		ins.walSyncer = newWalSyncer(option.WalSync, func(sync bool) error {
+			return flushWAL(db, sync)
+		}, func(err error) {
+			ins.handleError(context.Background(), err)
+		})
+		ins.walSyncer.Run()
+	}
 	return ins, nil
 }
 
@@ -667,7 +739,11 @@ func (s *rocksdb) CreateColumn(col CF) error {
 		s.lock.Unlock()
 		return nil
 	}
-	h, err := s.db.CreateColumnFamily(s.opt, col.String())
+	opt := s.opt
+	if cfOpt, ok := s.cfOpts[col]; ok {
+		opt = cfOpt
+	}
+	h, err := s.db.CreateColumnFamily(opt, col.String())
 	if err != nil {
 		s.lock.Unlock()
 		return err
@@ -963,10 +1039,45 @@ func (s *rocksdb) Stats(ctx context.Context) (stats Stats, err error) {
 			Total:               blockCacheUsage + totalIndexAndFilterUsage + totalMemtableUsage + blockPinnedUsage,
 		},
 	}
+	if s.sstFileManager != nil {
+		stats.PendingTrashBytes = s.sstFileManager.GetTotalTrashSize()
+	}
+	if s.optHelper.opt.EnableStatistics {
+		stats.Filter = parseFilterStats(s.opt.GetStatisticsString())
+	}
 	return
 }
 
+// parseFilterStats parses filter tickers from statistics string, like:
+// rocksdb.bloom.filter.useful COUNT : 10
+func parseFilterStats(statistics string) (stats FilterStats) {
+	tickers := map[string]*uint64{
+		"rocksdb.bloom.filter.useful":             &stats.Useful,
+		"rocksdb.bloom.filter.full.positive":      &stats.FullPositive,
+		"rocksdb.bloom.filter.full.true.positive": &stats.FullTruePositive,
+		"rocksdb.bloom.filter.prefix.checked":     &stats.PrefixChecked,
+		"rocksdb.bloom.filter.prefix.useful":      &stats.PrefixUseful,
+	}
+	for _, line := range strings.Split(statistics, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 || fields[1] != "COUNT" {
+			continue
+		}
+		if ticker, ok := tickers[fields[0]]; ok {
+			*ticker, _ = strconv.ParseUint(fields[3], 10, 64)
+		}
+	}
+	return
+}
+
+// WriteStall returns the last detected write stall state, the incoming
+// writes should be shed if writes are stopped
+func (s *rocksdb) WriteStall() WriteStallState {
+	return s.writeStall.State()
+}
+
 func (s *rocksdb) Close() {
+	s.writeStall.Close()
 	for i := range s.wchans {
 		close(s.wchans[i])
 	}
@@ -974,14 +1085,25 @@ func (s *rocksdb) Close() {
 		close(s.rchans[i])
 	}
 	s.wg.Wait()
+	// flush and sync wal after all writes done
+	s.walSyncer.Close()
 	s.wo.Destroy()
 	s.ro.Destroy()
 	s.opt.Destroy()
+	for _, opt := range s.cfOpts {
+		opt.Destroy()
+	}
 	s.fo.Destroy()
 	for i := range s.cfHandles {
 		s.cfHandles[i].Destroy()
 	}
 	s.db.Close()
+	if s.resource != nil {
+		s.resource.Release()
+	}
+	if s.ownedSstFileManager {
+		s.sstFileManager.Close()
+	}
 }
 
 type (
@@ -1157,6 +1279,7 @@ func (s *rocksdb) set(ctx context.Context, col CF, key []byte, value []byte, wri
 		s.handleError(ctx, err)
 		return err
 	}
+	s.walSyncer.Written(len(key) + len(value))
 	return nil
 }
 
@@ -1170,6 +1293,7 @@ func (s *rocksdb) delete(ctx context.Context, col CF, key []byte, writeOpt Write
 		s.handleError(ctx, err)
 		return err
 	}
+	s.walSyncer.Written(len(key))
 	return nil
 }
 
@@ -1185,6 +1309,7 @@ func (s *rocksdb) deleteRange(ctx context.Context, col CF, start, end []byte, wr
 		s.handleError(ctx, err)
 		return err
 	}
+	s.walSyncer.Written(len(start) + len(end))
 	return nil
 }
 
@@ -1198,6 +1323,7 @@ func (s *rocksdb) write(ctx context.Context, batch WriteBatch, writeOpt WriteOpt
 		s.handleError(ctx, err)
 		return err
 	}
+	s.walSyncer.Written(len(_batch.batch.Data()))
 	return nil
 }
 
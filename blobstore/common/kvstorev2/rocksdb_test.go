@@ -338,6 +338,51 @@ func Test_ShareWriteBufferManager(t *testing.T) {
 	defer eg2.close()
 }
 
+func Test_SharedResource(t *testing.T) {
+	ctx := context.TODO()
+	cfg := SharedResourceConfig{Name: "test_shared", BlockCacheSize: 1 << 20, WriteBufferSize: 1 << 20}
+
+	_, err := RegisterSharedResource(ctx, RocksdbLsmKVType, SharedResourceConfig{Name: "test_shared"})
+	require.ErrorIs(t, err, ErrSharedResourceInvalid)
+	_, err = newEngine(ctx, &Option{SharedResource: cfg.Name})
+	require.ErrorIs(t, err, ErrSharedResourceNotFound)
+
+	resource, err := RegisterSharedResource(ctx, RocksdbLsmKVType, cfg)
+	require.NoError(t, err)
+	same, err := RegisterSharedResource(ctx, RocksdbLsmKVType, cfg)
+	require.NoError(t, err)
+	require.Equal(t, resource, same)
+	same.Release()
+	_, err = RegisterSharedResource(ctx, RocksdbLsmKVType, SharedResourceConfig{Name: cfg.Name, BlockCacheSize: 1 << 10})
+	require.ErrorIs(t, err, ErrSharedResourceConflict)
+
+	opt := &Option{SharedResource: cfg.Name}
+	eg1, err := newEngine(ctx, opt)
+	require.NoError(t, err)
+	eg2, err := newEngine(ctx, opt)
+	require.NoError(t, err)
+	require.Nil(t, opt.Cache)
+	require.Equal(t, 3, resource.Stats().Refs)
+
+	require.NoError(t, eg1.engine.SetRaw(ctx, defaultCF, []byte("key"), []byte("value")))
+	require.NoError(t, eg1.engine.FlushCF(ctx, defaultCF))
+	_, err = eg1.engine.GetRaw(ctx, defaultCF, []byte("key"))
+	require.NoError(t, err)
+	stats := ListSharedResources()
+	require.Len(t, stats, 1)
+	require.Equal(t, cfg.Name, stats[0].Name)
+	require.Equal(t, cfg.BlockCacheSize, stats[0].BlockCacheSize)
+
+	// resource is closed after all stores closed
+	resource.Release()
+	eg1.close()
+	require.Equal(t, 1, resource.Stats().Refs)
+	eg2.close()
+	require.Len(t, ListSharedResources(), 0)
+	_, err = AcquireSharedResource(cfg.Name)
+	require.ErrorIs(t, err, ErrSharedResourceNotFound)
+}
+
 func TestOptHelper_SetGetOpts(t *testing.T) {
 	ctx := context.TODO()
 	eg, err := newEngine(ctx, nil)
@@ -535,6 +580,71 @@ func TestInstance_Stats(t *testing.T) {
 	fmt.Println(stats.Used/(1<<10), "kb")
 }
 
+func TestInstance_PrefixBloom(t *testing.T) {
+	ctx := context.TODO()
+	dataCF, extraCF := CF("data"), CF("extra")
+	cfOpt := ColumnFamilyOption{BloomBitsPerKey: 10, PrefixLen: 4, MemtablePrefixBloomRatio: 0.1}
+	eg, err := newEngine(ctx, &Option{
+		ColumnFamily:        []CF{dataCF},
+		EnableStatistics:    true,
+		ColumnFamilyOptions: map[CF]ColumnFamilyOption{dataCF: cfOpt, extraCF: cfOpt},
+	})
+	require.NoError(t, err)
+	defer eg.close()
+	require.NoError(t, eg.engine.CreateColumn(extraCF))
+
+	for _, col := range []CF{dataCF, extraCF} {
+		for _, prefix := range []string{"aaaa", "bbbb", "dddd"} {
+			for i := 0; i < 10; i++ {
+				require.NoError(t, eg.engine.SetRaw(ctx, col, []byte(fmt.Sprintf("%s%d", prefix, i)), []byte("v")))
+			}
+		}
+		require.NoError(t, eg.engine.FlushCF(ctx, col))
+
+		list := func(prefix, marker []byte) (n int) {
+			lr := eg.engine.List(ctx, col, prefix, marker, nil)
+			defer lr.Close()
+			for {
+				key, _, err := lr.ReadNextCopy()
+				require.NoError(t, err)
+				if key == nil {
+					return
+				}
+				n++
+			}
+		}
+		require.Equal(t, 10, list([]byte("bbbb"), nil))
+		require.Equal(t, 5, list([]byte("bbbb"), []byte("bbbb5")))
+		require.Equal(t, 0, list([]byte("cccc"), nil))
+		// prefix shorter than prefix length is not filtered
+		require.Equal(t, 20, list([]byte("b"), nil)+list([]byte("d"), nil))
+		require.Equal(t, 30, list(nil, nil))
+
+		_, err = eg.engine.GetRaw(ctx, col, []byte("cccc0"))
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+
+	stats, err := eg.engine.Stats(ctx)
+	require.NoError(t, err)
+	require.Less(t, uint64(0), stats.Filter.PrefixChecked)
+	t.Logf("filter stats: %+v, prefix useful rate: %f", stats.Filter, stats.Filter.PrefixUsefulRate())
+}
+
+func TestParseFilterStats(t *testing.T) {
+	stats := parseFilterStats("rocksdb.block.cache.miss COUNT : 3\n" +
+		"rocksdb.bloom.filter.useful COUNT : 6\n" +
+		"rocksdb.bloom.filter.full.positive COUNT : 4\n" +
+		"rocksdb.bloom.filter.full.true.positive COUNT : 3\n" +
+		"rocksdb.bloom.filter.prefix.checked COUNT : 8\n" +
+		"rocksdb.bloom.filter.prefix.useful COUNT : 2\n" +
+		"rocksdb.db.get.micros P50 : 1.0 P95 : 2.0 P99 : 3.0 P100 : 4.0 COUNT : 5 SUM : 6\n")
+	require.Equal(t, FilterStats{Useful: 6, FullPositive: 4, FullTruePositive: 3, PrefixChecked: 8, PrefixUseful: 2}, stats)
+	require.Equal(t, 0.6, stats.UsefulRate())
+	require.Equal(t, 0.1, stats.FalsePositiveRate())
+	require.Equal(t, 0.25, stats.PrefixUsefulRate())
+	require.Equal(t, float64(0), FilterStats{}.UsefulRate())
+}
+
 func TestEnv_SetLowPriorityBackgroundThreads(t *testing.T) {
 	ctx := context.TODO()
 	env := NewEnv(ctx, RocksdbLsmKVType)
@@ -548,6 +658,30 @@ func TestSstFileManager_Close(t *testing.T) {
 	mgr.Close()
 }
 
+func TestSstFileManager_Config(t *testing.T) {
+	ctx := context.TODO()
+	mgr := NewSstFileManagerWithConfig(ctx, RocksdbLsmKVType, nil, SstFileManagerConfig{
+		DeleteRateBytesPerSec: 1 << 20,
+		MaxTrashDBRatio:       0.5,
+	})
+	require.Equal(t, uint64(0), mgr.GetTotalTrashSize())
+	mgr.SetDeleteRateBytesPerSecond(0)
+	mgr.Close()
+
+	eg, err := newEngine(ctx, &Option{SstFileManagerConfig: SstFileManagerConfig{DeleteRateBytesPerSec: 1 << 20}})
+	require.NoError(t, err)
+	defer eg.close()
+	require.True(t, eg.engine.(*rocksdb).ownedSstFileManager)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, eg.engine.SetRaw(ctx, defaultCF, []byte(fmt.Sprintf("k%d", i)), []byte("v")))
+	}
+	require.NoError(t, eg.engine.FlushCF(ctx, defaultCF))
+	stats, err := eg.engine.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), stats.PendingTrashBytes)
+}
+
 func TestInstance_DeleteRange(t *testing.T) {
 	ctx := context.TODO()
 	eg, err := newEngine(ctx, nil)
@@ -0,0 +1,38 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+#include <memory>
+
+#include "rocksdb/sst_file_manager.h"
+
+extern "C" {
+    #include "sst_file_manager.h"
+}
+
+// the same definition of rocksdb c api, which is not exported
+struct rocksdb_sst_file_manager_t {
+    std::shared_ptr<rocksdb::SstFileManager> rep;
+};
+
+void sst_file_manager_set_delete_rate_bytes_per_second(rocksdb_sst_file_manager_t* sfm, int64_t rate) {
+    sfm->rep->SetDeleteRateBytesPerSecond(rate);
+}
+
+void sst_file_manager_set_max_trash_db_ratio(rocksdb_sst_file_manager_t* sfm, double ratio) {
+    sfm->rep->SetMaxTrashDBRatio(ratio);
+}
+
+uint64_t sst_file_manager_get_total_trash_size(rocksdb_sst_file_manager_t* sfm) {
+    return sfm->rep->GetTotalTrashSize();
+}
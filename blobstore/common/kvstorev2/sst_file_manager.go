@@ -0,0 +1,47 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+/*
+#cgo CXXFLAGS: -std=c++11
+#include "sst_file_manager.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	rdb "github.com/tecbot/gorocksdb"
+)
+
+// nativeSstFileManager returns the c pointer of sst file manager,
+// which is not exported by gorocksdb
+func nativeSstFileManager(m *rdb.SstFileManager) *C.rocksdb_sst_file_manager_t {
+	return (*struct {
+		c *C.rocksdb_sst_file_manager_t
+	})(unsafe.Pointer(m)).c
+}
+
+func (e *sstFileManager) SetDeleteRateBytesPerSecond(rate int64) {
+	C.sst_file_manager_set_delete_rate_bytes_per_second(nativeSstFileManager(e.SstFileManager), C.int64_t(rate))
+}
+
+func (e *sstFileManager) SetMaxTrashDBRatio(ratio float64) {
+	C.sst_file_manager_set_max_trash_db_ratio(nativeSstFileManager(e.SstFileManager), C.double(ratio))
+}
+
+func (e *sstFileManager) GetTotalTrashSize() uint64 {
+	return uint64(C.sst_file_manager_get_total_trash_size(nativeSstFileManager(e.SstFileManager)))
+}
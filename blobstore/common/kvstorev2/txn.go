@@ -0,0 +1,157 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrTxnConflict = errors.New("transaction conflict")
+	ErrTxnDone     = errors.New("transaction has been committed or rolled back")
+)
+
+type (
+	txnRead struct {
+		value []byte
+		exist bool
+	}
+	txnWrite struct {
+		value   []byte
+		deleted bool
+	}
+
+	// optimisticTxn buffers writes in memory and records the first read value of keys,
+	// the read keys are validated by reading them again when committing, so concurrent
+	// read-modify-write of the same keys fails with ErrTxnConflict except the first one.
+	optimisticTxn struct {
+		s      *rocksdb
+		col    CF
+		reads  map[string]txnRead
+		writes map[string]txnWrite
+		order  []string // written keys in order
+		done   bool
+	}
+)
+
+// NewTransaction returns an optimistic transaction of the column family.
+func (s *rocksdb) NewTransaction(col CF) (Transaction, error) {
+	if !s.CheckColumns(col) {
+		return nil, fmt.Errorf("column family %s not found", col)
+	}
+	return &optimisticTxn{
+		s:      s,
+		col:    col,
+		reads:  make(map[string]txnRead),
+		writes: make(map[string]txnWrite),
+	}, nil
+}
+
+// commitLock serializes validating and writing of transactions in the column family,
+// reading and modifying in transactions are not locked.
+func (s *rocksdb) commitLock(col CF) *sync.Mutex {
+	l, _ := s.txnLocks.LoadOrStore(col, &sync.Mutex{})
+	return l.(*sync.Mutex)
+}
+
+func (t *optimisticTxn) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if t.done {
+		return nil, ErrTxnDone
+	}
+	if w, ok := t.writes[string(key)]; ok {
+		if w.deleted {
+			return nil, ErrNotFound
+		}
+		return append([]byte(nil), w.value...), nil
+	}
+	if r, ok := t.reads[string(key)]; ok {
+		if !r.exist {
+			return nil, ErrNotFound
+		}
+		return append([]byte(nil), r.value...), nil
+	}
+
+	value, err := t.s.GetRaw(ctx, t.col, key)
+	if err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	t.reads[string(key)] = txnRead{value: value, exist: err == nil}
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (t *optimisticTxn) Set(key, value []byte) {
+	t.write(key, txnWrite{value: append([]byte(nil), value...)})
+}
+
+func (t *optimisticTxn) Delete(key []byte) {
+	t.write(key, txnWrite{deleted: true})
+}
+
+func (t *optimisticTxn) write(key []byte, w txnWrite) {
+	if t.done {
+		return
+	}
+	if _, ok := t.writes[string(key)]; !ok {
+		t.order = append(t.order, string(key))
+	}
+	t.writes[string(key)] = w
+}
+
+func (t *optimisticTxn) Commit(ctx context.Context, opts ...WriteOptFunc) error {
+	if t.done {
+		return ErrTxnDone
+	}
+	t.done = true
+	if len(t.writes) == 0 {
+		return nil
+	}
+
+	l := t.s.commitLock(t.col)
+	l.Lock()
+	defer l.Unlock()
+
+	for key, r := range t.reads {
+		value, err := t.s.GetRaw(ctx, t.col, []byte(key))
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+		if (err == nil) != r.exist || !bytes.Equal(value, r.value) {
+			return ErrTxnConflict
+		}
+	}
+
+	batch := t.s.NewWriteBatch()
+	defer batch.Close()
+	for _, key := range t.order {
+		if w := t.writes[key]; w.deleted {
+			batch.Delete(t.col, []byte(key))
+		} else {
+			batch.Put(t.col, []byte(key), w.value)
+		}
+	}
+	return t.s.Write(ctx, batch, opts...)
+}
+
+func (t *optimisticTxn) Rollback() {
+	t.done = true
+	t.reads, t.writes, t.order = nil, nil, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction(t *testing.T) {
+	ctx := context.TODO()
+	eg, err := newEngine(ctx, &Option{ColumnFamily: []CF{"data"}})
+	require.NoError(t, err)
+	defer eg.close()
+
+	_, err = eg.engine.NewTransaction("not-exist")
+	require.Error(t, err)
+
+	require.NoError(t, eg.engine.SetRaw(ctx, "data", []byte("k1"), []byte("v1")))
+
+	// read your writes
+	txn, err := eg.engine.NewTransaction("data")
+	require.NoError(t, err)
+	value, err := txn.Get(ctx, []byte("k1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), value)
+	_, err = txn.Get(ctx, []byte("k2"))
+	require.ErrorIs(t, err, ErrNotFound)
+	txn.Set([]byte("k2"), []byte("v2"))
+	value, err = txn.Get(ctx, []byte("k2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), value)
+	txn.Delete([]byte("k1"))
+	_, err = txn.Get(ctx, []byte("k1"))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// not visible before commit
+	_, err = eg.engine.GetRaw(ctx, "data", []byte("k2"))
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, txn.Commit(ctx))
+	require.ErrorIs(t, txn.Commit(ctx), ErrTxnDone)
+	_, err = eg.engine.GetRaw(ctx, "data", []byte("k1"))
+	require.ErrorIs(t, err, ErrNotFound)
+	value, err = eg.engine.GetRaw(ctx, "data", []byte("k2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), value)
+
+	// conflict with write after read
+	txn, _ = eg.engine.NewTransaction("data")
+	_, err = txn.Get(ctx, []byte("k2"))
+	require.NoError(t, err)
+	txn.Set([]byte("k2"), []byte("v3"))
+	require.NoError(t, eg.engine.SetRaw(ctx, "data", []byte("k2"), []byte("v4")))
+	require.ErrorIs(t, txn.Commit(ctx), ErrTxnConflict)
+	value, _ = eg.engine.GetRaw(ctx, "data", []byte("k2"))
+	require.Equal(t, []byte("v4"), value)
+
+	// conflict with created key after read
+	txn, _ = eg.engine.NewTransaction("data")
+	_, err = txn.Get(ctx, []byte("k3"))
+	require.ErrorIs(t, err, ErrNotFound)
+	txn.Set([]byte("k3"), []byte("v3"))
+	require.NoError(t, eg.engine.SetRaw(ctx, "data", []byte("k3"), []byte("other")))
+	require.ErrorIs(t, txn.Commit(ctx), ErrTxnConflict)
+
+	// rolled back
+	txn, _ = eg.engine.NewTransaction("data")
+	txn.Set([]byte("k4"), []byte("v4"))
+	txn.Rollback()
+	require.ErrorIs(t, txn.Commit(ctx), ErrTxnDone)
+	_, err = eg.engine.GetRaw(ctx, "data", []byte("k4"))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTransactionConcurrentIncrease(t *testing.T) {
+	ctx := context.TODO()
+	eg, err := newEngine(ctx, &Option{ColumnFamily: []CF{"data"}})
+	require.NoError(t, err)
+	defer eg.close()
+
+	key := []byte("counter")
+	increase := func() error {
+		for {
+			txn, err := eg.engine.NewTransaction("data")
+			if err != nil {
+				return err
+			}
+			n := 0
+			value, err := txn.Get(ctx, key)
+			if err == nil {
+				if n, err = strconv.Atoi(string(value)); err != nil {
+					return err
+				}
+			} else if err != ErrNotFound {
+				return err
+			}
+			txn.Set(key, []byte(strconv.Itoa(n+1)))
+			if err = txn.Commit(ctx); err != ErrTxnConflict {
+				return err
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				require.NoError(t, increase())
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, err := eg.engine.GetRaw(ctx, "data", key)
+	require.NoError(t, err)
+	require.Equal(t, "100", string(value))
+}
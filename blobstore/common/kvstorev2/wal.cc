@@ -0,0 +1,46 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "rocksdb/db.h"
+#include "rocksdb/options.h"
+
+extern "C" {
+    #include "wal.h"
+}
+
+// the same definitions of rocksdb c api, which are not exported
+struct rocksdb_t {
+    rocksdb::DB* rep;
+};
+
+struct rocksdb_options_t {
+    rocksdb::Options rep;
+};
+
+void db_flush_wal(rocksdb_t* db, unsigned char sync, char** errptr) {
+    rocksdb::Status s = db->rep->FlushWAL(sync);
+    if (!s.ok()) {
+        if (*errptr != nullptr) {
+            free(*errptr);
+        }
+        *errptr = strdup(s.ToString().c_str());
+    }
+}
+
+void options_set_manual_wal_flush(rocksdb_options_t* opt, unsigned char v) {
+    opt->rep.manual_wal_flush = v;
+}
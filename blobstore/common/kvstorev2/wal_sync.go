@@ -0,0 +1,190 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+/*
+#include <stdlib.h>
+#include "wal.h"
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	rdb "github.com/tecbot/gorocksdb"
+)
+
+const defaultWalSyncIntervalMs = 1000
+
+const (
+	// WalSyncNone wal file is synced by os, or with every write if Sync is set
+	WalSyncNone = WalSyncPolicy("")
+	// WalSyncEveryWrite syncs wal file with every write
+	WalSyncEveryWrite = WalSyncPolicy("every_write")
+	// WalSyncInterval syncs wal file in background every interval
+	WalSyncInterval = WalSyncPolicy("interval")
+	// WalSyncBytes syncs wal file in background after bytes written, or every interval at least
+	WalSyncBytes = WalSyncPolicy("bytes")
+)
+
+type (
+	WalSyncPolicy string
+	// WalSyncOption trades durability window of wal for write latency. Writes acknowledged
+	// since the last sync may be lost if the host crashed, and wal is buffered in memory if
+	// ManualFlush, which may be lost even if the process crashed before flushed by the syncer.
+	// The wal is always flushed and synced when the store closed.
+	WalSyncOption struct {
+		Policy      WalSyncPolicy `json:"policy,omitempty"`
+		ManualFlush bool          `json:"manual_flush,omitempty"`
+		IntervalMs  int           `json:"interval_ms,omitempty"`
+		Bytes       int64         `json:"bytes,omitempty"`
+	}
+)
+
+func flushWAL(db *rdb.DB, sync bool) error {
+	var cErr *C.char
+	C.db_flush_wal((*C.rocksdb_t)(db.UnsafeGetDB()), boolToChar(sync), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
+
+// setManualWalFlush sets manual_wal_flush of options, which is not exported by gorocksdb
+func setManualWalFlush(opts *rdb.Options, value bool) {
+	C.options_set_manual_wal_flush((*C.rocksdb_options_t)(nativeOptions(opts)), boolToChar(value))
+}
+
+func nativeOptions(opts *rdb.Options) unsafe.Pointer {
+	return unsafe.Pointer((*struct {
+		c *C.rocksdb_options_t
+	})(unsafe.Pointer(opts)).c)
+}
+
+func boolToChar(b bool) C.uchar {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// walSyncer flushes and syncs wal in background by policy,
+// the methods of nil syncer do nothing.
+type walSyncer struct {
+	sync     bool
+	interval time.Duration
+	bytes    int64
+	written  int64
+	flush    func(sync bool) error
+	onError  func(err error)
+
+	notifyCh  chan struct{}
+	closeCh   chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+}
+
+// newWalSyncer returns nil if wal is flushed and synced by rocksdb itself
+func newWalSyncer(opt WalSyncOption, flush func(sync bool) error, onError func(err error)) *walSyncer {
+	background := opt.Policy == WalSyncInterval || opt.Policy == WalSyncBytes
+	if !background && !opt.ManualFlush {
+		return nil
+	}
+	interval := time.Duration(opt.IntervalMs) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultWalSyncIntervalMs * time.Millisecond
+	}
+	w := &walSyncer{
+		sync:     background,
+		interval: interval,
+		flush:    flush,
+		onError:  onError,
+		notifyCh: make(chan struct{}, 1),
+		closeCh:  make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+	if opt.Policy == WalSyncBytes {
+		w.bytes = opt.Bytes
+	}
+	return w
+}
+
+// Run starts flushing in background
+func (w *walSyncer) Run() {
+	if w == nil {
+		return
+	}
+	go func() {
+		defer close(w.doneCh)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-w.notifyCh:
+			case <-w.closeCh:
+				return
+			}
+			w.doFlush(w.sync)
+		}
+	}()
+}
+
+// Written counts bytes written into wal, the syncer is notified if exceeds the bytes
+func (w *walSyncer) Written(n int) {
+	if w == nil || w.bytes <= 0 {
+		return
+	}
+	if atomic.AddInt64(&w.written, int64(n)) < w.bytes {
+		return
+	}
+	select {
+	case w.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops the background flushing, and flushes and syncs the wal at last
+func (w *walSyncer) Close() {
+	if w == nil {
+		return
+	}
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+		<-w.doneCh
+		w.doFlush(true)
+	})
+}
+
+func (w *walSyncer) doFlush(sync bool) {
+	atomic.StoreInt64(&w.written, 0)
+	if err := w.flush(sync); err != nil && w.onError != nil {
+		w.onError(err)
+	}
+}
+
+func (s *rocksdb) FlushWAL(ctx context.Context, sync bool) error {
+	if err := flushWAL(s.db, sync); err != nil {
+		s.handleError(ctx, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalSyncer(t *testing.T) {
+	require.Nil(t, newWalSyncer(WalSyncOption{}, nil, nil))
+	require.Nil(t, newWalSyncer(WalSyncOption{Policy: WalSyncEveryWrite}, nil, nil))
+	// methods of nil syncer do nothing
+	var nilSyncer *walSyncer
+	nilSyncer.Run()
+	nilSyncer.Written(1)
+	nilSyncer.Close()
+
+	// flushed by bytes
+	flushed := make(chan bool, 8)
+	errFlush := errors.New("flush failed")
+	errCh := make(chan error, 8)
+	w := newWalSyncer(WalSyncOption{Policy: WalSyncBytes, Bytes: 10, IntervalMs: 60000}, func(sync bool) error {
+		flushed <- sync
+		return errFlush
+	}, func(err error) { errCh <- err })
+	w.Run()
+	w.Written(5)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, 0, len(flushed))
+	w.Written(5)
+	require.True(t, <-flushed)
+	require.ErrorIs(t, <-errCh, errFlush)
+	// flushed and synced at close
+	w.Close()
+	require.True(t, <-flushed)
+	w.Close()
+	require.Equal(t, 0, len(flushed))
+
+	// manual flush without syncing in interval
+	var flushes, syncs int32
+	w = newWalSyncer(WalSyncOption{ManualFlush: true, IntervalMs: 10}, func(sync bool) error {
+		if sync {
+			atomic.AddInt32(&syncs, 1)
+		} else {
+			atomic.AddInt32(&flushes, 1)
+		}
+		return nil
+	}, nil)
+	w.Run()
+	w.Written(1 << 20)
+	time.Sleep(50 * time.Millisecond)
+	require.Less(t, int32(0), atomic.LoadInt32(&flushes))
+	require.Equal(t, int32(0), atomic.LoadInt32(&syncs))
+	w.Close()
+	require.Equal(t, int32(1), atomic.LoadInt32(&syncs))
+}
+
+func TestInstance_WalSync(t *testing.T) {
+	ctx := context.TODO()
+	for _, opt := range []WalSyncOption{
+		{Policy: WalSyncEveryWrite},
+		{Policy: WalSyncInterval, IntervalMs: 10},
+		{Policy: WalSyncBytes, Bytes: 16, ManualFlush: true},
+		{ManualFlush: true},
+	} {
+		path, err := genTmpPath()
+		require.NoError(t, err)
+		store, err := newRocksdb(ctx, path, &Option{CreateIfMissing: true, WalSync: opt, HandleError: func(ctx context.Context, err error) {}})
+		require.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, store.SetRaw(ctx, defaultCF, []byte("key"), []byte("value")))
+		}
+		require.NoError(t, store.FlushWAL(ctx, true))
+		store.Close()
+
+		// writes are persisted after closed
+		store, err = newRocksdb(ctx, path, &Option{CreateIfMissing: true, WalSync: opt})
+		require.NoError(t, err)
+		value, err := store.GetRaw(ctx, defaultCF, []byte("key"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+		store.Close()
+	}
+}
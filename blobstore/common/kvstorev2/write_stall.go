@@ -0,0 +1,126 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	rdb "github.com/tecbot/gorocksdb"
+
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+const defaultWriteStallCheckIntervalMs = 1000
+
+const (
+	WriteStallNone WriteStallState = iota
+	// WriteStallStopped writes are stopped until flush or compaction catch up
+	WriteStallStopped
+)
+
+type (
+	WriteStallState uint32
+	// HandleWriteStall is called when write stall state of db changed
+	HandleWriteStall func(ctx context.Context, state WriteStallState)
+)
+
+func (s WriteStallState) String() string {
+	switch s {
+	case WriteStallNone:
+		return "none"
+	case WriteStallStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// ProbeWriteStall returns the current write stall state of rocksdb,
+// delayed writes are throttled by rocksdb itself and not reported.
+func ProbeWriteStall(db *rdb.DB) WriteStallState {
+	if stopped, _ := strconv.ParseUint(db.GetProperty("rocksdb.is-write-stopped"), 10, 64); stopped != 0 {
+		return WriteStallStopped
+	}
+	return WriteStallNone
+}
+
+// WriteStallDetector polls write stall state by probe in interval,
+// and notifies the handler when the state changed.
+type WriteStallDetector struct {
+	state    uint32
+	interval time.Duration
+	probe    func() WriteStallState
+	handle   HandleWriteStall
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+func NewWriteStallDetector(interval time.Duration, probe func() WriteStallState, handle HandleWriteStall) *WriteStallDetector {
+	if interval <= 0 {
+		interval = defaultWriteStallCheckIntervalMs * time.Millisecond
+	}
+	return &WriteStallDetector{
+		interval: interval,
+		probe:    probe,
+		handle:   handle,
+		closeCh:  make(chan struct{}),
+	}
+}
+
+// Run starts polling in background
+func (d *WriteStallDetector) Run() {
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.check()
+			case <-d.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// State returns the last detected write stall state
+func (d *WriteStallDetector) State() WriteStallState {
+	return WriteStallState(atomic.LoadUint32(&d.state))
+}
+
+func (d *WriteStallDetector) check() {
+	state := d.probe()
+	old := WriteStallState(atomic.SwapUint32(&d.state, uint32(state)))
+	if old == state {
+		return
+	}
+
+	span, ctx := trace.StartSpanFromContext(context.Background(), "")
+	span.Warnf("write stall state changed from %s to %s", old, state)
+	if d.handle != nil {
+		d.handle(ctx, state)
+	}
+}
+
+func (d *WriteStallDetector) Close() {
+	d.closeOnce.Do(func() {
+		close(d.closeCh)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteStallDetector(t *testing.T) {
+	var probed uint32
+	notified := make(chan WriteStallState, 4)
+	d := NewWriteStallDetector(10*time.Millisecond, func() WriteStallState {
+		return WriteStallState(atomic.LoadUint32(&probed))
+	}, func(ctx context.Context, state WriteStallState) {
+		notified <- state
+	})
+	d.Run()
+	defer d.Close()
+
+	require.Equal(t, WriteStallNone, d.State())
+	atomic.StoreUint32(&probed, uint32(WriteStallStopped))
+	require.Equal(t, WriteStallStopped, <-notified)
+	require.Equal(t, WriteStallStopped, d.State())
+	require.Equal(t, "stopped", d.State().String())
+	atomic.StoreUint32(&probed, uint32(WriteStallNone))
+	require.Equal(t, WriteStallNone, <-notified)
+	require.Equal(t, "none", d.State().String())
+
+	// not changed, no notification
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 0, len(notified))
+}
+
+func TestInstance_WriteStall(t *testing.T) {
+	ctx := context.TODO()
+	path, err := genTmpPath()
+	require.NoError(t, err)
+	store, err := newRocksdb(ctx, path, &Option{CreateIfMissing: true, WriteStallCheckIntervalMs: 10})
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.Equal(t, WriteStallNone, store.WriteStall())
+	require.Equal(t, WriteStallNone, ProbeWriteStall(store.(*rocksdb).db))
+}
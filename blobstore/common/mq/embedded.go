@@ -0,0 +1,68 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// PathEmbeddedSend path of sending messages to embedded queue
+const PathEmbeddedSend = "/mq/send"
+
+var errNoEmbeddedHosts = errors.New("no hosts of embedded mq")
+
+// SendArgs args of sending messages to embedded queue
+type SendArgs struct {
+	Topic string   `json:"topic"`
+	Msgs  [][]byte `json:"msgs"`
+}
+
+type embeddedProducer struct {
+	client  rpc.Client
+	timeout time.Duration
+}
+
+func newEmbeddedProducer(cfg *ProducerCfg) (Producer, error) {
+	if len(cfg.Embedded.Hosts) == 0 {
+		return nil, errNoEmbeddedHosts
+	}
+	lbCfg := cfg.Embedded
+	if lbCfg.ClientTimeoutMs <= 0 {
+		lbCfg.ClientTimeoutMs = cfg.TimeoutMs
+	}
+	return &embeddedProducer{
+		client:  rpc.NewLbClient(&lbCfg, nil),
+		timeout: time.Duration(lbCfg.ClientTimeoutMs) * time.Millisecond,
+	}, nil
+}
+
+func (p *embeddedProducer) SendMessage(topic string, msg []byte) error {
+	return p.SendMessages(topic, [][]byte{msg})
+}
+
+func (p *embeddedProducer) SendMessages(topic string, msgs [][]byte) error {
+	_, ctx := trace.StartSpanFromContext(context.Background(), "")
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	return p.client.PostWith(ctx, PathEmbeddedSend, nil, SendArgs{Topic: topic, Msgs: msgs})
+}
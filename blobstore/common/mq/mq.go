@@ -0,0 +1,88 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mq
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+const (
+	// BackendKafka sends messages to kafka cluster, the default backend
+	BackendKafka = "kafka"
+	// BackendEmbedded sends messages to the kvstore-backed queue embedded in
+	// scheduler, for small clusters which have no kafka cluster
+	BackendEmbedded = "embedded"
+)
+
+// ErrUnsupportedBackend unsupported backend of message queue
+var ErrUnsupportedBackend = errors.New("unsupported mq backend")
+
+// Producer sends messages to topic of message queue
+type Producer interface {
+	SendMessage(topic string, msg []byte) (err error)
+	SendMessages(topic string, msgs [][]byte) (err error)
+}
+
+// ProducerCfg config of producer, config of kafka is inlined to be compatible with the old config
+type ProducerCfg struct {
+	Backend string `json:"backend"`
+	kafka.ProducerCfg
+	// hosts of scheduler for embedded backend
+	Embedded rpc.LbConfig `json:"embedded"`
+}
+
+// NewProducerFunc creates producer of the backend
+type NewProducerFunc func(cfg *ProducerCfg) (Producer, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]NewProducerFunc)
+)
+
+func init() {
+	Register(BackendKafka, func(cfg *ProducerCfg) (Producer, error) {
+		return kafka.NewProducer(&cfg.ProducerCfg)
+	})
+	Register(BackendEmbedded, newEmbeddedProducer)
+}
+
+// Register registers producer of other backend, the registered one is replaced if register
+// the backend again. Only kafka and embedded are supported, producer of other message queues
+// like pulsar is not provided, and scheduler has no consumer of them
+func Register(backend string, fn NewProducerFunc) {
+	backendsMu.Lock()
+	backends[backend] = fn
+	backendsMu.Unlock()
+}
+
+// NewProducer returns producer of the backend in config, kafka is used if backend is empty
+func NewProducer(cfg *ProducerCfg) (Producer, error) {
+	backend := cfg.Backend
+	if backend == "" {
+		backend = BackendKafka
+	}
+	backendsMu.RLock()
+	fn, ok := backends[backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBackend, backend)
+	}
+	return fn(cfg)
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mq
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+)
+
+type mockProducer struct {
+	msgs map[string][][]byte
+}
+
+func (p *mockProducer) SendMessage(topic string, msg []byte) error {
+	return p.SendMessages(topic, [][]byte{msg})
+}
+
+func (p *mockProducer) SendMessages(topic string, msgs [][]byte) error {
+	p.msgs[topic] = append(p.msgs[topic], msgs...)
+	return nil
+}
+
+func TestNewProducer(t *testing.T) {
+	const backendCustom = "custom"
+	_, err := NewProducer(&ProducerCfg{Backend: backendCustom})
+	require.True(t, errors.Is(err, ErrUnsupportedBackend))
+
+	p := &mockProducer{msgs: make(map[string][][]byte)}
+	Register(backendCustom, func(cfg *ProducerCfg) (Producer, error) { return p, nil })
+	defer func() {
+		backendsMu.Lock()
+		delete(backends, backendCustom)
+		backendsMu.Unlock()
+	}()
+	producer, err := NewProducer(&ProducerCfg{Backend: backendCustom})
+	require.NoError(t, err)
+	require.NoError(t, producer.SendMessage("topic", []byte("msg")))
+	require.Equal(t, [][]byte{[]byte("msg")}, p.msgs["topic"])
+
+	// kafka is the default backend
+	_, err = NewProducer(&ProducerCfg{})
+	require.Error(t, err)
+	_, err = NewProducer(&ProducerCfg{Backend: BackendEmbedded})
+	require.ErrorIs(t, err, errNoEmbeddedHosts)
+}
+
+func TestEmbeddedProducer(t *testing.T) {
+	var got []SendArgs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != PathEmbeddedSend {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var args SendArgs
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		got = append(got, args)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &ProducerCfg{Backend: BackendEmbedded}
+	cfg.TimeoutMs = 1000
+	cfg.Embedded = rpc.LbConfig{Hosts: []string{server.URL}}
+	producer, err := NewProducer(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, producer.SendMessage("delete", []byte("a")))
+	require.NoError(t, producer.SendMessages("repair", [][]byte{[]byte("b"), []byte("c")}))
+	require.Equal(t, []SendArgs{
+		{Topic: "delete", Msgs: [][]byte{[]byte("a")}},
+		{Topic: "repair", Msgs: [][]byte{[]byte("b"), []byte("c")}},
+	}, got)
+}
@@ -25,15 +25,19 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Location struct {
-	ClusterID            ClusterID                                                   `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3,casttype=ClusterID" json:"cluster_id,omitempty"`
-	CodeMode             github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,2,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"code_mode"`
-	Size_                uint64                                                      `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	SliceSize            uint32                                                      `protobuf:"varint,4,opt,name=slice_size,json=sliceSize,proto3" json:"blob_size"`
-	Crc                  uint32                                                      `protobuf:"varint,5,opt,name=crc,proto3" json:"crc,omitempty"`
-	Slices               []Slice                                                     `protobuf:"bytes,6,rep,name=slices,proto3" json:"blobs"`
-	XXX_NoUnkeyedLiteral struct{}                                                    `json:"-"`
-	XXX_unrecognized     []byte                                                      `json:"-"`
-	XXX_sizecache        int32                                                       `json:"-"`
+	ClusterID ClusterID                                                   `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3,casttype=ClusterID" json:"cluster_id,omitempty"`
+	CodeMode  github_com_cubefs_cubefs_blobstore_common_codemode.CodeMode `protobuf:"varint,2,opt,name=codemode,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/codemode.CodeMode" json:"code_mode"`
+	Size_     uint64                                                      `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	SliceSize uint32                                                      `protobuf:"varint,4,opt,name=slice_size,json=sliceSize,proto3" json:"blob_size"`
+	Crc       uint32                                                      `protobuf:"varint,5,opt,name=crc,proto3" json:"crc,omitempty"`
+	Slices    []Slice                                                     `protobuf:"bytes,6,rep,name=slices,proto3" json:"blobs"`
+	// codec of payload, kept in blob metadata of shardnode, not in the encoded location
+	Codec Codec `protobuf:"varint,7,opt,name=codec,proto3,casttype=Codec" json:"codec,omitempty"`
+	// size of the raw payload before encoded by codec, kept in blob metadata of shardnode
+	RawSize              uint64   `protobuf:"varint,8,opt,name=raw_size,json=rawSize,proto3" json:"raw_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Location) Reset()         { *m = Location{} }
@@ -111,10 +115,27 @@ func (m *Location) GetSlices() []Slice {
 	return nil
 }
 
+func (m *Location) GetCodec() Codec {
+	if m != nil {
+		return m.Codec
+	}
+	return 0
+}
+
+func (m *Location) GetRawSize() uint64 {
+	if m != nil {
+		return m.RawSize
+	}
+	return 0
+}
+
 type Blob struct {
-	Name                 []byte   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Location             Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location"`
-	Sealed               bool     `protobuf:"varint,3,opt,name=sealed,proto3" json:"sealed,omitempty"`
+	Name     []byte   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location"`
+	Sealed   bool     `protobuf:"varint,3,opt,name=sealed,proto3" json:"sealed,omitempty"`
+	// unix seconds before which the blob of worm space can not be deleted,
+	// set by the worm retention of space when the blob is sealed
+	Retention            int64    `protobuf:"varint,4,opt,name=retention,proto3" json:"retention,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -174,6 +195,13 @@ func (m *Blob) GetSealed() bool {
 	return false
 }
 
+func (m *Blob) GetRetention() int64 {
+	if m != nil {
+		return m.Retention
+	}
+	return 0
+}
+
 type Slice struct {
 	MinSliceID           BlobID   `protobuf:"varint,1,opt,name=min_slice_id,json=minSliceId,proto3,casttype=BlobID" json:"min_bid"`
 	Vid                  Vid      `protobuf:"varint,2,opt,name=vid,proto3,casttype=Vid" json:"vid,omitempty"`
@@ -254,36 +282,41 @@ func init() {
 func init() { proto.RegisterFile("blob.proto", fileDescriptor_6903d1e8a20272e8) }
 
 var fileDescriptor_6903d1e8a20272e8 = []byte{
-	// 460 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x51, 0x41, 0x8b, 0xd4, 0x4c,
-	0x10, 0xfd, 0xb2, 0xc9, 0x64, 0x93, 0xda, 0x1d, 0xf8, 0x68, 0x96, 0x25, 0x2e, 0x3a, 0x3d, 0x8c,
-	0x82, 0x73, 0x90, 0x04, 0xd6, 0x93, 0xec, 0x2d, 0x3b, 0x1e, 0x82, 0xee, 0xa5, 0x07, 0x44, 0xbc,
-	0x0c, 0x93, 0xee, 0x76, 0x6c, 0x48, 0xd2, 0x32, 0xc9, 0xec, 0x41, 0xf0, 0xa7, 0xf8, 0x7f, 0xf6,
-	0xe8, 0x0f, 0x90, 0x46, 0x72, 0x9c, 0x9f, 0x30, 0x27, 0xe9, 0x4a, 0x32, 0x7a, 0xd2, 0x53, 0x5e,
-	0xbd, 0x7a, 0x5d, 0xf5, 0xf2, 0x0a, 0x20, 0x2f, 0x74, 0x1e, 0x7f, 0xde, 0xea, 0x46, 0x93, 0x27,
-	0x7c, 0x97, 0xcb, 0x8f, 0x75, 0x6c, 0xa9, 0xba, 0xd1, 0x5b, 0x19, 0x73, 0x5d, 0x96, 0xba, 0xea,
-	0xda, 0x57, 0x17, 0x1b, 0xbd, 0xd1, 0x08, 0x13, 0x8b, 0x3a, 0x76, 0xf6, 0xe3, 0x04, 0x82, 0xb7,
-	0x9a, 0xaf, 0x1b, 0xa5, 0x2b, 0xf2, 0x0a, 0x80, 0x17, 0xbb, 0xba, 0x91, 0xdb, 0x95, 0x12, 0x91,
-	0x33, 0x75, 0xe6, 0xe3, 0xf4, 0xaa, 0x35, 0x34, 0xbc, 0xed, 0xd8, 0x6c, 0x71, 0xf8, 0xb3, 0x60,
-	0x61, 0xaf, 0xce, 0x04, 0x69, 0x20, 0xe0, 0x5a, 0xc8, 0x52, 0x0b, 0x19, 0x9d, 0xe0, 0xc3, 0xf7,
-	0xad, 0xa1, 0xc1, 0xad, 0x16, 0xf2, 0x4e, 0x0b, 0xb9, 0x37, 0x34, 0xb4, 0xfd, 0x95, 0x15, 0x1c,
-	0x0c, 0xbd, 0xd9, 0xa8, 0xe6, 0xd3, 0x2e, 0xb7, 0x0e, 0x93, 0xce, 0xf6, 0xf0, 0x39, 0xba, 0x4f,
-	0x3a, 0xf7, 0xc9, 0x30, 0x36, 0x1e, 0x66, 0xb1, 0xe3, 0x26, 0x42, 0xc0, 0xab, 0xd5, 0x17, 0x19,
-	0xb9, 0x53, 0x67, 0xee, 0x31, 0xc4, 0xe4, 0x05, 0x40, 0x5d, 0x28, 0x2e, 0x57, 0xd8, 0xf1, 0xd0,
-	0xcb, 0xd8, 0xee, 0xb7, 0x93, 0x91, 0x64, 0x21, 0x0a, 0x96, 0x56, 0xfd, 0x3f, 0xb8, 0x7c, 0xcb,
-	0xa3, 0x91, 0x95, 0x31, 0x0b, 0xc9, 0x1b, 0xf0, 0xb1, 0x5d, 0x47, 0xfe, 0xd4, 0x9d, 0x9f, 0x5d,
-	0x3f, 0x8b, 0xff, 0x9a, 0x6b, 0xbc, 0xb4, 0xe2, 0x74, 0xfc, 0x60, 0xe8, 0x7f, 0x7b, 0x43, 0x47,
-	0xa8, 0x62, 0xfd, 0x88, 0xd9, 0x57, 0xf0, 0xd2, 0x42, 0xe7, 0xd6, 0x68, 0xb5, 0x2e, 0x25, 0x66,
-	0x7a, 0xce, 0x10, 0x93, 0x0c, 0x82, 0xa2, 0x4f, 0x1e, 0x23, 0x3b, 0xbb, 0x7e, 0xfe, 0x8f, 0x55,
-	0xc3, 0xa1, 0x52, 0xcf, 0x6e, 0x63, 0xc7, 0xe7, 0xe4, 0x12, 0xfc, 0x5a, 0xae, 0x0b, 0x29, 0x30,
-	0x89, 0x80, 0xf5, 0xd5, 0xec, 0x9b, 0x03, 0x23, 0xf4, 0x47, 0x5e, 0xc3, 0x79, 0xa9, 0xaa, 0x55,
-	0x97, 0x4c, 0x7f, 0x5c, 0x2f, 0x7d, 0xda, 0x1a, 0x0a, 0x77, 0xaa, 0x42, 0x4d, 0xb6, 0xd8, 0x1b,
-	0x7a, 0x6a, 0x55, 0xb9, 0x12, 0x07, 0x43, 0x7d, 0xeb, 0x3c, 0x5b, 0x30, 0x28, 0x07, 0x81, 0x20,
-	0x8f, 0xc0, 0xbd, 0x57, 0xa2, 0xbf, 0xf0, 0xe9, 0xc1, 0x50, 0xf7, 0x9d, 0x12, 0xcc, 0x72, 0xe4,
-	0x02, 0x46, 0x5c, 0xef, 0xaa, 0x06, 0x2d, 0x8c, 0x59, 0x57, 0x90, 0xc7, 0x10, 0xde, 0xaf, 0x0b,
-	0x25, 0x96, 0xc3, 0x31, 0x3c, 0xf6, 0x9b, 0x48, 0x2f, 0x1f, 0xda, 0x89, 0xf3, 0xbd, 0x9d, 0x38,
-	0x3f, 0xdb, 0x89, 0xf3, 0x21, 0x88, 0x93, 0x1b, 0xfc, 0xd1, 0xdc, 0xc7, 0xcf, 0xcb, 0x5f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xc3, 0x0c, 0x43, 0x1c, 0xdf, 0x02, 0x00, 0x00,
+	// 539 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0xc1, 0x6e, 0xd3, 0x4c,
+	0x10, 0xfe, 0xfd, 0xdb, 0x49, 0xec, 0x69, 0x23, 0xd0, 0x52, 0x05, 0x53, 0x95, 0x6c, 0x14, 0x90,
+	0xc8, 0xa1, 0x72, 0x44, 0x10, 0x07, 0xd4, 0x9b, 0x1b, 0x0e, 0x11, 0xf4, 0xb2, 0x91, 0x10, 0xe2,
+	0x12, 0xd9, 0xde, 0x25, 0xac, 0x64, 0x7b, 0x2b, 0xdb, 0x69, 0x05, 0xef, 0xc2, 0x5b, 0xf0, 0x06,
+	0x5c, 0x7a, 0xe4, 0x09, 0x56, 0xc8, 0x47, 0x3f, 0x82, 0x4f, 0x68, 0xd7, 0x76, 0xd2, 0x13, 0x9c,
+	0x76, 0xf6, 0x9b, 0x6f, 0x67, 0xbf, 0xf9, 0x66, 0x00, 0xc2, 0x58, 0x84, 0xde, 0x75, 0x26, 0x0a,
+	0x81, 0x9e, 0x46, 0xbb, 0x90, 0x7d, 0xce, 0x3d, 0x05, 0xe5, 0x85, 0xc8, 0x98, 0x17, 0x89, 0x24,
+	0x11, 0x69, 0x93, 0x3e, 0x3d, 0xd9, 0x8a, 0xad, 0xd0, 0xe1, 0x5c, 0x45, 0x0d, 0x3a, 0xfd, 0x69,
+	0x82, 0xfd, 0x5e, 0x44, 0x41, 0xc1, 0x45, 0x8a, 0xde, 0x00, 0x44, 0xf1, 0x2e, 0x2f, 0x58, 0xb6,
+	0xe1, 0xd4, 0x35, 0x26, 0xc6, 0x6c, 0xe8, 0x9f, 0x96, 0x12, 0x3b, 0x97, 0x0d, 0xba, 0x5a, 0xd6,
+	0xf7, 0x2f, 0xc4, 0x69, 0xd9, 0x2b, 0x8a, 0x0a, 0xb0, 0x23, 0x41, 0x59, 0x22, 0x28, 0x73, 0xff,
+	0xd7, 0x0f, 0x3f, 0x96, 0x12, 0xdb, 0x97, 0x82, 0xb2, 0x2b, 0x41, 0x59, 0x25, 0xb1, 0xa3, 0xf2,
+	0x1b, 0x45, 0xa8, 0x25, 0xbe, 0xd8, 0xf2, 0xe2, 0xcb, 0x2e, 0x54, 0x0a, 0xe7, 0x8d, 0xec, 0xee,
+	0xd8, 0xab, 0x9f, 0x37, 0xea, 0xe7, 0x5d, 0x59, 0xaf, 0xab, 0x45, 0xf6, 0x3f, 0x21, 0x04, 0x56,
+	0xce, 0xbf, 0x31, 0xd7, 0x9c, 0x18, 0x33, 0x8b, 0xe8, 0x18, 0x9d, 0x03, 0xe4, 0x31, 0x8f, 0xd8,
+	0x46, 0x67, 0x2c, 0xad, 0x65, 0xa8, 0xfe, 0x57, 0x95, 0x35, 0x48, 0x1c, 0x4d, 0x58, 0x2b, 0xf6,
+	0x43, 0x30, 0xa3, 0x2c, 0x72, 0x7b, 0x8a, 0x46, 0x54, 0x88, 0xde, 0x41, 0x5f, 0xa7, 0x73, 0xb7,
+	0x3f, 0x31, 0x67, 0x47, 0x8b, 0xe7, 0xde, 0x5f, 0x7d, 0xf5, 0xd6, 0x8a, 0xec, 0x0f, 0xef, 0x24,
+	0xfe, 0xaf, 0x92, 0xb8, 0xa7, 0x59, 0xa4, 0x2d, 0x81, 0x16, 0xd0, 0x53, 0x62, 0x23, 0x77, 0xa0,
+	0x75, 0x9c, 0x55, 0x12, 0x3f, 0xd0, 0xc0, 0xb9, 0x48, 0x78, 0xc1, 0x92, 0xeb, 0xe2, 0x6b, 0x2d,
+	0x71, 0x4f, 0xb5, 0x16, 0x91, 0x86, 0x8a, 0x5e, 0x82, 0x9d, 0x05, 0xb7, 0x8d, 0x7c, 0x5b, 0x35,
+	0xe6, 0x8f, 0x2a, 0x89, 0x51, 0x87, 0x1d, 0x5e, 0x92, 0x41, 0x16, 0xdc, 0xaa, 0x2e, 0xa6, 0x3f,
+	0x0c, 0xb0, 0xfc, 0x58, 0x84, 0xca, 0x90, 0x34, 0x48, 0x98, 0x9e, 0xdd, 0x31, 0xd1, 0x31, 0x5a,
+	0x81, 0x1d, 0xb7, 0x13, 0xd6, 0xa3, 0x39, 0x5a, 0xbc, 0xf8, 0x47, 0x4b, 0xdd, 0x42, 0xf8, 0x96,
+	0xea, 0x8a, 0xec, 0x9f, 0xa3, 0x11, 0xf4, 0x73, 0x16, 0xc4, 0x8c, 0x6a, 0xc7, 0x6d, 0xd2, 0xde,
+	0xd0, 0x6b, 0x70, 0x32, 0x56, 0xb0, 0x54, 0xff, 0xa1, 0x2c, 0x37, 0xfd, 0xc7, 0x95, 0xc4, 0x8f,
+	0xf6, 0xe0, 0x3d, 0xd1, 0x07, 0xe6, 0xf4, 0xbb, 0x01, 0x3d, 0x6d, 0x1f, 0x7a, 0x0b, 0xc7, 0x09,
+	0x4f, 0x37, 0xcd, 0xe0, 0xda, 0xdd, 0xb3, 0xfc, 0x67, 0xa5, 0xc4, 0x70, 0xc5, 0x53, 0xcd, 0x59,
+	0x2d, 0x2b, 0x89, 0x07, 0x8a, 0x15, 0x72, 0x5a, 0x4b, 0xdc, 0x57, 0x0d, 0xaf, 0x96, 0x04, 0x92,
+	0x8e, 0x40, 0xd1, 0x13, 0x30, 0x6f, 0x38, 0x6d, 0x17, 0x70, 0x50, 0x4b, 0x6c, 0x7e, 0xe0, 0x94,
+	0x28, 0x0c, 0x9d, 0xa8, 0x49, 0xec, 0xd2, 0x42, 0x2b, 0x1f, 0x92, 0xe6, 0x82, 0xce, 0xc0, 0xb9,
+	0x09, 0x62, 0x4e, 0xd7, 0xdd, 0xae, 0x58, 0xe4, 0x00, 0xf8, 0xa3, 0xbb, 0x72, 0x6c, 0xfc, 0x2a,
+	0xc7, 0xc6, 0xef, 0x72, 0x6c, 0x7c, 0xb2, 0xbd, 0xf9, 0x85, 0xf6, 0x27, 0xec, 0xeb, 0xe3, 0xd5,
+	0x9f, 0x01, 0x00, 0xe2, 0xa9, 0x18, 0x09, 0x7e, 0x03, 0x00, 0x00,
 }
 
 func (m *Location) Marshal() (dAtA []byte, err error) {
@@ -310,6 +343,16 @@ func (m *Location) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.RawSize != 0 {
+		i = encodeVarintBlob(dAtA, i, uint64(m.RawSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Codec != 0 {
+		i = encodeVarintBlob(dAtA, i, uint64(m.Codec))
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.Slices) > 0 {
 		for iNdEx := len(m.Slices) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -376,6 +419,11 @@ func (m *Blob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Retention != 0 {
+		i = encodeVarintBlob(dAtA, i, uint64(m.Retention))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Sealed {
 		i--
 		if m.Sealed {
@@ -491,6 +539,12 @@ func (m *Location) Size() (n int) {
 			n += 1 + l + sovBlob(uint64(l))
 		}
 	}
+	if m.Codec != 0 {
+		n += 1 + sovBlob(uint64(m.Codec))
+	}
+	if m.RawSize != 0 {
+		n += 1 + sovBlob(uint64(m.RawSize))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -512,6 +566,9 @@ func (m *Blob) Size() (n int) {
 	if m.Sealed {
 		n += 2
 	}
+	if m.Retention != 0 {
+		n += 1 + sovBlob(uint64(m.Retention))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -706,6 +763,44 @@ func (m *Location) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codec", wireType)
+			}
+			m.Codec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Codec |= Codec(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RawSize", wireType)
+			}
+			m.RawSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RawSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlob(dAtA[iNdEx:])
@@ -844,6 +939,25 @@ func (m *Blob) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Sealed = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Retention", wireType)
+			}
+			m.Retention = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Retention |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlob(dAtA[iNdEx:])
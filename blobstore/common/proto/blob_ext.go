@@ -34,6 +34,14 @@ type BlobUnit struct {
 	Size uint32
 }
 
+// Codec is the compression codec of blob payload
+type Codec uint32
+
+const (
+	CodecNone Codec = iota
+	CodecZstd
+)
+
 // Copy returns a new same Location
 func (loc *Location) Copy() Location {
 	dst := Location{
@@ -43,6 +51,8 @@ func (loc *Location) Copy() Location {
 		SliceSize: loc.SliceSize,
 		Crc:       loc.Crc,
 		Slices:    make([]Slice, len(loc.Slices)),
+		Codec:     loc.Codec,
+		RawSize:   loc.RawSize,
 	}
 	copy(dst.Slices, loc.Slices)
 	return dst
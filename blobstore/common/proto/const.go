@@ -232,6 +232,15 @@ const (
 	ShardInitDoneKey         = "shard_init_done"
 )
 
+// MaintenanceWindowsConfigKey config key of maintenance windows calendar
+const MaintenanceWindowsConfigKey = "maintenance_windows"
+
+// ClusterFreezeConfigKey config key of the emergency freeze of cluster mutations
+const ClusterFreezeConfigKey = "cluster_freeze"
+
+// VolumeInspectConfigKey config key of volume inspection tuned at runtime
+const VolumeInspectConfigKey = "volume_inspect"
+
 func IsSysConfigKey(key string) bool {
 	switch key {
 	case VolumeChunkSizeKey, VolumeReserveSizeKey, CodeModeConfigKey, ShardInitDoneKey,
@@ -87,6 +87,14 @@ type ShardRepairMsg struct {
 	Retry     int       `json:"retry"`
 	Reason    string    `json:"reason"`
 	ReqId     string    `json:"req_id"`
+	// BadRange bytes range of the bad shard, nil means the whole shard
+	BadRange *ShardRange `json:"bad_range,omitempty"`
+}
+
+// ShardRange bytes range [From, To) of shard
+type ShardRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
 }
 
 func (msg *ShardRepairMsg) IsValid() bool {
@@ -26,10 +26,10 @@ func TestShardRepairMsg_IsValid(t *testing.T) {
 		msg ShardRepairMsg
 		ok  bool
 	}{
-		{ShardRepairMsg{1, 1, 1, []uint8{1}, 0, "access", ""}, true},
-		{ShardRepairMsg{1, 0, 1, []uint8{1}, 0, "access", ""}, false},
-		{ShardRepairMsg{1, 1, 0, []uint8{1}, 0, "access", ""}, false},
-		{ShardRepairMsg{1, 1, 1, []uint8{}, 0, "access", ""}, false},
+		{ShardRepairMsg{1, 1, 1, []uint8{1}, 0, "access", "", nil}, true},
+		{ShardRepairMsg{1, 0, 1, []uint8{1}, 0, "access", "", nil}, false},
+		{ShardRepairMsg{1, 1, 0, []uint8{1}, 0, "access", "", nil}, false},
+		{ShardRepairMsg{1, 1, 1, []uint8{}, 0, "access", "", nil}, false},
 	} {
 		require.Equal(t, cs.ok, cs.msg.IsValid())
 	}
@@ -37,13 +37,14 @@ const (
 type TaskType string
 
 const (
-	TaskTypeDiskRepair    TaskType = "disk_repair"
-	TaskTypeBalance       TaskType = "balance"
-	TaskTypeDiskDrop      TaskType = "disk_drop"
-	TaskTypeManualMigrate TaskType = "manual_migrate"
-	TaskTypeVolumeInspect TaskType = "volume_inspect"
-	TaskTypeShardRepair   TaskType = "shard_repair"
-	TaskTypeBlobDelete    TaskType = "blob_delete"
+	TaskTypeDiskRepair     TaskType = "disk_repair"
+	TaskTypeBalance        TaskType = "balance"
+	TaskTypeDiskDrop       TaskType = "disk_drop"
+	TaskTypeManualMigrate  TaskType = "manual_migrate"
+	TaskTypeVolumeInspect  TaskType = "volume_inspect"
+	TaskTypeShardRepair    TaskType = "shard_repair"
+	TaskTypeBlobDelete     TaskType = "blob_delete"
+	TaskTypeClusterMigrate TaskType = "cluster_migrate"
 
 	TaskTypeShardInspect    TaskType = "shard_inspect"
 	TaskTypeShardDiskRepair TaskType = "shard_disk_repair"
@@ -54,7 +55,7 @@ const (
 func (t TaskType) Valid() bool {
 	switch t {
 	case TaskTypeDiskRepair, TaskTypeBalance, TaskTypeDiskDrop, TaskTypeManualMigrate,
-		TaskTypeVolumeInspect, TaskTypeShardRepair, TaskTypeBlobDelete,
+		TaskTypeVolumeInspect, TaskTypeShardRepair, TaskTypeBlobDelete, TaskTypeClusterMigrate,
 		TaskTypeShardInspect, TaskTypeShardDiskRepair, TaskTypeShardMigrate, TaskTypeShardDiskDrop:
 		return true
 	default:
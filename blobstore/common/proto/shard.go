@@ -28,15 +28,18 @@ type (
 )
 
 func (s Suid) ShardID() ShardID {
-	return ShardID(s >> 32)
+	id, _, _ := decodeUnitID(uint64(s))
+	return ShardID(id)
 }
 
 func (s Suid) Index() uint8 {
-	return uint8(s & 0xff000000 >> 24)
+	_, idx, _ := decodeUnitID(uint64(s))
+	return idx
 }
 
 func (s Suid) Epoch() uint32 {
-	return uint32(s & 0xffffff)
+	_, _, epoch := decodeUnitID(uint64(s))
+	return epoch
 }
 
 func (s Suid) IsValid() bool {
@@ -44,8 +47,7 @@ func (s Suid) IsValid() bool {
 }
 
 func (s Suid) SuidPrefix() SuidPrefix {
-	suidPre := uint64(s) - uint64(s.Epoch())
-	return SuidPrefix(suidPre)
+	return EncodeSuidPrefix(s.ShardID(), s.Index())
 }
 
 func (s Suid) ToString() string {
@@ -53,20 +55,21 @@ func (s Suid) ToString() string {
 }
 
 func (s SuidPrefix) ShardID() ShardID {
-	return ShardID(s & 0xffffffff00000000 >> 32)
+	id, _, _ := decodeUnitID(uint64(s))
+	return ShardID(id)
 }
 
 func (s SuidPrefix) Index() uint8 {
-	return uint8(s & 0xff000000 >> 24)
+	_, idx, _ := decodeUnitID(uint64(s))
+	return idx
 }
 
 func EncodeSuid(shardID ShardID, index uint8, epoch uint32) Suid {
-	return Suid(uint64(shardID)<<32 + uint64(index)<<24 + uint64(epoch))
+	return Suid(encodeUnitID(uint32(shardID), index, epoch))
 }
 
 func EncodeSuidPrefix(shardID ShardID, idx uint8) SuidPrefix {
-	u64 := uint64(shardID)<<32 + uint64(idx)<<24
-	return SuidPrefix(u64)
+	return SuidPrefix(encodeUnitID(uint32(shardID), idx, 0))
 }
 
 type SpaceStatus uint8
@@ -0,0 +1,145 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// unit id is the composite id of volume unit(vuid) and shard unit(suid),
+// encoded as: [vid or shard id(32)][index(8)][epoch(24)]
+const (
+	unitIDShift    = 32
+	unitIndexShift = 24
+	unitIndexMask  = 0xff000000
+	unitEpochMask  = 0xffffff
+)
+
+func encodeUnitID(id uint32, index uint8, epoch uint32) uint64 {
+	return uint64(id)<<unitIDShift | uint64(index)<<unitIndexShift | uint64(epoch&unitEpochMask)
+}
+
+func decodeUnitID(u uint64) (id uint32, index uint8, epoch uint32) {
+	return uint32(u >> unitIDShift), uint8(u & unitIndexMask >> unitIndexShift), uint32(u & unitEpochMask)
+}
+
+// checkUnitID returns error with all invalid parts of the unit id,
+// name is the name of unit id and idName is the name of its high 32 bits.
+func checkUnitID(name, idName string, u uint64, index int, epoch int64) error {
+	var invalids []string
+	if u>>unitIDShift == 0 {
+		invalids = append(invalids, fmt.Sprintf("%s is 0", idName))
+	}
+	if index < MinIndex || index > MaxIndex {
+		invalids = append(invalids, fmt.Sprintf("index %d out of range [%d, %d]", index, MinIndex, MaxIndex))
+	}
+	if epoch < MinEpoch || epoch > MaxEpoch {
+		invalids = append(invalids, fmt.Sprintf("epoch %d out of range [%d, %d]", epoch, MinEpoch, MaxEpoch))
+	}
+	if len(invalids) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid %s(%d): %s", name, u, strings.Join(invalids, "; "))
+}
+
+// CheckVuid returns error with all invalid parts of the vuid.
+func CheckVuid(vuid Vuid) error {
+	return checkUnitID("vuid", "vid", uint64(vuid), int(vuid.Index()), int64(vuid.Epoch()))
+}
+
+// CheckSuid returns error with all invalid parts of the suid.
+func CheckSuid(suid Suid) error {
+	return checkUnitID("suid", "shard id", uint64(suid), int(suid.Index()), int64(suid.Epoch()))
+}
+
+// NewVuidPrefix composes vuid prefix by vid and index, the index is checked before truncated to uint8.
+func NewVuidPrefix(vid Vid, index int) (VuidPrefix, error) {
+	if err := checkUnitID("vuid prefix", "vid", encodeUnitID(uint32(vid), 0, 0), index, MinEpoch); err != nil {
+		return 0, err
+	}
+	return EncodeVuidPrefix(vid, uint8(index)), nil
+}
+
+// NewSuid composes suid by shard id, index and epoch.
+func NewSuid(shardID ShardID, index int, epoch uint32) (Suid, error) {
+	if err := checkUnitID("suid", "shard id", encodeUnitID(uint32(shardID), 0, 0), index, int64(epoch)); err != nil {
+		return 0, err
+	}
+	return EncodeSuid(shardID, uint8(index), epoch), nil
+}
+
+// CheckUnitIndex checks the index of unit in the units of volume or shard.
+func CheckUnitIndex(index, total int) error {
+	if total <= 0 || total > MaxIndex+1 {
+		return fmt.Errorf("invalid total units %d, should be in range [1, %d]", total, MaxIndex+1)
+	}
+	if index < 0 || index >= total {
+		return fmt.Errorf("unit index %d out of range [0, %d)", index, total)
+	}
+	return nil
+}
+
+// parseUnitID parses the decimal unit id, or the parts of unit id with
+// format "id:index:epoch".
+func parseUnitID(name, s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ":")
+	switch len(parts) {
+	case 1:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse %s(%s): %s", name, s, err.Error())
+		}
+		return u, nil
+	case 3:
+		id, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("parse %s(%s) id: %s", name, s, err.Error())
+		}
+		index, err := strconv.ParseUint(parts[1], 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("parse %s(%s) index: %s", name, s, err.Error())
+		}
+		epoch, err := strconv.ParseUint(parts[2], 10, 24)
+		if err != nil {
+			return 0, fmt.Errorf("parse %s(%s) epoch: %s", name, s, err.Error())
+		}
+		return encodeUnitID(uint32(id), uint8(index), uint32(epoch)), nil
+	default:
+		return 0, fmt.Errorf("parse %s(%s): should be decimal or id:index:epoch", name, s)
+	}
+}
+
+// ParseVuid parses vuid from decimal or "vid:index:epoch", and checks it.
+func ParseVuid(s string) (Vuid, error) {
+	u, err := parseUnitID("vuid", s)
+	if err != nil {
+		return InvalidVuid, err
+	}
+	vuid := Vuid(u)
+	return vuid, CheckVuid(vuid)
+}
+
+// ParseSuid parses suid from decimal or "shardID:index:epoch", and checks it.
+func ParseSuid(s string) (Suid, error) {
+	u, err := parseUnitID("suid", s)
+	if err != nil {
+		return InvalidSuid, err
+	}
+	suid := Suid(u)
+	return suid, CheckSuid(suid)
+}
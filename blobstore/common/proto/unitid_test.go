@@ -0,0 +1,105 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitIDEncodeDecode(t *testing.T) {
+	u := encodeUnitID(0xffffffff, 0xff, MaxEpoch)
+	require.Equal(t, uint64(0xffffffffffffffff), u)
+	id, index, epoch := decodeUnitID(u)
+	require.Equal(t, uint32(0xffffffff), id)
+	require.Equal(t, uint8(0xff), index)
+	require.Equal(t, uint32(MaxEpoch), epoch)
+
+	// epoch overflow does not pollute index
+	_, index, epoch = decodeUnitID(encodeUnitID(1, 2, MaxEpoch+2))
+	require.Equal(t, uint8(2), index)
+	require.Equal(t, uint32(1), epoch)
+
+	vuid, err := NewVuid(10, 3, 7)
+	require.NoError(t, err)
+	require.Equal(t, vuid, EncodeVuid(EncodeVuidPrefix(10, 3), 7))
+	require.Equal(t, Suid(vuid), EncodeSuid(10, 3, 7))
+	require.Equal(t, SuidPrefix(vuid.VuidPrefix()), EncodeSuid(10, 3, 7).SuidPrefix())
+}
+
+func TestUnitIDCheck(t *testing.T) {
+	require.NoError(t, CheckVuid(EncodeVuid(EncodeVuidPrefix(1, 0), MinEpoch)))
+	require.NoError(t, CheckSuid(EncodeSuid(1, MaxIndex, MaxEpoch)))
+
+	err := CheckVuid(EncodeVuid(EncodeVuidPrefix(0, 1), 0))
+	require.ErrorContains(t, err, "invalid vuid(16777216)")
+	require.ErrorContains(t, err, "vid is 0")
+	require.ErrorContains(t, err, "epoch 0 out of range")
+	err = CheckSuid(EncodeSuid(1, 1, 0))
+	require.ErrorContains(t, err, "invalid suid")
+	require.NotContains(t, err.Error(), "shard id is 0")
+
+	_, err = NewVuid(1, 1, MaxEpoch+1)
+	require.ErrorContains(t, err, "epoch 16777216 out of range")
+
+	prefix, err := NewVuidPrefix(2, 5)
+	require.NoError(t, err)
+	require.Equal(t, EncodeVuidPrefix(2, 5), prefix)
+	for _, index := range []int{-1, MaxIndex + 1} {
+		_, err = NewVuidPrefix(2, index)
+		require.ErrorContains(t, err, "index")
+		_, err = NewSuid(2, index, 1)
+		require.ErrorContains(t, err, "index")
+	}
+	_, err = NewSuid(0, 1, 0)
+	require.ErrorContains(t, err, "shard id is 0; epoch 0")
+	suid, err := NewSuid(2, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, EncodeSuid(2, 1, 3), suid)
+
+	require.NoError(t, CheckUnitIndex(0, 1))
+	require.NoError(t, CheckUnitIndex(255, 256))
+	require.Error(t, CheckUnitIndex(1, 1))
+	require.Error(t, CheckUnitIndex(-1, 1))
+	require.Error(t, CheckUnitIndex(0, 0))
+	require.Error(t, CheckUnitIndex(0, 257))
+}
+
+func TestUnitIDParse(t *testing.T) {
+	vuid, err := ParseVuid("425335980033")
+	require.NoError(t, err)
+	require.Equal(t, Vuid(425335980033), vuid)
+	vuid, err = ParseVuid(" 99:1:1 ")
+	require.NoError(t, err)
+	require.Equal(t, Vid(99), vuid.Vid())
+	require.Equal(t, uint8(1), vuid.Index())
+	require.Equal(t, uint32(1), vuid.Epoch())
+
+	suid, err := ParseSuid("7:2:3")
+	require.NoError(t, err)
+	require.Equal(t, EncodeSuid(7, 2, 3), suid)
+
+	for _, s := range []string{"", "x", "1:2", "1:256:1", "1:1:16777216", "4294967296:1:1", "-1"} {
+		_, err = ParseVuid(s)
+		require.Error(t, err, s)
+		_, err = ParseSuid(s)
+		require.Error(t, err, s)
+	}
+	// parsed but invalid
+	vuid, err = ParseVuid("0:1:0")
+	require.ErrorContains(t, err, "vid is 0")
+	require.Equal(t, uint8(1), vuid.Index())
+}
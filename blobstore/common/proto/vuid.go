@@ -15,7 +15,7 @@
 package proto
 
 import (
-	"errors"
+	"fmt"
 	"strconv"
 )
 
@@ -37,26 +37,24 @@ func (vu Vuid) IsValid() bool {
 
 func NewVuid(vid Vid, idx uint8, epoch uint32) (Vuid, error) {
 	if !IsValidEpoch(epoch) {
-		err := errors.New("fail to new vuid,Epoch is overflow")
+		err := fmt.Errorf("fail to new vuid, epoch %d out of range [%d, %d]", epoch, MinEpoch, MaxEpoch)
 		return 0, err
 	}
-
-	u64 := uint64(vid)<<32 + uint64(idx)<<24 + uint64(epoch)
-	return Vuid(u64), nil
+	return Vuid(encodeUnitID(uint32(vid), idx, epoch)), nil
 }
 
 func EncodeVuidPrefix(vid Vid, idx uint8) VuidPrefix {
-	u64 := uint64(vid)<<32 + uint64(idx)<<24
-	return VuidPrefix(u64)
+	return VuidPrefix(encodeUnitID(uint32(vid), idx, 0))
 }
 
 func EncodeVuid(v VuidPrefix, epoch uint32) Vuid {
-	u64 := uint64(v) + uint64(epoch)
-	return Vuid(u64)
+	id, idx, _ := decodeUnitID(uint64(v))
+	return Vuid(encodeUnitID(id, idx, epoch))
 }
 
 func (v Vuid) Vid() Vid {
-	return Vid(v & 0xffffffff00000000 >> 32)
+	id, _, _ := decodeUnitID(uint64(v))
+	return Vid(id)
 }
 
 func (v Vuid) ToString() string {
@@ -64,24 +62,27 @@ func (v Vuid) ToString() string {
 }
 
 func (v Vuid) Index() uint8 {
-	return uint8(v & 0xff000000 >> 24)
+	_, idx, _ := decodeUnitID(uint64(v))
+	return idx
 }
 
 func (v Vuid) Epoch() uint32 {
-	return uint32(v & 0xffffff)
+	_, _, epoch := decodeUnitID(uint64(v))
+	return epoch
 }
 
 func (v Vuid) VuidPrefix() VuidPrefix {
-	vuidPre := uint64(v) - uint64(v.Epoch())
-	return VuidPrefix(vuidPre)
+	return EncodeVuidPrefix(v.Vid(), v.Index())
 }
 
 func (v VuidPrefix) Vid() Vid {
-	return Vid(v & 0xffffffff00000000 >> 32)
+	id, _, _ := decodeUnitID(uint64(v))
+	return Vid(id)
 }
 
 func (v VuidPrefix) Index() uint8 {
-	return uint8(v & 0xff000000 >> 24)
+	_, idx, _ := decodeUnitID(uint64(v))
+	return idx
 }
 
 func IsValidEpoch(epoch uint32) bool {
@@ -510,9 +510,9 @@ func (g *internalGroupProcessor) SaveHardStateAndEntries(ctx context.Context, hs
 	return g.storage.SaveHardStateAndEntries(hs, entries)
 }
 
-func (g *internalGroupProcessor) ApplyLeaderChange(nodeID uint64) error {
+func (g *internalGroupProcessor) ApplyLeaderChange(nodeID, term uint64) error {
 	g.lead = nodeID
-	return g.sm.LeaderChange(nodeID)
+	return g.sm.LeaderChange(nodeID, term)
 }
 
 func (g *internalGroupProcessor) ApplySnapshot(ctx context.Context, snap raftpb.Snapshot) error {
@@ -582,6 +582,13 @@ func (g *internalGroupProcessor) ApplyCommittedEntries(ctx context.Context, entr
 			if err := proposalData.Unmarshal(entries[i].Data); err != nil {
 				return errors.Info(err, "unmarshal proposal data failed")
 			}
+			// the leader changed after the proposer checked its term, skip it on every member
+			if proposalData.Term != 0 && proposalData.Term != entries[i].Term {
+				span.Warnf("group: %d skip fenced proposal, term: %d, entry term: %d, index: %d",
+					g.id, proposalData.Term, entries[i].Term, entries[i].Index)
+				(*group)(g).doNotify(proposalData.notifyID, proposalResult{err: ErrProposalFenced})
+				allProposalData = allProposalData[:len(allProposalData)-1]
+			}
 		default:
 
 		}
@@ -90,7 +90,7 @@ type (
 		AddIncomingSnapshot(ctx context.Context, req *RaftSnapshotRequest, snapshot *incomingSnapshot) notify
 		ProcessRaftIncomingSnapshot(ctx context.Context) error
 		SaveHardStateAndEntries(ctx context.Context, hs raftpb.HardState, entries []raftpb.Entry) error
-		ApplyLeaderChange(nodeID uint64) error
+		ApplyLeaderChange(nodeID, term uint64) error
 		ApplySnapshot(ctx context.Context, snap raftpb.Snapshot) error
 		ApplyCommittedEntries(ctx context.Context, entries []raftpb.Entry) (err error)
 		ApplyReadIndex(ctx context.Context, readState raft.ReadState)
@@ -452,6 +452,7 @@ func (h *internalGroupHandler) processReady(ctx context.Context, g groupProcesso
 	var (
 		hasReady bool
 		rd       raft.Ready
+		term     uint64
 	)
 	g.WithRaftRawNodeLocked(func(rn *raft.RawNode) error {
 		hasReady = rn.HasReady()
@@ -459,6 +460,7 @@ func (h *internalGroupHandler) processReady(ctx context.Context, g groupProcesso
 			return nil
 		}
 		rd = rn.Ready()
+		term = rn.BasicStatus().Term
 		return nil
 	})
 	if !hasReady {
@@ -466,7 +468,7 @@ func (h *internalGroupHandler) processReady(ctx context.Context, g groupProcesso
 	}
 
 	if rd.SoftState != nil {
-		if err := g.ApplyLeaderChange(rd.SoftState.Lead); err != nil {
+		if err := g.ApplyLeaderChange(rd.SoftState.Lead, term); err != nil {
 			span.Panicf("leader change notify failed: %s", err)
 		}
 	}
@@ -28,6 +28,7 @@ var (
 	ErrNotFound              = errors.New("key not found")
 	ErrLearnerCanNotBeLeader = errors.New("learner can not be leader")
 	ErrEntryNotFound         = errors.New("entry not found")
+	ErrProposalFenced        = errors.New("proposal fenced by term")
 )
 
 type (
@@ -35,7 +36,9 @@ type (
 		// Apply will notify the state machine to apply all proposal data
 		// Note that the rets slice length should be equal to proposal data slice length
 		Apply(cxt context.Context, pd []ProposalData, index uint64) (rets []interface{}, err error)
-		LeaderChange(peerID uint64) error
+		// LeaderChange notify the new leader and the raft term it elected in,
+		// the term can be used as fencing token of the leader
+		LeaderChange(peerID, term uint64) error
 		ApplyMemberChange(cc *Member, index uint64) error
 		Snapshot() (Snapshot, error)
 		ApplySnapshot(ctx context.Context, h RaftSnapshotHeader, s Snapshot) error
@@ -78,17 +78,17 @@ func (mr *MockStateMachineMockRecorder) ApplySnapshot(ctx, h, s interface{}) *go
 }
 
 // LeaderChange mocks base method.
-func (m *MockStateMachine) LeaderChange(peerID uint64) error {
+func (m *MockStateMachine) LeaderChange(peerID, term uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LeaderChange", peerID)
+	ret := m.ctrl.Call(m, "LeaderChange", peerID, term)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // LeaderChange indicates an expected call of LeaderChange.
-func (mr *MockStateMachineMockRecorder) LeaderChange(peerID interface{}) *gomock.Call {
+func (mr *MockStateMachineMockRecorder) LeaderChange(peerID, term interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaderChange", reflect.TypeOf((*MockStateMachine)(nil).LeaderChange), peerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaderChange", reflect.TypeOf((*MockStateMachine)(nil).LeaderChange), peerID, term)
 }
 
 // Snapshot mocks base method.
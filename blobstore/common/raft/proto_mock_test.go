@@ -80,8 +80,8 @@ func (t *testStateMachine) Apply(cxt context.Context, pd []ProposalData, index u
 	return rets, nil
 }
 
-func (t *testStateMachine) LeaderChange(peerID uint64) error {
-	log.Infof("receive leader change notify: %d", peerID)
+func (t *testStateMachine) LeaderChange(peerID, term uint64) error {
+	log.Infof("receive leader change notify: %d, term: %d", peerID, term)
 	if peerID == 0 {
 		return nil
 	}
@@ -186,11 +186,16 @@ func (m *Error) GetErrorMsg() string {
 }
 
 type ProposalData struct {
-	Module               []byte   `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
-	Op                   uint32   `protobuf:"varint,2,opt,name=op,proto3" json:"op,omitempty"`
-	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Context              []byte   `protobuf:"bytes,4,opt,name=context,proto3" json:"context,omitempty"`
-	notifyID             uint64   `protobuf:"varint,5,opt,name=notify_id,json=notifyId,proto3" json:"-"`
+	Module   []byte `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Op       uint32 `protobuf:"varint,2,opt,name=op,proto3" json:"op,omitempty"`
+	Data     []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Context  []byte `protobuf:"bytes,4,opt,name=context,proto3" json:"context,omitempty"`
+	notifyID uint64 `protobuf:"varint,5,opt,name=notify_id,json=notifyId,proto3" json:"-"`
+	// sync requires the wal entry of proposal synced immediately on every member
+	Sync bool `protobuf:"varint,6,opt,name=sync,proto3" json:"sync,omitempty"`
+	// term of the leader which the proposer knows, the proposal is fenced at applying
+	// if it is appended in another term. zero means no fencing
+	Term                 uint64   `protobuf:"varint,7,opt,name=term,proto3" json:"term,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -264,6 +269,20 @@ func (m *ProposalData) GetnotifyID() uint64 {
 	return 0
 }
 
+func (m *ProposalData) GetSync() bool {
+	if m != nil {
+		return m.Sync
+	}
+	return false
+}
+
+func (m *ProposalData) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
 type Member struct {
 	NodeID               uint64           `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	Host                 string           `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
@@ -1108,82 +1127,83 @@ func init() {
 func init() { proto.RegisterFile("raft.proto", fileDescriptor_b042552c306ae59b) }
 
 var fileDescriptor_b042552c306ae59b = []byte{
-	// 1196 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xcf, 0x9d, 0xcf, 0x67, 0x7b, 0xec, 0xa4, 0xd7, 0x6d, 0x28, 0xa7, 0x02, 0x4d, 0xb8, 0xf0,
-	0x27, 0x05, 0x71, 0x41, 0xed, 0x13, 0xaa, 0x40, 0xf2, 0x3f, 0xa8, 0x69, 0x9a, 0xa6, 0xdb, 0xb4,
-	0x08, 0x5e, 0xac, 0xb3, 0x6f, 0x6d, 0x9f, 0x74, 0xbe, 0xbd, 0xee, 0xae, 0x2b, 0xf2, 0x21, 0x78,
-	0xe3, 0x0d, 0x89, 0xef, 0xc1, 0x07, 0x40, 0xea, 0x13, 0xe2, 0x1d, 0xc9, 0xaa, 0xfc, 0xc8, 0xa7,
-	0x40, 0xfb, 0xe7, 0x1c, 0x3b, 0x15, 0x69, 0x0b, 0x7d, 0xb1, 0x76, 0x66, 0x67, 0x7e, 0x9e, 0xf9,
-	0xcd, 0xce, 0xcc, 0x01, 0xb0, 0x68, 0x24, 0xc2, 0x9c, 0x51, 0x41, 0xd1, 0xbb, 0xc3, 0xd9, 0x80,
-	0x8c, 0x78, 0x38, 0x48, 0xe9, 0x80, 0x0b, 0xca, 0x48, 0x38, 0xa4, 0xd3, 0x29, 0xcd, 0x42, 0x69,
-	0x73, 0xed, 0xb2, 0xfc, 0xcd, 0x07, 0x07, 0x67, 0x0e, 0xd7, 0xb6, 0xc7, 0x74, 0x4c, 0xd5, 0xf1,
-	0x40, 0x9e, 0xb4, 0x36, 0x68, 0x43, 0xb9, 0xcb, 0x18, 0x65, 0xe8, 0x3d, 0x00, 0x22, 0x0f, 0xfd,
-	0x21, 0x8d, 0x89, 0x6f, 0xed, 0x5a, 0xfb, 0x9b, 0xb8, 0xa6, 0x34, 0x6d, 0x1a, 0x13, 0xf4, 0x0e,
-	0x68, 0xa1, 0x3f, 0xe5, 0x63, 0xdf, 0xde, 0xb5, 0xf6, 0x6b, 0xb8, 0xaa, 0x14, 0xf7, 0xf8, 0x38,
-	0xf8, 0xd9, 0x82, 0xc6, 0x31, 0xa3, 0x39, 0xe5, 0x51, 0xda, 0x89, 0x44, 0x84, 0xae, 0x82, 0x3b,
-	0xa5, 0xf1, 0x2c, 0xd5, 0x40, 0x0d, 0x6c, 0x24, 0xb4, 0x05, 0x36, 0xcd, 0x95, 0xfb, 0x26, 0xb6,
-	0x69, 0x8e, 0x10, 0x38, 0x71, 0x24, 0x22, 0xbf, 0xa4, 0xac, 0xd4, 0x19, 0xf9, 0x50, 0x19, 0xd2,
-	0x4c, 0x90, 0x1f, 0x85, 0xef, 0x28, 0x75, 0x21, 0xa2, 0x10, 0x6a, 0x19, 0x15, 0xc9, 0xe8, 0xb4,
-	0x9f, 0xc4, 0x7e, 0x79, 0xd7, 0xda, 0x77, 0x5a, 0x97, 0x17, 0xf3, 0x9d, 0xaa, 0x56, 0xf6, 0x3a,
-	0x7f, 0xcf, 0x77, 0xac, 0xcf, 0x70, 0x21, 0xc6, 0xc1, 0x6f, 0x16, 0xb8, 0xf7, 0xc8, 0x74, 0x40,
-	0x18, 0xda, 0x83, 0x4a, 0x46, 0x63, 0x22, 0x1d, 0x2d, 0xe5, 0x08, 0x8b, 0xf9, 0x8e, 0x7b, 0x44,
-	0x63, 0xd2, 0xeb, 0x60, 0x57, 0x5e, 0xf5, 0x62, 0x19, 0xcd, 0x84, 0x72, 0x61, 0xd2, 0x53, 0x67,
-	0xd4, 0x02, 0x47, 0x9c, 0xe6, 0x44, 0x45, 0xb8, 0x75, 0x33, 0x0c, 0x2f, 0x62, 0x3d, 0xd4, 0x7f,
-	0xd6, 0x9e, 0x44, 0xd9, 0x98, 0x9c, 0x9c, 0xe6, 0x04, 0x2b, 0x5f, 0x99, 0x51, 0x4a, 0x22, 0x96,
-	0x11, 0xa6, 0x32, 0xaa, 0xe2, 0x42, 0x5c, 0xcd, 0xb5, 0xbc, 0x96, 0x6b, 0xf0, 0x93, 0x05, 0x9b,
-	0x38, 0x1a, 0x89, 0x3b, 0x24, 0x62, 0x62, 0x40, 0x22, 0x81, 0x3e, 0x82, 0xea, 0x98, 0xd1, 0x59,
-	0x7e, 0x96, 0x43, 0x7d, 0x31, 0xdf, 0xa9, 0x7c, 0x23, 0x75, 0xbd, 0x0e, 0xae, 0xa8, 0x4b, 0x9d,
-	0xc5, 0x88, 0xd1, 0xa9, 0xca, 0xc2, 0xc1, 0xea, 0x2c, 0x79, 0x17, 0x54, 0xe5, 0xe0, 0x60, 0x5b,
-	0x50, 0x69, 0x23, 0x08, 0x9b, 0xaa, 0x70, 0x1c, 0xac, 0xce, 0xb2, 0x66, 0x32, 0x97, 0x44, 0x87,
-	0xe2, 0x60, 0x23, 0x7d, 0xeb, 0x54, 0x5d, 0xaf, 0x12, 0x3c, 0xb3, 0x01, 0xc9, 0x78, 0xee, 0x11,
-	0xce, 0xa3, 0x31, 0xc1, 0xe4, 0xc9, 0x8c, 0xf0, 0x37, 0x1b, 0xd4, 0x01, 0x54, 0xa6, 0x1a, 0x5d,
-	0xc5, 0x55, 0xbf, 0x79, 0x29, 0xd4, 0xaf, 0x38, 0x34, 0x7f, 0xda, 0x72, 0x9e, 0xcd, 0x77, 0x36,
-	0x70, 0x61, 0x85, 0x1e, 0x00, 0x4c, 0x0a, 0x7a, 0xb8, 0x5f, 0xde, 0x2d, 0xed, 0xd7, 0x6f, 0x7e,
-	0x7a, 0x71, 0x85, 0xd6, 0x28, 0x35, 0x78, 0x2b, 0x20, 0x68, 0x00, 0x57, 0x96, 0x52, 0x9f, 0x11,
-	0x9e, 0xd3, 0x8c, 0x13, 0xee, 0xbb, 0xff, 0x15, 0x1b, 0x2d, 0xd1, 0x70, 0x01, 0x16, 0xfc, 0x6a,
-	0xc1, 0x95, 0x35, 0x2a, 0xf5, 0xc5, 0x1b, 0xe5, 0xf2, 0x36, 0x94, 0x08, 0x63, 0x86, 0xc7, 0xbd,
-	0x8b, 0xe3, 0x56, 0xfd, 0xaf, 0xe2, 0xb5, 0xb0, 0xf4, 0x0a, 0xa6, 0xf0, 0xf6, 0x8b, 0xa5, 0x6e,
-	0x45, 0x62, 0x38, 0x41, 0x18, 0xaa, 0x4c, 0xcb, 0xdc, 0xb7, 0x14, 0x29, 0x9f, 0xbf, 0x9c, 0x94,
-	0x73, 0x40, 0x9a, 0x99, 0x25, 0x4e, 0xf0, 0x7b, 0x49, 0x3f, 0xad, 0x87, 0x59, 0x94, 0xf3, 0x09,
-	0x95, 0x1c, 0xc6, 0x84, 0xa1, 0xab, 0x60, 0x1b, 0x22, 0x6a, 0x2d, 0x77, 0x31, 0xdf, 0xb1, 0x7b,
-	0x1d, 0x6c, 0x27, 0x31, 0x7a, 0x60, 0x3a, 0xd2, 0x56, 0x1d, 0xf9, 0xe5, 0xcb, 0xff, 0x7e, 0x1d,
-	0x37, 0x2c, 0xc4, 0x95, 0x06, 0x7d, 0x04, 0x55, 0x2e, 0x58, 0x24, 0xc8, 0xf8, 0xd4, 0x34, 0xfa,
-	0x17, 0xaf, 0x0f, 0x6b, 0x00, 0xf0, 0x12, 0x0a, 0x0d, 0x60, 0x5b, 0x5a, 0xf7, 0xcd, 0x7b, 0xed,
-	0x9b, 0x8c, 0x4d, 0x55, 0x5e, 0x9b, 0x38, 0x8c, 0xd8, 0x8b, 0x0d, 0xd8, 0x91, 0x4d, 0x23, 0xa7,
-	0x4e, 0xd1, 0x00, 0x1f, 0xbc, 0xca, 0x88, 0x3a, 0xeb, 0x24, 0xe5, 0x1a, 0xdc, 0x80, 0xc6, 0x2a,
-	0x2d, 0xa8, 0x01, 0x55, 0xdc, 0x6d, 0xdf, 0x7f, 0xdc, 0xc5, 0xdf, 0x7b, 0x1b, 0xa8, 0x0e, 0x95,
-	0x56, 0xf3, 0xb0, 0x79, 0xd4, 0xee, 0x7a, 0x56, 0xe0, 0x43, 0xb5, 0x48, 0x55, 0x9a, 0xdd, 0x7d,
-	0xdc, 0x6f, 0x35, 0x4f, 0xda, 0x77, 0xbc, 0x8d, 0xe0, 0x17, 0xf3, 0xae, 0x0b, 0xa4, 0x22, 0xc4,
-	0x3b, 0xe0, 0x4e, 0x14, 0x47, 0xaa, 0x98, 0xaf, 0x94, 0xf8, 0x3a, 0xb7, 0xd8, 0xf8, 0x23, 0x0f,
-	0x4a, 0x9c, 0x3c, 0x31, 0xfb, 0x43, 0x1e, 0xd1, 0x36, 0x94, 0x47, 0x49, 0x16, 0xa5, 0xaa, 0x6c,
-	0x55, 0xac, 0x85, 0xe5, 0x5a, 0x71, 0xce, 0xd6, 0x4a, 0xf0, 0x87, 0x05, 0xdb, 0xeb, 0xd1, 0x99,
-	0xb6, 0x7b, 0x00, 0x2e, 0x17, 0x91, 0x98, 0x71, 0x15, 0xde, 0x6b, 0x95, 0xbe, 0xc0, 0x08, 0x1f,
-	0x2a, 0x00, 0x6c, 0x80, 0xe4, 0x58, 0x2f, 0x26, 0x99, 0xde, 0x25, 0x85, 0x18, 0xf4, 0xc0, 0xd5,
-	0xb6, 0x92, 0xd4, 0x47, 0x47, 0x77, 0x8f, 0xee, 0x7f, 0x77, 0xe4, 0x6d, 0x48, 0x22, 0x9b, 0xed,
-	0x76, 0xf7, 0xf8, 0xa4, 0xdb, 0xf1, 0x2c, 0x79, 0xd5, 0x3c, 0x3e, 0x3e, 0xec, 0x75, 0x3b, 0x9e,
-	0x8d, 0x6a, 0x50, 0xee, 0x62, 0x7c, 0x1f, 0x7b, 0x25, 0x69, 0xd5, 0xe9, 0xb6, 0x0f, 0x7b, 0x47,
-	0xdd, 0x8e, 0xe7, 0x04, 0x7f, 0xd9, 0xe0, 0x1c, 0x93, 0xff, 0xb3, 0xdb, 0xb6, 0xa1, 0x3c, 0x95,
-	0x5d, 0x6d, 0xe6, 0x86, 0x16, 0xa4, 0x65, 0x56, 0x2c, 0x5f, 0x07, 0xab, 0xb3, 0xfc, 0x38, 0x50,
-	0x2f, 0x59, 0xe6, 0x47, 0xd4, 0x7e, 0xa8, 0xe1, 0x9a, 0xd4, 0xc8, 0x64, 0x88, 0x5c, 0x1d, 0x79,
-	0x34, 0xe3, 0x24, 0xf6, 0x5d, 0x55, 0x06, 0x23, 0xa1, 0x1b, 0xe0, 0xe5, 0x24, 0x8b, 0x93, 0x6c,
-	0xdc, 0xe7, 0x86, 0x32, 0xbf, 0xa2, 0x60, 0x2f, 0x19, 0x7d, 0xc1, 0x24, 0xda, 0x83, 0x4d, 0x46,
-	0x86, 0x24, 0x13, 0xfd, 0x68, 0x28, 0x92, 0xa7, 0xc4, 0xaf, 0x2a, 0xa4, 0x86, 0x56, 0x36, 0x95,
-	0x4e, 0x86, 0x91, 0xf0, 0x7e, 0xb1, 0x4b, 0x6b, 0xca, 0xa2, 0x96, 0xf0, 0x43, 0xb3, 0x4d, 0xf7,
-	0x60, 0x33, 0xc9, 0x46, 0x69, 0x32, 0x9e, 0x88, 0xfe, 0x68, 0x96, 0xa6, 0x3e, 0x68, 0x8c, 0x42,
-	0xf9, 0xf5, 0x2c, 0x4d, 0xd1, 0x87, 0xb0, 0xb5, 0x34, 0x1a, 0xd2, 0x59, 0x26, 0xfc, 0xfa, 0xae,
-	0xb5, 0x5f, 0xc2, 0x4b, 0xd7, 0xb6, 0x54, 0x06, 0xcf, 0x6d, 0x70, 0x64, 0x72, 0x2b, 0x63, 0xc8,
-	0x59, 0x1b, 0x43, 0x2b, 0xac, 0xdb, 0x17, 0xb1, 0xae, 0xf6, 0x6c, 0x69, 0x65, 0xcf, 0x22, 0x70,
-	0x9e, 0x52, 0x41, 0x0a, 0x7e, 0xe5, 0xf9, 0xdf, 0x76, 0xaf, 0xd4, 0xa7, 0xba, 0x75, 0x5c, 0xad,
-	0xd7, 0xd2, 0xb9, 0x7a, 0x54, 0xce, 0xd7, 0xc3, 0x87, 0x4a, 0x94, 0xe7, 0x69, 0x42, 0x62, 0x45,
-	0xa3, 0x83, 0x0b, 0x11, 0xbd, 0x0f, 0x0d, 0xe5, 0x58, 0x5c, 0xd7, 0xd4, 0x75, 0x5d, 0xea, 0x9a,
-	0xc6, 0xe4, 0x63, 0xb8, 0x74, 0x48, 0xa2, 0xb8, 0x2f, 0x58, 0x94, 0xf1, 0x11, 0x61, 0x84, 0x28,
-	0x1e, 0x1d, 0xbc, 0x25, 0xd5, 0x27, 0x4b, 0x2d, 0xfa, 0x0a, 0xca, 0x39, 0x91, 0x83, 0xa7, 0xae,
-	0x06, 0x4f, 0x70, 0x71, 0xdf, 0xc8, 0xa7, 0x6a, 0xc6, 0x8e, 0x76, 0xfb, 0xe4, 0x16, 0x78, 0xe7,
-	0x3f, 0x98, 0xd0, 0x26, 0xd4, 0x9a, 0x71, 0xac, 0xd5, 0xde, 0x06, 0xf2, 0xa0, 0x81, 0xc9, 0x94,
-	0x3e, 0x25, 0x46, 0x63, 0xb5, 0xde, 0x7a, 0xb6, 0xb8, 0x6e, 0xfd, 0xb9, 0xb8, 0x6e, 0x3d, 0x5f,
-	0x5c, 0xb7, 0x7e, 0xa8, 0x84, 0x07, 0xb7, 0x25, 0xfa, 0xc0, 0x55, 0x5f, 0xb3, 0xb7, 0xfe, 0x09,
-	0x00, 0x00, 0xff, 0xff, 0xfc, 0x33, 0x5e, 0x35, 0x22, 0x0b, 0x00, 0x00,
+	// 1206 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0xce, 0xae, 0xd7, 0x6b, 0xfb, 0xd8, 0x49, 0xb7, 0xd3, 0x50, 0x56, 0x05, 0x9a, 0xb0, 0xe5,
+	0x27, 0x05, 0xb1, 0x41, 0xed, 0x15, 0xaa, 0x40, 0xf2, 0x1f, 0xd4, 0x34, 0x4d, 0xd3, 0x69, 0x5a,
+	0x04, 0x37, 0xd6, 0xda, 0x3b, 0xb6, 0x57, 0x5a, 0xef, 0x6c, 0x67, 0xc6, 0x11, 0x79, 0x08, 0x9e,
+	0x00, 0x89, 0xf7, 0x40, 0xe2, 0x16, 0xa9, 0x57, 0x88, 0x7b, 0x24, 0xab, 0xf2, 0x25, 0x4f, 0x81,
+	0xe6, 0x67, 0x1d, 0x3b, 0x45, 0x69, 0x0b, 0xbd, 0xb1, 0xe6, 0x7c, 0x73, 0xe6, 0xdb, 0x39, 0xdf,
+	0x99, 0x73, 0x8e, 0x01, 0x58, 0x34, 0x12, 0x61, 0xce, 0xa8, 0xa0, 0xe8, 0xdd, 0xe1, 0x6c, 0x40,
+	0x46, 0x3c, 0x1c, 0xa4, 0x74, 0xc0, 0x05, 0x65, 0x24, 0x1c, 0xd2, 0xe9, 0x94, 0x66, 0xa1, 0xf4,
+	0xb9, 0x76, 0x59, 0xfe, 0xe6, 0x83, 0xfd, 0xb3, 0x03, 0xd7, 0xb6, 0xc7, 0x74, 0x4c, 0xd5, 0x72,
+	0x5f, 0xae, 0x34, 0x1a, 0xb4, 0xa1, 0xdc, 0x65, 0x8c, 0x32, 0xf4, 0x1e, 0x00, 0x91, 0x8b, 0xfe,
+	0x90, 0xc6, 0xc4, 0xb7, 0x76, 0xad, 0xbd, 0x4d, 0x5c, 0x53, 0x48, 0x9b, 0xc6, 0x04, 0xbd, 0x03,
+	0xda, 0xe8, 0x4f, 0xf9, 0xd8, 0xb7, 0x77, 0xad, 0xbd, 0x1a, 0xae, 0x2a, 0xe0, 0x3e, 0x1f, 0x07,
+	0xbf, 0x59, 0xd0, 0x38, 0x62, 0x34, 0xa7, 0x3c, 0x4a, 0x3b, 0x91, 0x88, 0xd0, 0x55, 0x70, 0xa7,
+	0x34, 0x9e, 0xa5, 0x9a, 0xa8, 0x81, 0x8d, 0x85, 0xb6, 0xc0, 0xa6, 0xb9, 0x3a, 0xbe, 0x89, 0x6d,
+	0x9a, 0x23, 0x04, 0x4e, 0x1c, 0x89, 0xc8, 0x2f, 0x29, 0x2f, 0xb5, 0x46, 0x3e, 0x54, 0x86, 0x34,
+	0x13, 0xe4, 0x47, 0xe1, 0x3b, 0x0a, 0x2e, 0x4c, 0x14, 0x42, 0x2d, 0xa3, 0x22, 0x19, 0x9d, 0xf6,
+	0x93, 0xd8, 0x2f, 0xef, 0x5a, 0x7b, 0x4e, 0xeb, 0xf2, 0x62, 0xbe, 0x53, 0xd5, 0x60, 0xaf, 0xf3,
+	0xf7, 0x7c, 0xc7, 0xfa, 0x0c, 0x17, 0x66, 0x2c, 0xd9, 0xf9, 0x69, 0x36, 0xf4, 0xdd, 0x5d, 0x6b,
+	0xaf, 0x8a, 0xd5, 0x5a, 0x62, 0x82, 0xb0, 0xa9, 0x5f, 0x91, 0xc7, 0xb1, 0x5a, 0x07, 0xbf, 0x5a,
+	0xe0, 0xde, 0x27, 0xd3, 0x01, 0x61, 0xe8, 0x06, 0x54, 0x32, 0x1a, 0x13, 0xf9, 0x01, 0x4b, 0x7d,
+	0x00, 0x16, 0xf3, 0x1d, 0xf7, 0x90, 0xc6, 0xa4, 0xd7, 0xc1, 0xae, 0xdc, 0xd2, 0xbc, 0x13, 0xca,
+	0x85, 0x91, 0x41, 0xad, 0x51, 0x0b, 0x1c, 0x71, 0x9a, 0x13, 0x15, 0xc9, 0xd6, 0xad, 0x30, 0xbc,
+	0x28, 0x3b, 0xa1, 0xfe, 0x58, 0x7b, 0x12, 0x65, 0x63, 0x72, 0x7c, 0x9a, 0x13, 0xac, 0xce, 0xca,
+	0xc8, 0x53, 0x12, 0xb1, 0x8c, 0x30, 0x15, 0x79, 0x15, 0x17, 0xe6, 0xaa, 0x26, 0xe5, 0x35, 0x4d,
+	0x82, 0x9f, 0x2c, 0xd8, 0xc4, 0xd1, 0x48, 0xdc, 0x25, 0x11, 0x13, 0x03, 0x12, 0x09, 0xf4, 0x11,
+	0x54, 0xc7, 0x8c, 0xce, 0xf2, 0xb3, 0x18, 0xea, 0x8b, 0xf9, 0x4e, 0xe5, 0x1b, 0x89, 0xf5, 0x3a,
+	0xb8, 0xa2, 0x36, 0x75, 0x14, 0x23, 0x46, 0xa7, 0x2a, 0x0a, 0x07, 0xab, 0xb5, 0xcc, 0x8f, 0xa0,
+	0x2a, 0x06, 0x07, 0xdb, 0x82, 0x2e, 0xd5, 0x72, 0xce, 0xd4, 0x92, 0xb9, 0x95, 0xb1, 0x24, 0xfa,
+	0x2a, 0x0e, 0x36, 0xd6, 0xb7, 0x4e, 0xd5, 0xf5, 0x2a, 0xc1, 0x33, 0x1b, 0x90, 0xbc, 0xcf, 0x7d,
+	0xc2, 0x79, 0x34, 0x26, 0x98, 0x3c, 0x9d, 0x11, 0xfe, 0x66, 0x2f, 0xb5, 0x0f, 0x95, 0xa9, 0x66,
+	0x57, 0xf7, 0xaa, 0xdf, 0xba, 0x14, 0xea, 0xd7, 0x1e, 0x9a, 0x8f, 0xb6, 0x9c, 0x67, 0xf3, 0x9d,
+	0x0d, 0x5c, 0x78, 0xa1, 0x87, 0x00, 0x93, 0x42, 0x1e, 0xee, 0x97, 0x77, 0x4b, 0x7b, 0xf5, 0x5b,
+	0x9f, 0x5e, 0x9c, 0xa1, 0x35, 0x49, 0x0d, 0xdf, 0x0a, 0x09, 0x1a, 0xc0, 0x95, 0xa5, 0xd5, 0x67,
+	0x84, 0xe7, 0x34, 0xe3, 0x84, 0xfb, 0xee, 0x7f, 0xe5, 0x46, 0x4b, 0x36, 0x5c, 0x90, 0x05, 0xbf,
+	0x58, 0x70, 0x65, 0x4d, 0x4a, 0xbd, 0xf1, 0x46, 0xb5, 0xbc, 0x03, 0x25, 0xc2, 0x98, 0xd1, 0xf1,
+	0xc6, 0xc5, 0xf7, 0x56, 0x7d, 0x42, 0xdd, 0xd7, 0xc2, 0xf2, 0x54, 0x30, 0x85, 0xb7, 0x5f, 0x4c,
+	0x75, 0x2b, 0x12, 0xc3, 0x09, 0xc2, 0x50, 0x65, 0xda, 0xe6, 0xbe, 0xa5, 0x44, 0xf9, 0xfc, 0xe5,
+	0xa2, 0x9c, 0x23, 0xd2, 0xca, 0x2c, 0x79, 0x82, 0xdf, 0x4b, 0xfa, 0x69, 0x3d, 0xca, 0xa2, 0x9c,
+	0x4f, 0xa8, 0xd4, 0x30, 0x26, 0x0c, 0x5d, 0x05, 0xdb, 0x08, 0x51, 0x6b, 0xb9, 0x8b, 0xf9, 0x8e,
+	0xdd, 0xeb, 0x60, 0x3b, 0x89, 0xd1, 0x43, 0x53, 0x91, 0xb6, 0xaa, 0xc8, 0x2f, 0x5f, 0xfe, 0xf9,
+	0x75, 0xde, 0xb0, 0x30, 0x57, 0x0a, 0xf4, 0x31, 0x54, 0xb9, 0x60, 0x91, 0x20, 0xe3, 0x53, 0x53,
+	0xe8, 0x5f, 0xbc, 0x3e, 0xad, 0x21, 0xc0, 0x4b, 0x2a, 0x34, 0x80, 0x6d, 0xe9, 0xdd, 0x37, 0xef,
+	0xb5, 0x6f, 0x22, 0x36, 0x59, 0x79, 0x6d, 0xe1, 0x30, 0x62, 0x2f, 0x60, 0xa8, 0x23, 0x8b, 0x46,
+	0x76, 0x9d, 0xa2, 0x00, 0x3e, 0x78, 0x95, 0x16, 0x75, 0x56, 0x49, 0xea, 0x68, 0x70, 0x13, 0x1a,
+	0xab, 0xb2, 0xa0, 0x06, 0x54, 0x71, 0xb7, 0xfd, 0xe0, 0x49, 0x17, 0x7f, 0xef, 0x6d, 0xa0, 0x3a,
+	0x54, 0x5a, 0xcd, 0x83, 0xe6, 0x61, 0xbb, 0xeb, 0x59, 0x81, 0x0f, 0xd5, 0x22, 0x54, 0xe9, 0x76,
+	0xef, 0x49, 0xbf, 0xd5, 0x3c, 0x6e, 0xdf, 0xf5, 0x36, 0x82, 0x9f, 0xcd, 0xbb, 0x2e, 0x98, 0x8a,
+	0x2b, 0xde, 0x05, 0x77, 0xa2, 0x34, 0xf2, 0xad, 0x57, 0x0d, 0x7c, 0x5d, 0x5b, 0x6c, 0xce, 0x23,
+	0x0f, 0x4a, 0x9c, 0x3c, 0x35, 0x73, 0x46, 0x2e, 0xd1, 0x36, 0x94, 0x47, 0x49, 0x16, 0xa5, 0x2a,
+	0x6d, 0x55, 0xac, 0x8d, 0xe5, 0xf8, 0x71, 0xce, 0xc6, 0x4f, 0xf0, 0x87, 0x05, 0xdb, 0xeb, 0xb7,
+	0x33, 0x65, 0xf7, 0x10, 0x5c, 0x2e, 0x22, 0x31, 0xe3, 0xbe, 0xf5, 0xba, 0xa9, 0x2f, 0x38, 0xc2,
+	0x47, 0x8a, 0x00, 0x1b, 0x22, 0xd9, 0xd6, 0x8b, 0x4e, 0xa6, 0x67, 0x49, 0x61, 0x06, 0x3d, 0x70,
+	0xb5, 0xaf, 0x14, 0xf5, 0xf1, 0xe1, 0xbd, 0xc3, 0x07, 0xdf, 0x1d, 0x7a, 0x1b, 0x52, 0xc8, 0x66,
+	0xbb, 0xdd, 0x3d, 0x3a, 0xee, 0x76, 0x3c, 0x4b, 0x6e, 0x35, 0x8f, 0x8e, 0x0e, 0x7a, 0xdd, 0x8e,
+	0x67, 0xa3, 0x1a, 0x94, 0xbb, 0x18, 0x3f, 0xc0, 0x5e, 0x49, 0x7a, 0x75, 0xba, 0xed, 0x83, 0xde,
+	0x61, 0xb7, 0xe3, 0x39, 0xc1, 0x5f, 0x36, 0x38, 0x47, 0xe4, 0xff, 0xcc, 0xb6, 0x6d, 0x28, 0x4f,
+	0x65, 0x55, 0x9b, 0xbe, 0xa1, 0x0d, 0xe9, 0x99, 0x15, 0x43, 0xda, 0xc1, 0x6a, 0x2d, 0xff, 0x44,
+	0xa8, 0x97, 0x2c, 0xe3, 0x23, 0x6a, 0x3e, 0xd4, 0x70, 0x4d, 0x22, 0x32, 0x18, 0x22, 0x47, 0x47,
+	0x1e, 0xcd, 0x38, 0x89, 0xcd, 0x48, 0x36, 0x16, 0xba, 0x09, 0x5e, 0x4e, 0xb2, 0x38, 0xc9, 0xc6,
+	0x7d, 0x6e, 0x24, 0x33, 0x03, 0xfa, 0x92, 0xc1, 0x0b, 0x25, 0xd1, 0x0d, 0xd8, 0x64, 0x64, 0x48,
+	0x32, 0xd1, 0x8f, 0x86, 0x22, 0x39, 0x21, 0x7e, 0x55, 0x31, 0x35, 0x34, 0xd8, 0x54, 0x98, 0xbc,
+	0x46, 0xc2, 0xfb, 0xc5, 0x2c, 0xad, 0x29, 0x8f, 0x5a, 0xc2, 0x0f, 0x34, 0x20, 0x39, 0x92, 0x6c,
+	0x94, 0x26, 0xe3, 0x89, 0xe8, 0x8f, 0x66, 0x69, 0xea, 0x83, 0xe6, 0x28, 0xc0, 0xaf, 0x67, 0x69,
+	0x8a, 0x3e, 0x84, 0xad, 0xa5, 0xd3, 0x90, 0xce, 0x32, 0xe1, 0xd7, 0x77, 0xad, 0xbd, 0x12, 0x5e,
+	0x1e, 0x6d, 0x4b, 0x30, 0x78, 0x6e, 0x83, 0x23, 0x83, 0x5b, 0x69, 0x43, 0xce, 0x5a, 0x1b, 0x5a,
+	0x51, 0xdd, 0xbe, 0x48, 0x75, 0x35, 0x67, 0x4b, 0x2b, 0x73, 0x16, 0x81, 0x73, 0x42, 0x05, 0x29,
+	0xf4, 0x3d, 0xa1, 0x5a, 0xc0, 0x7f, 0x9b, 0xbd, 0x12, 0x4f, 0x75, 0xe9, 0xb8, 0x1a, 0xd7, 0xd6,
+	0xb9, 0x7c, 0x54, 0xce, 0xe7, 0xc3, 0x87, 0x4a, 0x94, 0xe7, 0x69, 0x42, 0x62, 0x25, 0xa3, 0x83,
+	0x0b, 0x13, 0xbd, 0x0f, 0x0d, 0x75, 0xb0, 0xd8, 0xae, 0xa9, 0xed, 0xba, 0xc4, 0x9a, 0xc6, 0xe5,
+	0x63, 0xb8, 0x74, 0x40, 0xa2, 0xb8, 0x2f, 0x58, 0x94, 0xf1, 0x11, 0x61, 0x84, 0x28, 0x1d, 0x1d,
+	0xbc, 0x25, 0xe1, 0xe3, 0x25, 0x8a, 0xbe, 0x82, 0x72, 0x4e, 0x64, 0xe3, 0xa9, 0xab, 0xc6, 0x13,
+	0x5c, 0x5c, 0x37, 0x47, 0x64, 0xd9, 0x76, 0xf4, 0xb1, 0x4f, 0x6e, 0x83, 0x77, 0xfe, 0x0f, 0x13,
+	0xda, 0x84, 0x5a, 0x33, 0x8e, 0x35, 0xec, 0x6d, 0x20, 0x0f, 0x1a, 0x98, 0x4c, 0xe9, 0x09, 0x31,
+	0x88, 0xd5, 0x7a, 0xeb, 0xd9, 0xe2, 0xba, 0xf5, 0xe7, 0xe2, 0xba, 0xf5, 0x7c, 0x71, 0xdd, 0xfa,
+	0xa1, 0x12, 0xee, 0xdf, 0x91, 0xec, 0x03, 0x57, 0xfd, 0xeb, 0xbd, 0xfd, 0xcf, 0x00, 0xa2, 0x45,
+	0x20, 0xdc, 0x4a, 0x0b, 0x00, 0x00,
 }
 
 func (m *Error) Marshal() (dAtA []byte, err error) {
@@ -1249,6 +1269,21 @@ func (m *ProposalData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Term != 0 {
+		i = encodeVarintRaft(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Sync {
+		i--
+		if m.Sync {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.notifyID != 0 {
 		i = encodeVarintRaft(dAtA, i, uint64(m.notifyID))
 		i--
@@ -1994,6 +2029,12 @@ func (m *ProposalData) Size() (n int) {
 	if m.notifyID != 0 {
 		n += 1 + sovRaft(uint64(m.notifyID))
 	}
+	if m.Sync {
+		n += 2
+	}
+	if m.Term != 0 {
+		n += 1 + sovRaft(uint64(m.Term))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2584,6 +2625,45 @@ func (m *ProposalData) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Sync = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaft(dAtA[iNdEx:])
@@ -733,3 +733,79 @@ func EncodeIndexLogKeyPrefix(id uint64) []byte {
 func EncodeSnapshotMetaKey(id uint64) []byte {
 	return encodeSnapshotMetaKey(id)
 }
+
+// GroupsKeyPrefix returns prefix of keys of all groups
+func GroupsKeyPrefix() []byte {
+	return append([]byte{}, groupPrefix...)
+}
+
+// EncodeGroupKeyPrefix returns prefix of all keys of the group
+func EncodeGroupKeyPrefix(id uint64) []byte {
+	b := make([]byte, 8+len(groupPrefix))
+	copy(b, groupPrefix)
+	binary.BigEndian.PutUint64(b[len(groupPrefix):], id)
+	return b
+}
+
+// DecodeGroupID returns id of the group which the key belongs to
+func DecodeGroupID(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(groupPrefix):])
+}
+
+// IsSyncEntry returns true if the marshaled wal entry is a proposal which requires
+// its wal synced immediately, the entry is scanned without being unmarshaled
+func IsSyncEntry(value []byte) bool {
+	entryType, _, ok := scanProtoField(value, 1)
+	if ok && entryType != uint64(raftpb.EntryNormal) {
+		return false
+	}
+	_, data, ok := scanProtoField(value, 4)
+	if !ok {
+		return false
+	}
+	sync, _, ok := scanProtoField(data, 6)
+	return ok && sync != 0
+}
+
+// scanProtoField returns the value of varint field or the payload of bytes field of the marshaled message
+func scanProtoField(b []byte, field uint64) (uint64, []byte, bool) {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return 0, nil, false
+		}
+		b = b[n:]
+		switch num, wireType := tag>>3, tag&7; wireType {
+		case 0:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return 0, nil, false
+			}
+			if num == field {
+				return v, nil, true
+			}
+			b = b[n:]
+		case 1, 5:
+			size := 8
+			if wireType == 5 {
+				size = 4
+			}
+			if len(b) < size {
+				return 0, nil, false
+			}
+			b = b[size:]
+		case 2:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return 0, nil, false
+			}
+			if num == field {
+				return 0, b[n : n+int(l)], true
+			}
+			b = b[n+int(l):]
+		default:
+			return 0, nil, false
+		}
+	}
+	return 0, nil, false
+}
@@ -325,6 +325,36 @@ func TestStorage_DecodeHardState(t *testing.T) {
 	t.Log("hs: ", hs, err)
 }
 
+func TestStorage_IsSyncEntry(t *testing.T) {
+	newEntry := func(typ raftpb.EntryType, data []byte) []byte {
+		entry := raftpb.Entry{Type: typ, Term: 1, Index: 10, Data: data}
+		value, err := entry.Marshal()
+		require.NoError(t, err)
+		return value
+	}
+	newProposal := func(sync bool) []byte {
+		pd := &ProposalData{Module: []byte("m"), Op: 1, Data: []byte("data"), Context: []byte("ctx"), Sync: sync}
+		pd.notifyID = 100
+		data, err := pd.Marshal()
+		require.NoError(t, err)
+		return data
+	}
+
+	require.True(t, IsSyncEntry(newEntry(raftpb.EntryNormal, newProposal(true))))
+	require.False(t, IsSyncEntry(newEntry(raftpb.EntryNormal, newProposal(false))))
+	require.False(t, IsSyncEntry(newEntry(raftpb.EntryNormal, nil)))
+	require.False(t, IsSyncEntry(newEntry(raftpb.EntryConfChange, newProposal(true))))
+
+	hs := raftpb.HardState{Term: 1, Vote: 2, Commit: 3}
+	value, err := hs.Marshal()
+	require.NoError(t, err)
+	require.False(t, IsSyncEntry(value))
+	// truncated entry
+	value = newEntry(raftpb.EntryNormal, newProposal(true))
+	require.False(t, IsSyncEntry(value[:len(value)-1]))
+	require.False(t, IsSyncEntry(nil))
+}
+
 func TestStorage_GetMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -56,6 +56,15 @@ func NewMemPool(sizeClasses map[int]int) *MemPool {
 	})
 }
 
+// NewNumaMemPool returns a MemPool within chan pool per NUMA node
+func NewNumaMemPool(sizeClasses map[int]int) *MemPool {
+	return NewMemPoolWith(sizeClasses, func(size, capacity int) Pool {
+		return NewNumaChanPool(func() []byte {
+			return make([]byte, size)
+		}, capacity)
+	})
+}
+
 // NewMemPoolWith new MemPool with size-class and self-defined pool
 func NewMemPoolWith(sizeClasses map[int]int, newPool func(size, capacity int) Pool) *MemPool {
 	pool := make([]Pool, 0, len(sizeClasses))
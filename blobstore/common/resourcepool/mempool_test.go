@@ -17,6 +17,7 @@ package resourcepool_test
 import (
 	"crypto/rand"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"runtime"
 	"testing"
@@ -65,6 +66,28 @@ func TestMemPoolBase(t *testing.T) {
 	require.Equal(t, bufm, bufmx)
 }
 
+func TestMemPoolNuma(t *testing.T) {
+	pool := rp.NewNumaMemPool(map[int]int{kb: 2, mb: -1})
+	require.NotNil(t, pool)
+
+	buf, err := pool.Get(kb / 2)
+	require.NoError(t, err)
+	require.Equal(t, kb/2, len(buf))
+	require.Equal(t, kb, cap(buf))
+	require.NoError(t, pool.Put(buf))
+
+	buf, err = pool.Alloc(mb4)
+	require.NoError(t, err)
+	require.Equal(t, mb4, len(buf))
+	require.NoError(t, pool.Put(buf))
+
+	st := pool.Status()
+	require.Equal(t, 2, len(st))
+	require.Equal(t, 2, st[0].Capacity)
+	require.Equal(t, -1, st[1].Capacity)
+	require.Equal(t, 0, st[0].Running)
+}
+
 func TestMemPoolEmpty(t *testing.T) {
 	pool := rp.NewMemPool(nil)
 	require.NotNil(t, pool)
@@ -273,8 +296,11 @@ func TestMemPoolStatus(t *testing.T) {
 	}
 }
 
-func BenchmarkMempool(b *testing.B) {
-	mp := rp.NewMemPool(map[int]int{
+// go test -bench=Mempool -numa, compares NUMA-aware pools with the global pools
+var benchNuma = flag.Bool("numa", false, "benchmark with NUMA-aware memory pools")
+
+func newBenchMempool() *rp.MemPool {
+	sizeClasses := map[int]int{
 		1 << 11: -1,
 		1 << 14: -1,
 		1 << 16: -1,
@@ -282,7 +308,15 @@ func BenchmarkMempool(b *testing.B) {
 		1 << 20: -1,
 		1 << 21: -1,
 		1 << 22: -1,
-	})
+	}
+	if *benchNuma {
+		return rp.NewNumaMemPool(sizeClasses)
+	}
+	return rp.NewMemPool(sizeClasses)
+}
+
+func BenchmarkMempool(b *testing.B) {
+	mp := newBenchMempool()
 
 	for _, size := range []int{
 		1 << 10,
@@ -304,6 +338,30 @@ func BenchmarkMempool(b *testing.B) {
 	}
 }
 
+func BenchmarkMempoolParallel(b *testing.B) {
+	mp := newBenchMempool()
+
+	for _, size := range []int{
+		1 << 10,
+		1 << 16,
+		1 << 20,
+		1 << 22,
+	} {
+		b.ResetTimer()
+		b.Run(humman(size), func(b *testing.B) {
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if buf, err := mp.Get(size); err == nil {
+						buf[0] = 1
+						_ = mp.Put(buf)
+					}
+				}
+			})
+		})
+	}
+}
+
 func BenchmarkZero(b *testing.B) {
 	funcZero := func(b, zero []byte) {
 		for len(b) > 0 {
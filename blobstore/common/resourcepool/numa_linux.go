@@ -0,0 +1,46 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const onlineNodesFile = "/sys/devices/system/node/online"
+
+func readOnlineNodes() string {
+	data, err := os.ReadFile(onlineNodesFile)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// numaNode returns NUMA node of cpu which current thread is running on.
+func numaNode() int {
+	var cpu, node uint32
+	_, _, errno := unix.RawSyscall(unix.SYS_GETCPU,
+		uintptr(unsafe.Pointer(&cpu)), uintptr(unsafe.Pointer(&node)), 0)
+	if errno != 0 {
+		return 0
+	}
+	return int(node)
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resourcepool
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// numaPool has sub-pool per NUMA node, goroutine gets and puts buffers
+// with the sub-pool of NUMA node which it's running on, avoids cross-socket
+// memory traffic of buffers on multi-sockets machine.
+type numaPool struct {
+	pools    []Pool
+	capacity int
+}
+
+// NewNumaChanPool return Pool within chan pool per NUMA node,
+// capacity is divided equally by nodes, no limit if capacity is negative.
+// It's the same as NewChanPool on single node machine.
+func NewNumaChanPool(newFunc func() []byte, capacity int) Pool {
+	nodes := numaNodes()
+	if nodes <= 1 {
+		return NewChanPool(newFunc, capacity)
+	}
+	return newNumaPool(nodes, capacity, func(capacity int) Pool {
+		return NewChanPool(newFunc, capacity)
+	})
+}
+
+func newNumaPool(nodes, capacity int, newPool func(capacity int) Pool) *numaPool {
+	nodeCapacity := capacity
+	if capacity > 0 {
+		nodeCapacity = (capacity + nodes - 1) / nodes
+	}
+	pools := make([]Pool, nodes)
+	for idx := range pools {
+		pools[idx] = newPool(nodeCapacity)
+	}
+	return &numaPool{pools: pools, capacity: capacity}
+}
+
+func (p *numaPool) local() Pool {
+	return p.pools[numaNode()%len(p.pools)]
+}
+
+func (p *numaPool) Get() (interface{}, error) {
+	return p.local().Get()
+}
+
+// Put adds x to the sub-pool of current node, buffer may be moved to
+// another node if the goroutine was rescheduled, it's acceptable.
+func (p *numaPool) Put(x interface{}) {
+	p.local().Put(x)
+}
+
+func (p *numaPool) Cap() int {
+	return p.capacity
+}
+
+func (p *numaPool) Len() (n int) {
+	for _, pool := range p.pools {
+		n += pool.Len()
+	}
+	return
+}
+
+func (p *numaPool) Idle() (n int) {
+	for _, pool := range p.pools {
+		n += pool.Idle()
+	}
+	return
+}
+
+var numaNodesCached int32
+
+// numaNodes returns count of NUMA nodes, at least 1.
+func numaNodes() int {
+	if n := atomic.LoadInt32(&numaNodesCached); n > 0 {
+		return int(n)
+	}
+	n := int32(parseNodeList(readOnlineNodes()))
+	atomic.StoreInt32(&numaNodesCached, n)
+	return int(n)
+}
+
+// parseNodeList returns max node id + 1 of node list, like "0-1,3",
+// returns 1 if the list is invalid.
+func parseNodeList(list string) int {
+	maxID := -1
+	for _, part := range strings.Split(strings.TrimSpace(list), ",") {
+		if part == "" {
+			continue
+		}
+		if idx := strings.LastIndexByte(part, '-'); idx >= 0 {
+			part = part[idx+1:]
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id < 0 {
+			return 1
+		}
+		if id > maxID {
+			maxID = id
+		}
+	}
+	if maxID < 0 {
+		return 1
+	}
+	return maxID + 1
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resourcepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumaPoolParseNodeList(t *testing.T) {
+	for _, cs := range []struct {
+		list  string
+		nodes int
+	}{
+		{"", 1},
+		{"\n", 1},
+		{"0\n", 1},
+		{"0-1\n", 2},
+		{"0-1,3", 4},
+		{"0,2-3", 4},
+		{"x", 1},
+		{"0-x", 1},
+	} {
+		require.Equal(t, cs.nodes, parseNodeList(cs.list), cs.list)
+	}
+	require.LessOrEqual(t, 1, numaNodes())
+	require.LessOrEqual(t, 0, numaNode())
+}
+
+func TestNumaPoolBase(t *testing.T) {
+	newPool := func(capacity int) Pool {
+		return NewPool(func() interface{} { return make([]byte, 1024) }, capacity)
+	}
+	{
+		p := newNumaPool(4, 10, newPool)
+		require.Equal(t, 10, p.Cap())
+		for _, pool := range p.pools {
+			require.Equal(t, 3, pool.Cap())
+		}
+		require.Equal(t, 12, p.Idle())
+
+		buf, err := p.Get()
+		require.NoError(t, err)
+		require.Equal(t, 1024, len(buf.([]byte)))
+		require.Equal(t, 1, p.Len())
+		require.Equal(t, 11, p.Idle())
+		p.Put(buf)
+		require.Equal(t, 0, p.Len())
+	}
+	{
+		p := newNumaPool(2, -1, newPool)
+		require.Equal(t, -1, p.Cap())
+		for _, pool := range p.pools {
+			require.Equal(t, -1, pool.Cap())
+		}
+		buf, err := p.Get()
+		require.NoError(t, err)
+		p.Put(buf)
+	}
+	{
+		p := NewNumaChanPool(func() []byte { return make([]byte, 1024) }, 100)
+		require.Equal(t, 100, p.Cap())
+		buf, err := p.Get()
+		require.NoError(t, err)
+		p.Put(buf)
+		require.Equal(t, 0, p.Len())
+	}
+}
@@ -15,29 +15,111 @@
 package auth
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/rpc/auth/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc2"
+	"github.com/cubefs/cubefs/blobstore/common/security"
 )
 
+var errBodyDigest = errors.New("mismatch body digest")
+
 type handler struct {
-	Secret []byte
+	Secret      []byte
+	ServiceAuth *security.ServiceAuth
 }
 
-func New(cfg *proto.Config) interface {
+// New returns auth handler, the service auth of process is shared if set,
+// so that keys rotated online take effect on servers and clients together.
+func New(cfg *proto.Config) (interface {
 	rpc2.Interceptor
 	rpc.ProgressHandler
-} {
-	if !cfg.EnableAuth || cfg.Secret == "" {
-		panic("auth secret can not be empty")
+}, error,
+) {
+	if !cfg.Enabled() {
+		return nil, errors.New("auth secret can not be empty")
+	}
+	h := &handler{Secret: []byte(cfg.Secret)}
+	if cfg.ServiceAuth != nil {
+		svcAuth, err := proto.ServiceAuthOf(cfg.ServiceAuth)
+		if err != nil {
+			return nil, err
+		}
+		h.ServiceAuth = svcAuth
+	}
+	if cfg.TLS != nil && h.ServiceAuth == nil {
+		return nil, errors.New("auth tls identity requires service auth")
+	}
+	return h, nil
+}
+
+func (h *handler) authenticate(token string, r security.ServiceRequest) error {
+	if h.ServiceAuth != nil && security.IsServiceToken(token) {
+		return h.ServiceAuth.Authenticate(token, r)
+	}
+	if len(h.Secret) == 0 {
+		return security.ErrServiceUnauthenticated
+	}
+	if err := proto.Decode(token, []byte(r.Path+r.Query), h.Secret); err != nil {
+		return err
+	}
+	if h.ServiceAuth != nil {
+		return h.ServiceAuth.Authorize(security.LegacyService, r.Path)
+	}
+	return nil
+}
+
+// authenticatePeer authorizes common name of the verified client certificate
+func (h *handler) authenticatePeer(req *http.Request) error {
+	if h.ServiceAuth == nil || req.TLS == nil || len(req.TLS.VerifiedChains) == 0 ||
+		len(req.TLS.VerifiedChains[0]) == 0 {
+		return security.ErrServiceUnauthenticated
+	}
+	service := req.TLS.VerifiedChains[0][0].Subject.CommonName
+	if service == "" || service == security.LegacyService {
+		return security.ErrServiceUnauthenticated
+	}
+	return h.ServiceAuth.Authorize(service, req.URL.Path)
+}
+
+// verifyBody checks the signed digest of body, and restores the read body
+func verifyBody(req *http.Request, digest string) error {
+	if digest == security.UnsignedPayload {
+		return nil
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		if digest != security.BodyDigest(nil) {
+			return errBodyDigest
+		}
+		return nil
+	}
+	b, err := io.ReadAll(io.LimitReader(req.Body, proto.MaxSignedBodySize+1))
+	if err != nil {
+		return err
 	}
-	return &handler{Secret: []byte(cfg.Secret)}
+	if len(b) > proto.MaxSignedBodySize || digest != security.BodyDigest(b) {
+		return errBodyDigest
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return nil
 }
 
 func (h *handler) Handler(w http.ResponseWriter, req *http.Request, f func(http.ResponseWriter, *http.Request)) {
-	if err := proto.Decode(req.Header.Get(proto.TokenHeaderKey), proto.ParamFromRequest(req), h.Secret); err != nil {
+	token := req.Header.Get(proto.TokenHeaderKey)
+	var err error
+	if token == "" {
+		err = h.authenticatePeer(req)
+	} else {
+		r := proto.ServiceRequestOf(req)
+		if err = h.authenticate(token, r); err == nil && security.IsServiceToken(token) {
+			err = verifyBody(req, r.BodyDigest)
+		}
+	}
+	if err != nil {
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
@@ -45,7 +127,12 @@ func (h *handler) Handler(w http.ResponseWriter, req *http.Request, f func(http.
 }
 
 func (h *handler) Handle(w rpc2.ResponseWriter, req *rpc2.Request, f rpc2.Handle) error {
-	if err := proto.Decode(req.Header.Get(proto.TokenHeaderKey), []byte(req.RemotePath), h.Secret); err != nil {
+	token := req.Header.Get(proto.TokenHeaderKey)
+	if err := h.authenticate(token, security.ServiceRequest{
+		Method:     proto.Rpc2Method,
+		Path:       req.RemotePath,
+		BodyDigest: security.BodyDigest(req.Parameter),
+	}); err != nil {
 		return rpc2.NewError(http.StatusForbidden, "Auth", err.Error())
 	}
 	return f(w, req)
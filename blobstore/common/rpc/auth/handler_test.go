@@ -15,9 +15,22 @@
 package auth
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,7 +38,9 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/rpc/auth/proto"
+	auth_transport "github.com/cubefs/cubefs/blobstore/common/rpc/auth/transport"
 	"github.com/cubefs/cubefs/blobstore/common/rpc2"
+	"github.com/cubefs/cubefs/blobstore/common/security"
 )
 
 var (
@@ -38,7 +53,11 @@ var (
 )
 
 func init() {
-	authHandler = New(&proto.Config{EnableAuth: true, Secret: testSecret})
+	var err error
+	authHandler, err = New(&proto.Config{EnableAuth: true, Secret: testSecret})
+	if err != nil {
+		panic(err)
+	}
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		authHandler.Handler(w, r, func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(200)
@@ -48,8 +67,12 @@ func init() {
 }
 
 func TestHandlerRpc(t *testing.T) {
-	require.Panics(t, func() { New(&proto.Config{EnableAuth: false, Secret: testSecret}) })
-	require.Panics(t, func() { New(&proto.Config{EnableAuth: true, Secret: ""}) })
+	_, err := New(&proto.Config{EnableAuth: false, Secret: testSecret})
+	require.Error(t, err)
+	_, err = New(&proto.Config{EnableAuth: true, Secret: ""})
+	require.Error(t, err)
+	_, err = New(&proto.Config{EnableAuth: true, Secret: testSecret, TLS: &proto.TLSConfig{}})
+	require.Error(t, err)
 
 	client := http.Client{}
 	req, _ := http.NewRequest("POST", testServer.URL+"/", nil)
@@ -81,3 +104,206 @@ func TestHandlerRpc2(t *testing.T) {
 	err = authHandler.Handle(nil, req, func(rpc2.ResponseWriter, *rpc2.Request) error { return nil })
 	require.Equal(t, http.StatusOK, rpc.DetectStatusCode(err))
 }
+
+func TestHandlerServiceAuth(t *testing.T) {
+	keys := map[string][]security.ServiceKey{"access": {{ID: "k1", Secret: "access-secret"}}}
+	cfg := &proto.Config{EnableAuth: true, ServiceAuth: &security.ServiceAuthConfig{
+		Keys:     keys,
+		Policies: []security.MethodPolicy{{Prefix: "/path", Services: []string{"access"}}},
+	}}
+	h, err := New(cfg)
+	require.NoError(t, err)
+	ok := func(rpc2.ResponseWriter, *rpc2.Request) error { return nil }
+
+	access, err := security.NewServiceAuth(security.ServiceAuthConfig{Service: "access", Keys: keys})
+	require.NoError(t, err)
+	sign := func(req *rpc2.Request) {
+		token, err := access.Sign(security.ServiceRequest{
+			Method:     proto.Rpc2Method,
+			Path:       req.RemotePath,
+			BodyDigest: security.BodyDigest(req.Parameter),
+		})
+		require.NoError(t, err)
+		req.Header.Set(proto.TokenHeaderKey, token)
+	}
+	req, _ := rpc2.NewRequest(context.Background(), "/", "/path", nil, nil)
+	sign(req)
+	require.Equal(t, http.StatusOK, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+	// replayed
+	require.Equal(t, http.StatusForbidden, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+
+	// parameter is signed
+	sign(req)
+	req.Parameter = []byte("modified")
+	require.Equal(t, http.StatusForbidden, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+
+	// not allowed by policy
+	req, _ = rpc2.NewRequest(context.Background(), "/", "/other", nil, nil)
+	sign(req)
+	require.Equal(t, http.StatusForbidden, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+
+	// shared secret is not configured
+	req, _ = rpc2.NewRequest(context.Background(), "/", "/path", nil, nil)
+	req.Header.Set(proto.TokenHeaderKey, proto.Encode(time.Now().Unix(), []byte(req.RemotePath), []byte(testSecret)))
+	require.Equal(t, http.StatusForbidden, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+
+	// shared secret is authorized as legacy service
+	cfg.Secret = testSecret
+	h, err = New(cfg)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+	cfg.ServiceAuth.Policies[0].Services = append(cfg.ServiceAuth.Policies[0].Services, security.LegacyService)
+	h, err = New(cfg)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+
+	signer, err := proto.NewSigner(&proto.Config{EnableAuth: true, ServiceAuth: &security.ServiceAuthConfig{
+		Service: "access", Keys: keys,
+	}})
+	require.NoError(t, err)
+	token, err := signer(security.ServiceRequest{
+		Method: proto.Rpc2Method, Path: req.RemotePath, BodyDigest: security.BodyDigest(nil),
+	})
+	require.NoError(t, err)
+	req.Header.Set(proto.TokenHeaderKey, token)
+	require.Equal(t, http.StatusOK, rpc.DetectStatusCode(h.Handle(nil, req, ok)))
+}
+
+func TestHandlerServiceAuthHTTP(t *testing.T) {
+	keys := map[string][]security.ServiceKey{"access": {{ID: "k1", Secret: "access-secret"}}}
+	h, err := New(&proto.Config{EnableAuth: true, ServiceAuth: &security.ServiceAuthConfig{
+		Keys: keys, DefaultAllow: true,
+	}})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.Handler(w, r, func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			w.Write(b)
+		})
+	}))
+	defer server.Close()
+
+	client := http.Client{Transport: auth_transport.New(&http.Transport{}, &proto.Config{
+		EnableAuth: true, ServiceAuth: &security.ServiceAuthConfig{Service: "access", Keys: keys},
+	})}
+	resp, err := client.Post(server.URL+"/blob?id=1", "", bytes.NewReader([]byte("body")))
+	require.NoError(t, err)
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "body", string(b))
+
+	// modified body, path, query or method
+	access, err := security.NewServiceAuth(security.ServiceAuthConfig{Service: "access", Keys: keys})
+	require.NoError(t, err)
+	r := security.ServiceRequest{
+		Method: http.MethodPost, Path: "/blob", Query: "id=1", BodyDigest: security.BodyDigest([]byte("body")),
+	}
+	for _, c := range []struct {
+		method, url, body string
+		code              int
+	}{
+		{http.MethodPost, "/blob?id=1", "body", http.StatusOK},
+		{http.MethodPost, "/blob?id=1", "other", http.StatusForbidden},
+		{http.MethodPost, "/blob?id=2", "body", http.StatusForbidden},
+		{http.MethodPut, "/blob?id=1", "body", http.StatusForbidden},
+		{http.MethodPost, "/other?id=1", "body", http.StatusForbidden},
+	} {
+		token, err := access.Sign(r)
+		require.NoError(t, err)
+		req, _ := http.NewRequest(c.method, server.URL+c.url, bytes.NewReader([]byte(c.body)))
+		req.Header.Set(proto.TokenHeaderKey, token)
+		req.Header.Set(proto.ContentDigestHeaderKey, r.BodyDigest)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, c.code, resp.StatusCode, c)
+	}
+
+	// unsigned payload of stream body
+	resp, err = client.Post(server.URL+"/blob", "", io.LimitReader(bytes.NewReader([]byte("stream")), 6))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func writeCert(t *testing.T, dir, name string, tmpl *x509.Certificate, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return cert, key
+}
+
+func TestHandlerTLSIdentity(t *testing.T) {
+	dir := t.TempDir()
+	notAfter := time.Now().Add(time.Hour)
+	ca, caKey := writeCert(t, dir, "ca", &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "ca"}, NotAfter: notAfter,
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, nil, nil)
+	writeCert(t, dir, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "server"}, NotAfter: notAfter,
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	for _, name := range []string{"access", "scheduler"} {
+		writeCert(t, dir, name, &x509.Certificate{
+			SerialNumber: big.NewInt(3), Subject: pkix.Name{CommonName: name}, NotAfter: notAfter,
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}, ca, caKey)
+	}
+	tlsConfig := func(name string) *proto.TLSConfig {
+		return &proto.TLSConfig{
+			CertFile: filepath.Join(dir, name+".crt"),
+			KeyFile:  filepath.Join(dir, name+".key"),
+			CAFile:   filepath.Join(dir, "ca.crt"),
+		}
+	}
+
+	serverTLS := tlsConfig("server")
+	h, err := New(&proto.Config{EnableAuth: true, TLS: serverTLS, ServiceAuth: &security.ServiceAuthConfig{
+		Policies: []security.MethodPolicy{{Prefix: "/", Services: []string{"access"}}},
+	}})
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.Handler(w, r, func(w http.ResponseWriter, r *http.Request) {})
+	}))
+	server.TLS, err = serverTLS.ServerConfig()
+	require.NoError(t, err)
+	server.StartTLS()
+	defer server.Close()
+	url := strings.Replace(server.URL, "https://", "http://", 1)
+
+	for name, code := range map[string]int{"access": http.StatusOK, "scheduler": http.StatusForbidden} {
+		client := http.Client{Transport: auth_transport.New(&http.Transport{}, &proto.Config{
+			EnableAuth: true, TLS: tlsConfig(name),
+		})}
+		resp, err := client.Get(url + "/blob")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, code, resp.StatusCode, name)
+	}
+
+	// no client certificate
+	clientTLS, err := tlsConfig("access").ClientConfig()
+	require.NoError(t, err)
+	clientTLS.Certificates = nil
+	client := http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	resp, err := client.Get(server.URL + "/blob")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
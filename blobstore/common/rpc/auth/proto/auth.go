@@ -17,10 +17,18 @@ package proto
 import (
 	"bytes"
 	"crypto/md5"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/security"
 )
 
 const (
@@ -28,6 +36,14 @@ const (
 
 	// #nosec G101
 	TokenHeaderKey = "BLOB-STORE-AUTH-TOKEN"
+	// ContentDigestHeaderKey hex sha256 of http body signed by service token,
+	// or security.UnsignedPayload if the body is not signed
+	ContentDigestHeaderKey = "BLOB-STORE-AUTH-CONTENT-SHA256"
+	// MaxSignedBodySize http body larger than this is not signed
+	MaxSignedBodySize = 1 << 20
+	// Rpc2Method method of rpc2 request in signing, the parameter of rpc2 request
+	// is signed as body, and the stream body is not signed
+	Rpc2Method = "RPC2"
 )
 
 var errMismatchToken = errors.New("mismatch token")
@@ -35,6 +51,121 @@ var errMismatchToken = errors.New("mismatch token")
 type Config struct {
 	EnableAuth bool   `json:"enable_auth"`
 	Secret     string `json:"secret"`
+	// ServiceAuth authentication with per-service identities, the shared secret
+	// is still accepted in verifying if configured, for compatibility, and it is
+	// authorized as security.LegacyService by policies
+	ServiceAuth *security.ServiceAuthConfig `json:"service_auth,omitempty"`
+	// TLS mutual tls of http server and client, common name of the verified client
+	// certificate is the service identity authorized by policies of service auth.
+	// rpc2 has no tls, and is authenticated by tokens only
+	TLS *TLSConfig `json:"tls,omitempty"`
+}
+
+// Enabled returns auth is enabled with secret or service auth
+func (cfg *Config) Enabled() bool {
+	return cfg.EnableAuth && (cfg.Secret != "" || cfg.ServiceAuth != nil)
+}
+
+// TLSConfig certificate and key of self, and ca to verify the peer
+type TLSConfig struct {
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	CAFile   string `json:"ca_file"`
+}
+
+func (c *TLSConfig) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	ca, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, fmt.Errorf("invalid ca file %s", c.CAFile)
+	}
+	return cert, pool, nil
+}
+
+// ServerConfig returns tls config of server, the client certificate is verified if given,
+// so that clients authenticated by tokens can request without certificate
+func (c *TLSConfig) ServerConfig() (*tls.Config, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientConfig returns tls config of client with its certificate
+func (c *TLSConfig) ClientConfig() (*tls.Config, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// Signer signs the request and returns the token
+type Signer func(r security.ServiceRequest) (string, error)
+
+var (
+	serviceAuthMu sync.RWMutex
+	serviceAuth   *security.ServiceAuth
+)
+
+// SetServiceAuth sets the service auth of process, which is shared by servers and clients
+// of the process, so that keys are rotated once for all of them
+func SetServiceAuth(a *security.ServiceAuth) {
+	serviceAuthMu.Lock()
+	serviceAuth = a
+	serviceAuthMu.Unlock()
+}
+
+// ServiceAuthOf returns the service auth of process if set, or a new one with the config
+func ServiceAuthOf(cfg *security.ServiceAuthConfig) (*security.ServiceAuth, error) {
+	serviceAuthMu.RLock()
+	a := serviceAuth
+	serviceAuthMu.RUnlock()
+	if a != nil {
+		return a, nil
+	}
+	return security.NewServiceAuth(*cfg)
+}
+
+// NewSigner returns signer of request token, returns nil if auth disabled.
+// Sign with service identity if configured, or with the shared secret.
+func NewSigner(cfg *Config) (Signer, error) {
+	if !cfg.EnableAuth {
+		return nil, nil
+	}
+	if cfg.ServiceAuth != nil {
+		svcAuth, err := ServiceAuthOf(cfg.ServiceAuth)
+		if err != nil {
+			return nil, err
+		}
+		if svcAuth.Service() != "" {
+			return svcAuth.Sign, nil
+		}
+	}
+	if cfg.Secret != "" {
+		secret := []byte(cfg.Secret)
+		return func(r security.ServiceRequest) (string, error) {
+			return Encode(time.Now().Unix(), []byte(r.Path+r.Query), secret), nil
+		}, nil
+	}
+	return nil, nil
 }
 
 // token simply: use timestamp as a token calculate param
@@ -91,3 +222,13 @@ func (t *token) calculate(secret []byte) {
 func ParamFromRequest(req *http.Request) []byte {
 	return []byte(req.URL.Path + req.URL.RawQuery)
 }
+
+// ServiceRequestOf returns the signed content of http request, body digest is in header
+func ServiceRequestOf(req *http.Request) security.ServiceRequest {
+	return security.ServiceRequest{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Query:      req.URL.RawQuery,
+		BodyDigest: req.Header.Get(ContentDigestHeaderKey),
+	}
+}
@@ -15,33 +15,119 @@
 package auth
 
 import (
+	"io"
 	"net/http"
-	"time"
+	"sync"
 
 	"github.com/cubefs/cubefs/blobstore/common/rpc/auth/proto"
+	"github.com/cubefs/cubefs/blobstore/common/security"
 )
 
 type transport struct {
-	Secret []byte
-	Tr     http.RoundTripper
+	cfg proto.Config
+	Tr  http.RoundTripper
+
+	once    sync.Once
+	sign    proto.Signer
+	signErr error
 }
 
+// New returns round tripper which signs requests, the signer is resolved at the first
+// request, so that the service auth of process set after this can be shared.
 func New(tr http.RoundTripper, cfg *proto.Config) http.RoundTripper {
-	if cfg.EnableAuth {
-		if cfg.Secret != "" {
-			return &transport{
-				Secret: []byte(cfg.Secret),
-				Tr:     tr,
-			}
+	if !cfg.EnableAuth {
+		return tr
+	}
+	t := &transport{cfg: *cfg, Tr: tr}
+	if cfg.TLS != nil {
+		t.signErr = t.setTLS(cfg.TLS)
+	}
+	return t
+}
+
+func (t *transport) setTLS(cfg *proto.TLSConfig) error {
+	tlsCfg, err := cfg.ClientConfig()
+	if err != nil {
+		return err
+	}
+	tr := t.Tr
+	if tr == nil {
+		tr = http.DefaultTransport
+	}
+	httpTr, ok := tr.(*http.Transport)
+	if !ok {
+		return nil
+	}
+	httpTr = httpTr.Clone()
+	httpTr.TLSClientConfig = tlsCfg
+	t.Tr = httpTr
+	return nil
+}
+
+func (t *transport) signer() (proto.Signer, error) {
+	t.once.Do(func() {
+		if t.signErr != nil {
+			return
 		}
+		t.sign, t.signErr = proto.NewSigner(&t.cfg)
+	})
+	return t.sign, t.signErr
+}
+
+func bodyDigest(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return security.BodyDigest(nil), nil
 	}
-	return tr
+	if req.GetBody == nil || req.ContentLength < 0 || req.ContentLength > proto.MaxSignedBodySize {
+		return security.UnsignedPayload, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	b, err := io.ReadAll(io.LimitReader(body, proto.MaxSignedBodySize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(b) > proto.MaxSignedBodySize {
+		return security.UnsignedPayload, nil
+	}
+	return security.BodyDigest(b), nil
 }
 
-// a simple auth token
 func (t *transport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
-	now := time.Now().Unix()
-	param := proto.ParamFromRequest(req)
-	req.Header.Set(proto.TokenHeaderKey, proto.Encode(now, param, t.Secret))
-	return t.Tr.RoundTrip(req)
+	sign, err := t.signer()
+	if err != nil {
+		return nil, err
+	}
+	tr := t.Tr
+	if tr == nil {
+		tr = http.DefaultTransport
+	}
+	if t.cfg.TLS == nil && sign == nil {
+		return tr.RoundTrip(req)
+	}
+	// round tripper should not modify the request
+	req = req.Clone(req.Context())
+	if t.cfg.TLS != nil && req.URL.Scheme == "http" {
+		req.URL.Scheme = "https"
+	}
+	if sign == nil {
+		return tr.RoundTrip(req)
+	}
+
+	digest, err := bodyDigest(req)
+	if err != nil {
+		return nil, err
+	}
+	r := proto.ServiceRequestOf(req)
+	r.BodyDigest = digest
+	token, err := sign(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(proto.ContentDigestHeaderKey, digest)
+	req.Header.Set(proto.TokenHeaderKey, token)
+	return tr.RoundTrip(req)
 }
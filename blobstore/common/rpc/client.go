@@ -21,6 +21,7 @@ import (
 	"io"
 	"net/http"
 	urllib "net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,6 +43,8 @@ type Config struct {
 	BodyBaseTimeoutMs int64 `json:"body_base_timeout_ms"`
 	// transport config
 	Tc TransportConfig `json:"transport_config"`
+	// client runtime of retry, breaker and metrics
+	Runtime RuntimeConfig `json:"runtime"`
 }
 
 // ErrBodyReadTimeout timeout error
@@ -110,7 +113,7 @@ func NewClient(cfg *Config) Client {
 	}
 	return &client{
 		client: &http.Client{
-			Transport: NewTransport(&cfg.Tc),
+			Transport: NewRuntimeTransport(&cfg.Runtime, NewTransport(&cfg.Tc)),
 			Timeout:   time.Duration(cfg.ClientTimeoutMs) * time.Millisecond,
 		},
 		bandwidthBPMs:     int64(cfg.BodyBandwidthMBPs * (1 << 20) / 1e3),
@@ -258,6 +261,9 @@ func (c *client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 	if req.Header.Get(HeaderUA) == "" {
 		req.Header.Set(HeaderUA, UserAgent)
 	}
+	if deadline, ok := ctx.Deadline(); ok {
+		req.Header.Set(HeaderTimeoutMs, strconv.FormatInt(remainingMs(deadline), 10))
+	}
 	span := trace.SpanFromContextSafe(ctx)
 	err := trace.InjectWithHTTPHeader(ctx, req)
 	if err != nil {
@@ -299,6 +305,14 @@ func (c *client) doWithCtx(ctx context.Context, req *http.Request) (resp *http.R
 	return
 }
 
+// remainingMs returns milliseconds to deadline, at least 1ms
+func remainingMs(deadline time.Time) int64 {
+	if ms := time.Until(deadline).Milliseconds(); ms > 0 {
+		return ms
+	}
+	return 1
+}
+
 // parseData close response body in this package.
 func parseData(resp *http.Response, data interface{}) (err error) {
 	defer resp.Body.Close()
@@ -346,8 +360,9 @@ func ParseResponseErr(resp *http.Response) (err error) {
 			return NewError(decodeStatus, "JSONDecode",
 				fmt.Errorf("%d response decode %s", resp.StatusCode, err.Error()))
 		}
-		err = NewError(resp.StatusCode, errR.Code, errors.New(errR.Error))
-		return
+		rErr := NewError(resp.StatusCode, errR.Code, errors.New(errR.Error))
+		rErr.Attr = errR.Attr
+		return rErr
 	}
 	return NewError(resp.StatusCode, resp.Status, fmt.Errorf("%d response", resp.StatusCode))
 }
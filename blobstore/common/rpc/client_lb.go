@@ -18,9 +18,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	urllib "net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 )
@@ -46,11 +49,22 @@ type LbConfig struct {
 	RequestTryTimes int `json:"try_times"`
 	// should retry function
 	ShouldRetry func(code int, err error) bool `json:"-"`
+	// RetryBudget limits the retries of all requests, unlimited if it's nil.
+	RetryBudget RetryBudget `json:"-"`
 
 	// config for simple client
 	Config
 }
 
+// RetryBudget limits retries of the lb client in proportion to requests,
+// it's shared by all requests of the client to avoid retry storms.
+type RetryBudget interface {
+	// Request is called once at the beginning of every request.
+	Request()
+	// Retry returns false if the request can not retry on next host.
+	Retry() bool
+}
+
 type lbClient struct {
 	requestTryTimes int
 	// host for simple client
@@ -60,7 +74,15 @@ type lbClient struct {
 	cfg *LbConfig
 }
 
-var _ Client = (*lbClient)(nil)
+var (
+	_ Client       = (*lbClient)(nil)
+	_ WarmUpClient = (*lbClient)(nil)
+)
+
+// WarmUpClient is the client which can warm up connections to hosts.
+type WarmUpClient interface {
+	WarmUp(ctx context.Context, method, uri string, conns int)
+}
 
 // NewLbClient returns a lb client
 func NewLbClient(cfg *LbConfig, sel Selector) Client {
@@ -91,8 +113,11 @@ func NewLbClient(cfg *LbConfig, sel Selector) Client {
 	}
 	cl := &lbClient{sel: sel, cfg: cfg}
 	cl.clientMap = make(map[UniqueHost]Client)
+	// the lb client retries on hosts by itself, so retry of runtime is disabled
+	hostConfig := cfg.Config
+	hostConfig.Runtime.Retry = RetryConfig{}
 	for _, host := range sel.GetAllHosts() {
-		cl.clientMap[host] = NewClient(&cfg.Config)
+		cl.clientMap[host] = NewClient(&hostConfig)
 	}
 	cl.requestTryTimes = cfg.RequestTryTimes
 	return cl
@@ -255,6 +280,9 @@ func (c *lbClient) doCtx(ctx context.Context, r *http.Request) (resp *http.Respo
 		tryTimes = c.requestTryTimes
 		index    = 0
 	)
+	if c.cfg.RetryBudget != nil {
+		c.cfg.RetryBudget.Request()
+	}
 
 	for i := 0; i < tryTimes; i++ {
 		// close failed body
@@ -288,21 +316,28 @@ func (c *lbClient) doCtx(ctx context.Context, r *http.Request) (resp *http.Respo
 			return
 		}
 		r.Host = r.URL.Host
+		startTime := time.Now()
 		resp, err = c.clientMap[host].Do(ctx, r)
-		if i == tryTimes-1 {
-			span.Warnf("try on last, try-times:%d %s %v", i+1, idHost, err)
-			return
-		}
 		code := 0
 		if resp != nil {
 			code = resp.StatusCode
 		}
+		shouldRetry := c.cfg.ShouldRetry(code, err)
+		c.feedback(host, time.Since(startTime), shouldRetry)
+		if i == tryTimes-1 {
+			span.Warnf("try on last, try-times:%d %s %v", i+1, idHost, err)
+			return
+		}
 
 		logInfo := fmt.Sprintf("try-times:%d code:%d %s %v", i+1, code, idHost, err)
-		if c.cfg.ShouldRetry(code, err) {
+		if shouldRetry {
 			span.Info("retry host", logInfo)
 			index++
 			c.sel.SetFailHost(host)
+			if c.cfg.RetryBudget != nil && !c.cfg.RetryBudget.Retry() {
+				span.Warn("retry budget exhausted,", logInfo)
+				return
+			}
 			if r.Body == nil {
 				continue
 			}
@@ -324,6 +359,40 @@ func (c *lbClient) doCtx(ctx context.Context, r *http.Request) (resp *http.Respo
 	return
 }
 
+func (c *lbClient) feedback(host UniqueHost, duration time.Duration, failed bool) {
+	if fb, ok := c.sel.(SelectorFeedback); ok {
+		fb.Feedback(host, duration, failed)
+	}
+}
+
+// WarmUp requests the uri on every host with conns concurrent requests to
+// establish connections in advance, the results are fed back to the selector.
+func (c *lbClient) WarmUp(ctx context.Context, method, uri string, conns int) {
+	var wg sync.WaitGroup
+	for host, cli := range c.clientMap {
+		for i := 0; i < conns; i++ {
+			wg.Add(1)
+			go func(host UniqueHost, cli Client) {
+				defer wg.Done()
+				req, err := http.NewRequest(method, host.Host()+uri, nil)
+				if err != nil {
+					return
+				}
+				startTime := time.Now()
+				resp, err := cli.Do(ctx, req)
+				code := 0
+				if resp != nil {
+					code = resp.StatusCode
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+				c.feedback(host, time.Since(startTime), c.cfg.ShouldRetry(code, err))
+			}(host, cli)
+		}
+	}
+	wg.Wait()
+}
+
 func (c *lbClient) Close() {
 	c.sel.Close()
 }
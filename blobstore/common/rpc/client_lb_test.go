@@ -401,3 +401,72 @@ func TestLbClient_DoContextCancel(t *testing.T) {
 	err = client.DoWith(cancel, request, result)
 	require.Error(t, err)
 }
+
+type feedbackSelector struct {
+	Selector
+	mu      sync.Mutex
+	results map[string][]bool
+}
+
+func (s *feedbackSelector) Feedback(host UniqueHost, duration time.Duration, failed bool) {
+	s.mu.Lock()
+	s.results[host.Host()] = append(s.results[host.Host()], failed)
+	s.mu.Unlock()
+}
+
+func TestLbClient_FeedbackAndWarmUp(t *testing.T) {
+	cfg := newCfg([]string{refusedHosts[0], testServer.URL}, nil)
+	cfg.FailRetryIntervalS = -1
+	sel := &feedbackSelector{Selector: NewSelector(cfg), results: make(map[string][]bool)}
+	client := NewLbClient(cfg, sel)
+	defer client.Close()
+
+	client.(WarmUpClient).WarmUp(context.Background(), http.MethodHead, "/get/name?id=1", 2)
+	require.Equal(t, []bool{true, true}, sel.results[refusedHosts[0]])
+	require.Equal(t, []bool{false, false}, sel.results[testServer.URL])
+
+	sel.results = make(map[string][]bool)
+	for range [4]struct{}{} {
+		result := &ret{}
+		require.NoError(t, client.GetWith(context.Background(), "/get/name?id=1", result))
+	}
+	require.Equal(t, []bool{false, false, false, false}, sel.results[testServer.URL])
+	for _, failed := range sel.results[refusedHosts[0]] {
+		require.True(t, failed)
+	}
+}
+
+type countBudget struct {
+	requests, retries int
+	allowed           int
+}
+
+func (b *countBudget) Request() { b.requests++ }
+func (b *countBudget) Retry() bool {
+	if b.retries >= b.allowed {
+		return false
+	}
+	b.retries++
+	return true
+}
+
+func TestLbClient_RetryBudget(t *testing.T) {
+	cfg := newCfg(refusedHosts, []string{testServer.URL})
+	cfg.RequestTryTimes = 3
+	cfg.FailRetryIntervalS = -1
+	budget := &countBudget{allowed: 2}
+	cfg.RetryBudget = budget
+	client := NewLbClient(cfg, NewSelector(cfg))
+	defer client.Close()
+
+	// retry on refused hosts to the backup host
+	result := &ret{}
+	require.NoError(t, client.GetWith(context.Background(), "/get/name?id=1", result))
+	require.Equal(t, 1, budget.requests)
+	require.Equal(t, 2, budget.retries)
+
+	// budget exhausted, no retry
+	require.Error(t, client.GetWith(context.Background(), "/get/name?id=1", result))
+	require.Equal(t, 2, budget.requests)
+	require.Equal(t, 2, budget.retries)
+}
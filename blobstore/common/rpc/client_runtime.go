@@ -0,0 +1,281 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrBreakerOpen request failed fast by the circuit breaker of host
+var ErrBreakerOpen = errors.New("rpc: circuit breaker is open")
+
+// RuntimeConfig client side runtime shared by api clients,
+// retry with backoff, circuit breaker per host and request metrics.
+type RuntimeConfig struct {
+	// Name of the client, label of metrics
+	Name    string        `json:"name"`
+	Retry   RetryConfig   `json:"retry"`
+	Breaker BreakerConfig `json:"breaker"`
+	// Metric enable prometheus metrics of requests
+	Metric bool `json:"metric"`
+}
+
+// RetryConfig retry on the same host if connection failed or 5xx responded,
+// only the idempotent request is retried, which method is idempotent or has
+// header Idempotency-Key, and its body can be rewound by GetBody.
+// retry is disabled in the clients which retry on hosts by themselves, like lb client.
+type RetryConfig struct {
+	// Times retry times after the first attempt, 0 means no retry
+	Times int `json:"times"`
+	// BackoffMs increasing delay of the attempts in milliseconds
+	BackoffMs int64 `json:"backoff_ms"`
+}
+
+// BreakerConfig breaker of host opens after consecutive failures,
+// and requests to the host fail fast in the open interval, then it's
+// half-open and admits a single probe, which closes or opens it again.
+type BreakerConfig struct {
+	// FailureThreshold consecutive failures to open breaker, 0 means disable
+	FailureThreshold int `json:"failure_threshold"`
+	// OpenIntervalMs interval of breaker opened, then try the host again
+	OpenIntervalMs int64 `json:"open_interval_ms"`
+}
+
+var (
+	clientMetricOnce sync.Once
+
+	clientMetricRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc",
+		Name:      "client_requests",
+		Help:      "rpc client requests with status code",
+	}, []string{"name", "method", "route", "code"})
+	clientMetricLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc",
+		Name:      "client_latency_ms",
+		Help:      "rpc client latency in millisecond",
+		Buckets:   []float64{1, 5, 10, 50, 100, 500, 1000, 5000, 10000, 30000},
+	}, []string{"name", "method", "route"})
+)
+
+const (
+	metricCodeError   = "error"
+	metricCodeBreaker = "breaker"
+)
+
+func registerClientMetrics() {
+	clientMetricOnce.Do(func() {
+		prometheus.MustRegister(clientMetricRequests, clientMetricLatency)
+	})
+}
+
+// NewRuntimeTransport returns transport wrapped with client runtime,
+// returns the transport itself if runtime is not enabled.
+func NewRuntimeTransport(cfg *RuntimeConfig, rt http.RoundTripper) http.RoundTripper {
+	conf := *cfg
+	if conf.Retry.Times <= 0 && conf.Breaker.FailureThreshold <= 0 && !conf.Metric {
+		return rt
+	}
+	if conf.Metric {
+		registerClientMetrics()
+	}
+	if conf.Breaker.FailureThreshold > 0 && conf.Breaker.OpenIntervalMs <= 0 {
+		conf.Breaker.OpenIntervalMs = 1000
+	}
+	return &runtimeTransport{
+		cfg:      conf,
+		rt:       rt,
+		breakers: make(map[string]*hostBreaker),
+	}
+}
+
+type runtimeTransport struct {
+	cfg RuntimeConfig
+	rt  http.RoundTripper
+
+	mu       sync.Mutex
+	breakers map[string]*hostBreaker
+}
+
+func (t *runtimeTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	start := time.Now()
+	defer func() { t.report(req, start, resp, err) }()
+
+	breaker := t.getBreaker(req.URL.Host)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if req, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+		probe := false
+		if breaker != nil {
+			var allowed bool
+			if allowed, probe = breaker.allow(); !allowed {
+				return nil, ErrBreakerOpen
+			}
+		}
+
+		resp, err = t.rt.RoundTrip(req)
+		failed := err != nil || isServerFailure(resp.StatusCode)
+		if breaker != nil {
+			breaker.report(failed, probe)
+		}
+		if !failed || attempt >= t.cfg.Retry.Times || !canRetry(req) {
+			return
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(time.Duration(t.cfg.Retry.BackoffMs*int64(attempt+1)) * time.Millisecond)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func (t *runtimeTransport) getBreaker(host string) *hostBreaker {
+	if t.cfg.Breaker.FailureThreshold <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	b, ok := t.breakers[host]
+	if !ok {
+		b = &hostBreaker{
+			threshold:    t.cfg.Breaker.FailureThreshold,
+			openInterval: time.Duration(t.cfg.Breaker.OpenIntervalMs) * time.Millisecond,
+		}
+		t.breakers[host] = b
+	}
+	t.mu.Unlock()
+	return b
+}
+
+func (t *runtimeTransport) report(req *http.Request, start time.Time, resp *http.Response, err error) {
+	if !t.cfg.Metric {
+		return
+	}
+	code := metricCodeError
+	switch {
+	case err == ErrBreakerOpen:
+		code = metricCodeBreaker
+	case err == nil:
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	route := metricRoute(req.URL.Path)
+	clientMetricRequests.WithLabelValues(t.cfg.Name, req.Method, route, code).Inc()
+	clientMetricLatency.WithLabelValues(t.cfg.Name, req.Method, route).
+		Observe(float64(time.Since(start)) / float64(time.Millisecond))
+}
+
+// metricRoute returns route of the path as metric label, the segments with digit
+// are arguments in path like ids and hosts, which are replaced to limit the cardinality.
+func metricRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "0123456789") {
+			segments[i] = ":"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isServerFailure(code int) bool {
+	return code >= 500 && code < 600
+}
+
+// canRetry returns true if the request is idempotent and can be rewound
+func canRetry(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+	default:
+		if _, ok := req.Header["Idempotency-Key"]; !ok {
+			return false
+		}
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	newReq := req.Clone(req.Context())
+	newReq.Body = body
+	return newReq, nil
+}
+
+// hostBreaker consecutive failures breaker of one host
+type hostBreaker struct {
+	threshold    int
+	openInterval time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	// probing is true if the probe of half-open breaker is in flight
+	probing bool
+}
+
+// allow returns if the request is allowed, and whether it's the probe of half-open breaker
+func (b *hostBreaker) allow() (allowed bool, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() {
+		return true, false
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return false, false
+	}
+	b.probing = true
+	return true, true
+}
+
+func (b *hostBreaker) report(failed bool, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if probe {
+		b.probing = false
+	}
+	if !failed {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		// half-open after the interval, opens again if still failed
+		b.openUntil = time.Now().Add(b.openInterval)
+	}
+}
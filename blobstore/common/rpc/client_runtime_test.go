@@ -0,0 +1,182 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRuntimeRetry(t *testing.T) {
+	var hits, fails int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if code, _ := strconv.Atoi(r.URL.Query().Get("code")); code > 0 {
+			w.WriteHeader(code)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		if int64(len(b)) != r.ContentLength || atomic.AddInt32(&fails, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cli := NewClient(&Config{Runtime: RuntimeConfig{
+		Name:   "test-retry",
+		Retry:  RetryConfig{Times: 2, BackoffMs: 1},
+		Metric: true,
+	}})
+	ctx := context.Background()
+
+	atomic.StoreInt32(&fails, 2)
+	require.NoError(t, cli.PutWith(ctx, server.URL+"/blob/put", nil, ret{Name: "retry"}))
+	require.Equal(t, int32(3), atomic.LoadInt32(&hits))
+
+	atomic.StoreInt32(&hits, 0)
+	atomic.StoreInt32(&fails, 3)
+	require.Error(t, cli.PutWith(ctx, server.URL+"/blob/put", nil, ret{Name: "retry"}))
+	require.Equal(t, int32(3), atomic.LoadInt32(&hits))
+
+	// not idempotent request is not retried
+	atomic.StoreInt32(&hits, 0)
+	atomic.StoreInt32(&fails, 1)
+	require.Error(t, cli.PostWith(ctx, server.URL+"/blob/put", nil, ret{Name: "retry"}))
+	require.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	// 6xx is not a failure of server
+	atomic.StoreInt32(&hits, 0)
+	require.Error(t, cli.PutWith(ctx, server.URL+"/blob/put/1?code=601", nil, nil))
+	require.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	require.Equal(t, 1.0, testutil.ToFloat64(clientMetricRequests.WithLabelValues("test-retry", http.MethodPut, "/blob/put/:", "601")))
+	require.Equal(t, 1.0, testutil.ToFloat64(clientMetricRequests.WithLabelValues("test-retry", http.MethodPut, "/blob/put", "200")))
+	require.Equal(t, 1.0, testutil.ToFloat64(clientMetricRequests.WithLabelValues("test-retry", http.MethodPut, "/blob/put", "503")))
+	require.Equal(t, 1.0, testutil.ToFloat64(clientMetricRequests.WithLabelValues("test-retry", http.MethodPost, "/blob/put", "503")))
+
+	// context canceled in backoff
+	cli = NewClient(&Config{Runtime: RuntimeConfig{Retry: RetryConfig{Times: 2, BackoffMs: 1000}}})
+	atomic.StoreInt32(&fails, 3)
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	err := cli.PutWith(ctx, server.URL, nil, nil)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestClientRuntimeBreaker(t *testing.T) {
+	var hits int32
+	failed := int32(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if atomic.LoadInt32(&failed) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cli := NewClient(&Config{Runtime: RuntimeConfig{
+		Name:    "test-breaker",
+		Breaker: BreakerConfig{FailureThreshold: 2, OpenIntervalMs: 200},
+		Metric:  true,
+	}})
+	ctx := context.Background()
+
+	for range [2]struct{}{} {
+		require.Error(t, cli.GetWith(ctx, server.URL, nil))
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&hits))
+	err := cli.GetWith(ctx, server.URL, nil)
+	require.True(t, errors.Is(err, ErrBreakerOpen))
+	require.Equal(t, int32(2), atomic.LoadInt32(&hits))
+	require.Equal(t, 1.0, testutil.ToFloat64(clientMetricRequests.WithLabelValues("test-breaker", http.MethodGet, "", metricCodeBreaker)))
+
+	// half-open
+	atomic.StoreInt32(&failed, 0)
+	time.Sleep(300 * time.Millisecond)
+	require.NoError(t, cli.GetWith(ctx, server.URL, nil))
+	require.NoError(t, cli.GetWith(ctx, server.URL, nil))
+	require.Equal(t, int32(4), atomic.LoadInt32(&hits))
+}
+
+func TestClientRuntimeBreakerProbe(t *testing.T) {
+	b := &hostBreaker{threshold: 1, openInterval: 10 * time.Millisecond}
+	allowed, probe := b.allow()
+	require.True(t, allowed)
+	require.False(t, probe)
+	b.report(true, probe)
+	allowed, _ = b.allow()
+	require.False(t, allowed)
+
+	// single probe is admitted in half-open, and opens again if failed
+	time.Sleep(20 * time.Millisecond)
+	allowed, probe = b.allow()
+	require.True(t, allowed)
+	require.True(t, probe)
+	allowed, _ = b.allow()
+	require.False(t, allowed)
+	b.report(true, probe)
+	allowed, _ = b.allow()
+	require.False(t, allowed)
+
+	// closed if probe succeeded
+	time.Sleep(20 * time.Millisecond)
+	allowed, probe = b.allow()
+	require.True(t, allowed)
+	require.True(t, probe)
+	b.report(false, probe)
+	for range [2]struct{}{} {
+		allowed, probe = b.allow()
+		require.True(t, allowed)
+		require.False(t, probe)
+	}
+}
+
+func TestClientRuntimeMetricRoute(t *testing.T) {
+	require.Equal(t, "", metricRoute(""))
+	require.Equal(t, "/volume/list", metricRoute("/volume/list"))
+	require.Equal(t, "/shard/get/diskid/:/vuid/:/bid/:", metricRoute("/shard/get/diskid/1/vuid/2/bid/3"))
+	require.Equal(t, "/service/get/host/:", metricRoute("/service/get/host/127.0.0.1:9500"))
+}
+
+func TestClientRuntimeLbNoRetry(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// lb client retries on hosts, retry of runtime is not stacked
+	cfg := &LbConfig{Hosts: []string{server.URL}, RequestTryTimes: 2}
+	cfg.Runtime.Retry = RetryConfig{Times: 2, BackoffMs: 1}
+	cli := NewLbClient(cfg, nil)
+	require.Error(t, cli.PutWith(context.Background(), "/blob/put", nil, nil))
+	require.Equal(t, int32(2), atomic.LoadInt32(&hits))
+	require.Equal(t, 2, cfg.Runtime.Retry.Times)
+}
@@ -55,6 +55,12 @@ type Selector interface {
 	Close()
 }
 
+// SelectorFeedback is an optional interface of Selector,
+// the lb client feeds back the duration and result of every request on the host.
+type SelectorFeedback interface {
+	Feedback(host UniqueHost, duration time.Duration, failed bool)
+}
+
 // allocate hostItem to request
 type selector struct {
 	// the frequency for a host to retry, if retryTimes > hostTryTimes the host will be marked as failed
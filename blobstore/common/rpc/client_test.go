@@ -382,3 +382,25 @@ func TestClient_PageNotFound(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, "", result.Name)
 }
+
+func TestClient_DeadlineContext(t *testing.T) {
+	router := New()
+	router.Handle(http.MethodGet, "/deadline", func(c *Context) {
+		ctx, cancel := c.DeadlineContext()
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		c.RespondJSON(ok && time.Until(deadline) <= 10*time.Second)
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	cli := NewClient(&Config{})
+	var ok bool
+	require.NoError(t, cli.GetWith(context.Background(), server.URL+"/deadline", &ok))
+	require.False(t, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, cli.GetWith(ctx, server.URL+"/deadline", &ok))
+	require.True(t, ok)
+}
@@ -16,6 +16,7 @@ package rpc
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"math"
@@ -24,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -95,6 +97,17 @@ func (c *Context) RequestLength() (int, error) {
 	return int(cl), nil
 }
 
+// DeadlineContext returns context of request bounded by deadline of client,
+// io of abandoned request can be cancelled with it
+func (c *Context) DeadlineContext() (context.Context, context.CancelFunc) {
+	ctx := c.Request.Context()
+	ms, err := strconv.ParseInt(c.Request.Header.Get(HeaderTimeoutMs), 10, 64)
+	if err != nil || ms <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+}
+
 // Next should be used only inside interceptor.
 // It executes the pending handlers inside the calling handler.
 func (c *Context) Next() {
@@ -155,6 +168,7 @@ func (c *Context) RespondError(err error) {
 	c.RespondStatusData(httpErr.StatusCode(), errorResponse{
 		Error: httpErr.Error(),
 		Code:  httpErr.ErrorCode(),
+		Attr:  DetectErrorAttr(err),
 	})
 }
 
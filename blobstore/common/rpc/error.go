@@ -29,6 +29,7 @@ type (
 		Status int    // http status code
 		Code   string // error code
 		Err    error  // error
+		Attr   string // attributes of error, encoded by server
 	}
 
 	// errorResponse response error with json
@@ -36,6 +37,7 @@ type (
 	errorResponse struct {
 		Error string `json:"error"`
 		Code  string `json:"code,omitempty"`
+		Attr  string `json:"attr,omitempty"`
 	}
 
 	statusCoder interface {
@@ -44,6 +46,9 @@ type (
 	errorCoder interface {
 		ErrorCode() string
 	}
+	errorAttrer interface {
+		ErrorAttr() string
+	}
 )
 
 var _ HTTPError = &Error{}
@@ -67,6 +72,11 @@ func (e *Error) ErrorCode() string {
 	return e.Code
 }
 
+// ErrorAttr returns attributes of error
+func (e *Error) ErrorAttr() string {
+	return e.Attr
+}
+
 // Error implements error
 func (e *Error) Error() string {
 	if e.Err == nil {
@@ -122,6 +132,19 @@ func DetectErrorCode(err error) string {
 	}
 }
 
+// DetectErrorAttr returns attributes of error, empty if not carried
+func DetectErrorAttr(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var ea errorAttrer
+	if errors.As(err, &ea) {
+		return ea.ErrorAttr()
+	}
+	return ""
+}
+
 // DetectError returns status code, error code, error
 func DetectError(err error) (int, string, error) {
 	return DetectStatusCode(err), DetectErrorCode(err), errors.Unwrap(err)
@@ -17,6 +17,7 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"syscall"
 	"testing"
 
@@ -106,4 +107,12 @@ func TestErrorBase(t *testing.T) {
 		require.Equal(t, "InternalServerError", httpErr.ErrorCode())
 		require.Equal(t, "error", httpErr.Error())
 	}
+	{
+		require.Equal(t, "", DetectErrorAttr(nil))
+		require.Equal(t, "", DetectErrorAttr(errors.New("error")))
+		err := NewError(500, "", errors.New("error"))
+		err.Attr = "common;server;"
+		require.Equal(t, "common;server;", DetectErrorAttr(err))
+		require.Equal(t, "common;server;", DetectErrorAttr(fmt.Errorf("wrapped: %w", err)))
+	}
 }
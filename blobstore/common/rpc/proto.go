@@ -44,6 +44,9 @@ const (
 	// crc checker
 	HeaderCrcEncoded    = "X-Crc-Encoded"
 	HeaderAckCrcEncoded = "X-Ack-Crc-Encoded"
+
+	// remaining milliseconds of client deadline
+	HeaderTimeoutMs = "X-Timeout-Ms"
 )
 
 // mime
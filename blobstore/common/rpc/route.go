@@ -44,6 +44,7 @@ type (
 		headMiddlewares []ProgressHandler  // middlewares run firstly of all
 		headHandler     http.HandlerFunc   // run this handler if has no middlewares
 		interceptors    []HandlerFunc      // interceptors after middlewares
+		routes          []RouteSchema      // registered routes
 	}
 )
 
@@ -115,6 +116,7 @@ func (r *Router) Handle(method, path string, handler HandlerFunc, opts ...Server
 		icnames = append(icnames, runtime.FuncForPC(reflect.ValueOf(ic).Pointer()).Name())
 	}
 	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	r.routes = append(r.routes, newRouteSchema(method, path, name, opt))
 	log.Infof("register handler method:%s, path:%s, interceptors:%s, handler:%s, opts:%+v",
 		method, path, icnames, name, opt)
 }
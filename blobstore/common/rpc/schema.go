@@ -0,0 +1,239 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// schema of http api, it's generated from route registration,
+// arguments and response of route are described with OptArgsSchema and OptRetSchema.
+type (
+	// APISchema machine-readable schema of service api
+	APISchema struct {
+		Service string        `json:"service"`
+		Routes  []RouteSchema `json:"routes"`
+		Errors  []ErrorSchema `json:"errors,omitempty"`
+	}
+	// RouteSchema schema of registered route
+	RouteSchema struct {
+		Method     string      `json:"method"`
+		Path       string      `json:"path"`
+		Handler    string      `json:"handler"`
+		PathParams []string    `json:"path_params,omitempty"`
+		ArgsIn     []string    `json:"args_in,omitempty"` // body, uri, query, form, postform
+		Args       *TypeSchema `json:"args,omitempty"`
+		Ret        *TypeSchema `json:"ret,omitempty"`
+	}
+	// TypeSchema schema of go type
+	TypeSchema struct {
+		Type   string        `json:"type"` // bool, int, uint, float, string, bytes, struct, array, map, any
+		Name   string        `json:"name,omitempty"`
+		Fields []FieldSchema `json:"fields,omitempty"`
+		Key    *TypeSchema   `json:"key,omitempty"`
+		Elem   *TypeSchema   `json:"elem,omitempty"`
+	}
+	// FieldSchema schema of struct field
+	FieldSchema struct {
+		Name     string      `json:"name"`
+		Optional bool        `json:"optional,omitempty"`
+		Schema   *TypeSchema `json:"schema"`
+	}
+	// ErrorSchema schema of error code
+	ErrorSchema struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+// OptArgsSchema describes arguments of route in api schema, args is pointer of struct.
+func OptArgsSchema(args interface{}) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) {
+		o.argsSchema = reflect.TypeOf(args)
+	})
+}
+
+// OptRetSchema describes response of route in api schema.
+func OptRetSchema(ret interface{}) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) {
+		o.retSchema = reflect.TypeOf(ret)
+	})
+}
+
+func newRouteSchema(method, path, handler string, opt *serverOptions) RouteSchema {
+	route := RouteSchema{Method: method, Path: path, Handler: handler}
+	for _, seg := range strings.Split(path, "/") {
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			route.PathParams = append(route.PathParams, seg[1:])
+		}
+	}
+	for _, in := range []struct {
+		is   bool
+		name string
+	}{
+		{opt.argsBody, "body"},
+		{opt.argsURI, "uri"},
+		{opt.argsQuery, "query"},
+		{opt.argsForm, "form"},
+		{opt.argsPostForm, "postform"},
+	} {
+		if in.is {
+			route.ArgsIn = append(route.ArgsIn, in.name)
+		}
+	}
+	if opt.argsSchema != nil {
+		route.Args = typeSchema(opt.argsSchema, !opt.argsBody && opt.hasArgs(), make(map[reflect.Type]bool))
+	}
+	if opt.retSchema != nil {
+		route.Ret = typeSchema(opt.retSchema, false, make(map[reflect.Type]bool))
+	}
+	return route
+}
+
+// TypeSchemaOf returns schema of value v in json.
+func TypeSchemaOf(v interface{}) *TypeSchema {
+	return typeSchema(reflect.TypeOf(v), false, make(map[reflect.Type]bool))
+}
+
+// typeSchema names field with registered args parser if byParser, else with json tag.
+// recursive type is described with its name only.
+func typeSchema(typ reflect.Type, byParser bool, visiting map[reflect.Type]bool) *TypeSchema {
+	if typ == nil {
+		return &TypeSchema{Type: "any"}
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	schema := &TypeSchema{Name: typeName(typ)}
+	switch typ.Kind() {
+	case reflect.Bool:
+		schema.Type = "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema.Type = "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		schema.Type = "uint"
+	case reflect.Float32, reflect.Float64:
+		schema.Type = "float"
+	case reflect.String:
+		schema.Type = "string"
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			schema.Type = "bytes"
+			break
+		}
+		schema.Type = "array"
+		schema.Elem = typeSchema(typ.Elem(), false, visiting)
+	case reflect.Map:
+		schema.Type = "map"
+		schema.Key = typeSchema(typ.Key(), false, visiting)
+		schema.Elem = typeSchema(typ.Elem(), false, visiting)
+	case reflect.Struct:
+		schema.Type = "struct"
+		if visiting[typ] {
+			return schema
+		}
+		visiting[typ] = true
+		schema.Fields = structFields(typ, byParser, visiting)
+		delete(visiting, typ)
+	default:
+		schema.Type = "any"
+	}
+	return schema
+}
+
+func structFields(typ reflect.Type, byParser bool, visiting map[reflect.Type]bool) []FieldSchema {
+	fields := make([]FieldSchema, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		ft := typ.Field(i)
+		if ft.PkgPath != "" && !ft.Anonymous { // unexported
+			continue
+		}
+
+		var (
+			name     string
+			optional bool
+		)
+		if byParser {
+			pVal, ok := registeredParsers[parserKey{PkgPath: typ.PkgPath(), Name: typ.Name(), FieldName: ft.Name}]
+			if !ok {
+				pVal.Name = strings.ToLower(ft.Name)
+			}
+			if pVal.Opt.Ignore {
+				continue
+			}
+			name, optional = pVal.Name, pVal.Opt.Omitempty
+		} else {
+			tags := strings.Split(ft.Tag.Get("json"), ",")
+			if tags[0] == "-" {
+				continue
+			}
+			if ft.Anonymous && tags[0] == "" {
+				fieldType := ft.Type
+				for fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				if fieldType.Kind() == reflect.Struct { // embedded fields are promoted in json
+					fields = append(fields, typeSchema(fieldType, false, visiting).Fields...)
+					continue
+				}
+			}
+			if ft.PkgPath != "" {
+				continue
+			}
+			name = ft.Name
+			if tags[0] != "" {
+				name = tags[0]
+			}
+			for _, t := range tags[1:] {
+				if t == "omitempty" {
+					optional = true
+				}
+			}
+		}
+		fields = append(fields, FieldSchema{
+			Name:     name,
+			Optional: optional,
+			Schema:   typeSchema(ft.Type, false, visiting),
+		})
+	}
+	return fields
+}
+
+func typeName(typ reflect.Type) string {
+	if typ.Name() == "" || typ.PkgPath() == "" {
+		return ""
+	}
+	return typ.String()
+}
+
+// Routes returns schema of routes registered in the router, sorted by path and method.
+func (r *Router) Routes() []RouteSchema {
+	routes := make([]RouteSchema, len(r.routes))
+	copy(routes, r.routes)
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Routes returns schema of routes registered in the default router.
+func Routes() []RouteSchema {
+	return DefaultRouter.Routes()
+}
@@ -0,0 +1,106 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	schemaInner struct {
+		Tag  string `json:"tag"`
+		Next *schemaInner
+	}
+	schemaArgs struct {
+		schemaInner
+		ID     uint32            `json:"id"`
+		Name   string            `json:"name,omitempty"`
+		Data   []byte            `json:"data"`
+		Labels map[string]int64  `json:"labels"`
+		Items  []schemaInner     `json:"items"`
+		Any    interface{}       `json:"any"`
+		Ignore bool              `json:"-"`
+		hidden int               //nolint:unused
+		Extra  map[string]string `json:"extra,omitempty"`
+	}
+	schemaQueryArgs struct {
+		ID   uint32
+		Name string `json:"name,omitempty"`
+		Skip bool   `json:"-"`
+	}
+)
+
+func TestServerSchemaRoutes(t *testing.T) {
+	RegisterArgsParser(&schemaQueryArgs{}, "json")
+	router := New()
+	handler := func(c *Context) {}
+	router.Handle(http.MethodPost, "/schema/body", handler, OptArgsBody(),
+		OptArgsSchema(&schemaArgs{}), OptRetSchema(&schemaInner{}))
+	router.Handle(http.MethodGet, "/schema/query/:id/*name", handler, OptArgsURI(), OptArgsQuery(),
+		OptArgsSchema(&schemaQueryArgs{}))
+	router.Handle(http.MethodGet, "/schema/none", handler)
+
+	routes := router.Routes()
+	require.Equal(t, 3, len(routes))
+
+	body := routes[0]
+	require.Equal(t, http.MethodPost, body.Method)
+	require.Equal(t, "/schema/body", body.Path)
+	require.Contains(t, body.Handler, "TestServerSchemaRoutes")
+	require.Equal(t, []string{"body"}, body.ArgsIn)
+	require.Equal(t, "struct", body.Args.Type)
+	require.Equal(t, "rpc.schemaArgs", body.Args.Name)
+	names := make([]string, 0)
+	for _, f := range body.Args.Fields {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"tag", "Next", "id", "name", "data", "labels", "items", "any", "extra"}, names)
+	require.Equal(t, "struct", body.Args.Fields[1].Schema.Type)
+	require.Nil(t, body.Args.Fields[1].Schema.Fields)
+	require.Equal(t, "uint", body.Args.Fields[2].Schema.Type)
+	require.True(t, body.Args.Fields[3].Optional)
+	require.Equal(t, "bytes", body.Args.Fields[4].Schema.Type)
+	require.Equal(t, "map", body.Args.Fields[5].Schema.Type)
+	require.Equal(t, "string", body.Args.Fields[5].Schema.Key.Type)
+	require.Equal(t, "int", body.Args.Fields[5].Schema.Elem.Type)
+	require.Equal(t, "array", body.Args.Fields[6].Schema.Type)
+	require.Equal(t, 2, len(body.Args.Fields[6].Schema.Elem.Fields))
+	require.Equal(t, "any", body.Args.Fields[7].Schema.Type)
+	require.Equal(t, "rpc.schemaInner", body.Ret.Name)
+
+	none := routes[1]
+	require.Equal(t, "/schema/none", none.Path)
+	require.Nil(t, none.ArgsIn)
+	require.Nil(t, none.Args)
+	require.Nil(t, none.Ret)
+
+	query := routes[2]
+	require.Equal(t, []string{"id", "name"}, query.PathParams)
+	require.Equal(t, []string{"uri", "query"}, query.ArgsIn)
+	require.Equal(t, 2, len(query.Args.Fields))
+	require.Equal(t, "id", query.Args.Fields[0].Name)
+	require.Equal(t, "name", query.Args.Fields[1].Name)
+	require.True(t, query.Args.Fields[1].Optional)
+
+	_, err := json.Marshal(APISchema{Service: "test", Routes: routes, Errors: []ErrorSchema{{Code: 400, Message: "bad"}}})
+	require.NoError(t, err)
+
+	require.Equal(t, "array", TypeSchemaOf([]schemaInner{}).Type)
+	require.Equal(t, "any", TypeSchemaOf(nil).Type)
+}
@@ -16,6 +16,7 @@ package rpc
 
 import (
 	"net/http"
+	"reflect"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -38,6 +39,9 @@ type (
 		argsPostForm bool
 
 		metaCapacity int
+
+		argsSchema reflect.Type // only for api schema
+		retSchema  reflect.Type // only for api schema
 	}
 	funcServerOption struct {
 		f func(*serverOptions)
@@ -53,6 +57,9 @@ func (so *serverOptions) copy() *serverOptions {
 		argsPostForm: so.argsPostForm,
 
 		metaCapacity: so.metaCapacity,
+
+		argsSchema: so.argsSchema,
+		retSchema:  so.retSchema,
 	}
 }
 
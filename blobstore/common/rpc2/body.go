@@ -22,10 +22,15 @@ import (
 	"github.com/cubefs/cubefs/blobstore/common/rpc2/transport"
 )
 
+type sizedReader interface {
+	io.Reader
+	Finished() bool
+}
+
 type bodyAndTrailer struct {
-	sr     *transport.SizedReader // smux reader
-	br     Body                   // body reader
-	remain int                    // body remain, read trailer if remain == 0
+	sr     sizedReader // smux reader
+	br     Body        // body reader
+	remain int         // body remain, read trailer if remain == 0
 	req    *Request
 	err    error
 
@@ -110,8 +115,9 @@ func (r *bodyAndTrailer) Close() error {
 		err := r.br.Close()
 		if cli := r.req.client; cli != nil {
 			cli.Connector.Put(r.req.Context(), r.req.conn,
-				err != nil || !r.sr.Finished())
+				err != nil || !r.sr.Finished() || r.req.connBroken)
 			r.req.conn = nil
+			r.req.releaseQueue()
 		}
 		r.storeError(err)
 		if !r.sr.Finished() {
@@ -142,7 +148,23 @@ func makeBodyWithTrailer(sr *transport.SizedReader, req *Request,
 	return r
 }
 
-// readHeaderFrame try to read request or response header.
+// makeWindowBodyWithTrailer body in retransmit windows and trailer remain.
+func makeWindowBodyWithTrailer(stream *transport.Stream, frame *transport.FrameRead,
+	req *Request, trailer *FixedHeader, l int64,
+) Body {
+	wb := newWindowBody(req.ctx, stream, frame, req.checksum, l, trailer.AllSize())
+	return &bodyAndTrailer{
+		sr:      wb,
+		br:      wb,
+		remain:  int(l),
+		req:     req,
+		trailer: trailer,
+	}
+}
+
+// readHeaderFrame try to read request or response header,
+// the header oversizing the payload of one frame is reassembled
+// from the continuation frames.
 func readHeaderFrame(ctx context.Context, stream *transport.Stream, hdr Unmarshaler) (*transport.FrameRead, error) {
 	frame, err := stream.ReadFrame(ctx)
 	if err != nil {
@@ -155,20 +177,66 @@ func readHeaderFrame(ctx context.Context, stream *transport.Stream, hdr Unmarsha
 		}
 	}()
 
-	if frame.Len() < _headerCell {
-		err = ErrFrameHeader
-		return nil, err
-	}
 	var cell headerCell
-	cell.Write(frame.Bytes(_headerCell))
-	headerSize := cell.Get()
-	if frame.Len() < headerSize {
-		err = ErrFrameHeader
+	if err = readFrameCell(frame, &cell); err != nil {
 		return nil, err
 	}
-
-	if err = hdr.Unmarshal(frame.Bytes(headerSize)); err != nil {
+	if frame, err = unmarshalHeaderFrame(ctx, stream, frame, cell, hdr); err != nil {
 		return nil, err
 	}
 	return frame, nil
 }
+
+func readFrameCell(frame *transport.FrameRead, cell *headerCell) error {
+	if frame.Len() < _headerCell {
+		return ErrFrameHeader
+	}
+	cell.Write(frame.Bytes(_headerCell))
+	return nil
+}
+
+// unmarshalHeaderFrame returns the frame which the header ends in, closed by caller.
+func unmarshalHeaderFrame(ctx context.Context, stream *transport.Stream, frame *transport.FrameRead,
+	cell headerCell, hdr Unmarshaler,
+) (*transport.FrameRead, error) {
+	headerSize := cell.Size()
+	if !cell.Continued() && frame.Len() < headerSize {
+		return frame, ErrFrameHeader
+	}
+	frame, b, err := readFrameBytes(ctx, stream, frame, headerSize)
+	if err != nil {
+		return frame, err
+	}
+	return frame, hdr.Unmarshal(b)
+}
+
+// readFrameBytes reads n bytes from the frame and its continuation frames,
+// the former frames are closed after copied, returns the last read frame
+// with the remaining payload, it should be closed by caller.
+// The buffer grows with the arrived frames rather than the size declared
+// by peer, so a bogus size cannot pin memory without sending the bytes.
+func readFrameBytes(ctx context.Context, stream *transport.Stream, frame *transport.FrameRead, n int) (
+	*transport.FrameRead, []byte, error,
+) {
+	if n < 0 || n > _maxCodecerSize {
+		return frame, nil, ErrFrameHeader
+	}
+	if frame.Len() >= n {
+		return frame, frame.Bytes(n), nil
+	}
+
+	var buff []byte
+	for {
+		buff = append(buff, frame.Bytes(int(minInt64(int64(frame.Len()), int64(n-len(buff)))))...)
+		if len(buff) == n {
+			return frame, buff, nil
+		}
+		next, err := stream.ReadFrame(ctx)
+		if err != nil {
+			getSpan(ctx).Warn("transport stream read continuation frame,", err.Error())
+			return frame, nil, err
+		}
+		frame.Close()
+		frame = next
+	}
+}
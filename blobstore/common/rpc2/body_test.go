@@ -16,7 +16,9 @@ package rpc2
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -70,6 +72,71 @@ func TestRpc2ReadFrame(t *testing.T) {
 		_, err = conn.ReadFrame(testCtx)
 		require.ErrorIs(t, io.EOF, err)
 	}
+	{
+		conn, err := cli.Connector.Get(testCtx, addr)
+		require.NoError(t, err)
+		var cell headerCell
+		cell.SetFramed(_maxCodecerSize+1, 0)
+		require.True(t, cell.Continued())
+		frame, _ := conn.AllocFrame(5)
+		frame.Write(append(cell[:], 0xee))
+		conn.WriteFrame(frame)
+		_, err = conn.ReadFrame(testCtx)
+		require.ErrorIs(t, io.EOF, err)
+	}
+}
+
+func handleFragmentedHeader(w ResponseWriter, req *Request) error {
+	var para strMessage
+	if err := req.ParseParameter(&para); err != nil {
+		return err
+	}
+	for key, val := range req.Header.M {
+		if strings.HasPrefix(key, "fragmented-") {
+			w.Header().Set(key, val)
+		}
+	}
+	w.SetContentLength(int64(len(para.Value)))
+	if err := w.WriteHeader(200, &para); err != nil {
+		return err
+	}
+	_, err := w.ReadFrom(bytes.NewReader([]byte(para.Value)))
+	return err
+}
+
+func TestRpc2FragmentedHeader(t *testing.T) {
+	handler := &Router{}
+	handler.Register("/", handleFragmentedHeader)
+	server, cli, shutdown := newServer("tcp", handler)
+	defer shutdown()
+
+	for _, size := range []int{1 << 10, server.Transport.MaxFrameSize, 3*server.Transport.MaxFrameSize + 7} {
+		var para, ret strMessage
+		para.Value = string(bytes.Repeat([]byte{'f'}, size))
+		req, err := NewRequest(testCtx, server.Name, "/", &para, nil)
+		require.NoError(t, err)
+		value := strings.Repeat("h", MaxHeaderLength)
+		n := size / MaxHeaderLength
+		for idx := 0; idx < n; idx++ {
+			req.Header.Set(fmt.Sprintf("fragmented-%d", idx), value)
+		}
+		resp, err := cli.Do(req, &ret)
+		require.NoError(t, err)
+		require.Equal(t, para.Value, ret.Value)
+		for idx := 0; idx < n; idx++ {
+			require.Equal(t, value, resp.Header.Get(fmt.Sprintf("fragmented-%d", idx)))
+		}
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, para.Value, string(body))
+		require.NoError(t, resp.Body.Close())
+	}
+
+	var para strMessage
+	para.Value = string(make([]byte, _maxCodecerSize))
+	req, err := NewRequest(testCtx, server.Name, "/", &para, nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, cli.DoWith(req, nil), ErrFrameHeader)
 }
 
 func handleRequstBody(w ResponseWriter, req *Request) error {
@@ -0,0 +1,132 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/util"
+)
+
+// ErrMemoryBudget request was rejected because of memory budget exceeded.
+var ErrMemoryBudget = NewError(429, "MemoryBudget", "rpc2: memory budget exceeded")
+
+// MemoryBudgetConfig limits request body bytes in flight of server,
+// the body bytes is reserved before reading and released after handled.
+//
+// The request waits at most WaitTimeout for the budget, the body is not read
+// in waiting, so the client is slowed down by flow control of transport.
+// Then the request is rejected with ErrMemoryBudget.
+// A request larger than budget is admitted only if nothing else is in flight,
+// and WaitTimeout should be less than ReadTimeout of server.
+type MemoryBudgetConfig struct {
+	TotalBytes      int64         `json:"total_bytes"`      // zero means no global limit
+	ConnectionBytes int64         `json:"connection_bytes"` // zero means no limit of each connection
+	WaitTimeout     util.Duration `json:"wait_timeout"`
+}
+
+type memoryBudget struct {
+	conf MemoryBudgetConfig
+
+	mu     sync.Mutex
+	used   int64
+	notify chan struct{} // closed when any bytes released
+}
+
+func newMemoryBudget(conf MemoryBudgetConfig) *memoryBudget {
+	return &memoryBudget{conf: conf, notify: make(chan struct{})}
+}
+
+func (b *memoryBudget) enabled() bool {
+	return b.conf.TotalBytes > 0 || b.conf.ConnectionBytes > 0
+}
+
+func (b *memoryBudget) inflight() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+// connection returns the budget of a new connection.
+func (b *memoryBudget) connection() *connBudget {
+	return &connBudget{budget: b}
+}
+
+// connBudget is the memory budget of one connection.
+type connBudget struct {
+	budget *memoryBudget
+	used   int64 // guarded by budget.mu
+}
+
+func (c *connBudget) inflight() int64 {
+	c.budget.mu.Lock()
+	defer c.budget.mu.Unlock()
+	return c.used
+}
+
+func (c *connBudget) tryReserve(n int64) (bool, <-chan struct{}) {
+	b := c.budget
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if (b.conf.TotalBytes <= 0 || b.used == 0 || b.used+n <= b.conf.TotalBytes) &&
+		(b.conf.ConnectionBytes <= 0 || c.used == 0 || c.used+n <= b.conf.ConnectionBytes) {
+		b.used += n
+		c.used += n
+		return true, nil
+	}
+	return false, b.notify
+}
+
+// reserve returns reserved bytes, waits for released bytes
+// if the budget is exceeded, returns ErrMemoryBudget after timeout.
+func (c *connBudget) reserve(n int64) (int64, error) {
+	if n <= 0 || !c.budget.enabled() {
+		return 0, nil
+	}
+	ok, notify := c.tryReserve(n)
+	if ok {
+		return n, nil
+	}
+	if c.budget.conf.WaitTimeout.Duration <= 0 {
+		return 0, ErrMemoryBudget
+	}
+
+	timer := time.NewTimer(c.budget.conf.WaitTimeout.Duration)
+	defer timer.Stop()
+	for {
+		select {
+		case <-notify:
+		case <-timer.C:
+			return 0, ErrMemoryBudget
+		}
+		if ok, notify = c.tryReserve(n); ok {
+			return n, nil
+		}
+	}
+}
+
+func (c *connBudget) release(n int64) {
+	if n <= 0 {
+		return
+	}
+	b := c.budget
+	b.mu.Lock()
+	b.used -= n
+	c.used -= n
+	close(b.notify)
+	b.notify = make(chan struct{})
+	b.mu.Unlock()
+}
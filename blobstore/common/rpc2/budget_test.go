@@ -0,0 +1,147 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBudgetReserve(t *testing.T) {
+	{
+		b := newMemoryBudget(MemoryBudgetConfig{})
+		cb := b.connection()
+		n, err := cb.reserve(1 << 30)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), n)
+		require.Equal(t, int64(0), b.inflight())
+	}
+	{
+		b := newMemoryBudget(MemoryBudgetConfig{TotalBytes: 100, ConnectionBytes: 60})
+		cb1, cb2 := b.connection(), b.connection()
+		n, err := cb1.reserve(50)
+		require.NoError(t, err)
+		require.Equal(t, int64(50), n)
+		_, err = cb1.reserve(20)
+		require.ErrorIs(t, err, ErrMemoryBudget)
+		n, err = cb2.reserve(50)
+		require.NoError(t, err)
+		_, err = cb2.reserve(1)
+		require.ErrorIs(t, err, ErrMemoryBudget)
+		require.Equal(t, int64(100), b.inflight())
+		require.Equal(t, int64(50), cb2.inflight())
+
+		cb2.release(n)
+		cb1.release(50)
+		require.Equal(t, int64(0), b.inflight())
+		// larger than budget if nothing in flight
+		n, err = cb1.reserve(200)
+		require.NoError(t, err)
+		require.Equal(t, int64(200), n)
+		cb1.release(n)
+	}
+	{
+		b := newMemoryBudget(MemoryBudgetConfig{TotalBytes: 100, WaitTimeout: utilDuration(50 * time.Millisecond)})
+		cb := b.connection()
+		n, err := cb.reserve(100)
+		require.NoError(t, err)
+		_, err = cb.reserve(10)
+		require.ErrorIs(t, err, ErrMemoryBudget)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := cb.reserve(10)
+			require.NoError(t, err)
+			cb.release(n)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		cb.release(n)
+		wg.Wait()
+		require.Equal(t, int64(0), cb.inflight())
+	}
+}
+
+func TestMemoryBudgetServer(t *testing.T) {
+	blocking := make(chan struct{})
+	handler := &Router{}
+	handler.Register("/", func(w ResponseWriter, req *Request) error {
+		if req.ContentLength > 0 {
+			buff := make([]byte, req.ContentLength)
+			if _, err := io.ReadFull(req.Body, buff); err != nil {
+				return err
+			}
+		}
+		if req.Header.Get("block") != "" {
+			<-blocking
+		}
+		return w.WriteOK(nil)
+	})
+
+	addr := getAddress("tcp")
+	server := Server{
+		Addresses: []NetworkAddress{{Network: "tcp", Address: addr}},
+		Transport: DefaultTransportConfig(),
+		Handler:   handler.MakeHandler(),
+		MemoryBudget: MemoryBudgetConfig{
+			TotalBytes:  1 << 10,
+			WaitTimeout: utilDuration(50 * time.Millisecond),
+		},
+	}
+	go func() { server.Serve() }()
+	server.WaitServe()
+	cli := Client{ConnectorConfig: ConnectorConfig{Network: "tcp"}, Retry: 1}
+	defer func() {
+		cli.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		server.Shutdown(ctx)
+		cancel()
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		req, err := NewRequest(testCtx, addr, "/", nil, bytes.NewReader(make([]byte, 1<<10)))
+		require.NoError(t, err)
+		req.Header.Set("block", "yes")
+		require.NoError(t, cli.DoWith(req, nil))
+	}()
+	for server.budget.inflight() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	req, err := NewRequest(testCtx, addr, "/", nil, bytes.NewReader(make([]byte, 10)))
+	require.NoError(t, err)
+	require.Equal(t, 429, DetectStatusCode(cli.DoWith(req, nil)))
+	// request without body is not limited
+	req, err = NewRequest(testCtx, addr, "/", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cli.DoWith(req, nil))
+
+	close(blocking)
+	wg.Wait()
+	require.Equal(t, int64(0), server.budget.inflight())
+	req, err = NewRequest(testCtx, addr, "/", nil, bytes.NewReader(make([]byte, 1<<10)))
+	require.NoError(t, err)
+	require.NoError(t, cli.DoWith(req, nil))
+}
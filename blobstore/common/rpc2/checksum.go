@@ -110,7 +110,8 @@ func unmarshalBlock(b []byte) (ChecksumBlock, error) {
 	if err := block.Unmarshal(b); err != nil {
 		return block, fmt.Errorf("rpc2: internal checksum %s", err.Error())
 	}
-	if _, exist := algorithms[block.Algorithm]; !exist || block.BlockSize == 0 {
+	if _, exist := algorithms[block.Algorithm]; !exist || block.BlockSize == 0 ||
+		block.windowSize() > _maxWindowSize {
 		return block, fmt.Errorf("rpc2: checksum(%s) not implements", block.String())
 	}
 	return block, nil
@@ -0,0 +1,321 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+// upload with retransmit window, window = blocks of encoded payload+cell
+// client | header | window1 |          | window1 |        | window2 | ... | trailer |
+// server |        |          | ack(bad) |         | ack ok |         | ...
+// ack    | cell(_ackCell) | block index or _ackOK |
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc2/transport"
+)
+
+const (
+	_ackCell = 0xffffffff // never be a size of header
+	_ackOK   = 0xffffffff
+	_ackSize = _headerCell + 4
+
+	_maxWindowSize       = 64 << 20
+	_maxWindowRetransmit = 3
+)
+
+// windowReader returns reader of the encoded window to write, replaced in tests.
+var windowReader = func(p []byte) io.Reader { return bytes.NewReader(p) }
+
+// retransmit returns true if the uploaded body with size is written in windows.
+func (cb *ChecksumBlock) retransmit(size int64) bool {
+	return cb.Window > 0 && cb.Direction.IsUpload() && size > 0
+}
+
+func (cb *ChecksumBlock) windowSize() int64 {
+	return int64(cb.Window) * int64(cb.BlockSize)
+}
+
+func writeAck(ctx context.Context, stream *transport.Stream, index uint32) error {
+	var ack [_ackSize]byte
+	binary.LittleEndian.PutUint32(ack[:_headerCell], _ackCell)
+	binary.LittleEndian.PutUint32(ack[_headerCell:], index)
+	_, err := stream.SizedWrite(ctx, bytes.NewReader(ack[:]), _ackSize)
+	return err
+}
+
+// readAckOrHeader returns the frame with unmarshaled header if it is not an ack.
+func readAckOrHeader(ctx context.Context, stream *transport.Stream, hdr Unmarshaler) (
+	frame *transport.FrameRead, index uint32, err error,
+) {
+	frame, err = stream.ReadFrame(ctx)
+	if err != nil {
+		getSpan(ctx).Warn("transport stream read frame,", err.Error())
+		return
+	}
+	defer func() {
+		if err != nil {
+			frame.Close()
+			frame = nil
+		}
+	}()
+
+	var cell headerCell
+	if err = readFrameCell(frame, &cell); err != nil {
+		return
+	}
+	if cell.Get() != _ackCell {
+		frame, err = unmarshalHeaderFrame(ctx, stream, frame, cell, hdr)
+		return
+	}
+	if frame.Len() != _ackSize-_headerCell {
+		err = ErrFrameProtocol
+		return
+	}
+	index = binary.LittleEndian.Uint32(frame.Bytes(_ackSize - _headerCell))
+	frame.Close()
+	return nil, index, nil
+}
+
+// writeWindows writes header, encoded body window by window and the trailer,
+// returns the frame of response header if server responded before
+// the body was fully written.
+func (req *Request) writeWindows(deadline time.Time, hdr Unmarshaler) (*transport.FrameRead, error) {
+	reqHeaderSize := req.RequestHeader.Size()
+	if reqHeaderSize > _maxCodecerSize {
+		return nil, ErrFrameHeader
+	}
+
+	var cell headerCell
+	cell.SetFramed(reqHeaderSize, req.conn.MaxPayloadSize())
+	req.conn.SetDeadline(deadline)
+	if _, err := req.conn.SizedWrite(req.ctx, codec2CellReader(cell, &req.RequestHeader),
+		_headerCell+reqHeaderSize); err != nil {
+		return nil, err
+	}
+
+	block := req.checksum
+	remain := req.ContentLength
+	buff := make([]byte, block.EncodeSize(minInt64(block.windowSize(), remain)))
+
+	var index uint32 // the first block index of window
+	for remain > 0 {
+		size := minInt64(block.windowSize(), remain)
+		encoded := buff[:block.EncodeSize(size)]
+		if _, err := io.ReadFull(req.Body, encoded); err != nil {
+			return nil, err
+		}
+
+		for {
+			if _, err := req.conn.SizedWrite(req.ctx, windowReader(encoded), len(encoded)); err != nil {
+				return nil, err
+			}
+			frame, bad, err := readAckOrHeader(req.ctx, req.conn, hdr)
+			if err != nil {
+				return nil, err
+			}
+			if frame != nil {
+				req.connBroken = true
+				return frame, nil
+			}
+			if bad == _ackOK {
+				break
+			}
+			if bad < index || bad >= index+block.Window {
+				return nil, ErrFrameProtocol
+			}
+			req.Span().Warnf("retransmit window(%d) of bad block(%d)", index/block.Window, bad)
+		}
+
+		index += block.Window
+		remain -= size
+	}
+
+	_, err := req.conn.SizedWrite(req.ctx, req.trailerReader(), req.Trailer.AllSize())
+	return nil, err
+}
+
+// windowBody decodes the uploaded body window by window, requests client
+// to retransmit the window if has bad block, then reads the trailer.
+type windowBody struct {
+	ctx    context.Context
+	stream *transport.Stream
+	frame  *transport.FrameRead // the remaining of header frame
+	block  ChecksumBlock
+	hasher hash.Hash
+
+	buff   []byte
+	off    int
+	end    int
+	remain int64  // body remain not received
+	index  uint32 // the first block index of next window
+	err    error
+
+	trailerSize int
+	trailer     *transport.SizedReader
+}
+
+func newWindowBody(ctx context.Context, stream *transport.Stream, frame *transport.FrameRead,
+	block ChecksumBlock, remain int64, trailerSize int,
+) *windowBody {
+	return &windowBody{
+		ctx:    ctx,
+		stream: stream,
+		frame:  frame,
+		block:  block,
+		hasher: block.Hasher(),
+
+		buff:   make([]byte, block.EncodeSize(minInt64(block.windowSize(), remain))),
+		remain: remain,
+
+		trailerSize: trailerSize,
+	}
+}
+
+// verify checks blocks of the encoded window and moves payloads to the front,
+// returns index of the bad block in window.
+func (r *windowBody) verify(encoded []byte) (uint32, error) {
+	blockSize, cellSize := int(r.block.BlockSize), r.hasher.Size()
+	var index uint32
+	n := 0
+	for off := 0; off < len(encoded); index++ {
+		size := blockSize
+		if rest := len(encoded) - off - cellSize; rest < size {
+			size = rest
+		}
+		payload := encoded[off : off+size]
+		r.hasher.Reset()
+		r.hasher.Write(payload)
+		if err := compare(r.block, encoded[off+size:off+size+cellSize], r.hasher); err != nil {
+			return index, err
+		}
+		n += copy(encoded[n:], payload)
+		off += size + cellSize
+	}
+	return 0, nil
+}
+
+func (r *windowBody) nextWindow() error {
+	size := minInt64(r.block.windowSize(), r.remain)
+	encoded := r.buff[:r.block.EncodeSize(size)]
+	for retry := 0; ; retry++ {
+		sr := r.stream.NewSizedReader(r.ctx, len(encoded), r.frame)
+		r.frame = nil
+		_, err := io.ReadFull(sr, encoded)
+		sr.Close()
+		if err != nil {
+			return err
+		}
+
+		bad, err := r.verify(encoded)
+		if err == nil {
+			break
+		}
+		if retry >= _maxWindowRetransmit {
+			return err
+		}
+		getSpan(r.ctx).Warnf("request to retransmit bad block(%d), %s", r.index+bad, err.Error())
+		if err = writeAck(r.ctx, r.stream, r.index+bad); err != nil {
+			return err
+		}
+	}
+	if err := writeAck(r.ctx, r.stream, _ackOK); err != nil {
+		return err
+	}
+
+	r.off, r.end = 0, int(size)
+	r.remain -= size
+	r.index += r.block.Window
+	return nil
+}
+
+// tryNextWindow returns io.EOF if the body was read completely.
+func (r *windowBody) tryNextWindow() error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.off < r.end {
+		return nil
+	}
+	if r.remain <= 0 {
+		return io.EOF
+	}
+	r.err = r.nextWindow()
+	return r.err
+}
+
+// Read reads the trailer after the body.
+func (r *windowBody) Read(p []byte) (int, error) {
+	if err := r.tryNextWindow(); err != nil {
+		if err != io.EOF {
+			return 0, err
+		}
+		if r.trailer == nil {
+			r.trailer = r.stream.NewSizedReader(r.ctx, r.trailerSize, nil)
+		}
+		return r.trailer.Read(p)
+	}
+	n := copy(p, r.buff[r.off:r.end])
+	r.off += n
+	return n, nil
+}
+
+func (r *windowBody) WriteTo(w io.Writer) (nn int64, err error) {
+	for {
+		if err = r.tryNextWindow(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		var n int
+		n, err = w.Write(r.buff[r.off:r.end])
+		r.off += n
+		nn += int64(n)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Finished has no frame of body and trailer in stream.
+func (r *windowBody) Finished() bool {
+	if r.err != nil || r.remain > 0 || r.off < r.end {
+		return false
+	}
+	return r.trailerSize == 0 || (r.trailer != nil && r.trailer.Finished())
+}
+
+func (r *windowBody) Close() error {
+	if r.frame != nil {
+		r.frame.Close()
+		r.frame = nil
+	}
+	if r.trailer != nil {
+		r.trailer.Close()
+	}
+	r.buff = nil
+	return nil
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
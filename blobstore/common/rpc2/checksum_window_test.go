@@ -0,0 +1,158 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newWindowBlock(blockSize, window uint32) ChecksumBlock {
+	return ChecksumBlock{
+		Algorithm: ChecksumAlgorithm_Crc_IEEE,
+		Direction: ChecksumDirection_Upload,
+		BlockSize: blockSize,
+		Window:    window,
+	}
+}
+
+func handleWindowUpload(w ResponseWriter, req *Request) error {
+	var args strMessage
+	req.ParseParameter(&args)
+	hasher := req.checksum.Hasher()
+	if _, err := req.Body.WriteTo(LimitWriter(hasher, req.ContentLength)); err != nil {
+		return err
+	}
+	args.Value = fmt.Sprintf("%v-%s", req.checksum.Readable(hasher.Sum(nil)), req.Trailer.Get("trailer"))
+	return w.WriteOK(&args)
+}
+
+func handleWindowNoBody(w ResponseWriter, req *Request) error {
+	return w.WriteOK(nil)
+}
+
+func corruptWindow(corrupt func(n int) bool) func() {
+	var n int32
+	old := windowReader
+	windowReader = func(p []byte) io.Reader {
+		if corrupt(int(atomic.AddInt32(&n, 1))) {
+			b := append([]byte{}, p...)
+			b[0]++
+			return bytes.NewReader(b)
+		}
+		return bytes.NewReader(p)
+	}
+	return func() { windowReader = old }
+}
+
+func windowUpload(t *testing.T, cli *Client, addr string, block ChecksumBlock, size int) error {
+	buff := make([]byte, size)
+	crand.Read(buff)
+	args := &strMessage{}
+	req, err := NewRequest(testCtx, addr, "/", args, bytes.NewReader(buff))
+	require.NoError(t, err)
+	req.ContentLength = int64(size)
+	req.OptionChecksum(block)
+	req.Trailer.SetLen("trailer", 4)
+	req.AfterBody = func() error {
+		req.Trailer.Set("trailer", "tail")
+		return nil
+	}
+	if err = cli.DoWith(req, args); err != nil {
+		return err
+	}
+	hasher := block.Hasher()
+	hasher.Write(buff)
+	require.Equal(t, fmt.Sprintf("%v-tail", block.Readable(hasher.Sum(nil))), args.Value)
+	return nil
+}
+
+func TestChecksumWindowUpload(t *testing.T) {
+	handler := &Router{}
+	handler.Register("/", handleWindowUpload)
+	server, cli, shutdown := newServer("tcp", handler)
+	defer shutdown()
+
+	for _, window := range []uint32{1, 3, 16} {
+		block := newWindowBlock(1<<10, window)
+		for _, size := range []int{1, 1 << 10, 3<<10 + 1, 64 << 10, 1<<20 + 7} {
+			require.NoError(t, windowUpload(t, cli, server.Name, block, size))
+		}
+	}
+	block := newWindowBlock(DefaultBlockSize, 4)
+	require.NoError(t, windowUpload(t, cli, server.Name, block, 4<<20+1))
+}
+
+func TestChecksumWindowRetransmit(t *testing.T) {
+	handler := &Router{}
+	handler.Register("/", handleWindowUpload)
+	server, cli, shutdown := newServer("tcp", handler)
+	defer shutdown()
+	block := newWindowBlock(1<<10, 4)
+
+	// the second and the third window was corrupted once
+	restore := corruptWindow(func(n int) bool { return n == 2 || n == 4 })
+	require.NoError(t, windowUpload(t, cli, server.Name, block, 16<<10))
+	restore()
+
+	// retransmit too many times
+	restore = corruptWindow(func(n int) bool { return n > 1 })
+	err := windowUpload(t, cli, server.Name, block, 16<<10)
+	restore()
+	require.Error(t, err)
+	require.Equal(t, 400, DetectStatusCode(err))
+
+	require.NoError(t, windowUpload(t, cli, server.Name, block, 16<<10))
+}
+
+func TestChecksumWindowNoBody(t *testing.T) {
+	handler := &Router{}
+	handler.Register("/", handleWindowNoBody)
+	server, cli, shutdown := newServer("tcp", handler)
+	defer shutdown()
+
+	for range [3]struct{}{} {
+		req, err := NewRequest(testCtx, server.Name, "/", nil, bytes.NewReader(make([]byte, 16<<10)))
+		require.NoError(t, err)
+		req.ContentLength = 16 << 10
+		req.OptionChecksum(newWindowBlock(1<<10, 4))
+		require.NoError(t, cli.DoWith(req, nil))
+		require.True(t, req.connBroken)
+	}
+}
+
+func TestChecksumWindowInvalid(t *testing.T) {
+	block := newWindowBlock(DefaultBlockSize, _maxWindowSize/DefaultBlockSize+1)
+	b, err := block.Marshal()
+	require.NoError(t, err)
+	_, err = unmarshalBlock(b)
+	require.Error(t, err)
+
+	req, err := NewRequest(testCtx, "", "/", nil, nil)
+	require.NoError(t, err)
+	require.Panics(t, func() { req.OptionChecksum(block) })
+
+	block.Window = 1
+	require.True(t, block.retransmit(1))
+	require.False(t, block.retransmit(0))
+	block.Direction = ChecksumDirection_Download
+	require.False(t, block.retransmit(1))
+}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	auth_proto "github.com/cubefs/cubefs/blobstore/common/rpc/auth/proto"
+	"github.com/cubefs/cubefs/blobstore/common/security"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/util"
 	"github.com/cubefs/cubefs/blobstore/util/defaulter"
 	"github.com/cubefs/cubefs/blobstore/util/retry"
@@ -43,6 +45,8 @@ type Client struct {
 	ResponseTimeout util.Duration `json:"response_timeout"`
 
 	Auth auth_proto.Config `json:"auth"`
+	// Queue limits requests in flight to each address
+	Queue QueueConfig `json:"queue"`
 
 	Selector rpc.Selector `json:"-"` // lb client
 	LbConfig struct {
@@ -53,6 +57,10 @@ type Client struct {
 		MaxFailsPeriodS    int      `json:"max_fails_period_s"`
 	} `json:"lb"`
 
+	sign    auth_proto.Signer
+	signErr error
+	queue   *sendQueue
+
 	// dead-lock copied Client when initOnce == 1
 	initOnce uint32 // 0 uninitialised, 1 doing, 2 done
 }
@@ -70,6 +78,15 @@ func (c *Client) Request(ctx context.Context, addr, path string,
 	return
 }
 
+// Methods returns registered methods of the server on addr.
+func (c *Client) Methods(ctx context.Context, addr string) ([]MethodInfo, error) {
+	ret := &AnyCodec[Methods]{}
+	if err := c.Request(ctx, addr, PathReflection, NoParameter, ret); err != nil {
+		return nil, err
+	}
+	return ret.Value.Methods, nil
+}
+
 func (c *Client) DoWith(req *Request, ret Unmarshaler) error {
 	resp, err := c.Do(req, ret)
 	if err != nil {
@@ -88,6 +105,8 @@ func (c *Client) Do(req *Request, ret Unmarshaler) (resp *Response, err error) {
 		if c.RetryOn == nil {
 			c.RetryOn = func(err error) bool { return DetectStatusCode(err) >= 500 }
 		}
+		c.sign, c.signErr = auth_proto.NewSigner(&c.Auth)
+		c.queue = newSendQueue(c.Queue)
 		atomic.StoreUint32(&c.initOnce, 2)
 	}
 
@@ -98,9 +117,8 @@ func (c *Client) Do(req *Request, ret Unmarshaler) (resp *Response, err error) {
 		return nil, ErrConnNoAddress
 	}
 
-	if c.Auth.EnableAuth && c.Auth.Secret != "" {
-		req.Header.Set(auth_proto.TokenHeaderKey, auth_proto.Encode(time.Now().Unix(),
-			[]byte(req.RemotePath), []byte(c.Auth.Secret)))
+	if c.signErr != nil {
+		return nil, c.signErr
 	}
 	if req.Header.Get(rpc.HeaderUA) == "" {
 		req.Header.Set(rpc.HeaderUA, rpc.UserAgent)
@@ -119,6 +137,19 @@ func (c *Client) Do(req *Request, ret Unmarshaler) (resp *Response, err error) {
 			lbHosts = lbHosts[1:]
 			req.RemoteAddr = lbHost.Host()
 		}
+		// sign every attempt, nonce of token can not be replayed
+		if c.sign != nil {
+			token, errSign := c.sign(security.ServiceRequest{
+				Method:     auth_proto.Rpc2Method,
+				Path:       req.RemotePath,
+				BodyDigest: security.BodyDigest(req.Parameter),
+			})
+			if errSign != nil {
+				return true, errSign
+			}
+			req.Header.stable = false
+			req.Header.Set(auth_proto.TokenHeaderKey, token)
+		}
 
 		resp, err = c.do(req, ret)
 		if err != nil {
@@ -175,13 +206,26 @@ func (c *Client) do(req *Request, ret Unmarshaler) (*Response, error) {
 
 	span := req.Span().WithOperation("client.do")
 
+	release := func() {}
+	if req.StreamCmd == StreamCmd_NOT {
+		var err error
+		if release, err = c.queue.acquire(req.Context(), req.RemoteAddr, req.admission); err != nil {
+			span.Warn("send queue ->", err)
+			return nil, err
+		}
+	}
 	conn, err := c.Connector.Get(req.Context(), req.RemoteAddr)
 	if err != nil {
 		span.Warn("get connection ->", err)
+		release()
 		return nil, err
 	}
+	// the stream is reused, reset priority and window of every request
+	conn.SetPriority(req.priority)
+	conn.SetWindow(req.window)
 	req.client = c
 	req.conn = conn
+	req.release = release
 	span.Debugf("get connection -> stream(%d, %v, %v)",
 		conn.ID(), conn.LocalAddr(), conn.RemoteAddr())
 
@@ -189,6 +233,7 @@ func (c *Client) do(req *Request, ret Unmarshaler) (*Response, error) {
 	if err != nil {
 		span.Warn("send request ->", err)
 		c.Connector.Put(req.Context(), req.conn, true)
+		req.releaseQueue()
 		return nil, err
 	}
 	if err = resp.ParseResult(ret); err != nil {
@@ -253,7 +298,10 @@ func NewRequest(ctx context.Context, addr, path string, para Marshaler, body io.
 
 	req := getRequest()
 	req.RemotePath = path
-	req.TraceID = getSpan(ctx).TraceID()
+	span := getSpan(ctx)
+	req.TraceID = span.TraceID()
+	// propagate sampling and baggage items of trace
+	span.Tracer().Inject(span.Context(), trace.TextMap, traceCarrier{header: &req.Header})
 	if psize := para.Size(); psize > 0 {
 		if cap(req.Parameter) >= psize {
 			nn, err := para.MarshalTo(req.Parameter[:psize])
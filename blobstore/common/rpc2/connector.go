@@ -119,7 +119,7 @@ type ConnectorConfig struct {
 	BufioReaderSize  int  `json:"bufio_reader_size"`
 	ConnectionWriteV bool `json:"connection_writev"`
 
-	// tcp or rdma
+	// tcp, rdma, unix or inproc, address with scheme dials by its network
 	Network     string        `json:"network"`
 	Dialer      Dialer        `json:"-"`
 	DialTimeout util.Duration `json:"dial_timeout"`
@@ -142,10 +142,27 @@ func defaultConnector(config ConnectorConfig) Connector {
 			}
 		case "rdma":
 			dialer = rdmaDialer{}
+		case NetworkUnix:
+			dialer = unixDialer{
+				timeout:  config.DialTimeout.Duration,
+				buffSize: config.BufioReaderSize,
+				writev:   config.ConnectionWriteV,
+			}
+		case NetworkInproc:
+			dialer = inprocDialer{buffSize: config.BufioReaderSize}
 		default:
 			panic("rpc2: connector network " + config.Network)
 		}
 	}
+	dialer = schemeDialer{
+		dialer: dialer,
+		unix: unixDialer{
+			timeout:  config.DialTimeout.Duration,
+			buffSize: config.BufioReaderSize,
+			writev:   config.ConnectionWriteV,
+		},
+		inproc: inprocDialer{buffSize: config.BufioReaderSize},
+	}
 	if config.Transport == nil {
 		config.Transport = DefaultTransportConfig()
 	}
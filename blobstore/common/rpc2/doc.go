@@ -44,4 +44,21 @@
 // :                      Fixed Trailer Bytes                      :
 // + - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - +
 
+// Header continued in multi Frame
+// the highest bit C of Header Length is set if the header oversizes payload
+// of one frame, the receiver reassembles it from the continuation frames.
+//  0                   1                   2                   3
+//  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+// +---------------------------------------------------------------+
+// |C|                      Header Length                          |
+// +---------------------------------------------------------------+
+// :                    Header in the first Frame                  :
+// + - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - +
+//                              |
+// :               Header Continued in Frames                      :
+//                              |
+// + - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - +
+// :                  Body Bytes and Trailer Bytes                 :
+// + - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - +
+
 package rpc2
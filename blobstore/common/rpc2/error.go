@@ -24,6 +24,7 @@ import (
 var (
 	DetectErrorCode  = rpc.DetectErrorCode
 	DetectStatusCode = rpc.DetectStatusCode
+	DetectErrorAttr  = rpc.DetectErrorAttr
 )
 
 func DetectError(err error) (int, string, error) {
@@ -48,6 +49,7 @@ func (m *Error) Unwrap() error     { return errors.New(m.Error()) }
 func (m *Error) StatusCode() int   { return int(m.GetStatus()) }
 func (m *Error) ErrorCode() string { return m.GetReason() }
 func (m *Error) Error() string     { return m.GetDetail() }
+func (m *Error) ErrorAttr() string { return m.GetAttr() }
 
 func ErrorString(err error) string {
 	if err == nil {
@@ -27,6 +27,7 @@ const (
 
 	HeaderInternalPrefix   = "internal-"
 	HeaderInternalChecksum = HeaderInternalPrefix + "stream-checksum"
+	HeaderInternalPriority = HeaderInternalPrefix + "stream-priority"
 )
 
 func withinLen(s string) bool { return len(s) <= MaxHeaderLength }
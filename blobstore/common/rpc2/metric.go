@@ -0,0 +1,134 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricConfig prometheus metrics of server, labeled by server name and the path
+// registered in router, requests matched no registered path are labeled as unmatched.
+type MetricConfig struct {
+	Enable bool `json:"enable"`
+}
+
+const metricUnmatchedRoute = "unmatched"
+
+var (
+	metricOnce sync.Once
+
+	metricLatencyBuckets = []float64{0.1, 0.5, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+	metricSizeBuckets    = prometheus.ExponentialBuckets(64, 4, 12) // 64B - 256MB
+
+	metricRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc2",
+		Name:      "server_requests",
+		Help:      "rpc2 server requests of method with status code",
+	}, []string{"server", "method", "status"})
+	metricLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc2",
+		Name:      "server_latency_ms",
+		Help:      "rpc2 server latency of method in millisecond",
+		Buckets:   metricLatencyBuckets,
+	}, []string{"server", "method"})
+	metricRequestSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc2",
+		Name:      "server_request_body_bytes",
+		Help:      "rpc2 server request body size of method",
+		Buckets:   metricSizeBuckets,
+	}, []string{"server", "method"})
+	metricResponseSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc2",
+		Name:      "server_response_body_bytes",
+		Help:      "rpc2 server response body size of method",
+		Buckets:   metricSizeBuckets,
+	}, []string{"server", "method"})
+	metricResponseFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc2",
+		Name:      "server_response_failures",
+		Help:      "rpc2 server failures of writing or flushing response of method",
+	}, []string{"server", "method"})
+	metricMirrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "rpc2",
+		Name:      "server_mirror_requests",
+		Help:      "rpc2 server mirrored requests of method with result",
+	}, []string{"server", "method", "result"})
+)
+
+func registerMetrics() {
+	metricOnce.Do(func() {
+		prometheus.MustRegister(metricRequests, metricLatency, metricRequestSize, metricResponseSize,
+			metricResponseFailures, metricMirrors)
+	})
+}
+
+type requestMetric struct {
+	enable    bool
+	server    string
+	startTime time.Time
+	reqSize   int64
+}
+
+// metricRoute returns the registered path as label of metrics, the remote
+// path is not used as it is supplied by peer and unbounded.
+func (req *Request) metricRoute() string {
+	if req.route == "" {
+		return metricUnmatchedRoute
+	}
+	return req.route
+}
+
+func (s *Server) startMetric(req *Request) requestMetric {
+	if !s.Metric.Enable {
+		return requestMetric{}
+	}
+	return requestMetric{
+		enable:    true,
+		server:    s.Name,
+		startTime: time.Now(),
+		reqSize:   req.ContentLength,
+	}
+}
+
+func (m requestMetric) report(req *Request, status int32, respSize int64) {
+	if !m.enable {
+		return
+	}
+	method := req.metricRoute()
+	metricRequests.WithLabelValues(m.server, method, strconv.Itoa(int(status))).Inc()
+	metricLatency.WithLabelValues(m.server, method).
+		Observe(float64(time.Since(m.startTime)) / float64(time.Millisecond))
+	metricRequestSize.WithLabelValues(m.server, method).Observe(float64(m.reqSize))
+	metricResponseSize.WithLabelValues(m.server, method).Observe(float64(respSize))
+}
+
+// reportFailure counts the response failed to write or flush, the request
+// is not reported as the peer has not received the response.
+func (m requestMetric) reportFailure(req *Request) {
+	if !m.enable {
+		return
+	}
+	metricResponseFailures.WithLabelValues(m.server, req.metricRoute()).Inc()
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerMetric(t *testing.T) {
+	name := "rpc2-metric-test"
+	metricRequests.Reset()
+	metricLatency.Reset()
+	metricRequestSize.Reset()
+	metricResponseSize.Reset()
+	metricResponseFailures.Reset()
+	server := Server{
+		Name:      name,
+		Addresses: []NetworkAddress{{Network: NetworkInproc, Address: name}},
+		Handler:   defHandler.MakeHandler(),
+		Metric:    MetricConfig{Enable: true},
+	}
+	go server.Serve()
+	server.WaitServe()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+
+	cli := &Client{ConnectorConfig: ConnectorConfig{Network: NetworkInproc}}
+	defer cli.Close()
+	for range [10]struct{}{} {
+		req, err := NewRequest(testCtx, name, "/", nil, bytes.NewReader(make([]byte, 1024)))
+		require.NoError(t, err)
+		require.NoError(t, cli.DoWith(req, nil))
+	}
+	req, err := NewRequest(testCtx, name, "/error", nil, nil)
+	require.NoError(t, err)
+	require.Error(t, cli.DoWith(req, nil))
+	// labeled by registered path, not the path of request
+	for _, path := range []string{"/not-found/1", "/not-found/2"} {
+		req, err = NewRequest(testCtx, name, path, nil, nil)
+		require.NoError(t, err)
+		require.Error(t, cli.DoWith(req, nil))
+	}
+
+	// reported after response flushed
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metricRequests.WithLabelValues(name, "/", "200")) == 10 &&
+			testutil.ToFloat64(metricRequests.WithLabelValues(name, "/error", "500")) >= 1 &&
+			testutil.ToFloat64(metricRequests.WithLabelValues(name, metricUnmatchedRoute, "404")) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, 3, testutil.CollectAndCount(metricLatency))
+	require.Equal(t, 3, testutil.CollectAndCount(metricRequestSize))
+	require.Equal(t, 3, testutil.CollectAndCount(metricResponseSize))
+
+	m := server.startMetric(&Request{})
+	m.reportFailure(&Request{route: "/"})
+	require.Equal(t, float64(1), testutil.ToFloat64(metricResponseFailures.WithLabelValues(name, "/")))
+
+	// disabled
+	m = (&Server{}).startMetric(&Request{})
+	require.False(t, m.enable)
+	m.report(&Request{}, 200, 0)
+	m.reportFailure(&Request{})
+}
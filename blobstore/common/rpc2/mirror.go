@@ -0,0 +1,252 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util"
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
+)
+
+const (
+	defaultMirrorQueueSize   = 1024
+	defaultMirrorConcurrency = 4
+	defaultMirrorMaxBodySize = 1 << 20
+	defaultMirrorTimeout     = 3 * time.Second
+)
+
+// MirrorConfig duplicates a percentage of requests to a shadow endpoint,
+// the shadow is canary tested with production traffic.
+//
+// The header, parameter and body of sampled request are copied, and queued
+// to send asynchronously after the request has been handled, so the shadow
+// never runs ahead of the serving, and the request is labeled by its route.
+// The response of shadow is ignored.
+// Requests are dropped if the queue is full, so the serving is never blocked
+// by the shadow. Stream requests and internal headers are not mirrored,
+// body of request larger than MaxBodySize is not mirrored either.
+type MirrorConfig struct {
+	Address     string   `json:"address"` // shadow endpoint, disabled if empty
+	Percent     float64  `json:"percent"` // (0, 100] percentage of sampled requests
+	Paths       []string `json:"paths"`   // mirror all paths if empty
+	MaxBodySize int64    `json:"max_body_size"`
+	QueueSize   int      `json:"queue_size"`
+	Concurrency int      `json:"concurrency"`
+
+	Client *Client `json:"client,omitempty"`
+}
+
+func (c *MirrorConfig) enabled() bool {
+	return c.Address != "" && c.Percent > 0
+}
+
+type mirrorRequest struct {
+	traceID string
+	path    string
+	route   string // label of metrics
+	header  Header
+	para    []byte
+	body    []byte
+}
+
+type mirror struct {
+	conf   MirrorConfig
+	server string
+	metric bool
+	paths  map[string]struct{}
+	client *Client
+
+	queue     chan *mirrorRequest
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newMirror(conf MirrorConfig, server string, metric bool) *mirror {
+	if conf.Percent > 100 {
+		conf.Percent = 100
+	}
+	defaulter.LessOrEqual(&conf.MaxBodySize, int64(defaultMirrorMaxBodySize))
+	defaulter.LessOrEqual(&conf.QueueSize, defaultMirrorQueueSize)
+	defaulter.LessOrEqual(&conf.Concurrency, defaultMirrorConcurrency)
+	if conf.Client == nil {
+		conf.Client = &Client{
+			ConnectorConfig: ConnectorConfig{Network: "tcp"},
+			Retry:           1,
+			Timeout:         util.Duration{Duration: defaultMirrorTimeout},
+		}
+	}
+
+	m := &mirror{
+		conf:   conf,
+		server: server,
+		metric: metric,
+		client: conf.Client,
+		queue:  make(chan *mirrorRequest, conf.QueueSize),
+		done:   make(chan struct{}),
+	}
+	if len(conf.Paths) > 0 {
+		m.paths = make(map[string]struct{}, len(conf.Paths))
+		for _, path := range conf.Paths {
+			m.paths[path] = struct{}{}
+		}
+	}
+	for i := 0; i < conf.Concurrency; i++ {
+		go m.loop()
+	}
+	return m
+}
+
+func (m *mirror) sampled(req *Request) bool {
+	if req.stream != nil || req.ContentLength > m.conf.MaxBodySize {
+		return false
+	}
+	if m.paths != nil {
+		if _, ok := m.paths[req.RemotePath]; !ok {
+			return false
+		}
+	}
+	return m.conf.Percent >= 100 || rand.Float64()*100 < m.conf.Percent
+}
+
+// handler wraps h, the body of sampled request is read into memory
+// and replaced before calling h, and queued after h returned.
+func (m *mirror) handler(h Handle) Handle {
+	return func(w ResponseWriter, req *Request) error {
+		if !m.sampled(req) {
+			return h(w, req)
+		}
+
+		mreq := &mirrorRequest{
+			traceID: req.TraceID,
+			path:    req.RemotePath,
+			header:  Header{},
+		}
+		for key, val := range req.Header.M {
+			if !strings.HasPrefix(key, HeaderInternalPrefix) {
+				mreq.header.Set(key, val)
+			}
+		}
+		if len(req.Parameter) > 0 {
+			mreq.para = append([]byte(nil), req.Parameter...)
+		}
+		if req.ContentLength > 0 {
+			mreq.body = make([]byte, req.ContentLength)
+			if _, err := io.ReadFull(req.Body, mreq.body); err != nil {
+				return NewError(400, "MirrorBody", err.Error())
+			}
+			req.Body = &mirrorBody{data: mreq.body, body: req.Body}
+		}
+
+		err := h(w, req)
+		mreq.route = req.metricRoute()
+		select {
+		case m.queue <- mreq:
+		default:
+			m.report(mreq.route, "dropped")
+		}
+		return err
+	}
+}
+
+func (m *mirror) loop() {
+	for {
+		select {
+		case <-m.done:
+			return
+		case mreq := <-m.queue:
+			m.send(mreq)
+		}
+	}
+}
+
+func (m *mirror) send(mreq *mirrorRequest) {
+	_, ctx := trace.StartSpanFromContextWithTraceID(context.Background(), "", mreq.traceID)
+	req, err := NewRequest(ctx, m.conf.Address, mreq.path, nil, bytes.NewReader(mreq.body))
+	if err != nil {
+		m.report(mreq.route, "failed")
+		return
+	}
+	req.Parameter = mreq.para
+	for key, val := range mreq.header.M {
+		req.Header.Set(key, val)
+	}
+
+	if err = m.client.DoWith(req, nil); err != nil {
+		req.Span().Debugf("mirror %s to %s, %s", mreq.path, m.conf.Address, err.Error())
+		m.report(mreq.route, "failed")
+	} else {
+		m.report(mreq.route, "sent")
+	}
+	req.reuse()
+}
+
+func (m *mirror) report(route, result string) {
+	if m.metric {
+		metricMirrors.WithLabelValues(m.server, route, result).Inc()
+	}
+}
+
+func (m *mirror) close() {
+	m.closeOnce.Do(func() {
+		close(m.done)
+		m.client.Close()
+	})
+}
+
+// mirrorBody replaces request body which has been read into memory,
+// closes the original body at last.
+type mirrorBody struct {
+	data []byte
+	off  int
+	body Body
+}
+
+func (b *mirrorBody) Read(p []byte) (int, error) {
+	if b.off >= len(b.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, b.data[b.off:])
+	b.off += n
+	return n, nil
+}
+
+func (b *mirrorBody) WriteTo(w io.Writer) (int64, error) {
+	remain := len(b.data) - b.off
+	if remain == 0 {
+		return 0, io.EOF
+	}
+	lw, ok := w.(*LimitedWriter)
+	if !ok {
+		return 0, ErrLimitedWriter
+	}
+	if lw.n > int64(remain) {
+		return 0, io.ErrShortWrite
+	}
+	n, err := lw.Write(b.data[b.off : b.off+int(lw.n)])
+	b.off += n
+	return int64(n), err
+}
+
+func (b *mirrorBody) Close() error {
+	return b.body.Close()
+}
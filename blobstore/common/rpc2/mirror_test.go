@@ -0,0 +1,168 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mirrorRecord struct {
+	path   string
+	header string
+	para   string
+	body   string
+}
+
+func TestMirrorBody(t *testing.T) {
+	body := &mirrorBody{data: []byte("0123456789"), body: NoBody}
+	buff := make([]byte, 4)
+	n, err := body.Read(buff)
+	require.NoError(t, err)
+	require.Equal(t, "0123", string(buff[:n]))
+
+	_, err = body.WriteTo(bytes.NewBuffer(nil))
+	require.ErrorIs(t, err, ErrLimitedWriter)
+	_, err = body.WriteTo(LimitWriter(bytes.NewBuffer(nil), 7))
+	require.ErrorIs(t, err, io.ErrShortWrite)
+
+	w := bytes.NewBuffer(nil)
+	nn, err := body.WriteTo(LimitWriter(w, 6))
+	require.NoError(t, err)
+	require.Equal(t, int64(6), nn)
+	require.Equal(t, "456789", w.String())
+	_, err = body.Read(buff)
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, body.Close())
+}
+
+func TestMirrorServer(t *testing.T) {
+	var mu sync.Mutex
+	var records []mirrorRecord
+	shadowRouter := &Router{}
+	shadowRouter.Register("/mirror", func(w ResponseWriter, req *Request) error {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		records = append(records, mirrorRecord{
+			path:   req.RemotePath,
+			header: req.Header.Get("key"),
+			para:   string(req.Parameter),
+			body:   string(body),
+		})
+		mu.Unlock()
+		return NewError(500, "Shadow", "response of shadow is ignored")
+	})
+	shadow, _, shadowShutdown := newServer("tcp", shadowRouter)
+	defer shadowShutdown()
+
+	handle := func(w ResponseWriter, req *Request) error {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		return w.WriteOK(&AnyCodec[string]{Value: string(body)})
+	}
+	router := &Router{}
+	router.Register("/mirror", handle)
+	router.Register("/nomirror", handle)
+
+	addr := getAddress("tcp")
+	server := Server{
+		Addresses: []NetworkAddress{{Network: "tcp", Address: addr}},
+		Transport: DefaultTransportConfig(),
+		Handler:   router.MakeHandler(),
+		Mirror: MirrorConfig{
+			Address:     shadow.Name,
+			Percent:     100,
+			Paths:       []string{"/mirror"},
+			MaxBodySize: 16,
+		},
+	}
+	go func() { server.Serve() }()
+	server.WaitServe()
+	cli := Client{ConnectorConfig: ConnectorConfig{Network: "tcp"}, Retry: 1}
+	defer func() {
+		cli.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		server.Shutdown(ctx)
+		cancel()
+	}()
+
+	request := func(path, body string) {
+		req, err := NewRequest(testCtx, addr, path, &AnyCodec[string]{Value: "para"}, bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		req.Header.Set("key", "value")
+		req.OptionCrcUpload()
+		ret := &AnyCodec[string]{}
+		require.NoError(t, cli.DoWith(req, ret))
+		require.Equal(t, body, ret.Value)
+	}
+	request("/mirror", "mirrored body")
+	request("/nomirror", "not in paths")
+	request("/mirror", "larger than max body size")
+	request("/mirror", "")
+
+	para, err := (&AnyCodec[string]{Value: "para"}).Marshal()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(records) == 2
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 2, len(records))
+	bodies := map[string]bool{}
+	for _, r := range records {
+		require.Equal(t, "/mirror", r.path)
+		require.Equal(t, "value", r.header)
+		require.Equal(t, string(para), r.para)
+		bodies[r.body] = true
+	}
+	require.True(t, bodies["mirrored body"])
+	require.True(t, bodies[""])
+}
+
+func TestMirrorDropped(t *testing.T) {
+	m := &mirror{
+		conf:  MirrorConfig{Percent: 100, MaxBodySize: 1 << 10},
+		queue: make(chan *mirrorRequest, 1),
+	}
+	var handled int
+	h := m.handler(func(w ResponseWriter, req *Request) error {
+		handled++
+		return nil
+	})
+	for range [3]struct{}{} {
+		req := &Request{RequestHeader: RequestHeader{RemotePath: "/"}}
+		require.NoError(t, h(nil, req))
+	}
+	require.Equal(t, 3, handled)
+	require.Equal(t, 1, len(m.queue))
+
+	require.False(t, m.sampled(&Request{stream: &serverStream{}}))
+	require.False(t, m.sampled(&Request{RequestHeader: RequestHeader{ContentLength: 1 << 11}}))
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HeaderService name of virtual service which the request is routed to.
+const HeaderService = "rpc2-service"
+
+type servicePrefix struct {
+	prefix  string
+	handler Handler
+}
+
+// ServiceMux routes requests of multiple virtual services sharing one listener,
+// each service has its own handler, like a Router with separate middlewares,
+// interceptors, limiters and auth policies.
+//
+// The service is selected by header HeaderService of the request at first,
+// then by the longest registered prefix of path, otherwise the default handler.
+type ServiceMux struct {
+	services map[string]Handler
+	prefixes []servicePrefix
+	fallback Handler
+}
+
+var _ Handler = (*ServiceMux)(nil)
+
+// Register registers handler of the service named in header.
+func (m *ServiceMux) Register(service string, h Handler) {
+	if service == "" {
+		panic("rpc2: empty service name")
+	}
+	if m.services == nil {
+		m.services = make(map[string]Handler)
+	}
+	if _, exist := m.services[service]; exist {
+		panic(fmt.Sprintf("rpc2: service(%s) has registered", service))
+	}
+	m.services[service] = h
+}
+
+// RegisterPrefix registers handler of requests with the path prefix.
+func (m *ServiceMux) RegisterPrefix(prefix string, h Handler) {
+	if prefix == "" {
+		panic("rpc2: empty service prefix")
+	}
+	for _, p := range m.prefixes {
+		if p.prefix == prefix {
+			panic(fmt.Sprintf("rpc2: service prefix(%s) has registered", prefix))
+		}
+	}
+	m.prefixes = append(m.prefixes, servicePrefix{prefix: prefix, handler: h})
+	sort.SliceStable(m.prefixes, func(i, j int) bool {
+		return len(m.prefixes[i].prefix) > len(m.prefixes[j].prefix)
+	})
+}
+
+// Default sets handler of requests matched no service.
+func (m *ServiceMux) Default(h Handler) {
+	m.fallback = h
+}
+
+func (m *ServiceMux) route(req *Request) (Handler, error) {
+	if service := req.Header.Get(HeaderService); service != "" {
+		if h, exist := m.services[service]; exist {
+			return h, nil
+		}
+		return nil, NewErrorf(404, "NoService", "no service(%s)", service)
+	}
+	for _, p := range m.prefixes {
+		if strings.HasPrefix(req.RemotePath, p.prefix) {
+			return p.handler, nil
+		}
+	}
+	if m.fallback != nil {
+		return m.fallback, nil
+	}
+	return nil, NewErrorf(404, "NoService", "no service for path(%s)", req.RemotePath)
+}
+
+func (m *ServiceMux) Handle(w ResponseWriter, req *Request) error {
+	h, err := m.route(req)
+	if err != nil {
+		return err
+	}
+	return h.Handle(w, req)
+}
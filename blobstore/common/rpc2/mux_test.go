@@ -0,0 +1,77 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func handleStatus(status int) Handle {
+	return func(ResponseWriter, *Request) error { return &Error{Status: int32(status)} }
+}
+
+func TestRpc2ServiceMux(t *testing.T) {
+	var data, admin, fallback Router
+	data.Register("/get", handleStatus(601))
+	admin.Middleware(func(w ResponseWriter, req *Request) error {
+		if req.Header.Get("token") != "admin" {
+			return &Error{Status: 403}
+		}
+		return nil
+	})
+	admin.Register("/admin/stat", handleStatus(602))
+	admin.Register("/get", handleStatus(603))
+	fallback.Register("get", handleStatus(604))
+
+	var mux ServiceMux
+	mux.Register("data", data.MakeHandler())
+	mux.Register("admin", admin.MakeHandler())
+	mux.RegisterPrefix("/admin/", admin.MakeHandler())
+	mux.RegisterPrefix("/", data.MakeHandler())
+	require.Panics(t, func() { mux.Register("", nil) })
+	require.Panics(t, func() { mux.Register("data", nil) })
+	require.Panics(t, func() { mux.RegisterPrefix("", nil) })
+	require.Panics(t, func() { mux.RegisterPrefix("/admin/", nil) })
+
+	server, cli, shutdown := newServerWithHandler("tcp", &mux)
+	defer shutdown()
+
+	do := func(service, path, token string) int {
+		req, err := NewRequest(testCtx, server.Name, path, nil, nil)
+		require.NoError(t, err)
+		if service != "" {
+			req.OptionService(service)
+		}
+		req.Header.Set("token", token)
+		return DetectStatusCode(cli.DoWith(req, nil))
+	}
+	// by header
+	require.Equal(t, 601, do("data", "/get", ""))
+	require.Equal(t, 403, do("admin", "/get", ""))
+	require.Equal(t, 603, do("admin", "/get", "admin"))
+	require.Equal(t, 404, do("none", "/get", "admin"))
+	require.Equal(t, 404, do("data", "/admin/stat", "admin"))
+	// by prefix
+	require.Equal(t, 403, do("", "/admin/stat", ""))
+	require.Equal(t, 602, do("", "/admin/stat", "admin"))
+	require.Equal(t, 601, do("", "/get", ""))
+	require.Equal(t, 404, do("", "get", ""))
+
+	// by default
+	mux.Default(fallback.MakeHandler())
+	require.Equal(t, 604, do("", "get", ""))
+}
@@ -0,0 +1,201 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/rpc2/transport"
+)
+
+// networks of transport besides tcp and rdma,
+// address with scheme selects the network, like:
+//
+//	unix:///var/run/blobnode.sock  same host traffic by unix domain socket
+//	inproc://shardnode             in process loopback for tests and embedded deployments
+const (
+	NetworkUnix   = "unix"
+	NetworkInproc = "inproc"
+)
+
+var ErrInprocNotFound = errors.New("rpc2: inproc address not found")
+
+const schemeSep = "://"
+
+// parseAddress returns network and address of the scheme,
+// network is empty if address has no scheme.
+func parseAddress(addr string) (network, address string) {
+	idx := strings.Index(addr, schemeSep)
+	if idx < 0 {
+		return "", addr
+	}
+	switch scheme := addr[:idx]; scheme {
+	case NetworkUnix, NetworkInproc:
+		return scheme, addr[idx+len(schemeSep):]
+	default:
+		return "", addr
+	}
+}
+
+// schemeDialer dials address with scheme by its network, others by default dialer.
+type schemeDialer struct {
+	dialer Dialer
+	unix   unixDialer
+	inproc inprocDialer
+}
+
+func (d schemeDialer) Dial(ctx context.Context, addr string) (transport.Conn, error) {
+	network, address := parseAddress(addr)
+	switch network {
+	case NetworkUnix:
+		return d.unix.Dial(ctx, address)
+	case NetworkInproc:
+		return d.inproc.Dial(ctx, address)
+	default:
+		return d.dialer.Dial(ctx, addr)
+	}
+}
+
+type unixDialer struct {
+	timeout  time.Duration
+	buffSize int
+	writev   bool
+}
+
+func (u unixDialer) Dial(ctx context.Context, addr string) (transport.Conn, error) {
+	var d net.Dialer
+	d.Timeout = u.timeout
+	conn, err := d.DialContext(ctx, NetworkUnix, addr)
+	if err != nil {
+		return nil, err
+	}
+	return newTcpConn(conn, u.buffSize, u.writev), nil
+}
+
+func newUnixListener(addr string) (net.Listener, error) {
+	// remove the socket file left by last process
+	if info, err := os.Stat(addr); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err = os.Remove(addr); err != nil {
+			return nil, err
+		}
+	}
+	return net.Listen(NetworkUnix, addr)
+}
+
+// in process loopback transport, the server listens on a name
+// registered in this process, and clients dial it with a pipe.
+var (
+	inprocMu        sync.RWMutex
+	inprocListeners = make(map[string]*inprocListener)
+	inprocConnID    uint64
+)
+
+type inprocAddr struct {
+	name string
+	id   uint64 // unique in process, keep local address of sessions different
+}
+
+func (inprocAddr) Network() string  { return NetworkInproc }
+func (a inprocAddr) String() string { return fmt.Sprintf("%s-%d", a.name, a.id) }
+
+type inprocConn struct {
+	net.Conn
+	local, remote inprocAddr
+}
+
+func (c *inprocConn) LocalAddr() net.Addr  { return c.local }
+func (c *inprocConn) RemoteAddr() net.Addr { return c.remote }
+
+type inprocListener struct {
+	name   string
+	connCh chan net.Conn
+	once   sync.Once
+	closed chan struct{}
+}
+
+func newInprocListener(name string) (net.Listener, error) {
+	inprocMu.Lock()
+	defer inprocMu.Unlock()
+	if _, has := inprocListeners[name]; has {
+		return nil, fmt.Errorf("rpc2: inproc address %s already in use", name)
+	}
+	ln := &inprocListener{
+		name:   name,
+		connCh: make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+	inprocListeners[name] = ln
+	return ln, nil
+}
+
+func (ln *inprocListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-ln.connCh:
+		return conn, nil
+	case <-ln.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (ln *inprocListener) Close() error {
+	ln.once.Do(func() {
+		inprocMu.Lock()
+		if inprocListeners[ln.name] == ln {
+			delete(inprocListeners, ln.name)
+		}
+		inprocMu.Unlock()
+		close(ln.closed)
+	})
+	return nil
+}
+
+func (ln *inprocListener) Addr() net.Addr { return inprocAddr{name: ln.name} }
+
+type inprocDialer struct {
+	buffSize int
+}
+
+func (d inprocDialer) Dial(ctx context.Context, addr string) (transport.Conn, error) {
+	inprocMu.RLock()
+	ln, has := inprocListeners[addr]
+	inprocMu.RUnlock()
+	if !has {
+		return nil, ErrInprocNotFound
+	}
+
+	clientAddr := inprocAddr{name: addr, id: atomic.AddUint64(&inprocConnID, 1)}
+	serverAddr := inprocAddr{name: addr, id: atomic.AddUint64(&inprocConnID, 1)}
+	client, server := net.Pipe()
+	select {
+	case ln.connCh <- &inprocConn{Conn: server, local: serverAddr, remote: clientAddr}:
+	case <-ln.closed:
+		client.Close()
+		server.Close()
+		return nil, ErrInprocNotFound
+	case <-ctx.Done():
+		client.Close()
+		server.Close()
+		return nil, ctx.Err()
+	}
+	return newTcpConn(&inprocConn{Conn: client, local: clientAddr, remote: serverAddr}, d.buffSize, false), nil
+}
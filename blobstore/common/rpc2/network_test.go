@@ -0,0 +1,127 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkParseAddress(t *testing.T) {
+	for _, cs := range []struct {
+		addr, network, address string
+	}{
+		{"127.0.0.1:9500", "", "127.0.0.1:9500"},
+		{"unix:///var/run/a.sock", NetworkUnix, "/var/run/a.sock"},
+		{"inproc://shardnode", NetworkInproc, "shardnode"},
+		{"http://127.0.0.1:9500", "", "http://127.0.0.1:9500"},
+	} {
+		network, address := parseAddress(cs.addr)
+		require.Equal(t, cs.network, network)
+		require.Equal(t, cs.address, address)
+	}
+}
+
+func runNetworkServer(t *testing.T, addr NetworkAddress) func() {
+	server := Server{
+		Name:      addr.Address,
+		Addresses: []NetworkAddress{addr},
+		Handler:   defHandler.MakeHandler(),
+	}
+	go func() {
+		if err := server.Serve(); err != nil && err != ErrServerClosed {
+			panic(err)
+		}
+	}()
+	server.WaitServe()
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		server.Shutdown(ctx)
+	}
+}
+
+func testNetworkRequest(t *testing.T, cli *Client, addr string) {
+	var wg sync.WaitGroup
+	for range [32]struct{}{} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buff := make([]byte, 1<<16)
+			req, err := NewRequest(testCtx, addr, "/", nil, bytes.NewReader(buff))
+			require.NoError(t, err)
+			require.NoError(t, cli.DoWith(req, nil))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNetworkUnix(t *testing.T) {
+	dir, err := os.MkdirTemp("", "rpc2-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "rpc2.sock")
+
+	shutdown := runNetworkServer(t, NetworkAddress{Network: NetworkUnix, Address: sock})
+	shutdown()
+	// socket file left by last server
+	shutdown = runNetworkServer(t, NetworkAddress{Network: NetworkUnix, Address: sock})
+	defer shutdown()
+
+	cli := &Client{ConnectorConfig: ConnectorConfig{Network: "tcp"}}
+	defer cli.Close()
+	testNetworkRequest(t, cli, "unix://"+sock)
+
+	cli = &Client{ConnectorConfig: ConnectorConfig{Network: NetworkUnix}}
+	defer cli.Close()
+	testNetworkRequest(t, cli, sock)
+}
+
+func TestNetworkInproc(t *testing.T) {
+	name := "rpc2-inproc-test"
+	shutdown := runNetworkServer(t, NetworkAddress{Network: NetworkInproc, Address: name})
+	_, err := newInprocListener(name)
+	require.Error(t, err)
+
+	cli := &Client{ConnectorConfig: ConnectorConfig{Network: "tcp"}}
+	testNetworkRequest(t, cli, "inproc://"+name)
+	cli.Close()
+
+	cli = &Client{ConnectorConfig: ConnectorConfig{Network: NetworkInproc}}
+	testNetworkRequest(t, cli, name)
+	cli.Close()
+
+	shutdown()
+	_, err = inprocDialer{}.Dial(testCtx, name)
+	require.ErrorIs(t, err, ErrInprocNotFound)
+
+	ln, err := newInprocListener(name)
+	require.NoError(t, err)
+	require.Equal(t, NetworkInproc, ln.Addr().Network())
+	ctx, cancel := context.WithCancel(testCtx)
+	cancel()
+	_, err = inprocDialer{}.Dial(ctx, name)
+	require.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, ln.Close())
+	_, err = ln.Accept()
+	require.Error(t, err)
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/util"
+)
+
+// ErrBusy request was rejected by client because the target is busy,
+// the send queue of target is full or the admission deadline exceeded.
+var ErrBusy = NewError(429, "Busy", "rpc2: client queue of target is busy")
+
+// QueueConfig limits requests in flight of client to each target address.
+//
+// A request is in flight from getting connection to closing response body,
+// the excess requests wait in the queue at most AdmissionTimeout and the
+// deadline of context, then are rejected with ErrBusy. The request is
+// rejected immediately if MaxWaiting requests are waiting already.
+// Stream requests are not limited.
+type QueueConfig struct {
+	MaxInflight      int           `json:"max_inflight"` // zero means no limit
+	MaxWaiting       int           `json:"max_waiting"`
+	AdmissionTimeout util.Duration `json:"admission_timeout"`
+}
+
+type sendQueue struct {
+	conf QueueConfig
+
+	mu      sync.Mutex
+	targets map[string]*targetQueue
+}
+
+type targetQueue struct {
+	inflight int
+	waiting  int
+	notify   chan struct{} // closed when any request released
+}
+
+func newSendQueue(conf QueueConfig) *sendQueue {
+	if conf.MaxInflight <= 0 {
+		return nil
+	}
+	return &sendQueue{conf: conf, targets: make(map[string]*targetQueue)}
+}
+
+func (q *sendQueue) tryAcquire(addr string, wait bool) (bool, <-chan struct{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	t := q.targets[addr]
+	if t == nil {
+		t = &targetQueue{notify: make(chan struct{})}
+		q.targets[addr] = t
+	}
+	if t.inflight < q.conf.MaxInflight {
+		t.inflight++
+		return true, nil
+	}
+	if wait {
+		if t.waiting >= q.conf.MaxWaiting {
+			return false, nil
+		}
+		t.waiting++
+	}
+	return false, t.notify
+}
+
+func (q *sendQueue) leave(addr string) {
+	q.mu.Lock()
+	t := q.targets[addr]
+	t.waiting--
+	q.tryRemove(addr, t)
+	q.mu.Unlock()
+}
+
+func (q *sendQueue) release(addr string) {
+	q.mu.Lock()
+	t := q.targets[addr]
+	t.inflight--
+	close(t.notify)
+	t.notify = make(chan struct{})
+	q.tryRemove(addr, t)
+	q.mu.Unlock()
+}
+
+func (q *sendQueue) tryRemove(addr string, t *targetQueue) {
+	if t.inflight == 0 && t.waiting == 0 {
+		delete(q.targets, addr)
+	}
+}
+
+// acquire returns function to release the in flight request of addr,
+// waits before the admission deadline if the target is full.
+func (q *sendQueue) acquire(ctx context.Context, addr string, timeout time.Duration) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+	release := func() { q.release(addr) }
+	ok, notify := q.tryAcquire(addr, true)
+	if ok {
+		return release, nil
+	}
+	if notify == nil {
+		return nil, ErrBusy
+	}
+	defer q.leave(addr)
+
+	if timeout <= 0 {
+		timeout = q.conf.AdmissionTimeout.Duration
+	}
+	if timeout <= 0 {
+		return nil, ErrBusy
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-notify:
+		case <-timer.C:
+			return nil, ErrBusy
+		case <-ctx.Done():
+			if ctx.Err() == context.Canceled {
+				return nil, ctx.Err()
+			}
+			return nil, ErrBusy
+		}
+		if ok, notify = q.tryAcquire(addr, false); ok {
+			return release, nil
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rpc2
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendQueue(t *testing.T) {
+	ctx := context.Background()
+	{
+		q := newSendQueue(QueueConfig{})
+		require.Nil(t, q)
+		release, err := q.acquire(ctx, "addr", 0)
+		require.NoError(t, err)
+		release()
+	}
+
+	q := newSendQueue(QueueConfig{MaxInflight: 1, MaxWaiting: 1})
+	release, err := q.acquire(ctx, "addr", 0)
+	require.NoError(t, err)
+	// other target is not limited
+	releaseOther, err := q.acquire(ctx, "other", 0)
+	require.NoError(t, err)
+	releaseOther()
+
+	// no admission timeout
+	_, err = q.acquire(ctx, "addr", 0)
+	require.ErrorIs(t, err, ErrBusy)
+	_, err = q.acquire(ctx, "addr", 10*time.Millisecond)
+	require.ErrorIs(t, err, ErrBusy)
+	ctxTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	_, err = q.acquire(ctxTimeout, "addr", time.Second)
+	require.ErrorIs(t, err, ErrBusy)
+	cancel()
+	ctxCancel, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = q.acquire(ctxCancel, "addr", time.Second)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// waiter is admitted after released, and the queue is full
+	done := make(chan struct{})
+	go func() {
+		r, e := q.acquire(ctx, "addr", time.Second)
+		require.NoError(t, e)
+		r()
+		close(done)
+	}()
+	for {
+		q.mu.Lock()
+		waiting := q.targets["addr"].waiting
+		q.mu.Unlock()
+		if waiting > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	_, err = q.acquire(ctx, "addr", time.Second)
+	require.ErrorIs(t, err, ErrBusy)
+	release()
+	<-done
+
+	q.mu.Lock()
+	require.Equal(t, 0, len(q.targets))
+	q.mu.Unlock()
+}
+
+func TestSendQueueClient(t *testing.T) {
+	blocking := make(chan struct{})
+	handler := &Router{}
+	handler.Register("/", func(w ResponseWriter, req *Request) error {
+		if req.Header.Get("block") != "" {
+			<-blocking
+		}
+		return w.WriteOK(nil)
+	})
+	server, cli, shutdown := newServer("tcp", handler)
+	defer shutdown()
+	cli.Queue = QueueConfig{MaxInflight: 1, MaxWaiting: 1}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		req, err := NewRequest(testCtx, server.Name, "/", nil, nil)
+		require.NoError(t, err)
+		req.Header.Set("block", "yes")
+		require.NoError(t, cli.DoWith(req, nil))
+	}()
+	for {
+		if q := cli.queue; q != nil {
+			q.mu.Lock()
+			tq := q.targets[server.Name]
+			inflight := tq != nil && tq.inflight > 0
+			q.mu.Unlock()
+			if inflight {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	req, err := NewRequest(testCtx, server.Name, "/", nil, nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, cli.DoWith(req, nil), ErrBusy)
+	require.Equal(t, 429, DetectStatusCode(ErrBusy))
+
+	// admitted before the deadline
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(blocking)
+	}()
+	req, err = NewRequest(testCtx, server.Name, "/", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cli.DoWith(req.OptionAdmissionTimeout(time.Second), nil))
+	wg.Wait()
+
+	cli.queue.mu.Lock()
+	require.Equal(t, 0, len(cli.queue.targets))
+	cli.queue.mu.Unlock()
+}
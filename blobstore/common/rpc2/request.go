@@ -37,12 +37,18 @@ type Request struct {
 	opts   []OptionRequest
 	conn   *transport.Stream
 
-	checksum ChecksumBlock
+	checksum   ChecksumBlock
+	connBroken bool // client side, responded before the body was written
+	admission  time.Duration
+	release    func() // client side, releases the send queue
+	priority   bool   // client side, high priority of stream
+	window     int    // client side, receive window of stream
 
 	// server side
 	cancel       context.CancelFunc
 	stream       *serverStream
 	readablePara bool
+	route        string // registered path of router which the request matched
 
 	Body    Body
 	GetBody func() (io.ReadCloser, error) // client side
@@ -106,12 +112,12 @@ func (req *Request) GetReadableParameter() []byte {
 
 func (req *Request) write(deadline time.Time) error {
 	reqHeaderSize := req.RequestHeader.Size()
-	if _headerCell+reqHeaderSize > req.conn.MaxPayloadSize() {
+	if reqHeaderSize > _maxCodecerSize {
 		return ErrFrameHeader
 	}
 
 	var cell headerCell
-	cell.Set(reqHeaderSize)
+	cell.SetFramed(reqHeaderSize, req.conn.MaxPayloadSize())
 	encodeLen := req.checksum.EncodeSize(req.ContentLength)
 	size := _headerCell + reqHeaderSize + int(encodeLen) + req.Trailer.AllSize()
 
@@ -125,17 +131,25 @@ func (req *Request) write(deadline time.Time) error {
 }
 
 func (req *Request) request(deadline time.Time) (*Response, error) {
-	if err := req.write(deadline); err != nil {
-		return nil, err
-	}
 	resp := &Response{Request: req}
-	frame, err := readHeaderFrame(req.ctx, req.conn, &resp.ResponseHeader)
+	var frame *transport.FrameRead
+	var err error
+	if req.checksum.retransmit(req.ContentLength) {
+		frame, err = req.writeWindows(deadline, &resp.ResponseHeader)
+	} else {
+		err = req.write(deadline)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if frame == nil {
+		if frame, err = readHeaderFrame(req.ctx, req.conn, &resp.ResponseHeader); err != nil {
+			return nil, err
+		}
+	}
 	if resp.Status < 200 || resp.Status >= 300 {
 		frame.Close()
-		return nil, NewError(resp.Status, resp.Reason, resp.Error)
+		return nil, &Error{Status: resp.Status, Reason: resp.Reason, Detail: resp.Error, Attr: resp.Attr}
 	}
 
 	decode := req.checksum != ChecksumBlock{} && req.checksum.Direction.IsDownload()
@@ -175,7 +189,8 @@ func (req *Request) OptionCrcUpload() *Request   { return req.optionCrc(Checksum
 func (req *Request) OptionCrcDownload() *Request { return req.optionCrc(ChecksumDirection_Download) }
 
 func (req *Request) OptionChecksum(block ChecksumBlock) *Request {
-	if _, exist := algorithms[block.Algorithm]; !exist || block.BlockSize == 0 {
+	if _, exist := algorithms[block.Algorithm]; !exist || block.BlockSize == 0 ||
+		block.windowSize() > _maxWindowSize {
 		panic(fmt.Sprintf("rpc2: checksum(%s) not implements", block.String()))
 	}
 	if req.checksum != (ChecksumBlock{}) {
@@ -207,6 +222,42 @@ func (req *Request) OptionChecksum(block ChecksumBlock) *Request {
 	return req
 }
 
+// OptionAdmissionTimeout sets the max waiting time in send queue of client,
+// it overrides QueueConfig.AdmissionTimeout of client.
+func (req *Request) OptionAdmissionTimeout(timeout time.Duration) *Request {
+	req.admission = timeout
+	return req
+}
+
+// OptionPriority sends the request and its response in high priority of the
+// connection, small requests are not blocked by bulk data of other streams.
+func (req *Request) OptionPriority() *Request {
+	req.priority = true
+	req.Header.Set(HeaderInternalPriority, "high")
+	return req
+}
+
+// OptionService routes the request to the virtual service of ServiceMux on server.
+func (req *Request) OptionService(service string) *Request {
+	req.Header.Set(HeaderService, service)
+	return req
+}
+
+// OptionStreamWindow sets receive window of the stream for the response,
+// a smaller window of bulk download bounds its bytes in flight of the connection,
+// it is not less than twice MaxFrameSize of transport.
+func (req *Request) OptionStreamWindow(size int) *Request {
+	req.window = size
+	return req
+}
+
+func (req *Request) releaseQueue() {
+	if req.release != nil {
+		req.release()
+		req.release = nil
+	}
+}
+
 func (req *Request) LocalAddrString() string {
 	if addr := req.conn.LocalAddr(); addr != nil {
 		return addr.String()
@@ -243,6 +294,7 @@ func getRequest() *Request {
 func putRequest(req *Request) {
 	req.StreamCmd = StreamCmd_NOT
 	req.RemotePath = ""
+	req.route = ""
 	req.TraceID = ""
 	req.ContentLength = 0
 	req.Header.Renew()
@@ -258,6 +310,9 @@ func putRequest(req *Request) {
 	req.conn = nil
 
 	req.checksum = ChecksumBlock{}
+	req.connBroken = false
+	req.admission = 0
+	req.release = nil
 
 	req.cancel = nil
 	req.stream = nil
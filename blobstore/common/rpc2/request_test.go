@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/blobstore/common/rpc2/transport"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/stretchr/testify/require"
 )
 
@@ -123,8 +124,8 @@ func TestRequestErrors(t *testing.T) {
 	req.OptionCrcDownload()
 	req.OptionCrcDownload()
 	require.NoError(t, err)
-	err = cli.DoWith(req, nil)
-	require.ErrorIs(t, ErrFrameHeader, err)
+	// header oversized one frame is continued in the following frames
+	require.NoError(t, cli.DoWith(req, nil))
 }
 
 func handleBodyReadable(w ResponseWriter, req *Request) error {
@@ -192,3 +193,60 @@ func TestRequestRetryCrc(t *testing.T) {
 	require.NoError(t, cli.DoWith(req, args))
 	require.True(t, args.Value == fmt.Sprintf("%d", binary.BigEndian.Uint32(sum)))
 }
+
+func TestRequestTraceBaggage(t *testing.T) {
+	var handler Router
+	handler.Register("/", func(w ResponseWriter, req *Request) error {
+		span := req.Span()
+		if span.BaggageItem("tenant") != "t1" || span.TraceID() != "trace-baggage" {
+			return NewError(400, "Baggage", "no baggage")
+		}
+		if span.IsSampled() {
+			return NewError(400, "Sampled", "should not be sampled")
+		}
+		return w.WriteOK(nil)
+	})
+	server, cli, shutdown := newServer("tcp", &handler)
+	defer shutdown()
+
+	tracer := trace.NewTracer("rpc2", trace.TracerOptions.Sampler(trace.NewProbabilitySampler(0)))
+	span := tracer.StartSpan("baggage").(trace.Span)
+	span.SetBaggageItem("tenant", "t1")
+	_, ctx := trace.StartSpanFromContextWithTraceID(trace.ContextWithSpan(testCtx, span), "", "trace-baggage")
+	req, err := NewRequest(ctx, server.Name, "/", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cli.DoWith(req, nil))
+}
+
+func TestRequestPriorityWindow(t *testing.T) {
+	buff := make([]byte, 8<<20)
+	crand.Read(buff)
+	var handler Router
+	handler.Register("/", func(w ResponseWriter, req *Request) error {
+		high := req.Header.Get(HeaderInternalPriority) != ""
+		if high != (req.Header.Get("priority") == "high") {
+			return NewError(400, "Priority", "mismatched priority")
+		}
+		w.SetContentLength(int64(len(buff)))
+		w.WriteHeader(200, nil)
+		_, err := w.ReadFrom(bytes.NewReader(buff))
+		return err
+	})
+	server, cli, shutdown := newServer("tcp", &handler)
+	defer shutdown()
+
+	for _, high := range []bool{true, false, true} {
+		req, err := NewRequest(testCtx, server.Name, "/", nil, nil)
+		require.NoError(t, err)
+		if high {
+			req.Header.Set("priority", "high")
+			req.OptionPriority().OptionStreamWindow(2 << 20)
+		}
+		resp, err := cli.Do(req, nil)
+		require.NoError(t, err)
+		got, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, buff, got)
+	}
+}
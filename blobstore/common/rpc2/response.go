@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"os"
 	"sync"
 
 	"github.com/cubefs/cubefs/blobstore/common/rpc2/transport"
@@ -42,6 +43,29 @@ type ResponseWriter interface {
 	AfterBody(func() error)
 }
 
+// FileBody is the body in section of file, it's sent from file to connection
+// without copying into userspace if the response body is not checksummed.
+type FileBody struct {
+	File   *os.File
+	Offset int64
+	Size   int64
+
+	sr *io.SectionReader
+}
+
+// NewFileBody returns body of size bytes in file at offset.
+func NewFileBody(f *os.File, off, size int64) *FileBody {
+	return &FileBody{File: f, Offset: off, Size: size}
+}
+
+// Read reads the section of file in the normal path.
+func (b *FileBody) Read(p []byte) (int, error) {
+	if b.sr == nil {
+		b.sr = io.NewSectionReader(b.File, b.Offset, b.Size)
+	}
+	return b.sr.Read(p)
+}
+
 // client side response
 type Response struct {
 	ResponseHeader
@@ -105,6 +129,7 @@ func (resp *response) SetError(err error) {
 	_, reason, detail := DetectError(err)
 	resp.hdr.Reason = reason
 	resp.hdr.Error = detail.Error()
+	resp.hdr.Attr = DetectErrorAttr(err)
 }
 
 func (resp *response) WriteOK(obj Marshaler) error {
@@ -139,7 +164,7 @@ func (resp *response) WriteHeader(status int, obj Marshaler) error {
 	resp.hdr.Parameter = b
 
 	var cell headerCell
-	cell.Set(resp.hdr.Size())
+	cell.SetFramed(resp.hdr.Size(), resp.conn.MaxPayloadSize())
 	resp.toWrite += _headerCell + resp.hdr.Size()
 	resp.toList = append(resp.toList, codec2CellReader(cell, &resp.hdr))
 	return nil
@@ -186,6 +211,11 @@ func (resp *response) ReadFrom(r io.Reader) (n int64, err error) {
 	}
 	resp.hasWroteBody = true
 
+	// checksum blocks are re-framed in userspace
+	if fb, ok := r.(*FileBody); ok && resp.bodyEncoder == nil && fb.Size >= int64(resp.remain) {
+		return resp.readFromFile(fb)
+	}
+
 	remain := resp.remain
 	r, toWrite := resp.encodeBody(io.LimitReader(r, int64(remain)))
 	resp.toWrite += toWrite + resp.hdr.Trailer.AllSize()
@@ -200,6 +230,31 @@ func (resp *response) ReadFrom(r io.Reader) (n int64, err error) {
 	return int64(remain), nil
 }
 
+func (resp *response) readFromFile(fb *FileBody) (int64, error) {
+	if err := resp.Flush(); err != nil {
+		return 0, err
+	}
+	if resp.connBroken {
+		return 0, io.ErrClosedPipe
+	}
+
+	remain := resp.remain
+	if _, err := resp.conn.WriteFile(resp.ctx, fb.File, fb.Offset, remain); err != nil {
+		resp.connBroken = true
+		return 0, err
+	}
+	resp.remain = 0
+	resp.toWrite += resp.hdr.Trailer.AllSize()
+	resp.toList = append(resp.toList, &trailerReader{
+		Fn:      resp.afterBody,
+		Trailer: &resp.hdr.Trailer,
+	})
+	if err := resp.Flush(); err != nil {
+		return 0, err
+	}
+	return int64(remain), nil
+}
+
 func (resp *response) Flush() error {
 	if len(resp.toList) == 0 {
 		return nil
@@ -267,6 +322,7 @@ func putResponse(resp *response) {
 	resp.hdr.Status = 0
 	resp.hdr.Reason = ""
 	resp.hdr.Error = ""
+	resp.hdr.Attr = ""
 	resp.hdr.ContentLength = 0
 	resp.hdr.Header.Renew()
 	resp.hdr.Trailer.Renew()
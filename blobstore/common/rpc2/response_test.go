@@ -15,6 +15,9 @@
 package rpc2
 
 import (
+	crand "crypto/rand"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -73,3 +76,67 @@ func TestResponseError(t *testing.T) {
 	require.NoError(t, err)
 	require.Error(t, cli.DoWith(req, nil))
 }
+
+func TestResponseErrorAttr(t *testing.T) {
+	var handler Router
+	handler.Register("/attr", func(w ResponseWriter, req *Request) error {
+		return &Error{Status: 1007, Reason: "NoLeader", Detail: "no leader", Attr: "shardnode;server;retryable"}
+	})
+	handler.Register("/none", func(w ResponseWriter, req *Request) error {
+		return NewError(500, "", "none")
+	})
+	server, cli, shutdown := newServer("tcp", &handler)
+	defer shutdown()
+
+	req, err := NewRequest(testCtx, server.Name, "/attr", nil, nil)
+	require.NoError(t, err)
+	err = cli.DoWith(req, nil)
+	require.Equal(t, 1007, DetectStatusCode(err))
+	require.Equal(t, "shardnode;server;retryable", DetectErrorAttr(err))
+
+	req, err = NewRequest(testCtx, server.Name, "/none", nil, nil)
+	require.NoError(t, err)
+	err = cli.DoWith(req, nil)
+	require.Equal(t, 500, DetectStatusCode(err))
+	require.Equal(t, "", DetectErrorAttr(err))
+}
+
+func TestResponseFileBody(t *testing.T) {
+	data := make([]byte, 4<<20)
+	crand.Read(data)
+	f, err := os.CreateTemp(t.TempDir(), "file")
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.Write(data)
+	require.NoError(t, err)
+
+	off, size := int64(10), int64(3<<20)
+	var handler Router
+	handler.Register("/file", func(w ResponseWriter, req *Request) error {
+		w.Trailer().SetLen("after", 4)
+		w.AfterBody(func() error {
+			w.Trailer().Set("after", "body")
+			return nil
+		})
+		w.SetContentLength(size)
+		_, err := w.ReadFrom(NewFileBody(f, off, size))
+		return err
+	})
+	server, cli, shutdown := newServer("tcp", &handler)
+	defer shutdown()
+
+	for _, crc := range []bool{false, true} {
+		req, err := NewRequest(testCtx, server.Name, "/file", nil, nil)
+		require.NoError(t, err)
+		if crc { // re-framed with checksum in userspace
+			req.OptionCrcDownload()
+		}
+		resp, err := cli.Do(req, nil)
+		require.NoError(t, err)
+		buf, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, data[off:off+size], buf)
+		require.Equal(t, "body", resp.Trailer.Get("after"))
+		resp.Body.Close()
+	}
+}
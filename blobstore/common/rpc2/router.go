@@ -16,10 +16,18 @@ package rpc2
 
 import (
 	"fmt"
+	"reflect"
 	"runtime/debug"
+	"sort"
 )
 
-const DefaultStatusPanic = 597
+const (
+	DefaultStatusPanic = 597
+
+	// PathReflection built-in path lists registered methods of router,
+	// it is registered only if reflection enabled.
+	PathReflection = "/rpc2/reflection"
+)
 
 type Handle func(ResponseWriter, *Request) error
 
@@ -47,12 +55,52 @@ var defaultPanicHandler = func(_ ResponseWriter, req *Request, err interface{},
 	return nil
 }
 
+// MethodInfo is description of registered method.
+type MethodInfo struct {
+	Path string `json:"path"`
+	// Parameter codec type name of the method, empty if not declared.
+	Parameter string `json:"parameter"`
+}
+
+// Methods result of reflection.
+type Methods struct {
+	Methods []MethodInfo `json:"methods"`
+}
+
+// RouteOption option of registered method.
+type RouteOption func(*route)
+
+// OptParameter declares parameter codec of the method.
+func OptParameter(para Codec) RouteOption {
+	return func(r *route) { r.info.Parameter = CodecName(para) }
+}
+
+// CodecName returns type name of codec, like "shardnode.GetBlobArgs".
+func CodecName(codec Codec) string {
+	if codec == nil {
+		return "nil"
+	}
+	if codec == NoParameter {
+		return "rpc2.NoParameter"
+	}
+	typ := reflect.TypeOf(codec)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.String()
+}
+
+type route struct {
+	handle Handle
+	info   MethodInfo
+}
+
 type Router struct {
 	PanicHandler func(w ResponseWriter, req *Request, err interface{}, stack []byte) error
 
 	interceptors []Interceptor
 	middlewares  []Handle
-	handlers     map[string]Handle
+	handlers     map[string]*route
 }
 
 var _ Handler = (&Router{}).MakeHandler()
@@ -71,20 +119,52 @@ func (r *Router) Middleware(mws ...Handle) {
 	r.middlewares = append(r.middlewares, mws...)
 }
 
-func (r *Router) Register(path string, handle Handle) {
+// Register registers handle on the path, the reflection path is reserved.
+func (r *Router) Register(path string, handle Handle, opts ...RouteOption) {
+	if path == PathReflection {
+		panic(fmt.Sprintf("rpc2: path(%s) is reserved", path))
+	}
+	r.register(path, handle, opts...)
+}
+
+// EnableReflection registers the reflection path, which exposes all
+// registered methods, so enable it only if the router is behind auth.
+func (r *Router) EnableReflection() {
+	r.register(PathReflection, r.handleReflection, OptParameter(NoParameter))
+}
+
+func (r *Router) register(path string, handle Handle, opts ...RouteOption) {
 	if r.handlers == nil {
-		r.handlers = make(map[string]Handle)
+		r.handlers = make(map[string]*route)
 	}
 	if _, exist := r.handlers[path]; exist {
 		panic(fmt.Sprintf("rpc2: path(%s) has registered", path))
 	}
-	r.handlers[path] = handle
+	rt := &route{handle: handle, info: MethodInfo{Path: path}}
+	for _, opt := range opts {
+		opt(rt)
+	}
+	r.handlers[path] = rt
 
 	if r.PanicHandler == nil {
 		r.PanicHandler = defaultPanicHandler
 	}
 }
 
+// Methods returns registered methods sorted by path.
+func (r *Router) Methods() []MethodInfo {
+	methods := make([]MethodInfo, 0, len(r.handlers))
+	for _, rt := range r.handlers {
+		methods = append(methods, rt.info)
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Path < methods[j].Path })
+	return methods
+}
+
+func (r *Router) handleReflection(w ResponseWriter, req *Request) error {
+	return w.WriteOK(&AnyCodec[Methods]{Value: Methods{Methods: r.Methods()}})
+}
+
 func (r *Router) MakeHandler() Handle {
 	if len(r.interceptors) == 0 {
 		return r.handleWithPanic(r.handle)
@@ -123,18 +203,19 @@ func (r *Router) handleWithPanic(h Handle) Handle {
 }
 
 func (r *Router) handle(w ResponseWriter, req *Request) (err error) {
-	handle, exist := r.handlers[req.RemotePath]
+	rt, exist := r.handlers[req.RemotePath]
 	if !exist {
 		err = NewErrorf(404, "NoRouter", "no router for path(%s)", req.RemotePath)
 		return
 	}
+	req.route = rt.info.Path
 
 	for idx := range r.middlewares {
 		if err = r.middlewares[idx](w, req); err != nil {
 			return
 		}
 	}
-	err = handle(w, req)
+	err = rt.handle(w, req)
 	if req.stream != nil { // stream
 		return
 	}
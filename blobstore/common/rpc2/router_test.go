@@ -102,3 +102,27 @@ func TestRpc2RouterInterceptor(t *testing.T) {
 	err = cli.DoWith(req, nil)
 	require.Equal(t, DefaultStatusPanic, DetectStatusCode(err))
 }
+
+func TestRpc2RouterReflection(t *testing.T) {
+	var handler Router
+	handler.Register("/none", handleNone)
+	handler.Register("/any", handleNone, OptParameter(&AnyCodec[struct{}]{}))
+	require.Panics(t, func() { handler.Register(PathReflection, handleNone) })
+
+	server, cli, shutdown := newServer("tcp", &handler)
+	defer shutdown()
+
+	// reflection is disabled by default
+	_, err := cli.Methods(testCtx, server.Name)
+	require.Equal(t, 404, DetectStatusCode(err))
+
+	handler.EnableReflection()
+	require.Panics(t, func() { handler.EnableReflection() })
+	methods, err := cli.Methods(testCtx, server.Name)
+	require.NoError(t, err)
+	require.Equal(t, []MethodInfo{
+		{Path: "/any", Parameter: "rpc2.AnyCodec[struct {}]"},
+		{Path: "/none"},
+		{Path: PathReflection, Parameter: "rpc2.NoParameter"},
+	}, methods)
+}
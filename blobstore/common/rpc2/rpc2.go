@@ -34,6 +34,8 @@ const (
 	Magic   = 0xee
 
 	_headerCell = 4
+	// the header is continued in the following frames if the bit of cell is set
+	_headerCellContinued = 1 << 31
 
 	_maxCodecerSize = 16 << 20
 )
@@ -43,7 +45,7 @@ var (
 
 	ErrServerClosed  = errors.New("rpc2: server closed")
 	ErrLimitedWriter = errors.New("rpc2: request or response body must wrap with rpc2.LimitedWriter")
-	ErrFrameHeader   = errors.New("rpc2: request or response header must be in independent frame")
+	ErrFrameHeader   = errors.New("rpc2: request or response header or message is oversized")
 	ErrFrameProtocol = errors.New("rpc2: undefined protocol frame")
 	ErrConnLimited   = NewError(400, "ConnLimited", "rpc2: session or stream was limited")
 	ErrConnNoAddress = NewError(400, "ConnNoAddress", "rpc2: lb client has no address")
@@ -233,8 +235,11 @@ func (c *codecReadWriter) Read(p []byte) (n int, err error) {
 		c.cache = cache
 	})
 	if c.reader != nil {
-		n, err = c.reader.Read(p)
-		c.remain -= n
+		var nn int
+		// the cell has been read if the codec oversizes the buffer
+		nn, err = c.reader.Read(p)
+		n += nn
+		c.remain -= nn
 		if c.remain <= 0 && c.cache != nil {
 			codecPool.Put(c.cache)
 			c.cache = nil
@@ -325,6 +330,24 @@ func ContextWithTrace(ctx context.Context) context.Context {
 	return ctx
 }
 
+// traceCarrier carries span context of trace in header.
+type traceCarrier struct {
+	header *Header
+}
+
+func (c traceCarrier) Set(key, val string) {
+	c.header.Set(key, val)
+}
+
+func (c traceCarrier) ForeachKey(handler func(key, val string) error) error {
+	for key, val := range c.header.M {
+		if err := handler(key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type headerCell [_headerCell]byte
 
 func (h *headerCell) Set(n int) {
@@ -335,6 +358,23 @@ func (h *headerCell) Get() int {
 	return int(binary.LittleEndian.Uint32((*h)[:]))
 }
 
+// SetFramed sets size of header, marks the header continued
+// if it oversizes the max payload of one frame.
+func (h *headerCell) SetFramed(n int, maxPayloadSize int) {
+	if _headerCell+n > maxPayloadSize {
+		n |= _headerCellContinued
+	}
+	h.Set(n)
+}
+
+func (h *headerCell) Continued() bool {
+	return h.Get()&_headerCellContinued != 0
+}
+
+func (h *headerCell) Size() int {
+	return h.Get() &^ _headerCellContinued
+}
+
 func (h *headerCell) Write(p []byte) (int, error) {
 	_ = p[3]
 	copy((*h)[:], p)
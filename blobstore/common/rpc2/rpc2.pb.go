@@ -386,6 +386,8 @@ type ResponseHeader struct {
 	Header        Header      `protobuf:"bytes,8,opt,name=header,proto3" json:"header"`
 	Trailer       FixedHeader `protobuf:"bytes,9,opt,name=trailer,proto3" json:"trailer"`
 	Parameter     []byte      `protobuf:"bytes,10,opt,name=parameter,proto3" json:"parameter,omitempty"`
+	// attributes of error, retryable, fault and owner
+	Attr string `protobuf:"bytes,11,opt,name=attr,proto3" json:"attr,omitempty"`
 }
 
 func (m *ResponseHeader) Reset()      { *m = ResponseHeader{} }
@@ -483,10 +485,18 @@ func (m *ResponseHeader) GetParameter() []byte {
 	return nil
 }
 
+func (m *ResponseHeader) GetAttr() string {
+	if m != nil {
+		return m.Attr
+	}
+	return ""
+}
+
 type Error struct {
 	Status int32  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
 	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	Attr   string `protobuf:"bytes,4,opt,name=attr,proto3" json:"attr,omitempty"`
 }
 
 func (m *Error) Reset()      { *m = Error{} }
@@ -542,10 +552,19 @@ func (m *Error) GetDetail() string {
 	return ""
 }
 
+func (m *Error) GetAttr() string {
+	if m != nil {
+		return m.Attr
+	}
+	return ""
+}
+
 type ChecksumBlock struct {
 	Algorithm ChecksumAlgorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=cubefs.blobstore.common.rpc2.ChecksumAlgorithm" json:"algorithm,omitempty"`
 	Direction ChecksumDirection `protobuf:"varint,2,opt,name=direction,proto3,enum=cubefs.blobstore.common.rpc2.ChecksumDirection" json:"direction,omitempty"`
 	BlockSize uint32            `protobuf:"varint,3,opt,name=blockSize,proto3" json:"blockSize,omitempty"`
+	// blocks of one retransmit window for upload, zero means no retransmit
+	Window uint32 `protobuf:"varint,4,opt,name=window,proto3" json:"window,omitempty"`
 }
 
 func (m *ChecksumBlock) Reset()      { *m = ChecksumBlock{} }
@@ -601,6 +620,13 @@ func (m *ChecksumBlock) GetBlockSize() uint32 {
 	return 0
 }
 
+func (m *ChecksumBlock) GetWindow() uint32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("cubefs.blobstore.common.rpc2.StreamCmd", StreamCmd_name, StreamCmd_value)
 	proto.RegisterEnum("cubefs.blobstore.common.rpc2.ChecksumAlgorithm", ChecksumAlgorithm_name, ChecksumAlgorithm_value)
@@ -619,56 +645,58 @@ func init() {
 func init() { proto.RegisterFile("rpc2.proto", fileDescriptor_af0916bb5e6806d0) }
 
 var fileDescriptor_af0916bb5e6806d0 = []byte{
-	// 779 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0x4f, 0x8f, 0xdb, 0x44,
-	0x1c, 0xcd, 0xc4, 0x8d, 0x13, 0xff, 0xd2, 0x04, 0x33, 0xaa, 0x2a, 0xab, 0xaa, 0x9c, 0x28, 0x02,
-	0x1a, 0x16, 0xd5, 0x41, 0x69, 0x0f, 0xfc, 0x91, 0x2a, 0x35, 0x9b, 0x2c, 0x1b, 0x89, 0xa6, 0xd5,
-	0xa4, 0x20, 0xc1, 0x81, 0xc8, 0xb1, 0x87, 0xd8, 0x5a, 0xdb, 0x13, 0xc6, 0x93, 0x92, 0x72, 0xe2,
-	0x23, 0xf0, 0x19, 0x10, 0x1f, 0x66, 0x25, 0x2e, 0x7b, 0x5c, 0x21, 0x14, 0xb1, 0xd9, 0x1b, 0x27,
-	0x3e, 0x02, 0x9a, 0xb1, 0xb3, 0x59, 0xc4, 0x12, 0x05, 0x6e, 0xdc, 0x7e, 0xef, 0x79, 0xde, 0xf3,
-	0xef, 0x3d, 0x4f, 0x14, 0x00, 0x3e, 0xf7, 0xba, 0xce, 0x9c, 0x33, 0xc1, 0xf0, 0x7d, 0x6f, 0x31,
-	0xa5, 0x5f, 0xa7, 0xce, 0x34, 0x62, 0xd3, 0x54, 0x30, 0x4e, 0x1d, 0x8f, 0xc5, 0x31, 0x4b, 0x1c,
-	0x79, 0xe6, 0xde, 0x9d, 0x19, 0x9b, 0x31, 0x75, 0xb0, 0x23, 0xa7, 0x4c, 0xd3, 0xfa, 0x11, 0x81,
-	0x7e, 0x4c, 0x5d, 0x9f, 0x72, 0xfc, 0x21, 0xa0, 0xd8, 0x42, 0x4d, 0xad, 0x5d, 0xed, 0xbe, 0xe7,
-	0xec, 0xb2, 0x72, 0x32, 0x81, 0xf3, 0x6c, 0x90, 0x08, 0xfe, 0x9a, 0xa0, 0x18, 0x3f, 0x00, 0x3d,
-	0x15, 0xee, 0x34, 0xa2, 0x56, 0xb1, 0x89, 0xda, 0x95, 0xde, 0x1b, 0xeb, 0x55, 0x23, 0x67, 0x7e,
-	0x5f, 0x35, 0xd0, 0x43, 0x92, 0x83, 0x7b, 0x8f, 0x41, 0xcf, 0x54, 0xd8, 0x04, 0xed, 0x84, 0xbe,
-	0xb6, 0x50, 0x13, 0xb5, 0x0d, 0x22, 0x47, 0x7c, 0x07, 0x4a, 0xaf, 0xdc, 0x68, 0x91, 0x79, 0x18,
-	0x24, 0x03, 0x1f, 0x15, 0x3f, 0x40, 0xad, 0xc7, 0x00, 0x47, 0xe1, 0x92, 0xfa, 0x9f, 0x4b, 0x46,
-	0x2a, 0x23, 0x9a, 0x28, 0x65, 0x8d, 0xc8, 0xf1, 0x66, 0x65, 0xeb, 0x57, 0x04, 0x55, 0x25, 0xcb,
-	0xf3, 0xf5, 0xb7, 0xf9, 0xde, 0xdf, 0x9d, 0xef, 0x9a, 0x2a, 0x0f, 0xd9, 0xbb, 0x75, 0xba, 0x6a,
-	0x14, 0xfe, 0x55, 0xd4, 0xaf, 0x76, 0x44, 0x7d, 0x72, 0x7d, 0xe1, 0x6a, 0xb7, 0xbd, 0xc7, 0x3a,
-	0x2a, 0xfb, 0xf5, 0x52, 0x7e, 0xd2, 0xa0, 0x46, 0xe8, 0x37, 0x0b, 0x9a, 0x8a, 0x3c, 0xa0, 0x05,
-	0xe5, 0x57, 0x94, 0xa7, 0x21, 0xcb, 0xca, 0x29, 0x91, 0x0d, 0x94, 0x05, 0xc5, 0xee, 0x2c, 0xf4,
-	0xd4, 0xfb, 0x4a, 0x24, 0x03, 0xf8, 0x08, 0x20, 0x15, 0x9c, 0xba, 0xf1, 0xc4, 0x8b, 0x7d, 0x4b,
-	0x6b, 0xa2, 0x76, 0xbd, 0xfb, 0x60, 0xf7, 0x2a, 0x63, 0x75, 0xfe, 0x30, 0xf6, 0x89, 0x91, 0x6e,
-	0x46, 0xdc, 0x80, 0x2a, 0xa7, 0x31, 0x13, 0x74, 0x32, 0x77, 0x45, 0x60, 0xdd, 0x52, 0x39, 0x21,
-	0xa3, 0x5e, 0xb8, 0x22, 0xc0, 0xef, 0x40, 0x45, 0x70, 0xd7, 0xa3, 0x93, 0xd0, 0xb7, 0x4a, 0xf2,
-	0x69, 0xaf, 0xba, 0x5e, 0x35, 0xca, 0x2f, 0x25, 0x37, 0xec, 0x93, 0xb2, 0x7a, 0x38, 0xf4, 0xf1,
-	0xdb, 0x50, 0xf7, 0x58, 0x22, 0x68, 0x22, 0x26, 0x11, 0x4d, 0x66, 0x22, 0xb0, 0xf4, 0x26, 0x6a,
-	0x6b, 0xa4, 0x96, 0xb3, 0x9f, 0x2a, 0x12, 0xf7, 0x40, 0x0f, 0x54, 0x62, 0xab, 0xa2, 0xea, 0x7b,
-	0x6b, 0x9f, 0xdb, 0x9a, 0x7f, 0xc1, 0x5c, 0x89, 0x87, 0x20, 0xdf, 0x1a, 0x46, 0x94, 0x5b, 0x86,
-	0x32, 0x79, 0x77, 0xef, 0x2b, 0x91, 0x3b, 0x6d, 0xf4, 0xf8, 0x3e, 0x18, 0x73, 0x97, 0xbb, 0x31,
-	0x15, 0x94, 0x5b, 0xd0, 0x44, 0xed, 0xdb, 0x64, 0x4b, 0xb4, 0x7e, 0x29, 0x42, 0x9d, 0xd0, 0x74,
-	0xce, 0x92, 0x94, 0xfe, 0xc7, 0xef, 0x74, 0x57, 0x5d, 0x39, 0xb1, 0x48, 0x55, 0xb5, 0x25, 0x92,
-	0x23, 0xc9, 0x73, 0xea, 0xa6, 0x2c, 0xc9, 0x4a, 0x25, 0x39, 0x92, 0x2e, 0x94, 0x73, 0xc6, 0x55,
-	0x7b, 0x06, 0xc9, 0xc0, 0x0d, 0xe5, 0x96, 0xff, 0xf7, 0xe5, 0x3e, 0x87, 0xd2, 0x40, 0x85, 0xdb,
-	0x56, 0x84, 0xfe, 0xa1, 0xa2, 0xe2, 0x5f, 0x2a, 0xba, 0x0b, 0xba, 0x4f, 0x85, 0x1b, 0x46, 0xea,
-	0xda, 0x1b, 0x24, 0x47, 0xad, 0x9f, 0x11, 0xd4, 0x0e, 0x03, 0xea, 0x9d, 0xa4, 0x8b, 0xb8, 0x17,
-	0x31, 0xef, 0x04, 0x3f, 0x03, 0xc3, 0x8d, 0x66, 0x8c, 0x87, 0x22, 0x88, 0x95, 0x79, 0xbd, 0xdb,
-	0xd9, 0x9d, 0x66, 0xa3, 0x7f, 0xba, 0x91, 0x91, 0xad, 0x83, 0xb4, 0xf3, 0x43, 0x4e, 0x3d, 0x11,
-	0xe6, 0x3b, 0xed, 0x6d, 0xd7, 0xdf, 0xc8, 0xc8, 0xd6, 0x41, 0xd6, 0x33, 0x95, 0x6b, 0x8e, 0xc3,
-	0xef, 0xa8, 0x8a, 0x52, 0x23, 0x5b, 0xe2, 0xa0, 0x03, 0xc6, 0xd5, 0x0f, 0x16, 0x97, 0x41, 0x1b,
-	0x3d, 0x7f, 0x69, 0x16, 0xe4, 0x30, 0xfe, 0x62, 0x64, 0x22, 0x39, 0xbc, 0x18, 0x1f, 0x9b, 0x45,
-	0x39, 0x1c, 0x0d, 0x47, 0xa6, 0x76, 0xf0, 0x04, 0xde, 0xfc, 0xdb, 0xf6, 0xf8, 0x36, 0x54, 0x9e,
-	0x46, 0xb3, 0xc9, 0x88, 0x25, 0xd4, 0x2c, 0x48, 0x74, 0xc8, 0xbd, 0xc9, 0x70, 0x30, 0x18, 0x98,
-	0x08, 0xd7, 0xc0, 0x38, 0x76, 0xd3, 0x60, 0xb2, 0x5c, 0x06, 0x8f, 0xcc, 0xe2, 0xc1, 0x27, 0x5b,
-	0xfd, 0xd5, 0xba, 0x52, 0xd1, 0x0f, 0xf9, 0x46, 0x0f, 0xa0, 0xf7, 0x17, 0xf3, 0x88, 0x2e, 0x4d,
-	0x24, 0xe7, 0xcf, 0xe6, 0x11, 0x73, 0x7d, 0xb3, 0xa8, 0x4e, 0xb1, 0x6f, 0x13, 0x85, 0xb4, 0xde,
-	0xc3, 0xf3, 0x0b, 0xbb, 0xf0, 0xc7, 0x85, 0x8d, 0xbe, 0x5f, 0xdb, 0xe8, 0x74, 0x6d, 0xa3, 0xb3,
-	0xb5, 0x8d, 0x7e, 0x5b, 0xdb, 0xe8, 0x87, 0x4b, 0xbb, 0x70, 0x76, 0x69, 0x17, 0xce, 0x2f, 0xed,
-	0xc2, 0x97, 0x65, 0xa7, 0xf3, 0xb1, 0xec, 0x68, 0xaa, 0xab, 0x3f, 0xb3, 0x47, 0x7f, 0x06, 0x00,
-	0x00, 0xff, 0xff, 0xd6, 0x4c, 0x57, 0x07, 0x0e, 0x07, 0x00, 0x00,
+	// 803 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0xcf, 0x8f, 0xdb, 0x44,
+	0x14, 0xce, 0xc4, 0x1b, 0x67, 0xfd, 0xd2, 0x2c, 0xee, 0xa8, 0xaa, 0xac, 0xaa, 0x72, 0xa2, 0x08,
+	0x68, 0x58, 0x54, 0x2f, 0x4a, 0x7b, 0xe0, 0x87, 0x54, 0xa9, 0xd9, 0x64, 0xd9, 0x48, 0x74, 0xa9,
+	0x66, 0x0b, 0x12, 0x1c, 0x88, 0x26, 0xf6, 0x10, 0x5b, 0x6b, 0x7b, 0xc2, 0x78, 0xd2, 0x4d, 0x39,
+	0xf1, 0x27, 0xf0, 0x37, 0x20, 0xfe, 0x98, 0x1e, 0x7b, 0xec, 0x01, 0x45, 0x34, 0xb9, 0x71, 0x40,
+	0xfc, 0x09, 0x68, 0xc6, 0x4e, 0x13, 0xd4, 0x25, 0x0a, 0xdc, 0x7a, 0x7b, 0xdf, 0xf3, 0x7c, 0xdf,
+	0x7b, 0xdf, 0xe7, 0xb1, 0x0c, 0x20, 0x26, 0x7e, 0xc7, 0x9b, 0x08, 0x2e, 0x39, 0xbe, 0xed, 0x4f,
+	0x47, 0xec, 0xfb, 0xcc, 0x1b, 0xc5, 0x7c, 0x94, 0x49, 0x2e, 0x98, 0xe7, 0xf3, 0x24, 0xe1, 0xa9,
+	0xa7, 0xce, 0xdc, 0xba, 0x31, 0xe6, 0x63, 0xae, 0x0f, 0x1e, 0xa9, 0x2a, 0xe7, 0xb4, 0x7e, 0x41,
+	0x60, 0x9e, 0x32, 0x1a, 0x30, 0x81, 0x3f, 0x01, 0x94, 0x38, 0xa8, 0x69, 0xb4, 0x6b, 0x9d, 0x0f,
+	0xbd, 0x6d, 0x52, 0x5e, 0x4e, 0xf0, 0x1e, 0xf5, 0x53, 0x29, 0x9e, 0x11, 0x94, 0xe0, 0x3b, 0x60,
+	0x66, 0x92, 0x8e, 0x62, 0xe6, 0x94, 0x9b, 0xa8, 0xbd, 0xdf, 0x7d, 0x67, 0x31, 0x6f, 0x14, 0x9d,
+	0x3f, 0xe6, 0x0d, 0x74, 0x97, 0x14, 0xe0, 0xd6, 0x7d, 0x30, 0x73, 0x16, 0xb6, 0xc1, 0xb8, 0x60,
+	0xcf, 0x1c, 0xd4, 0x44, 0x6d, 0x8b, 0xa8, 0x12, 0xdf, 0x80, 0xca, 0x53, 0x1a, 0x4f, 0x73, 0x0d,
+	0x8b, 0xe4, 0xe0, 0xd3, 0xf2, 0xc7, 0xa8, 0x75, 0x1f, 0xe0, 0x24, 0x9a, 0xb1, 0xe0, 0x6b, 0xd5,
+	0x51, 0xcc, 0x98, 0xa5, 0x9a, 0x59, 0x27, 0xaa, 0xbc, 0x9a, 0xd9, 0xfa, 0x0d, 0x41, 0x4d, 0xd3,
+	0x0a, 0x7f, 0xbd, 0xb5, 0xbf, 0x8f, 0xb6, 0xfb, 0xdb, 0x60, 0x15, 0x26, 0xbb, 0x7b, 0xcf, 0xe7,
+	0x8d, 0xd2, 0x7f, 0xb2, 0xfa, 0xdd, 0x16, 0xab, 0x0f, 0x36, 0x17, 0xae, 0x75, 0xda, 0x3b, 0xac,
+	0xa3, 0xbd, 0x6f, 0x86, 0xf2, 0xab, 0x01, 0x75, 0xc2, 0x7e, 0x98, 0xb2, 0x4c, 0x16, 0x06, 0x1d,
+	0xa8, 0x3e, 0x65, 0x22, 0x8b, 0x78, 0x1e, 0x4e, 0x85, 0xac, 0xa0, 0x0a, 0x28, 0xa1, 0xe3, 0xc8,
+	0xd7, 0xf3, 0x2a, 0x24, 0x07, 0xf8, 0x04, 0x20, 0x93, 0x82, 0xd1, 0x64, 0xe8, 0x27, 0x81, 0x63,
+	0x34, 0x51, 0xfb, 0xa0, 0x73, 0x67, 0xfb, 0x2a, 0xe7, 0xfa, 0xfc, 0x71, 0x12, 0x10, 0x2b, 0x5b,
+	0x95, 0xb8, 0x01, 0x35, 0xc1, 0x12, 0x2e, 0xd9, 0x70, 0x42, 0x65, 0xe8, 0xec, 0x69, 0x9f, 0x90,
+	0xb7, 0x1e, 0x53, 0x19, 0xe2, 0xf7, 0x61, 0x5f, 0x0a, 0xea, 0xb3, 0x61, 0x14, 0x38, 0x15, 0xf5,
+	0xb4, 0x5b, 0x5b, 0xcc, 0x1b, 0xd5, 0x27, 0xaa, 0x37, 0xe8, 0x91, 0xaa, 0x7e, 0x38, 0x08, 0xf0,
+	0x7b, 0x70, 0xe0, 0xf3, 0x54, 0xb2, 0x54, 0x0e, 0x63, 0x96, 0x8e, 0x65, 0xe8, 0x98, 0x4d, 0xd4,
+	0x36, 0x48, 0xbd, 0xe8, 0x7e, 0xa1, 0x9b, 0xb8, 0x0b, 0x66, 0xa8, 0x1d, 0x3b, 0xfb, 0x3a, 0xbe,
+	0x77, 0x77, 0xb9, 0xad, 0xc5, 0x1b, 0x2c, 0x98, 0x78, 0x00, 0x6a, 0x6a, 0x14, 0x33, 0xe1, 0x58,
+	0x5a, 0xe4, 0x83, 0x9d, 0xaf, 0x44, 0xa1, 0xb4, 0xe2, 0xe3, 0xdb, 0x60, 0x4d, 0xa8, 0xa0, 0x09,
+	0x93, 0x4c, 0x38, 0xd0, 0x44, 0xed, 0x6b, 0x64, 0xdd, 0x68, 0xfd, 0x59, 0x86, 0x03, 0xc2, 0xb2,
+	0x09, 0x4f, 0x33, 0xf6, 0x3f, 0xdf, 0xd3, 0x4d, 0x7d, 0xe5, 0xe4, 0x34, 0xd3, 0xd1, 0x56, 0x48,
+	0x81, 0x54, 0x5f, 0x30, 0x9a, 0xf1, 0x34, 0x0f, 0x95, 0x14, 0x48, 0xa9, 0x30, 0x21, 0xb8, 0xd0,
+	0xe9, 0x59, 0x24, 0x07, 0x57, 0x84, 0x5b, 0x7d, 0xdb, 0xc3, 0xc5, 0x18, 0xf6, 0xa8, 0x94, 0xc2,
+	0xa9, 0x69, 0xa3, 0xba, 0x6e, 0xf9, 0x50, 0xe9, 0x6b, 0xc3, 0xeb, 0xd8, 0xd0, 0xbf, 0xc4, 0x56,
+	0xfe, 0x47, 0x6c, 0x37, 0xc1, 0x0c, 0x98, 0xa4, 0x51, 0xac, 0x3f, 0x05, 0x8b, 0x14, 0xe8, 0xf5,
+	0x90, 0xbd, 0x8d, 0x21, 0x4b, 0x04, 0xf5, 0xe3, 0x90, 0xf9, 0x17, 0xd9, 0x34, 0xe9, 0xc6, 0xdc,
+	0xbf, 0xc0, 0x8f, 0xc0, 0xa2, 0xf1, 0x98, 0x8b, 0x48, 0x86, 0x89, 0x1e, 0x78, 0xd0, 0x39, 0xda,
+	0xee, 0x7a, 0xc5, 0x7f, 0xb8, 0xa2, 0x91, 0xb5, 0x82, 0x92, 0x0b, 0x22, 0xc1, 0x7c, 0x19, 0x15,
+	0x7b, 0xee, 0x2c, 0xd7, 0x5b, 0xd1, 0xc8, 0x5a, 0x41, 0xc5, 0x38, 0x52, 0x6b, 0x9e, 0x47, 0x3f,
+	0x32, 0x6d, 0xaf, 0x4e, 0xd6, 0x0d, 0xe5, 0xfc, 0x32, 0x4a, 0x03, 0x7e, 0xa9, 0x3d, 0xd6, 0x49,
+	0x81, 0x0e, 0x8f, 0xc0, 0x7a, 0xfd, 0xc1, 0xe3, 0x2a, 0x18, 0x67, 0x5f, 0x3e, 0xb1, 0x4b, 0xaa,
+	0x38, 0xff, 0xe6, 0xcc, 0x46, 0xaa, 0x78, 0x7c, 0x7e, 0x6a, 0x97, 0x55, 0x71, 0x32, 0x38, 0xb3,
+	0x8d, 0xc3, 0x07, 0x70, 0xfd, 0x0d, 0x57, 0xf8, 0x1a, 0xec, 0x3f, 0x8c, 0xc7, 0xc3, 0x33, 0x9e,
+	0x32, 0xbb, 0xa4, 0xd0, 0xb1, 0xf0, 0x87, 0x83, 0x7e, 0xbf, 0x6f, 0x23, 0x5c, 0x07, 0xeb, 0x94,
+	0x66, 0xe1, 0x70, 0x36, 0x0b, 0xef, 0xd9, 0xe5, 0xc3, 0xcf, 0xe1, 0xfa, 0x1b, 0x36, 0x14, 0xa3,
+	0x17, 0x89, 0x15, 0x1f, 0xc0, 0xec, 0x4d, 0x27, 0x31, 0x9b, 0xd9, 0x48, 0xd5, 0x5f, 0x4d, 0x62,
+	0x4e, 0x03, 0xbb, 0xac, 0x4f, 0xf1, 0xcb, 0x54, 0x23, 0xa3, 0x7b, 0xf7, 0xe5, 0x2b, 0xb7, 0xf4,
+	0xd7, 0x2b, 0x17, 0xfd, 0xb4, 0x70, 0xd1, 0xf3, 0x85, 0x8b, 0x5e, 0x2c, 0x5c, 0xf4, 0xfb, 0xc2,
+	0x45, 0x3f, 0x2f, 0xdd, 0xd2, 0x8b, 0xa5, 0x5b, 0x7a, 0xb9, 0x74, 0x4b, 0xdf, 0x56, 0xbd, 0xa3,
+	0xcf, 0x54, 0x76, 0x23, 0x53, 0xff, 0x0c, 0xef, 0xfd, 0x3d, 0x00, 0x5e, 0xe3, 0x90, 0x85, 0x4e,
+	0x07, 0x00, 0x00,
 }
 
 func (this *Header) GoString() string {
@@ -750,7 +778,7 @@ func (this *ResponseHeader) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 13)
+	s := make([]string, 0, 14)
 	s = append(s, "&rpc2.ResponseHeader{")
 	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
 	s = append(s, "Magic: "+fmt.Sprintf("%#v", this.Magic)+",\n")
@@ -761,6 +789,7 @@ func (this *ResponseHeader) GoString() string {
 	s = append(s, "Header: "+strings.Replace(this.Header.GoString(), `&`, ``, 1)+",\n")
 	s = append(s, "Trailer: "+strings.Replace(this.Trailer.GoString(), `&`, ``, 1)+",\n")
 	s = append(s, "Parameter: "+fmt.Sprintf("%#v", this.Parameter)+",\n")
+	s = append(s, "Attr: "+fmt.Sprintf("%#v", this.Attr)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -768,11 +797,12 @@ func (this *Error) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&rpc2.Error{")
 	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
 	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
 	s = append(s, "Detail: "+fmt.Sprintf("%#v", this.Detail)+",\n")
+	s = append(s, "Attr: "+fmt.Sprintf("%#v", this.Attr)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -780,11 +810,12 @@ func (this *ChecksumBlock) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&rpc2.ChecksumBlock{")
 	s = append(s, "Algorithm: "+fmt.Sprintf("%#v", this.Algorithm)+",\n")
 	s = append(s, "Direction: "+fmt.Sprintf("%#v", this.Direction)+",\n")
 	s = append(s, "BlockSize: "+fmt.Sprintf("%#v", this.BlockSize)+",\n")
+	s = append(s, "Window: "+fmt.Sprintf("%#v", this.Window)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1044,6 +1075,13 @@ func (m *ResponseHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Attr) > 0 {
+		i -= len(m.Attr)
+		copy(dAtA[i:], m.Attr)
+		i = encodeVarintRpc2(dAtA, i, uint64(len(m.Attr)))
+		i--
+		dAtA[i] = 0x5a
+	}
 	if len(m.Parameter) > 0 {
 		i -= len(m.Parameter)
 		copy(dAtA[i:], m.Parameter)
@@ -1128,6 +1166,13 @@ func (m *Error) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Attr) > 0 {
+		i -= len(m.Attr)
+		copy(dAtA[i:], m.Attr)
+		i = encodeVarintRpc2(dAtA, i, uint64(len(m.Attr)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Detail) > 0 {
 		i -= len(m.Detail)
 		copy(dAtA[i:], m.Detail)
@@ -1170,6 +1215,11 @@ func (m *ChecksumBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Window != 0 {
+		i = encodeVarintRpc2(dAtA, i, uint64(m.Window))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.BlockSize != 0 {
 		i = encodeVarintRpc2(dAtA, i, uint64(m.BlockSize))
 		i--
@@ -1327,6 +1377,10 @@ func (m *ResponseHeader) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRpc2(uint64(l))
 	}
+	l = len(m.Attr)
+	if l > 0 {
+		n += 1 + l + sovRpc2(uint64(l))
+	}
 	return n
 }
 
@@ -1347,6 +1401,10 @@ func (m *Error) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRpc2(uint64(l))
 	}
+	l = len(m.Attr)
+	if l > 0 {
+		n += 1 + l + sovRpc2(uint64(l))
+	}
 	return n
 }
 
@@ -1365,6 +1423,9 @@ func (m *ChecksumBlock) Size() (n int) {
 	if m.BlockSize != 0 {
 		n += 1 + sovRpc2(uint64(m.BlockSize))
 	}
+	if m.Window != 0 {
+		n += 1 + sovRpc2(uint64(m.Window))
+	}
 	return n
 }
 
@@ -1459,6 +1520,7 @@ func (this *ResponseHeader) String() string {
 		`Header:` + strings.Replace(strings.Replace(this.Header.String(), "Header", "Header", 1), `&`, ``, 1) + `,`,
 		`Trailer:` + strings.Replace(strings.Replace(this.Trailer.String(), "FixedHeader", "FixedHeader", 1), `&`, ``, 1) + `,`,
 		`Parameter:` + fmt.Sprintf("%v", this.Parameter) + `,`,
+		`Attr:` + fmt.Sprintf("%v", this.Attr) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1471,6 +1533,7 @@ func (this *Error) String() string {
 		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
 		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
 		`Detail:` + fmt.Sprintf("%v", this.Detail) + `,`,
+		`Attr:` + fmt.Sprintf("%v", this.Attr) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1483,6 +1546,7 @@ func (this *ChecksumBlock) String() string {
 		`Algorithm:` + fmt.Sprintf("%v", this.Algorithm) + `,`,
 		`Direction:` + fmt.Sprintf("%v", this.Direction) + `,`,
 		`BlockSize:` + fmt.Sprintf("%v", this.BlockSize) + `,`,
+		`Window:` + fmt.Sprintf("%v", this.Window) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2551,6 +2615,38 @@ func (m *ResponseHeader) Unmarshal(dAtA []byte) error {
 				m.Parameter = []byte{}
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc2
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc2
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc2
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc2(dAtA[iNdEx:])
@@ -2684,6 +2780,38 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			}
 			m.Detail = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc2
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc2
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc2
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc2(dAtA[iNdEx:])
@@ -2791,6 +2919,25 @@ func (m *ChecksumBlock) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			m.Window = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc2
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Window |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc2(dAtA[iNdEx:])
@@ -108,6 +108,10 @@ func newTcpServer() (string, *Client, func()) {
 }
 
 func newServer(network string, router *Router) (*Server, *Client, func()) {
+	return newServerWithHandler(network, router.MakeHandler())
+}
+
+func newServerWithHandler(network string, handler Handler) (*Server, *Client, func()) {
 	addr := getAddress(network)
 	trans := DefaultTransportConfig()
 	trans.Version = 2
@@ -115,7 +119,7 @@ func newServer(network string, router *Router) (*Server, *Client, func()) {
 		Name:         addr,
 		Addresses:    []NetworkAddress{{Network: network, Address: addr}},
 		Transport:    trans,
-		Handler:      router.MakeHandler(),
+		Handler:      handler,
 		StatDuration: utilDuration(777 * time.Millisecond),
 	}
 	server.RegisterOnShutdown(func() { log.Info("shutdown") })
@@ -24,6 +24,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/opentracing/opentracing-go/ext"
+
 	"github.com/cubefs/cubefs/blobstore/common/rpc2/transport"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/util"
@@ -39,6 +41,10 @@ func newListener(addr NetworkAddress) (net.Listener, error) {
 	switch addr.Network {
 	case "tcp":
 		return net.Listen(addr.Network, addr.Address)
+	case NetworkUnix:
+		return newUnixListener(addr.Address)
+	case NetworkInproc:
+		return newInprocListener(addr.Address)
 	default:
 		return nil, errors.New("rpc2: not implements " + addr.Network)
 	}
@@ -66,13 +72,21 @@ type Server struct {
 	StatDuration util.Duration `json:"stat_duration"`
 	statOnce     sync.Once
 
+	Metric MetricConfig `json:"metric"`
+
+	MemoryBudget MemoryBudgetConfig `json:"memory_budget"`
+	budget       *memoryBudget
+
+	Mirror MirrorConfig `json:"mirror"`
+	mirror *mirror
+
 	inServe    atomic.Value // true when server waiting to accept
 	inShutdown atomic.Value // true when server is in shutdown
 
 	listenerGroup sync.WaitGroup
 	mu            sync.Mutex
 	listeners     map[*net.Listener]struct{}
-	sessions      map[*transport.Session]struct{}
+	sessions      map[*transport.Session]*connBudget
 	onShutdown    []func()
 }
 
@@ -103,10 +117,10 @@ func (s *Server) stating() {
 				log.Debug("stating on", s.Name)
 				s.mu.Lock()
 				log.Debugf("server has %d listeners", len(s.listeners))
-				log.Debugf("server has %d sessions", len(s.sessions))
-				for sess := range s.sessions {
-					log.Debugf("session (%v - %v) has %d streams",
-						sess.LocalAddr(), sess.RemoteAddr(), sess.NumStreams())
+				log.Debugf("server has %d sessions, %d bytes in flight", len(s.sessions), s.budget.inflight())
+				for sess, cb := range s.sessions {
+					log.Debugf("session (%v - %v) has %d streams, %d bytes in flight",
+						sess.LocalAddr(), sess.RemoteAddr(), sess.NumStreams(), cb.inflight())
 				}
 				s.mu.Unlock()
 			}
@@ -145,6 +159,9 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	for _, f := range s.onShutdown {
 		go f()
 	}
+	if s.mirror != nil {
+		s.mirror.close()
+	}
 	s.mu.Unlock()
 
 	log.Warn("shutdown and try to sleep 5 senconds")
@@ -196,7 +213,13 @@ func (s *Server) Listen(ln net.Listener) error {
 		s.listeners = make(map[*net.Listener]struct{})
 	}
 	if s.sessions == nil {
-		s.sessions = make(map[*transport.Session]struct{})
+		s.sessions = make(map[*transport.Session]*connBudget)
+	}
+	if s.budget == nil {
+		s.budget = newMemoryBudget(s.MemoryBudget)
+	}
+	if s.mirror == nil && s.Mirror.enabled() {
+		s.mirror = newMirror(s.Mirror, s.Name, s.Metric.Enable)
 	}
 	_, has := s.listeners[key]
 	if has {
@@ -218,6 +241,9 @@ func (s *Server) Listen(ln net.Listener) error {
 	}()
 
 	s.stating()
+	if s.Metric.Enable {
+		registerMetrics()
+	}
 	if s.Transport == nil {
 		s.Transport = DefaultTransportConfig()
 	}
@@ -245,12 +271,13 @@ func (s *Server) Listen(ln net.Listener) error {
 }
 
 func (s *Server) handleSession(sess *transport.Session) {
+	cb := s.budget.connection()
 	s.mu.Lock()
-	s.sessions[sess] = struct{}{}
+	s.sessions[sess] = cb
 	s.mu.Unlock()
 	for {
 		if stream, err := sess.AcceptStream(); err == nil {
-			go s.handleStream(stream)
+			go s.handleStream(stream, cb)
 		} else {
 			log.Errorf("session %v accept stream %v, %s",
 				sess.LocalAddr(), sess.RemoteAddr(), err.Error())
@@ -263,8 +290,10 @@ func (s *Server) handleSession(sess *transport.Session) {
 	s.mu.Unlock()
 }
 
-func (s *Server) handleStream(stream *transport.Stream) {
+func (s *Server) handleStream(stream *transport.Stream, cb *connBudget) {
 	ctx := context.Background()
+	var reserved int64 // body bytes reserved of the handling request
+	defer func() { cb.release(reserved) }()
 	if err := func() error {
 		for {
 			req, err := s.readRequest(stream)
@@ -272,16 +301,29 @@ func (s *Server) handleStream(stream *transport.Stream) {
 				return err
 			}
 			ctx = req.Context()
+			metric := s.startMetric(req)
+
+			// reserve before reading body, the request is rejected
+			// without calling handler if the budget is exceeded
+			reserved, err = cb.reserve(req.ContentLength)
+			handle := s.Handler.Handle
+			if err != nil {
+				budgetErr := err
+				handle = func(ResponseWriter, *Request) error { return budgetErr }
+			} else if s.mirror != nil {
+				handle = s.mirror.handler(handle)
+			}
 
 			resp := getResponse()
 			resp.ctx = req.ctx
 			resp.conn = stream
 			if ss := req.stream; ss != nil {
-				if err = s.Handler.Handle(resp, req); err != nil {
+				if err = handle(resp, req); err != nil {
 					status, reason, detail := DetectError(err)
 					ss.hdr.Status = int32(status)
 					ss.hdr.Reason = reason
 					ss.hdr.Error = detail.Error()
+					ss.hdr.Attr = DetectErrorAttr(err)
 					getSpan(ctx).Warn(err)
 				} else {
 					ss.hdr.Status = 200
@@ -289,31 +331,38 @@ func (s *Server) handleStream(stream *transport.Stream) {
 				ss.sentHeader = false
 				ss.SendHeader(nil)
 				stream.Close()
+				metric.report(req, ss.hdr.Status, 0)
 				return err
 			}
 
 			resp.options(req)
-			if err = s.Handler.Handle(resp, req); err != nil {
+			if err = handle(resp, req); err != nil {
 				if resp.hasWroteHeader {
 					req.Span().Warn("handle error but header has wrote", err)
 				} else {
 					status, reason, detail := DetectError(err)
 					resp.hdr.Reason = reason
 					resp.hdr.Error = detail.Error()
+					resp.hdr.Attr = DetectErrorAttr(err)
 					resp.WriteHeader(status, NoParameter)
 					getSpan(ctx).Warn(err)
 				}
 			}
 
 			if err = resp.WriteOK(nil); err != nil {
+				metric.reportFailure(req)
 				return err
 			}
 			if err = resp.Flush(); err != nil {
+				metric.reportFailure(req)
 				return err
 			}
+			metric.report(req, resp.hdr.Status, resp.hdr.ContentLength)
 			if err = req.Body.Close(); err != nil {
 				return err
 			}
+			cb.release(reserved)
+			reserved = 0
 			if resp.connBroken {
 				return errors.New("stream conn has broken")
 			}
@@ -354,11 +403,13 @@ func (s *Server) readRequest(stream *transport.Stream) (*Request, error) {
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	_, ctx = trace.StartSpanFromContextWithTraceID(ctx, "", traceID)
+	spanCtx, _ := trace.Extract(trace.TextMap, traceCarrier{header: &req.Header})
+	_, ctx = trace.StartSpanFromContextWithTraceID(ctx, "", traceID, ext.RPCServerOption(spanCtx))
 
 	req.ctx = ctx
 	req.conn = stream
 	req.cancel = cancel
+	stream.SetPriority(req.Header.Get(HeaderInternalPriority) != "")
 	if sum := req.Header.Get(HeaderInternalChecksum); sum != "" {
 		block, err := unmarshalBlock([]byte(sum))
 		if err != nil {
@@ -369,14 +420,18 @@ func (s *Server) readRequest(stream *transport.Stream) (*Request, error) {
 	}
 
 	decode := req.checksum != ChecksumBlock{} && req.checksum.Direction.IsUpload()
-	payloadSize := req.Trailer.AllSize()
-	if decode {
-		payloadSize += int(req.checksum.EncodeSize(req.ContentLength))
+	if decode && req.checksum.retransmit(req.ContentLength) {
+		req.Body = makeWindowBodyWithTrailer(stream, frame, req, &req.Trailer, req.ContentLength)
 	} else {
-		payloadSize += int(req.ContentLength)
+		payloadSize := req.Trailer.AllSize()
+		if decode {
+			payloadSize += int(req.checksum.EncodeSize(req.ContentLength))
+		} else {
+			payloadSize += int(req.ContentLength)
+		}
+		req.Body = makeBodyWithTrailer(stream.NewSizedReader(req.ctx, payloadSize, frame),
+			req, &req.Trailer, req.ContentLength, decode)
 	}
-	req.Body = makeBodyWithTrailer(stream.NewSizedReader(req.ctx, payloadSize, frame),
-		req, &req.Trailer, req.ContentLength, decode)
 
 	if req.StreamCmd == StreamCmd_SYN {
 		req.stream = &serverStream{req: req}
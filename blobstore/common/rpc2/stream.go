@@ -155,7 +155,7 @@ func (cs *clientStream) SendMsg(a any) error {
 
 	req := cs.newRequest()
 	req.StreamCmd = StreamCmd_PSH
-	if _headerCell+req.RequestHeader.Size()+msg.Size() > req.conn.MaxPayloadSize() {
+	if msg.Size() > _maxCodecerSize {
 		return ErrFrameHeader
 	}
 	req.ContentLength = int64(msg.Size())
@@ -185,15 +185,16 @@ func (cs *clientStream) RecvMsg(a any) (err error) {
 		cs.trailer.Merge(resp.Trailer.ToHeader())
 		cs.req.client.Connector.Put(cs.req.Context(), cs.req.conn, true)
 		if resp.Status != 200 {
-			return NewError(resp.Status, resp.Reason, resp.Error)
+			return &Error{Status: resp.Status, Reason: resp.Reason, Detail: resp.Error, Attr: resp.Attr}
 		}
 		return io.EOF
 	}
 
-	if int64(frame.Len()) < resp.ContentLength {
-		return ErrFrameHeader
+	var b []byte
+	if frame, b, err = readFrameBytes(cs.Context(), conn, frame, int(resp.ContentLength)); err != nil {
+		return err
 	}
-	return msg.Unmarshal(frame.Bytes(int(resp.ContentLength)))
+	return msg.Unmarshal(b)
 }
 
 func (cs *clientStream) newRequest() *Request {
@@ -288,10 +289,11 @@ func (ss *serverStream) RecvMsg(a any) (err error) {
 		return
 	}
 
-	if int64(frame.Len()) < req.ContentLength {
-		return ErrFrameHeader
+	var b []byte
+	if frame, b, err = readFrameBytes(ss.Context(), ss.req.conn, frame, int(req.ContentLength)); err != nil {
+		return err
 	}
-	return msg.Unmarshal(frame.Bytes(int(req.ContentLength)))
+	return msg.Unmarshal(b)
 }
 
 func (ss *serverStream) supplyHeader() (err error) {
@@ -304,12 +306,12 @@ func (ss *serverStream) supplyHeader() (err error) {
 }
 
 func (ss *serverStream) writeFrameMsg(hdr *ResponseHeader, msg Marshaler) error {
-	size := _headerCell + hdr.Size() + msg.Size()
-	if size > ss.req.conn.MaxPayloadSize() {
+	if hdr.Size() > _maxCodecerSize || msg.Size() > _maxCodecerSize {
 		return ErrFrameHeader
 	}
+	size := _headerCell + hdr.Size() + msg.Size()
 	var cell headerCell
-	cell.Set(hdr.Size())
+	cell.SetFramed(hdr.Size(), ss.req.conn.MaxPayloadSize())
 	_, err := ss.req.conn.SizedWrite(ss.Context(), io.MultiReader(
 		codec2CellReader(cell, hdr), Codec2Reader(msg)), size)
 	return err
@@ -16,6 +16,7 @@ package rpc2
 
 import (
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/cubefs/cubefs/blobstore/cli/common/fmt"
@@ -116,3 +117,30 @@ func TestStreamBase(t *testing.T) {
 	<-waitc
 	require.Equal(t, "bbb", trailer.Get("stream-trailer"))
 }
+
+func TestStreamFragmentedMessage(t *testing.T) {
+	handler := &Router{}
+	handler.Register("/", handleStreamFull)
+	server, cli, shutdown := newServer("tcp", handler)
+	defer shutdown()
+	sc := StreamClient[streamReq, streamResp]{Client: cli}
+
+	var para strMessage
+	para.Value = strings.Repeat("p", 2*server.Transport.MaxFrameSize)
+	req, err := NewStreamRequest(testCtx, server.Name, "/", &para)
+	require.NoError(t, err)
+	cc, err := sc.Streaming(req, &para)
+	require.NoError(t, err)
+
+	for _, size := range []int{1, server.Transport.MaxFrameSize, 3 * server.Transport.MaxFrameSize} {
+		var msg streamReq
+		msg.Value = strings.Repeat("m", size)
+		require.NoError(t, cc.Send(&msg))
+		resp, err := cc.Recv()
+		require.NoError(t, err)
+		require.Equal(t, msg.Value, resp.Value)
+	}
+	require.NoError(t, cc.CloseSend())
+	_, err = cc.Recv()
+	require.ErrorIs(t, err, io.EOF)
+}
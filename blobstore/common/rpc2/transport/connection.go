@@ -3,6 +3,7 @@ package transport
 import (
 	"io"
 	"net"
+	"os"
 	"time"
 )
 
@@ -31,6 +32,12 @@ type WriteBuffers interface {
 	WriteBuffers(buffers []AssignedBuffer) (n int, err error)
 }
 
+// FileWriter writes n bytes of file at offset to connection,
+// it's zero-copy if the connection supports.
+type FileWriter interface {
+	WriteFile(f *os.File, off int64, n int) (int, error)
+}
+
 type netConn struct {
 	net.Conn
 	allocator Allocator
@@ -62,6 +69,19 @@ func (c *netConn) WriteBuffer(buffer AssignedBuffer) (n int, err error) {
 	return
 }
 
+var _ FileWriter = (*netConn)(nil)
+
+func (c *netConn) WriteFile(f *os.File, off int64, n int) (int, error) {
+	if written, handled, err := sendFile(c.Conn, f, off, n); handled {
+		return written, err
+	}
+	nn, err := io.Copy(c.Conn, io.NewSectionReader(f, off, int64(n)))
+	if nn != int64(n) && err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	return int(nn), err
+}
+
 type netConnv struct{ netConn }
 
 var _ WriteBuffers = netConnv{}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"os"
 	"sync"
 	"sync/atomic"
 )
@@ -91,6 +92,11 @@ type FrameWrite struct {
 	off  int
 	data []byte // with frame header
 
+	// payload in file, sent after the header in data
+	file    *os.File
+	fileOff int64
+	fileLen int
+
 	done uint32 // 0 = new, 1 = locked, 2 == closed
 	ctx  context.Context
 }
@@ -141,7 +147,7 @@ func (f *FrameWrite) ReadFrom(r io.Reader) (int64, error) {
 }
 
 func (f *FrameWrite) Len() int {
-	return f.off - headerSize
+	return f.off - headerSize + f.fileLen
 }
 
 func (f *FrameWrite) Close() (err error) {
@@ -152,6 +158,8 @@ func (f *FrameWrite) Close() (err error) {
 	}
 	f.data = nil
 	f.off = 0
+	f.file = nil
+	f.fileLen = 0
 	if f.ab != nil {
 		err = f.ab.Free()
 		f.ab = nil
@@ -0,0 +1,83 @@
+//go:build linux
+// +build linux
+
+package transport
+
+import (
+	"io"
+	"net"
+	"os"
+	"syscall"
+)
+
+// max bytes of once sendfile, same as linux MAX_RW_COUNT
+const maxSendfileSize = 0x7ffff000
+
+// sendFile sends n bytes of file at offset to the connection by sendfile(2),
+// the offset of file is not changed. It waits on the poller if the socket
+// is not writable, and returns the error if the write deadline exceeded.
+// Not handled if the connection has no raw fd or the file is unsupported.
+func sendFile(conn net.Conn, f *os.File, off int64, n int) (written int, handled bool, err error) {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return 0, false, nil
+	}
+	rawConn, err := sc.SyscallConn()
+	if err != nil {
+		return 0, false, nil
+	}
+	rawFile, err := f.SyscallConn()
+	if err != nil {
+		return 0, false, nil
+	}
+
+	var errno error
+	errCtrl := rawFile.Control(func(infd uintptr) {
+		err = rawConn.Write(func(outfd uintptr) bool {
+			for written < n {
+				size := n - written
+				if size > maxSendfileSize {
+					size = maxSendfileSize
+				}
+				m, e := syscall.Sendfile(int(outfd), int(infd), &off, size)
+				if m > 0 {
+					written += m
+				}
+				switch e {
+				case nil:
+					if m == 0 {
+						errno = io.ErrUnexpectedEOF
+						return true
+					}
+				case syscall.EINTR:
+				case syscall.EAGAIN:
+					return false
+				default:
+					errno = os.NewSyscallError("sendfile", e)
+					return true
+				}
+			}
+			return true
+		})
+	})
+	if errCtrl != nil {
+		return 0, false, nil
+	}
+	if err == nil {
+		err = errno
+	}
+	if written == 0 && isSendfileUnsupported(errno) {
+		return 0, false, nil
+	}
+	return written, true, err
+}
+
+func isSendfileUnsupported(err error) bool {
+	if se, ok := err.(*os.SyscallError); ok {
+		switch se.Err {
+		case syscall.EINVAL, syscall.ENOSYS, syscall.EOPNOTSUPP:
+			return true
+		}
+	}
+	return false
+}
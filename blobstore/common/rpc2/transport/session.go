@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,6 +21,7 @@ type CLASSID uint8
 const (
 	CLSCTRL CLASSID = iota
 	CLSDATA
+	CLSHIGH // data of high priority stream
 )
 
 var (
@@ -84,6 +86,7 @@ type Session struct {
 	deadline atomic.Value
 
 	ctrl         chan writeRequest // a ctrl frame for writing
+	high         chan writeRequest // data frames of high priority streams
 	writes       chan writeRequest
 	resultChPool sync.Pool
 }
@@ -99,6 +102,7 @@ func newSession(config *Config, conn Conn, client bool) *Session {
 	s.bucketNotify = make(chan struct{}, 1)
 	s.pingpong = make(chan struct{}, 1)
 	s.ctrl = make(chan writeRequest, 1)
+	s.high = make(chan writeRequest)
 	s.writes = make(chan writeRequest)
 	s.resultChPool = sync.Pool{New: func() interface{} {
 		return make(chan writeResult, 1)
@@ -461,6 +465,7 @@ func (s *Session) sendLoop() {
 	var idx, n, nn int
 	var err error
 	var request writeRequest
+	var hasFile bool
 
 	const maxLen = 32
 	maxSize := s.config.MaxReceiveBuffer / 2
@@ -472,21 +477,32 @@ func (s *Session) sendLoop() {
 		for idx = range requests {
 			requests[idx].frame.unlock()
 		}
+		if hasFile {
+			request.frame.unlock()
+		}
 		requests = requests[:0]
 		buffers = buffers[:0]
 	}()
 
 	for {
+		// ctrl frames first, then data frames of high priority streams
 		select {
 		case request = <-s.ctrl:
 		case <-s.die:
 			return
 		default:
 			select {
+			case request = <-s.high:
 			case <-s.die:
 				return
-			case request = <-s.ctrl:
-			case request = <-s.writes:
+			default:
+				select {
+				case <-s.die:
+					return
+				case request = <-s.ctrl:
+				case request = <-s.high:
+				case request = <-s.writes:
+				}
 			}
 		}
 		if !request.frame.tryLock() { // closed
@@ -494,6 +510,15 @@ func (s *Session) sendLoop() {
 		}
 		request.frame.writeHeader()
 
+	WriteFile:
+		if request.frame.file != nil {
+			if err = s.writeFileFrame(request); err != nil {
+				s.notifyWriteError(err)
+				return
+			}
+			continue
+		}
+
 		if !isWritev {
 			s.conn.SetWriteDeadline(request.deadline)
 			n, err = s.conn.WriteBuffer(request.frame.ab)
@@ -527,15 +552,23 @@ func (s *Session) sendLoop() {
 			}
 
 			select {
-			case request = <-s.writes:
+			case request = <-s.high:
 			default:
-				break LoopMore
+				select {
+				case request = <-s.writes:
+				default:
+					break LoopMore
+				}
 			}
 
 			if !request.frame.tryLock() {
 				break
 			}
 			request.frame.writeHeader()
+			if request.frame.file != nil { // written after the batch
+				hasFile = true
+				break
+			}
 		}
 
 		s.conn.SetWriteDeadline(deadline)
@@ -549,12 +582,34 @@ func (s *Session) sendLoop() {
 		}
 
 		for idx = range requests {
-			request = requests[idx]
-			n = request.frame.Len()
-			request.frame.unlock()
-			request.result <- writeResult{n: n}
+			n = requests[idx].frame.Len()
+			requests[idx].frame.unlock()
+			requests[idx].result <- writeResult{n: n}
+		}
+		requests = requests[:0]
+		if hasFile {
+			hasFile = false
+			goto WriteFile
+		}
+	}
+}
+
+// writeFileFrame writes the header and then the file payload of frame
+func (s *Session) writeFileFrame(request writeRequest) error {
+	f := request.frame
+	s.conn.SetWriteDeadline(request.deadline)
+	n, err := s.conn.WriteBuffer(f.ab)
+	if err == nil {
+		n, err = s.conn.(FileWriter).WriteFile(f.file, f.fileOff, f.fileLen)
+		if err == nil && n != f.fileLen {
+			err = io.ErrShortWrite
 		}
+	} else {
+		n = 0
 	}
+	f.unlock()
+	request.result <- writeResult{n: n, err: err}
+	return err
 }
 
 // writeFrame writes the frame to the underlying connection
@@ -578,8 +633,11 @@ func (s *Session) writeFrameInternal(f *FrameWrite, deadline writeDealine, class
 		result:   s.resultChPool.Get().(chan writeResult),
 	}
 	writeCh := s.writes
-	if class == CLSCTRL {
+	switch class {
+	case CLSCTRL:
 		writeCh = s.ctrl
+	case CLSHIGH:
+		writeCh = s.high
 	}
 
 	ctx := f.Context()
@@ -630,6 +688,18 @@ func (s *Session) newFrameWrite(cmd byte, sid uint32, size int) (*FrameWrite, er
 	}, nil
 }
 
+// newFileFrameWrite returns frame with payload of n bytes in file at offset
+func (s *Session) newFileFrameWrite(sid uint32, file *os.File, off int64, n int) (*FrameWrite, error) {
+	frame, err := s.newFrameWrite(cmdPSH, sid, 0)
+	if err != nil {
+		return nil, err
+	}
+	frame.file = file
+	frame.fileOff = off
+	frame.fileLen = n
+	return frame, nil
+}
+
 func (s *Session) newFrameRead(buffer AssignedBuffer) *FrameRead {
 	return &FrameRead{
 		ab:   buffer,
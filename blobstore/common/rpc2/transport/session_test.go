@@ -11,9 +11,11 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"os"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -1014,6 +1016,74 @@ func TestWriteFrameInternal(t *testing.T) {
 	}
 }
 
+func TestStreamPriorityWindow(t *testing.T) {
+	c1, c2, err := getTCPConnectionPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.Version = 2
+	server, _ := Server(newConn(c1), config)
+	defer server.Close()
+	client, _ := Client(newConn(c2), config)
+	defer client.Close()
+
+	cs, _ := client.OpenStream()
+	ss, err := server.AcceptStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs.SetPriority(true)
+	if cs.dataClass() != CLSHIGH {
+		t.Fatal("high priority stream")
+	}
+	writeRead := func() {
+		if _, err := cs.SizedWrite(testCtx, strings.NewReader("hello"), 5); err != nil {
+			t.Fatal(err)
+		}
+		fr, err := ss.ReadFrame(testCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fr.Close()
+	}
+	waitWindow := func(window uint32) {
+		for i := 0; i < 100; i++ {
+			if atomic.LoadUint32(&cs.peerWindow) == window {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("peer window not updated", atomic.LoadUint32(&cs.peerWindow), window)
+	}
+
+	writeRead()
+	waitWindow(uint32(config.MaxStreamBuffer))
+
+	cs.SetPriority(false)
+	if cs.dataClass() != CLSDATA {
+		t.Fatal("normal priority stream")
+	}
+	ss.SetWindow(3 << 20)
+	writeRead()
+	waitWindow(3 << 20)
+
+	ss.SetWindow(64 << 10)
+	if ss.windowSize() != uint32(2*config.MaxFrameSize) {
+		t.Fatal("min window", ss.windowSize())
+	}
+	writeRead()
+	waitWindow(uint32(2 * config.MaxFrameSize))
+
+	ss.SetWindow(config.MaxReceiveBuffer + 1)
+	if ss.windowSize() != uint32(config.MaxStreamBuffer) {
+		t.Fatal("reset window", ss.windowSize())
+	}
+	writeRead()
+	waitWindow(uint32(config.MaxStreamBuffer))
+}
+
 func TestReadDeadline(t *testing.T) {
 	_, stop, cli, err := setupServer(t)
 	if err != nil {
@@ -1061,6 +1131,71 @@ func TestWriteDeadline(t *testing.T) {
 	session.Close()
 }
 
+func TestWriteFile(t *testing.T) {
+	const k64 = 64 * (1 << 10)
+	data := make([]byte, 3*k64)
+	crand.Read(data)
+	f, err := os.CreateTemp(t.TempDir(), "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err = f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v2 := range []bool{false, true} {
+		_, stop, cli, err := _setupServer(t, v2, v2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config := DefaultConfig()
+		if v2 {
+			config.Version = 2
+		}
+		session, _ := Client(cli, config)
+		stream, _ := session.OpenStream()
+		stream.frameSize = k64
+
+		off, size := 100, 2*k64+10
+		n, err := stream.WriteFile(testCtx, f, int64(off), size)
+		if err != nil || n != size {
+			t.Fatal(n, err)
+		}
+		buf := make([]byte, size)
+		rc := stream.SizedReader(testCtx, size)
+		if _, err = io.ReadFull(rc, buf); err != nil {
+			t.Fatal(err)
+		}
+		rc.Close()
+		if !bytes.Equal(data[off:off+size], buf) {
+			t.Fatal("data mismatch")
+		}
+		// mixed with buffered frames
+		writeThenRead(stream, "hello")
+
+		// file is shorter
+		if _, err = stream.WriteFile(testCtx, f, int64(len(data)-10), 20); err != io.ErrUnexpectedEOF {
+			t.Fatal(err)
+		}
+		writeThenRead(stream, "world")
+		stream.Close()
+		session.Close()
+		stop()
+	}
+
+	// fallback to copy if no raw fd
+	c1, c2 := net.Pipe()
+	go func() {
+		newConn(c1).(FileWriter).WriteFile(f, 10, 100)
+		c1.Close()
+	}()
+	buf, _ := io.ReadAll(c2)
+	if !bytes.Equal(data[10:110], buf) {
+		t.Fatal("data mismatch")
+	}
+}
+
 func TestConnection(t *testing.T) {
 	c1, _ := newPipe()
 	conn1 := newConn(c1)
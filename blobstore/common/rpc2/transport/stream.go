@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"io"
 	"net"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -34,14 +35,18 @@ type Stream struct {
 	writeDeadline atomic.Value
 
 	// per stream sliding window control
-	numRead    uint32 // number of consumed bytes
-	numWritten uint32 // count num of bytes written
-	incr       uint32 // counting for sending
+	numRead       uint32 // number of consumed bytes
+	numWritten    uint32 // count num of bytes written
+	incr          uint32 // counting for sending
+	window        uint32 // receive window advertised to peer, MaxStreamBuffer if zero
+	windowChanged uint32 // advertise the window at the next read
 
 	// UPD command
 	peerConsumed uint32        // num of bytes the peer has consumed
 	peerWindow   uint32        // peer window, initialized to 256KB, updated by peer
 	chUpdate     chan struct{} // notify of remote data consuming and window update
+
+	high uint32 // data frames are sent in high priority if not zero
 }
 
 // newStream initiates a Stream struct
@@ -64,6 +69,49 @@ func (s *Stream) ID() uint32 {
 	return s.id
 }
 
+// SetPriority sets priority of data frames written by the stream, frames of
+// high priority streams are sent before the others in the same session,
+// so small requests are not blocked by large data of other streams.
+func (s *Stream) SetPriority(high bool) {
+	var val uint32
+	if high {
+		val = 1
+	}
+	atomic.StoreUint32(&s.high, val)
+}
+
+// SetWindow sets the receive window of the stream advertised to peer, which bounds
+// bytes in flight of the stream, resets to MaxStreamBuffer if size is not positive.
+// A smaller window of bulk stream leaves more session buffer to the other streams,
+// it's at least twice MaxFrameSize, as half of the window may be not acknowledged,
+// the peer should be able to send a full frame in the other half.
+// The window is advertised to peer at the next read, works on version 2 only.
+func (s *Stream) SetWindow(size int) {
+	switch {
+	case size <= 0 || size > s.sess.config.MaxReceiveBuffer:
+		size = 0
+	case size < 2*s.sess.config.MaxFrameSize:
+		size = 2 * s.sess.config.MaxFrameSize
+	}
+	if atomic.SwapUint32(&s.window, uint32(size)) != uint32(size) {
+		atomic.StoreUint32(&s.windowChanged, 1)
+	}
+}
+
+func (s *Stream) windowSize() uint32 {
+	if window := atomic.LoadUint32(&s.window); window > 0 {
+		return window
+	}
+	return uint32(s.sess.config.MaxStreamBuffer)
+}
+
+func (s *Stream) dataClass() CLASSID {
+	if atomic.LoadUint32(&s.high) > 0 {
+		return CLSHIGH
+	}
+	return CLSDATA
+}
+
 // MaxPayloadSize returns max payload size of frame
 func (s *Stream) MaxPayloadSize() int {
 	return s.frameSize - headerSize
@@ -143,7 +191,8 @@ func (s *Stream) tryReadFramev2() (*FrameRead, error) {
 	n := f.Len()
 	s.numRead += uint32(n)
 	s.incr += uint32(n)
-	if s.incr >= uint32(s.sess.config.MaxStreamBuffer/2) || s.numRead == uint32(n) {
+	changed := atomic.CompareAndSwapUint32(&s.windowChanged, 1, 0)
+	if s.incr >= s.windowSize()/2 || s.numRead == uint32(n) || changed {
 		notifyConsumed = s.numRead
 		s.incr = 0
 	}
@@ -177,9 +226,9 @@ func (s *Stream) sendWindowUpdate(consumed uint32) error {
 		return err
 	}
 	binary.LittleEndian.PutUint32(hdr[:], consumed)
-	binary.LittleEndian.PutUint32(hdr[4:], uint32(s.sess.config.MaxStreamBuffer))
+	binary.LittleEndian.PutUint32(hdr[4:], s.windowSize())
 	frame.Write(hdr[:])
-	_, err = s.sess.writeFrameInternal(frame, deadline, CLSDATA)
+	_, err = s.sess.writeFrameInternal(frame, deadline, s.dataClass())
 	return err
 }
 
@@ -246,6 +295,50 @@ func (s *Stream) SizedWrite(ctx context.Context, r io.Reader, size int) (n int,
 	return
 }
 
+// WriteFile writes size bytes of file at offset, the payload of frames is
+// sent from file to connection without copying into userspace if supported.
+// The file should not be closed or truncated before returned.
+func (s *Stream) WriteFile(ctx context.Context, f *os.File, off int64, size int) (n int, err error) {
+	if _, ok := s.sess.conn.(FileWriter); !ok {
+		return s.SizedWrite(ctx, io.NewSectionReader(f, off, int64(size)), size)
+	}
+	// the session is broken if file is shorter after frame header sent
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	if off+int64(size) > info.Size() {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	maxPayloadSize := s.MaxPayloadSize()
+	var nn int
+	var fw *FrameWrite
+	for size > 0 {
+		alloc := size
+		if alloc > maxPayloadSize {
+			alloc = maxPayloadSize
+		}
+
+		fw, err = s.sess.newFileFrameWrite(s.id, f, off, alloc)
+		if err != nil {
+			return
+		}
+
+		fw.WithContext(ctx)
+		nn, err = s.WriteFrame(fw)
+		fw.Close()
+		if err != nil {
+			return
+		}
+
+		n += nn
+		size -= nn
+		off += int64(nn)
+	}
+	return
+}
+
 // WriteFrame close frame by caller if has error.
 func (s *Stream) WriteFrame(frame *FrameWrite) (n int, err error) {
 	// check empty input
@@ -275,7 +368,7 @@ func (s *Stream) WriteFrame(frame *FrameWrite) (n int, err error) {
 		return s.writeFrameV2(frame, deadline)
 	}
 
-	sent, err := s.sess.writeFrameInternal(frame, deadline, CLSDATA)
+	sent, err := s.sess.writeFrameInternal(frame, deadline, s.dataClass())
 	s.numWritten += uint32(sent)
 	return sent, err
 }
@@ -298,7 +391,7 @@ func (s *Stream) writeFrameV2(frame *FrameWrite, deadline writeDealine) (n int,
 
 		win := int32(atomic.LoadUint32(&s.peerWindow)) - inflight
 		if win >= int32(frame.Len()) || s.numWritten == 0 {
-			sent, err := s.sess.writeFrameInternal(frame, deadline, CLSDATA)
+			sent, err := s.sess.writeFrameInternal(frame, deadline, s.dataClass())
 			s.numWritten += uint32(sent)
 			return sent, err
 		}
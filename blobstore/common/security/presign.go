@@ -0,0 +1,133 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package security
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+const (
+	// PresignTokenSize max size of presign token array
+	// hmac + op + bid + time
+	PresignTokenSize = 8 + 1 + 10 + 5
+
+	// PresignMaxExpiration max expiration of presign token
+	PresignMaxExpiration = time.Hour * 24 * 7
+)
+
+// PresignOp operation scope of presign token
+type PresignOp uint8
+
+// presign operations
+const (
+	PresignOpGet PresignOp = iota + 1
+	PresignOpDelete
+)
+
+// IsValid returns the operation is valid or not
+func (op PresignOp) IsValid() bool {
+	return op == PresignOpGet || op == PresignOpDelete
+}
+
+// PresignToken time-limited token restricted to one operation of one blob,
+// upstream gateways hand it out in presigned-style urls.
+// [0:8]   hmac (8) first 8 bytes of sha1 summary
+// [8:9]   op (1)
+// [9:]    bid (uvarint)
+// [-:]    time (uvarint) expired unix utc time
+type PresignToken struct {
+	Data   [PresignTokenSize]byte
+	Offset uint8
+}
+
+// IsValid returns the token is valid or not
+func (t *PresignToken) IsValid(op PresignOp, clusterID proto.ClusterID, codeMode codemode.CodeMode,
+	vid proto.Vid, bid proto.BlobID, size uint32, secretKey []byte,
+) bool {
+	if t.Offset <= 9 || PresignOp(t.Data[8]) != op {
+		return false
+	}
+
+	data := t.Data[:t.Offset]
+	offset := 9
+	tokenBid, n := binary.Uvarint(data[offset:])
+	if n <= 0 || tokenBid != uint64(bid) {
+		return false
+	}
+	offset += n
+	expiredTime, n := binary.Uvarint(data[offset:])
+	if n <= 0 || time.Now().UTC().Unix() > int64(expiredTime) {
+		return false
+	}
+
+	token := newPresignToken(op, clusterID, codeMode, vid, bid, size, uint32(expiredTime), secretKey)
+	return bytes.Equal(token.Data[0:8], t.Data[0:8])
+}
+
+// NewPresignToken returns a token of the operation on the blob with expiration
+func NewPresignToken(op PresignOp, clusterID proto.ClusterID, codeMode codemode.CodeMode,
+	vid proto.Vid, bid proto.BlobID, size uint32, expiration time.Duration, secretKey []byte,
+) PresignToken {
+	expiredTime := uint32(time.Now().Add(expiration).UTC().Unix())
+	return newPresignToken(op, clusterID, codeMode, vid, bid, size, expiredTime, secretKey)
+}
+
+func newPresignToken(op PresignOp, clusterID proto.ClusterID, codeMode codemode.CodeMode,
+	vid proto.Vid, bid proto.BlobID, size uint32, expiredTime uint32, secretKey []byte,
+) PresignToken {
+	var token PresignToken
+	data := token.Data[:]
+	data[8] = byte(op)
+
+	offset := 9
+	offset += binary.PutUvarint(data[offset:], uint64(bid))
+	offset += binary.PutUvarint(data[offset:], uint64(expiredTime))
+	token.Offset = uint8(offset)
+
+	var scope [4 + 1 + 4 + 4]byte
+	binary.BigEndian.PutUint32(scope[0:4], uint32(clusterID))
+	scope[4] = byte(codeMode)
+	binary.BigEndian.PutUint32(scope[5:9], uint32(vid))
+	binary.BigEndian.PutUint32(scope[9:13], size)
+
+	h := hmac.New(sha1.New, secretKey)
+	h.Write(scope[:])
+	h.Write(data[8:offset])
+	sum := h.Sum(nil)
+
+	copy(data[0:8], sum[0:8])
+	return token
+}
+
+// EncodePresignToken encode presign token to string
+func EncodePresignToken(token PresignToken) string {
+	return hex.EncodeToString(token.Data[:token.Offset])
+}
+
+// DecodePresignToken decode presign token from string
+func DecodePresignToken(s string) PresignToken {
+	var token PresignToken
+	src, _ := hex.DecodeString(s)
+	token.Offset = uint8(copy(token.Data[:], src))
+	return token
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package security_test
+
+import (
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/security"
+)
+
+func TestAccessServerPresignTokenValid(t *testing.T) {
+	secretKey := []byte{0x1f, 0xff}
+	for ii := 0; ii < 1000; ii++ {
+		cid := proto.ClusterID(mrand.Uint32())
+		vid := proto.Vid(mrand.Uint32())
+		bid := proto.BlobID(mrand.Uint64())
+		size := mrand.Uint32()
+		mode := codemode.EC6P6
+
+		token := security.NewPresignToken(security.PresignOpGet, cid, mode, vid, bid, size, time.Minute, secretKey)
+		require.True(t, token.IsValid(security.PresignOpGet, cid, mode, vid, bid, size, secretKey))
+
+		require.False(t, token.IsValid(security.PresignOpDelete, cid, mode, vid, bid, size, secretKey))
+		require.False(t, token.IsValid(security.PresignOpGet, cid+1, mode, vid, bid, size, secretKey))
+		require.False(t, token.IsValid(security.PresignOpGet, cid, codemode.EC3P3, vid, bid, size, secretKey))
+		require.False(t, token.IsValid(security.PresignOpGet, cid, mode, vid+1, bid, size, secretKey))
+		require.False(t, token.IsValid(security.PresignOpGet, cid, mode, vid, bid+1, size, secretKey))
+		require.False(t, token.IsValid(security.PresignOpGet, cid, mode, vid, bid, size+1, secretKey))
+		require.False(t, token.IsValid(security.PresignOpGet, cid, mode, vid, bid, size, secretKey[:1]))
+
+		str := security.EncodePresignToken(token)
+		tokenx := security.DecodePresignToken(str)
+		require.Equal(t, token.Offset, tokenx.Offset)
+		require.True(t, tokenx.IsValid(security.PresignOpGet, cid, mode, vid, bid, size, secretKey))
+
+		tokenx.Data[8] = byte(security.PresignOpDelete)
+		require.False(t, tokenx.IsValid(security.PresignOpDelete, cid, mode, vid, bid, size, secretKey))
+	}
+
+	var token security.PresignToken
+	require.False(t, token.IsValid(security.PresignOpGet, 1, codemode.EC6P6, 1, 1, 1, secretKey))
+	token = security.DecodePresignToken("invalid-hex")
+	require.False(t, token.IsValid(security.PresignOpGet, 1, codemode.EC6P6, 1, 1, 1, secretKey))
+}
+
+func TestAccessServerPresignTokenExpired(t *testing.T) {
+	secretKey := []byte{0x1f, 0xff}
+	for ii := 0; ii < 100; ii++ {
+		expired := mrand.Intn(40) - 20
+		token := security.NewPresignToken(security.PresignOpDelete, 1, codemode.EC6P6, 1, 1, 1,
+			time.Duration(expired)*time.Second, secretKey)
+		if expired >= 0 {
+			require.True(t, token.IsValid(security.PresignOpDelete, 1, codemode.EC6P6, 1, 1, 1, secretKey))
+		} else {
+			require.False(t, token.IsValid(security.PresignOpDelete, 1, codemode.EC6P6, 1, 1, 1, secretKey))
+		}
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/util/log"
+)
+
+const (
+	serviceTokenPrefix = "svc2."
+	serviceTokenFields = 6
+	serviceNonceSize   = 16
+
+	// PolicyAnyService matches all authenticated services in method policy,
+	// but not the legacy shared secret
+	PolicyAnyService = "*"
+	// LegacyService identity of requests authenticated by the legacy shared secret,
+	// the methods with policies are allowed only if it is listed explicitly
+	LegacyService = "_legacy"
+	// UnsignedPayload body digest of request whose body is not signed
+	UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+	defaultServiceTokenExpireS     = 300
+	defaultKeysFileReloadIntervalS = 10
+)
+
+var (
+	// ErrServiceUnauthenticated token of service identity is invalid
+	ErrServiceUnauthenticated = errors.New("service unauthenticated")
+	// ErrServiceForbidden service is not allowed to request the method
+	ErrServiceForbidden = errors.New("service forbidden")
+	// ErrServiceNoActiveKey service has no active key to sign
+	ErrServiceNoActiveKey = errors.New("service has no active key")
+	// ErrServiceTokenReused nonce of token has been used, the request is replayed
+	ErrServiceTokenReused = errors.New("service token reused")
+)
+
+// ServiceKey secret key of service identity. Keys of one service are rotated online
+// by their valid period, the newest active key signs requests, and all keys not
+// expired are accepted in verifying.
+type ServiceKey struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+	// NotBefore unix seconds the key begins to sign, 0 means always
+	NotBefore int64 `json:"not_before"`
+	// ExpiredAt unix seconds the key stops to be accepted, 0 means never
+	ExpiredAt int64 `json:"expired_at"`
+}
+
+func (k *ServiceKey) active(now int64) bool {
+	return k.NotBefore <= now && !k.expired(now)
+}
+
+func (k *ServiceKey) expired(now int64) bool {
+	return k.ExpiredAt > 0 && k.ExpiredAt <= now
+}
+
+// MethodPolicy services allowed to request the methods with path prefix,
+// the policy with the longest matched prefix takes effect.
+type MethodPolicy struct {
+	Prefix   string   `json:"prefix"`
+	Services []string `json:"services"`
+}
+
+// ServiceAuthConfig config of service authentication
+type ServiceAuthConfig struct {
+	// Service identity of self to sign requests, empty means not signing
+	Service string `json:"service"`
+	// Keys service identity to its keys
+	Keys map[string][]ServiceKey `json:"keys"`
+	// KeysFile json file of keys map, merged into Keys, and reloaded if it is modified
+	KeysFile string `json:"keys_file"`
+	// KeysFileReloadIntervalS interval to check modification of keys file
+	KeysFileReloadIntervalS int `json:"keys_file_reload_interval_s"`
+	// Policies per-method authorization, methods without matched policy
+	// are allowed to all authenticated services if DefaultAllow
+	Policies     []MethodPolicy `json:"policies"`
+	DefaultAllow bool           `json:"default_allow"`
+	// TokenExpireS max lifetime and clock skew of token, nonce of token is
+	// rejected if it is reused in this period
+	TokenExpireS int `json:"token_expire_s"`
+}
+
+// ServiceRequest the content of request signed by token
+type ServiceRequest struct {
+	Method string
+	Path   string
+	// Query raw query of path
+	Query string
+	// BodyDigest hex sha256 of request body, or UnsignedPayload
+	BodyDigest string
+}
+
+// BodyDigest returns hex sha256 of body
+func BodyDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// ServiceAuth signs and verifies request tokens of service identities,
+// and authorizes the method by policies.
+type ServiceAuth struct {
+	service      string
+	keysFile     string
+	defaultAllow bool
+	expire       int64
+	policies     []MethodPolicy
+
+	lock     sync.RWMutex
+	confKeys map[string][]ServiceKey
+	keys     map[string][]ServiceKey
+	fileMod  time.Time
+
+	nonces *nonceCache
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewServiceAuth returns service authentication with config,
+// keys file is watched until closed
+func NewServiceAuth(cfg ServiceAuthConfig) (*ServiceAuth, error) {
+	if cfg.TokenExpireS <= 0 {
+		cfg.TokenExpireS = defaultServiceTokenExpireS
+	}
+	if cfg.KeysFileReloadIntervalS <= 0 {
+		cfg.KeysFileReloadIntervalS = defaultKeysFileReloadIntervalS
+	}
+	if strings.Contains(cfg.Service, ".") || cfg.Service == LegacyService {
+		return nil, fmt.Errorf("invalid service identity %s", cfg.Service)
+	}
+	policies := append([]MethodPolicy{}, cfg.Policies...)
+	sort.SliceStable(policies, func(i, j int) bool {
+		return len(policies[i].Prefix) > len(policies[j].Prefix)
+	})
+	a := &ServiceAuth{
+		service:      cfg.Service,
+		keysFile:     cfg.KeysFile,
+		defaultAllow: cfg.DefaultAllow,
+		expire:       int64(cfg.TokenExpireS),
+		policies:     policies,
+		confKeys:     cfg.Keys,
+		nonces:       newNonceCache(2 * time.Duration(cfg.TokenExpireS) * time.Second),
+		done:         make(chan struct{}),
+	}
+	if err := a.Reload(); err != nil {
+		return nil, err
+	}
+	if a.keysFile != "" {
+		go a.watchKeysFile(time.Duration(cfg.KeysFileReloadIntervalS) * time.Second)
+	}
+	return a, nil
+}
+
+// Service returns service identity of self
+func (a *ServiceAuth) Service() string {
+	return a.service
+}
+
+// Close stops watching keys file
+func (a *ServiceAuth) Close() {
+	a.once.Do(func() { close(a.done) })
+}
+
+// watchKeysFile reloads keys file if it is modified, the old keys are kept if failed
+func (a *ServiceAuth) watchKeysFile(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.done:
+			return
+		}
+		info, err := os.Stat(a.keysFile)
+		if err != nil {
+			log.Warnf("stat keys file %s failed, err: %s", a.keysFile, err.Error())
+			continue
+		}
+		a.lock.RLock()
+		modified := !info.ModTime().Equal(a.fileMod)
+		a.lock.RUnlock()
+		if !modified {
+			continue
+		}
+		if err = a.Reload(); err != nil {
+			log.Warnf("reload keys file %s failed, err: %s", a.keysFile, err.Error())
+			continue
+		}
+		log.Infof("keys file %s reloaded", a.keysFile)
+	}
+}
+
+// ReloadKeys replaces keys in config and reloads keys file, rotates keys online
+func (a *ServiceAuth) ReloadKeys(confKeys map[string][]ServiceKey) error {
+	a.lock.Lock()
+	a.confKeys = confKeys
+	a.lock.Unlock()
+	return a.Reload()
+}
+
+// Reload reloads keys from keys file, rotates keys online
+func (a *ServiceAuth) Reload() error {
+	a.lock.RLock()
+	keys := make(map[string][]ServiceKey, len(a.confKeys))
+	for service, serviceKeys := range a.confKeys {
+		keys[service] = append(keys[service], serviceKeys...)
+	}
+	a.lock.RUnlock()
+
+	var fileMod time.Time
+	if a.keysFile != "" {
+		info, err := os.Stat(a.keysFile)
+		if err != nil {
+			return err
+		}
+		fileMod = info.ModTime()
+		data, err := os.ReadFile(a.keysFile)
+		if err != nil {
+			return err
+		}
+		fileKeys := make(map[string][]ServiceKey)
+		if err = json.Unmarshal(data, &fileKeys); err != nil {
+			return fmt.Errorf("parse keys file %s: %s", a.keysFile, err.Error())
+		}
+		for service, serviceKeys := range fileKeys {
+			keys[service] = append(keys[service], serviceKeys...)
+		}
+	}
+	return a.setKeys(keys, fileMod)
+}
+
+func (a *ServiceAuth) setKeys(keys map[string][]ServiceKey, fileMod time.Time) error {
+	for service, serviceKeys := range keys {
+		if service == "" || service == LegacyService || strings.Contains(service, ".") {
+			return fmt.Errorf("invalid service identity %s", service)
+		}
+		for _, key := range serviceKeys {
+			if key.ID == "" || strings.Contains(key.ID, ".") || key.Secret == "" {
+				return fmt.Errorf("invalid key %s of service %s", key.ID, service)
+			}
+		}
+	}
+	a.lock.Lock()
+	a.keys = keys
+	a.fileMod = fileMod
+	a.lock.Unlock()
+	return nil
+}
+
+func (a *ServiceAuth) getKey(service, keyID string) (ServiceKey, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	for _, key := range a.keys[service] {
+		if key.ID == keyID {
+			return key, true
+		}
+	}
+	return ServiceKey{}, false
+}
+
+// signingKey returns the newest active key of self
+func (a *ServiceAuth) signingKey(now int64) (ServiceKey, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	var signing ServiceKey
+	found := false
+	for _, key := range a.keys[a.service] {
+		if key.active(now) && (!found || key.NotBefore >= signing.NotBefore) {
+			signing, found = key, true
+		}
+	}
+	return signing, found
+}
+
+func serviceSum(service, keyID string, timestamp int64, nonce string, r ServiceRequest, secret []byte) string {
+	h := hmac.New(sha256.New, secret)
+	for _, field := range []string{
+		service, keyID, strconv.FormatInt(timestamp, 10), nonce,
+		r.Method, r.Path, r.Query, r.BodyDigest,
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IsServiceToken returns the token is signed with service identity or not
+func IsServiceToken(token string) bool {
+	return strings.HasPrefix(token, serviceTokenPrefix)
+}
+
+// Sign signs the request with the identity of self, every token has an unique nonce
+func (a *ServiceAuth) Sign(r ServiceRequest) (string, error) {
+	now := time.Now().Unix()
+	key, ok := a.signingKey(now)
+	if !ok {
+		return "", ErrServiceNoActiveKey
+	}
+	nonce := make([]byte, serviceNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	nonceStr := hex.EncodeToString(nonce)
+	return serviceTokenPrefix + strings.Join([]string{
+		a.service, key.ID, strconv.FormatInt(now, 10), nonceStr,
+		serviceSum(a.service, key.ID, now, nonceStr, r, []byte(key.Secret)),
+	}, "."), nil
+}
+
+// Verify verifies the token of request, returns identity of the service,
+// the nonce of token can be used only once
+func (a *ServiceAuth) Verify(token string, r ServiceRequest) (string, error) {
+	if !IsServiceToken(token) {
+		return "", ErrServiceUnauthenticated
+	}
+	fields := strings.Split(token, ".")
+	if len(fields) != serviceTokenFields {
+		return "", ErrServiceUnauthenticated
+	}
+	service, keyID, nonce := fields[1], fields[2], fields[4]
+	timestamp, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil || len(nonce) != 2*serviceNonceSize {
+		return "", ErrServiceUnauthenticated
+	}
+	now := time.Now().Unix()
+	if timestamp > now+a.expire || timestamp < now-a.expire {
+		return "", ErrServiceUnauthenticated
+	}
+
+	key, ok := a.getKey(service, keyID)
+	if !ok || key.expired(now) {
+		return "", ErrServiceUnauthenticated
+	}
+	sum := serviceSum(service, keyID, timestamp, nonce, r, []byte(key.Secret))
+	if !hmac.Equal([]byte(sum), []byte(fields[5])) {
+		return "", ErrServiceUnauthenticated
+	}
+	if !a.nonces.add(service + "." + nonce) {
+		return "", ErrServiceTokenReused
+	}
+	return service, nil
+}
+
+// Authorize checks the service is allowed to request the method path
+func (a *ServiceAuth) Authorize(service, path string) error {
+	for _, policy := range a.policies {
+		if !strings.HasPrefix(path, policy.Prefix) {
+			continue
+		}
+		for _, allowed := range policy.Services {
+			if allowed == service || (allowed == PolicyAnyService && service != LegacyService) {
+				return nil
+			}
+		}
+		return ErrServiceForbidden
+	}
+	if a.defaultAllow {
+		return nil
+	}
+	return ErrServiceForbidden
+}
+
+// Authenticate verifies the token and authorizes the method path of request
+func (a *ServiceAuth) Authenticate(token string, r ServiceRequest) error {
+	service, err := a.Verify(token, r)
+	if err != nil {
+		return err
+	}
+	return a.Authorize(service, r.Path)
+}
+
+// nonceCache records the used nonces in two generations, every nonce is kept
+// at least one period after added
+type nonceCache struct {
+	lock    sync.Mutex
+	period  time.Duration
+	rotated time.Time
+	current map[string]struct{}
+	last    map[string]struct{}
+}
+
+func newNonceCache(period time.Duration) *nonceCache {
+	return &nonceCache{
+		period:  period,
+		rotated: time.Now(),
+		current: make(map[string]struct{}),
+		last:    make(map[string]struct{}),
+	}
+}
+
+// add returns false if the nonce has been added
+func (c *nonceCache) add(nonce string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if now := time.Now(); now.Sub(c.rotated) >= c.period {
+		c.last, c.current = c.current, make(map[string]struct{})
+		c.rotated = now
+	}
+	if _, ok := c.current[nonce]; ok {
+		return false
+	}
+	if _, ok := c.last[nonce]; ok {
+		return false
+	}
+	c.current[nonce] = struct{}{}
+	return true
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package security_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/security"
+)
+
+func newServiceAuth(t *testing.T, service string, keys map[string][]security.ServiceKey) *security.ServiceAuth {
+	a, err := security.NewServiceAuth(security.ServiceAuthConfig{
+		Service: service,
+		Keys:    keys,
+		Policies: []security.MethodPolicy{
+			{Prefix: "/", Services: []string{security.PolicyAnyService}},
+			{Prefix: "/admin", Services: []string{"clustermgr"}},
+			{Prefix: "/admin/access", Services: []string{"access"}},
+		},
+	})
+	require.NoError(t, err)
+	return a
+}
+
+func TestServiceAuthSignVerify(t *testing.T) {
+	keys := map[string][]security.ServiceKey{
+		"access":     {{ID: "k1", Secret: "access-secret"}},
+		"clustermgr": {{ID: "k1", Secret: "cm-secret"}},
+	}
+	access := newServiceAuth(t, "access", keys)
+	cm := newServiceAuth(t, "clustermgr", keys)
+
+	r := security.ServiceRequest{Method: "POST", Path: "/shard/get", Query: "id=1", BodyDigest: security.BodyDigest([]byte("{}"))}
+	sign := func() string {
+		token, err := access.Sign(r)
+		require.NoError(t, err)
+		require.True(t, security.IsServiceToken(token))
+		return token
+	}
+
+	service, err := cm.Verify(sign(), r)
+	require.NoError(t, err)
+	require.Equal(t, "access", service)
+	for _, modified := range []security.ServiceRequest{
+		{Method: "GET", Path: r.Path, Query: r.Query, BodyDigest: r.BodyDigest},
+		{Method: r.Method, Path: "/shard/delete", Query: r.Query, BodyDigest: r.BodyDigest},
+		{Method: r.Method, Path: r.Path, Query: "id=2", BodyDigest: r.BodyDigest},
+		{Method: r.Method, Path: r.Path, Query: r.Query, BodyDigest: security.BodyDigest(nil)},
+	} {
+		_, err = cm.Verify(sign(), modified)
+		require.ErrorIs(t, err, security.ErrServiceUnauthenticated)
+	}
+	token := sign()
+	_, err = cm.Verify(token[:len(token)-1], r)
+	require.ErrorIs(t, err, security.ErrServiceUnauthenticated)
+	_, err = cm.Verify("svc2.access.k2.0.00.00", r)
+	require.ErrorIs(t, err, security.ErrServiceUnauthenticated)
+	_, err = cm.Verify("not-service-token", r)
+	require.ErrorIs(t, err, security.ErrServiceUnauthenticated)
+
+	// replayed token
+	_, err = cm.Verify(token, r)
+	require.NoError(t, err)
+	_, err = cm.Verify(token, r)
+	require.ErrorIs(t, err, security.ErrServiceTokenReused)
+
+	// policies
+	require.NoError(t, cm.Authorize("access", "/shard/get"))
+	require.ErrorIs(t, cm.Authorize("access", "/admin/config"), security.ErrServiceForbidden)
+	require.NoError(t, cm.Authorize("clustermgr", "/admin/config"))
+	require.NoError(t, cm.Authorize("access", "/admin/access/limit"))
+	require.ErrorIs(t, cm.Authorize("clustermgr", "/admin/access/limit"), security.ErrServiceForbidden)
+	// legacy secret is not matched by any service
+	require.ErrorIs(t, cm.Authorize(security.LegacyService, "/shard/get"), security.ErrServiceForbidden)
+
+	noPolicy, err := security.NewServiceAuth(security.ServiceAuthConfig{Keys: keys})
+	require.NoError(t, err)
+	require.ErrorIs(t, noPolicy.Authenticate(sign(), r), security.ErrServiceForbidden)
+	noPolicy, err = security.NewServiceAuth(security.ServiceAuthConfig{Keys: keys, DefaultAllow: true})
+	require.NoError(t, err)
+	require.NoError(t, noPolicy.Authenticate(sign(), r))
+	require.NoError(t, noPolicy.Authorize(security.LegacyService, "/shard/get"))
+
+	legacy, err := security.NewServiceAuth(security.ServiceAuthConfig{
+		Keys:     keys,
+		Policies: []security.MethodPolicy{{Prefix: "/shard", Services: []string{security.LegacyService}}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, legacy.Authorize(security.LegacyService, "/shard/get"))
+	require.ErrorIs(t, legacy.Authorize(security.LegacyService, "/blob/get"), security.ErrServiceForbidden)
+
+	_, err = security.NewServiceAuth(security.ServiceAuthConfig{Service: "a.b"})
+	require.Error(t, err)
+	_, err = security.NewServiceAuth(security.ServiceAuthConfig{Service: security.LegacyService})
+	require.Error(t, err)
+	_, err = security.NewServiceAuth(security.ServiceAuthConfig{
+		Keys: map[string][]security.ServiceKey{"access": {{ID: "k1"}}},
+	})
+	require.Error(t, err)
+	_, err = security.NewServiceAuth(security.ServiceAuthConfig{
+		Keys: map[string][]security.ServiceKey{security.LegacyService: {{ID: "k1", Secret: "s"}}},
+	})
+	require.Error(t, err)
+}
+
+func TestServiceAuthRotation(t *testing.T) {
+	now := time.Now().Unix()
+	oldKey := security.ServiceKey{ID: "old", Secret: "old-secret"}
+	newKey := security.ServiceKey{ID: "new", Secret: "new-secret", NotBefore: now + 3600}
+
+	access := newServiceAuth(t, "access", map[string][]security.ServiceKey{"access": {oldKey, newKey}})
+	r := security.ServiceRequest{Method: "GET", Path: "/blob/get", BodyDigest: security.BodyDigest(nil)}
+	sign := func() string {
+		token, err := access.Sign(r)
+		require.NoError(t, err)
+		return token
+	}
+	require.Contains(t, sign(), ".old.")
+
+	// server knows old key only
+	dir, err := os.MkdirTemp(os.TempDir(), "service_auth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keysFile := filepath.Join(dir, "keys.json")
+	require.NoError(t, os.WriteFile(keysFile, []byte(`{"access":[{"id":"old","secret":"old-secret"}]}`), 0o644))
+	server, err := security.NewServiceAuth(security.ServiceAuthConfig{KeysFile: keysFile, DefaultAllow: true})
+	require.NoError(t, err)
+	defer server.Close()
+	require.NoError(t, server.Authenticate(sign(), r))
+
+	// rotate: sign with new key, server accepts both keys
+	newKey.NotBefore = now
+	require.NoError(t, access.ReloadKeys(map[string][]security.ServiceKey{"access": {oldKey, newKey}}))
+	newToken := sign()
+	require.Contains(t, newToken, ".new.")
+	require.Error(t, server.Authenticate(newToken, r))
+
+	require.NoError(t, os.WriteFile(keysFile, []byte(`{"access":[{"id":"old","secret":"old-secret"},
+		{"id":"new","secret":"new-secret"}]}`), 0o644))
+	require.NoError(t, server.Reload())
+	oldSigner := newServiceAuth(t, "access", map[string][]security.ServiceKey{"access": {oldKey}})
+	oldToken, err := oldSigner.Sign(r)
+	require.NoError(t, err)
+	require.NoError(t, server.Authenticate(oldToken, r))
+	require.NoError(t, server.Authenticate(sign(), r))
+
+	// expire old key
+	oldKey.ExpiredAt = now
+	require.NoError(t, server.ReloadKeys(map[string][]security.ServiceKey{"access": {oldKey}}))
+	oldToken, err = oldSigner.Sign(r)
+	require.NoError(t, err)
+	require.Error(t, server.Authenticate(oldToken, r))
+	require.NoError(t, server.Authenticate(sign(), r))
+
+	// no active key
+	require.NoError(t, access.ReloadKeys(map[string][]security.ServiceKey{"access": {oldKey}}))
+	_, err = access.Sign(r)
+	require.ErrorIs(t, err, security.ErrServiceNoActiveKey)
+
+	require.NoError(t, os.Remove(keysFile))
+	require.Error(t, server.Reload())
+}
+
+func TestServiceAuthWatchKeysFile(t *testing.T) {
+	dir, err := os.MkdirTemp(os.TempDir(), "service_auth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keysFile := filepath.Join(dir, "keys.json")
+	require.NoError(t, os.WriteFile(keysFile, []byte(`{"access":[{"id":"k1","secret":"s1"}]}`), 0o644))
+
+	server, err := security.NewServiceAuth(security.ServiceAuthConfig{
+		KeysFile: keysFile, KeysFileReloadIntervalS: 1, DefaultAllow: true,
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	access := newServiceAuth(t, "access", map[string][]security.ServiceKey{"access": {{ID: "k2", Secret: "s2"}}})
+	r := security.ServiceRequest{Method: "GET", Path: "/blob/get", BodyDigest: security.BodyDigest(nil)}
+	token, err := access.Sign(r)
+	require.NoError(t, err)
+	require.Error(t, server.Authenticate(token, r))
+
+	// broken file keeps the old keys
+	modTime := time.Now().Add(time.Second)
+	require.NoError(t, os.WriteFile(keysFile, []byte(`{"access":`), 0o644))
+	require.NoError(t, os.Chtimes(keysFile, modTime, modTime))
+	time.Sleep(1500 * time.Millisecond)
+	old := newServiceAuth(t, "access", map[string][]security.ServiceKey{"access": {{ID: "k1", Secret: "s1"}}})
+	token, err = old.Sign(r)
+	require.NoError(t, err)
+	require.NoError(t, server.Authenticate(token, r))
+
+	modTime = modTime.Add(time.Second)
+	require.NoError(t, os.WriteFile(keysFile, []byte(`{"access":[{"id":"k2","secret":"s2"}]}`), 0o644))
+	require.NoError(t, os.Chtimes(keysFile, modTime, modTime))
+	require.Eventually(t, func() bool {
+		token, err := access.Sign(r)
+		require.NoError(t, err)
+		return server.Authenticate(token, r) == nil
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -35,6 +35,7 @@ var (
 	PrefixBaggage   = "blobstore-baggage-"
 	FieldKeyTraceID = prefixTracer + "traceid"
 	FieldKeySpanID  = prefixTracer + "spanid"
+	FieldKeySampled = prefixTracer + "sampled"
 )
 
 var (
@@ -84,6 +85,12 @@ func (t *TextMapPropagator) Inject(sc *SpanContext, carrier interface{}) error {
 	}
 	writer.Set(FieldKeyTraceID, sc.traceID)
 	writer.Set(FieldKeySpanID, sc.spanID.String())
+	switch sc.sampling {
+	case samplingAccept:
+		writer.Set(FieldKeySampled, "1")
+	case samplingDrop:
+		writer.Set(FieldKeySampled, "0")
+	}
 
 	sc.ForeachBaggageItems(func(k string, v []string) bool {
 		if k != internalTrackLogKey { // internal baggage will not inject
@@ -103,6 +110,7 @@ func (t *TextMapPropagator) Extract(carrier interface{}) (opentracing.SpanContex
 	var (
 		traceID    string
 		spanID     ID
+		sampling   samplingDecision
 		baggage    = make(map[string][]string)
 		fieldCount int
 		err        error
@@ -119,6 +127,11 @@ func (t *TextMapPropagator) Extract(carrier interface{}) (opentracing.SpanContex
 			}
 			spanID = ID(id)
 			fieldCount++
+		case FieldKeySampled:
+			sampling = samplingDrop
+			if val == "1" {
+				sampling = samplingAccept
+			}
 		default:
 			lowerKey := strings.ToLower(key)
 			if strings.HasPrefix(lowerKey, PrefixBaggage) {
@@ -139,9 +152,10 @@ func (t *TextMapPropagator) Extract(carrier interface{}) (opentracing.SpanContex
 		return nil, ErrSpanContextCorrupted
 	}
 	return &SpanContext{
-		traceID: traceID,
-		spanID:  spanID,
-		baggage: baggage,
+		traceID:  traceID,
+		spanID:   spanID,
+		sampling: sampling,
+		baggage:  baggage,
 	}, nil
 }
 
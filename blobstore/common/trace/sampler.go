@@ -0,0 +1,93 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package trace
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// samplingDecision is decided by the root span, and propagated to
+// all descendant spans in process or across rpc.
+type samplingDecision uint8
+
+const (
+	samplingUndecided samplingDecision = iota
+	samplingAccept
+	samplingDrop
+)
+
+// Sampler decides whether a root span is sampled.
+// The spans not sampled are still reported if error occurred.
+type Sampler interface {
+	Sample(operationName string) bool
+}
+
+// Reporter reports the finished span which is sampled or failed.
+type Reporter func(span Span)
+
+type alwaysSampler struct{}
+
+func (alwaysSampler) Sample(string) bool { return true }
+
+// probabilitySampler samples root spans with the probability.
+type probabilitySampler struct {
+	probability float64
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewProbabilitySampler returns a sampler with probability in [0, 1].
+func NewProbabilitySampler(probability float64) Sampler {
+	return &probabilitySampler{
+		probability: probability,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *probabilitySampler) Sample(string) bool {
+	if s.probability >= 1 {
+		return true
+	}
+	if s.probability <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Float64() < s.probability
+}
+
+// rateLimitingSampler samples at most perSecond root spans every second,
+// keeps trace volume bounded in high QPS.
+type rateLimitingSampler struct {
+	limiter *rate.Limiter
+}
+
+// NewRateLimitingSampler returns a sampler samples at most perSecond root spans.
+func NewRateLimitingSampler(perSecond float64) Sampler {
+	burst := int(perSecond)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitingSampler{limiter: rate.NewLimiter(rate.Limit(perSecond), burst)}
+}
+
+func (s *rateLimitingSampler) Sample(string) bool {
+	return s.limiter.Allow()
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package trace
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampler(t *testing.T) {
+	require.True(t, NewProbabilitySampler(1).Sample(""))
+	require.False(t, NewProbabilitySampler(0).Sample(""))
+	sampled := 0
+	sampler := NewProbabilitySampler(0.5)
+	for range [1000]struct{}{} {
+		if sampler.Sample("") {
+			sampled++
+		}
+	}
+	require.Less(t, sampled, 700)
+	require.Greater(t, sampled, 300)
+
+	sampler = NewRateLimitingSampler(10)
+	sampled = 0
+	for range [100]struct{}{} {
+		if sampler.Sample("") {
+			sampled++
+		}
+	}
+	require.LessOrEqual(t, sampled, 11)
+	require.GreaterOrEqual(t, sampled, 10)
+	time.Sleep(200 * time.Millisecond)
+	require.True(t, sampler.Sample(""))
+}
+
+func TestSamplingReport(t *testing.T) {
+	var reported []string
+	tracer := NewTracer("sampling",
+		TracerOptions.Sampler(NewProbabilitySampler(0)),
+		TracerOptions.Reporter(func(span Span) { reported = append(reported, span.OperationName()) }))
+
+	root := tracer.StartSpan("root").(Span)
+	require.False(t, root.IsSampled())
+	child := tracer.StartSpan("child", ChildOf(root.Context())).(Span)
+	require.False(t, child.IsSampled())
+	child.Finish()
+	root.Finish()
+	require.Equal(t, 0, len(reported))
+
+	// errors are always captured
+	failed := tracer.StartSpan("failed", ChildOf(root.Context())).(Span)
+	failed.AppendTrackLog("module", time.Now(), errors.New("failed"))
+	require.True(t, failed.IsSampled())
+	failed.Finish()
+	tagged := tracer.StartSpan("tagged").(Span)
+	ext.Error.Set(tagged, true)
+	tagged.Finish()
+	require.Equal(t, []string{"failed", "tagged"}, reported)
+
+	// sampling decision is propagated
+	carrier := TextMapCarrier(make(map[string]string))
+	require.NoError(t, tracer.Inject(root.Context(), TextMap, carrier))
+	require.Equal(t, "0", carrier[FieldKeySampled])
+	spanCtx, err := Extract(TextMap, carrier)
+	require.NoError(t, err)
+	remote := NewTracer("remote").StartSpan("remote", ChildOf(spanCtx)).(Span)
+	require.False(t, remote.IsSampled())
+
+	delete(carrier, FieldKeySampled)
+	spanCtx, err = Extract(TextMap, carrier)
+	require.NoError(t, err)
+	remote = NewTracer("remote").StartSpan("remote", ChildOf(spanCtx)).(Span)
+	require.True(t, remote.IsSampled())
+}
@@ -20,9 +20,11 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
 	ptlog "github.com/opentracing/opentracing-go/log"
 
 	"github.com/cubefs/cubefs/blobstore/util/log"
@@ -68,6 +70,10 @@ type Span interface {
 	// TrackLog returns track log, calls BaggageItem with default key fieldTrackLogKey.
 	TrackLog() []string
 
+	// IsSampled returns true if the trace is sampled or error occurred in span,
+	// the span is reported when finished only if it is sampled.
+	IsSampled() bool
+
 	// BaseLogger defines interface of application log apis.
 	log.BaseLogger
 }
@@ -105,6 +111,9 @@ type spanImpl struct {
 	// references for this span
 	references []opentracing.SpanReference
 
+	// failed is set if error tag is set or error track log appended.
+	failed int32
+
 	rw sync.RWMutex
 }
 
@@ -122,14 +131,15 @@ func (s *spanImpl) FinishWithOptions(opts opentracing.FinishOptions) {
 	s.duration = finishTime.Sub(s.startTime)
 
 	s.rw.Lock()
-	defer s.rw.Unlock()
-
 	s.logs = append(s.logs, opts.LogRecords...)
 	for _, ld := range opts.BulkLogData {
 		s.logs = append(s.logs, ld.ToLogRecord())
 	}
+	s.rw.Unlock()
 
-	// TODO report span
+	if reporter := s.tracer.options.reporter; reporter != nil && s.IsSampled() {
+		reporter(s)
+	}
 }
 
 // Context implements opentracing.Span API
@@ -220,6 +230,9 @@ func (s *spanImpl) SetTag(key string, value interface{}) opentracing.Span {
 		s.tags = Tags{}
 	}
 	s.tags[key] = value
+	if key == string(ext.Error) && value == true {
+		atomic.StoreInt32(&s.failed, 1)
+	}
 	return s
 }
 
@@ -281,6 +294,7 @@ func (s *spanImpl) AppendTrackLogWithDuration(module string, duration time.Durat
 	}
 
 	if err != nil {
+		atomic.StoreInt32(&s.failed, 1)
 		msg := err.Error()
 		errLen := spanOpt.errorLength
 		if len(msg) > errLen {
@@ -291,6 +305,12 @@ func (s *spanImpl) AppendTrackLogWithDuration(module string, duration time.Durat
 	s.track(module)
 }
 
+// IsSampled returns true if the trace is sampled or the span is failed,
+// errors are always captured even if the trace is not sampled.
+func (s *spanImpl) IsSampled() bool {
+	return s.context.IsSampled() || atomic.LoadInt32(&s.failed) == 1
+}
+
 // AppendTrackLogWithFunc records cost time for the function calling to a module.
 func (s *spanImpl) AppendTrackLogWithFunc(module string, fn func() error, opts ...SpanOption) {
 	startTime := time.Now()
@@ -338,14 +358,14 @@ const (
 )
 
 func (s *spanImpl) output(lvl log.Level, v []interface{}) {
-	if log.DefaultLogger.GetOutputLevel() > lvl {
+	if !log.Enabled(lvl) {
 		return
 	}
 	log.DefaultLogger.Output(s.String(), lvl, defaultCalldepth, v...)
 }
 
 func (s *spanImpl) outputf(lvl log.Level, format string, v []interface{}) {
-	if log.DefaultLogger.GetOutputLevel() > lvl {
+	if !log.Enabled(lvl) {
 		return
 	}
 	log.DefaultLogger.Outputf(s.String(), lvl, defaultCalldepth, format, v...)
@@ -432,14 +452,14 @@ func (s *operationSpan) String() string {
 }
 
 func (s *operationSpan) output(lvl log.Level, v []interface{}) {
-	if log.DefaultLogger.GetOutputLevel() > lvl {
+	if !log.Enabled(lvl) {
 		return
 	}
 	log.DefaultLogger.Output(s.String(), lvl, defaultCalldepth, v...)
 }
 
 func (s *operationSpan) outputf(lvl log.Level, format string, v []interface{}) {
-	if log.DefaultLogger.GetOutputLevel() > lvl {
+	if !log.Enabled(lvl) {
 		return
 	}
 	log.DefaultLogger.Outputf(s.String(), lvl, defaultCalldepth, format, v...)
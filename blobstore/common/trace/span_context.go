@@ -48,6 +48,9 @@ type SpanContext struct {
 	// Should be 0 if the current span is a root span.
 	parentID ID
 
+	// sampling is decided by root span and propagated to descendants.
+	sampling samplingDecision
+
 	// Distributed Context baggage.
 	baggage map[string][]string
 	sync.RWMutex
@@ -109,6 +112,11 @@ func (s *SpanContext) baggageItemDeepCopy(key string) (item []string) {
 	return
 }
 
+// IsSampled returns true if the trace is sampled
+func (s *SpanContext) IsSampled() bool {
+	return s.sampling != samplingDrop
+}
+
 // IsValid returns true if SpanContext is valid
 func (s *SpanContext) IsValid() bool {
 	return s.traceID != "" && s.spanID != 0
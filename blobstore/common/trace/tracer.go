@@ -76,6 +76,8 @@ type Tag = opentracing.Tag
 type Options struct {
 	maxLogsPerSpan   int
 	maxInternalTrack int
+	sampler          Sampler
+	reporter         Reporter
 }
 
 // Tracer implements opentracing.Tracer
@@ -106,6 +108,9 @@ func NewTracer(serviceName string, opts ...TracerOption) *Tracer {
 	if t.options.maxInternalTrack <= 0 {
 		t.options.maxInternalTrack = defaultInternalTrack
 	}
+	if t.options.sampler == nil {
+		t.options.sampler = alwaysSampler{}
+	}
 
 	return t
 }
@@ -173,6 +178,13 @@ func (t *Tracer) startSpanWithOptions(operationName string, opts opentracing.Sta
 			ctx.setBaggageItem(k, v)
 			return true
 		})
+		ctx.sampling = parent.sampling
+	}
+	if ctx.sampling == samplingUndecided {
+		ctx.sampling = samplingDrop
+		if t.options.sampler.Sample(operationName) {
+			ctx.sampling = samplingAccept
+		}
 	}
 
 	tags := opts.Tags
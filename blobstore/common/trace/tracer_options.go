@@ -33,3 +33,17 @@ func (tracerOptions) MaxInternalTrackLog(internalTrack int) TracerOption {
 		tracer.options.maxInternalTrack = internalTrack
 	}
 }
+
+// Sampler sets sampler of root spans, all spans are sampled by default.
+func (tracerOptions) Sampler(sampler Sampler) TracerOption {
+	return func(tracer *Tracer) {
+		tracer.options.sampler = sampler
+	}
+}
+
+// Reporter sets reporter of finished spans which are sampled or failed.
+func (tracerOptions) Reporter(reporter Reporter) TracerOption {
+	return func(tracer *Tracer) {
+		tracer.options.reporter = reporter
+	}
+}
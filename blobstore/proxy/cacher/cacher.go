@@ -72,6 +72,10 @@ type ConfigCache struct {
 	VolumeExpirationS int `json:"volume_expiration_seconds"`
 	DiskCapacity      int `json:"disk_capacity"`
 	DiskExpirationS   int `json:"disk_expiration_seconds"`
+
+	// VolumeStaleS serves cached volume marked as stale in seconds after it expired
+	// if clustermgr is unavailable, 0 means never serve stale volume.
+	VolumeStaleS int `json:"volume_stale_seconds"`
 }
 
 type valueExpired interface {
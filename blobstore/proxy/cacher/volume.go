@@ -22,7 +22,9 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/util/errors"
 )
@@ -38,6 +40,11 @@ func (v *expiryVolume) Expired() bool {
 	return v.ExpiryAt > 0 && time.Now().Unix() >= v.ExpiryAt
 }
 
+// Outdated returns true if expired more than staleS seconds.
+func (v *expiryVolume) Outdated(staleS int) bool {
+	return v.ExpiryAt > 0 && time.Now().Unix() >= v.ExpiryAt+int64(staleS)
+}
+
 func encodeVolume(v *expiryVolume) ([]byte, error) {
 	return json.Marshal(v)
 }
@@ -53,7 +60,8 @@ func (c *cacher) GetVolume(ctx context.Context, args *proxy.CacheVolumeArgs) (*p
 	span.Debugf("try to get volume %+v", args)
 
 	vid := args.Vid
-	if vol := c.getVolume(span, vid); vol != nil {
+	cached := c.getVolume(span, vid)
+	if vol := cached; vol != nil {
 		if !args.Flush { // read cache
 			return &vol.VersionVolume, nil
 		}
@@ -77,6 +85,17 @@ func (c *cacher) GetVolume(ctx context.Context, args *proxy.CacheVolumeArgs) (*p
 	if err != nil {
 		c.volumeReport("clustermgr", "miss")
 		span.Error("get volume from clustermgr failed", errors.Detail(err))
+		if rpc.DetectStatusCode(err) == errcode.CodeVolumeNotExist {
+			c.eraseVolume(span, vid)
+			return nil, err
+		}
+		if vol := c.getStaleVolume(span, vid, cached); vol != nil {
+			c.volumeReport("stale", "hit")
+			span.Warnf("serve stale volume:%d version:%d expiry:%d", vid, vol.Version, vol.ExpiryAt)
+			volume := vol.VersionVolume
+			volume.Stale = true
+			return &volume, nil
+		}
 		return nil, err
 	}
 	volume, ok := val.(*clustermgr.VolumeInfo)
@@ -88,6 +107,10 @@ func (c *cacher) GetVolume(ctx context.Context, args *proxy.CacheVolumeArgs) (*p
 	vol := new(expiryVolume)
 	vol.VersionVolume.VolumeInfo = *volume
 	vol.VersionVolume.Version = vol.GetVersion()
+	if cached != nil && cached.Version != vol.Version {
+		c.volumeReport("version", "updated")
+		span.Infof("volume:%d version updated %d -> %d", vid, cached.Version, vol.Version)
+	}
 	if expire := c.config.VolumeExpirationS; expire > 0 {
 		// random expiration to reduce intensive clustermgr requests.
 		expiration := rand.Intn(expire) + expire
@@ -117,6 +140,50 @@ func (c *cacher) GetVolume(ctx context.Context, args *proxy.CacheVolumeArgs) (*p
 	return &vol.VersionVolume, nil
 }
 
+// getStaleVolume returns the cached volume even if it has been expired
+// within stale seconds, to be read when clustermgr is unavailable.
+func (c *cacher) getStaleVolume(span trace.Span, vid proto.Vid, cached *expiryVolume) *expiryVolume {
+	if c.config.VolumeStaleS <= 0 {
+		return nil
+	}
+	vol := cached
+	if vol == nil {
+		if val, ok := c.volumeCache.Get(vid).(*expiryVolume); ok {
+			vol = val
+		}
+	}
+	if vol == nil {
+		key := diskvKeyVolume(vid)
+		data, err := c.diskv.Read(key)
+		if err != nil {
+			return nil
+		}
+		val, err := decodeVolume(data)
+		if err != nil {
+			span.Warnf("decode stale diskv path:%s %s", c.DiskvFilename(key), err.Error())
+			return nil
+		}
+		vol = val.(*expiryVolume)
+	}
+	if vol.Outdated(c.config.VolumeStaleS) {
+		c.volumeReport("stale", "outdated")
+		return nil
+	}
+	return vol
+}
+
+// eraseVolume removes the volume not existed in clustermgr.
+func (c *cacher) eraseVolume(span trace.Span, vid proto.Vid) {
+	c.volumeCache.Remove(vid)
+	key := diskvKeyVolume(vid)
+	if c.diskv.Has(key) {
+		span.Warnf("to erase not existed volume key:%s path:%s", key, c.DiskvFilename(key))
+		if err := c.diskv.Erase(key); err != nil {
+			span.Warnf("erase diskv key:%s error:%s", key, err.Error())
+		}
+	}
+}
+
 func (c *cacher) getVolume(span trace.Span, vid proto.Vid) *expiryVolume {
 	if val := c.getCachedValue(span, vid, diskvKeyVolume(vid),
 		c.volumeCache, decodeVolume, c.volumeReport); val != nil {
@@ -131,6 +131,82 @@ func TestProxyCacherVolumeError(t *testing.T) {
 	require.ErrorIs(t, errcode.ErrVolumeNotExist, err)
 }
 
+func TestProxyCacherVolumeStale(t *testing.T) {
+	c, cmCli, clean := newCacher(t, 1)
+	defer clean()
+	cc := c.(*cacher)
+	cc.config.VolumeStaleS = 4
+
+	volume := new(clustermgr.VolumeInfo)
+	volume.Units = []clustermgr.Unit{{Vuid: 1234}, {Vuid: 5678}}
+	cmCli.EXPECT().GetVolumeInfo(A, A).Return(volume, nil).Times(1)
+	vol, err := c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1})
+	require.NoError(t, err)
+	require.False(t, vol.Stale)
+	<-cc.syncChan
+
+	cmCli.EXPECT().GetVolumeInfo(A, A).Return(nil, errors.New("mock error")).AnyTimes()
+	vol, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1, Flush: true})
+	require.NoError(t, err)
+	require.True(t, vol.Stale)
+	require.Equal(t, uint32(0x9d31f755), vol.Version)
+
+	time.Sleep(time.Second * 2) // expired
+	vol, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1})
+	require.NoError(t, err)
+	require.True(t, vol.Stale)
+	{ // load stale volume from diskv
+		cc.volumeCache.Remove(proto.Vid(1))
+		vol, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1})
+		require.NoError(t, err)
+		require.True(t, vol.Stale)
+	}
+
+	time.Sleep(time.Second * 4) // outdated
+	_, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1})
+	require.Error(t, err)
+	_, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 2})
+	require.Error(t, err)
+}
+
+func TestProxyCacherVolumeInvalidate(t *testing.T) {
+	c, cmCli, clean := newCacher(t, 0)
+	defer clean()
+	cc := c.(*cacher)
+	cc.config.VolumeStaleS = 10
+
+	volume := new(clustermgr.VolumeInfo)
+	volume.Units = []clustermgr.Unit{{Vuid: 1234}, {Vuid: 5678}}
+	cmCli.EXPECT().GetVolumeInfo(A, A).Return(volume, nil).Times(1)
+	vol, err := c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1})
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x9d31f755), vol.Version)
+	<-cc.syncChan
+
+	// updated volume version
+	updated := new(clustermgr.VolumeInfo)
+	updated.Units = []clustermgr.Unit{{Vuid: 1234}, {Vuid: 5679}}
+	cmCli.EXPECT().GetVolumeInfo(A, A).Return(updated, nil).Times(1)
+	vol, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1, Flush: true})
+	require.NoError(t, err)
+	require.NotEqual(t, uint32(0x9d31f755), vol.Version)
+	version := vol.Version
+	<-cc.syncChan
+	cc.volumeCache.Remove(proto.Vid(1))
+	vol, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1})
+	require.NoError(t, err)
+	require.Equal(t, version, vol.Version)
+
+	// volume not exist
+	cmCli.EXPECT().GetVolumeInfo(A, A).Return(nil, errcode.ErrVolumeNotExist).Times(2)
+	_, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1, Flush: true})
+	require.ErrorIs(t, errcode.ErrVolumeNotExist, err)
+	require.Nil(t, cc.volumeCache.Get(proto.Vid(1)))
+	require.False(t, cc.diskv.Has(diskvKeyVolume(1)))
+	_, err = c.GetVolume(context.Background(), &proxy.CacheVolumeArgs{Vid: 1})
+	require.ErrorIs(t, errcode.ErrVolumeNotExist, err)
+}
+
 func TestProxyCacherVolumeCacheMiss(t *testing.T) {
 	c, cmCli, clean := newCacher(t, 2)
 	defer clean()
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/cubefs/cubefs/blobstore/proxy/mq (interfaces: BlobDeleteHandler,ShardRepairHandler,Producer)
+// Source: github.com/cubefs/cubefs/blobstore/proxy/mq (interfaces: BlobDeleteHandler,BlobEventHandler,ShardRepairHandler,Producer)
 
 // Package mock is a generated GoMock package.
 package mock
@@ -49,6 +49,43 @@ func (mr *MockBlobDeleteHandlerMockRecorder) SendDeleteMsg(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDeleteMsg", reflect.TypeOf((*MockBlobDeleteHandler)(nil).SendDeleteMsg), arg0, arg1)
 }
 
+// MockBlobEventHandler is a mock of BlobEventHandler interface.
+type MockBlobEventHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobEventHandlerMockRecorder
+}
+
+// MockBlobEventHandlerMockRecorder is the mock recorder for MockBlobEventHandler.
+type MockBlobEventHandlerMockRecorder struct {
+	mock *MockBlobEventHandler
+}
+
+// NewMockBlobEventHandler creates a new mock instance.
+func NewMockBlobEventHandler(ctrl *gomock.Controller) *MockBlobEventHandler {
+	mock := &MockBlobEventHandler{ctrl: ctrl}
+	mock.recorder = &MockBlobEventHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobEventHandler) EXPECT() *MockBlobEventHandlerMockRecorder {
+	return m.recorder
+}
+
+// SendBlobEvent mocks base method.
+func (m *MockBlobEventHandler) SendBlobEvent(arg0 context.Context, arg1 *proxy.BlobEventArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBlobEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBlobEvent indicates an expected call of SendBlobEvent.
+func (mr *MockBlobEventHandlerMockRecorder) SendBlobEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBlobEvent", reflect.TypeOf((*MockBlobEventHandler)(nil).SendBlobEvent), arg0, arg1)
+}
+
 // MockShardRepairHandler is a mock of ShardRepairHandler interface.
 type MockShardRepairHandler struct {
 	ctrl     *gomock.Controller
@@ -79,3 +79,33 @@ func (s *Service) SendDeleteMessage(c *rpc.Context) {
 
 	c.Respond()
 }
+
+// SendBlobEvent publishes blob lifecycle events to the sink of space
+// 1. put and delete events from access
+// 2. repair events from scheduler
+func (s *Service) SendBlobEvent(c *rpc.Context) {
+	span := trace.SpanFromContextSafe(c.Request.Context())
+	ctx := trace.ContextWithSpan(c.Request.Context(), span)
+
+	args := new(api.BlobEventArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+
+	span.Debugf("accept SendBlobEvent request, cluster_id: %d, events: %d", args.ClusterID, len(args.Events))
+	if args.ClusterID != s.ClusterID {
+		span.Errorf("clusterID not match: cluster_id[%d], self clusterID[%d]", args.ClusterID, s.ClusterID)
+		c.RespondError(errcode.ErrClusterIDNotMatch)
+		return
+	}
+
+	err := s.blobEventMgr.SendBlobEvent(ctx, args)
+	if err != nil {
+		span.Errorf("send blob event failed: %+v", err)
+		c.RespondError(err)
+		return
+	}
+
+	c.Respond()
+}
@@ -21,7 +21,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/blobstore/api/proxy"
-	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	mqueue "github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 )
@@ -31,15 +31,15 @@ type BlobDeleteHandler interface {
 	SendDeleteMsg(ctx context.Context, info *proxy.DeleteArgs) error
 }
 
-// Producer is used to send messages to kafka
+// Producer is used to send messages to message queue
 type Producer interface {
-	kafka.MsgProducer
+	mqueue.Producer
 }
 
 // BlobDeleteConfig is blob delete config
 type BlobDeleteConfig struct {
-	Topic        string            `json:"topic"`
-	MsgSenderCfg kafka.ProducerCfg `json:"msg_sender_cfg"`
+	Topic        string             `json:"topic"`
+	MsgSenderCfg mqueue.ProducerCfg `json:"msg_sender_cfg"`
 }
 
 // blobDeleteMgr is blob delete manager
@@ -50,7 +50,7 @@ type blobDeleteMgr struct {
 
 // NewBlobDeleteMgr returns blob delete manager to handle delete message
 func NewBlobDeleteMgr(cfg BlobDeleteConfig) (*blobDeleteMgr, error) {
-	delMsgSender, err := kafka.NewProducer(&cfg.MsgSenderCfg)
+	delMsgSender, err := mqueue.NewProducer(&cfg.MsgSenderCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +61,7 @@ func NewBlobDeleteMgr(cfg BlobDeleteConfig) (*blobDeleteMgr, error) {
 	}, nil
 }
 
-// SendDeleteMsg sends delete message to kafka
+// SendDeleteMsg sends delete message to mq
 func (d *blobDeleteMgr) SendDeleteMsg(ctx context.Context, info *proxy.DeleteArgs) error {
 	span := trace.SpanFromContextSafe(ctx)
 
@@ -71,7 +71,7 @@ func (d *blobDeleteMgr) SendDeleteMsg(ctx context.Context, info *proxy.DeleteArg
 			ClusterID: info.ClusterID,
 			Vid:       blobInfo.Vid,
 			Bid:       blobInfo.Bid,
-			Time:      time.Now().Unix(),
+			Time:      time.Now().Unix() + info.DelayS,
 			ReqId:     span.TraceID(),
 		}
 
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	mqueue "github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/util/errors"
 )
 
@@ -62,7 +63,7 @@ func TestNewBlobDeleteMgr(t *testing.T) {
 
 	mgr, err := NewBlobDeleteMgr(BlobDeleteConfig{
 		Topic:        "my_topic",
-		MsgSenderCfg: kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}},
+		MsgSenderCfg: mqueue.ProducerCfg{ProducerCfg: kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}}},
 	})
 	require.NoError(t, err)
 
@@ -81,7 +82,7 @@ func TestNewBlobDeleteMgr(t *testing.T) {
 
 	_, err = NewBlobDeleteMgr(BlobDeleteConfig{
 		Topic:        "",
-		MsgSenderCfg: kafka.ProducerCfg{},
+		MsgSenderCfg: mqueue.ProducerCfg{},
 	})
 	require.Error(t, err)
 }
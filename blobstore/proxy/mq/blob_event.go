@@ -0,0 +1,138 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	mqueue "github.com/cubefs/cubefs/blobstore/common/mq"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// BlobEventHandler stream http handler
+type BlobEventHandler interface {
+	SendBlobEvent(ctx context.Context, args *proxy.BlobEventArgs) error
+}
+
+// BlobEventSink publishes events to topic of mq, and posts events to webhook url,
+// events are dropped if both are empty
+type BlobEventSink struct {
+	Topic   string `json:"topic"`
+	Webhook string `json:"webhook"`
+}
+
+// BlobEventConfig is blob event config, events of space which is not
+// configured in Spaces are published to the Default sink
+type BlobEventConfig struct {
+	Default       BlobEventSink                   `json:"default"`
+	Spaces        map[proto.SpaceID]BlobEventSink `json:"spaces"`
+	WebhookConfig rpc.Config                      `json:"webhook_config"`
+	MsgSenderCfg  mqueue.ProducerCfg              `json:"-"`
+}
+
+func (c *BlobEventConfig) hasTopic() bool {
+	if c.Default.Topic != "" {
+		return true
+	}
+	for _, sink := range c.Spaces {
+		if sink.Topic != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// blobEventMsg message of blob event in mq
+type blobEventMsg struct {
+	ClusterID proto.ClusterID `json:"cluster_id"`
+	proxy.BlobEvent
+}
+
+// blobEventMgr is blob event manager
+type blobEventMgr struct {
+	cfg           BlobEventConfig
+	eventSender   Producer
+	webhookClient rpc.Client
+}
+
+// NewBlobEventMgr returns blob event manager, producer of mq is created if any topic configured
+func NewBlobEventMgr(cfg BlobEventConfig) (*blobEventMgr, error) {
+	mgr := &blobEventMgr{
+		cfg:           cfg,
+		webhookClient: rpc.NewClient(&cfg.WebhookConfig),
+	}
+	if !cfg.hasTopic() {
+		return mgr, nil
+	}
+
+	eventSender, err := mqueue.NewProducer(&cfg.MsgSenderCfg)
+	if err != nil {
+		return nil, err
+	}
+	mgr.eventSender = eventSender
+	return mgr, nil
+}
+
+func (m *blobEventMgr) sink(spaceID proto.SpaceID) BlobEventSink {
+	if spaceID != proto.InvalidSpaceID {
+		if sink, ok := m.cfg.Spaces[spaceID]; ok {
+			return sink
+		}
+	}
+	return m.cfg.Default
+}
+
+// SendBlobEvent publishes events to the sink of their space
+func (m *blobEventMgr) SendBlobEvent(ctx context.Context, args *proxy.BlobEventArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	sinks := make(map[BlobEventSink][]proxy.BlobEvent)
+	for _, event := range args.Events {
+		sink := m.sink(event.SpaceID)
+		if sink.Topic == "" && sink.Webhook == "" {
+			continue
+		}
+		sinks[sink] = append(sinks[sink], event)
+	}
+
+	for sink, events := range sinks {
+		if sink.Topic != "" {
+			msgs := make([][]byte, 0, len(events))
+			for _, event := range events {
+				msgByte, err := json.Marshal(blobEventMsg{ClusterID: args.ClusterID, BlobEvent: event})
+				if err != nil {
+					return fmt.Errorf("marshal message: event [%+v], err:[%w]", event, err)
+				}
+				msgs = append(msgs, msgByte)
+			}
+			if err := m.eventSender.SendMessages(sink.Topic, msgs); err != nil {
+				return fmt.Errorf("send blob events: topic[%s], events[%d], err[%w]", sink.Topic, len(events), err)
+			}
+		}
+		if sink.Webhook != "" {
+			err := m.webhookClient.PostWith(ctx, sink.Webhook, nil, &proxy.BlobEventArgs{ClusterID: args.ClusterID, Events: events})
+			if err != nil {
+				return fmt.Errorf("post blob events: webhook[%s], events[%d], err[%w]", sink.Webhook, len(events), err)
+			}
+		}
+		span.Debugf("send blob events success: sink[%+v], events[%d]", sink, len(events))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/proxy/mock"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+func TestBlobEventMgr_SendBlobEvent(t *testing.T) {
+	var posted []proxy.BlobEventArgs
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		args := proxy.BlobEventArgs{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&args))
+		posted = append(posted, args)
+		w.WriteHeader(200)
+	}))
+	defer webhook.Close()
+
+	sent := make(map[string][]blobEventMsg)
+	producer := mock.NewMockProducer(gomock.NewController(t))
+	producer.EXPECT().SendMessages(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(topic string, msgs [][]byte) error {
+		if topic == "failed" {
+			return ErrSendMessage
+		}
+		for _, msg := range msgs {
+			event := blobEventMsg{}
+			require.NoError(t, json.Unmarshal(msg, &event))
+			sent[topic] = append(sent[topic], event)
+		}
+		return nil
+	})
+
+	mgr := &blobEventMgr{
+		cfg: BlobEventConfig{
+			Default: BlobEventSink{Topic: "default"},
+			Spaces: map[proto.SpaceID]BlobEventSink{
+				1: {Topic: "space1", Webhook: webhook.URL},
+				2: {Webhook: webhook.URL},
+				3: {},
+				4: {Topic: "failed"},
+			},
+		},
+		eventSender:   producer,
+		webhookClient: rpc.NewClient(&rpc.Config{}),
+	}
+
+	err := mgr.SendBlobEvent(context.Background(), &proxy.BlobEventArgs{
+		ClusterID: 1,
+		Events: []proxy.BlobEvent{
+			{Type: proxy.BlobEventPut, SpaceID: 1, BlobName: []byte("a")},
+			{Type: proxy.BlobEventDelete, SpaceID: 1, BlobName: []byte("a")},
+			{Type: proxy.BlobEventPut, SpaceID: 2, BlobName: []byte("b")},
+			{Type: proxy.BlobEventPut, SpaceID: 3, BlobName: []byte("c")},
+			{Type: proxy.BlobEventPut, SpaceID: 5, BlobName: []byte("d")},
+			{Type: proxy.BlobEventRepair, Vid: 1, Bid: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(sent["space1"]))
+	require.Equal(t, proto.ClusterID(1), sent["space1"][0].ClusterID)
+	require.Equal(t, proxy.BlobEventDelete, sent["space1"][1].Type)
+	require.Equal(t, 2, len(sent["default"]))
+	require.Equal(t, []byte("d"), sent["default"][0].BlobName)
+	require.Equal(t, proto.BlobID(2), sent["default"][1].Bid)
+	require.Equal(t, 2, len(posted))
+	for _, args := range posted {
+		require.Equal(t, proto.ClusterID(1), args.ClusterID)
+		require.Contains(t, []int{1, 2}, len(args.Events))
+	}
+
+	err = mgr.SendBlobEvent(context.Background(), &proxy.BlobEventArgs{
+		Events: []proxy.BlobEvent{{Type: proxy.BlobEventPut, SpaceID: 4, BlobName: []byte("e")}},
+	})
+	require.True(t, errors.Is(err, ErrSendMessage))
+}
+
+func TestNewBlobEventMgr(t *testing.T) {
+	mgr, err := NewBlobEventMgr(BlobEventConfig{Spaces: map[proto.SpaceID]BlobEventSink{1: {Webhook: "http://127.0.0.1"}}})
+	require.NoError(t, err)
+	require.Nil(t, mgr.eventSender)
+	require.NoError(t, mgr.SendBlobEvent(context.Background(), &proxy.BlobEventArgs{
+		Events: []proxy.BlobEvent{{Type: proxy.BlobEventPut, SpaceID: 2, BlobName: []byte("a")}},
+	}))
+}
@@ -21,7 +21,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/blobstore/api/proxy"
-	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	mqueue "github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 )
@@ -33,14 +33,14 @@ type ShardRepairHandler interface {
 
 // ShardRepairConfig is shard repair config
 type ShardRepairConfig struct {
-	Topic         string            `json:"topic"`
-	PriorityTopic string            `json:"priority_topic"`
-	MsgSenderCfg  kafka.ProducerCfg `json:"msg_sender_cfg"`
+	Topic         string             `json:"topic"`
+	PriorityTopic string             `json:"priority_topic"`
+	MsgSenderCfg  mqueue.ProducerCfg `json:"msg_sender_cfg"`
 }
 
 // NewShardRepairMgr returns shard repair manager
 func NewShardRepairMgr(cfg ShardRepairConfig) (*shardRepairMgr, error) {
-	shardRepairMsgSender, err := kafka.NewProducer(&cfg.MsgSenderCfg)
+	shardRepairMsgSender, err := mqueue.NewProducer(&cfg.MsgSenderCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +58,7 @@ type shardRepairMgr struct {
 	priorityTopic        string
 	topic                string
 	topicSelector        func(info *proxy.ShardRepairArgs, topic, priorityTopic string) string
-	shardRepairMsgSender kafka.MsgProducer
+	shardRepairMsgSender mqueue.Producer
 }
 
 // SendShardRepairMsg sends shard repair msg to mq
@@ -73,6 +73,7 @@ func (s *shardRepairMgr) SendShardRepairMsg(ctx context.Context, info *proxy.Sha
 		BadIdx:    info.BadIdxes,
 		Reason:    info.Reason,
 		ReqId:     span.TraceID(),
+		BadRange:  info.BadRange,
 	}
 
 	msgByte, err := json.Marshal(msg)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	mqueue "github.com/cubefs/cubefs/blobstore/common/mq"
 )
 
 func TestShardRepairMgr_sendShardRepairMsg(t *testing.T) {
@@ -68,7 +69,7 @@ func TestShardRepairMgr_sendShardRepairMsg(t *testing.T) {
 func TestNewShardRepairMgr(t *testing.T) {
 	_, err := NewShardRepairMgr(ShardRepairConfig{
 		Topic:        "",
-		MsgSenderCfg: kafka.ProducerCfg{},
+		MsgSenderCfg: mqueue.ProducerCfg{},
 	})
 	require.Error(t, err)
 
@@ -77,7 +78,7 @@ func TestNewShardRepairMgr(t *testing.T) {
 	mgr, err := NewShardRepairMgr(ShardRepairConfig{
 		Topic:         "my_topic",
 		PriorityTopic: "my_topic",
-		MsgSenderCfg:  kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}},
+		MsgSenderCfg:  mqueue.ProducerCfg{ProducerCfg: kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}}},
 	})
 	require.NoError(t, err)
 
@@ -25,6 +25,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/cmd"
 	"github.com/cubefs/cubefs/blobstore/common/config"
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	mqueue "github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	alloc "github.com/cubefs/cubefs/blobstore/proxy/allocator"
@@ -54,11 +55,13 @@ var (
 
 // MQConfig is mq config
 type MQConfig struct {
-	BlobDeleteTopic          string            `json:"blob_delete_topic"`
-	ShardRepairTopic         string            `json:"shard_repair_topic"`
-	ShardRepairPriorityTopic string            `json:"shard_repair_priority_topic"`
-	MsgSender                kafka.ProducerCfg `json:"msg_sender"`
-	Version                  string            `json:"version"`
+	BlobDeleteTopic          string             `json:"blob_delete_topic"`
+	ShardRepairTopic         string             `json:"shard_repair_topic"`
+	ShardRepairPriorityTopic string             `json:"shard_repair_priority_topic"`
+	MsgSender                mqueue.ProducerCfg `json:"msg_sender"`
+	Version                  string             `json:"version"` // version of kafka backend
+	// BlobEvent sinks of blob lifecycle events, topics are sent by MsgSender
+	BlobEvent mq.BlobEventConfig `json:"blob_event"`
 }
 
 type Config struct {
@@ -82,6 +85,12 @@ func (c *Config) blobDeleteCfg() mq.BlobDeleteConfig {
 	}
 }
 
+func (c *Config) blobEventCfg() mq.BlobEventConfig {
+	cfg := c.MQ.BlobEvent
+	cfg.MsgSenderCfg = c.MQ.MsgSender
+	return cfg
+}
+
 func (c *Config) shardRepairCfg() mq.ShardRepairConfig {
 	return mq.ShardRepairConfig{
 		Topic:         c.MQ.ShardRepairTopic,
@@ -96,6 +105,7 @@ type Service struct {
 	// mq
 	shardRepairMgr mq.ShardRepairHandler
 	blobDeleteMgr  mq.BlobDeleteHandler
+	blobEventMgr   mq.BlobEventHandler
 	// allocator
 	volumeMgr alloc.VolumeMgr
 	// cacher
@@ -144,6 +154,10 @@ func New(cfg Config, cmcli clustermgr.APIProxy) *Service {
 	if err != nil {
 		log.Fatalf("fail to new shardRepairMgr, error: %s", err.Error())
 	}
+	blobEventMgr, err := mq.NewBlobEventMgr(cfg.blobEventCfg())
+	if err != nil {
+		log.Fatalf("fail to new blobEventMgr, error: %s", err.Error())
+	}
 
 	// allocator
 	volumeMgr, err := alloc.NewVolumeMgr(context.Background(), cfg.BlobConfig, cfg.VolConfig, cmcli)
@@ -174,6 +188,7 @@ func New(cfg Config, cmcli clustermgr.APIProxy) *Service {
 		cacher:         cacher,
 		shardRepairMgr: shardRepairMgr,
 		blobDeleteMgr:  blobDeleteMgr,
+		blobEventMgr:   blobEventMgr,
 	}
 }
 
@@ -204,6 +219,10 @@ func NewHandler(service *Service) *rpc.Router {
 	// request body: json
 	router.Handle(http.MethodPost, "/deletemsg", service.SendDeleteMessage, rpc.OptArgsBody())
 
+	// POST /blobevent
+	// request body: json
+	router.Handle(http.MethodPost, "/blobevent", service.SendBlobEvent, rpc.OptArgsBody())
+
 	// GET /cache/volume/{vid}?flush={flush}&version={version}
 	// response body: json
 	router.Handle(http.MethodGet, "/cache/volume/:vid", service.GetCacheVolume, rpc.OptArgsURI(), rpc.OptArgsQuery())
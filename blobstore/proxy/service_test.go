@@ -29,6 +29,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	mqueue "github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/proxy/allocator"
@@ -77,6 +78,15 @@ func newMockService(t *testing.T) *Service {
 			return nil
 		})
 
+	blobEventMgr := mock.NewMockBlobEventHandler(ctr)
+	blobEventMgr.EXPECT().SendBlobEvent(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, args *proxy.BlobEventArgs) error {
+			if len(args.Events) == 0 {
+				return errors.New("fake send blob event failed")
+			}
+			return nil
+		})
+
 	volumeMgr := mock.NewMockVolumeMgr(ctr)
 	volumeMgr.EXPECT().Alloc(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
 		func(ctx context.Context, args *proxy.AllocVolsArgs) (allocVols []proxy.AllocRet, err error) {
@@ -144,6 +154,7 @@ func newMockService(t *testing.T) *Service {
 		},
 		shardRepairMgr: shardRepairMgr,
 		blobDeleteMgr:  blobDeleteMgr,
+		blobEventMgr:   blobEventMgr,
 		volumeMgr:      volumeMgr,
 		cacher:         cacher,
 	}
@@ -170,9 +181,11 @@ func TestService_New(t *testing.T) {
 					BlobDeleteTopic:          "test1",
 					ShardRepairTopic:         "test2",
 					ShardRepairPriorityTopic: "test3",
-					MsgSender: kafka.ProducerCfg{
-						BrokerList: []string{seedBroker.Addr()},
-						TimeoutMs:  1,
+					MsgSender: mqueue.ProducerCfg{
+						ProducerCfg: kafka.ProducerCfg{
+							BrokerList: []string{seedBroker.Addr()},
+							TimeoutMs:  1,
+						},
 					},
 				},
 			},
@@ -257,6 +270,34 @@ func TestService_MQ(t *testing.T) {
 		err := cli.PostWith(ctx, proxyServer.URL+"/repairmsg", nil, tc.args)
 		require.Equal(t, tc.code, rpc.DetectStatusCode(err))
 	}
+
+	blobEventCases := []struct {
+		args proxy.BlobEventArgs
+		code int
+	}{
+		{
+			args: proxy.BlobEventArgs{
+				ClusterID: 1,
+				Events:    []proxy.BlobEvent{{Type: proxy.BlobEventPut, SpaceID: 1, BlobName: []byte("blob")}},
+			},
+			code: 200,
+		},
+		{
+			args: proxy.BlobEventArgs{
+				ClusterID: 2,
+				Events:    []proxy.BlobEvent{{Type: proxy.BlobEventPut, SpaceID: 1, BlobName: []byte("blob")}},
+			},
+			code: 803,
+		},
+		{
+			args: proxy.BlobEventArgs{ClusterID: 1},
+			code: 500,
+		},
+	}
+	for _, tc := range blobEventCases {
+		err := cli.PostWith(ctx, proxyServer.URL+"/blobevent", nil, tc.args)
+		require.Equal(t, tc.code, rpc.DetectStatusCode(err))
+	}
 }
 
 func TestService_Allocator(t *testing.T) {
@@ -17,6 +17,7 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"sort"
 	"time"
@@ -33,6 +34,11 @@ import (
 
 const (
 	collectBalanceTaskPauseS = 5
+
+	// FailureDomainHost units of one volume can not be placed in the same host
+	FailureDomainHost = "host"
+	// FailureDomainRack units of one volume can not be placed in the same rack
+	FailureDomainRack = "rack"
 )
 
 var (
@@ -46,6 +52,8 @@ var (
 type BalanceMgrConfig struct {
 	MaxDiskFreeChunkCnt int64 `json:"max_disk_free_chunk_cnt"`
 	MinDiskFreeChunkCnt int64 `json:"min_disk_free_chunk_cnt"`
+	// failure domain of volume units, host or rack
+	FailureDomain string `json:"failure_domain"`
 	MigrateConfig
 }
 
@@ -68,6 +76,7 @@ func NewBalanceMgr(clusterMgrCli client.ClusterMgrAPI, volumeUpdater client.IVol
 		clusterMgrCli:   clusterMgrCli,
 		cfg:             conf,
 	}
+	conf.validateMoveFunc = mgr.validateMove
 	mgr.IMigrator = NewMigrateMgr(clusterMgrCli, volumeUpdater, taskSwitch, taskLogger,
 		&conf.MigrateConfig, proto.TaskTypeBalance)
 	return mgr
@@ -109,10 +118,11 @@ func (mgr *BalanceMgr) collectionTask() (err error) {
 	span, ctx := trace.StartSpanFromContext(context.Background(), "balance_collectionTask")
 	defer span.Finish()
 
-	needBalanceDiskCnt := mgr.cfg.DiskConcurrency - mgr.IMigrator.GetMigratingDiskNum()
+	diskConcurrency := mgr.cfg.diskConcurrency(proto.TaskTypeBalance)
+	needBalanceDiskCnt := diskConcurrency - mgr.IMigrator.GetMigratingDiskNum()
 	if needBalanceDiskCnt <= 0 {
 		span.Warnf("the number of balancing disk is greater than config: current[%d], conf[%d]",
-			mgr.IMigrator.GetMigratingDiskNum(), mgr.cfg.DiskConcurrency)
+			mgr.IMigrator.GetMigratingDiskNum(), diskConcurrency)
 		return ErrTooManyBalancingTasks
 	}
 
@@ -213,6 +223,67 @@ func (mgr *BalanceMgr) selectBalanceVunit(ctx context.Context, diskID proto.Disk
 	return vuid, ErrNoBalanceVunit
 }
 
+// validateMove checks the destination of balance task is copyset safety, returns error
+// explaining why the move is unsafe:
+// 1. destination disk must be in the disk sets of the volume
+// 2. destination disk must be in the same idc with source disk
+// 3. destination disk must not be in the same failure domain with other units of the volume
+func (mgr *BalanceMgr) validateMove(ctx context.Context, volume *client.VolumeInfoSimple, src proto.Vuid, dest proto.VunitLocation) error {
+	destDisk, err := mgr.clusterMgrCli.GetDiskInfo(ctx, dest.DiskID)
+	if err != nil {
+		return fmt.Errorf("get destination disk[%d] info failed: %w", dest.DiskID, err)
+	}
+
+	var srcDisk *client.DiskInfoSimple
+	otherDisks := make([]*client.DiskInfoSimple, 0, len(volume.VunitLocations))
+	otherUnits := make([]proto.VunitLocation, 0, len(volume.VunitLocations))
+	diskSets := make(map[proto.DiskSetID]struct{})
+	for _, unit := range volume.VunitLocations {
+		disk, err := mgr.clusterMgrCli.GetDiskInfo(ctx, unit.DiskID)
+		if err != nil {
+			return fmt.Errorf("get disk[%d] info of vuid[%d] failed: %w", unit.DiskID, unit.Vuid, err)
+		}
+		if unit.Vuid.Index() == src.Index() {
+			srcDisk = disk
+			continue
+		}
+		diskSets[disk.DiskSetID] = struct{}{}
+		otherDisks = append(otherDisks, disk)
+		otherUnits = append(otherUnits, unit)
+	}
+	if srcDisk == nil {
+		return fmt.Errorf("source vuid[%d] not found in volume[%d]", src, volume.Vid)
+	}
+
+	if _, ok := diskSets[destDisk.DiskSetID]; len(diskSets) > 0 && !ok {
+		return fmt.Errorf("destination disk[%d] disk set[%d] is out of disk sets of volume[%d]",
+			destDisk.DiskID, destDisk.DiskSetID, volume.Vid)
+	}
+	if destDisk.Idc != srcDisk.Idc {
+		return fmt.Errorf("destination disk[%d] idc[%s] is not same with source disk[%d] idc[%s]",
+			destDisk.DiskID, destDisk.Idc, srcDisk.DiskID, srcDisk.Idc)
+	}
+	for idx, disk := range otherDisks {
+		if mgr.sameFailureDomain(destDisk, disk) {
+			return fmt.Errorf("destination disk[%d] is in the same %s[%s] with unit index[%d] vuid[%d] disk[%d]",
+				destDisk.DiskID, mgr.cfg.FailureDomain, mgr.failureDomainOf(disk),
+				otherUnits[idx].Vuid.Index(), otherUnits[idx].Vuid, disk.DiskID)
+		}
+	}
+	return nil
+}
+
+func (mgr *BalanceMgr) sameFailureDomain(a, b *client.DiskInfoSimple) bool {
+	return a.Idc == b.Idc && mgr.failureDomainOf(a) == mgr.failureDomainOf(b)
+}
+
+func (mgr *BalanceMgr) failureDomainOf(disk *client.DiskInfoSimple) string {
+	if mgr.cfg.FailureDomain == FailureDomainRack {
+		return disk.Rack
+	}
+	return disk.Host
+}
+
 // checkAndClearJunkTasksLoop due to network timeout, it may still have some junk migrate tasks in clustermgr,
 // and we need to clear those tasks later
 func (mgr *BalanceMgr) checkAndClearJunkTasksLoop() {
@@ -17,6 +17,7 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -270,3 +271,72 @@ func TestBalanceCheckAndClearJunkTasks(t *testing.T) {
 		mgr.checkAndClearJunkTasks()
 	}
 }
+
+func TestBalanceValidateMove(t *testing.T) {
+	ctx := context.Background()
+	mgr := newBalancer(t)
+	require.NotNil(t, mgr.cfg.validateMoveFunc)
+	mgr.cfg.FailureDomain = FailureDomainHost
+
+	volume := MockGenVolInfo(10001, codemode.EC6P6, proto.VolumeStatusIdle)
+	disks := make(map[proto.DiskID]*client.DiskInfoSimple)
+	for idx, unit := range volume.VunitLocations {
+		disks[unit.DiskID] = &client.DiskInfoSimple{
+			Idc:       "z0",
+			Rack:      fmt.Sprintf("rack%d", idx%4),
+			Host:      fmt.Sprintf("127.0.0.%d:8000", idx),
+			DiskID:    unit.DiskID,
+			DiskSetID: 1,
+		}
+	}
+	destDisk := &client.DiskInfoSimple{
+		Idc:       "z0",
+		Rack:      "rack1",
+		Host:      "127.0.0.100:8000",
+		DiskID:    proto.DiskID(99999),
+		DiskSetID: 1,
+	}
+	disks[destDisk.DiskID] = destDisk
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetDiskInfo(any, any).AnyTimes().DoAndReturn(
+		func(_ context.Context, diskID proto.DiskID) (*client.DiskInfoSimple, error) {
+			if disk, ok := disks[diskID]; ok {
+				return disk, nil
+			}
+			return nil, errMock
+		})
+
+	src := volume.VunitLocations[0].Vuid
+	dest := proto.VunitLocation{Vuid: src + 1, DiskID: destDisk.DiskID}
+	require.NoError(t, mgr.validateMove(ctx, volume, src, dest))
+
+	// destination disk not found
+	err := mgr.validateMove(ctx, volume, src, proto.VunitLocation{Vuid: src + 1, DiskID: 100000})
+	require.True(t, errors.Is(err, errMock))
+
+	// out of disk sets
+	destDisk.DiskSetID = 2
+	err = mgr.validateMove(ctx, volume, src, dest)
+	require.ErrorContains(t, err, "disk set")
+	destDisk.DiskSetID = 1
+
+	// cross idc
+	destDisk.Idc = "z1"
+	err = mgr.validateMove(ctx, volume, src, dest)
+	require.ErrorContains(t, err, "idc")
+	destDisk.Idc = "z0"
+
+	// same host with other unit
+	destDisk.Host = disks[volume.VunitLocations[3].DiskID].Host
+	err = mgr.validateMove(ctx, volume, src, dest)
+	require.ErrorContains(t, err, fmt.Sprintf("unit index[3] vuid[%d]", volume.VunitLocations[3].Vuid))
+	// same host with source unit
+	destDisk.Host = disks[volume.VunitLocations[0].DiskID].Host
+	require.NoError(t, mgr.validateMove(ctx, volume, src, dest))
+
+	// same rack with other unit
+	mgr.cfg.FailureDomain = FailureDomainRack
+	err = mgr.validateMove(ctx, volume, src, dest)
+	require.ErrorContains(t, err, "same rack[rack1]")
+	destDisk.Rack = "rack100"
+	require.NoError(t, mgr.validateMove(ctx, volume, src, dest))
+}
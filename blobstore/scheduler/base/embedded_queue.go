@@ -0,0 +1,303 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package base
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/cubefs/cubefs/blobstore/common/kvstore"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/closer"
+)
+
+const defaultEmbeddedPollIntervalMs = 500
+
+var (
+	embeddedSeqKey       = []byte("seq")
+	embeddedMsgKeyPrefix = []byte("msg/")
+
+	// ErrEmbeddedQueueClosed embedded queue is closed
+	ErrEmbeddedQueueClosed = errors.New("embedded queue is closed")
+)
+
+// EmbeddedQueueConfig config of embedded queue
+type EmbeddedQueueConfig struct {
+	Path           string `json:"path"`
+	PollIntervalMs int    `json:"poll_interval_ms"`
+}
+
+// EmbeddedQueue is a message queue backed by kvstore for small clusters without kafka,
+// messages of topic are consumed in order and deleted after consumed successfully.
+// Messages are synced to the local disk of scheduler leader before sending returns, but
+// they are not replicated, messages are lost if the disk of leader is broken or the
+// leader is replaced, so kafka is recommended if messages can't be lost
+type EmbeddedQueue struct {
+	db           kvstore.KVStore
+	pollInterval time.Duration
+
+	lock     sync.Mutex
+	seq      uint64
+	notifies map[string][]chan struct{}
+
+	// consumers running, db is closed after all consumers exited
+	consumers sync.WaitGroup
+	closer.Closer
+}
+
+// OpenEmbeddedQueue opens embedded queue on the path
+func OpenEmbeddedQueue(cfg EmbeddedQueueConfig) (*EmbeddedQueue, error) {
+	if cfg.PollIntervalMs <= 0 {
+		cfg.PollIntervalMs = defaultEmbeddedPollIntervalMs
+	}
+	db, err := kvstore.OpenDB(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var seq uint64
+	val, err := db.Get(embeddedSeqKey)
+	switch {
+	case err == nil:
+		seq = binary.BigEndian.Uint64(val)
+	case errors.Is(err, kvstore.ErrNotFound):
+	default:
+		db.Close()
+		return nil, err
+	}
+
+	return &EmbeddedQueue{
+		db:           db,
+		pollInterval: time.Duration(cfg.PollIntervalMs) * time.Millisecond,
+		seq:          seq,
+		notifies:     make(map[string][]chan struct{}),
+		Closer:       closer.New(),
+	}, nil
+}
+
+func embeddedTopicPrefix(topic string) []byte {
+	prefix := make([]byte, 0, len(embeddedMsgKeyPrefix)+len(topic)+9)
+	prefix = append(prefix, embeddedMsgKeyPrefix...)
+	prefix = append(prefix, topic...)
+	return append(prefix, '/')
+}
+
+func embeddedMsgKey(topic string, seq uint64) []byte {
+	prefix := embeddedTopicPrefix(topic)
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], seq)
+	return key
+}
+
+// SendMessage appends message to topic
+func (q *EmbeddedQueue) SendMessage(topic string, msg []byte) error {
+	return q.SendMessages(topic, [][]byte{msg})
+}
+
+// SendMessages appends messages to topic in one batch
+func (q *EmbeddedQueue) SendMessages(topic string, msgs [][]byte) error {
+	select {
+	case <-q.Closer.Done():
+		return ErrEmbeddedQueueClosed
+	default:
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	q.lock.Lock()
+	kvs := make([]kvstore.KV, 0, len(msgs)+1)
+	seq := q.seq
+	for _, msg := range msgs {
+		seq++
+		kvs = append(kvs, kvstore.KV{Key: embeddedMsgKey(topic, seq), Value: msg})
+	}
+	seqValue := make([]byte, 8)
+	binary.BigEndian.PutUint64(seqValue, seq)
+	kvs = append(kvs, kvstore.KV{Key: embeddedSeqKey, Value: seqValue})
+	if err := q.db.WriteBatch(kvs, true); err != nil {
+		q.lock.Unlock()
+		return err
+	}
+	q.seq = seq
+	notifies := q.notifies[topic]
+	q.lock.Unlock()
+
+	for _, ch := range notifies {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// fetch returns the oldest messages of topic no more than size
+func (q *EmbeddedQueue) fetch(topic string, size int) ([]*sarama.ConsumerMessage, [][]byte) {
+	prefix := embeddedTopicPrefix(topic)
+	iter := q.db.NewIterator(nil)
+	defer iter.Close()
+
+	msgs := make([]*sarama.ConsumerMessage, 0, size)
+	keys := make([][]byte, 0, size)
+	for iter.Seek(prefix); iter.ValidForPrefix(prefix) && len(msgs) < size; iter.Next() {
+		key, value := iter.Key(), iter.Value()
+		keyData := append([]byte{}, key.Data()...)
+		msgs = append(msgs, &sarama.ConsumerMessage{
+			Topic:     topic,
+			Offset:    int64(binary.BigEndian.Uint64(keyData[len(prefix):])),
+			Value:     append([]byte{}, value.Data()...),
+			Timestamp: time.Now(),
+		})
+		keys = append(keys, keyData)
+		key.Free()
+		value.Free()
+	}
+	return msgs, keys
+}
+
+func (q *EmbeddedQueue) subscribe(topic string) chan struct{} {
+	ch := make(chan struct{}, 1)
+	q.lock.Lock()
+	q.notifies[topic] = append(q.notifies[topic], ch)
+	q.lock.Unlock()
+	return ch
+}
+
+func (q *EmbeddedQueue) unsubscribe(topic string, ch chan struct{}) {
+	q.lock.Lock()
+	notifies := q.notifies[topic]
+	for idx := range notifies {
+		if notifies[idx] == ch {
+			notifies = append(notifies[:idx], notifies[idx+1:]...)
+			break
+		}
+	}
+	if len(notifies) == 0 {
+		delete(q.notifies, topic)
+	} else {
+		q.notifies[topic] = notifies
+	}
+	q.lock.Unlock()
+}
+
+type embeddedConsumer struct {
+	queue   *EmbeddedQueue
+	topic   string
+	notify  chan struct{}
+	stopped chan struct{}
+	closer.Closer
+}
+
+// Stop stops consumer and unsubscribes notify of the topic,
+// returns after the messages in consuming are done
+func (c *embeddedConsumer) Stop() {
+	c.Close()
+	<-c.stopped
+	c.queue.unsubscribe(c.topic, c.notify)
+}
+
+// StartKafkaConsumer starts consumer of topic in embedded queue, the batch of messages is
+// consumed when it is full or the max wait time comes, and deleted if consumed successfully
+func (q *EmbeddedQueue) StartKafkaConsumer(cfg KafkaConsumerCfg, fn func(msg []*sarama.ConsumerMessage,
+	consumerPause ConsumerPause) bool,
+) (GroupConsumer, error) {
+	span, _ := trace.StartSpanFromContext(context.Background(), "embedded-"+cfg.Topic)
+	notify := q.subscribe(cfg.Topic)
+	consumer := &embeddedConsumer{
+		queue:   q,
+		topic:   cfg.Topic,
+		notify:  notify,
+		stopped: make(chan struct{}),
+		Closer:  closer.New(),
+	}
+
+	q.consumers.Add(1)
+	go func() {
+		defer q.consumers.Done()
+		defer close(consumer.stopped)
+		poll := time.NewTicker(q.pollInterval)
+		defer poll.Stop()
+		maxWait := time.Second * time.Duration(cfg.MaxWaitTimeS)
+		lastConsume := time.Now()
+		for {
+			select {
+			case <-notify:
+			case <-poll.C:
+			case <-consumer.Done():
+				return
+			case <-q.Closer.Done():
+				return
+			}
+
+			for {
+				// stop consuming between batches
+				select {
+				case <-consumer.Done():
+					return
+				case <-q.Closer.Done():
+					return
+				default:
+				}
+				msgs, keys := q.fetch(cfg.Topic, cfg.MaxBatchSize)
+				if len(msgs) == 0 || (len(msgs) < cfg.MaxBatchSize && time.Since(lastConsume) < maxWait) {
+					break
+				}
+				lastMsg := msgs[len(msgs)-1]
+				if success := fn(msgs, consumer); !success {
+					span.Warnf("message not consume and return: topic[%s], offset[%d]", lastMsg.Topic, lastMsg.Offset)
+					break
+				}
+				lastConsume = time.Now()
+				if err := q.db.DeleteBatch(keys, false); err != nil {
+					span.Errorf("delete consumed messages failed: topic[%s], offset[%d], err[%+v]", lastMsg.Topic, lastMsg.Offset, err)
+					break
+				}
+			}
+		}
+	}()
+	span.Infof("start embedded consumer: topic[%s]", cfg.Topic)
+	return consumer, nil
+}
+
+// Close closes embedded queue after consumers exited
+func (q *EmbeddedQueue) Close() {
+	q.Closer.Close()
+	q.consumers.Wait()
+	q.db.Close()
+}
+
+type noopGroupConsumer struct{}
+
+func (noopGroupConsumer) Stop() {}
+
+type noopConsumer struct{}
+
+// NewNoopConsumer returns consumer which consumes nothing, for followers of embedded queue
+func NewNoopConsumer() KafkaConsumer {
+	return noopConsumer{}
+}
+
+func (noopConsumer) StartKafkaConsumer(cfg KafkaConsumerCfg, fn func(msg []*sarama.ConsumerMessage,
+	consumerPause ConsumerPause) bool,
+) (GroupConsumer, error) {
+	return noopGroupConsumer{}, nil
+}
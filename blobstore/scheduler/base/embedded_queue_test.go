@@ -0,0 +1,123 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package base
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedQueue(t *testing.T) {
+	cfg := EmbeddedQueueConfig{Path: t.TempDir(), PollIntervalMs: 10}
+	q, err := OpenEmbeddedQueue(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, q.SendMessage(testTopic, []byte("0")))
+	require.NoError(t, q.SendMessages(testTopic, [][]byte{[]byte("1"), []byte("2")}))
+	require.NoError(t, q.SendMessage("other", []byte("x")))
+	require.Equal(t, uint64(4), q.seq)
+
+	// reopen and recover sequence
+	q.Close()
+	require.ErrorIs(t, q.SendMessage(testTopic, []byte("3")), ErrEmbeddedQueueClosed)
+	q, err = OpenEmbeddedQueue(cfg)
+	require.NoError(t, err)
+	defer q.Close()
+	require.Equal(t, uint64(4), q.seq)
+
+	var failed int32 = 1
+	got := make(chan []*sarama.ConsumerMessage, 4)
+	consumer, err := q.StartKafkaConsumer(KafkaConsumerCfg{Topic: testTopic, MaxBatchSize: 2, MaxWaitTimeS: 1},
+		func(msgs []*sarama.ConsumerMessage, pause ConsumerPause) bool {
+			// the first batch is not consumed and will be consumed again
+			if atomic.CompareAndSwapInt32(&failed, 1, 0) {
+				return false
+			}
+			got <- msgs
+			return true
+		})
+	require.NoError(t, err)
+	defer consumer.Stop()
+
+	msgs := <-got
+	require.Equal(t, 2, len(msgs))
+	require.Equal(t, "0", string(msgs[0].Value))
+	require.Equal(t, "1", string(msgs[1].Value))
+	require.Equal(t, testTopic, msgs[0].Topic)
+	require.Less(t, msgs[0].Offset, msgs[1].Offset)
+
+	// the partial batch is consumed after max wait time
+	msgs = <-got
+	require.Equal(t, 1, len(msgs))
+	require.Equal(t, "2", string(msgs[0].Value))
+
+	require.NoError(t, q.SendMessages(testTopic, [][]byte{[]byte("3"), []byte("4")}))
+	select {
+	case msgs = <-got:
+		require.Equal(t, "3", string(msgs[0].Value))
+	case <-time.After(5 * time.Second):
+		t.Fatal("consume timeout")
+	}
+	msgs, _ = q.fetch(testTopic, 10)
+	require.Equal(t, 0, len(msgs))
+	msgs, _ = q.fetch("other", 10)
+	require.Equal(t, 1, len(msgs))
+
+	// notify of topic is unsubscribed after consumer stopped
+	other, err := q.StartKafkaConsumer(KafkaConsumerCfg{Topic: testTopic, MaxBatchSize: 2, MaxWaitTimeS: 1},
+		func(msgs []*sarama.ConsumerMessage, pause ConsumerPause) bool { return true })
+	require.NoError(t, err)
+	require.Equal(t, 2, len(q.notifies[testTopic]))
+	other.Stop()
+	require.Equal(t, 1, len(q.notifies[testTopic]))
+	consumer.Stop()
+	require.NotContains(t, q.notifies, testTopic)
+}
+
+func TestEmbeddedQueueStopConsumer(t *testing.T) {
+	q, err := OpenEmbeddedQueue(EmbeddedQueueConfig{Path: t.TempDir(), PollIntervalMs: 10})
+	require.NoError(t, err)
+	defer q.Close()
+	require.NoError(t, q.SendMessage(testTopic, []byte("0")))
+
+	var consumed int32
+	consuming := make(chan struct{})
+	consumer, err := q.StartKafkaConsumer(KafkaConsumerCfg{Topic: testTopic, MaxBatchSize: 1},
+		func(msgs []*sarama.ConsumerMessage, pause ConsumerPause) bool {
+			close(consuming)
+			time.Sleep(100 * time.Millisecond)
+			atomic.StoreInt32(&consumed, 1)
+			return true
+		})
+	require.NoError(t, err)
+
+	// stop returns after the messages in consuming are done
+	<-consuming
+	consumer.Stop()
+	require.Equal(t, int32(1), atomic.LoadInt32(&consumed))
+	msgs, _ := q.fetch(testTopic, 10)
+	require.Equal(t, 0, len(msgs))
+}
+
+func TestNoopConsumer(t *testing.T) {
+	consumer, err := NewNoopConsumer().StartKafkaConsumer(KafkaConsumerCfg{Topic: testTopic},
+		func(msgs []*sarama.ConsumerMessage, pause ConsumerPause) bool { return true })
+	require.NoError(t, err)
+	consumer.Stop()
+}
@@ -14,7 +14,7 @@
 
 package base
 
-import "github.com/cubefs/cubefs/blobstore/common/kafka"
+import "github.com/cubefs/cubefs/blobstore/common/mq"
 
 // IProducer define the interface of producer
 type IProducer interface {
@@ -24,12 +24,12 @@ type IProducer interface {
 
 type msgSender struct {
 	topic    string
-	producer kafka.MsgProducer
+	producer mq.Producer
 }
 
 // NewMsgSender returns message sender
-func NewMsgSender(cfg *kafka.ProducerCfg) (IProducer, error) {
-	producer, err := kafka.NewProducer(cfg)
+func NewMsgSender(cfg *mq.ProducerCfg) (IProducer, error) {
+	producer, err := mq.NewProducer(cfg)
 	if err != nil {
 		return nil, err
 	}
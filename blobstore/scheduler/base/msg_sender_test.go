@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	"github.com/cubefs/cubefs/blobstore/common/mq"
 )
 
 func TestSendMessage(t *testing.T) {
@@ -40,7 +41,7 @@ func TestSendMessage(t *testing.T) {
 		leader.Returns(prodSuccess)
 	}
 
-	msgSender, err := NewMsgSender(&kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}, Topic: testTopic})
+	msgSender, err := NewMsgSender(&mq.ProducerCfg{ProducerCfg: kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}, Topic: testTopic}})
 	require.NoError(t, err)
 
 	err = msgSender.SendMessage([]byte("dasdada"))
@@ -64,7 +65,7 @@ func TestSendMessages(t *testing.T) {
 		leader.Returns(prodSuccess)
 	}
 
-	msgSender, err := NewMsgSender(&kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}, Topic: testTopic})
+	msgSender, err := NewMsgSender(&mq.ProducerCfg{ProducerCfg: kafka.ProducerCfg{BrokerList: []string{seedBroker.Addr()}, Topic: testTopic}})
 	require.NoError(t, err)
 
 	err = msgSender.SendMessages([][]byte{[]byte("dasdada")})
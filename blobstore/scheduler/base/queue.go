@@ -73,11 +73,18 @@ type msgEx struct {
 	id       string
 	state    int
 	deadline time.Time
+	priority int
 	msg      interface{}
 }
 
 // Push push message to queue id is uniquely identifies。
 func (q *Queue) Push(id string, msg interface{}) error {
+	return q.PushWithPriority(id, msg, 0)
+}
+
+// PushWithPriority push message with priority, message with higher priority is popped firstly,
+// and it's FIFO in the same priority.
+func (q *Queue) PushWithPriority(id string, msg interface{}, priority int) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
@@ -86,16 +93,47 @@ func (q *Queue) Push(id string, msg interface{}) error {
 	}
 
 	m := &msgEx{
-		id:    id,
-		state: msgStateTodo,
-		msg:   msg,
+		id:       id,
+		state:    msgStateTodo,
+		priority: priority,
+		msg:      msg,
 	}
-	elem := q.todo.PushBack(m)
-	q.msgs[id] = elem
+	q.msgs[id] = q.insertTodo(m)
 
 	return nil
 }
 
+// insertTodo inserts message after the last one whose priority is not lower
+func (q *Queue) insertTodo(m *msgEx) *list.Element {
+	for ele := q.todo.Back(); ele != nil; ele = ele.Prev() {
+		if ele.Value.(*msgEx).priority >= m.priority {
+			return q.todo.InsertAfter(m, ele)
+		}
+	}
+	return q.todo.PushFront(m)
+}
+
+// UpdatePriority updates priority of message, it takes effect when message is in todo.
+func (q *Queue) UpdatePriority(id string, priority int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	elem, ok := q.msgs[id]
+	if !ok {
+		return ErrNoSuchMessageID
+	}
+	m := elem.Value.(*msgEx)
+	if m.priority == priority {
+		return nil
+	}
+	m.priority = priority
+	if m.state == msgStateTodo {
+		q.todo.Remove(elem)
+		q.msgs[id] = q.insertTodo(m)
+	}
+	return nil
+}
+
 // Pop  fetch a msg from queue。
 func (q *Queue) Pop() (string, interface{}, bool) {
 	q.mu.Lock()
@@ -237,6 +275,23 @@ func (q *TaskQueue) PopTask() (string, interface{}, bool) {
 	return "", nil, false
 }
 
+// PushTaskWithPriority push task with priority to queue, task with higher priority is popped firstly
+func (q *TaskQueue) PushTaskWithPriority(taskID string, task interface{}, priority int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.queue.PushWithPriority(taskID, task, priority)
+	if err != nil {
+		panic("unexpect push task fail " + err.Error())
+	}
+}
+
+// UpdateTaskPriority update priority of task, ignore if task not in queue
+func (q *TaskQueue) UpdateTaskPriority(taskID string, priority int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_ = q.queue.UpdatePriority(taskID, priority)
+}
+
 // RemoveTask remove task by taskID
 func (q *TaskQueue) RemoveTask(taskID string) error {
 	q.mu.Lock()
@@ -124,6 +124,44 @@ func (t *mockWorkerTask) ToTask() (*proto.Task, error) {
 	return nil, nil
 }
 
+func TestQueuePriority(t *testing.T) {
+	q := NewQueue(time.Hour)
+	require.NoError(t, q.Push("a", "a"))
+	require.NoError(t, q.PushWithPriority("b", "b", 2))
+	require.NoError(t, q.PushWithPriority("c", "c", -1))
+	require.NoError(t, q.PushWithPriority("d", "d", 2))
+	require.NoError(t, q.Push("e", "e"))
+	require.ErrorIs(t, q.PushWithPriority("a", "a", 1), errExistingMessageID)
+
+	require.NoError(t, q.UpdatePriority("e", 3))
+	require.NoError(t, q.UpdatePriority("b", 2))
+	require.ErrorIs(t, q.UpdatePriority("x", 1), ErrNoSuchMessageID)
+
+	id, _, exist := q.Pop()
+	require.True(t, exist)
+	require.Equal(t, "e", id)
+	// priority of doing message is updated without moving
+	require.NoError(t, q.UpdatePriority("e", -2))
+
+	require.NoError(t, q.UpdatePriority("c", 1))
+	for _, expected := range []string{"b", "d", "c", "a"} {
+		id, _, exist = q.Pop()
+		require.True(t, exist)
+		require.Equal(t, expected, id)
+	}
+	_, _, exist = q.Pop()
+	require.False(t, exist)
+
+	tq := NewTaskQueue(time.Hour)
+	tq.PushTask("a", "a")
+	tq.PushTaskWithPriority("b", "b", 1)
+	tq.UpdateTaskPriority("a", 2)
+	tq.UpdateTaskPriority("x", 2)
+	id, _, exist = tq.PopTask()
+	require.True(t, exist)
+	require.Equal(t, "a", id)
+}
+
 func TestTaskQueue(t *testing.T) {
 	// test Push
 	taskID1 := "task_id1"
@@ -288,8 +288,9 @@ func (statsMgr *TaskStatsMgr) Counters() (increaseDataSize, increaseShardCnt [co
 
 // statistics stats
 const (
-	KindFailed  = "failed"
-	KindSuccess = "success"
+	KindFailed    = "failed"
+	KindSuccess   = "success"
+	KindProtected = "protected"
 )
 
 // NewCounter returns statistics counter
@@ -26,9 +26,11 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/prometheus/client_golang/prometheus"
 
+	api "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/common/counter"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	"github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/recordlog"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
@@ -57,6 +59,8 @@ const (
 	DeleteStatusFailed
 	DeleteStatusUnexpect
 	DeleteStatusUndo
+	// DeleteStatusProtected the shards are in worm retention, the message is not retried
+	DeleteStatusProtected
 )
 
 // ErrVunitLengthNotEqual vunit length not equal
@@ -185,17 +189,27 @@ type BlobDeleteConfig struct {
 	DeleteLog        recordlog.Config `json:"delete_log"`
 
 	DeleteRatePerSecond int `json:"delete_rate_per_second"`
+
+	// shards of the same disk are mark deleted or deleted in one batch request,
+	// batch is sent when it is full or after waiting batch wait time,
+	// batch is disabled if size is less than 2
+	ShardBatchSize   int `json:"shard_batch_size"`
+	ShardBatchWaitMs int `json:"shard_batch_wait_ms"`
 }
 
 func (cfg *BlobDeleteConfig) topics() []string {
 	return []string{cfg.Kafka.TopicNormal, cfg.Kafka.TopicFailed}
 }
 
-func (cfg *BlobDeleteConfig) failedProducerConfig() *kafka.ProducerCfg {
-	return &kafka.ProducerCfg{
-		BrokerList: cfg.Kafka.BrokerList,
-		Topic:      cfg.Kafka.TopicFailed,
-		TimeoutMs:  cfg.Kafka.FailMsgSenderTimeoutMs,
+func (cfg *BlobDeleteConfig) failedProducerConfig() *mq.ProducerCfg {
+	return &mq.ProducerCfg{
+		Backend: cfg.Kafka.Backend,
+		ProducerCfg: kafka.ProducerCfg{
+			BrokerList: cfg.Kafka.BrokerList,
+			Topic:      cfg.Kafka.TopicFailed,
+			TimeoutMs:  cfg.Kafka.FailMsgSenderTimeoutMs,
+		},
+		Embedded: rpc.LbConfig{Hosts: cfg.Kafka.EmbeddedHosts},
 	}
 }
 
@@ -212,6 +226,7 @@ type BlobDeleteMgr struct {
 	delSuccessCounterByMin *counter.Counter
 	delFailCounter         prometheus.Counter
 	delFailCounterByMin    *counter.Counter
+	delProtectedCounter    prometheus.Counter
 	errStatsDistribution   *base.ErrorStats
 
 	kafkaConsumerClient base.KafkaConsumer
@@ -222,6 +237,7 @@ type BlobDeleteMgr struct {
 	deleteHourRange     HourRange
 	failMsgSender       base.IProducer
 	deleteLimiter       *rate.Limiter
+	shardBatcher        *shardDeleteBatcher
 
 	// delete log
 	delLogger recordlog.Encoder
@@ -264,6 +280,7 @@ func NewBlobDeleteMgr(
 		blobnodeCli:            blobnodeCli,
 		delSuccessCounter:      base.NewCounter(cfg.ClusterID, "delete", base.KindSuccess),
 		delFailCounter:         base.NewCounter(cfg.ClusterID, "delete", base.KindFailed),
+		delProtectedCounter:    base.NewCounter(cfg.ClusterID, "delete", base.KindProtected),
 		errStatsDistribution:   base.NewErrorStats(),
 		delSuccessCounterByMin: &counter.Counter{},
 		delFailCounterByMin:    &counter.Counter{},
@@ -279,6 +296,10 @@ func NewBlobDeleteMgr(
 		cfg:                 cfg,
 		Closer:              closer.New(),
 	}
+	if cfg.ShardBatchSize > 1 {
+		mgr.shardBatcher = newShardDeleteBatcher(blobnodeCli, cfg.ShardBatchSize,
+			time.Duration(cfg.ShardBatchWaitMs)*time.Millisecond)
+	}
 
 	return mgr, nil
 }
@@ -466,6 +487,12 @@ func (mgr *BlobDeleteMgr) recordAllResult(rets []delBlobRet) {
 			base.InsistOn(ctx, "deleter send2FailQueue", func() error {
 				return mgr.send2FailQueue(ctx, delMsg)
 			})
+		case DeleteStatusProtected:
+			// blobnode keeps the shards until retention, no retry to block the queue
+			span.Warnf("delete protected by worm retention and ignore: vid[%d], bid[%d], err[%+v]",
+				delMsg.Vid, delMsg.Bid, ret.err)
+			mgr.delProtectedCounter.Inc()
+			mgr.errStatsDistribution.AddFail(ret.err)
 		case DeleteStatusUnexpect:
 			span.Warnf("unexpected result will ignore: msg[%+v], err[%+v]", delMsg, ret.err)
 		case DeleteStatusUndo:
@@ -534,6 +561,9 @@ func (mgr *BlobDeleteMgr) consume(item *delBlobRet, consumerPause base.ConsumerP
 	span.Debugf("start delete msg[%+v]", item.delMsg)
 	if err := mgr.deleteWithCheckVolConsistency(item.ctx, item.delMsg); err != nil {
 		item.status = DeleteStatusFailed
+		if rpc.DetectStatusCode(err) == errcode.CodeShardWormProtected {
+			item.status = DeleteStatusProtected
+		}
 		item.err = err
 		return
 	}
@@ -674,10 +704,17 @@ func (mgr *BlobDeleteMgr) deleteShard(ctx context.Context, location proto.VunitL
 	}
 
 	var stage proto.DeleteStage
-	if markDelete {
+	switch {
+	case mgr.shardBatcher != nil:
+		stage = proto.DeleteStageDelete
+		if markDelete {
+			stage = proto.DeleteStageMarkDelete
+		}
+		err = mgr.shardBatcher.do(ctx, location, bid, markDelete)
+	case markDelete:
 		stage = proto.DeleteStageMarkDelete
 		err = mgr.blobnodeCli.MarkDelete(ctx, location, bid)
-	} else {
+	default:
 		stage = proto.DeleteStageDelete
 		err = mgr.blobnodeCli.Delete(ctx, location, bid)
 	}
@@ -709,6 +746,132 @@ func (mgr *BlobDeleteMgr) deleteShard(ctx context.Context, location proto.VunitL
 	return
 }
 
+type shardBatchKey struct {
+	host       string
+	diskID     proto.DiskID
+	markDelete bool
+}
+
+type shardBatchReq struct {
+	shard api.ShardID
+	dones []chan error
+}
+
+type shardBatch struct {
+	key   shardBatchKey
+	timer *time.Timer
+	reqs  []*shardBatchReq
+	index map[api.ShardID]*shardBatchReq
+}
+
+// shardDeleteBatcher gathers shards of the same disk from concurrent deleting blobs,
+// and sends them to blobnode with one batch request.
+type shardDeleteBatcher struct {
+	blobnodeCli client.BlobnodeAPI
+	batchSize   int
+	batchWait   time.Duration
+
+	mu      sync.Mutex
+	batches map[shardBatchKey]*shardBatch
+}
+
+func newShardDeleteBatcher(blobnodeCli client.BlobnodeAPI, batchSize int, batchWait time.Duration) *shardDeleteBatcher {
+	if batchSize > api.MaxBatchDeleteShards {
+		batchSize = api.MaxBatchDeleteShards
+	}
+	return &shardDeleteBatcher{
+		blobnodeCli: blobnodeCli,
+		batchSize:   batchSize,
+		batchWait:   batchWait,
+		batches:     make(map[shardBatchKey]*shardBatch),
+	}
+}
+
+// do mark deletes or deletes the shard within a batch of its disk, and waits the result of the shard
+func (b *shardDeleteBatcher) do(ctx context.Context, location proto.VunitLocation, bid proto.BlobID, markDelete bool) error {
+	key := shardBatchKey{host: location.Host, diskID: location.DiskID, markDelete: markDelete}
+	shard := api.ShardID{Vuid: location.Vuid, Bid: bid}
+	done := make(chan error, 1)
+
+	b.mu.Lock()
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &shardBatch{key: key, index: make(map[api.ShardID]*shardBatchReq)}
+		b.batches[key] = batch
+		batch.timer = time.AfterFunc(b.batchWait, func() { b.flush(batch) })
+	}
+	// the same shard may be deleted by duplicated messages, request it once in batch
+	if req, ok := batch.index[shard]; ok {
+		req.dones = append(req.dones, done)
+	} else {
+		req = &shardBatchReq{shard: shard, dones: []chan error{done}}
+		batch.index[shard] = req
+		batch.reqs = append(batch.reqs, req)
+	}
+	full := len(batch.reqs) >= b.batchSize
+	if full {
+		delete(b.batches, key)
+		batch.timer.Stop()
+	}
+	b.mu.Unlock()
+
+	if full {
+		go b.send(batch)
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *shardDeleteBatcher) flush(batch *shardBatch) {
+	b.mu.Lock()
+	if b.batches[batch.key] != batch { // has been sent as full
+		b.mu.Unlock()
+		return
+	}
+	delete(b.batches, batch.key)
+	b.mu.Unlock()
+
+	b.send(batch)
+}
+
+func (b *shardDeleteBatcher) send(batch *shardBatch) {
+	span, ctx := trace.StartSpanFromContext(context.Background(), "BatchDeleteShards")
+
+	shards := make([]api.ShardID, len(batch.reqs))
+	for i, req := range batch.reqs {
+		shards[i] = req.shard
+	}
+
+	var (
+		errs []error
+		err  error
+	)
+	if batch.key.markDelete {
+		errs, err = b.blobnodeCli.BatchMarkDelete(ctx, batch.key.host, batch.key.diskID, shards)
+	} else {
+		errs, err = b.blobnodeCli.BatchDelete(ctx, batch.key.host, batch.key.diskID, shards)
+	}
+	if err != nil {
+		span.Warnf("batch delete shards failed: host[%s], disk_id[%d], markDelete[%v], shards[%d], err[%+v]",
+			batch.key.host, batch.key.diskID, batch.key.markDelete, len(shards), err)
+	}
+
+	for i, req := range batch.reqs {
+		shardErr := err
+		if shardErr == nil {
+			shardErr = errs[i]
+		}
+		for _, done := range req.dones {
+			done <- shardErr
+		}
+	}
+}
+
 func (mgr *BlobDeleteMgr) send2FailQueue(ctx context.Context, msg *proto.DeleteMsg) error {
 	span := trace.SpanFromContextSafe(ctx)
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
 
+	api "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	"github.com/cubefs/cubefs/blobstore/common/counter"
 	bloberr "github.com/cubefs/cubefs/blobstore/common/errors"
@@ -82,6 +84,7 @@ func newBlobDeleteMgr(t *testing.T) *BlobDeleteMgr {
 
 		delSuccessCounter:    base.NewCounter(1, "delete", base.KindSuccess),
 		delFailCounter:       base.NewCounter(1, "delete", base.KindFailed),
+		delProtectedCounter:  base.NewCounter(1, "delete", base.KindProtected),
 		errStatsDistribution: base.NewErrorStats(),
 		delLogger:            delLogger,
 		deleteLimiter:        rate.NewLimiter(10, 10),
@@ -561,6 +564,36 @@ func TestBlobDeleteConsume(t *testing.T) {
 		mgr.blobnodeCli = oldBlobNode
 		mgr.slowDownTime = oldSlowDownTime
 	}
+	{
+		// shards in worm retention, not sent to fail queue
+		oldClusterTopology := mgr.clusterTopology
+		clusterTopology := NewMockClusterTopology(ctr)
+		clusterTopology.EXPECT().GetVolume(any).AnyTimes().DoAndReturn(
+			func(vid proto.Vid) (*client.VolumeInfoSimple, error) {
+				return &client.VolumeInfoSimple{Vid: vid, VunitLocations: []proto.VunitLocation{{Vuid: 1}}}, nil
+			},
+		)
+		clusterTopology.EXPECT().IsBrokenDisk(any).AnyTimes().Return(false)
+		mgr.clusterTopology = clusterTopology
+
+		oldBlobNode := mgr.blobnodeCli
+		blobnodeCli := NewMockBlobnodeAPI(ctr)
+		blobnodeCli.EXPECT().MarkDelete(any, any, any).AnyTimes().Return(bloberr.ErrShardWormProtected)
+		mgr.blobnodeCli = blobnodeCli
+		oldSender := mgr.failMsgSender
+		mgr.failMsgSender = NewMockProducer(ctr)
+
+		msg := &proto.DeleteMsg{Bid: 2, Vid: 2, ReqId: "wormProtected"}
+		ret := delBlobRet{delMsg: msg, ctx: ctx}
+		mgr.consume(&ret, commonCloser)
+		require.Equal(t, DeleteStatusProtected, ret.status)
+		require.ErrorIs(t, ret.err, bloberr.ErrShardWormProtected)
+		mgr.recordAllResult([]delBlobRet{ret})
+
+		mgr.clusterTopology = oldClusterTopology
+		mgr.blobnodeCli = oldBlobNode
+		mgr.failMsgSender = oldSender
+	}
 }
 
 // comment temporary
@@ -771,3 +804,74 @@ func TestDeleteBlob(t *testing.T) {
 		require.True(t, doneVolume.EqualWith(newVolume))
 	}
 }
+
+func TestBlobDeleteShardBatcher(t *testing.T) {
+	ctr := gomock.NewController(t)
+	ctx := context.Background()
+	location := func(vuid proto.Vuid) proto.VunitLocation {
+		return proto.VunitLocation{Vuid: vuid, Host: "host", DiskID: 1}
+	}
+	doAll := func(b *shardDeleteBatcher, markDelete bool, vuids ...proto.Vuid) []error {
+		errs := make([]error, len(vuids))
+		var wg sync.WaitGroup
+		wg.Add(len(vuids))
+		for i := range vuids {
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = b.do(ctx, location(vuids[i]), proto.BlobID(1), markDelete)
+			}(i)
+		}
+		wg.Wait()
+		return errs
+	}
+	{
+		// duplicated shard is requested once, batch is sent after waiting
+		blobnodeCli := NewMockBlobnodeAPI(ctr)
+		blobnodeCli.EXPECT().BatchMarkDelete(any, "host", proto.DiskID(1), any).Times(1).DoAndReturn(
+			func(_ context.Context, _ string, _ proto.DiskID, shards []api.ShardID) ([]error, error) {
+				require.Len(t, shards, 2)
+				errs := make([]error, len(shards))
+				for i, shard := range shards {
+					if shard.Vuid == 2 {
+						errs[i] = errcode.ErrOverload
+					}
+				}
+				return errs, nil
+			})
+		b := newShardDeleteBatcher(blobnodeCli, 3, 10*time.Millisecond)
+		errs := doAll(b, true, 1, 1, 2)
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+		require.ErrorIs(t, errs[2], errcode.ErrOverload)
+	}
+	{
+		// full batch is sent at once, request error is returned to all shards
+		blobnodeCli := NewMockBlobnodeAPI(ctr)
+		blobnodeCli.EXPECT().BatchDelete(any, any, any, any).Times(1).Return(nil, errMock)
+		b := newShardDeleteBatcher(blobnodeCli, 2, time.Hour)
+		for _, err := range doAll(b, false, 1, 2) {
+			require.ErrorIs(t, err, errMock)
+		}
+	}
+	{
+		// delete blob with batcher
+		blobnodeCli := NewMockBlobnodeAPI(ctr)
+		batchOk := func(_ context.Context, _ string, _ proto.DiskID, shards []api.ShardID) ([]error, error) {
+			return make([]error, len(shards)), nil
+		}
+		blobnodeCli.EXPECT().BatchMarkDelete(any, any, any, any).Times(6).DoAndReturn(batchOk)
+		blobnodeCli.EXPECT().BatchDelete(any, any, any, any).Times(6).DoAndReturn(batchOk)
+		mgr := newBlobDeleteMgr(t)
+		mgr.blobnodeCli = blobnodeCli
+		mgr.shardBatcher = newShardDeleteBatcher(blobnodeCli, defaultShardBatchSize, time.Millisecond)
+
+		volume := MockGenVolInfo(proto.Vid(1), codemode.EC3P3, proto.VolumeStatusActive)
+		msg := &proto.DeleteMsg{Bid: proto.BlobID(1)}
+		_, err := mgr.deleteBlob(ctx, volume, msg)
+		require.NoError(t, err)
+		require.Len(t, msg.BlobDelStages.Stages, 6)
+		for _, stage := range msg.BlobDelStages.Stages {
+			require.Equal(t, proto.DeleteStageDelete, stage)
+		}
+	}
+}
@@ -16,8 +16,12 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"io"
 
 	api "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 )
 
@@ -25,7 +29,13 @@ import (
 type BlobnodeAPI interface {
 	MarkDelete(ctx context.Context, location proto.VunitLocation, bid proto.BlobID) error
 	Delete(ctx context.Context, location proto.VunitLocation, bid proto.BlobID) error
+	BatchMarkDelete(ctx context.Context, host string, diskID proto.DiskID, shards []api.ShardID) ([]error, error)
+	BatchDelete(ctx context.Context, host string, diskID proto.DiskID, shards []api.ShardID) ([]error, error)
 	RepairShard(ctx context.Context, host string, task proto.ShardRepairTask) error
+	ListChunks(ctx context.Context, host string, diskID proto.DiskID) ([]*cmapi.ChunkInfo, error)
+	StatShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID) (*api.ShardInfo, error)
+	ListShards(ctx context.Context, location proto.VunitLocation) ([]*api.ShardInfo, error)
+	VerifyShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID) error
 }
 
 type blobnodeClient struct {
@@ -58,3 +68,88 @@ func (c *blobnodeClient) Delete(ctx context.Context, location proto.VunitLocatio
 		Bid:    bid,
 	})
 }
+
+// BatchMarkDelete mark delete shards of the disk in one request, returns errors of shards in order
+func (c *blobnodeClient) BatchMarkDelete(ctx context.Context, host string, diskID proto.DiskID, shards []api.ShardID) ([]error, error) {
+	ret, err := c.client.BatchMarkDeleteShards(ctx, host, &api.BatchDeleteShardsArgs{DiskID: diskID, Shards: shards})
+	if err != nil {
+		return nil, err
+	}
+	return shardErrors(ret, len(shards))
+}
+
+// BatchDelete delete shards of the disk in one request, returns errors of shards in order
+func (c *blobnodeClient) BatchDelete(ctx context.Context, host string, diskID proto.DiskID, shards []api.ShardID) ([]error, error) {
+	ret, err := c.client.BatchDeleteShards(ctx, host, &api.BatchDeleteShardsArgs{DiskID: diskID, Shards: shards})
+	if err != nil {
+		return nil, err
+	}
+	return shardErrors(ret, len(shards))
+}
+
+func shardErrors(ret *api.BatchDeleteShardsRet, n int) ([]error, error) {
+	if len(ret.Results) != n {
+		return nil, fmt.Errorf("mismatched batch results: expected %d, got %d", n, len(ret.Results))
+	}
+	errs := make([]error, n)
+	for i := range ret.Results {
+		errs[i] = ret.Results[i].Err()
+	}
+	return errs, nil
+}
+
+// ListChunks returns chunks on the disk
+func (c *blobnodeClient) ListChunks(ctx context.Context, host string, diskID proto.DiskID) ([]*cmapi.ChunkInfo, error) {
+	return c.client.ListChunks(ctx, host, &api.ListChunkArgs{DiskID: diskID})
+}
+
+// StatShard returns shard info of the blob on volume unit
+func (c *blobnodeClient) StatShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID) (*api.ShardInfo, error) {
+	return c.client.StatShard(ctx, location.Host, &api.StatShardArgs{
+		DiskID: location.DiskID,
+		Vuid:   location.Vuid,
+		Bid:    bid,
+	})
+}
+
+// ListShards returns all shards on volume unit
+func (c *blobnodeClient) ListShards(ctx context.Context, location proto.VunitLocation) ([]*api.ShardInfo, error) {
+	var (
+		shards   []*api.ShardInfo
+		startBid = proto.BlobID(0)
+	)
+	for {
+		infos, next, err := c.client.ListShards(ctx, location.Host, &api.ListShardsArgs{
+			DiskID:   location.DiskID,
+			Vuid:     location.Vuid,
+			StartBid: startBid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		shards = append(shards, infos...)
+		if next == proto.BlobID(0) {
+			return shards, nil
+		}
+		startBid = next
+	}
+}
+
+// VerifyShard reads the whole shard, the data is checked by crc on blobnode,
+// which aborts the response body if crc mismatched.
+func (c *blobnodeClient) VerifyShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID) error {
+	body, _, err := c.client.GetShard(ctx, location.Host, &api.GetShardArgs{
+		DiskID: location.DiskID,
+		Vuid:   location.Vuid,
+		Bid:    bid,
+		Type:   api.BackgroundIO,
+	})
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if _, err = io.Copy(io.Discard, body); err != nil {
+		return fmt.Errorf("%w: %s", errcode.ErrShardCrcMismatch, err.Error())
+	}
+	return nil
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	api "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/testing/mocks"
 )
 
@@ -42,3 +44,31 @@ func TestBlobnode(t *testing.T) {
 	err = cli.Delete(ctx, proto.VunitLocation{}, proto.BlobID(1))
 	require.NoError(t, err)
 }
+
+func TestBlobnodeBatchDelete(t *testing.T) {
+	any := gomock.Any()
+	ctx := context.Background()
+
+	cli := NewBlobnodeClient(&api.Config{}).(*blobnodeClient)
+	client := mocks.NewMockStorageAPI(gomock.NewController(t))
+	cli.client = client
+	shards := []api.ShardID{{Vuid: 1, Bid: 1}, {Vuid: 2, Bid: 1}}
+
+	client.EXPECT().BatchMarkDeleteShards(any, any, any).Return(&api.BatchDeleteShardsRet{
+		Results: []api.DeleteShardResult{{ShardID: shards[0]}, {ShardID: shards[1], Code: errcode.CodeOverload}},
+	}, nil)
+	errs, err := cli.BatchMarkDelete(ctx, "host", 1, shards)
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	require.Equal(t, errcode.CodeOverload, rpc.DetectStatusCode(errs[1]))
+
+	client.EXPECT().BatchDeleteShards(any, any, any).Return(&api.BatchDeleteShardsRet{
+		Results: []api.DeleteShardResult{{ShardID: shards[0]}},
+	}, nil)
+	_, err = cli.BatchDelete(ctx, "host", 1, shards)
+	require.Error(t, err)
+
+	client.EXPECT().BatchDeleteShards(any, any, any).Return(nil, errcode.ErrInvalidParam)
+	_, err = cli.BatchDelete(ctx, "host", 1, shards)
+	require.ErrorIs(t, err, errcode.ErrInvalidParam)
+}
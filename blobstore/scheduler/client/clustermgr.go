@@ -26,6 +26,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -96,8 +97,13 @@ type ClusterMgrTaskAPI interface {
 	ListMigratingDisks(ctx context.Context, taskType proto.TaskType) (disks []*MigratingDiskMeta, err error)
 	GetVolumeInspectCheckPoint(ctx context.Context) (ck *proto.VolumeInspectCheckPoint, err error)
 	SetVolumeInspectCheckPoint(ctx context.Context, startVid proto.Vid) (err error)
+	GetClusterMigrateProgress(ctx context.Context) (progress *api.ClusterMigrateProgress, err error)
+	SetClusterMigrateProgress(ctx context.Context, progress *api.ClusterMigrateProgress) (err error)
 	GetConsumeOffset(taskType proto.TaskType, topic string, partition int32) (offset int64, err error)
 	SetConsumeOffset(taskType proto.TaskType, topic string, partition int32, offset int64) (err error)
+	AddTaskRecord(ctx context.Context, record *api.TaskRecord) (err error)
+	ListTaskRecords(ctx context.Context, args *cmapi.ListKvOpts) (records []*api.TaskRecord, marker string, err error)
+	DeleteTaskRecord(ctx context.Context, record *api.TaskRecord) (err error)
 }
 
 // ClusterMgrAPI define the interface of clustermgr used by scheduler
@@ -136,6 +142,13 @@ type ClusterMgrAPI interface {
 //  for example:
 //		volume_inspect-checkpoint
 //
+// task record key
+//  - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+//  | _taskRecordPrefix | source_disk_id | finish_time | task_id |
+//  - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+//	for example:
+//		task_record-6-0001700000000000000000-disk_repair-6-1-cbkgq9qc605btusi7gf0
+//
 // kafka consume offset key
 //  - - - - - - - - - - - - - - - - - - - - - - - - - - - -
 //  | {task_type} | _consumeOffset | {topic} | {partition} |
@@ -149,6 +162,7 @@ const (
 	_migratingDiskPrefix = "migrating"
 	_checkPoint          = "checkpoint"
 	_consumeOffset       = "consume_offset"
+	_taskRecordPrefix    = "task_record"
 )
 
 var (
@@ -203,10 +217,27 @@ func genVolumeInspectCheckpointKey() string {
 	return proto.TaskTypeVolumeInspect.String() + _delimiter + _checkPoint
 }
 
+func genClusterMigrateCheckpointKey() string {
+	return proto.TaskTypeClusterMigrate.String() + _delimiter + _checkPoint
+}
+
 func genConsumerOffsetKey(taskType proto.TaskType, topic string, partition int32) string {
 	return fmt.Sprintf("%s%s%s%s%s%s%d", taskType, _delimiter, _consumeOffset, _delimiter, topic, _delimiter, partition)
 }
 
+// GenTaskRecordPrefix returns prefix of task records, records of all disks if diskID is invalid
+func GenTaskRecordPrefix(diskID proto.DiskID) string {
+	if diskID == proto.InvalidDiskID {
+		return _taskRecordPrefix + _delimiter
+	}
+	return fmt.Sprintf("%s%s%d%s", _taskRecordPrefix, _delimiter, diskID, _delimiter)
+}
+
+func genTaskRecordKey(record *api.TaskRecord) string {
+	return fmt.Sprintf("%s%022d%s%s", GenTaskRecordPrefix(record.SourceDiskID),
+		record.FinishTime.UnixNano(), _delimiter, record.TaskID)
+}
+
 // VolumeInfoSimple volume info used by scheduler
 type VolumeInfoSimple struct {
 	Vid            proto.Vid             `json:"vid"`
@@ -320,6 +351,8 @@ type DiskInfoSimple struct {
 	UsedChunkCnt int64            `json:"used_chunk_cnt"`
 	MaxChunkCnt  int64            `json:"max_chunk_cnt"`
 	FreeChunkCnt int64            `json:"free_chunk_cnt"`
+	DiskSetID    proto.DiskSetID  `json:"disk_set_id"`
+	NodeID       proto.NodeID     `json:"node_id"`
 }
 
 // IsHealth return true if disk is health
@@ -365,6 +398,8 @@ func (disk *DiskInfoSimple) set(info *clustermgr.BlobNodeDiskInfo) {
 	disk.UsedChunkCnt = info.UsedChunkCnt
 	disk.MaxChunkCnt = info.MaxChunkCnt
 	disk.FreeChunkCnt = info.FreeChunkCnt
+	disk.DiskSetID = info.DiskSetID
+	disk.NodeID = info.NodeID
 }
 
 // ShardNodeDiskInfo diskInfo for shard node
@@ -1061,6 +1096,24 @@ func (c *clustermgrClient) SetVolumeInspectCheckPoint(ctx context.Context, start
 	return c.client.SetKV(ctx, genVolumeInspectCheckpointKey(), checkPointBytes)
 }
 
+func (c *clustermgrClient) GetClusterMigrateProgress(ctx context.Context) (progress *api.ClusterMigrateProgress, err error) {
+	ret, err := c.client.GetKV(ctx, genClusterMigrateCheckpointKey())
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(ret.Value, &progress)
+	return
+}
+
+func (c *clustermgrClient) SetClusterMigrateProgress(ctx context.Context, progress *api.ClusterMigrateProgress) (err error) {
+	progress.Mtime = time.Now().String()
+	progressBytes, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return c.client.SetKV(ctx, genClusterMigrateCheckpointKey(), progressBytes)
+}
+
 func (c *clustermgrClient) GetConsumeOffset(taskType proto.TaskType, topic string, partition int32) (offset int64, err error) {
 	ret, err := c.client.GetKV(context.Background(), genConsumerOffsetKey(taskType, topic, partition))
 	if err != nil {
@@ -1084,6 +1137,40 @@ func (c *clustermgrClient) SetConsumeOffset(taskType proto.TaskType, topic strin
 	return c.client.SetKV(context.Background(), genConsumerOffsetKey(taskType, topic, partition), consumeOffsetBytes)
 }
 
+// AddTaskRecord adds record of finished task
+func (c *clustermgrClient) AddTaskRecord(ctx context.Context, record *api.TaskRecord) (err error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return c.setKV(ctx, genTaskRecordKey(record), data)
+}
+
+// ListTaskRecords returns records of finished task base on page size
+func (c *clustermgrClient) ListTaskRecords(ctx context.Context, args *cmapi.ListKvOpts) (records []*api.TaskRecord, marker string, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+	ret, err := c.client.ListKV(ctx, args)
+	if err != nil {
+		span.Errorf("list task record failed: err[%+v]", err)
+		return nil, marker, err
+	}
+	for _, v := range ret.Kvs {
+		var record *api.TaskRecord
+		if err = json.Unmarshal(v.Value, &record); err != nil {
+			span.Errorf("unmarshal task record failed: key[%s], err[%+v]", v.Key, err)
+			return nil, marker, err
+		}
+		records = append(records, record)
+	}
+	marker = ret.Marker
+	return
+}
+
+// DeleteTaskRecord deletes record of finished task
+func (c *clustermgrClient) DeleteTaskRecord(ctx context.Context, record *api.TaskRecord) (err error) {
+	return c.client.DeleteKV(ctx, genTaskRecordKey(record))
+}
+
 // ShardInfoSimple shard info used by scheduler
 type ShardInfoSimple struct {
 	ShardID        proto.ShardID               `json:"shard_id"`
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
@@ -461,6 +462,32 @@ func TestClustermgrClient(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, checkpoint.StartVid, checkpoint2.StartVid)
 	}
+	{
+		// set cluster migrate progress
+		progress := &api.ClusterMigrateProgress{
+			Marker:   []byte("marker"),
+			Migrated: 10,
+			Volumes:  []api.ClusterMigrateVolumeProgress{{Vid: 1, Migrated: 2, Bytes: 1024}},
+		}
+		cli.client.(*MockClusterManager).EXPECT().SetKV(any, any, any).DoAndReturn(
+			func(_ context.Context, key string, value []byte) error {
+				require.Equal(t, "cluster_migrate-checkpoint", key)
+				return nil
+			})
+		require.NoError(t, cli.SetClusterMigrateProgress(ctx, progress))
+		require.NotEmpty(t, progress.Mtime)
+
+		// get cluster migrate progress
+		progressBytes, _ := json.Marshal(progress)
+		cli.client.(*MockClusterManager).EXPECT().GetKV(any, any).Return(cmapi.GetKvRet{Value: progressBytes}, nil)
+		progress2, err := cli.GetClusterMigrateProgress(ctx)
+		require.NoError(t, err)
+		require.Equal(t, progress, progress2)
+
+		cli.client.(*MockClusterManager).EXPECT().GetKV(any, any).Return(cmapi.GetKvRet{}, errMock)
+		_, err = cli.GetClusterMigrateProgress(ctx)
+		require.True(t, errors.Is(err, errMock))
+	}
 	{
 		// set consume offset
 		topic := "test"
@@ -16,6 +16,7 @@ package client
 
 import (
 	"context"
+	"time"
 
 	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	api "github.com/cubefs/cubefs/blobstore/api/proxy"
@@ -26,6 +27,7 @@ import (
 // ProxyAPI define the interface of proxy used by scheduler
 type ProxyAPI interface {
 	SendShardRepairMsg(ctx context.Context, vid proto.Vid, bid proto.BlobID, badIdx []uint8) error
+	SendBlobRepairEvent(ctx context.Context, vid proto.Vid, bid proto.BlobID) error
 }
 
 // proxyClient proxy client
@@ -56,3 +58,23 @@ func (c *proxyClient) SendShardRepairMsg(ctx context.Context, vid proto.Vid, bid
 	span.Debugf("send shard repair msg ret err %+v", err)
 	return err
 }
+
+// SendBlobRepairEvent send repair completed event of blob
+func (c *proxyClient) SendBlobRepairEvent(ctx context.Context, vid proto.Vid, bid proto.BlobID) error {
+	pSpan := trace.SpanFromContextSafe(ctx)
+	span, ctx := trace.StartSpanFromContextWithTraceID(context.Background(), "SendBlobRepairEvent", pSpan.TraceID())
+	span.Debugf("send blob repair event vid %d bid %d", vid, bid)
+
+	err := c.client.SendBlobEvent(ctx, &api.BlobEventArgs{
+		ClusterID: c.clusterID,
+		Events: []api.BlobEvent{{
+			Type: api.BlobEventRepair,
+			Vid:  vid,
+			Bid:  bid,
+			Time: time.Now().Unix(),
+		}},
+	})
+
+	span.Debugf("send blob repair event ret err %+v", err)
+	return err
+}
@@ -8,7 +8,9 @@ import (
 	context "context"
 	reflect "reflect"
 
+	blobnode "github.com/cubefs/cubefs/blobstore/api/blobnode"
 	clustermgr "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	scheduler "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	proto "github.com/cubefs/cubefs/blobstore/common/proto"
 	client "github.com/cubefs/cubefs/blobstore/scheduler/client"
 	gomock "github.com/golang/mock/gomock"
@@ -65,6 +67,20 @@ func (mr *MockClusterMgrAPIMockRecorder) AddMigratingDisk(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMigratingDisk", reflect.TypeOf((*MockClusterMgrAPI)(nil).AddMigratingDisk), arg0, arg1)
 }
 
+// AddTaskRecord mocks base method.
+func (m *MockClusterMgrAPI) AddTaskRecord(arg0 context.Context, arg1 *scheduler.TaskRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskRecord indicates an expected call of AddTaskRecord.
+func (mr *MockClusterMgrAPIMockRecorder) AddTaskRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskRecord", reflect.TypeOf((*MockClusterMgrAPI)(nil).AddTaskRecord), arg0, arg1)
+}
+
 // AllocShardUnit mocks base method.
 func (m *MockClusterMgrAPI) AllocShardUnit(arg0 context.Context, arg1 proto.Suid, arg2 []proto.DiskID) (*client.AllocShardUnitInfo, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +139,35 @@ func (mr *MockClusterMgrAPIMockRecorder) DeleteMigratingDisk(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMigratingDisk", reflect.TypeOf((*MockClusterMgrAPI)(nil).DeleteMigratingDisk), arg0, arg1, arg2)
 }
 
+// DeleteTaskRecord mocks base method.
+func (m *MockClusterMgrAPI) DeleteTaskRecord(arg0 context.Context, arg1 *scheduler.TaskRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskRecord indicates an expected call of DeleteTaskRecord.
+func (mr *MockClusterMgrAPIMockRecorder) DeleteTaskRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskRecord", reflect.TypeOf((*MockClusterMgrAPI)(nil).DeleteTaskRecord), arg0, arg1)
+}
+
+// GetClusterMigrateProgress mocks base method.
+func (m *MockClusterMgrAPI) GetClusterMigrateProgress(arg0 context.Context) (*scheduler.ClusterMigrateProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterMigrateProgress", arg0)
+	ret0, _ := ret[0].(*scheduler.ClusterMigrateProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusterMigrateProgress indicates an expected call of GetClusterMigrateProgress.
+func (mr *MockClusterMgrAPIMockRecorder) GetClusterMigrateProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterMigrateProgress", reflect.TypeOf((*MockClusterMgrAPI)(nil).GetClusterMigrateProgress), arg0)
+}
+
 // GetConfig mocks base method.
 func (m *MockClusterMgrAPI) GetConfig(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -485,6 +530,22 @@ func (mr *MockClusterMgrAPIMockRecorder) ListShardDisk(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShardDisk", reflect.TypeOf((*MockClusterMgrAPI)(nil).ListShardDisk), arg0)
 }
 
+// ListTaskRecords mocks base method.
+func (m *MockClusterMgrAPI) ListTaskRecords(arg0 context.Context, arg1 *clustermgr.ListKvOpts) ([]*scheduler.TaskRecord, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskRecords", arg0, arg1)
+	ret0, _ := ret[0].([]*scheduler.TaskRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTaskRecords indicates an expected call of ListTaskRecords.
+func (mr *MockClusterMgrAPIMockRecorder) ListTaskRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskRecords", reflect.TypeOf((*MockClusterMgrAPI)(nil).ListTaskRecords), arg0, arg1)
+}
+
 // ListVolume mocks base method.
 func (m *MockClusterMgrAPI) ListVolume(arg0 context.Context, arg1 proto.Vid, arg2 int) ([]*client.VolumeInfoSimple, proto.Vid, error) {
 	m.ctrl.T.Helper()
@@ -543,6 +604,20 @@ func (mr *MockClusterMgrAPIMockRecorder) ReleaseVolumeUnit(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseVolumeUnit", reflect.TypeOf((*MockClusterMgrAPI)(nil).ReleaseVolumeUnit), arg0, arg1, arg2)
 }
 
+// SetClusterMigrateProgress mocks base method.
+func (m *MockClusterMgrAPI) SetClusterMigrateProgress(arg0 context.Context, arg1 *scheduler.ClusterMigrateProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClusterMigrateProgress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClusterMigrateProgress indicates an expected call of SetClusterMigrateProgress.
+func (mr *MockClusterMgrAPIMockRecorder) SetClusterMigrateProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClusterMigrateProgress", reflect.TypeOf((*MockClusterMgrAPI)(nil).SetClusterMigrateProgress), arg0, arg1)
+}
+
 // SetConfig mocks base method.
 func (m *MockClusterMgrAPI) SetConfig(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -734,6 +809,36 @@ func (m *MockBlobnodeAPI) EXPECT() *MockBlobnodeAPIMockRecorder {
 	return m.recorder
 }
 
+// BatchDelete mocks base method.
+func (m *MockBlobnodeAPI) BatchDelete(arg0 context.Context, arg1 string, arg2 proto.DiskID, arg3 []blobnode.ShardID) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockBlobnodeAPIMockRecorder) BatchDelete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockBlobnodeAPI)(nil).BatchDelete), arg0, arg1, arg2, arg3)
+}
+
+// BatchMarkDelete mocks base method.
+func (m *MockBlobnodeAPI) BatchMarkDelete(arg0 context.Context, arg1 string, arg2 proto.DiskID, arg3 []blobnode.ShardID) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchMarkDelete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchMarkDelete indicates an expected call of BatchMarkDelete.
+func (mr *MockBlobnodeAPIMockRecorder) BatchMarkDelete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchMarkDelete", reflect.TypeOf((*MockBlobnodeAPI)(nil).BatchMarkDelete), arg0, arg1, arg2, arg3)
+}
+
 // Delete mocks base method.
 func (m *MockBlobnodeAPI) Delete(arg0 context.Context, arg1 proto.VunitLocation, arg2 proto.BlobID) error {
 	m.ctrl.T.Helper()
@@ -748,6 +853,36 @@ func (mr *MockBlobnodeAPIMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobnodeAPI)(nil).Delete), arg0, arg1, arg2)
 }
 
+// ListChunks mocks base method.
+func (m *MockBlobnodeAPI) ListChunks(arg0 context.Context, arg1 string, arg2 proto.DiskID) ([]*clustermgr.ChunkInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChunks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*clustermgr.ChunkInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChunks indicates an expected call of ListChunks.
+func (mr *MockBlobnodeAPIMockRecorder) ListChunks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChunks", reflect.TypeOf((*MockBlobnodeAPI)(nil).ListChunks), arg0, arg1, arg2)
+}
+
+// ListShards mocks base method.
+func (m *MockBlobnodeAPI) ListShards(arg0 context.Context, arg1 proto.VunitLocation) ([]*blobnode.ShardInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShards", arg0, arg1)
+	ret0, _ := ret[0].([]*blobnode.ShardInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShards indicates an expected call of ListShards.
+func (mr *MockBlobnodeAPIMockRecorder) ListShards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShards", reflect.TypeOf((*MockBlobnodeAPI)(nil).ListShards), arg0, arg1)
+}
+
 // MarkDelete mocks base method.
 func (m *MockBlobnodeAPI) MarkDelete(arg0 context.Context, arg1 proto.VunitLocation, arg2 proto.BlobID) error {
 	m.ctrl.T.Helper()
@@ -776,6 +911,35 @@ func (mr *MockBlobnodeAPIMockRecorder) RepairShard(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairShard", reflect.TypeOf((*MockBlobnodeAPI)(nil).RepairShard), arg0, arg1, arg2)
 }
 
+// StatShard mocks base method.
+func (m *MockBlobnodeAPI) StatShard(arg0 context.Context, arg1 proto.VunitLocation, arg2 proto.BlobID) (*blobnode.ShardInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatShard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*blobnode.ShardInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatShard indicates an expected call of StatShard.
+func (mr *MockBlobnodeAPIMockRecorder) StatShard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatShard", reflect.TypeOf((*MockBlobnodeAPI)(nil).StatShard), arg0, arg1, arg2)
+}
+
+// VerifyShard mocks base method.
+func (m *MockBlobnodeAPI) VerifyShard(arg0 context.Context, arg1 proto.VunitLocation, arg2 proto.BlobID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyShard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyShard indicates an expected call of VerifyShard.
+func (mr *MockBlobnodeAPIMockRecorder) VerifyShard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyShard", reflect.TypeOf((*MockBlobnodeAPI)(nil).VerifyShard), arg0, arg1, arg2)
+}
+
 // MockVolumeUpdater is a mock of IVolumeUpdater interface.
 type MockVolumeUpdater struct {
 	ctrl     *gomock.Controller
@@ -850,6 +1014,20 @@ func (m *MockMqProxyAPI) EXPECT() *MockMqProxyAPIMockRecorder {
 	return m.recorder
 }
 
+// SendBlobRepairEvent mocks base method.
+func (m *MockMqProxyAPI) SendBlobRepairEvent(arg0 context.Context, arg1 proto.Vid, arg2 proto.BlobID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBlobRepairEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBlobRepairEvent indicates an expected call of SendBlobRepairEvent.
+func (mr *MockMqProxyAPIMockRecorder) SendBlobRepairEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBlobRepairEvent", reflect.TypeOf((*MockMqProxyAPI)(nil).SendBlobRepairEvent), arg0, arg1, arg2)
+}
+
 // SendShardRepairMsg mocks base method.
 func (m *MockMqProxyAPI) SendShardRepairMsg(arg0 context.Context, arg1 proto.Vid, arg2 proto.BlobID, arg3 []byte) error {
 	m.ctrl.T.Helper()
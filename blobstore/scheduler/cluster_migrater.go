@@ -0,0 +1,664 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"hash/crc32"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
+	"github.com/cubefs/cubefs/blobstore/common/taskswitch"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/scheduler/client"
+	"github.com/cubefs/cubefs/blobstore/sdk"
+	"github.com/cubefs/cubefs/blobstore/util/closer"
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
+	"github.com/cubefs/cubefs/blobstore/util/retry"
+	"github.com/cubefs/cubefs/blobstore/util/taskpool"
+)
+
+const (
+	defaultClusterMigrateListCount       = 100
+	defaultClusterMigrateConcurrency     = 10
+	defaultClusterMigrateMaxRetry        = 3
+	defaultClusterMigrateIntervalS       = 10
+	defaultClusterMigrateCheckpointIntvS = 30
+	defaultClusterMigrateMaxFailedBlobs  = 1000
+)
+
+var (
+	errBlobMigrated        = errors.New("blob already in destination")
+	errBlobMismatched      = errors.New("blob in destination mismatched")
+	errTooManyFailedBlobs  = errors.New("too many failed blobs")
+	errFailedBlobsRemained = errors.New("failed blobs remained after all listed")
+)
+
+// clusterMigrateSource lists and reads blobs of this cluster
+type clusterMigrateSource interface {
+	ListBlob(ctx context.Context, args *acapi.ListBlobArgs) (shardnode.ListBlobRet, error)
+	Get(ctx context.Context, args *acapi.GetArgs) (io.ReadCloser, error)
+	GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (io.ReadCloser, error)
+}
+
+// clusterMigrateDestination writes blobs into the destination cluster
+type clusterMigrateDestination interface {
+	PutBlob(ctx context.Context, args *acapi.PutBlobArgs) (proto.ClusterID, acapi.HashSumMap, error)
+	GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (io.ReadCloser, error)
+}
+
+// ClusterMigrateConfig config of copying blobs of this cluster into another cluster,
+// the blobs are listed and read by access of this cluster, and written by access of
+// destination cluster with the same name. the compressed blobs are copied with the
+// encoded payload, so Destination should be configured with the compress dictionaries
+// of the source spaces to read them.
+type ClusterMigrateConfig struct {
+	Enable    bool            `json:"enable"`
+	ClusterID proto.ClusterID `json:"-"`
+
+	Source      sdk.Config `json:"source"`
+	Destination sdk.Config `json:"destination"`
+	// DestinationClusterID is cluster of the blobs written into, the blob already
+	// in it is verified with the source blob before treated as migrated
+	DestinationClusterID proto.ClusterID `json:"destination_cluster_id"`
+
+	// code mode of blobs in destination cluster, keeps code mode of the source blob if not set
+	CodeMode codemode.CodeMode `json:"code_mode"`
+	// only migrates the blobs located in these volumes and tracks progress of each volume,
+	// migrates all blobs if not set. the volumes are listed one by one, blobs are filtered
+	// by location in shardnode, and a blob located in many volumes is migrated with the first.
+	Vids []proto.Vid `json:"vids"`
+
+	ListCount   int `json:"list_count"`
+	Concurrency int `json:"concurrency"`
+	MaxRetry    int `json:"max_retry"`
+	// MaxFailedBlobs stops listing new blobs until the failed blobs retried under it
+	MaxFailedBlobs int `json:"max_failed_blobs"`
+	// throttling of migration, no limit if not set
+	BlobsPerSecond int `json:"blobs_per_second"`
+	BytesPerSecond int `json:"bytes_per_second"`
+
+	IntervalS           int `json:"interval_s"`
+	CheckpointIntervalS int `json:"checkpoint_interval_s"`
+}
+
+// CheckAndFix check and fix cluster migrate config
+func (cfg *ClusterMigrateConfig) CheckAndFix() {
+	defaulter.LessOrEqual(&cfg.ListCount, defaultClusterMigrateListCount)
+	defaulter.LessOrEqual(&cfg.Concurrency, defaultClusterMigrateConcurrency)
+	defaulter.LessOrEqual(&cfg.MaxRetry, defaultClusterMigrateMaxRetry)
+	defaulter.LessOrEqual(&cfg.MaxFailedBlobs, defaultClusterMigrateMaxFailedBlobs)
+	defaulter.LessOrEqual(&cfg.IntervalS, defaultClusterMigrateIntervalS)
+	defaulter.LessOrEqual(&cfg.CheckpointIntervalS, defaultClusterMigrateCheckpointIntvS)
+}
+
+func newLimiter(perSecond int) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), perSecond)
+}
+
+type clusterMigrateResult uint8
+
+const (
+	clusterMigrateIgnored clusterMigrateResult = iota
+	clusterMigrateMigrated
+	clusterMigrateExisted
+	clusterMigrateUnsealed
+	clusterMigrateEmpty
+	clusterMigrateFailed
+)
+
+// ClusterMigrateMgr copies blobs of this cluster into the destination cluster,
+// blobs are listed in batch from the checkpointed marker and copied concurrently,
+// the marker is checkpointed after all blobs of former batches are copied.
+// the failed blobs are checkpointed with the marker, and retried before listing
+// the next batch, listing is paused if too many blobs failed.
+type ClusterMigrateMgr struct {
+	closer.Closer
+
+	taskSwitch    taskswitch.ISwitcher
+	clusterMgrCli client.ClusterMgrAPI
+	source        clusterMigrateSource
+	destination   clusterMigrateDestination
+	taskPool      taskpool.TaskPool
+	blobLimiter   *rate.Limiter
+	bytesLimiter  *rate.Limiter
+
+	loaded         bool
+	checkpointTime time.Time
+
+	mu       sync.Mutex
+	progress api.ClusterMigrateProgress
+	// progress of the specified volumes, which are listed in order of vids
+	volumes map[proto.Vid]*api.ClusterMigrateVolumeProgress
+	vids    []proto.Vid
+
+	cfg *ClusterMigrateConfig
+}
+
+// NewClusterMigrateMgr returns cluster migrate manager
+func NewClusterMigrateMgr(cfg *ClusterMigrateConfig, clusterMgrCli client.ClusterMgrAPI,
+	taskSwitch taskswitch.ISwitcher,
+) (*ClusterMigrateMgr, error) {
+	if cfg.DestinationClusterID == 0 {
+		return nil, errors.New("destination cluster id of cluster migrate is not set")
+	}
+	source, err := sdk.New(&cfg.Source)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := sdk.New(&cfg.Destination)
+	if err != nil {
+		return nil, err
+	}
+	return newClusterMigrateMgr(cfg, clusterMgrCli, taskSwitch, source, destination), nil
+}
+
+func newClusterMigrateMgr(cfg *ClusterMigrateConfig, clusterMgrCli client.ClusterMgrAPI, taskSwitch taskswitch.ISwitcher,
+	source clusterMigrateSource, destination clusterMigrateDestination,
+) *ClusterMigrateMgr {
+	volumes := make(map[proto.Vid]*api.ClusterMigrateVolumeProgress, len(cfg.Vids))
+	vids := make([]proto.Vid, 0, len(cfg.Vids))
+	for _, vid := range cfg.Vids {
+		if _, ok := volumes[vid]; !ok {
+			volumes[vid] = &api.ClusterMigrateVolumeProgress{Vid: vid}
+			vids = append(vids, vid)
+		}
+	}
+	sort.Slice(vids, func(i, j int) bool { return vids[i] < vids[j] })
+	return &ClusterMigrateMgr{
+		Closer:        closer.New(),
+		taskSwitch:    taskSwitch,
+		clusterMgrCli: clusterMgrCli,
+		source:        source,
+		destination:   destination,
+		taskPool:      taskpool.New(cfg.Concurrency, cfg.Concurrency),
+		blobLimiter:   newLimiter(cfg.BlobsPerSecond),
+		bytesLimiter:  newLimiter(cfg.BytesPerSecond),
+		volumes:       volumes,
+		vids:          vids,
+		cfg:           cfg,
+	}
+}
+
+// Enabled returns true if task switch status
+func (mgr *ClusterMigrateMgr) Enabled() bool {
+	return mgr.taskSwitch.Enabled()
+}
+
+// Run run cluster migrate manager
+func (mgr *ClusterMigrateMgr) Run() {
+	go mgr.run()
+}
+
+// Close close cluster migrate manager
+func (mgr *ClusterMigrateMgr) Close() {
+	mgr.Closer.Close()
+	mgr.taskPool.Close()
+}
+
+// Progress returns progress of cluster migration
+func (mgr *ClusterMigrateMgr) Progress() api.ClusterMigrateProgress {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	return mgr.snapshotLocked()
+}
+
+func (mgr *ClusterMigrateMgr) snapshotLocked() api.ClusterMigrateProgress {
+	progress := mgr.progress
+	progress.Volumes = make([]api.ClusterMigrateVolumeProgress, 0, len(mgr.volumes))
+	for _, volume := range mgr.volumes {
+		progress.Volumes = append(progress.Volumes, *volume)
+	}
+	sort.Slice(progress.Volumes, func(i, j int) bool { return progress.Volumes[i].Vid < progress.Volumes[j].Vid })
+	return progress
+}
+
+func (mgr *ClusterMigrateMgr) run() {
+	t := time.NewTicker(time.Duration(mgr.cfg.IntervalS) * time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			mgr.taskSwitch.WaitEnable()
+			mgr.migrateRun()
+		case <-mgr.Closer.Done():
+			return
+		}
+	}
+}
+
+// migrateRun migrates the blobs until all blobs listed, or the task switch is closed
+func (mgr *ClusterMigrateMgr) migrateRun() {
+	span, ctx := trace.StartSpanFromContext(context.Background(), "cluster.migrate.run")
+	defer span.Finish()
+
+	if !mgr.loaded {
+		if err := mgr.load(ctx); err != nil {
+			span.Errorf("load cluster migrate progress failed: err[%+v]", err)
+			return
+		}
+		mgr.loaded = true
+	}
+
+	for !mgr.progress.Finished && mgr.taskSwitch.Enabled() {
+		select {
+		case <-mgr.Closer.Done():
+			return
+		default:
+		}
+		if err := mgr.migrateBatch(ctx); err != nil {
+			span.Errorf("migrate blobs failed: marker[%s], err[%+v]", mgr.progress.Marker, err)
+			mgr.checkpoint(ctx, false)
+			return
+		}
+		mgr.checkpoint(ctx, mgr.progress.Finished)
+	}
+}
+
+// load loads the checkpointed progress, starts from the beginning if not found
+func (mgr *ClusterMigrateMgr) load(ctx context.Context) error {
+	progress, err := mgr.clusterMgrCli.GetClusterMigrateProgress(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), errcode.ErrNotFound.Error()) {
+			return nil
+		}
+		return err
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	for _, volume := range progress.Volumes {
+		if _, ok := mgr.volumes[volume.Vid]; ok {
+			volume := volume
+			mgr.volumes[volume.Vid] = &volume
+		}
+	}
+	progress.Volumes = nil
+	mgr.progress = *progress
+	return nil
+}
+
+// migrateBatch retries the failed blobs, then migrates a batch of blobs listed from marker
+func (mgr *ClusterMigrateMgr) migrateBatch(ctx context.Context) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	if err := mgr.retryFailed(ctx); err != nil {
+		return err
+	}
+	if mgr.progress.Listed {
+		mgr.mu.Lock()
+		mgr.progress.Finished = true
+		mgr.mu.Unlock()
+		return nil
+	}
+	if len(mgr.volumes) > 0 {
+		return mgr.migrateVolumeBatch(ctx)
+	}
+
+	ret, err := mgr.source.ListBlob(ctx, &acapi.ListBlobArgs{
+		ClusterID: mgr.cfg.ClusterID,
+		Mode:      acapi.GetShardModeLeader,
+		Marker:    mgr.progress.Marker,
+		Count:     uint64(mgr.cfg.ListCount),
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := mgr.migrateBlobs(ctx, ret.Blobs, 0, false)
+
+	mgr.mu.Lock()
+	mgr.progress.Marker = ret.NextMarker
+	mgr.listedLocked(len(ret.NextMarker) == 0, failed)
+	mgr.mu.Unlock()
+	span.Debugf("migrate blobs: count[%d], failed[%d], next marker[%s]", len(ret.Blobs), len(failed), ret.NextMarker)
+	return nil
+}
+
+// migrateVolumeBatch migrates a batch of blobs listed from marker of the first unlisted volume
+func (mgr *ClusterMigrateMgr) migrateVolumeBatch(ctx context.Context) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	mgr.mu.Lock()
+	volume := mgr.unlistedVolumeLocked()
+	if volume == nil {
+		mgr.listedLocked(true, nil)
+		mgr.mu.Unlock()
+		return nil
+	}
+	vid, marker := volume.Vid, volume.Marker
+	mgr.mu.Unlock()
+
+	ret, err := mgr.source.ListBlob(ctx, &acapi.ListBlobArgs{
+		ClusterID: mgr.cfg.ClusterID,
+		Mode:      acapi.GetShardModeLeader,
+		Marker:    marker,
+		Count:     uint64(mgr.cfg.ListCount),
+		Vid:       vid,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := mgr.migrateBlobs(ctx, ret.Blobs, vid, false)
+
+	mgr.mu.Lock()
+	volume.Marker = ret.NextMarker
+	volume.Listed = len(ret.NextMarker) == 0
+	mgr.listedLocked(mgr.unlistedVolumeLocked() == nil, failed)
+	mgr.mu.Unlock()
+	span.Debugf("migrate blobs of volume: vid[%d], count[%d], failed[%d], next marker[%s]",
+		vid, len(ret.Blobs), len(failed), ret.NextMarker)
+	return nil
+}
+
+// unlistedVolumeLocked returns the first volume which blobs are not all listed
+func (mgr *ClusterMigrateMgr) unlistedVolumeLocked() *api.ClusterMigrateVolumeProgress {
+	for _, vid := range mgr.vids {
+		if volume := mgr.volumes[vid]; !volume.Listed {
+			return volume
+		}
+	}
+	return nil
+}
+
+// listedLocked appends the failed blobs of the listed batch, and updates if all blobs listed
+func (mgr *ClusterMigrateMgr) listedLocked(listed bool, failed []proto.Blob) {
+	mgr.progress.Listed = listed
+	mgr.progress.FailedBlobs = append(mgr.progress.FailedBlobs, failed...)
+	mgr.progress.Failed = int64(len(mgr.progress.FailedBlobs))
+	mgr.progress.Finished = mgr.progress.Listed && len(mgr.progress.FailedBlobs) == 0
+}
+
+// retryFailed retries the failed blobs, returns error if the remained failed blobs
+// should be retried later before listing the next batch
+func (mgr *ClusterMigrateMgr) retryFailed(ctx context.Context) error {
+	mgr.mu.Lock()
+	blobs := mgr.progress.FailedBlobs
+	mgr.mu.Unlock()
+	if len(blobs) == 0 {
+		return nil
+	}
+
+	failed := mgr.migrateBlobs(ctx, blobs, 0, true)
+
+	mgr.mu.Lock()
+	mgr.progress.FailedBlobs = failed
+	mgr.progress.Failed = int64(len(failed))
+	listed := mgr.progress.Listed
+	mgr.mu.Unlock()
+
+	if len(failed) >= mgr.cfg.MaxFailedBlobs {
+		return errTooManyFailedBlobs
+	}
+	if listed && len(failed) > 0 {
+		return errFailedBlobsRemained
+	}
+	return nil
+}
+
+// migrateBlobs migrates blobs concurrently, returns the failed blobs.
+// the blobs listed in volume vid are ignored if it's migrated with the former volume.
+func (mgr *ClusterMigrateMgr) migrateBlobs(ctx context.Context, blobs []proto.Blob, vid proto.Vid, retried bool) []proto.Blob {
+	span := trace.SpanFromContextSafe(ctx)
+
+	var (
+		mu     sync.Mutex
+		failed []proto.Blob
+	)
+	wg := sync.WaitGroup{}
+	wg.Add(len(blobs))
+	for i := range blobs {
+		blob := &blobs[i]
+		mgr.taskPool.Run(func() {
+			defer wg.Done()
+			vids := mgr.selectedVids(blob.Location)
+			if len(mgr.volumes) > 0 && len(vids) == 0 {
+				return
+			}
+			if vid > 0 && vids[0] != vid {
+				return
+			}
+			result := mgr.migrateBlob(ctx, blob)
+			if result == clusterMigrateFailed {
+				span.Warnf("migrate blob failed: name[%s], location[%+v], retried[%t]", blob.Name, blob.Location, retried)
+				mu.Lock()
+				failed = append(failed, *blob)
+				mu.Unlock()
+			}
+			mgr.record(vids, blob.Location.Size_, result, retried)
+		})
+	}
+	wg.Wait()
+	return failed
+}
+
+// selectedVids returns the specified volumes which the blob located in, in order of vid
+func (mgr *ClusterMigrateMgr) selectedVids(loc proto.Location) []proto.Vid {
+	var vids []proto.Vid
+	for _, slice := range loc.Slices {
+		if _, ok := mgr.volumes[slice.Vid]; ok {
+			vids = append(vids, slice.Vid)
+		}
+	}
+	sort.Slice(vids, func(i, j int) bool { return vids[i] < vids[j] })
+	return vids
+}
+
+// migrateBlob reads the blob by its location and writes it into destination cluster,
+// the compressed blob is copied with the encoded payload and its codec.
+func (mgr *ClusterMigrateMgr) migrateBlob(ctx context.Context, blob *proto.Blob) clusterMigrateResult {
+	span := trace.SpanFromContextSafe(ctx)
+	loc := blob.Location
+	switch {
+	case !blob.Sealed:
+		span.Debugf("skip unsealed blob: name[%s]", blob.Name)
+		return clusterMigrateUnsealed
+	case loc.Size_ == 0:
+		span.Debugf("skip empty blob: name[%s]", blob.Name)
+		return clusterMigrateEmpty
+	default:
+	}
+
+	if err := mgr.throttle(ctx, loc.Size_); err != nil {
+		return clusterMigrateFailed
+	}
+
+	codeMode := mgr.cfg.CodeMode
+	if codeMode == 0 {
+		codeMode = loc.CodeMode
+	}
+	err := retry.Timed(mgr.cfg.MaxRetry, 200).RuptOn(func() (bool, error) {
+		body, err := mgr.source.Get(ctx, &acapi.GetArgs{Location: loc, ReadSize: loc.Size_})
+		if err != nil {
+			return false, err
+		}
+		defer body.Close()
+
+		_, _, err = mgr.destination.PutBlob(ctx, &acapi.PutBlobArgs{
+			CodeMode: codeMode,
+			BlobName: blob.Name,
+			NeedSeal: true,
+			Size:     loc.Size_,
+			Body:     body,
+			Codec:    loc.Codec,
+			RawSize:  loc.RawSize,
+		})
+		if rpc.DetectStatusCode(err) == errcode.CodeBlobAlreadyExists {
+			return true, errBlobMigrated
+		}
+		return false, err
+	})
+	if err == errBlobMigrated {
+		if err = mgr.verifyMigrated(ctx, blob); err == nil {
+			return clusterMigrateExisted
+		}
+	}
+	if err != nil {
+		span.Errorf("copy blob failed: name[%s], err[%+v]", blob.Name, err)
+		return clusterMigrateFailed
+	}
+	return clusterMigrateMigrated
+}
+
+// verifyMigrated checks the blob already in destination has the same data with the source,
+// the existed one may be a partial written blob or another blob with the same name.
+// the raw payload is compared as the blob in destination is read decompressed.
+func (mgr *ClusterMigrateMgr) verifyMigrated(ctx context.Context, blob *proto.Blob) error {
+	loc := blob.Location
+	size := loc.Size_
+	getSource := func() (io.ReadCloser, error) {
+		return mgr.source.Get(ctx, &acapi.GetArgs{Location: loc, ReadSize: loc.Size_})
+	}
+	if loc.Codec != proto.CodecNone {
+		size = loc.RawSize
+		getSource = func() (io.ReadCloser, error) {
+			return mgr.source.GetBlob(ctx, &acapi.GetBlobArgs{
+				ClusterID:   mgr.cfg.ClusterID,
+				Mode:        acapi.GetShardModeLeader,
+				BlobName:    blob.Name,
+				ReadSize:    size,
+				Consistency: acapi.ConsistencyReadAfterWrite,
+			})
+		}
+	}
+	srcCrc, err := readCrc(size, getSource)
+	if err != nil {
+		return err
+	}
+	// the smaller or unsealed blob in destination fails to read size of source
+	dstCrc, err := readCrc(size, func() (io.ReadCloser, error) {
+		return mgr.destination.GetBlob(ctx, &acapi.GetBlobArgs{
+			ClusterID:   mgr.cfg.DestinationClusterID,
+			Mode:        acapi.GetShardModeLeader,
+			BlobName:    blob.Name,
+			ReadSize:    size,
+			Consistency: acapi.ConsistencyReadAfterWrite,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if srcCrc != dstCrc {
+		return errBlobMismatched
+	}
+	return nil
+}
+
+func readCrc(size uint64, get func() (io.ReadCloser, error)) (uint32, error) {
+	body, err := get()
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	crc := crc32.NewIEEE()
+	n, err := io.Copy(crc, body)
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n) != size {
+		return 0, errBlobMismatched
+	}
+	return crc.Sum32(), nil
+}
+
+// throttle waits for the limits of blobs and bytes per second
+func (mgr *ClusterMigrateMgr) throttle(ctx context.Context, size uint64) error {
+	if err := mgr.blobLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	if burst := uint64(mgr.bytesLimiter.Burst()); burst > 0 {
+		for size > burst {
+			if err := mgr.bytesLimiter.WaitN(ctx, int(burst)); err != nil {
+				return err
+			}
+			size -= burst
+		}
+		return mgr.bytesLimiter.WaitN(ctx, int(size))
+	}
+	return nil
+}
+
+// record counts result of the blob, the failed blobs are counted by the failed list,
+// which is decreased if the retried blob is not failed again
+func (mgr *ClusterMigrateMgr) record(vids []proto.Vid, size uint64, result clusterMigrateResult, retried bool) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	skipped := &mgr.progress.Skipped
+	switch result {
+	case clusterMigrateMigrated:
+		mgr.progress.Migrated++
+		mgr.progress.Bytes += size
+	case clusterMigrateExisted:
+		skipped.Existed++
+	case clusterMigrateUnsealed:
+		skipped.Unsealed++
+	case clusterMigrateEmpty:
+		skipped.Empty++
+	case clusterMigrateFailed:
+	default:
+		return
+	}
+	for _, vid := range vids {
+		volume := mgr.volumes[vid]
+		switch {
+		case result == clusterMigrateFailed && !retried:
+			volume.Failed++
+		case result != clusterMigrateFailed && retried:
+			volume.Failed--
+		}
+		if result == clusterMigrateMigrated {
+			volume.Migrated++
+			volume.Bytes += size
+		}
+	}
+}
+
+// checkpoint saves the progress into clustermgr in interval, or immediately if force
+func (mgr *ClusterMigrateMgr) checkpoint(ctx context.Context, force bool) {
+	span := trace.SpanFromContextSafe(ctx)
+	if !force && time.Since(mgr.checkpointTime) < time.Duration(mgr.cfg.CheckpointIntervalS)*time.Second {
+		return
+	}
+
+	mgr.checkpointTime = time.Now()
+	progress := mgr.Progress()
+	err := retry.Timed(3, 200).On(func() error {
+		return mgr.clusterMgrCli.SetClusterMigrateProgress(ctx, &progress)
+	})
+	if err != nil {
+		span.Warnf("save cluster migrate progress failed: marker[%s], err[%+v]", progress.Marker, err)
+		return
+	}
+	span.Debugf("save cluster migrate progress: %+v", progress)
+}
@@ -0,0 +1,250 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/testing/mocks"
+)
+
+type fakeMigrateSource struct {
+	pages map[string]shardnode.ListBlobRet
+	// pages of the blobs listed in volume
+	volumePages map[proto.Vid]map[string]shardnode.ListBlobRet
+	getErrs     int
+}
+
+func (s *fakeMigrateSource) ListBlob(ctx context.Context, args *acapi.ListBlobArgs) (shardnode.ListBlobRet, error) {
+	pages := s.pages
+	if args.Vid > 0 {
+		pages = s.volumePages[args.Vid]
+	}
+	ret, ok := pages[string(args.Marker)]
+	if !ok {
+		return ret, errMock
+	}
+	return ret, nil
+}
+
+func (s *fakeMigrateSource) GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(make([]byte, args.ReadSize))), nil
+}
+
+func (s *fakeMigrateSource) Get(ctx context.Context, args *acapi.GetArgs) (io.ReadCloser, error) {
+	if s.getErrs > 0 {
+		s.getErrs--
+		return nil, errMock
+	}
+	return io.NopCloser(bytes.NewReader(make([]byte, args.ReadSize))), nil
+}
+
+type fakeMigrateDestination struct {
+	sync.Mutex
+	blobs  map[string]codemode.CodeMode
+	sizes  map[string]uint64
+	codecs map[string]proto.Codec
+}
+
+func (d *fakeMigrateDestination) PutBlob(ctx context.Context, args *acapi.PutBlobArgs) (proto.ClusterID, acapi.HashSumMap, error) {
+	d.Lock()
+	defer d.Unlock()
+	if _, ok := d.blobs[string(args.BlobName)]; ok {
+		return 0, nil, errcode.ErrBlobAlreadyExists
+	}
+	n, err := io.Copy(io.Discard, args.Body)
+	if err != nil || uint64(n) != args.Size {
+		return 0, nil, errMock
+	}
+	d.blobs[string(args.BlobName)] = args.CodeMode
+	d.sizes[string(args.BlobName)] = args.Size
+	if args.Codec != proto.CodecNone {
+		// the blob is read decompressed
+		d.sizes[string(args.BlobName)] = args.RawSize
+		d.codecs[string(args.BlobName)] = args.Codec
+	}
+	return 2, nil, nil
+}
+
+func (d *fakeMigrateDestination) GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (io.ReadCloser, error) {
+	d.Lock()
+	defer d.Unlock()
+	size, ok := d.sizes[string(args.BlobName)]
+	if !ok || args.ClusterID != 2 {
+		return nil, errcode.ErrNotFound
+	}
+	if args.Offset+args.ReadSize > size {
+		return nil, errcode.ErrIllegalArguments
+	}
+	return io.NopCloser(bytes.NewReader(make([]byte, args.ReadSize))), nil
+}
+
+func newMigrateBlob(name string, size uint64, sealed bool, vids ...proto.Vid) proto.Blob {
+	loc := proto.Location{ClusterID: 1, CodeMode: codemode.EC6P6, Size_: size}
+	for _, vid := range vids {
+		loc.Slices = append(loc.Slices, proto.Slice{Vid: vid, MinSliceID: 1, Count: 1})
+	}
+	return proto.Blob{Name: []byte(name), Location: loc, Sealed: sealed}
+}
+
+func newCompressedMigrateBlob(name string, size, rawSize uint64, vids ...proto.Vid) proto.Blob {
+	blob := newMigrateBlob(name, size, true, vids...)
+	blob.Location.Codec = proto.CodecZstd
+	blob.Location.RawSize = rawSize
+	return blob
+}
+
+func newClusterMigrater(t *testing.T, cfg *ClusterMigrateConfig, source clusterMigrateSource) (*ClusterMigrateMgr, *fakeMigrateDestination) {
+	ctr := gomock.NewController(t)
+	cfg.ClusterID = 1
+	cfg.DestinationClusterID = 2
+	cfg.CheckAndFix()
+	destination := &fakeMigrateDestination{
+		blobs:  map[string]codemode.CodeMode{"exist": codemode.EC6P6, "mismatch": codemode.EC6P6, "zexist": codemode.EC6P6},
+		sizes:  map[string]uint64{"exist": 10, "mismatch": 5, "zexist": 100},
+		codecs: map[string]proto.Codec{},
+	}
+	mgr := newClusterMigrateMgr(cfg, NewMockClusterMgrAPI(ctr), mocks.NewMockSwitcher(ctr), source, destination)
+	return mgr, destination
+}
+
+func TestClusterMigrateRun(t *testing.T) {
+	// b located in both volumes is migrated with volume 1
+	source := &fakeMigrateSource{volumePages: map[proto.Vid]map[string]shardnode.ListBlobRet{
+		1: {
+			"": {Blobs: []proto.Blob{
+				newMigrateBlob("a", 100, true, 1),
+				newMigrateBlob("b", 200, true, 2, 1),
+				newMigrateBlob("unsealed", 10, false, 1),
+			}, NextMarker: []byte("m1")},
+			"m1": {Blobs: []proto.Blob{
+				newMigrateBlob("mismatch", 10, true, 1),
+				newCompressedMigrateBlob("zipped", 50, 100, 1),
+			}},
+		},
+		2: {
+			"": {Blobs: []proto.Blob{
+				newMigrateBlob("b", 200, true, 2, 1),
+				newMigrateBlob("exist", 10, true, 2),
+				newMigrateBlob("empty", 0, true, 2),
+				newCompressedMigrateBlob("zexist", 50, 100, 2),
+			}},
+		},
+	}}
+	mgr, destination := newClusterMigrater(t, &ClusterMigrateConfig{
+		Vids: []proto.Vid{1, 2}, CodeMode: codemode.EC3P3, BlobsPerSecond: 100, BytesPerSecond: 128,
+	}, source)
+
+	mgr.taskSwitch.(*mocks.MockSwitcher).EXPECT().Enabled().AnyTimes().Return(true)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetClusterMigrateProgress(any).Return(nil, errcode.ErrNotFound)
+	var saved api.ClusterMigrateProgress
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().SetClusterMigrateProgress(any, any).Times(2).DoAndReturn(
+		func(_ context.Context, progress *api.ClusterMigrateProgress) error {
+			saved = *progress
+			return nil
+		})
+	// the existed blob with different size is failed, and retried after all listed
+	mgr.migrateRun()
+
+	progress := mgr.Progress()
+	require.True(t, progress.Listed)
+	require.False(t, progress.Finished)
+	require.Empty(t, progress.Marker)
+	require.Equal(t, int64(1), progress.Failed)
+	require.Len(t, progress.FailedBlobs, 1)
+	require.Equal(t, []byte("mismatch"), progress.FailedBlobs[0].Name)
+	require.Equal(t, int64(1), progress.Volumes[0].Failed)
+
+	destination.Lock()
+	delete(destination.blobs, "mismatch")
+	delete(destination.sizes, "mismatch")
+	destination.Unlock()
+	mgr.migrateRun()
+
+	progress = mgr.Progress()
+	require.Equal(t, saved, progress)
+	require.True(t, progress.Finished)
+	require.Equal(t, int64(4), progress.Migrated)
+	require.Equal(t, uint64(360), progress.Bytes)
+	require.Equal(t, api.ClusterMigrateSkipped{Existed: 2, Unsealed: 1, Empty: 1}, progress.Skipped)
+	require.Equal(t, int64(0), progress.Failed)
+	require.Empty(t, progress.FailedBlobs)
+	require.Equal(t, []api.ClusterMigrateVolumeProgress{
+		{Vid: 1, Listed: true, Migrated: 4, Bytes: 360},
+		{Vid: 2, Listed: true, Migrated: 1, Bytes: 200},
+	}, progress.Volumes)
+	require.Equal(t, map[string]codemode.CodeMode{
+		"exist": codemode.EC6P6, "zexist": codemode.EC6P6,
+		"a": codemode.EC3P3, "b": codemode.EC3P3, "mismatch": codemode.EC3P3, "zipped": codemode.EC3P3,
+	}, destination.blobs)
+	// the compressed blob is copied with its codec
+	require.Equal(t, map[string]proto.Codec{"zipped": proto.CodecZstd}, destination.codecs)
+	require.Equal(t, uint64(100), destination.sizes["zipped"])
+
+	// finished migration does nothing
+	mgr.migrateRun()
+}
+
+func TestClusterMigrateResume(t *testing.T) {
+	source := &fakeMigrateSource{pages: map[string]shardnode.ListBlobRet{
+		"m1": {Blobs: []proto.Blob{newMigrateBlob("c", 100, true, 1)}, NextMarker: []byte("m2")},
+		"m2": {Blobs: []proto.Blob{newMigrateBlob("d", 100, true, 1)}},
+	}, getErrs: 6}
+	mgr, destination := newClusterMigrater(t, &ClusterMigrateConfig{MaxFailedBlobs: 1}, source)
+
+	mgr.taskSwitch.(*mocks.MockSwitcher).EXPECT().Enabled().AnyTimes().Return(true)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetClusterMigrateProgress(any).Return(nil, errMock)
+	mgr.migrateRun()
+	require.False(t, mgr.loaded)
+
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetClusterMigrateProgress(any).Return(&api.ClusterMigrateProgress{
+		Marker: []byte("m1"), Migrated: 10, Volumes: []api.ClusterMigrateVolumeProgress{{Vid: 1, Migrated: 1}},
+	}, nil)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().SetClusterMigrateProgress(any, any).AnyTimes().Return(nil)
+	// c failed in batch and retry, listing from m2 is paused
+	mgr.migrateRun()
+
+	progress := mgr.Progress()
+	require.True(t, mgr.loaded)
+	require.False(t, progress.Finished)
+	require.Equal(t, []byte("m2"), progress.Marker)
+	require.Equal(t, int64(10), progress.Migrated)
+	require.Equal(t, int64(1), progress.Failed)
+	// volumes are tracked only if specified
+	require.Empty(t, progress.Volumes)
+	require.NotContains(t, destination.blobs, "c")
+
+	// c is retried before listing from m2
+	mgr.migrateRun()
+	progress = mgr.Progress()
+	require.True(t, progress.Finished)
+	require.Equal(t, int64(12), progress.Migrated)
+	require.Equal(t, int64(0), progress.Failed)
+	require.Contains(t, destination.blobs, "c")
+	require.Contains(t, destination.blobs, "d")
+}
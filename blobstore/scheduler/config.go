@@ -15,6 +15,8 @@
 package scheduler
 
 import (
+	"fmt"
+
 	"github.com/Shopify/sarama"
 
 	"github.com/cubefs/cubefs/blobstore/api/blobnode"
@@ -23,8 +25,10 @@ import (
 	"github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/cmd"
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	"github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/recordlog"
+	"github.com/cubefs/cubefs/blobstore/scheduler/base"
 	"github.com/cubefs/cubefs/blobstore/util/defaulter"
 )
 
@@ -44,6 +48,8 @@ const (
 	defaultInspectBatch      = 1000
 	defaultInspectTimeoutMs  = 10000
 
+	defaultInspectCheckpointIntervalS = 30
+
 	defaultTaskPoolSize           = 10
 	defaultDeleteHourRangeTo      = 24
 	defaultMessagePunishThreshold = 3
@@ -55,6 +61,8 @@ const (
 	defaultMaxBatchSize           = 10
 	defaultBatchIntervalSec       = 2
 	defaultDeleteRatePerSec       = 100
+	defaultShardBatchSize         = 64
+	defaultShardBatchWaitMs       = 10
 
 	defaultAppliedIndexThreshold = uint64(10)
 
@@ -94,13 +102,17 @@ type Config struct {
 	ManualMigrate MigrateConfig       `json:"manual_migrate"`
 	VolumeInspect VolumeInspectMgrCfg `json:"volume_inspect"`
 	TaskLog       recordlog.Config    `json:"task_log"`
+	TaskHistory   TaskHistoryConfig   `json:"task_history"`
 
 	ShardDiskRepair ShardMigrateConfig `json:"shard_disk_repair"`
 
 	Kafka       KafkaConfig       `json:"kafka"`
+	MQ          MQConfig          `json:"mq"`
 	ShardRepair ShardRepairConfig `json:"shard_repair"`
 	BlobDelete  BlobDeleteConfig  `json:"blob_delete"`
 
+	ClusterMigrate ClusterMigrateConfig `json:"cluster_migrate"`
+
 	ServiceRegister ServiceRegisterConfig `json:"service_register"`
 }
 
@@ -115,7 +127,9 @@ type ServiceRegisterConfig struct {
 
 // ShardRepairKafkaConfig is kafka config of shard repair
 type ShardRepairKafkaConfig struct {
+	Backend                string
 	BrokerList             []string
+	EmbeddedHosts          []string
 	TopicNormals           []string
 	TopicFailed            string
 	FailMsgSenderTimeoutMs int64
@@ -123,7 +137,9 @@ type ShardRepairKafkaConfig struct {
 
 // BlobDeleteKafkaConfig is kafka config of blob delete
 type BlobDeleteKafkaConfig struct {
+	Backend                string
 	BrokerList             []string
+	EmbeddedHosts          []string
 	FailMsgSenderTimeoutMs int64
 	TopicNormal            string
 	TopicFailed            string
@@ -144,6 +160,17 @@ type KafkaConfig struct {
 	Version                string   `json:"version"`
 }
 
+// MQConfig config of message queue backend, topics are configured in kafka config
+type MQConfig struct {
+	// kafka or embedded, default is kafka, other message queues like pulsar are not supported
+	Backend  string                   `json:"backend"`
+	Embedded base.EmbeddedQueueConfig `json:"embedded"`
+}
+
+func (c *MQConfig) isEmbedded() bool {
+	return c.Backend == mq.BackendEmbedded
+}
+
 type Services struct {
 	Leader  uint64            `json:"leader"`
 	NodeID  uint64            `json:"node_id"`
@@ -191,11 +218,21 @@ func (c *Config) fixConfig() (err error) {
 	defaulter.LessOrEqual(&c.TopologyUpdateIntervalMin, defaultTopologyUpdateIntervalMin)
 	defaulter.LessOrEqual(&c.VolumeCacheUpdateIntervalS, defaultVolumeCacheUpdateIntervalS)
 	defaulter.LessOrEqual(&c.TaskLog.ChunkBits, defaultDeleteLogChunkSize)
+	defaulter.LessOrEqual(&c.TaskHistory.RetentionDays, defaultTaskHistoryRetentionDays)
+	defaulter.LessOrEqual(&c.TaskHistory.CleanIntervalS, defaultTaskHistoryCleanIntervalS)
+	defaulter.LessOrEqual(&c.TaskHistory.MaxRecords, defaultTaskHistoryMaxRecords)
+	defaulter.LessOrEqual(&c.TaskHistory.BatchSize, defaultTaskHistoryBatchSize)
+	defaulter.LessOrEqual(&c.TaskHistory.FlushIntervalS, defaultTaskHistoryFlushIntervalS)
 	c.fixClientConfig()
 	if err := c.fixKafkaConfig(); err != nil {
 		return errInvalidKafka
 	}
-	c.fixBalanceConfig()
+	if err := c.fixMQConfig(); err != nil {
+		return err
+	}
+	if err := c.fixBalanceConfig(); err != nil {
+		return err
+	}
 	c.fixDiskDropConfig()
 	c.fixDiskRepairConfig()
 	c.fixManualMigrateConfig()
@@ -205,6 +242,7 @@ func (c *Config) fixConfig() (err error) {
 		return err
 	}
 	c.fixRegisterConfig()
+	c.fixClusterMigrateConfig()
 
 	c.fixShardDiskRepairConfig()
 	return nil
@@ -237,11 +275,38 @@ func (c *Config) fixKafkaConfig() (err error) {
 	return nil
 }
 
-func (c *Config) fixBalanceConfig() {
+func (c *Config) fixMQConfig() error {
+	defaulter.Empty(&c.MQ.Backend, mq.BackendKafka)
+	switch c.MQ.Backend {
+	case mq.BackendKafka:
+	case mq.BackendEmbedded:
+		if c.IsLeader() && c.MQ.Embedded.Path == "" {
+			return errInvalidMQ
+		}
+	default:
+		return fmt.Errorf("%w: %s is not supported", errInvalidMQ, c.MQ.Backend)
+	}
+	return nil
+}
+
+// embeddedHosts returns hosts of embedded queue, messages are sent to the leader
+func (c *Config) embeddedHosts() []string {
+	if !c.MQ.isEmbedded() {
+		return nil
+	}
+	return []string{scheme + c.Leader()}
+}
+
+func (c *Config) fixBalanceConfig() error {
 	c.Balance.ClusterID = c.ClusterID
 	defaulter.LessOrEqual(&c.Balance.MaxDiskFreeChunkCnt, defaultMaxDiskFreeChunkCnt)
 	defaulter.LessOrEqual(&c.Balance.MinDiskFreeChunkCnt, defaultMinDiskFreeChunkCnt)
+	defaulter.Empty(&c.Balance.FailureDomain, FailureDomainHost)
+	if c.Balance.FailureDomain != FailureDomainHost && c.Balance.FailureDomain != FailureDomainRack {
+		return errInvalidFailureDomain
+	}
 	c.Balance.CheckAndFix()
+	return nil
 }
 
 func (c *Config) fixDiskDropConfig() {
@@ -262,14 +327,12 @@ func (c *Config) fixManualMigrateConfig() {
 }
 
 func (c *Config) fixInspectConfig() {
-	defaulter.LessOrEqual(&c.VolumeInspect.TimeoutMs, defaultInspectTimeoutMs)
-	defaulter.LessOrEqual(&c.VolumeInspect.ListVolStep, defaultListVolStep)
-	defaulter.LessOrEqual(&c.VolumeInspect.ListVolIntervalMs, defaultListVolIntervalMs)
-	defaulter.LessOrEqual(&c.VolumeInspect.InspectBatch, defaultInspectBatch)
-	if c.VolumeInspect.InspectBatch < c.VolumeInspect.ListVolStep {
-		c.VolumeInspect.InspectBatch = c.VolumeInspect.ListVolStep
-	}
-	defaulter.LessOrEqual(&c.VolumeInspect.InspectIntervalS, defaultInspectIntervalS)
+	c.VolumeInspect.CheckAndFix()
+}
+
+func (c *Config) fixClusterMigrateConfig() {
+	c.ClusterMigrate.ClusterID = c.ClusterID
+	c.ClusterMigrate.CheckAndFix()
 }
 
 func (c *Config) fixShardRepairConfig() {
@@ -279,7 +342,9 @@ func (c *Config) fixShardRepairConfig() {
 	defaulter.LessOrEqual(&c.ShardRepair.MessagePunishThreshold, defaultMessagePunishThreshold)
 	defaulter.LessOrEqual(&c.ShardRepair.MessagePunishTimeM, defaultMessagePunishTimeM)
 	c.ShardRepair.Kafka.FailMsgSenderTimeoutMs = c.Kafka.FailMsgSenderTimeoutMs
+	c.ShardRepair.Kafka.Backend = c.MQ.Backend
 	c.ShardRepair.Kafka.BrokerList = c.Kafka.BrokerList
+	c.ShardRepair.Kafka.EmbeddedHosts = c.embeddedHosts()
 	c.ShardRepair.Kafka.TopicNormals = c.Kafka.Topics.ShardRepair
 	c.ShardRepair.Kafka.TopicFailed = c.Kafka.Topics.ShardRepairFailed
 }
@@ -303,7 +368,11 @@ func (c *Config) fixBlobDeleteConfig() error {
 	defaulter.Equal(&c.BlobDelete.MaxBatchSize, defaultMaxBatchSize)
 	defaulter.Equal(&c.BlobDelete.BatchIntervalS, defaultBatchIntervalSec)
 	defaulter.LessOrEqual(&c.BlobDelete.DeleteRatePerSecond, defaultDeleteRatePerSec)
+	defaulter.Equal(&c.BlobDelete.ShardBatchSize, defaultShardBatchSize)
+	defaulter.LessOrEqual(&c.BlobDelete.ShardBatchWaitMs, defaultShardBatchWaitMs)
+	c.BlobDelete.Kafka.Backend = c.MQ.Backend
 	c.BlobDelete.Kafka.BrokerList = c.Kafka.BrokerList
+	c.BlobDelete.Kafka.EmbeddedHosts = c.embeddedHosts()
 	c.BlobDelete.Kafka.FailMsgSenderTimeoutMs = c.Kafka.FailMsgSenderTimeoutMs
 	c.BlobDelete.Kafka.TopicNormal = c.Kafka.Topics.BlobDelete
 	c.BlobDelete.Kafka.TopicFailed = c.Kafka.Topics.BlobDeleteFailed
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	"github.com/cubefs/cubefs/blobstore/common/mq"
 )
 
 func TestConfigCheckAndFix(t *testing.T) {
@@ -95,3 +96,41 @@ func TestConfigCheckAndFix(t *testing.T) {
 		require.True(t, errors.Is(err, test.err))
 	}
 }
+
+func TestConfigFixMQ(t *testing.T) {
+	cfg := &Config{ClusterID: 1}
+	cfg.Services.Members = map[uint64]string{1: "127.0.0.1:9800", 2: "127.0.0.1:9880"}
+	cfg.Services.Leader = 1
+	cfg.Services.NodeID = 1
+	require.NoError(t, cfg.fixConfig())
+	require.Equal(t, mq.BackendKafka, cfg.MQ.Backend)
+	require.Nil(t, cfg.BlobDelete.Kafka.EmbeddedHosts)
+
+	cfg.MQ.Backend = "pulsar"
+	require.ErrorIs(t, cfg.fixConfig(), errInvalidMQ)
+
+	// path of embedded queue is required on leader
+	cfg.MQ.Backend = mq.BackendEmbedded
+	require.ErrorIs(t, cfg.fixConfig(), errInvalidMQ)
+	cfg.Services.NodeID = 2
+	require.NoError(t, cfg.fixConfig())
+	require.Equal(t, []string{"http://127.0.0.1:9800"}, cfg.BlobDelete.Kafka.EmbeddedHosts)
+	require.Equal(t, []string{"http://127.0.0.1:9800"}, cfg.ShardRepair.Kafka.EmbeddedHosts)
+	require.Equal(t, mq.BackendEmbedded, cfg.ShardRepair.failedProducerConfig().Backend)
+}
+
+func TestConfigFixBalanceFailureDomain(t *testing.T) {
+	cfg := &Config{ClusterID: 1}
+	cfg.Services.Members = map[uint64]string{1: "127.0.0.1:9800"}
+	cfg.Services.Leader = 1
+	cfg.Services.NodeID = 1
+	require.NoError(t, cfg.fixConfig())
+	require.Equal(t, FailureDomainHost, cfg.Balance.FailureDomain)
+
+	cfg.Balance.FailureDomain = FailureDomainRack
+	require.NoError(t, cfg.fixConfig())
+	require.Equal(t, FailureDomainRack, cfg.Balance.FailureDomain)
+
+	cfg.Balance.FailureDomain = "idc"
+	require.ErrorIs(t, cfg.fixConfig(), errInvalidFailureDomain)
+}
@@ -43,13 +43,20 @@ type DropMgrConfig struct {
 	TaskLimitPerDisk int `json:"task_limit_per_disk"`
 }
 
-var ErrHandleLockVolFail = errors.New("handle lock volume fail")
+var (
+	ErrHandleLockVolFail = errors.New("handle lock volume fail")
+	// ErrDiskDropLiveUnit live volume unit still maps to the dropping disk
+	ErrDiskDropLiveUnit = errors.New("live volume unit still on dropping disk")
+	// ErrDiskDropNotCollected tasks of the dropping disk are not generated completely
+	ErrDiskDropNotCollected = errors.New("dropping disk is not collected")
+)
 
 type dropDisk struct {
 	*client.DiskInfoSimple
 
 	undoneTaskCnt int64 // undone: generating + migrating tasks
 	collecting    atomic.Value
+	requeue       int32 // regenerate tasks in check loop
 	wait          chan struct{}
 }
 
@@ -67,6 +74,14 @@ func (d *dropDisk) isCollecting() bool {
 	return false
 }
 
+func (d *dropDisk) setRequeue() {
+	atomic.StoreInt32(&d.requeue, 1)
+}
+
+func (d *dropDisk) popRequeue() bool {
+	return atomic.CompareAndSwapInt32(&d.requeue, 1, 0)
+}
+
 func (d *dropDisk) getUndoneCnt() int64 {
 	return atomic.LoadInt64(&d.undoneTaskCnt)
 }
@@ -150,17 +165,19 @@ type DiskDropMgr struct {
 	prepareTaskPool  taskpool.TaskPool
 
 	clusterMgrCli client.ClusterMgrAPI
+	blobnodeCli   client.BlobnodeAPI
 	topologyMgr   IClusterTopology
 
 	cfg *DropMgrConfig
 }
 
 // NewDiskDropMgr returns disk drop manager
-func NewDiskDropMgr(clusterMgrCli client.ClusterMgrAPI, volumeUpdater client.IVolumeUpdater,
+func NewDiskDropMgr(clusterMgrCli client.ClusterMgrAPI, blobnodeCli client.BlobnodeAPI, volumeUpdater client.IVolumeUpdater,
 	taskSwitch taskswitch.ISwitcher, taskLogger recordlog.Encoder, conf *DropMgrConfig, topologyMgr IClusterTopology,
 ) *DiskDropMgr {
 	mgr := &DiskDropMgr{
 		clusterMgrCli:  clusterMgrCli,
+		blobnodeCli:    blobnodeCli,
 		topologyMgr:    topologyMgr,
 		cfg:            conf,
 		allDisks:       newDropDiskMap(),
@@ -452,9 +469,20 @@ func (mgr *DiskDropMgr) checkDroppedAndClear() {
 	span, ctx := trace.StartSpanFromContext(context.Background(), "disk_drop.checkDroppedAndClear")
 
 	for _, disk := range mgr.collectedDisks.list() {
+		if disk.popRequeue() {
+			span.Warnf("requeue dropping disk and regenerate tasks: disk_id[%d]", disk.DiskID)
+			mgr.collectedDisks.delete(disk.DiskID)
+			// need exit colleting goroutine
+			disk.done()
+			continue
+		}
 		if !mgr.checkDiskDropped(ctx, disk.DiskID) {
 			continue
 		}
+		if err := mgr.verifyDiskDropped(ctx, disk); err != nil {
+			span.Errorf("verify dropped disk failed and refuse to set dropped: disk_id[%d], err[%+v]", disk.DiskID, err)
+			continue
+		}
 		err := mgr.clusterMgrCli.SetDiskDropped(ctx, disk.DiskID)
 		if err != nil {
 			span.Errorf("set disk dropped failed: err[%+v]", err)
@@ -495,6 +523,45 @@ func (mgr *DiskDropMgr) checkDiskDropped(ctx context.Context, diskID proto.DiskI
 	return len(tasks) == 0 && len(vunitInfos) == 0
 }
 
+// verifyDiskDropped lists volume units of the disk in clustermgr and cross-checks residual chunks
+// listed via blobnode, returns error if any live volume unit still maps to the disk. The residual
+// chunks are checked at best, the disk is verified if its host is unreachable
+func (mgr *DiskDropMgr) verifyDiskDropped(ctx context.Context, disk *dropDisk) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	units, err := mgr.clusterMgrCli.ListDiskVolumeUnits(ctx, disk.DiskID)
+	if err != nil {
+		span.Errorf("list disk volume units failed: disk_id[%d], err[%+v]", disk.DiskID, err)
+		return err
+	}
+	if len(units) != 0 {
+		span.Errorf("live volume units still on dropping disk: disk_id[%d], units len[%d]", disk.DiskID, len(units))
+		return ErrDiskDropLiveUnit
+	}
+
+	chunks, err := mgr.blobnodeCli.ListChunks(ctx, disk.Host, disk.DiskID)
+	if err != nil {
+		span.Warnf("list residual chunks failed and skip: disk_id[%d], host[%s], err[%+v]", disk.DiskID, disk.Host, err)
+		return nil
+	}
+	for _, chunk := range chunks {
+		volume, err := mgr.clusterMgrCli.GetVolumeInfo(ctx, chunk.Vuid.Vid())
+		if err != nil {
+			span.Errorf("get volume info failed: vid[%d], err[%+v]", chunk.Vuid.Vid(), err)
+			return err
+		}
+		for _, location := range volume.VunitLocations {
+			if location.Vuid == chunk.Vuid || location.DiskID == disk.DiskID {
+				span.Errorf("live volume unit still on dropping disk: disk_id[%d], chunk vuid[%d], location[%+v]",
+					disk.DiskID, chunk.Vuid, location)
+				return ErrDiskDropLiveUnit
+			}
+		}
+	}
+	span.Infof("verify dropped disk: disk_id[%d], residual chunks[%d]", disk.DiskID, len(chunks))
+	return nil
+}
+
 func (mgr *DiskDropMgr) clearJunkTasks(ctx context.Context, tasks []*proto.MigrateTask) {
 	span := trace.SpanFromContextSafe(ctx)
 	for _, task := range tasks {
@@ -518,6 +585,24 @@ func (mgr *DiskDropMgr) clearTasksByDiskID(ctx context.Context, diskID proto.Dis
 	mgr.droppedDisks.add(diskID, time.Now())
 }
 
+// RequeueDisk marks the collected dropping disk which lost track of its tasks, the check loop
+// stops collecting of the disk and its tasks are regenerated from volume units in next collecting
+func (mgr *DiskDropMgr) RequeueDisk(ctx context.Context, diskID proto.DiskID) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	disk := mgr.allDisks.get(diskID)
+	if disk == nil {
+		return errors.New("not dropping disk")
+	}
+	if mgr.collectedDisks.get(diskID) == nil {
+		span.Warnf("dropping disk is generating tasks or waiting for collecting: disk_id[%d]", diskID)
+		return ErrDiskDropNotCollected
+	}
+	disk.setRequeue()
+	span.Infof("requeue dropping disk: disk_id[%d], undone tasks[%d]", diskID, disk.getUndoneCnt())
+	return nil
+}
+
 // checkAndClearJunkTasksLoop due to network timeout, the dropped disk may still have some junk migrate tasks in clustermgr,
 // and we need to clear those tasks later
 func (mgr *DiskDropMgr) checkAndClearJunkTasksLoop() {
@@ -23,6 +23,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
@@ -36,6 +37,7 @@ import (
 func newDiskDroper(t *testing.T) *DiskDropMgr {
 	ctr := gomock.NewController(t)
 	clusterMgr := NewMockClusterMgrAPI(ctr)
+	blobnodeCli := NewMockBlobnodeAPI(ctr)
 	volumeUpdater := NewMockVolumeUpdater(ctr)
 	taskSwitch := mocks.NewMockSwitcher(ctr)
 	taskLogger := mocks.NewMockRecordLogEncoder(ctr)
@@ -46,7 +48,7 @@ func newDiskDroper(t *testing.T) *DiskDropMgr {
 	migrater.EXPECT().StatQueueTaskCnt().AnyTimes().Return(0, 0, 0)
 	migrater.EXPECT().Close().AnyTimes().DoAndReturn(c.Close)
 	migrater.EXPECT().Done().AnyTimes().Return(c.Done())
-	mgr := NewDiskDropMgr(clusterMgr, volumeUpdater, taskSwitch, taskLogger, &DropMgrConfig{
+	mgr := NewDiskDropMgr(clusterMgr, blobnodeCli, volumeUpdater, taskSwitch, taskLogger, &DropMgrConfig{
 		TotalTaskLimit:   20,
 		TaskLimitPerDisk: 20,
 	}, topology)
@@ -238,6 +240,26 @@ func TestDiskDropCheckDroppedAndClear(t *testing.T) {
 	}
 }
 
+func TestDiskDropRequeueDisk(t *testing.T) {
+	ctx := context.Background()
+	mgr := newDiskDroper(t)
+	err := mgr.RequeueDisk(ctx, testDisk1.DiskID)
+	require.Error(t, err)
+
+	disk := &dropDisk{wait: make(chan struct{}, 1), DiskInfoSimple: testDisk1}
+	mgr.allDisks.add(disk)
+	err = mgr.RequeueDisk(ctx, testDisk1.DiskID)
+	require.ErrorIs(t, err, ErrDiskDropNotCollected)
+
+	// requeued disk is removed from collected disks without checking dropped
+	mgr.collectedDisks.add(disk)
+	require.NoError(t, mgr.RequeueDisk(ctx, testDisk1.DiskID))
+	mgr.checkDroppedAndClear()
+	require.Equal(t, 0, mgr.collectedDisks.size())
+	require.Equal(t, 1, len(disk.wait))
+	require.False(t, disk.popRequeue())
+}
+
 func TestDiskDropCheckAndClearJunkTasks(t *testing.T) {
 	{
 		mgr := newDiskDroper(t)
@@ -435,3 +457,64 @@ func TestDiskDropDiskProgress(t *testing.T) {
 		require.Equal(t, int(testDisk1.UsedChunkCnt-3), stats.MigratedTasksCnt)
 	}
 }
+
+func TestDiskDropVerifyDropped(t *testing.T) {
+	ctx := context.Background()
+	volume := MockGenVolInfo(10, codemode.EC6P6, proto.VolumeStatusIdle)
+	disk := &dropDisk{DiskInfoSimple: testDisk1}
+	mgr := newDiskDroper(t)
+	blobnodeCli := mgr.blobnodeCli.(*MockBlobnodeAPI)
+	cmCli := mgr.clusterMgrCli.(*MockClusterMgrAPI)
+
+	cmCli.EXPECT().ListDiskVolumeUnits(any, testDisk1.DiskID).Return(nil, errMock)
+	require.ErrorIs(t, mgr.verifyDiskDropped(ctx, disk), errMock)
+	cmCli.EXPECT().ListDiskVolumeUnits(any, testDisk1.DiskID).Return([]*client.VunitInfoSimple{{Vuid: volume.VunitLocations[0].Vuid}}, nil)
+	require.ErrorIs(t, mgr.verifyDiskDropped(ctx, disk), ErrDiskDropLiveUnit)
+	cmCli.EXPECT().ListDiskVolumeUnits(any, any).AnyTimes().Return(nil, nil)
+
+	// host is unreachable
+	blobnodeCli.EXPECT().ListChunks(any, testDisk1.Host, testDisk1.DiskID).Return(nil, errMock)
+	require.NoError(t, mgr.verifyDiskDropped(ctx, disk))
+
+	// no residual chunks
+	blobnodeCli.EXPECT().ListChunks(any, any, any).Return(nil, nil)
+	require.NoError(t, mgr.verifyDiskDropped(ctx, disk))
+
+	// residual chunk of migrated unit
+	oldVuid := proto.EncodeVuid(volume.VunitLocations[0].Vuid.VuidPrefix(), 0)
+	chunks := []*cmapi.ChunkInfo{{Vuid: oldVuid, DiskID: testDisk1.DiskID}}
+	blobnodeCli.EXPECT().ListChunks(any, any, any).Return(chunks, nil)
+	cmCli.EXPECT().GetVolumeInfo(any, proto.Vid(10)).Return(nil, errMock)
+	require.ErrorIs(t, mgr.verifyDiskDropped(ctx, disk), errMock)
+	blobnodeCli.EXPECT().ListChunks(any, any, any).Return(chunks, nil)
+	cmCli.EXPECT().GetVolumeInfo(any, proto.Vid(10)).Return(volume, nil)
+	require.NoError(t, mgr.verifyDiskDropped(ctx, disk))
+
+	// live unit still maps to the disk
+	liveVolume := MockGenVolInfo(10, codemode.EC6P6, proto.VolumeStatusIdle)
+	liveVolume.VunitLocations[1].DiskID = testDisk1.DiskID
+	blobnodeCli.EXPECT().ListChunks(any, any, any).Return(chunks, nil)
+	cmCli.EXPECT().GetVolumeInfo(any, proto.Vid(10)).Return(liveVolume, nil)
+	require.ErrorIs(t, mgr.verifyDiskDropped(ctx, disk), ErrDiskDropLiveUnit)
+	chunks[0].Vuid = volume.VunitLocations[0].Vuid
+	blobnodeCli.EXPECT().ListChunks(any, any, any).Return(chunks, nil)
+	cmCli.EXPECT().GetVolumeInfo(any, proto.Vid(10)).Return(volume, nil)
+	require.ErrorIs(t, mgr.verifyDiskDropped(ctx, disk), ErrDiskDropLiveUnit)
+
+	// refuse to set dropped
+	mgr.collectedDisks.add(disk)
+	mgr.IMigrator.(*MockMigrater).EXPECT().ListAllTaskByDiskID(any, any).AnyTimes().Return(nil, nil)
+	blobnodeCli.EXPECT().ListChunks(any, any, any).Return(chunks, nil)
+	cmCli.EXPECT().GetVolumeInfo(any, proto.Vid(10)).Return(volume, nil)
+	mgr.checkDroppedAndClear()
+	require.Equal(t, 1, mgr.collectedDisks.size())
+
+	// verified and set dropped
+	blobnodeCli.EXPECT().ListChunks(any, any, any).Return(nil, nil)
+	cmCli.EXPECT().SetDiskDropped(any, testDisk1.DiskID).Return(nil)
+	cmCli.EXPECT().DeleteMigratingDisk(any, any, any).Return(nil)
+	mgr.IMigrator.(*MockMigrater).EXPECT().ClearDeletedTasks(any).Return()
+	mgr.allDisks.add(&dropDisk{wait: make(chan struct{}, 1), DiskInfoSimple: testDisk1})
+	mgr.checkDroppedAndClear()
+	require.Equal(t, 0, mgr.collectedDisks.size())
+}
@@ -46,6 +46,10 @@ type DiskRepairMgr struct {
 	repairingDisks *migratingDisks
 
 	clusterMgrCli client.ClusterMgrAPI
+	volumeCache   IVolumeCache
+
+	// repair tasks in prepare queue are prioritized by durability risk of volume
+	risks *volumeRepairRisks
 
 	taskSwitch taskswitch.ISwitcher
 
@@ -59,7 +63,9 @@ type DiskRepairMgr struct {
 }
 
 // NewDiskRepairMgr returns repair manager
-func NewDiskRepairMgr(clusterMgrCli client.ClusterMgrAPI, taskSwitch taskswitch.ISwitcher, taskLogger recordlog.Encoder, cfg *MigrateConfig) *DiskRepairMgr {
+func NewDiskRepairMgr(clusterMgrCli client.ClusterMgrAPI, taskSwitch taskswitch.ISwitcher, taskLogger recordlog.Encoder,
+	cfg *MigrateConfig, volumeCache IVolumeCache,
+) *DiskRepairMgr {
 	mgr := &DiskRepairMgr{
 		Closer:         closer.New(),
 		prepareQueue:   base.NewTaskQueue(time.Duration(cfg.PrepareQueueRetryDelayS) * time.Second),
@@ -70,6 +76,8 @@ func NewDiskRepairMgr(clusterMgrCli client.ClusterMgrAPI, taskSwitch taskswitch.
 		repairingDisks: newMigratingDisks(),
 
 		clusterMgrCli: clusterMgrCli,
+		volumeCache:   volumeCache,
+		risks:         newVolumeRepairRisks(),
 		taskSwitch:    taskSwitch,
 		cfg:           cfg,
 		taskLogger:    taskLogger,
@@ -101,7 +109,10 @@ func (mgr *DiskRepairMgr) Load() error {
 		return nil
 	}
 
-	var junkTasks []*proto.MigrateTask
+	var (
+		junkTasks     []*proto.MigrateTask
+		preparedTasks []*proto.MigrateTask
+	)
 	for _, t := range tasks {
 		task := &proto.MigrateTask{}
 		err = task.Unmarshal(t.Data)
@@ -121,9 +132,12 @@ func (mgr *DiskRepairMgr) Load() error {
 		}
 
 		span.Infof("load task success: task_id[%s], state[%d]", t.TaskID, task.State)
+		if task.State != proto.MigrateStateFinished && task.State != proto.MigrateStateFinishedInAdvance {
+			mgr.risks.addTask(task.Vid(), task.TaskID, task.SourceVuid.Index())
+		}
 		switch task.State {
 		case proto.MigrateStateInited:
-			mgr.prepareQueue.PushTask(t.TaskID, task)
+			preparedTasks = append(preparedTasks, task)
 		case proto.MigrateStatePrepared:
 			mgr.workQueue.AddPreparedTask(task.SourceIDC, t.TaskID, task)
 		case proto.MigrateStateWorkCompleted:
@@ -134,6 +148,10 @@ func (mgr *DiskRepairMgr) Load() error {
 			return fmt.Errorf("unexpect migrate state: task[%+v]", t)
 		}
 	}
+	// push after all unfinished tasks tracked
+	for _, task := range preparedTasks {
+		mgr.prepareQueue.PushTaskWithPriority(task.TaskID, task, mgr.repairPriority(ctx, task.Vid()))
+	}
 
 	return mgr.clearJunkTasksWhenLoading(ctx, junkTasks)
 }
@@ -206,7 +224,7 @@ func (mgr *DiskRepairMgr) collectTask() {
 		mgr.hasRevised = true
 	}
 
-	if mgr.repairingDisks.size() >= mgr.cfg.DiskConcurrency {
+	if mgr.repairingDisks.size() >= mgr.cfg.diskConcurrency(proto.TaskTypeDiskRepair) {
 		return
 	}
 
@@ -373,7 +391,9 @@ func (mgr *DiskRepairMgr) initOneTask(ctx context.Context, badVuid proto.Vuid, b
 		return mgr.clusterMgrCli.AddMigrateTask(ctx, task)
 	})
 
-	mgr.prepareQueue.PushTask(t.TaskID, &t)
+	mgr.risks.addTask(t.Vid(), t.TaskID, badVuid.Index())
+	mgr.prepareQueue.PushTaskWithPriority(t.TaskID, &t, mgr.repairPriority(ctx, t.Vid()))
+	mgr.updateRepairPriority(ctx, t.Vid())
 	span.Infof("init repair task success %+v", t)
 }
 
@@ -496,11 +516,15 @@ func (mgr *DiskRepairMgr) finishTaskInAdvance(ctx context.Context, task *proto.M
 	if recordErr := mgr.taskLogger.Encode(task); recordErr != nil {
 		trace.SpanFromContextSafe(ctx).Errorf("record repair task failed: task[%+v], err[%+v]", task, recordErr)
 	}
+	mgr.cfg.addTaskRecord(ctx, mgr.taskStatsMgr, task)
 
 	mgr.finishTaskCounter.Add()
 	mgr.prepareQueue.RemoveTask(task.TaskID)
 	mgr.deletedTasks.add(task.SourceDiskID, task.TaskID)
 	base.VolTaskLockerInst().Unlock(ctx, uint32(task.Vid()))
+
+	mgr.risks.removeTask(task.Vid(), task.TaskID)
+	mgr.updateRepairPriority(ctx, task.Vid())
 }
 
 func (mgr *DiskRepairMgr) finishTaskLoop() {
@@ -573,6 +597,7 @@ func (mgr *DiskRepairMgr) finishTask(ctx context.Context, task *proto.MigrateTas
 	if recordErr := mgr.taskLogger.Encode(task); recordErr != nil {
 		span.Errorf("record repair task failed: task[%+v], err[%+v]", task, recordErr)
 	}
+	mgr.cfg.addTaskRecord(ctx, mgr.taskStatsMgr, task)
 
 	mgr.finishTaskCounter.Add()
 	// 1.remove task in memory
@@ -584,6 +609,8 @@ func (mgr *DiskRepairMgr) finishTask(ctx context.Context, task *proto.MigrateTas
 
 	base.VolTaskLockerInst().Unlock(ctx, uint32(task.Vid()))
 
+	mgr.risks.removeTask(task.Vid(), task.TaskID)
+	mgr.updateRepairPriority(ctx, task.Vid())
 	return nil
 }
 
@@ -57,7 +57,18 @@ func newDiskRepairer(t *testing.T) *DiskRepairMgr {
 			DiskConcurrency:      1,
 		},
 	}
-	return NewDiskRepairMgr(clusterMgr, taskSwitch, taskLogger, conf)
+	clusterMgr.EXPECT().AddTaskRecord(any, any).AnyTimes().Return(nil)
+	volumes := newMockVolInfoMap()
+	volumeCache := NewMockClusterTopology(ctr)
+	volumeCache.EXPECT().GetVolume(any).AnyTimes().DoAndReturn(
+		func(vid proto.Vid) (*client.VolumeInfoSimple, error) {
+			if volInfo, ok := volumes[vid]; ok {
+				return volInfo, nil
+			}
+			return nil, errMock
+		},
+	)
+	return NewDiskRepairMgr(clusterMgr, taskSwitch, taskLogger, conf, volumeCache)
 }
 
 func generateTaskArgs(task *proto.MigrateTask, reason string) *api.TaskArgs {
@@ -387,6 +398,59 @@ func TestDiskRepairerCollectTask(t *testing.T) {
 	}
 }
 
+func TestDiskRepairerPriority(t *testing.T) {
+	ctx := context.Background()
+	mgr := newDiskRepairer(t)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().AddMigrateTask(any, any).AnyTimes().Return(nil)
+
+	vuid1, _ := proto.NewVuid(1, 0, 1) // EC6P6
+	vuid2, _ := proto.NewVuid(2, 0, 1) // EC6P10L2
+	vuid10, _ := proto.NewVuid(10, 0, 1)
+	mgr.initOneTask(ctx, vuid10, proto.DiskID(1), "z0")
+	mgr.initOneTask(ctx, vuid2, proto.DiskID(1), "z0")
+	mgr.initOneTask(ctx, vuid1, proto.DiskID(1), "z0")
+	require.Equal(t, 1-6, mgr.repairPriority(ctx, 1))
+	require.Equal(t, 1-10, mgr.repairPriority(ctx, 2))
+	require.Equal(t, lowestRepairPriority, mgr.repairPriority(ctx, 10))
+
+	// volume with less remaining redundancy units is repaired first
+	popVid := func() proto.Vid {
+		_, task, exist := mgr.prepareQueue.PopTask()
+		require.True(t, exist)
+		return task.(*proto.MigrateTask).Vid()
+	}
+	require.Equal(t, proto.Vid(1), popVid())
+
+	// missed shards reported by inspection
+	mgr.ReportInspectBads(2, map[proto.BlobID][]uint8{
+		1: {1, 2, 3},
+		2: {0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		3: {1, 2, 3},
+	})
+	require.Equal(t, 0, mgr.repairPriority(ctx, 2))
+	require.Equal(t, 2, len(mgr.risks.inspectBads[2]))
+	require.Equal(t, proto.Vid(2), popVid())
+
+	// another repair task of volume
+	vuid2Other, _ := proto.NewVuid(2, 11, 1)
+	mgr.initOneTask(ctx, vuid2Other, proto.DiskID(2), "z0")
+	require.Equal(t, 1, mgr.repairPriority(ctx, 2))
+
+	// volume not in repairing is ignored
+	mgr.ReportInspectBads(3, map[proto.BlobID][]uint8{1: {1}})
+	require.Equal(t, 0, len(mgr.risks.inspectBads[3]))
+
+	// inspection without missed shards
+	mgr.ReportInspectBads(2, nil)
+	require.Equal(t, 2-10, mgr.repairPriority(ctx, 2))
+
+	for _, taskID := range mgr.risks.taskIDs(2) {
+		mgr.risks.removeTask(2, taskID)
+	}
+	require.Equal(t, 0, len(mgr.risks.taskIDs(2)))
+	require.Equal(t, 0-10, mgr.repairPriority(ctx, 2))
+}
+
 func TestDiskRepairerPopTaskAndPrepare(t *testing.T) {
 	{
 		mgr := newDiskRepairer(t)
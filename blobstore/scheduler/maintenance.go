@@ -0,0 +1,94 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/scheduler/client"
+	"github.com/cubefs/cubefs/blobstore/util/closer"
+)
+
+const syncMaintenanceWindowsIntervalS = 30
+
+type admitConcurrencyFunc func(taskType proto.TaskType, concurrency int) int
+
+// maintenanceCalendar maintenance windows of clustermgr config,
+// the admission concurrency of background tasks is scaled during windows.
+type maintenanceCalendar struct {
+	closer.Closer
+
+	mu      sync.RWMutex
+	windows cmapi.MaintenanceWindows
+
+	clusterMgrCli client.ClusterMgrConfigAPI
+}
+
+func newMaintenanceCalendar(clusterMgrCli client.ClusterMgrConfigAPI) *maintenanceCalendar {
+	return &maintenanceCalendar{
+		Closer:        closer.New(),
+		clusterMgrCli: clusterMgrCli,
+	}
+}
+
+// Run sync maintenance windows from clustermgr
+func (c *maintenanceCalendar) Run() {
+	c.update()
+	go func() {
+		t := time.NewTicker(syncMaintenanceWindowsIntervalS * time.Second)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				c.update()
+			case <-c.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (c *maintenanceCalendar) update() {
+	span, ctx := trace.StartSpanFromContext(context.Background(), "maintenance_calendar.update")
+	value, err := c.clusterMgrCli.GetConfig(ctx, proto.MaintenanceWindowsConfigKey)
+	if err != nil && !strings.Contains(err.Error(), errcode.ErrNotFound.Error()) {
+		span.Errorf("get maintenance windows failed: err[%+v]", err)
+		return
+	}
+	windows, err := cmapi.DecodeMaintenanceWindows(value)
+	if err != nil {
+		span.Errorf("decode maintenance windows failed: value[%s], err[%+v]", value, err)
+		return
+	}
+
+	c.mu.Lock()
+	c.windows = windows
+	c.mu.Unlock()
+}
+
+// Concurrency returns the admission concurrency of the task type now
+func (c *maintenanceCalendar) Concurrency(taskType proto.TaskType, concurrency int) int {
+	c.mu.RLock()
+	windows := c.windows
+	c.mu.RUnlock()
+	return windows.Concurrency(taskType, concurrency, time.Now())
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestMaintenanceCalendar(t *testing.T) {
+	ctr := gomock.NewController(t)
+	clusterMgr := NewMockClusterMgrAPI(ctr)
+	calendar := newMaintenanceCalendar(clusterMgr)
+	defer calendar.Close()
+
+	// not found
+	clusterMgr.EXPECT().GetConfig(any, proto.MaintenanceWindowsConfigKey).Return("", errcode.ErrNotFound)
+	calendar.update()
+	require.Equal(t, 10, calendar.Concurrency(proto.TaskTypeBalance, 10))
+
+	now := time.Now().Unix()
+	windows := cmapi.MaintenanceWindows{
+		{ID: 1, StartTime: now - 100, EndTime: now + 100, Factor: 0, TaskTypes: []proto.TaskType{proto.TaskTypeBalance}},
+		{ID: 2, StartTime: now - 100, EndTime: now + 100, Factor: 3, TaskTypes: []proto.TaskType{proto.TaskTypeDiskRepair}},
+	}
+	value, err := json.Marshal(windows)
+	require.NoError(t, err)
+	clusterMgr.EXPECT().GetConfig(any, proto.MaintenanceWindowsConfigKey).Return(string(value), nil)
+	calendar.update()
+	require.Equal(t, 0, calendar.Concurrency(proto.TaskTypeBalance, 10))
+	require.Equal(t, 3, calendar.Concurrency(proto.TaskTypeDiskRepair, 1))
+	require.Equal(t, 2, calendar.Concurrency(proto.TaskTypeShardDiskRepair, 2))
+
+	// keep the last windows if failed
+	clusterMgr.EXPECT().GetConfig(any, proto.MaintenanceWindowsConfigKey).Return("", errors.New("mock error"))
+	calendar.update()
+	clusterMgr.EXPECT().GetConfig(any, proto.MaintenanceWindowsConfigKey).Return("{", nil)
+	calendar.update()
+	require.Equal(t, 0, calendar.Concurrency(proto.TaskTypeBalance, 10))
+
+	conf := &MigrateConfig{}
+	conf.DiskConcurrency = 4
+	require.Equal(t, 4, conf.diskConcurrency(proto.TaskTypeBalance))
+	conf.admitConcurrencyFunc = calendar.Concurrency
+	require.Equal(t, 0, conf.diskConcurrency(proto.TaskTypeBalance))
+	require.Equal(t, 12, conf.diskConcurrency(proto.TaskTypeDiskRepair))
+}
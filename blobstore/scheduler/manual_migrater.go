@@ -16,9 +16,14 @@ package scheduler
 
 import (
 	"context"
+	"net/http"
 
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/recordlog"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/taskswitch"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/scheduler/client"
@@ -43,23 +48,34 @@ func NewManualMigrateMgr(clusterMgrCli client.ClusterMgrAPI, volumeUpdater clien
 	return mgr
 }
 
-// AddManualTask add manual migrate task
-func (mgr *ManualMigrateMgr) AddManualTask(ctx context.Context, vuid proto.Vuid, forbiddenDirectDownload bool) (err error) {
+// AddManualTask add manual migrate task of the volume unit, the unit must be on
+// the disk if diskID is valid, such as migrating it off before maintenance of the disk.
+func (mgr *ManualMigrateMgr) AddManualTask(ctx context.Context, vuid proto.Vuid, diskID proto.DiskID,
+	forbiddenDirectDownload bool,
+) (task *proto.MigrateTask, err error) {
 	span := trace.SpanFromContextSafe(ctx)
 
 	volume, err := mgr.clusterMgrCli.GetVolumeInfo(ctx, vuid.Vid())
 	if err != nil {
 		span.Errorf("get volume failed: vid[%d], err[%+v]", vuid.Vid(), err)
-		return err
+		return nil, err
 	}
-	diskID := volume.VunitLocations[vuid.Index()].DiskID
-	disk, err := mgr.clusterMgrCli.GetDiskInfo(ctx, diskID)
+	if int(vuid.Index()) >= len(volume.VunitLocations) {
+		span.Errorf("vuid out of volume units: vuid[%d], units[%d]", vuid, len(volume.VunitLocations))
+		return nil, errcode.ErrIllegalArguments
+	}
+	unit := volume.VunitLocations[vuid.Index()]
+	if diskID != proto.InvalidDiskID && unit.DiskID != diskID {
+		span.Errorf("volume unit not on the disk: vuid[%d], disk_id[%d], unit[%+v]", vuid, diskID, unit)
+		return nil, errcode.ErrIllegalArguments
+	}
+	disk, err := mgr.clusterMgrCli.GetDiskInfo(ctx, unit.DiskID)
 	if err != nil {
-		span.Errorf("get disk info failed:  disk_id[%d], err[%+v]", err)
-		return err
+		span.Errorf("get disk info failed:  disk_id[%d], err[%+v]", unit.DiskID, err)
+		return nil, err
 	}
 
-	task := &proto.MigrateTask{
+	task = &proto.MigrateTask{
 		TaskID:                  client.GenMigrateTaskID(proto.TaskTypeManualMigrate, disk.DiskID, uint32(vuid.Vid())),
 		TaskType:                proto.TaskTypeManualMigrate,
 		State:                   proto.MigrateStateInited,
@@ -71,5 +87,43 @@ func (mgr *ManualMigrateMgr) AddManualTask(ctx context.Context, vuid proto.Vuid,
 	mgr.IMigrator.AddTask(ctx, task)
 
 	span.Debugf("add manual migrate task success: task_info[%+v]", task)
-	return nil
+	return task, nil
+}
+
+// ManualTaskStatus returns status of the manual migrate task on the disk,
+// the task is finished if it has been removed and recorded.
+func (mgr *ManualMigrateMgr) ManualTaskStatus(ctx context.Context, diskID proto.DiskID, taskID string) (*api.ManualMigrateStatus, error) {
+	task, err := mgr.IMigrator.GetTask(ctx, taskID)
+	if err == nil {
+		return &api.ManualMigrateStatus{TaskID: taskID, State: task.State}, nil
+	}
+	if rpc.DetectStatusCode(err) != http.StatusNotFound {
+		return nil, err
+	}
+
+	marker := defaultListTaskMarker
+	for {
+		records, nextMarker, err := mgr.clusterMgrCli.ListTaskRecords(ctx, &cmapi.ListKvOpts{
+			Prefix: client.GenTaskRecordPrefix(diskID),
+			Marker: marker,
+			Count:  maxListTaskRecordsCount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record.TaskID == taskID {
+				return &api.ManualMigrateStatus{
+					TaskID:   taskID,
+					State:    record.State,
+					Finished: true,
+					Record:   record,
+				}, nil
+			}
+		}
+		marker = nextMarker
+		if marker == defaultListTaskMarker {
+			return nil, errcode.ErrNotFound
+		}
+	}
 }
@@ -24,6 +24,7 @@ import (
 
 	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/scheduler/client"
 	"github.com/cubefs/cubefs/blobstore/testing/mocks"
@@ -58,7 +59,7 @@ func TestManualMigrateAddTask(t *testing.T) {
 	{
 		mgr := newManualMigrater(t)
 		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Return(nil, errMock)
-		err := mgr.AddManualTask(ctx, proto.Vuid(1), false)
+		_, err := mgr.AddManualTask(ctx, proto.Vuid(1), proto.InvalidDiskID, false)
 		require.True(t, errors.Is(err, errMock))
 	}
 	{
@@ -66,7 +67,7 @@ func TestManualMigrateAddTask(t *testing.T) {
 		volume := MockGenVolInfo(10001, codemode.EC6P6, proto.VolumeStatusIdle)
 		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Return(volume, nil)
 		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetDiskInfo(any, any).Return(nil, errMock)
-		err := mgr.AddManualTask(ctx, proto.Vuid(1), false)
+		_, err := mgr.AddManualTask(ctx, proto.Vuid(1), proto.InvalidDiskID, false)
 		require.True(t, errors.Is(err, errMock))
 	}
 	{
@@ -75,9 +76,59 @@ func TestManualMigrateAddTask(t *testing.T) {
 		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Return(volume, nil)
 		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetDiskInfo(any, any).Return(&client.DiskInfoSimple{}, nil)
 		mgr.IMigrator.(*MockMigrater).EXPECT().AddTask(any, any).Return()
-		err := mgr.AddManualTask(ctx, proto.Vuid(1), false)
+		_, err := mgr.AddManualTask(ctx, proto.Vuid(1), proto.InvalidDiskID, false)
 		require.NoError(t, err)
 	}
+	{
+		// volume unit is not on the disk
+		mgr := newManualMigrater(t)
+		volume := MockGenVolInfo(10001, codemode.EC6P6, proto.VolumeStatusIdle)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Return(volume, nil).Times(2)
+		vuid := volume.VunitLocations[1].Vuid
+		_, err := mgr.AddManualTask(ctx, vuid, volume.VunitLocations[0].DiskID, false)
+		require.ErrorIs(t, err, errcode.ErrIllegalArguments)
+
+		diskID := volume.VunitLocations[1].DiskID
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetDiskInfo(any, any).Return(&client.DiskInfoSimple{DiskID: diskID}, nil)
+		mgr.IMigrator.(*MockMigrater).EXPECT().AddTask(any, any).Return()
+		task, err := mgr.AddManualTask(ctx, vuid, diskID, false)
+		require.NoError(t, err)
+		require.Equal(t, diskID, task.SourceDiskID)
+		require.Equal(t, vuid, task.SourceVuid)
+	}
+}
+
+func TestManualMigrateTaskStatus(t *testing.T) {
+	ctx := context.Background()
+	mgr := newManualMigrater(t)
+	migrater := mgr.IMigrator.(*MockMigrater)
+	clusterMgr := mgr.clusterMgrCli.(*MockClusterMgrAPI)
+
+	migrater.EXPECT().GetTask(any, any).Return(&proto.MigrateTask{State: proto.MigrateStatePrepared}, nil)
+	status, err := mgr.ManualTaskStatus(ctx, 1, "task1")
+	require.NoError(t, err)
+	require.False(t, status.Finished)
+	require.Equal(t, proto.MigrateStatePrepared, status.State)
+
+	migrater.EXPECT().GetTask(any, any).Return(nil, errMock)
+	_, err = mgr.ManualTaskStatus(ctx, 1, "task1")
+	require.ErrorIs(t, err, errMock)
+
+	// finished task is found in records
+	migrater.EXPECT().GetTask(any, any).Return(nil, errcode.ErrNotFound).Times(2)
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return([]*api.TaskRecord{{TaskID: "task0"}}, "marker", nil)
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return([]*api.TaskRecord{
+		{TaskID: "task1", State: proto.MigrateStateFinished},
+	}, "", nil)
+	status, err = mgr.ManualTaskStatus(ctx, 1, "task1")
+	require.NoError(t, err)
+	require.True(t, status.Finished)
+	require.Equal(t, proto.MigrateStateFinished, status.State)
+	require.Equal(t, "task1", status.Record.TaskID)
+
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return(nil, "", nil)
+	_, err = mgr.ManualTaskStatus(ctx, 1, "task1")
+	require.ErrorIs(t, err, errcode.ErrNotFound)
 }
 
 func TestManualMigrateAcquireTask(t *testing.T) {
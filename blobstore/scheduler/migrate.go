@@ -46,7 +46,7 @@ const (
 // MMigrator merged interfaces for mocking.
 type MMigrator interface {
 	IMigrator
-	IDisKMigrator
+	IDiskDropper
 	IManualMigrator
 }
 
@@ -93,10 +93,17 @@ type IDisKMigrator interface {
 	DiskProcess
 }
 
+// IDiskDropper interface of disk drop
+type IDiskDropper interface {
+	IDisKMigrator
+	RequeueDisk(ctx context.Context, diskID proto.DiskID) error
+}
+
 // IManualMigrator interface of manual migrator
 type IManualMigrator interface {
 	Migrator
-	AddManualTask(ctx context.Context, vuid proto.Vuid, forbiddenDirectDownload bool) (err error)
+	AddManualTask(ctx context.Context, vuid proto.Vuid, diskID proto.DiskID, forbiddenDirectDownload bool) (task *proto.MigrateTask, err error)
+	ManualTaskStatus(ctx context.Context, diskID proto.DiskID, taskID string) (*api.ManualMigrateStatus, error)
 }
 
 // IMigrator interface of common migrator
@@ -325,12 +332,25 @@ type MigrateConfig struct {
 	base.TaskCommonConfig
 
 	lockFailHandleFunc lockFailFunc
+	// validate destination of task before migrating
+	validateMoveFunc validateMoveFunc
 	// clear junk tasks
 	clearJunkTasksWhenLoadingFunc clearJunkTasksFunc
 	// finish drop task
 	finishTaskCallback taskLimitFunc
 	// load drop task
 	loadTaskCallback taskLimitFunc
+	// admission concurrency of maintenance windows
+	admitConcurrencyFunc admitConcurrencyFunc
+	// record finished tasks
+	addTaskRecordFunc addTaskRecordFunc
+}
+
+func (conf *MigrateConfig) diskConcurrency(taskType proto.TaskType) int {
+	if conf.admitConcurrencyFunc == nil {
+		return conf.DiskConcurrency
+	}
+	return conf.admitConcurrencyFunc(taskType, conf.DiskConcurrency)
 }
 
 type clearJunkTasksFunc func(ctx context.Context, tasks []*proto.MigrateTask) error
@@ -343,6 +363,8 @@ type taskLimitFunc func(diskId proto.DiskID)
 
 type lockFailFunc func(ctx context.Context, task *proto.MigrateTask) error
 
+type validateMoveFunc func(ctx context.Context, volume *client.VolumeInfoSimple, src proto.Vuid, dest proto.VunitLocation) error
+
 var defaultDiskTaskLimitFunc = func(diskId proto.DiskID) {
 	_ = struct{}{}
 }
@@ -372,6 +394,7 @@ type MigrateMgr struct {
 	taskLogger recordlog.Encoder
 
 	lockVolFailHandleFunc lockFailFunc
+	validateMoveFunc      validateMoveFunc
 	// clear junk tasks
 	clearJunkTasksCallBack clearJunkTasksFunc
 	// load and finish drop task
@@ -409,6 +432,7 @@ func NewMigrateMgr(
 		finishTaskCallback:     conf.finishTaskCallback,
 		loadTaskCallback:       conf.loadTaskCallback,
 		lockVolFailHandleFunc:  conf.lockFailHandleFunc,
+		validateMoveFunc:       conf.validateMoveFunc,
 
 		Closer: closer.New(),
 	}
@@ -606,6 +630,19 @@ func (mgr *MigrateMgr) prepareTask() (err error) {
 		return
 	}
 
+	if mgr.validateMoveFunc != nil {
+		if errValidate := mgr.validateMoveFunc(ctx, volInfo, migTask.SourceVuid, ret.Location()); errValidate != nil {
+			// the allocated unit is not bound to volume, and its chunk will be collected as rubbish by blobnode
+			span.Warnf("reject unsafe move: task_id[%s], dest[%+v], explain[%s]", migTask.TaskID, ret.Location(), errValidate)
+			if err = mgr.clusterMgrCli.UnlockVolume(ctx, migTask.SourceVuid.Vid()); err != nil {
+				span.Errorf("unlock volume failed: vid[%d], err[%+v]", migTask.SourceVuid.Vid(), err)
+				return err
+			}
+			mgr.finishTaskInAdvance(ctx, migTask, "unsafe move: "+errValidate.Error())
+			return nil
+		}
+	}
+
 	migTask.CodeMode = volInfo.CodeMode
 	migTask.Sources = volInfo.VunitLocations
 	migTask.SetDestination(ret.Location())
@@ -722,6 +759,7 @@ func (mgr *MigrateMgr) finishTask() (err error) {
 	if recordErr := mgr.taskLogger.Encode(migrateTask); recordErr != nil {
 		span.Errorf("record migrate task failed: task[%+v], err[%+v]", migrateTask, recordErr)
 	}
+	mgr.cfg.addTaskRecord(ctx, mgr.taskStatsMgr, migrateTask)
 
 	_ = mgr.finishQueue.RemoveTask(migrateTask.TaskID)
 	_ = mgr.updateVolumeCache(ctx, migrateTask)
@@ -780,6 +818,7 @@ func (mgr *MigrateMgr) finishTaskInAdvance(ctx context.Context, task *proto.Migr
 	if recordErr := mgr.taskLogger.Encode(task); recordErr != nil {
 		span.Errorf("record migrate task failed: task[%+v], err[%+v]", task, recordErr)
 	}
+	mgr.cfg.addTaskRecord(ctx, mgr.taskStatsMgr, task)
 
 	mgr.finishTaskCounter.Add()
 	_ = mgr.prepareQueue.RemoveTask(task.TaskID)
@@ -72,6 +72,7 @@ func newMigrateMgr(t *testing.T) *MigrateMgr {
 		},
 	}
 
+	clusterMgr.EXPECT().AddTaskRecord(any, any).AnyTimes().Return(nil)
 	mgr := NewMigrateMgr(clusterMgr, volumeUpdater, taskSwitch, taskLogger, conf, proto.TaskTypeBalance)
 	return mgr
 }
@@ -233,6 +234,35 @@ func TestPrepareMigrateTask(t *testing.T) {
 		err = mgr.prepareTask()
 		require.NoError(t, err)
 	}
+	{
+		// one task and finish in advance because the move is rejected by validation
+		mgr := newMigrateMgr(t)
+		mgr.validateMoveFunc = func(ctx context.Context, volume *client.VolumeInfoSimple, src proto.Vuid, dest proto.VunitLocation) error {
+			return errMock
+		}
+		volume := MockGenVolInfo(100, codemode.EC6P6, proto.VolumeStatusIdle)
+		t1 := mockGenMigrateTask(proto.TaskTypeManualMigrate, "z0", 4, 100, proto.MigrateStateInited,
+			map[proto.Vid]*client.VolumeInfoSimple{100: volume})
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().AddMigrateTask(any, any).Return(nil)
+		mgr.AddTask(ctx, t1)
+
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Times(2).Return(volume, nil)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().LockVolume(any, any).Times(2).Return(nil)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().AllocVolumeUnit(any, any, any).Times(2).Return(
+			&client.AllocVunitInfo{VunitLocation: proto.VunitLocation{Vuid: t1.SourceVuid + 1}}, nil)
+		// unlock failed
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().UnlockVolume(any, any).Return(errMock)
+		err := mgr.prepareTask()
+		require.True(t, errors.Is(err, errMock))
+		// unlock success
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().UnlockVolume(any, any).Return(nil)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().DeleteMigrateTask(any, any).Return(nil)
+		mgr.taskLogger.(*mocks.MockRecordLogEncoder).EXPECT().Encode(any).Return(nil)
+		err = mgr.prepareTask()
+		require.NoError(t, err)
+		err = mgr.prepareTask()
+		require.True(t, errors.Is(err, base.ErrNoTaskInQueue))
+	}
 }
 
 func TestFinishMigrateTask(t *testing.T) {
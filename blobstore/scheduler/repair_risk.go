@@ -0,0 +1,158 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// lowestRepairPriority is priority of repair task whose volume is unknown
+const lowestRepairPriority = math.MinInt32
+
+// IVolumeRiskReporter reports bad units of volume found by inspection
+type IVolumeRiskReporter interface {
+	ReportInspectBads(vid proto.Vid, bidsBads map[proto.BlobID][]uint8)
+}
+
+// volumeRepairRisks tracks lost units of volumes in repairing, which are units of unfinished
+// repair tasks and missed shards reported by the latest inspection of volume.
+type volumeRepairRisks struct {
+	sync.Mutex
+	tasks       map[proto.Vid]map[string]uint8 // task id -> bad index
+	inspectBads map[proto.Vid][][]uint8        // distinct bad indexes of blobs
+}
+
+func newVolumeRepairRisks() *volumeRepairRisks {
+	return &volumeRepairRisks{
+		tasks:       make(map[proto.Vid]map[string]uint8),
+		inspectBads: make(map[proto.Vid][][]uint8),
+	}
+}
+
+func (r *volumeRepairRisks) addTask(vid proto.Vid, taskID string, badIdx uint8) {
+	r.Lock()
+	defer r.Unlock()
+	tasks, ok := r.tasks[vid]
+	if !ok {
+		tasks = make(map[string]uint8)
+		r.tasks[vid] = tasks
+	}
+	tasks[taskID] = badIdx
+}
+
+func (r *volumeRepairRisks) removeTask(vid proto.Vid, taskID string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.tasks[vid], taskID)
+	if len(r.tasks[vid]) == 0 {
+		delete(r.tasks, vid)
+		delete(r.inspectBads, vid)
+	}
+}
+
+// setInspectBads replaces bads of volume with the latest inspection,
+// it's only tracked for volume in repairing.
+func (r *volumeRepairRisks) setInspectBads(vid proto.Vid, bidsBads map[proto.BlobID][]uint8) bool {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.tasks[vid]; !ok {
+		return false
+	}
+	if len(bidsBads) == 0 {
+		delete(r.inspectBads, vid)
+		return true
+	}
+
+	distinct := make(map[string]struct{}, len(bidsBads))
+	bads := make([][]uint8, 0, len(bidsBads))
+	for _, badIdxs := range bidsBads {
+		key := string(badIdxs)
+		if _, ok := distinct[key]; ok {
+			continue
+		}
+		distinct[key] = struct{}{}
+		bads = append(bads, badIdxs)
+	}
+	r.inspectBads[vid] = bads
+	return true
+}
+
+// lostUnits returns the max lost units of blobs in volume
+func (r *volumeRepairRisks) lostUnits(vid proto.Vid) int {
+	r.Lock()
+	defer r.Unlock()
+	lost := make(map[uint8]struct{}, len(r.tasks[vid]))
+	for _, idx := range r.tasks[vid] {
+		lost[idx] = struct{}{}
+	}
+	maxLost := len(lost)
+	for _, badIdxs := range r.inspectBads[vid] {
+		n := len(lost)
+		for _, idx := range badIdxs {
+			if _, ok := lost[idx]; !ok {
+				n++
+			}
+		}
+		if n > maxLost {
+			maxLost = n
+		}
+	}
+	return maxLost
+}
+
+func (r *volumeRepairRisks) taskIDs(vid proto.Vid) []string {
+	r.Lock()
+	defer r.Unlock()
+	ids := make([]string, 0, len(r.tasks[vid]))
+	for id := range r.tasks[vid] {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// repairPriority returns priority of repair task by durability risk of volume,
+// which is negative remaining redundancy units, the less remaining the higher priority.
+func (mgr *DiskRepairMgr) repairPriority(ctx context.Context, vid proto.Vid) int {
+	volInfo, err := mgr.volumeCache.GetVolume(vid)
+	if err != nil {
+		trace.SpanFromContextSafe(ctx).Warnf("get volume failed and repair with lowest priority: vid[%d], err[%+v]", vid, err)
+		return lowestRepairPriority
+	}
+	return mgr.risks.lostUnits(vid) - volInfo.CodeMode.Tactic().M
+}
+
+// updateRepairPriority recomputes priority of repair tasks of the volume in prepare queue
+func (mgr *DiskRepairMgr) updateRepairPriority(ctx context.Context, vid proto.Vid) {
+	priority := mgr.repairPriority(ctx, vid)
+	for _, taskID := range mgr.risks.taskIDs(vid) {
+		mgr.prepareQueue.UpdateTaskPriority(taskID, priority)
+	}
+}
+
+// ReportInspectBads reports missed shards of volume found by inspection, and re-prioritizes
+// its repair tasks, volume without missed shards is reported with empty bids bads.
+func (mgr *DiskRepairMgr) ReportInspectBads(vid proto.Vid, bidsBads map[proto.BlobID][]uint8) {
+	if !mgr.risks.setInspectBads(vid, bidsBads) {
+		return
+	}
+	span, ctx := trace.StartSpanFromContext(context.Background(), "disk_repair.ReportInspectBads")
+	span.Debugf("update repair priority with inspection: vid[%d], bids bads[%d]", vid, len(bidsBads))
+	mgr.updateRepairPriority(ctx, vid)
+}
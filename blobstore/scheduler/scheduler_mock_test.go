@@ -211,17 +211,18 @@ func (mr *MockMigraterMockRecorder) AcquireTask(arg0, arg1 interface{}) *gomock.
 }
 
 // AddManualTask mocks base method.
-func (m *MockMigrater) AddManualTask(arg0 context.Context, arg1 proto.Vuid, arg2 bool) error {
+func (m *MockMigrater) AddManualTask(arg0 context.Context, arg1 proto.Vuid, arg2 proto.DiskID, arg3 bool) (*proto.MigrateTask, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddManualTask", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AddManualTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*proto.MigrateTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddManualTask indicates an expected call of AddManualTask.
-func (mr *MockMigraterMockRecorder) AddManualTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockMigraterMockRecorder) AddManualTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddManualTask", reflect.TypeOf((*MockMigrater)(nil).AddManualTask), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddManualTask", reflect.TypeOf((*MockMigrater)(nil).AddManualTask), arg0, arg1, arg2, arg3)
 }
 
 // AddTask mocks base method.
@@ -458,6 +459,21 @@ func (mr *MockMigraterMockRecorder) Load() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockMigrater)(nil).Load))
 }
 
+// ManualTaskStatus mocks base method.
+func (m *MockMigrater) ManualTaskStatus(arg0 context.Context, arg1 proto.DiskID, arg2 string) (*scheduler.ManualMigrateStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManualTaskStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*scheduler.ManualMigrateStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ManualTaskStatus indicates an expected call of ManualTaskStatus.
+func (mr *MockMigraterMockRecorder) ManualTaskStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManualTaskStatus", reflect.TypeOf((*MockMigrater)(nil).ManualTaskStatus), arg0, arg1, arg2)
+}
+
 // Progress mocks base method.
 func (m *MockMigrater) Progress(arg0 context.Context) ([]proto.DiskID, int, int) {
 	m.ctrl.T.Helper()
@@ -543,6 +559,20 @@ func (mr *MockMigraterMockRecorder) ReportWorkerTaskStats(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportWorkerTaskStats", reflect.TypeOf((*MockMigrater)(nil).ReportWorkerTaskStats), arg0)
 }
 
+// RequeueDisk mocks base method.
+func (m *MockMigrater) RequeueDisk(arg0 context.Context, arg1 proto.DiskID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueDisk", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueDisk indicates an expected call of RequeueDisk.
+func (mr *MockMigraterMockRecorder) RequeueDisk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueDisk", reflect.TypeOf((*MockMigrater)(nil).RequeueDisk), arg0, arg1)
+}
+
 // Run mocks base method.
 func (m *MockMigrater) Run() {
 	m.ctrl.T.Helper()
@@ -702,6 +732,21 @@ func (mr *MockVolumeInspectorMockRecorder) GetTaskStats() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStats", reflect.TypeOf((*MockVolumeInspector)(nil).GetTaskStats))
 }
 
+// InspectVolume mocks base method.
+func (m *MockVolumeInspector) InspectVolume(arg0 context.Context, arg1 *scheduler.InspectVolumeArgs) (*scheduler.InspectVolumeRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectVolume", arg0, arg1)
+	ret0, _ := ret[0].(*scheduler.InspectVolumeRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectVolume indicates an expected call of InspectVolume.
+func (mr *MockVolumeInspectorMockRecorder) InspectVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectVolume", reflect.TypeOf((*MockVolumeInspector)(nil).InspectVolume), arg0, arg1)
+}
+
 // Run mocks base method.
 func (m *MockVolumeInspector) Run() {
 	m.ctrl.T.Helper()
@@ -15,12 +15,14 @@
 package scheduler
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
 
 	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
@@ -29,7 +31,10 @@ import (
 	"github.com/cubefs/cubefs/blobstore/util/task"
 )
 
-var errIllegalTaskType = rpc.NewError(http.StatusBadRequest, "illegal_type", errcode.ErrIllegalTaskType)
+var (
+	errIllegalTaskType = rpc.NewError(http.StatusBadRequest, "illegal_type", errcode.ErrIllegalTaskType)
+	errNoEmbeddedQueue = rpc.NewError(http.StatusServiceUnavailable, "no_embedded_queue", errors.New("no embedded queue"))
+)
 
 // Service rpc service
 type Service struct {
@@ -39,18 +44,22 @@ type Service struct {
 	followerHosts []string
 
 	balanceMgr    Migrator
-	diskDropMgr   IDisKMigrator
+	diskDropMgr   IDiskDropper
 	diskRepairMgr IDisKMigrator
 	manualMigMgr  IManualMigrator
 	inspectMgr    IVolumeInspector
 
 	shardDiskRepairMgr ShardDiskMigrator
+	maintenance        *maintenanceCalendar
+	clusterMigrateMgr  *ClusterMigrateMgr
 
 	shardRepairMgr  ITaskRunner
 	blobDeleteMgr   ITaskRunner
 	clusterTopology IClusterTopology
 	volumeUpdater   client.IVolumeUpdater
 	kafkaMonitors   []*base.KafkaTopicMonitor
+	embeddedQueue   *base.EmbeddedQueue
+	taskHistory     *taskHistory
 
 	clusterMgrCli client.ClusterMgrAPI
 }
@@ -100,10 +109,10 @@ func (svr *Service) HTTPTaskAcquire(c *rpc.Context) {
 		return
 	}
 
-	// acquire task ordered: returns disk repair task first and other random
+	// acquire task ordered: returns disk repair task first, then manual migrate task and other random
 	ctx := c.Request.Context()
 	migrators := []BaseMigrator{svr.diskRepairMgr, svr.shardDiskRepairMgr, svr.manualMigMgr, svr.diskDropMgr, svr.balanceMgr}
-	shuffledMigrators := migrators[2:]
+	shuffledMigrators := migrators[3:]
 	rand.Shuffle(len(shuffledMigrators), func(i, j int) {
 		shuffledMigrators[i], shuffledMigrators[j] = shuffledMigrators[j], shuffledMigrators[i]
 	})
@@ -290,6 +299,65 @@ func (svr *Service) HTTPDiskMigratingStats(c *rpc.Context) {
 	c.RespondJSON(stats)
 }
 
+// HTTPTaskRecords returns records of finished tasks
+func (svr *Service) HTTPTaskRecords(c *rpc.Context) {
+	args := new(api.ListTaskRecordsArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if args.TaskType != "" && !args.TaskType.Valid() {
+		c.RespondError(errIllegalTaskType)
+		return
+	}
+	ret, err := svr.taskHistory.List(c.Request.Context(), args)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+	c.RespondJSON(ret)
+}
+
+// HTTPTaskDiskMigrateReport returns migration report of disk
+func (svr *Service) HTTPTaskDiskMigrateReport(c *rpc.Context) {
+	args := new(api.DiskMigrateReportArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if args.DiskID == proto.InvalidDiskID {
+		c.RespondError(errcode.ErrIllegalArguments)
+		return
+	}
+	report, err := svr.taskHistory.DiskReport(c.Request.Context(), args.DiskID)
+	if err != nil {
+		c.RespondError(err)
+		return
+	}
+	c.RespondJSON(report)
+}
+
+// HTTPDiskDropRequeue requeues the stuck dropping disk
+func (svr *Service) HTTPDiskDropRequeue(c *rpc.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContextSafe(ctx)
+
+	args := new(api.DiskDropRequeueArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if args.DiskID == proto.InvalidDiskID {
+		c.RespondError(errcode.ErrIllegalArguments)
+		return
+	}
+	span.Warnf("accept requeue dropping disk: disk_id[%d]", args.DiskID)
+
+	if err := svr.diskDropMgr.RequeueDisk(ctx, args.DiskID); err != nil {
+		c.RespondError(rpc.Error2HTTPError(err))
+	}
+}
+
 // HTTPStats returns service stats
 func (svr *Service) HTTPStats(c *rpc.Context) {
 	ctx := c.Request.Context()
@@ -362,6 +430,14 @@ func (svr *Service) HTTPStats(c *rpc.Context) {
 		TimeOutPerMin:  fmt.Sprint(timeout),
 	}
 
+	// stats cluster migrate
+	if svr.clusterMigrateMgr != nil {
+		blobnodeTaskStats.ClusterMigrate = &api.ClusterMigrateStat{
+			Enable:                 svr.clusterMigrateMgr.Enabled(),
+			ClusterMigrateProgress: svr.clusterMigrateMgr.Progress(),
+		}
+	}
+
 	shard := api.ShardTaskStats{}
 	stats := svr.shardDiskRepairMgr.Stats()
 	stats.Enable = svr.shardDiskRepairMgr.Enabled()
@@ -392,8 +468,56 @@ func (svr *Service) HTTPManualMigrateTaskAdd(c *rpc.Context) {
 		return
 	}
 
-	err := svr.manualMigMgr.AddManualTask(ctx, args.Vuid, !args.DirectDownload)
-	c.RespondError(rpc.Error2HTTPError(err))
+	task, err := svr.manualMigMgr.AddManualTask(ctx, args.Unit(), args.DiskID, !args.DirectDownload)
+	if err != nil {
+		c.RespondError(rpc.Error2HTTPError(err))
+		return
+	}
+	c.RespondJSON(api.AddManualMigrateRet{TaskID: task.TaskID, DiskID: task.SourceDiskID})
+}
+
+// HTTPVolumeInspect inspects the volume immediately and repairs its bad shards if required
+func (svr *Service) HTTPVolumeInspect(c *rpc.Context) {
+	ctx := c.Request.Context()
+
+	args := new(api.InspectVolumeArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if args.Vid == proto.InvalidVid {
+		c.RespondError(errcode.ErrIllegalArguments)
+		return
+	}
+
+	ret, err := svr.inspectMgr.InspectVolume(ctx, args)
+	if err != nil {
+		c.RespondError(rpc.Error2HTTPError(err))
+		return
+	}
+	c.RespondJSON(ret)
+}
+
+// HTTPManualMigrateTaskStatus returns status of manual migrate task
+func (svr *Service) HTTPManualMigrateTaskStatus(c *rpc.Context) {
+	ctx := c.Request.Context()
+
+	args := new(api.ManualMigrateStatusArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if args.TaskID == "" || args.DiskID == proto.InvalidDiskID {
+		c.RespondError(errcode.ErrIllegalArguments)
+		return
+	}
+
+	status, err := svr.manualMigMgr.ManualTaskStatus(ctx, args.DiskID, args.TaskID)
+	if err != nil {
+		c.RespondError(rpc.Error2HTTPError(err))
+		return
+	}
+	c.RespondJSON(status)
 }
 
 // HTTPUpdateVolume updates volume cache
@@ -436,3 +560,24 @@ func (svr *Service) HTTPUpdateVolume(c *rpc.Context) {
 	}
 	c.Respond()
 }
+
+// HTTPEmbeddedSend sends messages to embedded queue
+func (svr *Service) HTTPEmbeddedSend(c *rpc.Context) {
+	args := new(mq.SendArgs)
+	if err := c.ParseArgs(args); err != nil {
+		c.RespondError(err)
+		return
+	}
+	if svr.embeddedQueue == nil {
+		c.RespondError(errNoEmbeddedQueue)
+		return
+	}
+
+	span := trace.SpanFromContextSafe(c.Request.Context())
+	if err := svr.embeddedQueue.SendMessages(args.Topic, args.Msgs); err != nil {
+		span.Errorf("send messages to embedded queue failed: topic[%s], len[%d], err[%+v]", args.Topic, len(args.Msgs), err)
+		c.RespondError(err)
+		return
+	}
+	c.Respond()
+}
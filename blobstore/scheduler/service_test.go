@@ -118,7 +118,8 @@ func newMockService(t *testing.T) *Service {
 	shardDiskRepair.EXPECT().ReportTask(any, any).Return(nil)
 
 	// add manual migrate task
-	manualMgr.EXPECT().AddManualTask(any, any, any).Return(nil)
+	manualMgr.EXPECT().AddManualTask(any, any, any, any).Return(&proto.MigrateTask{TaskID: "manual", SourceDiskID: 1}, nil)
+	manualMgr.EXPECT().ManualTaskStatus(any, any, any).Return(&api.ManualMigrateStatus{TaskID: "manual", Finished: true}, nil)
 
 	// acquire inspect task
 	inspectorMgr.EXPECT().AcquireInspect(any).Return(&proto.VolumeInspectTask{}, nil)
@@ -126,6 +127,10 @@ func newMockService(t *testing.T) *Service {
 	// complete inspect task
 	inspectorMgr.EXPECT().CompleteInspect(any, any).Return()
 
+	// inspect volume
+	inspectorMgr.EXPECT().InspectVolume(any, any).Return(&api.InspectVolumeRet{Vid: 1}, nil)
+	inspectorMgr.EXPECT().InspectVolume(any, any).Return(nil, errMock)
+
 	// volume update
 	clusterTopology.EXPECT().UpdateVolume(any).Return(&client.VolumeInfoSimple{}, nil)
 	clusterTopology.EXPECT().UpdateVolume(any).Return(nil, errMock)
@@ -172,6 +177,10 @@ func newMockService(t *testing.T) *Service {
 	diskDropMgr.EXPECT().DiskProgress(any, any).Return(&api.DiskMigratingStats{TotalTasksCnt: int(testDisk1.UsedChunkCnt), MigratedTasksCnt: 1}, nil)
 	shardDiskRepair.EXPECT().DiskProgress(any, any).Return(nil, errMock)
 	shardDiskRepair.EXPECT().DiskProgress(any, any).Return(&api.DiskMigratingStats{TotalTasksCnt: int(testDisk1.UsedChunkCnt), MigratedTasksCnt: 1}, nil)
+
+	// requeue dropping disk
+	diskDropMgr.EXPECT().RequeueDisk(any, any).Return(nil)
+	diskDropMgr.EXPECT().RequeueDisk(any, any).Return(ErrDiskDropNotCollected)
 	service := &Service{
 		ClusterID:     1,
 		leader:        true,
@@ -279,10 +288,28 @@ func TestServiceAPI(t *testing.T) {
 	require.NoError(t, err)
 
 	// add manual migrate task
-	err = cli.AddManualMigrateTask(ctx, &api.AddManualMigrateArgs{})
+	_, err = cli.AddManualMigrateTask(ctx, &api.AddManualMigrateArgs{})
 	require.Equal(t, 400, rpc.DetectStatusCode(err))
-	err = cli.AddManualMigrateTask(ctx, &api.AddManualMigrateArgs{Vuid: proto.Vuid(24726512599042)})
+	_, err = cli.AddManualMigrateTask(ctx, &api.AddManualMigrateArgs{Chunk: "invalid"})
+	require.Equal(t, 400, rpc.DetectStatusCode(err))
+	manualRet, err := cli.AddManualMigrateTask(ctx, &api.AddManualMigrateArgs{Vuid: proto.Vuid(24726512599042)})
+	require.NoError(t, err)
+	require.Equal(t, api.AddManualMigrateRet{TaskID: "manual", DiskID: 1}, manualRet)
+	// manual migrate task status
+	_, err = cli.ManualMigrateTaskStatus(ctx, &api.ManualMigrateStatusArgs{TaskID: "manual"})
+	require.Error(t, err)
+	manualStatus, err := cli.ManualMigrateTaskStatus(ctx, &api.ManualMigrateStatusArgs{TaskID: "manual", DiskID: 1})
 	require.NoError(t, err)
+	require.True(t, manualStatus.Finished)
+
+	// inspect volume
+	_, err = cli.InspectVolume(ctx, &api.InspectVolumeArgs{})
+	require.Error(t, err)
+	inspectRet, err := cli.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: 1, Repair: true})
+	require.NoError(t, err)
+	require.Equal(t, proto.Vid(1), inspectRet.Vid)
+	_, err = cli.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: 1})
+	require.Error(t, err)
 
 	// acquire inspect task
 	_, err = cli.AcquireInspectTask(ctx)
@@ -331,4 +358,11 @@ func TestServiceAPI(t *testing.T) {
 		require.Equal(t, int(testDisk1.UsedChunkCnt), stats.TotalTasksCnt)
 		require.Equal(t, 1, stats.MigratedTasksCnt)
 	}
+
+	// requeue dropping disk
+	requeuer := api.NewDiskDropRequeuer(&api.Config{})
+	err = requeuer.RequeueDropDisk(ctx, schedulerServer.URL, proto.InvalidDiskID)
+	require.Equal(t, 400, rpc.DetectStatusCode(err))
+	require.NoError(t, requeuer.RequeueDropDisk(ctx, schedulerServer.URL, diskID))
+	require.Error(t, requeuer.RequeueDropDisk(ctx, schedulerServer.URL, diskID))
 }
@@ -152,7 +152,7 @@ func (mgr *ShardDiskRepairMgr) collectionTask() {
 		mgr.hasRevised = true
 	}
 
-	if mgr.repairingDisks.size() >= mgr.cfg.DiskConcurrency {
+	if mgr.repairingDisks.size() >= mgr.cfg.diskConcurrency(proto.TaskTypeShardDiskRepair) {
 		return
 	}
 	disk, err := mgr.acquireBrokenDisk(ctx)
@@ -42,6 +42,15 @@ type ShardMigrateConfig struct {
 	AppliedIndexThreshold uint64 `json:"applied_index_threshold"`
 
 	loadTaskCallback loadTaskCallback
+	// admission concurrency of maintenance windows
+	admitConcurrencyFunc admitConcurrencyFunc
+}
+
+func (conf *ShardMigrateConfig) diskConcurrency(taskType proto.TaskType) int {
+	if conf.admitConcurrencyFunc == nil {
+		return conf.DiskConcurrency
+	}
+	return conf.admitConcurrencyFunc(taskType, conf.DiskConcurrency)
 }
 
 type ShardMigrator interface {
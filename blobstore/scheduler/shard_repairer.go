@@ -28,6 +28,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/common/counter"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/kafka"
+	"github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/recordlog"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
@@ -71,17 +72,24 @@ type ShardRepairConfig struct {
 
 	TaskPoolSize   int              `json:"task_pool_size"`
 	OrphanShardLog recordlog.Config `json:"orphan_shard_log"`
+
+	// publish repair completed event of blob with proxy
+	BlobEventEnable bool `json:"blob_event_enable"`
 }
 
 func (cfg *ShardRepairConfig) topics() []string {
 	return append(cfg.Kafka.TopicNormals, cfg.Kafka.TopicFailed)
 }
 
-func (cfg *ShardRepairConfig) failedProducerConfig() *kafka.ProducerCfg {
-	return &kafka.ProducerCfg{
-		BrokerList: cfg.Kafka.BrokerList,
-		Topic:      cfg.Kafka.TopicFailed,
-		TimeoutMs:  cfg.Kafka.FailMsgSenderTimeoutMs,
+func (cfg *ShardRepairConfig) failedProducerConfig() *mq.ProducerCfg {
+	return &mq.ProducerCfg{
+		Backend: cfg.Kafka.Backend,
+		ProducerCfg: kafka.ProducerCfg{
+			BrokerList: cfg.Kafka.BrokerList,
+			Topic:      cfg.Kafka.TopicFailed,
+			TimeoutMs:  cfg.Kafka.FailMsgSenderTimeoutMs,
+		},
+		Embedded: rpc.LbConfig{Hosts: cfg.Kafka.EmbeddedHosts},
 	}
 }
 
@@ -106,6 +114,7 @@ type ShardRepairMgr struct {
 
 	blobnodeCli      client.BlobnodeAPI
 	blobnodeSelector selector.Selector
+	eventSender      client.ProxyAPI
 
 	repairSuccessCounter    prometheus.Counter
 	repairSuccessCounterMin *counter.Counter
@@ -126,6 +135,7 @@ func NewShardRepairMgr(
 	switchMgr *taskswitch.SwitchMgr,
 	blobnodeCli client.BlobnodeAPI,
 	clusterMgrCli client.ClusterMgrAPI,
+	eventSender client.ProxyAPI,
 	kafkaClient base.KafkaConsumer,
 ) (*ShardRepairMgr, error) {
 	taskSwitch, err := switchMgr.AddSwitch(proto.TaskTypeShardRepair.String())
@@ -152,6 +162,7 @@ func NewShardRepairMgr(
 		taskSwitch:       taskSwitch,
 		clusterTopology:  clusterTopology,
 		blobnodeSelector: workerSelector,
+		eventSender:      eventSender,
 
 		kafkaConsumerClient: kafkaClient,
 		failMsgSender:       failMsgSender,
@@ -352,9 +363,21 @@ func (mgr *ShardRepairMgr) consume(ctx context.Context, repairMsg *proto.ShardRe
 		return shardRepairRet{status: ShardRepairStatusFailed, err: err}
 	}
 
+	mgr.sendRepairEvent(ctx, repairMsg)
 	return shardRepairRet{status: ShardRepairStatusDone}
 }
 
+// sendRepairEvent publishes repair completed event, the event is dropped if failed
+func (mgr *ShardRepairMgr) sendRepairEvent(ctx context.Context, repairMsg *proto.ShardRepairMsg) {
+	if !mgr.cfg.BlobEventEnable || mgr.eventSender == nil {
+		return
+	}
+	if err := mgr.eventSender.SendBlobRepairEvent(ctx, repairMsg.Vid, repairMsg.Bid); err != nil {
+		trace.SpanFromContextSafe(ctx).Warnf("send blob repair event failed: vid[%d], bid[%d], err[%+v]",
+			repairMsg.Vid, repairMsg.Bid, err)
+	}
+}
+
 func (mgr *ShardRepairMgr) repairWithCheckVolConsistency(ctx context.Context, repairMsg *proto.ShardRepairMsg) error {
 	return DoubleCheckedRun(ctx, mgr.clusterTopology, repairMsg.Vid, func(info *client.VolumeInfoSimple) (*client.VolumeInfoSimple, error) {
 		return mgr.tryRepair(ctx, info, repairMsg)
@@ -141,6 +141,20 @@ func TestConsumerShardRepairMsg(t *testing.T) {
 		ret := mgr.consume(ctx, msg, commonCloser)
 		require.Equal(t, ShardRepairStatusDone, ret.status)
 	}
+	{
+		// repair success and send blob event
+		eventSender := NewMockMqProxyAPI(ctr)
+		eventSender.EXPECT().SendBlobRepairEvent(any, proto.Vid(1), proto.BlobID(1)).Return(nil)
+		eventSender.EXPECT().SendBlobRepairEvent(any, any, any).Return(errMock)
+		mgr.eventSender = eventSender
+		mgr.cfg.BlobEventEnable = true
+		ret := mgr.consume(ctx, msg, commonCloser)
+		require.Equal(t, ShardRepairStatusDone, ret.status)
+		ret = mgr.consume(ctx, msg, commonCloser)
+		require.Equal(t, ShardRepairStatusDone, ret.status)
+		mgr.cfg.BlobEventEnable = false
+		mgr.eventSender = nil
+	}
 	{
 		// repair failed because worker err
 		oldBlobnode := mgr.blobnodeCli
@@ -248,7 +262,7 @@ func TestNewShardRepairMgr(t *testing.T) {
 	consumer.EXPECT().Stop().AnyTimes().Return()
 	kafkaClient.EXPECT().StartKafkaConsumer(any, any).AnyTimes().Return(consumer, nil)
 
-	mgr, err := NewShardRepairMgr(cfg, clusterTopology, switchMgr, blobnode, clusterCli, kafkaClient)
+	mgr, err := NewShardRepairMgr(cfg, clusterTopology, switchMgr, blobnode, clusterCli, nil, kafkaClient)
 	require.NoError(t, err)
 	require.False(t, mgr.Enabled())
 
@@ -264,7 +278,7 @@ func TestNewShardRepairMgr(t *testing.T) {
 	require.Nil(t, mgr.consumers)
 	mgr.Close()
 
-	_, err = NewShardRepairMgr(cfg, clusterTopology, switchMgr, blobnode, clusterCli, kafkaClient)
+	_, err = NewShardRepairMgr(cfg, clusterTopology, switchMgr, blobnode, clusterCli, nil, kafkaClient)
 	require.Error(t, err)
 }
 
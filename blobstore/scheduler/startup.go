@@ -27,6 +27,7 @@ import (
 	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/cmd"
 	"github.com/cubefs/cubefs/blobstore/common/config"
+	"github.com/cubefs/cubefs/blobstore/common/mq"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/recordlog"
 	"github.com/cubefs/cubefs/blobstore/common/rpc"
@@ -48,6 +49,9 @@ var (
 	errInvalidLeader    = errors.New("invalid leader")
 	errInvalidNodeID    = errors.New("invalid node_id")
 	errInvalidKafka     = errors.New("invalid kafka")
+	errInvalidMQ        = errors.New("invalid mq")
+
+	errInvalidFailureDomain = errors.New("invalid failure domain")
 )
 
 var (
@@ -119,8 +123,23 @@ func NewService(conf *Config) (svr *Service, err error) {
 	}
 	topologyMgr := NewClusterTopologyMgr(clusterMgrCli, topoConf)
 
-	kafkaClient := base.NewKafkaConsumer(conf.Kafka.BrokerList)
-	shardRepairMgr, err := NewShardRepairMgr(&conf.ShardRepair, topologyMgr, switchMgr, blobnodeCli, clusterMgrCli, kafkaClient)
+	var kafkaClient base.KafkaConsumer
+	switch {
+	case !conf.MQ.isEmbedded():
+		kafkaClient = base.NewKafkaConsumer(conf.Kafka.BrokerList)
+	case conf.IsLeader():
+		// messages of embedded queue are stored and consumed on leader
+		svr.embeddedQueue, err = base.OpenEmbeddedQueue(conf.MQ.Embedded)
+		if err != nil {
+			log.Errorf("open embedded queue: cfg[%+v], err[%+v]", conf.MQ.Embedded, err)
+			return nil, err
+		}
+		kafkaClient = svr.embeddedQueue
+	default:
+		kafkaClient = base.NewNoopConsumer()
+	}
+	mqProxy := client.NewProxyClient(&conf.Proxy, cmapi.New(&conf.ClusterMgr), conf.ClusterID)
+	shardRepairMgr, err := NewShardRepairMgr(&conf.ShardRepair, topologyMgr, switchMgr, blobnodeCli, clusterMgrCli, mqProxy, kafkaClient)
 	if err != nil {
 		log.Errorf("new shard repair mgr: cfg[%+v], err[%w]", conf.ShardRepair, err)
 		return nil, err
@@ -137,6 +156,7 @@ func NewService(conf *Config) (svr *Service, err error) {
 	svr.clusterTopology = topologyMgr
 	svr.volumeUpdater = volumeUpdater
 	svr.clusterMgrCli = clusterMgrCli
+	svr.taskHistory = newTaskHistory(clusterMgrCli, conf.IsLeader(), conf.TaskHistory)
 
 	if err = svr.register(conf.ServiceRegister); err != nil {
 		return nil, fmt.Errorf("service register: err:[%w]", err)
@@ -150,10 +170,12 @@ func NewService(conf *Config) (svr *Service, err error) {
 		return
 	}
 
-	err = svr.NewKafkaMonitor(conf.ClusterID)
-	if err != nil {
-		log.Errorf("run kafka monitor failed: err[%w]", err)
-		return nil, err
+	if !conf.MQ.isEmbedded() {
+		err = svr.NewKafkaMonitor(conf.ClusterID)
+		if err != nil {
+			log.Errorf("run kafka monitor failed: err[%w]", err)
+			return nil, err
+		}
 	}
 
 	// //===========blobnode module migrate manager===============
@@ -162,6 +184,19 @@ func NewService(conf *Config) (svr *Service, err error) {
 		return nil, err
 	}
 
+	// maintenance windows scale the admission of balance and repair tasks
+	maintenance := newMaintenanceCalendar(clusterMgrCli)
+	maintenance.Run()
+	conf.Balance.admitConcurrencyFunc = maintenance.Concurrency
+	conf.DiskRepair.admitConcurrencyFunc = maintenance.Concurrency
+	conf.ShardDiskRepair.admitConcurrencyFunc = maintenance.Concurrency
+	svr.maintenance = maintenance
+
+	conf.Balance.addTaskRecordFunc = svr.taskHistory.Add
+	conf.DiskDrop.addTaskRecordFunc = svr.taskHistory.Add
+	conf.DiskRepair.addTaskRecordFunc = svr.taskHistory.Add
+	conf.ManualMigrate.addTaskRecordFunc = svr.taskHistory.Add
+
 	balanceTaskSwitch, err := switchMgr.AddSwitch(proto.TaskTypeBalance.String())
 	if err != nil {
 		return nil, err
@@ -172,7 +207,7 @@ func NewService(conf *Config) (svr *Service, err error) {
 	if err != nil {
 		return nil, err
 	}
-	diskDropMgr := NewDiskDropMgr(clusterMgrCli, volumeUpdater, diskDropTaskSwitch, taskLogger, &conf.DiskDrop, topologyMgr)
+	diskDropMgr := NewDiskDropMgr(clusterMgrCli, blobnodeCli, volumeUpdater, diskDropTaskSwitch, taskLogger, &conf.DiskDrop, topologyMgr)
 
 	// new disk repair manager
 	diskRepairTaskSwitch, err := switchMgr.AddSwitch(proto.TaskTypeDiskRepair.String())
@@ -180,16 +215,15 @@ func NewService(conf *Config) (svr *Service, err error) {
 		return nil, err
 	}
 
-	diskRepairMgr := NewDiskRepairMgr(clusterMgrCli, diskRepairTaskSwitch, taskLogger, &conf.DiskRepair)
+	diskRepairMgr := NewDiskRepairMgr(clusterMgrCli, diskRepairTaskSwitch, taskLogger, &conf.DiskRepair, topologyMgr)
 
 	manualMigMgr := NewManualMigrateMgr(clusterMgrCli, volumeUpdater, taskLogger, &conf.ManualMigrate)
 
-	mqProxy := client.NewProxyClient(&conf.Proxy, cmapi.New(&conf.ClusterMgr), conf.ClusterID)
 	inspectorTaskSwitch, err := switchMgr.AddSwitch(proto.TaskTypeVolumeInspect.String())
 	if err != nil {
 		return nil, err
 	}
-	inspectMgr := NewVolumeInspectMgr(clusterMgrCli, mqProxy, inspectorTaskSwitch, &conf.VolumeInspect)
+	inspectMgr := NewVolumeInspectMgr(clusterMgrCli, blobnodeCli, mqProxy, topologyMgr, diskRepairMgr, inspectorTaskSwitch, &conf.VolumeInspect)
 
 	//===========shard module migrate manager===============
 	// new shard disk repair manager
@@ -199,12 +233,26 @@ func NewService(conf *Config) (svr *Service, err error) {
 	}
 	shardDiskRepairMgr := NewShardDiskRepairMgr(&conf.ShardDiskRepair, clusterMgrCli, shardDiskRepairTaskSwitch)
 
+	//===========cluster migrate manager===============
+	if conf.ClusterMigrate.Enable {
+		clusterMigrateTaskSwitch, err := switchMgr.AddSwitch(proto.TaskTypeClusterMigrate.String())
+		if err != nil {
+			return nil, err
+		}
+		svr.clusterMigrateMgr, err = NewClusterMigrateMgr(&conf.ClusterMigrate, clusterMgrCli, clusterMigrateTaskSwitch)
+		if err != nil {
+			log.Errorf("new cluster migrate mgr failed: err[%+v]", err)
+			return nil, err
+		}
+	}
+
 	svr.balanceMgr = balanceMgr
 	svr.diskDropMgr = diskDropMgr
 	svr.manualMigMgr = manualMigMgr
 	svr.diskRepairMgr = diskRepairMgr
 	svr.inspectMgr = inspectMgr
 	svr.shardDiskRepairMgr = shardDiskRepairMgr
+	svr.taskHistory.Run()
 
 	err = svr.waitAndLoad()
 	if err != nil {
@@ -265,6 +313,9 @@ func (svr *Service) Run() {
 	svr.manualMigMgr.Run()
 	svr.inspectMgr.Run()
 	svr.shardDiskRepairMgr.Run()
+	if svr.clusterMigrateMgr != nil {
+		svr.clusterMigrateMgr.Run()
+	}
 }
 
 // RunTask run shard repair and blob delete tasks
@@ -355,6 +406,9 @@ func (svr *Service) Close() {
 	log.Infof("stop scheduler service")
 	svr.blobDeleteMgr.Close()
 	svr.shardRepairMgr.Close()
+	if svr.embeddedQueue != nil {
+		svr.embeddedQueue.Close()
+	}
 	if !svr.leader {
 		return
 	}
@@ -365,6 +419,15 @@ func (svr *Service) Close() {
 	svr.manualMigMgr.Close()
 	svr.inspectMgr.Close()
 	svr.shardDiskRepairMgr.Close()
+	if svr.clusterMigrateMgr != nil {
+		svr.clusterMigrateMgr.Close()
+	}
+	if svr.maintenance != nil {
+		svr.maintenance.Close()
+	}
+	if svr.taskHistory != nil {
+		svr.taskHistory.Close()
+	}
 }
 
 // NewHandler returns app server handler
@@ -372,6 +435,8 @@ func NewHandler(service *Service) *rpc.Router {
 	rpc.RegisterArgsParser(&api.AcquireArgs{}, "json")
 	rpc.RegisterArgsParser(&api.DiskMigratingStatsArgs{}, "json")
 	rpc.RegisterArgsParser(&api.MigrateTaskDetailArgs{}, "json")
+	rpc.RegisterArgsParser(&api.ListTaskRecordsArgs{}, "json")
+	rpc.RegisterArgsParser(&api.DiskMigrateReportArgs{}, "json")
 
 	// rpc http svr interface
 	rpc.GET(api.PathTaskAcquire, service.HTTPTaskAcquire, rpc.OptArgsQuery())
@@ -379,9 +444,11 @@ func NewHandler(service *Service) *rpc.Router {
 	rpc.POST(api.PathTaskCancel, service.HTTPTaskCancel, rpc.OptArgsBody())
 	rpc.POST(api.PathTaskComplete, service.HTTPTaskComplete, rpc.OptArgsBody())
 	rpc.POST(api.PathManualMigrateTaskAdd, service.HTTPManualMigrateTaskAdd, rpc.OptArgsBody())
+	rpc.GET(api.PathManualMigrateTaskStatus, service.HTTPManualMigrateTaskStatus, rpc.OptArgsQuery())
 
 	rpc.GET(api.PathInspectAcquire, service.HTTPInspectAcquire)
 	rpc.POST(api.PathInspectComplete, service.HTTPInspectComplete, rpc.OptArgsBody())
+	rpc.POST(api.PathVolumeInspect, service.HTTPVolumeInspect, rpc.OptArgsBody())
 
 	rpc.POST(api.PathTaskReport, service.HTTPTaskReport, rpc.OptArgsBody())
 	rpc.POST(api.PathTaskRenewal, service.HTTPTaskRenewal, rpc.OptArgsBody())
@@ -390,8 +457,13 @@ func NewHandler(service *Service) *rpc.Router {
 	rpc.GET(api.PathStats, service.HTTPStats, rpc.OptArgsQuery())
 	rpc.GET(api.PathStatsLeader, service.HTTPStats, rpc.OptArgsQuery())
 	rpc.GET(api.PathStatsDiskMigrating, service.HTTPDiskMigratingStats, rpc.OptArgsQuery())
+	rpc.GET(api.PathTaskRecords, service.HTTPTaskRecords, rpc.OptArgsQuery())
+	rpc.GET(api.PathTaskDiskMigrateReport, service.HTTPTaskDiskMigrateReport, rpc.OptArgsQuery())
+	rpc.POST(api.PathDiskDropRequeue, service.HTTPDiskDropRequeue, rpc.OptArgsBody())
 
 	rpc.POST(api.PathUpdateVolume, service.HTTPUpdateVolume, rpc.OptArgsBody())
 
+	rpc.POST(mq.PathEmbeddedSend, service.HTTPEmbeddedSend, rpc.OptArgsBody())
+
 	return rpc.DefaultRouter
 }
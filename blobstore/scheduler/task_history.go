@@ -0,0 +1,310 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/scheduler/base"
+	"github.com/cubefs/cubefs/blobstore/scheduler/client"
+	"github.com/cubefs/cubefs/blobstore/util/closer"
+)
+
+const (
+	defaultTaskHistoryRetentionDays  = 30
+	defaultTaskHistoryCleanIntervalS = 3600
+	defaultTaskHistoryMaxRecords     = 100000
+	defaultTaskHistoryBatchSize      = 100
+	defaultTaskHistoryFlushIntervalS = 5
+	taskRecordQueueBatches           = 10
+	defaultListTaskRecordsCount      = 100
+	maxListTaskRecordsCount          = 1000
+	defaultListTaskMarker            = ""
+)
+
+// TaskHistoryConfig config of finished task records
+type TaskHistoryConfig struct {
+	RetentionDays  int `json:"retention_days"`
+	CleanIntervalS int `json:"clean_interval_s"`
+	// keep at most the newest MaxRecords records, older ones are removed by clean
+	MaxRecords int `json:"max_records"`
+	// records are written to clustermgr in batches asynchronously
+	BatchSize      int `json:"batch_size"`
+	FlushIntervalS int `json:"flush_interval_s"`
+}
+
+// newTaskRecord returns record of the finished task with its running statistics
+func newTaskRecord(task *proto.MigrateTask, statsMgr *base.TaskStatsMgr) *api.TaskRecord {
+	record := &api.TaskRecord{
+		TaskID:            task.TaskID,
+		TaskType:          task.TaskType,
+		State:             task.State,
+		SourceDiskID:      task.SourceDiskID,
+		SourceVuid:        task.SourceVuid,
+		DestinationDiskID: task.Destination.DiskID,
+		DestinationVuid:   task.Destination.Vuid,
+		RedoCount:         task.WorkerRedoCnt,
+		Error:             task.FinishAdvanceReason,
+		FinishTime:        time.Now(),
+	}
+	if detail, err := statsMgr.QueryTaskDetail(task.TaskID); err == nil {
+		record.BytesMoved = detail.Statistics.DoneSize
+		record.ShardsMoved = detail.Statistics.DoneCount
+		record.StartTime = detail.StartTime
+		record.DurationMs = record.FinishTime.Sub(detail.StartTime).Milliseconds()
+	}
+	return record
+}
+
+type addTaskRecordFunc func(ctx context.Context, record *api.TaskRecord)
+
+// addTaskRecord queues record of the finished task if task history is enabled
+func (conf *MigrateConfig) addTaskRecord(ctx context.Context, statsMgr *base.TaskStatsMgr, task *proto.MigrateTask) {
+	if conf.addTaskRecordFunc == nil {
+		return
+	}
+	conf.addTaskRecordFunc(ctx, newTaskRecord(task, statsMgr))
+}
+
+// taskHistory records and queries finished tasks, and cleans expired records on leader
+type taskHistory struct {
+	closer.Closer
+	clusterMgrCli client.ClusterMgrTaskAPI
+	leader        bool
+	retention     time.Duration
+	cleanInterval time.Duration
+	maxRecords    int
+	batchSize     int
+	flushInterval time.Duration
+	records       chan *api.TaskRecord
+}
+
+func newTaskHistory(clusterMgrCli client.ClusterMgrTaskAPI, leader bool, cfg TaskHistoryConfig) *taskHistory {
+	return &taskHistory{
+		Closer:        closer.New(),
+		clusterMgrCli: clusterMgrCli,
+		leader:        leader,
+		retention:     time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		cleanInterval: time.Duration(cfg.CleanIntervalS) * time.Second,
+		maxRecords:    cfg.MaxRecords,
+		batchSize:     cfg.BatchSize,
+		flushInterval: time.Duration(cfg.FlushIntervalS) * time.Second,
+		records:       make(chan *api.TaskRecord, cfg.BatchSize*taskRecordQueueBatches),
+	}
+}
+
+// Add queues the record to write, drop it if the queue is full as records are only for auditing
+func (h *taskHistory) Add(ctx context.Context, record *api.TaskRecord) {
+	select {
+	case h.records <- record:
+	default:
+		trace.SpanFromContextSafe(ctx).Warnf("task record queue is full, drop record: record[%+v]", record)
+	}
+}
+
+// Run writes queued records, and cleans expired records periodically on leader
+func (h *taskHistory) Run() {
+	go h.loopFlush()
+	if !h.leader {
+		return
+	}
+	go func() {
+		t := time.NewTicker(h.cleanInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				span, ctx := trace.StartSpanFromContext(context.Background(), "task_history.clean")
+				if err := h.clean(ctx); err != nil {
+					span.Errorf("clean task records failed: err[%+v]", err)
+				}
+			case <-h.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (h *taskHistory) loopFlush() {
+	t := time.NewTicker(h.flushInterval)
+	defer t.Stop()
+	batch := make([]*api.TaskRecord, 0, h.batchSize)
+	for {
+		select {
+		case record := <-h.records:
+			batch = append(batch, record)
+			if len(batch) < h.batchSize {
+				continue
+			}
+		case <-t.C:
+		case <-h.Done():
+			for len(h.records) > 0 {
+				batch = append(batch, <-h.records)
+			}
+			h.flush(batch)
+			return
+		}
+		h.flush(batch)
+		batch = batch[:0]
+	}
+}
+
+func (h *taskHistory) flush(records []*api.TaskRecord) {
+	if len(records) == 0 {
+		return
+	}
+	span, ctx := trace.StartSpanFromContext(context.Background(), "task_history.flush")
+	for _, record := range records {
+		if err := h.clusterMgrCli.AddTaskRecord(ctx, record); err != nil {
+			span.Errorf("add task record failed: record[%+v], err[%+v]", record, err)
+		}
+	}
+}
+
+// clean removes expired records, and the oldest records beyond max records
+func (h *taskHistory) clean(ctx context.Context) error {
+	span := trace.SpanFromContextSafe(ctx)
+	if !h.leader {
+		return nil
+	}
+	expired := time.Now().Add(-h.retention)
+	marker := defaultListTaskMarker
+	cleaned := 0
+	var remains []*api.TaskRecord
+	for {
+		records, nextMarker, err := h.clusterMgrCli.ListTaskRecords(ctx, &cmapi.ListKvOpts{
+			Prefix: client.GenTaskRecordPrefix(proto.InvalidDiskID),
+			Marker: marker,
+			Count:  maxListTaskRecordsCount,
+		})
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if record.FinishTime.After(expired) {
+				remains = append(remains, record)
+				continue
+			}
+			if err = h.clusterMgrCli.DeleteTaskRecord(ctx, record); err != nil {
+				return err
+			}
+			cleaned++
+		}
+		marker = nextMarker
+		if marker == defaultListTaskMarker {
+			break
+		}
+	}
+
+	if len(remains) > h.maxRecords {
+		sort.Slice(remains, func(i, j int) bool {
+			return remains[i].FinishTime.Before(remains[j].FinishTime)
+		})
+		for _, record := range remains[:len(remains)-h.maxRecords] {
+			if err := h.clusterMgrCli.DeleteTaskRecord(ctx, record); err != nil {
+				return err
+			}
+			cleaned++
+		}
+	}
+	span.Infof("clean task records: count[%d], before[%s], max[%d]", cleaned, expired, h.maxRecords)
+	return nil
+}
+
+// List returns records of finished tasks filtered by disk and task type
+func (h *taskHistory) List(ctx context.Context, args *api.ListTaskRecordsArgs) (*api.ListTaskRecordsRet, error) {
+	count := args.Count
+	if count <= 0 {
+		count = defaultListTaskRecordsCount
+	}
+	if count > maxListTaskRecordsCount {
+		count = maxListTaskRecordsCount
+	}
+
+	ret := &api.ListTaskRecordsRet{Marker: args.Marker}
+	for len(ret.Records) < count {
+		records, marker, err := h.clusterMgrCli.ListTaskRecords(ctx, &cmapi.ListKvOpts{
+			Prefix: client.GenTaskRecordPrefix(args.DiskID),
+			Marker: ret.Marker,
+			Count:  count - len(ret.Records),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if args.TaskType == "" || record.TaskType == args.TaskType {
+				ret.Records = append(ret.Records, record)
+			}
+		}
+		ret.Marker = marker
+		if marker == defaultListTaskMarker {
+			break
+		}
+	}
+	return ret, nil
+}
+
+// DiskReport returns migration report of the disk by its task records
+func (h *taskHistory) DiskReport(ctx context.Context, diskID proto.DiskID) (*api.DiskMigrateReport, error) {
+	report := &api.DiskMigrateReport{
+		DiskID:       diskID,
+		TaskTypes:    make(map[proto.TaskType]int),
+		Destinations: make(map[proto.DiskID]int),
+		Errors:       make(map[string]int),
+	}
+	marker := defaultListTaskMarker
+	for {
+		records, nextMarker, err := h.clusterMgrCli.ListTaskRecords(ctx, &cmapi.ListKvOpts{
+			Prefix: client.GenTaskRecordPrefix(diskID),
+			Marker: marker,
+			Count:  maxListTaskRecordsCount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			report.TaskCount++
+			report.TaskTypes[record.TaskType]++
+			switch record.State {
+			case proto.MigrateStateFinished:
+				report.FinishedCount++
+				report.Destinations[record.DestinationDiskID]++
+			case proto.MigrateStateFinishedInAdvance:
+				report.FinishedInAdvanceCount++
+				report.Errors[record.Error]++
+			default:
+			}
+			report.BytesMoved += record.BytesMoved
+			report.ShardsMoved += record.ShardsMoved
+			if !record.StartTime.IsZero() && (report.FirstStartTime.IsZero() || record.StartTime.Before(report.FirstStartTime)) {
+				report.FirstStartTime = record.StartTime
+			}
+			if record.FinishTime.After(report.LastFinishTime) {
+				report.LastFinishTime = record.FinishTime
+			}
+		}
+		marker = nextMarker
+		if marker == defaultListTaskMarker {
+			break
+		}
+	}
+	return report, nil
+}
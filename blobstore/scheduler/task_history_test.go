@@ -0,0 +1,182 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/scheduler/client"
+)
+
+var testTaskHistoryConfig = TaskHistoryConfig{
+	RetentionDays: 1, CleanIntervalS: 1, MaxRecords: 2, BatchSize: 2, FlushIntervalS: 1,
+}
+
+func TestTaskHistoryList(t *testing.T) {
+	ctx := context.Background()
+	ctr := gomock.NewController(t)
+	clusterMgr := NewMockClusterMgrAPI(ctr)
+	history := newTaskHistory(clusterMgr, true, testTaskHistoryConfig)
+	defer history.Close()
+
+	records := []*api.TaskRecord{
+		{TaskID: "t1", TaskType: proto.TaskTypeBalance, SourceDiskID: 1},
+		{TaskID: "t2", TaskType: proto.TaskTypeDiskRepair, SourceDiskID: 1},
+		{TaskID: "t3", TaskType: proto.TaskTypeBalance, SourceDiskID: 1},
+	}
+	clusterMgr.EXPECT().ListTaskRecords(any, any).DoAndReturn(
+		func(_ context.Context, opts *cmapi.ListKvOpts) ([]*api.TaskRecord, string, error) {
+			require.Equal(t, client.GenTaskRecordPrefix(1), opts.Prefix)
+			if opts.Marker == "" {
+				return records[:2], "m1", nil
+			}
+			return records[2:], "", nil
+		}).Times(2)
+	ret, err := history.List(ctx, &api.ListTaskRecordsArgs{DiskID: 1, TaskType: proto.TaskTypeBalance, Count: 2})
+	require.NoError(t, err)
+	require.Len(t, ret.Records, 2)
+	require.Equal(t, "t3", ret.Records[1].TaskID)
+	require.Equal(t, "", ret.Marker)
+
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return(nil, "", errMock)
+	_, err = history.List(ctx, &api.ListTaskRecordsArgs{DiskID: 1})
+	require.ErrorIs(t, err, errMock)
+}
+
+func TestTaskHistoryDiskReport(t *testing.T) {
+	ctx := context.Background()
+	ctr := gomock.NewController(t)
+	clusterMgr := NewMockClusterMgrAPI(ctr)
+	history := newTaskHistory(clusterMgr, true, testTaskHistoryConfig)
+	defer history.Close()
+
+	now := time.Now()
+	records := []*api.TaskRecord{
+		{
+			TaskID: "t1", TaskType: proto.TaskTypeBalance, State: proto.MigrateStateFinished,
+			SourceDiskID: 1, DestinationDiskID: 2, BytesMoved: 100, ShardsMoved: 2,
+			StartTime: now.Add(-time.Hour), FinishTime: now.Add(-time.Minute),
+		},
+		{
+			TaskID: "t2", TaskType: proto.TaskTypeBalance, State: proto.MigrateStateFinishedInAdvance,
+			SourceDiskID: 1, Error: "volume not idle", FinishTime: now,
+		},
+	}
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return(records, "", nil)
+	report, err := history.DiskReport(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.TaskCount)
+	require.Equal(t, 1, report.FinishedCount)
+	require.Equal(t, 1, report.FinishedInAdvanceCount)
+	require.Equal(t, uint64(100), report.BytesMoved)
+	require.Equal(t, 2, report.TaskTypes[proto.TaskTypeBalance])
+	require.Equal(t, 1, report.Destinations[2])
+	require.Equal(t, 1, report.Errors["volume not idle"])
+	require.True(t, report.FirstStartTime.Equal(records[0].StartTime))
+	require.True(t, report.LastFinishTime.Equal(now))
+
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return(nil, "", errMock)
+	_, err = history.DiskReport(ctx, 1)
+	require.ErrorIs(t, err, errMock)
+}
+
+func TestTaskHistoryClean(t *testing.T) {
+	ctx := context.Background()
+	ctr := gomock.NewController(t)
+	clusterMgr := NewMockClusterMgrAPI(ctr)
+	history := newTaskHistory(clusterMgr, true, testTaskHistoryConfig)
+	defer history.Close()
+
+	records := []*api.TaskRecord{
+		{TaskID: "expired", FinishTime: time.Now().Add(-48 * time.Hour)},
+		{TaskID: "recent", FinishTime: time.Now()},
+	}
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return(records, "", nil)
+	clusterMgr.EXPECT().DeleteTaskRecord(any, records[0]).Return(nil)
+	require.NoError(t, history.clean(ctx))
+
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return(records, "", nil)
+	clusterMgr.EXPECT().DeleteTaskRecord(any, records[0]).Return(errMock)
+	require.ErrorIs(t, history.clean(ctx), errMock)
+}
+
+func TestTaskHistoryCleanMaxRecords(t *testing.T) {
+	ctx := context.Background()
+	ctr := gomock.NewController(t)
+	clusterMgr := NewMockClusterMgrAPI(ctr)
+	history := newTaskHistory(clusterMgr, true, testTaskHistoryConfig)
+	defer history.Close()
+
+	now := time.Now()
+	records := []*api.TaskRecord{
+		{TaskID: "t2", FinishTime: now.Add(-2 * time.Minute)},
+		{TaskID: "t1", FinishTime: now.Add(-3 * time.Minute)},
+		{TaskID: "t3", FinishTime: now.Add(-time.Minute)},
+	}
+	clusterMgr.EXPECT().ListTaskRecords(any, any).Return(records, "", nil)
+	clusterMgr.EXPECT().DeleteTaskRecord(any, records[1]).Return(nil)
+	require.NoError(t, history.clean(ctx))
+
+	// follower never cleans
+	follower := newTaskHistory(clusterMgr, false, testTaskHistoryConfig)
+	defer follower.Close()
+	require.NoError(t, follower.clean(ctx))
+}
+
+func TestTaskHistoryAdd(t *testing.T) {
+	ctr := gomock.NewController(t)
+	clusterMgr := NewMockClusterMgrAPI(ctr)
+	history := newTaskHistory(clusterMgr, true, testTaskHistoryConfig)
+
+	added := make(chan string, 4)
+	clusterMgr.EXPECT().AddTaskRecord(any, any).DoAndReturn(
+		func(_ context.Context, record *api.TaskRecord) error {
+			added <- record.TaskID
+			return nil
+		}).Times(3)
+	go history.loopFlush()
+
+	ctx := context.Background()
+	history.Add(ctx, &api.TaskRecord{TaskID: "t1"})
+	history.Add(ctx, &api.TaskRecord{TaskID: "t2"})
+	require.Equal(t, "t1", <-added)
+	require.Equal(t, "t2", <-added)
+
+	// remaining records are flushed when closed
+	history.Add(ctx, &api.TaskRecord{TaskID: "t3"})
+	history.Close()
+	require.Equal(t, "t3", <-added)
+}
+
+func TestTaskHistoryAddQueueFull(t *testing.T) {
+	ctr := gomock.NewController(t)
+	clusterMgr := NewMockClusterMgrAPI(ctr)
+	history := newTaskHistory(clusterMgr, true, testTaskHistoryConfig)
+	defer history.Close()
+
+	ctx := context.Background()
+	for i := 0; i < cap(history.records)+1; i++ {
+		history.Add(ctx, &api.TaskRecord{TaskID: "t"})
+	}
+	require.Len(t, history.records, cap(history.records))
+}
@@ -16,19 +16,27 @@ package scheduler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/common/counter"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/rpc"
 	"github.com/cubefs/cubefs/blobstore/common/taskswitch"
 	"github.com/cubefs/cubefs/blobstore/common/trace"
 	"github.com/cubefs/cubefs/blobstore/scheduler/base"
 	"github.com/cubefs/cubefs/blobstore/scheduler/client"
 	"github.com/cubefs/cubefs/blobstore/util/closer"
+	"github.com/cubefs/cubefs/blobstore/util/defaulter"
 	"github.com/cubefs/cubefs/blobstore/util/log"
 	"github.com/cubefs/cubefs/blobstore/util/retry"
 )
@@ -37,6 +45,7 @@ import (
 type IVolumeInspector interface {
 	AcquireInspect(ctx context.Context) (*proto.VolumeInspectTask, error)
 	CompleteInspect(ctx context.Context, ret *proto.VolumeInspectRet)
+	InspectVolume(ctx context.Context, args *api.InspectVolumeArgs) (*api.InspectVolumeRet, error)
 	GetTaskStats() (finished, timeout [counter.SLOT]int)
 	Enabled() bool
 	Run()
@@ -51,18 +60,26 @@ const (
 
 // manager of volumes inspect
 // batch execution in steps(Non-persistent)
-// step1.gen inspect task
+// step1.gen inspect task of sampled volumes
 // step2.worker execute inspect task
 // step3.collect inspect missed shards info and notice mq proxy
+// the position is checkpointed after the missed shards of all former volumes are noticed,
+// so the inspection continues from the checkpoint after leader switched.
+// volumes of timeout tasks are requeued and inspected once again in next batch.
 var (
 	errTaskHasAcquired  = errors.New("task has been acquired")
 	errForbiddenAcquire = errors.New("forbidden acquire task")
 )
 
 type inspectTaskInfo struct {
+	vid         proto.Vid
 	t           *proto.VolumeInspectTask
 	ret         *proto.VolumeInspectRet
 	acquireTime *time.Time
+	// missed shards of the completed task has been noticed
+	reported bool
+	// task of requeued volume is not requeued again if timeout
+	requeued bool
 }
 
 func (t *inspectTaskInfo) tryAcquire() error {
@@ -145,7 +162,9 @@ func (d *badShardDeduplicator) key(vid proto.Vid, bid proto.BlobID, badIdxs []ui
 	return fmt.Sprintf("%d-%d-%v", vid, bid, badIdxs)
 }
 
-// VolumeInspectMgrCfg inspect task manager config
+// VolumeInspectMgrCfg inspect task manager config,
+// it can be tuned at runtime by clustermgr config of proto.VolumeInspectConfigKey,
+// which overrides the fields of local config except inspect interval.
 type VolumeInspectMgrCfg struct {
 	InspectIntervalS int `json:"inspect_interval_s"`
 	InspectBatch     int `json:"inspect_batch"`
@@ -156,6 +175,61 @@ type VolumeInspectMgrCfg struct {
 
 	// timeout of inspect
 	TimeoutMs int `json:"timeout_ms"`
+
+	// SampleRate rate of volumes to inspect in (0, 1], others means inspect all volumes.
+	// IDCSampleRates sample rate of volumes in the idc, the max rate of idcs
+	// which the volume located in is used.
+	SampleRate     float64            `json:"sample_rate"`
+	IDCSampleRates map[string]float64 `json:"idc_sample_rates"`
+
+	// interval of checkpointing position in the running batch
+	CheckpointIntervalS int `json:"checkpoint_interval_s"`
+}
+
+// CheckAndFix check and fix inspect config
+func (cfg *VolumeInspectMgrCfg) CheckAndFix() {
+	defaulter.LessOrEqual(&cfg.TimeoutMs, defaultInspectTimeoutMs)
+	defaulter.LessOrEqual(&cfg.ListVolStep, defaultListVolStep)
+	defaulter.LessOrEqual(&cfg.ListVolIntervalMs, defaultListVolIntervalMs)
+	defaulter.LessOrEqual(&cfg.InspectBatch, defaultInspectBatch)
+	if cfg.InspectBatch < cfg.ListVolStep {
+		cfg.InspectBatch = cfg.ListVolStep
+	}
+	defaulter.LessOrEqual(&cfg.InspectIntervalS, defaultInspectIntervalS)
+	defaulter.LessOrEqual(&cfg.CheckpointIntervalS, defaultInspectCheckpointIntervalS)
+}
+
+func validSampleRate(rate float64) bool {
+	return rate > 0 && rate < 1
+}
+
+// sampleRate returns sample rate of volume located in the idcs
+func (cfg *VolumeInspectMgrCfg) sampleRate(idcs map[string]struct{}) float64 {
+	rate := cfg.SampleRate
+	if !validSampleRate(rate) {
+		rate = 1
+	}
+	if len(cfg.IDCSampleRates) == 0 || len(idcs) == 0 {
+		return rate
+	}
+
+	var (
+		maxRate float64
+		matched bool
+	)
+	for idc := range idcs {
+		idcRate, ok := cfg.IDCSampleRates[idc]
+		if !ok {
+			idcRate = rate
+		} else if !validSampleRate(idcRate) {
+			idcRate = 1
+		}
+		if !matched || idcRate > maxRate {
+			maxRate = idcRate
+			matched = true
+		}
+	}
+	return maxRate
 }
 
 // VolumeInspectMgr inspect task manager
@@ -171,25 +245,37 @@ type VolumeInspectMgr struct {
 	startVid proto.Vid
 	// start vid in next batch
 	nextVid proto.Vid
+	// volumes of timeout tasks to inspect in next batch
+	requeueVids []proto.Vid
 
 	firstPrepare bool
+	// last time of checkpointing in the running batch
+	checkpointTime time.Time
 
 	taskSwitch    taskswitch.ISwitcher
 	clusterMgrCli client.ClusterMgrAPI
+	blobnodeCli   client.BlobnodeAPI
+	topology      IClusterTopology
 
 	repairShardSender client.ProxyAPI
 	sendDeduplicator  *badShardDeduplicator
+	riskReporter      IVolumeRiskReporter
 
 	completeTaskCounter counter.Counter
 	timeoutCounter      counter.Counter
 
-	cfg *VolumeInspectMgrCfg
+	// local config and the effective one tuned at runtime
+	localCfg VolumeInspectMgrCfg
+	cfg      *VolumeInspectMgrCfg
 }
 
 // NewVolumeInspectMgr returns inspect task manager
 func NewVolumeInspectMgr(
 	clusterMgrCli client.ClusterMgrAPI,
+	blobnodeCli client.BlobnodeAPI,
 	repairShardSender client.ProxyAPI,
+	topology IClusterTopology,
+	riskReporter IVolumeRiskReporter,
 	taskSwitch taskswitch.ISwitcher, cfg *VolumeInspectMgrCfg,
 ) *VolumeInspectMgr {
 	return &VolumeInspectMgr{
@@ -199,8 +285,12 @@ func NewVolumeInspectMgr(
 		firstPrepare:      true,
 		taskSwitch:        taskSwitch,
 		clusterMgrCli:     clusterMgrCli,
+		blobnodeCli:       blobnodeCli,
+		topology:          topology,
 		repairShardSender: repairShardSender,
 		sendDeduplicator:  newBadShardDeduplicator(defaultDuplicateCnt),
+		riskReporter:      riskReporter,
+		localCfg:          *cfg,
 		cfg:               cfg,
 	}
 }
@@ -234,11 +324,40 @@ func (mgr *VolumeInspectMgr) inspectRun() {
 	span, ctx := trace.StartSpanFromContext(context.Background(), "inspector.run")
 	defer span.Finish()
 
+	mgr.loadRuntimeConfig(ctx)
 	mgr.prepare(ctx)
 	mgr.waitCompleted(ctx)
 	mgr.finish(ctx)
 }
 
+// loadRuntimeConfig tunes the local config by clustermgr config,
+// keeps the current config if failed.
+func (mgr *VolumeInspectMgr) loadRuntimeConfig(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	value, err := mgr.clusterMgrCli.GetConfig(ctx, proto.VolumeInspectConfigKey)
+	if err != nil && !strings.Contains(err.Error(), errcode.ErrNotFound.Error()) {
+		span.Errorf("get inspect config failed: err[%+v]", err)
+		return
+	}
+
+	cfg := mgr.localCfg
+	cfg.IDCSampleRates = nil
+	if value != "" {
+		if err = json.Unmarshal([]byte(value), &cfg); err != nil {
+			span.Errorf("decode inspect config failed: value[%s], err[%+v]", value, err)
+			return
+		}
+		if cfg.IDCSampleRates == nil {
+			cfg.IDCSampleRates = mgr.localCfg.IDCSampleRates
+		}
+	}
+	cfg.InspectIntervalS = mgr.localCfg.InspectIntervalS
+	cfg.CheckAndFix()
+	mgr.cfg = &cfg
+	span.Debugf("inspect config: %+v", cfg)
+}
+
 func (mgr *VolumeInspectMgr) enableAcquire(enable bool) {
 	mgr.acquireEnableL.Lock()
 	defer mgr.acquireEnableL.Unlock()
@@ -254,8 +373,12 @@ func (mgr *VolumeInspectMgr) canAcquire() bool {
 func (mgr *VolumeInspectMgr) getStartVid(ctx context.Context) proto.Vid {
 	if mgr.firstPrepare {
 		mgr.firstPrepare = false
-		ck, err := mgr.clusterMgrCli.GetVolumeInspectCheckPoint(ctx)
-		if err == nil {
+		var ck *proto.VolumeInspectCheckPoint
+		err := retry.Timed(3, 200).On(func() (err error) {
+			ck, err = mgr.clusterMgrCli.GetVolumeInspectCheckPoint(ctx)
+			return
+		})
+		if err == nil && ck != nil {
 			return ck.StartVid
 		}
 		log.Warnf("firstPrepare get check point failed: err[%+v]", err)
@@ -282,6 +405,12 @@ func (mgr *VolumeInspectMgr) prepare(ctx context.Context) {
 	startVid := mgr.startVid
 	span.Infof("start prepare inspect task: start vid[%d]", startVid)
 
+	var diskIDCs map[proto.DiskID]string
+	if len(mgr.cfg.IDCSampleRates) > 0 {
+		diskIDCs = mgr.getDiskIDCs()
+	}
+
+	volCnt = mgr.prepareRequeued(ctx)
 	for volCnt < mgr.cfg.InspectBatch {
 		remainCnt := mgr.cfg.InspectBatch - volCnt
 		listStep := mgr.cfg.ListVolStep
@@ -306,9 +435,14 @@ func (mgr *VolumeInspectMgr) prepare(ctx context.Context) {
 				span.Infof("volume is active and skip: vid[%d]", vol.Vid)
 				continue
 			}
+			if !mgr.sampled(vol, diskIDCs) {
+				span.Debugf("volume is not sampled and skip: vid[%d]", vol.Vid)
+				continue
+			}
 
 			taskID := mgr.genTaskID(vol)
 			mgr.tasks[taskID] = &inspectTaskInfo{
+				vid:         vol.Vid,
 				t:           mgr.genInspectTask(taskID, vol),
 				ret:         nil,
 				acquireTime: nil,
@@ -326,6 +460,56 @@ func (mgr *VolumeInspectMgr) prepare(ctx context.Context) {
 	span.Infof("prepare finished: next vid[%d], task count[%d]", nextVid, len(mgr.tasks))
 }
 
+// prepareRequeued generates tasks of requeued volumes, returns count of the tasks
+func (mgr *VolumeInspectMgr) prepareRequeued(ctx context.Context) int {
+	span := trace.SpanFromContextSafe(ctx)
+
+	volCnt := 0
+	for _, vid := range mgr.requeueVids {
+		vol, err := mgr.clusterMgrCli.GetVolumeInfo(ctx, vid)
+		if err != nil {
+			span.Errorf("get requeued volume failed and skip: vid[%d], err[%+v]", vid, err)
+			continue
+		}
+		if vol.IsActive() {
+			span.Infof("requeued volume is active and skip: vid[%d]", vid)
+			continue
+		}
+
+		taskID := mgr.genTaskID(vol)
+		mgr.tasks[taskID] = &inspectTaskInfo{
+			vid:      vol.Vid,
+			t:        mgr.genInspectTask(taskID, vol),
+			requeued: true,
+		}
+		span.Debugf("prepare requeued inspect task: vid[%d], task_id[%s]", vol.Vid, taskID)
+		volCnt++
+	}
+	mgr.requeueVids = nil
+	return volCnt
+}
+
+func (mgr *VolumeInspectMgr) getDiskIDCs() map[proto.DiskID]string {
+	diskIDCs := make(map[proto.DiskID]string)
+	for idc := range mgr.topology.GetIDCs() {
+		for _, disk := range mgr.topology.GetIDCDisks(idc) {
+			diskIDCs[disk.DiskID] = idc
+		}
+	}
+	return diskIDCs
+}
+
+func (mgr *VolumeInspectMgr) sampled(vol *client.VolumeInfoSimple, diskIDCs map[proto.DiskID]string) bool {
+	idcs := make(map[string]struct{})
+	for _, location := range vol.VunitLocations {
+		if idc, ok := diskIDCs[location.DiskID]; ok {
+			idcs[idc] = struct{}{}
+		}
+	}
+	rate := mgr.cfg.sampleRate(idcs)
+	return rate >= 1 || rand.Float64() < rate
+}
+
 // AcquireInspect acquire inspect task
 func (mgr *VolumeInspectMgr) AcquireInspect(ctx context.Context) (*proto.VolumeInspectTask, error) {
 	if !mgr.canAcquire() {
@@ -388,6 +572,7 @@ func (mgr *VolumeInspectMgr) waitCompleted(ctx context.Context) {
 			if mgr.allTaskCompleted() {
 				return
 			}
+			mgr.checkpoint(ctx)
 		case <-mgr.Closer.Done():
 			return
 		}
@@ -410,19 +595,10 @@ func (mgr *VolumeInspectMgr) finish(ctx context.Context) {
 	span := trace.SpanFromContextSafe(ctx)
 	span.Infof("start finish inspect tasks...")
 
-	mgr.tasksL.Lock()
-	defer mgr.tasksL.Unlock()
-
-	// collect missed bids
-	var missedShards [][]*proto.MissedShard
-	for _, task := range mgr.tasks {
-		if task.hasMissedShard() {
-			missedShards = append(missedShards, task.ret.MissedShards)
-			continue
-		}
-	}
+	mgr.reportCompleted(ctx)
 
 	// clear & stats tasks
+	mgr.tasksL.Lock()
 	for taskID, task := range mgr.tasks {
 		span.Debugf("check task and clear: task_id[%s]", taskID)
 		if task.completed() {
@@ -430,11 +606,95 @@ func (mgr *VolumeInspectMgr) finish(ctx context.Context) {
 		}
 
 		if task.timeout(time.Duration(mgr.cfg.TimeoutMs)) {
-			span.Debugf("inspect timeout: task_id[%s]", taskID)
 			mgr.timeoutCounter.Add()
+			if task.requeued {
+				span.Warnf("requeued inspect timeout again and skip: task_id[%s], vid[%d]", taskID, task.vid)
+			} else {
+				span.Debugf("inspect timeout and requeue: task_id[%s], vid[%d]", taskID, task.vid)
+				mgr.requeueVids = append(mgr.requeueVids, task.vid)
+			}
 		}
 		delete(mgr.tasks, taskID)
 	}
+	mgr.tasksL.Unlock()
+
+	// requeued volumes are not inspected yet, keep them behind the checkpoint
+	vid := mgr.nextVid
+	for _, requeueVid := range mgr.requeueVids {
+		if requeueVid < vid {
+			vid = requeueVid
+		}
+	}
+	mgr.saveCheckpoint(ctx, vid)
+}
+
+// checkpoint saves the min vid of the tasks in running batch which are not reported,
+// after noticing the missed shards of completed tasks. timeout tasks are not reported
+// and will be requeued, except the requeued ones.
+func (mgr *VolumeInspectMgr) checkpoint(ctx context.Context) {
+	if time.Since(mgr.checkpointTime) < time.Duration(mgr.cfg.CheckpointIntervalS)*time.Second {
+		return
+	}
+	mgr.reportCompleted(ctx)
+
+	mgr.tasksL.Lock()
+	vid := mgr.nextVid
+	found := false
+	for _, task := range mgr.tasks {
+		if task.reported || (task.requeued && task.timeout(time.Duration(mgr.cfg.TimeoutMs))) {
+			continue
+		}
+		if !found || task.vid < vid {
+			vid = task.vid
+			found = true
+		}
+	}
+	mgr.tasksL.Unlock()
+
+	mgr.saveCheckpoint(ctx, vid)
+}
+
+func (mgr *VolumeInspectMgr) saveCheckpoint(ctx context.Context, vid proto.Vid) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	mgr.checkpointTime = time.Now()
+	err := retry.Timed(3, 200).On(func() error {
+		return mgr.clusterMgrCli.SetVolumeInspectCheckPoint(ctx, vid)
+	})
+	if err != nil {
+		span.Warnf("save checkpoint failed: vid[%d], err[%+v]", vid, err)
+		return
+	}
+	span.Debugf("save checkpoint: vid[%d]", vid)
+}
+
+// reportCompleted notices the missed shards of completed tasks which are not reported
+func (mgr *VolumeInspectMgr) reportCompleted(ctx context.Context) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	// collect missed bids
+	var (
+		missedShards [][]*proto.MissedShard
+		cleanVids    []proto.Vid
+	)
+	mgr.tasksL.Lock()
+	for _, task := range mgr.tasks {
+		if !task.completed() || task.reported {
+			continue
+		}
+		task.reported = true
+		if task.hasMissedShard() {
+			missedShards = append(missedShards, task.ret.MissedShards)
+		} else if task.ret.Err() == nil {
+			cleanVids = append(cleanVids, task.vid)
+		}
+	}
+	mgr.tasksL.Unlock()
+
+	// volume without missed shards clears its risk
+	for _, vid := range cleanVids {
+		mgr.reportRisk(vid, nil)
+	}
 
 	// post repair shard msg
 	for _, volMissedShards := range missedShards {
@@ -456,6 +716,7 @@ func (mgr *VolumeInspectMgr) finish(ctx context.Context) {
 			span.Errorf("collect volume inspect bads failed: vid[%d], err[%+v]", vid, err)
 			continue
 		}
+		mgr.reportRisk(vid, bidsBads)
 
 		for bid, bads := range bidsBads {
 			span.Infof("inspect missed: vid[%d], bid[%d], shards[%+v]", vid, bid, bads)
@@ -464,12 +725,11 @@ func (mgr *VolumeInspectMgr) finish(ctx context.Context) {
 			})
 		}
 	}
+}
 
-	err := retry.Timed(3, 200).On(func() error {
-		return mgr.clusterMgrCli.SetVolumeInspectCheckPoint(ctx, mgr.nextVid)
-	})
-	if err != nil {
-		span.Warnf("save checkpoint failed: err[%+v]", err)
+func (mgr *VolumeInspectMgr) reportRisk(vid proto.Vid, bidsBads map[proto.BlobID][]uint8) {
+	if mgr.riskReporter != nil {
+		mgr.riskReporter.ReportInspectBads(vid, bidsBads)
 	}
 }
 
@@ -531,6 +791,196 @@ func (mgr *VolumeInspectMgr) trySendShardRepairMsg(ctx context.Context, vid prot
 	return nil
 }
 
+// InspectVolume inspects shards of the volume or the blob immediately, bypassing the inspection cycle,
+// the repair msg of bad shards is sent if required and the volume is not active.
+func (mgr *VolumeInspectMgr) InspectVolume(ctx context.Context, args *api.InspectVolumeArgs) (*api.InspectVolumeRet, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	volInfo, err := mgr.clusterMgrCli.GetVolumeInfo(ctx, args.Vid)
+	if err != nil {
+		span.Errorf("get volume info failed: vid[%d], err[%+v]", args.Vid, err)
+		return nil, err
+	}
+
+	ret := &api.InspectVolumeRet{Vid: volInfo.Vid, Active: volInfo.IsActive()}
+	if args.Bid == proto.InValidBlobID {
+		ret.Units, ret.Blobs = mgr.inspectVolumeShards(ctx, volInfo)
+	} else {
+		ret.Units, ret.Blobs = mgr.inspectBlobShards(ctx, volInfo, args.Bid)
+	}
+	span.Infof("inspect volume: vid[%d], bid[%d], bad blobs[%d]", args.Vid, args.Bid, len(ret.Blobs))
+
+	if !args.Repair {
+		return ret, nil
+	}
+	if ret.Active {
+		span.Warnf("volume is active and skip repair: vid[%d]", volInfo.Vid)
+		return ret, nil
+	}
+	for i := range ret.Blobs {
+		blob := &ret.Blobs[i]
+		if len(blob.Bads) == 0 || !blob.Recoverable {
+			continue
+		}
+		// send repair msg regardless of deduplicator, the shards may be lost again
+		if err = mgr.repairShardSender.SendShardRepairMsg(ctx, volInfo.Vid, blob.Bid, blob.Bads); err != nil {
+			span.Errorf("send shard repair msg failed: vid[%d], bid[%d], err[%+v]", volInfo.Vid, blob.Bid, err)
+			return nil, err
+		}
+		mgr.sendDeduplicator.add(volInfo.Vid, blob.Bid, blob.Bads)
+		blob.Repairing = true
+		span.Infof("send shard repair msg success: vid[%d], bid[%d], bad idxs[%+v]", volInfo.Vid, blob.Bid, blob.Bads)
+	}
+	return ret, nil
+}
+
+// inspectVolumeShards lists shards of all volume units, returns blobs with missed shards,
+// shards on unit which failed to list are not taken as missed.
+func (mgr *VolumeInspectMgr) inspectVolumeShards(ctx context.Context, volInfo *client.VolumeInfoSimple) (
+	[]api.InspectUnit, []api.InspectBlob,
+) {
+	units := make([]api.InspectUnit, len(volInfo.VunitLocations))
+	unitShards := make([]map[proto.BlobID]*bnapi.ShardInfo, len(volInfo.VunitLocations))
+	var wg sync.WaitGroup
+	for idx, location := range volInfo.VunitLocations {
+		units[idx] = newInspectUnit(location)
+		wg.Add(1)
+		go func(idx int, location proto.VunitLocation) {
+			defer wg.Done()
+			shards, err := mgr.blobnodeCli.ListShards(ctx, location)
+			if err != nil {
+				units[idx].Err = err.Error()
+				return
+			}
+			units[idx].ShardCnt = len(shards)
+			unitShards[idx] = make(map[proto.BlobID]*bnapi.ShardInfo, len(shards))
+			for _, shard := range shards {
+				unitShards[idx][shard.Bid] = shard
+			}
+		}(idx, location)
+	}
+	wg.Wait()
+
+	bids := make([]proto.BlobID, 0)
+	allBids := make(map[proto.BlobID]struct{})
+	for _, shards := range unitShards {
+		for bid := range shards {
+			if _, ok := allBids[bid]; !ok {
+				allBids[bid] = struct{}{}
+				bids = append(bids, bid)
+			}
+		}
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i] < bids[j] })
+
+	var blobs []api.InspectBlob
+	for _, bid := range bids {
+		var (
+			bads     []uint8
+			existCnt int
+			markDel  bool
+		)
+		for idx, shards := range unitShards {
+			if shards == nil {
+				continue
+			}
+			shard, ok := shards[bid]
+			if !ok {
+				bads = append(bads, units[idx].Index)
+				continue
+			}
+			if shard.Flag == bnapi.ShardStatusMarkDelete {
+				markDel = true
+				break
+			}
+			existCnt++
+		}
+		if markDel || len(bads) == 0 {
+			continue
+		}
+		sortBads(bads)
+		blobs = append(blobs, api.InspectBlob{
+			Bid:         bid,
+			Bads:        bads,
+			Recoverable: existCnt >= volInfo.CodeMode.Tactic().N,
+		})
+	}
+	return units, blobs
+}
+
+// inspectBlobShards stats and reads shards of the blob on all volume units
+func (mgr *VolumeInspectMgr) inspectBlobShards(ctx context.Context, volInfo *client.VolumeInfoSimple, bid proto.BlobID) (
+	[]api.InspectUnit, []api.InspectBlob,
+) {
+	units := make([]api.InspectUnit, len(volInfo.VunitLocations))
+	shards := make([]api.InspectShard, len(volInfo.VunitLocations))
+	var wg sync.WaitGroup
+	for idx, location := range volInfo.VunitLocations {
+		units[idx] = newInspectUnit(location)
+		wg.Add(1)
+		go func(idx int, location proto.VunitLocation) {
+			defer wg.Done()
+			shards[idx] = mgr.inspectShard(ctx, location, bid)
+		}(idx, location)
+	}
+	wg.Wait()
+
+	blob := api.InspectBlob{Bid: bid, Shards: shards}
+	existCnt := 0
+	for _, shard := range shards {
+		switch shard.Status {
+		case api.InspectShardNormal:
+			existCnt++
+		case api.InspectShardMissing, api.InspectShardCorrupt:
+			blob.Bads = append(blob.Bads, shard.Index)
+		case api.InspectShardMarkDeleted:
+			// blob is deleting, nothing to repair
+			blob.Bads = nil
+			return units, []api.InspectBlob{blob}
+		}
+	}
+	sortBads(blob.Bads)
+	blob.Recoverable = existCnt >= volInfo.CodeMode.Tactic().N
+	return units, []api.InspectBlob{blob}
+}
+
+func (mgr *VolumeInspectMgr) inspectShard(ctx context.Context, location proto.VunitLocation, bid proto.BlobID) api.InspectShard {
+	shard := api.InspectShard{Index: location.Vuid.Index(), Status: api.InspectShardUnknown}
+	info, err := mgr.blobnodeCli.StatShard(ctx, location, bid)
+	if err != nil {
+		if rpc.DetectStatusCode(err) == errcode.CodeBidNotFound {
+			shard.Status = api.InspectShardMissing
+			return shard
+		}
+		shard.Err = err.Error()
+		return shard
+	}
+	shard.Size, shard.Crc = info.Size, info.Crc
+	if info.Flag == bnapi.ShardStatusMarkDelete {
+		shard.Status = api.InspectShardMarkDeleted
+		return shard
+	}
+
+	if err = mgr.blobnodeCli.VerifyShard(ctx, location, bid); err != nil {
+		shard.Err = err.Error()
+		if rpc.DetectStatusCode(err) == errcode.CodeShardCrcMismatch {
+			shard.Status = api.InspectShardCorrupt
+		}
+		return shard
+	}
+	shard.Status = api.InspectShardNormal
+	return shard
+}
+
+func newInspectUnit(location proto.VunitLocation) api.InspectUnit {
+	return api.InspectUnit{
+		Index:  location.Vuid.Index(),
+		Vuid:   location.Vuid,
+		Host:   location.Host,
+		DiskID: location.DiskID,
+	}
+}
+
 func (mgr *VolumeInspectMgr) allVolVisited() bool {
 	return mgr.startVid == mgr.nextVid
 }
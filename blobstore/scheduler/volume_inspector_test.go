@@ -23,7 +23,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	bnapi "github.com/cubefs/cubefs/blobstore/api/blobnode"
+	api "github.com/cubefs/cubefs/blobstore/api/scheduler"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/scheduler/client"
 	"github.com/cubefs/cubefs/blobstore/testing/mocks"
@@ -68,9 +71,11 @@ func newInspector(t *testing.T) *VolumeInspectMgr {
 	ctr := gomock.NewController(t)
 	clusterMgr := NewMockClusterMgrAPI(ctr)
 	taskSwitch := mocks.NewMockSwitcher(ctr)
+	blobnodeCli := NewMockBlobnodeAPI(ctr)
 	shardRepairSender := NewMockMqProxyAPI(ctr)
+	topology := NewMockClusterTopology(ctr)
 	conf := &VolumeInspectMgrCfg{InspectIntervalS: defaultInspectIntervalS, TimeoutMs: 1}
-	return NewVolumeInspectMgr(clusterMgr, shardRepairSender, taskSwitch, conf)
+	return NewVolumeInspectMgr(clusterMgr, blobnodeCli, shardRepairSender, topology, nil, taskSwitch, conf)
 }
 
 func TestInspectorRun(t *testing.T) {
@@ -80,6 +85,7 @@ func TestInspectorRun(t *testing.T) {
 	mgr.taskSwitch.(*mocks.MockSwitcher).EXPECT().Enabled().AnyTimes().Return(true)
 	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInspectCheckPoint(any).AnyTimes().Return(nil, errMock)
 	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().SetVolumeInspectCheckPoint(any, any).AnyTimes().Return(errMock)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetConfig(any, any).AnyTimes().Return("", errMock)
 
 	require.True(t, mgr.Enabled())
 	go mgr.Run()
@@ -132,6 +138,152 @@ func TestInspectorPrepare(t *testing.T) {
 	}
 }
 
+func TestInspectorRuntimeConfig(t *testing.T) {
+	ctx := context.Background()
+	mgr := newInspector(t)
+	mgr.localCfg.CheckAndFix()
+	cli := mgr.clusterMgrCli.(*MockClusterMgrAPI)
+
+	// keep current config if get failed
+	cli.EXPECT().GetConfig(any, any).Return("", errMock)
+	cfg := mgr.cfg
+	mgr.loadRuntimeConfig(ctx)
+	require.True(t, cfg == mgr.cfg)
+
+	cli.EXPECT().GetConfig(any, any).Return(`{"inspect_batch":200,"inspect_interval_s":100,"idc_sample_rates":{"z0":0.5}}`, nil)
+	mgr.loadRuntimeConfig(ctx)
+	require.Equal(t, 200, mgr.cfg.InspectBatch)
+	require.Equal(t, defaultInspectIntervalS, mgr.cfg.InspectIntervalS)
+	require.Equal(t, 0.5, mgr.cfg.IDCSampleRates["z0"])
+	require.Nil(t, mgr.localCfg.IDCSampleRates)
+
+	cli.EXPECT().GetConfig(any, any).Return("{", nil)
+	mgr.loadRuntimeConfig(ctx)
+	require.Equal(t, 200, mgr.cfg.InspectBatch)
+
+	cli.EXPECT().GetConfig(any, any).Return("", errcode.ErrNotFound)
+	mgr.loadRuntimeConfig(ctx)
+	require.Equal(t, defaultInspectBatch, mgr.cfg.InspectBatch)
+	require.Nil(t, mgr.cfg.IDCSampleRates)
+}
+
+func TestInspectorSampleRate(t *testing.T) {
+	cfg := &VolumeInspectMgrCfg{}
+	require.Equal(t, 1.0, cfg.sampleRate(nil))
+	cfg.SampleRate = 0.1
+	require.Equal(t, 0.1, cfg.sampleRate(map[string]struct{}{"z0": {}}))
+	cfg.IDCSampleRates = map[string]float64{"z0": 0.5, "z1": 0.01, "z2": 2}
+	require.Equal(t, 0.5, cfg.sampleRate(map[string]struct{}{"z0": {}, "z1": {}}))
+	require.Equal(t, 0.01, cfg.sampleRate(map[string]struct{}{"z1": {}}))
+	require.Equal(t, 0.1, cfg.sampleRate(map[string]struct{}{"z1": {}, "z3": {}}))
+	require.Equal(t, 1.0, cfg.sampleRate(map[string]struct{}{"z2": {}}))
+
+	// volume located in z1 is hardly sampled
+	ctx := context.Background()
+	mgr := newInspector(t)
+	mgr.firstPrepare = false
+	mgr.cfg.InspectBatch = 10
+	mgr.cfg.ListVolStep = 10
+	mgr.cfg.IDCSampleRates = map[string]float64{"z1": 1e-9}
+	vols := []*client.VolumeInfoSimple{
+		MockGenVolInfo(100012, codemode.EC6P6, proto.VolumeStatusIdle),
+		MockGenVolInfo(100013, codemode.EC6P6, proto.VolumeStatusIdle),
+	}
+	mgr.topology.(*MockClusterTopology).EXPECT().GetIDCs().Return(map[string]*IDC{"z0": {}, "z1": {}})
+	mgr.topology.(*MockClusterTopology).EXPECT().GetIDCDisks("z0").Return(nil)
+	mgr.topology.(*MockClusterTopology).EXPECT().GetIDCDisks("z1").Return(
+		[]*client.DiskInfoSimple{{DiskID: vols[1].VunitLocations[0].DiskID, Idc: "z1"}})
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().ListVolume(any, any, any).Return(vols, proto.Vid(100014), nil)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().ListVolume(any, any, any).Return(nil, proto.Vid(0), nil)
+	mgr.prepare(ctx)
+	require.Equal(t, 1, len(mgr.tasks))
+	for _, task := range mgr.tasks {
+		require.Equal(t, proto.Vid(100012), task.vid)
+	}
+}
+
+func TestInspectorCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	mgr := newInspector(t)
+	mgr.cfg.TimeoutMs = 10000
+	mgr.nextVid = 100
+	for vid := proto.Vid(10); vid < 13; vid++ {
+		vol := MockGenVolInfo(vid, codemode.EC6P6, proto.VolumeStatusIdle)
+		taskID := mgr.genTaskID(vol)
+		mgr.tasks[taskID] = &inspectTaskInfo{vid: vid, t: mgr.genInspectTask(taskID, vol)}
+	}
+	cli := mgr.clusterMgrCli.(*MockClusterMgrAPI)
+
+	// checkpoint the min vid of unreported tasks
+	mgr.tasks[mgr.genTaskID(&client.VolumeInfoSimple{Vid: 10})].ret = &proto.VolumeInspectRet{}
+	cli.EXPECT().SetVolumeInspectCheckPoint(any, proto.Vid(11)).Return(nil)
+	mgr.checkpoint(ctx)
+
+	// in checkpoint interval
+	mgr.cfg.CheckpointIntervalS = 100
+	mgr.checkpoint(ctx)
+
+	mgr.cfg.CheckpointIntervalS = 0
+	mgr.tasks[mgr.genTaskID(&client.VolumeInfoSimple{Vid: 11})].ret = &proto.VolumeInspectRet{
+		MissedShards: genMockFailShards(11, []proto.BlobID{3}),
+	}
+	mgr.tasks[mgr.genTaskID(&client.VolumeInfoSimple{Vid: 12})].ret = &proto.VolumeInspectRet{}
+	cli.EXPECT().GetVolumeInfo(any, proto.Vid(11)).Return(MockGenVolInfo(11, codemode.EC6P6, proto.VolumeStatusIdle), nil)
+	mgr.repairShardSender.(*MockMqProxyAPI).EXPECT().SendShardRepairMsg(any, proto.Vid(11), proto.BlobID(3), any).Return(nil)
+	cli.EXPECT().SetVolumeInspectCheckPoint(any, proto.Vid(100)).Return(nil)
+	mgr.checkpoint(ctx)
+
+	// reported tasks are not noticed again
+	cli.EXPECT().SetVolumeInspectCheckPoint(any, proto.Vid(100)).Return(nil)
+	mgr.finish(ctx)
+	require.Equal(t, 0, len(mgr.tasks))
+}
+
+func TestInspectorRequeueTimeout(t *testing.T) {
+	ctx := context.Background()
+	mgr := newInspector(t)
+	mgr.cfg.TimeoutMs = 1
+	mgr.cfg.InspectBatch = 2
+	mgr.cfg.ListVolStep = 2
+	mgr.firstPrepare = false
+	mgr.nextVid = 100
+	acquired := time.Now().Add(-time.Second)
+	for vid := proto.Vid(10); vid < 12; vid++ {
+		vol := MockGenVolInfo(vid, codemode.EC6P6, proto.VolumeStatusIdle)
+		taskID := mgr.genTaskID(vol)
+		mgr.tasks[taskID] = &inspectTaskInfo{vid: vid, t: mgr.genInspectTask(taskID, vol), acquireTime: &acquired}
+	}
+	mgr.tasks[mgr.genTaskID(&client.VolumeInfoSimple{Vid: 11})].ret = &proto.VolumeInspectRet{}
+	cli := mgr.clusterMgrCli.(*MockClusterMgrAPI)
+
+	// timeout task is kept behind the checkpoint
+	cli.EXPECT().SetVolumeInspectCheckPoint(any, proto.Vid(10)).Return(nil)
+	mgr.checkpoint(ctx)
+	cli.EXPECT().SetVolumeInspectCheckPoint(any, proto.Vid(10)).Return(nil)
+	mgr.finish(ctx)
+	require.Equal(t, []proto.Vid{10}, mgr.requeueVids)
+
+	// requeued volume is inspected in next batch
+	mgr.startVid = 0
+	cli.EXPECT().GetVolumeInfo(any, proto.Vid(10)).Return(MockGenVolInfo(10, codemode.EC6P6, proto.VolumeStatusIdle), nil)
+	cli.EXPECT().ListVolume(any, proto.Vid(100), 1).Return(
+		[]*client.VolumeInfoSimple{MockGenVolInfo(100, codemode.EC6P6, proto.VolumeStatusIdle)}, proto.Vid(101), nil)
+	mgr.prepare(ctx)
+	require.Equal(t, 2, len(mgr.tasks))
+	require.Nil(t, mgr.requeueVids)
+
+	// requeued task is not requeued again
+	for _, task := range mgr.tasks {
+		task.acquireTime = &acquired
+		if task.vid == 100 {
+			task.ret = &proto.VolumeInspectRet{}
+		}
+	}
+	cli.EXPECT().SetVolumeInspectCheckPoint(any, proto.Vid(101)).Return(nil)
+	mgr.finish(ctx)
+	require.Nil(t, mgr.requeueVids)
+}
+
 func TestInspectorWaitCompleted(t *testing.T) {
 	ctx := context.Background()
 	{
@@ -225,6 +377,46 @@ func TestInspectorFinish(t *testing.T) {
 	}
 }
 
+type mockRiskReporter map[proto.Vid]map[proto.BlobID][]uint8
+
+func (r mockRiskReporter) ReportInspectBads(vid proto.Vid, bidsBads map[proto.BlobID][]uint8) {
+	r[vid] = bidsBads
+}
+
+func TestInspectorReportRisk(t *testing.T) {
+	ctx := context.Background()
+	mgr := newInspector(t)
+	reporter := make(mockRiskReporter)
+	mgr.riskReporter = reporter
+
+	mgr.cfg.InspectBatch = 2
+	mgr.cfg.ListVolStep = 2
+
+	volume1 := MockGenVolInfo(100012, codemode.EC6P6, proto.VolumeStatusIdle)
+	volume2 := MockGenVolInfo(100013, codemode.EC6P6, proto.VolumeStatusIdle)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInspectCheckPoint(any).AnyTimes().Return(&proto.VolumeInspectCheckPoint{}, nil)
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().ListVolume(any, any, any).Return([]*client.VolumeInfoSimple{volume1, volume2}, proto.Vid(0), nil)
+
+	mgr.prepare(ctx)
+	require.Equal(t, 2, len(mgr.tasks))
+
+	for _, task := range mgr.tasks {
+		task.ret = &proto.VolumeInspectRet{}
+		if task.vid == volume1.Vid {
+			task.ret.MissedShards = genMockFailShards(volume1.Vid, []proto.BlobID{3, 4})
+		}
+	}
+	mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Return(volume1, nil)
+	mgr.repairShardSender.(*MockMqProxyAPI).EXPECT().SendShardRepairMsg(any, any, any, any).AnyTimes().Return(nil)
+	mgr.reportCompleted(ctx)
+
+	require.Equal(t, 2, len(reporter))
+	require.Equal(t, 2, len(reporter[volume1.Vid]))
+	bidsBads, ok := reporter[volume2.Vid]
+	require.True(t, ok)
+	require.Equal(t, 0, len(bidsBads))
+}
+
 func TestInspectorAcquire(t *testing.T) {
 	ctx := context.Background()
 	{
@@ -314,3 +506,100 @@ func TestInspectorGetTaskStats(t *testing.T) {
 	mgr := newInspector(t)
 	mgr.GetTaskStats()
 }
+
+func TestInspectorInspectVolume(t *testing.T) {
+	ctx := context.Background()
+	vid := proto.Vid(100012)
+	{
+		mgr := newInspector(t)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Return(nil, errMock)
+		_, err := mgr.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: vid})
+		require.ErrorIs(t, err, errMock)
+	}
+	{
+		// volume: bid 1 missed on unit 0 and 1, bid 2 is mark deleted, unit 11 failed to list
+		mgr := newInspector(t)
+		volume := MockGenVolInfo(vid, codemode.EC6P6, proto.VolumeStatusIdle)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Times(2).Return(volume, nil)
+		mgr.blobnodeCli.(*MockBlobnodeAPI).EXPECT().ListShards(any, any).Times(24).DoAndReturn(
+			func(_ context.Context, location proto.VunitLocation) ([]*bnapi.ShardInfo, error) {
+				idx := location.Vuid.Index()
+				if idx == 11 {
+					return nil, errMock
+				}
+				shards := []*bnapi.ShardInfo{{Bid: 2, Flag: bnapi.ShardStatusNormal}}
+				if idx == 0 {
+					shards[0].Flag = bnapi.ShardStatusMarkDelete
+				}
+				if idx > 1 {
+					shards = append(shards, &bnapi.ShardInfo{Bid: 1, Flag: bnapi.ShardStatusNormal})
+				}
+				return shards, nil
+			})
+
+		ret, err := mgr.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: vid})
+		require.NoError(t, err)
+		require.Len(t, ret.Units, 12)
+		require.Equal(t, 1, ret.Units[0].ShardCnt)
+		require.Equal(t, 2, ret.Units[2].ShardCnt)
+		require.NotEmpty(t, ret.Units[11].Err)
+		require.Equal(t, []api.InspectBlob{{Bid: 1, Bads: []uint8{0, 1}, Recoverable: true}}, ret.Blobs)
+
+		mgr.repairShardSender.(*MockMqProxyAPI).EXPECT().SendShardRepairMsg(any, vid, proto.BlobID(1), []uint8{0, 1}).Return(nil)
+		ret, err = mgr.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: vid, Repair: true})
+		require.NoError(t, err)
+		require.True(t, ret.Blobs[0].Repairing)
+		require.True(t, mgr.sendDeduplicator.reduplicate(vid, 1, []uint8{0, 1}))
+	}
+	{
+		// blob: missed on unit 0, corrupt on unit 1, unknown on unit 2
+		mgr := newInspector(t)
+		volume := MockGenVolInfo(vid, codemode.EC6P6, proto.VolumeStatusIdle)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Times(2).Return(volume, nil)
+		mgr.blobnodeCli.(*MockBlobnodeAPI).EXPECT().StatShard(any, any, any).Times(24).DoAndReturn(
+			func(_ context.Context, location proto.VunitLocation, bid proto.BlobID) (*bnapi.ShardInfo, error) {
+				switch location.Vuid.Index() {
+				case 0:
+					return nil, errcode.ErrNoSuchBid
+				case 2:
+					return nil, errMock
+				}
+				return &bnapi.ShardInfo{Bid: bid, Size: 10, Crc: 1, Flag: bnapi.ShardStatusNormal}, nil
+			})
+		mgr.blobnodeCli.(*MockBlobnodeAPI).EXPECT().VerifyShard(any, any, any).Times(20).DoAndReturn(
+			func(_ context.Context, location proto.VunitLocation, bid proto.BlobID) error {
+				if location.Vuid.Index() == 1 {
+					return errcode.ErrShardCrcMismatch
+				}
+				return nil
+			})
+
+		ret, err := mgr.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: vid, Bid: 5})
+		require.NoError(t, err)
+		require.Len(t, ret.Blobs, 1)
+		blob := ret.Blobs[0]
+		require.Equal(t, api.InspectShardMissing, blob.Shards[0].Status)
+		require.Equal(t, api.InspectShardCorrupt, blob.Shards[1].Status)
+		require.Equal(t, api.InspectShardUnknown, blob.Shards[2].Status)
+		require.Equal(t, api.InspectShardNormal, blob.Shards[3].Status)
+		require.Equal(t, uint32(1), blob.Shards[3].Crc)
+		require.Equal(t, []uint8{0, 1}, blob.Bads)
+		require.True(t, blob.Recoverable)
+
+		mgr.repairShardSender.(*MockMqProxyAPI).EXPECT().SendShardRepairMsg(any, any, any, any).Return(errMock)
+		_, err = mgr.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: vid, Bid: 5, Repair: true})
+		require.ErrorIs(t, err, errMock)
+	}
+	{
+		// active volume is not repaired
+		mgr := newInspector(t)
+		volume := MockGenVolInfo(vid, codemode.EC6P6, proto.VolumeStatusActive)
+		mgr.clusterMgrCli.(*MockClusterMgrAPI).EXPECT().GetVolumeInfo(any, any).Return(volume, nil)
+		mgr.blobnodeCli.(*MockBlobnodeAPI).EXPECT().StatShard(any, any, any).Times(12).Return(nil, errcode.ErrNoSuchBid)
+		ret, err := mgr.InspectVolume(ctx, &api.InspectVolumeArgs{Vid: vid, Bid: 5, Repair: true})
+		require.NoError(t, err)
+		require.True(t, ret.Active)
+		require.False(t, ret.Blobs[0].Recoverable)
+		require.False(t, ret.Blobs[0].Repairing)
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/access/stream"
 	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	"github.com/cubefs/cubefs/blobstore/cmd"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
@@ -85,16 +86,18 @@ type Config struct {
 	MaxRetry        int                `json:"max_retry"`
 	RetryDelayMs    uint32             `json:"retry_delay_ms"`
 	PartConcurrence int                `json:"part_concurrence"`
+	Compress        CompressConfig     `json:"compress"`
 
 	LogConf cmd.LogConfig `json:"log"`
 	Logger  io.Writer     `json:"-"`
 }
 
 type sdkHandler struct {
-	conf    Config
-	handler stream.StreamHandler
-	limiter stream.Limiter
-	closer  closer.Closer
+	conf       Config
+	handler    stream.StreamHandler
+	limiter    stream.Limiter
+	compressor *blobCompressor
+	closer     closer.Closer
 }
 
 func New(conf *Config) (acapi.Client, error) {
@@ -102,6 +105,12 @@ func New(conf *Config) (acapi.Client, error) {
 	// add region magic checksum to the secret keys
 	security.InitWithRegionMagic(conf.StreamConfig.ClusterConfig.RegionMagic)
 
+	compressor, err := newCompressor(conf)
+	if err != nil {
+		log.Errorf("new compressor failed, err: %+v", err)
+		return nil, err
+	}
+
 	cl := closer.New()
 	h, err := stream.NewStreamHandler(&conf.StreamConfig, cl.Done())
 	if err != nil {
@@ -110,10 +119,11 @@ func New(conf *Config) (acapi.Client, error) {
 	}
 
 	return &sdkHandler{
-		conf:    *conf,
-		handler: h,
-		limiter: stream.NewLimiter(conf.Limit),
-		closer:  cl,
+		conf:       *conf,
+		handler:    h,
+		limiter:    stream.NewLimiter(conf.Limit),
+		compressor: compressor,
+		closer:     cl,
 	}, nil
 }
 
@@ -186,6 +196,15 @@ func (s *sdkHandler) Delete(ctx context.Context, args *acapi.DeleteArgs) (failed
 	return nil, nil
 }
 
+func (s *sdkHandler) Presign(ctx context.Context, args *acapi.PresignArgs) (string, error) {
+	if !args.IsValid() || !security.LocationCrcVerify(&args.Location) {
+		return "", errcode.ErrIllegalArguments
+	}
+
+	token := args.Token(security.TokenSecretKeys()[0][:])
+	return security.EncodePresignToken(token), nil
+}
+
 func (s *sdkHandler) Put(ctx context.Context, args *acapi.PutArgs) (lc proto.Location, hm acapi.HashSumMap, err error) {
 	if args == nil {
 		return proto.Location{}, nil, errcode.ErrIllegalArguments
@@ -259,6 +278,28 @@ func (s *sdkHandler) DeleteBlob(ctx context.Context, args *acapi.DelBlobArgs) er
 	return s.handler.DeleteBlob(ctx, args)
 }
 
+func (s *sdkHandler) UndeleteBlob(ctx context.Context, args *acapi.UndeleteBlobArgs) error {
+	if !args.IsValid() {
+		return errcode.ErrIllegalArguments
+	}
+
+	ctx = acapi.ClientWithReqidContext(ctx)
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debugf("accept sdk UndeleteBlob request, name=%s, keys=%s, args: %v", args.BlobName, args.ShardKeys, *args)
+	return s.handler.UndeleteBlob(ctx, args)
+}
+
+func (s *sdkHandler) TransitionBlob(ctx context.Context, args *acapi.TransitionBlobArgs) error {
+	if !args.IsValid() {
+		return errcode.ErrIllegalArguments
+	}
+
+	ctx = acapi.ClientWithReqidContext(ctx)
+	span := trace.SpanFromContextSafe(ctx)
+	span.Debugf("accept sdk TransitionBlob request, name=%s, keys=%s, args: %v", args.BlobName, args.ShardKeys, *args)
+	return s.handler.TransitionBlob(ctx, args)
+}
+
 func (s *sdkHandler) GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (io.ReadCloser, error) {
 	if !args.IsValid() {
 		return nil, errcode.ErrIllegalArguments
@@ -266,18 +307,22 @@ func (s *sdkHandler) GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (io.R
 
 	ctx = acapi.ClientWithReqidContext(ctx)
 	span := trace.SpanFromContextSafe(ctx)
-	span.Debugf("accept sdk GetBlob request, name=%s, keys=%s, clusterID:%d, mode:%d, offset:%d, size:%d",
-		args.BlobName, args.ShardKeys, args.ClusterID, args.Mode, args.Offset, args.ReadSize)
+	span.Debugf("accept sdk GetBlob request, name=%s, keys=%s, clusterID:%d, mode:%d, offset:%d, size:%d, consistency:%s",
+		args.BlobName, args.ShardKeys, args.ClusterID, args.Mode, args.Offset, args.ReadSize, args.Consistency)
 	loc, err := s.handler.GetBlob(ctx, args)
 	if err != nil {
 		return nil, err
 	}
+	if loc.Codec != proto.CodecNone {
+		return s.getCompressedBlob(ctx, args, *loc)
+	}
 
 	arg := &acapi.GetArgs{
-		Location: *loc,
-		Offset:   args.Offset,
-		ReadSize: args.ReadSize,
-		Writer:   args.Writer,
+		Location:    *loc,
+		Offset:      args.Offset,
+		ReadSize:    args.ReadSize,
+		Consistency: args.Consistency,
+		Writer:      args.Writer,
 	}
 
 	return s.getBlobData(ctx, arg)
@@ -308,7 +353,11 @@ func (s *sdkHandler) PutBlob(ctx context.Context, args *acapi.PutBlobArgs) (cid
 		}
 	}()
 
-	loc, hashes, err := s.putBlobs(ctx, args)
+	putArgs, encoding, rawHashes, err := s.compressBlob(ctx, args)
+	if err != nil {
+		return 0, nil, err
+	}
+	loc, hashes, err := s.putBlobs(ctx, putArgs, encoding)
 	if err != nil {
 		span.Errorf("put blob fail, name=%s, keys=%s, location=%v, err=%+v", args.BlobName, args.ShardKeys, loc, err)
 		return loc.ClusterID, nil, err
@@ -323,14 +372,20 @@ func (s *sdkHandler) PutBlob(ctx context.Context, args *acapi.PutBlobArgs) (cid
 			ShardKeys: args.ShardKeys,
 			ClusterID: loc.ClusterID,
 			Slices:    loc.Slices,
-			Size:      args.Size, // before SealBlob, the loc.Size_ may be 0,
+			Size:      putArgs.Size, // before SealBlob, the loc.Size_ may be 0,
 		}
 		if err = s.sealBlob(ctx, sealArgs); err != nil {
 			span.Warnf("seal fail, seal args=%v", sealArgs)
 			return loc.ClusterID, nil, err
 		}
+	} else {
+		// the put event of sealed blob is sent by seal
+		s.handler.SendBlobEvent(ctx, loc.ClusterID, proxy.BlobEvent{Type: proxy.BlobEventPut, BlobName: args.BlobName})
 	}
 
+	if rawHashes != nil {
+		hashes = rawHashes
+	}
 	return loc.ClusterID, hashes, nil
 }
 
@@ -452,6 +507,7 @@ func (s *sdkHandler) getBlobData(ctx context.Context, args *acapi.GetArgs) (io.R
 
 func (s *sdkHandler) doGet(ctx context.Context, args *acapi.GetArgs) (io.ReadCloser, error) {
 	span := trace.SpanFromContextSafe(ctx)
+	ctx = acapi.WithConsistencyLevel(ctx, args.Consistency)
 	var err error
 
 	if args.Writer != nil {
@@ -963,13 +1019,16 @@ func (s *sdkHandler) putParts(ctx context.Context, args *acapi.PutArgs) (proto.L
 	return loc, hashSumMap, nil
 }
 
-func (s *sdkHandler) putBlobs(ctx context.Context, args *acapi.PutBlobArgs) (proto.Location, acapi.HashSumMap, error) {
+func (s *sdkHandler) putBlobs(ctx context.Context, args *acapi.PutBlobArgs, encoding blobEncoding) (proto.Location, acapi.HashSumMap, error) {
 	// create
 	created, err := s.createBlob(ctx, &acapi.CreateBlobArgs{
+		ClusterID: encoding.clusterID,
 		BlobName:  args.BlobName,
 		ShardKeys: args.ShardKeys,
 		CodeMode:  args.CodeMode,
 		Size:      args.Size,
+		Codec:     encoding.codec,
+		RawSize:   encoding.rawSize,
 	})
 	if err != nil {
 		return proto.Location{}, nil, err
@@ -1001,7 +1060,8 @@ func (s *sdkHandler) putBlobs(ctx context.Context, args *acapi.PutBlobArgs) (pro
 	}
 	defer memPool.Put(buf[:loc.SliceSize]) // prevent buf get smaller
 
-	// put every slice
+	// put every slice, data of worm space is written with retention
+	ctx = stream.WithSpaceBlob(ctx)
 	needRead := true
 	for blobIdx, retryCnt := 0, 0; blobIdx < len(loc.Slices); {
 		buf1, sliceIdx, remainSize, err := s.putOneSlice(ctx, args, loc, blobIdx, needRead, buf)
@@ -3,6 +3,7 @@ package sdk
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"hash/crc32"
@@ -14,9 +15,11 @@ import (
 
 	"github.com/cubefs/cubefs/blobstore/access/stream"
 	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/api/proxy"
 	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	"github.com/cubefs/cubefs/blobstore/cmd"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
+	"github.com/cubefs/cubefs/blobstore/common/compress"
 	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/security"
@@ -39,11 +42,14 @@ func newSdkHandler(t *testing.T) *sdkHandler {
 
 	conf := Config{LogConf: cmd.LogConfig{Level: log.Lpanic}}
 	fixConfig(&conf)
+	compressor, err := newCompressor(&conf)
+	require.NoError(t, err)
 	return &sdkHandler{
-		handler: h,
-		limiter: l,
-		conf:    conf,
-		closer:  closer.New(),
+		handler:    h,
+		limiter:    l,
+		compressor: compressor,
+		conf:       conf,
+		closer:     closer.New(),
 	}
 }
 
@@ -723,6 +729,23 @@ func TestSdkBlob_Delete(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSdkBlob_Undelete(t *testing.T) {
+	ctx := context.Background()
+	hd := newSdkHandler(t)
+
+	err := hd.UndeleteBlob(ctx, &acapi.UndeleteBlobArgs{})
+	require.ErrorIs(t, err, errcode.ErrIllegalArguments)
+
+	args := &acapi.UndeleteBlobArgs{ClusterID: 1, BlobName: []byte("blob1")}
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().UndeleteBlob(gAny, gAny).Return(errcode.ErrBlobNotInTrash)
+	err = hd.UndeleteBlob(ctx, args)
+	require.ErrorIs(t, err, errcode.ErrBlobNotInTrash)
+
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().UndeleteBlob(gAny, gAny).Return(nil)
+	err = hd.UndeleteBlob(ctx, args)
+	require.NoError(t, err)
+}
+
 func TestSdkBlob_Put(t *testing.T) {
 	ctx := context.Background()
 	hd := newSdkHandler(t)
@@ -763,7 +786,12 @@ func TestSdkBlob_Put(t *testing.T) {
 			io.Copy(wt, rd)
 			return nil
 		}).Times(9/4 + 1)
-	// put ok
+	// put ok, put event is sent without seal
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().SendBlobEvent(gAny, proto.ClusterID(1), gAny).Do(
+		func(_ context.Context, _ proto.ClusterID, event proxy.BlobEvent) {
+			require.Equal(t, proxy.BlobEventPut, event.Type)
+			require.Equal(t, []byte("blob1"), event.BlobName)
+		})
 	args.Hashes = acapi.HashAlgCRC32
 	cid, hashes, err := hd.PutBlob(ctx, args)
 	require.NoError(t, err)
@@ -916,7 +944,7 @@ func TestSdkBlob_Put(t *testing.T) {
 			return nil
 		}).Times(4)
 	args.Hashes = acapi.HashAlgCRC32
-	retLoc, hashes, err := hd.putBlobs(ctx, args)
+	retLoc, hashes, err := hd.putBlobs(ctx, args, blobEncoding{})
 	require.Nil(t, err)
 	require.Equal(t, proto.ClusterID(1), retLoc.ClusterID)
 	require.Equal(t, args.Size, retLoc.Size_)
@@ -953,7 +981,7 @@ func TestSdkBlob_Put(t *testing.T) {
 			io.Copy(wt, rd)
 			return nil
 		}).Times(3 + 2) // count 3+2
-	retLoc, hashes, err = hd.putBlobs(ctx, args)
+	retLoc, hashes, err = hd.putBlobs(ctx, args, blobEncoding{})
 	require.Nil(t, err)
 	require.Equal(t, proto.ClusterID(1), retLoc.ClusterID)
 	require.Equal(t, args.Size, retLoc.Size_)
@@ -995,7 +1023,7 @@ func TestSdkBlob_Put(t *testing.T) {
 			io.Copy(wt, rd)
 			return nil
 		}).Times(1)
-	retLoc, hashes, err = hd.putBlobs(ctx, args)
+	retLoc, hashes, err = hd.putBlobs(ctx, args, blobEncoding{})
 	require.Nil(t, err)
 	require.Equal(t, proto.ClusterID(1), retLoc.ClusterID)
 	require.Equal(t, args.Size, retLoc.Size_)
@@ -1035,3 +1063,162 @@ func TestSdkBlob_Put(t *testing.T) {
 	require.Equal(t, proto.ClusterID(1), cid)
 	require.Nil(t, hashes)
 }
+
+func TestSdkBlob_Compress(t *testing.T) {
+	ctx := context.Background()
+	hd := newSdkHandler(t)
+	hd.conf.ShardnodeConfig = &stream.ShardnodeConfig{}
+	compressor, err := compress.New(compress.Config{Enable: true, MinSize: 16}, nil)
+	require.NoError(t, err)
+	hd.compressor = &blobCompressor{Compressor: compressor, single: true}
+	security.InitWithRegionMagic("cn-south-1")
+
+	raw := bytes.Repeat([]byte("compressible blob "), 1<<10)
+	args := &acapi.PutBlobArgs{
+		BlobName: []byte("blob1"),
+		CodeMode: codemode.EC3P3,
+		NeedSeal: true,
+		Size:     uint64(len(raw)),
+		Hashes:   acapi.HashAlgCRC32,
+		Body:     bytes.NewReader(raw),
+	}
+
+	var compressedSize uint64
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().CreateBlob(gAny, gAny).DoAndReturn(
+		func(_ context.Context, args *acapi.CreateBlobArgs) (*proto.Location, error) {
+			require.Equal(t, proto.CodecZstd, args.Codec)
+			require.Equal(t, uint64(len(raw)), args.RawSize)
+			require.Less(t, args.Size, uint64(len(raw)))
+			compressedSize = args.Size
+			return &proto.Location{
+				ClusterID: 1,
+				SliceSize: 1 << 20,
+				Slices:    []proto.Slice{{MinSliceID: 1, Vid: 10, Count: 1}},
+				Codec:     args.Codec,
+			}, nil
+		})
+	stored := bytes.NewBuffer(nil)
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().PutAt(gAny, gAny, gAny, gAny, gAny, gAny, gAny).DoAndReturn(
+		func(ctx context.Context, rd io.Reader, cid proto.ClusterID, vid proto.Vid, bid proto.BlobID, sz int64, hm acapi.HasherMap) error {
+			_, err := io.Copy(stored, rd)
+			return err
+		})
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().SealBlob(gAny, gAny).DoAndReturn(
+		func(_ context.Context, args *acapi.SealBlobArgs) error {
+			require.Equal(t, compressedSize, args.Size)
+			return nil
+		})
+	_, hashes, err := hd.PutBlob(ctx, args)
+	require.NoError(t, err)
+	require.Equal(t, compressedSize, uint64(stored.Len()))
+	hasher := acapi.HashAlgCRC32.ToHasher()
+	hasher.Write(raw)
+	require.Equal(t, hasher.Sum(nil), hashes[acapi.HashAlgCRC32])
+
+	// get range of raw payload
+	loc := proto.Location{
+		ClusterID: 1,
+		CodeMode:  codemode.EC3P3,
+		Size_:     compressedSize,
+		SliceSize: 1 << 20,
+		Slices:    []proto.Slice{{MinSliceID: 1, Vid: 10, Count: 1, ValidSize: compressedSize}},
+		Codec:     proto.CodecZstd,
+		RawSize:   uint64(len(raw)),
+	}
+	require.NoError(t, security.LocationCrcFill(&loc))
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().GetBlob(gAny, gAny).Return(&loc, nil).Times(4)
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, compressedSize, uint64(0)).DoAndReturn(
+		func(ctx context.Context, w io.Writer, location proto.Location, readSize, offset uint64) (func() error, error) {
+			return func() error {
+				_, err := w.Write(stored.Bytes())
+				return err
+			}, nil
+		}).Times(2)
+	getArgs := &acapi.GetBlobArgs{ClusterID: 1, BlobName: []byte("blob1"), Offset: 10, ReadSize: 100}
+	rc, err := hd.GetBlob(ctx, getArgs)
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, raw[10:110], data)
+
+	w := bytes.NewBuffer(nil)
+	getArgs.Writer = w
+	_, err = hd.GetBlob(ctx, getArgs)
+	require.NoError(t, err)
+	require.Equal(t, raw[10:110], w.Bytes())
+
+	// out of range of raw payload
+	_, err = hd.GetBlob(ctx, &acapi.GetBlobArgs{ClusterID: 1, BlobName: []byte("blob1"), Offset: 10, ReadSize: uint64(len(raw))})
+	require.ErrorIs(t, err, errcode.ErrIllegalArguments)
+
+	loc.Codec = proto.CodecZstd + 1
+	_, err = hd.GetBlob(ctx, getArgs)
+	require.ErrorIs(t, err, errcode.ErrIllegalArguments)
+
+	// incompressible payload is put as is
+	random := make([]byte, 1<<10)
+	_, err = rand.Read(random)
+	require.NoError(t, err)
+	args.Body, args.Size = bytes.NewReader(random), uint64(len(random))
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().CreateBlob(gAny, gAny).DoAndReturn(
+		func(_ context.Context, args *acapi.CreateBlobArgs) (*proto.Location, error) {
+			require.Equal(t, proto.CodecNone, args.Codec)
+			require.Equal(t, uint64(len(random)), args.Size)
+			return &proto.Location{
+				ClusterID: 1,
+				SliceSize: 1 << 20,
+				Slices:    []proto.Slice{{MinSliceID: 1, Vid: 10, Count: 1}},
+			}, nil
+		})
+	stored.Reset()
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().PutAt(gAny, gAny, gAny, gAny, gAny, gAny, gAny).DoAndReturn(
+		func(ctx context.Context, rd io.Reader, cid proto.ClusterID, vid proto.Vid, bid proto.BlobID, sz int64, hm acapi.HasherMap) error {
+			_, err := io.Copy(stored, rd)
+			return err
+		})
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().SealBlob(gAny, gAny).Return(nil)
+	_, _, err = hd.PutBlob(ctx, args)
+	require.NoError(t, err)
+	require.Equal(t, random, stored.Bytes())
+
+	// encoded payload is put as is with its codec
+	encoded := []byte("encoded payload")
+	args = &acapi.PutBlobArgs{
+		BlobName: []byte("blob2"),
+		CodeMode: codemode.EC3P3,
+		NeedSeal: true,
+		Size:     uint64(len(encoded)),
+		Body:     bytes.NewReader(encoded),
+		Codec:    proto.CodecZstd,
+		RawSize:  1 << 10,
+	}
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().CreateBlob(gAny, gAny).DoAndReturn(
+		func(_ context.Context, args *acapi.CreateBlobArgs) (*proto.Location, error) {
+			require.Equal(t, proto.CodecZstd, args.Codec)
+			require.Equal(t, uint64(1<<10), args.RawSize)
+			require.Equal(t, uint64(len(encoded)), args.Size)
+			return &proto.Location{
+				ClusterID: 1,
+				SliceSize: 1 << 20,
+				Slices:    []proto.Slice{{MinSliceID: 1, Vid: 10, Count: 1}},
+				Codec:     args.Codec,
+			}, nil
+		})
+	stored.Reset()
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().PutAt(gAny, gAny, gAny, gAny, gAny, gAny, gAny).DoAndReturn(
+		func(ctx context.Context, rd io.Reader, cid proto.ClusterID, vid proto.Vid, bid proto.BlobID, sz int64, hm acapi.HasherMap) error {
+			_, err := io.Copy(stored, rd)
+			return err
+		})
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().SealBlob(gAny, gAny).Return(nil)
+	_, _, err = hd.PutBlob(ctx, args)
+	require.NoError(t, err)
+	require.Equal(t, encoded, stored.Bytes())
+
+	args.Body, args.NeedSeal = bytes.NewReader(encoded), false
+	_, _, err = hd.PutBlob(ctx, args)
+	require.ErrorIs(t, err, errcode.ErrIllegalArguments)
+	args.NeedSeal, args.Codec = true, proto.CodecZstd+1
+	_, _, err = hd.PutBlob(ctx, args)
+	require.ErrorIs(t, err, errcode.ErrIllegalArguments)
+}
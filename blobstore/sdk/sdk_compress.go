@@ -0,0 +1,222 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/cubefs/cubefs/blobstore/access/stream"
+	acapi "github.com/cubefs/cubefs/blobstore/api/access"
+	"github.com/cubefs/cubefs/blobstore/common/compress"
+	errcode "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+)
+
+// CompressConfig compresses payloads of the sealed blobs put by PutBlob,
+// GetBlob decompresses the blobs transparently whether enabled or not.
+type CompressConfig struct {
+	compress.Config
+	// Dictionaries base64 encoded zstd dictionaries by space name, blobs are compressed and
+	// decompressed with the dictionary of the space of cluster which the blob is put in.
+	Dictionaries map[string]string `json:"dictionaries"`
+}
+
+// blobCompressor compresses blobs with the dictionary of space of cluster
+type blobCompressor struct {
+	*compress.Compressor
+	// spaces of the configured clusters
+	spaces map[proto.ClusterID]string
+	// space is the only space of clusters, the cluster is chosen before compressing if not single
+	space  string
+	single bool
+}
+
+func newCompressor(conf *Config) (*blobCompressor, error) {
+	dicts := make(map[string][]byte, len(conf.Compress.Dictionaries))
+	for space, encoded := range conf.Compress.Dictionaries {
+		dict, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode dictionary of space %s: %s", space, err.Error())
+		}
+		dicts[space] = dict
+	}
+
+	c := &blobCompressor{spaces: make(map[proto.ClusterID]string)}
+	names := make(map[string]struct{})
+	for _, cluster := range conf.StreamConfig.ClusterConfig.Clusters {
+		c.spaces[cluster.ClusterID] = cluster.Space.Name
+		c.space = cluster.Space.Name
+		names[cluster.Space.Name] = struct{}{}
+	}
+	c.single = len(names) <= 1
+
+	compressor, err := compress.New(conf.Compress.Config, dicts)
+	if err != nil {
+		return nil, err
+	}
+	c.Compressor = compressor
+	return c, nil
+}
+
+// blobEncoding is how the payload of blob is encoded
+type blobEncoding struct {
+	clusterID proto.ClusterID
+	codec     proto.Codec
+	rawSize   uint64
+}
+
+// chooseSpace returns the space to compress blob in, the cluster of the space is chosen
+// to create the blob if clusters are in different spaces
+func (s *sdkHandler) chooseSpace() (proto.ClusterID, string, error) {
+	if s.compressor.single {
+		return 0, s.compressor.space, nil
+	}
+	admin, ok := s.handler.Admin().(*stream.StreamAdmin)
+	if !ok || admin.Controller == nil {
+		return 0, "", nil
+	}
+	cluster, err := admin.Controller.ChooseOne()
+	if err != nil {
+		return 0, "", err
+	}
+	return cluster.ClusterID, s.compressor.spaces[cluster.ClusterID], nil
+}
+
+// compressBlob returns args to put the compressed payload if it's compressible,
+// and the hashes of the raw payload which is read out.
+func (s *sdkHandler) compressBlob(ctx context.Context, args *acapi.PutBlobArgs) (
+	*acapi.PutBlobArgs, blobEncoding, acapi.HashSumMap, error,
+) {
+	// the encoded payload is written as is, it's decoded with the dictionary of space of the chosen cluster
+	if args.Codec != proto.CodecNone {
+		if args.Codec != proto.CodecZstd {
+			return nil, blobEncoding{}, nil, errcode.ErrIllegalArguments
+		}
+		return args, blobEncoding{codec: args.Codec, rawSize: args.RawSize}, nil, nil
+	}
+	// the codec is recorded at creating, and the size of sealed blob is the compressed size
+	if !args.NeedSeal || !s.compressor.Accept(int64(args.Size)) {
+		return args, blobEncoding{}, nil, nil
+	}
+	span := trace.SpanFromContextSafe(ctx)
+
+	clusterID, space, err := s.chooseSpace()
+	if err != nil {
+		return nil, blobEncoding{}, nil, err
+	}
+	raw := make([]byte, args.Size)
+	if _, err = io.ReadFull(args.Body, raw); err != nil {
+		return nil, blobEncoding{}, nil, err
+	}
+	putArgs := *args
+	putArgs.Body = bytes.NewReader(raw)
+
+	compressed, ok := s.compressor.Compress(space, raw)
+	if !ok {
+		span.Debugf("blob %s is incompressible, size:%d", args.BlobName, args.Size)
+		return &putArgs, blobEncoding{clusterID: clusterID}, nil, nil
+	}
+
+	hashSumMap := args.Hashes.ToHashSumMap()
+	for alg := range hashSumMap {
+		hasher := alg.ToHasher()
+		hasher.Write(raw)
+		hashSumMap[alg] = hasher.Sum(nil)
+	}
+	putArgs.Body = bytes.NewReader(compressed)
+	putArgs.Size = uint64(len(compressed))
+	putArgs.Hashes = 0
+	span.Debugf("blob %s compressed in space %s, size:%d -> %d", args.BlobName, space, args.Size, putArgs.Size)
+	return &putArgs, blobEncoding{clusterID: clusterID, codec: proto.CodecZstd, rawSize: args.Size}, hashSumMap, nil
+}
+
+// getCompressedBlob decompresses the payload in stream, and returns the range of raw payload
+func (s *sdkHandler) getCompressedBlob(ctx context.Context, args *acapi.GetBlobArgs, loc proto.Location) (io.ReadCloser, error) {
+	span := trace.SpanFromContextSafe(ctx)
+	if loc.Codec != proto.CodecZstd {
+		span.Errorf("unknown codec %d of blob %s", loc.Codec, args.BlobName)
+		return nil, errcode.ErrIllegalArguments
+	}
+	if args.ReadSize == 0 {
+		return noopBody{}, nil
+	}
+	if args.Offset+args.ReadSize > loc.RawSize {
+		return nil, errcode.ErrIllegalArguments
+	}
+
+	body, err := s.getBlobData(ctx, &acapi.GetArgs{
+		Location:    loc,
+		ReadSize:    loc.Size_,
+		Consistency: args.Consistency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.compressor.NewReader(s.compressor.spaces[loc.ClusterID], body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	rc := &decompressedBody{r: r, body: body, remain: args.ReadSize}
+
+	// skip the head of raw payload
+	if _, err = io.CopyN(io.Discard, r, int64(args.Offset)); err != nil {
+		rc.Close()
+		span.Errorf("decompress blob %s failed, err:%+v", args.BlobName, err)
+		return nil, errcode.ErrReaderError
+	}
+
+	if args.Writer != nil {
+		defer rc.Close()
+		if _, err = io.Copy(args.Writer, rc); err != nil {
+			span.Errorf("decompress blob %s failed, err:%+v", args.BlobName, err)
+			return noopBody{}, err
+		}
+		return noopBody{}, nil
+	}
+	return rc, nil
+}
+
+// decompressedBody reads remain bytes of the raw payload
+type decompressedBody struct {
+	r      io.ReadCloser
+	body   io.ReadCloser
+	remain uint64
+}
+
+func (d *decompressedBody) Read(p []byte) (int, error) {
+	if d.remain == 0 {
+		return 0, io.EOF
+	}
+	if uint64(len(p)) > d.remain {
+		p = p[:d.remain]
+	}
+	n, err := d.r.Read(p)
+	d.remain -= uint64(n)
+	if err == io.EOF && d.remain > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (d *decompressedBody) Close() error {
+	d.r.Close()
+	return d.body.Close()
+}
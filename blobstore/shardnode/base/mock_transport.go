@@ -217,6 +217,21 @@ func (mr *MockTransportMockRecorder) ListDisks(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisks", reflect.TypeOf((*MockTransport)(nil).ListDisks), ctx)
 }
 
+// ListShardUnits mocks base method.
+func (m *MockTransport) ListShardUnits(ctx context.Context, diskID proto.DiskID) ([]clustermgr.ShardUnitInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShardUnits", ctx, diskID)
+	ret0, _ := ret[0].([]clustermgr.ShardUnitInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShardUnits indicates an expected call of ListShardUnits.
+func (mr *MockTransportMockRecorder) ListShardUnits(ctx, diskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShardUnits", reflect.TypeOf((*MockTransport)(nil).ListShardUnits), ctx, diskID)
+}
+
 // NodeID mocks base method.
 func (m *MockTransport) NodeID() proto.NodeID {
 	m.ctrl.T.Helper()
@@ -31,6 +31,7 @@ type (
 	Transport interface {
 		GetConfig(ctx context.Context, key string) (string, error)
 		ShardReport(ctx context.Context, reports []clustermgr.ShardUnitInfo) ([]clustermgr.ShardTask, error)
+		ListShardUnits(ctx context.Context, diskID proto.DiskID) ([]clustermgr.ShardUnitInfo, error)
 		GetRouteUpdate(ctx context.Context, routeVersion proto.RouteVersion) (proto.RouteVersion, []clustermgr.CatalogChangeItem, error)
 		NodeTransport
 		SpaceTransport
@@ -214,6 +215,10 @@ func (t *transport) ShardReport(ctx context.Context, reports []clustermgr.ShardU
 	return resp, err
 }
 
+func (t *transport) ListShardUnits(ctx context.Context, diskID proto.DiskID) ([]clustermgr.ShardUnitInfo, error) {
+	return t.cmClient.ListShardUnit(ctx, &clustermgr.ListShardUnitArgs{DiskID: diskID})
+}
+
 func (t *transport) ListDisks(ctx context.Context) ([]clustermgr.ShardNodeDiskInfo, error) {
 	args := &clustermgr.ListOptionArgs{
 		Host:  t.myself.Host,
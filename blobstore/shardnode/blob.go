@@ -82,7 +82,7 @@ func (s *service) listBlob(ctx context.Context, req *shardnode.ListBlobArgs) (re
 	if err != nil {
 		return shardnode.ListBlobRet{}, err
 	}
-	blobs, nextMarker, err := space.ListBlob(ctx, req.GetHeader(), req.GetPrefix(), req.GetMarker(), req.GetCount())
+	blobs, nextMarker, err := space.ListBlob(ctx, req.GetHeader(), req.GetPrefix(), req.GetMarker(), req.GetCount(), req.GetVid())
 	if err != nil {
 		return
 	}
@@ -91,6 +91,75 @@ func (s *service) listBlob(ctx context.Context, req *shardnode.ListBlobArgs) (re
 	return
 }
 
+func (s *service) trashBlob(ctx context.Context, req *shardnode.TrashBlobArgs) error {
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return err
+	}
+	return space.TrashBlob(ctx, req)
+}
+
+func (s *service) undeleteBlob(ctx context.Context, req *shardnode.UndeleteBlobArgs) (resp shardnode.UndeleteBlobRet, err error) {
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return
+	}
+	return space.UndeleteBlob(ctx, req)
+}
+
+func (s *service) listTrashBlob(ctx context.Context, req *shardnode.ListTrashBlobArgs) (resp shardnode.ListTrashBlobRet, err error) {
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return
+	}
+	blobs, nextMarker, err := space.ListTrashBlob(ctx, req.GetHeader(), req.GetMarker(), req.GetCount())
+	if err != nil {
+		return
+	}
+	resp.Blobs = blobs
+	resp.NextMarker = nextMarker
+	return
+}
+
+func (s *service) purgeTrashBlob(ctx context.Context, req *shardnode.PurgeTrashBlobArgs) error {
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return err
+	}
+	return space.PurgeTrashBlob(ctx, req)
+}
+
+func (s *service) refDedup(ctx context.Context, req *shardnode.RefDedupArgs) (resp shardnode.RefDedupRet, err error) {
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return
+	}
+	return space.RefDedup(ctx, req)
+}
+
+func (s *service) getDedup(ctx context.Context, req *shardnode.GetDedupArgs) (resp shardnode.GetDedupRet, err error) {
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return
+	}
+	return space.GetDedup(ctx, req)
+}
+
+func (s *service) unrefDedup(ctx context.Context, req *shardnode.UnrefDedupArgs) (resp shardnode.UnrefDedupRet, err error) {
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return
+	}
+	return space.UnrefDedup(ctx, req)
+}
+
 func (s *service) allocSlice(ctx context.Context, req *shardnode.AllocSliceArgs) (resp shardnode.AllocSliceRet, err error) {
 	sid := req.Header.SpaceID
 	space, err := s.catalog.GetSpace(ctx, sid)
@@ -17,6 +17,7 @@ package catalog
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/common/codemode"
@@ -99,6 +100,7 @@ func NewCatalog(ctx context.Context, cfg *Config) *Catalog {
 			fieldMetas:  spaceMeta.FieldMetas,
 			shardGetter: cfg.ShardGetter,
 			allocator:   alc,
+			retention:   time.Duration(spaceMeta.WormRetentionSecs) * time.Second,
 		})
 		if err != nil {
 			span.Panicf("new space failed: %s", err)
@@ -143,6 +145,7 @@ func (c *Catalog) updateSpace(ctx context.Context, sid proto.SpaceID) error {
 		fieldMetas:  spaceMeta.FieldMetas,
 		shardGetter: c.cfg.ShardGetter,
 		allocator:   c.allocator,
+		retention:   time.Duration(spaceMeta.WormRetentionSecs) * time.Second,
 	})
 	if err != nil {
 		err = errors.Info(err, "new space failed")
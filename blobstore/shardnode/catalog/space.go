@@ -15,6 +15,7 @@
 package catalog
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"fmt"
@@ -40,6 +41,7 @@ type (
 		fieldMetas   []clustermgr.FieldMeta
 		shardGetter  ShardGetter
 		allocator    allocator.Allocator
+		retention    time.Duration
 	}
 )
 
@@ -49,6 +51,10 @@ const (
 	opInsert = "i"
 	opUpdate = "u"
 	opDelete = "d"
+	opTrash  = "t"
+	opUndel  = "r"
+	opRef    = "f"
+	opTxn    = "x"
 
 	blobTraceTag = "BlobName"
 )
@@ -67,6 +73,7 @@ func newSpace(cfg *spaceConfig) (*Space, error) {
 		fieldMetas:   fieldMetaMap,
 		shardGetter:  cfg.shardGetter,
 		allocator:    cfg.allocator,
+		retention:    cfg.retention,
 	}
 
 	return s, nil
@@ -79,6 +86,8 @@ type Space struct {
 	clusterID proto.ClusterID
 	sid       proto.SpaceID
 	name      string
+	// retention worm retention of blobs, zero if not worm space
+	retention time.Duration
 
 	// mutable
 	spaceVersion uint64
@@ -104,6 +113,8 @@ func (s *Space) InsertItem(ctx context.Context, h shardnode.ShardOpHeader, i sha
 	return shard.InsertItem(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
 	}, s.generateSpaceKey(i.ID), i)
 }
 
@@ -119,6 +130,8 @@ func (s *Space) UpdateItem(ctx context.Context, h shardnode.ShardOpHeader, i sha
 	return shard.UpdateItem(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
 	}, s.generateSpaceKey(i.ID), i)
 }
 
@@ -131,6 +144,8 @@ func (s *Space) DeleteItem(ctx context.Context, h shardnode.ShardOpHeader, id []
 	return shard.DeleteItem(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
 	}, s.generateSpaceKey(id))
 }
 
@@ -146,11 +161,31 @@ func (s *Space) GetItem(ctx context.Context, h shardnode.ShardOpHeader, id []byt
 	}, s.generateSpaceKey(id))
 }
 
-func (s *Space) ListItem(ctx context.Context, h shardnode.ShardOpHeader, prefix, marker []byte, count uint64) ([]shardnode.Item, []byte, error) {
+func (s *Space) GetItemVersion(ctx context.Context, h shardnode.ShardOpHeader, id []byte, version uint64) (shardnode.Item, error) {
+	shard, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return shardnode.Item{}, err
+	}
+
+	return shard.GetItemVersion(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+	}, s.generateSpaceKey(id), version)
+}
+
+func (s *Space) ListItem(ctx context.Context, h shardnode.ShardOpHeader, prefix, marker []byte, count uint64, filters []shardnode.FieldFilter) ([]shardnode.Item, []byte, error) {
 	shard, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
 	if err != nil {
 		return nil, nil, err
 	}
+	for i := range filters {
+		if !filters[i].Valid() {
+			return nil, nil, apierr.ErrIllegalArguments
+		}
+		if _, ok := s.fieldMetas[filters[i].FieldID]; !ok {
+			return nil, nil, apierr.ErrUnknownField
+		}
+	}
 
 	var _marker []byte
 	if len(marker) > 0 {
@@ -159,7 +194,7 @@ func (s *Space) ListItem(ctx context.Context, h shardnode.ShardOpHeader, prefix,
 	items, nextMarker, err := shard.ListItem(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
-	}, s.generateSpacePrefix(prefix), _marker, count)
+	}, s.generateSpacePrefix(prefix), _marker, count, filters)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -197,6 +232,8 @@ func (s *Space) CreateBlob(ctx context.Context, req *shardnode.CreateBlobArgs) (
 			CodeMode:  req.CodeMode,
 			SliceSize: req.SliceSize,
 			Crc:       0,
+			Codec:     req.Codec,
+			RawSize:   req.RawSize,
 		},
 		Sealed: false,
 	}
@@ -228,6 +265,8 @@ INSERT:
 	cb, _err := sd.CreateBlob(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
 	}, s.generateSpaceKey(req.Name), b)
 	span.AppendTrackLog(opInsert, start, _err, trace.OptSpanDurationUs())
 	if _err != nil {
@@ -270,10 +309,22 @@ func (s *Space) DeleteBlob(ctx context.Context, req *shardnode.DeleteBlobArgs) e
 		return err
 	}
 
+	if s.retention > 0 {
+		b, err := s.getBlob(ctx, sd, h, req.Name)
+		if err != nil {
+			return err
+		}
+		if err = checkBlobRetention(&b); err != nil {
+			return err
+		}
+	}
+
 	start := time.Now()
 	err = sd.DeleteBlob(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
 	}, s.generateSpaceKey(req.Name))
 	span.AppendTrackLog(opDelete, start, err, trace.OptSpanDurationUs())
 	return err
@@ -293,12 +344,17 @@ func (s *Space) FindAndDeleteBlob(ctx context.Context, req *shardnode.DeleteBlob
 	if err != nil {
 		return
 	}
+	if err = checkBlobRetention(&blob); err != nil {
+		return
+	}
 	resp.Blob = blob
 
 	start := time.Now()
 	err = sd.DeleteBlob(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
 	}, s.generateSpaceKey(req.Name))
 	span.AppendTrackLog(opDelete, start, err, trace.OptSpanDurationUs())
 	return
@@ -332,80 +388,108 @@ func (s *Space) SealBlob(ctx context.Context, req *shardnode.SealBlobArgs) (err
 		}
 	}()
 
-	if len(b.Location.Slices) != len(req.Slices) {
-		span.Errorf("slices length not equal")
-		return apierr.ErrIllegalSlices
+	if err = sealBlob(&b, req.GetSize_(), req.Slices); err != nil {
+		return
 	}
+	b.Retention = s.blobRetention()
 
-	b.Sealed = true
-	b.Location.Size_ = req.GetSize_()
+	start := time.Now()
+	err = sd.UpdateBlob(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
+	}, key, b)
+	span.AppendTrackLog(opUpdate, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		err = errors.Info(err, "update kv failed")
+		return err
+	}
+	return nil
+}
 
-	sliceSize := b.Location.SliceSize
-	remainSize := req.GetSize_()
-	for i := range req.Slices {
-		if !compareSlice(req.Slices[i], b.Location.Slices[i]) {
-			err = apierr.ErrIllegalSlices
-			return
-		}
+func (s *Space) ListBlob(ctx context.Context, h shardnode.ShardOpHeader, prefix, marker []byte, count uint64, vid proto.Vid) (blobs []proto.Blob, nextMarker []byte, err error) {
+	shard, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return
+	}
 
-		/*last slice may not be full written,
-		0 < remainSize <= req.Slices[i].Count*sliceSize
-		and remainSize must equal to req.Slices[i].ValidSize*/
-		if i == len(req.Slices)-1 {
-			if remainSize > uint64(req.Slices[i].Count*sliceSize) ||
-				remainSize <= 0 ||
-				req.Slices[i].ValidSize != remainSize {
-				err = apierr.ErrIllegalLocationSize
-				return
-			}
-			b.Location.Slices[i].ValidSize = remainSize
-			b.Location.Slices[i].Count = req.Slices[i].Count
-			break
-		}
+	var _marker []byte
+	if len(marker) > 0 {
+		_marker = s.generateSpaceKey(marker)
+	}
 
-		/*local validSize recorded: blob was re-allocated,
-		and current slice is not the last slice,
-		req.Slice[i].ValidSize must equal to b.Location.Slices[i].ValidSize*/
-		if b.Location.Slices[i].ValidSize != 0 {
-			validSize := b.Location.Slices[i].ValidSize
-			if validSize >= remainSize || req.Slices[i].ValidSize != validSize ||
-				req.Slices[i].Count != b.Location.Slices[i].Count {
-				err = apierr.ErrIllegalLocationSize
-				return
-			}
-			remainSize -= validSize
-			continue
-		}
+	blobs, nextMarker, err = shard.ListBlob(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+	}, s.generateSpacePrefix(prefix), _marker, count, vid)
+	if err != nil {
+		err = errors.Info(err, "shard list blob failed")
+		return nil, nil, err
+	}
+	if len(nextMarker) > 0 {
+		nextMarker = s.decodeSpaceKey(nextMarker)
+	}
+	return blobs, nextMarker, nil
+}
 
-		/*local validSize not recorded: blob was not re-allocated, or
-		current slice is remaining slices after re-allocated, and not the last slice*/
-		validSize := uint64(req.Slices[i].Count * sliceSize)
-		if validSize >= remainSize {
-			err = apierr.ErrIllegalLocationSize
-			return
+func (s *Space) TrashBlob(ctx context.Context, req *shardnode.TrashBlobArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+	span.SetTag(blobTraceTag, string(req.Name))
+
+	h := req.Header
+	sd, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return err
+	}
+
+	if s.retention > 0 {
+		b, err := s.getBlob(ctx, sd, h, req.Name)
+		if err != nil {
+			return err
+		}
+		if err = checkBlobRetention(&b); err != nil {
+			return err
 		}
-		b.Location.Slices[i].ValidSize = validSize
-		remainSize -= validSize
 	}
 
-	if err = security.LocationCrcFill(&b.Location); err != nil {
-		return err
+	start := time.Now()
+	err = sd.TrashBlob(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
+	}, s.generateSpaceKey(req.Name), req.ExpireTime)
+	span.AppendTrackLog(opTrash, start, err, trace.OptSpanDurationUs())
+	return err
+}
+
+func (s *Space) UndeleteBlob(ctx context.Context, req *shardnode.UndeleteBlobArgs) (resp shardnode.UndeleteBlobRet, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+	span.SetTag(blobTraceTag, string(req.Name))
+
+	h := req.Header
+	sd, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return
 	}
 
 	start := time.Now()
-	err = sd.UpdateBlob(ctx, storage.OpHeader{
+	blob, err := sd.UndeleteBlob(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
-	}, key, b)
-	span.AppendTrackLog(opUpdate, start, err, trace.OptSpanDurationUs())
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
+	}, s.generateSpaceKey(req.Name))
+	span.AppendTrackLog(opUndel, start, err, trace.OptSpanDurationUs())
 	if err != nil {
-		err = errors.Info(err, "update kv failed")
-		return err
+		return
 	}
-	return nil
+	resp.Blob = blob
+	return
 }
 
-func (s *Space) ListBlob(ctx context.Context, h shardnode.ShardOpHeader, prefix, marker []byte, count uint64) (blobs []proto.Blob, nextMarker []byte, err error) {
+func (s *Space) ListTrashBlob(ctx context.Context, h shardnode.ShardOpHeader, marker []byte, count uint64) (blobs []shardnode.TrashBlob, nextMarker []byte, err error) {
 	shard, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
 	if err != nil {
 		return
@@ -416,12 +500,13 @@ func (s *Space) ListBlob(ctx context.Context, h shardnode.ShardOpHeader, prefix,
 		_marker = s.generateSpaceKey(marker)
 	}
 
-	blobs, nextMarker, err = shard.ListBlob(ctx, storage.OpHeader{
+	blobs, nextMarker, err = shard.ListTrashBlob(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
-	}, s.generateSpacePrefix(prefix), _marker, count)
+		LeaderTerm:   h.LeaderTerm,
+	}, s.generateSpacePrefix(nil), _marker, count)
 	if err != nil {
-		err = errors.Info(err, "shard list blob failed")
+		err = errors.Info(err, "shard list trash blob failed")
 		return nil, nil, err
 	}
 	if len(nextMarker) > 0 {
@@ -430,6 +515,215 @@ func (s *Space) ListBlob(ctx context.Context, h shardnode.ShardOpHeader, prefix,
 	return blobs, nextMarker, nil
 }
 
+func (s *Space) PurgeTrashBlob(ctx context.Context, req *shardnode.PurgeTrashBlobArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+	span.SetTag(blobTraceTag, string(req.Name))
+
+	h := req.Header
+	sd, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = sd.DeleteTrashBlob(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
+	}, s.generateSpaceKey(req.Name), req.Version)
+	span.AppendTrackLog(opDelete, start, err, trace.OptSpanDurationUs())
+	return err
+}
+
+func (s *Space) RefDedup(ctx context.Context, req *shardnode.RefDedupArgs) (resp shardnode.RefDedupRet, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	h := req.Header
+	sd, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return
+	}
+
+	start := time.Now()
+	ref, err := sd.RefDedup(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
+	}, s.generateSpaceKey(req.Key), req.Ref, shardnode.DedupRef{Hash: req.Hash, Location: req.Location})
+	span.AppendTrackLog(opRef, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return
+	}
+	resp.Ref = ref
+	return
+}
+
+func (s *Space) GetDedup(ctx context.Context, req *shardnode.GetDedupArgs) (resp shardnode.GetDedupRet, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	h := req.Header
+	sd, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return
+	}
+
+	start := time.Now()
+	resp, err = sd.GetDedup(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+	}, s.generateSpaceKey(req.Key))
+	span.AppendTrackLog(opGet, start, err, trace.OptSpanDurationUs())
+	return
+}
+
+func (s *Space) UnrefDedup(ctx context.Context, req *shardnode.UnrefDedupArgs) (resp shardnode.UnrefDedupRet, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	h := req.Header
+	sd, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return
+	}
+
+	start := time.Now()
+	resp, err = sd.UnrefDedup(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
+	}, s.generateSpaceKey(req.Key), req.Ref, req.Location)
+	span.AppendTrackLog(opRef, start, err, trace.OptSpanDurationUs())
+	return
+}
+
+// CommitTxn commits operations of items and blobs in the same shard atomically
+func (s *Space) CommitTxn(ctx context.Context, req *shardnode.CommitTxnArgs) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	h := req.Header
+	sd, err := s.shardGetter.GetShard(h.DiskID, h.Suid)
+	if err != nil {
+		return err
+	}
+
+	ops := make([]storage.TxnOp, len(req.Ops))
+	for i := range req.Ops {
+		op := &req.Ops[i]
+		ops[i] = storage.TxnOp{
+			Type:       op.Type,
+			ShardKeys:  op.ShardKeys,
+			Item:       op.Item,
+			Blob:       op.Blob,
+			Cond:       op.Cond,
+			ExpireTime: op.ExpireTime,
+		}
+		switch op.Type {
+		case shardnode.TxnOpPutItem, shardnode.TxnOpUpdateItem:
+			if !s.validateFields(op.Item.Fields) {
+				return apierr.ErrUnknownField
+			}
+			ops[i].Key = s.generateSpaceKey(op.Item.ID)
+		case shardnode.TxnOpDeleteItem:
+			ops[i].Key = s.generateSpaceKey(op.Item.ID)
+		case shardnode.TxnOpPutBlob:
+			ops[i].Key = s.generateSpaceKey(op.Name)
+			if err = s.putTxnBlob(ctx, sd, h, op.Name, &ops[i]); err != nil {
+				return err
+			}
+		case shardnode.TxnOpDeleteBlob:
+			ops[i].Key = s.generateSpaceKey(op.Name)
+			if s.retention > 0 {
+				opHeader := h
+				opHeader.ShardKeys = op.ShardKeys
+				b, err := s.getBlob(ctx, sd, opHeader, op.Name)
+				if err != nil {
+					return err
+				}
+				if err = checkBlobRetention(&b); err != nil {
+					return err
+				}
+			}
+		default:
+			ops[i].Key = s.generateSpaceKey(op.Name)
+		}
+	}
+
+	start := time.Now()
+	err = sd.CommitTxn(ctx, storage.OpHeader{
+		RouteVersion: h.RouteVersion,
+		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
+	}, ops)
+	span.AppendTrackLog(opTxn, start, err, trace.OptSpanDurationUs())
+	return err
+}
+
+// putTxnBlob validates the blob of put blob operation. The unsealed blob is sealed with size and
+// slices of the operation as SealBlob, and the location of sealed blob is replaced with the validated
+// one of the operation. The operation is conditioned on the blob value validated with, so the blob
+// can't be changed between validating and applying
+func (s *Space) putTxnBlob(ctx context.Context, sd storage.ShardHandler, h shardnode.ShardOpHeader, name []byte, op *storage.TxnOp) error {
+	if op.Cond.Type != shardnode.TxnCondNone && op.Cond.Type != shardnode.TxnCondExist &&
+		op.Cond.Type != shardnode.TxnCondValueEqual {
+		return apierr.ErrIllegalTxn
+	}
+
+	h.ShardKeys = op.ShardKeys
+	b, err := s.getBlob(ctx, sd, h, name)
+	if err != nil {
+		if errors.Is(err, apierr.ErrKeyNotFound) {
+			return apierr.ErrTxnConflict
+		}
+		return err
+	}
+	value, err := b.Marshal()
+	if err != nil {
+		return err
+	}
+	if op.Cond.Type == shardnode.TxnCondValueEqual && !bytes.Equal(value, op.Cond.Value) {
+		return apierr.ErrTxnConflict
+	}
+
+	if !b.Sealed {
+		err = sealBlob(&b, op.Blob.Location.Size_, op.Blob.Location.Slices)
+	} else {
+		b.Location = op.Blob.Location
+		err = s.validateSealedLocation(&b.Location)
+	}
+	if err != nil {
+		return err
+	}
+	// the data of blob is written before now, extends the retention from now
+	if retention := s.blobRetention(); retention > b.Retention {
+		b.Retention = retention
+	}
+	op.Blob = b
+	op.Cond = shardnode.TxnCond{Type: shardnode.TxnCondValueEqual, Value: value}
+	return nil
+}
+
+// validateSealedLocation checks the location of sealed blob is written completely in this cluster,
+// and refills crc of location
+func (s *Space) validateSealedLocation(loc *proto.Location) error {
+	if loc.ClusterID != s.clusterID || loc.Size_ == 0 || len(loc.Slices) == 0 {
+		return apierr.ErrIllegalLocationSize
+	}
+	size := uint64(0)
+	for i := range loc.Slices {
+		if isEmptySlice(loc.Slices[i]) || loc.Slices[i].ValidSize > uint64(loc.Slices[i].Count)*uint64(loc.SliceSize) {
+			return apierr.ErrIllegalSlices
+		}
+		size += loc.Slices[i].ValidSize
+	}
+	if size != loc.Size_ {
+		return apierr.ErrIllegalLocationSize
+	}
+	return security.LocationCrcFill(loc)
+}
+
 func (s *Space) AllocSlice(ctx context.Context, req *shardnode.AllocSliceArgs) (resp shardnode.AllocSliceRet, err error) {
 	span := trace.SpanFromContextSafe(ctx)
 	span.SetTag(blobTraceTag, string(req.Name))
@@ -523,6 +817,8 @@ func (s *Space) AllocSlice(ctx context.Context, req *shardnode.AllocSliceArgs) (
 	err = sd.UpdateBlob(ctx, storage.OpHeader{
 		RouteVersion: h.RouteVersion,
 		ShardKeys:    h.ShardKeys,
+		Sync:         h.Sync,
+		LeaderTerm:   h.LeaderTerm,
 	}, key, b)
 	span.AppendTrackLog(opUpdate, start, err, trace.OptSpanDurationUs())
 	if err != nil {
@@ -628,6 +924,80 @@ func (s *Space) generateSpacePrefixLen(prefix []byte) int {
 	return 8 + len(prefix)
 }
 
+// blobRetention returns the worm retention of blob sealed now, zero if not worm space.
+// The shards of blob are written before sealed, so they are not protected after the blob.
+func (s *Space) blobRetention() int64 {
+	if s.retention <= 0 {
+		return 0
+	}
+	return time.Now().Add(s.retention).Unix()
+}
+
+// checkBlobRetention returns ErrShardWormProtected if the blob is in worm retention
+func checkBlobRetention(b *proto.Blob) error {
+	if b.Retention > time.Now().Unix() {
+		return apierr.ErrShardWormProtected
+	}
+	return nil
+}
+
+// sealBlob seals the blob with the size and slices written, the slices are validated with
+// the allocated slices of blob location, and crc of location is refilled
+func sealBlob(b *proto.Blob, size uint64, slices []proto.Slice) error {
+	if len(b.Location.Slices) != len(slices) {
+		return apierr.ErrIllegalSlices
+	}
+
+	b.Sealed = true
+	b.Location.Size_ = size
+
+	sliceSize := b.Location.SliceSize
+	remainSize := size
+	for i := range slices {
+		if !compareSlice(slices[i], b.Location.Slices[i]) {
+			return apierr.ErrIllegalSlices
+		}
+
+		/*last slice may not be full written,
+		0 < remainSize <= slices[i].Count*sliceSize
+		and remainSize must equal to slices[i].ValidSize*/
+		if i == len(slices)-1 {
+			if remainSize > uint64(slices[i].Count*sliceSize) ||
+				remainSize <= 0 ||
+				slices[i].ValidSize != remainSize {
+				return apierr.ErrIllegalLocationSize
+			}
+			b.Location.Slices[i].ValidSize = remainSize
+			b.Location.Slices[i].Count = slices[i].Count
+			break
+		}
+
+		/*local validSize recorded: blob was re-allocated,
+		and current slice is not the last slice,
+		slices[i].ValidSize must equal to b.Location.Slices[i].ValidSize*/
+		if b.Location.Slices[i].ValidSize != 0 {
+			validSize := b.Location.Slices[i].ValidSize
+			if validSize >= remainSize || slices[i].ValidSize != validSize ||
+				slices[i].Count != b.Location.Slices[i].Count {
+				return apierr.ErrIllegalLocationSize
+			}
+			remainSize -= validSize
+			continue
+		}
+
+		/*local validSize not recorded: blob was not re-allocated, or
+		current slice is remaining slices after re-allocated, and not the last slice*/
+		validSize := uint64(slices[i].Count * sliceSize)
+		if validSize >= remainSize {
+			return apierr.ErrIllegalLocationSize
+		}
+		b.Location.Slices[i].ValidSize = validSize
+		remainSize -= validSize
+	}
+
+	return security.LocationCrcFill(&b.Location)
+}
+
 func isEmptySlice(s proto.Slice) bool {
 	return s.MinSliceID == proto.InValidBlobID && s.Vid == proto.InvalidVid && s.ValidSize == 0 && s.Count == 0
 }
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,7 @@ import (
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/security"
 	"github.com/cubefs/cubefs/blobstore/shardnode/mock"
+	"github.com/cubefs/cubefs/blobstore/shardnode/storage"
 	"github.com/cubefs/cubefs/blobstore/util/errors"
 )
 
@@ -103,6 +105,12 @@ func TestSpace_Item(t *testing.T) {
 	require.Equal(t, shardnode.Item{Fields: fields}, ret)
 	_, err = mockSpace.shardErrSpace.GetItem(ctx, oph, []byte{99})
 	require.Equal(t, apierr.ErrShardDoesNotExist, err)
+	gomock.InOrder(mockSpace.mockHandler.EXPECT().GetItemVersion(A, A, A, uint64(2)).Return(shardnode.Item{Fields: fields, Version: 2}, nil))
+	ret, err = mockSpace.space.GetItemVersion(ctx, oph, []byte{1}, 2)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), ret.Version)
+	_, err = mockSpace.shardErrSpace.GetItemVersion(ctx, oph, []byte{99}, 2)
+	require.Equal(t, apierr.ErrShardDoesNotExist, err)
 	// update
 	gomock.InOrder(mockSpace.mockHandler.EXPECT().UpdateItem(A, A, A, A).Return(nil))
 	err = mockSpace.space.UpdateItem(ctx, oph, shardnode.Item{Fields: fields})
@@ -114,7 +122,7 @@ func TestSpace_Item(t *testing.T) {
 	err = mockSpace.shardErrSpace.UpdateItem(ctx, oph, shardnode.Item{})
 	require.Equal(t, apierr.ErrShardDoesNotExist, err)
 	// list
-	gomock.InOrder(mockSpace.mockHandler.EXPECT().ListItem(A, A, A, A, A).Return([]shardnode.Item{
+	gomock.InOrder(mockSpace.mockHandler.EXPECT().ListItem(A, A, A, A, A, A).Return([]shardnode.Item{
 		{
 			ID:     []byte("1"),
 			Fields: fields,
@@ -124,9 +132,13 @@ func TestSpace_Item(t *testing.T) {
 			Fields: fields,
 		},
 	}, mockSpace.space.generateSpaceKey([]byte("3")), nil))
-	_, marker, err := mockSpace.space.ListItem(ctx, oph, nil, nil, 2)
+	_, marker, err := mockSpace.space.ListItem(ctx, oph, nil, nil, 2, nil)
 	require.Nil(t, err)
 	require.Equal(t, []byte("3"), marker)
+	_, _, err = mockSpace.space.ListItem(ctx, oph, nil, nil, 2, []shardnode.FieldFilter{{FieldID: 1}})
+	require.Equal(t, apierr.ErrIllegalArguments, err)
+	_, _, err = mockSpace.space.ListItem(ctx, oph, nil, nil, 2, []shardnode.FieldFilter{{FieldID: 3, Op: shardnode.FilterOpEqual}})
+	require.Equal(t, apierr.ErrUnknownField, err)
 
 	// delete
 	gomock.InOrder(mockSpace.mockHandler.EXPECT().DeleteItem(A, A, A).Return(nil))
@@ -400,6 +412,45 @@ func TestSpace_FindAndDeleteBlob(t *testing.T) {
 	require.Equal(t, ret.Blob, blob)
 }
 
+func TestSpace_WormBlob(t *testing.T) {
+	ctx := context.Background()
+	mockSpace, cleanSpace := newMockSpace(t)
+	defer cleanSpace()
+	space := mockSpace.space
+	space.retention = time.Hour
+
+	name := []byte("blob")
+	slices := []proto.Slice{{Vid: 1, MinSliceID: 1, Count: 10, ValidSize: 100}}
+	b := proto.Blob{Name: name, Location: proto.Location{CodeMode: codemode.EC6P6, SliceSize: 10, Slices: slices}}
+
+	// retention is set by the space when sealed
+	var sealed proto.Blob
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(b, nil)
+	mockSpace.mockHandler.EXPECT().UpdateBlob(A, A, A, A).DoAndReturn(
+		func(_ context.Context, _ storage.OpHeader, _ []byte, blob proto.Blob) error {
+			sealed = blob
+			return nil
+		})
+	require.NoError(t, space.SealBlob(ctx, &shardnode.SealBlobArgs{Name: name, Size_: 100, Slices: slices}))
+	require.True(t, sealed.Sealed)
+	require.Greater(t, sealed.Retention, time.Now().Add(time.Hour-time.Minute).Unix())
+
+	// protected blob can not be deleted
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(sealed, nil).Times(3)
+	err := space.DeleteBlob(ctx, &shardnode.DeleteBlobArgs{Name: name})
+	require.ErrorIs(t, err, apierr.ErrShardWormProtected)
+	_, err = space.FindAndDeleteBlob(ctx, &shardnode.DeleteBlobArgs{Name: name})
+	require.ErrorIs(t, err, apierr.ErrShardWormProtected)
+	err = space.TrashBlob(ctx, &shardnode.TrashBlobArgs{Name: name})
+	require.ErrorIs(t, err, apierr.ErrShardWormProtected)
+
+	// retention passed
+	sealed.Retention = time.Now().Add(-time.Second).Unix()
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(sealed, nil)
+	mockSpace.mockHandler.EXPECT().DeleteBlob(A, A, A).Return(nil)
+	require.NoError(t, space.DeleteBlob(ctx, &shardnode.DeleteBlobArgs{Name: name}))
+}
+
 func TestSpace_ListBlob(t *testing.T) {
 	mockSpace, cleanSpace := newMockSpace(t)
 	defer cleanSpace()
@@ -412,15 +463,126 @@ func TestSpace_ListBlob(t *testing.T) {
 	}
 
 	nextMarker := []byte("next")
-	mockSpace.mockHandler.EXPECT().ListBlob(A, A, A, A, A).Return(
+	mockSpace.mockHandler.EXPECT().ListBlob(A, A, A, A, A, A).Return(
 		blobs, space.generateSpaceKey(nextMarker), nil,
 	)
-	blobs, m, err := space.ListBlob(ctx, shardnode.ShardOpHeader{}, nil, []byte("b1"), 10)
+	blobs, m, err := space.ListBlob(ctx, shardnode.ShardOpHeader{}, nil, []byte("b1"), 10, 0)
 	require.Nil(t, err)
 	require.Equal(t, nextMarker, m)
 	require.Equal(t, 10, len(blobs))
 }
 
+func TestSpace_TrashBlob(t *testing.T) {
+	ctx := context.Background()
+	mockSpace, cleanSpace := newMockSpace(t)
+	defer cleanSpace()
+	space := mockSpace.space
+
+	blob := proto.Blob{Name: []byte("blob"), Location: proto.Location{CodeMode: codemode.EC6P6}}
+
+	mockSpace.mockHandler.EXPECT().TrashBlob(A, A, A, A).Return(nil)
+	err := space.TrashBlob(ctx, &shardnode.TrashBlobArgs{Name: []byte("blob"), ExpireTime: 100})
+	require.Nil(t, err)
+
+	mockSpace.mockHandler.EXPECT().UndeleteBlob(A, A, A).Return(blob, nil)
+	ret, err := space.UndeleteBlob(ctx, &shardnode.UndeleteBlobArgs{Name: []byte("blob")})
+	require.Nil(t, err)
+	require.Equal(t, blob, ret.Blob)
+
+	nextMarker := []byte("next")
+	mockSpace.mockHandler.EXPECT().ListTrashBlob(A, A, A, A, A).Return(
+		[]shardnode.TrashBlob{{Blob: blob, ExpireTime: 100}}, space.generateSpaceKey(nextMarker), nil,
+	)
+	blobs, m, err := space.ListTrashBlob(ctx, shardnode.ShardOpHeader{}, nil, 10)
+	require.Nil(t, err)
+	require.Equal(t, nextMarker, m)
+	require.Equal(t, 1, len(blobs))
+
+	mockSpace.mockHandler.EXPECT().DeleteTrashBlob(A, A, A, uint64(1)).Return(nil)
+	err = space.PurgeTrashBlob(ctx, &shardnode.PurgeTrashBlobArgs{Name: []byte("blob"), Version: 1})
+	require.Nil(t, err)
+}
+
+func TestSpace_CommitTxn(t *testing.T) {
+	ctx := context.Background()
+	mockSpace, cleanSpace := newMockSpace(t)
+	defer cleanSpace()
+	space := mockSpace.space
+
+	name := []byte("blob")
+	shardKeys := [][]byte{name}
+	created := proto.Blob{
+		Name: name,
+		Location: proto.Location{
+			CodeMode:  codemode.EC6P6,
+			SliceSize: 64,
+			Slices:    []proto.Slice{{MinSliceID: 1, Vid: 100, Count: 160}},
+		},
+	}
+	createdValue, err := created.Marshal()
+	require.Nil(t, err)
+	putOp := shardnode.TxnOp{
+		Type:      shardnode.TxnOpPutBlob,
+		ShardKeys: shardKeys,
+		Name:      name,
+		Blob: proto.Blob{Location: proto.Location{
+			Size_:  1024,
+			Slices: []proto.Slice{{MinSliceID: 1, Vid: 100, Count: 16, ValidSize: 1024}},
+		}},
+	}
+	args := &shardnode.CommitTxnArgs{Ops: []shardnode.TxnOp{putOp, {
+		Type:       shardnode.TxnOpDeleteBlob,
+		ShardKeys:  shardKeys,
+		Name:       []byte("blob1"),
+		ExpireTime: 100,
+	}}}
+
+	// seal the created blob, conditioned on the created value
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(created, nil)
+	mockSpace.mockHandler.EXPECT().CommitTxn(A, A, A).DoAndReturn(
+		func(_ context.Context, _ storage.OpHeader, ops []storage.TxnOp) error {
+			require.Equal(t, 2, len(ops))
+			require.True(t, ops[0].Blob.Sealed)
+			require.Equal(t, uint64(1024), ops[0].Blob.Location.Size_)
+			require.True(t, security.LocationCrcVerify(&ops[0].Blob.Location))
+			require.Equal(t, shardnode.TxnCond{Type: shardnode.TxnCondValueEqual, Value: createdValue}, ops[0].Cond)
+			require.Equal(t, int64(100), ops[1].ExpireTime)
+			return nil
+		})
+	require.Nil(t, space.CommitTxn(ctx, args))
+
+	// illegal slices
+	args.Ops[0].Blob.Location.Slices[0].Vid = 101
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(created, nil)
+	require.ErrorIs(t, space.CommitTxn(ctx, args), apierr.ErrIllegalSlices)
+
+	// replace location of sealed blob
+	sealed := created
+	sealed.Sealed = true
+	args.Ops = []shardnode.TxnOp{putOp}
+	args.Ops[0].Blob.Location.Slices = []proto.Slice{{MinSliceID: 10, Vid: 200, Count: 16, ValidSize: 1024}}
+	args.Ops[0].Blob.Location.SliceSize = 64
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(sealed, nil)
+	mockSpace.mockHandler.EXPECT().CommitTxn(A, A, A).DoAndReturn(
+		func(_ context.Context, _ storage.OpHeader, ops []storage.TxnOp) error {
+			require.Equal(t, proto.Vid(200), ops[0].Blob.Location.Slices[0].Vid)
+			return nil
+		})
+	require.Nil(t, space.CommitTxn(ctx, args))
+	args.Ops[0].Blob.Location.Size_ = 2048
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(sealed, nil)
+	require.ErrorIs(t, space.CommitTxn(ctx, args), apierr.ErrIllegalLocationSize)
+
+	// blob not created or changed
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(proto.Blob{}, apierr.ErrKeyNotFound)
+	require.ErrorIs(t, space.CommitTxn(ctx, args), apierr.ErrTxnConflict)
+	args.Ops[0].Cond = shardnode.TxnCond{Type: shardnode.TxnCondValueEqual, Value: createdValue}
+	mockSpace.mockHandler.EXPECT().GetBlob(A, A, A).Return(sealed, nil)
+	require.ErrorIs(t, space.CommitTxn(ctx, args), apierr.ErrTxnConflict)
+	args.Ops[0].Cond = shardnode.TxnCond{Type: shardnode.TxnCondNotExist}
+	require.ErrorIs(t, space.CommitTxn(ctx, args), apierr.ErrIllegalTxn)
+}
+
 func Test_SpaceKey(t *testing.T) {
 	space := Space{sid: 1000, spaceVersion: 0}
 
@@ -62,6 +62,9 @@ func (s *service) getItem(ctx context.Context, req *shardnode.GetItemArgs) (item
 	if err != nil {
 		return
 	}
+	if req.GetVersion() > 0 {
+		return space.GetItemVersion(ctx, req.GetHeader(), req.GetID(), req.GetVersion())
+	}
 	item, err = space.GetItem(ctx, req.GetHeader(), req.GetID())
 	return
 }
@@ -72,7 +75,7 @@ func (s *service) listItem(ctx context.Context, req *shardnode.ListItemArgs) (re
 	if err != nil {
 		return
 	}
-	items, nextMarker, err := space.ListItem(ctx, req.GetHeader(), req.GetPrefix(), req.GetMarker(), req.GetCount())
+	items, nextMarker, err := space.ListItem(ctx, req.GetHeader(), req.GetPrefix(), req.GetMarker(), req.GetCount(), req.GetFilters())
 	if err != nil {
 		return
 	}
@@ -145,6 +145,20 @@ func (mr *MockShardBlobHandlerMockRecorder) DeleteBlob(ctx, h, name interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).DeleteBlob), ctx, h, name)
 }
 
+// DeleteTrashBlob mocks base method.
+func (m *MockShardBlobHandler) DeleteTrashBlob(ctx context.Context, h storage.OpHeader, name []byte, version uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrashBlob", ctx, h, name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrashBlob indicates an expected call of DeleteTrashBlob.
+func (mr *MockShardBlobHandlerMockRecorder) DeleteTrashBlob(ctx, h, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrashBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).DeleteTrashBlob), ctx, h, name, version)
+}
+
 // GetBlob mocks base method.
 func (m *MockShardBlobHandler) GetBlob(ctx context.Context, h storage.OpHeader, name []byte) (proto.Blob, error) {
 	m.ctrl.T.Helper()
@@ -160,10 +174,25 @@ func (mr *MockShardBlobHandlerMockRecorder) GetBlob(ctx, h, name interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).GetBlob), ctx, h, name)
 }
 
+// GetDedup mocks base method.
+func (m *MockShardBlobHandler) GetDedup(ctx context.Context, h storage.OpHeader, key []byte) (shardnode.GetDedupRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDedup", ctx, h, key)
+	ret0, _ := ret[0].(shardnode.GetDedupRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDedup indicates an expected call of GetDedup.
+func (mr *MockShardBlobHandlerMockRecorder) GetDedup(ctx, h, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDedup", reflect.TypeOf((*MockShardBlobHandler)(nil).GetDedup), ctx, h, key)
+}
+
 // ListBlob mocks base method.
-func (m *MockShardBlobHandler) ListBlob(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64) ([]proto.Blob, []byte, error) {
+func (m *MockShardBlobHandler) ListBlob(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64, vid proto.Vid) ([]proto.Blob, []byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBlob", ctx, h, prefix, marker, count)
+	ret := m.ctrl.Call(m, "ListBlob", ctx, h, prefix, marker, count, vid)
 	ret0, _ := ret[0].([]proto.Blob)
 	ret1, _ := ret[1].([]byte)
 	ret2, _ := ret[2].(error)
@@ -171,9 +200,84 @@ func (m *MockShardBlobHandler) ListBlob(ctx context.Context, h storage.OpHeader,
 }
 
 // ListBlob indicates an expected call of ListBlob.
-func (mr *MockShardBlobHandlerMockRecorder) ListBlob(ctx, h, prefix, marker, count interface{}) *gomock.Call {
+func (mr *MockShardBlobHandlerMockRecorder) ListBlob(ctx, h, prefix, marker, count, vid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).ListBlob), ctx, h, prefix, marker, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).ListBlob), ctx, h, prefix, marker, count, vid)
+}
+
+// ListTrashBlob mocks base method.
+func (m *MockShardBlobHandler) ListTrashBlob(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64) ([]shardnode.TrashBlob, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashBlob", ctx, h, prefix, marker, count)
+	ret0, _ := ret[0].([]shardnode.TrashBlob)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTrashBlob indicates an expected call of ListTrashBlob.
+func (mr *MockShardBlobHandlerMockRecorder) ListTrashBlob(ctx, h, prefix, marker, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).ListTrashBlob), ctx, h, prefix, marker, count)
+}
+
+// RefDedup mocks base method.
+func (m *MockShardBlobHandler) RefDedup(ctx context.Context, h storage.OpHeader, key, ref []byte, value shardnode.DedupRef) (shardnode.DedupRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefDedup", ctx, h, key, ref, value)
+	ret0, _ := ret[0].(shardnode.DedupRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefDedup indicates an expected call of RefDedup.
+func (mr *MockShardBlobHandlerMockRecorder) RefDedup(ctx, h, key, ref, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefDedup", reflect.TypeOf((*MockShardBlobHandler)(nil).RefDedup), ctx, h, key, ref, value)
+}
+
+// TrashBlob mocks base method.
+func (m *MockShardBlobHandler) TrashBlob(ctx context.Context, h storage.OpHeader, name []byte, expireTime int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashBlob", ctx, h, name, expireTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrashBlob indicates an expected call of TrashBlob.
+func (mr *MockShardBlobHandlerMockRecorder) TrashBlob(ctx, h, name, expireTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).TrashBlob), ctx, h, name, expireTime)
+}
+
+// UndeleteBlob mocks base method.
+func (m *MockShardBlobHandler) UndeleteBlob(ctx context.Context, h storage.OpHeader, name []byte) (proto.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndeleteBlob", ctx, h, name)
+	ret0, _ := ret[0].(proto.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndeleteBlob indicates an expected call of UndeleteBlob.
+func (mr *MockShardBlobHandlerMockRecorder) UndeleteBlob(ctx, h, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlob", reflect.TypeOf((*MockShardBlobHandler)(nil).UndeleteBlob), ctx, h, name)
+}
+
+// UnrefDedup mocks base method.
+func (m *MockShardBlobHandler) UnrefDedup(ctx context.Context, h storage.OpHeader, key, ref []byte, location proto.Location) (shardnode.UnrefDedupRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnrefDedup", ctx, h, key, ref, location)
+	ret0, _ := ret[0].(shardnode.UnrefDedupRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnrefDedup indicates an expected call of UnrefDedup.
+func (mr *MockShardBlobHandlerMockRecorder) UnrefDedup(ctx, h, key, ref, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnrefDedup", reflect.TypeOf((*MockShardBlobHandler)(nil).UnrefDedup), ctx, h, key, ref, location)
 }
 
 // UpdateBlob mocks base method.
@@ -242,6 +346,21 @@ func (mr *MockShardItemHandlerMockRecorder) GetItem(ctx, h, id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockShardItemHandler)(nil).GetItem), ctx, h, id)
 }
 
+// GetItemVersion mocks base method.
+func (m *MockShardItemHandler) GetItemVersion(ctx context.Context, h storage.OpHeader, id []byte, version uint64) (shardnode.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemVersion", ctx, h, id, version)
+	ret0, _ := ret[0].(shardnode.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemVersion indicates an expected call of GetItemVersion.
+func (mr *MockShardItemHandlerMockRecorder) GetItemVersion(ctx, h, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemVersion", reflect.TypeOf((*MockShardItemHandler)(nil).GetItemVersion), ctx, h, id, version)
+}
+
 // InsertItem mocks base method.
 func (m *MockShardItemHandler) InsertItem(ctx context.Context, h storage.OpHeader, id []byte, i shardnode.Item) error {
 	m.ctrl.T.Helper()
@@ -257,9 +376,9 @@ func (mr *MockShardItemHandlerMockRecorder) InsertItem(ctx, h, id, i interface{}
 }
 
 // ListItem mocks base method.
-func (m *MockShardItemHandler) ListItem(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64) ([]shardnode.Item, []byte, error) {
+func (m *MockShardItemHandler) ListItem(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64, filters []shardnode.FieldFilter) ([]shardnode.Item, []byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListItem", ctx, h, prefix, marker, count)
+	ret := m.ctrl.Call(m, "ListItem", ctx, h, prefix, marker, count, filters)
 	ret0, _ := ret[0].([]shardnode.Item)
 	ret1, _ := ret[1].([]byte)
 	ret2, _ := ret[2].(error)
@@ -267,9 +386,9 @@ func (m *MockShardItemHandler) ListItem(ctx context.Context, h storage.OpHeader,
 }
 
 // ListItem indicates an expected call of ListItem.
-func (mr *MockShardItemHandlerMockRecorder) ListItem(ctx, h, prefix, marker, count interface{}) *gomock.Call {
+func (mr *MockShardItemHandlerMockRecorder) ListItem(ctx, h, prefix, marker, count, filters interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItem", reflect.TypeOf((*MockShardItemHandler)(nil).ListItem), ctx, h, prefix, marker, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItem", reflect.TypeOf((*MockShardItemHandler)(nil).ListItem), ctx, h, prefix, marker, count, filters)
 }
 
 // UpdateItem mocks base method.
@@ -286,6 +405,43 @@ func (mr *MockShardItemHandlerMockRecorder) UpdateItem(ctx, h, id, i interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockShardItemHandler)(nil).UpdateItem), ctx, h, id, i)
 }
 
+// MockShardTxnHandler is a mock of ShardTxnHandler interface.
+type MockShardTxnHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockShardTxnHandlerMockRecorder
+}
+
+// MockShardTxnHandlerMockRecorder is the mock recorder for MockShardTxnHandler.
+type MockShardTxnHandlerMockRecorder struct {
+	mock *MockShardTxnHandler
+}
+
+// NewMockShardTxnHandler creates a new mock instance.
+func NewMockShardTxnHandler(ctrl *gomock.Controller) *MockShardTxnHandler {
+	mock := &MockShardTxnHandler{ctrl: ctrl}
+	mock.recorder = &MockShardTxnHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShardTxnHandler) EXPECT() *MockShardTxnHandlerMockRecorder {
+	return m.recorder
+}
+
+// CommitTxn mocks base method.
+func (m *MockShardTxnHandler) CommitTxn(ctx context.Context, h storage.OpHeader, ops []storage.TxnOp) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTxn", ctx, h, ops)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTxn indicates an expected call of CommitTxn.
+func (mr *MockShardTxnHandlerMockRecorder) CommitTxn(ctx, h, ops interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTxn", reflect.TypeOf((*MockShardTxnHandler)(nil).CommitTxn), ctx, h, ops)
+}
+
 // MockSpaceShardHandler is a mock of ShardHandler interface.
 type MockSpaceShardHandler struct {
 	ctrl     *gomock.Controller
@@ -337,6 +493,20 @@ func (mr *MockSpaceShardHandlerMockRecorder) Checkpoint(ctx interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockSpaceShardHandler)(nil).Checkpoint), ctx)
 }
 
+// CommitTxn mocks base method.
+func (m *MockSpaceShardHandler) CommitTxn(ctx context.Context, h storage.OpHeader, ops []storage.TxnOp) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTxn", ctx, h, ops)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTxn indicates an expected call of CommitTxn.
+func (mr *MockSpaceShardHandlerMockRecorder) CommitTxn(ctx, h, ops interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTxn", reflect.TypeOf((*MockSpaceShardHandler)(nil).CommitTxn), ctx, h, ops)
+}
+
 // CreateBlob mocks base method.
 func (m *MockSpaceShardHandler) CreateBlob(ctx context.Context, h storage.OpHeader, name []byte, b proto.Blob) (proto.Blob, error) {
 	m.ctrl.T.Helper()
@@ -380,6 +550,20 @@ func (mr *MockSpaceShardHandlerMockRecorder) DeleteItem(ctx, h, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockSpaceShardHandler)(nil).DeleteItem), ctx, h, id)
 }
 
+// DeleteTrashBlob mocks base method.
+func (m *MockSpaceShardHandler) DeleteTrashBlob(ctx context.Context, h storage.OpHeader, name []byte, version uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrashBlob", ctx, h, name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrashBlob indicates an expected call of DeleteTrashBlob.
+func (mr *MockSpaceShardHandlerMockRecorder) DeleteTrashBlob(ctx, h, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrashBlob", reflect.TypeOf((*MockSpaceShardHandler)(nil).DeleteTrashBlob), ctx, h, name, version)
+}
+
 // GetBlob mocks base method.
 func (m *MockSpaceShardHandler) GetBlob(ctx context.Context, h storage.OpHeader, name []byte) (proto.Blob, error) {
 	m.ctrl.T.Helper()
@@ -395,6 +579,21 @@ func (mr *MockSpaceShardHandlerMockRecorder) GetBlob(ctx, h, name interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlob", reflect.TypeOf((*MockSpaceShardHandler)(nil).GetBlob), ctx, h, name)
 }
 
+// GetDedup mocks base method.
+func (m *MockSpaceShardHandler) GetDedup(ctx context.Context, h storage.OpHeader, key []byte) (shardnode.GetDedupRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDedup", ctx, h, key)
+	ret0, _ := ret[0].(shardnode.GetDedupRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDedup indicates an expected call of GetDedup.
+func (mr *MockSpaceShardHandlerMockRecorder) GetDedup(ctx, h, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDedup", reflect.TypeOf((*MockSpaceShardHandler)(nil).GetDedup), ctx, h, key)
+}
+
 // GetItem mocks base method.
 func (m *MockSpaceShardHandler) GetItem(ctx context.Context, h storage.OpHeader, id []byte) (shardnode.Item, error) {
 	m.ctrl.T.Helper()
@@ -410,6 +609,21 @@ func (mr *MockSpaceShardHandlerMockRecorder) GetItem(ctx, h, id interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockSpaceShardHandler)(nil).GetItem), ctx, h, id)
 }
 
+// GetItemVersion mocks base method.
+func (m *MockSpaceShardHandler) GetItemVersion(ctx context.Context, h storage.OpHeader, id []byte, version uint64) (shardnode.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemVersion", ctx, h, id, version)
+	ret0, _ := ret[0].(shardnode.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemVersion indicates an expected call of GetItemVersion.
+func (mr *MockSpaceShardHandlerMockRecorder) GetItemVersion(ctx, h, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemVersion", reflect.TypeOf((*MockSpaceShardHandler)(nil).GetItemVersion), ctx, h, id, version)
+}
+
 // GetRouteVersion mocks base method.
 func (m *MockSpaceShardHandler) GetRouteVersion() proto.RouteVersion {
 	m.ctrl.T.Helper()
@@ -467,9 +681,9 @@ func (mr *MockSpaceShardHandlerMockRecorder) InsertItem(ctx, h, id, i interface{
 }
 
 // ListBlob mocks base method.
-func (m *MockSpaceShardHandler) ListBlob(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64) ([]proto.Blob, []byte, error) {
+func (m *MockSpaceShardHandler) ListBlob(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64, vid proto.Vid) ([]proto.Blob, []byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBlob", ctx, h, prefix, marker, count)
+	ret := m.ctrl.Call(m, "ListBlob", ctx, h, prefix, marker, count, vid)
 	ret0, _ := ret[0].([]proto.Blob)
 	ret1, _ := ret[1].([]byte)
 	ret2, _ := ret[2].(error)
@@ -477,15 +691,15 @@ func (m *MockSpaceShardHandler) ListBlob(ctx context.Context, h storage.OpHeader
 }
 
 // ListBlob indicates an expected call of ListBlob.
-func (mr *MockSpaceShardHandlerMockRecorder) ListBlob(ctx, h, prefix, marker, count interface{}) *gomock.Call {
+func (mr *MockSpaceShardHandlerMockRecorder) ListBlob(ctx, h, prefix, marker, count, vid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlob", reflect.TypeOf((*MockSpaceShardHandler)(nil).ListBlob), ctx, h, prefix, marker, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlob", reflect.TypeOf((*MockSpaceShardHandler)(nil).ListBlob), ctx, h, prefix, marker, count, vid)
 }
 
 // ListItem mocks base method.
-func (m *MockSpaceShardHandler) ListItem(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64) ([]shardnode.Item, []byte, error) {
+func (m *MockSpaceShardHandler) ListItem(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64, filters []shardnode.FieldFilter) ([]shardnode.Item, []byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListItem", ctx, h, prefix, marker, count)
+	ret := m.ctrl.Call(m, "ListItem", ctx, h, prefix, marker, count, filters)
 	ret0, _ := ret[0].([]shardnode.Item)
 	ret1, _ := ret[1].([]byte)
 	ret2, _ := ret[2].(error)
@@ -493,9 +707,54 @@ func (m *MockSpaceShardHandler) ListItem(ctx context.Context, h storage.OpHeader
 }
 
 // ListItem indicates an expected call of ListItem.
-func (mr *MockSpaceShardHandlerMockRecorder) ListItem(ctx, h, prefix, marker, count interface{}) *gomock.Call {
+func (mr *MockSpaceShardHandlerMockRecorder) ListItem(ctx, h, prefix, marker, count, filters interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItem", reflect.TypeOf((*MockSpaceShardHandler)(nil).ListItem), ctx, h, prefix, marker, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItem", reflect.TypeOf((*MockSpaceShardHandler)(nil).ListItem), ctx, h, prefix, marker, count, filters)
+}
+
+// ListTrashBlob mocks base method.
+func (m *MockSpaceShardHandler) ListTrashBlob(ctx context.Context, h storage.OpHeader, prefix, marker []byte, count uint64) ([]shardnode.TrashBlob, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashBlob", ctx, h, prefix, marker, count)
+	ret0, _ := ret[0].([]shardnode.TrashBlob)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTrashBlob indicates an expected call of ListTrashBlob.
+func (mr *MockSpaceShardHandlerMockRecorder) ListTrashBlob(ctx, h, prefix, marker, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashBlob", reflect.TypeOf((*MockSpaceShardHandler)(nil).ListTrashBlob), ctx, h, prefix, marker, count)
+}
+
+// RefDedup mocks base method.
+func (m *MockSpaceShardHandler) RefDedup(ctx context.Context, h storage.OpHeader, key, ref []byte, value shardnode.DedupRef) (shardnode.DedupRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefDedup", ctx, h, key, ref, value)
+	ret0, _ := ret[0].(shardnode.DedupRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefDedup indicates an expected call of RefDedup.
+func (mr *MockSpaceShardHandlerMockRecorder) RefDedup(ctx, h, key, ref, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefDedup", reflect.TypeOf((*MockSpaceShardHandler)(nil).RefDedup), ctx, h, key, ref, value)
+}
+
+// SetReadonly mocks base method.
+func (m *MockSpaceShardHandler) SetReadonly(ctx context.Context, readonly bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReadonly", ctx, readonly)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReadonly indicates an expected call of SetReadonly.
+func (mr *MockSpaceShardHandlerMockRecorder) SetReadonly(ctx, readonly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReadonly", reflect.TypeOf((*MockSpaceShardHandler)(nil).SetReadonly), ctx, readonly)
 }
 
 // Stats mocks base method.
@@ -527,6 +786,50 @@ func (mr *MockSpaceShardHandlerMockRecorder) TransferLeader(ctx, diskID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferLeader", reflect.TypeOf((*MockSpaceShardHandler)(nil).TransferLeader), ctx, diskID)
 }
 
+// TrashBlob mocks base method.
+func (m *MockSpaceShardHandler) TrashBlob(ctx context.Context, h storage.OpHeader, name []byte, expireTime int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashBlob", ctx, h, name, expireTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrashBlob indicates an expected call of TrashBlob.
+func (mr *MockSpaceShardHandlerMockRecorder) TrashBlob(ctx, h, name, expireTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashBlob", reflect.TypeOf((*MockSpaceShardHandler)(nil).TrashBlob), ctx, h, name, expireTime)
+}
+
+// UndeleteBlob mocks base method.
+func (m *MockSpaceShardHandler) UndeleteBlob(ctx context.Context, h storage.OpHeader, name []byte) (proto.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndeleteBlob", ctx, h, name)
+	ret0, _ := ret[0].(proto.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndeleteBlob indicates an expected call of UndeleteBlob.
+func (mr *MockSpaceShardHandlerMockRecorder) UndeleteBlob(ctx, h, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlob", reflect.TypeOf((*MockSpaceShardHandler)(nil).UndeleteBlob), ctx, h, name)
+}
+
+// UnrefDedup mocks base method.
+func (m *MockSpaceShardHandler) UnrefDedup(ctx context.Context, h storage.OpHeader, key, ref []byte, location proto.Location) (shardnode.UnrefDedupRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnrefDedup", ctx, h, key, ref, location)
+	ret0, _ := ret[0].(shardnode.UnrefDedupRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnrefDedup indicates an expected call of UnrefDedup.
+func (mr *MockSpaceShardHandlerMockRecorder) UnrefDedup(ctx, h, key, ref, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnrefDedup", reflect.TypeOf((*MockSpaceShardHandler)(nil).UnrefDedup), ctx, h, key, ref, location)
+}
+
 // UpdateBlob mocks base method.
 func (m *MockSpaceShardHandler) UpdateBlob(ctx context.Context, h storage.OpHeader, name []byte, b proto.Blob) error {
 	m.ctrl.T.Helper()
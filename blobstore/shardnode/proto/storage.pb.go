@@ -25,8 +25,11 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Item struct {
-	ID                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Fields               []Field  `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields"`
+	ID     []byte  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fields []Field `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields"`
+	// version and mtime(unix seconds) are set only if item versioning is enabled
+	Version              uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Mtime                int64    `protobuf:"varint,4,opt,name=mtime,proto3" json:"mtime,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -79,6 +82,94 @@ func (m *Item) GetFields() []Field {
 	return nil
 }
 
+func (m *Item) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Item) GetMtime() int64 {
+	if m != nil {
+		return m.Mtime
+	}
+	return 0
+}
+
+// VersionedItem update of item proposed into raft with the versioning policy of leader,
+// the overwritten item is kept as an old version, and old versions beyond max_versions or
+// overwritten for more than ttl_s are removed at applying
+type VersionedItem struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Item                 Item     `protobuf:"bytes,2,opt,name=item,proto3" json:"item"`
+	MaxVersions          uint32   `protobuf:"varint,3,opt,name=max_versions,json=maxVersions,proto3" json:"max_versions,omitempty"`
+	TTLS                 int64    `protobuf:"varint,4,opt,name=ttl_s,json=ttlS,proto3" json:"ttl_s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VersionedItem) Reset()         { *m = VersionedItem{} }
+func (m *VersionedItem) String() string { return proto.CompactTextString(m) }
+func (*VersionedItem) ProtoMessage()    {}
+func (*VersionedItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{1}
+}
+func (m *VersionedItem) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VersionedItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersionedItem.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VersionedItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionedItem.Merge(m, src)
+}
+func (m *VersionedItem) XXX_Size() int {
+	return m.Size()
+}
+func (m *VersionedItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionedItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionedItem proto.InternalMessageInfo
+
+func (m *VersionedItem) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *VersionedItem) GetItem() Item {
+	if m != nil {
+		return m.Item
+	}
+	return Item{}
+}
+
+func (m *VersionedItem) GetMaxVersions() uint32 {
+	if m != nil {
+		return m.MaxVersions
+	}
+	return 0
+}
+
+func (m *VersionedItem) GetTTLS() int64 {
+	if m != nil {
+		return m.TTLS
+	}
+	return 0
+}
+
 type Field struct {
 	ID                   github_com_cubefs_cubefs_blobstore_common_proto.FieldID `protobuf:"varint,1,opt,name=id,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.FieldID" json:"id,omitempty"`
 	Value                []byte                                                  `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -91,7 +182,7 @@ func (m *Field) Reset()         { *m = Field{} }
 func (m *Field) String() string { return proto.CompactTextString(m) }
 func (*Field) ProtoMessage()    {}
 func (*Field) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{1}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{2}
 }
 func (m *Field) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -145,7 +236,7 @@ func (m *ShardMemberCtx) Reset()         { *m = ShardMemberCtx{} }
 func (m *ShardMemberCtx) String() string { return proto.CompactTextString(m) }
 func (*ShardMemberCtx) ProtoMessage()    {}
 func (*ShardMemberCtx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0d2c4ccf1453ffdb, []int{2}
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{3}
 }
 func (m *ShardMemberCtx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -181,34 +272,360 @@ func (m *ShardMemberCtx) GetSuid() github_com_cubefs_cubefs_blobstore_common_pro
 	return 0
 }
 
+// TxnOp operation of transaction proposed into raft, key is encoded with shard
+// and value is marshaled item or blob
+type TxnOp struct {
+	Type        uint32                                                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Key         []byte                                                  `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value       []byte                                                  `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	CondType    uint32                                                  `protobuf:"varint,4,opt,name=cond_type,json=condType,proto3" json:"cond_type,omitempty"`
+	CondFieldID github_com_cubefs_cubefs_blobstore_common_proto.FieldID `protobuf:"varint,5,opt,name=cond_field_id,json=condFieldId,proto3,casttype=github.com/cubefs/cubefs/blobstore/common/proto.FieldID" json:"cond_field_id,omitempty"`
+	CondValue   []byte                                                  `protobuf:"bytes,6,opt,name=cond_value,json=condValue,proto3" json:"cond_value,omitempty"`
+	// shard keys, expire time and version of trash for delete blob operation
+	ShardKeys            [][]byte `protobuf:"bytes,7,rep,name=shard_keys,json=shardKeys,proto3" json:"shard_keys,omitempty"`
+	ExpireTime           int64    `protobuf:"varint,8,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	Version              uint64   `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxnOp) Reset()         { *m = TxnOp{} }
+func (m *TxnOp) String() string { return proto.CompactTextString(m) }
+func (*TxnOp) ProtoMessage()    {}
+func (*TxnOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{4}
+}
+func (m *TxnOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxnOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxnOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxnOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnOp.Merge(m, src)
+}
+func (m *TxnOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxnOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxnOp proto.InternalMessageInfo
+
+func (m *TxnOp) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *TxnOp) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *TxnOp) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *TxnOp) GetCondType() uint32 {
+	if m != nil {
+		return m.CondType
+	}
+	return 0
+}
+
+func (m *TxnOp) GetCondFieldID() github_com_cubefs_cubefs_blobstore_common_proto.FieldID {
+	if m != nil {
+		return m.CondFieldID
+	}
+	return 0
+}
+
+func (m *TxnOp) GetCondValue() []byte {
+	if m != nil {
+		return m.CondValue
+	}
+	return nil
+}
+
+func (m *TxnOp) GetShardKeys() [][]byte {
+	if m != nil {
+		return m.ShardKeys
+	}
+	return nil
+}
+
+func (m *TxnOp) GetExpireTime() int64 {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return 0
+}
+
+func (m *TxnOp) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type Txn struct {
+	Ops                  []TxnOp  `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Txn) Reset()         { *m = Txn{} }
+func (m *Txn) String() string { return proto.CompactTextString(m) }
+func (*Txn) ProtoMessage()    {}
+func (*Txn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{5}
+}
+func (m *Txn) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Txn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Txn.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Txn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Txn.Merge(m, src)
+}
+func (m *Txn) XXX_Size() int {
+	return m.Size()
+}
+func (m *Txn) XXX_DiscardUnknown() {
+	xxx_messageInfo_Txn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Txn proto.InternalMessageInfo
+
+func (m *Txn) GetOps() []TxnOp {
+	if m != nil {
+		return m.Ops
+	}
+	return nil
+}
+
+// DedupOp ref or unref of dedup key proposed into raft, key and ref_key are encoded
+// with shard, value is the marshaled dedup ref to save for ref, and the marshaled
+// dedup ref with the expected location for unref
+type DedupOp struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	RefKey               []byte   `protobuf:"bytes,2,opt,name=ref_key,json=refKey,proto3" json:"ref_key,omitempty"`
+	Value                []byte   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DedupOp) Reset()         { *m = DedupOp{} }
+func (m *DedupOp) String() string { return proto.CompactTextString(m) }
+func (*DedupOp) ProtoMessage()    {}
+func (*DedupOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{6}
+}
+func (m *DedupOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DedupOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DedupOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DedupOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DedupOp.Merge(m, src)
+}
+func (m *DedupOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *DedupOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_DedupOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DedupOp proto.InternalMessageInfo
+
+func (m *DedupOp) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *DedupOp) GetRefKey() []byte {
+	if m != nil {
+		return m.RefKey
+	}
+	return nil
+}
+
+func (m *DedupOp) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// TrashOp trash or undelete of blob proposed into raft, key is the blob key encoded with
+// shard, the blob or trash blob is read and checked at applying, version is chosen by
+// leader to make trash key of the same blob name unique
+type TrashOp struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Version              uint64   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	ExpireTime           int64    `protobuf:"varint,3,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	ShardKeys            [][]byte `protobuf:"bytes,4,rep,name=shard_keys,json=shardKeys,proto3" json:"shard_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrashOp) Reset()         { *m = TrashOp{} }
+func (m *TrashOp) String() string { return proto.CompactTextString(m) }
+func (*TrashOp) ProtoMessage()    {}
+func (*TrashOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{7}
+}
+func (m *TrashOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TrashOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TrashOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TrashOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrashOp.Merge(m, src)
+}
+func (m *TrashOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *TrashOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrashOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrashOp proto.InternalMessageInfo
+
+func (m *TrashOp) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *TrashOp) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *TrashOp) GetExpireTime() int64 {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return 0
+}
+
+func (m *TrashOp) GetShardKeys() [][]byte {
+	if m != nil {
+		return m.ShardKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Item)(nil), "persistent.Item")
+	proto.RegisterType((*VersionedItem)(nil), "persistent.VersionedItem")
 	proto.RegisterType((*Field)(nil), "persistent.Field")
 	proto.RegisterType((*ShardMemberCtx)(nil), "persistent.ShardMemberCtx")
+	proto.RegisterType((*TxnOp)(nil), "persistent.TxnOp")
+	proto.RegisterType((*Txn)(nil), "persistent.Txn")
+	proto.RegisterType((*DedupOp)(nil), "persistent.DedupOp")
+	proto.RegisterType((*TrashOp)(nil), "persistent.TrashOp")
 }
 
 func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 280 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x8e, 0x31, 0x4b, 0xc4, 0x30,
-	0x18, 0x86, 0x6d, 0xed, 0x15, 0x89, 0x9e, 0x60, 0x39, 0x8e, 0xe2, 0xd0, 0x1e, 0x9d, 0x6e, 0x6a,
-	0x40, 0x05, 0x07, 0x17, 0xad, 0x22, 0xdc, 0x20, 0x62, 0xcf, 0xc9, 0xad, 0x69, 0xbf, 0xf6, 0x02,
-	0xed, 0xa5, 0x34, 0xa9, 0xf8, 0x13, 0x6f, 0xf4, 0x17, 0x04, 0xc9, 0xcf, 0xb8, 0x49, 0x92, 0x56,
-	0x9d, 0x9d, 0xbe, 0xe4, 0xcb, 0x9b, 0xe7, 0x7d, 0xd0, 0x94, 0x0b, 0xd6, 0x65, 0x15, 0xc4, 0x6d,
-	0xc7, 0x04, 0xf3, 0x50, 0x0b, 0x1d, 0xa7, 0x5c, 0xc0, 0x56, 0x9c, 0xcf, 0x2a, 0x56, 0x31, 0xb3,
-	0xc6, 0xfa, 0x34, 0x24, 0xa2, 0x67, 0xe4, 0xac, 0x04, 0x34, 0xde, 0x1c, 0xd9, 0xb4, 0xf0, 0xad,
-	0x85, 0xb5, 0x3c, 0x49, 0x5c, 0x25, 0x43, 0x7b, 0xf5, 0x90, 0xda, 0xb4, 0xf0, 0x30, 0x72, 0x4b,
-	0x0a, 0x75, 0xc1, 0x7d, 0x7b, 0x71, 0xb8, 0x3c, 0xbe, 0x38, 0x8b, 0xff, 0x90, 0xf1, 0xa3, 0x7e,
-	0x49, 0x9c, 0x9d, 0x0c, 0x0f, 0xd2, 0x31, 0x16, 0xb5, 0x68, 0x62, 0xd6, 0xde, 0xcb, 0x2f, 0x71,
-	0x9a, 0xdc, 0x0d, 0xc4, 0xbd, 0x0c, 0xaf, 0x2b, 0x2a, 0x36, 0x3d, 0x89, 0x73, 0xd6, 0xe0, 0xbc,
-	0x27, 0x50, 0xf2, 0x9f, 0x41, 0x6a, 0x46, 0xb4, 0x3d, 0xe0, 0x9c, 0x35, 0x0d, 0xdb, 0x62, 0x23,
-	0x38, 0x74, 0x8c, 0x32, 0x33, 0x34, 0x79, 0xcf, 0xea, 0x1e, 0x7c, 0x5b, 0x7b, 0xa6, 0xc3, 0x25,
-	0x2a, 0xd1, 0xe9, 0x7a, 0x93, 0x75, 0xc5, 0x13, 0x34, 0x04, 0xba, 0x7b, 0xf1, 0xe1, 0xbd, 0x22,
-	0x87, 0xf7, 0x63, 0xb9, 0x93, 0xdc, 0x2a, 0x19, 0x3a, 0xeb, 0x9e, 0x16, 0x7b, 0x19, 0x5e, 0xfd,
-	0xb7, 0x5e, 0xff, 0x4b, 0x0d, 0x2d, 0x99, 0xef, 0x54, 0x60, 0x7d, 0xaa, 0xc0, 0xfa, 0x52, 0x81,
-	0xf5, 0x76, 0x14, 0xe3, 0x1b, 0x13, 0x21, 0xae, 0x19, 0x97, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xb7, 0x02, 0x8a, 0x21, 0x7c, 0x01, 0x00, 0x00,
+	// 605 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc7, 0xb1, 0xbd, 0xf9, 0xe8, 0xa4, 0x41, 0x65, 0x55, 0x15, 0x0b, 0xd4, 0x3a, 0xf8, 0x14,
+	0x38, 0x24, 0xa8, 0x20, 0x71, 0xe0, 0x02, 0x69, 0x85, 0xd4, 0x16, 0x54, 0xe1, 0x58, 0x3d, 0x70,
+	0xb1, 0xe2, 0x78, 0x93, 0x5a, 0x8d, 0xbd, 0x96, 0x77, 0x5d, 0x39, 0x27, 0x1e, 0x81, 0xd7, 0x2a,
+	0x37, 0x9e, 0xc0, 0x42, 0x7e, 0x8c, 0x9e, 0xd0, 0x8e, 0xdd, 0x36, 0xfd, 0xe0, 0x80, 0x38, 0x79,
+	0x77, 0x76, 0x3c, 0xf3, 0x9f, 0xdf, 0xfe, 0x17, 0xba, 0x42, 0xf2, 0x74, 0x32, 0x67, 0x83, 0x24,
+	0xe5, 0x92, 0x53, 0x48, 0x58, 0x2a, 0x42, 0x21, 0x59, 0x2c, 0x9f, 0x6d, 0xce, 0xf9, 0x9c, 0x63,
+	0x78, 0xa8, 0x56, 0x55, 0x86, 0xfd, 0x1d, 0xc8, 0x81, 0x64, 0x11, 0xdd, 0x02, 0x3d, 0x0c, 0x4c,
+	0xad, 0xa7, 0xf5, 0xd7, 0x47, 0xcd, 0xb2, 0xb0, 0xf4, 0x83, 0x7d, 0x47, 0x0f, 0x03, 0x3a, 0x84,
+	0xe6, 0x2c, 0x64, 0x8b, 0x40, 0x98, 0x7a, 0xcf, 0xe8, 0x77, 0x76, 0x9f, 0x0c, 0x6e, 0x4a, 0x0e,
+	0x3e, 0xa9, 0x93, 0x11, 0xb9, 0x28, 0xac, 0x47, 0x4e, 0x9d, 0x46, 0x4d, 0x68, 0x9d, 0xab, 0x0c,
+	0x1e, 0x9b, 0x46, 0x4f, 0xeb, 0x13, 0xe7, 0x6a, 0x4b, 0x37, 0xa1, 0x11, 0xc9, 0x30, 0x62, 0x26,
+	0xe9, 0x69, 0x7d, 0xc3, 0xa9, 0x36, 0xf6, 0x0f, 0x0d, 0xba, 0x27, 0x55, 0x06, 0x0b, 0x50, 0xca,
+	0x06, 0x18, 0x67, 0x6c, 0x59, 0x69, 0x71, 0xd4, 0x92, 0xbe, 0x02, 0x12, 0x4a, 0x16, 0x99, 0x7a,
+	0x4f, 0xeb, 0x77, 0x76, 0x37, 0x56, 0x25, 0xa8, 0x3f, 0x6a, 0x05, 0x98, 0x43, 0x5f, 0xc0, 0x7a,
+	0x34, 0xc9, 0xbd, 0xba, 0xa9, 0x40, 0x11, 0x5d, 0xa7, 0x13, 0x4d, 0xf2, 0xba, 0x8b, 0xa0, 0xdb,
+	0xd0, 0x90, 0x72, 0xe1, 0x89, 0x4a, 0xc8, 0xa8, 0x5d, 0x16, 0x16, 0x71, 0xdd, 0xcf, 0x63, 0x87,
+	0x48, 0xb9, 0x18, 0xdb, 0x09, 0x34, 0x70, 0x30, 0xfa, 0xf5, 0x9a, 0x49, 0x77, 0xf4, 0xb1, 0x62,
+	0x72, 0x59, 0x58, 0xef, 0xe6, 0xa1, 0x3c, 0xcd, 0xfc, 0xc1, 0x94, 0x47, 0xc3, 0x69, 0xe6, 0xb3,
+	0x99, 0xb8, 0xfa, 0xf8, 0x0b, 0xee, 0x2b, 0xfe, 0x6c, 0x38, 0xe5, 0x51, 0xc4, 0xe3, 0x21, 0x22,
+	0xae, 0x28, 0xd5, 0x38, 0x37, 0xa1, 0x71, 0x3e, 0x59, 0x64, 0x0c, 0x47, 0x59, 0x77, 0xaa, 0x8d,
+	0x3d, 0x83, 0xc7, 0xe3, 0xd3, 0x49, 0x1a, 0x7c, 0x61, 0x91, 0xcf, 0xd2, 0x3d, 0x99, 0x53, 0x17,
+	0x88, 0xc8, 0xea, 0xe6, 0x64, 0xf4, 0x41, 0x29, 0x1c, 0x67, 0x61, 0x70, 0x59, 0x58, 0x6f, 0xff,
+	0xb5, 0xbd, 0xfa, 0xcf, 0xc1, 0x6a, 0xf6, 0x4f, 0x1d, 0x1a, 0x6e, 0x1e, 0x1f, 0x27, 0x94, 0x02,
+	0x91, 0xcb, 0x84, 0x55, 0xc3, 0x39, 0xb8, 0xbe, 0xe2, 0xae, 0xdf, 0x70, 0xbf, 0x56, 0x6b, 0xac,
+	0xa8, 0xa5, 0xcf, 0x61, 0x6d, 0xca, 0xe3, 0xc0, 0xc3, 0x02, 0x04, 0x0b, 0xb4, 0x55, 0xc0, 0x55,
+	0x45, 0x62, 0xe8, 0xe2, 0x21, 0xba, 0xc1, 0x0b, 0x03, 0xb3, 0x81, 0xf8, 0x0e, 0xcb, 0xc2, 0xea,
+	0xec, 0xf1, 0x38, 0xa8, 0x61, 0xfc, 0x0f, 0xc7, 0xce, 0xf4, 0xba, 0x4e, 0x40, 0xb7, 0x01, 0xb0,
+	0x5f, 0xa5, 0xb3, 0x89, 0x3a, 0x51, 0xde, 0x09, 0x6a, 0xdd, 0x06, 0x10, 0x8a, 0xac, 0x77, 0xc6,
+	0x96, 0xc2, 0x6c, 0xf5, 0x0c, 0x75, 0x8c, 0x91, 0x23, 0xb6, 0x14, 0xd4, 0x82, 0x0e, 0xcb, 0x93,
+	0x30, 0x65, 0x1e, 0x1a, 0xb3, 0x8d, 0xc6, 0x84, 0x2a, 0xe4, 0x86, 0x11, 0x5b, 0x75, 0xf3, 0xda,
+	0x2d, 0x37, 0xdb, 0xaf, 0xc1, 0x70, 0xf3, 0x98, 0xbe, 0x04, 0x83, 0x27, 0xc2, 0xd4, 0xee, 0x3f,
+	0x0e, 0x04, 0x5d, 0x5b, 0x53, 0xe5, 0xd8, 0x87, 0xd0, 0xda, 0x67, 0x41, 0x96, 0x1c, 0x27, 0x0f,
+	0x58, 0xfc, 0x29, 0xb4, 0x52, 0x36, 0xf3, 0x6e, 0x2e, 0xa0, 0x99, 0xb2, 0xd9, 0xd1, 0xdf, 0xee,
+	0xc0, 0xce, 0xa0, 0xe5, 0xa6, 0x13, 0x71, 0xfa, 0x60, 0xad, 0x15, 0xd1, 0xfa, 0xed, 0x27, 0x78,
+	0x67, 0x5e, 0xe3, 0xde, 0xbc, 0xb7, 0x79, 0x91, 0x3b, 0xbc, 0x46, 0x5b, 0x17, 0xe5, 0x8e, 0xf6,
+	0xab, 0xdc, 0xd1, 0x7e, 0x97, 0x3b, 0xda, 0xb7, 0xf6, 0x60, 0xf8, 0x1e, 0xaf, 0xc6, 0x6f, 0xe2,
+	0xe7, 0xcd, 0x9f, 0x01, 0x00, 0x9c, 0x75, 0x80, 0x24, 0x7f, 0x04, 0x00, 0x00,
 }
 
 func (m *Item) Marshal() (dAtA []byte, err error) {
@@ -235,6 +652,16 @@ func (m *Item) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Mtime != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.Mtime))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Version != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.Fields) > 0 {
 		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -259,7 +686,7 @@ func (m *Item) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Field) Marshal() (dAtA []byte, err error) {
+func (m *VersionedItem) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -269,12 +696,12 @@ func (m *Field) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Field) MarshalTo(dAtA []byte) (int, error) {
+func (m *VersionedItem) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *VersionedItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -283,19 +710,73 @@ func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintStorage(dAtA, i, uint64(len(m.Value)))
+	if m.TTLS != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.TTLS))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x20
 	}
-	if m.ID != 0 {
-		i = encodeVarintStorage(dAtA, i, uint64(m.ID))
+	if m.MaxVersions != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.MaxVersions))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0x18
 	}
-	return len(dAtA) - i, nil
+	{
+		size, err := m.Item.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintStorage(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Field) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Field) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
 func (m *ShardMemberCtx) Marshal() (dAtA []byte, err error) {
@@ -330,6 +811,230 @@ func (m *ShardMemberCtx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *TxnOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxnOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxnOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.ExpireTime != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.ExpireTime))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.ShardKeys) > 0 {
+		for iNdEx := len(m.ShardKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ShardKeys[iNdEx])
+			copy(dAtA[i:], m.ShardKeys[iNdEx])
+			i = encodeVarintStorage(dAtA, i, uint64(len(m.ShardKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.CondValue) > 0 {
+		i -= len(m.CondValue)
+		copy(dAtA[i:], m.CondValue)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.CondValue)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.CondFieldID != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.CondFieldID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.CondType != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.CondType))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Txn) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Txn) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Txn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Ops) > 0 {
+		for iNdEx := len(m.Ops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintStorage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DedupOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DedupOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DedupOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RefKey) > 0 {
+		i -= len(m.RefKey)
+		copy(dAtA[i:], m.RefKey)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.RefKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TrashOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TrashOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TrashOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ShardKeys) > 0 {
+		for iNdEx := len(m.ShardKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ShardKeys[iNdEx])
+			copy(dAtA[i:], m.ShardKeys[iNdEx])
+			i = encodeVarintStorage(dAtA, i, uint64(len(m.ShardKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.ExpireTime != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.ExpireTime))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Version != 0 {
+		i = encodeVarintStorage(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintStorage(dAtA []byte, offset int, v uint64) int {
 	offset -= sovStorage(v)
 	base := offset
@@ -357,6 +1062,36 @@ func (m *Item) Size() (n int) {
 			n += 1 + l + sovStorage(uint64(l))
 		}
 	}
+	if m.Version != 0 {
+		n += 1 + sovStorage(uint64(m.Version))
+	}
+	if m.Mtime != 0 {
+		n += 1 + sovStorage(uint64(m.Mtime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VersionedItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = m.Item.Size()
+	n += 1 + l + sovStorage(uint64(l))
+	if m.MaxVersions != 0 {
+		n += 1 + sovStorage(uint64(m.MaxVersions))
+	}
+	if m.TTLS != 0 {
+		n += 1 + sovStorage(uint64(m.TTLS))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -397,44 +1132,805 @@ func (m *ShardMemberCtx) Size() (n int) {
 	return n
 }
 
-func sovStorage(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozStorage(x uint64) (n int) {
-	return sovStorage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *TxnOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovStorage(uint64(m.Type))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.CondType != 0 {
+		n += 1 + sovStorage(uint64(m.CondType))
+	}
+	if m.CondFieldID != 0 {
+		n += 1 + sovStorage(uint64(m.CondFieldID))
+	}
+	l = len(m.CondValue)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if len(m.ShardKeys) > 0 {
+		for _, b := range m.ShardKeys {
+			l = len(b)
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.ExpireTime != 0 {
+		n += 1 + sovStorage(uint64(m.ExpireTime))
+	}
+	if m.Version != 0 {
+		n += 1 + sovStorage(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (m *Item) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
+
+func (m *Txn) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ops) > 0 {
+		for _, e := range m.Ops {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DedupOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.RefKey)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TrashOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovStorage(uint64(m.Version))
+	}
+	if m.ExpireTime != 0 {
+		n += 1 + sovStorage(uint64(m.ExpireTime))
+	}
+	if len(m.ShardKeys) > 0 {
+		for _, b := range m.ShardKeys {
+			l = len(b)
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovStorage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozStorage(x uint64) (n int) {
+	return sovStorage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Item) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Item: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Item: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, Field{})
+			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mtime", wireType)
+			}
+			m.Mtime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mtime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VersionedItem) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VersionedItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VersionedItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Item", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Item.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxVersions", wireType)
+			}
+			m.MaxVersions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxVersions |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTLS", wireType)
+			}
+			m.TTLS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTLS |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Field) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Field: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Field: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= github_com_cubefs_cubefs_blobstore_common_proto.FieldID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardMemberCtx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardMemberCtx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardMemberCtx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+			}
+			m.Suid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxnOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
 				return ErrIntOverflowStorage
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxnOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxnOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CondType", wireType)
+			}
+			m.CondType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CondType |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CondFieldID", wireType)
+			}
+			m.CondFieldID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CondFieldID |= github_com_cubefs_cubefs_blobstore_common_proto.FieldID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CondValue", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.CondValue = append(m.CondValue[:0], dAtA[iNdEx:postIndex]...)
+			if m.CondValue == nil {
+				m.CondValue = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Item: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Item: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeys", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -461,14 +1957,101 @@ func (m *Item) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
+			m.ShardKeys = append(m.ShardKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ShardKeys[len(m.ShardKeys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTime", wireType)
+			}
+			m.ExpireTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Txn) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Txn: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Txn: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ops", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -495,8 +2078,8 @@ func (m *Item) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fields = append(m.Fields, Field{})
-			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Ops = append(m.Ops, TxnOp{})
+			if err := m.Ops[len(m.Ops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -522,7 +2105,7 @@ func (m *Item) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Field) Unmarshal(dAtA []byte) error {
+func (m *DedupOp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -545,17 +2128,17 @@ func (m *Field) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Field: wiretype end group for non-group")
+			return fmt.Errorf("proto: DedupOp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Field: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DedupOp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			m.ID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStorage
@@ -565,12 +2148,61 @@ func (m *Field) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= github_com_cubefs_cubefs_blobstore_common_proto.FieldID(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefKey = append(m.RefKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.RefKey == nil {
+				m.RefKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
@@ -626,7 +2258,7 @@ func (m *Field) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardMemberCtx) Unmarshal(dAtA []byte) error {
+func (m *TrashOp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -649,17 +2281,51 @@ func (m *ShardMemberCtx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardMemberCtx: wiretype end group for non-group")
+			return fmt.Errorf("proto: TrashOp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardMemberCtx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TrashOp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Suid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.Suid = 0
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStorage
@@ -669,11 +2335,62 @@ func (m *ShardMemberCtx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Suid |= github_com_cubefs_cubefs_blobstore_common_proto.Suid(b&0x7F) << shift
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTime", wireType)
+			}
+			m.ExpireTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShardKeys = append(m.ShardKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ShardKeys[len(m.ShardKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorage(dAtA[iNdEx:])
@@ -143,6 +143,126 @@ func (s *RpcService) ListBlob(w rpc2.ResponseWriter, req *rpc2.Request) error {
 	return w.WriteOK(&ret)
 }
 
+func (s *RpcService) TrashBlob(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.TrashBlobArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive TrashBlob request, args:%+v", args)
+
+	if err := s.trashBlob(ctx, args); err != nil {
+		span.Errorf("trash blob failed, err: %s, name: %s", errors.Detail(err), string(args.Name))
+		return err
+	}
+	return nil
+}
+
+func (s *RpcService) UndeleteBlob(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.UndeleteBlobArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive UndeleteBlob request, args:%+v", args)
+
+	ret, err := s.undeleteBlob(ctx, args)
+	if err != nil {
+		span.Errorf("undelete blob failed, err: %s, name: %s", errors.Detail(err), string(args.Name))
+		return err
+	}
+	return w.WriteOK(&ret)
+}
+
+func (s *RpcService) ListTrashBlob(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.ListTrashBlobArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive ListTrashBlob request, args:%+v", args)
+
+	ret, err := s.listTrashBlob(ctx, args)
+	if err != nil {
+		span.Errorf("list trash blob failed, err: %s", errors.Detail(err))
+		return err
+	}
+	return w.WriteOK(&ret)
+}
+
+func (s *RpcService) PurgeTrashBlob(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.PurgeTrashBlobArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive PurgeTrashBlob request, args:%+v", args)
+
+	return s.purgeTrashBlob(ctx, args)
+}
+
+func (s *RpcService) RefDedup(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.RefDedupArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive RefDedup request, args:%+v", args)
+
+	ret, err := s.refDedup(ctx, args)
+	if err != nil {
+		span.Errorf("ref dedup failed, err: %s, key: %s", errors.Detail(err), string(args.Key))
+		return err
+	}
+	return w.WriteOK(&ret)
+}
+
+func (s *RpcService) GetDedup(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.GetDedupArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive GetDedup request, args:%+v", args)
+
+	ret, err := s.getDedup(ctx, args)
+	if err != nil {
+		span.Errorf("get dedup failed, err: %s, key: %s", errors.Detail(err), string(args.Key))
+		return err
+	}
+	return w.WriteOK(&ret)
+}
+
+func (s *RpcService) UnrefDedup(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.UnrefDedupArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive UnrefDedup request, args:%+v", args)
+
+	ret, err := s.unrefDedup(ctx, args)
+	if err != nil {
+		span.Errorf("unref dedup failed, err: %s, key: %s", errors.Detail(err), string(args.Key))
+		return err
+	}
+	return w.WriteOK(&ret)
+}
+
 func (s *RpcService) AllocSlice(w rpc2.ResponseWriter, req *rpc2.Request) error {
 	ctx := req.Context()
 	span := req.Span()
@@ -234,6 +354,23 @@ func (s *RpcService) ListItem(w rpc2.ResponseWriter, req *rpc2.Request) error {
 	return w.WriteOK(&ret)
 }
 
+func (s *RpcService) CommitTxn(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.CommitTxnArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Debugf("receive CommitTxn request, args:%+v", args)
+
+	if err := s.commitTxn(ctx, args); err != nil {
+		span.Errorf("commit txn failed, err: %s, ops: %d", errors.Detail(err), len(args.Ops))
+		return err
+	}
+	return nil
+}
+
 func (s *RpcService) AddShard(w rpc2.ResponseWriter, req *rpc2.Request) error {
 	ctx := req.Context()
 	span := req.Span()
@@ -285,6 +422,23 @@ func (s *RpcService) TransferShardLeader(w rpc2.ResponseWriter, req *rpc2.Reques
 	return nil
 }
 
+func (s *RpcService) SetShardReadonly(w rpc2.ResponseWriter, req *rpc2.Request) error {
+	ctx := req.Context()
+	span := req.Span()
+
+	args := &shardnode.SetShardReadonlyArgs{}
+	if err := req.ParseParameter(args); err != nil {
+		return err
+	}
+	span.Infof("receive SetShardReadonly request, args:%+v", args)
+
+	if err := s.setShardReadonly(ctx, args); err != nil {
+		span.Errorf("set shard readonly failed, err: %s", errors.Detail(err))
+		return err
+	}
+	return nil
+}
+
 func (s *RpcService) GetShardInfo(w rpc2.ResponseWriter, req *rpc2.Request) error {
 	ctx := req.Context()
 	span := req.Span()
@@ -417,34 +571,44 @@ func initConfig(args []string) (*cmd.Config, error) {
 
 func newHandler(s *RpcService) *rpc2.Router {
 	handler := &rpc2.Router{}
-	handler.Register("/blob/create", s.CreateBlob)
-	handler.Register("/blob/delete", s.DeleteBlob)
-	handler.Register("/blob/findAndDelete", s.FindAndDeleteBlob)
-	handler.Register("/blob/seal", s.SealBlob)
-	handler.Register("/blob/get", s.GetBlob)
-	handler.Register("/blob/list", s.ListBlob)
-	handler.Register("/slice/alloc", s.AllocSlice)
-
-	handler.Register("/item/insert", s.InsertItem)
-	handler.Register("/item/delete", s.DeleteItem)
-	handler.Register("/item/update", s.UpdateItem)
-	handler.Register("/item/get", s.GetItem)
-	handler.Register("/item/list", s.ListItem)
-
-	handler.Register("/shard/add", s.AddShard)
-	handler.Register("/shard/update", s.UpdateShard)
-	handler.Register("/shard/leadertransfer", s.TransferShardLeader)
-
-	handler.Register("/shard/info", s.GetShardInfo)
-	handler.Register("/shard/stats", s.GetShardStats)
-	handler.Register("/shard/list", s.ListShard)
-	handler.Register("/volume/list", s.ListVolume)
-
-	handler.Register("/tcmalloc/stats", s.TCMallocStats)
-	handler.Register("/tcmalloc/free", s.TCMallocFree)
-	handler.Register("/tcmalloc/rate", s.TCMallocMemoryReleaseRate)
-
-	handler.Register("/db/stats", s.DBStats)
+	handler.Register("/blob/create", s.CreateBlob, rpc2.OptParameter(&shardnode.CreateBlobArgs{}))
+	handler.Register("/blob/delete", s.DeleteBlob, rpc2.OptParameter(&shardnode.DeleteBlobArgs{}))
+	handler.Register("/blob/findAndDelete", s.FindAndDeleteBlob, rpc2.OptParameter(&shardnode.DeleteBlobArgs{}))
+	handler.Register("/blob/seal", s.SealBlob, rpc2.OptParameter(&shardnode.SealBlobArgs{}))
+	handler.Register("/blob/get", s.GetBlob, rpc2.OptParameter(&shardnode.GetBlobArgs{}))
+	handler.Register("/blob/list", s.ListBlob, rpc2.OptParameter(&shardnode.ListBlobArgs{}))
+	handler.Register("/blob/trash", s.TrashBlob, rpc2.OptParameter(&shardnode.TrashBlobArgs{}))
+	handler.Register("/blob/undelete", s.UndeleteBlob, rpc2.OptParameter(&shardnode.UndeleteBlobArgs{}))
+	handler.Register("/blob/trash/list", s.ListTrashBlob, rpc2.OptParameter(&shardnode.ListTrashBlobArgs{}))
+	handler.Register("/blob/trash/purge", s.PurgeTrashBlob, rpc2.OptParameter(&shardnode.PurgeTrashBlobArgs{}))
+	handler.Register("/slice/alloc", s.AllocSlice, rpc2.OptParameter(&shardnode.AllocSliceArgs{}))
+	handler.Register("/dedup/ref", s.RefDedup, rpc2.OptParameter(&shardnode.RefDedupArgs{}))
+	handler.Register("/dedup/get", s.GetDedup, rpc2.OptParameter(&shardnode.GetDedupArgs{}))
+	handler.Register("/dedup/unref", s.UnrefDedup, rpc2.OptParameter(&shardnode.UnrefDedupArgs{}))
+
+	handler.Register("/item/insert", s.InsertItem, rpc2.OptParameter(&shardnode.InsertItemArgs{}))
+	handler.Register("/item/delete", s.DeleteItem, rpc2.OptParameter(&shardnode.DeleteItemArgs{}))
+	handler.Register("/item/update", s.UpdateItem, rpc2.OptParameter(&shardnode.UpdateItemArgs{}))
+	handler.Register("/item/get", s.GetItem, rpc2.OptParameter(&shardnode.GetItemArgs{}))
+	handler.Register("/item/list", s.ListItem, rpc2.OptParameter(&shardnode.ListItemArgs{}))
+
+	handler.Register("/txn/commit", s.CommitTxn, rpc2.OptParameter(&shardnode.CommitTxnArgs{}))
+
+	handler.Register("/shard/add", s.AddShard, rpc2.OptParameter(&shardnode.AddShardArgs{}))
+	handler.Register("/shard/update", s.UpdateShard, rpc2.OptParameter(&shardnode.UpdateShardArgs{}))
+	handler.Register("/shard/leadertransfer", s.TransferShardLeader, rpc2.OptParameter(&shardnode.TransferShardLeaderArgs{}))
+	handler.Register("/shard/readonly", s.SetShardReadonly, rpc2.OptParameter(&shardnode.SetShardReadonlyArgs{}))
+
+	handler.Register("/shard/info", s.GetShardInfo, rpc2.OptParameter(&shardnode.GetShardArgs{}))
+	handler.Register("/shard/stats", s.GetShardStats, rpc2.OptParameter(&shardnode.GetShardArgs{}))
+	handler.Register("/shard/list", s.ListShard, rpc2.OptParameter(&shardnode.ListShardArgs{}))
+	handler.Register("/volume/list", s.ListVolume, rpc2.OptParameter(&shardnode.ListVolumeArgs{}))
+
+	handler.Register("/tcmalloc/stats", s.TCMallocStats, rpc2.OptParameter(rpc2.NoParameter))
+	handler.Register("/tcmalloc/free", s.TCMallocFree, rpc2.OptParameter(rpc2.NoParameter))
+	handler.Register("/tcmalloc/rate", s.TCMallocMemoryReleaseRate, rpc2.OptParameter(rpc2.NoParameter))
+
+	handler.Register("/db/stats", s.DBStats, rpc2.OptParameter(&shardnode.DBStatsArgs{}))
 
 	return handler
 }
@@ -174,7 +174,7 @@ func TestRpcService_Blob(t *testing.T) {
 	sh.EXPECT().DeleteBlob(A, A, A).Return(nil).AnyTimes()
 	sh.EXPECT().UpdateBlob(A, A, A, A).Return(nil).AnyTimes()
 
-	sh.EXPECT().ListBlob(A, A, A, A, A).Return(
+	sh.EXPECT().ListBlob(A, A, A, A, A, A).Return(
 		[]proto.Blob{blob}, nil, nil,
 	)
 
@@ -279,7 +279,7 @@ func TestRpcService_Item(t *testing.T) {
 
 	sh.EXPECT().GetItem(A, A, A).Return(item, nil).Times(2).AnyTimes()
 	sh.EXPECT().UpdateItem(A, A, A, A).Return(nil).AnyTimes()
-	sh.EXPECT().ListItem(A, A, A, A, A).Return([]shardnode.Item{item}, nil, nil).AnyTimes()
+	sh.EXPECT().ListItem(A, A, A, A, A, A).Return([]shardnode.Item{item}, nil, nil).AnyTimes()
 	sh.EXPECT().DeleteItem(A, A, A).Return(nil).AnyTimes()
 
 	suid := proto.EncodeSuid(shardID, 1, 0)
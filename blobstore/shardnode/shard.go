@@ -59,6 +59,16 @@ func (s *service) transferShardLeader(ctx context.Context, req *shardnode.Transf
 	return shard.TransferLeader(ctx, req.GetDestDiskID())
 }
 
+// setShardReadonly set shard readonly or writable through raft
+func (s *service) setShardReadonly(ctx context.Context, req *shardnode.SetShardReadonlyArgs) error {
+	shard, err := s.GetShard(req.DiskID, req.Suid)
+	if err != nil {
+		return err
+	}
+
+	return shard.SetReadonly(ctx, req.GetReadonly())
+}
+
 func (s *service) getShardUintInfo(ctx context.Context, diskID proto.DiskID, suid proto.Suid) (ret clustermgr.ShardUnitInfo, err error) {
 	shard, err := s.GetShard(diskID, suid)
 	if err != nil {
@@ -181,12 +191,15 @@ func (s *service) loop(ctx context.Context) {
 	routeUpdateTicker := time.NewTicker(time.Duration(s.cfg.RouteUpdateIntervalS) * time.Second)
 	checkpointTicker := time.NewTicker(time.Duration(s.cfg.CheckPointIntervalM) * time.Minute)
 	trashShardCheckTicker := time.NewTicker(time.Duration(s.cfg.ShardCheckAndClearIntervalH) * time.Hour)
+	orphanGCTicker := time.NewTicker(time.Duration(s.cfg.OrphanGCIntervalH) * time.Hour)
 
 	defer func() {
 		heartbeatTicker.Stop()
 		reportTicker.Stop()
 		routeUpdateTicker.Stop()
 		checkpointTicker.Stop()
+		trashShardCheckTicker.Stop()
+		orphanGCTicker.Stop()
 	}()
 
 	var span trace.Span
@@ -211,6 +224,7 @@ func (s *service) loop(ctx context.Context) {
 					Free:         diskInfo.Free,
 					UsedShardCnt: int32(disk.GetShardCnt()),
 				})
+				disk.ReportPendingTrash()
 			}
 			if err := s.transport.HeartbeatDisks(ctx, diskReports); err != nil {
 				span.Warnf("heartbeat to master failed: %s", err)
@@ -224,6 +238,8 @@ func (s *service) loop(ctx context.Context) {
 			s.generateTasksAndExecute(ctx, tasks, proto.ShardTaskTypeCheckpoint, "do checkpoint")
 		case <-trashShardCheckTicker.C:
 			s.generateTasksAndExecute(ctx, tasks, proto.ShardTaskTypeCheckAndClear, "check shard and clear")
+		case <-orphanGCTicker.C:
+			s.gcOrphans(ctx)
 		case <-s.closer.Done():
 			return
 		}
@@ -397,3 +413,30 @@ func (s *service) generateTasksAndExecute(
 		}
 	}
 }
+
+// gcOrphans deletes shard data and raft log of disks which the shard is
+// neither loaded nor assigned to the disk in clustermgr route
+func (s *service) gcOrphans(ctx context.Context) {
+	span, ctx := trace.StartSpanFromContext(ctx, "gc-orphans")
+	grace := time.Duration(s.cfg.OrphanGCGraceH) * time.Hour
+
+	for _, disk := range s.getAllDisks() {
+		units, err := s.transport.ListShardUnits(ctx, disk.DiskID())
+		if err != nil {
+			span.Warnf("list shard units of disk[%d] failed: %s", disk.DiskID(), err)
+			continue
+		}
+		assigned := make(map[proto.ShardID]struct{}, len(units))
+		for _, unit := range units {
+			assigned[unit.Suid.ShardID()] = struct{}{}
+		}
+
+		deleted, err := disk.GCOrphans(ctx, assigned, grace)
+		if len(deleted) > 0 {
+			span.Warnf("disk[%d] deleted orphan shards: %v", disk.DiskID(), deleted)
+		}
+		if err != nil {
+			span.Errorf("gc orphans of disk[%d] failed: %s", disk.DiskID(), errors.Detail(err))
+		}
+	}
+}
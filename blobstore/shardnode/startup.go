@@ -70,8 +70,10 @@ func (s *service) initDisks(ctx context.Context) error {
 			StoreConfig:     s.cfg.StoreConfig,
 			Transport:       s.transport,
 			RaftConfig:      s.cfg.RaftConfig,
+			GroupCommit:     s.cfg.GroupCommit,
 			ShardBaseConfig: s.cfg.ShardBaseConfig,
 			HandleEIO:       s.handleEIO,
+			ShardMetrics:    s.shardMetrics,
 		})
 		// open disk failed, check disk status,
 		if err != nil {
@@ -320,8 +322,10 @@ func (s *service) waitReOpenDisk(ctx context.Context, diskInfo clustermgr.ShardN
 					StoreConfig:     s.cfg.StoreConfig,
 					Transport:       s.transport,
 					RaftConfig:      s.cfg.RaftConfig,
+					GroupCommit:     s.cfg.GroupCommit,
 					ShardBaseConfig: s.cfg.ShardBaseConfig,
 					HandleEIO:       s.handleEIO,
+					ShardMetrics:    s.shardMetrics,
 				})
 				if err != nil {
 					span.Errorf("open disk[%s] failed: %s", diskInfo.Path, err)
@@ -387,6 +391,8 @@ func initServiceConfig(cfg *Config) {
 	defaulter.LessOrEqual(&cfg.ShardBaseConfig.TruncateWalLogInterval, uint64(1<<16))
 	defaulter.LessOrEqual(&cfg.ShardBaseConfig.RaftSnapTransmitConfig.BatchInflightNum, 64)
 	defaulter.LessOrEqual(&cfg.ShardBaseConfig.RaftSnapTransmitConfig.BatchInflightSize, 1<<20)
+	defaulter.LessOrEqual(&cfg.GroupCommit.WindowMS, 1)
+	defaulter.LessOrEqual(&cfg.GroupCommit.MaxBatchNum, 64)
 	defaulter.LessOrEqual(&cfg.HeartBeatIntervalS, int64(1))
 	defaulter.LessOrEqual(&cfg.ReportIntervalS, int64(60))
 	defaulter.LessOrEqual(&cfg.RouteUpdateIntervalS, int64(5))
@@ -394,6 +400,8 @@ func initServiceConfig(cfg *Config) {
 	defaulter.LessOrEqual(&cfg.WaitRepairCloseDiskIntervalS, int64(30))
 	defaulter.LessOrEqual(&cfg.WaitReOpenDiskIntervalS, int64(30))
 	defaulter.LessOrEqual(&cfg.ShardCheckAndClearIntervalH, int64(24))
+	defaulter.LessOrEqual(&cfg.OrphanGCIntervalH, int64(1))
+	defaulter.LessOrEqual(&cfg.OrphanGCGraceH, int64(24))
 }
 
 func isDiskInfoMatch(a, b clustermgr.ShardNodeDiskInfo) bool {
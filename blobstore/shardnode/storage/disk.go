@@ -55,9 +55,11 @@ type (
 		CheckMountPoint bool
 		StoreConfig     store.Config
 		RaftConfig      raft.Config
+		GroupCommit     GroupCommitConfig
 		Transport       base.Transport
 		ShardBaseConfig ShardBaseConfig
 		HandleEIO       func(ctx context.Context, diskID proto.DiskID, err error)
+		ShardMetrics    *ShardMetrics
 	}
 )
 
@@ -186,12 +188,15 @@ type Disk struct {
 		shards     map[proto.Suid]*shard
 		shardCheck map[proto.ShardID]struct{}
 	}
-	raftManager raft.Manager
-	store       *store.Store
-	cfg         DiskConfig
+	raftManager  raft.Manager
+	walCommitter *groupCommitter
+	store        *store.Store
+	cfg          DiskConfig
 
 	lock               sync.RWMutex
 	isRaftErrorHandled bool
+
+	orphans orphans
 }
 
 func (d *Disk) Load(ctx context.Context) error {
@@ -200,7 +205,8 @@ func (d *Disk) Load(ctx context.Context) error {
 	// initial raft manager
 	raftConfig := &d.cfg.RaftConfig
 	raftConfig.NodeID = uint64(d.diskInfo.DiskID)
-	raftConfig.Storage = &raftStorage{kvStore: d.store.RaftStore()}
+	d.walCommitter = newGroupCommitter(d.cfg.GroupCommit, d.store.RaftStore())
+	raftConfig.Storage = &raftStorage{kvStore: d.store.RaftStore(), committer: d.walCommitter}
 	raftConfig.Logger = log.DefaultLogger
 	raftConfig.ErrorHandler = d.handleRaftError
 	raftManager, err := raft.NewManager(raftConfig)
@@ -236,6 +242,12 @@ func (d *Disk) Load(ctx context.Context) error {
 		kg.Close()
 		vg.Close()
 
+		recoverIndex, err := d.verifyShardApplied(ctx, suid, shardInfo)
+		if err != nil {
+			span.Warnf("suid[%d] verify shard applied failed, err: %v", suid, err)
+			return err
+		}
+
 		shard, err := newShard(ctx, shardConfig{
 			suid:            suid,
 			diskID:          d.diskInfo.DiskID,
@@ -250,11 +262,15 @@ func (d *Disk) Load(ctx context.Context) error {
 			span.Warnf("suid[%d] new shard failed, err: %v", suid, err)
 			return err
 		}
+		if recoverIndex > 0 {
+			shard.shardState.setRecovering(recoverIndex)
+		}
 
 		d.shardsMu.Lock()
 		d.shardsMu.shards[suid] = shard
 		d.shardsMu.shardCheck[suid.ShardID()] = struct{}{}
 		d.shardsMu.Unlock()
+		d.cfg.ShardMetrics.addShard(d.diskInfo.DiskID, suid)
 
 		shard.Start()
 	}
@@ -326,6 +342,7 @@ func (d *Disk) AddShard(ctx context.Context, suid proto.Suid,
 
 	d.shardsMu.shards[suid] = shard
 	d.shardsMu.shardCheck[suid.ShardID()] = struct{}{}
+	d.cfg.ShardMetrics.addShard(d.diskInfo.DiskID, suid)
 	shard.Start()
 	return nil
 }
@@ -417,6 +434,7 @@ func (d *Disk) DeleteShard(ctx context.Context, suid proto.Suid, version proto.R
 
 	delete(d.shardsMu.shards, suid)
 	delete(d.shardsMu.shardCheck, suid.ShardID())
+	d.cfg.ShardMetrics.removeShard(suid)
 	span.Warnf("disk[%d] shard[%d], suid[%d] delete success", d.DiskID(), suid.ShardID(), suid)
 
 	return nil
@@ -539,10 +557,24 @@ func (d *Disk) DBStats(ctx context.Context, db string) (stats kvstore.Stats, err
 	return d.store.DBStats(ctx, db)
 }
 
+// ReportPendingTrash reports bytes of obsolete sst files waiting to be deleted on the disk
+func (d *Disk) ReportPendingTrash() {
+	sstPendingTrashMetric.WithLabelValues(d.DiskID().ToString()).Set(float64(d.store.PendingTrashBytes()))
+}
+
 func (d *Disk) Close() {
+	sstPendingTrashMetric.DeleteLabelValues(d.DiskID().ToString())
+	d.shardsMu.RLock()
+	for suid := range d.shardsMu.shards {
+		d.cfg.ShardMetrics.removeShard(suid)
+	}
+	d.shardsMu.RUnlock()
 	if d.raftManager != nil {
 		d.raftManager.Close()
 	}
+	if d.walCommitter != nil {
+		d.walCommitter.Close()
+	}
 	if d.store != nil {
 		d.store.Close()
 	}
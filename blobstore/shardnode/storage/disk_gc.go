@@ -0,0 +1,151 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/raft"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+// orphans of the disk are the shard data and raft log left in store, which the shard is
+// neither loaded on this disk nor assigned to this disk in clustermgr route, such as
+// the shard was migrated out or deleted with failure in halfway.
+type orphans struct {
+	sync.Mutex
+	firstSeen map[proto.ShardID]time.Time
+}
+
+// GCOrphans scans the shard data and raft log of disk, the orphan found at the first time is
+// recorded, and deleted if it is still orphan after grace period. Returns the deleted orphans.
+func (d *Disk) GCOrphans(ctx context.Context, assigned map[proto.ShardID]struct{}, grace time.Duration) ([]proto.ShardID, error) {
+	span := trace.SpanFromContextSafe(ctx)
+	if err := d.prepRWCheck(); err != nil {
+		return nil, err
+	}
+
+	dataIDs, err := scanIDs(ctx, d.store.KVStore(), dataCF, shardDataPrefix, math.MaxUint32,
+		func(id uint64) []byte {
+			raw := make([]byte, shardDataPrefixSize())
+			encodeShardDataPrefix(proto.ShardID(id), raw)
+			return raw
+		}, func(key []byte) uint64 {
+			return uint64(decodeShardDataPrefix(key))
+		})
+	if err != nil {
+		return nil, errors.Info(err, "scan shard data failed")
+	}
+	raftIDs, err := scanIDs(ctx, d.store.RaftStore(), raftWalCF, raft.GroupsKeyPrefix(), math.MaxUint64,
+		raft.EncodeGroupKeyPrefix, raft.DecodeGroupID)
+	if err != nil {
+		return nil, errors.Info(err, "scan raft log failed")
+	}
+
+	// hold the shards to avoid adding shard when deleting orphans
+	d.shardsMu.RLock()
+	defer d.shardsMu.RUnlock()
+
+	found := make(map[proto.ShardID]struct{})
+	for _, ids := range []map[uint64]struct{}{dataIDs, raftIDs} {
+		for id := range ids {
+			shardID := proto.ShardID(id)
+			if _, ok := d.shardsMu.shardCheck[shardID]; ok {
+				continue
+			}
+			if _, ok := assigned[shardID]; ok {
+				continue
+			}
+			found[shardID] = struct{}{}
+		}
+	}
+
+	d.orphans.Lock()
+	defer d.orphans.Unlock()
+	if d.orphans.firstSeen == nil {
+		d.orphans.firstSeen = make(map[proto.ShardID]time.Time)
+	}
+	for shardID := range d.orphans.firstSeen {
+		if _, ok := found[shardID]; !ok {
+			delete(d.orphans.firstSeen, shardID)
+		}
+	}
+
+	now := time.Now()
+	deleted := make([]proto.ShardID, 0)
+	for shardID := range found {
+		seen, ok := d.orphans.firstSeen[shardID]
+		if !ok {
+			span.Warnf("disk[%d] found orphan shard[%d]", d.DiskID(), shardID)
+			d.orphans.firstSeen[shardID] = now
+			continue
+		}
+		if now.Sub(seen) < grace {
+			continue
+		}
+		if err = d.deleteOrphan(ctx, shardID); err != nil {
+			return deleted, errors.Info(err, "delete orphan failed", shardID)
+		}
+		span.Warnf("disk[%d] orphan shard[%d] found at %s is deleted", d.DiskID(), shardID, seen)
+		delete(d.orphans.firstSeen, shardID)
+		deleted = append(deleted, shardID)
+	}
+	return deleted, nil
+}
+
+func (d *Disk) deleteOrphan(ctx context.Context, shardID proto.ShardID) error {
+	start, end := make([]byte, shardDataPrefixSize()), make([]byte, shardMaxPrefixSize())
+	encodeShardDataPrefix(shardID, start)
+	encodeShardDataMaxPrefix(shardID, end)
+	if err := d.store.KVStore().DeleteRange(ctx, dataCF, start, end); err != nil {
+		return err
+	}
+	return d.store.RaftStore().DeleteRange(ctx, raftWalCF,
+		raft.EncodeGroupKeyPrefix(uint64(shardID)), raft.EncodeGroupKeyPrefix(uint64(shardID)+1))
+}
+
+// scanIDs returns ids of all keys with prefix, and seeks to the next id after one id found.
+func scanIDs(ctx context.Context, kvStore kvstore.Store, cf kvstore.CF, prefix []byte, maxID uint64,
+	encode func(id uint64) []byte, decode func(key []byte) uint64,
+) (map[uint64]struct{}, error) {
+	lr := kvStore.List(ctx, cf, prefix, nil, nil)
+	defer lr.Close()
+
+	ids := make(map[uint64]struct{})
+	for {
+		kg, vg, err := lr.ReadNext()
+		if err != nil {
+			return nil, err
+		}
+		if kg == nil || vg == nil {
+			return ids, nil
+		}
+		id := decode(kg.Key())
+		kg.Close()
+		vg.Close()
+
+		ids[id] = struct{}{}
+		if id >= maxID {
+			return ids, nil
+		}
+		lr.Seek(encode(id + 1))
+	}
+}
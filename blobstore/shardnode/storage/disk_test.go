@@ -15,16 +15,20 @@
 package storage
 
 import (
+	"math"
 	"os"
 	"strings"
 	"sync/atomic"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
 	"github.com/cubefs/cubefs/blobstore/common/errors"
+	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/raft"
 	"github.com/cubefs/cubefs/blobstore/common/sharding"
@@ -241,6 +245,159 @@ func TestServerDisk_Shard(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestServerDisk_GCOrphans(t *testing.T) {
+	diskID := genDiskID(1)[0]
+	disk, clearFunc, err := NewMockDisk(t, diskID)
+	defer clearFunc()
+	require.NoError(t, err)
+	d := disk.GetDisk()
+
+	rgs := sharding.InitShardingRange(sharding.RangeType_RangeTypeHash, 1, 1)
+	suid := proto.EncodeSuid(1, 0, 0)
+	require.NoError(t, d.AddShard(ctx, suid, 0, *rgs[0], []clustermgr.ShardUnit{{DiskID: diskID, Suid: suid}}))
+
+	kvStore, raftStore := d.store.KVStore(), d.store.RaftStore()
+	dataKey := func(shardID proto.ShardID) []byte {
+		raw := make([]byte, shardItemPrefixSize()+1)
+		encodeShardItemPrefix(shardID, raw)
+		raw[len(raw)-1] = 'k'
+		return raw
+	}
+	for _, shardID := range []proto.ShardID{1, 3} {
+		require.NoError(t, kvStore.SetRaw(ctx, dataCF, dataKey(shardID), []byte("v")))
+	}
+	require.NoError(t, raftStore.SetRaw(ctx, raftWalCF, raft.EncodeHardStateKey(3), []byte("v")))
+	require.NoError(t, raftStore.SetRaw(ctx, raftWalCF, raft.EncodeHardStateKey(4), []byte("v")))
+	require.NoError(t, raftStore.SetRaw(ctx, raftWalCF, raft.EncodeIndexLogKey(4, 10), []byte("v")))
+
+	// found at the first time
+	deleted, err := d.GCOrphans(ctx, map[proto.ShardID]struct{}{5: {}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(deleted))
+
+	// shard 4 assigned to this disk in route
+	deleted, err = d.GCOrphans(ctx, map[proto.ShardID]struct{}{4: {}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []proto.ShardID{3}, deleted)
+	_, err = kvStore.GetRaw(ctx, dataCF, dataKey(3))
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+	_, err = raftStore.GetRaw(ctx, raftWalCF, raft.EncodeHardStateKey(3))
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+	_, err = kvStore.GetRaw(ctx, dataCF, dataKey(1))
+	require.NoError(t, err)
+
+	// in grace period
+	for range [2]struct{}{} {
+		deleted, err = d.GCOrphans(ctx, nil, time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(deleted))
+	}
+	_, err = raftStore.GetRaw(ctx, raftWalCF, raft.EncodeIndexLogKey(4, 10))
+	require.NoError(t, err)
+
+	deleted, err = d.GCOrphans(ctx, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, []proto.ShardID{4}, deleted)
+	_, err = raftStore.GetRaw(ctx, raftWalCF, raft.EncodeIndexLogKey(4, 10))
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+	_, err = kvStore.GetRaw(ctx, dataCF, dataKey(1))
+	require.NoError(t, err)
+
+	d.SetBroken()
+	_, err = d.GCOrphans(ctx, nil, 0)
+	require.Error(t, err)
+}
+
+func TestServerDisk_VerifyShardApplied(t *testing.T) {
+	diskID := genDiskID(1)[0]
+	disk, clearFunc, err := NewMockDisk(t, diskID)
+	defer clearFunc()
+	require.NoError(t, err)
+	d := disk.GetDisk()
+
+	shardID := proto.ShardID(10)
+	suid := proto.EncodeSuid(shardID, 0, 0)
+	keys := &shardKeysGenerator{suid: suid}
+	info := &shardInfo{
+		ShardID:      shardID,
+		AppliedIndex: 10,
+		Units: []clustermgr.ShardUnit{
+			{DiskID: diskID, Suid: suid},
+			{DiskID: diskID + 1, Suid: proto.EncodeSuid(shardID, 1, 0)},
+			{DiskID: diskID + 2, Suid: proto.EncodeSuid(shardID, 2, 0)},
+		},
+	}
+	kvStore, raftStore := d.store.KVStore(), d.store.RaftStore()
+
+	_, err = decodeApplyMarker([]byte("invalid"))
+	require.Error(t, err)
+	index, err := decodeApplyMarker(encodeApplyMarker(10))
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+
+	// no marker of older version
+	recoverIndex, err := d.verifyShardApplied(ctx, suid, info)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), recoverIndex)
+	for _, marker := range []uint64{10, 12} {
+		require.NoError(t, kvStore.SetRaw(ctx, dataCF, keys.encodeApplyMarkerKey(), encodeApplyMarker(marker)))
+		recoverIndex, err = d.verifyShardApplied(ctx, suid, info)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), recoverIndex)
+	}
+
+	// state machine lags behind raft
+	itemKey := keys.encodeItemKey([]byte("k"))
+	require.NoError(t, kvStore.SetRaw(ctx, dataCF, itemKey, []byte("v")))
+	require.NoError(t, kvStore.SetRaw(ctx, dataCF, keys.encodeApplyMarkerKey(), encodeApplyMarker(5)))
+	hs := raftpb.HardState{Term: 3, Vote: uint64(diskID), Commit: 10}
+	raw, err := hs.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, raftStore.SetRaw(ctx, raftWalCF, raft.EncodeHardStateKey(uint64(shardID)), raw))
+	require.NoError(t, raftStore.SetRaw(ctx, raftWalCF, raft.EncodeIndexLogKey(uint64(shardID), 8), []byte("v")))
+	require.NoError(t, raftStore.SetRaw(ctx, raftWalCF, raft.EncodeSnapshotMetaKey(uint64(shardID)), []byte("v")))
+
+	recoverIndex, err = d.verifyShardApplied(ctx, suid, info)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), recoverIndex)
+	require.Equal(t, uint64(0), info.AppliedIndex)
+	for _, key := range [][]byte{itemKey, keys.encodeApplyMarkerKey()} {
+		_, err = kvStore.GetRaw(ctx, dataCF, key)
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+	}
+	raw, err = kvStore.GetRaw(ctx, dataCF, keys.encodeShardInfoKey())
+	require.NoError(t, err)
+	saved := &shardInfo{}
+	require.NoError(t, saved.Unmarshal(raw))
+	require.Equal(t, uint64(0), saved.AppliedIndex)
+	for _, key := range [][]byte{raft.EncodeIndexLogKey(uint64(shardID), 8), raft.EncodeSnapshotMetaKey(uint64(shardID))} {
+		_, err = raftStore.GetRaw(ctx, raftWalCF, key)
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+	}
+	raw, err = raftStore.GetRaw(ctx, raftWalCF, raft.EncodeHardStateKey(uint64(shardID)))
+	require.NoError(t, err)
+	require.NoError(t, hs.Unmarshal(raw))
+	require.Equal(t, raftpb.HardState{Term: 3, Vote: uint64(diskID)}, hs)
+
+	// single member refuses to serve
+	info.AppliedIndex = 10
+	info.Units = info.Units[:1]
+	require.NoError(t, kvStore.SetRaw(ctx, dataCF, keys.encodeApplyMarkerKey(), []byte("corrupted")))
+	recoverIndex, err = d.verifyShardApplied(ctx, suid, info)
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), recoverIndex)
+	_, err = kvStore.GetRaw(ctx, dataCF, keys.encodeApplyMarkerKey())
+	require.NoError(t, err)
+
+	var state shardState
+	state.setRecovering(10)
+	require.True(t, state.isRecovering())
+	state.recovered(9)
+	require.True(t, state.isRecovering())
+	state.recovered(11)
+	require.False(t, state.isRecovering())
+}
+
 func TestServerDisk_Raft(t *testing.T) {
 	diskID := genDiskID(3)
 	disks, clearFunc, err := setUpRaftDisks(t, diskID)
@@ -0,0 +1,154 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
+)
+
+// GroupCommitConfig group commit of raft wal, the fsyncs of shards
+// on the same disk are coalesced within the window.
+type GroupCommitConfig struct {
+	Enable      bool `json:"enable"`
+	WindowMS    int  `json:"window_ms"`
+	MaxBatchNum int  `json:"max_batch_num"`
+}
+
+type commitRequest struct {
+	batch kvstore.WriteBatch
+	sync  bool
+	done  chan error
+}
+
+// groupCommitter writes raft wal batches of one disk.
+// In group commit mode, the first writer becomes leader and waits for the window,
+// then writes all pending batches in order and syncs with the last one,
+// the followers wait for the result of leader.
+type groupCommitter struct {
+	cfg     GroupCommitConfig
+	kvStore kvstore.Store
+	syncOpt kvstore.WriteOption
+
+	// serializes commits, the requests arrived during
+	// committing are grouped by the next leader
+	commitMu sync.Mutex
+
+	mu      sync.Mutex
+	leading bool
+	pending []commitRequest
+	flushCh chan struct{}
+}
+
+func newGroupCommitter(cfg GroupCommitConfig, kvStore kvstore.Store) *groupCommitter {
+	syncOpt := kvStore.NewWriteOption()
+	syncOpt.SetSync(true)
+	return &groupCommitter{
+		cfg:     cfg,
+		kvStore: kvStore,
+		syncOpt: syncOpt,
+		flushCh: make(chan struct{}, 1),
+	}
+}
+
+// Write writes the wal batch, sync means the batch should be synced
+// immediately without waiting for the window.
+func (c *groupCommitter) Write(batch kvstore.WriteBatch, sync bool) error {
+	if !c.cfg.Enable {
+		if sync {
+			return c.kvStore.Write(context.TODO(), batch, kvstore.WithWriteOption(c.syncOpt))
+		}
+		return c.kvStore.Write(context.TODO(), batch)
+	}
+
+	req := commitRequest{batch: batch, sync: sync, done: make(chan error, 1)}
+	c.mu.Lock()
+	c.pending = append(c.pending, req)
+	if c.leading {
+		full := c.cfg.MaxBatchNum > 0 && len(c.pending) >= c.cfg.MaxBatchNum
+		c.mu.Unlock()
+		if full || sync {
+			c.notifyFlush()
+		}
+		return <-req.done
+	}
+	c.leading = true
+	// drop the flush signal of last round
+	select {
+	case <-c.flushCh:
+	default:
+	}
+	c.mu.Unlock()
+
+	if !sync {
+		c.waitWindow()
+	}
+
+	c.commitMu.Lock()
+	c.mu.Lock()
+	reqs := c.pending
+	c.pending = nil
+	c.leading = false
+	c.mu.Unlock()
+
+	c.commit(reqs)
+	c.commitMu.Unlock()
+	return <-req.done
+}
+
+func (c *groupCommitter) Close() {
+	c.syncOpt.Close()
+}
+
+func (c *groupCommitter) notifyFlush() {
+	select {
+	case c.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *groupCommitter) waitWindow() {
+	if c.cfg.WindowMS <= 0 {
+		return
+	}
+	t := time.NewTimer(time.Duration(c.cfg.WindowMS) * time.Millisecond)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-c.flushCh:
+	}
+}
+
+// commit writes batches in order, the synced write of the last batch
+// persists all the former batches in wal, so its error returns to the
+// requests which need sync, others get the error of their own write.
+func (c *groupCommitter) commit(reqs []commitRequest) {
+	last := len(reqs) - 1
+	errs := make([]error, len(reqs))
+	for i := 0; i < last; i++ {
+		errs[i] = c.kvStore.Write(context.TODO(), reqs[i].batch)
+	}
+	syncErr := c.kvStore.Write(context.TODO(), reqs[last].batch, kvstore.WithWriteOption(c.syncOpt))
+	errs[last] = syncErr
+	for i := range reqs {
+		if errs[i] == nil && reqs[i].sync {
+			errs[i] = syncErr
+		}
+		reqs[i].done <- errs[i]
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
+	"github.com/cubefs/cubefs/blobstore/common/raft"
+)
+
+func newMockCommitter(t *testing.T, cfg GroupCommitConfig, writeErr error) (c *groupCommitter, writes, syncs *int32) {
+	writes, syncs = new(int32), new(int32)
+	opt := kvstore.NewMockWriteOption(C(t))
+	opt.EXPECT().SetSync(true)
+	opt.EXPECT().Close()
+	kvStore := kvstore.NewMockStore(C(t))
+	kvStore.EXPECT().NewWriteOption().Return(opt)
+	kvStore.EXPECT().Write(A, A, A).DoAndReturn(
+		func(_ context.Context, _ kvstore.WriteBatch, opts ...kvstore.WriteOptFunc) error {
+			atomic.AddInt32(writes, 1)
+			if len(opts) > 0 {
+				atomic.AddInt32(syncs, 1)
+				return writeErr
+			}
+			return nil
+		}).AnyTimes()
+	return newGroupCommitter(cfg, kvStore), writes, syncs
+}
+
+func TestGroupCommitter(t *testing.T) {
+	concurrentWrite := func(c *groupCommitter, n int) []error {
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = c.Write(nil, false)
+			}(i)
+		}
+		wg.Wait()
+		return errs
+	}
+
+	// disabled
+	{
+		c, writes, syncs := newMockCommitter(t, GroupCommitConfig{}, nil)
+		require.NoError(t, c.Write(nil, false))
+		require.NoError(t, c.Write(nil, true))
+		require.Equal(t, int32(2), *writes)
+		require.Equal(t, int32(1), *syncs)
+		c.Close()
+	}
+	// coalesced in window
+	{
+		c, writes, syncs := newMockCommitter(t, GroupCommitConfig{Enable: true, WindowMS: 200}, nil)
+		for _, err := range concurrentWrite(c, 8) {
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(8), *writes)
+		require.Equal(t, int32(1), *syncs)
+		c.Close()
+	}
+	// sync error returns to the requests which need sync and the last one
+	{
+		c, _, syncs := newMockCommitter(t, GroupCommitConfig{Enable: true, WindowMS: 200}, errors.New("sync"))
+		reqs := []commitRequest{
+			{sync: true, done: make(chan error, 1)},
+			{done: make(chan error, 1)},
+			{done: make(chan error, 1)},
+		}
+		c.commit(reqs)
+		require.Error(t, <-reqs[0].done)
+		require.NoError(t, <-reqs[1].done)
+		require.Error(t, <-reqs[2].done)
+		require.Equal(t, int32(1), *syncs)
+		c.Close()
+	}
+	// full batch
+	{
+		c, writes, _ := newMockCommitter(t, GroupCommitConfig{Enable: true, WindowMS: 10000, MaxBatchNum: 4}, nil)
+		start := time.Now()
+		concurrentWrite(c, 4)
+		require.Less(t, time.Since(start), 5*time.Second)
+		require.Equal(t, int32(4), *writes)
+		c.Close()
+	}
+	// sync request bypasses the window
+	{
+		c, _, syncs := newMockCommitter(t, GroupCommitConfig{Enable: true, WindowMS: 10000}, nil)
+		start := time.Now()
+		require.NoError(t, c.Write(nil, true))
+		require.Less(t, time.Since(start), 5*time.Second)
+		require.Equal(t, int32(1), *syncs)
+		c.Close()
+	}
+	// sync flag carried by wal entry bypasses the window
+	{
+		c, _, syncs := newMockCommitter(t, GroupCommitConfig{Enable: true, WindowMS: 10000}, nil)
+		wb := kvstore.NewMockWriteBatch(C(t))
+		wb.EXPECT().Put(A, A, A).Times(2)
+		kvStore := c.kvStore.(*kvstore.MockStore)
+		kvStore.EXPECT().NewWriteBatch().Return(wb)
+		stg := &raftStorage{kvStore: kvStore, committer: c}
+
+		pd := raft.ProposalData{Op: 1, Data: []byte("data"), Sync: true}
+		data, err := pd.Marshal()
+		require.NoError(t, err)
+		entry := raftpb.Entry{Term: 1, Index: 1, Data: data}
+		value, err := entry.Marshal()
+		require.NoError(t, err)
+
+		batch := stg.NewBatch()
+		batch.Put([]byte("hard state"), nil)
+		require.False(t, *batch.(raftBatch).sync)
+		batch.Put(raft.EncodeIndexLogKey(1, 1), value)
+		require.True(t, *batch.(raftBatch).sync)
+		start := time.Now()
+		require.NoError(t, stg.Write(batch))
+		require.Less(t, time.Since(start), 5*time.Second)
+		require.Equal(t, int32(1), *syncs)
+		c.Close()
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+const (
+	MetricTierShard = "shard"
+	MetricTierDisk  = "disk"
+	MetricTierNode  = "node"
+
+	metricOpRead  = "read"
+	metricOpWrite = "write"
+
+	defaultMaxShardSeries = 1024
+)
+
+var shardRequestsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "blobstore",
+		Subsystem: "shardnode",
+		Name:      "shard_requests",
+		Help:      "shard requests in aggregation tier of shard, disk or node",
+	},
+	[]string{"tier", "disk_id", "shard_id", "op"},
+)
+
+var sstPendingTrashMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "blobstore",
+		Subsystem: "shardnode",
+		Name:      "sst_pending_trash_bytes",
+		Help:      "bytes of obsolete sst files waiting to be deleted in trash of disk",
+	},
+	[]string{"disk_id"},
+)
+
+func init() {
+	prometheus.MustRegister(shardRequestsMetric)
+	prometheus.MustRegister(sstPendingTrashMetric)
+}
+
+// ShardMetricConfig controls cardinality of per-shard metrics.
+// Shards are reported in shard tier if the number of shards is not more than
+// MaxShardSeries, otherwise rolled up to RollupTier of disk or node.
+// DrillDownShards are always reported in shard tier.
+type ShardMetricConfig struct {
+	Enable          bool            `json:"enable"`
+	MaxShardSeries  int             `json:"max_shard_series"`
+	RollupTier      string          `json:"rollup_tier"`
+	DrillDownShards []proto.ShardID `json:"drill_down_shards"`
+}
+
+// ShardMetrics reports requests of shards, shared by all disks of node.
+// nil ShardMetrics reports nothing.
+type ShardMetrics struct {
+	maxShardSeries int
+	rollupTier     string
+	drillDown      map[proto.ShardID]struct{}
+
+	lock     sync.RWMutex
+	shards   map[proto.Suid]proto.DiskID
+	rolledUp bool
+}
+
+// NewShardMetrics returns nil if metrics is not enabled.
+func NewShardMetrics(cfg ShardMetricConfig) *ShardMetrics {
+	if !cfg.Enable {
+		return nil
+	}
+	if cfg.MaxShardSeries <= 0 {
+		cfg.MaxShardSeries = defaultMaxShardSeries
+	}
+	if cfg.RollupTier != MetricTierNode {
+		cfg.RollupTier = MetricTierDisk
+	}
+	m := &ShardMetrics{
+		maxShardSeries: cfg.MaxShardSeries,
+		rollupTier:     cfg.RollupTier,
+		drillDown:      make(map[proto.ShardID]struct{}, len(cfg.DrillDownShards)),
+		shards:         make(map[proto.Suid]proto.DiskID),
+	}
+	for _, shardID := range cfg.DrillDownShards {
+		m.drillDown[shardID] = struct{}{}
+	}
+	return m
+}
+
+func (m *ShardMetrics) addShard(diskID proto.DiskID, suid proto.Suid) {
+	if m == nil {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.shards[suid] = diskID
+	if m.rolledUp || len(m.shards) <= m.maxShardSeries {
+		return
+	}
+
+	// roll up, series of shards not drilled down are stale
+	m.rolledUp = true
+	for suid := range m.shards {
+		if !m.isDrillDown(suid) {
+			m.deleteShardSeries(suid)
+		}
+	}
+}
+
+func (m *ShardMetrics) removeShard(suid proto.Suid) {
+	if m == nil {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.shards[suid]; !ok {
+		return
+	}
+	delete(m.shards, suid)
+	m.deleteShardSeries(suid)
+	if !m.rolledUp || len(m.shards) > m.maxShardSeries {
+		return
+	}
+
+	// back to shard tier, series of rollup tier are stale
+	m.rolledUp = false
+	shardRequestsMetric.DeletePartialMatch(prometheus.Labels{"tier": m.rollupTier})
+}
+
+func (m *ShardMetrics) record(diskID proto.DiskID, suid proto.Suid, op string) {
+	if m == nil {
+		return
+	}
+	m.lock.RLock()
+	rolledUp := m.rolledUp
+	m.lock.RUnlock()
+
+	tier, diskLabel, shardLabel := m.labels(rolledUp, diskID, suid)
+	shardRequestsMetric.WithLabelValues(tier, diskLabel, shardLabel, op).Inc()
+}
+
+func (m *ShardMetrics) labels(rolledUp bool, diskID proto.DiskID, suid proto.Suid) (tier, diskLabel, shardLabel string) {
+	diskLabel = strconv.FormatUint(uint64(diskID), 10)
+	if !rolledUp || m.isDrillDown(suid) {
+		return MetricTierShard, diskLabel, strconv.FormatUint(uint64(suid.ShardID()), 10)
+	}
+	if m.rollupTier == MetricTierDisk {
+		return MetricTierDisk, diskLabel, ""
+	}
+	return MetricTierNode, "", ""
+}
+
+func (m *ShardMetrics) isDrillDown(suid proto.Suid) bool {
+	_, ok := m.drillDown[suid.ShardID()]
+	return ok
+}
+
+func (m *ShardMetrics) deleteShardSeries(suid proto.Suid) {
+	shardRequestsMetric.DeletePartialMatch(prometheus.Labels{
+		"tier":     MetricTierShard,
+		"shard_id": strconv.FormatUint(uint64(suid.ShardID()), 10),
+	})
+}
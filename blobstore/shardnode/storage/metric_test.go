@@ -0,0 +1,77 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+func TestShardMetricsTier(t *testing.T) {
+	shardRequestsMetric.Reset()
+	defer shardRequestsMetric.Reset()
+
+	var nilMetrics *ShardMetrics
+	nilMetrics.addShard(1, proto.EncodeSuid(1, 0, 0))
+	nilMetrics.record(1, proto.EncodeSuid(1, 0, 0), metricOpRead)
+	nilMetrics.removeShard(proto.EncodeSuid(1, 0, 0))
+	require.Nil(t, NewShardMetrics(ShardMetricConfig{}))
+	require.Equal(t, 0, testutil.CollectAndCount(shardRequestsMetric))
+
+	m := NewShardMetrics(ShardMetricConfig{
+		Enable:          true,
+		MaxShardSeries:  2,
+		RollupTier:      "invalid",
+		DrillDownShards: []proto.ShardID{3},
+	})
+	require.Equal(t, MetricTierDisk, m.rollupTier)
+
+	suids := []proto.Suid{proto.EncodeSuid(1, 0, 0), proto.EncodeSuid(2, 0, 0), proto.EncodeSuid(3, 0, 0)}
+	m.addShard(1, suids[0])
+	m.addShard(1, suids[1])
+	for _, suid := range suids[:2] {
+		m.record(1, suid, metricOpRead)
+	}
+	require.Equal(t, 2, testutil.CollectAndCount(shardRequestsMetric))
+	require.Equal(t, float64(1), testutil.ToFloat64(shardRequestsMetric.WithLabelValues(MetricTierShard, "1", "1", metricOpRead)))
+
+	// rolled up to disk tier, drill down shard is still in shard tier
+	m.addShard(1, suids[2])
+	require.Equal(t, 0, testutil.CollectAndCount(shardRequestsMetric))
+	for _, suid := range suids {
+		m.record(1, suid, metricOpWrite)
+	}
+	require.Equal(t, 2, testutil.CollectAndCount(shardRequestsMetric))
+	require.Equal(t, float64(2), testutil.ToFloat64(shardRequestsMetric.WithLabelValues(MetricTierDisk, "1", "", metricOpWrite)))
+	require.Equal(t, float64(1), testutil.ToFloat64(shardRequestsMetric.WithLabelValues(MetricTierShard, "1", "3", metricOpWrite)))
+
+	// back to shard tier
+	m.removeShard(suids[2])
+	require.Equal(t, 0, testutil.CollectAndCount(shardRequestsMetric))
+	m.record(1, suids[0], metricOpWrite)
+	require.Equal(t, float64(1), testutil.ToFloat64(shardRequestsMetric.WithLabelValues(MetricTierShard, "1", "1", metricOpWrite)))
+
+	// rolled up to node tier
+	m = NewShardMetrics(ShardMetricConfig{Enable: true, MaxShardSeries: 1, RollupTier: MetricTierNode})
+	m.addShard(1, suids[0])
+	m.addShard(2, suids[1])
+	m.record(1, suids[0], metricOpRead)
+	m.record(2, suids[1], metricOpRead)
+	require.Equal(t, float64(2), testutil.ToFloat64(shardRequestsMetric.WithLabelValues(MetricTierNode, "", "", metricOpRead)))
+}
@@ -25,6 +25,10 @@ import (
 const (
 	MaxKeySize   = 1 << 15
 	MaxValueSize = 1 << 24
+	MaxTxnOps    = 64
+
+	// maxListFilteredCount max filtered out values in one list
+	maxListFilteredCount = 1 << 12
 
 	dataCF  = "data"
 	lockCF  = "lock"
@@ -35,11 +39,21 @@ var (
 	// top level prefix
 	shardDataPrefix = []byte{'d'}
 	shardInfoPrefix = []byte{'s'}
+	// shardApplyPrefix is the prefix of shard's apply marker, it's out of the
+	// shard data range, so it is not included in raft snapshot
+	shardApplyPrefix = []byte{'m'}
 
 	// shard's internal suffix
-	itemSuffix = []byte{'a'}
-	blobSuffix = []byte{'b'}
-	maxSuffix  = []byte{'z'}
+	itemSuffix     = []byte{'a'}
+	blobSuffix     = []byte{'b'}
+	trashSuffix    = []byte{'t'}
+	dedupSuffix    = []byte{'r'}
+	dedupRefSuffix = []byte{'f'}
+	versionSuffix  = []byte{'v'}
+	// readonlySuffix is the shardnode local readonly flag of shard, it's in the
+	// shard data range, so it is carried by raft snapshot
+	readonlySuffix = []byte{'o'}
+	maxSuffix      = []byte{'z'}
 )
 
 type Timestamp struct{}
@@ -61,6 +75,10 @@ func shardInfoPrefixSize() int {
 	return len(shardInfoPrefix) + 8
 }
 
+func shardApplyPrefixSize() int {
+	return len(shardApplyPrefix) + 8
+}
+
 func shardItemPrefixSize() int {
 	return shardDataPrefixSize() + len(itemSuffix)
 }
@@ -69,6 +87,26 @@ func shardBlobPrefixSize() int {
 	return shardDataPrefixSize() + len(blobSuffix)
 }
 
+func shardTrashPrefixSize() int {
+	return shardDataPrefixSize() + len(trashSuffix)
+}
+
+func shardDedupPrefixSize() int {
+	return shardDataPrefixSize() + len(dedupSuffix)
+}
+
+func shardDedupRefPrefixSize() int {
+	return shardDataPrefixSize() + len(dedupRefSuffix)
+}
+
+func shardVersionPrefixSize() int {
+	return shardDataPrefixSize() + len(versionSuffix)
+}
+
+func shardReadonlyKeySize() int {
+	return shardDataPrefixSize() + len(readonlySuffix)
+}
+
 func shardMaxPrefixSize() int {
 	return shardDataPrefixSize() + len(maxSuffix)
 }
@@ -97,11 +135,24 @@ func decodeShardInfoPrefix(raw []byte) proto.Suid {
 	return proto.Suid(binary.BigEndian.Uint64(raw[prefixSize:]))
 }
 
+func encodeShardApplyPrefix(suid proto.Suid, raw []byte) {
+	if raw == nil || cap(raw) == 0 {
+		panic("invalid raw input")
+	}
+	prefixSize := len(shardApplyPrefix)
+	copy(raw, shardApplyPrefix)
+	binary.BigEndian.PutUint64(raw[prefixSize:], uint64(suid))
+}
+
 func encodeShardDataPrefix(shardID proto.ShardID, raw []byte) {
 	copy(raw, shardDataPrefix)
 	binary.BigEndian.PutUint32(raw[len(shardDataPrefix):], uint32(shardID))
 }
 
+func decodeShardDataPrefix(raw []byte) proto.ShardID {
+	return proto.ShardID(binary.BigEndian.Uint32(raw[len(shardDataPrefix):]))
+}
+
 func encodeShardItemPrefix(shardID proto.ShardID, raw []byte) {
 	shardPrefixSize := shardDataPrefixSize()
 	encodeShardDataPrefix(shardID, raw)
@@ -114,6 +165,36 @@ func encodeShardBlobPrefix(shardID proto.ShardID, raw []byte) {
 	copy(raw[shardPrefixSize:], blobSuffix)
 }
 
+func encodeShardTrashPrefix(shardID proto.ShardID, raw []byte) {
+	shardPrefixSize := shardDataPrefixSize()
+	encodeShardDataPrefix(shardID, raw)
+	copy(raw[shardPrefixSize:], trashSuffix)
+}
+
+func encodeShardDedupPrefix(shardID proto.ShardID, raw []byte) {
+	shardPrefixSize := shardDataPrefixSize()
+	encodeShardDataPrefix(shardID, raw)
+	copy(raw[shardPrefixSize:], dedupSuffix)
+}
+
+func encodeShardDedupRefPrefix(shardID proto.ShardID, raw []byte) {
+	shardPrefixSize := shardDataPrefixSize()
+	encodeShardDataPrefix(shardID, raw)
+	copy(raw[shardPrefixSize:], dedupRefSuffix)
+}
+
+func encodeShardVersionPrefix(shardID proto.ShardID, raw []byte) {
+	shardPrefixSize := shardDataPrefixSize()
+	encodeShardDataPrefix(shardID, raw)
+	copy(raw[shardPrefixSize:], versionSuffix)
+}
+
+func encodeShardReadonlyKey(shardID proto.ShardID, raw []byte) {
+	shardPrefixSize := shardDataPrefixSize()
+	encodeShardDataPrefix(shardID, raw)
+	copy(raw[shardPrefixSize:], readonlySuffix)
+}
+
 func encodeShardDataMaxPrefix(shardID proto.ShardID, raw []byte) {
 	shardPrefixSize := shardDataPrefixSize()
 	encodeShardDataPrefix(shardID, raw)
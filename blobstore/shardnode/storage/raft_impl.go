@@ -33,6 +33,9 @@ const (
 type RaftSnapshotTransmitConfig struct {
 	BatchInflightNum  int `json:"batch_inflight_num"`
 	BatchInflightSize int `json:"batch_inflight_size"`
+	// ApplyByIngest builds sst files from the received snapshot and ingests them
+	// into store, instead of writing key-value pairs batch by batch
+	ApplyByIngest bool `json:"apply_by_ingest"`
 }
 
 type raftSnapshot struct {
@@ -105,7 +108,8 @@ func (r *raftSnapshot) Close() error {
 }
 
 type raftStorage struct {
-	kvStore kvstore.Store
+	kvStore   kvstore.Store
+	committer *groupCommitter
 }
 
 func (w *raftStorage) Get(key []byte) (raft.ValGetter, error) {
@@ -124,11 +128,12 @@ func (w *raftStorage) Iter(prefix []byte) raft.Iterator {
 }
 
 func (w *raftStorage) NewBatch() raft.Batch {
-	return raftBatch{cf: raftWalCF, batch: w.kvStore.NewWriteBatch()}
+	return raftBatch{cf: raftWalCF, batch: w.kvStore.NewWriteBatch(), sync: new(bool)}
 }
 
 func (w *raftStorage) Write(b raft.Batch) error {
-	return w.kvStore.Write(context.TODO(), b.(raftBatch).batch, nil)
+	batch := b.(raftBatch)
+	return w.committer.Write(batch.batch, batch.sync != nil && *batch.sync)
 }
 
 type raftIterator struct {
@@ -158,9 +163,17 @@ func (i raftIterator) Close() {
 type raftBatch struct {
 	cf    kvstore.CF
 	batch kvstore.WriteBatch
+	// sync records whether the wal batch has entries which require sync
+	// immediately, the flag is carried by entry to sync on every member, nil for snapshot batch
+	sync *bool
 }
 
-func (t raftBatch) Put(key, value []byte) { t.batch.Put(t.cf, key, value) }
+func (t raftBatch) Put(key, value []byte) {
+	if t.sync != nil && !*t.sync {
+		*t.sync = raft.IsSyncEntry(value)
+	}
+	t.batch.Put(t.cf, key, value)
+}
 
 func (t raftBatch) Delete(key []byte) { t.batch.Delete(t.cf, key) }
 
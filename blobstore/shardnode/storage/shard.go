@@ -16,6 +16,7 @@ package storage
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"sync"
@@ -62,7 +63,16 @@ type (
 		UpdateBlob(ctx context.Context, h OpHeader, name []byte, b proto.Blob) error
 		DeleteBlob(ctx context.Context, h OpHeader, name []byte) error
 		GetBlob(ctx context.Context, h OpHeader, name []byte) (proto.Blob, error)
-		ListBlob(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64) (blobs []proto.Blob, nextMarker []byte, err error)
+		ListBlob(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64, vid proto.Vid) (blobs []proto.Blob, nextMarker []byte, err error)
+		// trash
+		TrashBlob(ctx context.Context, h OpHeader, name []byte, expireTime int64) error
+		UndeleteBlob(ctx context.Context, h OpHeader, name []byte) (proto.Blob, error)
+		ListTrashBlob(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64) (blobs []shardnode.TrashBlob, nextMarker []byte, err error)
+		DeleteTrashBlob(ctx context.Context, h OpHeader, name []byte, version uint64) error
+		// dedup
+		RefDedup(ctx context.Context, h OpHeader, key, ref []byte, value shardnode.DedupRef) (shardnode.DedupRef, error)
+		GetDedup(ctx context.Context, h OpHeader, key []byte) (shardnode.GetDedupRet, error)
+		UnrefDedup(ctx context.Context, h OpHeader, key, ref []byte, location proto.Location) (shardnode.UnrefDedupRet, error)
 	}
 	ShardItemHandler interface {
 		// item
@@ -70,13 +80,19 @@ type (
 		UpdateItem(ctx context.Context, h OpHeader, id []byte, i shardnode.Item) error
 		DeleteItem(ctx context.Context, h OpHeader, id []byte) error
 		GetItem(ctx context.Context, h OpHeader, id []byte) (shardnode.Item, error)
-		ListItem(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64) (items []shardnode.Item, nextMarker []byte, err error)
+		GetItemVersion(ctx context.Context, h OpHeader, id []byte, version uint64) (shardnode.Item, error)
+		ListItem(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64, filters []shardnode.FieldFilter) (items []shardnode.Item, nextMarker []byte, err error)
+	}
+	ShardTxnHandler interface {
+		CommitTxn(ctx context.Context, h OpHeader, ops []TxnOp) error
 	}
 	ShardHandler interface {
 		ShardItemHandler
 		ShardBlobHandler
+		ShardTxnHandler
 		GetRouteVersion() proto.RouteVersion
 		TransferLeader(ctx context.Context, diskID proto.DiskID) error
+		SetReadonly(ctx context.Context, readonly bool) error
 		Checkpoint(ctx context.Context) error
 		Stats(ctx context.Context, readIndex bool) (shardnode.ShardStats, error)
 		GetSuid() proto.Suid
@@ -86,13 +102,36 @@ type (
 	OpHeader struct {
 		RouteVersion proto.RouteVersion
 		ShardKeys    [][]byte
+		Sync         bool
+		// LeaderTerm is the fencing token of writes, zero means no fencing
+		LeaderTerm uint64
+	}
+	// TxnOp operation of transaction, key is the item id or blob name of space,
+	// expire time is the trash expire time of delete blob operation
+	TxnOp struct {
+		Type       shardnode.TxnOpType
+		ShardKeys  [][]byte
+		Key        []byte
+		Item       shardnode.Item
+		Blob       proto.Blob
+		Cond       shardnode.TxnCond
+		ExpireTime int64
 	}
 
 	ShardBaseConfig struct {
 		RaftSnapTransmitConfig RaftSnapshotTransmitConfig `json:"raft_snap_transmit_config"`
 		TruncateWalLogInterval uint64                     `json:"truncate_wal_log_interval"`
+		ItemVersion            ItemVersionConfig          `json:"item_version"`
 		Transport              base.ShardTransport
 	}
+	// ItemVersionConfig keeps the overwritten item as an old version if enabled, old versions
+	// beyond MaxVersions or overwritten for more than TTLS are removed, zero means no limit.
+	// All versions of item are removed when the item is deleted.
+	ItemVersionConfig struct {
+		Enable      bool   `json:"enable"`
+		MaxVersions uint32 `json:"max_versions"`
+		TTLS        int64  `json:"ttl_s"`
+	}
 
 	shardConfig struct {
 		*ShardBaseConfig
@@ -124,6 +163,9 @@ func newShard(ctx context.Context, cfg shardConfig) (s *shard, err error) {
 		cfg:  cfg.ShardBaseConfig,
 	}
 	s.shardInfoMu.shardInfo = cfg.shardInfo
+	if s.shardInfoMu.readonly, err = s.loadReadonly(ctx); err != nil {
+		return nil, err
+	}
 
 	// initial members
 	members := make([]raft.Member, 0, len(cfg.shardInfo.Units))
@@ -181,8 +223,10 @@ type shard struct {
 		shardInfo
 
 		leader             proto.DiskID
+		leaderTerm         uint64
 		lastStableIndex    uint64
 		lastTruncatedIndex uint64
+		readonly           bool
 	}
 
 	// add disk ref for finalizer gc
@@ -199,6 +243,9 @@ func (s *shard) InsertItem(ctx context.Context, h OpHeader, id []byte, i shardno
 	if !s.isLeader() {
 		return apierr.ErrShardNodeNotLeader
 	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return err
+	}
 	if err := s.checkShardOptHeader(h); err != nil {
 		return err
 	}
@@ -208,6 +255,10 @@ func (s *shard) InsertItem(ctx context.Context, h OpHeader, id []byte, i shardno
 	defer s.shardState.prepRWCheckDone()
 
 	internalItem := protoItemToInternalItem(i)
+	if s.cfg.ItemVersion.Enable {
+		internalItem.Version = 1
+		internalItem.Mtime = time.Now().Unix()
+	}
 	kv, err := initKV(s.shardKeys.encodeItemKey(id), &io.LimitedReader{R: rpc2.Codec2Reader(&internalItem), N: int64(internalItem.Size())})
 	defer kv.Release()
 	if err != nil {
@@ -218,7 +269,7 @@ func (s *shard) InsertItem(ctx context.Context, h OpHeader, id []byte, i shardno
 		Op:   raftOpInsertItem,
 		Data: kv.Marshal(),
 	}
-	resp, err := s.raftGroup.Propose(ctx, &proposalData)
+	resp, err := s.propose(ctx, h, &proposalData)
 	if err != nil {
 		return err
 	}
@@ -230,6 +281,9 @@ func (s *shard) UpdateItem(ctx context.Context, h OpHeader, id []byte, i shardno
 	if !s.isLeader() {
 		return apierr.ErrShardNodeNotLeader
 	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return err
+	}
 	if err := s.checkShardOptHeader(h); err != nil {
 		return err
 	}
@@ -239,6 +293,9 @@ func (s *shard) UpdateItem(ctx context.Context, h OpHeader, id []byte, i shardno
 	defer s.shardState.prepRWCheckDone()
 
 	internalItem := protoItemToInternalItem(i)
+	if s.cfg.ItemVersion.Enable {
+		return s.updateItemVersion(ctx, h, id, internalItem)
+	}
 	kv, err := initKV(s.shardKeys.encodeItemKey(id), &io.LimitedReader{R: rpc2.Codec2Reader(&internalItem), N: int64(internalItem.Size())})
 	defer kv.Release()
 	if err != nil {
@@ -249,11 +306,39 @@ func (s *shard) UpdateItem(ctx context.Context, h OpHeader, id []byte, i shardno
 		Op:   raftOpUpdateItem,
 		Data: kv.Marshal(),
 	}
-	_, err = s.raftGroup.Propose(ctx, &proposalData)
+	_, err = s.propose(ctx, h, &proposalData)
 
 	return err
 }
 
+// updateItemVersion proposes the update with versioning policy of leader and the write time,
+// so that replicas apply the same version chain
+func (s *shard) updateItemVersion(ctx context.Context, h OpHeader, id []byte, i item) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	i.Mtime = time.Now().Unix()
+	vi := &shardnodeproto.VersionedItem{
+		Key:         s.shardKeys.encodeItemKey(id),
+		Item:        i,
+		MaxVersions: s.cfg.ItemVersion.MaxVersions,
+		TTLS:        s.cfg.ItemVersion.TTLS,
+	}
+	data, err := vi.Marshal()
+	if err != nil {
+		return err
+	}
+	proposalData := raft.ProposalData{
+		Op:   raftOpUpdateItemVersion,
+		Data: data,
+	}
+	resp, err := s.propose(ctx, h, &proposalData)
+	if err != nil {
+		return err
+	}
+	appendTrackLogAfterPropose(span, resp.Data)
+	return nil
+}
+
 func (s *shard) GetItem(ctx context.Context, h OpHeader, id []byte) (protoItem shardnode.Item, err error) {
 	vg, err := s.get(ctx, h, s.shardKeys.encodeItemKey(id))
 	if err != nil {
@@ -270,9 +355,45 @@ func (s *shard) GetItem(ctx context.Context, h OpHeader, id []byte) (protoItem s
 	protoItem.ID = itm.ID
 	// transform into external item
 	protoItem.Fields = internalFieldsToProtoFields(itm.Fields)
+	protoItem.Version = itm.Version
 	return
 }
 
+// GetItemVersion returns the item of version, the latest item returns if version is zero.
+// Item written before versioning enabled is taken as version 1
+func (s *shard) GetItemVersion(ctx context.Context, h OpHeader, id []byte, version uint64) (protoItem shardnode.Item, err error) {
+	protoItem, err = s.GetItem(ctx, h, id)
+	if err != nil || version == 0 {
+		return
+	}
+	latest := protoItem.Version
+	if latest == 0 {
+		latest = 1
+	}
+	if version == latest {
+		return
+	}
+	if version > latest {
+		return shardnode.Item{}, apierr.ErrKeyNotFound
+	}
+
+	vg, err := s.get(ctx, h, s.shardKeys.encodeItemVersionKey(s.shardKeys.encodeItemKey(id), version))
+	if err != nil {
+		return shardnode.Item{}, err
+	}
+	itm := &item{}
+	err = itm.Unmarshal(vg.Value())
+	vg.Close()
+	if err != nil {
+		return shardnode.Item{}, err
+	}
+	return shardnode.Item{
+		ID:      itm.ID,
+		Fields:  internalFieldsToProtoFields(itm.Fields),
+		Version: itm.Version,
+	}, nil
+}
+
 func (s *shard) GetItems(ctx context.Context, h OpHeader, keys [][]byte) (ret []shardnode.Item, err error) {
 	if err := s.checkShardOptHeader(h); err != nil {
 		return nil, err
@@ -281,6 +402,7 @@ func (s *shard) GetItems(ctx context.Context, h OpHeader, keys [][]byte) (ret []
 		return nil, convertStoppingWriteErr(err)
 	}
 	defer s.shardState.prepRWCheckDone()
+	s.recordMetric(metricOpRead)
 
 	store := s.store.KVStore()
 	vgs, err := store.MultiGet(ctx, dataCF, keys, nil)
@@ -300,23 +422,30 @@ func (s *shard) GetItems(ctx context.Context, h OpHeader, keys [][]byte) (ret []
 			return
 		}
 		ret[i] = shardnode.Item{
-			ID:     item.ID,
-			Fields: internalFieldsToProtoFields(item.Fields),
+			ID:      item.ID,
+			Fields:  internalFieldsToProtoFields(item.Fields),
+			Version: item.Version,
 		}
 	}
 
 	return
 }
 
-func (s *shard) ListItem(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64) (items []shardnode.Item, nextMarker []byte, err error) {
-	rangeFunc := func(value []byte) error {
+func (s *shard) ListItem(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64, filters []shardnode.FieldFilter) (items []shardnode.Item, nextMarker []byte, err error) {
+	rangeFunc := func(value []byte) (bool, error) {
 		itm := &item{}
-		err := itm.Unmarshal(value)
+		if err := itm.Unmarshal(value); err != nil {
+			return false, err
+		}
+		if !matchItemFilters(itm, filters) {
+			return false, nil
+		}
 		items = append(items, shardnode.Item{
-			ID:     itm.ID,
-			Fields: internalFieldsToProtoFields(itm.Fields),
+			ID:      itm.ID,
+			Fields:  internalFieldsToProtoFields(itm.Fields),
+			Version: itm.Version,
 		})
-		return err
+		return true, nil
 	}
 	if len(marker) > 0 {
 		marker = s.shardKeys.encodeItemKey(marker)
@@ -326,15 +455,148 @@ func (s *shard) ListItem(ctx context.Context, h OpHeader, prefix, marker []byte,
 }
 
 func (s *shard) DeleteItem(ctx context.Context, h OpHeader, id []byte) error {
+	if s.cfg.ItemVersion.Enable {
+		return s.delete(ctx, h, s.shardKeys.encodeItemKey(id), raftOpDeleteItemVersion)
+	}
 	return s.delete(ctx, h, s.shardKeys.encodeItemKey(id), raftOpDeleteItem)
 }
 
+// CommitTxn applies operations of the transaction in one proposal and one write batch,
+// the conditions are checked at applying, and ErrTxnConflict returns if any one is not satisfied.
+// All operations must belong to this shard, transaction across shards is not supported
+func (s *shard) CommitTxn(ctx context.Context, h OpHeader, ops []TxnOp) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	if !s.isLeader() {
+		return apierr.ErrShardNodeNotLeader
+	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return err
+	}
+	if err := s.checkShardOptHeader(h); err != nil {
+		return err
+	}
+	txn, err := s.encodeTxn(ops)
+	if err != nil {
+		return err
+	}
+	if err = s.shardState.prepRWCheck(ctx); err != nil {
+		return convertStoppingWriteErr(err)
+	}
+	defer s.shardState.prepRWCheckDone()
+
+	data, err := txn.Marshal()
+	if err != nil {
+		return err
+	}
+	proposalData := raft.ProposalData{
+		Op:   raftOpTxn,
+		Data: data,
+	}
+	resp, err := s.propose(ctx, h, &proposalData)
+	if err != nil {
+		return err
+	}
+	appendTrackLogAfterPropose(span, resp.Data)
+	if fetchTxnConflictFromProposeRet(resp.Data) {
+		return apierr.ErrTxnConflict
+	}
+	return nil
+}
+
+// encodeTxn checks operations of the transaction and encodes them with the shard keys,
+// shard keys of every operation is required and must belong to the range of shard
+func (s *shard) encodeTxn(ops []TxnOp) (*shardnodeproto.Txn, error) {
+	if len(ops) == 0 || len(ops) > MaxTxnOps {
+		return nil, apierr.ErrIllegalTxn
+	}
+
+	s.shardInfoMu.RLock()
+	rg := s.shardInfoMu.Range
+	s.shardInfoMu.RUnlock()
+
+	// all blobs deleted in the transaction are trashed with the same version
+	version := uint64(time.Now().UnixNano())
+	txn := &shardnodeproto.Txn{Ops: make([]shardnodeproto.TxnOp, 0, len(ops))}
+	keys := make(map[string]struct{}, len(ops))
+	for i := range ops {
+		op := &ops[i]
+		if len(op.ShardKeys) == 0 {
+			return nil, apierr.ErrIllegalTxn
+		}
+		if !rg.Belong(sharding.NewCompareItem(rg.Type, op.ShardKeys)) {
+			return nil, apierr.ErrShardRangeMismatch
+		}
+		if _, ok := shardnode.TxnCondType_name[int32(op.Cond.Type)]; !ok {
+			return nil, apierr.ErrIllegalTxn
+		}
+
+		var err error
+		txnOp := shardnodeproto.TxnOp{
+			Type:        uint32(op.Type),
+			CondType:    uint32(op.Cond.Type),
+			CondFieldID: op.Cond.FieldID,
+			CondValue:   op.Cond.Value,
+		}
+		isBlobOp := op.Type == shardnode.TxnOpPutBlob || op.Type == shardnode.TxnOpDeleteBlob
+		// field condition is for item only, and value condition is for blob only
+		if (isBlobOp && op.Cond.Type == shardnode.TxnCondFieldEqual) ||
+			(!isBlobOp && op.Cond.Type == shardnode.TxnCondValueEqual) {
+			return nil, apierr.ErrIllegalTxn
+		}
+
+		switch op.Type {
+		case shardnode.TxnOpPutItem, shardnode.TxnOpUpdateItem:
+			internalItem := protoItemToInternalItem(op.Item)
+			txnOp.Key = s.shardKeys.encodeItemKey(op.Key)
+			txnOp.Value, err = internalItem.Marshal()
+		case shardnode.TxnOpDeleteItem:
+			txnOp.Key = s.shardKeys.encodeItemKey(op.Key)
+		case shardnode.TxnOpPutBlob:
+			// only sealed blob can be put, the blob is created by CreateBlob
+			if !op.Blob.Sealed || op.Cond.Type == shardnode.TxnCondNotExist {
+				return nil, apierr.ErrIllegalTxn
+			}
+			txnOp.Key = s.shardKeys.encodeBlobKey(op.Key)
+			txnOp.Value, err = op.Blob.Marshal()
+		case shardnode.TxnOpDeleteBlob:
+			// blob is moved into trash, and its data is deleted after expired
+			if op.ExpireTime <= 0 {
+				return nil, apierr.ErrIllegalTxn
+			}
+			txnOp.Key = s.shardKeys.encodeBlobKey(op.Key)
+			txnOp.ShardKeys = op.ShardKeys
+			txnOp.ExpireTime = op.ExpireTime
+			txnOp.Version = version
+		default:
+			return nil, apierr.ErrIllegalTxn
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(txnOp.Value) > MaxValueSize {
+			return nil, apierr.ErrValueSizeTooLarge
+		}
+
+		// one key can only be operated once in a transaction
+		if _, ok := keys[string(txnOp.Key)]; ok {
+			return nil, apierr.ErrIllegalTxn
+		}
+		keys[string(txnOp.Key)] = struct{}{}
+		txn.Ops = append(txn.Ops, txnOp)
+	}
+	return txn, nil
+}
+
 func (s *shard) CreateBlob(ctx context.Context, h OpHeader, name []byte, b proto.Blob) (proto.Blob, error) {
 	span := trace.SpanFromContextSafe(ctx)
 
 	if !s.isLeader() {
 		return proto.Blob{}, apierr.ErrShardNodeNotLeader
 	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return proto.Blob{}, err
+	}
 	if err := s.checkShardOptHeader(h); err != nil {
 		return proto.Blob{}, err
 	}
@@ -353,7 +615,7 @@ func (s *shard) CreateBlob(ctx context.Context, h OpHeader, name []byte, b proto
 		Op:   raftOpInsertBlob,
 		Data: kv.Marshal(),
 	}
-	resp, err := s.raftGroup.Propose(ctx, &proposalData)
+	resp, err := s.propose(ctx, h, &proposalData)
 	if err != nil {
 		return proto.Blob{}, err
 	}
@@ -367,6 +629,9 @@ func (s *shard) UpdateBlob(ctx context.Context, h OpHeader, name []byte, b proto
 	if !s.isLeader() {
 		return apierr.ErrShardNodeNotLeader
 	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return err
+	}
 	if err := s.checkShardOptHeader(h); err != nil {
 		return err
 	}
@@ -385,7 +650,7 @@ func (s *shard) UpdateBlob(ctx context.Context, h OpHeader, name []byte, b proto
 		Op:   raftOpUpdateBlob,
 		Data: kv.Marshal(),
 	}
-	resp, err := s.raftGroup.Propose(ctx, &proposalData)
+	resp, err := s.propose(ctx, h, &proposalData)
 	if err != nil {
 		return err
 	}
@@ -408,14 +673,18 @@ func (s *shard) GetBlob(ctx context.Context, h OpHeader, name []byte) (proto.Blo
 	return blob, nil
 }
 
-func (s *shard) ListBlob(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64) (blobs []proto.Blob, nextMarker []byte, err error) {
-	rangeFunc := func(data []byte) error {
+// ListBlob lists blobs from marker, only the blobs with slices located in vid are taken if vid is set
+func (s *shard) ListBlob(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64, vid proto.Vid) (blobs []proto.Blob, nextMarker []byte, err error) {
+	rangeFunc := func(data []byte) (bool, error) {
 		b := proto.Blob{}
 		if err = b.Unmarshal(data); err != nil {
-			return err
+			return false, err
+		}
+		if vid > 0 && !blobInVolume(&b, vid) {
+			return false, nil
 		}
 		blobs = append(blobs, b)
-		return nil
+		return true, nil
 	}
 
 	if len(marker) > 0 {
@@ -426,10 +695,305 @@ func (s *shard) ListBlob(ctx context.Context, h OpHeader, prefix, marker []byte,
 	return blobs, s.shardKeys.decodeBlobKey(nextMarker), err
 }
 
+func blobInVolume(b *proto.Blob, vid proto.Vid) bool {
+	for _, slice := range b.Location.Slices {
+		if slice.Vid == vid {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *shard) DeleteBlob(ctx context.Context, h OpHeader, name []byte) error {
 	return s.delete(ctx, h, s.shardKeys.encodeBlobKey(name), raftOpDeleteBlob)
 }
 
+// TrashBlob moves blob into trash table, the blob can be restored by UndeleteBlob before expire time,
+// the blob is read and moved at applying with a version to keep trashes of the same name
+func (s *shard) TrashBlob(ctx context.Context, h OpHeader, name []byte, expireTime int64) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	if !s.isLeader() {
+		return apierr.ErrShardNodeNotLeader
+	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return err
+	}
+	if err := s.checkShardOptHeader(h); err != nil {
+		return err
+	}
+	if err := s.shardState.prepRWCheck(ctx); err != nil {
+		return convertStoppingWriteErr(err)
+	}
+	defer s.shardState.prepRWCheckDone()
+
+	op := shardnodeproto.TrashOp{
+		Key:        s.shardKeys.encodeBlobKey(name),
+		Version:    uint64(time.Now().UnixNano()),
+		ExpireTime: expireTime,
+		ShardKeys:  h.ShardKeys,
+	}
+	data, err := op.Marshal()
+	if err != nil {
+		return err
+	}
+
+	proposalData := raft.ProposalData{
+		Op:   raftOpTrashBlob,
+		Data: data,
+	}
+	resp, err := s.propose(ctx, h, &proposalData)
+	if err != nil {
+		return err
+	}
+	appendTrackLogAfterPropose(span, resp.Data)
+	if fetchKeyMissedFromProposeRet(resp.Data) {
+		return apierr.ErrKeyNotFound
+	}
+	return nil
+}
+
+// UndeleteBlob restores the latest trash of blob, returns the restored blob
+func (s *shard) UndeleteBlob(ctx context.Context, h OpHeader, name []byte) (proto.Blob, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	if !s.isLeader() {
+		return proto.Blob{}, apierr.ErrShardNodeNotLeader
+	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return proto.Blob{}, err
+	}
+	if err := s.checkShardOptHeader(h); err != nil {
+		return proto.Blob{}, err
+	}
+	if err := s.shardState.prepRWCheck(ctx); err != nil {
+		return proto.Blob{}, convertStoppingWriteErr(err)
+	}
+	defer s.shardState.prepRWCheckDone()
+
+	tb, found, err := s.getLatestTrashBlob(ctx, name)
+	if err != nil {
+		return proto.Blob{}, err
+	}
+	// expired blob is waiting for purging, its data may be deleted already
+	if !found || tb.ExpireTime <= time.Now().Unix() {
+		return proto.Blob{}, apierr.ErrBlobNotInTrash
+	}
+
+	op := shardnodeproto.TrashOp{
+		Key:     s.shardKeys.encodeBlobKey(name),
+		Version: tb.Version,
+	}
+	data, err := op.Marshal()
+	if err != nil {
+		return proto.Blob{}, err
+	}
+
+	proposalData := raft.ProposalData{
+		Op:   raftOpUndeleteBlob,
+		Data: data,
+	}
+	resp, err := s.propose(ctx, h, &proposalData)
+	if err != nil {
+		return proto.Blob{}, err
+	}
+	appendTrackLogAfterPropose(span, resp.Data)
+	ret := fetchApplyRetFromProposeRet(resp.Data)
+	if ret.keyMissed {
+		return proto.Blob{}, apierr.ErrBlobNotInTrash
+	}
+	if ret.keyExists {
+		return proto.Blob{}, apierr.ErrBlobAlreadyExists
+	}
+	return ret.blob, nil
+}
+
+// getLatestTrashBlob returns the trash blob of name with the largest version
+func (s *shard) getLatestTrashBlob(ctx context.Context, name []byte) (tb shardnode.TrashBlob, found bool, err error) {
+	prefix := s.shardKeys.encodeTrashKey(name)
+	cursor := s.store.KVStore().List(ctx, dataCF, prefix, nil, nil)
+	defer cursor.Close()
+
+	for {
+		kg, vg, err := cursor.ReadNext()
+		if err != nil {
+			return tb, false, err
+		}
+		if kg == nil || vg == nil {
+			return tb, found, nil
+		}
+		// trash key of other name with the prefix is longer than version key of name
+		if len(kg.Key()) != len(prefix)+8 {
+			kg.Close()
+			vg.Close()
+			continue
+		}
+		// versions are in ascending order, the last one is the latest
+		tb = shardnode.TrashBlob{}
+		err = tb.Unmarshal(vg.Value())
+		kg.Close()
+		vg.Close()
+		if err != nil {
+			return tb, false, errors.Info(err, "unmarshal trash blob failed")
+		}
+		found = true
+	}
+}
+
+// ListTrashBlob lists trash blobs on leader only, so that expired trash is purged by the latest view of shard
+func (s *shard) ListTrashBlob(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64) (blobs []shardnode.TrashBlob, nextMarker []byte, err error) {
+	if !s.isLeader() {
+		return nil, nil, apierr.ErrShardNodeNotLeader
+	}
+	rangeFunc := func(data []byte) (bool, error) {
+		tb := shardnode.TrashBlob{}
+		if err = tb.Unmarshal(data); err != nil {
+			return false, err
+		}
+		blobs = append(blobs, tb)
+		return true, nil
+	}
+
+	if len(marker) > 0 {
+		marker = s.shardKeys.encodeTrashKey(marker)
+	}
+
+	nextMarker, err = s.list(ctx, h, s.shardKeys.encodeTrashKey(prefix), marker, count, rangeFunc)
+	return blobs, s.shardKeys.decodeTrashKey(nextMarker), err
+}
+
+// DeleteTrashBlob deletes the trash of blob with the version only
+func (s *shard) DeleteTrashBlob(ctx context.Context, h OpHeader, name []byte, version uint64) error {
+	return s.delete(ctx, h, s.shardKeys.encodeTrashVersionKey(name, version), raftOpDeleteTrashBlob)
+}
+
+// RefDedup refers the dedup key with the reference, the value is saved with count 1 if not exists,
+// ref of the same reference takes effect only once.
+// returns the saved ref, whose location may be different from the argument
+func (s *shard) RefDedup(ctx context.Context, h OpHeader, key, ref []byte, value shardnode.DedupRef) (shardnode.DedupRef, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	if len(ref) == 0 {
+		return shardnode.DedupRef{}, apierr.ErrIllegalArguments
+	}
+	if !s.isLeader() {
+		return shardnode.DedupRef{}, apierr.ErrShardNodeNotLeader
+	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return shardnode.DedupRef{}, err
+	}
+	if err := s.checkShardOptHeader(h); err != nil {
+		return shardnode.DedupRef{}, err
+	}
+	if err := s.shardState.prepRWCheck(ctx); err != nil {
+		return shardnode.DedupRef{}, convertStoppingWriteErr(err)
+	}
+	defer s.shardState.prepRWCheckDone()
+
+	raw, err := value.Marshal()
+	if err != nil {
+		return shardnode.DedupRef{}, err
+	}
+	op := shardnodeproto.DedupOp{
+		Key:    s.shardKeys.encodeDedupKey(key),
+		RefKey: s.shardKeys.encodeDedupRefKey(key, ref),
+		Value:  raw,
+	}
+	data, err := op.Marshal()
+	if err != nil {
+		return shardnode.DedupRef{}, err
+	}
+
+	proposalData := raft.ProposalData{
+		Op:   raftOpRefDedup,
+		Data: data,
+	}
+	resp, err := s.propose(ctx, h, &proposalData)
+	if err != nil {
+		return shardnode.DedupRef{}, err
+	}
+	appendTrackLogAfterPropose(span, resp.Data)
+	return fetchDedupRetFromProposeRet(resp.Data).Ref, nil
+}
+
+// GetDedup returns the dedup ref and one of its references
+func (s *shard) GetDedup(ctx context.Context, h OpHeader, key []byte) (shardnode.GetDedupRet, error) {
+	vg, err := s.get(ctx, h, s.shardKeys.encodeDedupKey(key))
+	if err != nil {
+		return shardnode.GetDedupRet{}, err
+	}
+	ret := shardnode.GetDedupRet{}
+	err = ret.Ref.Unmarshal(vg.Value())
+	vg.Close()
+	if err != nil {
+		return shardnode.GetDedupRet{}, errors.Info(err, "unmarshal dedup ref failed")
+	}
+
+	// list nothing, the first reference returns as next marker
+	refPrefix := s.shardKeys.encodeDedupRefPrefix(key)
+	first, err := s.list(ctx, h, refPrefix, nil, 0, nil)
+	if err != nil {
+		return shardnode.GetDedupRet{}, err
+	}
+	if len(first) > len(refPrefix) {
+		ret.FirstRef = first[len(refPrefix):]
+	}
+	return ret, nil
+}
+
+// UnrefDedup removes the reference of the dedup key if it refers to the location,
+// the dedup key is deleted when the last reference is removed.
+// returns the ref with the remaining count
+func (s *shard) UnrefDedup(ctx context.Context, h OpHeader, key, ref []byte, location proto.Location) (shardnode.UnrefDedupRet, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	if len(ref) == 0 {
+		return shardnode.UnrefDedupRet{}, apierr.ErrIllegalArguments
+	}
+	if !s.isLeader() {
+		return shardnode.UnrefDedupRet{}, apierr.ErrShardNodeNotLeader
+	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return shardnode.UnrefDedupRet{}, err
+	}
+	if err := s.checkShardOptHeader(h); err != nil {
+		return shardnode.UnrefDedupRet{}, err
+	}
+	if err := s.shardState.prepRWCheck(ctx); err != nil {
+		return shardnode.UnrefDedupRet{}, convertStoppingWriteErr(err)
+	}
+	defer s.shardState.prepRWCheckDone()
+
+	expected := shardnode.DedupRef{Location: location}
+	raw, err := expected.Marshal()
+	if err != nil {
+		return shardnode.UnrefDedupRet{}, err
+	}
+	op := shardnodeproto.DedupOp{
+		Key:    s.shardKeys.encodeDedupKey(key),
+		RefKey: s.shardKeys.encodeDedupRefKey(key, ref),
+		Value:  raw,
+	}
+	data, err := op.Marshal()
+	if err != nil {
+		return shardnode.UnrefDedupRet{}, err
+	}
+
+	proposalData := raft.ProposalData{
+		Op:   raftOpUnrefDedup,
+		Data: data,
+	}
+	resp, err := s.propose(ctx, h, &proposalData)
+	if err != nil {
+		return shardnode.UnrefDedupRet{}, err
+	}
+	appendTrackLogAfterPropose(span, resp.Data)
+	if fetchKeyMissedFromProposeRet(resp.Data) {
+		return shardnode.UnrefDedupRet{}, apierr.ErrKeyNotFound
+	}
+	return fetchDedupRetFromProposeRet(resp.Data), nil
+}
+
 func (s *shard) GetRouteVersion() proto.RouteVersion {
 	s.shardInfoMu.RLock()
 	routeVersion := s.shardInfoMu.RouteVersion
@@ -461,6 +1025,8 @@ func (s *shard) Stats(ctx context.Context, readIndex bool) (shardnode.ShardStats
 	routeVersion := s.shardInfoMu.RouteVersion
 	appliedIndex := s.shardInfoMu.AppliedIndex
 	rg := s.shardInfoMu.Range
+	leaderTerm := s.shardInfoMu.leaderTerm
+	readonly := s.shardInfoMu.readonly
 	s.shardInfoMu.RUnlock()
 
 	leaderUnit, err := s.getLeader(true)
@@ -487,11 +1053,13 @@ func (s *shard) Stats(ctx context.Context, readIndex bool) (shardnode.ShardStats
 		LeaderDiskID: leaderUnit.GetDiskID(),
 		LeaderSuid:   leaderUnit.GetSuid(),
 		LeaderHost:   leaderHost,
+		LeaderTerm:   leaderTerm,
 		Learner:      leaderUnit.GetLearner(),
 		RouteVersion: routeVersion,
 		Range:        rg,
 		Units:        units,
 		RaftStat:     *raftStat,
+		Readonly:     readonly,
 	}, nil
 }
 
@@ -595,6 +1163,29 @@ func (s *shard) TransferLeader(ctx context.Context, diskID proto.DiskID) error {
 	return s.raftGroup.LeaderTransfer(ctx, uint64(diskID))
 }
 
+// SetReadonly proposes the readonly flag of shard, so that all replicas reject writes
+// after the proposal applied, reads are not affected
+func (s *shard) SetReadonly(ctx context.Context, readonly bool) error {
+	if !s.isLeader() {
+		return apierr.ErrShardNodeNotLeader
+	}
+	if err := s.shardState.prepRWCheck(ctx); err != nil {
+		return convertStoppingWriteErr(err)
+	}
+	defer s.shardState.prepRWCheckDone()
+
+	data := []byte{0}
+	if readonly {
+		data[0] = 1
+	}
+	// propose with raft group directly as the proposal of shard is rejected when readonly
+	_, err := s.raftGroup.Propose(ctx, &raft.ProposalData{
+		Op:   raftOpSetReadonly,
+		Data: data,
+	})
+	return err
+}
+
 func (s *shard) SaveShardInfo(ctx context.Context, withLock bool, flush bool) error {
 	span := trace.SpanFromContextSafe(ctx)
 	if withLock {
@@ -693,6 +1284,7 @@ func (s *shard) DeleteShard(ctx context.Context, nodeHost string, clearData bool
 
 	batch.DeleteRange(dataCF, s.shardKeys.encodeShardDataPrefix(), s.shardKeys.encodeShardDataMaxPrefix())
 	batch.Delete(dataCF, s.shardKeys.encodeShardInfoKey())
+	batch.Delete(dataCF, s.shardKeys.encodeApplyMarkerKey())
 	if err = kvStore.Write(ctx, batch); err != nil {
 		return errors.Info(err, "kvstore write batch failed")
 	}
@@ -801,6 +1393,7 @@ func (s *shard) get(ctx context.Context, h OpHeader, key []byte) (ValGetter, err
 		return nil, convertStoppingWriteErr(err)
 	}
 	defer s.shardState.prepRWCheckDone()
+	s.recordMetric(metricOpRead)
 
 	kvStore := s.store.KVStore()
 	ret, err := kvStore.Get(ctx, dataCF, key, nil)
@@ -819,6 +1412,9 @@ func (s *shard) delete(ctx context.Context, h OpHeader, key []byte, op uint32) e
 	if !s.isLeader() {
 		return apierr.ErrShardNodeNotLeader
 	}
+	if err := s.checkLeaderTerm(h); err != nil {
+		return err
+	}
 	if err := s.checkShardOptHeader(h); err != nil {
 		return err
 	}
@@ -832,7 +1428,7 @@ func (s *shard) delete(ctx context.Context, h OpHeader, key []byte, op uint32) e
 		Data: key,
 	}
 
-	resp, err := s.raftGroup.Propose(ctx, &proposalData)
+	resp, err := s.propose(ctx, h, &proposalData)
 	if err != nil {
 		return err
 	}
@@ -840,21 +1436,56 @@ func (s *shard) delete(ctx context.Context, h OpHeader, key []byte, op uint32) e
 	return nil
 }
 
-func (s *shard) list(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64, rangeFunc func([]byte) error) (nextMarker []byte, err error) {
+// propose with the durability of op header, the wal entry of request which requires
+// sync is flushed without waiting for group commit on leader and followers
+func (s *shard) propose(ctx context.Context, h OpHeader, pdata *raft.ProposalData) (raft.ProposalResponse, error) {
+	if s.isReadonly() {
+		return raft.ProposalResponse{}, apierr.ErrShardReadonly
+	}
+	// shed the proposal when writes of disk are stopped, client should retry later
+	if state := s.store.WriteStall(); state == kvstore.WriteStallStopped {
+		trace.SpanFromContextSafe(ctx).Warnf("shard[%d] suid[%d] reject proposal as write stall: %s", s.suid.ShardID(), s.suid, state)
+		return raft.ProposalResponse{}, apierr.ErrShardNodeWriteStall
+	}
+	pdata.Sync = h.Sync
+	// the term is checked again at applying, as leader may change after checking
+	pdata.Term = h.LeaderTerm
+	s.recordMetric(metricOpWrite)
+	resp, err := s.raftGroup.Propose(ctx, pdata)
+	if errors.Is(err, raft.ErrProposalFenced) {
+		return resp, apierr.ErrShardFenced
+	}
+	return resp, err
+}
+
+func (s *shard) recordMetric(op string) {
+	if s.disk != nil {
+		s.disk.cfg.ShardMetrics.record(s.diskID, s.suid, op)
+	}
+}
+
+// list ranges the values from marker with prefix, rangeFunc returns false if the value is filtered out,
+// and the scanning stops at the next key if too many values were filtered out.
+func (s *shard) list(ctx context.Context, h OpHeader, prefix, marker []byte, count uint64, rangeFunc func([]byte) (bool, error)) (nextMarker []byte, err error) {
 	span := trace.SpanFromContextSafe(ctx)
 	if h.RouteVersion < s.GetRouteVersion() {
 		return nil, apierr.ErrShardRouteVersionNeedUpdate
 	}
+	if s.shardState.isRecovering() {
+		return nil, apierr.ErrShardRecovering
+	}
 	if err := s.shardState.prepRWCheck(ctx); err != nil {
 		return nil, convertStoppingWriteErr(err)
 	}
 	defer s.shardState.prepRWCheckDone()
+	s.recordMetric(metricOpRead)
 
 	kvStore := s.store.KVStore()
 	cursor := kvStore.List(ctx, dataCF, prefix, marker, nil)
 	defer cursor.Close()
 
 	count += 1
+	filtered := 0
 	for count > 0 {
 		kg, vg, err := cursor.ReadNext()
 		if err != nil {
@@ -877,7 +1508,8 @@ func (s *shard) list(ctx context.Context, h OpHeader, prefix, marker []byte, cou
 			span.Panicf("range func is nil")
 		}
 
-		if err = rangeFunc(vg.Value()); err != nil {
+		taken, err := rangeFunc(vg.Value())
+		if err != nil {
 			err = errors.Info(err, fmt.Sprintf("range func failed, key: %v, value:%v", kg.Key(), vg.Value()))
 			kg.Close()
 			vg.Close()
@@ -886,7 +1518,12 @@ func (s *shard) list(ctx context.Context, h OpHeader, prefix, marker []byte, cou
 
 		kg.Close()
 		vg.Close()
-		count--
+		if taken {
+			count--
+		} else if filtered++; filtered >= maxListFilteredCount {
+			// returns the next key as marker
+			count = 1
+		}
 	}
 	return nextMarker, nil
 }
@@ -948,6 +1585,9 @@ func (s *shard) checkShardOptHeader(h OpHeader) error {
 	if h.RouteVersion < s.GetRouteVersion() {
 		return apierr.ErrShardRouteVersionNeedUpdate
 	}
+	if s.shardState.isRecovering() {
+		return apierr.ErrShardRecovering
+	}
 	ci := sharding.NewCompareItem(s.shardInfoMu.Range.Type, h.ShardKeys)
 	if !s.shardInfoMu.Range.Belong(ci) {
 		return apierr.ErrShardRangeMismatch
@@ -955,6 +1595,44 @@ func (s *shard) checkShardOptHeader(h OpHeader) error {
 	return nil
 }
 
+// checkLeaderTerm fences the write whose leader term is not equal to the term this
+// leader elected in. a larger term means this leader is stale and a newer one exists,
+// a smaller term means the client should refresh the leader before retry.
+func (s *shard) checkLeaderTerm(h OpHeader) error {
+	if h.LeaderTerm == 0 {
+		return nil
+	}
+	s.shardInfoMu.RLock()
+	term := s.shardInfoMu.leaderTerm
+	s.shardInfoMu.RUnlock()
+
+	if h.LeaderTerm != term {
+		return apierr.ErrShardFenced
+	}
+	return nil
+}
+
+// loadReadonly loads readonly flag from the persisted key of shard
+func (s *shard) loadReadonly(ctx context.Context) (bool, error) {
+	vg, err := s.store.KVStore().Get(ctx, dataCF, s.shardKeys.encodeReadonlyKey(), nil)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	vg.Close()
+	return true, nil
+}
+
+func (s *shard) isReadonly() bool {
+	s.shardInfoMu.RLock()
+	readonly := s.shardInfoMu.readonly
+	s.shardInfoMu.RUnlock()
+
+	return readonly
+}
+
 func (s *shard) isLeader() bool {
 	s.shardInfoMu.RLock()
 	isLeader := s.shardInfoMu.leader == s.diskID
@@ -1028,6 +1706,9 @@ type shardState struct {
 
 	restartLeaderReadIndex uint32
 	readIndexFunc          func(ctx context.Context) error
+	// recoverIndex is the applied index the shard must catch up with before serving,
+	// it is set when the state machine is found inconsistent with raft at startup
+	recoverIndex uint64
 
 	lock sync.RWMutex
 }
@@ -1197,6 +1878,79 @@ func (s *shardKeysGenerator) decodeBlobKey(key []byte) []byte {
 	return key[shardBlobPrefixSize:]
 }
 
+// encode trash key with prefix: d[shardID]-t-[key]
+func (s *shardKeysGenerator) encodeTrashKey(key []byte) []byte {
+	shardTrashPrefixSize := shardTrashPrefixSize()
+	newKey := make([]byte, shardTrashPrefixSize+len(key))
+	encodeShardTrashPrefix(s.suid.ShardID(), newKey)
+	copy(newKey[shardTrashPrefixSize:], key)
+	return newKey
+}
+
+// encode trash key with version: d[shardID]-t-[key]-[version]
+func (s *shardKeysGenerator) encodeTrashVersionKey(key []byte, version uint64) []byte {
+	trashKey := s.encodeTrashKey(key)
+	newKey := make([]byte, len(trashKey)+8)
+	copy(newKey, trashKey)
+	binary.BigEndian.PutUint64(newKey[len(trashKey):], version)
+	return newKey
+}
+
+func (s *shardKeysGenerator) decodeTrashKey(key []byte) []byte {
+	if len(key) == 0 {
+		return nil
+	}
+	shardTrashPrefixSize := shardTrashPrefixSize()
+	return key[shardTrashPrefixSize:]
+}
+
+// encode dedup key with prefix: d[shardID]-r-[key]
+func (s *shardKeysGenerator) encodeDedupKey(key []byte) []byte {
+	shardDedupPrefixSize := shardDedupPrefixSize()
+	newKey := make([]byte, shardDedupPrefixSize+len(key))
+	encodeShardDedupPrefix(s.suid.ShardID(), newKey)
+	copy(newKey[shardDedupPrefixSize:], key)
+	return newKey
+}
+
+// encode dedup ref prefix: d[shardID]-f-[len(key)]-[key], the length of dedup key
+// avoids that references of one dedup key are listed with the other key's
+func (s *shardKeysGenerator) encodeDedupRefPrefix(key []byte) []byte {
+	return s.encodeDedupRefKey(key, nil)
+}
+
+// encode dedup ref key with prefix: d[shardID]-f-[len(key)]-[key]-[ref]
+func (s *shardKeysGenerator) encodeDedupRefKey(key, ref []byte) []byte {
+	shardDedupRefPrefixSize := shardDedupRefPrefixSize()
+	newKey := make([]byte, shardDedupRefPrefixSize+4+len(key)+len(ref))
+	encodeShardDedupRefPrefix(s.suid.ShardID(), newKey)
+	binary.BigEndian.PutUint32(newKey[shardDedupRefPrefixSize:], uint32(len(key)))
+	copy(newKey[shardDedupRefPrefixSize+4:], key)
+	copy(newKey[shardDedupRefPrefixSize+4+len(key):], ref)
+	return newKey
+}
+
+// encode item version prefix: d[shardID]-v-[len(key)]-[key], the length of key
+// avoids that versions of one item are listed with the other item's
+func (s *shardKeysGenerator) encodeItemVersionPrefix(key []byte) []byte {
+	return s.encodeItemVersionKey(key, 0)[:shardVersionPrefixSize()+4+len(key)]
+}
+
+// encode item version key with prefix: d[shardID]-v-[len(key)]-[key]-[version]
+func (s *shardKeysGenerator) encodeItemVersionKey(key []byte, version uint64) []byte {
+	shardVersionPrefixSize := shardVersionPrefixSize()
+	newKey := make([]byte, shardVersionPrefixSize+4+len(key)+8)
+	encodeShardVersionPrefix(s.suid.ShardID(), newKey)
+	binary.BigEndian.PutUint32(newKey[shardVersionPrefixSize:], uint32(len(key)))
+	copy(newKey[shardVersionPrefixSize+4:], key)
+	binary.BigEndian.PutUint64(newKey[len(newKey)-8:], version)
+	return newKey
+}
+
+func (s *shardKeysGenerator) decodeItemVersion(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(key)-8:])
+}
+
 // encode shard info key with prefix: s[shardID]
 func (s *shardKeysGenerator) encodeShardInfoKey() []byte {
 	key := make([]byte, shardInfoPrefixSize())
@@ -1204,6 +1958,20 @@ func (s *shardKeysGenerator) encodeShardInfoKey() []byte {
 	return key
 }
 
+// encode shard apply marker key with prefix: m[suid]
+func (s *shardKeysGenerator) encodeApplyMarkerKey() []byte {
+	key := make([]byte, shardApplyPrefixSize())
+	encodeShardApplyPrefix(s.suid, key)
+	return key
+}
+
+// encode shard readonly flag key with prefix: d[shardID]o
+func (s *shardKeysGenerator) encodeReadonlyKey() []byte {
+	key := make([]byte, shardReadonlyKeySize())
+	encodeShardReadonlyKey(s.suid.ShardID(), key)
+	return key
+}
+
 // encode shard data prefix with prefix: d[shardID]
 // it can be used for listing all shard's data or delete shard's data
 func (s *shardKeysGenerator) encodeShardDataPrefix() []byte {
@@ -1240,6 +2008,23 @@ func protoFieldsToInternalFields(external []shardnode.Field) []shardnodeproto.Fi
 	return ret
 }
 
+// matchItemFilters returns true if the item matches all filters, absent field equals to empty value
+func matchItemFilters(itm *item, filters []shardnode.FieldFilter) bool {
+	for i := range filters {
+		var value []byte
+		for j := range itm.Fields {
+			if itm.Fields[j].ID == filters[i].FieldID {
+				value = itm.Fields[j].Value
+				break
+			}
+		}
+		if !filters[i].Match(value) {
+			return false
+		}
+	}
+	return true
+}
+
 func internalFieldsToProtoFields(internal []shardnodeproto.Field) []shardnode.Field {
 	// todo: use memory pool
 	ret := make([]shardnode.Field, len(internal))
@@ -1274,6 +2059,50 @@ func fetchBlobFromProposeRet(data interface{}) (b proto.Blob) {
 	return ret.blob
 }
 
+func fetchDedupRetFromProposeRet(data interface{}) (ret shardnode.UnrefDedupRet) {
+	if data == nil {
+		return
+	}
+	r, ok := data.(applyRet)
+	if !ok {
+		panic("illegal response.Data type")
+	}
+	return r.dedupRet
+}
+
+func fetchApplyRetFromProposeRet(data interface{}) (ret applyRet) {
+	if data == nil {
+		return
+	}
+	ret, ok := data.(applyRet)
+	if !ok {
+		panic("illegal response.Data type")
+	}
+	return
+}
+
+func fetchKeyMissedFromProposeRet(data interface{}) bool {
+	if data == nil {
+		return false
+	}
+	ret, ok := data.(applyRet)
+	if !ok {
+		panic("illegal response.Data type")
+	}
+	return ret.keyMissed
+}
+
+func fetchTxnConflictFromProposeRet(data interface{}) bool {
+	if data == nil {
+		return false
+	}
+	ret, ok := data.(applyRet)
+	if !ok {
+		panic("illegal response.Data type")
+	}
+	return ret.txnConflict
+}
+
 type ShardKeysGenerator struct {
 	shardKeysGenerator
 }
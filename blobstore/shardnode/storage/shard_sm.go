@@ -19,10 +19,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"sync/atomic"
 	"time"
 
 	"github.com/cubefs/cubefs/blobstore/api/clustermgr"
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/raft"
@@ -39,6 +41,15 @@ const (
 	raftOpInsertBlob
 	raftOpUpdateBlob
 	raftOpDeleteBlob
+	raftOpTrashBlob
+	raftOpUndeleteBlob
+	raftOpDeleteTrashBlob
+	raftOpRefDedup
+	raftOpUnrefDedup
+	raftOpTxn
+	raftOpUpdateItemVersion
+	raftOpDeleteItemVersion
+	raftOpSetReadonly
 
 	setRaw = "set"
 	getRaw = "get"
@@ -70,6 +81,16 @@ func (s *shardSM) Apply(ctx context.Context, pd []raft.ProposalData, index uint6
 				return
 			}
 			rets[i] = applyRet{traceLog: _span.TrackLog()}
+		case raftOpUpdateItemVersion:
+			if err = s.applyUpdateItemVersion(c, pd[i].Data); err != nil {
+				return
+			}
+			rets[i] = applyRet{traceLog: _span.TrackLog()}
+		case raftOpDeleteItemVersion:
+			if err = s.applyDeleteItemVersion(c, pd[i].Data); err != nil {
+				return
+			}
+			rets[i] = applyRet{traceLog: _span.TrackLog()}
 		case raftOpInsertBlob:
 			var blob proto.Blob
 			if blob, err = s.applyInsertBlob(c, pd[i].Data); err != nil {
@@ -84,26 +105,82 @@ func (s *shardSM) Apply(ctx context.Context, pd []raft.ProposalData, index uint6
 				return
 			}
 			rets[i] = applyRet{traceLog: _span.TrackLog()}
-		case raftOpDeleteBlob, raftOpDeleteItem:
+		case raftOpTrashBlob:
+			var missed bool
+			if missed, err = s.applyTrashBlob(c, pd[i].Data); err != nil {
+				return
+			}
+			rets[i] = applyRet{
+				traceLog:  _span.TrackLog(),
+				keyMissed: missed,
+			}
+		case raftOpUndeleteBlob:
+			var ret applyRet
+			if ret, err = s.applyUndeleteBlob(c, pd[i].Data); err != nil {
+				return
+			}
+			ret.traceLog = _span.TrackLog()
+			rets[i] = ret
+		case raftOpRefDedup:
+			var ref shardnode.DedupRef
+			if ref, err = s.applyRefDedup(c, pd[i].Data); err != nil {
+				return
+			}
+			rets[i] = applyRet{
+				traceLog: _span.TrackLog(),
+				dedupRet: shardnode.UnrefDedupRet{Ref: ref},
+			}
+		case raftOpUnrefDedup:
+			var (
+				ret    shardnode.UnrefDedupRet
+				missed bool
+			)
+			if ret, missed, err = s.applyUnrefDedup(c, pd[i].Data); err != nil {
+				return
+			}
+			rets[i] = applyRet{
+				traceLog:  _span.TrackLog(),
+				dedupRet:  ret,
+				keyMissed: missed,
+			}
+		case raftOpTxn:
+			var conflict bool
+			if conflict, err = s.applyTxn(c, pd[i].Data); err != nil {
+				return
+			}
+			rets[i] = applyRet{
+				traceLog:    _span.TrackLog(),
+				txnConflict: conflict,
+			}
+		case raftOpDeleteBlob, raftOpDeleteItem, raftOpDeleteTrashBlob:
 			if err = s.applyDeleteRaw(c, pd[i].Data); err != nil {
 				return
 			}
 			rets[i] = applyRet{traceLog: _span.TrackLog()}
+		case raftOpSetReadonly:
+			if err = s.applySetReadonly(c, pd[i].Data); err != nil {
+				return
+			}
+			rets[i] = applyRet{traceLog: _span.TrackLog()}
 		default:
 			panic(fmt.Sprintf("unsupported operation type: %d", pd[i].Op))
 		}
 	}
 
+	if err = s.saveApplyMarker(ctx, index); err != nil {
+		return
+	}
 	s.setAppliedIndex(index)
 	return
 }
 
-func (s *shardSM) LeaderChange(peerID uint64) error {
-	log.Info(fmt.Sprintf("shard[%d] receive Leader change, diskID: %d, suid: %d, peerID: %d",
-		s.suid.ShardID(), s.diskID, s.suid, peerID))
+func (s *shardSM) LeaderChange(peerID, term uint64) error {
+	log.Info(fmt.Sprintf("shard[%d] receive Leader change, diskID: %d, suid: %d, peerID: %d, term: %d",
+		s.suid.ShardID(), s.diskID, s.suid, peerID, term))
 	// todo: report Leader change to master
 	s.shardInfoMu.Lock()
 	s.shardInfoMu.leader = proto.DiskID(peerID)
+	s.shardInfoMu.leaderTerm = term
 	s.shardInfoMu.Unlock()
 
 	if peerID > 0 && peerID != uint64(s.disk.DiskID()) {
@@ -239,6 +316,21 @@ func (s *shardSM) ApplySnapshot(ctx context.Context, header raft.RaftSnapshotHea
 		return err
 	}
 
+	applyBatch := func(batch kvstore.WriteBatch) error {
+		return kvStore.Write(ctx, batch, nil)
+	}
+	var restorer kvstore.Restorer
+	if s.cfg.RaftSnapTransmitConfig.ApplyByIngest {
+		var err error
+		if restorer, err = kvStore.NewRestorer(ctx, dataCF, kvstore.RestoreOption{}); err != nil {
+			return errors.Info(err, "new restorer failed")
+		}
+		defer restorer.Close()
+		applyBatch = func(batch kvstore.WriteBatch) error {
+			return putBatchIntoRestorer(restorer, batch)
+		}
+	}
+
 	for {
 		batch, err := snap.ReadBatch()
 		if err != nil && err != io.EOF {
@@ -246,7 +338,7 @@ func (s *shardSM) ApplySnapshot(ctx context.Context, header raft.RaftSnapshotHea
 		}
 
 		if batch != nil {
-			if _err := kvStore.Write(ctx, batch.(raftBatch).batch, nil); _err != nil {
+			if _err := applyBatch(batch.(raftBatch).batch); _err != nil {
 				span.Debugf("shard[%d] suid[%d] applying snapshot, apply index:%d", s.suid.ShardID(), s.suid, snap.Index())
 				batch.Close()
 				return _err
@@ -257,8 +349,25 @@ func (s *shardSM) ApplySnapshot(ctx context.Context, header raft.RaftSnapshotHea
 			break
 		}
 	}
+	if restorer != nil {
+		if err := restorer.Ingest(ctx); err != nil {
+			return errors.Info(err, "ingest snapshot failed")
+		}
+	}
+
+	// restore readonly flag carried by snapshot
+	readonly, err := (*shard)(s).loadReadonly(ctx)
+	if err != nil {
+		return errors.Info(err, "load readonly failed")
+	}
+	s.shardInfoMu.Lock()
+	s.shardInfoMu.readonly = readonly
+	s.shardInfoMu.Unlock()
 
 	// save applied index and shard's info
+	if err := s.saveApplyMarker(ctx, snap.Index()); err != nil {
+		return errors.Info(err, "save apply marker failed")
+	}
 	s.setAppliedIndex(snap.Index())
 	// save shard unit by members
 	members := header.Members
@@ -287,6 +396,21 @@ func (s *shardSM) ApplySnapshot(ctx context.Context, header raft.RaftSnapshotHea
 	return nil
 }
 
+// putBatchIntoRestorer puts the batch of snapshot into restorer, the keys of
+// snapshot are read from iterator in ascending order.
+func putBatchIntoRestorer(restorer kvstore.Restorer, batch kvstore.WriteBatch) error {
+	iter := batch.Iterator()
+	for iter.Next() {
+		if typ := iter.Type(); typ != kvstore.WriteBatchValueRecord && typ != kvstore.WriteBatchCFValueRecord {
+			return fmt.Errorf("unexpected record type %d in snapshot", typ)
+		}
+		if err := restorer.Put(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *shardSM) applyUpdateItem(ctx context.Context, data []byte) error {
 	span := trace.SpanFromContext(ctx)
 
@@ -315,30 +439,208 @@ func (s *shardSM) applyUpdateItem(ctx context.Context, data []byte) error {
 	}
 	vg.Close()
 
+	updateItemFields(item, pi.Fields)
+
+	data, err = item.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := kvStore.SetRaw(ctx, dataCF, key, data, nil); err != nil {
+		return errors.Info(err, "kv store set failed")
+	}
+
+	return nil
+}
+
+// updateItemFields updates existed fields or inserts new fields of item
+func updateItemFields(itm *item, fields []shardnodeproto.Field) {
 	fieldMap := make(map[proto.FieldID]int)
-	for i := range item.Fields {
-		fieldMap[item.Fields[i].ID] = i
+	for i := range itm.Fields {
+		fieldMap[itm.Fields[i].ID] = i
 	}
-	for _, updateField := range pi.Fields {
-		// update existed field or insert new field
+	for _, updateField := range fields {
 		if idx, ok := fieldMap[updateField.ID]; ok {
-			item.Fields[idx].Value = updateField.Value
+			itm.Fields[idx].Value = updateField.Value
 			continue
 		}
-		item.Fields = append(item.Fields, shardnodeproto.Field{ID: updateField.ID, Value: updateField.Value})
+		itm.Fields = append(itm.Fields, shardnodeproto.Field{ID: updateField.ID, Value: updateField.Value})
 	}
+}
 
-	data, err = item.Marshal()
+// applyUpdateItemVersion keeps the current item as an old version and writes the updated item
+// as the next version in one write batch, then removes old versions out of the proposed policy.
+// The overwritten time of version is the mtime of its next version, all of the time comes from
+// proposal, so that replicas keep the same versions.
+func (s *shardSM) applyUpdateItemVersion(ctx context.Context, data []byte) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	vi := &shardnodeproto.VersionedItem{}
+	if err := vi.Unmarshal(data); err != nil {
+		return err
+	}
+
+	kvStore := s.store.KVStore()
+	start := time.Now()
+	vg, err := kvStore.Get(ctx, dataCF, vi.Key, nil)
+	withErr := err
+	if errors.Is(withErr, kvstore.ErrNotFound) {
+		withErr = nil
+	}
+	span.AppendTrackLog(getRaw, start, withErr, trace.OptSpanDurationUs())
+	if err != nil {
+		// replay raft wal log may meet with item deleted and replay update item operation
+		if errors.Is(err, kvstore.ErrNotFound) {
+			span.Warnf("item[%s] has been deleted", vi.Item.ID)
+			return nil
+		}
+		return errors.Info(err, "get raw kv failed")
+	}
+	current := &item{}
+	err = current.Unmarshal(vg.Value())
+	vg.Close()
 	if err != nil {
 		return err
 	}
-	if err := kvStore.SetRaw(ctx, dataCF, key, data, nil); err != nil {
-		return errors.Info(err, "kv store set failed")
+	if current.Version == 0 {
+		current.Version = 1
+	}
+
+	next := &item{ID: current.ID, Fields: append([]shardnodeproto.Field(nil), current.Fields...)}
+	updateItemFields(next, vi.Item.Fields)
+	// nothing changed at the same time, e.g. replay the last update of raft wal log
+	if current.Mtime == vi.Item.Mtime && itemFieldsEqual(current.Fields, next.Fields) {
+		return nil
+	}
+	next.Version = current.Version + 1
+	next.Mtime = vi.Item.Mtime
+
+	removes, err := s.expiredItemVersions(ctx, vi, current)
+	if err != nil {
+		return err
+	}
+	currentValue, err := current.Marshal()
+	if err != nil {
+		return err
+	}
+	nextValue, err := next.Marshal()
+	if err != nil {
+		return err
+	}
+
+	batch := kvStore.NewWriteBatch()
+	defer batch.Close()
+	batch.Put(dataCF, s.shardKeys.encodeItemVersionKey(vi.Key, current.Version), currentValue)
+	batch.Put(dataCF, vi.Key, nextValue)
+	for _, version := range removes {
+		batch.Delete(dataCF, s.shardKeys.encodeItemVersionKey(vi.Key, version))
 	}
 
+	start = time.Now()
+	err = kvStore.Write(ctx, batch, nil)
+	span.AppendTrackLog(setRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return errors.Info(err, "kv store write batch failed")
+	}
 	return nil
 }
 
+// expiredItemVersions returns old versions beyond max versions or overwritten for more than ttl,
+// the current item is taken as the latest old version
+func (s *shardSM) expiredItemVersions(ctx context.Context, vi *shardnodeproto.VersionedItem, current *item) ([]uint64, error) {
+	versions := make([]uint64, 0)
+	mtimes := make([]int64, 0)
+
+	lr := s.store.KVStore().List(ctx, dataCF, s.shardKeys.encodeItemVersionPrefix(vi.Key), nil, nil)
+	defer lr.Close()
+	for {
+		kg, vg, err := lr.ReadNext()
+		if err != nil {
+			return nil, errors.Info(err, "read next item version failed")
+		}
+		if kg == nil || vg == nil {
+			break
+		}
+		version := s.shardKeys.decodeItemVersion(kg.Key())
+		old := &item{}
+		err = old.Unmarshal(vg.Value())
+		kg.Close()
+		vg.Close()
+		if err != nil {
+			return nil, err
+		}
+		if version >= current.Version {
+			break
+		}
+		versions = append(versions, version)
+		mtimes = append(mtimes, old.Mtime)
+	}
+	versions = append(versions, current.Version)
+	mtimes = append(mtimes, current.Mtime)
+
+	removeCount := 0
+	if vi.MaxVersions > 0 && len(versions) > int(vi.MaxVersions) {
+		removeCount = len(versions) - int(vi.MaxVersions)
+	}
+	removes := make([]uint64, 0)
+	for i := range versions {
+		overwritten := vi.Item.Mtime
+		if i+1 < len(versions) {
+			overwritten = mtimes[i+1]
+		}
+		if i < removeCount || (vi.TTLS > 0 && vi.Item.Mtime-overwritten >= vi.TTLS) {
+			removes = append(removes, versions[i])
+		}
+	}
+	return removes, nil
+}
+
+// applyDeleteItemVersion deletes item with all of its old versions
+func (s *shardSM) applyDeleteItemVersion(ctx context.Context, key []byte) error {
+	span := trace.SpanFromContextSafe(ctx)
+
+	kvStore := s.store.KVStore()
+	start := time.Now()
+	vg, err := kvStore.Get(ctx, dataCF, key, nil)
+	withErr := err
+	if errors.Is(withErr, kvstore.ErrNotFound) {
+		withErr = nil
+	}
+	span.AppendTrackLog(getRaw, start, withErr, trace.OptSpanDurationUs())
+	if err != nil {
+		if !errors.Is(err, kvstore.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+	vg.Close()
+
+	batch := kvStore.NewWriteBatch()
+	defer batch.Close()
+	batch.Delete(dataCF, key)
+	batch.DeleteRange(dataCF, s.shardKeys.encodeItemVersionKey(key, 0),
+		append(s.shardKeys.encodeItemVersionKey(key, math.MaxUint64), 0))
+
+	start = time.Now()
+	err = kvStore.Write(ctx, batch, nil)
+	span.AppendTrackLog(delRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return errors.Info(err, "kv store write batch failed")
+	}
+	return nil
+}
+
+func itemFieldsEqual(a, b []shardnodeproto.Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || !bytes.Equal(a[i].Value, b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *shardSM) applyInsertItem(ctx context.Context, data []byte) error {
 	span := trace.SpanFromContextSafe(ctx)
 
@@ -479,8 +781,449 @@ func (s *shardSM) applyDeleteRaw(ctx context.Context, data []byte) error {
 	return nil
 }
 
+// applyTrashBlob moves blob into the versioned trash key in one write batch,
+// returns missed if blob has been deleted or trashed
+func (s *shardSM) applyTrashBlob(ctx context.Context, data []byte) (bool, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	op := shardnodeproto.TrashOp{}
+	if err := op.Unmarshal(data); err != nil {
+		return false, errors.Info(err, "unmarshal trash op failed")
+	}
+
+	kvStore := s.store.KVStore()
+	start := time.Now()
+	vg, err := kvStore.Get(ctx, dataCF, op.Key, nil)
+	withErr := err
+	if errors.Is(withErr, kvstore.ErrNotFound) {
+		withErr = nil
+	}
+	span.AppendTrackLog(getRaw, start, withErr, trace.OptSpanDurationUs())
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotFound) {
+			span.Warnf("shard [%d] blob key [%s] not found, skip trash", s.suid, string(op.Key))
+			return true, nil
+		}
+		return false, errors.Info(err, "get blob kv failed")
+	}
+	tb := shardnode.TrashBlob{ExpireTime: op.ExpireTime, ShardKeys: op.ShardKeys, Version: op.Version}
+	err = tb.Blob.Unmarshal(vg.Value())
+	vg.Close()
+	if err != nil {
+		return false, errors.Info(err, "unmarshal blob failed")
+	}
+	value, err := tb.Marshal()
+	if err != nil {
+		return false, err
+	}
+
+	trashKey := s.shardKeys.encodeTrashVersionKey(s.shardKeys.decodeBlobKey(op.Key), op.Version)
+	batch := kvStore.NewWriteBatch()
+	defer batch.Close()
+	batch.Put(dataCF, trashKey, value)
+	batch.Delete(dataCF, op.Key)
+
+	start = time.Now()
+	err = kvStore.Write(ctx, batch, nil)
+	span.AppendTrackLog(setRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return false, errors.Info(err, "kv store write batch failed")
+	}
+	return false, nil
+}
+
+// applyUndeleteBlob moves the versioned trash key back to blob key, returns missed if trash
+// has been purged, or exists if a new blob with the same name has been created
+func (s *shardSM) applyUndeleteBlob(ctx context.Context, data []byte) (applyRet, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	op := shardnodeproto.TrashOp{}
+	if err := op.Unmarshal(data); err != nil {
+		return applyRet{}, errors.Info(err, "unmarshal trash op failed")
+	}
+	key := op.Key
+	trashKey := s.shardKeys.encodeTrashVersionKey(s.shardKeys.decodeBlobKey(key), op.Version)
+	kvStore := s.store.KVStore()
+
+	start := time.Now()
+	vg, err := kvStore.Get(ctx, dataCF, trashKey, nil)
+	withErr := err
+	if errors.Is(withErr, kvstore.ErrNotFound) {
+		withErr = nil
+	}
+	span.AppendTrackLog(getRaw, start, withErr, trace.OptSpanDurationUs())
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotFound) {
+			span.Warnf("shard [%d] trash blob key [%s] has been purged", s.suid, string(trashKey))
+			return applyRet{keyMissed: true}, nil
+		}
+		return applyRet{}, errors.Info(err, "get trash kv failed")
+	}
+	tb := shardnode.TrashBlob{}
+	err = tb.Unmarshal(vg.Value())
+	vg.Close()
+	if err != nil {
+		return applyRet{}, errors.Info(err, "unmarshal trash blob failed")
+	}
+
+	vg, err = kvStore.Get(ctx, dataCF, key, nil)
+	if err == nil {
+		vg.Close()
+		span.Warnf("shard [%d] blob key [%s] already exists, skip undelete", s.suid, string(key))
+		return applyRet{keyExists: true}, nil
+	}
+	if !errors.Is(err, kvstore.ErrNotFound) {
+		return applyRet{}, errors.Info(err, "get blob kv failed")
+	}
+
+	value, err := tb.Blob.Marshal()
+	if err != nil {
+		return applyRet{}, err
+	}
+	batch := kvStore.NewWriteBatch()
+	defer batch.Close()
+	batch.Put(dataCF, key, value)
+	batch.Delete(dataCF, trashKey)
+
+	start = time.Now()
+	err = kvStore.Write(ctx, batch, nil)
+	span.AppendTrackLog(setRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return applyRet{}, errors.Info(err, "kv store write batch failed")
+	}
+	return applyRet{blob: tb.Blob}, nil
+}
+
+// applyRefDedup saves the reference of exist dedup ref and increases its count,
+// or saves the new one with its first reference, the exist reference is skipped
+func (s *shardSM) applyRefDedup(ctx context.Context, data []byte) (shardnode.DedupRef, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	op := shardnodeproto.DedupOp{}
+	if err := op.Unmarshal(data); err != nil {
+		return shardnode.DedupRef{}, errors.Info(err, "unmarshal dedup op failed")
+	}
+	arg := shardnode.DedupRef{}
+	if err := arg.Unmarshal(op.Value); err != nil {
+		return arg, errors.Info(err, "unmarshal dedup ref failed")
+	}
+
+	ref, exist, err := s.getDedupRef(ctx, op.Key)
+	if err != nil {
+		return ref, err
+	}
+	if exist {
+		// the reference has been referred by the retried request
+		referred, err := s.dedupRefExist(ctx, op.RefKey)
+		if err != nil || referred {
+			return ref, err
+		}
+	} else {
+		ref = arg
+		ref.RefCount = 0
+	}
+	ref.RefCount++
+
+	value, err := ref.Marshal()
+	if err != nil {
+		return ref, err
+	}
+	kvStore := s.store.KVStore()
+	batch := kvStore.NewWriteBatch()
+	defer batch.Close()
+	batch.Put(dataCF, op.Key, value)
+	batch.Put(dataCF, op.RefKey, nil)
+
+	start := time.Now()
+	err = kvStore.Write(ctx, batch, nil)
+	span.AppendTrackLog(setRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return ref, errors.Info(err, "kv store write batch failed")
+	}
+	return ref, nil
+}
+
+// applyUnrefDedup removes the reference of dedup ref which refers to the expected location
+// and decreases its count, the dedup ref and all its references are deleted if no reference.
+// missed returns if the dedup ref not exists or refers to other location
+func (s *shardSM) applyUnrefDedup(ctx context.Context, data []byte) (ret shardnode.UnrefDedupRet, missed bool, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	op := shardnodeproto.DedupOp{}
+	if err = op.Unmarshal(data); err != nil {
+		return ret, false, errors.Info(err, "unmarshal dedup op failed")
+	}
+	expected := shardnode.DedupRef{}
+	if err = expected.Unmarshal(op.Value); err != nil {
+		return ret, false, errors.Info(err, "unmarshal dedup ref failed")
+	}
+
+	ref, exist, err := s.getDedupRef(ctx, op.Key)
+	if err != nil {
+		return ret, false, err
+	}
+	if !exist || !sameDedupLocation(ref.Location, expected.Location) {
+		span.Warnf("shard [%d] dedup key [%s] of the location has been deleted", s.suid, string(op.Key))
+		return ret, true, nil
+	}
+	ret.Ref = ref
+	// the reference has been unreferred by the retried or concurrent request
+	referred, err := s.dedupRefExist(ctx, op.RefKey)
+	if err != nil || !referred {
+		return ret, false, err
+	}
+
+	kvStore := s.store.KVStore()
+	batch := kvStore.NewWriteBatch()
+	defer batch.Close()
+	if ref.RefCount > 0 {
+		ref.RefCount--
+	}
+	if ref.RefCount == 0 {
+		refPrefix := s.shardKeys.encodeDedupRefPrefix(op.Key[shardDedupPrefixSize():])
+		batch.Delete(dataCF, op.Key)
+		batch.DeleteRange(dataCF, refPrefix, prefixEnd(refPrefix))
+	} else {
+		value, err := ref.Marshal()
+		if err != nil {
+			return ret, false, err
+		}
+		batch.Put(dataCF, op.Key, value)
+		batch.Delete(dataCF, op.RefKey)
+	}
+
+	start := time.Now()
+	err = kvStore.Write(ctx, batch, nil)
+	span.AppendTrackLog(delRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return ret, false, errors.Info(err, "kv store write batch failed")
+	}
+	ret.Ref = ref
+	ret.Unreferred = true
+	ret.Last = ref.RefCount == 0
+	return ret, false, nil
+}
+
+func (s *shardSM) getDedupRef(ctx context.Context, key []byte) (ref shardnode.DedupRef, exist bool, err error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	start := time.Now()
+	vg, err := s.store.KVStore().Get(ctx, dataCF, key, nil)
+	withErr := err
+	if errors.Is(withErr, kvstore.ErrNotFound) {
+		withErr = nil
+	}
+	span.AppendTrackLog(getRaw, start, withErr, trace.OptSpanDurationUs())
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotFound) {
+			return ref, false, nil
+		}
+		return ref, false, errors.Info(err, "get dedup kv failed")
+	}
+	err = ref.Unmarshal(vg.Value())
+	vg.Close()
+	if err != nil {
+		return ref, false, errors.Info(err, "unmarshal dedup ref failed")
+	}
+	return ref, true, nil
+}
+
+func (s *shardSM) dedupRefExist(ctx context.Context, refKey []byte) (bool, error) {
+	vg, err := s.store.KVStore().Get(ctx, dataCF, refKey, nil)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotFound) {
+			return false, nil
+		}
+		return false, errors.Info(err, "get dedup ref kv failed")
+	}
+	vg.Close()
+	return true, nil
+}
+
+// prefixEnd returns the smallest key which is greater than all keys with the prefix
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// sameDedupLocation compares the locations by the first slice, which is unique
+// for each uploaded content
+func sameDedupLocation(a, b proto.Location) bool {
+	if a.ClusterID != b.ClusterID || len(a.Slices) == 0 || len(b.Slices) == 0 {
+		return false
+	}
+	return a.Slices[0].Vid == b.Slices[0].Vid && a.Slices[0].MinSliceID == b.Slices[0].MinSliceID
+}
+
+// applyTxn checks conditions of all operations of the transaction and writes them in one write batch,
+// nothing is written and conflict returns if any condition is not satisfied
+func (s *shardSM) applyTxn(ctx context.Context, data []byte) (bool, error) {
+	span := trace.SpanFromContextSafe(ctx)
+
+	txn := &shardnodeproto.Txn{}
+	if err := txn.Unmarshal(data); err != nil {
+		return false, errors.Info(err, "unmarshal txn failed")
+	}
+
+	kvStore := s.store.KVStore()
+	batch := kvStore.NewWriteBatch()
+	defer batch.Close()
+	for i := range txn.Ops {
+		op := &txn.Ops[i]
+
+		start := time.Now()
+		vg, err := kvStore.Get(ctx, dataCF, op.Key, nil)
+		withErr := err
+		if errors.Is(withErr, kvstore.ErrNotFound) {
+			withErr = nil
+		}
+		span.AppendTrackLog(getRaw, start, withErr, trace.OptSpanDurationUs())
+		if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+			return false, errors.Info(err, "get raw kv failed")
+		}
+		var value []byte
+		exist := err == nil
+		if exist {
+			value = make([]byte, vg.Size())
+			copy(value, vg.Value())
+			vg.Close()
+		}
+
+		satisfied, err := checkTxnCond(op, exist, value)
+		if err != nil {
+			return false, err
+		}
+		// update item and put blob require the key exists
+		if satisfied && (op.Type == uint32(shardnode.TxnOpUpdateItem) || op.Type == uint32(shardnode.TxnOpPutBlob)) {
+			satisfied = exist
+		}
+		if !satisfied {
+			span.Warnf("shard [%d] txn op[%d] key [%s] conflicts, cond type: %d", s.suid, i, string(op.Key), op.CondType)
+			return true, nil
+		}
+
+		switch shardnode.TxnOpType(op.Type) {
+		case shardnode.TxnOpPutItem, shardnode.TxnOpPutBlob:
+			batch.Put(dataCF, op.Key, op.Value)
+		case shardnode.TxnOpUpdateItem:
+			itm, pi := &item{}, &item{}
+			if err = itm.Unmarshal(value); err != nil {
+				return false, err
+			}
+			if err = pi.Unmarshal(op.Value); err != nil {
+				return false, err
+			}
+			updateItemFields(itm, pi.Fields)
+			if value, err = itm.Marshal(); err != nil {
+				return false, err
+			}
+			batch.Put(dataCF, op.Key, value)
+		case shardnode.TxnOpDeleteItem:
+			if exist {
+				batch.Delete(dataCF, op.Key)
+			}
+		case shardnode.TxnOpDeleteBlob:
+			if !exist {
+				continue
+			}
+			// move blob into trash as TrashBlob, the data is deleted by purge loop after expired
+			tb := shardnode.TrashBlob{ExpireTime: op.ExpireTime, ShardKeys: op.ShardKeys, Version: op.Version}
+			if err = tb.Blob.Unmarshal(value); err != nil {
+				return false, errors.Info(err, "unmarshal blob failed")
+			}
+			if value, err = tb.Marshal(); err != nil {
+				return false, err
+			}
+			batch.Put(dataCF, s.shardKeys.encodeTrashVersionKey(s.shardKeys.decodeBlobKey(op.Key), op.Version), value)
+			batch.Delete(dataCF, op.Key)
+		default:
+			return false, errors.Newf("unsupported txn operation type: %d", op.Type)
+		}
+	}
+
+	start := time.Now()
+	err := kvStore.Write(ctx, batch, nil)
+	span.AppendTrackLog(setRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return false, errors.Info(err, "kv store write batch failed")
+	}
+	return false, nil
+}
+
+// checkTxnCond returns true if the condition of operation is satisfied with the current value
+func checkTxnCond(op *shardnodeproto.TxnOp, exist bool, value []byte) (bool, error) {
+	switch shardnode.TxnCondType(op.CondType) {
+	case shardnode.TxnCondNone:
+		return true, nil
+	case shardnode.TxnCondNotExist:
+		return !exist, nil
+	case shardnode.TxnCondExist:
+		return exist, nil
+	case shardnode.TxnCondFieldEqual:
+		if !exist {
+			return false, nil
+		}
+		itm := &item{}
+		if err := itm.Unmarshal(value); err != nil {
+			return false, err
+		}
+		for i := range itm.Fields {
+			if itm.Fields[i].ID == op.CondFieldID {
+				return bytes.Equal(itm.Fields[i].Value, op.CondValue), nil
+			}
+		}
+		// absent field equals to empty value
+		return len(op.CondValue) == 0, nil
+	case shardnode.TxnCondValueEqual:
+		return exist && bytes.Equal(value, op.CondValue), nil
+	default:
+		return false, nil
+	}
+}
+
+// applySetReadonly persists the readonly flag as a key in shard data range,
+// so the flag is carried by snapshot to the replica catching up
+func (s *shardSM) applySetReadonly(ctx context.Context, data []byte) error {
+	span := trace.SpanFromContextSafe(ctx)
+	if len(data) != 1 {
+		return errors.Newf("invalid readonly data: %v", data)
+	}
+	readonly := data[0] == 1
+
+	s.shardInfoMu.Lock()
+	defer s.shardInfoMu.Unlock()
+	if s.shardInfoMu.readonly == readonly {
+		return nil
+	}
+
+	kvStore := s.store.KVStore()
+	key := s.shardKeys.encodeReadonlyKey()
+	start := time.Now()
+	var err error
+	if readonly {
+		err = kvStore.SetRaw(ctx, dataCF, key, data, nil)
+	} else {
+		err = kvStore.Delete(ctx, dataCF, key, nil)
+	}
+	span.AppendTrackLog(setRaw, start, err, trace.OptSpanDurationUs())
+	if err != nil {
+		return errors.Info(err, "save readonly failed")
+	}
+	s.shardInfoMu.readonly = readonly
+	span.Infof("shard[%d] suid[%d] set readonly: %v", s.suid.ShardID(), s.suid, readonly)
+	return nil
+}
+
 func (s *shardSM) setAppliedIndex(index uint64) {
 	atomic.StoreUint64(&s.shardInfoMu.AppliedIndex, index)
+	s.shardState.recovered(index)
 }
 
 func (s *shardSM) getAppliedIndex() uint64 {
@@ -490,4 +1233,11 @@ func (s *shardSM) getAppliedIndex() uint64 {
 type applyRet struct {
 	traceLog []string
 	blob     proto.Blob
+	dedupRet shardnode.UnrefDedupRet
+
+	// key of op is not found, or already exists
+	keyMissed bool
+	keyExists bool
+
+	txnConflict bool
 }
@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
 	"github.com/cubefs/cubefs/blobstore/common/errors"
 	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
 	cproto "github.com/cubefs/cubefs/blobstore/common/proto"
@@ -107,7 +109,7 @@ func TestServerShardSM_Item(t *testing.T) {
 	}
 	rets, marker, err := mockShard.shard.ListItem(ctx, OpHeader{
 		ShardKeys: [][]byte{items[0].ID},
-	}, nil, items[0].ID, uint64(n-1))
+	}, nil, items[0].ID, uint64(n-1), nil)
 	require.Nil(t, err)
 	require.Equal(t, items[n-1].ID, marker)
 
@@ -117,9 +119,119 @@ func TestServerShardSM_Item(t *testing.T) {
 
 	_, marker, err = mockShard.shard.ListItem(ctx, OpHeader{
 		ShardKeys: [][]byte{items[0].ID},
-	}, nil, items[0].ID, uint64(n))
+	}, nil, items[0].ID, uint64(n), nil)
 	require.Nil(t, err)
 	require.Nil(t, marker)
+
+	// List with filters
+	rets, marker, err = mockShard.shard.ListItem(ctx, OpHeader{
+		ShardKeys: [][]byte{items[0].ID},
+	}, nil, nil, uint64(n), []shardnode.FieldFilter{
+		{FieldID: 0, Op: shardnode.FilterOpPrefix, Value: []byte("str")},
+		{FieldID: 1, Op: shardnode.FilterOpGreaterEqual, Value: []byte("2")},
+	})
+	require.Nil(t, err)
+	require.Nil(t, marker)
+	require.Equal(t, 2, len(rets))
+	require.Equal(t, items[2].ID, rets[0].ID)
+	require.Equal(t, items[3].ID, rets[1].ID)
+	// absent field equals to empty value
+	rets, _, err = mockShard.shard.ListItem(ctx, OpHeader{
+		ShardKeys: [][]byte{items[0].ID},
+	}, nil, nil, uint64(n), []shardnode.FieldFilter{{FieldID: 2, Op: shardnode.FilterOpEqual}})
+	require.Nil(t, err)
+	require.Equal(t, n, len(rets))
+}
+
+func TestServerShardSM_ItemVersion(t *testing.T) {
+	mockShard, shardClean := newMockShard(t)
+	defer shardClean()
+	sk := mockShard.shard.shardKeys
+	h := OpHeader{ShardKeys: [][]byte{[]byte("a")}}
+
+	insert := func(id string, mtime int64) {
+		itm := &proto.Item{ID: []byte(id), Fields: []proto.Field{{ID: 0, Value: []byte("0")}}, Version: 1, Mtime: mtime}
+		kv, err := initKV(sk.encodeItemKey(itm.ID), &io.LimitedReader{R: rpc2.Codec2Reader(itm), N: int64(itm.Size())})
+		require.NoError(t, err)
+		require.NoError(t, mockShard.shardSM.applyInsertItem(ctx, kv.Marshal()))
+	}
+	update := func(id string, value string, mtime int64, maxVersions uint32, ttl int64) {
+		vi := &proto.VersionedItem{
+			Key:         sk.encodeItemKey([]byte(id)),
+			Item:        proto.Item{ID: []byte(id), Fields: []proto.Field{{ID: 0, Value: []byte(value)}}, Mtime: mtime},
+			MaxVersions: maxVersions,
+			TTLS:        ttl,
+		}
+		data, err := vi.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, mockShard.shardSM.applyUpdateItemVersion(ctx, data))
+	}
+	checkVersion := func(id string, version uint64, value string) {
+		itm, err := mockShard.shard.GetItemVersion(ctx, h, []byte(id), version)
+		require.NoError(t, err)
+		require.Equal(t, value, string(itm.Fields[0].Value))
+	}
+
+	insert("a", 100)
+	insert("ab", 100)
+	for i := 1; i <= 4; i++ {
+		update("a", fmt.Sprint(i), int64(100+i), 0, 0)
+	}
+	update("ab", "1", 101, 0, 0)
+	// replay the last update
+	update("a", "4", 104, 0, 0)
+
+	itm, err := mockShard.shard.GetItem(ctx, h, []byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), itm.Version)
+	checkVersion("a", 0, "4")
+	for i := 1; i <= 5; i++ {
+		checkVersion("a", uint64(i), fmt.Sprint(i-1))
+	}
+	_, err = mockShard.shard.GetItemVersion(ctx, h, []byte("a"), 6)
+	require.ErrorIs(t, err, errors.ErrKeyNotFound)
+	checkVersion("ab", 1, "0")
+	checkVersion("ab", 2, "1")
+
+	// remove by count, keep 3 old versions
+	update("a", "5", 105, 3, 0)
+	for i := 1; i <= 2; i++ {
+		_, err = mockShard.shard.GetItemVersion(ctx, h, []byte("a"), uint64(i))
+		require.ErrorIs(t, err, errors.ErrKeyNotFound)
+	}
+	for i := 3; i <= 6; i++ {
+		checkVersion("a", uint64(i), fmt.Sprint(i-1))
+	}
+	// remove by ttl, version 3 to 5 are overwritten before 150
+	update("a", "6", 200, 0, 50)
+	for i := 3; i <= 5; i++ {
+		_, err = mockShard.shard.GetItemVersion(ctx, h, []byte("a"), uint64(i))
+		require.ErrorIs(t, err, errors.ErrKeyNotFound)
+	}
+	checkVersion("a", 6, "5")
+	checkVersion("a", 7, "6")
+
+	// item written before versioning enabled
+	legacy := &proto.Item{ID: []byte("b"), Fields: []proto.Field{{ID: 0, Value: []byte("0")}}}
+	kv, err := initKV(sk.encodeItemKey(legacy.ID), &io.LimitedReader{R: rpc2.Codec2Reader(legacy), N: int64(legacy.Size())})
+	require.NoError(t, err)
+	require.NoError(t, mockShard.shardSM.applyInsertItem(ctx, kv.Marshal()))
+	checkVersion("b", 1, "0")
+	update("b", "1", 100, 0, 0)
+	checkVersion("b", 1, "0")
+	checkVersion("b", 2, "1")
+
+	// delete with all versions
+	require.NoError(t, mockShard.shardSM.applyDeleteItemVersion(ctx, sk.encodeItemKey([]byte("a"))))
+	require.NoError(t, mockShard.shardSM.applyDeleteItemVersion(ctx, sk.encodeItemKey([]byte("a"))))
+	_, err = mockShard.shard.GetItem(ctx, h, []byte("a"))
+	require.ErrorIs(t, err, errors.ErrKeyNotFound)
+	_, err = mockShard.shard.store.KVStore().GetRaw(ctx, dataCF, sk.encodeItemVersionKey(sk.encodeItemKey([]byte("a")), 6))
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+	checkVersion("ab", 1, "0")
+	update("a", "1", 300, 0, 0)
+	_, err = mockShard.shard.GetItem(ctx, h, []byte("a"))
+	require.ErrorIs(t, err, errors.ErrKeyNotFound)
 }
 
 func TestServerShardSM_Apply(t *testing.T) {
@@ -205,7 +317,8 @@ func TestServer_BlobList(t *testing.T) {
 	n := 4
 	for i := 0; i < n; i++ {
 		b := cproto.Blob{
-			Name: []byte(fmt.Sprintf("blob%d", i)),
+			Name:     []byte(fmt.Sprintf("blob%d", i)),
+			Location: cproto.Location{Slices: []cproto.Slice{{Vid: cproto.Vid(i%2 + 1)}}},
 		}
 		kv, _ := initKV(sk.encodeBlobKey(b.Name), &io.LimitedReader{R: rpc2.Codec2Reader(&b), N: int64(b.Size())})
 		mockShard.shardSM.applyInsertBlob(ctx, kv.Marshal())
@@ -213,7 +326,7 @@ func TestServer_BlobList(t *testing.T) {
 	}
 
 	// without prefix and marker
-	retBlobs, mkr, err := mockShard.shard.ListBlob(ctx, OpHeader{}, nil, nil, uint64(n+1))
+	retBlobs, mkr, err := mockShard.shard.ListBlob(ctx, OpHeader{}, nil, nil, uint64(n+1), 0)
 	require.Nil(t, err)
 	require.Equal(t, n, len(retBlobs))
 	for i := 0; i < n; i++ {
@@ -221,12 +334,12 @@ func TestServer_BlobList(t *testing.T) {
 	}
 	require.Nil(t, mkr)
 
-	_, mkr, err = mockShard.shard.ListBlob(ctx, OpHeader{}, nil, nil, uint64(n-1))
+	_, mkr, err = mockShard.shard.ListBlob(ctx, OpHeader{}, nil, nil, uint64(n-1), 0)
 	require.Nil(t, err)
 	require.Equal(t, blobs[n-1].Name, mkr)
 
 	// with prefix
-	_, mkr, err = mockShard.shard.ListBlob(ctx, OpHeader{}, []byte("blob"), nil, uint64(n))
+	_, mkr, err = mockShard.shard.ListBlob(ctx, OpHeader{}, []byte("blob"), nil, uint64(n), 0)
 	require.Nil(t, err)
 	require.Equal(t, n, len(retBlobs))
 	for i := 0; i < n; i++ {
@@ -235,11 +348,19 @@ func TestServer_BlobList(t *testing.T) {
 	require.Nil(t, mkr)
 
 	// with marker
-	retBlobs, mkr, err = mockShard.shard.ListBlob(ctx, OpHeader{}, nil, []byte("blob3"), uint64(1))
+	retBlobs, mkr, err = mockShard.shard.ListBlob(ctx, OpHeader{}, nil, []byte("blob3"), uint64(1), 0)
 	require.Nil(t, err)
 	require.Nil(t, mkr)
 	require.Equal(t, 1, len(retBlobs))
 	require.Equal(t, blobs[n-1].Name, retBlobs[0].Name)
+
+	// with volume
+	retBlobs, mkr, err = mockShard.shard.ListBlob(ctx, OpHeader{}, nil, nil, uint64(n), 2)
+	require.Nil(t, err)
+	require.Nil(t, mkr)
+	require.Equal(t, 2, len(retBlobs))
+	require.Equal(t, blobs[1].Name, retBlobs[0].Name)
+	require.Equal(t, blobs[3].Name, retBlobs[1].Name)
 }
 
 func TestServer_CreateBlob(t *testing.T) {
@@ -260,6 +381,281 @@ func TestServer_CreateBlob(t *testing.T) {
 	require.NotEqual(t, b1, b11)
 }
 
+func TestServer_TrashBlob(t *testing.T) {
+	ctx := context.Background()
+	mockShard, shardClean := newMockShard(t)
+	defer shardClean()
+
+	sk := mockShard.shard.shardKeys
+	b := cproto.Blob{Name: []byte("blob1")}
+	kv, _ := initKV(sk.encodeBlobKey(b.Name), &io.LimitedReader{R: rpc2.Codec2Reader(&b), N: int64(b.Size())})
+	_, err := mockShard.shardSM.applyInsertBlob(ctx, kv.Marshal())
+	require.Nil(t, err)
+
+	trashOp := func(version uint64) []byte {
+		op := proto.TrashOp{Key: sk.encodeBlobKey(b.Name), Version: version, ExpireTime: 100}
+		data, _ := op.Marshal()
+		return data
+	}
+	missed, err := mockShard.shardSM.applyTrashBlob(ctx, trashOp(1))
+	require.Nil(t, err)
+	require.False(t, missed)
+	// blob has been trashed
+	missed, err = mockShard.shardSM.applyTrashBlob(ctx, trashOp(2))
+	require.Nil(t, err)
+	require.True(t, missed)
+
+	tb := shardnode.TrashBlob{Blob: b, ExpireTime: 100, Version: 1}
+	blobs, _, err := mockShard.shard.ListBlob(ctx, OpHeader{}, nil, nil, 10, 0)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(blobs))
+	trashBlobs, _, err := mockShard.shard.ListTrashBlob(ctx, OpHeader{}, nil, nil, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(trashBlobs))
+	require.Equal(t, tb, trashBlobs[0])
+
+	// trash of the same name is kept with another version
+	_, err = mockShard.shardSM.applyInsertBlob(ctx, kv.Marshal())
+	require.Nil(t, err)
+	_, err = mockShard.shardSM.applyTrashBlob(ctx, trashOp(3))
+	require.Nil(t, err)
+	trashBlobs, _, err = mockShard.shard.ListTrashBlob(ctx, OpHeader{}, nil, nil, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(trashBlobs))
+	latest, found, err := mockShard.shard.getLatestTrashBlob(ctx, b.Name)
+	require.Nil(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(3), latest.Version)
+
+	ret, err := mockShard.shardSM.applyUndeleteBlob(ctx, trashOp(3))
+	require.Nil(t, err)
+	require.Equal(t, b.Name, ret.blob.Name)
+	blobs, _, err = mockShard.shard.ListBlob(ctx, OpHeader{}, nil, nil, 10, 0)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(blobs))
+	require.Equal(t, b.Name, blobs[0].Name)
+	trashBlobs, _, err = mockShard.shard.ListTrashBlob(ctx, OpHeader{}, nil, nil, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(trashBlobs))
+
+	// blob already exists
+	ret, err = mockShard.shardSM.applyUndeleteBlob(ctx, trashOp(1))
+	require.Nil(t, err)
+	require.True(t, ret.keyExists)
+	// trash has been purged
+	ret, err = mockShard.shardSM.applyUndeleteBlob(ctx, trashOp(3))
+	require.Nil(t, err)
+	require.True(t, ret.keyMissed)
+}
+
+func TestServer_DedupRef(t *testing.T) {
+	ctx := context.Background()
+	mockShard, shardClean := newMockShard(t)
+	defer shardClean()
+
+	sk := mockShard.shard.shardKeys
+	key := []byte("hash1")
+	h := OpHeader{ShardKeys: [][]byte{key}}
+	location := cproto.Location{ClusterID: 1, Size_: 10, Slices: []cproto.Slice{{MinSliceID: 1, Vid: 1, Count: 1}}}
+	refDedup := func(refID []byte, loc cproto.Location) shardnode.DedupRef {
+		value, _ := (&shardnode.DedupRef{Hash: key, Location: loc}).Marshal()
+		op := proto.DedupOp{Key: sk.encodeDedupKey(key), RefKey: sk.encodeDedupRefKey(key, refID), Value: value}
+		data, _ := op.Marshal()
+		ret, err := mockShard.shardSM.applyRefDedup(ctx, data)
+		require.Nil(t, err)
+		return ret
+	}
+	unrefDedup := func(refID []byte, loc cproto.Location) (shardnode.UnrefDedupRet, bool) {
+		value, _ := (&shardnode.DedupRef{Location: loc}).Marshal()
+		op := proto.DedupOp{Key: sk.encodeDedupKey(key), RefKey: sk.encodeDedupRefKey(key, refID), Value: value}
+		data, _ := op.Marshal()
+		ret, missed, err := mockShard.shardSM.applyUnrefDedup(ctx, data)
+		require.Nil(t, err)
+		return ret, missed
+	}
+
+	ret := refDedup([]byte("ref1"), location)
+	require.Equal(t, uint64(1), ret.RefCount)
+	require.Equal(t, location, ret.Location)
+
+	// exist ref keeps the first location, ref of the same reference takes effect only once
+	other := cproto.Location{ClusterID: 1, Size_: 10, Slices: []cproto.Slice{{MinSliceID: 2, Vid: 2, Count: 1}}}
+	for _, refID := range [][]byte{[]byte("ref2"), []byte("ref2"), []byte("ref3")} {
+		ret = refDedup(refID, other)
+	}
+	require.Equal(t, uint64(3), ret.RefCount)
+	require.Equal(t, location, ret.Location)
+
+	getRet, err := mockShard.shard.GetDedup(ctx, h, key)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), getRet.Ref.RefCount)
+	require.Equal(t, []byte("ref1"), getRet.FirstRef)
+
+	// unref of other location misses
+	_, missed := unrefDedup([]byte("ref1"), other)
+	require.True(t, missed)
+
+	// unref of the same reference takes effect only once
+	unrefRet, missed := unrefDedup([]byte("ref1"), location)
+	require.False(t, missed)
+	require.True(t, unrefRet.Unreferred)
+	require.False(t, unrefRet.Last)
+	require.Equal(t, uint64(2), unrefRet.Ref.RefCount)
+	unrefRet, missed = unrefDedup([]byte("ref1"), location)
+	require.False(t, missed)
+	require.False(t, unrefRet.Unreferred)
+	require.Equal(t, uint64(2), unrefRet.Ref.RefCount)
+
+	getRet, err = mockShard.shard.GetDedup(ctx, h, key)
+	require.Nil(t, err)
+	require.Equal(t, []byte("ref2"), getRet.FirstRef)
+
+	unrefRet, _ = unrefDedup([]byte("ref2"), location)
+	require.False(t, unrefRet.Last)
+	unrefRet, _ = unrefDedup([]byte("ref3"), location)
+	require.True(t, unrefRet.Unreferred)
+	require.True(t, unrefRet.Last)
+	require.Equal(t, uint64(0), unrefRet.Ref.RefCount)
+	_, err = mockShard.shard.GetDedup(ctx, h, key)
+	require.ErrorIs(t, err, errors.ErrKeyNotFound)
+
+	// has been deleted
+	_, missed = unrefDedup([]byte("ref3"), location)
+	require.True(t, missed)
+
+	// references are deleted with the last one, recreated with the other location
+	ret = refDedup([]byte("ref1"), other)
+	require.Equal(t, uint64(1), ret.RefCount)
+	require.Equal(t, other, ret.Location)
+	getRet, err = mockShard.shard.GetDedup(ctx, h, key)
+	require.Nil(t, err)
+	require.Equal(t, []byte("ref1"), getRet.FirstRef)
+	_, missed = unrefDedup([]byte("ref1"), location)
+	require.True(t, missed)
+}
+
+func TestServer_Txn(t *testing.T) {
+	ctx := context.Background()
+	mockShard, shardClean := newMockShard(t)
+	defer shardClean()
+
+	blobName, idxID, seqID := []byte("blob1"), []byte("idx1"), []byte("seq")
+	h := OpHeader{ShardKeys: [][]byte{blobName}}
+	commit := func(ops ...TxnOp) bool {
+		txn, err := mockShard.shard.encodeTxn(ops)
+		require.Nil(t, err)
+		data, err := txn.Marshal()
+		require.Nil(t, err)
+		conflict, err := mockShard.shardSM.applyTxn(ctx, data)
+		require.Nil(t, err)
+		return conflict
+	}
+	seqItem := func(seq string) shardnode.Item {
+		return shardnode.Item{ID: seqID, Fields: []shardnode.Field{{ID: 1, Value: []byte(seq)}}}
+	}
+	getSeq := func() string {
+		itm, err := mockShard.shard.GetItem(ctx, h, seqID)
+		require.Nil(t, err)
+		return string(itm.Fields[0].Value)
+	}
+
+	shardKeys := [][]byte{blobName}
+	// put blob requires the blob created
+	blob := cproto.Blob{Name: blobName, Sealed: true}
+	createOps := []TxnOp{
+		{Type: shardnode.TxnOpPutBlob, ShardKeys: shardKeys, Key: blobName, Blob: blob},
+		{Type: shardnode.TxnOpPutItem, ShardKeys: shardKeys, Key: idxID, Item: shardnode.Item{ID: idxID, Fields: []shardnode.Field{{ID: 1, Value: blobName}}}},
+		{Type: shardnode.TxnOpPutItem, ShardKeys: shardKeys, Key: seqID, Item: seqItem("1")},
+	}
+	require.True(t, commit(createOps...))
+	_, err := mockShard.shard.GetItem(ctx, h, seqID)
+	require.ErrorIs(t, err, errors.ErrKeyNotFound)
+
+	// seal created blob with index and sequence
+	created := cproto.Blob{Name: blobName}
+	kv, _ := initKV(mockShard.shard.shardKeys.encodeBlobKey(blobName), &io.LimitedReader{R: rpc2.Codec2Reader(&created), N: int64(created.Size())})
+	_, err = mockShard.shardSM.applyInsertBlob(ctx, kv.Marshal())
+	require.Nil(t, err)
+	createdValue, err := created.Marshal()
+	require.Nil(t, err)
+	createOps[0].Cond = shardnode.TxnCond{Type: shardnode.TxnCondValueEqual, Value: createdValue}
+	createOps[2].Cond = shardnode.TxnCond{Type: shardnode.TxnCondNotExist}
+	require.False(t, commit(createOps...))
+	b, err := mockShard.shard.GetBlob(ctx, h, blobName)
+	require.Nil(t, err)
+	require.True(t, b.Sealed)
+	require.Equal(t, "1", getSeq())
+
+	// conflicts and nothing written
+	createOps[2].Item = seqItem("2")
+	require.True(t, commit(createOps...))
+	require.Equal(t, "1", getSeq())
+
+	// compare and update sequence, delete index
+	casOp := TxnOp{
+		Type: shardnode.TxnOpUpdateItem, ShardKeys: shardKeys, Key: seqID, Item: seqItem("2"),
+		Cond: shardnode.TxnCond{Type: shardnode.TxnCondFieldEqual, FieldID: 1, Value: []byte("1")},
+	}
+	require.False(t, commit(casOp, TxnOp{Type: shardnode.TxnOpDeleteItem, ShardKeys: shardKeys, Key: idxID}))
+	require.Equal(t, "2", getSeq())
+	_, err = mockShard.shard.GetItem(ctx, h, idxID)
+	require.ErrorIs(t, err, errors.ErrKeyNotFound)
+	// stale value
+	require.True(t, commit(casOp))
+	// update not exist item
+	require.True(t, commit(TxnOp{Type: shardnode.TxnOpUpdateItem, ShardKeys: shardKeys, Key: idxID, Item: shardnode.Item{ID: idxID}}))
+
+	// compare and swap blob record
+	oldValue, err := blob.Marshal()
+	require.Nil(t, err)
+	newBlob := cproto.Blob{Name: blobName, Location: cproto.Location{CodeMode: 2}, Sealed: true}
+	swapOp := TxnOp{
+		Type: shardnode.TxnOpPutBlob, ShardKeys: shardKeys, Key: blobName, Blob: newBlob,
+		Cond: shardnode.TxnCond{Type: shardnode.TxnCondValueEqual, Value: oldValue},
+	}
+	require.False(t, commit(swapOp))
+	b, err = mockShard.shard.GetBlob(ctx, h, blobName)
+	require.Nil(t, err)
+	require.Equal(t, newBlob.Location.CodeMode, b.Location.CodeMode)
+	// swapped already
+	require.True(t, commit(swapOp))
+
+	// delete blob into trash and sequence
+	expireTime := time.Now().Add(time.Hour).Unix()
+	require.False(t, commit(
+		TxnOp{Type: shardnode.TxnOpDeleteBlob, ShardKeys: shardKeys, Key: blobName, ExpireTime: expireTime, Cond: shardnode.TxnCond{Type: shardnode.TxnCondExist}},
+		TxnOp{Type: shardnode.TxnOpDeleteItem, ShardKeys: shardKeys, Key: seqID},
+	))
+	_, err = mockShard.shard.GetBlob(ctx, h, blobName)
+	require.ErrorIs(t, err, errors.ErrKeyNotFound)
+	tb, found, err := mockShard.shard.getLatestTrashBlob(ctx, blobName)
+	require.Nil(t, err)
+	require.True(t, found)
+	require.Equal(t, expireTime, tb.ExpireTime)
+	require.Equal(t, shardKeys, tb.ShardKeys)
+	require.Equal(t, newBlob.Location.CodeMode, tb.Blob.Location.CodeMode)
+
+	// illegal txn
+	_, err = mockShard.shard.encodeTxn(nil)
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+	_, err = mockShard.shard.encodeTxn([]TxnOp{{Type: shardnode.TxnOpDeleteItem, ShardKeys: shardKeys, Key: seqID}, {Type: shardnode.TxnOpPutItem, ShardKeys: shardKeys, Key: seqID}})
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+	_, err = mockShard.shard.encodeTxn([]TxnOp{{Type: shardnode.TxnOpDeleteBlob, ShardKeys: shardKeys, Key: blobName, ExpireTime: expireTime, Cond: shardnode.TxnCond{Type: shardnode.TxnCondFieldEqual}}})
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+	_, err = mockShard.shard.encodeTxn([]TxnOp{{Type: shardnode.TxnOpPutItem, ShardKeys: shardKeys, Key: seqID, Cond: shardnode.TxnCond{Type: shardnode.TxnCondValueEqual}}})
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+	_, err = mockShard.shard.encodeTxn([]TxnOp{{Type: shardnode.TxnOpNone, ShardKeys: shardKeys, Key: blobName}})
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+	// shard keys required
+	_, err = mockShard.shard.encodeTxn([]TxnOp{{Type: shardnode.TxnOpPutItem, Key: seqID}})
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+	// unsealed blob and delete blob without expire time
+	_, err = mockShard.shard.encodeTxn([]TxnOp{{Type: shardnode.TxnOpPutBlob, ShardKeys: shardKeys, Key: blobName}})
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+	_, err = mockShard.shard.encodeTxn([]TxnOp{{Type: shardnode.TxnOpDeleteBlob, ShardKeys: shardKeys, Key: blobName}})
+	require.ErrorIs(t, err, errors.ErrIllegalTxn)
+}
+
 func TestServer_Snapshot(t *testing.T) {
 	mockShard, shardClean := newMockShard(t)
 	defer shardClean()
@@ -292,6 +688,16 @@ func TestServer_Snapshot(t *testing.T) {
 	err = mockShard.shardSM.ApplySnapshot(context.TODO(), raft.RaftSnapshotHeader{Members: members}, ss)
 	require.Nil(t, err)
 
+	// apply snapshot by ingesting sst files
+	mockShard.shardSM.cfg.RaftSnapTransmitConfig.ApplyByIngest = true
+	ss, err = mockShard.shardSM.Snapshot()
+	require.Nil(t, err)
+	err = mockShard.shardSM.ApplySnapshot(context.TODO(), raft.RaftSnapshotHeader{Members: members}, ss)
+	require.Nil(t, err)
+	mockShard.shardSM.cfg.RaftSnapTransmitConfig.ApplyByIngest = false
+	_, err = mockShard.shard.GetBlob(ctx, OpHeader{ShardKeys: [][]byte{b1.Name}}, b1.Name)
+	require.Nil(t, err)
+
 	// if shard is stop writing when processing snapshot, do not return err
 
 	mockShard.shard.shardState.stopWriting()
@@ -115,8 +115,10 @@ func newMockShard(tb testing.TB) (*mockShard, func()) {
 			shardInfo
 
 			leader             proto.DiskID
+			leaderTerm         uint64
 			lastStableIndex    uint64
 			lastTruncatedIndex uint64
+			readonly           bool
 		}{
 			leader: 1, shardInfo: shardInfo{
 				ShardID: 1,
@@ -260,6 +262,98 @@ func TestServerShard_Item(t *testing.T) {
 	mockShard.shard.diskID = 1
 }
 
+func TestServerShard_LeaderTerm(t *testing.T) {
+	mockShard, shardClean := newMockShard(t)
+	defer shardClean()
+
+	item := shardnode.Item{ID: []byte{1}, Fields: []shardnode.Field{{ID: 0, Value: []byte("string")}}}
+	mockShard.shard.shardInfoMu.leaderTerm = 2
+
+	// no fencing
+	h := OpHeader{ShardKeys: [][]byte{item.ID}}
+	require.Nil(t, mockShard.shard.InsertItem(ctx, h, item.ID, item))
+	// the leader known by client
+	h.LeaderTerm = 2
+	require.Nil(t, mockShard.shard.UpdateItem(ctx, h, item.ID, item))
+	// newer leader exists
+	h.LeaderTerm = 3
+	require.Equal(t, apierr.ErrShardFenced, mockShard.shard.InsertItem(ctx, h, item.ID, item))
+	require.Equal(t, apierr.ErrShardFenced, mockShard.shard.DeleteItem(ctx, h, item.ID))
+	_, err := mockShard.shard.CreateBlob(ctx, h, []byte("blob"), proto.Blob{})
+	require.Equal(t, apierr.ErrShardFenced, err)
+	// stale leader of client
+	h.LeaderTerm = 1
+	require.Equal(t, apierr.ErrShardFenced, mockShard.shard.CommitTxn(ctx, h, nil))
+	// read is not fenced
+	_, err = mockShard.shard.GetItem(ctx, h, item.ID)
+	require.NotEqual(t, apierr.ErrShardFenced, err)
+
+	mockShard.mockRaftGroup.EXPECT().Stat().Return(&raft.Stat{}, nil)
+	stats, err := mockShard.shard.Stats(ctx, true)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), stats.LeaderTerm)
+
+	// leader changed after checking, fenced at applying
+	raftGroup := raft.NewMockGroup(mockShard.ctl)
+	raftGroup.EXPECT().Propose(A, A).DoAndReturn(func(_ context.Context, pdata *raft.ProposalData) (raft.ProposalResponse, error) {
+		require.Equal(t, uint64(2), pdata.Term)
+		return raft.ProposalResponse{}, raft.ErrProposalFenced
+	})
+	mockShard.shard.raftGroup = raftGroup
+	defer func() { mockShard.shard.raftGroup = mockShard.mockRaftGroup }()
+	h.LeaderTerm = 2
+	require.Equal(t, apierr.ErrShardFenced, mockShard.shard.UpdateItem(ctx, h, item.ID, item))
+}
+
+func TestServerShard_Readonly(t *testing.T) {
+	mockShard, shardClean := newMockShard(t)
+	defer shardClean()
+
+	item := shardnode.Item{ID: []byte{1}, Fields: []shardnode.Field{{ID: 0, Value: []byte("string")}}}
+	h := OpHeader{ShardKeys: [][]byte{item.ID}}
+
+	require.Nil(t, mockShard.shard.SetReadonly(ctx, true))
+	mockShard.shard.diskID = 2
+	require.Equal(t, apierr.ErrShardNodeNotLeader, mockShard.shard.SetReadonly(ctx, true))
+	mockShard.shard.diskID = 1
+
+	_, err := mockShard.shardSM.Apply(ctx, []raft.ProposalData{{Op: raftOpSetReadonly, Data: []byte{1}}}, 1)
+	require.Nil(t, err)
+	readonly, err := mockShard.shard.loadReadonly(ctx)
+	require.Nil(t, err)
+	require.True(t, readonly)
+
+	require.Equal(t, apierr.ErrShardReadonly, mockShard.shard.InsertItem(ctx, h, item.ID, item))
+	require.Equal(t, apierr.ErrShardReadonly, mockShard.shard.DeleteItem(ctx, h, item.ID))
+	_, err = mockShard.shard.CreateBlob(ctx, h, []byte("blob"), proto.Blob{})
+	require.Equal(t, apierr.ErrShardReadonly, err)
+	// read is not affected
+	_, err = mockShard.shard.GetItem(ctx, h, item.ID)
+	require.NotEqual(t, apierr.ErrShardReadonly, err)
+
+	mockShard.mockRaftGroup.EXPECT().Stat().Return(&raft.Stat{}, nil)
+	stats, err := mockShard.shard.Stats(ctx, true)
+	require.Nil(t, err)
+	require.True(t, stats.Readonly)
+
+	// readonly flag is carried by snapshot, and restored after applying snapshot
+	ss, err := mockShard.shardSM.Snapshot()
+	require.Nil(t, err)
+	mockShard.shard.shardInfoMu.readonly = false
+	require.Nil(t, mockShard.shardSM.ApplySnapshot(ctx, raft.RaftSnapshotHeader{}, ss))
+	require.True(t, mockShard.shard.isReadonly())
+
+	_, err = mockShard.shardSM.Apply(ctx, []raft.ProposalData{{Op: raftOpSetReadonly, Data: []byte{0}}}, 2)
+	require.Nil(t, err)
+	readonly, err = mockShard.shard.loadReadonly(ctx)
+	require.Nil(t, err)
+	require.False(t, readonly)
+	require.Nil(t, mockShard.shard.InsertItem(ctx, h, item.ID, item))
+
+	_, err = mockShard.shardSM.Apply(ctx, []raft.ProposalData{{Op: raftOpSetReadonly}}, 3)
+	require.Error(t, err)
+}
+
 func TestServerShard_Stats(t *testing.T) {
 	mockShard, shardClean := newMockShard(t)
 	defer shardClean()
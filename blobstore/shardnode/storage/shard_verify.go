@@ -0,0 +1,171 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"sync/atomic"
+
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+	"github.com/cubefs/cubefs/blobstore/common/raft"
+	"github.com/cubefs/cubefs/blobstore/common/trace"
+	"github.com/cubefs/cubefs/blobstore/util/errors"
+)
+
+// apply marker records the applied index of shard's state machine, it's written
+// after the data of raft entries applied, and before the shard info is saved with
+// the applied index. So the marker is never less than the applied index of shard
+// info if the state machine is persisted consistently with raft.
+//
+// marker value: [index(8)][crc32 of index(4)]
+const applyMarkerSize = 8 + 4
+
+var errInvalidApplyMarker = errors.New("invalid apply marker")
+
+func encodeApplyMarker(index uint64) []byte {
+	raw := make([]byte, applyMarkerSize)
+	binary.BigEndian.PutUint64(raw, index)
+	binary.BigEndian.PutUint32(raw[8:], crc32.ChecksumIEEE(raw[:8]))
+	return raw
+}
+
+func decodeApplyMarker(raw []byte) (uint64, error) {
+	if len(raw) != applyMarkerSize {
+		return 0, errInvalidApplyMarker
+	}
+	if crc32.ChecksumIEEE(raw[:8]) != binary.BigEndian.Uint32(raw[8:]) {
+		return 0, errInvalidApplyMarker
+	}
+	return binary.BigEndian.Uint64(raw), nil
+}
+
+func (s *shardSM) saveApplyMarker(ctx context.Context, index uint64) error {
+	return s.store.KVStore().SetRaw(ctx, dataCF, s.shardKeys.encodeApplyMarkerKey(), encodeApplyMarker(index))
+}
+
+// verifyShardApplied compares the applied index of shard info with the apply marker
+// of state machine when loading shard. It returns the index the shard must catch up
+// with before serving if they are inconsistent, or zero.
+//
+// The state machine and raft log of shard are reset for recovering from leader's
+// snapshot, the hard state's term and vote is kept for raft safety. The shard with
+// single member has no where to recover from, it refuses to serve until manual repair.
+func (d *Disk) verifyShardApplied(ctx context.Context, suid proto.Suid, info *shardInfo) (uint64, error) {
+	span := trace.SpanFromContextSafe(ctx)
+	keys := &shardKeysGenerator{suid: suid}
+
+	raw, err := d.store.KVStore().GetRaw(ctx, dataCF, keys.encodeApplyMarkerKey())
+	if err != nil {
+		// shard created by older version or nothing applied yet
+		if errors.Is(err, kvstore.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Info(err, "get apply marker failed")
+	}
+	marker, err := decodeApplyMarker(raw)
+	if err == nil && marker >= info.AppliedIndex {
+		return 0, nil
+	}
+	span.Errorf("disk[%d] shard[%d] suid[%d] state machine inconsistent with raft, applied: %d, marker: %d, err: %v",
+		d.DiskID(), suid.ShardID(), suid, info.AppliedIndex, marker, err)
+
+	if len(info.Units) <= 1 {
+		span.Errorf("disk[%d] suid[%d] has no member to recover from, refuse to serve", d.DiskID(), suid)
+		return math.MaxUint64, nil
+	}
+
+	recoverIndex := info.AppliedIndex
+	if recoverIndex == 0 {
+		recoverIndex = 1
+	}
+	if err = d.resetShardForSnapshot(ctx, suid, info); err != nil {
+		return 0, errors.Info(err, "reset shard for snapshot failed")
+	}
+	span.Warnf("disk[%d] suid[%d] reset to recover from snapshot, recover index: %d", d.DiskID(), suid, recoverIndex)
+	return recoverIndex, nil
+}
+
+// resetShardForSnapshot clears the shard's data and raft log, and resets the commit
+// index of hard state, then the leader sends snapshot to the shard as a new member.
+func (d *Disk) resetShardForSnapshot(ctx context.Context, suid proto.Suid, info *shardInfo) error {
+	keys := &shardKeysGenerator{suid: suid}
+	kvStore := d.store.KVStore()
+
+	info.AppliedIndex = 0
+	value, err := info.Marshal()
+	if err != nil {
+		return err
+	}
+	batch := kvStore.NewWriteBatch()
+	batch.DeleteRange(dataCF, keys.encodeShardDataPrefix(), keys.encodeShardDataMaxPrefix())
+	batch.Delete(dataCF, keys.encodeApplyMarkerKey())
+	batch.Put(dataCF, keys.encodeShardInfoKey(), value)
+	if err = kvStore.Write(ctx, batch); err != nil {
+		return err
+	}
+	if err = kvStore.FlushCF(ctx, dataCF); err != nil {
+		return err
+	}
+
+	groupID := uint64(suid.ShardID())
+	raftStore := d.store.RaftStore()
+	raftBatch := raftStore.NewWriteBatch()
+	raftBatch.DeleteRange(raftWalCF, raft.EncodeIndexLogKey(groupID, 0), raft.EncodeIndexLogKey(groupID, math.MaxUint64))
+	raftBatch.Delete(raftWalCF, raft.EncodeSnapshotMetaKey(groupID))
+
+	raw, err := raftStore.GetRaw(ctx, raftWalCF, raft.EncodeHardStateKey(groupID))
+	if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		hs := raftpb.HardState{}
+		if err = hs.Unmarshal(raw); err != nil {
+			return err
+		}
+		hs.Commit = 0
+		if raw, err = hs.Marshal(); err != nil {
+			return err
+		}
+		raftBatch.Put(raftWalCF, raft.EncodeHardStateKey(groupID), raw)
+	}
+	return raftStore.Write(ctx, raftBatch)
+}
+
+func (s *shardState) setRecovering(index uint64) {
+	atomic.StoreUint64(&s.recoverIndex, index)
+}
+
+func (s *shardState) isRecovering() bool {
+	return atomic.LoadUint64(&s.recoverIndex) > 0
+}
+
+// recovered ends the recovering if the applied index catches up with recover index
+func (s *shardState) recovered(applied uint64) {
+	for {
+		index := atomic.LoadUint64(&s.recoverIndex)
+		if index == 0 || applied < index {
+			return
+		}
+		if atomic.CompareAndSwapUint64(&s.recoverIndex, index, 0) {
+			return
+		}
+	}
+}
@@ -27,6 +27,8 @@ type Config struct {
 	RaftOption kvstore.Option                       `json:"raft_option"`
 	Path       string                               `json:"-"`
 	HandleEIO  func(ctx context.Context, err error) `json:"-"`
+	// SstFileManager limits deleting rate of obsolete sst files of kv and raft store on the disk
+	SstFileManager kvstore.SstFileManagerConfig `json:"sst_file_manager"`
 }
 
 type Store struct {
@@ -34,6 +36,8 @@ type Store struct {
 	raftStore    kvstore.Store
 	defaultRawFS RawFS
 	handleError  func(ctx context.Context, err error)
+	// sstFileManager shared by kv and raft store, nil if not limited
+	sstFileManager kvstore.SstFileManager
 
 	cfg *Config
 }
@@ -48,12 +52,25 @@ func NewStore(ctx context.Context, cfg *Config) (*Store, error) {
 		}
 	}
 
+	var sstFileManager kvstore.SstFileManager
+	if cfg.SstFileManager.DeleteRateBytesPerSec > 0 {
+		sstFileManager = kvstore.NewSstFileManagerWithConfig(ctx, kvstore.RocksdbLsmKVType, nil, cfg.SstFileManager)
+		cfg.KVOption.SstFileManager = sstFileManager
+		cfg.RaftOption.SstFileManager = sstFileManager
+	}
+	closeSstFileManager := func() {
+		if sstFileManager != nil {
+			sstFileManager.Close()
+		}
+	}
+
 	kvStorePath := cfg.Path + "/kv"
 	// disable kv wal to optimized latency
 	cfg.KVOption.DisableWal = true
 	cfg.KVOption.HandleError = handleError
 	kvStore, err := kvstore.NewKVStore(ctx, kvStorePath, kvstore.RocksdbLsmKVType, &cfg.KVOption)
 	if err != nil {
+		closeSstFileManager()
 		return nil, errors.Info(err, "open kv store failed")
 	}
 
@@ -62,6 +79,7 @@ func NewStore(ctx context.Context, cfg *Config) (*Store, error) {
 	raftStore, err := kvstore.NewKVStore(ctx, raftStorePath, kvstore.RocksdbLsmKVType, &cfg.RaftOption)
 	if err != nil {
 		kvStore.Close()
+		closeSstFileManager()
 		return nil, errors.Info(err, "open raft store failed")
 	}
 
@@ -71,6 +89,8 @@ func NewStore(ctx context.Context, cfg *Config) (*Store, error) {
 		defaultRawFS: &posixRawFS{path: cfg.Path + "/raw", handleError: handleError},
 		handleError:  handleError,
 		cfg:          cfg,
+
+		sstFileManager: sstFileManager,
 	}, nil
 }
 
@@ -105,7 +125,27 @@ func (s *Store) DBStats(ctx context.Context, db string) (kvstore.Stats, error) {
 	}
 }
 
+// WriteStall returns the worse write stall state of kv store and raft store
+func (s *Store) WriteStall() kvstore.WriteStallState {
+	kvState, raftState := s.kvStore.WriteStall(), s.raftStore.WriteStall()
+	if kvState > raftState {
+		return kvState
+	}
+	return raftState
+}
+
+// PendingTrashBytes returns bytes of obsolete sst files waiting to be deleted on the disk
+func (s *Store) PendingTrashBytes() uint64 {
+	if s.sstFileManager == nil {
+		return 0
+	}
+	return s.sstFileManager.GetTotalTrashSize()
+}
+
 func (s *Store) Close() {
 	s.kvStore.Close()
 	s.raftStore.Close()
+	if s.sstFileManager != nil {
+		s.sstFileManager.Close()
+	}
 }
@@ -25,6 +25,7 @@ import (
 	shardnodeapi "github.com/cubefs/cubefs/blobstore/api/shardnode"
 	"github.com/cubefs/cubefs/blobstore/cmd"
 	apierr "github.com/cubefs/cubefs/blobstore/common/errors"
+	kvstore "github.com/cubefs/cubefs/blobstore/common/kvstorev2"
 	"github.com/cubefs/cubefs/blobstore/common/proto"
 	"github.com/cubefs/cubefs/blobstore/common/raft"
 	"github.com/cubefs/cubefs/blobstore/common/rpc2"
@@ -50,10 +51,16 @@ type Config struct {
 		CheckMountPoint bool     `json:"check_mount_point"`
 	} `json:"disks_config"`
 
-	StoreConfig     store.Config            `json:"store_config"`
-	RaftConfig      raft.Config             `json:"raft_config"`
-	ShardBaseConfig storage.ShardBaseConfig `json:"shard_base_config"`
-	NodeConfig      cmapi.ShardNodeInfo     `json:"node_config"`
+	StoreConfig     store.Config              `json:"store_config"`
+	RaftConfig      raft.Config               `json:"raft_config"`
+	GroupCommit     storage.GroupCommitConfig `json:"group_commit"`
+	ShardBaseConfig storage.ShardBaseConfig   `json:"shard_base_config"`
+	NodeConfig      cmapi.ShardNodeInfo       `json:"node_config"`
+	ShardMetric     storage.ShardMetricConfig `json:"shard_metric"`
+
+	// SharedResources block cache and write buffer manager shared by stores of
+	// all disks, used by kv_option or raft_option with shared_resource name
+	SharedResources []kvstore.SharedResourceConfig `json:"shared_resources"`
 
 	AllocVolConfig struct {
 		BidAllocNums         uint64  `json:"bid_alloc_nums"`
@@ -72,6 +79,10 @@ type Config struct {
 	WaitRepairCloseDiskIntervalS int64 `json:"wait_repair_close_disk_interval_s"`
 	WaitReOpenDiskIntervalS      int64 `json:"wait_re_open_disk_interval_s"`
 	ShardCheckAndClearIntervalH  int64 `json:"shard_check_and_clear_interval_h"`
+	// OrphanGCIntervalH checks shard data and raft log of shards not belong to the disk,
+	// and deletes them after OrphanGCGraceH since found
+	OrphanGCIntervalH int64 `json:"orphan_gc_interval_h"`
+	OrphanGCGraceH    int64 `json:"orphan_gc_grace_h"`
 }
 
 func newService(cfg *Config) *service {
@@ -81,7 +92,8 @@ func newService(cfg *Config) *service {
 	initServiceConfig(cfg)
 	cmClient := cmapi.New(&cfg.CmConfig)
 	snClient := shardnodeapi.New(rpc2.Client{RetryOn: func(err error) bool {
-		return rpc2.DetectStatusCode(err) < apierr.CodeShardNodeNotLeader
+		code := rpc2.DetectStatusCode(err)
+		return code < apierr.CodeShardNodeNotLeader || code == apierr.CodeShardNodeWriteStall
 	}})
 	transport := base.NewTransport(cmClient, snClient, &cfg.NodeConfig)
 	cfg.ShardBaseConfig.Transport = transport
@@ -102,6 +114,17 @@ func newService(cfg *Config) *service {
 		taskPool:  taskpool.New(defaultTaskPoolSize, defaultTaskPoolSize),
 		closer:    closer.New(),
 		disks:     make(map[proto.DiskID]*storage.Disk),
+
+		shardMetrics: storage.NewShardMetrics(cfg.ShardMetric),
+	}
+
+	// register shared resources before opening stores of disks
+	for _, resourceCfg := range cfg.SharedResources {
+		resource, err := kvstore.RegisterSharedResource(ctx, kvstore.RocksdbLsmKVType, resourceCfg)
+		if err != nil {
+			span.Fatalf("register shared resource[%+v] failed: %s", resourceCfg, err)
+		}
+		svr.sharedResources = append(svr.sharedResources, resource)
 	}
 
 	// load disks
@@ -135,6 +158,8 @@ func newService(cfg *Config) *service {
 		},
 	})
 	svr.catalog = c
+	// find orphans at startup, deleted by the loop after grace period
+	svr.gcOrphans(ctx)
 	go svr.loop(ctx)
 	span.Infof("service started success")
 
@@ -148,9 +173,11 @@ type service struct {
 	taskPool  taskpool.TaskPool
 	groupRun  singleflight.Group
 
-	cfg    Config
-	lock   sync.RWMutex
-	closer closer.Closer
+	cfg             Config
+	lock            sync.RWMutex
+	closer          closer.Closer
+	sharedResources []*kvstore.SharedResource
+	shardMetrics    *storage.ShardMetrics
 }
 
 func (s *service) getDisk(diskID proto.DiskID) (*storage.Disk, error) {
@@ -183,4 +210,7 @@ func (s *service) getAllDisks() []*storage.Disk {
 func (s *service) close() {
 	s.closer.Close()
 	s.cfg.RaftConfig.Transport.Close()
+	for _, resource := range s.sharedResources {
+		resource.Release()
+	}
 }
@@ -124,6 +124,7 @@ func mockClusterMgrRouter(service *mockClusterMgr) *rpc.Router {
 	r.Handle(http.MethodGet, "/volume/alloc", service.AllocVolume)
 	r.Handle(http.MethodPost, "/shardnode/disk/heartbeat", service.HeartBeat)
 	r.Handle(http.MethodPost, "/shard/report", service.ShardReport, rpc.OptArgsQuery())
+	r.Handle(http.MethodGet, "/shard/unit/list", service.ListShardUnit, rpc.OptArgsQuery())
 	r.Handle(http.MethodPost, "/shardnode/disk/set", service.SetDisk)
 	r.Handle(http.MethodGet, "/shardnode/disk/info", service.GetDiskInfo)
 
@@ -258,6 +259,10 @@ func (mcm *mockClusterMgr) ShardReport(c *rpc.Context) {
 	c.RespondJSON(&cmapi.ShardReportRet{})
 }
 
+func (mcm *mockClusterMgr) ListShardUnit(c *rpc.Context) {
+	c.RespondJSON(&cmapi.ListShardUnitRet{})
+}
+
 func (mcm *mockClusterMgr) SetDisk(c *rpc.Context) {
 	c.Respond()
 }
@@ -0,0 +1,59 @@
+// Copyright 2024 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package shardnode
+
+import (
+	"context"
+
+	"github.com/cubefs/cubefs/blobstore/api/shardnode"
+	apierr "github.com/cubefs/cubefs/blobstore/common/errors"
+	"github.com/cubefs/cubefs/blobstore/shardnode/storage"
+)
+
+func (s *service) commitTxn(ctx context.Context, req *shardnode.CommitTxnArgs) error {
+	if len(req.Ops) < 1 || len(req.Ops) > storage.MaxTxnOps {
+		return apierr.ErrIllegalTxn
+	}
+	for i := range req.Ops {
+		// every operation must carry its shard keys to check the shard range
+		if len(req.Ops[i].ShardKeys) < 1 {
+			return apierr.ErrIllegalTxn
+		}
+		key := req.Ops[i].Name
+		switch req.Ops[i].Type {
+		case shardnode.TxnOpPutItem, shardnode.TxnOpUpdateItem, shardnode.TxnOpDeleteItem:
+			key = req.Ops[i].Item.ID
+			if len(key) < 1 {
+				return apierr.ErrItemIDEmpty
+			}
+		case shardnode.TxnOpPutBlob, shardnode.TxnOpDeleteBlob:
+			if len(key) < 1 {
+				return apierr.ErrBlobNameEmpty
+			}
+		default:
+			return apierr.ErrIllegalTxn
+		}
+		if len(key) > storage.MaxKeySize {
+			return apierr.ErrKeySizeTooLarge
+		}
+	}
+
+	sid := req.Header.SpaceID
+	space, err := s.catalog.GetSpace(ctx, sid)
+	if err != nil {
+		return err
+	}
+	return space.CommitTxn(ctx, req)
+}
@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 
 	access "github.com/cubefs/cubefs/blobstore/api/access"
+	proxy "github.com/cubefs/cubefs/blobstore/api/proxy"
 	shardnode "github.com/cubefs/cubefs/blobstore/api/shardnode"
 	codemode "github.com/cubefs/cubefs/blobstore/common/codemode"
 	proto "github.com/cubefs/cubefs/blobstore/common/proto"
@@ -213,3 +214,43 @@ func (mr *MockStreamHandlerMockRecorder) SealBlob(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealBlob", reflect.TypeOf((*MockStreamHandler)(nil).SealBlob), arg0, arg1)
 }
+
+// SendBlobEvent mocks base method.
+func (m *MockStreamHandler) SendBlobEvent(arg0 context.Context, arg1 proto.ClusterID, arg2 proxy.BlobEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SendBlobEvent", arg0, arg1, arg2)
+}
+
+// SendBlobEvent indicates an expected call of SendBlobEvent.
+func (mr *MockStreamHandlerMockRecorder) SendBlobEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBlobEvent", reflect.TypeOf((*MockStreamHandler)(nil).SendBlobEvent), arg0, arg1, arg2)
+}
+
+// TransitionBlob mocks base method.
+func (m *MockStreamHandler) TransitionBlob(arg0 context.Context, arg1 *access.TransitionBlobArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionBlob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransitionBlob indicates an expected call of TransitionBlob.
+func (mr *MockStreamHandlerMockRecorder) TransitionBlob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionBlob", reflect.TypeOf((*MockStreamHandler)(nil).TransitionBlob), arg0, arg1)
+}
+
+// UndeleteBlob mocks base method.
+func (m *MockStreamHandler) UndeleteBlob(arg0 context.Context, arg1 *access.UndeleteBlobArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndeleteBlob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UndeleteBlob indicates an expected call of UndeleteBlob.
+func (mr *MockStreamHandlerMockRecorder) UndeleteBlob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlob", reflect.TypeOf((*MockStreamHandler)(nil).UndeleteBlob), arg0, arg1)
+}
@@ -67,6 +67,21 @@ func (mr *MockAccessAPIMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccessAPI)(nil).Get), arg0, arg1)
 }
 
+// Presign mocks base method.
+func (m *MockAccessAPI) Presign(arg0 context.Context, arg1 *access.PresignArgs) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Presign", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Presign indicates an expected call of Presign.
+func (mr *MockAccessAPIMockRecorder) Presign(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Presign", reflect.TypeOf((*MockAccessAPI)(nil).Presign), arg0, arg1)
+}
+
 // Put mocks base method.
 func (m *MockAccessAPI) Put(arg0 context.Context, arg1 *access.PutArgs) (proto.Location, access.HashSumMap, error) {
 	m.ctrl.T.Helper()
@@ -38,6 +38,36 @@ func (m *MockStorageAPI) EXPECT() *MockStorageAPIMockRecorder {
 	return m.recorder
 }
 
+// BatchDeleteShards mocks base method.
+func (m *MockStorageAPI) BatchDeleteShards(arg0 context.Context, arg1 string, arg2 *blobnode.BatchDeleteShardsArgs) (*blobnode.BatchDeleteShardsRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeleteShards", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*blobnode.BatchDeleteShardsRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDeleteShards indicates an expected call of BatchDeleteShards.
+func (mr *MockStorageAPIMockRecorder) BatchDeleteShards(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteShards", reflect.TypeOf((*MockStorageAPI)(nil).BatchDeleteShards), arg0, arg1, arg2)
+}
+
+// BatchMarkDeleteShards mocks base method.
+func (m *MockStorageAPI) BatchMarkDeleteShards(arg0 context.Context, arg1 string, arg2 *blobnode.BatchDeleteShardsArgs) (*blobnode.BatchDeleteShardsRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchMarkDeleteShards", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*blobnode.BatchDeleteShardsRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchMarkDeleteShards indicates an expected call of BatchMarkDeleteShards.
+func (mr *MockStorageAPIMockRecorder) BatchMarkDeleteShards(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchMarkDeleteShards", reflect.TypeOf((*MockStorageAPI)(nil).BatchMarkDeleteShards), arg0, arg1, arg2)
+}
+
 // Close mocks base method.
 func (m *MockStorageAPI) Close(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -125,6 +125,21 @@ func (mr *MockClientAPIMockRecorder) GetConfig(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockClientAPI)(nil).GetConfig), arg0, arg1)
 }
 
+// GetRouteChecksum mocks base method.
+func (m *MockClientAPI) GetRouteChecksum(arg0 context.Context, arg1 *clustermgr.GetRouteChecksumArgs) (*clustermgr.GetRouteChecksumRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouteChecksum", arg0, arg1)
+	ret0, _ := ret[0].(*clustermgr.GetRouteChecksumRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRouteChecksum indicates an expected call of GetRouteChecksum.
+func (mr *MockClientAPIMockRecorder) GetRouteChecksum(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouteChecksum", reflect.TypeOf((*MockClientAPI)(nil).GetRouteChecksum), arg0, arg1)
+}
+
 // GetService mocks base method.
 func (m *MockClientAPI) GetService(arg0 context.Context, arg1 clustermgr.GetServiceArgs) (clustermgr.ServiceInfo, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +170,21 @@ func (mr *MockClientAPIMockRecorder) GetSpaceByName(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceByName", reflect.TypeOf((*MockClientAPI)(nil).GetSpaceByName), arg0, arg1)
 }
 
+// GetVolumeChanges mocks base method.
+func (m *MockClientAPI) GetVolumeChanges(arg0 context.Context, arg1 *clustermgr.GetVolumeChangesArgs) (*clustermgr.GetVolumeChangesRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolumeChanges", arg0, arg1)
+	ret0, _ := ret[0].(*clustermgr.GetVolumeChangesRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolumeChanges indicates an expected call of GetVolumeChanges.
+func (mr *MockClientAPIMockRecorder) GetVolumeChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumeChanges", reflect.TypeOf((*MockClientAPI)(nil).GetVolumeChanges), arg0, arg1)
+}
+
 // GetVolumeInfo mocks base method.
 func (m *MockClientAPI) GetVolumeInfo(arg0 context.Context, arg1 *clustermgr.GetVolumeArgs) (*clustermgr.VolumeInfo, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +95,20 @@ func (mr *MockProxyClientMockRecorder) ListVolumes(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumes", reflect.TypeOf((*MockProxyClient)(nil).ListVolumes), arg0, arg1, arg2)
 }
 
+// SendBlobEvent mocks base method.
+func (m *MockProxyClient) SendBlobEvent(arg0 context.Context, arg1 string, arg2 *proxy.BlobEventArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBlobEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBlobEvent indicates an expected call of SendBlobEvent.
+func (mr *MockProxyClientMockRecorder) SendBlobEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBlobEvent", reflect.TypeOf((*MockProxyClient)(nil).SendBlobEvent), arg0, arg1, arg2)
+}
+
 // SendDeleteMsg mocks base method.
 func (m *MockProxyClient) SendDeleteMsg(arg0 context.Context, arg1 string, arg2 *proxy.DeleteArgs) error {
 	m.ctrl.T.Helper()
@@ -161,6 +175,20 @@ func (m *MockProxyLbRpcClient) EXPECT() *MockProxyLbRpcClientMockRecorder {
 	return m.recorder
 }
 
+// SendBlobEvent mocks base method.
+func (m *MockProxyLbRpcClient) SendBlobEvent(arg0 context.Context, arg1 *proxy.BlobEventArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBlobEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBlobEvent indicates an expected call of SendBlobEvent.
+func (mr *MockProxyLbRpcClientMockRecorder) SendBlobEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBlobEvent", reflect.TypeOf((*MockProxyLbRpcClient)(nil).SendBlobEvent), arg0, arg1)
+}
+
 // SendDeleteMsg mocks base method.
 func (m *MockProxyLbRpcClient) SendDeleteMsg(arg0 context.Context, arg1 *proxy.DeleteArgs) error {
 	m.ctrl.T.Helper()
@@ -67,11 +67,12 @@ func (mr *MockISchedulerMockRecorder) AcquireTask(arg0, arg1 interface{}) *gomoc
 }
 
 // AddManualMigrateTask mocks base method.
-func (m *MockIScheduler) AddManualMigrateTask(arg0 context.Context, arg1 *scheduler.AddManualMigrateArgs) error {
+func (m *MockIScheduler) AddManualMigrateTask(arg0 context.Context, arg1 *scheduler.AddManualMigrateArgs) (scheduler.AddManualMigrateRet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddManualMigrateTask", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(scheduler.AddManualMigrateRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddManualMigrateTask indicates an expected call of AddManualMigrateTask.
@@ -165,6 +166,21 @@ func (mr *MockISchedulerMockRecorder) DetailMigrateTask(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetailMigrateTask", reflect.TypeOf((*MockIScheduler)(nil).DetailMigrateTask), arg0, arg1)
 }
 
+// DiskMigrateReport mocks base method.
+func (m *MockIScheduler) DiskMigrateReport(arg0 context.Context, arg1 *scheduler.DiskMigrateReportArgs) (*scheduler.DiskMigrateReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiskMigrateReport", arg0, arg1)
+	ret0, _ := ret[0].(*scheduler.DiskMigrateReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiskMigrateReport indicates an expected call of DiskMigrateReport.
+func (mr *MockISchedulerMockRecorder) DiskMigrateReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskMigrateReport", reflect.TypeOf((*MockIScheduler)(nil).DiskMigrateReport), arg0, arg1)
+}
+
 // DiskMigratingStats mocks base method.
 func (m *MockIScheduler) DiskMigratingStats(arg0 context.Context, arg1 *scheduler.DiskMigratingStatsArgs) (*scheduler.DiskMigratingStats, error) {
 	m.ctrl.T.Helper()
@@ -180,6 +196,21 @@ func (mr *MockISchedulerMockRecorder) DiskMigratingStats(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskMigratingStats", reflect.TypeOf((*MockIScheduler)(nil).DiskMigratingStats), arg0, arg1)
 }
 
+// InspectVolume mocks base method.
+func (m *MockIScheduler) InspectVolume(arg0 context.Context, arg1 *scheduler.InspectVolumeArgs) (scheduler.InspectVolumeRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectVolume", arg0, arg1)
+	ret0, _ := ret[0].(scheduler.InspectVolumeRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectVolume indicates an expected call of InspectVolume.
+func (mr *MockISchedulerMockRecorder) InspectVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectVolume", reflect.TypeOf((*MockIScheduler)(nil).InspectVolume), arg0, arg1)
+}
+
 // LeaderStats mocks base method.
 func (m *MockIScheduler) LeaderStats(arg0 context.Context) (scheduler.TasksStat, error) {
 	m.ctrl.T.Helper()
@@ -195,6 +226,36 @@ func (mr *MockISchedulerMockRecorder) LeaderStats(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaderStats", reflect.TypeOf((*MockIScheduler)(nil).LeaderStats), arg0)
 }
 
+// ListTaskRecords mocks base method.
+func (m *MockIScheduler) ListTaskRecords(arg0 context.Context, arg1 *scheduler.ListTaskRecordsArgs) (*scheduler.ListTaskRecordsRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskRecords", arg0, arg1)
+	ret0, _ := ret[0].(*scheduler.ListTaskRecordsRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskRecords indicates an expected call of ListTaskRecords.
+func (mr *MockISchedulerMockRecorder) ListTaskRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskRecords", reflect.TypeOf((*MockIScheduler)(nil).ListTaskRecords), arg0, arg1)
+}
+
+// ManualMigrateTaskStatus mocks base method.
+func (m *MockIScheduler) ManualMigrateTaskStatus(arg0 context.Context, arg1 *scheduler.ManualMigrateStatusArgs) (scheduler.ManualMigrateStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManualMigrateTaskStatus", arg0, arg1)
+	ret0, _ := ret[0].(scheduler.ManualMigrateStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ManualMigrateTaskStatus indicates an expected call of ManualMigrateTaskStatus.
+func (mr *MockISchedulerMockRecorder) ManualMigrateTaskStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManualMigrateTaskStatus", reflect.TypeOf((*MockIScheduler)(nil).ManualMigrateTaskStatus), arg0, arg1)
+}
+
 // ReclaimBlobnodeTask mocks base method.
 func (m *MockIScheduler) ReclaimBlobnodeTask(arg0 context.Context, arg1 *scheduler.BlobnodeTaskArgs) error {
 	m.ctrl.T.Helper()
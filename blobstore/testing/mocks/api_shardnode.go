@@ -50,6 +50,20 @@ func (mr *MockShardnodeAccessMockRecorder) AllocSlice(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocSlice", reflect.TypeOf((*MockShardnodeAccess)(nil).AllocSlice), arg0, arg1, arg2)
 }
 
+// CommitTxn mocks base method.
+func (m *MockShardnodeAccess) CommitTxn(arg0 context.Context, arg1 string, arg2 shardnode.CommitTxnArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTxn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTxn indicates an expected call of CommitTxn.
+func (mr *MockShardnodeAccessMockRecorder) CommitTxn(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTxn", reflect.TypeOf((*MockShardnodeAccess)(nil).CommitTxn), arg0, arg1, arg2)
+}
+
 // CreateBlob mocks base method.
 func (m *MockShardnodeAccess) CreateBlob(arg0 context.Context, arg1 string, arg2 shardnode.CreateBlobArgs) (shardnode.CreateBlobRet, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +123,21 @@ func (mr *MockShardnodeAccessMockRecorder) GetBlob(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlob", reflect.TypeOf((*MockShardnodeAccess)(nil).GetBlob), arg0, arg1, arg2)
 }
 
+// GetDedup mocks base method.
+func (m *MockShardnodeAccess) GetDedup(arg0 context.Context, arg1 string, arg2 shardnode.GetDedupArgs) (shardnode.GetDedupRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDedup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(shardnode.GetDedupRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDedup indicates an expected call of GetDedup.
+func (mr *MockShardnodeAccessMockRecorder) GetDedup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDedup", reflect.TypeOf((*MockShardnodeAccess)(nil).GetDedup), arg0, arg1, arg2)
+}
+
 // GetShardStats mocks base method.
 func (m *MockShardnodeAccess) GetShardStats(arg0 context.Context, arg1 string, arg2 shardnode.GetShardArgs) (shardnode.ShardStats, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +168,50 @@ func (mr *MockShardnodeAccessMockRecorder) ListBlob(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlob", reflect.TypeOf((*MockShardnodeAccess)(nil).ListBlob), arg0, arg1, arg2)
 }
 
+// ListTrashBlob mocks base method.
+func (m *MockShardnodeAccess) ListTrashBlob(arg0 context.Context, arg1 string, arg2 shardnode.ListTrashBlobArgs) (shardnode.ListTrashBlobRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashBlob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(shardnode.ListTrashBlobRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrashBlob indicates an expected call of ListTrashBlob.
+func (mr *MockShardnodeAccessMockRecorder) ListTrashBlob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashBlob", reflect.TypeOf((*MockShardnodeAccess)(nil).ListTrashBlob), arg0, arg1, arg2)
+}
+
+// PurgeTrashBlob mocks base method.
+func (m *MockShardnodeAccess) PurgeTrashBlob(arg0 context.Context, arg1 string, arg2 shardnode.PurgeTrashBlobArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashBlob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTrashBlob indicates an expected call of PurgeTrashBlob.
+func (mr *MockShardnodeAccessMockRecorder) PurgeTrashBlob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashBlob", reflect.TypeOf((*MockShardnodeAccess)(nil).PurgeTrashBlob), arg0, arg1, arg2)
+}
+
+// RefDedup mocks base method.
+func (m *MockShardnodeAccess) RefDedup(arg0 context.Context, arg1 string, arg2 shardnode.RefDedupArgs) (shardnode.RefDedupRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefDedup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(shardnode.RefDedupRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefDedup indicates an expected call of RefDedup.
+func (mr *MockShardnodeAccessMockRecorder) RefDedup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefDedup", reflect.TypeOf((*MockShardnodeAccess)(nil).RefDedup), arg0, arg1, arg2)
+}
+
 // SealBlob mocks base method.
 func (m *MockShardnodeAccess) SealBlob(arg0 context.Context, arg1 string, arg2 shardnode.SealBlobArgs) error {
 	m.ctrl.T.Helper()
@@ -152,3 +225,47 @@ func (mr *MockShardnodeAccessMockRecorder) SealBlob(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealBlob", reflect.TypeOf((*MockShardnodeAccess)(nil).SealBlob), arg0, arg1, arg2)
 }
+
+// TrashBlob mocks base method.
+func (m *MockShardnodeAccess) TrashBlob(arg0 context.Context, arg1 string, arg2 shardnode.TrashBlobArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashBlob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrashBlob indicates an expected call of TrashBlob.
+func (mr *MockShardnodeAccessMockRecorder) TrashBlob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashBlob", reflect.TypeOf((*MockShardnodeAccess)(nil).TrashBlob), arg0, arg1, arg2)
+}
+
+// UndeleteBlob mocks base method.
+func (m *MockShardnodeAccess) UndeleteBlob(arg0 context.Context, arg1 string, arg2 shardnode.UndeleteBlobArgs) (shardnode.UndeleteBlobRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndeleteBlob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(shardnode.UndeleteBlobRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndeleteBlob indicates an expected call of UndeleteBlob.
+func (mr *MockShardnodeAccessMockRecorder) UndeleteBlob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlob", reflect.TypeOf((*MockShardnodeAccess)(nil).UndeleteBlob), arg0, arg1, arg2)
+}
+
+// UnrefDedup mocks base method.
+func (m *MockShardnodeAccess) UnrefDedup(arg0 context.Context, arg1 string, arg2 shardnode.UnrefDedupArgs) (shardnode.UnrefDedupRet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnrefDedup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(shardnode.UnrefDedupRet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnrefDedup indicates an expected call of UnrefDedup.
+func (mr *MockShardnodeAccessMockRecorder) UnrefDedup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnrefDedup", reflect.TypeOf((*MockShardnodeAccess)(nil).UnrefDedup), arg0, arg1, arg2)
+}
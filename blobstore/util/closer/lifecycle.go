@@ -0,0 +1,173 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package closer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClosed is the health of closed subsystem, or starting a closed subsystem.
+var ErrClosed = errors.New("closer: subsystem closed")
+
+// Subsystem is one part of a service, all functions are optional.
+type Subsystem struct {
+	Name string
+	// Start starts the subsystem before its children.
+	Start func() error
+	// Stop stops the subsystem after its children, it's called if
+	// the subsystem was started or there is no Start function.
+	Stop func()
+	// Health returns nil if the subsystem is healthy.
+	Health func() error
+}
+
+// Lifecycle is a tree of subsystems with ordered startup and shutdown.
+//
+// The children are started in order of registering after its parent, and
+// closed in reverse order before its parent. Done channel of subsystem is
+// closed at the beginning of Close, the running loops of subsystem can
+// select on it instead of an ad-hoc close channel.
+type Lifecycle interface {
+	Closer
+	// Name returns the path name of subsystem from the root, like "root/child".
+	Name() string
+	// Register registers a child subsystem, returns lifecycle of the child.
+	Register(sub Subsystem) Lifecycle
+	// Start starts the subsystem and all its children which are not started yet,
+	// returns the first error, and the caller should Close the lifecycle to stop
+	// the started subsystems.
+	Start() error
+	// Health returns health of the subsystem and all its children by path name.
+	Health() map[string]error
+}
+
+// NewLifecycle returns lifecycle of the root subsystem.
+func NewLifecycle(sub Subsystem) Lifecycle {
+	return newLifecycle(sub, sub.Name)
+}
+
+type lifecycle struct {
+	closer
+	sub  Subsystem
+	name string
+
+	startMu sync.Mutex // serializes Start, Start of subsystem may register children
+
+	mu       sync.Mutex
+	started  bool
+	children []*lifecycle
+}
+
+func newLifecycle(sub Subsystem, name string) *lifecycle {
+	return &lifecycle{closer: closer{ch: make(chan struct{})}, sub: sub, name: name}
+}
+
+func (l *lifecycle) Name() string {
+	return l.name
+}
+
+func (l *lifecycle) Register(sub Subsystem) Lifecycle {
+	child := newLifecycle(sub, l.name+"/"+sub.Name)
+	l.mu.Lock()
+	l.children = append(l.children, child)
+	l.mu.Unlock()
+	return child
+}
+
+func (l *lifecycle) Start() error {
+	l.startMu.Lock()
+	defer l.startMu.Unlock()
+	if l.closed() {
+		return ErrClosed
+	}
+
+	l.mu.Lock()
+	started := l.started
+	l.mu.Unlock()
+	if !started {
+		if l.sub.Start != nil {
+			if err := l.sub.Start(); err != nil {
+				return fmt.Errorf("start %s: %w", l.name, err)
+			}
+		}
+		l.mu.Lock()
+		l.started = true
+		l.mu.Unlock()
+	}
+
+	l.mu.Lock()
+	children := l.children
+	l.mu.Unlock()
+	for _, child := range children {
+		if err := child.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *lifecycle) Close() {
+	l.once.Do(func() {
+		close(l.ch)
+		// wait for the starting
+		l.startMu.Lock()
+		l.mu.Lock()
+		children := l.children
+		started := l.started
+		l.mu.Unlock()
+		l.startMu.Unlock()
+
+		for idx := len(children) - 1; idx >= 0; idx-- {
+			children[idx].Close()
+		}
+		if l.sub.Stop != nil && (started || l.sub.Start == nil) {
+			l.sub.Stop()
+		}
+	})
+}
+
+func (l *lifecycle) Health() map[string]error {
+	health := make(map[string]error)
+	l.health(health)
+	return health
+}
+
+func (l *lifecycle) health(health map[string]error) {
+	if l.closed() {
+		health[l.name] = ErrClosed
+	} else if l.sub.Health != nil {
+		health[l.name] = l.sub.Health()
+	} else {
+		health[l.name] = nil
+	}
+
+	l.mu.Lock()
+	children := l.children
+	l.mu.Unlock()
+	for _, child := range children {
+		child.health(health)
+	}
+}
+
+func (l *lifecycle) closed() bool {
+	select {
+	case <-l.ch:
+		return true
+	default:
+		return false
+	}
+}
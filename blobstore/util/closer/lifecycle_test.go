@@ -0,0 +1,117 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package closer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/blobstore/util/closer"
+)
+
+func TestLifecycleOrder(t *testing.T) {
+	var events []string
+	subsystem := func(name string) closer.Subsystem {
+		return closer.Subsystem{
+			Name:  name,
+			Start: func() error { events = append(events, "start "+name); return nil },
+			Stop:  func() { events = append(events, "stop "+name) },
+		}
+	}
+
+	root := closer.NewLifecycle(subsystem("root"))
+	a := root.Register(subsystem("a"))
+	a1 := a.Register(subsystem("a1"))
+	root.Register(subsystem("b"))
+	require.Equal(t, "root/a/a1", a1.Name())
+
+	require.NoError(t, root.Start())
+	require.NoError(t, root.Start())
+	require.Equal(t, []string{"start root", "start a", "start a1", "start b"}, events)
+
+	// started by starting again
+	root.Register(subsystem("c"))
+	require.NoError(t, root.Start())
+	require.Equal(t, "start c", events[len(events)-1])
+
+	events = events[:0]
+	a1Done := a1.Done()
+	root.Close()
+	root.Close()
+	<-a1Done
+	require.Equal(t, []string{"stop c", "stop b", "stop a1", "stop a", "stop root"}, events)
+	require.ErrorIs(t, root.Start(), closer.ErrClosed)
+}
+
+func TestLifecycleStartFailed(t *testing.T) {
+	var stopped []string
+	errStart := errors.New("start failed")
+	root := closer.NewLifecycle(closer.Subsystem{Name: "root"})
+	root.Register(closer.Subsystem{
+		Name:  "a",
+		Start: func() error { return nil },
+		Stop:  func() { stopped = append(stopped, "a") },
+	})
+	root.Register(closer.Subsystem{
+		Name:  "b",
+		Start: func() error { return errStart },
+		Stop:  func() { stopped = append(stopped, "b") },
+	})
+	root.Register(closer.Subsystem{
+		Name: "c",
+		Stop: func() { stopped = append(stopped, "c") },
+	})
+
+	err := root.Start()
+	require.ErrorIs(t, err, errStart)
+	require.Contains(t, err.Error(), "root/b")
+	root.Close()
+	// not started subsystem is not stopped, but no Start function
+	require.Equal(t, []string{"c", "a"}, stopped)
+}
+
+func TestLifecycleHealth(t *testing.T) {
+	errUnhealthy := errors.New("unhealthy")
+	root := closer.NewLifecycle(closer.Subsystem{Name: "root"})
+	root.Register(closer.Subsystem{Name: "a", Health: func() error { return errUnhealthy }})
+	b := root.Register(closer.Subsystem{Name: "b", Health: func() error { return nil }})
+	b.Register(closer.Subsystem{
+		Name: "b1",
+		Start: func() error {
+			// register in starting
+			b.Register(closer.Subsystem{Name: "b2"})
+			return nil
+		},
+	})
+	require.NoError(t, root.Start())
+
+	require.Equal(t, map[string]error{
+		"root":      nil,
+		"root/a":    errUnhealthy,
+		"root/b":    nil,
+		"root/b/b1": nil,
+		"root/b/b2": nil,
+	}, root.Health())
+
+	b.Close()
+	health := root.Health()
+	require.NoError(t, health["root"])
+	require.ErrorIs(t, health["root/a"], errUnhealthy)
+	require.ErrorIs(t, health["root/b"], closer.ErrClosed)
+	require.ErrorIs(t, health["root/b/b2"], closer.ErrClosed)
+	root.Close()
+}
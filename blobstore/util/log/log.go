@@ -89,6 +89,27 @@ var levelToStrings = []string{
 	"[FATAL]",
 }
 
+var levelNames = []string{
+	"debug",
+	"info",
+	"warn",
+	"error",
+	"panic",
+	"fatal",
+}
+
+// defines log output format
+const (
+	formatText int32 = iota
+	formatJSON
+)
+
+var formatMapping = map[string]int32{
+	"":     formatText,
+	"text": formatText,
+	"json": formatJSON,
+}
+
 // DefaultLogger default logger initial with os.Stderr.
 var DefaultLogger Logger
 
@@ -157,6 +178,48 @@ func ChangeDefaultLevelHandler() (string, http.HandlerFunc) {
 	}
 }
 
+// ChangeModuleLevelHandler returns http handler of modules log level modify API,
+// the module level is removed if post with empty level.
+func ChangeModuleLevelHandler() (string, http.HandlerFunc) {
+	return "/log/level/module", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			levels := GetModuleLevels()
+			modules := make(map[string]string, len(levels))
+			for module, lvl := range levels {
+				modules[module] = levelNames[lvl]
+			}
+			data, _ := json.Marshal(map[string]interface{}{"modules": modules})
+			w.Write(data)
+		case http.MethodPost:
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			module := cleanModule(r.FormValue("module"))
+			if module == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			lvlName := r.FormValue("level")
+			if lvlName == "" {
+				DeleteModuleLevel(module)
+				return
+			}
+			var level Level
+			if lvl, ok := levelMapping[lvlName]; ok {
+				level = lvl
+			} else if err := level.UnmarshalJSON([]byte(lvlName)); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			SetModuleLevel(module, level)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
 func Printf(format string, v ...interface{}) { DefaultLogger.(*logger).outputf(Linfo, format, v) }
 func Println(v ...interface{})               { DefaultLogger.(*logger).output(Linfo, v) }
 func Debugf(format string, v ...interface{}) { DefaultLogger.(*logger).outputf(Ldebug, format, v) }
@@ -192,3 +255,28 @@ func Panic(v ...interface{}) {
 func GetOutputLevel() Level    { return DefaultLogger.GetOutputLevel() }
 func SetOutputLevel(lvl Level) { DefaultLogger.SetOutputLevel(lvl) }
 func SetOutput(w io.Writer)    { DefaultLogger.SetOutput(w) }
+
+// SetFormat sets output format of default logger, "text" or "json".
+func SetFormat(format string) error { return DefaultLogger.(*logger).SetFormat(format) }
+
+// GetModuleLevels returns levels of modules overriding the default level.
+func GetModuleLevels() map[string]Level { return DefaultLogger.(*logger).GetModuleLevels() }
+
+// SetModuleLevels replaces levels of all modules.
+func SetModuleLevels(levels map[string]Level) { DefaultLogger.(*logger).SetModuleLevels(levels) }
+
+// SetModuleLevel sets level of the module, module is path of package directory
+// like "blobnode" or "common/rpc", the longest matched module takes effect.
+func SetModuleLevel(module string, lvl Level) { DefaultLogger.(*logger).SetModuleLevel(module, lvl) }
+
+// DeleteModuleLevel removes level of the module, uses the default level.
+func DeleteModuleLevel(module string) { DefaultLogger.(*logger).DeleteModuleLevel(module) }
+
+// Enabled returns false if the level must not be output by default logger,
+// checking it before building expensive message.
+func Enabled(lvl Level) bool {
+	if l, ok := DefaultLogger.(*logger); ok {
+		return l.enabled(lvl)
+	}
+	return lvl >= DefaultLogger.GetOutputLevel()
+}
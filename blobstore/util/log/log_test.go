@@ -30,14 +30,17 @@ import (
 )
 
 var (
-	httpAddr     string
-	logLevelPath string
+	httpAddr        string
+	logLevelPath    string
+	moduleLevelPath string
 )
 
 func init() {
 	path, handler := ChangeDefaultLevelHandler()
 	mux := http.NewServeMux()
 	mux.HandleFunc(path, handler)
+	moduleLevelPath, handler = ChangeModuleLevelHandler()
+	mux.HandleFunc(moduleLevelPath, handler)
 	server := httptest.NewServer(mux)
 	httpAddr = server.URL
 	logLevelPath = path
@@ -215,3 +218,56 @@ func TestLoggerSetLevelHandler(t *testing.T) {
 		require.Equal(t, Lfatal, GetOutputLevel())
 	}
 }
+
+func TestLoggerSetModuleLevelHandler(t *testing.T) {
+	addr := httpAddr + moduleLevelPath
+	defer SetModuleLevels(nil)
+	getModules := func() map[string]string {
+		resp, err := http.Get(addr)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+		var ret struct {
+			Modules map[string]string `json:"modules"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&ret))
+		return ret.Modules
+	}
+	require.Empty(t, getModules())
+
+	for _, form := range []url.Values{
+		{"level": []string{"debug"}},
+		{"module": []string{"/"}, "level": []string{"debug"}},
+		{"module": []string{"blobnode"}, "level": []string{"nan"}},
+		{"module": []string{"blobnode"}, "level": []string{"100"}},
+	} {
+		resp, err := http.PostForm(addr, form)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, 400, resp.StatusCode)
+	}
+	{
+		resp, err := http.Head(addr)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, 405, resp.StatusCode)
+	}
+
+	for _, form := range []url.Values{
+		{"module": []string{"blobnode"}, "level": []string{"debug"}},
+		{"module": []string{"common/rpc/"}, "level": []string{"3"}},
+	} {
+		resp, err := http.PostForm(addr, form)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+	}
+	require.Equal(t, map[string]string{"blobnode": "debug", "common/rpc": "error"}, getModules())
+	require.Equal(t, map[string]Level{"blobnode": Ldebug, "common/rpc": Lerror}, GetModuleLevels())
+
+	resp, err := http.PostForm(addr, url.Values{"module": []string{"blobnode"}})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+	require.Equal(t, map[string]string{"common/rpc": "error"}, getModules())
+}
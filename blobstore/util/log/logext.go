@@ -20,16 +20,24 @@ import (
 	"io"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 )
 
+const jsonTimeLayout = "2006-01-02T15:04:05.000000Z07:00"
+
 type logger struct {
 	level     int32
 	calldepth int
 	writer    atomic.Value
 	pool      sync.Pool
+
+	format    int32        // formatText or formatJSON
+	modules   atomic.Value // *moduleLevels
+	modulesMu sync.Mutex   // serializes modification of modules
 }
 
 type logWriter struct {
@@ -53,7 +61,7 @@ func New(out io.Writer, calldepth int) Logger {
 }
 
 func (l *logger) Output(id string, lvl Level, calldepth int, a ...interface{}) error {
-	if int32(lvl) < atomic.LoadInt32(&l.level) || lvl >= maxLevel {
+	if !l.enabled(lvl) {
 		return nil
 	}
 	_, file, line, ok := runtime.Caller(calldepth)
@@ -61,11 +69,14 @@ func (l *logger) Output(id string, lvl Level, calldepth int, a ...interface{}) e
 		file = "???"
 		line = 0
 	}
+	if !l.fileEnabled(file, lvl) {
+		return nil
+	}
 	return l.write(id, lvl, file, line, fmt.Sprintln(a...))
 }
 
 func (l *logger) Outputf(id string, lvl Level, calldepth int, format string, a ...interface{}) error {
-	if int32(lvl) < atomic.LoadInt32(&l.level) || lvl >= maxLevel {
+	if !l.enabled(lvl) {
 		return nil
 	}
 	_, file, line, ok := runtime.Caller(calldepth)
@@ -73,14 +84,52 @@ func (l *logger) Outputf(id string, lvl Level, calldepth int, format string, a .
 		file = "???"
 		line = 0
 	}
+	if !l.fileEnabled(file, lvl) {
+		return nil
+	}
 	return l.write(id, lvl, file, line, fmt.Sprintf(format, a...))
 }
 
+// enabled returns false if the level is lower than default level and levels of all modules.
+func (l *logger) enabled(lvl Level) bool {
+	if lvl >= maxLevel {
+		return false
+	}
+	if int32(lvl) >= atomic.LoadInt32(&l.level) {
+		return true
+	}
+	return lvl >= l.getModules().min
+}
+
+// fileEnabled returns true if the level is output in the file, the level of
+// module which the file belongs to overrides the default level.
+func (l *logger) fileEnabled(file string, lvl Level) bool {
+	modules := l.getModules()
+	if len(modules.levels) == 0 {
+		return true
+	}
+	if mlvl, ok := modules.match(file); ok {
+		return lvl >= mlvl
+	}
+	return int32(lvl) >= atomic.LoadInt32(&l.level)
+}
+
+func (l *logger) getModules() *moduleLevels {
+	if modules, ok := l.modules.Load().(*moduleLevels); ok {
+		return modules
+	}
+	return noModules
+}
+
 func (l *logger) write(id string, lvl Level, file string, line int, s string) error {
 	now := time.Now()
 	buf := l.pool.Get().(*bytes.Buffer)
 
 	buf.Reset()
+	if atomic.LoadInt32(&l.format) == formatJSON {
+		l.formatJSON(buf, now, id, file, line, lvl, s)
+		return l.flush(buf)
+	}
 	l.formatOutput(buf, now, file, line, lvl)
 	if id != "" {
 		buf.WriteByte('[')
@@ -92,6 +141,10 @@ func (l *logger) write(id string, lvl Level, file string, line int, s string) er
 	if len(s) > 0 && s[len(s)-1] != '\n' {
 		buf.WriteByte('\n')
 	}
+	return l.flush(buf)
+}
+
+func (l *logger) flush(buf *bytes.Buffer) error {
 	out := l.writer.Load().(io.Writer)
 	_, err := out.Write(buf.Bytes())
 	l.pool.Put(buf)
@@ -160,6 +213,42 @@ func (l *logger) SetOutputLevel(lvl Level) {
 	atomic.StoreInt32(&l.level, int32(lvl))
 }
 
+func (l *logger) SetFormat(format string) error {
+	f, ok := formatMapping[strings.ToLower(format)]
+	if !ok {
+		return fmt.Errorf("invalid log format: %s", format)
+	}
+	atomic.StoreInt32(&l.format, f)
+	return nil
+}
+
+func (l *logger) GetModuleLevels() map[string]Level {
+	return l.getModules().copyLevels()
+}
+
+// SetModuleLevels replaces levels of all modules.
+func (l *logger) SetModuleLevels(levels map[string]Level) {
+	l.modulesMu.Lock()
+	l.modules.Store(newModuleLevels(levels))
+	l.modulesMu.Unlock()
+}
+
+func (l *logger) SetModuleLevel(module string, lvl Level) {
+	l.modulesMu.Lock()
+	levels := l.GetModuleLevels()
+	levels[cleanModule(module)] = lvl
+	l.modules.Store(newModuleLevels(levels))
+	l.modulesMu.Unlock()
+}
+
+func (l *logger) DeleteModuleLevel(module string) {
+	l.modulesMu.Lock()
+	levels := l.GetModuleLevels()
+	delete(levels, cleanModule(module))
+	l.modules.Store(newModuleLevels(levels))
+	l.modulesMu.Unlock()
+}
+
 func (l *logger) formatOutput(buf *bytes.Buffer, t time.Time, file string, line int, lvl Level) {
 	year, month, day := t.Date()
 	itoa(buf, year, 4)
@@ -188,6 +277,74 @@ func (l *logger) formatOutput(buf *bytes.Buffer, t time.Time, file string, line
 	buf.WriteByte(' ')
 }
 
+// formatJSON formats one line of json object with fields time, level, file, trace and msg.
+func (l *logger) formatJSON(buf *bytes.Buffer, t time.Time, id string, file string, line int, lvl Level, s string) {
+	var b [64]byte
+	buf.WriteString(`{"time":"`)
+	buf.Write(t.AppendFormat(b[:0], jsonTimeLayout))
+	buf.WriteString(`","level":"`)
+	buf.WriteString(levelNames[lvl])
+	buf.WriteString(`","file":`)
+	writeJSONString(buf, file)
+	buf.WriteByte(':')
+	itoa(buf, line, -1)
+	buf.WriteByte('"')
+	if id != "" {
+		buf.WriteString(`,"trace":`)
+		writeJSONString(buf, id)
+		buf.WriteByte('"')
+	}
+	buf.WriteString(`,"msg":`)
+	writeJSONString(buf, strings.TrimSuffix(s, "\n"))
+	buf.WriteString("\"}\n")
+}
+
+const hexDigits = "0123456789abcdef"
+
+// writeJSONString writes the opening quote and escaped string, without the closing quote.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				buf.WriteString(s[start:i])
+				buf.WriteString(`\ufffd`)
+				i += size
+				start = i
+				continue
+			}
+			i += size
+			continue
+		}
+		if c >= 0x20 && c != '"' && c != '\\' {
+			i++
+			continue
+		}
+		buf.WriteString(s[start:i])
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hexDigits[c>>4])
+			buf.WriteByte(hexDigits[c&0xf])
+		}
+		i++
+		start = i
+	}
+	buf.WriteString(s[start:])
+}
+
 // itoa cheap integer to fixed width decimal ASCII.
 // a negative width to avoid zero-padding.
 // the buffer has enough capacity.
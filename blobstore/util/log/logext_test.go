@@ -16,6 +16,7 @@ package log
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"math/rand"
 	"os"
@@ -88,6 +89,69 @@ func TestExtLogger(t *testing.T) {
 	l.Output("it-a-id", Lerror, 1, "application message")
 }
 
+func TestExtLoggerJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(buf, 2)
+	require.Error(t, l.(*logger).SetFormat("xml"))
+	require.NoError(t, l.(*logger).SetFormat("JSON"))
+
+	l.Output("trace-id", Linfo, 1, "json \"message\"\n\tline\x01", "\xff")
+	var entry map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "info", entry["level"])
+	require.Equal(t, "trace-id", entry["trace"])
+	require.Equal(t, "json \"message\"\n\tline\x01 \ufffd", entry["msg"])
+	require.Contains(t, entry["file"], "util/log/logext_test.go:")
+	_, err := time.Parse(jsonTimeLayout, entry["time"])
+	require.NoError(t, err)
+
+	buf.Reset()
+	l.Warnf("no %s", "trace")
+	entry = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "warn", entry["level"])
+	require.Equal(t, "no trace", entry["msg"])
+	_, ok := entry["trace"]
+	require.False(t, ok)
+
+	require.NoError(t, l.(*logger).SetFormat("text"))
+	buf.Reset()
+	l.Warn("text")
+	require.Contains(t, buf.String(), "[WARN]")
+}
+
+func TestExtLoggerModuleLevels(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(buf, 2).(*logger)
+	require.False(t, l.enabled(Ldebug))
+
+	l.SetModuleLevel("/util/", Ldebug)
+	l.SetModuleLevel("util/log", Lerror)
+	l.SetModuleLevel("notexist", Lwarn)
+	require.Equal(t, map[string]Level{"util": Ldebug, "util/log": Lerror, "notexist": Lwarn}, l.GetModuleLevels())
+	require.True(t, l.enabled(Ldebug))
+
+	// the longest module util/log matched
+	l.Debug("debug")
+	l.Warn("warn")
+	require.Zero(t, buf.Len())
+	l.Error("error")
+	require.Contains(t, buf.String(), "error")
+
+	l.DeleteModuleLevel("util/log")
+	buf.Reset()
+	l.Debug("debug")
+	require.Contains(t, buf.String(), "debug")
+	require.True(t, l.fileEnabled("/a/util/b.go", Ldebug))
+	require.False(t, l.fileEnabled("/a/utils/b.go", Ldebug))
+	require.True(t, l.fileEnabled("/a/utils/b.go", Linfo))
+
+	l.SetModuleLevels(nil)
+	require.Empty(t, l.GetModuleLevels())
+	require.False(t, l.enabled(Ldebug))
+	require.True(t, l.fileEnabled("/a/util/b.go", Ldebug))
+}
+
 func BenchmarkFormatOutput(b *testing.B) {
 	l := &logger{}
 	buf := new(bytes.Buffer)
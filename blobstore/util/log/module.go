@@ -0,0 +1,78 @@
+// Copyright 2022 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package log
+
+import (
+	"strings"
+	"sync"
+)
+
+// moduleLevels log levels overriding the default level of modules.
+// module is path of package directory, like "blobnode" or "common/rpc",
+// matched with the source file path of caller, the longest one takes effect.
+type moduleLevels struct {
+	levels map[string]Level
+	min    Level
+	files  sync.Map // file path => matched module level, -1 means not matched
+}
+
+var noModules = newModuleLevels(nil)
+
+func newModuleLevels(levels map[string]Level) *moduleLevels {
+	m := &moduleLevels{levels: make(map[string]Level, len(levels)), min: maxLevel}
+	for module, lvl := range levels {
+		module = cleanModule(module)
+		if module == "" {
+			continue
+		}
+		if lvl >= maxLevel {
+			lvl = Lfatal
+		}
+		m.levels[module] = lvl
+		if lvl < m.min {
+			m.min = lvl
+		}
+	}
+	return m
+}
+
+// match returns the level of module which the file belongs to.
+func (m *moduleLevels) match(file string) (Level, bool) {
+	if val, ok := m.files.Load(file); ok {
+		lvl := val.(Level)
+		return lvl, lvl >= 0
+	}
+
+	matched, lvl := "", Level(-1)
+	for module, l := range m.levels {
+		if len(module) > len(matched) && strings.Contains(file, "/"+module+"/") {
+			matched, lvl = module, l
+		}
+	}
+	m.files.Store(file, lvl)
+	return lvl, lvl >= 0
+}
+
+func (m *moduleLevels) copyLevels() map[string]Level {
+	levels := make(map[string]Level, len(m.levels))
+	for module, lvl := range m.levels {
+		levels[module] = lvl
+	}
+	return levels
+}
+
+func cleanModule(module string) string {
+	return strings.Trim(strings.TrimSpace(module), "/")
+}
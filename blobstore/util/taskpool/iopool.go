@@ -32,6 +32,7 @@ const (
 
 	opDequeue = "dequeue"
 	opOnDisk  = "disk"
+	opCancel  = "cancel" // dropped in queue, as ctx done
 )
 
 type IoPoolTaskArgs struct {
@@ -162,10 +163,11 @@ func (p *ioPoolSimple) doWork(task *taskInfo) {
 	p.reportMetric(opDequeue, task.tm) // from enqueue to dequeue
 	select {
 	case <-task.ctx.Done(): // dont exec func
+		p.reportMetric(opCancel, task.tm) // from enqueue to cancelled
 	default:
 		task.fn()
+		p.reportMetric(opOnDisk, start) // from dequeue to op done
 	}
-	p.reportMetric(opOnDisk, start) // from dequeue to op done
 	task.done <- struct{}{}
 }
 